@@ -0,0 +1,47 @@
+// Command 01-basics prints a receipt from name:quantity:price items,
+// using the parsing and formatting funcs of exercise 01:
+//
+//	go run ./cmd/examples/01-basics apple:3:1.50 bread:1:2.99 "olive oil:2:8.75"
+//
+// You don't need to change this file; it works once the exercise does.
+// Add -tags solutions to see the reference solution run it.
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	basics "github.com/imgarylai/learn-go/exercises/01-basics"
+)
+
+func main() {
+	items := os.Args[1:]
+	if len(items) == 0 {
+		items = []string{"apple:3:1.50", "bread:1:2.99", "olive oil:2:8.75"}
+	}
+
+	var total float64
+	for i, item := range items {
+		name, rest, ok1 := strings.Cut(item, ":")
+		qtyText, priceText, ok2 := strings.Cut(rest, ":")
+		if !ok1 || !ok2 {
+			fmt.Fprintf(os.Stderr, "%q: want name:quantity:price\n", item)
+			os.Exit(2)
+		}
+		qty, err := strconv.Atoi(qtyText)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%q: %v\n", item, err)
+			os.Exit(1)
+		}
+		price, err := basics.ParsePrice(priceText)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%q: %v\n", item, err)
+			os.Exit(1)
+		}
+		fmt.Println(basics.ZeroPad(i+1, 3), basics.FormatRow(name, qty, price))
+		total += float64(qty) * price
+	}
+	fmt.Println("Total:", basics.FormatFixed(total, 2))
+}
@@ -0,0 +1,35 @@
+// Command 02-functions times the three Fibonacci funcs of exercise 02
+// against each other, to show what memoization buys:
+//
+//	go run ./cmd/examples/02-functions -n 35
+//
+// You don't need to change this file; it works once the exercise does.
+// Add -tags solutions to see the reference solution run it.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"time"
+
+	functions "github.com/imgarylai/learn-go/exercises/02-functions"
+)
+
+func main() {
+	n := flag.Int("n", 32, "which Fibonacci number to compute")
+	flag.Parse()
+
+	for _, f := range []struct {
+		name string
+		fib  func(int) int
+	}{
+		{"Fib (plain recursion)", functions.Fib},
+		{"FibMemo (memoized)", functions.FibMemo},
+		{"FibLoop (a loop)", functions.FibLoop},
+	} {
+		start := time.Now()
+		v := f.fib(*n)
+		fmt.Printf("%-22s fib(%d) = %d in %v\n", f.name, *n, v, time.Since(start))
+	}
+	fmt.Printf("%d! = %d\n", min(*n, 20), functions.Factorial(min(*n, 20)))
+}
@@ -0,0 +1,34 @@
+// Command 03-structs builds a few values with the constructors of
+// exercise 03 and prints them as the JSON an API would send:
+//
+//	go run ./cmd/examples/03-structs
+//
+// You don't need to change this file; it works once the exercise does.
+// Add -tags solutions to see the reference solution run it.
+package main
+
+import (
+	"fmt"
+	"os"
+
+	structs "github.com/imgarylai/learn-go/exercises/03-structs"
+)
+
+func main() {
+	u := structs.NewUser(1, "Ada", "ada@example.com")
+	fmt.Printf("%s (valid email: %v)\n", u.DisplayName(), u.IsValidEmail())
+	u.UpdateEmail("ada@lovelace.dev")
+	data, err := structs.MarshalUser(*u)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	fmt.Println(string(data))
+
+	admin := structs.NewAdmin(2, "Grace", "grace@example.com", "superadmin")
+	fmt.Printf("%s can delete: %v\n", admin.DisplayName(), admin.CanDelete())
+
+	p := structs.NewProduct(7, "Keyboard", 80)
+	sale := p.WithDiscount(25)
+	fmt.Printf("%s: %.2f, on sale %.2f\n", p.Name, p.Price, sale.Price)
+}
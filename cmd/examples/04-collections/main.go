@@ -0,0 +1,47 @@
+// Command 04-collections counts the words of a text file with the
+// slice and map funcs of exercise 04:
+//
+//	go run ./cmd/examples/04-collections [file]
+//
+// It reads exercises/07-file-processing/testdata/sample.txt by default.
+// You don't need to change this file; it works once the exercise does.
+// Add -tags solutions to see the reference solution run it.
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	collections "github.com/imgarylai/learn-go/exercises/04-collections"
+)
+
+func main() {
+	file := "exercises/07-file-processing/testdata/sample.txt"
+	if len(os.Args) > 1 {
+		file = os.Args[1]
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	words := strings.Fields(strings.ToLower(string(data)))
+	for i, w := range words {
+		words[i] = strings.Trim(w, ".,!?;:\"'")
+	}
+	counts := collections.CountOccurrences(words)
+	fmt.Printf("%d words, %d different\n", len(words), len(collections.Deduplicate(words)))
+	fmt.Printf("most common: %q\n", collections.GetTopScorer(counts))
+
+	lengths := make([]int, len(words))
+	for i, w := range words {
+		lengths[i] = len(w)
+	}
+	fmt.Printf("longest word: %d letters; %d words longer than 4\n",
+		collections.Max(lengths), len(collections.FilterGreaterThan(lengths, 4)))
+	for i, line := range collections.Chunk(collections.Deduplicate(words), 8) {
+		fmt.Printf("%2d: %s\n", i+1, strings.Join(line, " "))
+	}
+}
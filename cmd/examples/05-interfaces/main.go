@@ -0,0 +1,37 @@
+// Command 05-interfaces pipes standard input through the io.Reader and
+// io.Writer of exercise 05: it shouts the text back, then counts the
+// words, like `tr a-z A-Z | tee /dev/stderr | wc -w`.
+//
+//	echo "readers all the way down" | go run ./cmd/examples/05-interfaces
+//
+// You don't need to change this file; it works once the exercise does.
+// Add -tags solutions to see the reference solution run it.
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	interfaces "github.com/imgarylai/learn-go/exercises/05-interfaces"
+)
+
+func main() {
+	// One pass over stdin feeds both: io.TeeReader copies everything
+	// Shout reads into the word counter too.
+	var words interfaces.WordCountWriter
+	n, err := interfaces.Shout(os.Stdout, io.TeeReader(os.Stdin, &words))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	fmt.Printf("\n%d bytes, %d words\n", n, words.Words)
+
+	tasks := []interfaces.Task{
+		{Name: "deploy", Priority: 2},
+		{Name: "fix prod", Priority: 1},
+		{Name: "lunch", Priority: 5},
+		{Name: "review", Priority: 3},
+	}
+	fmt.Println("most urgent:", interfaces.MostUrgent(tasks, 2))
+}
@@ -0,0 +1,61 @@
+// Command 06-concurrency puts the patterns of exercise 06 to work: a
+// worker pool over a batch of jobs, a parallel sum over a large slice,
+// and slow "requests" raced against a timeout:
+//
+//	go run ./cmd/examples/06-concurrency -jobs 1000 -workers 8 -timeout 50ms
+//
+// You don't need to change this file; it works once the exercise does.
+// Add -tags solutions to see the reference solution run it.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"time"
+
+	concurrency "github.com/imgarylai/learn-go/exercises/06-concurrency"
+)
+
+func main() {
+	n := flag.Int("jobs", 100, "how many jobs to give the worker pool")
+	workers := flag.Int("workers", 4, "how many workers the pool runs")
+	timeout := flag.Duration("timeout", 50*time.Millisecond, "how long a request may take")
+	flag.Parse()
+
+	// The pool squares each job. The results come back in whatever
+	// order the workers finish, but their sum is always 1²+2²+...+n².
+	jobs := make([]int, *n)
+	for i := range jobs {
+		jobs[i] = i + 1
+	}
+	results := concurrency.WorkerPool(jobs, *workers)
+	sum := 0
+	for _, r := range results {
+		sum += r
+	}
+	fmt.Printf("worker pool: %d results from %d workers, sum %d (want %d)\n",
+		len(results), *workers, sum, *n*(*n+1)*(2**n+1)/6)
+
+	// One goroutine per chunk, like Promise.all over the chunks.
+	big := make([]int, 4_000_000)
+	for i := range big {
+		big[i] = i % 10
+	}
+	parts := [][]int{big[:1_000_000], big[1_000_000:2_000_000], big[2_000_000:3_000_000], big[3_000_000:]}
+	start := time.Now()
+	total := concurrency.SumParallel(parts)
+	fmt.Printf("parallel sum: %d in %v\n", total, time.Since(start).Round(time.Microsecond))
+
+	// Requests that take longer than the timeout are abandoned.
+	for _, latency := range []time.Duration{10 * time.Millisecond, 30 * time.Millisecond, 80 * time.Millisecond, 200 * time.Millisecond} {
+		v, ok := concurrency.WithTimeout(func() int {
+			time.Sleep(latency)
+			return int(latency.Milliseconds())
+		}, *timeout)
+		if ok {
+			fmt.Printf("request taking %v: served (%d)\n", latency, v)
+		} else {
+			fmt.Printf("request taking %v: timed out after %v\n", latency, *timeout)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+// Command 07-file-processing reads the product catalog and people list
+// in exercise 07's testdata with the funcs you wrote, and prints a
+// summary of each:
+//
+//	go run ./cmd/examples/07-file-processing [-dir exercises/07-file-processing/testdata]
+//
+// You don't need to change this file; it works once the exercise does.
+// Add -tags solutions to see the reference solution run it.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+
+	fileprocessing "github.com/imgarylai/learn-go/exercises/07-file-processing"
+)
+
+func main() {
+	dir := flag.String("dir", "exercises/07-file-processing/testdata", "where products.csv, people.csv and sample.txt are")
+	flag.Parse()
+
+	products, err := fileprocessing.ReadProducts(filepath.Join(*dir, "products.csv"))
+	check(err)
+	fmt.Printf("%d products worth %.2f in all\n", len(products), fileprocessing.CalculateTotalValue(products))
+	groups := fileprocessing.GroupProductsByCategory(products)
+	for _, category := range slices.Sorted(maps.Keys(groups)) {
+		fmt.Printf("  %-12s %d\n", category, len(groups[category]))
+	}
+	if p := fileprocessing.FindMostExpensive(products); p != nil {
+		fmt.Printf("most expensive: %s (%.2f)\n", p.Name, p.Price)
+	}
+
+	people, err := fileprocessing.ReadCSV(filepath.Join(*dir, "people.csv"))
+	check(err)
+	fmt.Printf("\n%d people:\n", len(people))
+	for _, p := range people {
+		fmt.Printf("  %-8s %3d  %s\n", p.Name, p.Age, p.Email)
+	}
+
+	lines, err := fileprocessing.CountLines(filepath.Join(*dir, "sample.txt"))
+	check(err)
+	fmt.Printf("\nsample.txt has %d lines\n", lines)
+}
+
+func check(err error) {
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,53 @@
+// Command 08-data-processing prints the sales report and a payroll
+// summary for the CSV files in exercise 08's testdata, using the funcs
+// you wrote:
+//
+//	go run ./cmd/examples/08-data-processing [-dir exercises/08-data-processing/testdata]
+//
+// You don't need to change this file; it works once the exercise does.
+// Add -tags solutions to see the reference solution run it.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+
+	dataprocessing "github.com/imgarylai/learn-go/exercises/08-data-processing"
+)
+
+func main() {
+	dir := flag.String("dir", "exercises/08-data-processing/testdata", "where sales.csv and employees.csv are")
+	top := flag.Int("top", 3, "how many of the biggest sales and earners to list")
+	flag.Parse()
+
+	sales, err := dataprocessing.ReadSalesCSV(filepath.Join(*dir, "sales.csv"))
+	check(err)
+	fmt.Print(dataprocessing.FormatSalesReport(sales))
+	fmt.Printf("\nBiggest sales:\n")
+	for _, s := range dataprocessing.TopNSales(sales, *top) {
+		fmt.Printf("  %-8s %3d x %6.2f  %s\n", s.Product, s.Quantity, s.Price, s.Region)
+	}
+
+	employees, err := dataprocessing.ReadEmployees(filepath.Join(*dir, "employees.csv"))
+	check(err)
+	fmt.Printf("\nPayroll: %d a year for %d people\n", dataprocessing.TotalPayroll(employees), len(employees))
+	avg := dataprocessing.AverageSalaryByDepartment(employees)
+	for _, dept := range slices.Sorted(maps.Keys(avg)) {
+		fmt.Printf("  %-12s %10.2f average\n", dept, avg[dept])
+	}
+	fmt.Printf("Top earners:\n")
+	for _, e := range dataprocessing.TopEarners(employees, *top) {
+		fmt.Printf("  %-10s %-12s %d\n", e.Name, e.Department, e.Salary)
+	}
+}
+
+func check(err error) {
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,50 @@
+// Command 09-matrices reads a matrix from a CSV file and works through
+// exercise 09 on it: transpose, row and column sums, and the product of
+// the matrix with its transpose:
+//
+//	go run ./cmd/examples/09-matrices [exercises/09-matrices/testdata/matrix.csv]
+//
+// You don't need to change this file; it works once the exercise does.
+// Add -tags solutions to see the reference solution run it.
+package main
+
+import (
+	"fmt"
+	"os"
+
+	matrices "github.com/imgarylai/learn-go/exercises/09-matrices"
+)
+
+func main() {
+	file := "exercises/09-matrices/testdata/matrix.csv"
+	if len(os.Args) > 1 {
+		file = os.Args[1]
+	}
+	m, err := matrices.ReadMatrixCSV(file)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	show("matrix", m)
+	t := matrices.Transpose(m)
+	show("transposed", t)
+	fmt.Println("row sums:   ", matrices.RowSums(m))
+	fmt.Println("column sums:", matrices.ColumnSums(m))
+	product, err := matrices.Multiply(m, t)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	show("matrix × transposed", product)
+}
+
+func show(title string, m [][]int) {
+	fmt.Println(title + ":")
+	for _, row := range m {
+		for _, v := range row {
+			fmt.Printf("%6d", v)
+		}
+		fmt.Println()
+	}
+}
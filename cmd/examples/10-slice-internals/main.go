@@ -0,0 +1,37 @@
+// Command 10-slice-internals shows how append grows a slice and how
+// slices share their backing array, with the funcs of exercise 10:
+//
+//	go run ./cmd/examples/10-slice-internals -n 2000
+//
+// You don't need to change this file; it works once the exercise does.
+// Add -tags solutions to see the reference solution run it.
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	sliceinternals "github.com/imgarylai/learn-go/exercises/10-slice-internals"
+)
+
+func main() {
+	n := flag.Int("n", 1000, "how many elements to append")
+	flag.Parse()
+
+	fmt.Printf("capacity as %d elements are appended one by one:\n", *n)
+	prev := 0
+	for _, c := range sliceinternals.CapacityGrowth(*n) {
+		growth := ""
+		if prev > 0 {
+			growth = fmt.Sprintf("  (x%.2f)", float64(c)/float64(prev))
+		}
+		fmt.Printf("  %6d%s\n", c, growth)
+		prev = c
+	}
+
+	s := sliceinternals.Squares(8)
+	w := sliceinternals.Window(s, 2, 5)
+	w = append(w, -1) // a full slice expression makes this copy, not overwrite s[5]
+	fmt.Printf("\nsquares %v\nwindow [2:5] plus an append %v\n", s, w)
+	fmt.Printf("last 3 %v, without index 0 %v\n", sliceinternals.Last(s, 3), sliceinternals.RemoveAt(sliceinternals.Clone(s), 0))
+}
@@ -0,0 +1,50 @@
+// Command 11-deep-copy changes a clone of a user and shows that the
+// original is untouched, then does the same to a plain `copy := user`,
+// which shares the original's pointers, slices and maps:
+//
+//	go run ./cmd/examples/11-deep-copy
+//
+// You don't need to change this file; it works once the exercise does.
+// Add -tags solutions to see the reference solution run it.
+package main
+
+import (
+	"fmt"
+
+	deepcopy "github.com/imgarylai/learn-go/exercises/11-deep-copy"
+)
+
+func main() {
+	u := deepcopy.User{
+		Name:     "Ada",
+		Age:      36,
+		Address:  &deepcopy.Address{Street: "1 Analytical Way", City: "London"},
+		Tags:     []string{"admin"},
+		Settings: map[string]string{"theme": "dark"},
+		Manager:  &deepcopy.User{Name: "Grace", Age: 50},
+	}
+	fmt.Println("original:       ", describe(u))
+
+	clone := deepcopy.Clone(u)
+	fmt.Println("clone is equal: ", deepcopy.Equal(u, clone))
+	change(&clone)
+	fmt.Println("changed clone:  ", describe(clone))
+	fmt.Println("original:       ", describe(u))
+
+	plain := u
+	change(&plain)
+	fmt.Println("changed copy:   ", describe(plain))
+	fmt.Println("original:       ", describe(u), "<- changed too")
+}
+
+func change(u *deepcopy.User) {
+	u.Address.City = "Paris"
+	u.Tags[0] = "guest"
+	u.Settings["theme"] = "light"
+	u.Manager.Name = "Someone else"
+}
+
+func describe(u deepcopy.User) string {
+	return fmt.Sprintf("%s in %s, tags %v, settings %v, managed by %s",
+		u.Name, u.Address.City, u.Tags, u.Settings, u.Manager.Name)
+}
@@ -0,0 +1,42 @@
+// Command 12-clock runs the rate limiter of exercise 12 on the real
+// clock: a burst of requests arrives every interval, and the limiter
+// lets through at most -limit in any -window:
+//
+//	go run ./cmd/examples/12-clock -limit 3 -window 1s -every 200ms
+//
+// The tests drive the same code with a FakeClock, so they take no time
+// at all; here you watch it happen. You don't need to change this file;
+// it works once the exercise does. Add -tags solutions to see the
+// reference solution run it.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"time"
+
+	clock "github.com/imgarylai/learn-go/exercises/12-clock"
+)
+
+func main() {
+	limit := flag.Int("limit", 3, "requests allowed per window")
+	window := flag.Duration("window", time.Second, "the sliding window")
+	every := flag.Duration("every", 200*time.Millisecond, "time between requests")
+	n := flag.Int("n", 15, "how many requests to send")
+	flag.Parse()
+
+	c := clock.RealClock{}
+	rw := clock.NewRateWindow(c, *window, *limit)
+	start := c.Now()
+	for i := range *n {
+		verdict := "rejected"
+		if rw.Allow() {
+			verdict = "allowed"
+		}
+		fmt.Printf("%6v  request %2d  %s\n", c.Now().Sub(start).Round(10*time.Millisecond), i+1, verdict)
+		<-c.After(*every)
+	}
+
+	tok := clock.Token{Value: "demo", ExpiresAt: c.Now().Add(-time.Second)}
+	fmt.Println("a token that expired a second ago is expired:", clock.IsExpired(tok, c))
+}
@@ -0,0 +1,54 @@
+// Command 13-string-algorithms runs the string funcs of exercise 13 on
+// text from the command line or a file:
+//
+//	go run ./cmd/examples/13-string-algorithms "Was it a car or a cat I saw?"
+//	go run ./cmd/examples/13-string-algorithms -file exercises/07-file-processing/testdata/sample.txt
+//
+// You don't need to change this file; it works once the exercise does.
+// Add -tags solutions to see the reference solution run it.
+package main
+
+import (
+	"cmp"
+	"flag"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+
+	stringalgorithms "github.com/imgarylai/learn-go/exercises/13-string-algorithms"
+)
+
+func main() {
+	file := flag.String("file", "", "read the text from this file")
+	shift := flag.Int("shift", 3, "Caesar shift")
+	flag.Parse()
+
+	text := strings.Join(flag.Args(), " ")
+	if *file != "" {
+		data, err := os.ReadFile(*file)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		text = string(data)
+	}
+	if text == "" {
+		text = "A man, a plan, a canal: Panama"
+	}
+
+	fmt.Printf("palindrome: %v\n", stringalgorithms.IsPalindrome(text))
+	encoded := stringalgorithms.Caesar(text, *shift)
+	fmt.Printf("Caesar %+d:  %s\n", *shift, strings.TrimSpace(encoded))
+	fmt.Printf("and back:   %s\n", strings.TrimSpace(stringalgorithms.Caesar(encoded, -*shift)))
+
+	freq := stringalgorithms.WordFrequency(text)
+	words := slices.SortedFunc(maps.Keys(freq), func(a, b string) int {
+		return cmp.Or(freq[b]-freq[a], strings.Compare(a, b))
+	})
+	fmt.Println("most common words:")
+	for _, w := range words[:min(5, len(words))] {
+		fmt.Printf("  %-10s %d\n", w, freq[w])
+	}
+}
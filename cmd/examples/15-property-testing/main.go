@@ -0,0 +1,59 @@
+// Command 15-property-testing runs your properties from exercise 15
+// against a few buggy implementations of reverse and shows which ones
+// they catch, with the smallest input that gives each bug away:
+//
+//	go run ./cmd/examples/15-property-testing
+//
+// You don't need to change this file; it works once the exercise does.
+// Add -tags solutions to see the reference solution run it.
+package main
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+
+	propertytesting "github.com/imgarylai/learn-go/exercises/15-property-testing"
+	"github.com/imgarylai/learn-go/internal/proptest"
+)
+
+func main() {
+	reverses := []struct {
+		name string
+		f    func([]int) []int
+	}{
+		{"correct", func(s []int) []int {
+			out := slices.Clone(s)
+			slices.Reverse(out)
+			return out
+		}},
+		{"returns a copy unchanged", slices.Clone[[]int]},
+		{"drops the last element", func(s []int) []int {
+			out := slices.Clone(s[:max(len(s)-1, 0)])
+			slices.Reverse(out)
+			return out
+		}},
+		{"sorts instead", func(s []int) []int { return slices.Sorted(slices.Values(s)) }},
+	}
+	props := []struct {
+		name string
+		p    func(reverse func([]int) []int, s []int) bool
+	}{
+		{"ReverseTwice", propertytesting.ReverseTwice},
+		{"ReverseMovesFirstToLast", propertytesting.ReverseMovesFirstToLast},
+	}
+
+	ints := proptest.SliceOf(proptest.Int(-10, 10), 20)
+	r := rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64()))
+	for _, rev := range reverses {
+		fmt.Printf("%s:\n", rev.name)
+		for _, p := range props {
+			f, failed := proptest.Find(r, ints, func(s []int) bool { return p.p(rev.f, s) })
+			if failed {
+				fmt.Printf("  %-24s caught it: %v\n", p.name, f)
+			} else {
+				fmt.Printf("  %-24s holds\n", p.name)
+			}
+		}
+	}
+}
@@ -0,0 +1,54 @@
+// Command 16-static-analysis runs your analyzers from exercise 16 on
+// real code, the way `go vet` runs its own:
+//
+//	go run ./cmd/examples/16-static-analysis ./exercises/...
+//	go run ./cmd/examples/16-static-analysis ./internal/...
+//
+// It's a small analysis driver: go/packages loads and type-checks the
+// packages, and package checker runs the analyzers over them.
+//
+// You don't need to change this file; it works once the exercise does.
+// Add -tags solutions to see the reference solution run it.
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/checker"
+	"golang.org/x/tools/go/packages"
+
+	staticanalysis "github.com/imgarylai/learn-go/exercises/16-static-analysis"
+)
+
+func main() {
+	patterns := os.Args[1:]
+	if len(patterns) == 0 {
+		patterns = []string{"./exercises/..."}
+	}
+	// LoadAllSyntax type-checks dependencies from source, so this works
+	// whatever Go version compiled the standard library.
+	pkgs, err := packages.Load(&packages.Config{Mode: packages.LoadAllSyntax}, patterns...)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if packages.PrintErrors(pkgs) > 0 {
+		os.Exit(1)
+	}
+
+	graph, err := checker.Analyze([]*analysis.Analyzer{staticanalysis.NoPrint, staticanalysis.FileClose}, pkgs, nil)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	found := 0
+	for _, act := range graph.Roots {
+		for _, d := range act.Diagnostics {
+			fmt.Printf("%s: %s (%s)\n", act.Package.Fset.Position(d.Pos), d.Message, act.Analyzer.Name)
+			found++
+		}
+	}
+	fmt.Printf("%d problem(s) in %d package(s)\n", found, len(pkgs))
+}
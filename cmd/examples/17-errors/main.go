@@ -0,0 +1,85 @@
+// Command 17-errors loads a config file and validates the users in it
+// with the funcs of exercise 17, then shows what a caller can still find
+// out from the errors that come back:
+//
+//	go run ./cmd/examples/17-errors users.json
+//
+// The file is a JSON array of {"Name", "Email", "Age"} objects; without
+// one it uses a few users of its own. Each read is retried, and every
+// error is classified, so try a path that doesn't exist too.
+//
+// You don't need to change this file; it works once the exercise does.
+// Add -tags solutions to see the reference solution run it.
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+
+	errorhandling "github.com/imgarylai/learn-go/exercises/17-errors"
+)
+
+const sample = `[
+  {"Name": "Ada", "Email": "ada@example.com", "Age": 36},
+  {"Name": "", "Email": "nobody.example.com", "Age": 36},
+  {"Name": "Methuselah", "Email": "m@example.com", "Age": 969}
+]`
+
+func main() {
+	flag.Parse()
+
+	read := func(string) ([]byte, error) { return []byte(sample), nil }
+	name := "the built-in sample"
+	if flag.NArg() > 0 {
+		name = flag.Arg(0)
+		read = readFile
+	}
+
+	var data []byte
+	err := errorhandling.Retry(3, func() error {
+		var err error
+		data, err = errorhandling.LoadConfig(name, read)
+		return err
+	})
+	if err != nil {
+		fmt.Printf("couldn't load %s (%s):\n%s\n", name, errorhandling.Classify(err), indent(err))
+		if errors.Is(err, fs.ErrNotExist) {
+			fmt.Println("errors.Is(err, fs.ErrNotExist) sees through the wrapping: the file isn't there.")
+		}
+		os.Exit(1)
+	}
+
+	var users []errorhandling.User
+	if err := json.Unmarshal(data, &users); err != nil {
+		fmt.Printf("%s isn't a JSON list of users: %v\n", name, err)
+		os.Exit(1)
+	}
+	for i, u := range users {
+		err := errorhandling.Validate(u)
+		if err == nil {
+			fmt.Printf("user %d (%s): ok\n", i+1, u.Name)
+			continue
+		}
+		err = fmt.Errorf("user %d: %w", i+1, err)
+		fmt.Printf("user %d: %s, fields %v\n%s\n", i+1, errorhandling.Classify(err), errorhandling.InvalidFields(err), indent(err))
+	}
+}
+
+// readFile fails for good on a missing file, so Retry stops at once,
+// and gives every other error another try.
+func readFile(name string) ([]byte, error) {
+	data, err := os.ReadFile(name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, errors.Join(err, errorhandling.ErrPermanent)
+	}
+	return data, err
+}
+
+func indent(err error) string {
+	return "  " + strings.ReplaceAll(err.Error(), "\n", "\n  ")
+}
@@ -0,0 +1,75 @@
+// Command 18-generics compares the words of two text files with the
+// generic funcs and types of exercise 18:
+//
+//	go run ./cmd/examples/18-generics [file] [other]
+//
+// It reads exercises/07-file-processing/testdata/sample.txt by default,
+// and compares it with this file's own source. Word lengths go through
+// Min, Max and Average, the words through Set, and every read through a
+// Result, so try a file that doesn't exist too.
+//
+// You don't need to change this file; it works once the exercise does.
+// Add -tags solutions to see the reference solution run it.
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+
+	generics "github.com/imgarylai/learn-go/exercises/18-generics"
+)
+
+func main() {
+	files := []string{"exercises/07-file-processing/testdata/sample.txt", "cmd/examples/18-generics/main.go"}
+	copy(files, os.Args[1:])
+
+	var sets []generics.Set[string]
+	for _, name := range files {
+		words := generics.MapResult(read(name), split).Or(nil)
+		if len(words) == 0 {
+			fmt.Printf("%s: no words\n", name)
+			continue
+		}
+		lengths := make([]int, len(words))
+		counts := map[int]int{}
+		for i, w := range words {
+			lengths[i] = len(w)
+			counts[generics.Clamp(len(w), 1, 10)]++
+		}
+		fmt.Printf("%s: %d words, %d to %d letters, %.1f on average\n",
+			name, len(words), generics.Min(lengths[0], lengths[1:]...),
+			generics.Max(lengths[0], lengths[1:]...), generics.Average(lengths))
+		for _, p := range generics.Entries(counts) {
+			label := fmt.Sprint(p.Key)
+			if p.Key == 10 {
+				label = "10+"
+			}
+			fmt.Printf("  %3s letters: %d\n", label, p.Value)
+		}
+		sets = append(sets, generics.NewSet(words...))
+	}
+	if len(sets) < 2 {
+		os.Exit(1)
+	}
+
+	both := sets[0].Intersect(sets[1])
+	fmt.Printf("%d distinct words in all, %d in both:\n%s\n",
+		sets[0].Union(sets[1]).Len(), both.Len(), strings.Join(generics.Sorted(both), " "))
+}
+
+// read wraps os.ReadFile's two results in one Result, printing the
+// error if there is one.
+func read(name string) generics.Result[string] {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return generics.Fail[string](err)
+	}
+	return generics.Ok(string(data))
+}
+
+func split(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool { return !unicode.IsLetter(r) })
+}
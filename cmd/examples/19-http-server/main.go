@@ -0,0 +1,53 @@
+// Command 19-http-server serves the to-do API of exercise 19 for real:
+//
+//	go run ./cmd/examples/19-http-server [-addr localhost:8080]
+//
+// then, from another terminal:
+//
+//	curl -i localhost:8080/todos -d '{"title": "Buy milk"}'
+//	curl localhost:8080/todos?done=false
+//	curl -i -X DELETE localhost:8080/todos/1
+//
+// Every request is logged with the status it got. Stop it with Ctrl-C.
+//
+// You don't need to change this file; it works once the exercise does.
+// Add -tags solutions to see the reference solution run it.
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+
+	httpserver "github.com/imgarylai/learn-go/exercises/19-http-server"
+)
+
+func main() {
+	addr := flag.String("addr", "localhost:8080", "address to listen on")
+	flag.Parse()
+
+	h := httpserver.NewServer().Handler()
+	log.Printf("listening on http://%s", *addr)
+	log.Fatal(http.ListenAndServe(*addr, logged(h)))
+}
+
+// statusRecorder remembers the status a handler wrote, for the log.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// logged is middleware, like an Express app.use((req, res, next) => ...):
+// a handler that wraps another.
+func logged(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		h.ServeHTTP(rec, r)
+		log.Printf("%s %s %d", r.Method, r.URL, rec.status)
+	})
+}
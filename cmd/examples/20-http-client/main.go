@@ -0,0 +1,83 @@
+// Command 20-http-client drives the to-do API of exercise 19 with the
+// client of exercise 20:
+//
+//	go run ./cmd/examples/20-http-client [-flaky 0.5]
+//
+// It starts the server itself, on a free local port, behind a gate that
+// wants a bearer token and fails a share of requests (-flaky) with 503.
+// The client creates a few to-dos and lists them again, retrying its
+// way past the failures; every request the server sees is printed.
+//
+// You don't need to change this file; it works once both exercises do.
+// Add -tags solutions to see the reference solutions run it.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"time"
+
+	httpserver "github.com/imgarylai/learn-go/exercises/19-http-server"
+	httpclient "github.com/imgarylai/learn-go/exercises/20-http-client"
+)
+
+const token = "learngo"
+
+type todo struct {
+	ID    int    `json:"id"`
+	Title string `json:"title"`
+	Done  bool   `json:"done"`
+}
+
+func main() {
+	flaky := flag.Float64("flaky", 0.5, "share of requests the server fails with 503")
+	flag.Parse()
+
+	ln, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		log.Fatal(err)
+	}
+	go http.Serve(ln, gate(httpserver.NewServer().Handler(), *flaky))
+
+	c := httpclient.NewClient("http://"+ln.Addr().String(), 5*time.Second)
+	c.Retries = 5
+	c.Backoff = 50 * time.Millisecond
+	c.WithToken(token)
+
+	ctx := context.Background()
+	for _, title := range []string{"Buy milk", "Walk the dog", "Learn Go"} {
+		var t todo
+		if err := c.PostJSON(ctx, "/todos", map[string]string{"title": title}, &t, 2*time.Second); err != nil {
+			log.Fatalf("create %q: %v", title, err)
+		}
+		fmt.Printf("created #%d %s\n", t.ID, t.Title)
+	}
+	var all []todo
+	if err := c.GetJSON(ctx, "/todos", &all); err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("the server has %d to-dos\n", len(all))
+}
+
+// gate checks the token, then fails a share of requests before they
+// reach h.
+func gate(h http.Handler, flaky float64) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Header.Get("Authorization") != "Bearer "+token:
+			fmt.Printf("  server: %s %s -> 401\n", r.Method, r.URL)
+			http.Error(w, "no token", http.StatusUnauthorized)
+		case rand.Float64() < flaky:
+			fmt.Printf("  server: %s %s -> 503\n", r.Method, r.URL)
+			http.Error(w, "try again", http.StatusServiceUnavailable)
+		default:
+			fmt.Printf("  server: %s %s\n", r.Method, r.URL)
+			h.ServeHTTP(w, r)
+		}
+	})
+}
@@ -0,0 +1,70 @@
+// Command 21-json streams a large order export through StreamItems
+// from exercise 21 without ever holding it in memory:
+//
+//	go run ./cmd/examples/21-json [-items 1000000]
+//
+// A goroutine writes the export into one end of an io.Pipe while
+// StreamItems reads the other, so memory use stays flat however big
+// -items gets. Then it round-trips a few events through EncodeEvent and
+// DecodeEvent.
+//
+// You don't need to change this file; it works once the exercise does.
+// Add -tags solutions to see the reference solution run it.
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"runtime"
+
+	jsonadvanced "github.com/imgarylai/learn-go/exercises/21-json"
+)
+
+func main() {
+	items := flag.Int("items", 1_000_000, "items in the export")
+	flag.Parse()
+
+	r, w := io.Pipe()
+	go func() {
+		bw := bufio.NewWriter(w)
+		fmt.Fprint(bw, `{"exported": "2024-03-01", "items": [`)
+		for i := range *items {
+			if i > 0 {
+				fmt.Fprint(bw, ",")
+			}
+			fmt.Fprintf(bw, `{"sku": "SKU-%d", "qty": %d}`, i%500, i%7+1)
+		}
+		fmt.Fprint(bw, `], "source": "example"}`)
+		w.CloseWithError(bw.Flush())
+	}()
+
+	units := 0
+	n, err := jsonadvanced.StreamItems(r, func(it jsonadvanced.Item) error {
+		units += it.Qty
+		return nil
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	var m runtime.MemStats
+	runtime.ReadMemStats(&m)
+	fmt.Printf("streamed %d items, %d units, with %d KiB of heap in use\n", n, units, m.HeapInuse/1024)
+
+	for _, e := range []jsonadvanced.Event{
+		jsonadvanced.Click{X: 120, Y: 48},
+		jsonadvanced.Purchase{SKU: "SKU-7", Cents: 1999},
+	} {
+		data, err := jsonadvanced.EncodeEvent(e)
+		if err != nil {
+			log.Fatal(err)
+		}
+		back, err := jsonadvanced.DecodeEvent(data)
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("%s -> %#v\n", data, back)
+	}
+}
@@ -0,0 +1,86 @@
+// Command 22-context fetches from pretend mirrors and processes pretend
+// jobs under one deadline, with the funcs of exercise 22:
+//
+//	go run ./cmd/examples/22-context [-timeout 2s]
+//
+// Every job takes a random 50-500ms. When -timeout runs out, or you
+// press Ctrl-C, the workers stop mid-job and the program reports how
+// far it got and why it stopped.
+//
+// You don't need to change this file; it works once the exercise does.
+// Add -tags solutions to see the reference solution run it.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"os/signal"
+	"time"
+
+	ctxwork "github.com/imgarylai/learn-go/exercises/22-context"
+)
+
+func main() {
+	timeout := flag.Duration("timeout", 2*time.Second, "how long everything may take")
+	flag.Parse()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	ctx = ctxwork.WithRequestID(ctx, fmt.Sprintf("run-%04d", rand.IntN(10000)))
+
+	err := ctxwork.CallWithTimeout(ctx, *timeout, func(ctx context.Context) error {
+		id, _ := ctxwork.RequestID(ctx)
+		mirror, err := ctxwork.FirstResult(ctx, fetch("eu"), fetch("us"), fetch("asia"))
+		if err != nil {
+			return err
+		}
+		fmt.Printf("[%s] fastest mirror: %s\n", id, mirror)
+
+		jobs := make(chan int)
+		go func() {
+			defer close(jobs)
+			for i := 1; i <= 50; i++ {
+				select {
+				case jobs <- i:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+		done := 0
+		for range ctxwork.Workers(ctx, 4, jobs, work) {
+			done++
+		}
+		fmt.Printf("[%s] %d of 50 jobs done\n", id, done)
+		return ctx.Err()
+	})
+	switch {
+	case errors.Is(err, ctxwork.ErrTooSlow):
+		fmt.Printf("stopped: %v (after %v)\n", err, *timeout)
+	case errors.Is(err, context.Canceled):
+		fmt.Println("stopped: interrupted")
+	case err != nil:
+		fmt.Println("failed:", err)
+	default:
+		fmt.Println("all done in time")
+	}
+}
+
+// fetch pretends to download from a mirror, which takes up to a second.
+func fetch(mirror string) func(context.Context) (string, error) {
+	return func(ctx context.Context) (string, error) {
+		if err := ctxwork.Sleep(ctx, time.Duration(rand.IntN(1000))*time.Millisecond); err != nil {
+			return "", err
+		}
+		return mirror, nil
+	}
+}
+
+func work(ctx context.Context, job int) int {
+	ctxwork.Sleep(ctx, time.Duration(50+rand.IntN(450))*time.Millisecond)
+	return job
+}
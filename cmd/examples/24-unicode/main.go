@@ -0,0 +1,53 @@
+// Command 24-unicode measures text three ways, in bytes, runes and
+// grapheme clusters, with the funcs of exercise 24, and reverses it
+// without breaking a character:
+//
+//	go run ./cmd/examples/24-unicode "noël" "🇯🇵🇫🇷" "👍🏽 ok"
+//	printf 'bad \xff byte\n' | go run ./cmd/examples/24-unicode
+//
+// With no arguments it reads lines from standard input instead, and
+// checks each one is valid UTF-8 first, repairing it when it isn't.
+//
+// You don't need to change this file; it works once the exercise does.
+// Add -tags solutions to see the reference solution run it.
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+
+	unicodestrings "github.com/imgarylai/learn-go/exercises/24-unicode"
+)
+
+func main() {
+	flag.Parse()
+	if flag.NArg() > 0 {
+		for _, s := range flag.Args() {
+			show(s)
+		}
+		return
+	}
+
+	sc := bufio.NewScanner(os.Stdin)
+	for sc.Scan() {
+		line := sc.Bytes()
+		if err := unicodestrings.CheckUTF8(line); err != nil {
+			fmt.Printf("%q: %v, repaired\n", line, err)
+		}
+		show(unicodestrings.Sanitize(line))
+	}
+	if err := sc.Err(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func show(s string) {
+	bytes, runes := unicodestrings.Lengths(s)
+	graphemes := unicodestrings.Graphemes(s)
+	fmt.Printf("%s\n  %d bytes, %d runes, %d characters: %q\n  reversed: %s\n  first 5: %s\n",
+		s, bytes, runes, len(graphemes), graphemes,
+		unicodestrings.Reverse(s), unicodestrings.Truncate(s, 5))
+}
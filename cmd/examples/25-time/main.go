@@ -0,0 +1,65 @@
+// Command 25-time shows a moment around the world and whether an
+// office in one zone is open then, with the funcs of exercise 25:
+//
+//	go run ./cmd/examples/25-time
+//	go run ./cmd/examples/25-time -at "2024-03-08 23:30:00" -office Asia/Tokyo
+//
+// -at takes any timestamp ParseTimestamp does and defaults to now; it's
+// also the office's clock, the way a test would set Now.
+//
+// You don't need to change this file; it works once the exercise does.
+// Add -tags solutions to see the reference solution run it.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+	_ "time/tzdata"
+
+	timehandling "github.com/imgarylai/learn-go/exercises/25-time"
+)
+
+func main() {
+	at := flag.String("at", "", "the moment to show (default now)")
+	zones := flag.String("zones", "America/Los_Angeles,America/New_York,Europe/London,Asia/Kolkata,Asia/Tokyo", "comma-separated IANA zones")
+	officeZone := flag.String("office", "America/New_York", "the office's zone")
+	flag.Parse()
+
+	now := time.Now()
+	if *at != "" {
+		t, err := timehandling.ParseTimestamp(*at)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(2)
+		}
+		now = t
+	}
+
+	for _, zone := range strings.Split(*zones, ",") {
+		t, err := timehandling.InZone(now, zone)
+		if err != nil {
+			fmt.Printf("%-22s %v\n", zone, err)
+			continue
+		}
+		fmt.Printf("%-22s %s (hour from %s)\n", zone, timehandling.FormatHuman(t), timehandling.StartOfHour(t).Format("15:04"))
+	}
+
+	loc, err := time.LoadLocation(*officeZone)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	office := timehandling.NewOffice(loc)
+	office.Now = func() time.Time { return now }
+	fmt.Println()
+	if office.IsOpen() {
+		fmt.Printf("The office in %s is open.\n", loc)
+		return
+	}
+	next := office.NextOpening()
+	fmt.Printf("The office in %s is closed. It opens %s, in %s.\n",
+		loc, timehandling.FormatHuman(next), timehandling.FormatDuration(next.Sub(now)))
+}
@@ -0,0 +1,64 @@
+// Command 26-regexp reads a log in exercise 26's format, keeps the
+// entries at or above a level, redacts them and highlights a term,
+// with the funcs of exercise 26:
+//
+//	go run ./cmd/examples/26-regexp -level warn -grep refused < exercises/26-regexp/testdata/app.log
+//
+// It ends by listing the lines it couldn't parse.
+//
+// You don't need to change this file; it works once the exercise does.
+// Add -tags solutions to see the reference solution run it.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	regexps "github.com/imgarylai/learn-go/exercises/26-regexp"
+)
+
+var levels = []string{"DEBUG", "INFO", "WARN", "ERROR"}
+
+func main() {
+	level := flag.String("level", "debug", "the lowest level to show")
+	grep := flag.String("grep", "", "only show messages containing this, highlighted")
+	flag.Parse()
+
+	lowest := slices.Index(levels, strings.ToUpper(*level))
+	if lowest < 0 {
+		fmt.Fprintf(os.Stderr, "unknown level %q; want one of %s\n", *level, strings.Join(levels, ", "))
+		os.Exit(2)
+	}
+	text, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	entries, bad := regexps.ParseLog(string(text))
+	for _, e := range entries {
+		if slices.Index(levels, e.Level) < lowest {
+			continue
+		}
+		msg := regexps.Redact(regexps.ReformatDates(e.Message))
+		if *grep != "" {
+			highlighted := regexps.Highlight(msg, *grep)
+			if highlighted == msg {
+				continue
+			}
+			msg = highlighted
+		}
+		component := ""
+		if e.Component != "" {
+			component = "[" + e.Component + "] "
+		}
+		fmt.Printf("%-5s %s %s%s\n", e.Level, e.Time, component, msg)
+	}
+	if len(bad) > 0 {
+		fmt.Printf("\ncouldn't parse lines %v\n", bad)
+	}
+}
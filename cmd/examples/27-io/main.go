@@ -0,0 +1,48 @@
+// Command 27-io copies stdin to stdout through the readers and writers
+// of exercise 27, like a small `tr a-z A-Z | sed 's/^/> /'`:
+//
+//	go run ./cmd/examples/27-io -upper -quote "> " < readme.md
+//
+// It ends by printing to stderr the size, line count and SHA-256 of the
+// text it copied, before quoting; without -upper they match what
+// `wc -c -l` and `sha256sum` say about the input.
+//
+// You don't need to change this file; it works once the exercise does.
+// Add -tags solutions to see the reference solution run it.
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	iocompose "github.com/imgarylai/learn-go/exercises/27-io"
+)
+
+func main() {
+	upper := flag.Bool("upper", false, "upper-case the text")
+	quote := flag.String("quote", "", "a prefix for every line")
+	flag.Parse()
+
+	var src io.Reader = os.Stdin
+	if *upper {
+		src = iocompose.NewUpperReader(src)
+	}
+	out := bufio.NewWriter(os.Stdout)
+	var dst io.Writer = out
+	if *quote != "" {
+		dst = iocompose.NewPrefixWriter(out, *quote)
+	}
+
+	stats, err := iocompose.Archive(src, dst)
+	if ferr := out.Flush(); err == nil {
+		err = ferr
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	fmt.Fprintf(os.Stderr, "%d bytes, %d lines, sha256 %s\n", stats.Bytes, stats.Lines, stats.SHA256)
+}
@@ -0,0 +1,44 @@
+// Command 28-sorting sorts the lines of stdin in natural order, so
+// file9 comes before file10, with the funcs of exercise 28:
+//
+//	ls exercises | go run ./cmd/examples/28-sorting -r
+//
+// Compare with `sort` and `sort -V`.
+//
+// You don't need to change this file; it works once the exercise does.
+// Add -tags solutions to see the reference solution run it.
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+
+	sorting "github.com/imgarylai/learn-go/exercises/28-sorting"
+)
+
+func main() {
+	reverse := flag.Bool("r", false, "sort in reverse")
+	flag.Parse()
+
+	var lines []string
+	sc := bufio.NewScanner(os.Stdin)
+	for sc.Scan() {
+		lines = append(lines, sc.Text())
+	}
+	if err := sc.Err(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	compare := sorting.NaturalCompare
+	if *reverse {
+		compare = sorting.Desc(compare)
+	}
+	slices.SortFunc(lines, compare)
+	for _, line := range lines {
+		fmt.Println(line)
+	}
+}
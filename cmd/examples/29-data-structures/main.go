@@ -0,0 +1,73 @@
+// Command 29-data-structures counts the words of stdin in exercise 29's
+// binary search tree and prints the most common ones, picked with its
+// heap:
+//
+//	go run ./cmd/examples/29-data-structures -n 5 < readme.md
+//
+// With -all it lists every word and its count, in alphabetical order,
+// straight from the tree.
+//
+// You don't need to change this file; it works once the exercise does.
+// Add -tags solutions to see the reference solution run it.
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+
+	datastructures "github.com/imgarylai/learn-go/exercises/29-data-structures"
+)
+
+type count struct {
+	word string
+	n    int
+}
+
+func main() {
+	top := flag.Int("n", 10, "how many of the most common words to show")
+	all := flag.Bool("all", false, "list every word in alphabetical order")
+	flag.Parse()
+
+	var counts datastructures.Tree[string, int]
+	sc := bufio.NewScanner(os.Stdin)
+	sc.Split(bufio.ScanWords)
+	for sc.Scan() {
+		word := strings.ToLower(strings.TrimFunc(sc.Text(), func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		}))
+		if word == "" {
+			continue
+		}
+		n, _ := counts.Get(word)
+		counts.Put(word, n+1)
+	}
+	if err := sc.Err(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	if *all {
+		for word, n := range counts.All() {
+			fmt.Printf("%6d %s\n", n, word)
+		}
+		return
+	}
+	words := func(yield func(count) bool) {
+		for word, n := range counts.All() {
+			if !yield(count{word, n}) {
+				return
+			}
+		}
+	}
+	mostCommon := datastructures.Smallest(words, *top, func(a, b count) bool {
+		return a.n > b.n || a.n == b.n && a.word < b.word
+	})
+	for _, c := range mostCommon {
+		fmt.Printf("%6d %s\n", c.n, c.word)
+	}
+	fmt.Printf("%d different words; the tree is %d levels deep\n", counts.Len(), counts.Height())
+}
@@ -0,0 +1,74 @@
+// Command 30-algorithms plans a route through this course with the
+// funcs of exercise 30. The exercises and their prerequisites are a
+// graph: it prints an order to do them all in, or the shortest chain of
+// prerequisites from one exercise to another:
+//
+//	go run ./cmd/examples/30-algorithms
+//	go run ./cmd/examples/30-algorithms -from 01-basics -to 29-data-structures
+//
+// An exercise it doesn't know gets a suggestion of the nearest name.
+//
+// You don't need to change this file; it works once the exercise does.
+// Add -tags solutions to see the reference solution run it.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	algorithms "github.com/imgarylai/learn-go/exercises/30-algorithms"
+	"github.com/imgarylai/learn-go/internal/registry"
+)
+
+func main() {
+	from := flag.String("from", "", "the exercise to start from")
+	to := flag.String("to", "", "the exercise to get to")
+	flag.Parse()
+
+	// An edge from each prerequisite to the exercises that need it.
+	g := algorithms.Graph{}
+	var ids []string
+	for _, e := range registry.All() {
+		ids = append(ids, e.ID)
+		if _, ok := g[e.ID]; !ok {
+			g[e.ID] = nil // a node even if nothing needs it
+		}
+		for _, p := range e.Prerequisites {
+			g[p] = append(g[p], e.ID)
+		}
+	}
+
+	if *from == "" && *to == "" {
+		order, err := algorithms.TopoSort(g)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		fmt.Println(strings.Join(order, "\n"))
+		return
+	}
+	for _, id := range []string{*from, *to} {
+		if _, ok := g[id]; !ok {
+			fmt.Fprintf(os.Stderr, "no exercise %q; did you mean %s?\n", id, nearest(id, ids))
+			os.Exit(2)
+		}
+	}
+	path := algorithms.ShortestPath(g, *from, *to)
+	if path == nil {
+		fmt.Printf("%s isn't a prerequisite of %s, directly or not\n", *from, *to)
+		return
+	}
+	fmt.Println(strings.Join(path, " → "))
+}
+
+func nearest(id string, ids []string) string {
+	best := ids[0]
+	for _, other := range ids[1:] {
+		if algorithms.EditDistance(id, other) < algorithms.EditDistance(id, best) {
+			best = other
+		}
+	}
+	return best
+}
@@ -0,0 +1,64 @@
+// Command 31-database keeps a list of people in a SQLite file with the
+// funcs of exercise 31. Given a CSV on stdin, in the format of
+// testdata/people.csv, it imports it, all or nothing; then it lists the
+// people older than -older:
+//
+//	go run ./cmd/examples/31-database -db people.db < exercises/31-database/testdata/people.csv
+//	go run ./cmd/examples/31-database -db people.db -older 27
+//
+// You don't need to change this file; it works once the exercise does.
+// Add -tags solutions to see the reference solution run it.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	database "github.com/imgarylai/learn-go/exercises/31-database"
+)
+
+func main() {
+	path := flag.String("db", "people.db", "the database file, created if it doesn't exist")
+	older := flag.Int("older", 0, "list the people older than this")
+	flag.Parse()
+
+	if err := run(context.Background(), *path, *older); err != nil {
+		fmt.Fprintln(os.Stderr, "31-database:", err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, path string, older int) error {
+	db, err := database.Open(ctx, path)
+	if err != nil {
+		return err
+	}
+	if db == nil {
+		return errors.New("Open returned no database: exercise 31 isn't done yet")
+	}
+	defer db.Close()
+	if err := database.CreateTables(ctx, db); err != nil {
+		return err
+	}
+
+	// Only read stdin when something is piped in, not from a terminal.
+	if fi, err := os.Stdin.Stat(); err == nil && fi.Mode()&os.ModeCharDevice == 0 {
+		n, err := database.ImportPeople(ctx, db, os.Stdin)
+		if err != nil {
+			return fmt.Errorf("import: %w", err)
+		}
+		fmt.Printf("imported %d people\n", n)
+	}
+
+	people, err := database.PeopleOlderThan(ctx, db, older)
+	if err != nil {
+		return err
+	}
+	for _, p := range people {
+		fmt.Printf("%4d  %-12s %3d  %s\n", p.ID, p.Name, p.Age, p.Email)
+	}
+	return nil
+}
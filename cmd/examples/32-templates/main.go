@@ -0,0 +1,73 @@
+// Command 32-templates renders a sales CSV, in the format of exercise
+// 8's testdata/sales.csv, with the templates of exercise 32: a text
+// report, or with -html an HTML page:
+//
+//	go run ./cmd/examples/32-templates < exercises/32-templates/testdata/sales.csv
+//	go run ./cmd/examples/32-templates -html -title "Q1 sales" < exercises/32-templates/testdata/sales.csv > sales.html
+//
+// You don't need to change this file; it works once the exercise does.
+// Add -tags solutions to see the reference solution run it.
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	templates "github.com/imgarylai/learn-go/exercises/32-templates"
+)
+
+func main() {
+	html := flag.Bool("html", false, "write an HTML page instead of a text report")
+	title := flag.String("title", "Sales", "the title of the HTML page")
+	flag.Parse()
+
+	sales, err := readSales(os.Stdin)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "32-templates:", err)
+		os.Exit(1)
+	}
+	out := bufio.NewWriter(os.Stdout)
+	if *html {
+		err = templates.SalesPage(out, *title, sales)
+	} else {
+		err = templates.SalesReport(out, sales)
+	}
+	if ferr := out.Flush(); err == nil {
+		err = ferr
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "32-templates:", err)
+		os.Exit(1)
+	}
+}
+
+// readSales reads product,quantity,price,region rows after a header.
+func readSales(r io.Reader) ([]templates.Sale, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = 4
+	records, err := cr.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	var sales []templates.Sale
+	for i, rec := range records {
+		if i == 0 {
+			continue // the header
+		}
+		qty, err := strconv.Atoi(rec[1])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: quantity: %w", i+1, err)
+		}
+		price, err := strconv.ParseFloat(rec[2], 64)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: price: %w", i+1, err)
+		}
+		sales = append(sales, templates.Sale{Product: rec[0], Quantity: qty, Price: price, Region: rec[3]})
+	}
+	return sales, nil
+}
@@ -0,0 +1,20 @@
+// Command 33-cli is csvtool, the command-line tool of exercise 33. All
+// it does is hand the real arguments and streams to Run and exit with
+// what it returns:
+//
+//	go run ./cmd/examples/33-cli filter -col region -eq North exercises/33-cli/testdata/sales.csv
+//	go run ./cmd/examples/33-cli convert -to md < exercises/33-cli/testdata/sales.csv
+//
+// You don't need to change this file; it works once the exercise does.
+// Add -tags solutions to see the reference solution run it.
+package main
+
+import (
+	"os"
+
+	cli "github.com/imgarylai/learn-go/exercises/33-cli"
+)
+
+func main() {
+	os.Exit(cli.Run(os.Args[1:], cli.Env{Stdin: os.Stdin, Stdout: os.Stdout, Stderr: os.Stderr}))
+}
@@ -0,0 +1,35 @@
+// Command 34-config loads a service's configuration with exercise 34's
+// LoadConfig, from the current directory and the real environment, and
+// prints the result as JSON, or what's wrong with it:
+//
+//	APP_CONFIG=exercises/34-config/testdata/config.yaml APP_DATABASE_URL=postgres://localhost/shop go run ./cmd/examples/34-config
+//	APP_CONFIG=exercises/34-config/testdata/config.json APP_LOG_LEVEL=verbose go run ./cmd/examples/34-config
+//
+// fs.FS paths are relative and use slashes, so APP_CONFIG can't be
+// absolute here.
+//
+// You don't need to change this file; it works once the exercise does.
+// Add -tags solutions to see the reference solution run it.
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	config "github.com/imgarylai/learn-go/exercises/34-config"
+)
+
+func main() {
+	cfg, err := config.LoadConfig(os.DirFS("."), os.Getenv)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "34-config:", err)
+		os.Exit(1)
+	}
+	data, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "34-config:", err)
+		os.Exit(1)
+	}
+	fmt.Printf("%s\n", data)
+}
@@ -0,0 +1,40 @@
+// Command 35-hashing prints the SHA-256 of each file it's given, or of
+// stdin with none, in the format of sha256sum, using exercise 35's
+// SumFile and SumReader:
+//
+//	go run ./cmd/examples/35-hashing exercises/35-hashing/testdata/proverbs.txt
+//	go run ./cmd/examples/35-hashing < exercises/35-hashing/testdata/proverbs.txt
+//
+// You don't need to change this file; it works once the exercise does.
+// Add -tags solutions to see the reference solution run it.
+package main
+
+import (
+	"fmt"
+	"os"
+
+	hashing "github.com/imgarylai/learn-go/exercises/35-hashing"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		sum, err := hashing.SumReader(os.Stdin)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "35-hashing:", err)
+			os.Exit(1)
+		}
+		fmt.Printf("%s  -\n", sum)
+		return
+	}
+	code := 0
+	for _, path := range os.Args[1:] {
+		sum, err := hashing.SumFile(path)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "35-hashing:", err)
+			code = 1
+			continue
+		}
+		fmt.Printf("%s  %s\n", sum, path)
+	}
+	os.Exit(code)
+}
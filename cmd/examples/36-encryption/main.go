@@ -0,0 +1,59 @@
+// Command 36-encryption encrypts stdin with a passphrase, using
+// exercise 36's EncryptWithPassphrase, and writes it as base64; with -d
+// it decrypts what it wrote. The passphrase comes from the PASSPHRASE
+// environment variable, since a flag would show up in ps and shell
+// history:
+//
+//	echo 'meet at noon' | PASSPHRASE=hunter2 go run ./cmd/examples/36-encryption > secret.txt
+//	PASSPHRASE=hunter2 go run ./cmd/examples/36-encryption -d < secret.txt
+//
+// You don't need to change this file; it works once the exercise does.
+// Add -tags solutions to see the reference solution run it.
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	encryption "github.com/imgarylai/learn-go/exercises/36-encryption"
+)
+
+func main() {
+	decrypt := flag.Bool("d", false, "decrypt instead")
+	flag.Parse()
+
+	pass := os.Getenv("PASSPHRASE")
+	if pass == "" {
+		fmt.Fprintln(os.Stderr, "36-encryption: set PASSPHRASE")
+		os.Exit(2)
+	}
+	in, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "36-encryption:", err)
+		os.Exit(1)
+	}
+
+	var out []byte
+	if *decrypt {
+		data, err := base64.StdEncoding.DecodeString(string(bytes.TrimSpace(in)))
+		if err == nil {
+			out, err = encryption.DecryptWithPassphrase(pass, data)
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "36-encryption:", err)
+			os.Exit(1)
+		}
+	} else {
+		data, err := encryption.EncryptWithPassphrase(pass, in)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "36-encryption:", err)
+			os.Exit(1)
+		}
+		out = []byte(base64.StdEncoding.EncodeToString(data) + "\n")
+	}
+	os.Stdout.Write(out)
+}
@@ -0,0 +1,42 @@
+// Command 37-archives packs a directory into an archive with exercise
+// 37's CompressDir, or with -x unpacks one with ExtractArchive. The
+// archive's extension, .zip, .tar.gz or .tgz, picks the format:
+//
+//	go run ./cmd/examples/37-archives exercises/37-archives /tmp/ex37.zip
+//	go run ./cmd/examples/37-archives -x /tmp/ex37.zip /tmp/ex37
+//
+// You don't need to change this file; it works once the exercise does.
+// Add -tags solutions to see the reference solution run it.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	archives "github.com/imgarylai/learn-go/exercises/37-archives"
+)
+
+func main() {
+	extract := flag.Bool("x", false, "extract ARCHIVE into DIR instead")
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: 37-archives DIR ARCHIVE\n       37-archives -x ARCHIVE DIR")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 2 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	var err error
+	if *extract {
+		err = archives.ExtractArchive(flag.Arg(0), flag.Arg(1))
+	} else {
+		err = archives.CompressDir(flag.Arg(0), flag.Arg(1))
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "37-archives:", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,28 @@
+// Command 38-encodings prints the title of each item in an RSS feed
+// read from stdin, using exercise 38's ItemTitles, which streams it a
+// token at a time:
+//
+//	go run ./cmd/examples/38-encodings < exercises/38-encodings/testdata/feed.xml
+//	curl -s https://example.com/feed.xml | go run ./cmd/examples/38-encodings
+//
+// You don't need to change this file; it works once the exercise does.
+// Add -tags solutions to see the reference solution run it.
+package main
+
+import (
+	"fmt"
+	"os"
+
+	encodings "github.com/imgarylai/learn-go/exercises/38-encodings"
+)
+
+func main() {
+	titles, err := encodings.ItemTitles(os.Stdin)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "38-encodings:", err)
+		os.Exit(1)
+	}
+	for _, title := range titles {
+		fmt.Println(title)
+	}
+}
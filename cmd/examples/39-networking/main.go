@@ -0,0 +1,58 @@
+// Command 39-networking serves exercise 39's line protocol over TCP
+// with its Server, until Ctrl-C, which shuts it down gracefully: it
+// stops taking connections and gives the open ones five seconds to
+// QUIT. Try it with nc or telnet:
+//
+//	go run ./cmd/examples/39-networking -addr localhost:7000
+//	nc localhost 7000
+//	PING
+//	ADD 2 40
+//	QUIT
+//
+// You don't need to change this file; it works once the exercise does.
+// Add -tags solutions to see the reference solution run it.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"time"
+
+	networking "github.com/imgarylai/learn-go/exercises/39-networking"
+)
+
+func main() {
+	addr := flag.String("addr", "localhost:7000", "address to listen on")
+	flag.Parse()
+
+	ln, err := net.Listen("tcp", *addr)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "39-networking:", err)
+		os.Exit(1)
+	}
+	fmt.Println("listening on", ln.Addr())
+
+	s := &networking.Server{Handler: networking.ServeLines}
+	errc := make(chan error, 1)
+	go func() { errc <- s.Serve(ln) }()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	select {
+	case err := <-errc:
+		fmt.Fprintln(os.Stderr, "39-networking:", err)
+		os.Exit(1)
+	case <-ctx.Done():
+	}
+
+	fmt.Println("shutting down")
+	timeout, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := s.Shutdown(timeout); err != nil {
+		fmt.Println("closed the connections still open:", err)
+	}
+}
@@ -0,0 +1,66 @@
+// Command 40-processes runs exercise 40's RunWorker under
+// RunUntilSignal: it ticks until you press Ctrl-C, or something sends
+// it SIGTERM, then drains and exits cleanly. Run it, and from another
+// terminal try kill -TERM, or press Ctrl-C:
+//
+//	go run ./cmd/examples/40-processes [-interval 500ms]
+//
+// With -pipe it instead prints the ten commonest words on stdin, with
+// a Pipeline like tr | sort | uniq -c | sort -rn:
+//
+//	go run ./cmd/examples/40-processes -pipe < readme.md
+//
+// You don't need to change this file; it works once the exercise does.
+// Add -tags solutions to see the reference solution run it.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"syscall"
+	"time"
+
+	processes "github.com/imgarylai/learn-go/exercises/40-processes"
+)
+
+func main() {
+	interval := flag.Duration("interval", 500*time.Millisecond, "how often the worker ticks")
+	pipe := flag.Bool("pipe", false, "count the words on stdin with a pipeline instead")
+	flag.Parse()
+
+	if *pipe {
+		countWords()
+		return
+	}
+	fmt.Printf("pid %d; press Ctrl-C to stop\n", os.Getpid())
+	err := processes.RunUntilSignal(func(ctx context.Context) error {
+		return processes.RunWorker(ctx, *interval, os.Stdout)
+	}, os.Interrupt, syscall.SIGTERM)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "40-processes:", err)
+		os.Exit(1)
+	}
+}
+
+func countWords() {
+	in, err := io.ReadAll(os.Stdin)
+	if err == nil {
+		var out string
+		out, err = processes.Pipeline(context.Background(), string(in),
+			[]string{"tr", "-cs", "A-Za-z", "\n"},
+			[]string{"sort"},
+			[]string{"uniq", "-c"},
+			[]string{"sort", "-rn"},
+		)
+		lines := strings.SplitAfter(out, "\n")
+		fmt.Print(strings.Join(lines[:min(10, len(lines))], ""))
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "40-processes:", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,64 @@
+// Command 41-iofs prints how big each directory under DIR is, like
+// `du -b`, with exercise 41's DirSizes, or with -name the files whose
+// names match a glob pattern, like `find DIR -name`, with FindFiles.
+// Either way DIR, "." by default, is opened with os.DirFS:
+//
+//	go run ./cmd/examples/41-iofs exercises
+//	go run ./cmd/examples/41-iofs -name '*_test.go' exercises
+//
+// You don't need to change this file; it works once the exercise does.
+// Add -tags solutions to see the reference solution run it.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+
+	iofs "github.com/imgarylai/learn-go/exercises/41-iofs"
+)
+
+func main() {
+	pattern := flag.String("name", "", "list the files matching this glob `pattern` instead")
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: 41-iofs [-name pattern] [DIR]")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() > 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	dir := "."
+	if flag.NArg() == 1 {
+		dir = flag.Arg(0)
+	}
+	fsys := os.DirFS(dir)
+
+	if *pattern != "" {
+		files, err := iofs.FindFiles(fsys, *pattern)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "41-iofs:", err)
+			os.Exit(1)
+		}
+		for _, f := range files {
+			fmt.Println(f)
+		}
+		return
+	}
+
+	sizes, err := iofs.DirSizes(fsys)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "41-iofs:", err)
+		os.Exit(1)
+	}
+	dirs := make([]string, 0, len(sizes))
+	for d := range sizes {
+		dirs = append(dirs, d)
+	}
+	slices.Sort(dirs)
+	for _, d := range dirs {
+		fmt.Printf("%10d  %s\n", sizes[d], d)
+	}
+}
@@ -0,0 +1,63 @@
+// Command 42-sync-primitives fetches some made-up pages with exercise
+// 42's FetchAll, a few at a time, counting them with Stats, and prints
+// what each returned and how many were ever in flight at once. -fail
+// makes one page fail, to show the rest being canceled:
+//
+//	go run ./cmd/examples/42-sync-primitives -n 20 -limit 4
+//	go run ./cmd/examples/42-sync-primitives -fail 7
+//
+// You don't need to change this file; it works once the exercise does.
+// Add -tags solutions to see the reference solution run it.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"time"
+
+	primitives "github.com/imgarylai/learn-go/exercises/42-sync-primitives"
+)
+
+func main() {
+	n := flag.Int("n", 10, "how many pages to fetch")
+	limit := flag.Int("limit", 3, "how many to fetch at once; 0 for no limit")
+	fail := flag.Int("fail", -1, "the page that fails, if any")
+	flag.Parse()
+
+	var stats primitives.Stats
+	fetch := func(ctx context.Context, key string) (string, error) {
+		stats.Begin()
+		var err error
+		defer func() { stats.End(err) }()
+		if key == fmt.Sprint("page", *fail) {
+			err = fmt.Errorf("%s: 404 not found", key)
+			return "", err
+		}
+		select {
+		case <-time.After(time.Duration(50+rand.IntN(150)) * time.Millisecond):
+			return fmt.Sprintf("<h1>%s</h1>", key), nil
+		case <-ctx.Done():
+			err = ctx.Err()
+			return "", err
+		}
+	}
+
+	keys := make([]string, *n)
+	for i := range keys {
+		keys[i] = fmt.Sprint("page", i)
+	}
+	start := time.Now()
+	pages, err := primitives.FetchAll(context.Background(), keys, *limit, fetch)
+	for i, p := range pages {
+		fmt.Printf("%-8s %s\n", keys[i], p)
+	}
+	s := stats.Snapshot()
+	fmt.Printf("%d fetches, %d failed, at most %d at once, in %v\n", s.Requests, s.Errors, s.Peak, time.Since(start).Round(time.Millisecond))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "42-sync-primitives:", err)
+		os.Exit(1)
+	}
+}
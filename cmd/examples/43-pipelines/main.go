@@ -0,0 +1,66 @@
+// Command 43-pipelines finds the first N primes with a pipeline built
+// from exercise 43's stages: an endless stream of numbers, checked a
+// few at a time by OrderedMap, and cut off once there are enough. Then
+// it cancels the pipeline, which stops every stage:
+//
+//	go run ./cmd/examples/43-pipelines -n 20 -workers 4
+//
+// You don't need to change this file; it works once the exercise does.
+// Add -tags solutions to see the reference solution run it.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	pipelines "github.com/imgarylai/learn-go/exercises/43-pipelines"
+)
+
+type checked struct {
+	n     int
+	prime bool
+}
+
+func main() {
+	count := flag.Int("n", 10, "how many primes to find")
+	workers := flag.Int("workers", 4, "how many numbers to check at once")
+	flag.Parse()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	numbers := make(chan int)
+	go func() {
+		for n := 2; ; n++ {
+			select {
+			case numbers <- n:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	results := pipelines.OrderedMap(ctx, numbers, *workers, func(ctx context.Context, n int) checked {
+		return checked{n, isPrime(n)}
+	})
+	found := 0
+	for r := range results {
+		if !r.prime {
+			continue
+		}
+		fmt.Println(r.n)
+		if found++; found == *count {
+			break
+		}
+	}
+}
+
+func isPrime(n int) bool {
+	for d := 2; d*d <= n; d++ {
+		if n%d == 0 {
+			return false
+		}
+	}
+	return n >= 2
+}
@@ -0,0 +1,77 @@
+// Command 44-resilience calls a made-up flaky service through exercise
+// 44's patterns: a rate limiter spacing the calls out, a Retrier riding
+// out the odd failure, and a Breaker that opens when the service goes
+// down for a while, so the calls fail fast until it's back:
+//
+//	go run ./cmd/examples/44-resilience -calls 30 -rate 20
+//
+// You don't need to change this file; it works once the exercise does.
+// Add -tags solutions to see the reference solution run it.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	resilience "github.com/imgarylai/learn-go/exercises/44-resilience"
+)
+
+// service fails about one call in ten, and every call from 300ms to
+// 700ms after it starts, as if it had gone down.
+type service struct {
+	start time.Time
+	calls int
+}
+
+func (s *service) call(context.Context) error {
+	s.calls++
+	up := time.Since(s.start)
+	if up >= 300*time.Millisecond && up < 700*time.Millisecond || rand.IntN(10) == 0 {
+		return errors.New("503 service unavailable")
+	}
+	return nil
+}
+
+func main() {
+	calls := flag.Int("calls", 30, "how many requests to make")
+	perSecond := flag.Float64("rate", 20, "requests a second")
+	flag.Parse()
+
+	clock := resilience.RealClock{}
+	limiter := resilience.NewLimiter(clock, *perSecond, 1)
+	breaker := resilience.NewBreaker(clock, 3, 200*time.Millisecond)
+	retrier := resilience.Retrier{
+		Attempts: 3,
+		Backoff:  resilience.Backoff{Initial: 10 * time.Millisecond, Multiplier: 2, Max: 100 * time.Millisecond, Jitter: 0.5},
+		Clock:    clock,
+		Rand:     rand.Float64,
+	}
+	start := time.Now()
+	svc := &service{start: start}
+	ctx := context.Background()
+
+	for i := range *calls {
+		if err := limiter.Wait(ctx); err != nil {
+			fmt.Println("rate limiter:", err)
+			return
+		}
+		err := retrier.Do(ctx, func(ctx context.Context) error {
+			err := breaker.Do(func() error { return svc.call(ctx) })
+			if errors.Is(err, resilience.ErrOpen) {
+				return resilience.Permanent(err)
+			}
+			return err
+		})
+		result := "ok"
+		if err != nil {
+			result = err.Error()
+		}
+		fmt.Printf("%6s  request %2d  breaker %-9v  %s\n",
+			time.Since(start).Round(time.Millisecond), i+1, breaker.State(), result)
+	}
+	fmt.Printf("%d requests, %d calls to the service\n", *calls, svc.calls)
+}
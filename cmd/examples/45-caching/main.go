@@ -0,0 +1,48 @@
+// Command 45-caching sends bursts of concurrent requests for a few keys
+// to a slow lookup, memoized with exercise 45's Memoize in front of an
+// LRU cache. The first burst makes one lookup per key, however many
+// requests ask for it; the bursts after that are served from the cache:
+//
+//	go run ./cmd/examples/45-caching -requests 100 -keys 5 -bursts 3
+//
+// You don't need to change this file; it works once the exercise does.
+// Add -tags solutions to see the reference solution run it.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	caching "github.com/imgarylai/learn-go/exercises/45-caching"
+)
+
+func main() {
+	requests := flag.Int("requests", 100, "concurrent requests in each burst")
+	keys := flag.Int("keys", 5, "how many different keys they ask for")
+	bursts := flag.Int("bursts", 3, "how many bursts to send")
+	flag.Parse()
+
+	var lookups atomic.Int32
+	lookup := caching.Memoize[string, string](caching.NewLRU[string, string](*keys), func(key string) (string, error) {
+		lookups.Add(1)
+		time.Sleep(100 * time.Millisecond) // a slow database query
+		return "value of " + key, nil
+	})
+
+	for b := range *bursts {
+		start := time.Now()
+		before := lookups.Load()
+		var wg sync.WaitGroup
+		for i := range *requests {
+			wg.Go(func() {
+				lookup(fmt.Sprint("key-", i%*keys))
+			})
+		}
+		wg.Wait()
+		fmt.Printf("burst %d: %d requests, %d lookups, %v\n",
+			b+1, *requests, lookups.Load()-before, time.Since(start).Round(time.Millisecond))
+	}
+}
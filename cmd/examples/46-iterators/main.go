@@ -0,0 +1,43 @@
+// Command 46-iterators prints the first few lines of its input that
+// contain a string, numbered, like grep -n piped into head. It's built
+// from exercise 46's sequences, so it stops reading as soon as it has
+// enough lines, even from a file far bigger than memory:
+//
+//	go run ./cmd/examples/46-iterators -grep func -n 5 < exercises/46-iterators/iterators.go
+//
+// You don't need to change this file; it works once the exercise does.
+// Add -tags solutions to see the reference solution run it.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	iterators "github.com/imgarylai/learn-go/exercises/46-iterators"
+)
+
+type numbered struct {
+	n    int
+	line string
+}
+
+func main() {
+	grep := flag.String("grep", "", "only print lines containing this")
+	n := flag.Int("n", 10, "how many lines to print")
+	flag.Parse()
+
+	// Filter takes an iter.Seq, so pair each line with its number.
+	all := func(yield func(numbered) bool) {
+		for i, line := range iterators.Enumerate(iterators.Lines(os.Stdin)) {
+			if !yield(numbered{i + 1, line}) {
+				return
+			}
+		}
+	}
+	matching := iterators.Filter(all, func(l numbered) bool { return strings.Contains(l.line, *grep) })
+	for l := range iterators.Take(matching, *n) {
+		fmt.Printf("%d:%s\n", l.n, l.line)
+	}
+}
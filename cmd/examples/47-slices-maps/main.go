@@ -0,0 +1,37 @@
+// Command 47-slices-maps ranks the name=score pairs it's given, with
+// exercise 47's functions: the ranking, ties in alphabetical order,
+// the winner, and the distinct scores:
+//
+//	go run ./cmd/examples/47-slices-maps ada=95 alan=87 grace=95 linus=70
+//
+// You don't need to change this file; it works once the exercise does.
+// Add -tags solutions to see the reference solution run it.
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	slicesmaps "github.com/imgarylai/learn-go/exercises/47-slices-maps"
+)
+
+func main() {
+	scores := map[string]int{}
+	for _, arg := range os.Args[1:] {
+		name, score, ok := strings.Cut(arg, "=")
+		n, err := strconv.Atoi(score)
+		if !ok || err != nil {
+			fmt.Fprintf(os.Stderr, "%q isn't name=score\n", arg)
+			os.Exit(2)
+		}
+		scores[name] = n
+	}
+
+	for i, name := range slicesmaps.Ranking(scores) {
+		fmt.Printf("%2d. %-10s %d\n", i+1, name, scores[name])
+	}
+	fmt.Println("winner:", slicesmaps.DisplayName(slicesmaps.TopScorer(scores), "nobody", ""))
+	fmt.Println("scores:", slicesmaps.DistinctScores(scores))
+}
@@ -0,0 +1,35 @@
+// Command 48-pointers shows exercise 48's copies and sharing at work:
+// two teams made from one with WithMember, the windows over a list of
+// numbers, and users updated through IndexByName. Before the aliasing
+// bugs are fixed, the output shows each of them:
+//
+//	go run ./cmd/examples/48-pointers
+//
+// You don't need to change this file; it works once the exercise does.
+// Add -tags solutions to see the reference solution run it.
+package main
+
+import (
+	"fmt"
+
+	pointers "github.com/imgarylai/learn-go/exercises/48-pointers"
+)
+
+func main() {
+	members := make([]string, 0, 8)
+	base := pointers.Team{Name: "core", Members: append(members, "ada", "alan")}
+	red := base.WithMember("grace")
+	blue := base.WithMember("linus")
+	fmt.Println("base:", base.Members)
+	fmt.Println("red: ", red.Members)
+	fmt.Println("blue:", blue.Members)
+
+	fmt.Println("windows:", pointers.Windows([]int{1, 2, 3, 4, 5}, 3))
+
+	paths := pointers.WithSuffixes(append(make([]string, 0, 4), "usr", "local"), "bin", "lib")
+	fmt.Println("paths:", paths)
+
+	users := []pointers.User{{Name: "ada", Email: "ada@example.com"}, {Name: "alan", Email: "alan@example.com"}}
+	pointers.IndexByName(users)["ada"].Email = "countess@example.com"
+	fmt.Println("users:", users)
+}
@@ -0,0 +1,71 @@
+// Command 49-optimization times exercise 49's funcs on a report of
+// 20,000 rows: rendering it, counting the words on each line, writing
+// it to a file and counting the file's lines. Run it before and after
+// optimizing to see the difference outside a benchmark:
+//
+//	go run ./cmd/examples/49-optimization
+//
+// You don't need to change this file; it works once the exercise does.
+// Add -tags solutions to see the reference solution run it.
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	optimization "github.com/imgarylai/learn-go/exercises/49-optimization"
+)
+
+func main() {
+	rows := make([]optimization.Row, 20_000)
+	for i := range rows {
+		rows[i] = optimization.Row{Name: fmt.Sprintf("item %d", i), Count: i * 37}
+	}
+
+	start := time.Now()
+	table := optimization.RenderTable(rows)
+	fmt.Printf("RenderTable:   %8d bytes in %v\n", len(table), time.Since(start))
+
+	lines := strings.Split(strings.TrimSuffix(table, "\n"), "\n")
+	start = time.Now()
+	counts := optimization.DistinctWords(lines)
+	fmt.Printf("DistinctWords: %8d lines in %v\n", len(counts), time.Since(start))
+
+	dir, err := os.MkdirTemp("", "optimization")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "report.txt")
+
+	f, err := os.Create(path)
+	if err != nil {
+		log.Fatal(err)
+	}
+	start = time.Now()
+	err = optimization.WriteLines(f, lines)
+	elapsed := time.Since(start)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("WriteLines:    %8d lines in %v\n", len(lines), elapsed)
+
+	f, err = os.Open(path)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer f.Close()
+	start = time.Now()
+	n, err := optimization.CountByte(f, '\n')
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("CountByte:     %8d lines in %v\n", n, time.Since(start))
+}
@@ -0,0 +1,52 @@
+// Command learngo-server hosts a classroom leaderboard for `learngo submit`.
+//
+// Usage:
+//
+//	learngo-server [-addr :8080] [-db leaderboard.db]
+//
+// Start it on a machine everyone in the workshop can reach, then have
+// students run `learngo submit --server http://that-machine:8080`.
+// Open the same URL in a browser (or on the projector) to see the board.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/imgarylai/learn-go/internal/leaderboard"
+)
+
+func main() {
+	addr := flag.String("addr", ":8080", "address to listen on")
+	dbPath := flag.String("db", "leaderboard.db", "SQLite database file")
+	flag.Parse()
+
+	if err := run(*addr, *dbPath); err != nil {
+		fmt.Fprintf(os.Stderr, "learngo-server: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(addr, dbPath string) error {
+	store, err := leaderboard.Open(dbPath)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           leaderboard.NewHandler(store),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	log.Printf("leaderboard on http://localhost%s (data in %s)", addr, dbPath)
+	if err := srv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
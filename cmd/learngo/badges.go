@@ -0,0 +1,39 @@
+package main
+
+import (
+	"fmt"
+	"text/tabwriter"
+	"time"
+
+	"github.com/imgarylai/learn-go/internal/achievements"
+)
+
+// runBadges implements `learngo badges`: every badge, with when you
+// earned it or what it takes. Badges are handed out by the commands
+// that record progress: run, test-all, verify, quiz and bench.
+func runBadges(a *app, args []string) error {
+	if len(args) > 0 {
+		return errUsage
+	}
+	prog, _, err := a.loadProgress()
+	if err != nil {
+		return err
+	}
+
+	tw := tabwriter.NewWriter(a.stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "BADGE\tEARNED\tHOW")
+	count := 0
+	for _, b := range achievements.All {
+		earned := "-"
+		if at, ok := prog.Badges[b.ID]; ok {
+			earned = at.Local().Format(time.DateOnly)
+			count++
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", b.Title, earned, b.Description)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	fmt.Fprintf(a.stdout, "\n%d of %d earned.\n", count, len(achievements.All))
+	return nil
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/imgarylai/learn-go/internal/runner"
+)
+
+func TestRunAwardsBadges(t *testing.T) {
+	a := newTestApp(t)
+	fakeResults(a, map[string]runner.Result{
+		"exercises/06-concurrency": failing("TestWorkerPool"),
+	})
+
+	_, stdout, _ := runApp(t, a, "run", "04")
+	if !strings.Contains(stdout, "Badge earned: First green module") {
+		t.Errorf("no badge for the first green exercise:\n%s", stdout)
+	}
+	if _, stdout, _ = runApp(t, a, "run", "05"); strings.Contains(stdout, "Badge earned") {
+		t.Errorf("badge awarded twice:\n%s", stdout)
+	}
+	// The race detector is on by default for 06, but its tests fail.
+	if _, stdout, _ = runApp(t, a, "run", "06"); strings.Contains(stdout, "Race-free") {
+		t.Errorf("race-free badge for failing tests:\n%s", stdout)
+	}
+
+	fakeResults(a, nil)
+	if _, stdout, _ = runApp(t, a, "run", "06"); !strings.Contains(stdout, "Badge earned: Race-free concurrency") {
+		t.Errorf("no race-free badge:\n%s", stdout)
+	}
+
+	code, stdout, _ := runApp(t, a, "badges")
+	if code != 0 {
+		t.Fatalf("exit code %d", code)
+	}
+	for _, want := range []string{`First green module\s+\d{4}-`, `Race-free concurrency\s+\d{4}-`, `All green\s+-`, `2 of 5 earned\.`} {
+		if !regexp.MustCompile(want).MatchString(stdout) {
+			t.Errorf("missing %s:\n%s", want, stdout)
+		}
+	}
+}
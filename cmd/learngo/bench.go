@@ -0,0 +1,260 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"path/filepath"
+	"text/tabwriter"
+	"time"
+
+	"github.com/imgarylai/learn-go/internal/achievements"
+	"github.com/imgarylai/learn-go/internal/bench"
+	"github.com/imgarylai/learn-go/internal/registry"
+	"github.com/imgarylai/learn-go/internal/solutions"
+)
+
+// runBench implements `learngo bench [--bench regexp] [--check | --solution] <exercise>`.
+//
+// Every run is appended to the history file, and the report compares it
+// with the run before so you can see whether a change actually helped.
+// --solution benchmarks the reference solution instead and compares it
+// with your last run, so you can see what a faster approach buys.
+// --check grades the solution against the exercise's committed baseline;
+// --write-baseline records that baseline from the reference solution
+// (for maintainers).
+func runBench(a *app, args []string) error {
+	fs := flag.NewFlagSet("bench", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	pattern := fs.String("bench", ".", "only run benchmarks matching this regexp")
+	check := fs.Bool("check", false, "fail if slower or allocating more than the baseline")
+	solution := fs.Bool("solution", false, "benchmark the reference solution and compare it with your last run")
+	writeBaseline := fs.Bool("write-baseline", false, "record the reference solution's run as the exercise's baseline")
+	if err := fs.Parse(args); err != nil || fs.NArg() != 1 || (*check && (*solution || *writeBaseline)) {
+		return errUsage
+	}
+	e, ok := registry.Lookup(fs.Arg(0))
+	if !ok {
+		return fmt.Errorf("unknown exercise %q (see `learngo list`)", fs.Arg(0))
+	}
+	if *check {
+		return benchCheck(a, e)
+	}
+	var extra []string
+	if *solution || *writeBaseline {
+		if e.Pack != "" {
+			return fmt.Errorf("%s comes from the %s pack, which has no reference solution", e.ID, e.Pack)
+		}
+		extra = append(extra, "-tags="+solutions.Tag)
+	}
+
+	results, err := a.benchmark(context.Background(), e.Dir(), *pattern, extra...)
+	if err != nil {
+		return err
+	}
+	if len(results) == 0 {
+		fmt.Fprintf(a.stdout, "%s has no benchmarks matching %q\n", e.ID, *pattern)
+		return nil
+	}
+
+	path, err := a.benchHistory()
+	if err != nil {
+		return err
+	}
+	history, err := bench.LoadHistory(path)
+	if err != nil {
+		return err
+	}
+	prev, _ := history.Last(e.ID)
+	if *solution {
+		fmt.Fprintf(a.stdout, "Reference solution for %s, against your last run:\n\n", e.ID)
+	}
+
+	tw := tabwriter.NewWriter(a.stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "BENCHMARK\tNS/OP\tDELTA\tALLOCS/OP\tDELTA\t")
+	regressions := 0
+	for _, d := range bench.Compare(prev.Results, results) {
+		nsDelta, allocDelta := "new", "new"
+		if d.HasPrev {
+			nsDelta = fmt.Sprintf("%+.1f%%", d.NsChange()*100)
+			allocDelta = fmt.Sprintf("%+d", d.AllocsChange())
+		}
+		mark := ""
+		if d.Regressed() && !*solution {
+			mark = "<- regression"
+			regressions++
+		}
+		fmt.Fprintf(tw, "%s\t%.2f\t%s\t%d\t%s\t%s\n",
+			d.Name, d.Current.NsPerOp, nsDelta, d.Current.AllocsPerOp, allocDelta, mark)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	switch {
+	case *writeBaseline:
+		return saveBaseline(a, e, results, extra...)
+	case *solution:
+		// Not your code, so it stays out of your history.
+		if prev.Time.IsZero() {
+			fmt.Fprintf(a.stdout, "\nNo run of yours recorded yet; run `learngo bench %s` to compare.\n", e.ID)
+		} else {
+			fmt.Fprintln(a.stdout, "\nA negative DELTA means the reference solution is faster or allocates less than yours.")
+		}
+		return nil
+	case prev.Time.IsZero():
+		fmt.Fprintln(a.stdout, "\nFirst run recorded; run again after a change to see deltas.")
+	case regressions > 0:
+		fmt.Fprintf(a.stdout, "\n%d regression(s) since %s (tolerance %.0f%% ns/op, 0 allocs/op).\n",
+			regressions, prev.Time.Format(time.DateTime), bench.Tolerance*100)
+	default:
+		fmt.Fprintf(a.stdout, "\nNo regressions since %s.\n", prev.Time.Format(time.DateTime))
+	}
+
+	history.Add(e.ID, bench.Record{Time: time.Now(), Results: results})
+	if err := history.Save(path); err != nil {
+		return err
+	}
+
+	prog, progPath, err := a.loadProgress()
+	if err != nil {
+		return err
+	}
+	badges := achievements.Award(prog, achievements.Event{Exercise: e.ID, Bench: results}, a.clock())
+	if len(badges) == 0 {
+		return nil
+	}
+	a.announce(badges)
+	return prog.Save(progPath)
+}
+
+// benchCheck implements `learngo bench --check`. Timings are scaled by
+// the calibration benchmark so a slow laptop isn't penalized.
+func benchCheck(a *app, e registry.Exercise) error {
+	ctx := context.Background()
+	root, err := a.rootDir()
+	if err != nil {
+		return err
+	}
+	baseline, err := bench.LoadBaseline(e.DirIn(root))
+	if errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("%s has no benchmark baseline; only performance exercises can be checked", e.ID)
+	}
+	if err != nil {
+		return err
+	}
+
+	// A fast wrong answer isn't a pass: the stubs return nil in no time.
+	res, err := a.test(ctx, e.Dir())
+	if err != nil {
+		return err
+	}
+	if !res.OK() {
+		fmt.Fprintf(a.stdout, "%s has failing tests; make them pass before checking performance.\n", e.ID)
+		return exitError{code: 1}
+	}
+
+	calibration, err := a.calibrate(ctx)
+	if err != nil {
+		return err
+	}
+	results, err := a.benchmark(ctx, e.Dir(), ".")
+	if err != nil {
+		return err
+	}
+
+	tw := tabwriter.NewWriter(a.stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "BENCHMARK\tNS/OP\tLIMIT\tALLOCS/OP\tLIMIT\t")
+	regressions := 0
+	for _, v := range baseline.Check(results, calibration) {
+		mark := "ok"
+		if v.Regressed() {
+			mark = "<- too slow"
+			if v.Current.AllocsPerOp > v.MaxAllocs {
+				mark = "<- too many allocations"
+			}
+			regressions++
+		}
+		if v.Missing {
+			fmt.Fprintf(tw, "%s\t-\t%.2f\t-\t%d\t<- missing\n", v.Name, v.MaxNs, v.MaxAllocs)
+			continue
+		}
+		fmt.Fprintf(tw, "%s\t%.2f\t%.2f\t%d\t%d\t%s\n",
+			v.Name, v.Current.NsPerOp, v.MaxNs, v.Current.AllocsPerOp, v.MaxAllocs, mark)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	scale := calibration / baseline.CalibrationNsPerOp
+	fmt.Fprintf(a.stdout, "\nThis machine runs the calibration benchmark at %.2fx the baseline's time; limits are scaled to match, plus %.0f%%.\n",
+		scale, bench.BaselineTolerance*100)
+	if regressions > 0 {
+		fmt.Fprintf(a.stdout, "%d benchmark(s) over the limit.\n", regressions)
+		return exitError{code: 1}
+	}
+	fmt.Fprintln(a.stdout, "All benchmarks within the baseline.")
+	return nil
+}
+
+// baselineRuns is how many runs a baseline is the median of.
+const baselineRuns = 5
+
+// saveBaseline writes e's baseline with this machine's calibration
+// timing. results is the run just made; a few more are added so the
+// baseline is a median rather than one noisy sample.
+func saveBaseline(a *app, e registry.Exercise, results []bench.Result, extra ...string) error {
+	ctx := context.Background()
+	runs := [][]bench.Result{results}
+	var calibrations [][]bench.Result
+	for len(calibrations) < baselineRuns {
+		if len(runs) < baselineRuns {
+			r, err := a.benchmark(ctx, e.Dir(), ".", extra...)
+			if err != nil {
+				return err
+			}
+			runs = append(runs, r)
+		}
+		c, err := a.calibrate(ctx)
+		if err != nil {
+			return err
+		}
+		calibrations = append(calibrations, []bench.Result{{Name: "BenchmarkCalibrate", NsPerOp: c}})
+	}
+	root, err := a.rootDir()
+	if err != nil {
+		return err
+	}
+	b := bench.Baseline{
+		CalibrationNsPerOp: bench.Median(calibrations)[0].NsPerOp,
+		Results:            bench.Median(runs),
+	}
+	if err := b.Save(e.DirIn(root)); err != nil {
+		return err
+	}
+	fmt.Fprintf(a.stdout, "Wrote %s\n", filepath.Join(e.Dir(), bench.BaselineFile))
+	return nil
+}
+
+// calibrate measures this machine with the calibration benchmark.
+func (a *app) calibrate(ctx context.Context) (float64, error) {
+	results, err := a.benchmark(ctx, bench.CalibrationDir, "^BenchmarkCalibrate$")
+	if err != nil {
+		return 0, err
+	}
+	if len(results) == 0 || results[0].NsPerOp == 0 {
+		return 0, errors.New("calibration benchmark produced no result")
+	}
+	return results[0].NsPerOp, nil
+}
+
+// benchHistory returns the path of the benchmark history file.
+func (a *app) benchHistory() (string, error) {
+	if a.benchHistoryPath != "" {
+		return a.benchHistoryPath, nil
+	}
+	return bench.DefaultHistoryPath()
+}
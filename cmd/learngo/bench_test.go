@@ -0,0 +1,169 @@
+package main
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/imgarylai/learn-go/internal/bench"
+	"github.com/imgarylai/learn-go/internal/runner"
+)
+
+func TestBenchReportsDeltas(t *testing.T) {
+	a := newTestApp(t)
+	runs := [][]bench.Result{
+		{{Name: "BenchmarkSum", NsPerOp: 100, AllocsPerOp: 0}},
+		{{Name: "BenchmarkSum", NsPerOp: 200, AllocsPerOp: 1}},
+	}
+	a.runBench = func(context.Context, string, string, string, ...string) ([]bench.Result, error) {
+		r := runs[0]
+		runs = runs[1:]
+		return r, nil
+	}
+
+	code, stdout, stderr := runApp(t, a, "bench", "04")
+	if code != 0 {
+		t.Fatalf("first run: code %d, stderr %q", code, stderr)
+	}
+	if !strings.Contains(stdout, "First run recorded") {
+		t.Errorf("first run output:\n%s", stdout)
+	}
+
+	code, stdout, _ = runApp(t, a, "bench", "04")
+	if code != 0 {
+		t.Errorf("regressions are reported, not fatal: got code %d", code)
+	}
+	for _, want := range []string{"+100.0%", "+1", "<- regression", "1 regression(s)"} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("expected %q in output:\n%s", want, stdout)
+		}
+	}
+}
+
+func TestBenchSolutionComparesWithYourLastRun(t *testing.T) {
+	a := newTestApp(t)
+	var tagged []bool
+	a.runBench = func(_ context.Context, _, _, _ string, args ...string) ([]bench.Result, error) {
+		solution := slices.Contains(args, "-tags=solutions")
+		tagged = append(tagged, solution)
+		if solution {
+			return []bench.Result{{Name: "BenchmarkMapInts", NsPerOp: 50, AllocsPerOp: 1}}, nil
+		}
+		return []bench.Result{{Name: "BenchmarkMapInts", NsPerOp: 100, AllocsPerOp: 14}}, nil
+	}
+
+	runApp(t, a, "bench", "02")
+	code, stdout, stderr := runApp(t, a, "bench", "--solution", "02")
+	if code != 0 {
+		t.Fatalf("code %d, stderr %q", code, stderr)
+	}
+	for _, want := range []string{"Reference solution for 02-functions", "-50.0%", "-13", "reference solution is faster"} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("expected %q in output:\n%s", want, stdout)
+		}
+	}
+	if !slices.Equal(tagged, []bool{false, true}) {
+		t.Errorf("solution tag per run: %v", tagged)
+	}
+
+	// The reference run must not become "your last run".
+	_, stdout, _ = runApp(t, a, "bench", "02")
+	if !strings.Contains(stdout, "No regressions") || strings.Contains(stdout, "+100.0%") {
+		t.Errorf("second run of yours:\n%s", stdout)
+	}
+}
+
+func TestBenchWithoutBenchmarks(t *testing.T) {
+	a := newTestApp(t)
+	a.runBench = func(context.Context, string, string, string, ...string) ([]bench.Result, error) {
+		return nil, nil
+	}
+
+	code, stdout, _ := runApp(t, a, "bench", "01-basics")
+	if code != 0 || !strings.Contains(stdout, "has no benchmarks") {
+		t.Errorf("got code %d, output %q", code, stdout)
+	}
+}
+
+func TestBenchUsage(t *testing.T) {
+	code, _, stderr := runCLI(t, "bench")
+	if code != 2 || !strings.Contains(stderr, "usage: learngo bench") {
+		t.Errorf("got code %d, stderr %q", code, stderr)
+	}
+}
+
+// fakeBaselineRun makes a.runBench report the calibration benchmark at
+// calibration ns/op and every exercise benchmark at the baseline's
+// numbers times slowdown, with extraAllocs on top.
+func fakeBaselineRun(t *testing.T, a *app, calibration, slowdown float64, extraAllocs int64) {
+	t.Helper()
+	baseline, err := bench.LoadBaseline("../../exercises/04-collections")
+	if err != nil {
+		t.Fatal(err)
+	}
+	a.runBench = func(_ context.Context, _, dir, _ string, _ ...string) ([]bench.Result, error) {
+		if dir == bench.CalibrationDir {
+			return []bench.Result{{Name: "BenchmarkCalibrate", NsPerOp: calibration}}, nil
+		}
+		var out []bench.Result
+		for _, r := range baseline.Results {
+			r.NsPerOp *= slowdown
+			r.AllocsPerOp += extraAllocs
+			out = append(out, r)
+		}
+		return out, nil
+	}
+	fakeResults(a, nil)
+}
+
+func TestBenchCheck(t *testing.T) {
+	baseline, err := bench.LoadBaseline("../../exercises/04-collections")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cal := baseline.CalibrationNsPerOp
+
+	tests := []struct {
+		name                  string
+		calibration, slowdown float64
+		extraAllocs           int64
+		wantCode              int
+		want                  string
+	}{
+		{"same machine, same speed", cal, 1, 0, 0, "All benchmarks within"},
+		{"slower machine", cal * 3, 3, 0, 0, "3.00x"},
+		{"slower solution", cal, 3, 0, 1, "<- too slow"},
+		{"more allocations", cal, 1, 1, 1, "<- too many allocations"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := newTestApp(t)
+			fakeBaselineRun(t, a, tt.calibration, tt.slowdown, tt.extraAllocs)
+			code, stdout, stderr := runApp(t, a, "bench", "--check", "04")
+			if code != tt.wantCode {
+				t.Errorf("code: got %d, want %d\n%s%s", code, tt.wantCode, stdout, stderr)
+			}
+			if !strings.Contains(stdout, tt.want) {
+				t.Errorf("expected %q in:\n%s", tt.want, stdout)
+			}
+		})
+	}
+}
+
+func TestBenchCheckNeedsPassingTests(t *testing.T) {
+	a := newTestApp(t)
+	fakeBaselineRun(t, a, 1, 1, 0)
+	fakeResults(a, map[string]runner.Result{"exercises/04-collections": failing("TestSum")})
+	code, stdout, _ := runApp(t, a, "bench", "--check", "04")
+	if code != 1 || !strings.Contains(stdout, "failing tests") {
+		t.Errorf("code %d:\n%s", code, stdout)
+	}
+}
+
+func TestBenchCheckWithoutBaseline(t *testing.T) {
+	code, _, stderr := runCLI(t, "bench", "--check", "01")
+	if code != 1 || !strings.Contains(stderr, "no benchmark baseline") {
+		t.Errorf("code %d, stderr %q", code, stderr)
+	}
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/user"
+	"strings"
+
+	"github.com/imgarylai/learn-go/internal/certificate"
+	"github.com/imgarylai/learn-go/internal/registry"
+)
+
+// runCertificate implements `learngo certificate [--name "Your Name"] [--out file.svg]`.
+// It re-runs every exercise first: no certificate until everything is
+// green, with the tests that shipped, as `learngo verify` checks them.
+func runCertificate(a *app, args []string) error {
+	fs := flag.NewFlagSet("certificate", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	name := fs.String("name", "", "name to print on the certificate (default: your account name)")
+	out := fs.String("out", "certificate.svg", "where to write the SVG")
+	if err := fs.Parse(args); err != nil || fs.NArg() > 0 {
+		return errUsage
+	}
+	if *name == "" {
+		*name = accountName()
+	}
+	if *name == "" {
+		return fmt.Errorf("couldn't work out your name; pass --name")
+	}
+
+	data := certificate.Data{Name: *name, Date: a.clock()}
+	topics := map[string]bool{}
+	var unfinished []string
+	for _, e := range registry.All() {
+		if e.Pack != "" {
+			continue // the certificate is for this course, not add-on packs
+		}
+		if err := a.checkTests(e); err != nil {
+			return err
+		}
+		res, err := a.test(context.Background(), e.Dir())
+		if err != nil {
+			return fmt.Errorf("%s: %w", e.ID, err)
+		}
+		if !res.OK() {
+			unfinished = append(unfinished, e.ID)
+			continue
+		}
+		pass, _, _ := res.Counts()
+		data.Exercises++
+		data.Tests += pass
+		for _, t := range e.Topics {
+			topics[t] = true
+		}
+	}
+	data.Topics = len(topics)
+
+	if len(unfinished) > 0 {
+		fmt.Fprintf(a.stdout, "Almost there! These exercises still have failing tests:\n  %s\n",
+			strings.Join(unfinished, "\n  "))
+		return exitError{code: 1}
+	}
+
+	f, err := os.Create(*out)
+	if err != nil {
+		return err
+	}
+	if err := certificate.WriteSVG(f, data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(a.stdout, "Congratulations, %s! %d exercises, %d passing tests.\nWrote %s\n",
+		data.Name, data.Exercises, data.Tests, *out)
+	return nil
+}
+
+// accountName is the user's full name if the OS knows it, else their login.
+func accountName() string {
+	u, err := user.Current()
+	if err != nil {
+		return os.Getenv("USER")
+	}
+	if full, _, _ := strings.Cut(u.Name, ","); full != "" {
+		return full
+	}
+	return u.Username
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/imgarylai/learn-go/internal/integrity"
+	"github.com/imgarylai/learn-go/internal/registry"
+	"github.com/imgarylai/learn-go/internal/runner"
+)
+
+func TestCertificateRequiresEverythingGreen(t *testing.T) {
+	a := newTestApp(t)
+	fakeResults(a, map[string]runner.Result{
+		"exercises/06-concurrency": failing("TestWorkerPool"),
+	})
+	out := filepath.Join(t.TempDir(), "cert.svg")
+
+	code, stdout, _ := runApp(t, a, "certificate", "--name", "Gopher", "--out", out)
+	if code != 1 || !strings.Contains(stdout, "06-concurrency") {
+		t.Errorf("got code %d, output:\n%s", code, stdout)
+	}
+	if _, err := os.Stat(out); err == nil {
+		t.Error("certificate written despite failing tests")
+	}
+}
+
+func TestCertificateWritesSVG(t *testing.T) {
+	a := newTestApp(t)
+	a.now = func() time.Time { return time.Date(2025, time.March, 14, 9, 0, 0, 0, time.UTC) }
+	fakeResults(a, nil) // every exercise passes one test
+	out := filepath.Join(t.TempDir(), "cert.svg")
+
+	code, stdout, stderr := runApp(t, a, "certificate", "--name", "Gopher", "--out", out)
+	if code != 0 {
+		t.Fatalf("code %d, stderr %q", code, stderr)
+	}
+	if !strings.Contains(stdout, "Congratulations, Gopher!") {
+		t.Errorf("output:\n%s", stdout)
+	}
+
+	svg, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantCount := fmt.Sprintf("%d exercises", len(registry.All()))
+	if !strings.Contains(string(svg), "Gopher") || !strings.Contains(string(svg), wantCount) ||
+		!strings.Contains(string(svg), "March 14, 2025") {
+		t.Errorf("unexpected SVG:\n%s", svg)
+	}
+}
+
+// shippedTestsRoot returns an app whose root is a scratch directory
+// holding every exercise's tests as they shipped, and nothing else.
+func shippedTestsRoot(t *testing.T) *app {
+	t.Helper()
+	a := newTestApp(t)
+	a.root = t.TempDir()
+	m, err := integrity.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for id, files := range m {
+		for name := range files {
+			data, err := os.ReadFile(filepath.Join("..", "..", "exercises", id, name))
+			if err != nil {
+				t.Fatal(err)
+			}
+			path := filepath.Join(a.root, "exercises", id, name)
+			if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+				t.Fatal(err)
+			}
+			writeFile(t, path, string(data))
+		}
+	}
+	return a
+}
+
+func TestCertificateRefusesEditedTests(t *testing.T) {
+	a := shippedTestsRoot(t)
+	writeFile(t, filepath.Join(a.root, "exercises", "04-collections", "collections_test.go"), "package collections\n")
+	fakeResults(a, nil)
+	out := filepath.Join(t.TempDir(), "cert.svg")
+
+	code, stdout, _ := runApp(t, a, "certificate", "--name", "Gopher", "--out", out)
+	if code != 1 || !strings.Contains(stdout, "modified exercises/04-collections/collections_test.go") {
+		t.Errorf("got code %d, output:\n%s", code, stdout)
+	}
+	if _, err := os.Stat(out); err == nil {
+		t.Error("certificate written despite edited tests")
+	}
+}
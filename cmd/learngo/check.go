@@ -0,0 +1,74 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/imgarylai/learn-go/internal/constraint"
+	"github.com/imgarylai/learn-go/internal/registry"
+)
+
+// runCheck implements `learngo check [exercise...]`: passing tests isn't
+// enough if the solution sidesteps what the exercise teaches, e.g. by
+// calling slices.Max in the collections module. With no exercises it
+// checks all of them.
+func runCheck(a *app, args []string) error {
+	exercises := registry.All()
+	if len(args) > 0 {
+		exercises = exercises[:0]
+		for _, id := range args {
+			e, ok := registry.Lookup(id)
+			if !ok {
+				return fmt.Errorf("unknown exercise %q (see `learngo list`)", id)
+			}
+			if e.Pack != "" {
+				fmt.Fprintf(a.stdout, "%s: exercise packs have no rules to check\n", e.ID)
+				continue
+			}
+			exercises = append(exercises, e)
+		}
+	}
+	// Pack exercises live outside this module, and constraints are
+	// only declared for the built-in ones.
+	exercises = slices.DeleteFunc(exercises, func(e registry.Exercise) bool { return e.Pack != "" })
+	if len(exercises) == 0 {
+		return nil
+	}
+
+	root, err := a.rootDir()
+	if err != nil {
+		return err
+	}
+	dirs := make([]string, len(exercises))
+	for i, e := range exercises {
+		dirs[i] = e.Dir()
+	}
+	results, err := constraint.Check(root, dirs...)
+	if err != nil {
+		return err
+	}
+
+	failed := false
+	for i, res := range results {
+		e := exercises[i]
+		switch {
+		case res.Err != nil:
+			fmt.Fprintf(a.stdout, "%s: skipped, %v\n", e.ID, res.Err)
+		case len(res.Violations) > 0:
+			failed = true
+			fmt.Fprintf(a.stdout, "%s:\n", e.ID)
+			for _, v := range res.Violations {
+				fmt.Fprintf(a.stdout, "  %s\n", v)
+			}
+		case len(e.Constraints) > 0:
+			fmt.Fprintf(a.stdout, "%s: ok\n", e.ID)
+			for _, c := range e.Constraints {
+				fmt.Fprintf(a.stdout, "  ✓ %s\n", constraint.Describe(c))
+			}
+		}
+	}
+	if failed {
+		return exitError{code: 1}
+	}
+	return nil
+}
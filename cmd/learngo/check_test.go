@@ -0,0 +1,18 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCheckStubs(t *testing.T) {
+	code, stdout, stderr := runCLI(t, "check", "04", "06", "07")
+	if code != 0 {
+		t.Fatalf("exit code %d\nstdout: %s\nstderr: %s", code, stdout, stderr)
+	}
+	for _, want := range []string{"04-collections: ok", "no import of slices", "CountLines must not call os.ReadFile"} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("expected %q in:\n%s", want, stdout)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"text/tabwriter"
+
+	"github.com/imgarylai/learn-go/internal/runner"
+)
+
+// printCoverage writes how much of the code res's tests ran, and the
+// functions they didn't run in full. A function whose test passes
+// anyway is the interesting case: a branch nobody ran may not work.
+func printCoverage(w io.Writer, res runner.Result) {
+	c := res.Coverage
+	fmt.Fprintf(w, "  coverage %.1f%% of statements\n", c.Total)
+	passed := res.Passed()
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	for _, f := range c.Uncovered() {
+		note := ""
+		if slices.Contains(passed, "Test"+f.Func) {
+			note = "<- Test" + f.Func + " passes without running all of it"
+		}
+		fmt.Fprintf(tw, "    %s\t%.1f%%\t%s\t%s\n", f.Func, f.Percent, f.Where(), note)
+	}
+	tw.Flush()
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"runtime"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/imgarylai/learn-go/internal/registry"
+	"github.com/imgarylai/learn-go/internal/runner"
+	"github.com/imgarylai/learn-go/internal/solutions"
+)
+
+// flakeStats is how one test did across every run.
+type flakeStats struct {
+	name   string
+	runs   int
+	failed int
+	procs  []int // GOMAXPROCS of the failing runs
+}
+
+func (s flakeStats) flaky() bool { return s.failed > 0 && s.failed < s.runs }
+
+// runFlake implements `learngo flake [--runs N] [--race] [--solution] <exercise>`.
+//
+// Concurrency bugs often only show up on some runs: a data race or a
+// missing WaitGroup.Wait can pass nine times out of ten. flake runs the
+// tests over and over, with the race detector on and GOMAXPROCS picked
+// at random each time, and reports how often each test failed. A test
+// that fails sometimes but not always is flaky. --solution checks the
+// reference solution, for maintainers hunting timing-sensitive tests.
+func runFlake(a *app, args []string) error {
+	fs := flag.NewFlagSet("flake", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	runs := fs.Int("runs", 10, "how many times to run the tests")
+	race := fs.Bool("race", true, "run with the race detector (needs cgo)")
+	solution := fs.Bool("solution", false, "test the reference solution instead of your code")
+	if err := fs.Parse(args); err != nil || fs.NArg() != 1 || *runs < 1 {
+		return errUsage
+	}
+	e, ok := registry.Lookup(fs.Arg(0))
+	if !ok {
+		return fmt.Errorf("unknown exercise %q (see `learngo list`)", fs.Arg(0))
+	}
+
+	var extra []string
+	if *solution {
+		if e.Pack != "" {
+			return fmt.Errorf("%s comes from the %s pack, which has no reference solution", e.ID, e.Pack)
+		}
+		extra = append(extra, "-tags="+solutions.Tag)
+	}
+
+	stats, err := a.flakeRuns(e, *runs, *race, extra...)
+	if err != nil {
+		return err
+	}
+
+	tw := tabwriter.NewWriter(a.stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "TEST\tRUNS\tFAILED\tRATE\t")
+	flaky := 0
+	for _, s := range stats {
+		mark := ""
+		switch {
+		case s.flaky():
+			flaky++
+			mark = "<- flaky, failed with GOMAXPROCS " + joinInts(s.procs)
+		case s.failed > 0:
+			mark = "<- always fails"
+		}
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%.0f%%\t%s\n", s.name, s.runs, s.failed, float64(s.failed)*100/float64(s.runs), mark)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	if flaky > 0 {
+		fmt.Fprintf(a.stdout, "\n%d flaky test(s) in %d runs. Rerun one with: go test -race -count=20 -cpu=N -run 'TestName$' ./%s\n",
+			flaky, *runs, e.Dir())
+		return exitError{code: 1}
+	}
+	fmt.Fprintf(a.stdout, "\nNo flaky tests in %d runs.\n", *runs)
+	return nil
+}
+
+// flakeRuns runs e's tests n times and tallies the top-level tests, in
+// the order they first ran.
+func (a *app) flakeRuns(e registry.Exercise, n int, race bool, extra ...string) ([]flakeStats, error) {
+	seed := uint64(a.clock().UnixNano())
+	r := rand.New(rand.NewPCG(seed, seed))
+	// More Ps than cores is allowed and shakes out different
+	// interleavings, so go up to twice the core count.
+	maxProcs := 2 * runtime.NumCPU()
+
+	var stats []flakeStats
+	index := map[string]int{}
+	ctx := context.Background()
+	for range n {
+		procs := 1 + r.IntN(maxProcs)
+		// -count=1 skips the test cache, which would otherwise replay
+		// the first result every time.
+		args := append([]string{"-count=1", "-cpu=" + strconv.Itoa(procs)}, extra...)
+		if race {
+			args = append(args, "-race")
+		}
+		res, err := a.test(ctx, e.Dir(), args...)
+		if err != nil {
+			return nil, err
+		}
+		if res.BuildFailed {
+			return nil, fmt.Errorf("%s doesn't build:\n  %s", e.ID, strings.Join(res.BuildOutput, "\n  "))
+		}
+
+		for _, t := range res.Tests {
+			if strings.Contains(t.Name, "/") || t.Status == runner.Skip {
+				continue
+			}
+			i, ok := index[t.Name]
+			if !ok {
+				i = len(stats)
+				index[t.Name] = i
+				stats = append(stats, flakeStats{name: t.Name})
+			}
+			stats[i].runs++
+			if t.Status == runner.Fail {
+				stats[i].failed++
+				stats[i].procs = append(stats[i].procs, procs)
+			}
+		}
+	}
+	return stats, nil
+}
+
+func joinInts(nums []int) string {
+	s := make([]string, len(nums))
+	for i, n := range nums {
+		s[i] = strconv.Itoa(n)
+	}
+	return strings.Join(s, ", ")
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/imgarylai/learn-go/internal/runner"
+)
+
+func TestFlakeReportsIntermittentFailures(t *testing.T) {
+	a := newTestApp(t)
+	var calls [][]string
+	a.runTests = func(_ context.Context, _, dir string, args ...string) (runner.Result, error) {
+		calls = append(calls, args)
+		race := runner.Pass
+		if len(calls)%2 == 0 {
+			race = runner.Fail
+		}
+		return runner.Result{Tests: []runner.Test{
+			{Name: "TestStable", Status: runner.Pass},
+			{Name: "TestRacy", Status: race},
+			{Name: "TestRacy/sub", Status: race},
+			{Name: "TestBroken", Status: runner.Fail},
+		}}, nil
+	}
+
+	code, stdout, _ := runApp(t, a, "flake", "--runs", "4", "06")
+	if code != 1 {
+		t.Errorf("exit code: got %d, want 1", code)
+	}
+	if len(calls) != 4 {
+		t.Fatalf("ran the tests %d times, want 4", len(calls))
+	}
+	for _, args := range calls {
+		if !slices.Contains(args, "-race") || !slices.Contains(args, "-count=1") ||
+			!slices.ContainsFunc(args, func(s string) bool { return strings.HasPrefix(s, "-cpu=") }) {
+			t.Errorf("go test args: got %q", args)
+		}
+	}
+
+	lines := strings.Split(stdout, "\n")
+	find := func(name string) string {
+		for _, l := range lines {
+			if strings.HasPrefix(l, name+" ") {
+				return l
+			}
+		}
+		t.Errorf("no row for %s:\n%s", name, stdout)
+		return ""
+	}
+	if row := find("TestRacy"); !strings.Contains(row, "50%") || !strings.Contains(row, "flaky") {
+		t.Errorf("TestRacy row: %q", row)
+	}
+	if row := find("TestBroken"); !strings.Contains(row, "always fails") {
+		t.Errorf("TestBroken row: %q", row)
+	}
+	if row := find("TestStable"); strings.Contains(row, "<-") {
+		t.Errorf("TestStable row: %q", row)
+	}
+	if strings.Contains(stdout, "TestRacy/sub") {
+		t.Errorf("subtests should be folded into their parent:\n%s", stdout)
+	}
+	if !strings.Contains(stdout, "1 flaky test(s) in 4 runs") {
+		t.Errorf("missing summary:\n%s", stdout)
+	}
+}
+
+func TestFlakeOptions(t *testing.T) {
+	a := newTestApp(t)
+	var calls [][]string
+	a.runTests = func(_ context.Context, _, _ string, args ...string) (runner.Result, error) {
+		calls = append(calls, args)
+		return runner.Result{Tests: []runner.Test{{Name: "TestOK", Status: runner.Pass}}}, nil
+	}
+
+	code, stdout, stderr := runApp(t, a, "flake", "--runs=2", "--race=false", "--solution", "06-concurrency")
+	if code != 0 {
+		t.Fatalf("exit code %d, stderr %q", code, stderr)
+	}
+	if !strings.Contains(stdout, "No flaky tests in 2 runs.") {
+		t.Errorf("got:\n%s", stdout)
+	}
+	for _, args := range calls {
+		if slices.Contains(args, "-race") {
+			t.Errorf("--race=false still passed -race: %q", args)
+		}
+		if !slices.Contains(args, "-tags=solutions") {
+			t.Errorf("--solution didn't build with the solutions tag: %q", args)
+		}
+	}
+}
+
+func TestFlakeUsage(t *testing.T) {
+	for _, args := range [][]string{
+		{"flake"},
+		{"flake", "--runs", "0", "06"},
+		{"flake", "06", "07"},
+	} {
+		if code, _, _ := runCLI(t, args...); code != 2 {
+			t.Errorf("%q: got code %d, want 2", args, code)
+		}
+	}
+}
+
+func TestFlakeBuildFailure(t *testing.T) {
+	a := newTestApp(t)
+	fakeResults(a, map[string]runner.Result{
+		"exercises/06-concurrency": {BuildFailed: true, BuildOutput: []string{"concurrency.go:1:1: oops"}},
+	})
+	code, _, stderr := runApp(t, a, "flake", "06")
+	if code != 1 || !strings.Contains(stderr, "doesn't build") || !strings.Contains(stderr, "oops") {
+		t.Errorf("got code %d, stderr %q", code, stderr)
+	}
+}
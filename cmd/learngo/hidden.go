@@ -0,0 +1,110 @@
+package main
+
+import (
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/imgarylai/learn-go/internal/hidden"
+	"github.com/imgarylai/learn-go/internal/registry"
+)
+
+// runHidden implements `learngo hidden seal|list`, the instructor's
+// side of hidden tests (see package hidden).
+//
+//	learngo hidden seal [--out file] <dir>
+//	learngo hidden list <dir|file>
+//
+// seal encrypts a directory of hidden tests into one file, safe to
+// commit or hand to a grading server, and prints the key that opens it;
+// `learngo report --hidden file` grades with it once $LEARNGO_HIDDEN_KEY
+// holds that key. list shows which tests a directory or sealed file has.
+func runHidden(a *app, args []string) error {
+	if len(args) == 0 {
+		return errUsage
+	}
+	switch args[0] {
+	case "seal":
+		return a.sealHidden(args[1:])
+	case "list":
+		return a.listHidden(args[1:])
+	}
+	return errUsage
+}
+
+func (a *app) sealHidden(args []string) error {
+	fs := flag.NewFlagSet("hidden seal", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	out := fs.String("out", "hidden.sealed", "where to write the sealed tests")
+	if err := fs.Parse(args); err != nil || fs.NArg() != 1 {
+		return errUsage
+	}
+	set, err := loadHidden(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	// Reuse the key already set, so resealing after an edit doesn't mean
+	// handing out a new one.
+	key := hidden.NewKey()
+	if env := os.Getenv(hidden.KeyEnv); env != "" {
+		if key, err = hidden.ParseKey(env); err != nil {
+			return err
+		}
+	}
+	data, err := hidden.Seal(set, key)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(*out, data, 0o644); err != nil {
+		return err
+	}
+	fmt.Fprintf(a.stdout, "Sealed the hidden tests of %s into %s.\n", strings.Join(set.IDs(), ", "), *out)
+	fmt.Fprintf(a.stdout, "Grade with them by setting, and keeping to yourself:\n\n  %s=%s\n", hidden.KeyEnv, hex.EncodeToString(key))
+	return nil
+}
+
+func (a *app) listHidden(args []string) error {
+	if len(args) != 1 {
+		return errUsage
+	}
+	set, err := loadHidden(args[0])
+	if err != nil {
+		return err
+	}
+	for _, id := range set.IDs() {
+		tests, err := set.Tests(id)
+		if err != nil {
+			return fmt.Errorf("%s: %w", id, err)
+		}
+		fmt.Fprintf(a.stdout, "%s\n", id)
+		for _, name := range tests {
+			fmt.Fprintf(a.stdout, "  %s\n", name)
+		}
+	}
+	return nil
+}
+
+// loadHidden reads a directory or sealed file of hidden tests and keys
+// it by full exercise ID, so its folders can be named "04" as on the
+// command line.
+func loadHidden(path string) (hidden.Set, error) {
+	set, err := hidden.Load(path)
+	if err != nil {
+		return nil, err
+	}
+	out := hidden.Set{}
+	for _, id := range set.IDs() {
+		e, ok := registry.Lookup(id)
+		if !ok {
+			return nil, fmt.Errorf("hidden tests for unknown exercise %q", id)
+		}
+		if _, dup := out[e.ID]; dup {
+			return nil, fmt.Errorf("hidden tests for %s are listed twice", e.ID)
+		}
+		out[e.ID] = set[id]
+	}
+	return out, nil
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/imgarylai/learn-go/internal/hints"
+	"github.com/imgarylai/learn-go/internal/i18n"
+	"github.com/imgarylai/learn-go/internal/registry"
+)
+
+// runHint implements `learngo hint [--lang l] [--tests] <exercise>`.
+// Hints go from gentle to specific, so read them one at a time if you
+// only need a nudge. Each time is counted in the progress file.
+//
+// The built-in exercises' prompt and hints come in English, Traditional
+// Chinese and Japanese; --lang picks one, and otherwise LEARNGO_LANG or
+// the locale (LANG) does. Hints from an exercise pack are shown as the
+// pack wrote them.
+//
+// --tests runs the tests first and adds hints about how they fail: a
+// nil map, an index out of range, a deadlock... (see hints.Failures).
+// That run isn't recorded as one of yours.
+func runHint(a *app, args []string) error {
+	fs := flag.NewFlagSet("hint", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	langFlag := fs.String("lang", "", "language: "+strings.Join(i18n.Languages(), ", "))
+	tests := fs.Bool("tests", false, "run the tests and add hints about how they fail")
+	if err := fs.Parse(args); err != nil || fs.NArg() != 1 {
+		return errUsage
+	}
+	lang := i18n.Detect()
+	if *langFlag != "" {
+		var ok bool
+		if lang, ok = i18n.Match(*langFlag); !ok {
+			return fmt.Errorf("no hints in %q (want one of %s)", *langFlag, strings.Join(i18n.Languages(), ", "))
+		}
+	}
+	e, ok := registry.Lookup(fs.Arg(0))
+	if !ok {
+		return fmt.Errorf("unknown exercise %q (see `learngo list`)", fs.Arg(0))
+	}
+
+	providers := hints.Chain{hints.Static{}}
+	req := hints.Request{Exercise: e, Lang: lang}
+	ctx := context.Background()
+	if *tests {
+		res, err := a.test(ctx, e.Dir())
+		if err != nil {
+			return err
+		}
+		req.Result = &res
+		providers = append(providers, hints.Failures{})
+	}
+	found, err := providers.Hints(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	if e.Pack == "" {
+		if prompt := i18n.Prompt(lang, e.ID); prompt != "" {
+			fmt.Fprintf(a.stdout, "%s\n\n", prompt)
+		}
+	}
+	if len(found) == 0 {
+		fmt.Fprintln(a.stdout, i18n.T(lang, "hint.none", e.ID))
+		return nil
+	}
+	fmt.Fprintln(a.stdout, i18n.T(lang, "hint.title", e.ID))
+	for i, h := range found {
+		if h.Test != "" {
+			fmt.Fprintf(a.stdout, "  %d. %s: %s\n", i+1, h.Test, h.Text)
+		} else {
+			fmt.Fprintf(a.stdout, "  %d. %s\n", i+1, h.Text)
+		}
+	}
+
+	// Counted for `learngo serve`, so you can see where you needed help.
+	prog, path, err := a.loadProgress()
+	if err != nil {
+		return err
+	}
+	prog.Get(e.ID).Hints++
+	return prog.Save(path)
+}
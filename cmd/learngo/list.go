@@ -0,0 +1,73 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/imgarylai/learn-go/internal/progress"
+	"github.com/imgarylai/learn-go/internal/registry"
+)
+
+// runList implements `learngo list [--tag topic] [--difficulty level]`.
+// --topic is the same as --tag, matching `learngo run`.
+// DONE is the share of an exercise's tests that passed the last time
+// `learngo run` or `test-all` ran them.
+func runList(a *app, args []string) error {
+	fs := flag.NewFlagSet("list", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	var topic string
+	fs.StringVar(&topic, "tag", "", "only exercises with this topic")
+	fs.StringVar(&topic, "topic", "", "only exercises with this topic")
+	difficulty := fs.String("difficulty", "", "beginner, intermediate or advanced")
+	if err := fs.Parse(args); err != nil || fs.NArg() > 0 {
+		return errUsage
+	}
+
+	filter, err := parseFilter(topic, *difficulty)
+	if err != nil {
+		return err
+	}
+	matches := registry.Select(filter)
+	if len(matches) == 0 {
+		fmt.Fprintln(a.stdout, "no exercises match")
+		return nil
+	}
+
+	prog, _, err := a.loadProgress()
+	if err != nil {
+		return err
+	}
+
+	tw := tabwriter.NewWriter(a.stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "EXERCISE\tDIFFICULTY\tDONE\tTOPICS")
+	for _, e := range matches {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", e.ID, e.Difficulty, completion(prog, e.ID), strings.Join(e.Topics, ", "))
+	}
+	return tw.Flush()
+}
+
+// parseFilter builds the filter behind the --topic and --difficulty
+// flags of list and run.
+func parseFilter(topic, difficulty string) (registry.Filter, error) {
+	filter := registry.Filter{Topic: topic}
+	if difficulty != "" {
+		d, err := registry.ParseDifficulty(difficulty)
+		if err != nil {
+			return registry.Filter{}, err
+		}
+		filter.Difficulty = d
+	}
+	return filter, nil
+}
+
+// completion formats id's completion for list, "-" if it was never run.
+func completion(prog *progress.File, id string) string {
+	share, ok := prog.Completion(id)
+	if !ok {
+		return "-"
+	}
+	return fmt.Sprintf("%.0f%%", share*100)
+}
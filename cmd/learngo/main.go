@@ -0,0 +1,354 @@
+// Command learngo helps you work through the exercises in this repo.
+//
+// Usage:
+//
+//	learngo <command> [flags] [args]
+//
+// Run `learngo help` for the list of commands.
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/imgarylai/learn-go/internal/achievements"
+	"github.com/imgarylai/learn-go/internal/bench"
+	"github.com/imgarylai/learn-go/internal/pack"
+	"github.com/imgarylai/learn-go/internal/progress"
+	"github.com/imgarylai/learn-go/internal/registry"
+	"github.com/imgarylai/learn-go/internal/runner"
+)
+
+// command is one learngo subcommand, like `git status` or `npm run`.
+type command struct {
+	name    string
+	usage   string
+	summary string
+	run     func(a *app, args []string) error
+}
+
+// commands is filled in by init so that `help` can list itself.
+var commands []command
+
+func init() {
+	commands = []command{
+		{"list", "list [--topic topic] [--difficulty level]", "List exercises with their topics and difficulty", runList},
+		{"next", "next", "Suggest the next unlocked exercise", runNext},
+		{"path", "path <exercise>", "Show what to finish before an exercise, and what it unlocks", runPath},
+		{"start", "start <exercise>", "Mark an exercise as started", runStart},
+		{"pause", "pause <exercise>", "Stop the clock on an exercise without finishing it", runPause},
+		{"done", "done <exercise>", "Mark an exercise as done", runDone},
+		{"verify", "verify <exercise>", "Check the tests are unmodified and pass, then mark an exercise done", runVerify},
+		{"hint", "hint [--lang l] [--tests] <exercise>", "Show hints for an exercise", runHint},
+		{"quiz", "quiz <exercise>", "Answer a short multiple-choice quiz on an exercise's ideas", runQuiz},
+		{"check", "check [exercise...]", "Check solutions follow each exercise's rules", runCheck},
+		{"diff", "diff <exercise>", "Show your changes against the original stub", runDiff},
+		{"reset", "reset [--no-backup] <exercise> [file...]", "Back up your work and restore the original stub", runReset},
+		{"migrate", "migrate [--dry-run] [--stash] [exercise...]", "Merge a new version of the stubs into your work after a git pull", runMigrate},
+		{"run", "run [-v] [--race] [--cover] [--topic topic] [--difficulty level] [exercise...]", "Run the tests of one exercise, or all of them in order", runRun},
+		{"watch", "watch [--debounce d] <exercise>", "Rerun an exercise's tests every time you save", runWatch},
+		{"test-all", "test-all", "Test every exercise and print a summary", runTestAll},
+		{"report", "report [--format json|junit|tap|rubric] [--weights file] [--hidden dir|file] [--cover] [--out file] [exercise...]", "Export test results as JSON, JUnit XML, TAP or a graded rubric", runReport},
+		{"stats", "stats", "Show time spent per exercise", runStats},
+		{"badges", "badges", "Show the badges you've earned and how to earn the rest", runBadges},
+		{"progress", "progress export [--name name] [--out file] | import [--replace] <file>", "Export your progress to a file, or import one", runProgress},
+		{"serve", "serve [--addr host:port]", "Show progress and run tests from a dashboard in the browser", runServe},
+		{"tui", "tui", "Browse and test exercises in an interactive terminal UI", runTUI},
+		{"bench", "bench [--bench regexp] [--check | --solution] <exercise>", "Run benchmarks and compare with the previous run or the baseline", runBench},
+		{"profile", "profile [--bench regexp] [--top n] [--out dir] [--solution] <exercise>", "Profile an exercise's benchmarks and show the CPU and memory hot spots", runProfile},
+		{"certificate", "certificate [--name name] [--out file.svg]", "Render a completion certificate once everything passes", runCertificate},
+		{"submit", "submit [--server url] [--handle name]", "Post your scores to a classroom leaderboard", runSubmit},
+		{"flake", "flake [--runs N] [--race=false] [--solution] <exercise>", "Rerun an exercise's tests to find intermittent failures", runFlake},
+		{"variants", "variants <exercise> [func...]", "Benchmark the different reference implementations of a func against each other", runVariants},
+		{"mutate", "mutate [--parallel n] [exercise...]", "Find bugs the exercise tests miss by mutating reference solutions", runMutate},
+		{"similarity", "similarity [--exercise id] [--base dir] [--min score] <dir>...", "Compare student submissions for instructors", runSimilarity},
+		{"hidden", "hidden seal [--out file] <dir> | list <dir|file>", "Seal an instructor's hidden tests for grading, or list them", runHidden},
+		{"install-pack", "install-pack [--force] <module>[@version]", "Install an exercise pack published as a Go module", runInstallPack},
+		{"help", "help", "Show this help", runHelp},
+	}
+}
+
+// app carries everything a command needs. Tests swap the reader,
+// writers and root instead of touching os.Stdin, os.Stdout or the real
+// checkout.
+type app struct {
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+	root   string // repository root; found lazily when empty
+
+	progressPath     string // progress file; progress.DefaultPath() when empty
+	benchHistoryPath string // bench history; bench.DefaultHistoryPath() when empty
+	backupDir        string // where reset saves work; stubs.DefaultBackupDir() when empty
+
+	// packPaths is where to look for exercise packs; nil means
+	// pack.DefaultPaths(). Tests set it to an empty slice.
+	packPaths []string
+
+	// runTests runs one exercise's tests. nil means runner.Run;
+	// tests plug in a fake so they don't shell out to `go test`.
+	runTests func(ctx context.Context, root, dir string, args ...string) (runner.Result, error)
+	// runBench is the same idea for benchmarks; nil means bench.Run.
+	runBench func(ctx context.Context, root, dir, pattern string, args ...string) ([]bench.Result, error)
+	// readCoverage summarizes a coverage profile; nil means
+	// runner.ReadCoverage.
+	readCoverage func(ctx context.Context, root, dir, profile string) (*runner.Coverage, error)
+
+	// knownMutants lists the mutants known to survive, by exercise; nil
+	// means mutate.Known(). Tests set it to an empty map.
+	knownMutants map[string][]string
+
+	// download fetches an exercise pack published as a Go module; nil
+	// means pack.Download.
+	download func(ctx context.Context, module string) (dir, version string, err error)
+
+	// now is the clock; nil means time.Now.
+	now func() time.Time
+}
+
+// errUsage signals a bad invocation; main exits with code 2.
+var errUsage = errors.New("usage error")
+
+// exitError lets a command choose its exit code without printing an error.
+type exitError struct{ code int }
+
+func (e exitError) Error() string { return fmt.Sprintf("exit status %d", e.code) }
+
+func main() {
+	a := &app{stdin: os.Stdin, stdout: os.Stdout, stderr: os.Stderr}
+	os.Exit(a.run(os.Args[1:]))
+}
+
+// run dispatches to a subcommand and converts its error into an exit code.
+func (a *app) run(args []string) int {
+	if len(args) == 0 {
+		runHelp(a, nil)
+		return 2
+	}
+
+	unregister := a.loadPacks()
+	defer unregister()
+
+	for _, c := range commands {
+		if c.name != args[0] {
+			continue
+		}
+		err := c.run(a, args[1:])
+		var exit exitError
+		switch {
+		case err == nil:
+			return 0
+		case errors.As(err, &exit):
+			return exit.code
+		case errors.Is(err, errUsage):
+			fmt.Fprintf(a.stderr, "usage: learngo %s\n", c.usage)
+			return 2
+		default:
+			fmt.Fprintf(a.stderr, "learngo %s: %v\n", c.name, err)
+			return 1
+		}
+	}
+
+	fmt.Fprintf(a.stderr, "learngo: unknown command %q\n\n", args[0])
+	runHelp(a, nil)
+	return 2
+}
+
+func runHelp(a *app, _ []string) error {
+	fmt.Fprintln(a.stdout, "learngo - run and track the learn-go exercises")
+	fmt.Fprintln(a.stdout)
+	fmt.Fprintln(a.stdout, "Commands:")
+	for _, c := range commands {
+		fmt.Fprintf(a.stdout, "  %-12s %s\n", c.name, c.summary)
+	}
+	return nil
+}
+
+// rootDir returns the repository root. LEARNGO_ROOT wins; otherwise we
+// walk up from the working directory until we find exercises/ and go.mod,
+// the same way `git` finds .git.
+func (a *app) rootDir() (string, error) {
+	if a.root != "" {
+		return a.root, nil
+	}
+	if env := os.Getenv("LEARNGO_ROOT"); env != "" {
+		a.root = env
+		return a.root, nil
+	}
+
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	for {
+		if isDir(filepath.Join(dir, "exercises")) && isFile(filepath.Join(dir, "go.mod")) {
+			a.root = dir
+			return dir, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", errors.New("not inside the learn-go repository (set LEARNGO_ROOT)")
+		}
+		dir = parent
+	}
+}
+
+// loadPacks registers the exercises of every pack it can find, so the
+// commands see them like built-in ones. A broken pack is reported and
+// skipped rather than making learngo unusable. The returned func
+// removes the registered exercises again.
+func (a *app) loadPacks() (unregister func()) {
+	paths, err := a.packDirs()
+	if err != nil {
+		fmt.Fprintf(a.stderr, "learngo: exercise packs: %v\n", err)
+		return func() {}
+	}
+
+	packs, err := pack.Discover(paths)
+	if err != nil {
+		fmt.Fprintf(a.stderr, "learngo: skipping exercise pack: %v\n", err)
+	}
+	var undo []func()
+	for _, p := range packs {
+		u, err := registry.Register(p.RegistryExercises()...)
+		if err != nil {
+			fmt.Fprintf(a.stderr, "learngo: skipping exercise pack %s: %v\n", p.Name, err)
+			continue
+		}
+		undo = append(undo, u)
+	}
+	return func() {
+		for _, u := range slices.Backward(undo) {
+			u()
+		}
+	}
+}
+
+// packDirs returns where to look for exercise packs.
+func (a *app) packDirs() ([]string, error) {
+	if a.packPaths != nil {
+		return a.packPaths, nil
+	}
+	return pack.DefaultPaths()
+}
+
+// clock returns the current time.
+func (a *app) clock() time.Time {
+	if a.now != nil {
+		return a.now()
+	}
+	return time.Now()
+}
+
+// test runs the tests of the exercise in dir, within the time and
+// memory limits of runner.LimitsFromEnv.
+func (a *app) test(ctx context.Context, dir string, args ...string) (runner.Result, error) {
+	root, err := a.rootDir()
+	if err != nil {
+		return runner.Result{}, err
+	}
+	limits, err := runner.LimitsFromEnv()
+	if err != nil {
+		return runner.Result{}, err
+	}
+	args = append(limits.Args(), args...)
+	if a.runTests != nil {
+		return a.runTests(ctx, root, dir, args...)
+	}
+	return runner.Run(ctx, root, dir, args...)
+}
+
+// testCovered is test with -coverprofile, filling in res.Coverage when
+// the exercise builds.
+func (a *app) testCovered(ctx context.Context, dir string, args ...string) (runner.Result, error) {
+	f, err := os.CreateTemp("", "learngo-cover-*.out")
+	if err != nil {
+		return runner.Result{}, err
+	}
+	f.Close()
+	defer os.Remove(f.Name())
+
+	res, err := a.test(ctx, dir, append(runner.CoverArgs(f.Name()), args...)...)
+	if err != nil || res.BuildFailed {
+		return res, err
+	}
+	root, err := a.rootDir()
+	if err != nil {
+		return res, err
+	}
+	read := runner.ReadCoverage
+	if a.readCoverage != nil {
+		read = a.readCoverage
+	}
+	res.Coverage, err = read(ctx, root, dir, f.Name())
+	return res, err
+}
+
+// benchmark runs the benchmarks matching pattern in dir.
+func (a *app) benchmark(ctx context.Context, dir, pattern string, args ...string) ([]bench.Result, error) {
+	root, err := a.rootDir()
+	if err != nil {
+		return nil, err
+	}
+	if a.runBench != nil {
+		return a.runBench(ctx, root, dir, pattern, args...)
+	}
+	return bench.Run(ctx, root, dir, pattern, args...)
+}
+
+// loadProgress reads the progress file and returns it with its path,
+// so a command can update and Save it.
+func (a *app) loadProgress() (*progress.File, string, error) {
+	path, err := a.progressFile()
+	if err != nil {
+		return nil, "", err
+	}
+	f, err := progress.Load(path)
+	return f, path, err
+}
+
+// progressFile returns the path of the progress file. Other state, like
+// submit's handle, is kept in the same directory.
+func (a *app) progressFile() (string, error) {
+	if a.progressPath != "" {
+		return a.progressPath, nil
+	}
+	return progress.DefaultPath()
+}
+
+// recordRun notes which of id's tests passed, for the completion
+// percentage `learngo list` shows, and counts the run for `learngo
+// stats`. A build failure says nothing about which functions work, so
+// it leaves the last good run in place.
+func recordRun(f *progress.File, id string, res runner.Result, now time.Time) {
+	if res.BuildFailed {
+		return
+	}
+	pass, fail, _ := res.Counts()
+	e := f.Get(id)
+	e.RecordTests(res.Passed(), pass+fail)
+	e.NoteRun(now)
+}
+
+// announce congratulates you on badges you've just earned.
+func (a *app) announce(badges []achievements.Badge) {
+	for _, b := range badges {
+		fmt.Fprintf(a.stdout, "\nBadge earned: %s (%s)\n", b.Title, b.Description)
+	}
+}
+
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
+
+func isFile(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// newTestApp returns an app rooted at this checkout with a throwaway
+// state directory, so tests never touch ~/.learn-go.
+func newTestApp(t *testing.T) *app {
+	t.Helper()
+	dir := t.TempDir()
+	return &app{
+		stdin:            strings.NewReader(""),
+		stdout:           &bytes.Buffer{},
+		stderr:           &bytes.Buffer{},
+		root:             "../..",
+		progressPath:     filepath.Join(dir, "progress.json"),
+		benchHistoryPath: filepath.Join(dir, "bench-history.json"),
+		backupDir:        filepath.Join(dir, "backups"),
+		packPaths:        []string{},
+		knownMutants:     map[string][]string{},
+	}
+}
+
+// runApp runs a in-process and returns what it printed.
+func runApp(t *testing.T, a *app, args ...string) (code int, stdout, stderr string) {
+	t.Helper()
+	out, errOut := a.stdout.(*bytes.Buffer), a.stderr.(*bytes.Buffer)
+	out.Reset()
+	errOut.Reset()
+	code = a.run(args)
+	return code, out.String(), errOut.String()
+}
+
+// runCLI runs learngo in-process and captures its output.
+func runCLI(t *testing.T, args ...string) (code int, stdout, stderr string) {
+	t.Helper()
+	return runApp(t, newTestApp(t), args...)
+}
+
+func TestUnknownCommand(t *testing.T) {
+	code, _, stderr := runCLI(t, "frobnicate")
+	if code != 2 {
+		t.Errorf("exit code: got %d, want 2", code)
+	}
+	if !strings.Contains(stderr, "unknown command") {
+		t.Errorf("stderr: got %q", stderr)
+	}
+}
+
+func TestListFilters(t *testing.T) {
+	code, stdout, _ := runCLI(t, "list", "--tag", "concurrency", "--difficulty", "intermediate")
+	if code != 0 {
+		t.Fatalf("exit code: got %d, want 0", code)
+	}
+	if !strings.Contains(stdout, "06-concurrency") {
+		t.Errorf("expected 06-concurrency in output:\n%s", stdout)
+	}
+	if strings.Contains(stdout, "01-basics") {
+		t.Errorf("01-basics should be filtered out:\n%s", stdout)
+	}
+}
+
+func TestListAll(t *testing.T) {
+	_, stdout, _ := runCLI(t, "list")
+	for _, id := range []string{"01-basics", "08-data-processing"} {
+		if !strings.Contains(stdout, id) {
+			t.Errorf("expected %s in output:\n%s", id, stdout)
+		}
+	}
+}
+
+func TestListBadDifficulty(t *testing.T) {
+	code, _, stderr := runCLI(t, "list", "--difficulty", "expert")
+	if code != 1 || !strings.Contains(stderr, "unknown difficulty") {
+		t.Errorf("got code %d, stderr %q", code, stderr)
+	}
+}
@@ -0,0 +1,238 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/imgarylai/learn-go/internal/migrate"
+	"github.com/imgarylai/learn-go/internal/registry"
+	"github.com/imgarylai/learn-go/internal/stubs"
+)
+
+// runMigrate implements `learngo migrate [--dry-run] [--stash]
+// [exercise...]`.
+//
+// After a `git pull` brings a new version of an exercise, it merges the
+// changes to the stubs into your files one declaration at a time (see
+// package migrate), so your solutions survive and the functions you
+// haven't started become the new stubs. With no arguments it migrates
+// every exercise you're behind on.
+//
+// git refuses to pull over files you changed and didn't commit. --stash
+// is for that case: `git stash`, `git pull`, then `learngo migrate
+// --stash` reads your work back from the stash instead of from disk.
+func runMigrate(a *app, args []string) error {
+	flags := flag.NewFlagSet("migrate", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	dryRun := flags.Bool("dry-run", false, "report what would change without writing anything")
+	stash := flags.Bool("stash", false, "read your files from the latest `git stash` instead of the working tree")
+	if err := flags.Parse(args); err != nil {
+		return errUsage
+	}
+	root, err := a.rootDir()
+	if err != nil {
+		return err
+	}
+	prog, progPath, err := a.loadProgress()
+	if err != nil {
+		return err
+	}
+	from := func(id string) int {
+		if pe := prog.Exercises[id]; pe != nil {
+			return max(pe.Version, 1)
+		}
+		return 1
+	}
+
+	var todo []registry.Exercise
+	if flags.NArg() == 0 {
+		for _, e := range registry.All() {
+			if e.Pack == "" && from(e.ID) < e.CurrentVersion() {
+				todo = append(todo, e)
+			}
+		}
+		if len(todo) == 0 {
+			fmt.Fprintln(a.stdout, "Every exercise is up to date.")
+			return nil
+		}
+	}
+	for _, arg := range flags.Args() {
+		e, ok := registry.Lookup(arg)
+		if !ok {
+			return fmt.Errorf("unknown exercise %q (see `learngo list`)", arg)
+		}
+		if e.Pack != "" {
+			return fmt.Errorf("%s comes from the %s pack, which has no stored stubs (try git in %s)", e.ID, e.Pack, e.Path)
+		}
+		todo = append(todo, e)
+	}
+
+	conflicts, testsChanged := false, false
+	for i, e := range todo {
+		if i > 0 {
+			fmt.Fprintln(a.stdout)
+		}
+		v := from(e.ID)
+		if v >= e.CurrentVersion() {
+			fmt.Fprintf(a.stdout, "%s is up to date (version %d).\n", e.ID, v)
+			continue
+		}
+		base, err := stubs.FilesAt(e.ID, v)
+		if errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("%s: version %d of the stubs isn't stored, so there's nothing to merge from; `learngo reset` starts over", e.ID, v)
+		}
+		if err != nil {
+			return err
+		}
+		theirs, err := stubs.Files(e.ID)
+		if err != nil {
+			return err
+		}
+		readMine := func(name string) ([]byte, error) {
+			return os.ReadFile(filepath.Join(e.DirIn(root), filepath.FromSlash(name)))
+		}
+		if *stash {
+			readMine = func(name string) ([]byte, error) {
+				return gitStashed(root, path.Join(filepath.ToSlash(e.Dir()), name))
+			}
+		}
+
+		fmt.Fprintf(a.stdout, "%s: version %d -> %d\n", e.ID, v, e.CurrentVersion())
+		merged, conflicted, err := a.migrateFiles(e, base, theirs, readMine)
+		if err != nil {
+			return err
+		}
+		conflicts = conflicts || conflicted
+		if *dryRun {
+			continue
+		}
+		for _, f := range merged {
+			p := filepath.Join(e.DirIn(root), filepath.FromSlash(f.Name))
+			if f.Data == nil {
+				err = os.Remove(p)
+			} else {
+				err = writeFileAll(p, f.Data)
+			}
+			if err != nil && !errors.Is(err, fs.ErrNotExist) {
+				return err
+			}
+		}
+		prog.Get(e.ID).Version = e.CurrentVersion()
+		// The tests aren't merged: they come with the pull, and have to
+		// be exactly the ones that shipped.
+		if err := a.checkTests(e); err != nil {
+			var exit exitError
+			if !errors.As(err, &exit) {
+				return err
+			}
+			testsChanged = true
+		}
+	}
+
+	if *dryRun {
+		fmt.Fprintln(a.stdout, "\nNothing was written (--dry-run).")
+	} else if err := prog.Save(progPath); err != nil {
+		return err
+	}
+	if *stash && !*dryRun {
+		fmt.Fprintln(a.stdout, "\nYour work is merged in; once you're happy with it, `git stash drop`.")
+	}
+	if conflicts {
+		fmt.Fprintln(a.stdout, "\nA conflict keeps your version: apply the upstream change shown above by hand, then `learngo run` the exercise.")
+	}
+	if conflicts || testsChanged {
+		return exitError{code: 1}
+	}
+	return nil
+}
+
+// migrateFiles merges every stub file of e from base to theirs into
+// your copy, read with readMine, and prints what happened to each
+// declaration. It returns the files to write, nil Data meaning delete,
+// and whether there was a conflict.
+func (a *app) migrateFiles(e registry.Exercise, base, theirs []stubs.File, readMine func(name string) ([]byte, error)) ([]stubs.File, bool, error) {
+	old := map[string][]byte{}
+	for _, f := range base {
+		old[f.Name] = f.Data
+	}
+	var names []string
+	for _, f := range slices.Concat(base, theirs) {
+		if !slices.Contains(names, f.Name) {
+			names = append(names, f.Name)
+		}
+	}
+	slices.Sort(names)
+
+	var out []stubs.File
+	conflicted := false
+	for _, name := range names {
+		i := slices.IndexFunc(theirs, func(f stubs.File) bool { return f.Name == name })
+		mine, err := readMine(name)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, false, err
+		}
+		switch {
+		case i < 0 && mine == nil:
+			// Gone upstream, and you'd already deleted it.
+		case i < 0 && bytes.Equal(mine, old[name]):
+			out = append(out, stubs.File{Name: name})
+			fmt.Fprintf(a.stdout, "  %s: removed, it's no longer part of the exercise\n", name)
+		case i < 0:
+			conflicted = true
+			fmt.Fprintf(a.stdout, "  %s: conflict, it's no longer part of the exercise but you changed it; it's kept\n", name)
+		case mine == nil:
+			out = append(out, theirs[i])
+			fmt.Fprintf(a.stdout, "  %s: added\n", name)
+		default:
+			merged, changes, err := migrate.Merge(old[name], mine, theirs[i].Data)
+			if err != nil {
+				return nil, false, fmt.Errorf("%s: %w", path.Join(filepath.ToSlash(e.Dir()), name), err)
+			}
+			if !bytes.Equal(merged, mine) {
+				out = append(out, stubs.File{Name: name, Data: merged})
+			}
+			for _, c := range changes {
+				fmt.Fprintf(a.stdout, "  %s: %s\n", name, c)
+				if c.Status == migrate.Conflict {
+					conflicted = true
+					for _, line := range strings.Split(strings.TrimSuffix(c.Upstream, "\n"), "\n") {
+						fmt.Fprintf(a.stdout, "      %s\n", line)
+					}
+				}
+			}
+		}
+	}
+	if len(out) == 0 && !conflicted {
+		fmt.Fprintln(a.stdout, "  nothing to change: your files already follow the new stubs")
+	}
+	return out, conflicted, nil
+}
+
+// gitStashed reads a file as it is in the latest stash, fs.ErrNotExist
+// if it isn't there.
+func gitStashed(root, name string) ([]byte, error) {
+	cmd := exec.Command("git", "show", "stash@{0}:"+name)
+	cmd.Dir = root
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	var exit *exec.ExitError
+	if errors.As(err, &exit) {
+		msg := stderr.String()
+		if strings.Contains(msg, "does not exist") || strings.Contains(msg, "exists on disk, but not in") {
+			return nil, fs.ErrNotExist
+		}
+		return nil, fmt.Errorf("git show stash@{0}:%s: %s", name, strings.TrimSpace(msg))
+	}
+	return out, err
+}
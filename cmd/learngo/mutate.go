@@ -0,0 +1,318 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/imgarylai/learn-go/internal/mutate"
+	"github.com/imgarylai/learn-go/internal/registry"
+	"github.com/imgarylai/learn-go/internal/runner"
+	"github.com/imgarylai/learn-go/internal/solutions"
+	"github.com/imgarylai/learn-go/internal/stubs"
+)
+
+// solutionFile is the name the reference solution gets in the overlay.
+const solutionFile = "learngo_solution.go"
+
+// runMutate implements `learngo mutate [exercise...]`, a tool for
+// maintainers: it plants small bugs in each reference solution and
+// lists the ones the exercise's tests don't catch. With no exercises it
+// checks all of them.
+//
+// Survivors already listed in internal/mutate/survivors.txt are shown
+// but don't fail the run; new ones do, and so do listed ones that no
+// longer survive, so the list only ever shrinks. So does an exercise
+// whose reference solution doesn't build or pass its own tests. That
+// makes the command usable as a CI check as exercises are added.
+//
+// Nothing on disk changes. The stub, the solution and each mutant are
+// swapped in with `go test -overlay`, so your own work is left alone.
+func runMutate(a *app, args []string) error {
+	fset := flag.NewFlagSet("mutate", flag.ContinueOnError)
+	fset.SetOutput(io.Discard)
+	parallel := fset.Int("parallel", runtime.GOMAXPROCS(0), "mutants to test at once")
+	if err := fset.Parse(args); err != nil || *parallel < 1 {
+		return errUsage
+	}
+
+	exercises := registry.All()
+	if fset.NArg() > 0 {
+		exercises = nil
+		for _, id := range fset.Args() {
+			e, ok := registry.Lookup(id)
+			if !ok {
+				return fmt.Errorf("unknown exercise %q (see `learngo list`)", id)
+			}
+			exercises = append(exercises, e)
+		}
+	}
+	root, err := a.rootDir()
+	if err != nil {
+		return err
+	}
+	known := a.knownMutants
+	if known == nil {
+		known = mutate.Known()
+	}
+
+	ctx := context.Background()
+	var unlisted []string // survivors to list, or better, kill
+	stale, broken := false, false
+	for _, e := range exercises {
+		if e.Pack != "" {
+			fmt.Fprintf(a.stdout, "%s: skipped, exercise packs have no stored stubs\n", e.ID)
+			continue
+		}
+		rep, err := a.mutateExercise(ctx, root, e, *parallel)
+		if err != nil {
+			// A solution that doesn't build or pass its tests would
+			// otherwise exempt the exercise from the check.
+			fmt.Fprintf(a.stdout, "%s: %v\n", e.ID, err)
+			broken = true
+			continue
+		}
+
+		fmt.Fprintf(a.stdout, "%s: %d mutants, %d killed, %d survived", e.ID, rep.total, rep.killed, len(rep.survived))
+		if rep.invalid > 0 {
+			fmt.Fprintf(a.stdout, ", %d didn't compile", rep.invalid)
+		}
+		if n := rep.killed + len(rep.survived); n > 0 {
+			fmt.Fprintf(a.stdout, " (score %d%%)", rep.killed*100/n)
+		}
+		fmt.Fprintln(a.stdout)
+		var ids []string
+		for _, m := range rep.survived {
+			ids = append(ids, m.ID)
+			if slices.Contains(known[e.ID], m.ID) {
+				fmt.Fprintf(a.stdout, "  known     %s\n", m)
+				continue
+			}
+			fmt.Fprintf(a.stdout, "  survived  %s\n", m)
+			unlisted = append(unlisted, e.ID+" "+m.ID)
+		}
+		for _, id := range known[e.ID] {
+			if !slices.Contains(ids, id) {
+				fmt.Fprintf(a.stdout, "  killed    %s, which is listed as a known survivor\n", id)
+				stale = true
+			}
+		}
+	}
+
+	if stale {
+		fmt.Fprintf(a.stdout, "\nThe tests now catch mutants listed in %s: take them off the list.\n", mutate.KnownFile)
+	}
+	if len(unlisted) > 0 {
+		fmt.Fprintf(a.stdout, "\nAdd tests that catch the new survivors. If one doesn't change what the code does, list it in %s instead:\n\n", mutate.KnownFile)
+		for _, line := range unlisted {
+			fmt.Fprintf(a.stdout, "%s\n", line)
+		}
+	}
+	if stale || broken || len(unlisted) > 0 {
+		return exitError{code: 1}
+	}
+	return nil
+}
+
+// mutationReport is what mutating one exercise found.
+type mutationReport struct {
+	total    int
+	killed   int
+	invalid  int             // mutants that didn't build; they say nothing about the tests
+	survived []mutate.Mutant // in source order
+}
+
+// mutateExercise tests every mutant of e's reference solution. An
+// exercise with subpackages has a solution.go.txt in each of them.
+func (a *app) mutateExercise(ctx context.Context, root string, e registry.Exercise, parallel int) (mutationReport, error) {
+	dir, err := filepath.Abs(e.DirIn(root))
+	if err != nil {
+		return mutationReport{}, err
+	}
+	refs, err := findSolutions(dir)
+	if err != nil {
+		return mutationReport{}, err
+	}
+	if len(refs) == 0 {
+		return mutationReport{}, errors.New("no solution.go.txt")
+	}
+
+	// Each mutant changes one package's solution; target is the file it
+	// replaces in the overlay.
+	type job struct {
+		target string
+		m      mutate.Mutant
+	}
+	var jobs []job
+	for _, pkg := range slices.Sorted(maps.Keys(refs)) {
+		name := path.Join(filepath.ToSlash(e.Dir()), pkg, "solution.go.txt")
+		mutants, err := mutate.Generate(name, refs[pkg])
+		if err != nil {
+			return mutationReport{}, err
+		}
+		for _, m := range mutants {
+			if pkg != "." {
+				m.ID = pkg + "." + m.ID // ingest.ReadAll: ...
+			}
+			jobs = append(jobs, job{filepath.Join(dir, filepath.FromSlash(pkg), solutionFile), m})
+		}
+	}
+
+	tmp, err := os.MkdirTemp("", "learngo-mutate-")
+	if err != nil {
+		return mutationReport{}, err
+	}
+	defer os.RemoveAll(tmp)
+
+	// The overlay replaces your files with the stubs minus the funcs the
+	// solutions provide, so every run starts from the same code.
+	base, err := stubOverlay(e.ID, dir, tmp, refs)
+	if err != nil {
+		return mutationReport{}, err
+	}
+	test := func(name, target string, src []byte) (runner.Result, error) {
+		overlay, err := writeOverlay(tmp, name, base, target, src)
+		if err != nil {
+			return runner.Result{}, err
+		}
+		// -failfast: one failing test is enough to kill a mutant. The
+		// timeout catches mutants that loop forever.
+		return a.test(ctx, e.Dir(), "-overlay="+overlay, "-failfast", "-vet=off", "-timeout=60s")
+	}
+
+	ref, err := test("reference", "", nil)
+	if err != nil {
+		return mutationReport{}, err
+	}
+	if !ref.OK() {
+		return mutationReport{}, errors.New("the reference solution fails its own tests")
+	}
+
+	results := make([]runner.Result, len(jobs))
+	errs := make([]error, len(jobs))
+	sem := make(chan struct{}, parallel)
+	var wg sync.WaitGroup
+	for i, j := range jobs {
+		wg.Go(func() {
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			results[i], errs[i] = test(fmt.Sprintf("mutant-%d", i), j.target, j.m.Src)
+		})
+	}
+	wg.Wait()
+	if err := errors.Join(errs...); err != nil {
+		return mutationReport{}, err
+	}
+
+	rep := mutationReport{total: len(jobs)}
+	for i, res := range results {
+		switch {
+		case res.BuildFailed:
+			rep.invalid++
+		case res.OK():
+			rep.survived = append(rep.survived, jobs[i].m)
+		default:
+			rep.killed++
+		}
+	}
+	return rep, nil
+}
+
+// findSolutions reads every solution.go.txt under dir, keyed by its
+// package directory relative to dir ("." for the top one).
+func findSolutions(dir string) (map[string][]byte, error) {
+	refs := map[string][]byte{}
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() && d.Name() == "testdata" {
+			return filepath.SkipDir
+		}
+		if d.IsDir() || d.Name() != "solution.go.txt" {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, filepath.Dir(p))
+		if err != nil {
+			return err
+		}
+		data, err := os.ReadFile(p)
+		refs[filepath.ToSlash(rel)] = data
+		return err
+	})
+	return refs, err
+}
+
+// stubOverlay writes the stubs of exercise id into tmp, stripped of the
+// funcs the solutions provide, and the solutions next to them. It
+// returns the overlay entries that put them in place of the files in
+// dir. Files in dir that aren't stubs are hidden.
+func stubOverlay(id, dir, tmp string, refs map[string][]byte) (map[string]string, error) {
+	files, err := stubs.Files(id)
+	if err != nil {
+		return nil, err
+	}
+	current, err := sourceFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	replace := map[string]string{}
+	for _, name := range current {
+		replace[filepath.Join(dir, filepath.FromSlash(name))] = "" // deleted unless it's a stub
+	}
+	for i, f := range files {
+		src := f.Data
+		if solution, ok := refs[path.Dir(f.Name)]; ok {
+			if src, err = solutions.Strip(f.Name, f.Data, solution); err != nil {
+				return nil, fmt.Errorf("%s: %w", f.Name, err)
+			}
+		}
+		p := filepath.Join(tmp, fmt.Sprintf("stub-%d-%s", i, path.Base(f.Name)))
+		if err := os.WriteFile(p, src, 0o644); err != nil {
+			return nil, err
+		}
+		replace[filepath.Join(dir, filepath.FromSlash(f.Name))] = p
+	}
+	for pkg, solution := range refs {
+		p := filepath.Join(tmp, "solution-"+strings.ReplaceAll(pkg, "/", "-")+".go")
+		if err := os.WriteFile(p, solution, 0o644); err != nil {
+			return nil, err
+		}
+		replace[filepath.Join(dir, filepath.FromSlash(pkg), solutionFile)] = p
+	}
+	return replace, nil
+}
+
+// writeOverlay writes an overlay file for `go test -overlay` and returns
+// its path. It holds the base entries, plus src in place of target when
+// target isn't empty.
+func writeOverlay(tmp, name string, base map[string]string, target string, src []byte) (string, error) {
+	replace := maps.Clone(base)
+	if target != "" {
+		srcPath := filepath.Join(tmp, name+".go")
+		if err := os.WriteFile(srcPath, src, 0o644); err != nil {
+			return "", err
+		}
+		replace[target] = srcPath
+	}
+
+	data, err := json.Marshal(struct{ Replace map[string]string }{replace})
+	if err != nil {
+		return "", err
+	}
+	overlay := filepath.Join(tmp, name+".json")
+	return overlay, os.WriteFile(overlay, data, 0o644)
+}
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/imgarylai/learn-go/internal/runner"
+)
+
+// fakeMutationRuns makes a.runTests judge the solution in the overlay:
+// it passes when killed returns false. It records each solution it saw.
+func fakeMutationRuns(t *testing.T, a *app, killed func(src string) bool) *[]string {
+	t.Helper()
+	var mu sync.Mutex
+	var seen []string
+	a.runTests = func(_ context.Context, _, _ string, args ...string) (runner.Result, error) {
+		var overlay struct{ Replace map[string]string }
+		for _, arg := range args {
+			if path, ok := strings.CutPrefix(arg, "-overlay="); ok {
+				data, err := os.ReadFile(path)
+				if err != nil {
+					return runner.Result{}, err
+				}
+				if err := json.Unmarshal(data, &overlay); err != nil {
+					return runner.Result{}, err
+				}
+			}
+		}
+		var src []byte
+		for target, path := range overlay.Replace {
+			if filepath.Base(target) == solutionFile {
+				var err error
+				if src, err = os.ReadFile(path); err != nil {
+					return runner.Result{}, err
+				}
+			}
+		}
+		mu.Lock()
+		seen = append(seen, string(src))
+		mu.Unlock()
+		if killed(string(src)) {
+			return failing("TestSomething"), nil
+		}
+		return runner.Result{Tests: []runner.Test{{Name: "TestSomething", Status: runner.Pass}}}, nil
+	}
+	return &seen
+}
+
+func referenceSolution(t *testing.T, id string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("../../exercises", id, "solution.go.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestMutateReportsSurvivors(t *testing.T) {
+	a := newTestApp(t)
+	ref := referenceSolution(t, "13-string-algorithms")
+	// Every mutant dies except one in Caesar's shift normalization.
+	seen := fakeMutationRuns(t, a, func(src string) bool {
+		return src != ref && !strings.Contains(src, "(shift%27 + 26) % 26")
+	})
+
+	code, stdout, stderr := runApp(t, a, "mutate", "13-string-algorithms")
+	if code != 1 {
+		t.Errorf("exit code: got %d, want 1 (stderr %q)", code, stderr)
+	}
+	if !strings.Contains(stdout, "13-string-algorithms: ") || !strings.Contains(stdout, "1 survived") {
+		t.Errorf("missing summary:\n%s", stdout)
+	}
+	if !strings.Contains(stdout, "survived  exercises/13-string-algorithms/solution.go.txt:") ||
+		!strings.Contains(stdout, "\n13-string-algorithms Caesar: constant: 26 -> 27\n") {
+		t.Errorf("survivor not listed:\n%s", stdout)
+	}
+	if len(*seen) < 2 {
+		t.Fatalf("tests ran %d times, want the reference plus every mutant", len(*seen))
+	}
+	for _, src := range *seen {
+		if !strings.Contains(src, "package stringalgorithms") {
+			t.Errorf("the overlay doesn't hold a solution:\n%s", src)
+			break
+		}
+	}
+}
+
+func TestMutateKnownSurvivors(t *testing.T) {
+	a := newTestApp(t)
+	ref := referenceSolution(t, "13-string-algorithms")
+	fakeMutationRuns(t, a, func(src string) bool {
+		return src != ref && !strings.Contains(src, "(shift%27 + 26) % 26")
+	})
+	a.knownMutants = map[string][]string{"13-string-algorithms": {"Caesar: constant: 26 -> 27"}}
+
+	code, stdout, _ := runApp(t, a, "mutate", "13")
+	if code != 0 || !strings.Contains(stdout, "  known     exercises/13-string-algorithms/solution.go.txt:80:") {
+		t.Errorf("a known survivor failed the run: code %d\n%s", code, stdout)
+	}
+
+	// Once the tests catch it, it has to come off the list.
+	fakeMutationRuns(t, a, func(src string) bool { return src != ref })
+	code, stdout, _ = runApp(t, a, "mutate", "13")
+	if code != 1 || !strings.Contains(stdout, "killed    Caesar: constant: 26 -> 27, which is listed") {
+		t.Errorf("a stale entry passed: code %d\n%s", code, stdout)
+	}
+}
+
+func TestMutateAllKilled(t *testing.T) {
+	a := newTestApp(t)
+	ref := referenceSolution(t, "13-string-algorithms")
+	fakeMutationRuns(t, a, func(src string) bool { return src != ref })
+
+	code, stdout, _ := runApp(t, a, "mutate", "13")
+	if code != 0 {
+		t.Errorf("exit code: got %d, want 0", code)
+	}
+	if !strings.Contains(stdout, "0 survived (score 100%)") {
+		t.Errorf("got:\n%s", stdout)
+	}
+}
+
+func TestMutateBrokenReference(t *testing.T) {
+	a := newTestApp(t)
+	fakeMutationRuns(t, a, func(string) bool { return true })
+
+	code, stdout, _ := runApp(t, a, "mutate", "13-string-algorithms")
+	if code != 1 {
+		t.Errorf("exit code: got %d, want 1", code)
+	}
+	if !strings.Contains(stdout, "13-string-algorithms: the reference solution fails its own tests") {
+		t.Errorf("got:\n%s", stdout)
+	}
+}
+
+func TestMutateUsage(t *testing.T) {
+	if code, _, _ := runCLI(t, "mutate", "--parallel", "0"); code != 2 {
+		t.Errorf("--parallel 0: got code %d, want 2", code)
+	}
+	if code, _, stderr := runCLI(t, "mutate", "99-nope"); code != 1 || !strings.Contains(stderr, "unknown exercise") {
+		t.Errorf("unknown exercise: got code %d, stderr %q", code, stderr)
+	}
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/imgarylai/learn-go/internal/progress"
+	"github.com/imgarylai/learn-go/internal/registry"
+)
+
+// runNext implements `learngo next`: it suggests what to work on based on
+// which exercises are done and which prerequisites that unlocks.
+func runNext(a *app, args []string) error {
+	if len(args) > 0 {
+		return errUsage
+	}
+	prog, _, err := a.loadProgress()
+	if err != nil {
+		return err
+	}
+
+	unlocked := registry.Unlocked(func(id string) bool {
+		return prog.Status(id) == progress.Done
+	})
+	if len(unlocked) == 0 {
+		fmt.Fprintln(a.stdout, "Every exercise is done. Nice work!")
+		return nil
+	}
+
+	// Finish what you started before opening something new.
+	pick := unlocked[0]
+	for _, e := range unlocked {
+		if prog.Status(e.ID) == progress.Started {
+			pick = e
+			break
+		}
+	}
+
+	verb := "Next up"
+	if prog.Status(pick.ID) == progress.Started {
+		verb = "Keep going with"
+	}
+	fmt.Fprintf(a.stdout, "%s: %s (%s)\n", verb, pick.ID, pick.Difficulty)
+	fmt.Fprintf(a.stdout, "  topics: %s\n", strings.Join(pick.Topics, ", "))
+	fmt.Fprintf(a.stdout, "  cd %s && go test -v\n", pick.Dir())
+
+	if len(unlocked) > 1 {
+		fmt.Fprintln(a.stdout, "\nAlso unlocked:")
+		for _, e := range unlocked {
+			if e.ID != pick.ID {
+				fmt.Fprintf(a.stdout, "  %s\n", e.ID)
+			}
+		}
+	}
+	return nil
+}
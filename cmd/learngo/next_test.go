@@ -0,0 +1,62 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNextFollowsPrerequisites(t *testing.T) {
+	a := newTestApp(t)
+
+	_, stdout, _ := runApp(t, a, "next")
+	if !strings.Contains(stdout, "Next up: 01-basics") {
+		t.Errorf("fresh start:\n%s", stdout)
+	}
+
+	for _, id := range []string{"01", "02"} {
+		if code, _, stderr := runApp(t, a, "done", id); code != 0 {
+			t.Fatalf("done %s: code %d, stderr %q", id, code, stderr)
+		}
+	}
+	_, stdout, _ = runApp(t, a, "next")
+	if !strings.Contains(stdout, "Next up: 03-structs") || !strings.Contains(stdout, "04-collections") {
+		t.Errorf("after 01+02:\n%s", stdout)
+	}
+	if strings.Contains(stdout, "05-interfaces") {
+		t.Errorf("05-interfaces needs 03-structs first:\n%s", stdout)
+	}
+}
+
+func TestNextPrefersStartedExercise(t *testing.T) {
+	a := newTestApp(t)
+	runApp(t, a, "done", "01")
+	runApp(t, a, "done", "02")
+	runApp(t, a, "start", "04")
+
+	_, stdout, _ := runApp(t, a, "next")
+	if !strings.Contains(stdout, "Keep going with: 04-collections") {
+		t.Errorf("got:\n%s", stdout)
+	}
+}
+
+func TestPathListsPrerequisitesInOrder(t *testing.T) {
+	a := newTestApp(t)
+	if code, _, stderr := runApp(t, a, "done", "01"); code != 0 {
+		t.Fatalf("done: code %d, stderr %q", code, stderr)
+	}
+
+	code, stdout, stderr := runApp(t, a, "path", "06")
+	if code != 0 {
+		t.Fatalf("code %d, stderr %q", code, stderr)
+	}
+	first, second, target := strings.Index(stdout, "✓ 01-basics"), strings.Index(stdout, "02-functions"), strings.Index(stdout, "  06-concurrency\n")
+	if first < 0 || first > second || second > target {
+		t.Errorf("prerequisites missing or out of order:\n%s", stdout)
+	}
+	if !strings.Contains(stdout, "2 exercise(s) to finish") {
+		t.Errorf("missing count of what's left:\n%s", stdout)
+	}
+	if !strings.Contains(stdout, "counts towards:\n") || !strings.Contains(stdout, "14-capstone") {
+		t.Errorf("missing what 06 unlocks:\n%s", stdout)
+	}
+}
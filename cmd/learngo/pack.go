@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/imgarylai/learn-go/internal/pack"
+	"github.com/imgarylai/learn-go/internal/registry"
+)
+
+// runInstallPack implements `learngo install-pack [--force]
+// <module>[@version]`: it downloads an exercise pack published as a Go
+// module and copies it into the first pack directory, where every
+// command finds it from then on. --force replaces an installed pack of
+// the same name, which is how you upgrade one.
+func runInstallPack(a *app, args []string) error {
+	fs := flag.NewFlagSet("install-pack", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	force := fs.Bool("force", false, "replace the installed pack of the same name")
+	if err := fs.Parse(args); err != nil || fs.NArg() != 1 {
+		return errUsage
+	}
+	dirs, err := a.packDirs()
+	if err != nil {
+		return err
+	}
+	if len(dirs) == 0 || dirs[0] == "" {
+		return errors.New("no pack directory to install into (is LEARNGO_PACKS empty?)")
+	}
+
+	download := a.download
+	if download == nil {
+		download = pack.Download
+	}
+	module := fs.Arg(0)
+	src, version, err := download(context.Background(), module)
+	if err != nil {
+		return err
+	}
+	p, err := pack.Install(src, dirs[0], *force)
+	if err != nil {
+		return err
+	}
+	// Catch clashes with the other packs and unknown prerequisites now,
+	// not on the next run. The old copy of this pack is still loaded.
+	exs := slices.DeleteFunc(p.RegistryExercises(), func(e registry.Exercise) bool {
+		old, ok := registry.Lookup(e.ID)
+		return ok && old.ID == e.ID && old.Pack == p.Name
+	})
+	if unregister, err := registry.Register(exs...); err != nil {
+		fmt.Fprintf(a.stderr, "learngo: warning: the pack won't load: %v\n", err)
+	} else {
+		unregister()
+	}
+
+	module, _, _ = strings.Cut(module, "@")
+	title := p.Name
+	if p.Title != "" {
+		title = p.Title + " (" + p.Name + ")"
+	}
+	fmt.Fprintf(a.stdout, "Installed %s from %s@%s into %s:\n", title, module, version, p.Dir)
+	for _, e := range p.Exercises {
+		fmt.Fprintf(a.stdout, "  %-24s %s\n", e.ID, e.Title)
+	}
+	fmt.Fprintln(a.stdout, "They show up in `learngo list`; `learngo start` one to begin.")
+	return nil
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/imgarylai/learn-go/internal/pack"
+	"github.com/imgarylai/learn-go/internal/registry"
+	"github.com/imgarylai/learn-go/internal/runner"
+)
+
+func TestExercisePack(t *testing.T) {
+	packs := t.TempDir()
+	for _, dir := range []string{"acme/acme-01-ledger", "broken"} {
+		if err := os.MkdirAll(filepath.Join(packs, dir), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeFile(t, filepath.Join(packs, "acme", pack.ManifestFile), `{
+  "name": "acme",
+  "exercises": [{
+    "id": "acme-01-ledger",
+    "title": "Ledger",
+    "topics": ["money"],
+    "difficulty": "beginner",
+    "prerequisites": ["01-basics"],
+    "hints": ["Store cents as int64."]
+  }]
+}`)
+	writeFile(t, filepath.Join(packs, "broken", pack.ManifestFile), "{")
+
+	a := newTestApp(t)
+	a.packPaths = []string{packs}
+
+	code, stdout, stderr := runApp(t, a, "list", "--tag", "money")
+	if code != 0 || !strings.Contains(stdout, "acme-01-ledger") {
+		t.Fatalf("list: exit %d\n%s", code, stdout)
+	}
+	if !strings.Contains(stderr, "skipping exercise pack") {
+		t.Errorf("the broken pack should be reported, got %q", stderr)
+	}
+
+	if code, _, stderr := runApp(t, a, "start", "acme-01-ledger"); code != 0 {
+		t.Fatalf("start: exit %d: %s", code, stderr)
+	}
+	if _, stdout, _ := runApp(t, a, "hint", "acme-01-ledger"); !strings.Contains(stdout, "1. Store cents as int64.") {
+		t.Errorf("hint:\n%s", stdout)
+	}
+	if code, _, stderr := runApp(t, a, "reset", "acme-01-ledger"); code != 1 || !strings.Contains(stderr, "acme pack") {
+		t.Errorf("reset: exit %d: %s", code, stderr)
+	}
+
+	if _, ok := registry.Lookup("acme-01-ledger"); ok {
+		t.Error("pack exercises should be unregistered after the command")
+	}
+}
+
+func TestHintWithoutHints(t *testing.T) {
+	t.Setenv("LEARNGO_LANG", "en")
+	code, stdout, _ := runCLI(t, "hint", "01")
+	if code != 0 || !strings.Contains(stdout, "No hints for 01-basics") {
+		t.Errorf("exit %d:\n%s", code, stdout)
+	}
+}
+
+func TestHintInAnotherLanguage(t *testing.T) {
+	t.Setenv("LEARNGO_LANG", "ja")
+	code, stdout, _ := runCLI(t, "hint", "04")
+	if code != 0 || !strings.Contains(stdout, "04-collections のヒント:") || !strings.Contains(stdout, "1. nil スライス") {
+		t.Errorf("LEARNGO_LANG=ja: exit %d:\n%s", code, stdout)
+	}
+
+	code, stdout, _ = runCLI(t, "hint", "--lang", "zh_TW.UTF-8", "04")
+	if code != 0 || !strings.Contains(stdout, "04-collections 的提示：") {
+		t.Errorf("--lang zh_TW.UTF-8: exit %d:\n%s", code, stdout)
+	}
+
+	if code, _, stderr := runCLI(t, "hint", "--lang", "fr", "04"); code != 1 || !strings.Contains(stderr, "en, ja, zh-TW") {
+		t.Errorf("--lang fr: exit %d: %s", code, stderr)
+	}
+}
+func TestHintFromTests(t *testing.T) {
+	t.Setenv("LEARNGO_LANG", "en")
+	a := newTestApp(t)
+	res := failing("TestSum", "TestMax")
+	res.Tests[1].Output = []string{"panic: runtime error: index out of range [0] with length 0"}
+	fakeResults(a, map[string]runner.Result{"exercises/04-collections": res})
+
+	_, stdout, _ := runApp(t, a, "hint", "04")
+	if strings.Contains(stdout, "TestMax") {
+		t.Errorf("ran the tests without --tests:\n%s", stdout)
+	}
+	code, stdout, _ := runApp(t, a, "hint", "--tests", "04")
+	if code != 0 || !strings.Contains(stdout, ". TestMax: An index went past the end") {
+		t.Errorf("exit %d:\n%s", code, stdout)
+	}
+
+	// Hints about the tests even for an exercise without written ones.
+	fakeResults(a, map[string]runner.Result{"exercises/01-basics": failing("TestZeroValues")})
+	if _, stdout, _ := runApp(t, a, "hint", "--tests", "01"); !strings.Contains(stdout, "1. TestZeroValues fails") {
+		t.Errorf("01:\n%s", stdout)
+	}
+}
+
+func TestInstallPack(t *testing.T) {
+	a := newTestApp(t)
+	a.packPaths = []string{filepath.Join(t.TempDir(), "packs")}
+	module := writeTestPack(t, t.TempDir())
+	var asked string
+	a.download = func(_ context.Context, m string) (string, string, error) {
+		asked = m
+		return module, "v1.2.0", nil
+	}
+
+	code, stdout, stderr := runApp(t, a, "install-pack", "github.com/acme/go-exercises")
+	if code != 0 || asked != "github.com/acme/go-exercises" {
+		t.Fatalf("exit %d, downloaded %q: %s", code, asked, stderr)
+	}
+	for _, want := range []string{"Installed ACME drills (acme) from github.com/acme/go-exercises@v1.2.0", "acme-01-ledger", "Ledger"} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("expected %q in:\n%s", want, stdout)
+		}
+	}
+	if stderr != "" {
+		t.Errorf("stderr: %s", stderr)
+	}
+	if _, stdout, _ := runApp(t, a, "list"); !strings.Contains(stdout, "acme-01-ledger") {
+		t.Errorf("list doesn't show the installed pack:\n%s", stdout)
+	}
+
+	if code, _, stderr := runApp(t, a, "install-pack", "github.com/acme/go-exercises@v1.2.0"); code != 1 || !strings.Contains(stderr, "already installed") {
+		t.Errorf("again: exit %d: %s", code, stderr)
+	}
+	if code, _, stderr := runApp(t, a, "install-pack", "--force", "github.com/acme/go-exercises@v1.2.0"); code != 0 || stderr != "" {
+		t.Errorf("--force: exit %d: %s", code, stderr)
+	}
+	if code, _, _ := runApp(t, a, "install-pack"); code != 2 {
+		t.Errorf("no module: exit %d", code)
+	}
+}
+
+// writeTestPack lays out a one-exercise pack module in dir, as `go mod
+// download` would unpack it.
+func writeTestPack(t *testing.T, dir string) string {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Join(dir, "acme-01-ledger"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, filepath.Join(dir, "go.mod"), "module github.com/acme/go-exercises\n\ngo 1.25\n")
+	writeFile(t, filepath.Join(dir, "acme-01-ledger", "ledger.go"), "package ledger\n")
+	writeFile(t, filepath.Join(dir, pack.ManifestFile), `{
+  "name": "acme",
+  "title": "ACME drills",
+  "exercises": [{"id": "acme-01-ledger", "title": "Ledger", "difficulty": "beginner", "prerequisites": ["01-basics"]}]
+}`)
+	return dir
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/imgarylai/learn-go/internal/progress"
+	"github.com/imgarylai/learn-go/internal/registry"
+)
+
+// runPath implements `learngo path <exercise>`: the learning path to an
+// exercise, i.e. everything it builds on in the order to do it, and what
+// finishing it unlocks.
+func runPath(a *app, args []string) error {
+	if len(args) != 1 {
+		return errUsage
+	}
+	e, ok := registry.Lookup(args[0])
+	if !ok {
+		return fmt.Errorf("unknown exercise %q (see `learngo list`)", args[0])
+	}
+	prog, _, err := a.loadProgress()
+	if err != nil {
+		return err
+	}
+	mark := func(id string) string {
+		switch prog.Status(id) {
+		case progress.Done:
+			return "✓"
+		case progress.Started:
+			return "…"
+		}
+		return " "
+	}
+
+	requires := registry.Requires(e.ID)
+	if len(requires) == 0 {
+		fmt.Fprintf(a.stdout, "%s has no prerequisites.\n", e.ID)
+	} else {
+		left := 0
+		fmt.Fprintf(a.stdout, "Path to %s:\n", e.ID)
+		for _, r := range requires {
+			if prog.Status(r.ID) != progress.Done {
+				left++
+			}
+			fmt.Fprintf(a.stdout, "  %s %s\n", mark(r.ID), r.ID)
+		}
+		fmt.Fprintf(a.stdout, "  %s %s\n", mark(e.ID), e.ID)
+		if left == 0 {
+			fmt.Fprintln(a.stdout, "Everything before it is done: it's unlocked.")
+		} else {
+			fmt.Fprintf(a.stdout, "%d exercise(s) to finish before it unlocks.\n", left)
+		}
+	}
+
+	if unlocks := registry.Unlocks(e.ID); len(unlocks) > 0 {
+		fmt.Fprintln(a.stdout, "\nFinishing it counts towards:")
+		for _, u := range unlocks {
+			fmt.Fprintf(a.stdout, "  %s\n", u.ID)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/imgarylai/learn-go/internal/bench"
+	"github.com/imgarylai/learn-go/internal/registry"
+	"github.com/imgarylai/learn-go/internal/runner"
+	"github.com/imgarylai/learn-go/internal/solutions"
+)
+
+// runProfile implements `learngo profile [--bench regexp] [--top n]
+// [--out dir] [--solution] <exercise>`.
+//
+// It runs the benchmarks with -cpuprofile and -memprofile, keeps the
+// pprof files, and prints where the time and the allocations went: the
+// flags and `go tool pprof` calls you'd otherwise have to look up, a
+// bit like the Performance tab of Chrome DevTools on the command line.
+func runProfile(a *app, args []string) error {
+	fs := flag.NewFlagSet("profile", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	pattern := fs.String("bench", ".", "only run benchmarks matching this regexp")
+	top := fs.Int("top", 10, "how many hot spots to list")
+	out := fs.String("out", "", "where to write the profiles (default ~/.learn-go/profiles/<exercise>/<time>)")
+	solution := fs.Bool("solution", false, "profile the reference solution instead of your code")
+	if err := fs.Parse(args); err != nil || fs.NArg() != 1 || *top < 1 {
+		return errUsage
+	}
+	e, ok := registry.Lookup(fs.Arg(0))
+	if !ok {
+		return fmt.Errorf("unknown exercise %q (see `learngo list`)", fs.Arg(0))
+	}
+
+	dir := *out
+	if dir == "" {
+		base, err := bench.DefaultProfileDir()
+		if err != nil {
+			return err
+		}
+		dir = filepath.Join(base, e.ID, a.clock().Format("20060102-150405"))
+	}
+	dir, err := filepath.Abs(dir) // go test writes relative paths inside the package
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	extra := bench.ProfileArgs(dir)
+	if *solution {
+		if e.Pack != "" {
+			return fmt.Errorf("%s comes from the %s pack, which has no reference solution", e.ID, e.Pack)
+		}
+		extra = append(extra, "-tags="+solutions.Tag)
+	}
+	ctx := context.Background()
+	results, err := a.benchmark(ctx, e.Dir(), *pattern, extra...)
+	if err != nil {
+		return err
+	}
+	if len(results) == 0 {
+		fmt.Fprintf(a.stdout, "%s has no benchmarks matching %q\n", e.ID, *pattern)
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(a.stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "BENCHMARK\tNS/OP\tB/OP\tALLOCS/OP")
+	for _, r := range results {
+		fmt.Fprintf(tw, "%s\t%.2f\t%d\t%d\n", r.Name, r.NsPerOp, r.BytesPerOp, r.AllocsPerOp)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	yours := "YourFunc" // for the pprof -list example below
+	sections := []struct{ kind, title string }{
+		{"cpu", "CPU: where the time went"},
+		{"mem", "Memory: where the bytes were allocated"},
+	}
+	for _, s := range sections {
+		spots, err := bench.Top(ctx, dir, s.kind, *top)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(a.stdout, "\n%s\n", s.title)
+		tw := tabwriter.NewWriter(a.stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, "  FLAT\tFLAT%\tCUM\tCUM%\tFUNCTION")
+		for _, h := range spots {
+			if name := shortFunc(h.Func); yours == "YourFunc" && !(runner.Frame{Func: h.Func}).Std() && !strings.Contains(name, ".Benchmark") {
+				yours = name[strings.Index(name, ".")+1:]
+			}
+			fmt.Fprintf(tw, "  %s\t%.1f%%\t%s\t%.1f%%\t%s\n", h.Flat, h.FlatPct, h.Cum, h.CumPct, shortFunc(h.Func))
+		}
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintf(a.stdout, "\nFLAT is time or memory spent in the function itself; CUM adds what it calls.\n")
+	fmt.Fprintf(a.stdout, "Profiles are in %s. To dig further:\n", dir)
+	fmt.Fprintf(a.stdout, "  go tool pprof -http=: %s    # flame graph in the browser\n", filepath.Join(dir, "cpu.pprof"))
+	fmt.Fprintf(a.stdout, "  go tool pprof -sample_index=alloc_space -list '%s' %s\n", yours, filepath.Join(dir, "mem.pprof"))
+	return nil
+}
+
+// shortFunc drops the import path from a function name, leaving the
+// package: "02-functions.MapInts" rather than the full module path.
+func shortFunc(name string) string {
+	return name[strings.LastIndex(name, "/")+1:]
+}
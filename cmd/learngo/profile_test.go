@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime/pprof"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/imgarylai/learn-go/internal/bench"
+)
+
+// fakeProfiledBench makes a.runBench write real, if tiny, profiles of
+// this test process where -cpuprofile and -memprofile point.
+func fakeProfiledBench(a *app) {
+	a.runBench = func(_ context.Context, _, _, _ string, args ...string) ([]bench.Result, error) {
+		flagValue := func(name string) string { return args[slices.Index(args, name)+1] }
+		cpu, err := os.Create(flagValue("-cpuprofile"))
+		if err != nil {
+			return nil, err
+		}
+		defer cpu.Close()
+		if err := pprof.StartCPUProfile(cpu); err != nil {
+			return nil, err
+		}
+		var sink [][]byte
+		for i := range 10_000 {
+			sink = append(sink, make([]byte, i))
+		}
+		pprof.StopCPUProfile()
+
+		mem, err := os.Create(flagValue("-memprofile"))
+		if err != nil {
+			return nil, err
+		}
+		defer mem.Close()
+		if err := pprof.Lookup("allocs").WriteTo(mem, 0); err != nil {
+			return nil, err
+		}
+		return []bench.Result{{Name: "BenchmarkMapInts", N: len(sink), NsPerOp: 1500, BytesPerOp: 80, AllocsPerOp: 1}}, nil
+	}
+}
+
+func TestProfile(t *testing.T) {
+	a := newTestApp(t)
+	fakeProfiledBench(a)
+	out := t.TempDir()
+
+	code, stdout, stderr := runApp(t, a, "profile", "--bench", "MapInts", "--out", out, "02")
+	if code != 0 {
+		t.Fatalf("exit %d: %s", code, stderr)
+	}
+	for _, want := range []string{
+		"BenchmarkMapInts", "CPU: where the time went", "Memory: where the bytes were allocated",
+		"go tool pprof -http=: " + filepath.Join(out, "cpu.pprof"),
+	} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("missing %q:\n%s", want, stdout)
+		}
+	}
+
+	if code, _, _ := runApp(t, a, "profile", "02", "03"); code != 2 {
+		t.Errorf("two exercises: exit %d, want 2", code)
+	}
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/imgarylai/learn-go/internal/progress"
+)
+
+// runProgress implements `learngo progress export|import`, for moving
+// your progress (statuses, time spent, test results, hints and quiz
+// scores) to another machine or handing it to an instructor.
+//
+//	learngo progress export [--name name] [--out file]
+//	learngo progress import [--replace] <file>
+//
+// import merges by default, keeping the furthest-along of each side, so
+// two machines can trade exports back and forth without losing work.
+func runProgress(a *app, args []string) error {
+	if len(args) == 0 {
+		return errUsage
+	}
+	switch args[0] {
+	case "export":
+		return a.exportProgress(args[1:])
+	case "import":
+		return a.importProgress(args[1:])
+	}
+	return errUsage
+}
+
+func (a *app) exportProgress(args []string) error {
+	fs := flag.NewFlagSet("progress export", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	name := fs.String("name", "", "who the progress belongs to (default: your account name)")
+	out := fs.String("out", "", "write to this file instead of stdout")
+	if err := fs.Parse(args); err != nil || fs.NArg() > 0 {
+		return errUsage
+	}
+	if *name == "" {
+		*name = accountName()
+	}
+	prog, _, err := a.loadProgress()
+	if err != nil {
+		return err
+	}
+	x := prog.Export(*name, a.clock())
+
+	if *out == "" {
+		_, err := x.WriteTo(a.stdout)
+		return err
+	}
+	f, err := os.Create(*out)
+	if err != nil {
+		return err
+	}
+	if _, err := x.WriteTo(f); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	fmt.Fprintf(a.stdout, "Exported %d exercise(s) to %s\n", len(x.Exercises), *out)
+	return nil
+}
+
+func (a *app) importProgress(args []string) error {
+	fs := flag.NewFlagSet("progress import", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	replace := fs.Bool("replace", false, "overwrite your progress instead of merging")
+	if err := fs.Parse(args); err != nil || fs.NArg() != 1 {
+		return errUsage
+	}
+
+	in := a.stdin
+	if file := fs.Arg(0); file != "-" {
+		f, err := os.Open(file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		in = f
+	}
+	x, err := progress.ReadExport(in)
+	if errors.Is(err, progress.ErrNotExport) {
+		return fmt.Errorf("%s: %w (make one with `learngo progress export`)", fs.Arg(0), err)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", fs.Arg(0), err)
+	}
+
+	prog, path, err := a.loadProgress()
+	if err != nil {
+		return err
+	}
+	if *replace {
+		prog = &progress.File{}
+	}
+	prog.Merge(x)
+	if err := prog.Save(path); err != nil {
+		return err
+	}
+
+	from := ""
+	if x.Learner != "" {
+		from = " from " + x.Learner
+	}
+	verb := "Merged"
+	if *replace {
+		verb = "Replaced your progress with"
+	}
+	fmt.Fprintf(a.stdout, "%s %d exercise(s)%s, exported %s.\n", verb, len(x.Exercises), from, x.Exported.Local().Format("2006-01-02 15:04"))
+	return nil
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/imgarylai/learn-go/internal/progress"
+)
+
+func TestProgressExportAndImport(t *testing.T) {
+	home := newTestApp(t)
+	now := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+	home.now = func() time.Time { return now }
+	runApp(t, home, "start", "04")
+	now = now.Add(time.Hour)
+	runApp(t, home, "pause", "04")
+
+	out := filepath.Join(t.TempDir(), "ada.json")
+	code, stdout, stderr := runApp(t, home, "progress", "export", "--name", "Ada", "--out", out)
+	if code != 0 || !strings.Contains(stdout, "Exported 1 exercise(s)") {
+		t.Fatalf("export: exit %d: %s%s", code, stdout, stderr)
+	}
+
+	work := newTestApp(t)
+	runApp(t, work, "start", "05")
+	code, stdout, stderr = runApp(t, work, "progress", "import", out)
+	if code != 0 || !strings.Contains(stdout, "Merged 1 exercise(s) from Ada") {
+		t.Fatalf("import: exit %d: %s%s", code, stdout, stderr)
+	}
+	prog, err := progress.Load(work.progressPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := prog.Get("04-collections").TimeSpent(now); got != time.Hour {
+		t.Errorf("04-collections: %v spent, want 1h", got)
+	}
+	if prog.Status("05-interfaces") != progress.Started {
+		t.Error("merging should keep what was already there")
+	}
+
+	runApp(t, work, "progress", "import", "--replace", out)
+	if prog, _ := progress.Load(work.progressPath); prog.Status("05-interfaces") != progress.NotStarted {
+		t.Error("--replace should drop what was there")
+	}
+}
+
+func TestProgressImportRejectsOtherFiles(t *testing.T) {
+	a := newTestApp(t)
+	a.stdin = strings.NewReader(`{"exercises": {}}`)
+	code, _, stderr := runApp(t, a, "progress", "import", "-")
+	if code != 1 || !strings.Contains(stderr, "not a learngo progress export") {
+		t.Errorf("exit %d: %s", code, stderr)
+	}
+	if code, _, _ := runApp(t, a, "progress", "upload"); code != 2 {
+		t.Errorf("unknown subcommand: exit %d, want 2", code)
+	}
+}
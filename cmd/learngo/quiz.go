@@ -0,0 +1,47 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/imgarylai/learn-go/internal/achievements"
+	"github.com/imgarylai/learn-go/internal/progress"
+	"github.com/imgarylai/learn-go/internal/quiz"
+	"github.com/imgarylai/learn-go/internal/registry"
+)
+
+// runQuiz implements `learngo quiz <exercise>`: a few multiple-choice
+// questions on what the exercise teaches, answered one at a time. The
+// score is saved in the progress file next to your test results, and
+// you can retake it as often as you like.
+func runQuiz(a *app, args []string) error {
+	if len(args) != 1 {
+		return errUsage
+	}
+	e, ok := registry.Lookup(args[0])
+	if !ok {
+		return fmt.Errorf("unknown exercise %q (see `learngo list`)", args[0])
+	}
+	qs := quiz.For(e.ID)
+	if len(qs) == 0 {
+		fmt.Fprintf(a.stdout, "No quiz for %s yet.\n", e.ID)
+		return nil
+	}
+
+	prog, path, err := a.loadProgress()
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(a.stdout, "Quiz for %s: %d questions. Answer with a letter.\n", e.ID, len(qs))
+	if last := prog.Get(e.ID).Quiz; last != nil {
+		fmt.Fprintf(a.stdout, "Last time you scored %d/%d.\n", last.Correct, last.Total)
+	}
+
+	correct, err := quiz.Take(a.stdin, a.stdout, qs)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(a.stdout, "\nScore: %d/%d.\n", correct, len(qs))
+	prog.Get(e.ID).Quiz = &progress.QuizScore{Correct: correct, Total: len(qs), Taken: a.clock()}
+	a.announce(achievements.Award(prog, achievements.Event{Exercise: e.ID}, a.clock()))
+	return prog.Save(path)
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestQuizRecordsScore(t *testing.T) {
+	a := newTestApp(t)
+	// 04-collections: nil map, 0 for a missing key, random order.
+	a.stdin = strings.NewReader("b\nc\na\n")
+	code, stdout, stderr := runApp(t, a, "quiz", "04")
+	if code != 0 {
+		t.Fatalf("exit %d: %s", code, stderr)
+	}
+	if !strings.Contains(stdout, "Score: 2/3.") {
+		t.Errorf("stdout:\n%s", stdout)
+	}
+
+	a.stdin = strings.NewReader("")
+	code, stdout, stderr = runApp(t, a, "quiz", "04")
+	if code != 1 || !strings.Contains(stdout, "Last time you scored 2/3.") || !strings.Contains(stderr, "quiz aborted") {
+		t.Errorf("exit %d:\n%s\n%s", code, stdout, stderr)
+	}
+	prog, _, err := a.loadProgress()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if q := prog.Get("04-collections").Quiz; q == nil || q.Correct != 2 || q.Total != 3 {
+		t.Errorf("an aborted quiz shouldn't replace the score: %+v", q)
+	}
+}
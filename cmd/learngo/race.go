@@ -0,0 +1,75 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/imgarylai/learn-go/internal/runner"
+)
+
+// raceExplanation is printed with the first race `learngo run` finds.
+const raceExplanation = `A data race: two goroutines used the same memory at the same time, at
+least one of them writing, and nothing decided which went first. JS
+can't have one, since its event loop runs one callback at a time, but
+goroutines really do run in parallel. Make one wait for the other:
+guard the data with a sync.Mutex, hand it over on a channel, or use
+sync/atomic.`
+
+// printRaces writes which goroutines collided in each race, and where,
+// with file names relative to root. explain adds what a data race is.
+func printRaces(w io.Writer, root string, races []runner.Race, explain bool) {
+	r := lipgloss.NewRenderer(w)
+	label := r.NewStyle().Bold(true).Foreground(lipgloss.Color("203")).Render("DATA RACE")
+	dim := r.NewStyle().Foreground(lipgloss.Color("243"))
+
+	for _, race := range races {
+		ids := make([]string, len(race.Accesses))
+		for i, acc := range race.Accesses {
+			ids[i] = fmt.Sprint(acc.Goroutine)
+		}
+		where := ""
+		if race.Test != "" {
+			where = " in " + race.Test
+		}
+		fmt.Fprintf(w, "  %s%s: goroutines %s collided\n", label, where, strings.Join(ids, " and "))
+
+		for _, acc := range race.Accesses {
+			fmt.Fprintf(w, "    goroutine %d: %s at %s\n", acc.Goroutine, strings.ToLower(acc.Op), frameString(root, acc.Stack))
+			for _, g := range race.Goroutines {
+				if g.ID == acc.Goroutine && len(g.Created) > 0 {
+					fmt.Fprintf(w, "      %s\n", dim.Render("started at "+frameString(root, g.Created)))
+				}
+			}
+		}
+	}
+	if explain {
+		fmt.Fprintln(w)
+		for _, line := range strings.Split(raceExplanation, "\n") {
+			fmt.Fprintf(w, "  %s\n", dim.Render(line))
+		}
+	}
+}
+
+// frameString describes the innermost frame of stack that's in the code
+// under test rather than in the runtime or package testing.
+func frameString(root string, stack []runner.Frame) string {
+	if len(stack) == 0 {
+		return "an unknown place"
+	}
+	f := stack[0]
+	for _, s := range stack {
+		if !s.Std() {
+			f = s
+			break
+		}
+	}
+	file := f.File
+	if rel, err := filepath.Rel(root, file); err == nil && !strings.HasPrefix(rel, "..") {
+		file = rel
+	}
+	return fmt.Sprintf("%s:%d (%s)", filepath.ToSlash(file), f.Line, f.Name())
+}
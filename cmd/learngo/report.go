@@ -0,0 +1,131 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/imgarylai/learn-go/internal/grade"
+	"github.com/imgarylai/learn-go/internal/hidden"
+	"github.com/imgarylai/learn-go/internal/registry"
+	"github.com/imgarylai/learn-go/internal/report"
+	"github.com/imgarylai/learn-go/internal/runner"
+)
+
+// runReport implements `learngo report [--format json|junit|tap|rubric] [--weights file] [--hidden dir|file] [--cover] [--out file] [exercise...]`.
+// With no exercises it tests all of them. --weights regrades with an
+// instructor's points per test (see grade.Weights). --hidden runs the
+// instructor's hidden tests alongside the visible ones and grades both
+// into one score (see package hidden). --cover adds how much of each
+// function the tests ran: a function whose test passes without running
+// it all may have a branch that doesn't work.
+func runReport(a *app, args []string) error {
+	fs := flag.NewFlagSet("report", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	format := fs.String("format", "json", "json, junit, tap or rubric")
+	out := fs.String("out", "", "write to this file instead of stdout")
+	weightsFile := fs.String("weights", "", "JSON file of points per test, overriding the defaults")
+	hiddenPath := fs.String("hidden", "", "directory or sealed file of hidden tests to grade with")
+	cover := fs.Bool("cover", false, "include per-function coverage")
+	if err := fs.Parse(args); err != nil {
+		return errUsage
+	}
+
+	write, ok := report.Formats[*format]
+	if !ok {
+		return fmt.Errorf("unknown format %q (want %s)", *format, strings.Join(slices.Sorted(maps.Keys(report.Formats)), ", "))
+	}
+
+	exercises := registry.All()
+	if fs.NArg() > 0 {
+		exercises = exercises[:0]
+		for _, id := range fs.Args() {
+			e, ok := registry.Lookup(id)
+			if !ok {
+				return fmt.Errorf("unknown exercise %q (see `learngo list`)", id)
+			}
+			exercises = append(exercises, e)
+		}
+	}
+
+	root, err := a.rootDir()
+	if err != nil {
+		return err
+	}
+	var set hidden.Set
+	hiddenTests := map[string][]string{}
+	if *hiddenPath != "" {
+		if set, err = loadHidden(*hiddenPath); err != nil {
+			return err
+		}
+		for _, e := range exercises {
+			if hiddenTests[e.ID], err = set.Tests(e.ID); err != nil {
+				return fmt.Errorf("%s: hidden tests: %w", e.ID, err)
+			}
+		}
+	}
+	if *weightsFile != "" {
+		w, err := readWeights(*weightsFile, root, hiddenTests)
+		if err != nil {
+			return err
+		}
+		for i, e := range exercises {
+			exercises[i] = w.Apply(e)
+		}
+	}
+
+	test := a.test
+	if *cover {
+		test = a.testCovered
+	}
+	results := make([]runner.Result, len(exercises))
+	for i, e := range exercises {
+		var args []string
+		if len(set[e.ID]) > 0 {
+			overlay, cleanup, err := set.Overlay(e.ID, e.DirIn(root))
+			if err != nil {
+				return fmt.Errorf("%s: %w", e.ID, err)
+			}
+			defer cleanup()
+			args = append(args, overlay)
+		}
+		res, err := test(context.Background(), e.Dir(), args...)
+		if err != nil {
+			return fmt.Errorf("%s: %w", e.ID, err)
+		}
+		results[i] = res
+	}
+	r := report.New(root, exercises, results, hiddenTests, time.Now())
+
+	if *out == "" {
+		return write(a.stdout, r)
+	}
+	f, err := os.Create(*out)
+	if err != nil {
+		return err
+	}
+	if err := write(f, r); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func readWeights(path, root string, hiddenTests map[string][]string) (grade.Weights, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	w, err := grade.ReadWeights(f)
+	if err != nil {
+		return nil, err
+	}
+	return w.Resolve(root, hiddenTests)
+}
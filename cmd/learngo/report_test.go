@@ -0,0 +1,191 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/imgarylai/learn-go/internal/report"
+	"github.com/imgarylai/learn-go/internal/runner"
+)
+
+func TestReportJSON(t *testing.T) {
+	a := newTestApp(t)
+	fakeResults(a, map[string]runner.Result{
+		"exercises/04-collections": failing("TestSum"),
+	})
+
+	code, stdout, stderr := runApp(t, a, "report", "--format=json", "04", "05")
+	if code != 0 {
+		t.Fatalf("code %d, stderr %q", code, stderr)
+	}
+	var r report.Report
+	if err := json.Unmarshal([]byte(stdout), &r); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, stdout)
+	}
+	if len(r.Exercises) != 2 || r.Exercises[0].ID != "04-collections" || r.Summary.Failed != 1 {
+		t.Errorf("unexpected report: %+v", r)
+	}
+}
+
+func TestReportJUnit(t *testing.T) {
+	a := newTestApp(t)
+	fakeResults(a, nil)
+
+	_, stdout, _ := runApp(t, a, "report", "--format", "junit")
+	if !strings.HasPrefix(stdout, "<?xml") || !strings.Contains(stdout, "<testsuites") {
+		t.Errorf("not JUnit XML:\n%s", stdout)
+	}
+}
+
+func TestReportUnknownFormat(t *testing.T) {
+	code, _, stderr := runCLI(t, "report", "--format", "csv")
+	if code != 1 || !strings.Contains(stderr, "unknown format") || !strings.Contains(stderr, "json, junit, rubric, tap") {
+		t.Errorf("got code %d, stderr %q", code, stderr)
+	}
+}
+
+func TestReportTAP(t *testing.T) {
+	a := newTestApp(t)
+	fakeResults(a, map[string]runner.Result{"exercises/04-collections": failing("TestSum")})
+
+	code, stdout, stderr := runApp(t, a, "report", "--format", "tap", "04", "05")
+	if code != 0 {
+		t.Fatalf("code %d, stderr %q", code, stderr)
+	}
+	if !strings.HasPrefix(stdout, "TAP version 14\n1..2\n") || !strings.Contains(stdout, "not ok 1 - 04-collections") ||
+		!strings.Contains(stdout, "ok 2 - 05-interfaces") {
+		t.Errorf("unexpected TAP:\n%s", stdout)
+	}
+}
+
+func TestReportRubric(t *testing.T) {
+	a := newTestApp(t)
+	a.runTests = func(_ context.Context, _, dir string, _ ...string) (runner.Result, error) {
+		return runner.Result{Tests: []runner.Test{
+			{Name: "TestWorkerPool", Status: runner.Pass},
+			{Name: "TestChannelBasics", Status: runner.Fail},
+		}}, nil
+	}
+
+	code, stdout, stderr := runApp(t, a, "report", "--format", "rubric", "06")
+	if code != 0 {
+		t.Fatalf("code %d, stderr %q", code, stderr)
+	}
+	// TestWorkerPool is worth 3 points in the registry; tests that didn't
+	// run are listed at 0.
+	for _, want := range []string{"TestWorkerPool", "3/3", "TestChannelBasics", "0/1", "TestCounter", "total"} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("expected %q in:\n%s", want, stdout)
+		}
+	}
+}
+
+func TestReportWeightsFile(t *testing.T) {
+	a := newTestApp(t)
+	a.runTests = func(_ context.Context, _, dir string, _ ...string) (runner.Result, error) {
+		return runner.Result{Tests: []runner.Test{{Name: "TestWorkerPool", Status: runner.Pass}}}, nil
+	}
+	weights := filepath.Join(t.TempDir(), "weights.json")
+	writeFile(t, weights, `{"06": {"TestWorkerPool": 10}}`)
+
+	code, stdout, stderr := runApp(t, a, "report", "--format", "rubric", "--weights", weights, "06")
+	if code != 0 {
+		t.Fatalf("code %d, stderr %q", code, stderr)
+	}
+	if !strings.Contains(stdout, "10/10") {
+		t.Errorf("TestWorkerPool should be worth 10 points:\n%s", stdout)
+	}
+
+	writeFile(t, weights, `{"06": {"TestWorkerPoool": 10}}`)
+	if code, _, stderr := runApp(t, a, "report", "--weights", weights, "06"); code != 1 || !strings.Contains(stderr, "no test TestWorkerPoool") {
+		t.Errorf("typo: got code %d, stderr %q", code, stderr)
+	}
+}
+
+func TestReportCover(t *testing.T) {
+	a := newTestApp(t)
+	fakeResults(a, nil)
+	a.readCoverage = func(_ context.Context, _, dir, _ string) (*runner.Coverage, error) {
+		return &runner.Coverage{Total: 80, Funcs: []runner.FuncCoverage{{Func: "F", File: dir + "/f.go", Line: 3, Percent: 80}}}, nil
+	}
+
+	_, stdout, _ := runApp(t, a, "report", "04")
+	if strings.Contains(stdout, `"coverage"`) {
+		t.Errorf("coverage without --cover:\n%s", stdout)
+	}
+	code, stdout, stderr := runApp(t, a, "report", "--cover", "04")
+	if code != 0 {
+		t.Fatalf("code %d, stderr %q", code, stderr)
+	}
+	var r report.Report
+	if err := json.Unmarshal([]byte(stdout), &r); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, stdout)
+	}
+	if c := r.Exercises[0].Coverage; c == nil || c.Total != 80 || len(c.Functions) != 1 || c.Functions[0].Line != 3 {
+		t.Errorf("coverage: got %+v", c)
+	}
+}
+
+func TestReportHidden(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(dir, "06"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, filepath.Join(dir, "06", "pool_test.go"), "package concurrency\n\nimport \"testing\"\n\nfunc TestPoolNoJobs(t *testing.T) {}\n\nfunc TestPoolOneWorker(t *testing.T) {}\n")
+
+	a := newTestApp(t)
+	var overlay string
+	a.runTests = func(_ context.Context, _, dir string, args ...string) (runner.Result, error) {
+		for _, arg := range args {
+			if path, ok := strings.CutPrefix(arg, "-overlay="); ok {
+				data, _ := os.ReadFile(path)
+				overlay = string(data)
+			}
+		}
+		return runner.Result{Tests: []runner.Test{
+			{Name: "TestWorkerPool", Status: runner.Pass},
+			{Name: "TestPoolNoJobs", Status: runner.Fail, Output: []string{"pool_test.go:5: the secret edge case"}},
+		}}, nil
+	}
+
+	sealed := filepath.Join(dir, "hidden.sealed")
+	code, stdout, stderr := runApp(t, a, "hidden", "seal", "--out", sealed, dir)
+	if code != 0 || !strings.Contains(stdout, "06-concurrency") {
+		t.Fatalf("seal: code %d, stdout %q, stderr %q", code, stdout, stderr)
+	}
+	_, key, _ := strings.Cut(stdout, "LEARNGO_HIDDEN_KEY=")
+	t.Setenv("LEARNGO_HIDDEN_KEY", strings.TrimSpace(key))
+
+	code, stdout, stderr = runApp(t, a, "report", "--format", "json", "--hidden", sealed, "06")
+	if code != 0 {
+		t.Fatalf("code %d, stderr %q", code, stderr)
+	}
+	if !strings.Contains(overlay, "hidden_pool_test.go") {
+		t.Errorf("the hidden tests weren't overlaid: %q", overlay)
+	}
+	if strings.Contains(stdout, "secret edge case") {
+		t.Errorf("the report shows a hidden test's output:\n%s", stdout)
+	}
+	var r report.Report
+	if err := json.Unmarshal([]byte(stdout), &r); err != nil {
+		t.Fatal(err)
+	}
+	var hiddenItems []string
+	for _, it := range r.Exercises[0].Rubric {
+		if it.Hidden {
+			hiddenItems = append(hiddenItems, it.Test+" "+string(it.Status))
+		}
+	}
+	if strings.Join(hiddenItems, ", ") != "TestPoolNoJobs fail, TestPoolOneWorker fail" {
+		t.Errorf("hidden rubric items: %q", hiddenItems)
+	}
+
+	t.Setenv("LEARNGO_HIDDEN_KEY", "")
+	if code, _, stderr := runApp(t, a, "report", "--hidden", sealed, "06"); code != 1 || !strings.Contains(stderr, "is sealed") {
+		t.Errorf("no key: code %d, stderr %q", code, stderr)
+	}
+}
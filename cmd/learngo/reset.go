@@ -0,0 +1,212 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/imgarylai/learn-go/internal/registry"
+	"github.com/imgarylai/learn-go/internal/solutions"
+	"github.com/imgarylai/learn-go/internal/stubs"
+	"github.com/imgarylai/learn-go/internal/textdiff"
+)
+
+// runReset implements `learngo reset [--no-backup] <exercise> [file...]`.
+// Your files are copied to the backup directory first, so a reset is
+// never destructive unless you ask for --no-backup. Naming files resets
+// just those and leaves the rest of your work alone. Test files and
+// testdata are never touched; they aren't yours to edit.
+func runReset(a *app, args []string) error {
+	fs := flag.NewFlagSet("reset", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	noBackup := fs.Bool("no-backup", false, "don't keep a copy of your files")
+	if err := fs.Parse(args); err != nil || fs.NArg() == 0 {
+		return errUsage
+	}
+	e, dir, err := a.exerciseDir(fs.Args()[:1])
+	if err != nil {
+		return err
+	}
+	originals, err := stubs.Files(e.ID)
+	if err != nil {
+		return err
+	}
+	current, err := sourceFiles(dir)
+	if err != nil {
+		return err
+	}
+
+	if len(fs.Args()) > 1 {
+		names := make([]string, len(originals))
+		for i, f := range originals {
+			names[i] = f.Name
+		}
+		var only []string
+		for _, name := range fs.Args()[1:] {
+			if !slices.Contains(names, filepath.ToSlash(name)) {
+				return fmt.Errorf("%s has no stub file %q (it has %s)", e.ID, name, strings.Join(names, ", "))
+			}
+			only = append(only, filepath.ToSlash(name))
+		}
+		keep := func(name string) bool { return slices.Contains(only, name) }
+		originals = slices.DeleteFunc(originals, func(f stubs.File) bool { return !keep(f.Name) })
+		current = slices.DeleteFunc(current, func(name string) bool { return !keep(name) })
+	}
+
+	backup := ""
+	if !*noBackup {
+		backupRoot := a.backupDir
+		if backupRoot == "" {
+			if backupRoot, err = stubs.DefaultBackupDir(); err != nil {
+				return err
+			}
+		}
+		backup = filepath.Join(backupRoot, e.ID, a.clock().Format("20060102-150405"))
+		if err := os.MkdirAll(backup, 0o755); err != nil {
+			return err
+		}
+		for _, name := range current {
+			data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(name)))
+			if err != nil {
+				return err
+			}
+			if err := writeFileAll(filepath.Join(backup, filepath.FromSlash(name)), data); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Files you added (helpers.go, say) go too, or they'd clash with the stub.
+	for _, name := range current {
+		if err := os.Remove(filepath.Join(dir, filepath.FromSlash(name))); err != nil {
+			return err
+		}
+	}
+	for _, f := range originals {
+		if err := writeFileAll(filepath.Join(dir, filepath.FromSlash(f.Name)), f.Data); err != nil {
+			return err
+		}
+	}
+
+	what := e.ID
+	if len(fs.Args()) > 1 {
+		what = strings.Join(fs.Args()[1:], ", ") + " in " + e.ID
+	}
+	if backup == "" {
+		fmt.Fprintf(a.stdout, "Reset %s to the original stub. No backup was kept.\n", what)
+		return nil
+	}
+	fmt.Fprintf(a.stdout, "Reset %s to the original stub. Your work is saved in %s\n", what, backup)
+	return nil
+}
+
+// runDiff implements `learngo diff <exercise>`: a unified diff from the
+// original stub to your files, like `git diff` against a fresh clone.
+func runDiff(a *app, args []string) error {
+	e, dir, err := a.exerciseDir(args)
+	if err != nil {
+		return err
+	}
+	originals, err := stubs.Files(e.ID)
+	if err != nil {
+		return err
+	}
+	current, err := sourceFiles(dir)
+	if err != nil {
+		return err
+	}
+
+	stub := map[string]string{}
+	names := current
+	for _, f := range originals {
+		stub[f.Name] = string(f.Data)
+		if !slices.Contains(current, f.Name) {
+			names = append(names, f.Name)
+		}
+	}
+
+	changed := false
+	for _, name := range names {
+		var mine string
+		data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(name)))
+		switch {
+		case err == nil:
+			mine = string(data)
+		case !errors.Is(err, fs.ErrNotExist):
+			return err
+		}
+		path := filepath.ToSlash(e.Dir()) + "/" + name
+		if d := textdiff.Unified("stub/"+path, path, stub[name], mine); d != "" {
+			fmt.Fprint(a.stdout, d)
+			changed = true
+		}
+	}
+	if !changed {
+		fmt.Fprintf(a.stdout, "%s is unchanged from the stub.\n", e.ID)
+	}
+	return nil
+}
+
+// exerciseDir resolves the single exercise argument to its directory.
+func (a *app) exerciseDir(args []string) (registry.Exercise, string, error) {
+	if len(args) != 1 {
+		return registry.Exercise{}, "", errUsage
+	}
+	e, ok := registry.Lookup(args[0])
+	if !ok {
+		return e, "", fmt.Errorf("unknown exercise %q (see `learngo list`)", args[0])
+	}
+	if e.Pack != "" {
+		// Only the built-in stubs are embedded; a pack's own repo is
+		// the place to get its originals back.
+		return e, "", fmt.Errorf("%s comes from the %s pack, which has no stored stubs (try git in %s)", e.ID, e.Pack, e.Path)
+	}
+	root, err := a.rootDir()
+	if err != nil {
+		return e, "", err
+	}
+	return e, e.DirIn(root), nil
+}
+
+// sourceFiles lists the non-test .go files in dir and its subpackages:
+// the ones students edit, so not the generated solutions. Names are
+// relative to dir, with slashes, like stubs.File names.
+func sourceFiles(dir string) ([]string, error) {
+	var names []string
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if p != dir && d.Name() == "testdata" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		n := d.Name()
+		if !strings.HasSuffix(n, ".go") || strings.HasSuffix(n, "_test.go") || solutions.IsGenerated(n) {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		names = append(names, filepath.ToSlash(rel))
+		return nil
+	})
+	return names, err
+}
+
+// writeFileAll is os.WriteFile, creating missing parent directories.
+func writeFileAll(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
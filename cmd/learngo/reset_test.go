@@ -0,0 +1,210 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/imgarylai/learn-go/internal/registry"
+	"github.com/imgarylai/learn-go/internal/stubs"
+)
+
+// scratchRoot returns an app rooted at a temporary copy of 04-collections,
+// so reset can scribble on it without touching this checkout.
+func scratchRoot(t *testing.T) (*app, string) {
+	t.Helper()
+	a := newTestApp(t)
+	a.root = t.TempDir()
+	dir := filepath.Join(a.root, "exercises", "04-collections")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	files, err := stubs.Files("04-collections")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range files {
+		writeFile(t, filepath.Join(dir, f.Name), string(f.Data))
+	}
+	writeFile(t, filepath.Join(dir, "collections_test.go"), "package collections\n")
+	return a, dir
+}
+
+func writeFile(t *testing.T, path, data string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDiffAndReset(t *testing.T) {
+	a, dir := scratchRoot(t)
+	a.now = func() time.Time { return time.Date(2025, 5, 6, 7, 8, 9, 0, time.UTC) }
+
+	_, stdout, _ := runApp(t, a, "diff", "04")
+	if !strings.Contains(stdout, "unchanged") {
+		t.Errorf("fresh stub should be unchanged:\n%s", stdout)
+	}
+
+	stub, _ := os.ReadFile(filepath.Join(dir, "collections.go"))
+	mine := strings.Replace(string(stub), "return nil", "return []int{1, 2, 3, 4, 5}", 1)
+	writeFile(t, filepath.Join(dir, "collections.go"), mine)
+	writeFile(t, filepath.Join(dir, "helpers.go"), "package collections\n")
+
+	_, stdout, _ = runApp(t, a, "diff", "04")
+	for _, want := range []string{"+++ exercises/04-collections/collections.go", "+\treturn []int{1, 2, 3, 4, 5}", "+++ exercises/04-collections/helpers.go"} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("expected %q in diff:\n%s", want, stdout)
+		}
+	}
+
+	code, stdout, stderr := runApp(t, a, "reset", "04")
+	if code != 0 {
+		t.Fatalf("reset: code %d, stderr %s", code, stderr)
+	}
+	backup := filepath.Join(a.backupDir, "04-collections", "20250506-070809")
+	if !strings.Contains(stdout, backup) {
+		t.Errorf("reset should say where the backup is:\n%s", stdout)
+	}
+
+	if got, _ := os.ReadFile(filepath.Join(dir, "collections.go")); string(got) != string(stub) {
+		t.Error("collections.go was not restored")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "helpers.go")); err == nil {
+		t.Error("helpers.go should be removed")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "collections_test.go")); err != nil {
+		t.Error("test files must be left alone")
+	}
+	if got, _ := os.ReadFile(filepath.Join(backup, "collections.go")); string(got) != mine {
+		t.Error("backup doesn't hold the student's work")
+	}
+	if _, err := os.Stat(filepath.Join(backup, "helpers.go")); err != nil {
+		t.Error("helpers.go should be backed up")
+	}
+}
+
+func TestResetNestedPackages(t *testing.T) {
+	a := newTestApp(t)
+	a.root = t.TempDir()
+	dir := filepath.Join(a.root, "exercises", "14-capstone")
+	files, err := stubs.Files("14-capstone")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range files {
+		p := filepath.Join(dir, filepath.FromSlash(f.Name))
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		writeFile(t, p, string(f.Data))
+	}
+	writeFile(t, filepath.Join(dir, "api", "api.go"), "package api\n\n// my work\n")
+
+	_, stdout, _ := runApp(t, a, "diff", "14")
+	if !strings.Contains(stdout, "exercises/14-capstone/api/api.go") || !strings.Contains(stdout, "+// my work") {
+		t.Errorf("diff should cover subpackages:\n%s", stdout)
+	}
+
+	if code, _, stderr := runApp(t, a, "reset", "14"); code != 0 {
+		t.Fatalf("reset: code %d, stderr %q", code, stderr)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "api", "api.go"))
+	if err != nil || strings.Contains(string(data), "my work") {
+		t.Errorf("api/api.go wasn't restored: %v\n%s", err, data)
+	}
+	backups, _ := filepath.Glob(filepath.Join(a.backupDir, "14-capstone", "*", "api", "api.go"))
+	if len(backups) != 1 {
+		t.Errorf("want api/api.go in the backup, found %v", backups)
+	}
+}
+
+func TestResetOneFileWithoutBackup(t *testing.T) {
+	a, dir := scratchRoot(t)
+	first, second := "collections.go", "generics.go"
+	stub, _ := os.ReadFile(filepath.Join(dir, first))
+	writeFile(t, filepath.Join(dir, first), "package collections\n\n// mine 1\n")
+	writeFile(t, filepath.Join(dir, second), "package collections\n\n// mine 2\n")
+
+	code, stdout, stderr := runApp(t, a, "reset", "--no-backup", "04", first)
+	if code != 0 || !strings.Contains(stdout, "Reset "+first+" in 04-collections") || !strings.Contains(stdout, "No backup") {
+		t.Fatalf("reset: code %d, stdout %q, stderr %q", code, stdout, stderr)
+	}
+	if got, _ := os.ReadFile(filepath.Join(dir, first)); string(got) != string(stub) {
+		t.Errorf("%s was not restored", first)
+	}
+	if got, _ := os.ReadFile(filepath.Join(dir, second)); !strings.Contains(string(got), "mine 2") {
+		t.Errorf("%s should be left alone, got:\n%s", second, got)
+	}
+	if _, err := os.Stat(a.backupDir); err == nil {
+		t.Error("--no-backup still wrote a backup")
+	}
+
+	if code, _, stderr := runApp(t, a, "reset", "04", "nope.go"); code != 1 || !strings.Contains(stderr, `no stub file "nope.go"`) {
+		t.Errorf("unknown file: code %d, stderr %q", code, stderr)
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	a, dir := scratchRoot(t)
+	_, stdout, _ := runApp(t, a, "migrate")
+	if !strings.Contains(stdout, "Every exercise is up to date.") {
+		t.Errorf("fresh checkout:\n%s", stdout)
+	}
+	if code, stdout, _ := runApp(t, a, "migrate", "04"); code != 0 || !strings.Contains(stdout, "04-collections is up to date (version 1).") {
+		t.Errorf("exit %d:\n%s", code, stdout)
+	}
+
+	// A version 2 that adds a parameter to Sum and a new function, and
+	// drops a file, against a copy where Max and Sum are solved.
+	base := []stubs.File{
+		{Name: "a.go", Data: []byte("package x\n\nfunc Sum(n []int) int { return 0 }\n\nfunc Max(n []int) int { return 0 }\n")},
+		{Name: "old.go", Data: []byte("package x\n")},
+	}
+	theirs := []stubs.File{
+		{Name: "a.go", Data: []byte("package x\n\nfunc Sum(n []int, from int) int { return 0 }\n\nfunc Max(n []int) int { return 0 }\n\nfunc Min(n []int) int { return 0 }\n")},
+		{Name: "b.go", Data: []byte("package x\n")},
+	}
+	mine := map[string]string{
+		"a.go":   "package x\n\nfunc Sum(n []int) int { return len(n) }\n\nfunc Max(n []int) int { return n[0] }\n",
+		"old.go": "package x\n",
+	}
+	e, _ := registry.Lookup("04")
+	files, conflicted, err := a.migrateFiles(e, base, theirs, func(name string) ([]byte, error) {
+		if s, ok := mine[name]; ok {
+			return []byte(s), nil
+		}
+		return nil, os.ErrNotExist
+	})
+	if err != nil || !conflicted {
+		t.Fatalf("got %v, conflicted %v", err, conflicted)
+	}
+	stdout = a.stdout.(*bytes.Buffer).String()
+	for _, want := range []string{
+		"a.go: func Sum: conflict, you both changed it; yours is kept",
+		"+func Sum(n []int, from int) int { return 0 }",
+		"a.go: func Min: added",
+		"b.go: added",
+		"old.go: removed",
+	} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("expected %q in:\n%s", want, stdout)
+		}
+	}
+	var names []string
+	for _, f := range files {
+		names = append(names, f.Name)
+		if f.Name == "a.go" && !strings.Contains(string(f.Data), "return n[0]") {
+			t.Errorf("Max lost your solution:\n%s", f.Data)
+		}
+	}
+	if got := strings.Join(names, " "); got != "a.go b.go old.go" {
+		t.Errorf("files to write: %s", got)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "b.go")); err == nil {
+		t.Error("migrateFiles wrote to disk")
+	}
+}
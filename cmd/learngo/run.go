@@ -0,0 +1,190 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/imgarylai/learn-go/internal/achievements"
+	"github.com/imgarylai/learn-go/internal/registry"
+	"github.com/imgarylai/learn-go/internal/runner"
+)
+
+// runRun implements `learngo run [-v] [--race] [--cover] [--topic t]
+// [--difficulty level] [exercise...]`, the shortcut for `cd exercises/NN-* && go test`. With
+// no exercises it runs all of them in order; --topic and --difficulty
+// narrow that down, like `jest --testPathPattern`. It prints one
+// PASS/FAIL line per exercise and, for failures, the tests that failed;
+// -v adds what those tests printed.
+//
+// --race runs the tests with the race detector and explains any data
+// race it finds. It's on by default for exercises about concurrency
+// (registry.Exercise.Race); --race=false turns it off.
+//
+// --cover measures which functions the tests actually ran and lists the
+// ones they only ran part of, or not at all.
+//
+// The passing tests are saved in the progress file, for `learngo list`.
+// Unlike test-all it doesn't care what you've started: any failure makes
+// it exit 1, like `npm test`.
+func runRun(a *app, args []string) error {
+	fs := flag.NewFlagSet("run", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	verbose := fs.Bool("v", false, "show the output of failing tests")
+	race := fs.Bool("race", false, "run with the race detector (needs cgo)")
+	cover := fs.Bool("cover", false, "show the functions the tests didn't fully run")
+	topic := fs.String("topic", "", "only exercises with this topic")
+	difficulty := fs.String("difficulty", "", "beginner, intermediate or advanced")
+	if err := fs.Parse(args); err != nil {
+		return errUsage
+	}
+	filter, err := parseFilter(*topic, *difficulty)
+	if err != nil {
+		return err
+	}
+	raceSet := false
+	fs.Visit(func(f *flag.Flag) { raceSet = raceSet || f.Name == "race" })
+
+	exercises := registry.Select(filter)
+	if fs.NArg() > 0 {
+		exercises = nil
+		for _, id := range fs.Args() {
+			e, ok := registry.Lookup(id)
+			if !ok {
+				return fmt.Errorf("unknown exercise %q (see `learngo list`)", id)
+			}
+			if filter.Match(e) {
+				exercises = append(exercises, e)
+			}
+		}
+	}
+	if len(exercises) == 0 {
+		fmt.Fprintln(a.stdout, "no exercises match")
+		return nil
+	}
+
+	prog, path, err := a.loadProgress()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	test := a.test
+	if *cover {
+		test = a.testCovered
+	}
+	failed, explained, noCgo := 0, false, false
+	for _, e := range exercises {
+		withRace := e.Race && !noCgo
+		if raceSet {
+			withRace = *race
+		}
+		var args []string
+		if withRace {
+			args = append(args, "-race")
+		}
+		res, err := test(ctx, e.Dir(), args...)
+		if err != nil && withRace && !raceSet && strings.Contains(err.Error(), "requires cgo") {
+			// The race detector was only on by default, so run without
+			// it rather than fail.
+			fmt.Fprintf(a.stdout, "(%s: no race detector without cgo; testing without it)\n", e.ID)
+			noCgo = true
+			res, err = test(ctx, e.Dir())
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %w", e.ID, err)
+		}
+		if !res.OK() {
+			failed++
+		}
+		recordRun(prog, e.ID, res, a.clock())
+		printRun(a.stdout, e.ID, res, *verbose)
+		if res.Coverage != nil {
+			printCoverage(a.stdout, res)
+		}
+		if len(res.Races) > 0 {
+			root, err := a.rootDir()
+			if err != nil {
+				return err
+			}
+			printRaces(a.stdout, root, res.Races, !explained)
+			explained = true
+		}
+		a.announce(achievements.Award(prog, achievements.Event{Exercise: e.ID, Tests: &res, Race: withRace}, a.clock()))
+	}
+	if err := prog.Save(path); err != nil {
+		return err
+	}
+
+	if len(exercises) > 1 {
+		fmt.Fprintf(a.stdout, "\n%d of %d exercise(s) passed.\n", len(exercises)-failed, len(exercises))
+	}
+	if failed > 0 {
+		return exitError{code: 1}
+	}
+	return nil
+}
+
+// printRun writes the summary of one exercise's test run, in color when
+// w is a terminal.
+func printRun(w io.Writer, id string, res runner.Result, verbose bool) {
+	r := lipgloss.NewRenderer(w)
+	passLabel := r.NewStyle().Bold(true).Foreground(lipgloss.Color("42")).Render("PASS")
+	failLabel := r.NewStyle().Bold(true).Foreground(lipgloss.Color("203")).Render("FAIL")
+	dim := r.NewStyle().Foreground(lipgloss.Color("243"))
+	warn := r.NewStyle().Foreground(lipgloss.Color("203"))
+
+	pass, fail, skip := res.Counts()
+	switch {
+	case res.BuildFailed:
+		fmt.Fprintf(w, "%s  %s  build failed\n", failLabel, id)
+		for _, line := range res.BuildOutput {
+			fmt.Fprintf(w, "    %s\n", line)
+		}
+		return
+	case fail > 0:
+		fmt.Fprintf(w, "%s  %s  %d passed, %d failed", failLabel, id, pass, fail)
+	default:
+		fmt.Fprintf(w, "%s  %s  %d passed", passLabel, id, pass)
+	}
+	if skip > 0 {
+		fmt.Fprintf(w, ", %d skipped", skip)
+	}
+	fmt.Fprintf(w, " (%s)\n", res.Elapsed.Round(time.Millisecond))
+
+	for _, t := range res.Tests {
+		if t.Status != runner.Fail || strings.Contains(t.Name, "/") {
+			continue
+		}
+		if t.Aborted != "" {
+			fmt.Fprintf(w, "  - %s %s\n", t.Name, warn.Render(string(t.Aborted)))
+		} else {
+			fmt.Fprintf(w, "  - %s\n", t.Name)
+		}
+		if !verbose {
+			continue
+		}
+		// A table test's messages belong to its failing subtests.
+		for _, sub := range res.Tests {
+			if sub.Name != t.Name && !strings.HasPrefix(sub.Name, t.Name+"/") {
+				continue
+			}
+			for _, line := range sub.Output {
+				fmt.Fprintf(w, "      %s\n", dim.Render(strings.TrimSpace(line)))
+			}
+		}
+	}
+	switch res.Aborted {
+	case runner.TimedOut:
+		fmt.Fprintln(w, "  The tests were stopped at the time limit: look for a loop that never ends, or a goroutine")
+		fmt.Fprintln(w, "  waiting for something that never comes. LEARNGO_TIMEOUT changes the limit.")
+	case runner.OutOfMemory:
+		fmt.Fprintln(w, "  The tests were stopped at the memory limit: look for a slice or map that grows without end.")
+		fmt.Fprintln(w, "  LEARNGO_MEMORY changes the limit.")
+	}
+}
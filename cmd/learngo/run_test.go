@@ -0,0 +1,281 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/imgarylai/learn-go/internal/runner"
+)
+
+func TestRunOneExercise(t *testing.T) {
+	a := newTestApp(t)
+	fakeResults(a, nil)
+
+	code, stdout, stderr := runApp(t, a, "run", "04-collections")
+	if code != 0 {
+		t.Fatalf("exit code %d, stderr %q", code, stderr)
+	}
+	if !strings.Contains(stdout, "PASS  04-collections  1 passed") {
+		t.Errorf("missing PASS line:\n%s", stdout)
+	}
+	if strings.Contains(stdout, "01-basics") {
+		t.Errorf("ran more than the one exercise:\n%s", stdout)
+	}
+}
+
+func TestRunAllInOrder(t *testing.T) {
+	a := newTestApp(t)
+	fakeResults(a, map[string]runner.Result{
+		"exercises/04-collections": failing("TestSum"),
+	})
+
+	code, stdout, _ := runApp(t, a, "run")
+	if code != 1 {
+		t.Errorf("exit code: got %d, want 1", code)
+	}
+	first, fourth := strings.Index(stdout, "01-basics"), strings.Index(stdout, "FAIL  04-collections")
+	if first < 0 || fourth < 0 || first > fourth {
+		t.Errorf("exercises missing or out of order:\n%s", stdout)
+	}
+	if !strings.Contains(stdout, "  - TestSum") {
+		t.Errorf("failing test not listed:\n%s", stdout)
+	}
+}
+
+func TestRunVerboseShowsSubtestOutput(t *testing.T) {
+	a := newTestApp(t)
+	fakeResults(a, map[string]runner.Result{
+		"exercises/04-collections": {Tests: []runner.Test{
+			{Name: "TestSum", Status: runner.Fail},
+			{Name: "TestSum/empty", Status: runner.Fail, Output: []string{"    collections_test.go:12: got 1, want 0"}},
+		}},
+	})
+
+	_, stdout, _ := runApp(t, a, "run", "-v", "04")
+	if !strings.Contains(stdout, "collections_test.go:12: got 1, want 0") {
+		t.Errorf("subtest output missing:\n%s", stdout)
+	}
+}
+
+func TestRunUnknownExercise(t *testing.T) {
+	code, _, stderr := runCLI(t, "run", "99-nope")
+	if code != 1 || !strings.Contains(stderr, "unknown exercise") {
+		t.Errorf("got code %d, stderr %q", code, stderr)
+	}
+}
+
+func TestRunRecordsCompletion(t *testing.T) {
+	a := newTestApp(t)
+	fakeResults(a, map[string]runner.Result{
+		"exercises/04-collections": {Tests: []runner.Test{
+			{Name: "TestSum", Status: runner.Pass},
+			{Name: "TestMax", Status: runner.Fail},
+			{Name: "TestMin", Status: runner.Pass},
+			{Name: "TestAvg", Status: runner.Fail},
+		}},
+	})
+
+	runApp(t, a, "run", "04")
+	_, stdout, _ := runApp(t, a, "list")
+	for _, line := range strings.Split(stdout, "\n") {
+		if strings.HasPrefix(line, "04-collections") && !strings.Contains(line, "50%") {
+			t.Errorf("04-collections should be 50%% done: %q", line)
+		}
+		if strings.HasPrefix(line, "01-basics") && strings.Contains(line, "%") {
+			t.Errorf("01-basics was never run: %q", line)
+		}
+	}
+}
+
+func TestRunFiltersByTopicAndDifficulty(t *testing.T) {
+	a := newTestApp(t)
+	var ran []string
+	a.runTests = func(_ context.Context, _, dir string, _ ...string) (runner.Result, error) {
+		ran = append(ran, dir)
+		return runner.Result{Tests: []runner.Test{{Name: "TestOK", Status: runner.Pass}}}, nil
+	}
+
+	code, stdout, stderr := runApp(t, a, "run", "--topic", "channels", "--difficulty", "intermediate")
+	if code != 0 {
+		t.Fatalf("exit %d: %s", code, stderr)
+	}
+	if !slices.Equal(ran, []string{"exercises/06-concurrency"}) {
+		t.Errorf("ran %v, want only 06-concurrency", ran)
+	}
+
+	ran = nil
+	code, stdout, _ = runApp(t, a, "run", "--topic", "channels", "01", "02")
+	if code != 0 || len(ran) != 0 || !strings.Contains(stdout, "no exercises match") {
+		t.Errorf("exit %d, ran %v:\n%s", code, ran, stdout)
+	}
+
+	if code, _, stderr := runApp(t, a, "run", "--difficulty", "expert"); code != 1 || !strings.Contains(stderr, "unknown difficulty") {
+		t.Errorf("exit %d: %s", code, stderr)
+	}
+}
+
+func TestRunRaceFlag(t *testing.T) {
+	a := newTestApp(t)
+	raced := map[string]bool{}
+	a.runTests = func(_ context.Context, _, dir string, args ...string) (runner.Result, error) {
+		raced[dir] = slices.Contains(args, "-race")
+		return runner.Result{Tests: []runner.Test{{Name: "TestOK", Status: runner.Pass}}}, nil
+	}
+
+	tests := []struct {
+		flags          []string
+		want04, want06 bool
+	}{
+		{nil, false, true}, // on by default for 06-concurrency only
+		{[]string{"--race"}, true, true},
+		{[]string{"--race=false"}, false, false},
+	}
+	for _, tt := range tests {
+		clear(raced)
+		args := append(append([]string{"run"}, tt.flags...), "04", "06")
+		if code, _, stderr := runApp(t, a, args...); code != 0 {
+			t.Fatalf("%v: exit %d: %s", tt.flags, code, stderr)
+		}
+		if raced["exercises/04-collections"] != tt.want04 || raced["exercises/06-concurrency"] != tt.want06 {
+			t.Errorf("%v: -race for 04 %v, 06 %v; want %v, %v", tt.flags,
+				raced["exercises/04-collections"], raced["exercises/06-concurrency"], tt.want04, tt.want06)
+		}
+	}
+}
+
+func TestRunExplainsRaces(t *testing.T) {
+	a := newTestApp(t)
+	file := "../../exercises/06-concurrency/concurrency.go"
+	race := runner.Race{
+		Test: "TestConcurrentIncrement",
+		Accesses: []runner.Access{
+			{Op: "Read", Goroutine: 8, Stack: []runner.Frame{
+				{Func: "github.com/imgarylai/learn-go/exercises/06-concurrency.(*Counter).Increment", File: file, Line: 48},
+			}},
+			{Op: "Previous write", Goroutine: 7, Stack: []runner.Frame{
+				{Func: "runtime.racewrite", File: "/usr/local/go/src/runtime/race.go", Line: 1},
+				{Func: "github.com/imgarylai/learn-go/exercises/06-concurrency.(*Counter).Increment", File: file, Line: 48},
+			}},
+		},
+		Goroutines: []runner.Goroutine{
+			{ID: 8, State: "running", Created: []runner.Frame{
+				{Func: "github.com/imgarylai/learn-go/exercises/06-concurrency.ConcurrentIncrement", File: file, Line: 60},
+			}},
+		},
+	}
+	res := failing("TestConcurrentIncrement")
+	res.Races = []runner.Race{race}
+	fakeResults(a, map[string]runner.Result{"exercises/06-concurrency": res})
+
+	code, stdout, _ := runApp(t, a, "run", "06", "06")
+	if code != 1 {
+		t.Errorf("exit code: got %d, want 1", code)
+	}
+	for _, want := range []string{
+		"DATA RACE in TestConcurrentIncrement: goroutines 8 and 7 collided",
+		"goroutine 8: read at exercises/06-concurrency/concurrency.go:48 ((*Counter).Increment)",
+		"started at exercises/06-concurrency/concurrency.go:60 (ConcurrentIncrement)",
+		"goroutine 7: previous write at exercises/06-concurrency/concurrency.go:48",
+	} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("missing %q:\n%s", want, stdout)
+		}
+	}
+	if n := strings.Count(stdout, "A data race:"); n != 1 {
+		t.Errorf("explained %d times, want once:\n%s", n, stdout)
+	}
+}
+
+func TestRunWithoutCgo(t *testing.T) {
+	a := newTestApp(t)
+	a.runTests = func(_ context.Context, _, _ string, args ...string) (runner.Result, error) {
+		if slices.Contains(args, "-race") {
+			return runner.Result{}, errors.New("go: -race requires cgo; enable cgo by setting CGO_ENABLED=1")
+		}
+		return runner.Result{Tests: []runner.Test{{Name: "TestOK", Status: runner.Pass}}}, nil
+	}
+
+	code, stdout, stderr := runApp(t, a, "run", "06")
+	if code != 0 || !strings.Contains(stdout, "no race detector without cgo") || !strings.Contains(stdout, "PASS  06-concurrency") {
+		t.Errorf("default: exit %d, stdout %q, stderr %q", code, stdout, stderr)
+	}
+	// Asking for the race detector explicitly is an error without cgo.
+	if code, _, stderr := runApp(t, a, "run", "--race", "06"); code != 1 || !strings.Contains(stderr, "requires cgo") {
+		t.Errorf("--race: exit %d, stderr %q", code, stderr)
+	}
+}
+
+func TestRunCover(t *testing.T) {
+	a := newTestApp(t)
+	var args []string
+	a.runTests = func(_ context.Context, _, _ string, got ...string) (runner.Result, error) {
+		args = got
+		return runner.Result{Tests: []runner.Test{
+			{Name: "TestSum", Status: runner.Pass},
+			{Name: "TestMax", Status: runner.Fail},
+		}}, nil
+	}
+	a.readCoverage = func(_ context.Context, _, _, _ string) (*runner.Coverage, error) {
+		return &runner.Coverage{Total: 62.5, Funcs: []runner.FuncCoverage{
+			{Func: "Sum", File: "example.com/x/collections.go", Line: 5, Percent: 75},
+			{Func: "Max", File: "example.com/x/collections.go", Line: 14, Percent: 50},
+			{Func: "Min", File: "example.com/x/collections.go", Line: 30, Percent: 100},
+		}}, nil
+	}
+
+	code, stdout, _ := runApp(t, a, "run", "--cover", "04")
+	if code != 1 {
+		t.Errorf("exit code: got %d, want 1", code)
+	}
+	if !slices.ContainsFunc(args, func(s string) bool { return strings.HasPrefix(s, "-coverprofile=") }) {
+		t.Errorf("go test args: %v", args)
+	}
+	for _, want := range []string{"coverage 62.5% of statements", "collections.go:5", "TestSum passes without running all of it", "Max"} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("missing %q:\n%s", want, stdout)
+		}
+	}
+	if strings.Contains(stdout, "Min") || strings.Contains(stdout, "TestMax passes") {
+		t.Errorf("only partly run functions should be listed, and only passing tests noted:\n%s", stdout)
+	}
+
+	if _, stdout, _ := runApp(t, a, "run", "04"); strings.Contains(stdout, "coverage") {
+		t.Errorf("coverage without --cover:\n%s", stdout)
+	}
+}
+
+func TestRunLimits(t *testing.T) {
+	t.Setenv("LEARNGO_TIMEOUT", "5s")
+	t.Setenv("LEARNGO_MEMORY", "")
+	a := newTestApp(t)
+	var args []string
+	a.runTests = func(_ context.Context, _, _ string, got ...string) (runner.Result, error) {
+		args = got
+		return runner.Result{
+			Aborted: runner.TimedOut,
+			Tests: []runner.Test{
+				{Name: "TestOK", Status: runner.Pass},
+				{Name: "TestCollectFromChannel", Status: runner.Fail, Aborted: runner.TimedOut},
+			},
+		}, nil
+	}
+
+	code, stdout, _ := runApp(t, a, "run", "04")
+	if code != 1 {
+		t.Errorf("exit code: got %d, want 1", code)
+	}
+	if !slices.Contains(args, "-timeout=5s") {
+		t.Errorf("go test args: %q", args)
+	}
+	if !strings.Contains(stdout, "- TestCollectFromChannel timed out") || !strings.Contains(stdout, "LEARNGO_TIMEOUT changes the limit") {
+		t.Errorf("stdout:\n%s", stdout)
+	}
+
+	t.Setenv("LEARNGO_TIMEOUT", "soon")
+	if code, _, stderr := runApp(t, a, "run", "04"); code != 1 || !strings.Contains(stderr, "LEARNGO_TIMEOUT") {
+		t.Errorf("exit %d: %s", code, stderr)
+	}
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"time"
+
+	"github.com/imgarylai/learn-go/internal/achievements"
+	"github.com/imgarylai/learn-go/internal/dashboard"
+	"github.com/imgarylai/learn-go/internal/progress"
+	"github.com/imgarylai/learn-go/internal/registry"
+	"github.com/imgarylai/learn-go/internal/runner"
+)
+
+// runServe implements `learngo serve [--addr host:port]`: a dashboard in
+// the browser with your progress, time spent and hints used for every
+// exercise, and a button to run its tests, like `vitest --ui`. It reads
+// the same progress file as the CLI and records runs the way `learngo
+// run` does, so both stay in sync. Ctrl-C stops it.
+func runServe(a *app, args []string) error {
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	addr := fs.String("addr", "localhost:8000", "address to listen on")
+	if err := fs.Parse(args); err != nil || fs.NArg() != 0 {
+		return errUsage
+	}
+
+	ln, err := net.Listen("tcp", *addr)
+	if err != nil {
+		return err
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	fmt.Fprintf(a.stdout, "Dashboard on http://%s. Press Ctrl-C to stop.\n", ln.Addr())
+	return a.serve(ctx, ln)
+}
+
+// serve runs the dashboard on ln until ctx is done.
+func (a *app) serve(ctx context.Context, ln net.Listener) error {
+	srv := &http.Server{
+		Handler:           a.dashboard(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	errc := make(chan error, 1)
+	go func() { errc <- srv.Serve(ln) }()
+
+	select {
+	case err := <-errc:
+		return err
+	case <-ctx.Done():
+	}
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		if !errors.Is(err, context.DeadlineExceeded) {
+			return err
+		}
+		// A browser can hold a connection open past the timeout:
+		// close it rather than fail a stop the user asked for.
+		srv.Close()
+	}
+	if err := <-errc; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// dashboard wires the dashboard to the progress file and test runner.
+func (a *app) dashboard() *dashboard.Handler {
+	// Two runs at once would each load the progress file, record
+	// their result and save, and the second save would drop the first.
+	var mu sync.Mutex
+	return dashboard.NewHandler(dashboard.Options{
+		Exercises: registry.All(),
+		Progress: func() (*progress.File, error) {
+			prog, _, err := a.loadProgress()
+			return prog, err
+		},
+		Run: func(ctx context.Context, e registry.Exercise) (runner.Result, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			res, err := a.test(ctx, e.Dir())
+			if err != nil {
+				return res, err
+			}
+			prog, path, err := a.loadProgress()
+			if err != nil {
+				return res, err
+			}
+			recordRun(prog, e.ID, res, a.clock())
+			achievements.Award(prog, achievements.Event{Exercise: e.ID, Tests: &res}, a.clock())
+			return res, prog.Save(path)
+		},
+		Now: a.clock,
+	})
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/imgarylai/learn-go/internal/dashboard"
+	"github.com/imgarylai/learn-go/internal/runner"
+)
+
+func TestServeRecordsRunsAndHints(t *testing.T) {
+	a := newTestApp(t)
+	fakeResults(a, map[string]runner.Result{
+		"exercises/04-collections": {Tests: []runner.Test{
+			{Name: "TestSum", Status: runner.Pass},
+			{Name: "TestMax", Status: runner.Fail},
+		}},
+	})
+	runApp(t, a, "hint", "04")
+	runApp(t, a, "hint", "04")
+
+	ln, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, stop := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- a.serve(ctx, ln) }()
+	base := "http://" + ln.Addr().String()
+	// A kept-alive connection would keep Shutdown waiting.
+	client := &http.Client{Timeout: 5 * time.Second, Transport: &http.Transport{DisableKeepAlives: true}}
+
+	resp, err := client.Post(base+"/exercises/04-collections/run", "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	resp, err = client.Get(base + "/api/exercises")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var rows []dashboard.Row
+	err = json.NewDecoder(resp.Body).Decode(&rows)
+	resp.Body.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, row := range rows {
+		if row.ID == "04-collections" && (row.Hints != 2 || row.Completion != 0.5) {
+			t.Errorf("04-collections: %d hints, %.0f%% done; want 2 and 50%%", row.Hints, row.Completion*100)
+		}
+	}
+
+	stop()
+	if err := <-done; err != nil {
+		t.Errorf("serve: %v", err)
+	}
+	// The run from the browser counts for the CLI too.
+	prog, _, err := a.loadProgress()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if share, ok := prog.Completion("04-collections"); !ok || share != 0.5 {
+		t.Errorf("progress file: %v %v", share, ok)
+	}
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"path/filepath"
+	"text/tabwriter"
+
+	"github.com/imgarylai/learn-go/internal/registry"
+	"github.com/imgarylai/learn-go/internal/similarity"
+)
+
+// runSimilarity implements
+// `learngo similarity [--exercise id] [--base dir] [--min score] <submission-dir>...`.
+//
+// Each directory is one student's work. With --exercise, only that
+// exercise's folder inside each submission is compared, and the stub
+// from this checkout is subtracted as the shared starter code.
+func runSimilarity(a *app, args []string) error {
+	fs := flag.NewFlagSet("similarity", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	exercise := fs.String("exercise", "", "compare only this exercise inside each submission")
+	base := fs.String("base", "", "starter code to ignore (default: the exercise stub with --exercise)")
+	minScore := fs.Float64("min", 0, "only show pairs scoring at least this (0 to 1)")
+	if err := fs.Parse(args); err != nil || fs.NArg() < 2 {
+		return errUsage
+	}
+
+	dirs := fs.Args()
+	if *exercise != "" {
+		e, ok := registry.Lookup(*exercise)
+		if !ok {
+			return fmt.Errorf("unknown exercise %q (see `learngo list`)", *exercise)
+		}
+		for i, d := range dirs {
+			if sub := filepath.Join(d, e.Dir()); isDir(sub) {
+				dirs[i] = sub
+			}
+		}
+		if *base == "" {
+			root, err := a.rootDir()
+			if err != nil {
+				return err
+			}
+			*base = e.DirIn(root)
+		}
+	}
+
+	var starter similarity.Fingerprint
+	if *base != "" {
+		var err error
+		if starter, err = similarity.LoadDir(*base); err != nil {
+			return err
+		}
+	}
+
+	fps := make([]similarity.Fingerprint, len(dirs))
+	for i, d := range dirs {
+		fp, err := similarity.LoadDir(d)
+		if err != nil {
+			return err
+		}
+		fp.Subtract(starter)
+		fps[i] = fp
+	}
+
+	tw := tabwriter.NewWriter(a.stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "SCORE\tA\tB")
+	for _, p := range similarity.Compare(fps) {
+		if p.Score < *minScore {
+			continue
+		}
+		fmt.Fprintf(tw, "%.2f\t%s\t%s\n", p.Score, p.A, p.B)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	fmt.Fprintln(a.stdout, "\nScores run from 0 (nothing shared) to 1 (same code up to renaming). Read the code before drawing conclusions.")
+	return nil
+}
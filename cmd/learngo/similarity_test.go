@@ -0,0 +1,49 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSimilarity(t *testing.T) {
+	dir := t.TempDir()
+	solution, err := os.ReadFile("../../exercises/04-collections/solution.go.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	renamed := strings.ReplaceAll(string(solution), "result", "out")
+
+	write := func(student, src string) string {
+		d := filepath.Join(dir, student, "exercises", "04-collections")
+		if err := os.MkdirAll(d, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(d, "collections.go"), []byte(src), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return filepath.Join(dir, student)
+	}
+	alice := write("alice", string(solution))
+	bob := write("bob", renamed)
+
+	code, stdout, stderr := runCLI(t, "similarity", "--exercise", "04", alice, bob)
+	if code != 0 {
+		t.Fatalf("exit code %d: %s", code, stderr)
+	}
+	if !strings.Contains(stdout, "1.00") {
+		t.Errorf("a renamed copy should score 1.00:\n%s", stdout)
+	}
+
+	_, stdout, _ = runCLI(t, "similarity", "--min", "1.01", alice, bob)
+	if strings.Contains(stdout, "alice") {
+		t.Errorf("--min should hide the pair:\n%s", stdout)
+	}
+}
+
+func TestSimilarityNeedsTwoDirs(t *testing.T) {
+	if code, _, _ := runCLI(t, "similarity", "."); code != 2 {
+		t.Errorf("exit code: got %d, want 2", code)
+	}
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/imgarylai/learn-go/internal/progress"
+	"github.com/imgarylai/learn-go/internal/registry"
+)
+
+// Time tracking is opt-in: the clock only runs between `learngo start`
+// and `learngo pause`/`done`. If you never use them, nothing is recorded.
+
+// runStart implements `learngo start <exercise>`.
+func runStart(a *app, args []string) error {
+	version := 0
+	if len(args) == 1 {
+		if ex, ok := registry.Lookup(args[0]); ok {
+			version = ex.CurrentVersion()
+		}
+	}
+	return updateExercise(a, args, func(e *progress.Exercise) string {
+		if e.Status == "" && e.Version == 0 {
+			e.Version = version // what `learngo migrate` starts from
+		}
+		e.Status = progress.Started
+		e.StartSession(a.clock())
+		return "Started %s; the clock is running. Failing tests there now make `learngo test-all` fail."
+	})
+}
+
+// runPause implements `learngo pause <exercise>`.
+func runPause(a *app, args []string) error {
+	return updateExercise(a, args, func(e *progress.Exercise) string {
+		if !e.Open() {
+			return "%s isn't being timed; `learngo start` it first."
+		}
+		e.EndSession(a.clock())
+		return "Paused %s. `learngo start` it again to pick up where you left off."
+	})
+}
+
+// runDone implements `learngo done <exercise>`, which unlocks the
+// exercises that list it as a prerequisite (see `learngo next`). It
+// refuses if the exercise's tests have been edited; `learngo verify`
+// also checks they pass.
+func runDone(a *app, args []string) error {
+	if len(args) == 1 {
+		if e, ok := registry.Lookup(args[0]); ok {
+			if err := a.checkTests(e); err != nil {
+				return err
+			}
+		}
+	}
+	return updateExercise(a, args, func(e *progress.Exercise) string {
+		e.Status = progress.Done
+		e.EndSession(a.clock())
+		return "Marked %s as done. Run `learngo next` to see what's unlocked."
+	})
+}
+
+// updateExercise loads the progress file, applies update to the exercise
+// named in args and saves it again. update returns the message to print,
+// with %s standing for the exercise ID.
+func updateExercise(a *app, args []string, update func(*progress.Exercise) string) error {
+	if len(args) != 1 {
+		return errUsage
+	}
+	e, ok := registry.Lookup(args[0])
+	if !ok {
+		return fmt.Errorf("unknown exercise %q (see `learngo list`)", args[0])
+	}
+
+	f, path, err := a.loadProgress()
+	if err != nil {
+		return err
+	}
+	msg := update(f.Get(e.ID))
+	if err := f.Save(path); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(a.stdout, msg+"\n", e.ID)
+	return nil
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"fmt"
+	"text/tabwriter"
+	"time"
+
+	"github.com/imgarylai/learn-go/internal/registry"
+)
+
+// runStats implements `learngo stats`: per exercise, the time spent
+// (from the sessions recorded by start/pause/done), how many test runs
+// it took, how long from the first failing run to the first all-green
+// one, and how many times you asked for hints.
+func runStats(a *app, args []string) error {
+	if len(args) > 0 {
+		return errUsage
+	}
+	prog, _, err := a.loadProgress()
+	if err != nil {
+		return err
+	}
+
+	now := a.clock()
+	tw := tabwriter.NewWriter(a.stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "EXERCISE\tSTATUS\tSESSIONS\tTIME\tRUNS\tTO GREEN\tHINTS")
+	var total time.Duration
+	var runs, hints, tracked int
+	for _, e := range registry.All() {
+		entry, ok := prog.Exercises[e.ID]
+		if !ok {
+			fmt.Fprintf(tw, "%s\t%s\t-\t-\t-\t-\t-\n", e.ID, statusLabel(prog.Status(e.ID)))
+			continue
+		}
+		runs += entry.Runs
+		hints += entry.Hints
+
+		sessions, clock := "-", "-"
+		if len(entry.Sessions) > 0 {
+			spent := entry.TimeSpent(now)
+			total += spent
+			tracked++
+			sessions, clock = fmt.Sprint(len(entry.Sessions)), formatDuration(spent)
+			if entry.Open() {
+				clock += " (running)"
+			}
+		}
+		green := "-"
+		if d, ok := entry.TimeToGreen(); ok {
+			green = formatDuration(d)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%d\n",
+			e.ID, statusLabel(entry.Status), sessions, clock, dashIfZero(entry.Runs), green, entry.Hints)
+	}
+	fmt.Fprintf(tw, "total\t\t\t%s\t%d\t\t%d\n", formatDuration(total), runs, hints)
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	if tracked == 0 {
+		fmt.Fprintln(a.stdout, "\nNo time recorded yet. `learngo start <exercise>` starts the clock.")
+	}
+	fmt.Fprintln(a.stdout, "\nTO GREEN runs from your first failing `learngo run` to the first one where every test passes.")
+	return nil
+}
+
+func dashIfZero(n int) string {
+	if n == 0 {
+		return "-"
+	}
+	return fmt.Sprint(n)
+}
+
+// formatDuration prints a duration the way people say it: "1h05m", "12m".
+func formatDuration(d time.Duration) string {
+	d = d.Round(time.Minute)
+	h, m := int(d.Hours()), int(d.Minutes())%60
+	if h > 0 {
+		return fmt.Sprintf("%dh%02dm", h, m)
+	}
+	return fmt.Sprintf("%dm", m)
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/imgarylai/learn-go/internal/runner"
+)
+
+func TestStatsTracksSessions(t *testing.T) {
+	a := newTestApp(t)
+	now := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+	a.now = func() time.Time { return now }
+
+	runApp(t, a, "start", "04")
+	now = now.Add(40 * time.Minute)
+	runApp(t, a, "pause", "04")
+	now = now.Add(3 * time.Hour) // a break doesn't count
+	runApp(t, a, "start", "04")
+	now = now.Add(35 * time.Minute)
+	runApp(t, a, "done", "04")
+	runApp(t, a, "start", "05")
+	now = now.Add(5 * time.Minute)
+
+	code, stdout, _ := runApp(t, a, "stats")
+	if code != 0 {
+		t.Fatalf("code %d", code)
+	}
+	for _, want := range []string{"1h15m", "5m (running)", "1h20m"} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("expected %q in:\n%s", want, stdout)
+		}
+	}
+}
+
+func TestStatsTimeToGreen(t *testing.T) {
+	a := newTestApp(t)
+	now := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+	a.now = func() time.Time { return now }
+
+	fakeResults(a, map[string]runner.Result{"exercises/04-collections": failing("TestSum")})
+	runApp(t, a, "run", "04")
+	now = now.Add(20 * time.Minute)
+	runApp(t, a, "run", "04")
+	runApp(t, a, "hint", "04")
+	now = now.Add(25 * time.Minute)
+	fakeResults(a, nil)
+	runApp(t, a, "run", "04")
+
+	_, stdout, _ := runApp(t, a, "stats")
+	line := ""
+	for l := range strings.Lines(stdout) {
+		if strings.HasPrefix(l, "04-collections") {
+			line = l
+		}
+	}
+	if got := strings.Fields(line); len(got) != 7 || got[4] != "3" || got[5] != "45m" || got[6] != "1" {
+		t.Errorf("got %q, want 3 runs, 45m to green and 1 hint:\n%s", line, stdout)
+	}
+}
+
+func TestPauseWithoutStart(t *testing.T) {
+	_, stdout, _ := runCLI(t, "pause", "04")
+	if !strings.Contains(stdout, "isn't being timed") {
+		t.Errorf("got %q", stdout)
+	}
+}
+
+func TestStatsEmpty(t *testing.T) {
+	_, stdout, _ := runCLI(t, "stats")
+	if !strings.Contains(stdout, "No time recorded yet") {
+		t.Errorf("got:\n%s", stdout)
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	tests := map[time.Duration]string{
+		0:                             "0m",
+		90 * time.Second:              "2m",
+		time.Hour + 5*time.Minute:     "1h05m",
+		26*time.Hour + 59*time.Minute: "26h59m",
+	}
+	for d, want := range tests {
+		if got := formatDuration(d); got != want {
+			t.Errorf("formatDuration(%v): got %q, want %q", d, got, want)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/imgarylai/learn-go/internal/leaderboard"
+	"github.com/imgarylai/learn-go/internal/registry"
+	"github.com/imgarylai/learn-go/internal/runner"
+)
+
+func TestSubmit(t *testing.T) {
+	store, err := leaderboard.Open(":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+	srv := httptest.NewServer(leaderboard.NewHandler(store))
+	defer srv.Close()
+
+	a := newTestApp(t)
+	fakeResults(a, map[string]runner.Result{
+		"exercises/04-collections": failing("TestSum"),
+	})
+	code, stdout, stderr := runApp(t, a, "submit", "--server", srv.URL+"/", "--handle", "team-rocket")
+	if code != 0 {
+		t.Fatalf("exit code %d, stderr: %s", code, stderr)
+	}
+	if !strings.Contains(stdout, "team-rocket") || !strings.Contains(stdout, "#1 of 1") {
+		t.Errorf("stdout: %s", stdout)
+	}
+
+	entries, err := store.Leaderboard(t.Context(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Every exercise but 04 passes its one fake test.
+	n := len(entries)
+	if n != 1 || entries[0].Passed != entries[0].Total-1 || entries[0].Done != entries[0].Total-1 {
+		t.Errorf("leaderboard: %+v", entries)
+	}
+}
+
+func TestSubmitNeedsServer(t *testing.T) {
+	t.Setenv("LEARNGO_SERVER", "")
+	code, _, stderr := runCLI(t, "submit")
+	if code != 1 || !strings.Contains(stderr, "--server") {
+		t.Errorf("code %d, stderr %q", code, stderr)
+	}
+}
+
+func TestSubmitHandleIsSaved(t *testing.T) {
+	a := newTestApp(t)
+	h, err := a.savedHandle()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(h, "gopher-") {
+		t.Errorf("handle %q doesn't start with gopher-", h)
+	}
+	again, err := a.savedHandle()
+	if err != nil || again != h {
+		t.Errorf("second call: got %q, %v; want %q again", again, err, h)
+	}
+	data, err := os.ReadFile(filepath.Join(filepath.Dir(a.progressPath), handleFile))
+	if err != nil || strings.TrimSpace(string(data)) != h {
+		t.Errorf("saved handle: got %q, %v; want %q", data, err, h)
+	}
+
+	// Another install gets its own.
+	if other, err := newTestApp(t).savedHandle(); err != nil || other == h {
+		t.Errorf("another install's handle: got %q, %v; want one other than %q", other, err, h)
+	}
+}
+
+func TestSubmitLeavesOutEditedTests(t *testing.T) {
+	store, err := leaderboard.Open(":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+	srv := httptest.NewServer(leaderboard.NewHandler(store))
+	defer srv.Close()
+
+	a := shippedTestsRoot(t)
+	writeFile(t, filepath.Join(a.root, "exercises", "04-collections", "collections_test.go"), "package collections\n")
+	fakeResults(a, nil)
+	code, stdout, stderr := runApp(t, a, "submit", "--server", srv.URL, "--handle", "team-rocket")
+	if code != 0 {
+		t.Fatalf("exit code %d, stderr: %s", code, stderr)
+	}
+	if !strings.Contains(stdout, "modified exercises/04-collections/collections_test.go") {
+		t.Errorf("stdout doesn't say which tests were edited:\n%s", stdout)
+	}
+
+	entries, err := store.Leaderboard(t.Context(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Every exercise but 04 is sent, each passing its one fake test.
+	if want := len(registry.All()) - 1; len(entries) != 1 || entries[0].Total != want || entries[0].Passed != want {
+		t.Errorf("leaderboard: %+v; want %d of %d passing", entries, want, want)
+	}
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/imgarylai/learn-go/internal/achievements"
+	"github.com/imgarylai/learn-go/internal/progress"
+	"github.com/imgarylai/learn-go/internal/registry"
+	"github.com/imgarylai/learn-go/internal/runner"
+)
+
+// runTestAll implements `learngo test-all`.
+//
+// Unlike `go test ./...`, it only fails for exercises you've started:
+// the untouched stubs are expected to fail and would drown out the
+// failures you actually care about.
+func runTestAll(a *app, args []string) error {
+	if len(args) > 0 {
+		return errUsage
+	}
+	prog, path, err := a.loadProgress()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	exercises := registry.All()
+	results := make([]runner.Result, len(exercises))
+	var badges []achievements.Badge
+	for i, e := range exercises {
+		res, err := a.test(ctx, e.Dir())
+		if err != nil {
+			return fmt.Errorf("%s: %w", e.ID, err)
+		}
+		results[i] = res
+		recordRun(prog, e.ID, res, a.clock())
+		badges = append(badges, achievements.Award(prog, achievements.Event{Exercise: e.ID, Tests: &results[i]}, a.clock())...)
+	}
+	if err := prog.Save(path); err != nil {
+		return err
+	}
+
+	tw := tabwriter.NewWriter(a.stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "EXERCISE\tSTATUS\tPASS\tFAIL\tSKIP")
+	var total [3]int
+	for i, e := range exercises {
+		res := results[i]
+		pass, fail, skip := res.Counts()
+		total[0], total[1], total[2] = total[0]+pass, total[1]+fail, total[2]+skip
+		if res.BuildFailed {
+			fmt.Fprintf(tw, "%s\t%s\t-\t-\t-\n", e.ID, statusLabel(prog.Status(e.ID)))
+			continue
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%d\t%d\n", e.ID, statusLabel(prog.Status(e.ID)), pass, fail, skip)
+	}
+	fmt.Fprintf(tw, "total\t\t%d\t%d\t%d\n", total[0], total[1], total[2])
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	failedStarted := 0
+	for i, e := range exercises {
+		res := results[i]
+		if res.OK() {
+			continue
+		}
+		if prog.Status(e.ID) == progress.Started {
+			failedStarted++
+		}
+
+		if res.BuildFailed {
+			fmt.Fprintf(a.stdout, "\n%s: build failed\n", e.ID)
+			for _, line := range res.BuildOutput {
+				fmt.Fprintf(a.stdout, "    %s\n", line)
+			}
+			continue
+		}
+		fmt.Fprintf(a.stdout, "\n%s:\n", e.ID)
+		for _, name := range res.Failed() {
+			fmt.Fprintf(a.stdout, "  - %s\n", name)
+		}
+	}
+
+	a.announce(badges)
+	if failedStarted > 0 {
+		fmt.Fprintf(a.stdout, "\n%d started exercise(s) still failing.\n", failedStarted)
+		return exitError{code: 1}
+	}
+	return nil
+}
+
+func statusLabel(s progress.Status) string {
+	if s == progress.NotStarted {
+		return "-"
+	}
+	return string(s)
+}
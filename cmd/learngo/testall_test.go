@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/imgarylai/learn-go/internal/runner"
+)
+
+// fakeResults makes a.runTests return canned results keyed by exercise
+// directory. Exercises not in the map pass with a single test.
+func fakeResults(a *app, results map[string]runner.Result) {
+	a.runTests = func(_ context.Context, _, dir string, _ ...string) (runner.Result, error) {
+		if res, ok := results[dir]; ok {
+			return res, nil
+		}
+		return runner.Result{Tests: []runner.Test{{Name: "TestOK", Status: runner.Pass}}}, nil
+	}
+}
+
+func failing(names ...string) runner.Result {
+	var res runner.Result
+	for _, n := range names {
+		res.Tests = append(res.Tests, runner.Test{Name: n, Status: runner.Fail})
+	}
+	return res
+}
+
+func TestTestAllIgnoresUnstartedFailures(t *testing.T) {
+	a := newTestApp(t)
+	fakeResults(a, map[string]runner.Result{
+		"exercises/04-collections": failing("TestSum", "TestMax"),
+	})
+
+	code, stdout, _ := runApp(t, a, "test-all")
+	if code != 0 {
+		t.Errorf("exit code: got %d, want 0 (04 isn't started)", code)
+	}
+	for _, want := range []string{"04-collections:", "TestSum", "TestMax", "total"} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("expected %q in output:\n%s", want, stdout)
+		}
+	}
+}
+
+func TestTestAllFailsForStartedExercise(t *testing.T) {
+	a := newTestApp(t)
+	fakeResults(a, map[string]runner.Result{
+		"exercises/04-collections": failing("TestSum"),
+	})
+
+	if code, _, stderr := runApp(t, a, "start", "collections"); code != 0 {
+		t.Fatalf("start: code %d, stderr %q", code, stderr)
+	}
+	code, stdout, _ := runApp(t, a, "test-all")
+	if code != 1 {
+		t.Errorf("exit code: got %d, want 1", code)
+	}
+	if !strings.Contains(stdout, "1 started exercise(s) still failing") {
+		t.Errorf("missing summary line:\n%s", stdout)
+	}
+}
+
+func TestTestAllReportsBuildFailures(t *testing.T) {
+	a := newTestApp(t)
+	fakeResults(a, map[string]runner.Result{
+		"exercises/02-functions": {BuildFailed: true, BuildOutput: []string{"./functions.go:3:1: syntax error"}},
+	})
+
+	_, stdout, _ := runApp(t, a, "test-all")
+	if !strings.Contains(stdout, "build failed") || !strings.Contains(stdout, "syntax error") {
+		t.Errorf("build failure not reported:\n%s", stdout)
+	}
+}
+
+func TestStartUnknownExercise(t *testing.T) {
+	code, _, stderr := runCLI(t, "start", "99-nope")
+	if code != 1 || !strings.Contains(stderr, "unknown exercise") {
+		t.Errorf("got code %d, stderr %q", code, stderr)
+	}
+}
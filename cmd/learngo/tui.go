@@ -0,0 +1,48 @@
+package main
+
+import (
+	"context"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/imgarylai/learn-go/internal/grade"
+	"github.com/imgarylai/learn-go/internal/progress"
+	"github.com/imgarylai/learn-go/internal/registry"
+	"github.com/imgarylai/learn-go/internal/runner"
+	"github.com/imgarylai/learn-go/internal/tui"
+)
+
+// runTUI implements `learngo tui`, a full-screen exercise browser.
+func runTUI(a *app, args []string) error {
+	if len(args) > 0 {
+		return errUsage
+	}
+	root, err := a.rootDir()
+	if err != nil {
+		return err
+	}
+	prog, _, err := a.loadProgress()
+	if err != nil {
+		return err
+	}
+
+	exercises := registry.All()
+	status := make(map[string]progress.Status, len(exercises))
+	for _, e := range exercises {
+		status[e.ID] = prog.Status(e.ID)
+	}
+
+	limits, err := runner.LimitsFromEnv()
+	if err != nil {
+		return err
+	}
+	run := func(ctx context.Context, dir string, onOutput func(string), args ...string) (runner.Result, error) {
+		return runner.Stream(ctx, root, dir, onOutput, append(limits.Args(), args...)...)
+	}
+	list := func(e registry.Exercise) ([]string, error) {
+		return grade.TestNames(e.DirIn(root))
+	}
+	p := tea.NewProgram(tui.New(exercises, status, run, list), tea.WithAltScreen(), tea.WithOutput(a.stdout))
+	_, err = p.Run()
+	return err
+}
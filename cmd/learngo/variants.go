@@ -0,0 +1,177 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/imgarylai/learn-go/internal/bench"
+	"github.com/imgarylai/learn-go/internal/registry"
+	"github.com/imgarylai/learn-go/internal/solutions"
+)
+
+// runVariants implements `learngo variants <exercise> [func...]`: for
+// each func that has more than one reference implementation (see
+// solutions.VariantsFile), it runs the func's benchmark against every
+// one of them and prints them side by side, so you can see what the
+// idiomatic version buys over the obvious one.
+//
+// Like mutate, it swaps the implementations in with `go test -overlay`,
+// so your own files are left alone. Each one has to pass the exercise's
+// tests before its numbers count.
+func runVariants(a *app, args []string) error {
+	fset := flag.NewFlagSet("variants", flag.ContinueOnError)
+	fset.SetOutput(io.Discard)
+	if err := fset.Parse(args); err != nil || fset.NArg() < 1 {
+		return errUsage
+	}
+	e, ok := registry.Lookup(fset.Arg(0))
+	if !ok {
+		return fmt.Errorf("unknown exercise %q (see `learngo list`)", fset.Arg(0))
+	}
+	if e.Pack != "" {
+		return fmt.Errorf("%s comes from the %s pack, which has no reference solution", e.ID, e.Pack)
+	}
+	only := fset.Args()[1:]
+
+	root, err := a.rootDir()
+	if err != nil {
+		return err
+	}
+	dir, err := filepath.Abs(e.DirIn(root))
+	if err != nil {
+		return err
+	}
+	refs, err := findSolutions(dir)
+	if err != nil {
+		return err
+	}
+
+	// Every package of the exercise can have its own variants file.
+	type group struct {
+		pkg   string
+		impls []solutions.Implementation
+	}
+	var groups []group
+	for _, pkg := range slices.Sorted(maps.Keys(refs)) {
+		variants, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(pkg), solutions.VariantsFile))
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		impls, err := solutions.Implementations(refs[pkg], variants)
+		if err != nil {
+			return fmt.Errorf("%s: %w", filepath.Join(e.Dir(), pkg), err)
+		}
+		for _, fn := range funcsOf(impls) {
+			if len(only) > 0 && !slices.Contains(only, fn) {
+				continue
+			}
+			groups = append(groups, group{pkg, slices.DeleteFunc(slices.Clone(impls), func(im solutions.Implementation) bool { return im.Func != fn })})
+		}
+	}
+	if len(groups) == 0 {
+		if len(only) > 0 {
+			return fmt.Errorf("%s has no variants of %s", e.ID, strings.Join(only, ", "))
+		}
+		fmt.Fprintf(a.stdout, "%s has only one reference solution for each func.\n", e.ID)
+		return nil
+	}
+
+	tmp, err := os.MkdirTemp("", "learngo-variants-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmp)
+
+	ctx := context.Background()
+	for i, g := range groups {
+		if i > 0 {
+			fmt.Fprintln(a.stdout)
+		}
+		fn := g.impls[0].Func
+		fmt.Fprintf(a.stdout, "%s in %s:\n", fn, e.ID)
+		pattern := "^Benchmark" + regexp.QuoteMeta(strings.ReplaceAll(fn, ".", "")) + "$"
+
+		results := make([]bench.Result, len(g.impls))
+		for j, im := range g.impls {
+			fmt.Fprintf(a.stdout, "  %-12s %s\n", im.Label, summary(im.Doc))
+
+			swapped := maps.Clone(refs)
+			swapped[g.pkg] = im.Src
+			base, err := stubOverlay(e.ID, dir, tmp, swapped)
+			if err != nil {
+				return err
+			}
+			overlay, err := writeOverlay(tmp, fmt.Sprintf("variant-%d-%d", i, j), base, "", nil)
+			if err != nil {
+				return err
+			}
+			res, err := a.test(ctx, e.Dir(), "-overlay="+overlay, "-vet=off")
+			if err != nil {
+				return err
+			}
+			if !res.OK() {
+				return fmt.Errorf("%s (%s) fails the exercise's tests", fn, im.Label)
+			}
+			rs, err := a.benchmark(ctx, e.Dir(), pattern, "-overlay="+overlay)
+			if err != nil {
+				return err
+			}
+			if len(rs) == 0 {
+				return fmt.Errorf("%s has no benchmark matching %s", e.ID, pattern)
+			}
+			results[j] = rs[0]
+		}
+
+		fmt.Fprintln(a.stdout)
+		tw := tabwriter.NewWriter(a.stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, "VARIANT\tNS/OP\tB/OP\tALLOCS/OP\tVS. REFERENCE\t")
+		ref := results[0]
+		for j, im := range g.impls {
+			r := results[j]
+			vs := "-"
+			if !im.Reference && ref.NsPerOp > 0 {
+				vs = fmt.Sprintf("%.2fx time, %+d allocs", r.NsPerOp/ref.NsPerOp, r.AllocsPerOp-ref.AllocsPerOp)
+			}
+			fmt.Fprintf(tw, "%s\t%.2f\t%d\t%d\t%s\t\n", im.Label, r.NsPerOp, r.BytesPerOp, r.AllocsPerOp, vs)
+		}
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// funcsOf lists the funcs impls covers, in order.
+func funcsOf(impls []solutions.Implementation) []string {
+	var funcs []string
+	for _, im := range impls {
+		if !slices.Contains(funcs, im.Func) {
+			funcs = append(funcs, im.Func)
+		}
+	}
+	return funcs
+}
+
+// summary is the first paragraph of a doc comment, on one line,
+// without the "5. " solution.go.txt numbers its funcs with.
+func summary(doc string) string {
+	para, _, _ := strings.Cut(doc, "\n\n")
+	para = numbered.ReplaceAllString(para, "")
+	return strings.Join(strings.Fields(para), " ")
+}
+
+var numbered = regexp.MustCompile(`^\d+\.\s+`)
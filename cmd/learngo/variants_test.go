@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/imgarylai/learn-go/internal/bench"
+	"github.com/imgarylai/learn-go/internal/runner"
+)
+
+func TestVariants(t *testing.T) {
+	a := newTestApp(t)
+	// The concat variant is the slow one; tell it apart by its source.
+	seen := fakeMutationRuns(t, a, func(string) bool { return false })
+	var patterns []string
+	a.runBench = func(_ context.Context, _, _, pattern string, args ...string) ([]bench.Result, error) {
+		patterns = append(patterns, pattern)
+		src := (*seen)[len(*seen)-1]
+		if strings.Contains(src, "out +=") {
+			return []bench.Result{{Name: "BenchmarkCaesar", NsPerOp: 3000, AllocsPerOp: 900}}, nil
+		}
+		return []bench.Result{{Name: "BenchmarkCaesar", NsPerOp: 100, AllocsPerOp: 1}}, nil
+	}
+
+	code, stdout, stderr := runApp(t, a, "variants", "13")
+	if code != 0 {
+		t.Fatalf("exit %d: %s", code, stderr)
+	}
+	for _, want := range []string{"Caesar in 13-string-algorithms:", "map ", "concat ", "builder ", "30.00x time, +899 allocs"} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("missing %q in:\n%s", want, stdout)
+		}
+	}
+	if len(patterns) != 3 || patterns[0] != "^BenchmarkCaesar$" {
+		t.Errorf("benchmark patterns: %q", patterns)
+	}
+}
+
+func TestVariantsMustPass(t *testing.T) {
+	a := newTestApp(t)
+	fakeMutationRuns(t, a, func(src string) bool { return strings.Contains(src, "b.Grow") })
+	a.runBench = func(context.Context, string, string, string, ...string) ([]bench.Result, error) {
+		return []bench.Result{{Name: "BenchmarkCaesar", NsPerOp: 100}}, nil
+	}
+	code, _, stderr := runApp(t, a, "variants", "13", "Caesar")
+	if code != 1 || !strings.Contains(stderr, "Caesar (builder) fails the exercise's tests") {
+		t.Errorf("exit %d: %s", code, stderr)
+	}
+}
+
+func TestVariantsNone(t *testing.T) {
+	a := newTestApp(t)
+	a.runTests = func(context.Context, string, string, ...string) (runner.Result, error) {
+		t.Fatal("nothing to test")
+		return runner.Result{}, nil
+	}
+	if _, stdout, _ := runApp(t, a, "variants", "04"); !strings.Contains(stdout, "only one reference solution") {
+		t.Errorf("04: %s", stdout)
+	}
+	if code, _, stderr := runApp(t, a, "variants", "13", "IsPalindrome"); code != 1 || !strings.Contains(stderr, "no variants of IsPalindrome") {
+		t.Errorf("IsPalindrome: exit %d: %s", code, stderr)
+	}
+}
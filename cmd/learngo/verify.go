@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/imgarylai/learn-go/internal/achievements"
+	"github.com/imgarylai/learn-go/internal/integrity"
+	"github.com/imgarylai/learn-go/internal/progress"
+	"github.com/imgarylai/learn-go/internal/registry"
+)
+
+// runVerify implements `learngo verify <exercise>`: the honest way to
+// finish an exercise. It checks the tests are the ones that shipped,
+// runs them, and marks the exercise done only if every one passes.
+func runVerify(a *app, args []string) error {
+	if len(args) != 1 {
+		return errUsage
+	}
+	e, ok := registry.Lookup(args[0])
+	if !ok {
+		return fmt.Errorf("unknown exercise %q (see `learngo list`)", args[0])
+	}
+	if err := a.checkTests(e); err != nil {
+		return err
+	}
+
+	res, err := a.test(context.Background(), e.Dir())
+	if err != nil {
+		return err
+	}
+	prog, path, err := a.loadProgress()
+	if err != nil {
+		return err
+	}
+	recordRun(prog, e.ID, res, a.clock())
+	if res.OK() {
+		pe := prog.Get(e.ID)
+		pe.Status = progress.Done
+		pe.EndSession(a.clock())
+	}
+	badges := achievements.Award(prog, achievements.Event{Exercise: e.ID, Tests: &res}, a.clock())
+	if err := prog.Save(path); err != nil {
+		return err
+	}
+
+	printRun(a.stdout, e.ID, res, false)
+	if !res.OK() {
+		fmt.Fprintf(a.stdout, "\n%s isn't done yet; fix the failing tests and verify again.\n", e.ID)
+		return exitError{code: 1}
+	}
+	fmt.Fprintf(a.stdout, "\nVerified %s and marked it as done. Run `learngo next` to see what's unlocked.\n", e.ID)
+	a.announce(badges)
+	return nil
+}
+
+// checkTests makes sure e's tests haven't been edited, so passing them
+// means something. If they have, it lists the files and how to restore
+// them, and returns exit status 1. Exercises from a pack carry no
+// checksums and always pass.
+func (a *app) checkTests(e registry.Exercise) error {
+	m, err := integrity.Load()
+	if err != nil {
+		return err
+	}
+	root, err := a.rootDir()
+	if err != nil {
+		return err
+	}
+	changes, err := m.Check(e.ID, e.DirIn(root))
+	if errors.Is(err, integrity.ErrNoManifest) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+
+	fmt.Fprintf(a.stdout, "The tests of %s have been changed, so passing them doesn't count:\n", e.ID)
+	var restore, remove []string
+	for _, c := range changes {
+		name := path.Join(e.Dir(), c.File)
+		fmt.Fprintf(a.stdout, "  %-9s%s\n", c.Kind, name)
+		if c.Kind == "added" {
+			remove = append(remove, name)
+		} else {
+			restore = append(restore, name)
+		}
+	}
+	if len(restore) > 0 {
+		fmt.Fprintf(a.stdout, "Restore them with: git checkout -- %s\n", strings.Join(restore, " "))
+	}
+	if len(remove) > 0 {
+		fmt.Fprintf(a.stdout, "Remove the new ones with: rm %s\n", strings.Join(remove, " "))
+	}
+	return exitError{code: 1}
+}
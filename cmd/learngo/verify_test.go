@@ -0,0 +1,90 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/imgarylai/learn-go/internal/integrity"
+	"github.com/imgarylai/learn-go/internal/runner"
+)
+
+// shippedTests copies 04-collections' real tests into a scratch root.
+func shippedTests(t *testing.T) (*app, string) {
+	t.Helper()
+	a, dir := scratchRoot(t)
+	m, err := integrity.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name := range m["04-collections"] {
+		data, err := os.ReadFile(filepath.Join("..", "..", "exercises", "04-collections", name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		writeFile(t, filepath.Join(dir, name), string(data))
+	}
+	return a, dir
+}
+
+func TestVerifyMarksDone(t *testing.T) {
+	a, _ := shippedTests(t)
+	fakeResults(a, nil)
+
+	code, stdout, stderr := runApp(t, a, "verify", "04")
+	if code != 0 || !strings.Contains(stdout, "Verified 04-collections") {
+		t.Fatalf("exit %d:\n%s%s", code, stdout, stderr)
+	}
+	prog, _, err := a.loadProgress()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if prog.Status("04-collections") != "done" {
+		t.Error("04-collections should be done")
+	}
+}
+
+func TestVerifyFailingTests(t *testing.T) {
+	a, _ := shippedTests(t)
+	fakeResults(a, map[string]runner.Result{"exercises/04-collections": failing("TestSum")})
+
+	code, stdout, _ := runApp(t, a, "verify", "04")
+	if code != 1 || !strings.Contains(stdout, "isn't done yet") {
+		t.Errorf("exit %d:\n%s", code, stdout)
+	}
+}
+
+func TestEditedTestsAreRefused(t *testing.T) {
+	a, dir := shippedTests(t)
+	fakeResults(a, nil)
+	writeFile(t, filepath.Join(dir, "collections_test.go"), "package collections\n")
+	writeFile(t, filepath.Join(dir, "main_test.go"), "package collections\n")
+
+	for _, cmd := range []string{"verify", "done"} {
+		code, stdout, _ := runApp(t, a, cmd, "04")
+		if code != 1 {
+			t.Errorf("%s: exit %d, want 1", cmd, code)
+		}
+		for _, want := range []string{
+			"modified exercises/04-collections/collections_test.go",
+			"added    exercises/04-collections/main_test.go",
+			"git checkout -- exercises/04-collections/collections_test.go",
+			"rm exercises/04-collections/main_test.go",
+		} {
+			if !strings.Contains(stdout, want) {
+				t.Errorf("%s: output is missing %q:\n%s", cmd, want, stdout)
+			}
+		}
+	}
+	prog, _, err := a.loadProgress()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if prog.Status("04-collections") == "done" {
+		t.Error("04-collections was marked done with edited tests")
+	}
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+
+	"github.com/imgarylai/learn-go/internal/registry"
+)
+
+// runWatch implements `learngo watch [--debounce d] <exercise>`, the
+// `jest --watch` of this repo: it runs the exercise's tests, then runs
+// them again every time you save a file in it, until you press Ctrl-C.
+func runWatch(a *app, args []string) error {
+	fs := flag.NewFlagSet("watch", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	debounce := fs.Duration("debounce", 200*time.Millisecond, "wait this long after a change for more changes")
+	if err := fs.Parse(args); err != nil || fs.NArg() != 1 || *debounce < 0 {
+		return errUsage
+	}
+	e, ok := registry.Lookup(fs.Arg(0))
+	if !ok {
+		return fmt.Errorf("unknown exercise %q (see `learngo list`)", fs.Arg(0))
+	}
+	root, err := a.rootDir()
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	return a.watch(ctx, e, e.DirIn(root), *debounce)
+}
+
+// watch reruns e's tests whenever a file under dir changes, until ctx is
+// done. Editors often write a file in several steps (truncate, write,
+// rename), so it waits for debounce without further changes first.
+func (a *app) watch(ctx context.Context, e registry.Exercise, dir string, debounce time.Duration) error {
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	defer w.Close()
+	// Watches aren't recursive, so every package directory gets one.
+	err = filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || !d.IsDir() {
+			return err
+		}
+		return w.Add(p)
+	})
+	if err != nil {
+		return err
+	}
+
+	run := func(reason string) error {
+		fmt.Fprintf(a.stdout, "\n[%s] %s\n", a.clock().Format("15:04:05"), reason)
+		res, err := a.test(ctx, e.Dir())
+		if err != nil {
+			return err
+		}
+		printRun(a.stdout, e.ID, res, true)
+		fmt.Fprintln(a.stdout, "Watching for changes. Press Ctrl-C to stop.")
+		return nil
+	}
+	if err := run("watching " + e.ID); err != nil {
+		return err
+	}
+
+	var (
+		timer   <-chan time.Time
+		changed string
+	)
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case ev, ok := <-w.Events:
+			if !ok {
+				return nil
+			}
+			if info, err := os.Stat(ev.Name); err == nil && info.IsDir() && ev.Has(fsnotify.Create) {
+				w.Add(ev.Name) // a new subpackage
+				continue
+			}
+			if !watched(ev) {
+				continue
+			}
+			if rel, err := filepath.Rel(dir, ev.Name); err == nil {
+				changed = filepath.ToSlash(rel)
+			}
+			timer = time.After(debounce)
+		case err, ok := <-w.Errors:
+			if !ok {
+				return nil
+			}
+			return err
+		case <-timer:
+			timer = nil
+			if err := run(changed + " changed"); err != nil {
+				if ctx.Err() != nil {
+					return nil // Ctrl-C during a run
+				}
+				return err
+			}
+		}
+	}
+}
+
+// watched reports whether ev should trigger a test run: a Go file or
+// test data was written, created, removed or renamed. Editor swap and
+// backup files (.foo.swp, foo.go~) and permission changes don't count.
+func watched(ev fsnotify.Event) bool {
+	if ev.Op == fsnotify.Chmod {
+		return false
+	}
+	name := filepath.Base(ev.Name)
+	if strings.HasPrefix(name, ".") || strings.HasSuffix(name, "~") {
+		return false
+	}
+	return filepath.Ext(name) == ".go" || strings.Contains(filepath.ToSlash(ev.Name), "/testdata/")
+}
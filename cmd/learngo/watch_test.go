@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+
+	"github.com/imgarylai/learn-go/internal/registry"
+	"github.com/imgarylai/learn-go/internal/runner"
+)
+
+func TestWatchRerunsOnSave(t *testing.T) {
+	a, dir := scratchRoot(t)
+	runs := make(chan struct{}, 10)
+	a.runTests = func(context.Context, string, string, ...string) (runner.Result, error) {
+		runs <- struct{}{}
+		return failing("TestSum"), nil
+	}
+	e, _ := registry.Lookup("04")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- a.watch(ctx, e, dir, 10*time.Millisecond) }()
+
+	wait := func(what string) {
+		t.Helper()
+		select {
+		case <-runs:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("no test run %s", what)
+		}
+	}
+	wait("at start")
+	writeFile(t, filepath.Join(dir, "collections.go"), "package collections\n")
+	wait("after saving collections.go")
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	stdout := a.stdout.(interface{ String() string }).String()
+	for _, want := range []string{"watching 04-collections", "collections.go changed", "FAIL  04-collections", "  - TestSum"} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("missing %q in output:\n%s", want, stdout)
+		}
+	}
+}
+
+func TestWatchedEvents(t *testing.T) {
+	for _, tt := range []struct {
+		ev   fsnotify.Event
+		want bool
+	}{
+		{fsnotify.Event{Name: "/x/collections.go", Op: fsnotify.Write}, true},
+		{fsnotify.Event{Name: "/x/helpers.go", Op: fsnotify.Create}, true},
+		{fsnotify.Event{Name: "/x/testdata/sales.csv", Op: fsnotify.Write}, true},
+		{fsnotify.Event{Name: "/x/collections.go", Op: fsnotify.Chmod}, false},
+		{fsnotify.Event{Name: "/x/.collections.go.swp", Op: fsnotify.Write}, false},
+		{fsnotify.Event{Name: "/x/collections.go~", Op: fsnotify.Write}, false},
+		{fsnotify.Event{Name: "/x/notes.md", Op: fsnotify.Write}, false},
+	} {
+		if got := watched(tt.ev); got != tt.want {
+			t.Errorf("watched(%v) = %v, want %v", tt.ev, got, tt.want)
+		}
+	}
+}
+
+func TestWatchUsage(t *testing.T) {
+	if code, _, _ := runCLI(t, "watch"); code != 2 {
+		t.Errorf("got code %d, want 2", code)
+	}
+	if code, _, stderr := runCLI(t, "watch", "99-nope"); code != 1 || !strings.Contains(stderr, "unknown exercise") {
+		t.Errorf("got code %d, stderr %q", code, stderr)
+	}
+}
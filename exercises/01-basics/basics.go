@@ -1,3 +1,5 @@
+//go:build !solutions
+
 package basics
 
 // Exercise 1: Variables and Types
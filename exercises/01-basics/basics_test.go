@@ -1,8 +1,9 @@
 package basics
 
 import (
-	"math"
 	"testing"
+
+	"github.com/imgarylai/learn-go/internal/testutil"
 )
 
 func TestGetGreeting(t *testing.T) {
@@ -78,7 +79,7 @@ func TestGetCircleArea(t *testing.T) {
 	for _, tc := range tests {
 		result := GetCircleArea(tc.radius)
 		// Allow small floating point difference
-		if math.Abs(result-tc.expected) > 0.0001 {
+		if !testutil.Near(result, tc.expected, 0.0001) {
 			t.Errorf("GetCircleArea(%f): got %f, want %f", tc.radius, result, tc.expected)
 		}
 	}
@@ -0,0 +1,92 @@
+//go:build !solutions
+
+package basics
+
+// Exercise 1, part 2: Parsing and formatting numbers
+//
+// JS is forgiving: Number("abc") is NaN, parseInt("42px") is 42, and
+// nothing throws. Go's strconv package returns an error instead, and you
+// decide what to do with it. This is error-as-value in practice:
+//
+//	n, err := strconv.Atoi(s)
+//	if err != nil {
+//		return 0, err
+//	}
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// ErrNegativeAge is returned by ParseAge for ages below zero.
+var ErrNegativeAge = errors.New("age must not be negative")
+
+// 8. Parse an integer
+// In JS: parseInt(s, 10), but "42px" and "" must be errors here
+func ParseAge(s string) (int, error) {
+	// TODO: use strconv.Atoi and return its error as is
+	// A negative age returns ErrNegativeAge
+	// With an error, the int is 0
+	return 0, nil
+}
+
+// 9. Parse a float
+// In JS: parseFloat(s)
+func ParsePrice(s string) (float64, error) {
+	// TODO: use strconv.ParseFloat(s, 64)
+	return 0, nil
+}
+
+// 10. Parse a boolean
+// strconv.ParseBool accepts 1, t, T, TRUE, true, True and the same for false.
+func ParseFlag(s string) (bool, error) {
+	// TODO: use strconv.ParseBool
+	return false, nil
+}
+
+// 11. Format a float with a fixed number of decimals
+// In JS: n.toFixed(digits)
+func FormatFixed(f float64, digits int) string {
+	// TODO: use strconv.FormatFloat with the 'f' format
+	return ""
+}
+
+// 12. Integer to binary and hexadecimal
+// In JS: n.toString(2) and n.toString(16)
+func ToBinary(n int64) string {
+	// TODO: use strconv.FormatInt(n, 2)
+	return ""
+}
+
+func ToHex(n int64) string {
+	// TODO: lowercase hex, no 0x prefix
+	return ""
+}
+
+// 13. Parse hexadecimal, with or without a 0x prefix
+// In JS: parseInt("ff", 16) or Number("0xff")
+func ParseHex(s string) (int64, error) {
+	// TODO: strip an optional "0x", then strconv.ParseInt(s, 16, 64)
+	return 0, nil
+}
+
+// 14. Pad with fmt verbs
+// In JS: String(n).padStart(width, "0")
+func ZeroPad(n, width int) string {
+	// TODO: fmt.Sprintf with %0*d takes the width as an argument
+	return ""
+}
+
+// 15. Line up a table row
+// Name left-aligned in 10 columns, quantity right-aligned in 5,
+// price right-aligned in 8 with 2 decimals, separated by "|":
+// "apple     |    3|    1.50"
+func FormatRow(name string, qty int, price float64) string {
+	// TODO: %-10s pads on the right, %5d and %8.2f on the left
+	return ""
+}
+
+// Keep imports used
+var _ = strconv.Itoa
+var _ = fmt.Sprintf
@@ -0,0 +1,141 @@
+package basics
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+)
+
+func TestParseAge(t *testing.T) {
+	for in, want := range map[string]int{"42": 42, "0": 0} {
+		got, err := ParseAge(in)
+		if err != nil || got != want {
+			t.Errorf("ParseAge(%q): got %d, %v; want %d, nil", in, got, err, want)
+		}
+	}
+
+	tests := []struct {
+		in   string
+		want error
+	}{
+		{"", strconv.ErrSyntax},
+		{"42px", strconv.ErrSyntax},
+		{"4.2", strconv.ErrSyntax},
+		{" 42", strconv.ErrSyntax},
+		{"99999999999999999999", strconv.ErrRange},
+		{"-1", ErrNegativeAge},
+	}
+	for _, tt := range tests {
+		if got, err := ParseAge(tt.in); got != 0 || !errors.Is(err, tt.want) {
+			t.Errorf("ParseAge(%q): got %d, %v; want 0, %v", tt.in, got, err, tt.want)
+		}
+	}
+}
+
+func TestParseAgeErrorNamesTheInput(t *testing.T) {
+	_, err := ParseAge("abc")
+	var numErr *strconv.NumError
+	if !errors.As(err, &numErr) {
+		t.Fatalf("got %v (%T), want a *strconv.NumError", err, err)
+	}
+	if numErr.Num != "abc" {
+		t.Errorf("NumError.Num: got %q, want %q", numErr.Num, "abc")
+	}
+}
+
+func TestParsePrice(t *testing.T) {
+	valid := map[string]float64{"1.5": 1.5, "0": 0, "-2.25": -2.25, "1e3": 1000}
+	for in, want := range valid {
+		got, err := ParsePrice(in)
+		if err != nil || got != want {
+			t.Errorf("ParsePrice(%q): got %v, %v; want %v, nil", in, got, err, want)
+		}
+	}
+
+	for _, in := range []string{"", "$1.50", "1,50", "one"} {
+		if _, err := ParsePrice(in); !errors.Is(err, strconv.ErrSyntax) {
+			t.Errorf("ParsePrice(%q): got error %v, want a syntax error", in, err)
+		}
+	}
+}
+
+func TestParseFlag(t *testing.T) {
+	for in, want := range map[string]bool{"true": true, "1": true, "T": true, "false": false, "0": false, "FALSE": false} {
+		got, err := ParseFlag(in)
+		if err != nil || got != want {
+			t.Errorf("ParseFlag(%q): got %v, %v; want %v, nil", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"", "yes", "on", "2"} {
+		if _, err := ParseFlag(in); err == nil {
+			t.Errorf("ParseFlag(%q): expected an error", in)
+		}
+	}
+}
+
+func TestFormatFixed(t *testing.T) {
+	tests := []struct {
+		f      float64
+		digits int
+		want   string
+	}{
+		{3.14159, 2, "3.14"},
+		{2.5, 0, "2"}, // rounds half to even, unlike toFixed
+		{1, 3, "1.000"},
+		{-0.125, 2, "-0.12"},
+	}
+	for _, tt := range tests {
+		if got := FormatFixed(tt.f, tt.digits); got != tt.want {
+			t.Errorf("FormatFixed(%v, %d): got %q, want %q", tt.f, tt.digits, got, tt.want)
+		}
+	}
+}
+
+func TestBaseConversion(t *testing.T) {
+	if got := ToBinary(10); got != "1010" {
+		t.Errorf("ToBinary(10): got %q, want 1010", got)
+	}
+	if got := ToBinary(-5); got != "-101" {
+		t.Errorf("ToBinary(-5): got %q, want -101", got)
+	}
+	if got := ToHex(255); got != "ff" {
+		t.Errorf("ToHex(255): got %q, want ff", got)
+	}
+
+	for _, in := range []string{"ff", "FF", "0xff"} {
+		if got, err := ParseHex(in); err != nil || got != 255 {
+			t.Errorf("ParseHex(%q): got %d, %v; want 255, nil", in, got, err)
+		}
+	}
+	for _, in := range []string{"", "0x", "fg", "0xx1"} {
+		if _, err := ParseHex(in); err == nil {
+			t.Errorf("ParseHex(%q): expected an error", in)
+		}
+	}
+}
+
+func TestZeroPad(t *testing.T) {
+	if got := ZeroPad(42, 5); got != "00042" {
+		t.Errorf("ZeroPad(42, 5): got %q, want 00042", got)
+	}
+	if got := ZeroPad(123456, 3); got != "123456" {
+		t.Errorf("ZeroPad never truncates: got %q", got)
+	}
+}
+
+func TestFormatRow(t *testing.T) {
+	tests := []struct {
+		name  string
+		qty   int
+		price float64
+		want  string
+	}{
+		{"apple", 3, 1.5, "apple     |    3|    1.50"},
+		{"watermelon", 12, 10, "watermelon|   12|   10.00"},
+	}
+	for _, tt := range tests {
+		if got := FormatRow(tt.name, tt.qty, tt.price); got != tt.want {
+			t.Errorf("FormatRow(%q, %d, %v):\ngot  %q\nwant %q", tt.name, tt.qty, tt.price, got, tt.want)
+		}
+	}
+}
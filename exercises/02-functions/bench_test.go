@@ -0,0 +1,29 @@
+package functions
+
+import "testing"
+
+// Benchmarks: `learngo bench 02` runs these, and `learngo bench
+// --solution 02` runs them against the reference solution to compare.
+// Appending to a nil slice in MapInts works, but a slice made with the
+// right length up front allocates once instead of every time it grows.
+
+var benchNums = func() []int {
+	nums := make([]int, 10_000)
+	for i := range nums {
+		nums[i] = i
+	}
+	return nums
+}()
+
+func BenchmarkMapInts(b *testing.B) {
+	double := func(n int) int { return n * 2 }
+	for b.Loop() {
+		MapInts(benchNums, double)
+	}
+}
+
+func BenchmarkSum(b *testing.B) {
+	for b.Loop() {
+		Sum(benchNums...)
+	}
+}
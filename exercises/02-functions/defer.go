@@ -0,0 +1,103 @@
+//go:build !solutions
+
+package functions
+
+// Exercise 2, part 3: Cleaning up with defer
+//
+// defer schedules a call to run when the surrounding function returns,
+// whether it returns normally, early, or by panicking. It's Go's
+// try/finally. Three details trip people up:
+//
+//   - deferred calls run last-in, first-out;
+//   - the arguments of a deferred call are evaluated right away, but the
+//     call runs at the end of the function, not the end of the loop;
+//   - a deferred func can change the named results of its function,
+//     which is the only way to report an error from a deferred Close.
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// Tracker hands out fake resources (think files or connections) and
+// remembers what happened to them, so tests can catch leaks.
+type Tracker struct {
+	Log  []string // "open a", "close a", ... in the order it happened
+	open []string
+}
+
+// Resource is one fake resource from a Tracker.
+type Resource struct {
+	Name     string
+	CloseErr error // what Close returns, to simulate a failed flush
+	tracker  *Tracker
+	closed   bool
+}
+
+// Open opens a resource. You don't need to change this.
+func (t *Tracker) Open(name string) *Resource {
+	t.Log = append(t.Log, "open "+name)
+	t.open = append(t.open, name)
+	return &Resource{Name: name, tracker: t}
+}
+
+// Close closes r. Closing twice is an error, as it is for *os.File.
+// You don't need to change this.
+func (r *Resource) Close() error {
+	if r.closed {
+		return fmt.Errorf("%s: already closed", r.Name)
+	}
+	r.closed = true
+	r.tracker.Log = append(r.tracker.Log, "close "+r.Name)
+	r.tracker.open = slices.DeleteFunc(r.tracker.open, func(n string) bool { return n == r.Name })
+	return r.CloseErr
+}
+
+// Leaked lists the resources that were opened but never closed.
+func (t *Tracker) Leaked() []string {
+	return t.open
+}
+
+// 13. Deferred calls run in reverse
+// Defer three closures that append "first", "second" and "third" to
+// order, in that order. The result shows the order they actually ran.
+func DeferOrder() (order []string) {
+	// TODO: defer func() { order = append(order, "first") }() and so on
+	// The closures can change order because it's a named result
+	return nil
+}
+
+// 14. defer in a loop
+// Process every name: open it, call fn, and close it again BEFORE
+// opening the next one. With thousands of files, keeping them all open
+// until the function returns would run out of file descriptors.
+// Stop at the first error from fn, but still close that resource.
+func ProcessAll(t *Tracker, names []string, fn func(*Resource) error) error {
+	// TODO: a defer directly in the loop body runs when ProcessAll
+	// returns, not when the iteration ends. Move the body into a func
+	// literal (or a helper function) so each defer runs per iteration
+	return nil
+}
+
+// 15. Don't lose the error from Close
+// Run work on r and close r afterwards. If work fails, return its
+// error. If work succeeds but Close fails, return the Close error
+// wrapped as "closing NAME: <err>".
+func UseResource(r *Resource, work func(*Resource) error) (err error) {
+	// TODO: a plain `defer r.Close()` throws its error away. Defer a
+	// closure that calls Close and assigns to err if err is still nil
+	return nil
+}
+
+// 16. A helper that can't leak
+// In JS: try { return fn(r) } finally { r.close() }
+// WithResource opens name, passes it to fn and always closes it, even
+// if fn panics (the panic should still propagate to the caller).
+func WithResource(t *Tracker, name string, fn func(*Resource) error) error {
+	// TODO: open, then reuse UseResource
+	return nil
+}
+
+// Keep imports used
+var _ = errors.Join
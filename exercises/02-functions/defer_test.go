@@ -0,0 +1,128 @@
+package functions
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/imgarylai/learn-go/internal/assert"
+)
+
+// checkNoLeaks fails the test if any resource is still open.
+func checkNoLeaks(t *testing.T, tr *Tracker) {
+	t.Helper()
+	if leaked := tr.Leaked(); len(leaked) > 0 {
+		t.Errorf("leaked resources: %v\nlog: %v", leaked, tr.Log)
+	}
+}
+
+func TestDeferOrder(t *testing.T) {
+	want := []string{"third", "second", "first"}
+	assert.Equal(t, DeferOrder(), want)
+}
+
+func TestProcessAllClosesEachBeforeTheNext(t *testing.T) {
+	var tr Tracker
+	var seen []string
+	err := ProcessAll(&tr, []string{"a", "b", "c"}, func(r *Resource) error {
+		seen = append(seen, r.Name)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ProcessAll failed: %v", err)
+	}
+	assert.Equal(t, seen, []string{"a", "b", "c"}, "what fn saw")
+
+	want := []string{"open a", "close a", "open b", "close b", "open c", "close c"}
+	assert.Equal(t, tr.Log, want, "log")
+	checkNoLeaks(t, &tr)
+}
+
+func TestProcessAllStopsAtFirstError(t *testing.T) {
+	var tr Tracker
+	boom := errors.New("boom")
+	err := ProcessAll(&tr, []string{"a", "b", "c"}, func(r *Resource) error {
+		if r.Name == "b" {
+			return boom
+		}
+		return nil
+	})
+	if !errors.Is(err, boom) {
+		t.Errorf("got error %v, want %v", err, boom)
+	}
+	want := []string{"open a", "close a", "open b", "close b"}
+	assert.Equal(t, tr.Log, want, "log")
+	checkNoLeaks(t, &tr)
+}
+
+func TestUseResource(t *testing.T) {
+	var tr Tracker
+	r := tr.Open("db")
+	ran := false
+	if err := UseResource(r, func(*Resource) error { ran = true; return nil }); err != nil {
+		t.Errorf("got error %v, want nil", err)
+	}
+	if !ran {
+		t.Error("work wasn't called")
+	}
+	checkNoLeaks(t, &tr)
+}
+
+func TestUseResourceReportsCloseError(t *testing.T) {
+	var tr Tracker
+	r := tr.Open("file.txt")
+	flush := errors.New("disk full")
+	r.CloseErr = flush
+
+	err := UseResource(r, func(*Resource) error { return nil })
+	if !errors.Is(err, flush) {
+		t.Fatalf("got error %v, want the Close error %v", err, flush)
+	}
+	if want := "closing file.txt: disk full"; err.Error() != want {
+		t.Errorf("got %q, want %q", err, want)
+	}
+	checkNoLeaks(t, &tr)
+}
+
+func TestUseResourceWorkErrorWins(t *testing.T) {
+	var tr Tracker
+	r := tr.Open("file.txt")
+	r.CloseErr = errors.New("disk full")
+	work := errors.New("bad input")
+
+	if err := UseResource(r, func(*Resource) error { return work }); !errors.Is(err, work) {
+		t.Errorf("got error %v, want the work error %v", err, work)
+	}
+	checkNoLeaks(t, &tr)
+}
+
+func TestWithResource(t *testing.T) {
+	var tr Tracker
+	var name string
+	err := WithResource(&tr, "conn", func(r *Resource) error {
+		name = r.Name
+		return nil
+	})
+	if err != nil || name != "conn" {
+		t.Errorf("got %q, %v; want conn, nil", name, err)
+	}
+	assert.Equal(t, tr.Log, []string{"open conn", "close conn"}, "log")
+	checkNoLeaks(t, &tr)
+}
+
+func TestWithResourceClosesOnPanic(t *testing.T) {
+	var tr Tracker
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("the panic should reach the caller")
+			}
+		}()
+		WithResource(&tr, "conn", func(*Resource) error {
+			panic("something went badly wrong")
+		})
+	}()
+	if len(tr.Log) == 0 {
+		t.Fatal("the resource was never opened")
+	}
+	checkNoLeaks(t, &tr)
+}
@@ -1,3 +1,5 @@
+//go:build !solutions
+
 package functions
 
 // Exercise 2: Functions and Error Handling
@@ -1,15 +1,16 @@
 package functions
 
 import (
-	"reflect"
 	"testing"
+
+	"github.com/imgarylai/learn-go/internal/assert"
 )
 
 func TestDivide(t *testing.T) {
 	tests := []struct {
-		a, b     int
-		wantQ    int
-		wantR    int
+		a, b  int
+		wantQ int
+		wantR int
 	}{
 		{17, 5, 3, 2},
 		{10, 3, 3, 1},
@@ -44,10 +45,13 @@ func TestSafeDivide(t *testing.T) {
 	}
 
 	// Test division by zero
-	_, err = SafeDivide(10, 0)
+	result, err = SafeDivide(10, 0)
 	if err == nil {
 		t.Error("SafeDivide(10, 0): expected error, got nil")
 	}
+	if result != 0 {
+		t.Errorf("SafeDivide(10, 0): got %d with the error, want 0", result)
+	}
 }
 
 func TestGetOperation(t *testing.T) {
@@ -128,16 +132,12 @@ func TestMapInts(t *testing.T) {
 	// Test double
 	result := MapInts([]int{1, 2, 3}, func(n int) int { return n * 2 })
 	expected := []int{2, 4, 6}
-	if !reflect.DeepEqual(result, expected) {
-		t.Errorf("MapInts double: got %v, want %v", result, expected)
-	}
+	assert.Equal(t, result, expected, "MapInts double")
 
 	// Test square
 	result = MapInts([]int{1, 2, 3, 4}, func(n int) int { return n * n })
 	expected = []int{1, 4, 9, 16}
-	if !reflect.DeepEqual(result, expected) {
-		t.Errorf("MapInts square: got %v, want %v", result, expected)
-	}
+	assert.Equal(t, result, expected, "MapInts square")
 
 	// Test empty
 	result = MapInts([]int{}, func(n int) int { return n })
@@ -0,0 +1,54 @@
+//go:build !solutions
+
+package functions
+
+// Exercise 2, part 2: Recursion and memoization
+//
+// Recursion works as in JS, and Go has no tail-call optimization either,
+// so deep recursion costs a stack frame per call. Go's stacks grow as
+// needed, so you rarely overflow, but exponential recursion is still
+// exponential. Caching results (memoization) or rewriting the recursion
+// as a loop fixes that.
+
+// 8. Recursive factorial
+// 0! and 1! are 1; n! = n * (n-1)!
+// uint64 holds up to 20!; treat negative n like 0.
+func Factorial(n int) uint64 {
+	// TODO: a base case, then call Factorial(n-1)
+	return 0
+}
+
+// 9. Recursive Fibonacci: Fib(0) = 0, Fib(1) = 1, Fib(n) = Fib(n-1) + Fib(n-2)
+// Write it the straightforward way. It makes about 1.6^n calls, so
+// Fib(50) already takes minutes.
+func Fib(n int) int {
+	// TODO
+	return 0
+}
+
+// 10. A generic memoizer
+// In JS: const memo = fn => { const cache = new Map(); return x => ... }
+// The cache key has to be comparable so it can be a map key.
+func Memoize[K comparable, V any](fn func(K) V) func(K) V {
+	// TODO: return a closure with its own map[K]V cache; call fn only
+	// for keys it hasn't seen yet
+	return fn
+}
+
+// 11. Memoized recursive Fibonacci
+// The recursive calls must go through the memoized function too,
+// otherwise only the outermost call is cached.
+func FibMemo(n int) int {
+	// TODO: declare the variable first so the closure can refer to it:
+	//   var fib func(int) int
+	//   fib = Memoize(func(n int) int { ... fib(n-1) + fib(n-2) ... })
+	return 0
+}
+
+// 12. The same recursion as a loop
+// Carry the last two values forward instead of recursing: constant
+// memory, no stack growth, no cache.
+func FibLoop(n int) int {
+	// TODO: a, b := 0, 1, then step n times
+	return 0
+}
@@ -0,0 +1,104 @@
+package functions
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFactorial(t *testing.T) {
+	tests := []struct {
+		n    int
+		want uint64
+	}{
+		{0, 1},
+		{1, 1},
+		{5, 120},
+		{10, 3628800},
+		{20, 2432902008176640000},
+		{-3, 1},
+	}
+	for _, tt := range tests {
+		if got := Factorial(tt.n); got != tt.want {
+			t.Errorf("Factorial(%d): got %d, want %d", tt.n, got, tt.want)
+		}
+	}
+}
+
+var fibs = []int{0, 1, 1, 2, 3, 5, 8, 13, 21, 34, 55}
+
+func TestFib(t *testing.T) {
+	for n, want := range fibs {
+		if got := Fib(n); got != want {
+			t.Errorf("Fib(%d): got %d, want %d", n, got, want)
+		}
+	}
+}
+
+func TestMemoize(t *testing.T) {
+	calls := 0
+	square := Memoize(func(n int) int {
+		calls++
+		return n * n
+	})
+
+	for range 3 {
+		if got := square(12); got != 144 {
+			t.Fatalf("square(12): got %d, want 144", got)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("fn was called %d times for the same key, want 1", calls)
+	}
+	square(13)
+	if calls != 2 {
+		t.Errorf("a new key should call fn again: %d calls, want 2", calls)
+	}
+
+	// Works for any comparable key type.
+	shout := Memoize(func(s string) string { return s + "!" })
+	if got := shout("go"); got != "go!" {
+		t.Errorf(`shout("go"): got %q`, got)
+	}
+}
+
+// within fails the test if fn doesn't finish in time, so a slow
+// solution fails instead of hanging the whole test run.
+func within(t *testing.T, d time.Duration, fn func()) {
+	t.Helper()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		fn()
+	}()
+	select {
+	case <-done:
+	case <-time.After(d):
+		t.Fatalf("didn't finish within %v; is every recursive call cached?", d)
+	}
+}
+
+func TestFibMemo(t *testing.T) {
+	for n, want := range fibs {
+		if got := FibMemo(n); got != want {
+			t.Errorf("FibMemo(%d): got %d, want %d", n, got, want)
+		}
+	}
+
+	// Plain recursion would need about 10^18 calls for this.
+	var got int
+	within(t, 2*time.Second, func() { got = FibMemo(90) })
+	if want := 2880067194370816120; got != want {
+		t.Errorf("FibMemo(90): got %d, want %d", got, want)
+	}
+}
+
+func TestFibLoop(t *testing.T) {
+	for n, want := range fibs {
+		if got := FibLoop(n); got != want {
+			t.Errorf("FibLoop(%d): got %d, want %d", n, got, want)
+		}
+	}
+	if got, want := FibLoop(90), 2880067194370816120; got != want {
+		t.Errorf("FibLoop(90): got %d, want %d", got, want)
+	}
+}
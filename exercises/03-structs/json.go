@@ -0,0 +1,93 @@
+//go:build !solutions
+
+package structs
+
+// Exercise 3, part 2: JSON and struct tags
+//
+// encoding/json is Go's JSON.stringify and JSON.parse. It only sees
+// exported (Capitalized) fields, and struct tags control the rest:
+//
+//	`json:"name"`            rename the key
+//	`json:"email,omitempty"` leave the key out when the value is empty
+//	`json:"-"`               never read or write this field
+//
+// Embedded structs are flattened: their fields appear directly in the
+// outer object, much like { ...user, role } in JS.
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// DateLayout is how a Date looks in JSON. Go layouts are written with
+// the reference time Mon Jan 2 15:04:05 MST 2006, not YYYY-MM-DD.
+const DateLayout = "2006-01-02"
+
+// Date is a calendar day that reads and writes JSON as "2024-03-01".
+// It embeds time.Time, so all of Time's methods (Year, Format, ...)
+// work on it, but the MarshalJSON/UnmarshalJSON below replace Time's
+// own RFC 3339 format.
+type Date struct {
+	time.Time
+}
+
+// Profile is what a settings page would load and save.
+type Profile struct {
+	Admin             // flattened: id, name, email and role are top-level keys
+	Bio      string   `json:"bio,omitempty"`
+	Password string   `json:"-"` // must never leave the server
+	Joined   Date     `json:"joined"`
+	Tags     []string `json:"tags,omitempty"`
+	Manager  *User    `json:"manager,omitempty"` // nil pointers are empty too
+}
+
+// 11. Encode a user
+// In JS: JSON.stringify(user)
+func MarshalUser(u User) ([]byte, error) {
+	// TODO: use json.Marshal
+	return nil, nil
+}
+
+// 12. Decode a user
+// In JS: JSON.parse(data)
+func UnmarshalUser(data []byte) (User, error) {
+	// TODO: use json.Unmarshal into a User and return it
+	// Hint: pass a pointer, json.Unmarshal(data, &u)
+	return User{}, nil
+}
+
+// 13. Custom encoding for Date
+// json.Marshal calls this method because Date implements json.Marshaler,
+// like a toJSON() method in JS.
+func (d Date) MarshalJSON() ([]byte, error) {
+	// TODO: format d with DateLayout and return it as a JSON string
+	// Hint: json.Marshal(d.Format(DateLayout)) adds the quotes for you
+	return nil, nil
+}
+
+// 14. Custom decoding for Date
+// This one needs a pointer receiver: it has to change d.
+func (d *Date) UnmarshalJSON(data []byte) error {
+	// TODO: unmarshal data into a string, then time.Parse it with
+	// DateLayout and store the result in d.Time
+	// Return the error if either step fails
+	return nil
+}
+
+// 15. Encode a profile
+func MarshalProfile(p Profile) ([]byte, error) {
+	// TODO: use json.Marshal; the tags do the rest
+	return nil, nil
+}
+
+// 16. Decode a list of products, rejecting keys Product doesn't have
+// json.Unmarshal silently ignores unknown keys, so a typo like "prise"
+// would just leave Price at 0.
+func ParseProducts(data []byte) ([]Product, error) {
+	// TODO: create a json.Decoder over bytes.NewReader(data) (or
+	// strings.NewReader), call DisallowUnknownFields, then Decode
+	return nil, nil
+}
+
+// Keep imports used
+var _ = json.Marshal
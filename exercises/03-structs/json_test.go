@@ -0,0 +1,152 @@
+package structs
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/imgarylai/learn-go/internal/assert"
+)
+
+func TestMarshalUser(t *testing.T) {
+	got, err := MarshalUser(User{ID: 1, Name: "Alice", Email: "alice@example.com"})
+	if err != nil {
+		t.Fatalf("MarshalUser failed: %v", err)
+	}
+	want := `{"id":1,"name":"Alice","email":"alice@example.com"}`
+	if string(got) != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+
+	// omitempty drops the empty email.
+	got, _ = MarshalUser(User{ID: 2, Name: "Bob"})
+	if want := `{"id":2,"name":"Bob"}`; string(got) != want {
+		t.Errorf("empty email: got %s, want %s", got, want)
+	}
+}
+
+func TestUnmarshalUser(t *testing.T) {
+	u, err := UnmarshalUser([]byte(`{"id":3,"name":"Carol","email":"carol@example.com"}`))
+	if err != nil {
+		t.Fatalf("UnmarshalUser failed: %v", err)
+	}
+	want := User{ID: 3, Name: "Carol", Email: "carol@example.com"}
+	if u != want {
+		t.Errorf("got %+v, want %+v", u, want)
+	}
+
+	if _, err := UnmarshalUser([]byte(`{"id":"three"}`)); err == nil {
+		t.Error("expected an error for a string id")
+	}
+}
+
+func TestUserRoundTrip(t *testing.T) {
+	in := User{ID: 4, Name: "Dan", Email: "dan@example.com"}
+	data, err := MarshalUser(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err := UnmarshalUser(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out != in {
+		t.Errorf("round trip: got %+v, want %+v", out, in)
+	}
+}
+
+func TestDateJSON(t *testing.T) {
+	d := Date{time.Date(2024, time.March, 1, 15, 4, 5, 0, time.UTC)}
+	data, err := json.Marshal(d)
+	if err != nil {
+		t.Fatalf("marshal failed: %v", err)
+	}
+	if want := `"2024-03-01"`; string(data) != want {
+		t.Errorf("marshal: got %s, want %s", data, want)
+	}
+
+	var back Date
+	if err := json.Unmarshal([]byte(`"2023-12-25"`), &back); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+	if y, m, day := back.Date(); y != 2023 || m != time.December || day != 25 {
+		t.Errorf("unmarshal: got %v, want 2023-12-25", back.Time)
+	}
+
+	for _, bad := range []string{`"25/12/2023"`, `20231225`, `"2023-13-01"`} {
+		if err := json.Unmarshal([]byte(bad), &back); err == nil {
+			t.Errorf("unmarshal %s: expected an error", bad)
+		}
+	}
+
+	// Each step's own error comes back, not a later one.
+	var typeErr *json.UnmarshalTypeError
+	if err := json.Unmarshal([]byte(`20231225`), &back); !errors.As(err, &typeErr) {
+		t.Errorf("unmarshal 20231225: got %v, want json.Unmarshal's *json.UnmarshalTypeError", err)
+	}
+	var parseErr *time.ParseError
+	if err := json.Unmarshal([]byte(`"25/12/2023"`), &back); !errors.As(err, &parseErr) {
+		t.Errorf(`unmarshal "25/12/2023": got %v, want time.Parse's *time.ParseError`, err)
+	}
+}
+
+func TestMarshalProfile(t *testing.T) {
+	p := Profile{
+		Admin:    Admin{User: User{ID: 1, Name: "Alice"}, Role: "superadmin"},
+		Password: "hunter2",
+		Joined:   Date{time.Date(2024, time.January, 2, 0, 0, 0, 0, time.UTC)},
+	}
+	data, err := MarshalProfile(p)
+	if err != nil {
+		t.Fatalf("MarshalProfile failed: %v", err)
+	}
+
+	// Embedded structs are flattened and empty fields are omitted.
+	want := `{"id":1,"name":"Alice","role":"superadmin","joined":"2024-01-02"}`
+	if string(data) != want {
+		t.Errorf("got %s, want %s", data, want)
+	}
+	if strings.Contains(string(data), "hunter2") {
+		t.Error("the password must never be serialized")
+	}
+}
+
+func TestProfileRoundTrip(t *testing.T) {
+	in := Profile{
+		Admin:   Admin{User: User{ID: 7, Name: "Gus", Email: "gus@example.com"}, Role: "editor"},
+		Bio:     "Writes Go",
+		Joined:  Date{time.Date(2022, time.June, 30, 0, 0, 0, 0, time.UTC)},
+		Tags:    []string{"go", "json"},
+		Manager: &User{ID: 1, Name: "Alice"},
+	}
+	data, err := MarshalProfile(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out Profile
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatalf("unmarshal %s: %v", data, err)
+	}
+	if !out.Joined.Equal(in.Joined.Time) {
+		t.Errorf("joined: got %v, want %v", out.Joined.Time, in.Joined.Time)
+	}
+	out.Joined = in.Joined // compared above; Equal ignores location details
+	assert.Equal(t, out, in, "round trip")
+}
+
+func TestParseProducts(t *testing.T) {
+	data := []byte(`[{"id":1,"name":"Pen","price":1.5},{"id":2,"name":"Ink","price":4}]`)
+	got, err := ParseProducts(data)
+	if err != nil {
+		t.Fatalf("ParseProducts failed: %v", err)
+	}
+	want := []Product{{1, "Pen", 1.5}, {2, "Ink", 4}}
+	assert.Equal(t, got, want)
+
+	if _, err := ParseProducts([]byte(`[{"id":1,"name":"Pen","prise":1.5}]`)); err == nil {
+		t.Error(`expected an error for the unknown key "prise"`)
+	}
+}
@@ -1,3 +1,5 @@
+//go:build !solutions
+
 package structs
 
 // Exercise 3: Structs and Methods
@@ -13,10 +15,11 @@ import (
 
 // User represents a user (like a TS interface or class)
 // In TS: interface User { id: number; name: string; email: string; }
+// The json tags are used in part 2 (json.go).
 type User struct {
-	ID    int
-	Name  string
-	Email string
+	ID    int    `json:"id"`
+	Name  string `json:"name"`
+	Email string `json:"email,omitempty"`
 }
 
 // 1. Constructor function - Go convention: NewXxx
@@ -50,8 +53,8 @@ func (u User) IsValidEmail() bool {
 // Admin embeds User (like inheritance/composition)
 // In JS: class Admin extends User { role: string; }
 type Admin struct {
-	User // embedded - Admin "inherits" User's fields and methods
-	Role string
+	User        // embedded - Admin "inherits" User's fields and methods
+	Role string `json:"role"`
 }
 
 // 5. Constructor for embedded struct
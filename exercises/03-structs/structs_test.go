@@ -1,8 +1,9 @@
 package structs
 
 import (
-	"math"
 	"testing"
+
+	"github.com/imgarylai/learn-go/internal/testutil"
 )
 
 func TestNewUser(t *testing.T) {
@@ -155,7 +156,7 @@ func TestRectanglePerimeter(t *testing.T) {
 	rect := Rectangle{Width: 10, Height: 5}
 	perimeter := rect.Perimeter()
 
-	if math.Abs(perimeter-30) > 0.001 {
+	if !testutil.Near(perimeter, 30, 0.001) {
 		t.Errorf("Perimeter: got %f, want 30", perimeter)
 	}
 }
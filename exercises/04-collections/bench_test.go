@@ -0,0 +1,51 @@
+package collections
+
+import (
+	"fmt"
+	"testing"
+)
+
+// Benchmarks: `learngo bench 04` runs these, and `learngo bench --check 04`
+// compares them with testdata/bench-baseline.json, recorded from the
+// reference solution. The usual way to fall behind is growing a slice
+// one append at a time when you already know how long it will be.
+
+var benchNums = func() []int {
+	nums := make([]int, 10_000)
+	for i := range nums {
+		nums[i] = (i * 7919) % 1000
+	}
+	return nums
+}()
+
+func BenchmarkDouble(b *testing.B) {
+	for b.Loop() {
+		Double(benchNums)
+	}
+}
+
+func BenchmarkFilterGreaterThan(b *testing.B) {
+	for b.Loop() {
+		FilterGreaterThan(benchNums, 500)
+	}
+}
+
+func BenchmarkCountOccurrences(b *testing.B) {
+	items := make([]string, 10_000)
+	for i := range items {
+		items[i] = fmt.Sprint("item", i%100)
+	}
+	for b.Loop() {
+		CountOccurrences(items)
+	}
+}
+
+func BenchmarkGetNames(b *testing.B) {
+	people := make([]Person, 10_000)
+	for i := range people {
+		people[i] = Person{Name: fmt.Sprint("person", i), Age: i % 90}
+	}
+	for b.Loop() {
+		GetNames(people)
+	}
+}
@@ -1,3 +1,5 @@
+//go:build !solutions
+
 package collections
 
 // Exercise 4: Slices and Maps
@@ -1,27 +1,27 @@
 package collections
 
 import (
-	"reflect"
 	"sort"
 	"testing"
+
+	"github.com/imgarylai/learn-go/internal/assert"
 )
 
 func TestCreateSlice(t *testing.T) {
 	result := CreateSlice()
 	expected := []int{1, 2, 3, 4, 5}
 
-	if !reflect.DeepEqual(result, expected) {
-		t.Errorf("got %v, want %v", result, expected)
-	}
+	assert.Equal(t, result, expected)
 }
 
 func TestSliceMiddle(t *testing.T) {
 	result := SliceMiddle([]int{1, 2, 3, 4, 5})
 	expected := []int{2, 3}
 
-	if !reflect.DeepEqual(result, expected) {
-		t.Errorf("got %v, want %v", result, expected)
-	}
+	assert.Equal(t, result, expected)
+
+	// Exactly 3 elements is long enough
+	assert.Equal(t, SliceMiddle([]int{1, 2, 3}), []int{2, 3}, "SliceMiddle([1 2 3])")
 
 	// Test short slice
 	result = SliceMiddle([]int{1, 2})
@@ -34,9 +34,7 @@ func TestDouble(t *testing.T) {
 	result := Double([]int{1, 2, 3})
 	expected := []int{2, 4, 6}
 
-	if !reflect.DeepEqual(result, expected) {
-		t.Errorf("got %v, want %v", result, expected)
-	}
+	assert.Equal(t, result, expected)
 
 	// Test empty
 	result = Double([]int{})
@@ -49,9 +47,7 @@ func TestFilterGreaterThan(t *testing.T) {
 	result := FilterGreaterThan([]int{1, 5, 10, 3, 8, 2}, 5)
 	expected := []int{10, 8}
 
-	if !reflect.DeepEqual(result, expected) {
-		t.Errorf("got %v, want %v", result, expected)
-	}
+	assert.Equal(t, result, expected)
 }
 
 func TestSum(t *testing.T) {
@@ -100,9 +96,7 @@ func TestCreateScores(t *testing.T) {
 	}
 
 	expected := map[string]int{"alice": 95, "bob": 87, "charlie": 92}
-	if !reflect.DeepEqual(scores, expected) {
-		t.Errorf("got %v, want %v", scores, expected)
-	}
+	assert.Equal(t, scores, expected)
 }
 
 func TestGetScore(t *testing.T) {
@@ -152,9 +146,7 @@ func TestCountOccurrences(t *testing.T) {
 	result := CountOccurrences(items)
 
 	expected := map[string]int{"a": 3, "b": 2, "c": 1}
-	if !reflect.DeepEqual(result, expected) {
-		t.Errorf("got %v, want %v", result, expected)
-	}
+	assert.Equal(t, result, expected)
 }
 
 func TestGetAdults(t *testing.T) {
@@ -187,9 +179,7 @@ func TestGetNames(t *testing.T) {
 	result := GetNames(people)
 	expected := []string{"Alice", "Bob"}
 
-	if !reflect.DeepEqual(result, expected) {
-		t.Errorf("got %v, want %v", result, expected)
-	}
+	assert.Equal(t, result, expected)
 }
 
 func TestFindByName(t *testing.T) {
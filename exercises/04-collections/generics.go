@@ -0,0 +1,75 @@
+//go:build !solutions
+
+package collections
+
+// Exercise 4, part 2: Generic slice helpers
+//
+// Lodash gives JS _.chunk, _.zip, _.flatten, _.uniq and _.partition.
+// In Go you write them once with type parameters and they work for
+// every element type, checked at compile time.
+//
+// Watch out for aliasing: a sub-slice like s[1:3] shares memory with s.
+// Appending to it can overwrite s's later elements, and writing to it
+// changes s. The tests check that your helpers don't leak that surprise
+// to their callers.
+
+// 15. Split into chunks
+// In JS: _.chunk([1, 2, 3, 4, 5], 2) // [[1, 2], [3, 4], [5]]
+func Chunk[T any](s []T, size int) [][]T {
+	// TODO: split s into chunks of size elements; the last may be shorter
+	// If size < 1, return nil
+	// Appending to one chunk must not overwrite the next one.
+	// Hint: the full slice expression s[low:high:max] caps the capacity
+	return nil
+}
+
+// Pair holds one element from each slice passed to Zip.
+type Pair[A, B any] struct {
+	First  A
+	Second B
+}
+
+// 16. Zip two slices together
+// In JS: _.zip(["a", "b"], [1, 2]) // [["a", 1], ["b", 2]]
+func Zip[A, B any](a []A, b []B) []Pair[A, B] {
+	// TODO: pair up a[i] and b[i]; stop at the end of the shorter slice
+	return nil
+}
+
+// 17. Flatten one level
+// In JS: [[1, 2], [3]].flat()
+func Flatten[T any](s [][]T) []T {
+	// TODO: return all elements in order, in a new slice
+	// Changing the result must not change the inner slices.
+	return nil
+}
+
+// 18. Reverse in place
+// In JS: arr.reverse()
+func Reverse[T any](s []T) {
+	// TODO: reverse s without allocating a new slice
+	// Hint: swap with s[i], s[j] = s[j], s[i]
+}
+
+// 19. Reversed copy
+// In JS: arr.toReversed()
+func Reversed[T any](s []T) []T {
+	// TODO: return a reversed copy and leave s alone
+	return nil
+}
+
+// 20. Remove duplicates, keeping the first occurrence
+// In JS: [...new Set(arr)]
+func Deduplicate[T comparable](s []T) []T {
+	// TODO: return a new slice without duplicates, in original order
+	// Hint: a map[T]struct{} makes a cheap set
+	return nil
+}
+
+// 21. Partition by a predicate
+// In JS: _.partition(nums, n => n % 2 === 0)
+func Partition[T any](s []T, keep func(T) bool) (matched, rest []T) {
+	// TODO: return the elements for which keep is true, and the others
+	// Both results keep the original order. Don't modify s.
+	return nil, nil
+}
@@ -0,0 +1,151 @@
+package collections
+
+import (
+	"testing"
+
+	"github.com/imgarylai/learn-go/internal/assert"
+)
+
+func TestChunk(t *testing.T) {
+	tests := []struct {
+		s    []int
+		size int
+		want [][]int
+	}{
+		{[]int{1, 2, 3, 4, 5}, 2, [][]int{{1, 2}, {3, 4}, {5}}},
+		{[]int{1, 2, 3, 4}, 2, [][]int{{1, 2}, {3, 4}}},
+		{[]int{1, 2}, 5, [][]int{{1, 2}}},
+		{[]int{1, 2, 3}, 1, [][]int{{1}, {2}, {3}}},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, Chunk(tt.s, tt.size), tt.want, "Chunk(%v, %d)", tt.s, tt.size)
+	}
+
+	if got := Chunk([]int{}, 3); len(got) != 0 {
+		t.Errorf("empty slice: got %v, want no chunks", got)
+	}
+	if got := Chunk([]int{1, 2}, 0); got != nil {
+		t.Errorf("size 0: got %v, want nil", got)
+	}
+
+	// Works for any element type.
+	words := Chunk([]string{"a", "b", "c"}, 2)
+	assert.Equal(t, words, [][]string{{"a", "b"}, {"c"}}, "strings")
+}
+
+func TestChunkAliasing(t *testing.T) {
+	nums := []int{1, 2, 3, 4, 5}
+	chunks := Chunk(nums, 2)
+	if len(chunks) != 3 {
+		t.Fatalf("got %v, want 3 chunks", chunks)
+	}
+
+	// If chunks[0] still has room up to the end of nums, this append
+	// writes 99 into nums[2], which is also chunks[1][0].
+	_ = append(chunks[0], 99)
+	if chunks[1][0] != 3 {
+		t.Errorf("appending to chunk 0 changed chunk 1: got %v", chunks[1])
+	}
+	if nums[2] != 3 {
+		t.Errorf("appending to chunk 0 changed the input: got %v", nums)
+	}
+}
+
+func TestZip(t *testing.T) {
+	got := Zip([]string{"a", "b", "c"}, []int{1, 2})
+	want := []Pair[string, int]{{"a", 1}, {"b", 2}}
+	assert.Equal(t, got, want)
+
+	if got := Zip([]int{}, []string{"x"}); len(got) != 0 {
+		t.Errorf("empty slice: got %v, want no pairs", got)
+	}
+}
+
+func TestFlatten(t *testing.T) {
+	got := Flatten([][]int{{1, 2}, {}, {3}, nil, {4, 5}})
+	want := []int{1, 2, 3, 4, 5}
+	assert.Equal(t, got, want)
+
+	if got := Flatten([][]int{}); len(got) != 0 {
+		t.Errorf("empty: got %v, want empty", got)
+	}
+}
+
+func TestFlattenAliasing(t *testing.T) {
+	// With one inner slice it's tempting to return it as is.
+	inner := []int{1, 2}
+	got := Flatten([][]int{inner})
+	if len(got) != 2 {
+		t.Fatalf("got %v, want [1 2]", got)
+	}
+	got[0] = 99
+	if inner[0] != 1 {
+		t.Errorf("changing the result changed the input: got %v", inner)
+	}
+}
+
+func TestReverse(t *testing.T) {
+	tests := []struct {
+		s, want []int
+	}{
+		{[]int{1, 2, 3, 4}, []int{4, 3, 2, 1}},
+		{[]int{1, 2, 3}, []int{3, 2, 1}},
+		{[]int{1}, []int{1}},
+		{[]int{}, []int{}},
+	}
+	for _, tt := range tests {
+		s := append([]int{}, tt.s...)
+		Reverse(s)
+		assert.Equal(t, s, tt.want, "Reverse(%v)", tt.s)
+	}
+
+	// Reversing a sub-slice only touches that window of the array.
+	nums := []int{1, 2, 3, 4, 5}
+	Reverse(nums[1:4])
+	assert.Equal(t, nums, []int{1, 4, 3, 2, 5}, "Reverse(nums[1:4])")
+}
+
+func TestReversed(t *testing.T) {
+	nums := []string{"a", "b", "c"}
+	got := Reversed(nums)
+	assert.Equal(t, got, []string{"c", "b", "a"})
+	assert.Equal(t, nums, []string{"a", "b", "c"}, "input changed")
+
+	if len(got) == 0 {
+		return
+	}
+	got[0] = "z"
+	if nums[2] != "c" {
+		t.Errorf("result shares memory with the input: got %v", nums)
+	}
+	if got := Reversed([]int{}); len(got) != 0 {
+		t.Errorf("empty: got %v, want empty", got)
+	}
+}
+
+func TestDeduplicate(t *testing.T) {
+	nums := []int{3, 1, 3, 2, 1, 3}
+	got := Deduplicate(nums)
+	assert.Equal(t, got, []int{3, 1, 2})
+	// The in-place trick out := s[:0] would overwrite the input.
+	assert.Equal(t, nums, []int{3, 1, 3, 2, 1, 3}, "input changed")
+
+	words := Deduplicate([]string{"go", "js", "go", "ts"})
+	assert.Equal(t, words, []string{"go", "js", "ts"}, "strings")
+}
+
+func TestPartition(t *testing.T) {
+	nums := []int{1, 2, 3, 4, 5, 6}
+	even, odd := Partition(nums, func(n int) bool { return n%2 == 0 })
+	assert.Equal(t, even, []int{2, 4, 6}, "matched")
+	assert.Equal(t, odd, []int{1, 3, 5}, "rest")
+	assert.Equal(t, nums, []int{1, 2, 3, 4, 5, 6}, "input changed")
+
+	// Appending to one result must not clobber the other.
+	_ = append(even, 100)
+	assert.Equal(t, odd, []int{1, 3, 5}, "appending to matched changed rest")
+
+	long, short := Partition([]string{"go", "rust", "js"}, func(s string) bool { return len(s) > 2 })
+	assert.Equal(t, long, []string{"rust"}, "strings matched")
+	assert.Equal(t, short, []string{"go", "js"}, "strings rest")
+}
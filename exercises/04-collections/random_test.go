@@ -0,0 +1,175 @@
+package collections
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/imgarylai/learn-go/internal/proptest"
+	"github.com/imgarylai/learn-go/internal/testutil"
+)
+
+// Randomized tests: instead of checking one hand-picked answer, we feed
+// in lots of random inputs and check properties that must always hold.
+// A failing run prints its seed; rerun with LEARNGO_SEED=<seed> to
+// get exactly the same inputs back.
+
+const randomRuns = 100
+
+func TestDoubleRandom(t *testing.T) {
+	r := testutil.Rand(t)
+	for range randomRuns {
+		nums := testutil.Ints(r, r.IntN(20), -1000, 1000)
+		got := Double(nums)
+
+		if len(got) != len(nums) {
+			t.Fatalf("Double(%v): got %d elements, want %d", nums, len(got), len(nums))
+		}
+		for i := range nums {
+			if got[i] != nums[i]*2 {
+				t.Fatalf("Double(%v)[%d]: got %d, want %d", nums, i, got[i], nums[i]*2)
+			}
+		}
+	}
+}
+
+func TestFilterGreaterThanRandom(t *testing.T) {
+	r := testutil.Rand(t)
+	for range randomRuns {
+		nums := testutil.Ints(r, r.IntN(20), -50, 50)
+		threshold := r.IntN(101) - 50
+		got := FilterGreaterThan(nums, threshold)
+
+		// Every kept number is above the threshold, in the original order,
+		// and nothing above the threshold was dropped.
+		j := 0
+		for _, n := range nums {
+			if n <= threshold {
+				continue
+			}
+			if j >= len(got) || got[j] != n {
+				t.Fatalf("FilterGreaterThan(%v, %d): got %v", nums, threshold, got)
+			}
+			j++
+		}
+		if j != len(got) {
+			t.Fatalf("FilterGreaterThan(%v, %d): got %v (extra elements)", nums, threshold, got)
+		}
+	}
+}
+
+// The next two use internal/proptest, which also shrinks a failing
+// input: instead of twenty random numbers you get the smallest slice
+// that still breaks the property, like fast-check does in JS.
+
+func TestSumRandom(t *testing.T) {
+	ints := proptest.SliceOf(proptest.Int(-1000, 1000), 20)
+
+	// Splitting a slice in two never changes its sum...
+	proptest.Check2(t, ints, ints, func(a, b []int) bool {
+		return Sum(slices.Concat(a, b)) == Sum(a)+Sum(b)
+	})
+	// ...and a slice of one number sums to that number. Without this, a
+	// Sum that always returns 0 would pass.
+	proptest.Check(t, proptest.Int(-1000, 1000), func(n int) bool {
+		return Sum([]int{n}) == n
+	})
+}
+
+func TestReverseRandom(t *testing.T) {
+	ints := proptest.SliceOf(proptest.Int(-1000, 1000), 20)
+
+	// Reversing twice gives the slice back, and Reversed leaves its
+	// argument alone...
+	proptest.Check(t, ints, func(s []int) bool {
+		orig := slices.Clone(s)
+		return slices.Equal(Reversed(Reversed(s)), orig) && slices.Equal(s, orig)
+	})
+	// ...but moves the first element to the end. Without this, returning
+	// a plain copy would pass.
+	proptest.Check(t, ints, func(s []int) bool {
+		r := Reversed(s)
+		return len(r) == len(s) && (len(s) == 0 || r[len(r)-1] == s[0])
+	})
+	// Reverse does the same in place.
+	proptest.Check(t, ints, func(s []int) bool {
+		want := Reversed(s)
+		Reverse(s)
+		return slices.Equal(s, want)
+	})
+}
+
+func TestMaxRandom(t *testing.T) {
+	r := testutil.Rand(t)
+	for range randomRuns {
+		nums := testutil.Ints(r, 1+r.IntN(20), -1000, 1000)
+		got := Max(nums)
+
+		found := false
+		for _, n := range nums {
+			if n > got {
+				t.Fatalf("Max(%v) = %d, but %d is bigger", nums, got, n)
+			}
+			found = found || n == got
+		}
+		if !found {
+			t.Fatalf("Max(%v) = %d, which isn't in the slice", nums, got)
+		}
+	}
+}
+
+func TestCountOccurrencesRandom(t *testing.T) {
+	r := testutil.Rand(t)
+	words := []string{"go", "js", "ts", "rust", "zig"}
+	for range randomRuns {
+		items := make([]string, r.IntN(30))
+		for i := range items {
+			items[i] = testutil.Pick(r, words)
+		}
+		got := CountOccurrences(items)
+
+		// The counts add up to the number of items, and each count is right.
+		total := 0
+		for word, n := range got {
+			total += n
+			want := 0
+			for _, it := range items {
+				if it == word {
+					want++
+				}
+			}
+			if n != want {
+				t.Fatalf("CountOccurrences(%v)[%q]: got %d, want %d", items, word, n, want)
+			}
+		}
+		if total != len(items) {
+			t.Fatalf("CountOccurrences(%v): counts add up to %d, want %d", items, total, len(items))
+		}
+	}
+}
+
+func TestGetAdultsRandom(t *testing.T) {
+	r := testutil.Rand(t)
+	for range randomRuns {
+		people := make([]Person, r.IntN(15))
+		for i := range people {
+			people[i] = Person{Name: fmt.Sprintf("p%d", i), Age: r.IntN(40)}
+		}
+		got := GetAdults(people)
+
+		want := 0
+		for _, p := range people {
+			if p.Age >= 18 {
+				want++
+			}
+		}
+		if len(got) != want {
+			t.Fatalf("GetAdults: got %d adults, want %d (people %v)", len(got), want, people)
+		}
+		for _, p := range got {
+			if p.Age < 18 {
+				t.Fatalf("GetAdults returned a minor: %+v", p)
+			}
+		}
+	}
+}
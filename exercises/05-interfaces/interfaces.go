@@ -1,3 +1,5 @@
+//go:build !solutions
+
 package interfaces
 
 // Exercise 5: Interfaces
@@ -4,6 +4,8 @@ import (
 	"math"
 	"strings"
 	"testing"
+
+	"github.com/imgarylai/learn-go/internal/testutil"
 )
 
 func TestRectangleArea(t *testing.T) {
@@ -29,7 +31,7 @@ func TestCircleArea(t *testing.T) {
 	area := circle.Area()
 
 	expected := math.Pi * 25 // Pi * r^2
-	if math.Abs(area-expected) > 0.0001 {
+	if !testutil.Near(area, expected, 0.0001) {
 		t.Errorf("got %f, want %f", area, expected)
 	}
 }
@@ -39,7 +41,7 @@ func TestCirclePerimeter(t *testing.T) {
 	perimeter := circle.Perimeter()
 
 	expected := 2 * math.Pi * 5 // 2 * Pi * r
-	if math.Abs(perimeter-expected) > 0.0001 {
+	if !testutil.Near(perimeter, expected, 0.0001) {
 		t.Errorf("got %f, want %f", perimeter, expected)
 	}
 }
@@ -83,10 +85,13 @@ func TestGetRadius(t *testing.T) {
 	}
 
 	rect := Rectangle{Width: 10, Height: 5}
-	_, ok = GetRadius(rect)
+	radius, ok = GetRadius(rect)
 	if ok {
 		t.Error("should return false for Rectangle")
 	}
+	if radius != 0 {
+		t.Errorf("got radius %f for a Rectangle, want 0", radius)
+	}
 }
 
 func TestDescribeType(t *testing.T) {
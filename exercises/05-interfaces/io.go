@@ -0,0 +1,77 @@
+//go:build !solutions
+
+package interfaces
+
+// Exercise 5, part 2: io.Reader and io.Writer
+//
+// Two tiny interfaces do most of the work in Go's standard library:
+//
+//	type Reader interface { Read(p []byte) (n int, err error) }
+//	type Writer interface { Write(p []byte) (n int, err error) }
+//
+// Files, network connections, gzip streams, HTTP bodies and
+// bytes.Buffer all implement them, a bit like Node's Readable and
+// Writable streams. Anything you write against io.Reader works with
+// all of them, and data flows through in chunks instead of being loaded
+// into memory at once.
+
+import (
+	"fmt"
+	"io"
+)
+
+// WordCountWriter is an io.Writer that throws the data away and counts
+// the words in it, like piping into `wc -w`. Words are separated by
+// spaces, tabs and newlines.
+type WordCountWriter struct {
+	Words  int
+	inWord bool // whether the last byte seen was part of a word
+}
+
+// 13. Implement io.Writer
+// Write may be called many times with arbitrary chunks: "hel" and then
+// "lo world" is two words, not three.
+func (w *WordCountWriter) Write(p []byte) (int, error) {
+	// TODO: walk through p; count a word each time a non-space byte
+	// follows a space (or comes first), and remember the state in
+	// w.inWord for the next call
+	// An io.Writer must return len(p), nil when it accepted everything
+	return 0, nil
+}
+
+// UppercaseReader wraps another reader and upper-cases ASCII letters
+// as they pass through, like a Transform stream in Node.
+type UppercaseReader struct {
+	R io.Reader
+}
+
+// 14. Implement io.Reader
+func (u UppercaseReader) Read(p []byte) (int, error) {
+	// TODO: read into p from u.R, then convert p[:n] in place
+	// ('a'..'z' become 'A'..'Z'; leave other bytes alone)
+	// Return n and the error from u.R unchanged: io.EOF included
+	return 0, io.EOF
+}
+
+// 15. Count the words of any reader with io.Copy
+func CountWords(r io.Reader) (int, error) {
+	// TODO: io.Copy from r into a WordCountWriter and return its count
+	return 0, nil
+}
+
+// 16. Stream src to dst in capitals
+// In Node: src.pipe(upperCaseTransform).pipe(dst)
+func Shout(dst io.Writer, src io.Reader) (int64, error) {
+	// TODO: io.Copy into dst from an UppercaseReader wrapping src
+	return 0, nil
+}
+
+// 17. fmt.Fprintf writes to any io.Writer
+// Write "Hello, NAME! You have N new messages.\n" to w.
+func Greet(w io.Writer, name string, messages int) error {
+	// TODO: use fmt.Fprintf and return its error
+	return nil
+}
+
+// Keep imports used
+var _ = fmt.Fprintf
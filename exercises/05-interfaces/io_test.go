@@ -0,0 +1,119 @@
+package interfaces
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+// Compile-time checks, a common Go idiom: these lines stop compiling if
+// the types ever stop implementing the interfaces.
+var (
+	_ io.Writer = (*WordCountWriter)(nil)
+	_ io.Reader = UppercaseReader{}
+)
+
+func TestWordCountWriter(t *testing.T) {
+	tests := []struct {
+		text string
+		want int
+	}{
+		{"", 0},
+		{"hello", 1},
+		{"hello world", 2},
+		{"  leading and trailing  ", 3},
+		{"tabs\tand\nnewlines\n\n", 3},
+	}
+	for _, tt := range tests {
+		var w WordCountWriter
+		n, err := w.Write([]byte(tt.text))
+		if err != nil || n != len(tt.text) {
+			t.Errorf("Write(%q): got %d, %v; want %d, nil", tt.text, n, err, len(tt.text))
+		}
+		if w.Words != tt.want {
+			t.Errorf("Write(%q): counted %d words, want %d", tt.text, w.Words, tt.want)
+		}
+	}
+}
+
+func TestWordCountWriterChunks(t *testing.T) {
+	var w WordCountWriter
+	for _, chunk := range []string{"hel", "lo wor", "ld", " ", "again"} {
+		w.Write([]byte(chunk))
+	}
+	if w.Words != 3 {
+		t.Errorf("counted %d words across chunks, want 3", w.Words)
+	}
+}
+
+func TestUppercaseReader(t *testing.T) {
+	r := UppercaseReader{strings.NewReader("Hello, gopher 123! a to z")}
+	got, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("ReadAll failed: %v", err)
+	}
+	if want := "HELLO, GOPHER 123! A TO Z"; string(got) != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestUppercaseReaderFollowsTheContract(t *testing.T) {
+	// iotest.TestReader reads in odd-sized pieces and checks the
+	// results the way the io.Reader documentation says they must be.
+	r := UppercaseReader{strings.NewReader("streaming, one chunk at a time")}
+	if err := iotest.TestReader(r, []byte("STREAMING, ONE CHUNK AT A TIME")); err != nil {
+		t.Error(err)
+	}
+
+	// Errors from the wrapped reader come through unchanged.
+	boom := errors.New("disk on fire")
+	if _, err := (UppercaseReader{iotest.ErrReader(boom)}).Read(make([]byte, 8)); !errors.Is(err, boom) {
+		t.Errorf("got error %v, want %v", err, boom)
+	}
+}
+
+func TestCountWords(t *testing.T) {
+	text := strings.Repeat("the quick brown fox ", 1000)
+	// OneByteReader hands out a single byte per Read, the worst case
+	// for a streaming word counter.
+	n, err := CountWords(iotest.OneByteReader(strings.NewReader(text)))
+	if err != nil {
+		t.Fatalf("CountWords failed: %v", err)
+	}
+	if n != 4000 {
+		t.Errorf("got %d words, want 4000", n)
+	}
+}
+
+func TestShout(t *testing.T) {
+	var buf bytes.Buffer
+	n, err := Shout(&buf, iotest.HalfReader(strings.NewReader("quiet please")))
+	if err != nil {
+		t.Fatalf("Shout failed: %v", err)
+	}
+	if got, want := buf.String(), "QUIET PLEASE"; got != want || n != int64(len(want)) {
+		t.Errorf("got %q (%d bytes), want %q", got, n, want)
+	}
+}
+
+func TestGreet(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Greet(&buf, "Ada", 3); err != nil {
+		t.Fatalf("Greet failed: %v", err)
+	}
+	if want := "Hello, Ada! You have 3 new messages.\n"; buf.String() != want {
+		t.Errorf("got %q, want %q", buf.String(), want)
+	}
+
+	// The same function writes to any io.Writer, our own included.
+	var w WordCountWriter
+	if err := Greet(&w, "Ada", 3); err != nil {
+		t.Fatal(err)
+	}
+	if w.Words != 7 {
+		t.Errorf("greeting has %d words, want 7", w.Words)
+	}
+}
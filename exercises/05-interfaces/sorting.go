@@ -0,0 +1,87 @@
+//go:build !solutions
+
+package interfaces
+
+// Exercise 5, part 3: Implementing standard library interfaces
+//
+// sort.Sort and the container/heap functions don't know your types.
+// They ask for a small interface instead, the way Array.prototype.sort
+// asks for a compare function in JS. Implement the methods and the
+// library does the rest.
+
+import (
+	"container/heap"
+	"sort"
+)
+
+// ByAge sorts people from youngest to oldest. It's a named slice type
+// so that it can have methods; convert with ByAge(people).
+type ByAge []Person
+
+// 18. Implement sort.Interface: Len, Less and Swap
+// In JS: people.sort((a, b) => a.Age - b.Age)
+func (a ByAge) Len() int {
+	// TODO: return the number of people
+	return 0
+}
+
+func (a ByAge) Less(i, j int) bool {
+	// TODO: report whether a[i] should come before a[j]
+	// Break ties by Name, so the order is always the same
+	return false
+}
+
+func (a ByAge) Swap(i, j int) {
+	// TODO: swap a[i] and a[j]
+}
+
+// Task is a job in a priority queue. A lower Priority number is more
+// urgent: priority 1 runs before priority 5.
+type Task struct {
+	Name     string
+	Priority int
+}
+
+// TaskHeap is a min-heap of tasks for container/heap. There's no
+// built-in priority queue in JS; this is what you'd reach for npm for.
+type TaskHeap []Task
+
+// 19. Implement heap.Interface
+// heap.Interface is sort.Interface plus Push and Pop. heap.Push and
+// heap.Pop call these to grow and shrink the slice, then restore the
+// heap order themselves using Less and Swap.
+func (h TaskHeap) Len() int {
+	// TODO
+	return 0
+}
+
+func (h TaskHeap) Less(i, j int) bool {
+	// TODO: the more urgent task (lower Priority) comes first
+	return false
+}
+
+func (h TaskHeap) Swap(i, j int) {
+	// TODO
+}
+
+// Push needs a pointer receiver because it changes the slice length.
+func (h *TaskHeap) Push(x any) {
+	// TODO: append x.(Task) to *h
+}
+
+func (h *TaskHeap) Pop() any {
+	// TODO: remove and return the LAST element of *h
+	// (heap.Pop has already swapped the smallest one there)
+	return nil
+}
+
+// 20. Use the heap: the n most urgent task names, most urgent first
+func MostUrgent(tasks []Task, n int) []string {
+	// TODO: copy tasks into a TaskHeap (don't reorder the caller's
+	// slice), heap.Init it, then heap.Pop up to n times
+	return nil
+}
+
+// Keep imports used
+var _ = heap.Init
+var _ = sort.Sort
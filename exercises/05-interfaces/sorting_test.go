@@ -0,0 +1,78 @@
+package interfaces
+
+import (
+	"container/heap"
+	"sort"
+	"testing"
+
+	"github.com/imgarylai/learn-go/internal/assert"
+)
+
+var (
+	_ sort.Interface = ByAge(nil)
+	_ heap.Interface = (*TaskHeap)(nil)
+)
+
+func TestByAge(t *testing.T) {
+	people := []Person{
+		{"Carol", 35},
+		{"Alice", 30},
+		{"Dave", 25},
+		{"Bob", 30},
+	}
+	sort.Sort(ByAge(people))
+
+	want := []Person{
+		{"Dave", 25},
+		{"Alice", 30},
+		{"Bob", 30},
+		{"Carol", 35},
+	}
+	assert.Equal(t, people, want)
+}
+
+func TestByAgeWithSortHelpers(t *testing.T) {
+	people := ByAge{{"Zed", 40}, {"Amy", 20}}
+	if sort.IsSorted(people) {
+		t.Fatal("IsSorted: unsorted input reported as sorted")
+	}
+	sort.Sort(sort.Reverse(people)) // Reverse flips your Less
+	if people[0].Name != "Zed" {
+		t.Errorf("sort.Reverse: got %v, want Zed first", people)
+	}
+}
+
+func TestTaskHeap(t *testing.T) {
+	h := &TaskHeap{}
+	heap.Init(h)
+	for _, task := range []Task{{"deploy", 3}, {"fix prod", 1}, {"lunch", 5}, {"review", 2}} {
+		heap.Push(h, task)
+	}
+	if h.Len() != 4 {
+		t.Fatalf("Len after 4 pushes: got %d", h.Len())
+	}
+
+	var order []string
+	for h.Len() > 0 {
+		task, ok := heap.Pop(h).(Task)
+		if !ok {
+			t.Fatal("Pop should return a Task")
+		}
+		order = append(order, task.Name)
+	}
+	want := []string{"fix prod", "review", "deploy", "lunch"}
+	assert.Equal(t, order, want, "pop order")
+}
+
+func TestMostUrgent(t *testing.T) {
+	tasks := []Task{{"c", 3}, {"a", 1}, {"d", 4}, {"b", 2}}
+	got := MostUrgent(tasks, 2)
+	assert.Equal(t, got, []string{"a", "b"})
+	if tasks[0].Name != "c" {
+		t.Errorf("MostUrgent reordered the caller's slice: %v", tasks)
+	}
+
+	if got := MostUrgent(tasks, 10); len(got) != 4 {
+		t.Errorf("n > len: got %v, want all 4 tasks", got)
+	}
+}
@@ -0,0 +1,39 @@
+package concurrency
+
+import (
+	"fmt"
+	"testing"
+)
+
+// Benchmarks: `learngo bench 06` runs these, and `learngo bench
+// --solution 06` runs them against the reference solution. They show
+// what goroutines cost: with work this small, more workers mostly add
+// channel traffic, so compare the sub-benchmarks before reaching for 64.
+
+var benchJobs = func() []int {
+	jobs := make([]int, 1_000)
+	for i := range jobs {
+		jobs[i] = i
+	}
+	return jobs
+}()
+
+func BenchmarkWorkerPool(b *testing.B) {
+	for _, workers := range []int{1, 4, 64} {
+		b.Run(fmt.Sprint("workers=", workers), func(b *testing.B) {
+			for b.Loop() {
+				WorkerPool(benchJobs, workers)
+			}
+		})
+	}
+}
+
+func BenchmarkSumParallel(b *testing.B) {
+	chunks := make([][]int, 10)
+	for i := range chunks {
+		chunks[i] = benchJobs
+	}
+	for b.Loop() {
+		SumParallel(chunks)
+	}
+}
@@ -1,3 +1,5 @@
+//go:build !solutions
+
 package concurrency
 
 // Exercise 6: Concurrency with Goroutines and Channels
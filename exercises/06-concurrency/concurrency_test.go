@@ -104,10 +104,13 @@ func TestWithTimeoutFailure(t *testing.T) {
 		return 42
 	}
 
-	_, ok := WithTimeout(slow, 50*time.Millisecond)
+	result, ok := WithTimeout(slow, 50*time.Millisecond)
 	if ok {
 		t.Error("expected timeout, got success")
 	}
+	if result != 0 {
+		t.Errorf("got %d on a timeout, want 0", result)
+	}
 }
 
 func TestSumParallel(t *testing.T) {
@@ -0,0 +1,101 @@
+package concurrency
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/imgarylai/learn-go/internal/testutil"
+)
+
+// Randomized tests: random job lists and worker counts shake out
+// solutions that only work for the exact inputs in the fixed tests
+// (e.g. a pool that deadlocks when there are more workers than jobs).
+// A failing run prints its seed; rerun with LEARNGO_SEED=<seed>.
+
+const randomRuns = 50
+
+func TestCollectFromChannelRandom(t *testing.T) {
+	r := testutil.Rand(t)
+	for range randomRuns {
+		count := r.IntN(50)
+		got := CollectFromChannel(count)
+
+		if len(got) != count {
+			t.Fatalf("CollectFromChannel(%d): got %d values", count, len(got))
+		}
+		for i, v := range got {
+			if v != i {
+				t.Fatalf("CollectFromChannel(%d)[%d]: got %d, want %d", count, i, v, i)
+			}
+		}
+	}
+}
+
+func TestSumParallelRandom(t *testing.T) {
+	r := testutil.Rand(t)
+	for range randomRuns {
+		parts := make([][]int, r.IntN(10))
+		want := 0
+		for i := range parts {
+			parts[i] = testutil.Ints(r, r.IntN(10), -100, 100)
+			for _, n := range parts[i] {
+				want += n
+			}
+		}
+
+		if got := SumParallel(parts); got != want {
+			t.Fatalf("SumParallel(%v): got %d, want %d", parts, got, want)
+		}
+	}
+}
+
+func TestWorkerPoolRandom(t *testing.T) {
+	r := testutil.Rand(t)
+	for range randomRuns {
+		jobs := testutil.Ints(r, r.IntN(30), -20, 20)
+		workers := 1 + r.IntN(8) // sometimes more workers than jobs
+
+		got := WorkerPool(jobs, workers)
+
+		// Order doesn't matter, but the multiset of results does.
+		want := make([]int, len(jobs))
+		for i, j := range jobs {
+			want[i] = j * j
+		}
+		got = slices.Clone(got)
+		slices.Sort(got)
+		slices.Sort(want)
+		if !slices.Equal(got, want) {
+			t.Fatalf("WorkerPool(%v, %d): got %v, want %v (any order)", jobs, workers, got, want)
+		}
+	}
+}
+
+func TestFanOutFanInRandom(t *testing.T) {
+	r := testutil.Rand(t)
+	for range randomRuns {
+		nums := testutil.Ints(r, r.IntN(30), -100, 100)
+		workers := 1 + r.IntN(8)
+
+		want := 0
+		for _, n := range nums {
+			want += 2 * n
+		}
+		if got := FanOutFanIn(nums, workers); got != want {
+			t.Fatalf("FanOutFanIn(%v, %d): got %d, want %d", nums, workers, got, want)
+		}
+	}
+}
+
+func TestConcurrentIncrementRandom(t *testing.T) {
+	r := testutil.Rand(t)
+	for range 10 {
+		times := r.IntN(2000)
+		c := &Counter{}
+		ConcurrentIncrement(c, times)
+
+		if c.Value() != times {
+			t.Fatalf("ConcurrentIncrement(%d): counter is %d (lost updates?)", times, c.Value())
+		}
+	}
+}
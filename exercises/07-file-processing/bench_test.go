@@ -0,0 +1,45 @@
+package fileprocessing
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Benchmarks: `learngo bench 07` runs these, and `learngo bench --check 07`
+// compares them with testdata/bench-baseline.json, recorded from the
+// reference solution. Reading line by line with bufio.Scanner keeps
+// allocations flat no matter how big the file is.
+
+func benchFile(b *testing.B, lines int) string {
+	b.Helper()
+	var sb strings.Builder
+	for i := range lines {
+		fmt.Fprintf(&sb, "line %d of the benchmark file\n", i)
+	}
+	path := filepath.Join(b.TempDir(), "big.txt")
+	if err := os.WriteFile(path, []byte(sb.String()), 0o644); err != nil {
+		b.Fatal(err)
+	}
+	return path
+}
+
+func BenchmarkCountLines(b *testing.B) {
+	path := benchFile(b, 10_000)
+	for b.Loop() {
+		if _, err := CountLines(path); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkReadLines(b *testing.B) {
+	path := benchFile(b, 10_000)
+	for b.Loop() {
+		if _, err := ReadLines(path); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
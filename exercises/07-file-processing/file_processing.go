@@ -1,3 +1,5 @@
+//go:build !solutions
+
 package fileprocessing
 
 import (
@@ -53,6 +55,8 @@ func ReadCSV(filename string) ([]Person, error) {
 	// Skip header row
 	// Parse each row into Person struct
 	// Hint: use strconv.Atoi for age conversion
+	// A row without three fields is an error naming its line, not a
+	// panic: check len(row) before reading row[2]
 	return nil, nil
 }
 
@@ -1,36 +1,20 @@
 package fileprocessing
 
 import (
+	"errors"
+	"io/fs"
 	"os"
 	"path/filepath"
-	"reflect"
+	"strings"
 	"testing"
-)
-
-// Helper to create temp directory for tests
-func setupTestDir(t *testing.T) string {
-	t.Helper()
-	dir, err := os.MkdirTemp("", "fileprocessing-test-*")
-	if err != nil {
-		t.Fatal(err)
-	}
-	t.Cleanup(func() { os.RemoveAll(dir) })
-	return dir
-}
 
-// Helper to write test file
-func writeTestFile(t *testing.T, dir, name, content string) string {
-	t.Helper()
-	path := filepath.Join(dir, name)
-	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
-		t.Fatal(err)
-	}
-	return path
-}
+	"github.com/imgarylai/learn-go/internal/assert"
+	"github.com/imgarylai/learn-go/internal/testutil"
+)
 
 func TestReadLines(t *testing.T) {
-	dir := setupTestDir(t)
-	path := writeTestFile(t, dir, "test.txt", "line1\nline2\nline3")
+	dir := t.TempDir()
+	path := testutil.WriteFile(t, dir, "test.txt", "line1\nline2\nline3")
 
 	lines, err := ReadLines(path)
 	if err != nil {
@@ -38,14 +22,12 @@ func TestReadLines(t *testing.T) {
 	}
 
 	expected := []string{"line1", "line2", "line3"}
-	if !reflect.DeepEqual(lines, expected) {
-		t.Errorf("got %v, want %v", lines, expected)
-	}
+	assert.Equal(t, lines, expected)
 }
 
 func TestReadLinesEmpty(t *testing.T) {
-	dir := setupTestDir(t)
-	path := writeTestFile(t, dir, "empty.txt", "")
+	dir := t.TempDir()
+	path := testutil.WriteFile(t, dir, "empty.txt", "")
 
 	lines, err := ReadLines(path)
 	if err != nil {
@@ -64,8 +46,15 @@ func TestReadLinesNotFound(t *testing.T) {
 	}
 }
 
+func TestReadLinesDirectory(t *testing.T) {
+	// Opening a directory works; reading it doesn't.
+	if _, err := ReadLines(t.TempDir()); err == nil {
+		t.Error("expected an error for a directory")
+	}
+}
+
 func TestWriteLines(t *testing.T) {
-	dir := setupTestDir(t)
+	dir := t.TempDir()
 	path := filepath.Join(dir, "output.txt")
 
 	lines := []string{"hello", "world", "go"}
@@ -75,14 +64,12 @@ func TestWriteLines(t *testing.T) {
 
 	// Verify
 	readBack, _ := ReadLines(path)
-	if !reflect.DeepEqual(readBack, lines) {
-		t.Errorf("got %v, want %v", readBack, lines)
-	}
+	assert.Equal(t, readBack, lines)
 }
 
 func TestCountLines(t *testing.T) {
-	dir := setupTestDir(t)
-	path := writeTestFile(t, dir, "count.txt", "one\ntwo\nthree\nfour\nfive")
+	dir := t.TempDir()
+	path := testutil.WriteFile(t, dir, "count.txt", "one\ntwo\nthree\nfour\nfive")
 
 	count, err := CountLines(path)
 	if err != nil {
@@ -95,11 +82,11 @@ func TestCountLines(t *testing.T) {
 }
 
 func TestReadCSV(t *testing.T) {
-	dir := setupTestDir(t)
+	dir := t.TempDir()
 	csvContent := `name,age,email
 Alice,30,alice@example.com
 Bob,25,bob@example.com`
-	path := writeTestFile(t, dir, "people.csv", csvContent)
+	path := testutil.WriteFile(t, dir, "people.csv", csvContent)
 
 	people, err := ReadCSV(path)
 	if err != nil {
@@ -111,13 +98,34 @@ Bob,25,bob@example.com`
 		{Name: "Bob", Age: 25, Email: "bob@example.com"},
 	}
 
-	if !reflect.DeepEqual(people, expected) {
-		t.Errorf("got %+v, want %+v", people, expected)
+	assert.Equal(t, people, expected)
+}
+
+func TestReadCSVErrors(t *testing.T) {
+	tests := []struct {
+		name, csv string
+		want      string // in the error
+	}{
+		{"bad quoting", "name,age,email\nAlice,30,\"alice@example.com\n", ""},
+		{"age not a number", "name,age,email\nAlice,thirty,alice@example.com\n", "thirty"},
+		{"too few fields", "name\nAlice\n", "line 2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := testutil.WriteFile(t, t.TempDir(), "people.csv", tt.csv)
+			people, err := ReadCSV(path)
+			if err == nil || len(people) != 0 {
+				t.Fatalf("ReadCSV(%q) = %v, %v; want an error", tt.csv, people, err)
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("ReadCSV(%q) error = %q; want %q in it", tt.csv, err, tt.want)
+			}
+		})
 	}
 }
 
 func TestWriteCSV(t *testing.T) {
-	dir := setupTestDir(t)
+	dir := t.TempDir()
 	path := filepath.Join(dir, "output.csv")
 
 	people := []Person{
@@ -135,19 +143,18 @@ func TestWriteCSV(t *testing.T) {
 		t.Fatalf("ReadCSV failed: %v", err)
 	}
 
-	if !reflect.DeepEqual(readBack, people) {
-		t.Errorf("got %+v, want %+v", readBack, people)
-	}
+	assert.Equal(t, readBack, people)
 }
 
 func TestFilterCSV(t *testing.T) {
-	dir := setupTestDir(t)
+	dir := t.TempDir()
 	inputCSV := `name,age,email
 Alice,30,alice@example.com
 Bob,17,bob@example.com
 Charlie,45,charlie@example.com
-Diana,15,diana@example.com`
-	inputPath := writeTestFile(t, dir, "input.csv", inputCSV)
+Diana,15,diana@example.com
+Ed,18,ed@example.com`
+	inputPath := testutil.WriteFile(t, dir, "input.csv", inputCSV)
 	outputPath := filepath.Join(dir, "filtered.csv")
 
 	if err := FilterCSV(inputPath, outputPath, 18); err != nil {
@@ -159,8 +166,8 @@ Diana,15,diana@example.com`
 		t.Fatalf("ReadCSV failed: %v", err)
 	}
 
-	if len(filtered) != 2 {
-		t.Errorf("expected 2 adults, got %d", len(filtered))
+	if len(filtered) != 3 {
+		t.Errorf("expected 3 adults (18 counts), got %d", len(filtered))
 	}
 
 	for _, p := range filtered {
@@ -171,12 +178,12 @@ Diana,15,diana@example.com`
 }
 
 func TestReadJSON(t *testing.T) {
-	dir := setupTestDir(t)
+	dir := t.TempDir()
 	jsonContent := `[
 		{"name": "Alice", "age": 30, "email": "alice@example.com"},
 		{"name": "Bob", "age": 25, "email": "bob@example.com"}
 	]`
-	path := writeTestFile(t, dir, "people.json", jsonContent)
+	path := testutil.WriteFile(t, dir, "people.json", jsonContent)
 
 	people, err := ReadJSON(path)
 	if err != nil {
@@ -192,8 +199,17 @@ func TestReadJSON(t *testing.T) {
 	}
 }
 
+func TestReadJSONErrors(t *testing.T) {
+	for _, data := range []string{`[`, `[{"name": "Bob", "age": "30"}]`, `{"name": "not an array"}`} {
+		path := testutil.WriteFile(t, t.TempDir(), "people.json", data)
+		if people, err := ReadJSON(path); err == nil {
+			t.Errorf("ReadJSON(%s) = %v, nil; want an error", data, people)
+		}
+	}
+}
+
 func TestWriteJSON(t *testing.T) {
-	dir := setupTestDir(t)
+	dir := t.TempDir()
 	path := filepath.Join(dir, "output.json")
 
 	people := []Person{
@@ -210,16 +226,23 @@ func TestWriteJSON(t *testing.T) {
 		t.Fatalf("ReadJSON failed: %v", err)
 	}
 
-	if !reflect.DeepEqual(readBack, people) {
-		t.Errorf("got %+v, want %+v", readBack, people)
+	assert.Equal(t, readBack, people)
+
+	// Data, not a program: nobody gets to execute it.
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm&0o111 != 0 {
+		t.Errorf("mode %v; want no execute bits, e.g. 0644", perm)
 	}
 }
 
 func TestConvertCSVToJSON(t *testing.T) {
-	dir := setupTestDir(t)
+	dir := t.TempDir()
 	csvContent := `name,age,email
 Frank,40,frank@example.com`
-	csvPath := writeTestFile(t, dir, "convert.csv", csvContent)
+	csvPath := testutil.WriteFile(t, dir, "convert.csv", csvContent)
 	jsonPath := filepath.Join(dir, "convert.json")
 
 	if err := ConvertCSVToJSON(csvPath, jsonPath); err != nil {
@@ -237,9 +260,9 @@ Frank,40,frank@example.com`
 }
 
 func TestProcessLargeFile(t *testing.T) {
-	dir := setupTestDir(t)
+	dir := t.TempDir()
 	content := "line1\nline2\nline3\nline4\nline5"
-	path := writeTestFile(t, dir, "large.txt", content)
+	path := testutil.WriteFile(t, dir, "large.txt", content)
 
 	var lines []string
 	var lineNums []int
@@ -259,8 +282,61 @@ func TestProcessLargeFile(t *testing.T) {
 	}
 
 	expectedNums := []int{1, 2, 3, 4, 5}
-	if !reflect.DeepEqual(lineNums, expectedNums) {
-		t.Errorf("line numbers: got %v, want %v", lineNums, expectedNums)
+	assert.Equal(t, lineNums, expectedNums, "line numbers")
+}
+
+func TestProcessLargeFileStops(t *testing.T) {
+	path := testutil.WriteFile(t, t.TempDir(), "large.txt", "line1\nline2\nline3")
+	errStop := errors.New("stop")
+	calls := 0
+	err := ProcessLargeFile(path, func(lineNum int, line string) error {
+		calls++
+		if lineNum == 2 {
+			return errStop
+		}
+		return nil
+	})
+	if !errors.Is(err, errStop) {
+		t.Errorf("got error %v, want the one process returned", err)
+	}
+	if calls != 2 {
+		t.Errorf("process called %d times, want 2: stop at its error", calls)
+	}
+}
+
+func TestMissingFiles(t *testing.T) {
+	dir := t.TempDir()
+	missing := filepath.Join(dir, "missing.txt")
+	noDir := filepath.Join(dir, "no", "such", "dir", "out.txt")
+	people := []Person{{Name: "Alice", Age: 30, Email: "alice@example.com"}}
+
+	tests := []struct {
+		name string
+		fn   func() error
+	}{
+		{"ReadLines", func() error { _, err := ReadLines(missing); return err }},
+		{"WriteLines", func() error { return WriteLines(noDir, []string{"x"}) }},
+		{"CountLines", func() error {
+			n, err := CountLines(missing)
+			if n != 0 {
+				t.Errorf("CountLines(missing) = %d with the error, want 0", n)
+			}
+			return err
+		}},
+		{"ReadCSV", func() error { _, err := ReadCSV(missing); return err }},
+		{"WriteCSV", func() error { return WriteCSV(noDir, people) }},
+		{"FilterCSV", func() error { return FilterCSV(missing, filepath.Join(dir, "out.csv"), 18) }},
+		{"ReadJSON", func() error { _, err := ReadJSON(missing); return err }},
+		{"ConvertCSVToJSON", func() error { return ConvertCSVToJSON(missing, filepath.Join(dir, "out.json")) }},
+		{"ProcessLargeFile", func() error {
+			return ProcessLargeFile(missing, func(int, string) error { return nil })
+		}},
+		{"ReadProducts", func() error { _, err := ReadProducts(missing); return err }},
+	}
+	for _, tt := range tests {
+		if err := tt.fn(); !errors.Is(err, fs.ErrNotExist) {
+			t.Errorf("%s: got error %v, want one wrapping fs.ErrNotExist", tt.name, err)
+		}
 	}
 }
 
@@ -338,7 +414,7 @@ func TestCalculateTotalValue(t *testing.T) {
 
 	// 999.99 + 79.99 + 12.99 + 4.99 + 49.99 + 29.99 + 19.99 + 34.99 = 1232.92
 	expected := 1232.92
-	if total < expected-0.01 || total > expected+0.01 {
+	if !testutil.Near(total, expected, 0.01) {
 		t.Errorf("total: got %f, want %f", total, expected)
 	}
 }
@@ -356,6 +432,20 @@ func TestFindMostExpensive(t *testing.T) {
 	}
 }
 
+func TestReadProductsBadCSV(t *testing.T) {
+	path := testutil.WriteFile(t, t.TempDir(), "products.csv", "id,name,price,category\n1,\"Laptop,999.99,Electronics\n")
+	if products, err := ReadProducts(path); err == nil {
+		t.Errorf("ReadProducts(bad quoting) = %v, nil; want an error", products)
+	}
+}
+
+func TestFindMostExpensiveOne(t *testing.T) {
+	products := []Product{{ID: 1, Name: "Pen", Price: 1.5, Category: "Office"}}
+	if most := FindMostExpensive(products); most != &products[0] {
+		t.Errorf("got %v, want a pointer to the only product", most)
+	}
+}
+
 func TestFindMostExpensiveEmpty(t *testing.T) {
 	most := FindMostExpensive([]Product{})
 	if most != nil {
@@ -0,0 +1,136 @@
+package fileprocessing
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/imgarylai/learn-go/internal/assert"
+)
+
+// Fuzz tests. Plain `go test` runs each one on the seed inputs added
+// with f.Add, like any other test. `go test -fuzz=FuzzReadCSV` keeps
+// making up new inputs from them until one breaks a rule below (a panic
+// counts), then saves it under testdata/fuzz/FuzzReadCSV so every later
+// `go test` runs it too. It's property-based testing, like fast-check
+// in JS, built into the go tool.
+//
+// The rules are what any correct answer must do with a file it has
+// never seen: report malformed input as an error, never panic, and
+// read back exactly what the matching Write function wrote.
+
+// fuzzFile writes data to a fresh file and returns its path.
+func fuzzFile(t *testing.T, name, data string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func FuzzReadLines(f *testing.F) {
+	f.Add("line1\nline2\nline3")
+	f.Add("trailing newline\n")
+	f.Add("")
+	f.Add("\n\n")
+	f.Add("windows\r\nline endings\r\n")
+	f.Add("日本語\n🙂")
+
+	f.Fuzz(func(t *testing.T, data string) {
+		lines, err := ReadLines(fuzzFile(t, "input.txt", data))
+		if err != nil {
+			return // e.g. a line longer than bufio.Scanner's 64KB limit
+		}
+		// Every line of the input, without its line ending ("\n", or
+		// "\r\n" from Windows); a final newline doesn't start a new line.
+		want := strings.Split(data, "\n")
+		if data == "" || strings.HasSuffix(data, "\n") {
+			want = want[:len(want)-1]
+		}
+		for i := range want {
+			want[i] = strings.TrimSuffix(want[i], "\r")
+		}
+		if !slices.Equal(lines, want) {
+			t.Errorf("ReadLines(%q) = %q, want %q", data, lines, want)
+		}
+
+		n, err := CountLines(fuzzFile(t, "count.txt", data))
+		if err != nil || n != len(lines) {
+			t.Errorf("CountLines(%q) = %d, %v; ReadLines found %d lines", data, n, err, len(lines))
+		}
+	})
+}
+
+func FuzzReadCSV(f *testing.F) {
+	people, err := os.ReadFile(filepath.Join("testdata", "people.csv"))
+	if err != nil {
+		f.Fatal(err)
+	}
+	f.Add(string(people))
+	f.Add("name,age,email\n")
+	f.Add("name,age,email\n\"Smith, Jane\",41,\"jane@example.com\"\n")
+	f.Add("name,age,email\nAlice,thirty,alice@example.com\n")
+	f.Add("name\nAlice\n")
+	f.Add("name,age,email\nAlice,30\n")
+	f.Add("name,age,email\n\"unterminated,30,x\n")
+	f.Add("")
+
+	f.Fuzz(func(t *testing.T, data string) {
+		got, err := ReadCSV(fuzzFile(t, "input.csv", data))
+		if err != nil {
+			return // malformed input is fine, as long as it's an error
+		}
+		// Whatever was read must survive a trip through WriteCSV.
+		path := filepath.Join(t.TempDir(), "output.csv")
+		if err := WriteCSV(path, got); err != nil {
+			t.Fatalf("WriteCSV(%v): %v", got, err)
+		}
+		again, err := ReadCSV(path)
+		if err != nil {
+			t.Fatalf("ReadCSV can't read what WriteCSV wrote for %v: %v", got, err)
+		}
+		if len(got) > 0 {
+			assert.Equal(t, again, got, "read %q, then WriteCSV and ReadCSV", data)
+		}
+	})
+}
+
+func FuzzReadJSON(f *testing.F) {
+	f.Add(`[{"name": "Alice", "age": 30, "email": "alice@example.com"}]`)
+	f.Add(`[]`)
+	f.Add(`null`)
+	f.Add(`[{"name": "Bob"}]`)
+	f.Add(`[{"name": "Bob", "age": "30"}]`)
+	f.Add(`{"name": "not an array"}`)
+	f.Add(`[{"name": "é😀"}]`)
+	f.Add(`[`)
+
+	f.Fuzz(func(t *testing.T, data string) {
+		got, err := ReadJSON(fuzzFile(t, "input.json", data))
+		if err != nil {
+			return
+		}
+		for _, p := range got {
+			// encoding/json turns invalid UTF-8 into U+FFFD, so anything
+			// it decoded is valid text.
+			if !utf8.ValidString(p.Name) || !utf8.ValidString(p.Email) {
+				t.Errorf("ReadJSON(%q) returned invalid UTF-8: %+v", data, p)
+			}
+		}
+		path := filepath.Join(t.TempDir(), "output.json")
+		if err := WriteJSON(path, got); err != nil {
+			t.Fatalf("WriteJSON(%v): %v", got, err)
+		}
+		again, err := ReadJSON(path)
+		if err != nil {
+			t.Fatalf("ReadJSON can't read what WriteJSON wrote for %v: %v", got, err)
+		}
+		if len(got) > 0 {
+			assert.Equal(t, again, got, "read %q, then WriteJSON and ReadJSON", data)
+		}
+	})
+}
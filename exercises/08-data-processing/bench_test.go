@@ -0,0 +1,50 @@
+package dataprocessing
+
+import (
+	"fmt"
+	"testing"
+)
+
+// Benchmarks: `learngo bench 08` runs these, and `learngo bench
+// --solution 08` runs them against the reference solution. The
+// hand-written FilterSales and the generic Filter should cost about
+// the same: Go generics don't box values the way interface{} would.
+
+var benchSales = func() []Sale {
+	regions := []string{"North", "South", "East", "West"}
+	sales := make([]Sale, 10_000)
+	for i := range sales {
+		sales[i] = Sale{
+			Product:  fmt.Sprint("product", i%50),
+			Quantity: i % 20,
+			Price:    float64(i%100) + 0.99,
+			Region:   regions[i%len(regions)],
+		}
+	}
+	return sales
+}()
+
+func BenchmarkFilterSales(b *testing.B) {
+	for b.Loop() {
+		FilterSales(benchSales, 10)
+	}
+}
+
+func BenchmarkFilter(b *testing.B) {
+	bigOrder := func(s Sale) bool { return s.Quantity > 10 }
+	for b.Loop() {
+		Filter(benchSales, bigOrder)
+	}
+}
+
+func BenchmarkRevenueByRegion(b *testing.B) {
+	for b.Loop() {
+		RevenueByRegion(benchSales)
+	}
+}
+
+func BenchmarkTopNSales(b *testing.B) {
+	for b.Loop() {
+		TopNSales(benchSales, 10)
+	}
+}
@@ -1,3 +1,5 @@
+//go:build !solutions
+
 package dataprocessing
 
 // Exercise 8: Data Processing
@@ -207,9 +209,48 @@ func TotalPayroll(employees []Employee) int {
 // 23. ReadSalesCSV reads sales.csv and returns []Sale
 func ReadSalesCSV(filename string) ([]Sale, error) {
 	// TODO: Read sales.csv and parse into []Sale
+	// Columns: product,quantity,price,region. Return an error naming
+	// the line ("line 2: ...") for a row with fewer than four, rather
+	// than panicking on row[3]
 	return nil, nil
 }
 
+// ============ Part 5: Formatted Reports ============
+
+// The layout of FormatSalesReport, as fmt verbs: region names padded to
+// 10 on the left, numbers right-aligned. In JS you'd reach for
+// padEnd/padStart; %-10s and %5d do both.
+const (
+	reportHeading = "%-10s %5s %6s %10s\n"
+	reportRow     = "%-10s %5d %6d %10.2f\n"
+)
+
+// 24. FormatSalesReport renders a plain-text report. For the sample
+// sales in the tests:
+//
+//	Sales report
+//
+//	Region     Sales  Units    Revenue
+//	East           1      3     150.00
+//	North          2     25     700.00
+//	South          2     13     450.00
+//	Total          5     41    1300.00
+//
+//	Top product: Gizmo (450.00)
+//
+// Regions are in alphabetical order. The top product is the one with
+// the most revenue, the alphabetically first on a tie; leave its line
+// (and the blank line before it) out when there are no sales.
+// Every line ends with "\n". The tests compare with the golden files
+// testdata/report-*.golden and show a diff when they don't match.
+func FormatSalesReport(sales []Sale) string {
+	// TODO: start with "Sales report\n\n" and the column headings
+	// (fmt.Sprintf(reportHeading, "Region", "Sales", "Units", "Revenue")),
+	// then one reportRow per region and one for the total.
+	// A strings.Builder and fmt.Fprintf(&b, ...) save building up +=
+	return ""
+}
+
 // Keep imports used
 var (
 	_ = sort.Slice
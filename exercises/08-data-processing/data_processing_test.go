@@ -1,12 +1,18 @@
 package dataprocessing
 
 import (
-	"reflect"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
 	"sort"
+	"strings"
 	"testing"
 
 	"github.com/go-gota/gota/dataframe"
 	"github.com/go-gota/gota/series"
+	"github.com/imgarylai/learn-go/internal/assert"
+	"github.com/imgarylai/learn-go/internal/testutil"
 )
 
 // Test data
@@ -46,9 +52,7 @@ func TestGetProductNames(t *testing.T) {
 	}
 
 	expected := []string{"Widget", "Gadget", "Widget", "Gizmo", "Gadget"}
-	if !reflect.DeepEqual(names, expected) {
-		t.Errorf("got %v, want %v", names, expected)
-	}
+	assert.Equal(t, names, expected)
 }
 
 func TestTotalRevenue(t *testing.T) {
@@ -126,9 +130,7 @@ func TestUniqueProducts(t *testing.T) {
 	// Sort for comparison
 	sort.Strings(unique)
 	expected := []string{"Gadget", "Gizmo", "Widget"}
-	if !reflect.DeepEqual(unique, expected) {
-		t.Errorf("got %v, want %v", unique, expected)
-	}
+	assert.Equal(t, unique, expected)
 }
 
 func TestSalesCountByProduct(t *testing.T) {
@@ -153,9 +155,7 @@ func TestGenericFilter(t *testing.T) {
 	evens := Filter(nums, func(n int) bool { return n%2 == 0 })
 
 	expected := []int{2, 4, 6}
-	if !reflect.DeepEqual(evens, expected) {
-		t.Errorf("got %v, want %v", evens, expected)
-	}
+	assert.Equal(t, evens, expected)
 }
 
 func TestGenericMap(t *testing.T) {
@@ -163,18 +163,14 @@ func TestGenericMap(t *testing.T) {
 	doubled := Map(nums, func(n int) int { return n * 2 })
 
 	expected := []int{2, 4, 6}
-	if !reflect.DeepEqual(doubled, expected) {
-		t.Errorf("got %v, want %v", doubled, expected)
-	}
+	assert.Equal(t, doubled, expected)
 
 	// Test type transformation
 	strs := Map(nums, func(n int) string {
 		return string(rune('A' + n - 1))
 	})
 	expectedStrs := []string{"A", "B", "C"}
-	if !reflect.DeepEqual(strs, expectedStrs) {
-		t.Errorf("got %v, want %v", strs, expectedStrs)
-	}
+	assert.Equal(t, strs, expectedStrs)
 }
 
 func TestGenericReduce(t *testing.T) {
@@ -260,14 +256,20 @@ func TestSortByQuantity(t *testing.T) {
 	sorted := SortByQuantity(df, true)
 
 	// First row should have highest quantity (15)
-	firstQty := sorted.Elem(0, 1).Int()
+	firstQty, err := sorted.Elem(0, 1).Int()
+	if err != nil {
+		t.Fatal(err)
+	}
 	if firstQty != 15 {
 		t.Errorf("expected first quantity to be 15, got %d", firstQty)
 	}
 
 	// Sort ascending
 	sortedAsc := SortByQuantity(df, false)
-	firstQtyAsc := sortedAsc.Elem(0, 1).Int()
+	firstQtyAsc, err := sortedAsc.Elem(0, 1).Int()
+	if err != nil {
+		t.Fatal(err)
+	}
 	if firstQtyAsc != 3 {
 		t.Errorf("expected first quantity to be 3, got %d", firstQtyAsc)
 	}
@@ -307,11 +309,43 @@ func TestReadEmployees(t *testing.T) {
 	}
 
 	// Check first employee
-	if employees[0].Name != "Alice" || employees[0].Department != "Engineering" {
+	if employees[0].ID != 1 || employees[0].Name != "Alice" || employees[0].Department != "Engineering" {
 		t.Errorf("first employee: got %+v", employees[0])
 	}
 }
 
+// writeCSV writes data to a file in a temporary directory and returns
+// its path.
+func writeCSV(t *testing.T, data string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "data.csv")
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestReadCSVErrors(t *testing.T) {
+	if _, err := ReadEmployees("testdata/missing.csv"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("ReadEmployees of a missing file: got error %v, want one that errors.Is fs.ErrNotExist", err)
+	}
+	if _, err := ReadSalesCSV("testdata/missing.csv"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("ReadSalesCSV of a missing file: got error %v, want one that errors.Is fs.ErrNotExist", err)
+	}
+
+	// An unterminated quote: encoding/csv's ReadAll fails.
+	bad := writeCSV(t, "id,name,department,salary,years\n1,\"Alice,Engineering,95000,5\n")
+	if employees, err := ReadEmployees(bad); err == nil {
+		t.Errorf("ReadEmployees of a malformed file = %+v, nil; want an error", employees)
+	}
+
+	short := writeCSV(t, "product,quantity\nWidget,10\n")
+	_, err := ReadSalesCSV(short)
+	if err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("ReadSalesCSV of a file with 2 columns: got error %v, want one naming line 2", err)
+	}
+}
+
 func TestAverageSalaryByDepartment(t *testing.T) {
 	employees, _ := ReadEmployees("testdata/employees.csv")
 	avg := AverageSalaryByDepartment(employees)
@@ -348,8 +382,9 @@ func TestFilterByExperience(t *testing.T) {
 	employees, _ := ReadEmployees("testdata/employees.csv")
 	experienced := FilterByExperience(employees, 5)
 
-	if len(experienced) != 4 {
-		t.Errorf("expected 4 with 5+ years, got %d", len(experienced))
+	// Alice (5), Charlie (8), Frank (6), Henry (10), Jack (7)
+	if len(experienced) != 5 {
+		t.Errorf("expected 5 with 5+ years, got %d", len(experienced))
 	}
 
 	for _, e := range experienced {
@@ -408,6 +443,44 @@ func TestReadSalesCSVWithAnalysis(t *testing.T) {
 	}
 }
 
+// ============ Part 5: Formatted Report Tests ============
+
+// The expected reports are golden files in testdata/. If you change the
+// layout on purpose, `go test -run TestFormatSalesReport -update`
+// rewrites them; check the diff before keeping it.
+func TestFormatSalesReport(t *testing.T) {
+	tests := []struct {
+		name  string
+		sales []Sale
+	}{
+		{"sample", getSampleSales()}, // Gizmo and Widget tie on revenue
+		{"empty", nil},
+		{"single", []Sale{{Product: "Widget", Quantity: 2, Price: 2.5, Region: "West"}}},
+		{"ties", []Sale{ // Apple is the first of five products on 60.00; Acai comes before it, on less
+			{Product: "Fig", Quantity: 6, Price: 10, Region: "North"},
+			{Product: "Cherry", Quantity: 3, Price: 20, Region: "South"},
+			{Product: "Acai", Quantity: 1, Price: 5, Region: "North"},
+			{Product: "Elderberry", Quantity: 12, Price: 5, Region: "East"},
+			{Product: "Apple", Quantity: 2, Price: 30, Region: "West"},
+			{Product: "Banana", Quantity: 4, Price: 15, Region: "South"},
+			{Product: "Grape", Quantity: 1, Price: 59.5, Region: "East"},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FormatSalesReport(tt.sales)
+			testutil.Golden(t, filepath.Join("testdata", "report-"+tt.name+".golden"), []byte(got))
+			// Ranging over a map visits keys in a different order each
+			// time, and the report mustn't depend on it.
+			for range 20 {
+				if again := FormatSalesReport(tt.sales); again != got {
+					t.Fatalf("FormatSalesReport changed between calls on the same sales:\n%s\nthen\n%s", got, again)
+				}
+			}
+		})
+	}
+}
+
 // Keep imports
 var (
 	_ = series.Int
@@ -0,0 +1,65 @@
+package dataprocessing
+
+import (
+	"encoding/csv"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// FuzzReadSalesCSV: plain `go test` runs it on the seeds below;
+// `go test -fuzz=FuzzReadSalesCSV` keeps inventing new CSV files from
+// them until ReadSalesCSV panics or misreads one, and saves that file
+// under testdata/fuzz so it becomes a regular test case.
+//
+// Real exports are messy: short rows, quoted commas, a price of "N/A".
+// Malformed input may be an error; it may never be a panic.
+func FuzzReadSalesCSV(f *testing.F) {
+	sales, err := os.ReadFile(filepath.Join("testdata", "sales.csv"))
+	if err != nil {
+		f.Fatal(err)
+	}
+	f.Add(string(sales))
+	f.Add("product,quantity,price,region\n")
+	f.Add("product,quantity,price,region\n\"Widget, large\",2,9.99,North\n")
+	f.Add("product,quantity,price,region\nWidget,N/A,9.99,North\n")
+	f.Add("product\nWidget\n")
+	f.Add("product,quantity,price,region\nWidget,2,9.99\n")
+	f.Add("product,quantity,price,region,notes\nWidget,2,9.99,North,rush\n")
+	f.Add("")
+
+	f.Fuzz(func(t *testing.T, data string) {
+		path := filepath.Join(t.TempDir(), "sales.csv")
+		if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		got, err := ReadSalesCSV(path)
+		if err != nil {
+			return
+		}
+
+		// On success every row after the header is a sale, in order.
+		records, err := csv.NewReader(strings.NewReader(data)).ReadAll()
+		if err != nil {
+			t.Fatalf("ReadSalesCSV(%q) accepted a file encoding/csv rejects: %v", data, err)
+		}
+		if want := max(len(records)-1, 0); len(got) != want {
+			t.Fatalf("ReadSalesCSV(%q) returned %d sales, want %d", data, len(got), want)
+		}
+		for i, s := range got {
+			row := records[i+1]
+			if s.Product != row[0] || s.Region != row[3] {
+				t.Errorf("row %d %q: got %+v", i+1, row, s)
+			}
+			if qty, err := strconv.Atoi(row[1]); err == nil && s.Quantity != qty {
+				t.Errorf("row %d %q: quantity %d, want %d", i+1, row, s.Quantity, qty)
+			}
+			if price, err := strconv.ParseFloat(row[2], 64); err == nil && s.Price != price && !(math.IsNaN(price) && math.IsNaN(s.Price)) {
+				t.Errorf("row %d %q: price %v, want %v", i+1, row, s.Price, price)
+			}
+		}
+	})
+}
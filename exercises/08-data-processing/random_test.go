@@ -0,0 +1,226 @@
+package dataprocessing
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/imgarylai/learn-go/internal/proptest"
+	"github.com/imgarylai/learn-go/internal/testutil"
+)
+
+// Randomized tests: each run builds a fresh batch of sales from a seed
+// and checks properties that hold for any data, so hardcoding the
+// answers for getSampleSales() won't get you through.
+// A failing run prints its seed; rerun with LEARNGO_SEED=<seed>.
+
+const randomRuns = 100
+
+// Prices are multiples of 1/4 so float sums are exact and can be
+// compared with ==, whatever order they're added in.
+var (
+	randomProducts = []string{"Widget", "Gadget", "Gizmo", "Doohickey"}
+	randomRegions  = []string{"North", "South", "East", "West"}
+	randomPrices   = []float64{2.5, 10, 19.75, 25, 50}
+)
+
+func randomSales(t *testing.T) func() []Sale {
+	r := testutil.Rand(t)
+	return func() []Sale {
+		sales := make([]Sale, r.IntN(25))
+		for i := range sales {
+			sales[i] = Sale{
+				Product:  testutil.Pick(r, randomProducts),
+				Quantity: r.IntN(100),
+				Price:    testutil.Pick(r, randomPrices),
+				Region:   testutil.Pick(r, randomRegions),
+			}
+		}
+		return sales
+	}
+}
+
+func revenue(s Sale) float64 { return float64(s.Quantity) * s.Price }
+
+func TestFilterSalesRandom(t *testing.T) {
+	next := randomSales(t)
+	for range randomRuns {
+		sales := next()
+		minQty := 50
+		got := FilterSales(sales, minQty)
+
+		want := 0
+		for _, s := range sales {
+			if s.Quantity > minQty {
+				want++
+			}
+		}
+		if len(got) != want {
+			t.Fatalf("FilterSales: got %d sales, want %d", len(got), want)
+		}
+		for _, s := range got {
+			if s.Quantity <= minQty {
+				t.Fatalf("FilterSales kept %+v (quantity <= %d)", s, minQty)
+			}
+		}
+	}
+}
+
+func TestRevenueRandom(t *testing.T) {
+	next := randomSales(t)
+	for range randomRuns {
+		sales := next()
+		total := TotalRevenue(sales)
+
+		// Revenue split by region must add back up to the total.
+		var byRegion float64
+		for _, v := range RevenueByRegion(sales) {
+			byRegion += v
+		}
+		if byRegion != total {
+			t.Fatalf("RevenueByRegion adds up to %.2f, TotalRevenue is %.2f", byRegion, total)
+		}
+
+		// So must the groups from GroupByRegion.
+		count := 0
+		for region, group := range GroupByRegion(sales) {
+			for _, s := range group {
+				if s.Region != region {
+					t.Fatalf("GroupByRegion put %+v under %q", s, region)
+				}
+			}
+			count += len(group)
+		}
+		if count != len(sales) {
+			t.Fatalf("GroupByRegion: groups hold %d sales, want %d", count, len(sales))
+		}
+	}
+}
+
+func TestTopNSalesRandom(t *testing.T) {
+	next := randomSales(t)
+	for range randomRuns {
+		sales := next()
+		n := len(sales) / 2
+		got := TopNSales(sales, n)
+
+		if len(got) != n {
+			t.Fatalf("TopNSales(_, %d): got %d sales", n, len(got))
+		}
+		for i := 1; i < len(got); i++ {
+			if revenue(got[i]) > revenue(got[i-1]) {
+				t.Fatalf("TopNSales not sorted by revenue: %v", got)
+			}
+		}
+
+		// Nothing left out may beat the smallest one we kept. Count how
+		// many sales beat it: that can't be more than n.
+		if n > 0 {
+			smallest, above := revenue(got[n-1]), 0
+			for _, s := range sales {
+				if revenue(s) > smallest {
+					above++
+				}
+			}
+			if above > n {
+				t.Fatalf("TopNSales(_, %d) missed a bigger sale: kept %v", n, got)
+			}
+		}
+	}
+}
+
+func TestUniqueProductsRandom(t *testing.T) {
+	next := randomSales(t)
+	for range randomRuns {
+		sales := next()
+		got := UniqueProducts(sales)
+		counts := SalesCountByProduct(sales)
+
+		if len(got) != len(counts) {
+			t.Fatalf("UniqueProducts: got %v, but SalesCountByProduct saw %d products", got, len(counts))
+		}
+		seen := map[string]bool{}
+		for _, p := range got {
+			if seen[p] {
+				t.Fatalf("UniqueProducts: %q appears twice in %v", p, got)
+			}
+			seen[p] = true
+		}
+
+		total := 0
+		for _, n := range counts {
+			total += n
+		}
+		if total != len(sales) {
+			t.Fatalf("SalesCountByProduct adds up to %d, want %d", total, len(sales))
+		}
+	}
+}
+
+func TestGenericHelpersRandom(t *testing.T) {
+	next := randomSales(t)
+	for range randomRuns {
+		sales := next()
+		isBig := func(s Sale) bool { return s.Quantity > 50 }
+
+		// Filter and Map agree with the hand-written versions...
+		if got, want := len(Filter(sales, isBig)), len(FilterSales(sales, 50)); got != want {
+			t.Fatalf("Filter: got %d, FilterSales got %d", got, want)
+		}
+		names := Map(sales, func(s Sale) string { return s.Product })
+		if len(names) != len(sales) {
+			t.Fatalf("Map: got %d names for %d sales", len(names), len(sales))
+		}
+
+		// ...and Reduce can rebuild TotalRevenue.
+		sum := Reduce(sales, 0.0, func(acc float64, s Sale) float64 { return acc + revenue(s) })
+		if sum != TotalRevenue(sales) {
+			t.Fatalf("Reduce: got %.2f, TotalRevenue got %.2f", sum, TotalRevenue(sales))
+		}
+	}
+}
+
+// The laws every Filter, Map and Reduce obey, whatever the data, checked
+// with internal/proptest. A failure shows the smallest slice that breaks
+// one.
+func TestGenericLawsRandom(t *testing.T) {
+	ints := proptest.SliceOf(proptest.Int(-100, 100), 20)
+	even := func(n int) bool { return n%2 == 0 }
+	double := func(n int) int { return n * 2 }
+	inc := func(n int) int { return n + 1 }
+
+	// Filter keeps exactly the matching elements, in order: the same as
+	// appending them one by one.
+	proptest.Check(t, ints, func(s []int) bool {
+		var want []int
+		for _, n := range s {
+			if even(n) {
+				want = append(want, n)
+			}
+		}
+		return slices.Equal(Filter(s, even), want)
+	})
+	// Filtering twice with the same predicate changes nothing more.
+	proptest.Check(t, ints, func(s []int) bool {
+		once := Filter(s, even)
+		return slices.Equal(Filter(once, even), once)
+	})
+
+	// Mapping the identity gives the slice back, and mapping f then g
+	// is mapping "g after f" once.
+	proptest.Check(t, ints, func(s []int) bool {
+		return slices.Equal(Map(s, func(n int) int { return n }), s)
+	})
+	proptest.Check(t, ints, func(s []int) bool {
+		return slices.Equal(Map(Map(s, double), inc), Map(s, func(n int) int { return inc(double(n)) }))
+	})
+
+	// Reducing with append rebuilds the slice, and reducing a
+	// concatenation with + is the sum of the two reductions.
+	proptest.Check(t, ints, func(s []int) bool {
+		return slices.Equal(Reduce(s, []int{}, func(acc []int, n int) []int { return append(acc, n) }), s)
+	})
+	add := func(acc, n int) int { return acc + n }
+	proptest.Check2(t, ints, ints, func(a, b []int) bool {
+		return Reduce(slices.Concat(a, b), 0, add) == Reduce(a, 0, add)+Reduce(b, 0, add)
+	})
+}
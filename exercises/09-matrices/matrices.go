@@ -0,0 +1,84 @@
+//go:build !solutions
+
+package matrices
+
+import (
+	"encoding/csv"
+	"errors"
+	"os"
+	"strconv"
+)
+
+// Exercise 9: Matrices and 2D slices
+//
+// Go has no built-in matrix type. A [][]int is a slice of rows, and each
+// row is its own slice, just like a JS number[][]. The difference is
+// that you allocate every row yourself; there's no Array.from to lean on.
+// Run tests with: go test -v
+
+// ErrDimensionMismatch is returned when two matrices can't be combined.
+var ErrDimensionMismatch = errors.New("matrix dimensions don't match")
+
+// 1. Allocate a rows x cols matrix of zeros
+// In JS: Array.from({ length: rows }, () => new Array(cols).fill(0))
+func NewMatrix(rows, cols int) [][]int {
+	// TODO: make the outer slice, then make each row
+	// Every row needs its own backing array: reusing one row slice
+	// would mean writing m[0][0] also changes m[1][0].
+	return nil
+}
+
+// 2. Identity matrix: 1 on the diagonal, 0 everywhere else
+func Identity(n int) [][]int {
+	// TODO: start from NewMatrix(n, n)
+	return nil
+}
+
+// 3. Transpose: rows become columns
+//
+//	[[1, 2, 3],     [[1, 4],
+//	 [4, 5, 6]]  ->  [2, 5],
+//	                 [3, 6]]
+func Transpose(m [][]int) [][]int {
+	// TODO: a rows x cols matrix becomes cols x rows
+	// Result[j][i] = m[i][j]
+	// An empty matrix transposes to an empty matrix.
+	return nil
+}
+
+// 4. Matrix multiplication
+// Each result cell is the dot product of a row of a and a column of b.
+func Multiply(a, b [][]int) ([][]int, error) {
+	// TODO: a is n x m, b must be m x p, the result is n x p
+	// Return ErrDimensionMismatch if a's column count isn't b's row count
+	// A b with no rows has no columns either, so p is 0.
+	return nil, nil
+}
+
+// 5. Sum of each row
+// In JS: m.map(row => row.reduce((s, x) => s + x, 0))
+func RowSums(m [][]int) []int {
+	// TODO: one sum per row
+	return nil
+}
+
+// 6. Sum of each column
+func ColumnSums(m [][]int) []int {
+	// TODO: one sum per column; index the other way around
+	// An empty matrix has no columns.
+	return nil
+}
+
+// 7. Read a matrix from a CSV file with one row per line and no header
+func ReadMatrixCSV(filename string) ([][]int, error) {
+	// TODO: open the file and use csv.Reader's ReadAll
+	// Convert every cell with strconv.Atoi
+	// Rows of different lengths are an error: csv.Reader already checks
+	// that for you unless you change FieldsPerRecord
+	return nil, nil
+}
+
+// Keep imports used
+var _ = csv.NewReader
+var _ = os.Open
+var _ = strconv.Atoi
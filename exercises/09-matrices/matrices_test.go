@@ -0,0 +1,157 @@
+package matrices
+
+import (
+	"errors"
+	"io/fs"
+	"testing"
+
+	"github.com/imgarylai/learn-go/internal/assert"
+)
+
+func TestNewMatrix(t *testing.T) {
+	m := NewMatrix(2, 3)
+	want := [][]int{{0, 0, 0}, {0, 0, 0}}
+	if !assert.Equal(t, m, want) {
+		t.FailNow()
+	}
+
+	// Rows must not share memory.
+	m[0][1] = 7
+	if m[1][1] != 0 {
+		t.Errorf("writing m[0][1] changed m[1][1]: %v", m)
+	}
+
+	if got := NewMatrix(0, 3); len(got) != 0 {
+		t.Errorf("0 rows: got %v, want empty", got)
+	}
+}
+
+func TestIdentity(t *testing.T) {
+	want := [][]int{
+		{1, 0, 0},
+		{0, 1, 0},
+		{0, 0, 1},
+	}
+	assert.Equal(t, Identity(3), want)
+}
+
+func TestTranspose(t *testing.T) {
+	tests := []struct {
+		name    string
+		m, want [][]int
+	}{
+		{"2x3", [][]int{{1, 2, 3}, {4, 5, 6}}, [][]int{{1, 4}, {2, 5}, {3, 6}}},
+		{"3x1", [][]int{{1}, {2}, {3}}, [][]int{{1, 2, 3}}},
+		{"1x3", [][]int{{1, 2, 3}}, [][]int{{1}, {2}, {3}}},
+		{"square", [][]int{{1, 2}, {3, 4}}, [][]int{{1, 3}, {2, 4}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, Transpose(tt.m), tt.want)
+		})
+	}
+
+	if got := Transpose([][]int{}); len(got) != 0 {
+		t.Errorf("empty: got %v, want empty", got)
+	}
+}
+
+func TestTransposeDoesNotModifyInput(t *testing.T) {
+	m := [][]int{{1, 2}, {3, 4}}
+	Transpose(m)
+	assert.Equal(t, m, [][]int{{1, 2}, {3, 4}}, "input changed")
+}
+
+func TestMultiply(t *testing.T) {
+	a := [][]int{
+		{1, 2, 3},
+		{4, 5, 6},
+	}
+	b := [][]int{
+		{7, 8},
+		{9, 10},
+		{11, 12},
+	}
+	got, err := Multiply(a, b)
+	if err != nil {
+		t.Fatalf("Multiply failed: %v", err)
+	}
+	want := [][]int{
+		{58, 64},
+		{139, 154},
+	}
+	assert.Equal(t, got, want)
+
+	// Multiplying by the identity changes nothing.
+	got, err = Multiply(a, Identity(3))
+	if err != nil {
+		t.Fatalf("a x I: %v", err)
+	}
+	assert.Equal(t, got, a, "a x I")
+}
+
+func TestMultiplyShapes(t *testing.T) {
+	tests := []struct {
+		name       string
+		a, b, want [][]int
+	}{
+		{"1x3 x 3x1", [][]int{{1, 2, 3}}, [][]int{{4}, {5}, {6}}, [][]int{{32}}},
+		{"3x1 x 1x2", [][]int{{1}, {2}, {3}}, [][]int{{4, 5}}, [][]int{{4, 5}, {8, 10}, {12, 15}}},
+		{"2x0 x 0x0", [][]int{{}, {}}, [][]int{}, [][]int{{}, {}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Multiply(tt.a, tt.b)
+			if err != nil {
+				t.Fatalf("Multiply failed: %v", err)
+			}
+			assert.Equal(t, got, tt.want)
+		})
+	}
+}
+
+func TestMultiplyDimensionMismatch(t *testing.T) {
+	a := [][]int{{1, 2, 3}}
+	b := [][]int{{1, 2}, {3, 4}}
+	if _, err := Multiply(a, b); !errors.Is(err, ErrDimensionMismatch) {
+		t.Errorf("1x3 x 2x2: got error %v, want ErrDimensionMismatch", err)
+	}
+}
+
+func TestRowSums(t *testing.T) {
+	m := [][]int{{1, 2, 3}, {4, 5, 6}}
+	assert.Equal(t, RowSums(m), []int{6, 15})
+}
+
+func TestColumnSums(t *testing.T) {
+	m := [][]int{{1, 2, 3}, {4, 5, 6}}
+	assert.Equal(t, ColumnSums(m), []int{5, 7, 9})
+	assert.Equal(t, ColumnSums([][]int{{1, 2, 3}}), []int{1, 2, 3}, "one row")
+	if got := ColumnSums([][]int{}); len(got) != 0 {
+		t.Errorf("empty: got %v, want empty", got)
+	}
+}
+
+func TestReadMatrixCSV(t *testing.T) {
+	m, err := ReadMatrixCSV("testdata/matrix.csv")
+	if err != nil {
+		t.Fatalf("ReadMatrixCSV failed: %v", err)
+	}
+	want := [][]int{{1, 2, 3}, {4, 5, 6}}
+	assert.Equal(t, m, want)
+}
+
+func TestReadMatrixCSVErrors(t *testing.T) {
+	for _, file := range []string{
+		"testdata/ragged.csv",
+		"testdata/not-a-number.csv",
+		"testdata/missing.csv",
+	} {
+		if _, err := ReadMatrixCSV(file); err == nil {
+			t.Errorf("%s: expected an error", file)
+		}
+	}
+	if _, err := ReadMatrixCSV("testdata/missing.csv"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("testdata/missing.csv: got error %v, want one wrapping fs.ErrNotExist", err)
+	}
+}
@@ -0,0 +1,77 @@
+//go:build !solutions
+
+package sliceinternals
+
+// Exercise 10: Slice internals - length, capacity and aliasing
+//
+// A Go slice is a small struct: a pointer to a backing array, a length
+// and a capacity. Copying a slice copies that struct, not the elements,
+// so two slices can quietly share memory. JS arrays never do this:
+// arr.slice() always copies. Most surprising Go slice bugs come from
+// forgetting the difference.
+//
+// Run tests with: go test -v
+
+// 1. Watch append grow a slice
+// Start from a nil slice, append 0, 1, ..., n-1 one at a time, and
+// record cap(s) every time it changes (including the first append).
+func CapacityGrowth(n int) []int {
+	// TODO: track the previous capacity and record each new one
+	// The exact numbers are up to the runtime; the tests only check
+	// that capacity grows in jumps, not one element at a time.
+	return nil
+}
+
+// 2. Preallocate when you know the size
+// In JS: Array.from({ length: n }, (_, i) => i * i)
+func Squares(n int) []int {
+	// TODO: return [0, 1, 4, 9, ...] with n elements
+	// Use make([]int, 0, n) or make([]int, n) so append never has to
+	// reallocate: the tests count allocations.
+	return nil
+}
+
+// 3. Copy a slice
+// In JS: [...arr] or arr.slice()
+func Clone(s []int) []int {
+	// TODO: return a new slice with the same elements
+	// Writing to the result must not change s. Hint: make + copy
+	// A nil input gives a nil result.
+	return nil
+}
+
+// 4. A window that can't write past its end
+// In JS: arr.slice(from, to) - but without copying
+func Window(s []int, from, to int) []int {
+	// TODO: return s[from:to] with its capacity capped at its length
+	// so appending to the window reallocates instead of overwriting
+	// s[to]. Hint: the full slice expression s[low:high:max]
+	return nil
+}
+
+// 5. Append without touching the caller's backing array
+// Two callers appending to the same base must not see each other's
+// elements.
+func AppendTo(base []int, values ...int) []int {
+	// TODO: return base followed by values
+	// If base has spare capacity, a plain append writes into it, and the
+	// next AppendTo(base, ...) overwrites what this one returned.
+	return nil
+}
+
+// 6. Remove an element without modifying the input
+// In JS: arr.toSpliced(i, 1)
+func RemoveAt(s []int, i int) []int {
+	// TODO: return a new slice without s[i]; leave s unchanged
+	// append(s[:i], s[i+1:]...) shifts s's own elements left. Why?
+	return nil
+}
+
+// 7. Keep the last n elements without pinning a huge array
+// A small sub-slice keeps its entire backing array alive, so the
+// garbage collector can't free it.
+func Last(s []int, n int) []int {
+	// TODO: return a copy of the last n elements (all of s if n > len(s))
+	// The result's capacity should be exactly n.
+	return nil
+}
@@ -0,0 +1,132 @@
+package sliceinternals
+
+import (
+	"testing"
+
+	"github.com/imgarylai/learn-go/internal/assert"
+)
+
+func TestCapacityGrowth(t *testing.T) {
+	caps := CapacityGrowth(1000)
+	if len(caps) == 0 {
+		t.Fatal("got no capacities")
+	}
+	if caps[0] < 1 {
+		t.Errorf("first capacity: got %d, want at least 1", caps[0])
+	}
+	for i := 1; i < len(caps); i++ {
+		if caps[i] <= caps[i-1] {
+			t.Errorf("capacities must grow: %v", caps)
+			break
+		}
+	}
+	if last := caps[len(caps)-1]; last < 1000 {
+		t.Errorf("last capacity: got %d, want at least 1000", last)
+	}
+	// Growing one element at a time would need 1000 entries.
+	if len(caps) > 50 {
+		t.Errorf("got %d capacity changes for 1000 appends; append should grow in jumps", len(caps))
+	}
+}
+
+func TestSquares(t *testing.T) {
+	got := Squares(5)
+	assert.Equal(t, got, []int{0, 1, 4, 9, 16})
+	if cap(got) != 5 {
+		t.Errorf("cap: got %d, want 5", cap(got))
+	}
+
+	allocs := testing.AllocsPerRun(100, func() { Squares(1000) })
+	if allocs > 1 {
+		t.Errorf("Squares(1000) made %.0f allocations, want 1", allocs)
+	}
+}
+
+func TestClone(t *testing.T) {
+	s := []int{1, 2, 3}
+	c := Clone(s)
+	if !assert.Equal(t, c, s) {
+		t.FailNow()
+	}
+	c[0] = 99
+	if s[0] != 1 {
+		t.Errorf("writing to the clone changed the original: %v", s)
+	}
+
+	if got := Clone(nil); got != nil {
+		t.Errorf("Clone(nil): got %v, want nil", got)
+	}
+}
+
+func TestWindow(t *testing.T) {
+	s := []int{1, 2, 3, 4, 5}
+	w := Window(s, 1, 3)
+	if !assert.Equal(t, w, []int{2, 3}) {
+		t.FailNow()
+	}
+	if cap(w) != len(w) {
+		t.Errorf("cap: got %d, want %d", cap(w), len(w))
+	}
+
+	_ = append(w, 99)
+	if s[3] != 4 {
+		t.Errorf("appending to the window overwrote s[3]: %v", s)
+	}
+
+	// A window is a view, not a copy: writes go through.
+	w[0] = 20
+	if s[1] != 20 {
+		t.Errorf("Window should share memory with s; s[1] is %d", s[1])
+	}
+}
+
+func TestAppendTo(t *testing.T) {
+	base := make([]int, 2, 10)
+	base[0], base[1] = 1, 2
+
+	a := AppendTo(base, 3)
+	b := AppendTo(base, 4, 5)
+	if !assert.Equal(t, a, []int{1, 2, 3}, "a") {
+		t.FailNow()
+	}
+	assert.Equal(t, b, []int{1, 2, 4, 5}, "b")
+	if len(base) != 2 {
+		t.Errorf("base changed length: %v", base)
+	}
+
+	a[0] = 100
+	if base[0] != 1 {
+		t.Errorf("writing to the result changed base: %v", base)
+	}
+}
+
+func TestRemoveAt(t *testing.T) {
+	s := []int{1, 2, 3, 4}
+	got := RemoveAt(s, 1)
+	assert.Equal(t, got, []int{1, 3, 4})
+	assert.Equal(t, s, []int{1, 2, 3, 4}, "input changed")
+
+	assert.Equal(t, RemoveAt([]int{1, 2}, 1), []int{1}, "last element")
+	assert.Equal(t, RemoveAt([]int{1}, 0), []int{}, "only element")
+}
+
+func TestLast(t *testing.T) {
+	big := make([]int, 1_000_000)
+	for i := range big {
+		big[i] = i
+	}
+
+	got := Last(big, 3)
+	if !assert.Equal(t, got, []int{999_997, 999_998, 999_999}) {
+		t.FailNow()
+	}
+	if cap(got) != 3 {
+		t.Errorf("cap: got %d, want 3; the result still pins the big array", cap(got))
+	}
+	got[0] = -1
+	if big[999_997] != 999_997 {
+		t.Error("writing to the result changed the input")
+	}
+
+	assert.Equal(t, Last([]int{1, 2}, 5), []int{1, 2}, "n > len")
+}
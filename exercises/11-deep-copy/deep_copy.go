@@ -0,0 +1,87 @@
+//go:build !solutions
+
+package deepcopy
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Exercise 11: Deep copy and equality
+//
+// Assigning a struct copies its fields, like { ...user } in JS: a
+// shallow copy. Fields that are pointers, slices or maps still point at
+// the same data afterwards, so changing copy.Tags[0] also changes
+// user.Tags[0]. Go has no structuredClone; you write the deep copy
+// yourself, field by field.
+//
+// Equality has the same twist: == works on structs only when every field
+// is comparable, and slices and maps aren't.
+//
+// Run tests with: go test -v
+
+// Address is referenced through a pointer, so copies share it.
+type Address struct {
+	Street string
+	City   string
+}
+
+// User mixes every kind of field that a plain copy shares.
+type User struct {
+	Name     string
+	Age      int
+	Address  *Address          // may be nil
+	Tags     []string          // may be nil
+	Settings map[string]string // may be nil
+	Manager  *User             // may be nil; managers can have managers
+}
+
+// 1. Deep copy
+// In JS: structuredClone(user)
+func Clone(u User) User {
+	// TODO: start with c := u (copies Name and Age), then replace every
+	// pointer, slice and map field with a copy of its own
+	// Keep nil as nil: a nil Tags must not become []string{}
+	// Clone the Manager recursively
+	return User{}
+}
+
+// 2. Equality, by hand
+// Two users are equal when all their fields are. Nil and empty slices or
+// maps count as equal here, because callers can't tell them apart.
+func Equal(a, b User) bool {
+	// TODO: compare Name and Age with ==
+	// Addresses: both nil, or both non-nil with equal values (*a == *b)
+	// Tags: same length and same elements in order
+	// Settings: same length and every key maps to the same value
+	// Managers: both nil, or both non-nil and Equal
+	return false
+}
+
+// 3. Equality with reflect.DeepEqual
+// DeepEqual follows pointers, slices and maps for you, but it is
+// stricter than Equal: a nil slice and an empty slice are different.
+func DeepEqual(a, b User) bool {
+	// TODO: use reflect.DeepEqual
+	return false
+}
+
+// 4. Equality with a custom comparator
+// In JS: a.length === b.length && a.every((x, i) => eq(x, b[i]))
+func EqualFunc[T any](a, b []T, eq func(T, T) bool) bool {
+	// TODO: same length, and eq reports true for every pair a[i], b[i]
+	return false
+}
+
+// 5. A comparator for EqualFunc
+// Treat two users as the same person when their names match
+// case-insensitively and they live in the same city (or both have no
+// address). Ignore every other field.
+func SamePerson(a, b User) bool {
+	// TODO: strings.EqualFold compares names ignoring case
+	return false
+}
+
+// Keep imports used
+var _ = reflect.DeepEqual
+var _ = strings.EqualFold
@@ -0,0 +1,188 @@
+package deepcopy
+
+import (
+	"testing"
+
+	"github.com/imgarylai/learn-go/internal/assert"
+)
+
+func alice() User {
+	return User{
+		Name:     "Alice",
+		Age:      30,
+		Address:  &Address{Street: "1 Main St", City: "Springfield"},
+		Tags:     []string{"admin", "go"},
+		Settings: map[string]string{"theme": "dark"},
+		Manager: &User{
+			Name:    "Bob",
+			Age:     50,
+			Address: &Address{City: "Shelbyville"},
+		},
+	}
+}
+
+func TestCloneCopiesValues(t *testing.T) {
+	u := alice()
+	c := Clone(u)
+	if !assert.Equal(t, c, u, "clone differs from the original") {
+		t.FailNow()
+	}
+}
+
+// Every test below mutates the clone and checks the original is untouched.
+
+func TestCloneAddressIsIndependent(t *testing.T) {
+	u := alice()
+	c := Clone(u)
+	if c.Address == nil {
+		t.Fatal("Address was dropped")
+	}
+	if c.Address == u.Address {
+		t.Fatal("clone shares the Address pointer")
+	}
+	c.Address.City = "Capital City"
+	if u.Address.City != "Springfield" {
+		t.Errorf("changing the clone's city changed the original: %q", u.Address.City)
+	}
+}
+
+func TestCloneTagsAreIndependent(t *testing.T) {
+	u := alice()
+	c := Clone(u)
+	if len(c.Tags) != 2 {
+		t.Fatalf("Tags: got %v", c.Tags)
+	}
+	c.Tags[0] = "guest"
+	if u.Tags[0] != "admin" {
+		t.Errorf("changing the clone's tags changed the original: %v", u.Tags)
+	}
+}
+
+func TestCloneSettingsAreIndependent(t *testing.T) {
+	u := alice()
+	c := Clone(u)
+	if c.Settings == nil {
+		t.Fatal("Settings was dropped")
+	}
+	c.Settings["theme"] = "light"
+	c.Settings["lang"] = "en"
+	if len(u.Settings) != 1 || u.Settings["theme"] != "dark" {
+		t.Errorf("changing the clone's settings changed the original: %v", u.Settings)
+	}
+}
+
+func TestCloneManagerIsIndependent(t *testing.T) {
+	u := alice()
+	c := Clone(u)
+	if c.Manager == nil || c.Manager.Address == nil {
+		t.Fatal("Manager was dropped")
+	}
+	c.Manager.Name = "Carol"
+	c.Manager.Address.City = "Ogdenville"
+	if u.Manager.Name != "Bob" || u.Manager.Address.City != "Shelbyville" {
+		t.Errorf("changing the clone's manager changed the original: %+v", *u.Manager)
+	}
+}
+
+func TestCloneKeepsNil(t *testing.T) {
+	c := Clone(User{Name: "Nobody"})
+	if c.Name != "Nobody" {
+		t.Fatalf("Name: got %q", c.Name)
+	}
+	if c.Address != nil || c.Tags != nil || c.Settings != nil || c.Manager != nil {
+		t.Errorf("nil fields should stay nil: %+v", c)
+	}
+}
+
+func TestEqual(t *testing.T) {
+	if !Equal(alice(), alice()) {
+		t.Error("two separately built but identical users should be Equal")
+	}
+
+	tests := []struct {
+		name   string
+		change func(*User)
+	}{
+		{"name", func(u *User) { u.Name = "Alicia" }},
+		{"age", func(u *User) { u.Age++ }},
+		{"nil address", func(u *User) { u.Address = nil }},
+		{"city", func(u *User) { u.Address.City = "Capital City" }},
+		{"tag order", func(u *User) { u.Tags[0], u.Tags[1] = u.Tags[1], u.Tags[0] }},
+		{"extra tag", func(u *User) { u.Tags = append(u.Tags, "ops") }},
+		{"setting", func(u *User) { u.Settings["theme"] = "light" }},
+		{"other setting", func(u *User) { delete(u.Settings, "theme"); u.Settings["lang"] = "dark" }},
+		{"manager", func(u *User) { u.Manager.Age++ }},
+		{"no manager", func(u *User) { u.Manager = nil }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changed := alice()
+			tt.change(&changed)
+			if Equal(alice(), changed) || Equal(changed, alice()) {
+				t.Errorf("users differing in %s should not be Equal", tt.name)
+			}
+		})
+	}
+}
+
+func TestEqualVersusDeepEqual(t *testing.T) {
+	a := User{Name: "Dee", Tags: nil, Settings: nil}
+	b := User{Name: "Dee", Tags: []string{}, Settings: map[string]string{}}
+
+	if !Equal(a, b) {
+		t.Error("Equal: nil and empty should count as equal")
+	}
+	if DeepEqual(a, b) {
+		t.Error("DeepEqual: reflect.DeepEqual tells nil and empty apart")
+	}
+	if !DeepEqual(alice(), alice()) {
+		t.Error("DeepEqual: identical users should be equal")
+	}
+}
+
+func TestEqualFunc(t *testing.T) {
+	same := func(a, b int) bool { return a == b }
+	if !EqualFunc([]int{1, 2, 3}, []int{1, 2, 3}, same) {
+		t.Error("identical slices should be equal")
+	}
+	if EqualFunc([]int{1, 2}, []int{1, 2, 3}, same) {
+		t.Error("different lengths should not be equal")
+	}
+	if EqualFunc([]int{1, 2, 3}, []int{1, 2, 4}, same) {
+		t.Error("different elements should not be equal")
+	}
+	if !EqualFunc([]int{}, nil, same) {
+		t.Error("two empty slices should be equal")
+	}
+
+	parity := func(a, b int) bool { return a%2 == b%2 }
+	if !EqualFunc([]int{1, 2}, []int{3, 4}, parity) {
+		t.Error("the comparator decides what equal means")
+	}
+}
+
+func TestSamePerson(t *testing.T) {
+	a := alice()
+	b := User{Name: "ALICE", Age: 99, Address: &Address{City: "Springfield"}}
+	if !SamePerson(a, b) {
+		t.Error("same name (any case) and city should be the same person")
+	}
+
+	b.Address.City = "Shelbyville"
+	if SamePerson(a, b) {
+		t.Error("different cities should not be the same person")
+	}
+	if SamePerson(a, User{Name: "Alice"}) {
+		t.Error("an address and no address should not be the same person")
+	}
+	if !SamePerson(User{Name: "Zed"}, User{Name: "zed"}) {
+		t.Error("two people without addresses compare by name")
+	}
+
+	team := []User{alice(), {Name: "Carl"}}
+	other := []User{b, {Name: "carl"}}
+	other[0].Address = &Address{City: "Springfield"}
+	if !EqualFunc(team, other, SamePerson) {
+		t.Error("EqualFunc with SamePerson should match the teams")
+	}
+}
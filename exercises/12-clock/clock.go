@@ -0,0 +1,121 @@
+//go:build !solutions
+
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// Exercise 12: An injectable clock
+//
+// Code that calls time.Now() directly is hard to test: you can't make
+// "an hour later" happen without waiting an hour. In JS you'd reach for
+// jest.useFakeTimers(). In Go the usual trick is plainer: accept a
+// small interface instead of calling the time package, pass the real
+// clock in production and a fake one in tests.
+//
+// Run tests with: go test -v
+
+// Clock is the part of the time package our code needs.
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+// RealClock is the production Clock, backed by the time package.
+type RealClock struct{}
+
+// 1. The real clock just forwards to the time package
+func (RealClock) Now() time.Time {
+	// TODO: return time.Now()
+	return time.Time{}
+}
+
+func (RealClock) After(d time.Duration) <-chan time.Time {
+	// TODO: return time.After(d)
+	return nil
+}
+
+// FakeClock is a Clock for tests. Time stands still until Advance
+// moves it, so tests are instant and always give the same result.
+type FakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	waiters []waiter // channels handed out by After that haven't fired yet
+}
+
+type waiter struct {
+	at time.Time
+	ch chan time.Time
+}
+
+// 2. Constructor
+func NewFakeClock(start time.Time) *FakeClock {
+	// TODO: return a FakeClock whose current time is start
+	return nil
+}
+
+// 3. Now returns the fake current time
+func (c *FakeClock) Now() time.Time {
+	// TODO: lock c.mu (tests may call this from several goroutines)
+	return time.Time{}
+}
+
+// 4. After returns a channel that receives the time once Advance has
+// moved the clock d or more past the moment After was called
+func (c *FakeClock) After(d time.Duration) <-chan time.Time {
+	// TODO: make a channel with a buffer of 1, so Advance can send
+	// without blocking, and remember it in c.waiters
+	// If d <= 0, send right away instead
+	return nil
+}
+
+// 5. Advance moves the clock forward and fires every waiter that is due
+func (c *FakeClock) Advance(d time.Duration) {
+	// TODO: add d to c.now, send c.now on each due waiter's channel,
+	// and keep only the waiters that aren't due yet
+}
+
+// Token is a credential that stops working at ExpiresAt.
+type Token struct {
+	Value     string
+	ExpiresAt time.Time
+}
+
+// 6. Take the clock as a parameter instead of calling time.Now()
+func IsExpired(tok Token, c Clock) bool {
+	// TODO: a token is expired at ExpiresAt and any time after
+	return false
+}
+
+// RateWindow allows at most limit events in any window-long stretch of
+// time: a sliding-window rate limiter.
+type RateWindow struct {
+	clock  Clock
+	window time.Duration
+	limit  int
+	events []time.Time // times of the allowed events, oldest first
+}
+
+// 7. Constructor
+func NewRateWindow(c Clock, window time.Duration, limit int) *RateWindow {
+	// TODO
+	return nil
+}
+
+// 8. Allow reports whether another event fits in the window, and
+// records it if so
+func (r *RateWindow) Allow() bool {
+	// TODO: drop events that are window or more in the past, then
+	// allow and record the event if fewer than limit remain
+	return false
+}
+
+// 9. Wait for done, but give up after timeout
+// In JS: Promise.race([done, sleep(timeout)])
+func WaitOrTimeout(c Clock, done <-chan struct{}, timeout time.Duration) bool {
+	// TODO: select on done and c.After(timeout)
+	// Return true if done closed first, false on timeout
+	return false
+}
@@ -0,0 +1,185 @@
+package clock
+
+import (
+	"testing"
+	"time"
+)
+
+var start = time.Date(2025, time.January, 1, 12, 0, 0, 0, time.UTC)
+
+var (
+	_ Clock = RealClock{}
+	_ Clock = (*FakeClock)(nil)
+)
+
+func TestRealClock(t *testing.T) {
+	before := time.Now()
+	now := RealClock{}.Now()
+	if now.Before(before) || now.After(time.Now()) {
+		t.Errorf("Now: got %v, want about %v", now, before)
+	}
+
+	ch := RealClock{}.After(time.Millisecond)
+	if ch == nil {
+		t.Fatal("After returned a nil channel")
+	}
+	select {
+	case <-ch:
+	case <-time.After(time.Second):
+		t.Error("After(1ms) didn't fire within a second")
+	}
+}
+
+func TestFakeClockNowAndAdvance(t *testing.T) {
+	c := NewFakeClock(start)
+	if c == nil {
+		t.Fatal("NewFakeClock returned nil")
+	}
+	if got := c.Now(); !got.Equal(start) {
+		t.Fatalf("Now: got %v, want %v", got, start)
+	}
+	c.Advance(90 * time.Minute)
+	if got, want := c.Now(), start.Add(90*time.Minute); !got.Equal(want) {
+		t.Errorf("after Advance: got %v, want %v", got, want)
+	}
+}
+
+// fired reports whether ch has a value ready, without waiting.
+func fired(ch <-chan time.Time) bool {
+	select {
+	case <-ch:
+		return true
+	default:
+		return false
+	}
+}
+
+func TestFakeClockAfter(t *testing.T) {
+	c := NewFakeClock(start)
+	if c == nil {
+		t.Fatal("NewFakeClock returned nil")
+	}
+	soon := c.After(time.Minute)
+	later := c.After(time.Hour)
+	if soon == nil || later == nil {
+		t.Fatal("After returned a nil channel")
+	}
+
+	if fired(soon) {
+		t.Fatal("After(1m) fired before the clock moved")
+	}
+	c.Advance(59 * time.Second)
+	if fired(soon) {
+		t.Fatal("After(1m) fired after only 59s")
+	}
+	c.Advance(time.Second)
+	if !fired(soon) {
+		t.Fatal("After(1m) didn't fire after 1m")
+	}
+	if fired(later) {
+		t.Fatal("After(1h) fired after 1m")
+	}
+	c.Advance(2 * time.Hour)
+	if !fired(later) {
+		t.Error("After(1h) didn't fire after 2h")
+	}
+
+	if !fired(c.After(0)) {
+		t.Error("After(0) should fire immediately")
+	}
+	tiny := c.After(time.Nanosecond)
+	if fired(tiny) {
+		t.Error("After(1ns) fired before the clock moved")
+	}
+	c.Advance(time.Nanosecond)
+	if !fired(tiny) {
+		t.Error("After(1ns) didn't fire after 1ns")
+	}
+}
+
+func TestIsExpired(t *testing.T) {
+	c := NewFakeClock(start)
+	if c == nil {
+		t.Fatal("NewFakeClock returned nil")
+	}
+	tok := Token{Value: "abc", ExpiresAt: start.Add(time.Hour)}
+
+	if IsExpired(tok, c) {
+		t.Error("a token with an hour left is not expired")
+	}
+	c.Advance(time.Hour)
+	if !IsExpired(tok, c) {
+		t.Error("a token is expired at ExpiresAt")
+	}
+	c.Advance(time.Hour)
+	if !IsExpired(tok, c) {
+		t.Error("a token is expired after ExpiresAt")
+	}
+}
+
+func TestRateWindow(t *testing.T) {
+	c := NewFakeClock(start)
+	r := NewRateWindow(c, time.Minute, 3)
+	if c == nil || r == nil {
+		t.Fatal("constructors returned nil")
+	}
+
+	for i := range 3 {
+		if !r.Allow() {
+			t.Fatalf("event %d should be allowed", i+1)
+		}
+		c.Advance(10 * time.Second)
+	}
+	// Events at 0s, 10s and 20s; it's now 30s.
+	if r.Allow() {
+		t.Fatal("a 4th event within a minute should be refused")
+	}
+
+	c.Advance(30 * time.Second) // 60s: the event at 0s has left the window
+	if !r.Allow() {
+		t.Fatal("the window should have slid past the first event")
+	}
+	if r.Allow() {
+		t.Fatal("only one slot should have opened")
+	}
+
+	c.Advance(time.Hour)
+	for i := range 3 {
+		if !r.Allow() {
+			t.Errorf("after a quiet hour, event %d should be allowed", i+1)
+		}
+	}
+}
+
+func TestWaitOrTimeout(t *testing.T) {
+	c := NewFakeClock(start)
+	if c == nil {
+		t.Fatal("NewFakeClock returned nil")
+	}
+
+	done := make(chan struct{})
+	close(done)
+	if !WaitOrTimeout(c, done, time.Second) {
+		t.Error("done was already closed; want true")
+	}
+
+	result := make(chan bool)
+	go func() { result <- WaitOrTimeout(c, make(chan struct{}), time.Minute) }()
+
+	// Keep nudging the clock until the waiter has registered and fired.
+	// No real minute passes: the fake clock makes this instant.
+	deadline := time.After(5 * time.Second)
+	for {
+		c.Advance(time.Minute)
+		select {
+		case ok := <-result:
+			if ok {
+				t.Error("nothing closed done; want false after the timeout")
+			}
+			return
+		case <-deadline:
+			t.Fatal("WaitOrTimeout never returned")
+		case <-time.After(time.Millisecond):
+		}
+	}
+}
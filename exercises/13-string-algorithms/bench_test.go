@@ -0,0 +1,32 @@
+package stringalgorithms
+
+import (
+	"strings"
+	"testing"
+)
+
+// Benchmarks: `learngo bench 13` runs these, and `learngo bench
+// --solution 13` runs them against the reference solution. Building
+// Caesar's output with += copies the whole string on every rune; a
+// strings.Builder or a []rune doesn't.
+
+var benchText = strings.Repeat("The quick brown fox jumps over the lazy dog. ", 200)
+
+func BenchmarkCaesar(b *testing.B) {
+	for b.Loop() {
+		Caesar(benchText, 3)
+	}
+}
+
+func BenchmarkWordFrequency(b *testing.B) {
+	for b.Loop() {
+		WordFrequency(benchText)
+	}
+}
+
+func BenchmarkIsPalindrome(b *testing.B) {
+	s := strings.Repeat("ab", 5_000) + strings.Repeat("ba", 5_000)
+	for b.Loop() {
+		IsPalindrome(s)
+	}
+}
@@ -0,0 +1,78 @@
+//go:build !solutions
+
+package stringalgorithms
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Exercise 13: String algorithms
+//
+// A Go string is a read-only slice of bytes, usually UTF-8. len(s) counts
+// bytes and s[i] is a byte, so "é" has length 2 and "日本" has length 6.
+// JS strings are UTF-16 and have a similar trap with emoji. To work with
+// characters, range over the string or convert it to []rune: each rune
+// is one Unicode code point.
+// Run tests with: go test -v
+
+// 1. Palindrome check
+// In JS: const t = clean(s); return t === [...t].reverse().join("")
+// Ignore case, spaces and punctuation: only letters and digits count.
+func IsPalindrome(s string) bool {
+	// TODO: collect the lowercased letters and digits as []rune
+	// (unicode.IsLetter, unicode.IsDigit, unicode.ToLower), then compare
+	// from both ends towards the middle
+	// Reversing bytes instead of runes breaks "été": é is two bytes
+	return false
+}
+
+// 2. Anagram check
+// "Listen" and "Silent" use the same letters the same number of times.
+// Ignore case and spaces; every other character counts.
+func IsAnagram(a, b string) bool {
+	// TODO: count the runes of a in a map[rune]int, subtract the runes of
+	// b, and check that every count ends at zero
+	return false
+}
+
+// 3. Word frequency
+// Words are runs of letters, digits and apostrophes, compared case-
+// insensitively: "Café, café!" counts "café" twice.
+func WordFrequency(text string) map[string]int {
+	// TODO: strings.FieldsFunc splits wherever your func returns true;
+	// lowercase each word with strings.ToLower before counting
+	// Return an empty (non-nil) map for text without words
+	return nil
+}
+
+// 4. Longest common prefix
+// LongestCommonPrefix([]string{"flower", "flow", "flight"}) == "fl"
+func LongestCommonPrefix(words []string) string {
+	// TODO: start with the first word and shorten it while another word
+	// doesn't start with it (strings.HasPrefix)
+	// Careful: "héllo" and "hèllo" share the byte 0xC3 after the h, but
+	// half a character isn't a prefix. The result must be valid UTF-8
+	// (utf8.ValidString), so only cut at rune boundaries
+	// No words means no prefix: return ""
+	return ""
+}
+
+// 5. Caesar cipher
+// Shift every ASCII letter by shift places, wrapping around the
+// alphabet and keeping its case: Caesar("Hello, Zoë!", 3) == "Khoor, Crë!"
+// Everything else, including non-ASCII letters like ë, stays as it is.
+// A negative shift decodes, and any shift (even 100 or -29) must work.
+func Caesar(s string, shift int) string {
+	// TODO: use strings.Map with a func(rune) rune
+	// For 'a' <= r <= 'z': 'a' + (r-'a'+shift) mod 26
+	// Go's % keeps the sign of the left side (-1 % 26 == -1, as in JS),
+	// so normalize shift into 0..25 first
+	return s
+}
+
+// Keep imports used
+var _ = strings.Map
+var _ = unicode.IsLetter
+var _ = utf8.ValidString
@@ -0,0 +1,136 @@
+package stringalgorithms
+
+import (
+	"testing"
+	"unicode/utf8"
+
+	"github.com/imgarylai/learn-go/internal/assert"
+)
+
+func TestIsPalindrome(t *testing.T) {
+	tests := []struct {
+		s    string
+		want bool
+	}{
+		{"racecar", true},
+		{"A man, a plan, a canal: Panama", true},
+		{"Was it a car or a cat I saw?", true},
+		{"été", true},
+		{"日本日", true},
+		{"Ésé", true},
+		{"", true},
+		{"hello", false},
+		{"étè", false}, // é and è are different letters
+		{"日本", false},
+		{"ab1ba", true},
+		{"12321", true},
+		{"123", false},
+	}
+	for _, tt := range tests {
+		if got := IsPalindrome(tt.s); got != tt.want {
+			t.Errorf("IsPalindrome(%q): got %v, want %v", tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestIsAnagram(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"listen", "silent", true},
+		{"Listen", "Silent", true},
+		{"Dormitory", "dirty room", true},
+		{"évian", "naïve", false}, // é and ï aren't e and i
+		{"niño", "ñino", true},
+		{"アニメ", "メアニ", true},
+		{"hello", "world", false},
+		{"aab", "abb", false},
+		{"abc", "abcd", false},
+		{"", "", true},
+	}
+	for _, tt := range tests {
+		if got := IsAnagram(tt.a, tt.b); got != tt.want {
+			t.Errorf("IsAnagram(%q, %q): got %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestWordFrequency(t *testing.T) {
+	got := WordFrequency("The cat and the hat. THE END!")
+	want := map[string]int{"the": 3, "cat": 1, "and": 1, "hat": 1, "end": 1}
+	assert.Equal(t, got, want)
+
+	got = WordFrequency("Café, café! CAFÉ? don't stop… Straße straße")
+	want = map[string]int{"café": 3, "don't": 1, "stop": 1, "straße": 2}
+	assert.Equal(t, got, want, "unicode")
+
+	got = WordFrequency("  ,.!  ")
+	if got == nil || len(got) != 0 {
+		t.Errorf("no words: got %#v, want an empty map", got)
+	}
+}
+
+func TestLongestCommonPrefix(t *testing.T) {
+	tests := []struct {
+		words []string
+		want  string
+	}{
+		{[]string{"flower", "flow", "flight"}, "fl"},
+		{[]string{"dog", "racecar", "car"}, ""},
+		{[]string{"interview", "internet", "interval"}, "inter"},
+		{[]string{"alone"}, "alone"},
+		{[]string{"same", "same"}, "same"},
+		{[]string{"", "abc"}, ""},
+		{[]string{"日本語", "日本人"}, "日本"},
+		{[]string{"héllo", "hèllo"}, "h"}, // é and è share their first byte
+		{nil, ""},
+	}
+	for _, tt := range tests {
+		got := LongestCommonPrefix(tt.words)
+		if got != tt.want {
+			t.Errorf("LongestCommonPrefix(%q): got %q, want %q", tt.words, got, tt.want)
+		}
+		if !utf8.ValidString(got) {
+			t.Errorf("LongestCommonPrefix(%q): %q isn't valid UTF-8", tt.words, got)
+		}
+	}
+}
+
+func TestCaesar(t *testing.T) {
+	tests := []struct {
+		s     string
+		shift int
+		want  string
+	}{
+		{"abc", 1, "bcd"},
+		{"xyz", 3, "abc"},
+		{"Hello, World!", 13, "Uryyb, Jbeyq!"},
+		{"Hello, Zoë!", 3, "Khoor, Crë!"},
+		{"AZ az", 1, "BA ba"},
+		{"abc", 26, "abc"},
+		{"abc", 27, "bcd"},
+		{"abc", -1, "zab"},
+		{"abc", -27, "zab"},
+		{"日本 go", 2, "日本 iq"},
+		{"", 5, ""},
+	}
+	for _, tt := range tests {
+		if got := Caesar(tt.s, tt.shift); got != tt.want {
+			t.Errorf("Caesar(%q, %d): got %q, want %q", tt.s, tt.shift, got, tt.want)
+		}
+	}
+}
+
+func TestCaesarRoundTrip(t *testing.T) {
+	msg := "Meet me at the café at 10, señor."
+	for _, shift := range []int{1, 7, 25, 100, -3} {
+		enc := Caesar(msg, shift)
+		if enc == msg {
+			t.Errorf("shift %d: Caesar didn't change %q", shift, msg)
+		}
+		if dec := Caesar(enc, -shift); dec != msg {
+			t.Errorf("shift %d: decoding gave %q, want %q", shift, dec, msg)
+		}
+	}
+}
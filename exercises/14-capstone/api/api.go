@@ -0,0 +1,56 @@
+//go:build !solutions
+
+// Package api serves a report over HTTP as JSON.
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/imgarylai/learn-go/exercises/14-capstone/report"
+)
+
+// ShutdownTimeout is how long Serve waits for requests in flight once
+// it's told to stop.
+const ShutdownTimeout = 5 * time.Second
+
+// 8. Routes
+// Like an Express app with three routes:
+//
+//	GET /healthz               200, body "ok"
+//	GET /report                the whole report as JSON
+//	GET /categories/{name}     one CategoryTotal as JSON, or 404 with
+//	                           {"error": "unknown category \"name\""}
+//
+// JSON responses need the header Content-Type: application/json.
+// Anything else gets the mux's usual 404 or 405.
+func NewHandler(r report.Report) http.Handler {
+	// TODO: mux := http.NewServeMux(), then mux.HandleFunc("GET /report", ...)
+	// Method and {name} patterns need Go 1.22+; read the name with
+	// req.PathValue("name")
+	// A small writeJSON(w, status, v) helper saves repeating yourself
+	return http.NotFoundHandler()
+}
+
+// 9. Serve until told to stop
+// Serve h on ln until ctx is cancelled, then shut down gracefully:
+// stop accepting connections but let requests in flight finish (up to
+// ShutdownTimeout). Return nil after a clean shutdown, or the error
+// that stopped the server: Shutdown's, if a request outlasts
+// ShutdownTimeout.
+func Serve(ctx context.Context, ln net.Listener, h http.Handler) error {
+	// TODO: run srv.Serve(ln) in a goroutine and send its error on a
+	// channel, then select on ctx.Done() and that channel
+	// srv.Serve returns http.ErrServerClosed after Shutdown; that one
+	// isn't a failure
+	// Shutdown needs a fresh context: ctx is already cancelled
+	return nil
+}
+
+// Keep imports used
+var _ = json.Marshal
+var _ = errors.Is
@@ -0,0 +1,177 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/imgarylai/learn-go/exercises/14-capstone/report"
+)
+
+var rep = report.Report{
+	Revenue: 300,
+	Categories: []report.CategoryTotal{
+		{Category: "Kitchen", Units: 10, Revenue: 200},
+		{Category: "Office", Units: 50, Revenue: 100},
+	},
+	Regions: []report.RegionTotal{{Region: "North", Revenue: 300}},
+}
+
+func get(t *testing.T, h http.Handler, method, path string) *httptest.ResponseRecorder {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(method, path, nil))
+	return rec
+}
+
+func TestHealthz(t *testing.T) {
+	rec := get(t, NewHandler(rep), "GET", "/healthz")
+	if rec.Code != http.StatusOK || rec.Body.String() != "ok" {
+		t.Errorf("got %d %q, want 200 \"ok\"", rec.Code, rec.Body.String())
+	}
+}
+
+func TestReportEndpoint(t *testing.T) {
+	rec := get(t, NewHandler(rep), "GET", "/report")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status: got %d, want 200", rec.Code)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Content-Type: got %q", ct)
+	}
+	var got report.Report
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatalf("body isn't a report: %v\n%s", err, rec.Body.String())
+	}
+	if got.Revenue != 300 || len(got.Categories) != 2 || got.Categories[0].Category != "Kitchen" {
+		t.Errorf("got %+v", got)
+	}
+}
+
+func TestCategoryEndpoint(t *testing.T) {
+	h := NewHandler(rep)
+
+	rec := get(t, h, "GET", "/categories/Office")
+	var c report.CategoryTotal
+	if rec.Code != http.StatusOK || json.Unmarshal(rec.Body.Bytes(), &c) != nil || c.Units != 50 {
+		t.Errorf("Office: got %d %s", rec.Code, rec.Body.String())
+	}
+
+	rec = get(t, h, "GET", "/categories/Garden")
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("Garden: got status %d, want 404", rec.Code)
+	}
+	var e struct{ Error string }
+	if err := json.Unmarshal(rec.Body.Bytes(), &e); err != nil || e.Error != `unknown category "Garden"` {
+		t.Errorf("Garden: got body %q", rec.Body.String())
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Garden: Content-Type %q", ct)
+	}
+}
+
+func TestWrongMethod(t *testing.T) {
+	if rec := get(t, NewHandler(rep), "POST", "/report"); rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("POST /report: got %d, want 405", rec.Code)
+	}
+}
+
+func TestServeShutsDownGracefully(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	started := make(chan struct{})
+	slow := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		close(started)
+		time.Sleep(200 * time.Millisecond)
+		io.WriteString(w, "done")
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	served := make(chan error, 1)
+	go func() { served <- Serve(ctx, ln, slow) }()
+
+	// Start a slow request, then ask the server to stop while it runs.
+	body := make(chan string, 1)
+	go func() {
+		resp, err := http.Get("http://" + ln.Addr().String())
+		if err != nil {
+			body <- "error: " + err.Error()
+			return
+		}
+		defer resp.Body.Close()
+		b, _ := io.ReadAll(resp.Body)
+		body <- string(b)
+	}()
+	select {
+	case <-started:
+	case err := <-served:
+		t.Fatalf("Serve returned before ctx was cancelled: %v", err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the request never reached the handler")
+	}
+	cancel()
+
+	select {
+	case err := <-served:
+		if err != nil {
+			t.Errorf("Serve: got %v, want nil after a graceful shutdown", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Serve didn't return after ctx was cancelled")
+	}
+	if got := <-body; got != "done" {
+		t.Errorf("the request in flight got %q, want it to finish with \"done\"", got)
+	}
+
+	if _, err := http.Get("http://" + ln.Addr().String()); err == nil {
+		t.Error("the server still accepts connections after shutdown")
+	}
+}
+
+func TestServeGivesUpOnShutdown(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	started := make(chan struct{})
+	release := make(chan struct{})
+	defer close(release)
+	stuck := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		close(started)
+		<-release
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	served := make(chan error, 1)
+	go func() { served <- Serve(ctx, ln, stuck) }()
+	go http.Get("http://" + ln.Addr().String())
+	select {
+	case <-started:
+	case err := <-served:
+		t.Fatalf("Serve returned before ctx was cancelled: %v", err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the request never reached the handler")
+	}
+	cancel()
+
+	select {
+	case err := <-served:
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Serve: got %v, want context.DeadlineExceeded when a request outlasts ShutdownTimeout", err)
+		}
+	case <-time.After(ShutdownTimeout + 5*time.Second):
+		t.Fatal("Serve didn't return after ShutdownTimeout")
+	}
+}
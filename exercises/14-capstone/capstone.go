@@ -0,0 +1,84 @@
+//go:build !solutions
+
+package capstone
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+
+	"github.com/imgarylai/learn-go/exercises/14-capstone/api"
+	"github.com/imgarylai/learn-go/exercises/14-capstone/ingest"
+	"github.com/imgarylai/learn-go/exercises/14-capstone/report"
+)
+
+// Exercise 14: Capstone
+//
+// The other exercises each practice one idea. This one puts them
+// together into a small but real program, the kind of thing you'd run
+// in production:
+//
+//	products.csv ─┐
+//	sales-*.csv ──┴─> ingest ──> report ──┬─> report.json, report.csv
+//	                  (concurrent,        └─> HTTP JSON API, until Ctrl+C
+//	                   validated)
+//
+// Each box is its own package, like separate modules in a Node project:
+// ingest/ reads and checks the CSVs, report/ aggregates and exports, and
+// api/ serves the result. This package wires them together, and
+// cmd/salesreport is the main function that calls it.
+//
+// Work through ingest, report and api first (exercises 1 to 9); their
+// tests tell you when each piece works. Then finish this file: the
+// end-to-end test drives the whole flow through HTTP.
+// Run all the tests with: go test ./...
+
+// Config says where the program reads, writes and listens.
+type Config struct {
+	Products string       // path of the product catalog
+	Sales    []string     // sales files, read concurrently
+	OutDir   string       // where report.json and report.csv go
+	Listener net.Listener // where the API listens
+	Log      io.Writer    // skipped rows are reported here; nil means don't
+}
+
+// 10. Build the report from the input files
+// Read the catalog, load every sales file, build the report and set
+// its Rejected count. Print each rejected row to cfg.Log as
+// "skipped <row error>", one per line.
+func BuildReport(ctx context.Context, cfg Config) (report.Report, error) {
+	// TODO: os.Open the catalog (and defer Close), ingest.ReadProducts,
+	// ingest.LoadSales(ctx, cfg.Sales...), report.Build
+	return report.Report{}, nil
+}
+
+// 11. Export the report
+// Write report.json and report.csv into dir, creating dir if needed.
+// Don't ignore the error from closing a file you wrote: that's where
+// a full disk shows up.
+func Export(dir string, r report.Report) error {
+	// TODO: os.MkdirAll, then os.Create each file and use
+	// report.WriteJSON and report.WriteCSV
+	// The named-result defer trick from 02-functions works well here
+	return nil
+}
+
+// 12. Run the whole program
+// Build the report, export it, then serve it with api.Serve until ctx
+// is cancelled. Return the first error; don't start serving if the
+// report can't be built or written.
+func Run(ctx context.Context, cfg Config) error {
+	// TODO: BuildReport, Export(cfg.OutDir, ...), then
+	// api.Serve(ctx, cfg.Listener, api.NewHandler(rep))
+	return nil
+}
+
+// Keep imports used
+var _ = fmt.Fprintln
+var _ = os.Open
+var _ = filepath.Join
+var _ = api.Serve
+var _ = ingest.ReadProducts
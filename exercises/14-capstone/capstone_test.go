@@ -0,0 +1,237 @@
+package capstone
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/fs"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/imgarylai/learn-go/exercises/14-capstone/api"
+	"github.com/imgarylai/learn-go/exercises/14-capstone/report"
+	"github.com/imgarylai/learn-go/internal/assert"
+	"github.com/imgarylai/learn-go/internal/testutil"
+)
+
+func testConfig(t *testing.T) Config {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+	return Config{
+		Products: "testdata/products.csv",
+		Sales:    []string{"testdata/sales-north.csv", "testdata/sales-south.csv", "testdata/sales-west.csv"},
+		OutDir:   filepath.Join(t.TempDir(), "out"),
+		Listener: ln,
+	}
+}
+
+// testClient closes each connection after its response. An idle
+// keep-alive connection would hold up the server's Shutdown for all of
+// api.ShutdownTimeout.
+var testClient = &http.Client{
+	Timeout:   5 * time.Second,
+	Transport: &http.Transport{DisableKeepAlives: true},
+}
+
+// getJSON fetches url and decodes the body into v.
+func getJSON(t *testing.T, url string, v any) int {
+	t.Helper()
+	resp, err := testClient.Get(url)
+	if err != nil {
+		t.Fatalf("GET %s: %v", url, err)
+	}
+	defer resp.Body.Close()
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		t.Fatalf("GET %s: body isn't JSON: %v", url, err)
+	}
+	return resp.StatusCode
+}
+
+func TestEndToEnd(t *testing.T) {
+	cfg := testConfig(t)
+	var log bytes.Buffer
+	cfg.Log = &log
+	base := "http://" + cfg.Listener.Addr().String()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan error, 1)
+	go func() { done <- Run(ctx, cfg) }()
+
+	// Wait for the server to come up, the way a load balancer would.
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		resp, err := testClient.Get(base + "/healthz")
+		if err == nil {
+			resp.Body.Close()
+			if resp.StatusCode == http.StatusOK {
+				break
+			}
+		}
+		select {
+		case err := <-done:
+			t.Fatalf("Run returned before serving: %v", err)
+		default:
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("the server never answered /healthz")
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	var rep report.Report
+	if code := getJSON(t, base+"/report", &rep); code != http.StatusOK {
+		t.Fatalf("/report: status %d", code)
+	}
+	if !testutil.Near(rep.Revenue, 3671.52, 0.005) {
+		t.Errorf("revenue: got %.2f, want 3671.52", rep.Revenue)
+	}
+	var cats, regions []string
+	for _, c := range rep.Categories {
+		cats = append(cats, c.Category)
+	}
+	for _, r := range rep.Regions {
+		regions = append(regions, r.Region)
+	}
+	assert.Equal(t, cats, []string{"Electronics", "Kitchen", "Office"}, "categories")
+	assert.Equal(t, regions, []string{"North", "West", "South"}, "regions")
+	assert.Equal(t, rep.Unknown, []string{"Stapler"}, "unknown")
+	if rep.Rejected != 2 {
+		t.Errorf("rejected %d, want 2", rep.Rejected)
+	}
+
+	var office report.CategoryTotal
+	if code := getJSON(t, base+"/categories/Office", &office); code != http.StatusOK || office.Units != 27 || !testutil.Near(office.Revenue, 193.75, 0.005) {
+		t.Errorf("/categories/Office: got %d %+v", code, office)
+	}
+
+	// The exports were written before the server started.
+	csvData, err := os.ReadFile(filepath.Join(cfg.OutDir, "report.csv"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutil.Golden(t, "testdata/report.csv.golden", csvData)
+	jsonData, err := os.ReadFile(filepath.Join(cfg.OutDir, "report.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var exported report.Report
+	if err := json.Unmarshal(jsonData, &exported); err != nil {
+		t.Errorf("report.json: %v\n%s", err, jsonData)
+	} else {
+		assert.Equal(t, exported, rep, "report.json doesn't match /report")
+	}
+
+	cancel()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Run: got %v, want nil after a graceful shutdown", err)
+		}
+	case <-time.After(api.ShutdownTimeout + 5*time.Second):
+		t.Fatal("Run didn't return after ctx was cancelled")
+	}
+
+	for _, want := range []string{"skipped testdata/sales-north.csv:5:", "skipped testdata/sales-south.csv:6:"} {
+		if !strings.Contains(log.String(), want) {
+			t.Errorf("log is missing %q:\n%s", want, log.String())
+		}
+	}
+}
+
+func TestRunFailsBeforeServing(t *testing.T) {
+	cfg := testConfig(t)
+	cfg.Products = "testdata/missing.csv"
+
+	done := make(chan error, 1)
+	go func() { done <- Run(context.Background(), cfg) }()
+	select {
+	case err := <-done:
+		if !errors.Is(err, fs.ErrNotExist) {
+			t.Errorf("got %v, want an fs.ErrNotExist error for a missing catalog", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run kept going without a catalog")
+	}
+	if _, err := os.Stat(cfg.OutDir); err == nil {
+		t.Error("nothing should be exported when the report can't be built")
+	}
+}
+
+func TestRunFailsToExport(t *testing.T) {
+	cfg := testConfig(t)
+	// A directory can't be made inside a file.
+	cfg.OutDir = filepath.Join(testutil.WriteFile(t, t.TempDir(), "file", ""), "out")
+
+	done := make(chan error, 1)
+	go func() { done <- Run(context.Background(), cfg) }()
+	select {
+	case err := <-done:
+		if err == nil {
+			t.Error("want an error when the report can't be exported")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run started serving a report it couldn't export")
+	}
+}
+
+func TestBuildReportBadCatalog(t *testing.T) {
+	cfg := testConfig(t)
+	cfg.Products = testutil.WriteFile(t, t.TempDir(), "products.csv",
+		"id,name,price,category\n1,Laptop,999.99,Electronics\n2,Mouse,cheap,Electronics\n")
+	_, err := BuildReport(context.Background(), cfg)
+	if err == nil || !strings.Contains(err.Error(), cfg.Products) {
+		t.Errorf("got %v, want an error naming %s", err, cfg.Products)
+	}
+}
+
+func TestBuildReportMissingSalesFile(t *testing.T) {
+	cfg := testConfig(t)
+	cfg.Sales = append(cfg.Sales, "testdata/sales-east.csv")
+	if _, err := BuildReport(context.Background(), cfg); err == nil {
+		t.Error("want an error for a sales file that doesn't exist")
+	}
+}
+
+func TestExportCreatesDir(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "a", "b")
+	rep := report.Report{Categories: []report.CategoryTotal{{Category: "Kitchen", Units: 1, Revenue: 2.5}}}
+	if err := Export(dir, rep); err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	for _, name := range []string{"report.json", "report.csv"} {
+		f, err := os.Open(filepath.Join(dir, name))
+		if err != nil {
+			t.Errorf("%s: %v", name, err)
+			continue
+		}
+		data, _ := io.ReadAll(f)
+		f.Close()
+		if !strings.Contains(string(data), "Kitchen") {
+			t.Errorf("%s doesn't mention Kitchen:\n%s", name, data)
+		}
+	}
+}
+
+func TestExportFails(t *testing.T) {
+	dir := t.TempDir()
+	// report.csv can still be written, but report.json can't.
+	if err := os.Mkdir(filepath.Join(dir, "report.json"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	err := Export(dir, report.Report{})
+	if err == nil || !strings.Contains(err.Error(), "report.json") {
+		t.Errorf("got %v, want an error naming report.json", err)
+	}
+}
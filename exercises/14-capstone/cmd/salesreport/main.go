@@ -0,0 +1,57 @@
+// Command salesreport runs the capstone: it reads a product catalog and
+// sales files, writes report.json and report.csv, and serves the report
+// over HTTP until you press Ctrl+C.
+//
+//	go run ./cmd/salesreport -products testdata/products.csv testdata/sales-*.csv
+//	curl localhost:8080/report
+//
+// You don't need to change this file; it works once capstone.Run does.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+
+	capstone "github.com/imgarylai/learn-go/exercises/14-capstone"
+)
+
+func main() {
+	products := flag.String("products", "testdata/products.csv", "product catalog")
+	out := flag.String("out", "out", "directory for report.json and report.csv")
+	addr := flag.String("addr", "localhost:8080", "where the API listens")
+	flag.Parse()
+	if flag.NArg() == 0 {
+		fmt.Fprintln(os.Stderr, "usage: salesreport [flags] sales.csv...")
+		os.Exit(2)
+	}
+
+	// Ctrl+C (SIGINT) or a SIGTERM from a process manager cancels ctx,
+	// which is what tells the server to shut down gracefully. It's
+	// process.on("SIGINT", ...) in Node.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	ln, err := net.Listen("tcp", *addr)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	fmt.Printf("serving on http://%s (Ctrl+C to stop)\n", ln.Addr())
+
+	err = capstone.Run(ctx, capstone.Config{
+		Products: *products,
+		Sales:    flag.Args(),
+		OutDir:   *out,
+		Listener: ln,
+		Log:      os.Stderr,
+	})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
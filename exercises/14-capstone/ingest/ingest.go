@@ -0,0 +1,100 @@
+//go:build !solutions
+
+// Package ingest reads the capstone's input files: the product catalog
+// and any number of sales files, checked row by row.
+package ingest
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"sync"
+)
+
+// Product is one row of products.csv: id,name,price,category.
+type Product struct {
+	ID       int
+	Name     string
+	Price    float64
+	Category string
+}
+
+// Sale is one row of a sales file: product,quantity,price,region.
+type Sale struct {
+	Product  string
+	Quantity int
+	Price    float64 // per unit
+	Region   string
+}
+
+// Why a sales row was rejected.
+var (
+	ErrColumns  = errors.New("want 4 columns")
+	ErrQuantity = errors.New("quantity must be a whole number above 0")
+	ErrPrice    = errors.New("price must be a number, 0 or more")
+	ErrProduct  = errors.New("product is empty")
+)
+
+// RowError is a sales row that was skipped. Line is 1-based and counts
+// the header, so it matches what an editor shows.
+type RowError struct {
+	File string
+	Line int
+	Err  error
+}
+
+func (e *RowError) Error() string { return fmt.Sprintf("%s:%d: %v", e.File, e.Line, e.Err) }
+
+// Unwrap lets errors.Is(rowErr, ErrQuantity) see the reason.
+func (e *RowError) Unwrap() error { return e.Err }
+
+// Batch is everything LoadSales read.
+type Batch struct {
+	Sales    []Sale
+	Rejected []*RowError
+}
+
+// 1. Read the product catalog
+// The catalog is trusted data: a malformed row is an error for the whole
+// file, wrapped as "line N: <err>".
+func ReadProducts(r io.Reader) ([]Product, error) {
+	// TODO: csv.NewReader(r), skip the header, then parse each row with
+	// strconv.Atoi and strconv.ParseFloat
+	return nil, nil
+}
+
+// 2. Read one sales file, skipping bad rows
+// Sales come from many shops and some rows are wrong. Instead of
+// failing, collect a *RowError for each bad row and keep going. A file
+// that isn't CSV at all (or has no header) is still an error.
+func ReadSales(file string, r io.Reader) ([]Sale, []*RowError, error) {
+	// TODO: set FieldsPerRecord = -1 so a short row isn't a csv error,
+	// then check len(record) yourself (ErrColumns)
+	// Validate in column order and report the first problem:
+	// ErrProduct, ErrQuantity, ErrPrice
+	// Hint: reader.FieldPos(0) gives the line of the record just read
+	return nil, nil, nil
+}
+
+// 3. Read every sales file at once
+// In JS: await Promise.all(paths.map(readSales))
+// Read each file in its own goroutine. Keep the results in the order of
+// paths, not the order the goroutines finish, so the output is
+// repeatable. If any file can't be opened, return the errors joined.
+// Stop early with ctx.Err() if ctx is cancelled before you start.
+func LoadSales(ctx context.Context, paths ...string) (Batch, error) {
+	// TODO: give each goroutine its own slot in a results slice, so
+	// they never write to the same memory; sync.WaitGroup waits for all
+	// Then append the slots together in order
+	return Batch{}, nil
+}
+
+// Keep imports used
+var _ = csv.NewReader
+var _ = os.Open
+var _ = strconv.Atoi
+var _ sync.WaitGroup
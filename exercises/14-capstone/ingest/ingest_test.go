@@ -0,0 +1,169 @@
+package ingest
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/imgarylai/learn-go/internal/assert"
+	"github.com/imgarylai/learn-go/internal/testutil"
+)
+
+func TestReadProducts(t *testing.T) {
+	in := "id,name,price,category\n1,Laptop,999.99,Electronics\n3,Coffee Mug,12.99,Kitchen\n"
+	got, err := ReadProducts(strings.NewReader(in))
+	if err != nil {
+		t.Fatalf("ReadProducts failed: %v", err)
+	}
+	want := []Product{
+		{ID: 1, Name: "Laptop", Price: 999.99, Category: "Electronics"},
+		{ID: 3, Name: "Coffee Mug", Price: 12.99, Category: "Kitchen"},
+	}
+	assert.Equal(t, got, want)
+}
+
+func TestReadProductsRejectsBadCatalog(t *testing.T) {
+	in := "id,name,price,category\n1,Laptop,999.99,Electronics\n2,Mouse,cheap,Electronics\n"
+	_, err := ReadProducts(strings.NewReader(in))
+	if err == nil {
+		t.Fatal("expected an error for a price that isn't a number")
+	}
+	if !strings.Contains(err.Error(), "line 3") {
+		t.Errorf("error %q should name line 3", err)
+	}
+}
+
+func TestReadProductsErrors(t *testing.T) {
+	tests := []struct {
+		name, in string
+		want     string // in the error
+	}{
+		{"empty", "", "header"},
+		{"bad id", "id,name,price,category\nX1,Laptop,999.99,Electronics\n", "line 2"},
+		{"short row", "id,name,price,category\n1,Laptop\n", "line 2"},
+		// The line a row starts on, even when a quoted field spans two.
+		{"multi-line id", "id,name,price,category\n\"4\n\",Pen,1,Office\n", "line 2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ReadProducts(strings.NewReader(tt.in))
+			if err == nil {
+				t.Fatalf("ReadProducts(%q) = %v, nil; want an error", tt.in, got)
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error %q should mention %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadSales(t *testing.T) {
+	in := strings.Join([]string{
+		"product,quantity,price,region",
+		"Laptop,2,999.99,North",
+		"Headphones,three,79.99,North",
+		",1,5.00,North",
+		"Mug,1,-2,South",
+		"Mug,0,12.99,South",
+		"Notebook,5,4.99",
+		"Pen",
+		"Notebook,5,4.99,South",
+		"Sticker,3,0,South", // free is fine
+	}, "\n")
+
+	sales, rejected, err := ReadSales("north.csv", strings.NewReader(in))
+	if err != nil {
+		t.Fatalf("ReadSales failed: %v", err)
+	}
+	want := []Sale{
+		{Product: "Laptop", Quantity: 2, Price: 999.99, Region: "North"},
+		{Product: "Notebook", Quantity: 5, Price: 4.99, Region: "South"},
+		{Product: "Sticker", Quantity: 3, Price: 0, Region: "South"},
+	}
+	assert.Equal(t, sales, want, "sales")
+
+	wantRejected := []struct {
+		line int
+		err  error
+	}{
+		{3, ErrQuantity},
+		{4, ErrProduct},
+		{5, ErrPrice},
+		{6, ErrQuantity},
+		{7, ErrColumns},
+		{8, ErrColumns},
+	}
+	if len(rejected) != len(wantRejected) {
+		t.Fatalf("rejected: got %v, want %d rows", rejected, len(wantRejected))
+	}
+	for i, w := range wantRejected {
+		r := rejected[i]
+		if r.File != "north.csv" || r.Line != w.line || !errors.Is(r, w.err) {
+			t.Errorf("rejected[%d]: got %v, want north.csv:%d: %v", i, r, w.line, w.err)
+		}
+	}
+	if len(rejected) > 0 && rejected[0].Error() != "north.csv:3: "+ErrQuantity.Error() {
+		t.Errorf("message: got %q", rejected[0].Error())
+	}
+}
+
+func TestReadSalesEmptyFile(t *testing.T) {
+	if _, _, err := ReadSales("empty.csv", strings.NewReader("")); err == nil {
+		t.Error("a file without a header should be an error")
+	}
+}
+
+func TestReadSalesNotCSV(t *testing.T) {
+	in := "product,quantity,price,region\nMug,1,2.50,\"North\n"
+	if _, _, err := ReadSales("quote.csv", strings.NewReader(in)); err == nil || !strings.Contains(err.Error(), "quote.csv") {
+		t.Errorf("got %v; want an error naming quote.csv for a quote that's never closed", err)
+	}
+}
+
+func TestLoadSales(t *testing.T) {
+	dir := t.TempDir()
+	var paths []string
+	for i, region := range []string{"North", "South", "East", "West"} {
+		data := "product,quantity,price,region\n" +
+			strings.Repeat("Mug,1,2.50,"+region+"\n", i+1) +
+			"Mug,none,2.50," + region + "\n"
+		paths = append(paths, testutil.WriteFile(t, dir, region+".csv", data))
+	}
+
+	b, err := LoadSales(context.Background(), paths...)
+	if err != nil {
+		t.Fatalf("LoadSales failed: %v", err)
+	}
+	var regions []string
+	for _, s := range b.Sales {
+		regions = append(regions, s.Region)
+	}
+	want := []string{"North", "South", "South", "East", "East", "East", "West", "West", "West", "West"}
+	assert.Equal(t, regions, want, "sales should keep the order of paths")
+	if len(b.Rejected) != 4 {
+		t.Fatalf("rejected: got %v, want one row per file", b.Rejected)
+	}
+	for i, r := range b.Rejected {
+		if r.File != paths[i] {
+			t.Errorf("rejected[%d] is from %s, want %s", i, r.File, paths[i])
+		}
+	}
+}
+
+func TestLoadSalesMissingFile(t *testing.T) {
+	_, err := LoadSales(context.Background(), filepath.Join(t.TempDir(), "nope.csv"))
+	if !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("got %v, want an fs.ErrNotExist error", err)
+	}
+}
+
+func TestLoadSalesCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := LoadSales(ctx, "../testdata/sales-north.csv"); !errors.Is(err, context.Canceled) {
+		t.Errorf("got %v, want context.Canceled", err)
+	}
+}
@@ -0,0 +1,80 @@
+//go:build !solutions
+
+// Package report turns validated sales into totals and writes them out
+// as JSON or CSV.
+package report
+
+import (
+	"cmp"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"slices"
+	"strconv"
+
+	"github.com/imgarylai/learn-go/exercises/14-capstone/ingest"
+)
+
+// CategoryTotal is the sales of one product category.
+type CategoryTotal struct {
+	Category string  `json:"category"`
+	Units    int     `json:"units"`
+	Revenue  float64 `json:"revenue"`
+}
+
+// RegionTotal is the sales of one region.
+type RegionTotal struct {
+	Region  string  `json:"region"`
+	Revenue float64 `json:"revenue"`
+}
+
+// Report is the summary the API serves and the exporters write.
+type Report struct {
+	Revenue    float64         `json:"revenue"`
+	Categories []CategoryTotal `json:"categories"`
+	Regions    []RegionTotal   `json:"regions"`
+	// Unknown lists products that were sold but aren't in the catalog,
+	// sorted and without duplicates. Their sales aren't counted.
+	Unknown  []string `json:"unknown_products,omitempty"`
+	Rejected int      `json:"rejected_rows"` // filled in by the caller
+}
+
+// 4. Aggregate
+// Revenue is quantity times the sale's unit price (prices change, so
+// don't use the catalog's). Look up each product's category by name.
+// Sort categories and regions by revenue, highest first, and by name
+// when two are equal, so the output never depends on map order.
+func Build(products []ingest.Product, sales []ingest.Sale) Report {
+	// TODO: map product name -> category, then total into maps keyed by
+	// category and region, and turn those into sorted slices
+	// Hint: slices.SortFunc with cmp.Compare, or cmp.Or to chain keys
+	return Report{}
+}
+
+// 5. Find a category
+func (r Report) Category(name string) (CategoryTotal, bool) {
+	// TODO: look through r.Categories
+	return CategoryTotal{}, false
+}
+
+// 6. Export as JSON, indented with two spaces
+func WriteJSON(w io.Writer, r Report) error {
+	// TODO: json.NewEncoder(w) with SetIndent("", "  ")
+	return nil
+}
+
+// 7. Export the categories as CSV
+// One header row, category,units,revenue, then one row per category
+// with revenue to two decimals: Kitchen,16,207.84
+func WriteCSV(w io.Writer, r Report) error {
+	// TODO: csv.NewWriter, then Flush and return its Error()
+	// strconv.FormatFloat(x, 'f', 2, 64) gives two decimals
+	return nil
+}
+
+// Keep imports used
+var _ = cmp.Compare[int]
+var _ = csv.NewWriter
+var _ = json.NewEncoder
+var _ = slices.SortFunc[[]int]
+var _ = strconv.FormatFloat
@@ -0,0 +1,142 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/imgarylai/learn-go/exercises/14-capstone/ingest"
+	"github.com/imgarylai/learn-go/internal/testutil"
+)
+
+var products = []ingest.Product{
+	{ID: 1, Name: "Laptop", Price: 999.99, Category: "Electronics"},
+	{ID: 2, Name: "Mug", Price: 12.99, Category: "Kitchen"},
+	{ID: 3, Name: "Pan", Price: 30, Category: "Kitchen"},
+	{ID: 4, Name: "Pen", Price: 1, Category: "Office"},
+}
+
+var sales = []ingest.Sale{
+	{Product: "Mug", Quantity: 10, Price: 12.99, Region: "North"},
+	{Product: "Laptop", Quantity: 1, Price: 949.99, Region: "South"}, // on sale
+	{Product: "Pan", Quantity: 2, Price: 30, Region: "North"},
+	{Product: "Stapler", Quantity: 3, Price: 6, Region: "North"},
+	{Product: "Pen", Quantity: 60, Price: 1, Region: "South"},
+	{Product: "Stapler", Quantity: 1, Price: 6, Region: "South"},
+	{Product: "Eraser", Quantity: 1, Price: 1, Region: "East"},
+}
+
+func TestBuild(t *testing.T) {
+	r := Build(products, sales)
+
+	if !testutil.Near(r.Revenue, 1199.89, 0.005) {
+		t.Errorf("revenue: got %.2f, want 1199.89", r.Revenue)
+	}
+
+	wantCats := []CategoryTotal{
+		{"Electronics", 1, 949.99},
+		{"Kitchen", 12, 189.90},
+		{"Office", 60, 60},
+	}
+	if len(r.Categories) != len(wantCats) {
+		t.Fatalf("categories: got %+v, want %+v", r.Categories, wantCats)
+	}
+	for i, w := range wantCats {
+		g := r.Categories[i]
+		if g.Category != w.Category || g.Units != w.Units || !testutil.Near(g.Revenue, w.Revenue, 0.005) {
+			t.Errorf("categories[%d]: got %+v, want %+v", i, g, w)
+		}
+	}
+
+	wantRegions := []RegionTotal{{"South", 1009.99}, {"North", 189.90}}
+	if len(r.Regions) != len(wantRegions) {
+		t.Fatalf("regions: got %+v, want %+v", r.Regions, wantRegions)
+	}
+	for i, w := range wantRegions {
+		if g := r.Regions[i]; g.Region != w.Region || !testutil.Near(g.Revenue, w.Revenue, 0.005) {
+			t.Errorf("regions[%d]: got %+v, want %+v", i, g, w)
+		}
+	}
+
+	if len(r.Unknown) != 2 || r.Unknown[0] != "Eraser" || r.Unknown[1] != "Stapler" {
+		t.Errorf("unknown: got %v, want [Eraser Stapler]", r.Unknown)
+	}
+}
+
+func TestBuildTiesSortByName(t *testing.T) {
+	r := Build(products, []ingest.Sale{
+		{Product: "Pen", Quantity: 1, Price: 5, Region: "West"},
+		{Product: "Pan", Quantity: 1, Price: 5, Region: "East"},
+	})
+	if len(r.Categories) != 2 || r.Categories[0].Category != "Kitchen" {
+		t.Errorf("categories: got %+v, want Kitchen before Office", r.Categories)
+	}
+	if len(r.Regions) != 2 || r.Regions[0].Region != "East" {
+		t.Errorf("regions: got %+v, want East before West", r.Regions)
+	}
+}
+
+func TestCategory(t *testing.T) {
+	r := Build(products, sales)
+	c, ok := r.Category("Kitchen")
+	if !ok || c.Units != 12 {
+		t.Errorf("Kitchen: got %+v, %v", c, ok)
+	}
+	if _, ok := r.Category("Garden"); ok {
+		t.Error("Garden shouldn't be found")
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	r := Build(products, sales)
+	r.Rejected = 3
+	var buf bytes.Buffer
+	if err := WriteJSON(&buf, r); err != nil {
+		t.Fatalf("WriteJSON failed: %v", err)
+	}
+	if !bytes.Contains(buf.Bytes(), []byte("\n  \"revenue\": ")) {
+		t.Errorf("want JSON indented by two spaces:\n%s", buf.String())
+	}
+
+	var got map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("output isn't JSON: %v\n%s", err, buf.String())
+	}
+	for _, key := range []string{"revenue", "categories", "regions", "unknown_products", "rejected_rows"} {
+		if _, ok := got[key]; !ok {
+			t.Errorf("missing key %q in %s", key, buf.String())
+		}
+	}
+	if got["rejected_rows"] != 3.0 {
+		t.Errorf("rejected_rows: got %v, want 3", got["rejected_rows"])
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, Build(products, sales)); err != nil {
+		t.Fatalf("WriteCSV failed: %v", err)
+	}
+	want := "category,units,revenue\nElectronics,1,949.99\nKitchen,12,189.90\nOffice,60,60.00\n"
+	if buf.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+// failWriter fails every write, like a full disk.
+type failWriter struct{}
+
+var errDiskFull = errors.New("disk full")
+
+func (failWriter) Write([]byte) (int, error) { return 0, errDiskFull }
+
+func TestWriteErrors(t *testing.T) {
+	r := Build(products, sales)
+	if err := WriteCSV(failWriter{}, r); !errors.Is(err, errDiskFull) {
+		t.Errorf("WriteCSV: got %v, want the writer's error", err)
+	}
+	if err := WriteJSON(failWriter{}, r); !errors.Is(err, errDiskFull) {
+		t.Errorf("WriteJSON: got %v, want the writer's error", err)
+	}
+}
@@ -0,0 +1,104 @@
+//go:build !solutions
+
+package propertytesting
+
+// Exercise 15: Property-based testing
+//
+// An example-based test checks one input: Reverse([1 2 3]) is [3 2 1].
+// A property is a rule that holds for every input: reversing twice
+// gives back what you started with. A property-based test (fast-check
+// in JS, internal/proptest here) generates hundreds of inputs, and when
+// one breaks the rule it shrinks it to the smallest one that still
+// does.
+//
+// This time you write the properties, not the code under test. Each
+// func gets an implementation and one input, and returns false if the
+// implementation breaks the rule for that input. The tests run your
+// properties against a correct implementation, where they must hold,
+// and against buggy ones, where at least one of them must fail. One
+// property is rarely enough to catch every bug: that's why they come in
+// pairs.
+// Run tests with: go test -v
+
+// 1. Round trip (involution)
+// In JS: fc.property(fc.array(fc.integer()), s => equal(reverse(reverse(s)), s))
+func ReverseTwice(reverse func([]int) []int, s []int) bool {
+	// TODO: reverse s twice and compare with s (slices.Equal)
+	return true
+}
+
+// 2. A second property for reverse
+// Returning s unchanged passes ReverseTwice. Check what reverse is
+// actually for: the result is as long as s, and s's first element ends
+// up last.
+func ReverseMovesFirstToLast(reverse func([]int) []int, s []int) bool {
+	// TODO: compare lengths, then, if s isn't empty, compare the last
+	// element of the result with s[0]
+	return true
+}
+
+// 3. Postcondition
+// Every element of sort(s) is <= the one after it.
+func SortIsOrdered(sort func([]int) []int, s []int) bool {
+	// TODO: walk the result and compare neighbours
+	// (slices.IsSorted does it too)
+	return true
+}
+
+// 4. Invariant
+// An empty result is always ordered. Check sort(s) has the same
+// elements as s, each as many times.
+func SortKeepsElements(sort func([]int) []int, s []int) bool {
+	// TODO: count s's elements in a map[int]int, subtract the result's,
+	// and check every count ends at zero
+	return true
+}
+
+// 5. Dedupe removes repeated values, keeping the first of each.
+// No value appears twice in dedupe(s).
+func DedupeHasNoRepeats(dedupe func([]int) []int, s []int) bool {
+	// TODO: remember what you've seen in a map[int]bool
+	return true
+}
+
+// 6. ...and no value is lost or made up: every value of s is in
+// dedupe(s), and every value of dedupe(s) is in s.
+func DedupeKeepsEveryValue(dedupe func([]int) []int, s []int) bool {
+	// TODO: slices.Contains in both directions
+	return true
+}
+
+// 7. Homomorphism
+// Splitting a slice in two doesn't change its sum:
+// sum(a ++ b) == sum(a) + sum(b).
+func SumSplits(sum func([]int) int, a, b []int) bool {
+	// TODO: slices.Concat(a, b) joins them without touching either
+	return true
+}
+
+// 8. Base case
+// A sum that always returns 0 passes SumSplits. The sum of one number
+// is that number.
+func SumOfOne(sum func([]int) int, n int) bool {
+	// TODO
+	return true
+}
+
+// 9. Round trip (encode/decode)
+// encode is run-length encoding, "aaab" -> "3a1b", and decode undoes
+// it. Whatever s is, decode(encode(s)) is s again. In JS you'd check
+// JSON.parse(JSON.stringify(x)) the same way.
+func RoundTrip(encode, decode func(string) string, s string) bool {
+	// TODO
+	return true
+}
+
+// 10. Test oracle
+// search reports whether x is in a sorted slice, with binary search.
+// A linear scan is slower but obviously right, so compare with one:
+// sort a copy of s, search it for x, and check the answer matches
+// slices.Contains(s, x).
+func SearchAgreesWithScan(search func(sorted []int, x int) bool, s []int, x int) bool {
+	// TODO: slices.Sorted(slices.Values(s)) sorts a copy
+	return true
+}
@@ -0,0 +1,334 @@
+package propertytesting
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/imgarylai/learn-go/internal/proptest"
+	"github.com/imgarylai/learn-go/internal/testutil"
+)
+
+// Each test runs your properties twice over: against a correct
+// implementation, where every property must hold, and against buggy
+// ones, where at least one property must find an input that fails.
+// Small numbers make duplicates common, which several bugs need.
+
+var (
+	ints  = proptest.SliceOf(proptest.Int(-10, 10), 20)
+	pairs = proptest.Zip(ints, ints)
+)
+
+// holds checks that prop accepts the correct implementation: a property
+// that rejects working code is as useless as one that accepts anything.
+func holds[T any](t *testing.T, name string, g proptest.Gen[T], prop func(T) bool) {
+	t.Helper()
+	if f, ok := proptest.Find(testutil.Rand(t), g, prop); ok {
+		t.Errorf("%s rejects a correct implementation: %v", name, f)
+	}
+}
+
+// finds reports whether prop fails for some input from g.
+func finds[T any](r *rand.Rand, g proptest.Gen[T], prop func(T) bool) bool {
+	_, ok := proptest.Find(r, g, prop)
+	return ok
+}
+
+type sliceFunc = func([]int) []int
+
+func reverse(s []int) []int {
+	out := slices.Clone(s)
+	slices.Reverse(out)
+	return out
+}
+
+func TestReverseProperties(t *testing.T) {
+	holds(t, "ReverseTwice", ints, func(s []int) bool { return ReverseTwice(reverse, s) })
+	holds(t, "ReverseMovesFirstToLast", ints, func(s []int) bool { return ReverseMovesFirstToLast(reverse, s) })
+
+	bugs := []struct {
+		bug string
+		f   sliceFunc
+	}{
+		{"returns a copy unchanged", slices.Clone[[]int]},
+		{"drops the last element", func(s []int) []int {
+			if len(s) == 0 {
+				return nil
+			}
+			return reverse(s[:len(s)-1])
+		}},
+		{"leaves the first element in place", func(s []int) []int {
+			if len(s) == 0 {
+				return nil
+			}
+			return append([]int{s[0]}, reverse(s[1:])...)
+		}},
+	}
+	r := testutil.Rand(t)
+	for _, b := range bugs {
+		if !finds(r, ints, func(s []int) bool { return ReverseTwice(b.f, s) }) &&
+			!finds(r, ints, func(s []int) bool { return ReverseMovesFirstToLast(b.f, s) }) {
+			t.Errorf("no property catches a reverse that %s", b.bug)
+		}
+	}
+}
+
+func TestSortProperties(t *testing.T) {
+	sorted := func(s []int) []int { return slices.Sorted(slices.Values(s)) }
+	holds(t, "SortIsOrdered", ints, func(s []int) bool { return SortIsOrdered(sorted, s) })
+	holds(t, "SortKeepsElements", ints, func(s []int) bool { return SortKeepsElements(sorted, s) })
+
+	bugs := []struct {
+		bug string
+		f   sliceFunc
+	}{
+		{"returns a copy unchanged", slices.Clone[[]int]},
+		{"returns nothing", func([]int) []int { return nil }},
+		{"sorts in descending order", func(s []int) []int { return reverse(sorted(s)) }},
+		{"drops duplicates", func(s []int) []int { return slices.Compact(sorted(s)) }},
+		{"drops the largest element", func(s []int) []int {
+			out := sorted(s)
+			if len(out) == 0 {
+				return out
+			}
+			return out[:len(out)-1]
+		}},
+		{"swaps the first two elements", func(s []int) []int {
+			out := sorted(s)
+			if len(out) > 1 {
+				out[0], out[1] = out[1], out[0]
+			}
+			return out
+		}},
+	}
+	r := testutil.Rand(t)
+	for _, b := range bugs {
+		if !finds(r, ints, func(s []int) bool { return SortIsOrdered(b.f, s) }) &&
+			!finds(r, ints, func(s []int) bool { return SortKeepsElements(b.f, s) }) {
+			t.Errorf("no property catches a sort that %s", b.bug)
+		}
+	}
+}
+
+func dedupe(s []int) []int {
+	var out []int
+	for _, n := range s {
+		if !slices.Contains(out, n) {
+			out = append(out, n)
+		}
+	}
+	return out
+}
+
+func TestDedupeProperties(t *testing.T) {
+	holds(t, "DedupeHasNoRepeats", ints, func(s []int) bool { return DedupeHasNoRepeats(dedupe, s) })
+	holds(t, "DedupeKeepsEveryValue", ints, func(s []int) bool { return DedupeKeepsEveryValue(dedupe, s) })
+
+	bugs := []struct {
+		bug string
+		f   sliceFunc
+	}{
+		{"returns a copy unchanged", slices.Clone[[]int]},
+		{"only removes neighbouring duplicates, like uniq", func(s []int) []int { return slices.Compact(slices.Clone(s)) }},
+		{"returns nothing", func([]int) []int { return nil }},
+		{"drops every value that appears twice", func(s []int) []int {
+			counts := map[int]int{}
+			for _, n := range s {
+				counts[n]++
+			}
+			return slices.DeleteFunc(slices.Clone(s), func(n int) bool { return counts[n] > 1 })
+		}},
+		{"makes up a value", func(s []int) []int { return append(dedupe(s), 100) }},
+	}
+	r := testutil.Rand(t)
+	for _, b := range bugs {
+		if !finds(r, ints, func(s []int) bool { return DedupeHasNoRepeats(b.f, s) }) &&
+			!finds(r, ints, func(s []int) bool { return DedupeKeepsEveryValue(b.f, s) }) {
+			t.Errorf("no property catches a dedupe that %s", b.bug)
+		}
+	}
+}
+
+func sum(s []int) int {
+	total := 0
+	for _, n := range s {
+		total += n
+	}
+	return total
+}
+
+func TestSumProperties(t *testing.T) {
+	nums := proptest.Int(-1000, 1000)
+	holds(t, "SumSplits", pairs, func(p proptest.Pair[[]int, []int]) bool { return SumSplits(sum, p.A, p.B) })
+	holds(t, "SumOfOne", nums, func(n int) bool { return SumOfOne(sum, n) })
+
+	bugs := []struct {
+		bug string
+		f   func([]int) int
+	}{
+		{"always returns 0", func([]int) int { return 0 }},
+		{"skips the last element", func(s []int) int {
+			if len(s) == 0 {
+				return 0
+			}
+			return sum(s[:len(s)-1])
+		}},
+		{"skips the first element", func(s []int) int {
+			if len(s) == 0 {
+				return 0
+			}
+			return sum(s[1:])
+		}},
+		{"adds the length too", func(s []int) int { return sum(s) + len(s) }},
+		{"returns the largest element", func(s []int) int {
+			if len(s) == 0 {
+				return 0
+			}
+			return slices.Max(s)
+		}},
+	}
+	r := testutil.Rand(t)
+	for _, b := range bugs {
+		if !finds(r, pairs, func(p proptest.Pair[[]int, []int]) bool { return SumSplits(b.f, p.A, p.B) }) &&
+			!finds(r, nums, func(n int) bool { return SumOfOne(b.f, n) }) {
+			t.Errorf("no property catches a sum that %s", b.bug)
+		}
+	}
+}
+
+// runs calls emit with the length and byte of each run in s: "aaab" has
+// runs (3, 'a') and (1, 'b').
+func runs(s string, emit func(n int, c byte)) {
+	for i := 0; i < len(s); {
+		j := i
+		for j < len(s) && s[j] == s[i] {
+			j++
+		}
+		emit(j-i, s[i])
+		i = j
+	}
+}
+
+// encode is run-length encoding: "aaab" -> "3a1b".
+func encode(s string) string {
+	var b strings.Builder
+	runs(s, func(n int, c byte) { fmt.Fprintf(&b, "%d%c", n, c) })
+	return b.String()
+}
+
+func decode(s string) string {
+	var b strings.Builder
+	for len(s) > 0 {
+		i := strings.IndexFunc(s, func(r rune) bool { return r < '0' || r > '9' })
+		if i < 0 {
+			break
+		}
+		n, _ := strconv.Atoi(s[:i])
+		b.WriteString(strings.Repeat(s[i:i+1], n))
+		s = s[i+1:]
+	}
+	return b.String()
+}
+
+func TestRoundTripProperty(t *testing.T) {
+	// Mostly a's, so runs of 10 or more come up.
+	strs := proptest.StringOf("aaab", 30)
+	holds(t, "RoundTrip", strs, func(s string) bool { return RoundTrip(encode, decode, s) })
+
+	bugs := []struct {
+		bug    string
+		encode func(string) string
+	}{
+		{"forgets the last run", func(s string) string {
+			var b strings.Builder
+			var last string
+			runs(s, func(n int, c byte) {
+				b.WriteString(last)
+				last = fmt.Sprintf("%d%c", n, c)
+			})
+			return b.String()
+		}},
+		{"writes only the last digit of a count", func(s string) string {
+			var b strings.Builder
+			runs(s, func(n int, c byte) { fmt.Fprintf(&b, "%d%c", n%10, c) })
+			return b.String()
+		}},
+		{"puts the letter before the count", func(s string) string {
+			var b strings.Builder
+			runs(s, func(n int, c byte) { fmt.Fprintf(&b, "%c%d", c, n) })
+			return b.String()
+		}},
+	}
+	r := testutil.Rand(t)
+	for _, b := range bugs {
+		if !finds(r, strs, func(s string) bool { return RoundTrip(b.encode, decode, s) }) {
+			t.Errorf("RoundTrip doesn't catch an encoder that %s", b.bug)
+		}
+	}
+}
+
+func search(sorted []int, x int) bool {
+	_, found := slices.BinarySearch(sorted, x)
+	return found
+}
+
+func TestSearchProperty(t *testing.T) {
+	// Off-by-one bugs live at the ends, so x is often the smallest or
+	// largest element of s; a random x would mostly miss them.
+	random := proptest.Zip(ints, proptest.Int(-12, 12))
+	inputs := proptest.Gen[proptest.Pair[[]int, int]]{
+		Generate: func(r *rand.Rand, size int) proptest.Pair[[]int, int] {
+			p := random.Generate(r, size)
+			if len(p.A) > 0 {
+				switch r.IntN(3) {
+				case 0:
+					p.B = slices.Min(p.A)
+				case 1:
+					p.B = slices.Max(p.A)
+				}
+			}
+			return p
+		},
+		Shrink: random.Shrink,
+	}
+	holds(t, "SearchAgreesWithScan", inputs, func(p proptest.Pair[[]int, int]) bool {
+		return SearchAgreesWithScan(search, p.A, p.B)
+	})
+
+	bugs := []struct {
+		bug string
+		f   func([]int, int) bool
+	}{
+		{"never looks at the last element", func(s []int, x int) bool {
+			lo, hi := 0, len(s)-2
+			for lo <= hi {
+				mid := (lo + hi) / 2
+				switch {
+				case s[mid] == x:
+					return true
+				case s[mid] < x:
+					lo = mid + 1
+				default:
+					hi = mid - 1
+				}
+			}
+			return false
+		}},
+		{"never looks at the first element", func(s []int, x int) bool {
+			return len(s) > 0 && search(s[1:], x)
+		}},
+		{"always says no", func([]int, int) bool { return false }},
+		{"says yes for anything in range", func(s []int, x int) bool {
+			return len(s) > 0 && s[0] <= x && x <= s[len(s)-1]
+		}},
+	}
+	r := testutil.Rand(t)
+	for _, b := range bugs {
+		if !finds(r, inputs, func(p proptest.Pair[[]int, int]) bool { return SearchAgreesWithScan(b.f, p.A, p.B) }) {
+			t.Errorf("SearchAgreesWithScan doesn't catch a search that %s", b.bug)
+		}
+	}
+}
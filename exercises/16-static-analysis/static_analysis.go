@@ -0,0 +1,97 @@
+//go:build !solutions
+
+package staticanalysis
+
+import (
+	"go/ast"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// Exercise 16: Write your own analyzer
+//
+// `go vet` is a set of analyzers: small programs that read your code's
+// syntax tree and type information and report suspicious things. An
+// analyzer is Go's ESLint rule: where a rule's create(context) returns
+// visitors and calls context.report, an analyzer's Run gets a Pass with
+// the parsed files (pass.Files), what every name refers to
+// (pass.TypesInfo) and pass.Reportf to report a problem.
+//
+// You'll write two, on top of golang.org/x/tools/go/analysis. The tests
+// use analysistest, Go's RuleTester: it runs an analyzer over the small
+// packages in testdata/src and checks that it reports exactly the lines
+// marked `// want "..."`, no more, no fewer.
+// Run tests with: go test -v
+
+// NoPrint flags fmt.Print, fmt.Printf and fmt.Println in library code.
+// A library that prints decides for its callers where the output goes;
+// it should return the value, or write to an io.Writer it's given.
+// Commands (package main) may print.
+var NoPrint = &analysis.Analyzer{
+	Name: "noprint",
+	Doc:  "report fmt.Print, Printf and Println calls outside package main",
+	Run:  RunNoPrint,
+}
+
+// FileClose flags files opened with os.Open, os.Create or os.OpenFile
+// that are never closed, the Go version of a file handle leak.
+var FileClose = &analysis.Analyzer{
+	Name: "fileclose",
+	Doc:  "report *os.File variables that are opened but never closed",
+	Run:  RunFileClose,
+}
+
+// 1. Which function is this?
+// Report whether call calls fmt.Print, fmt.Printf or fmt.Println.
+// Looking at the text isn't enough: the package could be imported under
+// another name, and a method called Println on your own type is fine.
+// Ask the type checker instead, like TypeScript's checker resolving a
+// symbol rather than matching its name.
+func IsFmtPrint(pass *analysis.Pass, call *ast.CallExpr) bool {
+	// TODO: typeutil.Callee(pass.TypesInfo, call) returns the called
+	// func or method as a types.Object (nil for a conversion or a call
+	// of a func value). Check it's a *types.Func, that its Pkg() isn't
+	// nil and has Path() "fmt", and that its Name() is one of the three.
+	return false
+}
+
+// 2. The noprint analyzer
+// Report every fmt.Print, Printf or Println call with
+//
+//	pass.Reportf(call.Pos(), "fmt.%s in library code: return the value or take an io.Writer", name)
+//
+// unless the package is main (pass.Pkg.Name()).
+func RunNoPrint(pass *analysis.Pass) (any, error) {
+	// TODO: ast.Inspect(file, func(n ast.Node) bool { ... }) visits
+	// every node of each file in pass.Files; look for *ast.CallExpr.
+	// The name is in call.Fun, an *ast.SelectorExpr (fmt.Println) whose
+	// Sel is the identifier Println.
+	return nil, nil
+}
+
+// 3. The fileclose analyzer
+// In each function, find the variables a file is assigned to:
+//
+//	f, err := os.Open(name)
+//
+// and report the ones that are never closed (f.Close(), deferred or
+// not) with
+//
+//	pass.Reportf(pos, "%s is opened with %s but never closed", name, "os.Open")
+//
+// at the variable's position. A function that returns f hands it to its
+// caller, who has to close it, so that's fine too. Anything subtler,
+// like passing f to another function that closes it, is out of scope:
+// real analyzers draw a line somewhere as well.
+func RunFileClose(pass *analysis.Pass) (any, error) {
+	// TODO: for each *ast.FuncDecl with a Body:
+	//   - in every *ast.AssignStmt whose right-hand side is one call to
+	//     os.Open, os.Create or os.OpenFile, the first Lhs identifier is
+	//     the file; pass.TypesInfo.ObjectOf(ident) gives its variable
+	//     (skip "_").
+	//   - a selector x.Close where x's object is that variable closes it;
+	//     so does a return statement that mentions x.
+	// Keying a map by the types.Object rather than the name keeps two
+	// variables called f in different scopes apart.
+	return nil, nil
+}
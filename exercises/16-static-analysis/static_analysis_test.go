@@ -0,0 +1,31 @@
+package staticanalysis
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+// analysistest loads the packages in testdata/src, runs an analyzer on
+// them and compares what it reports with the `// want` comments there:
+// a missing report and an unexpected one both fail the test. Read the
+// testdata files to see the cases your analyzer has to get right.
+
+func TestAnalyzersAreValid(t *testing.T) {
+	if err := analysis.Validate([]*analysis.Analyzer{NoPrint, FileClose}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestNoPrint(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), NoPrint, "library")
+}
+
+func TestNoPrintAllowsMain(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), NoPrint, "cmdtool")
+}
+
+func TestFileClose(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), FileClose, "files")
+}
@@ -0,0 +1,10 @@
+// Command cmdtool may print: it's a program, not a library. The
+// noprint analyzer should report nothing here.
+package main
+
+import "fmt"
+
+func main() {
+	fmt.Println("hello")
+	fmt.Printf("%d\n", 42)
+}
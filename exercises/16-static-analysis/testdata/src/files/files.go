@@ -0,0 +1,85 @@
+// Package files is the fileclose analyzer's test input.
+package files
+
+import (
+	"bufio"
+	"io"
+	"os"
+)
+
+func Deferred(name string) (string, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	b, err := io.ReadAll(f)
+	return string(b), err
+}
+
+func Leaks(name string) (int, error) {
+	f, err := os.Open(name) // want `f is opened with os.Open but never closed`
+	if err != nil {
+		return 0, err
+	}
+	n := 0
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		n++
+	}
+	return n, sc.Err()
+}
+
+func CreateLeaks(name string) error {
+	out, err := os.Create(name) // want `out is opened with os.Create but never closed`
+	if err != nil {
+		return err
+	}
+	_, err = out.WriteString("data")
+	return err
+}
+
+func ClosedExplicitly(name string) error {
+	out, err := os.OpenFile(name, os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := out.WriteString("more"); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// The caller gets the file, and closing it is their job.
+func Returned(name string) (*os.File, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// Two variables called f: closing the first doesn't close the second.
+func TwoFiles(a, b string) error {
+	{
+		f, err := os.Open(a)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+	}
+	f, err := os.Open(b) // want `f is opened with os.Open but never closed`
+	if err != nil {
+		return err
+	}
+	_, err = f.Stat()
+	return err
+}
+
+// Opening something that isn't a file is none of the analyzer's
+// business.
+func NotAFile(name string) ([]byte, error) {
+	data, err := os.ReadFile(name)
+	return data, err
+}
@@ -0,0 +1,45 @@
+// Package library is the noprint analyzer's test input. Each call it
+// should report is followed by a "want" comment, a regexp its message
+// must match.
+package library
+
+import (
+	"fmt"
+	f "fmt"
+	"io"
+)
+
+func Greet(name string) {
+	fmt.Println("hello,", name) // want `fmt.Println in library code`
+}
+
+func Total(n int) {
+	fmt.Printf("total: %d\n", n) // want `fmt.Printf in library code`
+	fmt.Print("done")            // want `fmt.Print in library code`
+}
+
+// Renamed imports are still fmt.
+func Renamed() {
+	f.Println("hi") // want `fmt.Println in library code`
+}
+
+// Formatting a string or writing to a writer you were given is fine.
+func Fine(w io.Writer, n int) string {
+	fmt.Fprintln(w, n)
+	return fmt.Sprintf("%d", n)
+}
+
+// A method called Println on your own type isn't fmt's.
+type Logger struct{ lines []string }
+
+func (l *Logger) Println(s string) { l.lines = append(l.lines, s) }
+
+func UseLogger(l *Logger) {
+	l.Println("not fmt")
+}
+
+// Neither is a local func value.
+func Shadowed() {
+	Println := func(string) {}
+	Println("not fmt")
+}
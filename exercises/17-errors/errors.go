@@ -0,0 +1,149 @@
+//go:build !solutions
+
+package errorhandling
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Exercise 17: Errors in depth
+//
+// 02-functions returned errors; this one is about what callers do with
+// them. In JS you'd check `err instanceof NotFoundError` or look at
+// `err.cause`. Go errors form a chain instead: fmt.Errorf with %w wraps
+// one error in another, adding context on the way up, and errors.Is and
+// errors.As search the whole chain, so the caller can still tell what
+// went wrong underneath. errors.Join turns the chain into a tree, for
+// when several things went wrong at once.
+//
+// Run tests with: go test -v
+
+// Sentinel errors are plain values to compare against, like the
+// `code` strings on Node errors ("ENOENT"). Callers test for them with
+// errors.Is, never with ==, so they still match once wrapped.
+var (
+	ErrNotFound  = errors.New("not found")
+	ErrInvalid   = errors.New("invalid")
+	ErrPermanent = errors.New("permanent failure")
+)
+
+// 1. Return a sentinel
+// Lookup returns m[key], or ErrNotFound itself when key isn't in m.
+func Lookup(m map[string]int, key string) (int, error) {
+	// TODO: use the two-value form, v, ok := m[key]
+	return 0, nil
+}
+
+// 2. Wrap with %w
+// LoadConfig reads the file name with read. When read fails, return the
+// error wrapped with the file name: "load config app.json: <err>". With
+// %w the caller can still ask errors.Is(err, fs.ErrNotExist); with %v it
+// couldn't.
+// In JS: throw new Error(`load config ${name}`, { cause: err })
+func LoadConfig(name string, read func(string) ([]byte, error)) ([]byte, error) {
+	// TODO: fmt.Errorf("load config %s: %w", name, err)
+	return nil, nil
+}
+
+// 3. Break the chain on purpose
+// Opaque returns an error with the same message as err that errors.Is
+// and errors.As can't see through. A package does this at its
+// boundary when the error underneath is an implementation detail
+// callers shouldn't come to depend on. Opaque(nil) is nil.
+func Opaque(err error) error {
+	// TODO: %v formats the error without wrapping it
+	return err
+}
+
+// 4. errors.Is
+// Classify names what went wrong: "ok" for nil, "not found" for
+// ErrNotFound, "invalid" for ErrInvalid, "other" for anything else,
+// however deeply the error is wrapped or joined. When both sentinels
+// are in there, not found wins.
+func Classify(err error) string {
+	// TODO: errors.Is(err, ErrNotFound), ...
+	return ""
+}
+
+// 5. A custom error type
+// ValidationError says which field was wrong and why. Error formats it
+// as "<field>: <err>"; Unwrap returns Err, which puts Err in the chain:
+// errors.Is(&ValidationError{"age", ErrInvalid}, ErrInvalid) is true.
+// In JS: class ValidationError extends Error { constructor(field, cause) ... }
+type ValidationError struct {
+	Field string
+	Err   error
+}
+
+func (e *ValidationError) Error() string {
+	// TODO
+	return ""
+}
+
+func (e *ValidationError) Unwrap() error {
+	// TODO
+	return nil
+}
+
+// User is what Validate checks.
+type User struct {
+	Name  string
+	Email string
+	Age   int
+}
+
+// 6. errors.Join
+// Validate checks every field and reports every problem, not just the
+// first, the way a form shows all its errors at once. Each problem is a
+// *ValidationError wrapping ErrInvalid: Name must not be empty, Email
+// must contain an "@", Age must be between 0 and 150. Its Field is the
+// field's name in lower case, as a JSON form would send it: "name",
+// "email" or "age". Check the fields in that order and join the
+// problems with errors.Join; a valid user gives nil.
+func Validate(u User) error {
+	// TODO: collect the problems in a []error; errors.Join(nil...) is nil
+	return nil
+}
+
+// 7. errors.As, and walking the tree
+// errors.As finds the first *ValidationError in err. InvalidFields
+// finds all of them, in order, and returns their fields. Wrapped
+// errors have an Unwrap() error method, joined ones an
+// Unwrap() []error; follow both.
+func InvalidFields(err error) []string {
+	// TODO: if err is a *ValidationError, take its Field; then recurse
+	// into whatever err.(interface{ Unwrap() error }) or
+	// err.(interface{ Unwrap() []error }) gives back
+	return nil
+}
+
+// 8. A custom Is method
+// StatusError is an HTTP error. Two of them should match on the code
+// alone, so callers can write errors.Is(err, &StatusError{Code: 404})
+// whatever the message. errors.Is calls an Is method when an error in
+// the chain has one.
+type StatusError struct {
+	Code int
+	Msg  string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("%d %s", e.Code, e.Msg)
+}
+
+func (e *StatusError) Is(target error) bool {
+	// TODO: true when target is a *StatusError with the same Code
+	return false
+}
+
+// 9. Retry, joining the failures
+// Retry calls f up to attempts times, until it returns nil. It gives up
+// early on an error that is ErrPermanent. When no attempt succeeds, it
+// returns every error, each wrapped as "attempt <n>: <err>" (counting
+// from 1), joined with errors.Join, so the caller sees all of them and
+// errors.Is still finds each cause.
+func Retry(attempts int, f func() error) error {
+	// TODO
+	return nil
+}
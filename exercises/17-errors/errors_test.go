@@ -0,0 +1,241 @@
+package errorhandling
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"testing"
+
+	"github.com/imgarylai/learn-go/internal/assert"
+)
+
+func TestLookup(t *testing.T) {
+	m := map[string]int{"a": 1, "zero": 0}
+
+	v, err := Lookup(m, "a")
+	if err != nil || v != 1 {
+		t.Errorf(`Lookup("a") = %d, %v; want 1, nil`, v, err)
+	}
+	// A key holding the zero value is still there.
+	if v, err := Lookup(m, "zero"); err != nil || v != 0 {
+		t.Errorf(`Lookup("zero") = %d, %v; want 0, nil`, v, err)
+	}
+	// The sentinel itself, so even == works here. Once wrapped, only
+	// errors.Is does.
+	if v, err := Lookup(m, "b"); err != ErrNotFound || v != 0 {
+		t.Errorf(`Lookup("b") = %d, %v; want 0, ErrNotFound`, v, err)
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	var asked string
+	data, err := LoadConfig("app.json", func(name string) ([]byte, error) {
+		asked = name
+		return []byte("{}"), nil
+	})
+	if err != nil || string(data) != "{}" || asked != "app.json" {
+		t.Errorf("got %q, %v (read %q); want \"{}\", nil (read \"app.json\")", data, err, asked)
+	}
+
+	data, err = LoadConfig("app.json", func(string) ([]byte, error) {
+		return nil, fs.ErrNotExist
+	})
+	if err == nil {
+		t.Fatal("a failed read gave no error")
+	}
+	assert.Equal(t, err.Error(), "load config app.json: file does not exist", "message")
+	if !errors.Is(err, fs.ErrNotExist) {
+		t.Error("errors.Is(err, fs.ErrNotExist) is false: wrap the error with the %w verb")
+	}
+	if data != nil {
+		t.Errorf("data with an error: %q", data)
+	}
+}
+
+func TestOpaque(t *testing.T) {
+	if Opaque(nil) != nil {
+		t.Error("Opaque(nil) isn't nil")
+	}
+
+	err := fmt.Errorf("query users: %w", &ValidationError{Field: "id", Err: ErrNotFound})
+	o := Opaque(err)
+	if o == nil {
+		t.Fatal("Opaque returned nil")
+	}
+	assert.Equal(t, o.Error(), err.Error(), "message")
+	if errors.Is(o, ErrNotFound) {
+		t.Error("errors.Is still finds ErrNotFound through Opaque")
+	}
+	var v *ValidationError
+	if errors.As(o, &v) {
+		t.Error("errors.As still finds the *ValidationError through Opaque")
+	}
+}
+
+func TestClassify(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"nil", nil, "ok"},
+		{"sentinel", ErrNotFound, "not found"},
+		{"wrapped twice", fmt.Errorf("handler: %w", fmt.Errorf("lookup: %w", ErrNotFound)), "not found"},
+		{"invalid", fmt.Errorf("parse: %w", ErrInvalid), "invalid"},
+		{"joined", errors.Join(ErrInvalid, fmt.Errorf("x: %w", ErrNotFound)), "not found"},
+		{"other", errors.New("disk full"), "other"},
+		{"formatted with %v", fmt.Errorf("lookup: %v", ErrNotFound), "other"},
+	} {
+		assert.Equal(t, Classify(tt.err), tt.want, tt.name)
+	}
+}
+
+func TestValidationError(t *testing.T) {
+	v := &ValidationError{Field: "age", Err: ErrInvalid}
+	assert.Equal(t, v.Error(), "age: invalid", "Error()")
+	if errors.Unwrap(v) != ErrInvalid {
+		t.Errorf("Unwrap: got %v, want ErrInvalid", errors.Unwrap(v))
+	}
+
+	err := fmt.Errorf("save user: %w", v)
+	if !errors.Is(err, ErrInvalid) {
+		t.Error("errors.Is can't find ErrInvalid behind a wrapped *ValidationError")
+	}
+	var got *ValidationError
+	if !errors.As(err, &got) || got.Field != "age" {
+		t.Errorf("errors.As: got %v", got)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	valid := User{Name: "Ada", Email: "ada@example.com", Age: 36}
+	if err := Validate(valid); err != nil {
+		t.Errorf("Validate(%+v) = %v, want nil", valid, err)
+	}
+	for _, age := range []int{0, 150} {
+		u := valid
+		u.Age = age
+		if err := Validate(u); err != nil {
+			t.Errorf("age %d: got %v, want nil", age, err)
+		}
+	}
+	for _, age := range []int{-1, 151} {
+		u := valid
+		u.Age = age
+		err := Validate(u)
+		if err == nil {
+			t.Errorf("age %d: no error", age)
+			continue
+		}
+		assert.Equal(t, err.Error(), "age: invalid", fmt.Sprintf("age %d", age))
+	}
+
+	err := Validate(User{Email: "ada.example.com", Age: 200})
+	if err == nil {
+		t.Fatal("Validate accepted a user with every field wrong")
+	}
+	// errors.Join puts one error per line.
+	assert.Equal(t, err.Error(), "name: invalid\nemail: invalid\nage: invalid", "message")
+	if !errors.Is(err, ErrInvalid) {
+		t.Error("errors.Is(err, ErrInvalid) is false")
+	}
+	var v *ValidationError
+	if !errors.As(err, &v) || v.Field != "name" {
+		t.Errorf("errors.As: got %v, want the name error first", v)
+	}
+}
+
+func TestInvalidFields(t *testing.T) {
+	if got := InvalidFields(nil); len(got) != 0 {
+		t.Errorf("InvalidFields(nil) = %q", got)
+	}
+	if got := InvalidFields(errors.New("boom")); len(got) != 0 {
+		t.Errorf("no ValidationError: got %q", got)
+	}
+
+	tree := errors.Join(
+		fmt.Errorf("user 1: %w", errors.Join(
+			&ValidationError{Field: "name", Err: ErrInvalid},
+			&ValidationError{Field: "age", Err: ErrInvalid},
+		)),
+		errors.New("unrelated"),
+		&ValidationError{Field: "address", Err: &ValidationError{Field: "zip", Err: ErrInvalid}},
+	)
+	assert.Equal(t, InvalidFields(tree), []string{"name", "age", "address", "zip"})
+}
+
+func TestStatusError(t *testing.T) {
+	err := fmt.Errorf("fetch /users/7: %w", &StatusError{Code: 404, Msg: "Not Found"})
+
+	if !errors.Is(err, &StatusError{Code: 404}) {
+		t.Error("errors.Is(err, &StatusError{Code: 404}) is false: the message shouldn't matter")
+	}
+	if errors.Is(err, &StatusError{Code: 500, Msg: "Not Found"}) {
+		t.Error("a 404 matches a 500")
+	}
+	if errors.Is(err, errors.New("404 Not Found")) {
+		t.Error("a 404 matches an error that isn't a *StatusError")
+	}
+}
+
+func TestRetry(t *testing.T) {
+	t.Run("succeeds", func(t *testing.T) {
+		calls := 0
+		err := Retry(5, func() error {
+			calls++
+			if calls < 3 {
+				return errors.New("busy")
+			}
+			return nil
+		})
+		if err != nil || calls != 3 {
+			t.Errorf("got %v after %d calls, want nil after 3", err, calls)
+		}
+	})
+
+	t.Run("first try", func(t *testing.T) {
+		calls := 0
+		if err := Retry(3, func() error { calls++; return nil }); err != nil || calls != 1 {
+			t.Errorf("got %v after %d calls, want nil after 1", err, calls)
+		}
+	})
+
+	t.Run("gives up", func(t *testing.T) {
+		calls := 0
+		timeout := errors.New("timeout")
+		err := Retry(3, func() error {
+			calls++
+			if calls == 2 {
+				return timeout
+			}
+			return fmt.Errorf("busy %d", calls)
+		})
+		if calls != 3 {
+			t.Errorf("f was called %d times, want 3", calls)
+		}
+		if err == nil {
+			t.Fatal("no error after every attempt failed")
+		}
+		assert.Equal(t, err.Error(), "attempt 1: busy 1\nattempt 2: timeout\nattempt 3: busy 3", "message")
+		if !errors.Is(err, timeout) {
+			t.Error("errors.Is can't find the second attempt's error")
+		}
+	})
+
+	t.Run("permanent", func(t *testing.T) {
+		calls := 0
+		err := Retry(5, func() error {
+			calls++
+			if calls == 2 {
+				return fmt.Errorf("bad request: %w", ErrPermanent)
+			}
+			return errors.New("busy")
+		})
+		if calls != 2 {
+			t.Errorf("f was called %d times, want 2: stop at a permanent error", calls)
+		}
+		if !errors.Is(err, ErrPermanent) {
+			t.Errorf("got %v, want an error that is ErrPermanent", err)
+		}
+	})
+}
@@ -0,0 +1,203 @@
+//go:build !solutions
+
+package generics
+
+import (
+	"cmp"
+	"fmt"
+)
+
+// Exercise 18: Generics in depth
+//
+// 04 and 08 wrote helpers over []T with `any` and `comparable`. Those
+// constraints let you move values around but not do much with them:
+// with T any you can't write a < b or a + b. This module is about
+// constraints that allow more, and about where Go's generics stop
+// short of TypeScript's.
+//
+// A constraint is an interface. It can list methods, like the
+// interfaces of 05, and it can list types: a type set. cmp.Ordered is
+// every type < works on (it started life as constraints.Ordered in
+// golang.org/x/exp). ~int means "int, or any type whose underlying type
+// is int", so your own `type Celsius float64` is allowed too.
+//
+// Run tests with: go test -v
+
+// 1. Min with cmp.Ordered
+// In TS: function min<T extends number | string>(first: T, ...rest: T[]): T
+// Taking first separately means Min can't be called with no values at
+// all, so there's always an answer.
+func Min[T cmp.Ordered](first T, rest ...T) T {
+	// TODO: start from first and keep whichever is smaller
+	// (the built-in min does it for two values; write the loop)
+	return first
+}
+
+// 2. Max, the same way
+func Max[T cmp.Ordered](first T, rest ...T) T {
+	// TODO
+	return first
+}
+
+// 3. Clamp
+// Clamp limits v to [lo, hi]: lo if it's below, hi if it's above.
+// In JS: Math.min(Math.max(v, lo), hi), for numbers only.
+func Clamp[T cmp.Ordered](v, lo, hi T) T {
+	// TODO
+	return v
+}
+
+// Number is a custom type set: the types + and / work on. The ~ lets
+// named types in, like Celsius in the tests.
+//
+// Number has no methods, only types, so it can only be a constraint:
+// `var n Number` doesn't compile. TypeScript's number | bigint is a
+// type you can declare variables of; a Go type set isn't.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 | ~float32 | ~float64
+}
+
+// 4. Sum over a type set
+// Sum adds up nums, 0 for none. The result has the element type, so
+// the sum of []Celsius is a Celsius.
+func Sum[T Number](nums []T) T {
+	// TODO: var total T starts at the zero value
+	var total T
+	return total
+}
+
+// 5. Converting type parameters
+// Average is the mean of nums as a float64, 0 for none. T(x) and
+// float64(x) convert when every type in the set can, which is the case
+// for Number.
+func Average[T Number](nums []T) float64 {
+	// TODO: Sum, then float64(...) / float64(len(nums))
+	return 0
+}
+
+// Set is a set of values, like new Set() in JS. A map with empty
+// struct values takes no space for them.
+type Set[T comparable] map[T]struct{}
+
+// 6. A generic type and its methods
+// NewSet returns a set holding items. With no items Go can't infer T,
+// so callers write NewSet[int]().
+func NewSet[T comparable](items ...T) Set[T] {
+	// TODO: make the map, then Add each item
+	return Set[T]{}
+}
+
+// Add puts v in the set.
+func (s Set[T]) Add(v T) {
+	// TODO
+}
+
+// Has reports whether v is in the set.
+func (s Set[T]) Has(v T) bool {
+	// TODO: _, ok := s[v]
+	return false
+}
+
+// Len is the number of values in the set.
+func (s Set[T]) Len() int {
+	// TODO
+	return 0
+}
+
+// 7. Methods returning the same generic type
+// Union is a new set with the values of both; Intersect has the values
+// that are in both. Neither changes s or other.
+func (s Set[T]) Union(other Set[T]) Set[T] {
+	// TODO
+	return nil
+}
+
+func (s Set[T]) Intersect(other Set[T]) Set[T] {
+	// TODO: loop over the smaller set
+	return nil
+}
+
+// 8. A method can't add a constraint
+// Sorted returns the values of s in increasing order. Sorting needs <,
+// but Set's T is only comparable, and a method can't ask for more than
+// its type does: `func (s Set[T]) Sorted() []T` couldn't compare. So
+// it's a function with its own, stricter, type parameter instead.
+func Sorted[T cmp.Ordered](s Set[T]) []T {
+	// TODO: collect the keys, then slices.Sort
+	return nil
+}
+
+// Result holds either a value or an error, like a settled Promise:
+// { status: "fulfilled", value } or { status: "rejected", reason }.
+type Result[T any] struct {
+	Value T
+	Err   error
+}
+
+// 9. Generic constructors and methods
+// Ok and Fail build a Result. Fail's Value is the zero value of T.
+func Ok[T any](v T) Result[T] {
+	// TODO
+	return Result[T]{}
+}
+
+func Fail[T any](err error) Result[T] {
+	// TODO
+	return Result[T]{}
+}
+
+// Get returns the value and the error, in Go's usual shape.
+func (r Result[T]) Get() (T, error) {
+	// TODO
+	var zero T
+	return zero, nil
+}
+
+// Or returns the value, or fallback when r failed.
+func (r Result[T]) Or(fallback T) T {
+	// TODO
+	return fallback
+}
+
+// 10. A new type parameter needs a function
+// MapResult applies f to the value of a successful Result; a failed one
+// keeps its error, with U's zero value. In TS this would be a method,
+// result.map(f); in Go methods can't have type parameters of their own.
+func MapResult[T, U any](r Result[T], f func(T) U) Result[U] {
+	// TODO
+	return Result[U]{}
+}
+
+// Pair is a key and its value, like one entry of Object.entries.
+type Pair[K comparable, V any] struct {
+	Key   K
+	Value V
+}
+
+// 11. Two type parameters, two constraints
+// Entries returns m's key-value pairs sorted by key, so the order is
+// the same every time (map iteration order isn't).
+// In JS: Object.entries(obj).sort(([a], [b]) => a < b ? -1 : 1)
+func Entries[K cmp.Ordered, V any](m map[K]V) []Pair[K, V] {
+	// TODO
+	return nil
+}
+
+// Enum is a constraint with a type set and a method: an integer type
+// whose values can name themselves. With ~int alone you could convert
+// to and from int but not call String; with String alone you couldn't
+// convert. Weekday in the tests is one.
+type Enum interface {
+	~int
+	fmt.Stringer
+}
+
+// 12. Using both halves of a constraint
+// ParseEnum finds the value of E named s, trying E(0) up to E(n-1), and
+// reports whether there was one. E(i) works because of ~int, v.String()
+// because of fmt.Stringer.
+func ParseEnum[E Enum](s string, n int) (E, bool) {
+	// TODO
+	var zero E
+	return zero, false
+}
@@ -0,0 +1,244 @@
+package generics
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/imgarylai/learn-go/internal/assert"
+)
+
+// Named types: ~ in a constraint is what lets them in.
+type (
+	Celsius float64
+	Name    string
+)
+
+type Weekday int
+
+const (
+	Sunday Weekday = iota
+	Monday
+	Tuesday
+	Wednesday
+	Thursday
+	Friday
+	Saturday
+)
+
+var weekdays = []string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"}
+
+func (d Weekday) String() string {
+	if d < 0 || int(d) >= len(weekdays) {
+		return "Weekday(" + strconv.Itoa(int(d)) + ")"
+	}
+	return weekdays[d]
+}
+
+func TestMinMax(t *testing.T) {
+	ints := []struct {
+		name          string
+		first         int
+		rest          []int
+		wantMin, want int
+	}{
+		{"one value", 7, nil, 7, 7},
+		{"first is smallest", 1, []int{5, 3}, 1, 5},
+		{"last is smallest", 4, []int{9, -2}, -2, 9},
+		{"duplicates", 3, []int{3, 3}, 3, 3},
+	}
+	for _, tt := range ints {
+		assert.Equal(t, Min(tt.first, tt.rest...), tt.wantMin, "Min: "+tt.name)
+		assert.Equal(t, Max(tt.first, tt.rest...), tt.want, "Max: "+tt.name)
+	}
+
+	// The same funcs, other ordered types.
+	assert.Equal(t, Min("pear", "apple", "fig"), "apple", "Min of strings")
+	assert.Equal(t, Max(Name("ann"), Name("bob")), Name("bob"), "Max of a named string type")
+	assert.Equal(t, Min(2.5, -0.5), -0.5, "Min of floats")
+	assert.Equal(t, Max(Celsius(-3), Celsius(21.5), Celsius(4)), Celsius(21.5), "Max of Celsius")
+}
+
+func TestClamp(t *testing.T) {
+	for _, tt := range []struct {
+		v, lo, hi, want int
+	}{
+		{5, 0, 10, 5},
+		{-1, 0, 10, 0},
+		{11, 0, 10, 10},
+		{0, 0, 10, 0},
+		{10, 0, 10, 10},
+		{3, 3, 3, 3},
+	} {
+		assert.Equal(t, Clamp(tt.v, tt.lo, tt.hi), tt.want, "Clamp(%d, %d, %d)", tt.v, tt.lo, tt.hi)
+	}
+	assert.Equal(t, Clamp("zebra", "a", "m"), "m", "Clamp of strings")
+	assert.Equal(t, Clamp(Celsius(-40), -10, 40), Celsius(-10), "Clamp of Celsius")
+}
+
+func TestSumAverage(t *testing.T) {
+	assert.Equal(t, Sum([]int{1, 2, 3, 4}), 10, "Sum of ints")
+	assert.Equal(t, Sum([]int{}), 0, "Sum of none")
+	assert.Equal(t, Sum([]int8{100, 20}), int8(120), "Sum of int8")
+	assert.Equal(t, Sum([]Celsius{20, 22.5}), Celsius(42.5), "Sum of Celsius")
+
+	for _, tt := range []struct {
+		name string
+		got  float64
+		want float64
+	}{
+		{"ints", Average([]int{1, 2, 3, 4}), 2.5}, // not 2: divide as floats
+		{"none", Average([]float64{}), 0},
+		{"nil", Average[int](nil), 0},
+		{"Celsius", Average([]Celsius{18, 21, 24}), 21},
+		{"one", Average([]int64{7}), 7},
+	} {
+		assert.Equal(t, tt.got, tt.want, "Average of "+tt.name)
+	}
+}
+
+func TestSet(t *testing.T) {
+	s := NewSet("go", "ts", "go")
+	if s == nil {
+		t.Fatal("NewSet returned nil")
+	}
+	assert.Equal(t, s.Len(), 2, "Len after a duplicate")
+	for _, tt := range []struct {
+		v    string
+		want bool
+	}{
+		{"go", true}, {"ts", true}, {"rust", false}, {"", false},
+	} {
+		assert.Equal(t, s.Has(tt.v), tt.want, "Has(%q)", tt.v)
+	}
+
+	s.Add("rust")
+	s.Add("go")
+	assert.Equal(t, s.Len(), 3, "Len after Add")
+	if !s.Has("rust") {
+		t.Error("Has(\"rust\") is false after Add")
+	}
+
+	empty := NewSet[int]()
+	if empty == nil || empty.Len() != 0 || empty.Has(0) {
+		t.Errorf("NewSet[int]() = %v", empty)
+	}
+	empty.Add(0)
+	if !empty.Has(0) {
+		t.Error("NewSet[int]() can't be added to")
+	}
+}
+
+func TestUnionIntersect(t *testing.T) {
+	for _, tt := range []struct {
+		name       string
+		a, b       []int
+		union, and []int
+	}{
+		{"overlap", []int{1, 2, 3}, []int{2, 3, 4}, []int{1, 2, 3, 4}, []int{2, 3}},
+		{"disjoint", []int{1}, []int{2}, []int{1, 2}, []int{}},
+		{"one empty", nil, []int{5, 6}, []int{5, 6}, []int{}},
+		{"bigger first", []int{1, 2, 3, 4, 5}, []int{5}, []int{1, 2, 3, 4, 5}, []int{5}},
+	} {
+		a, b := NewSet(tt.a...), NewSet(tt.b...)
+		assert.Equal(t, Sorted(a.Union(b)), tt.union, tt.name+": Union")
+		assert.Equal(t, Sorted(a.Intersect(b)), tt.and, tt.name+": Intersect")
+		assert.Equal(t, Sorted(a), sortedOf(tt.a), tt.name+": Union or Intersect changed a")
+		assert.Equal(t, Sorted(b), sortedOf(tt.b), tt.name+": Union or Intersect changed b")
+	}
+}
+
+// sortedOf is what Sorted(NewSet(s...)) should give, for s already
+// in order.
+func sortedOf(s []int) []int {
+	if s == nil {
+		return []int{}
+	}
+	return s
+}
+
+func TestSorted(t *testing.T) {
+	assert.Equal(t, Sorted(NewSet(3, 1, 2)), []int{1, 2, 3}, "ints")
+	assert.Equal(t, Sorted(NewSet("b", "c", "a")), []string{"a", "b", "c"}, "strings")
+	assert.Equal(t, Sorted(NewSet[float64]()), []float64{}, "an empty set")
+}
+
+func TestResult(t *testing.T) {
+	boom := errors.New("boom")
+
+	ok := Ok(42)
+	if v, err := ok.Get(); v != 42 || err != nil {
+		t.Errorf("Ok(42).Get() = %d, %v", v, err)
+	}
+	assert.Equal(t, ok.Or(0), 42, "Ok(42).Or(0)")
+
+	failed := Fail[int](boom)
+	if v, err := failed.Get(); v != 0 || err != boom {
+		t.Errorf("Fail(boom).Get() = %d, %v; want 0, boom", v, err)
+	}
+	assert.Equal(t, failed.Or(-1), -1, "Fail(boom).Or(-1)")
+
+	// Ok(0) is a success even though its value is the zero value.
+	assert.Equal(t, Ok(0).Or(9), 0, "Ok(0).Or(9)")
+	assert.Equal(t, Ok("").Or("fallback"), "", `Ok("").Or("fallback")`)
+}
+
+func TestMapResult(t *testing.T) {
+	boom := errors.New("boom")
+	for _, tt := range []struct {
+		name string
+		in   Result[int]
+		want Result[string]
+	}{
+		{"ok", Ok(7), Ok("7")},
+		{"zero", Ok(0), Ok("0")},
+		{"failed", Fail[int](boom), Fail[string](boom)},
+	} {
+		called := false
+		got := MapResult(tt.in, func(n int) string {
+			called = true
+			return strconv.Itoa(n)
+		})
+		assert.Equal(t, got, tt.want, tt.name)
+		if called != (tt.in.Err == nil) {
+			t.Errorf("%s: f called = %v", tt.name, called)
+		}
+	}
+
+	// Chaining across types: int -> string -> int.
+	n := MapResult(MapResult(Ok(12), strconv.Itoa), func(s string) int { return len(s) })
+	assert.Equal(t, n, Ok(2), "chained")
+}
+
+func TestEntries(t *testing.T) {
+	assert.Equal(t, Entries(map[string]int{"b": 2, "c": 3, "a": 1}),
+		[]Pair[string, int]{{"a", 1}, {"b", 2}, {"c", 3}}, "string keys")
+	assert.Equal(t, Entries(map[int]bool{10: true, -1: false, 3: true}),
+		[]Pair[int, bool]{{-1, false}, {3, true}, {10, true}}, "int keys")
+	assert.Equal(t, Entries(map[Name][]int{"x": {1}}),
+		[]Pair[Name, []int]{{"x", []int{1}}}, "named key type")
+	assert.Equal(t, len(Entries(map[string]int{})), 0, "an empty map")
+}
+
+func TestParseEnum(t *testing.T) {
+	for _, tt := range []struct {
+		s      string
+		want   Weekday
+		wantOK bool
+	}{
+		{"Sunday", Sunday, true},
+		{"Wednesday", Wednesday, true},
+		{"Saturday", Saturday, true},
+		{"Funday", Sunday, false},
+		{"sunday", Sunday, false},
+	} {
+		got, ok := ParseEnum[Weekday](tt.s, len(weekdays))
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("ParseEnum(%q) = %v, %v; want %v, %v", tt.s, got, ok, tt.want, tt.wantOK)
+		}
+	}
+	// n limits the search.
+	if got, ok := ParseEnum[Weekday]("Saturday", 6); ok {
+		t.Errorf(`ParseEnum("Saturday", 6) = %v, true; Saturday is 6, past the end`, got)
+	}
+}
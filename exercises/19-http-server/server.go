@@ -0,0 +1,137 @@
+//go:build !solutions
+
+package httpserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"sync"
+)
+
+// Exercise 19: HTTP server basics
+//
+// A small to-do API, the kind you'd write with Express:
+//
+//	app.get("/todos/:id", (req, res) => res.status(200).json(todo))
+//
+// In Go a handler is anything with ServeHTTP(w, r): r is the request,
+// and you answer by writing to w, headers first, then the status, then
+// the body. http.ServeMux routes requests to handlers; since Go 1.22 its
+// patterns take a method and {wildcards}, like "GET /todos/{id}", so the
+// standard library is all you need.
+//
+// The tests never open a port: httptest.NewRecorder is a
+// ResponseWriter that remembers what was written, and
+// httptest.NewRequest builds a request without a client.
+//
+// Run tests with: go test -v
+
+// 1. Query parameters
+// Hello answers GET /hello?name=Ada with the text "Hello, Ada!", or
+// "Hello, world!" without a name.
+// In Express: req.query.name
+func Hello(w http.ResponseWriter, r *http.Request) {
+	// TODO: r.URL.Query().Get("name") is "" when it's missing;
+	// fmt.Fprintf(w, ...) writes the body, with an implicit 200
+}
+
+// 2. Writing JSON
+// WriteJSON sends v as JSON with the given status and the header
+// Content-Type: application/json. Headers must be set before
+// WriteHeader: once the status is sent, changing them does nothing.
+// In Express: res.status(status).json(v)
+func WriteJSON(w http.ResponseWriter, status int, v any) {
+	// TODO: w.Header().Set, w.WriteHeader, then json.NewEncoder(w).Encode(v)
+}
+
+// 3. Errors as JSON
+// WriteError sends {"error": msg} with the given status, so clients
+// get JSON whatever happens.
+func WriteError(w http.ResponseWriter, status int, msg string) {
+	// TODO: WriteJSON with a map[string]string
+}
+
+// Todo is one item of the list. The struct tags give the JSON names.
+type Todo struct {
+	ID    int    `json:"id"`
+	Title string `json:"title"`
+	Done  bool   `json:"done"`
+}
+
+// Server holds the to-dos in memory. The mux runs every request in its
+// own goroutine, so the handlers take mu before touching todos or
+// nextID.
+type Server struct {
+	mu     sync.Mutex
+	todos  map[int]Todo
+	nextID int
+}
+
+// NewServer returns a Server with no to-dos; the first one gets ID 1.
+func NewServer() *Server {
+	return &Server{todos: map[int]Todo{}, nextID: 1}
+}
+
+// 4. Routing with method and path patterns
+// Handler routes requests to the handlers below:
+//
+//	GET    /hello        Hello
+//	GET    /todos        ListTodos
+//	POST   /todos        CreateTodo
+//	GET    /todos/{id}   GetTodo
+//	DELETE /todos/{id}   DeleteTodo
+//
+// The mux answers everything else itself: 404 for a path it doesn't
+// know, 405 Method Not Allowed for a known path with another method.
+func (s *Server) Handler() http.Handler {
+	// TODO: mux := http.NewServeMux(); mux.HandleFunc("GET /hello", Hello)
+	// A method value, s.ListTodos, is a func like any other
+	return http.NotFoundHandler()
+}
+
+// 5. Filtering with the query string
+// ListTodos answers 200 with a JSON array of every to-do, by ID, and
+// [] rather than null when there are none. Two optional parameters
+// narrow it down:
+//
+//	?done=true|false   only the to-dos that are, or aren't, done
+//	?limit=N           at most N of them, N >= 0
+//
+// A value that doesn't parse is the client's fault: 400 with an error
+// naming the parameter, like {"error": "bad limit \"ten\""}.
+func (s *Server) ListTodos(w http.ResponseWriter, r *http.Request) {
+	// TODO: strconv.ParseBool and strconv.Atoi; collect the IDs, sort
+	// them, then build the list
+}
+
+// 6. Reading a JSON body
+// CreateTodo adds a to-do from a body like {"title": "Buy milk"} and
+// answers 201 Created with the new to-do and a Location header giving
+// its URL, /todos/<id>. New to-dos aren't done. Bad requests get 400:
+// {"error": "bad JSON: <why>"} for a body that isn't JSON, and
+// {"error": "title is required"} for a missing or blank title.
+// In Express: express.json() parses req.body for you; here you decode it.
+func (s *Server) CreateTodo(w http.ResponseWriter, r *http.Request) {
+	// TODO: var body struct{ Title string }; json.NewDecoder(r.Body).Decode(&body)
+	// strings.TrimSpace tells a blank title from a real one
+}
+
+// 7. Path values
+// GetTodo answers 200 with the to-do whose ID is the {id} in the path,
+// 404 when there's none, and 400 when {id} isn't a number.
+// In Express: req.params.id
+func (s *Server) GetTodo(w http.ResponseWriter, r *http.Request) {
+	// TODO: r.PathValue("id") is set by the mux's {id} pattern
+}
+
+// 8. Status codes without a body
+// DeleteTodo removes the to-do {id} and answers 204 No Content, with no
+// body at all. Like GetTodo it answers 404 or 400 when it can't.
+func (s *Server) DeleteTodo(w http.ResponseWriter, r *http.Request) {
+	// TODO: w.WriteHeader(http.StatusNoContent)
+}
+
+// Keep imports used
+var _ = json.Marshal
+var _ = strconv.Atoi
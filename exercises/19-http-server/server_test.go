@@ -0,0 +1,255 @@
+package httpserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/imgarylai/learn-go/internal/assert"
+)
+
+// do sends one request to h and returns what it wrote.
+func do(h http.Handler, method, target, body string) *httptest.ResponseRecorder {
+	var r *http.Request
+	if body == "" {
+		r = httptest.NewRequest(method, target, nil)
+	} else {
+		r = httptest.NewRequest(method, target, strings.NewReader(body))
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, r)
+	return rec
+}
+
+// decode parses a JSON response into a T, failing the test if it isn't
+// one.
+func decode[T any](t *testing.T, rec *httptest.ResponseRecorder) T {
+	t.Helper()
+	var v T
+	if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Content-Type: got %q, want application/json", ct)
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &v); err != nil {
+		t.Fatalf("body isn't a %T: %v\n%s", v, err, rec.Body.String())
+	}
+	return v
+}
+
+// withTodos is a Server holding the given titles, IDs from 1, with the
+// even IDs done.
+func withTodos(titles ...string) *Server {
+	s := NewServer()
+	for i, title := range titles {
+		id := i + 1
+		s.todos[id] = Todo{ID: id, Title: title, Done: id%2 == 0}
+	}
+	s.nextID = len(titles) + 1
+	return s
+}
+
+func TestHello(t *testing.T) {
+	for _, tt := range []struct {
+		target, want string
+	}{
+		{"/hello", "Hello, world!"},
+		{"/hello?name=Ada", "Hello, Ada!"},
+		{"/hello?name=", "Hello, world!"},
+		{"/hello?name=Grace%20Hopper&x=1", "Hello, Grace Hopper!"},
+	} {
+		rec := do(http.HandlerFunc(Hello), "GET", tt.target, "")
+		assert.Equal(t, rec.Code, http.StatusOK, tt.target+": status")
+		assert.Equal(t, rec.Body.String(), tt.want, tt.target)
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	rec := httptest.NewRecorder()
+	WriteJSON(rec, http.StatusAccepted, Todo{ID: 3, Title: "x"})
+	assert.Equal(t, rec.Code, http.StatusAccepted, "status")
+	assert.Equal(t, decode[Todo](t, rec), Todo{ID: 3, Title: "x"}, "body")
+	assert.Equal(t, strings.TrimSpace(rec.Body.String()), `{"id":3,"title":"x","done":false}`, "field names")
+
+	rec = httptest.NewRecorder()
+	WriteError(rec, http.StatusTeapot, "short and stout")
+	assert.Equal(t, rec.Code, http.StatusTeapot, "WriteError status")
+	assert.Equal(t, decode[map[string]string](t, rec), map[string]string{"error": "short and stout"}, "WriteError body")
+}
+
+func TestRoutes(t *testing.T) {
+	h := withTodos("a").Handler()
+	for _, tt := range []struct {
+		method, target string
+		want           int
+	}{
+		{"GET", "/hello", http.StatusOK},
+		{"GET", "/todos", http.StatusOK},
+		{"GET", "/todos/1", http.StatusOK},
+		{"POST", "/todos", http.StatusBadRequest}, // routed, but no body
+		{"DELETE", "/todos/9", http.StatusNotFound},
+		{"POST", "/hello", http.StatusMethodNotAllowed},
+		{"PUT", "/todos/1", http.StatusMethodNotAllowed},
+		{"DELETE", "/todos", http.StatusMethodNotAllowed},
+		{"GET", "/nope", http.StatusNotFound},
+		{"GET", "/todos/1/extra", http.StatusNotFound},
+	} {
+		rec := do(h, tt.method, tt.target, "")
+		assert.Equal(t, rec.Code, tt.want, "%s %s", tt.method, tt.target)
+	}
+	// GET /hello is the mux's, so the query still gets through.
+	assert.Equal(t, do(h, "GET", "/hello?name=mux", "").Body.String(), "Hello, mux!", "GET /hello?name=mux")
+}
+
+func TestListTodos(t *testing.T) {
+	h := withTodos("one", "two", "three", "four", "five").Handler()
+	for _, tt := range []struct {
+		query string
+		want  []int
+	}{
+		{"", []int{1, 2, 3, 4, 5}},
+		{"?done=true", []int{2, 4}},
+		{"?done=false", []int{1, 3, 5}},
+		{"?done=0", []int{1, 3, 5}},
+		{"?limit=2", []int{1, 2}},
+		{"?limit=0", []int{}},
+		{"?limit=99", []int{1, 2, 3, 4, 5}},
+		{"?done=false&limit=2", []int{1, 3}},
+	} {
+		rec := do(h, "GET", "/todos"+tt.query, "")
+		assert.Equal(t, rec.Code, http.StatusOK, "status for "+tt.query)
+		ids := []int{}
+		for _, todo := range decode[[]Todo](t, rec) {
+			ids = append(ids, todo.ID)
+		}
+		assert.Equal(t, ids, tt.want, "GET /todos"+tt.query)
+	}
+
+	for _, tt := range []struct {
+		query, want string
+	}{
+		{"?done=maybe", `bad done "maybe"`},
+		{"?limit=ten", `bad limit "ten"`},
+		{"?limit=-1", `bad limit "-1"`},
+	} {
+		rec := do(h, "GET", "/todos"+tt.query, "")
+		assert.Equal(t, rec.Code, http.StatusBadRequest, "status for "+tt.query)
+		assert.Equal(t, decode[map[string]string](t, rec)["error"], tt.want, tt.query)
+	}
+}
+
+func TestListTodosEmpty(t *testing.T) {
+	rec := do(NewServer().Handler(), "GET", "/todos", "")
+	// [] and not null: a JS client calling .map on it shouldn't crash.
+	assert.Equal(t, strings.TrimSpace(rec.Body.String()), "[]", "no to-dos")
+}
+
+func TestCreateTodo(t *testing.T) {
+	s := NewServer()
+	h := s.Handler()
+
+	rec := do(h, "POST", "/todos", `{"title": "  Buy milk "}`)
+	assert.Equal(t, rec.Code, http.StatusCreated, "status")
+	assert.Equal(t, rec.Header().Get("Location"), "/todos/1", "Location")
+	assert.Equal(t, decode[Todo](t, rec), Todo{ID: 1, Title: "Buy milk"}, "body")
+
+	rec = do(h, "POST", "/todos", `{"title": "Walk the dog", "done": true}`)
+	assert.Equal(t, rec.Header().Get("Location"), "/todos/2", "second Location")
+	assert.Equal(t, decode[Todo](t, rec), Todo{ID: 2, Title: "Walk the dog"}, "a new to-do isn't done")
+
+	// What was created can be fetched again.
+	assert.Equal(t, decode[Todo](t, do(h, "GET", "/todos/2", "")).Title, "Walk the dog", "GET the new to-do")
+
+	for _, tt := range []struct{ name, body, want string }{
+		{"not JSON", `title=x`, "bad JSON"},
+		{"empty body", ``, "bad JSON"},
+		{"wrong type", `{"title": 7}`, "bad JSON"},
+		{"no title", `{}`, "title is required"},
+		{"blank title", `{"title": "   "}`, "title is required"},
+	} {
+		rec := do(h, "POST", "/todos", tt.body)
+		assert.Equal(t, rec.Code, http.StatusBadRequest, tt.name)
+		if msg := decode[map[string]string](t, rec)["error"]; !strings.HasPrefix(msg, tt.want) {
+			t.Errorf("%s: error %q, want one starting %q", tt.name, msg, tt.want)
+		}
+	}
+	assert.Equal(t, len(s.todos), 2, "to-dos after bad requests")
+}
+
+func TestGetTodo(t *testing.T) {
+	h := withTodos("one", "two").Handler()
+
+	rec := do(h, "GET", "/todos/2", "")
+	assert.Equal(t, rec.Code, http.StatusOK, "status")
+	assert.Equal(t, decode[Todo](t, rec), Todo{ID: 2, Title: "two", Done: true}, "GET /todos/2")
+
+	for _, tt := range []struct {
+		id        string
+		want      int
+		wantError string
+	}{
+		{"3", http.StatusNotFound, "no to-do 3"},
+		{"0", http.StatusNotFound, "no to-do 0"},
+		{"two", http.StatusBadRequest, `bad id "two"`},
+	} {
+		rec := do(h, "GET", "/todos/"+tt.id, "")
+		assert.Equal(t, rec.Code, tt.want, "GET /todos/"+tt.id)
+		assert.Equal(t, decode[map[string]string](t, rec)["error"], tt.wantError, "error for "+tt.id)
+	}
+}
+
+func TestGetTodoDirect(t *testing.T) {
+	// Called without the mux, the handler only has the path value if the
+	// test sets it, as the mux would.
+	r := httptest.NewRequest("GET", "/anything", nil)
+	r.SetPathValue("id", "1")
+	rec := httptest.NewRecorder()
+	withTodos("one").GetTodo(rec, r)
+	assert.Equal(t, decode[Todo](t, rec).Title, "one", "GetTodo with id 1")
+}
+
+func TestDeleteTodo(t *testing.T) {
+	s := withTodos("one", "two", "three")
+	h := s.Handler()
+
+	rec := do(h, "DELETE", "/todos/2", "")
+	assert.Equal(t, rec.Code, http.StatusNoContent, "status")
+	assert.Equal(t, rec.Body.Len(), 0, "body length")
+	assert.Equal(t, do(h, "GET", "/todos/2", "").Code, http.StatusNotFound, "GET after DELETE")
+	assert.Equal(t, len(s.todos), 2, "to-dos left")
+
+	assert.Equal(t, do(h, "DELETE", "/todos/2", "").Code, http.StatusNotFound, "DELETE twice")
+	rec = do(h, "DELETE", "/todos/x", "")
+	assert.Equal(t, rec.Code, http.StatusBadRequest, "DELETE /todos/x")
+	assert.Equal(t, len(s.todos), 2, "to-dos left after bad deletes")
+
+	// IDs aren't reused after a delete.
+	rec = do(h, "POST", "/todos", `{"title": "four"}`)
+	assert.Equal(t, decode[Todo](t, rec).ID, 4, "ID after a delete")
+}
+
+func TestConcurrentRequests(t *testing.T) {
+	// Run with -race to see what a missing lock does.
+	s := NewServer()
+	h := s.Handler()
+	var wg sync.WaitGroup
+	for i := range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			do(h, "POST", "/todos", fmt.Sprintf(`{"title": "t%d"}`, i))
+			do(h, "GET", "/todos", "")
+		}()
+	}
+	wg.Wait()
+
+	todos := decode[[]Todo](t, do(h, "GET", "/todos", ""))
+	assert.Equal(t, len(todos), 50, "to-dos")
+	for i, todo := range todos {
+		if todo.ID != i+1 {
+			t.Fatalf("to-do %d has ID %d: every create should get its own ID", i, todo.ID)
+		}
+	}
+}
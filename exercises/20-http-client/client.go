@@ -0,0 +1,169 @@
+//go:build !solutions
+
+package httpclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// Exercise 20: An HTTP client with retries and timeouts
+//
+// 19 was the server side; this is the caller's. In JS you'd reach for
+// fetch, or axios with axios-retry and an interceptor for the auth
+// header. Go's http.Client does the fetching, and everything else is
+// small code of your own: a context for the timeout, a loop for the
+// retries, and a RoundTripper, the layer under the client that actually
+// sends each request, for the header.
+//
+// One difference from fetch trips everyone up: a 404 or a 500 is not an
+// error to http.Client. You get a response and must check StatusCode
+// yourself. Another: the response body must be closed, or the
+// connection can't be reused.
+//
+// The tests run each client against httptest.NewServer, a real server
+// on a local port that a test sets up in a line.
+//
+// Run tests with: go test -v
+
+// Client calls a JSON API at BaseURL.
+type Client struct {
+	BaseURL string
+	HTTP    *http.Client
+
+	// Retries is how many more times Do tries after a failure worth
+	// retrying; 0 means one attempt only.
+	Retries int
+	// Backoff is the wait before the first retry. It doubles for each
+	// retry after that, up to MaxBackoff.
+	Backoff time.Duration
+
+	// Sleep waits between retries; nil means sleep, below. The tests
+	// swap it for a func that records the waits instead of taking them.
+	Sleep func(ctx context.Context, d time.Duration) error
+}
+
+// MaxBackoff caps how long Do waits between two attempts.
+const MaxBackoff = 30 * time.Second
+
+// StatusError is what the JSON methods return for a response that
+// isn't 2xx: fetch's `if (!res.ok) throw ...`, done once.
+type StatusError struct {
+	Code int
+	Body string // the start of the response body, for the message
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("HTTP %d: %s", e.Code, e.Body)
+}
+
+// sleep waits d, or less if ctx is done first, in which case it returns
+// ctx's error. Unlike time.Sleep it can be cancelled.
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// 1. A client with a timeout
+// NewClient returns a Client for baseURL whose http.Client gives up on
+// any request that takes longer than timeout in all, body included.
+// The zero http.Client never gives up, which is rarely what you want.
+func NewClient(baseURL string, timeout time.Duration) *Client {
+	// TODO: &http.Client{Timeout: timeout}
+	return &Client{BaseURL: baseURL}
+}
+
+// 2. Exponential backoff
+// BackoffFor is how long to wait before retry number n (0 for the
+// first): base, then 2*base, 4*base, ..., never more than MaxBackoff.
+func BackoffFor(base time.Duration, n int) time.Duration {
+	// TODO: double in a loop and stop at MaxBackoff, so a large n can't
+	// overflow
+	return base
+}
+
+// 3. Retrying
+// Do sends req with c.HTTP and retries it when that's worth it: the
+// request failed to go through at all, or the server answered 5xx.
+// A 4xx is the caller's fault and won't get better, so it comes back at
+// once. Between attempts Do waits BackoffFor(c.Backoff, n) with c.Sleep
+// (or sleep), and gives up early when the wait returns an error, as
+// sleep does once req.Context() is done. After the last attempt it
+// returns whatever that attempt got, 5xx response included.
+//
+// A request body can only be read once. Requests made by
+// http.NewRequestWithContext from a bytes.Reader have GetBody, which
+// returns a fresh copy; set req.Body from it before sending again.
+// (http.Transport happens to rewind by itself, but not every
+// RoundTripper does.) And close the body of every response you don't
+// return.
+func (c *Client) Do(req *http.Request) (*http.Response, error) {
+	// TODO: a loop of c.HTTP.Do; resp.StatusCode >= 500 is worth a retry
+	return nil, nil
+}
+
+// 4. GET JSON into a struct
+// GetJSON sends GET BaseURL+path through Do and decodes the JSON
+// response into out, a pointer. A response that isn't 2xx gives a
+// *StatusError holding the code and up to the first 512 bytes of the
+// body.
+// In JS: const res = await fetch(url); if (!res.ok) throw ...; out = await res.json()
+func (c *Client) GetJSON(ctx context.Context, path string, out any) error {
+	// TODO: http.NewRequestWithContext(ctx, "GET", c.BaseURL+path, nil);
+	// defer resp.Body.Close(); io.LimitReader for the error body
+	return nil
+}
+
+// 5. POST with a deadline
+// PostJSON sends in as a JSON body, with Content-Type: application/json,
+// to POST BaseURL+path, and decodes the response into out unless out is
+// nil. The whole call, retries and waits included, must finish within
+// timeout; past it the error is (or wraps) context.DeadlineExceeded.
+// Errors otherwise as in GetJSON.
+func (c *Client) PostJSON(ctx context.Context, path string, in, out any, timeout time.Duration) error {
+	// TODO: ctx, cancel := context.WithTimeout(ctx, timeout); defer cancel()
+	// Build the body with bytes.NewReader, so Do can rewind it
+	return nil
+}
+
+// 6. A RoundTripper
+// AuthTransport adds Authorization: Bearer <Token> to every request,
+// then sends it with Base, or http.DefaultTransport when Base is nil.
+// Like an axios request interceptor, but a layer lower: the
+// http.Client never notices.
+//
+// A RoundTripper must not change the request it's given (the caller
+// may still be using it), so add the header to a clone.
+type AuthTransport struct {
+	Token string
+	Base  http.RoundTripper
+}
+
+func (t *AuthTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// TODO: req.Clone(req.Context())
+	return nil, nil
+}
+
+// 7. Wrapping an existing client
+// WithToken makes c send token with every request by putting an
+// AuthTransport in front of c.HTTP's transport. The rest of c.HTTP,
+// its Timeout for one, stays as it was.
+func (c *Client) WithToken(token string) {
+	// TODO: c.HTTP.Transport = &AuthTransport{...}
+}
+
+// Keep imports used
+var _ = bytes.NewReader
+var _ = json.Marshal
+var _ = io.ReadAll
@@ -0,0 +1,419 @@
+package httpclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/imgarylai/learn-go/internal/assert"
+)
+
+type User struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// fake is a server that answers with the statuses in codes, one per
+// request, repeating the last, and records what it was sent.
+type fake struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	codes    []int
+	body     string
+	requests []*http.Request
+	bodies   []string
+}
+
+func newFake(t *testing.T, body string, codes ...int) *fake {
+	f := &fake{codes: codes, body: body}
+	f.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		f.mu.Lock()
+		n := min(len(f.requests), len(f.codes)-1)
+		f.requests = append(f.requests, r)
+		f.bodies = append(f.bodies, string(data))
+		code := f.codes[n]
+		f.mu.Unlock()
+		w.WriteHeader(code)
+		io.WriteString(w, f.body)
+	}))
+	t.Cleanup(f.Close)
+	return f
+}
+
+func (f *fake) calls() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.requests)
+}
+
+// got returns request i and its body, failing the test if the server
+// didn't get that many.
+func (f *fake) got(t *testing.T, i int) (*http.Request, string) {
+	t.Helper()
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if i >= len(f.requests) {
+		t.Fatalf("the server got %d requests, want at least %d", len(f.requests), i+1)
+	}
+	return f.requests[i], f.bodies[i]
+}
+
+// recordSleep makes c record its waits instead of taking them.
+func recordSleep(c *Client) *[]time.Duration {
+	var waits []time.Duration
+	c.Sleep = func(ctx context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		return ctx.Err()
+	}
+	return &waits
+}
+
+func TestNewClient(t *testing.T) {
+	c := NewClient("http://example.com", 3*time.Second)
+	assert.Equal(t, c.BaseURL, "http://example.com", "BaseURL")
+	if c.HTTP == nil {
+		t.Fatal("HTTP is nil")
+	}
+	assert.Equal(t, c.HTTP.Timeout, 3*time.Second, "Timeout")
+}
+
+func TestBackoffFor(t *testing.T) {
+	for _, tt := range []struct {
+		base time.Duration
+		n    int
+		want time.Duration
+	}{
+		{100 * time.Millisecond, 0, 100 * time.Millisecond},
+		{100 * time.Millisecond, 1, 200 * time.Millisecond},
+		{100 * time.Millisecond, 3, 800 * time.Millisecond},
+		{time.Second, 4, 16 * time.Second},
+		{time.Second, 5, MaxBackoff},
+		{20 * time.Second, 1, MaxBackoff},
+		{15 * time.Second, 1, MaxBackoff},
+		{10 * time.Second, 1, 20 * time.Second},
+		{time.Second, 1000, MaxBackoff}, // no overflow
+		{time.Minute, 0, MaxBackoff},
+		{0, 5, 0},
+	} {
+		assert.Equal(t, BackoffFor(tt.base, tt.n), tt.want, "BackoffFor(%v, %d)", tt.base, tt.n)
+	}
+}
+
+func TestDoRetries(t *testing.T) {
+	for _, tt := range []struct {
+		name      string
+		codes     []int
+		retries   int
+		wantCode  int
+		wantCalls int
+		wantWaits []time.Duration
+	}{
+		{"ok at once", []int{200}, 3, 200, 1, nil},
+		{"5xx then ok", []int{503, 500, 200}, 3, 200, 3, []time.Duration{10 * time.Millisecond, 20 * time.Millisecond}},
+		{"always 5xx", []int{502}, 2, 502, 3, []time.Duration{10 * time.Millisecond, 20 * time.Millisecond}},
+		{"4xx isn't retried", []int{404, 200}, 3, 404, 1, nil},
+		{"no retries", []int{500, 200}, 0, 500, 1, nil},
+		{"just under 5xx", []int{499, 200}, 3, 499, 1, nil},
+	} {
+		f := newFake(t, "hi", tt.codes...)
+		c := &Client{HTTP: f.Client(), Retries: tt.retries, Backoff: 10 * time.Millisecond}
+		waits := recordSleep(c)
+
+		req, _ := http.NewRequest("GET", f.URL, nil)
+		resp, err := c.Do(req)
+		if err != nil || resp == nil {
+			t.Fatalf("%s: got %v, %v", tt.name, resp, err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		assert.Equal(t, resp.StatusCode, tt.wantCode, tt.name+": status")
+		assert.Equal(t, string(body), "hi", tt.name+": the returned body is still readable")
+		assert.Equal(t, f.calls(), tt.wantCalls, tt.name+": requests")
+		assert.Equal(t, *waits, tt.wantWaits, tt.name+": waits")
+	}
+}
+
+// roundTripFunc is a RoundTripper made of a func, like
+// http.HandlerFunc. Unlike http.Transport it doesn't rewind bodies.
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+func TestDoRewindsBody(t *testing.T) {
+	var bodies []string
+	codes := []int{500, 500, 201}
+	c := &Client{Retries: 2, HTTP: &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		data, _ := io.ReadAll(r.Body)
+		r.Body.Close()
+		bodies = append(bodies, string(data))
+		code := codes[len(bodies)-1]
+		return &http.Response{StatusCode: code, Body: io.NopCloser(strings.NewReader("")), Request: r}, nil
+	})}}
+	recordSleep(c)
+
+	req, _ := http.NewRequest("POST", "http://example.com", strings.NewReader(`{"n":1}`))
+	resp, err := c.Do(req)
+	if err != nil || resp == nil {
+		t.Fatalf("got %v, %v", resp, err)
+	}
+	resp.Body.Close()
+	assert.Equal(t, resp.StatusCode, 201, "status")
+	assert.Equal(t, bodies, []string{`{"n":1}`, `{"n":1}`, `{"n":1}`}, "bodies sent")
+
+	// A body that can't be had again is an error, not an empty retry.
+	bodies = nil
+	req, _ = http.NewRequest("POST", "http://example.com", strings.NewReader("x"))
+	broken := errors.New("body gone")
+	req.GetBody = func() (io.ReadCloser, error) { return nil, broken }
+	if _, err := c.Do(req); !errors.Is(err, broken) {
+		t.Errorf("GetBody failed: got %v, want its error", err)
+	}
+	assert.Equal(t, len(bodies), 1, "requests sent with a broken GetBody")
+}
+
+func TestDoNetworkError(t *testing.T) {
+	f := newFake(t, "", 200)
+	f.Close() // nothing listens there now
+
+	c := &Client{HTTP: &http.Client{}, Retries: 2, Backoff: time.Millisecond}
+	waits := recordSleep(c)
+	req, _ := http.NewRequest("GET", f.URL, nil)
+	if _, err := c.Do(req); err == nil {
+		t.Error("no error from a server that's gone")
+	}
+	assert.Equal(t, len(*waits), 2, "retries after a network error")
+}
+
+func TestDoStopsWhenCancelled(t *testing.T) {
+	f := newFake(t, "", 503)
+	c := &Client{HTTP: f.Client(), Retries: 5, Backoff: time.Millisecond}
+	ctx, cancel := context.WithCancel(context.Background())
+	c.Sleep = func(context.Context, time.Duration) error {
+		cancel() // the caller gives up during the first wait
+		return context.Canceled
+	}
+
+	req, _ := http.NewRequestWithContext(ctx, "GET", f.URL, nil)
+	resp, err := c.Do(req)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("got %v, %v; want context.Canceled", resp, err)
+	}
+	assert.Equal(t, f.calls(), 1, "requests")
+
+	// Any error from the wait ends it, not just the context's.
+	interrupted := errors.New("interrupted")
+	c.Sleep = func(context.Context, time.Duration) error { return interrupted }
+	req, _ = http.NewRequest("GET", f.URL, nil)
+	if _, err := c.Do(req); !errors.Is(err, interrupted) {
+		t.Errorf("Sleep failed: got %v, want its error", err)
+	}
+	assert.Equal(t, f.calls(), 2, "requests after Sleep failed")
+
+	// Already cancelled: no request gets through, and no waiting either.
+	waits := recordSleep(c)
+	req, _ = http.NewRequestWithContext(ctx, "GET", f.URL, nil)
+	if _, err := c.Do(req); !errors.Is(err, context.Canceled) {
+		t.Errorf("cancelled context: got %v", err)
+	}
+	assert.Equal(t, len(*waits), 0, "waits with a cancelled context")
+}
+
+func TestSleep(t *testing.T) {
+	if err := sleep(context.Background(), time.Millisecond); err != nil {
+		t.Errorf("sleep: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	start := time.Now()
+	if err := sleep(ctx, time.Hour); !errors.Is(err, context.Canceled) {
+		t.Errorf("cancelled sleep: %v", err)
+	}
+	if time.Since(start) > time.Second {
+		t.Error("a cancelled sleep still waited")
+	}
+}
+
+func TestGetJSON(t *testing.T) {
+	f := newFake(t, `{"id": 7, "name": "Ada"}`, 200)
+	c := NewClient(f.URL, time.Second)
+
+	var u User
+	if err := c.GetJSON(context.Background(), "/users/7", &u); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, u, User{ID: 7, Name: "Ada"}, "decoded user")
+	r, _ := f.got(t, 0)
+	assert.Equal(t, f.calls(), 1, "requests")
+	assert.Equal(t, r.Method, "GET", "method")
+	assert.Equal(t, r.URL.Path, "/users/7", "path")
+
+	if err := NewClient("://nowhere", time.Second).GetJSON(context.Background(), "/", &u); err == nil {
+		t.Error("no error for a bad URL")
+	}
+
+	bad := newFake(t, `not json`, 200)
+	if err := NewClient(bad.URL, time.Second).GetJSON(context.Background(), "/", &u); err == nil {
+		t.Error("no error for a body that isn't JSON")
+	}
+}
+
+func TestGetJSONStatusError(t *testing.T) {
+	for _, tt := range []struct {
+		code     int
+		body     string
+		wantBody string
+	}{
+		{404, `{"error": "no user 9"}`, `{"error": "no user 9"}`},
+		{500, "", ""},
+		{302, "", ""}, // no Location, so the client can't follow it
+		{418, strings.Repeat("x", 600), strings.Repeat("x", 512)},
+	} {
+		f := newFake(t, tt.body, tt.code)
+		var u User
+		err := NewClient(f.URL, time.Second).GetJSON(context.Background(), "/users/9", &u)
+		var se *StatusError
+		if !errors.As(err, &se) {
+			t.Errorf("%d: got %v, want a *StatusError", tt.code, err)
+			continue
+		}
+		assert.Equal(t, *se, StatusError{Code: tt.code, Body: tt.wantBody}, "%d", tt.code)
+		assert.Equal(t, u, User{}, "%d: out was written", tt.code)
+	}
+}
+
+func TestPostJSON(t *testing.T) {
+	f := newFake(t, `{"id": 1, "name": "Ada"}`, 201)
+	c := NewClient(f.URL, time.Second)
+
+	var got User
+	if err := c.PostJSON(context.Background(), "/users", User{Name: "Ada"}, &got, time.Second); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, got, User{ID: 1, Name: "Ada"}, "response")
+	r, body := f.got(t, 0)
+	assert.Equal(t, f.calls(), 1, "requests")
+	assert.Equal(t, r.Method, "POST", "method")
+	assert.Equal(t, r.URL.Path, "/users", "path")
+	assert.Equal(t, r.Header.Get("Content-Type"), "application/json", "Content-Type")
+	var sent User
+	if err := json.Unmarshal([]byte(body), &sent); err != nil || sent != (User{Name: "Ada"}) {
+		t.Errorf("the server got %q", body)
+	}
+
+	// out == nil: the response isn't decoded, so it needn't be JSON.
+	empty := newFake(t, "", 204)
+	if err := NewClient(empty.URL, time.Second).PostJSON(context.Background(), "/ping", map[string]int{}, nil, time.Second); err != nil {
+		t.Errorf("204 with out == nil: %v", err)
+	}
+
+	if err := NewClient("://nowhere", time.Second).PostJSON(context.Background(), "/", User{}, nil, time.Second); err == nil {
+		t.Error("no error for a bad URL")
+	}
+	if err := c.PostJSON(context.Background(), "/users", func() {}, nil, time.Second); err == nil {
+		t.Error("no error for a value json can't encode")
+	}
+
+	failed := newFake(t, "taken", 409)
+	err := NewClient(failed.URL, time.Second).PostJSON(context.Background(), "/users", User{}, &got, time.Second)
+	var se *StatusError
+	if !errors.As(err, &se) || se.Code != 409 || se.Body != "taken" {
+		t.Errorf("409: got %v", err)
+	}
+}
+
+func TestPostJSONTimeout(t *testing.T) {
+	block := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-block:
+		case <-r.Context().Done():
+		}
+	}))
+	defer srv.Close()
+	defer close(block)
+
+	c := NewClient(srv.URL, time.Minute)
+	start := time.Now()
+	err := c.PostJSON(context.Background(), "/slow", User{}, nil, 50*time.Millisecond)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("got %v, want context.DeadlineExceeded", err)
+	}
+	if d := time.Since(start); d > 5*time.Second {
+		t.Errorf("took %v: the timeout didn't apply", d)
+	}
+}
+
+func TestPostJSONRetriesWithinTimeout(t *testing.T) {
+	f := newFake(t, `{"id": 2}`, 503, 200)
+	c := NewClient(f.URL, time.Second)
+	c.Retries = 3
+	waits := recordSleep(c)
+
+	var got User
+	if err := c.PostJSON(context.Background(), "/users", User{Name: "Bo"}, &got, time.Second); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, got.ID, 2, "ID after a retry")
+	assert.Equal(t, len(*waits), 1, "waits")
+	_, first := f.got(t, 0)
+	_, retry := f.got(t, 1)
+	assert.Equal(t, retry, first, "the retry's body")
+}
+
+func TestAuthTransport(t *testing.T) {
+	f := newFake(t, "{}", 200)
+
+	var base http.RoundTripper = f.Client().Transport
+	tr := &AuthTransport{Token: "s3cret", Base: base}
+	req, _ := http.NewRequest("GET", f.URL, nil)
+	req.Header.Set("Accept", "application/json")
+	resp, err := tr.RoundTrip(req)
+	if err != nil || resp == nil {
+		t.Fatalf("RoundTrip: %v, %v", resp, err)
+	}
+	resp.Body.Close()
+	r, _ := f.got(t, 0)
+	assert.Equal(t, r.Header.Get("Authorization"), "Bearer s3cret", "Authorization")
+	assert.Equal(t, r.Header.Get("Accept"), "application/json", "other headers")
+	assert.Equal(t, req.Header.Get("Authorization"), "", "the caller's request was changed")
+
+	// Without Base it uses http.DefaultTransport.
+	resp, err = (&AuthTransport{Token: "t"}).RoundTrip(req)
+	if err != nil || resp == nil {
+		t.Fatalf("RoundTrip with no Base: %v, %v", resp, err)
+	}
+	resp.Body.Close()
+	r, _ = f.got(t, 1)
+	assert.Equal(t, r.Header.Get("Authorization"), "Bearer t", "Authorization with no Base")
+}
+
+func TestWithToken(t *testing.T) {
+	f := newFake(t, `{"id": 1}`, 200)
+	c := NewClient(f.URL, 2*time.Second)
+	if c.HTTP == nil {
+		t.Fatal("NewClient left HTTP nil")
+	}
+	c.HTTP.Transport = f.Client().Transport
+	c.WithToken("abc")
+
+	var u User
+	if err := c.GetJSON(context.Background(), "/me", &u); err != nil {
+		t.Fatal(err)
+	}
+	r, _ := f.got(t, 0)
+	assert.Equal(t, r.Header.Get("Authorization"), "Bearer abc", "Authorization")
+	assert.Equal(t, c.HTTP.Timeout, 2*time.Second, "Timeout after WithToken")
+	at, ok := c.HTTP.Transport.(*AuthTransport)
+	if !ok || at.Base != f.Client().Transport {
+		t.Errorf("Transport = %#v, want an *AuthTransport around the old one", c.HTTP.Transport)
+	}
+}
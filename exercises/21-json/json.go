@@ -0,0 +1,208 @@
+//go:build !solutions
+
+package jsonadvanced
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// Exercise 21: JSON in depth
+//
+// 03-structs covered struct tags and one MarshalJSON. Real APIs ask for
+// more: enums that travel as strings, timestamps as numbers, PATCH
+// bodies where "missing" and "null" and "" all mean different things,
+// payloads whose shape depends on a field next to them, and arrays too
+// big to load at once. In JS all of that is JSON.parse plus code that
+// pokes at the result; in Go it's the encoding/json interfaces and
+// types you'll use here.
+//
+// Run tests with: go test -v
+
+// Status is an account's state. In Go it's an int, cheap to compare and
+// switch on; in JSON it's a string, so API clients never see the
+// numbers.
+type Status int
+
+const (
+	Active Status = iota
+	Suspended
+	Closed
+)
+
+var statusNames = []string{"active", "suspended", "closed"}
+
+func (s Status) String() string {
+	if s < 0 || int(s) >= len(statusNames) {
+		return fmt.Sprintf("Status(%d)", int(s))
+	}
+	return statusNames[s]
+}
+
+// 1. MarshalJSON on a value receiver
+// MarshalJSON writes s as its name, "active". A value receiver means
+// Status values and *Status pointers both get it; with a pointer
+// receiver a Status field in a struct passed by value wouldn't. A
+// Status with no name is an error.
+func (s Status) MarshalJSON() ([]byte, error) {
+	// TODO: json.Marshal(s.String()) quotes and escapes for you
+	return nil, nil
+}
+
+// 2. UnmarshalJSON on a pointer receiver
+// UnmarshalJSON reads a name back, failing on one it doesn't know:
+// {"status": "deleted"} is an error, not a silent Active. Anything
+// but a string is an error too: return the one json.Unmarshal gives,
+// which says what it got. null leaves s as it is, which is what
+// encoding/json does for its own types and what it expects of an
+// Unmarshaler.
+func (s *Status) UnmarshalJSON(data []byte) error {
+	// TODO: unmarshal into a string first, then look it up
+	return nil
+}
+
+// UnixTime is a time that travels as whole seconds since 1970, the way
+// many APIs (and Date.now() / 1000 in JS) send it: 1700000000. A
+// time.Time on its own marshals as RFC 3339, "2023-11-14T22:13:20Z".
+type UnixTime struct {
+	time.Time
+}
+
+// 3. Times as numbers
+// MarshalJSON writes the seconds as a JSON number, or null for the zero
+// time. UnmarshalJSON reads a number back, as a UTC time, and null as
+// the zero time. Anything else, a string included, is an error.
+func (t UnixTime) MarshalJSON() ([]byte, error) {
+	// TODO: strconv.AppendInt, or json.Marshal(t.Unix())
+	return nil, nil
+}
+
+func (t *UnixTime) UnmarshalJSON(data []byte) error {
+	// TODO: string(data) == "null" first; then unmarshal into an int64
+	// and time.Unix(sec, 0).UTC()
+	return nil
+}
+
+// Account is what the API serves.
+//
+// omitempty leaves out false, 0, "", nil and empty slices and maps, but
+// never a struct: a zero time.Time would still be written out. omitzero
+// (Go 1.24) leaves out any zero value, structs included, so Closed
+// disappears until the account is closed.
+type Account struct {
+	ID      int       `json:"id"`
+	Name    string    `json:"name"`
+	Email   string    `json:"email,omitempty"`
+	Status  Status    `json:"status"`
+	Created UnixTime  `json:"created"`
+	Closed  time.Time `json:"closed,omitzero"`
+	Tags    []string  `json:"tags"`
+}
+
+// 4. Strict decoding
+// DecodeStrict unmarshals data into v like json.Unmarshal, but rejects
+// keys v has no field for, which is usually a typo on the client's
+// side ({"emial": ...}), and anything after the first value.
+func DecodeStrict(data []byte, v any) error {
+	// TODO: dec := json.NewDecoder(bytes.NewReader(data));
+	// dec.DisallowUnknownFields(); after Decode, dec.More() or a second
+	// Decode returning io.EOF tells you whether anything is left
+	return nil
+}
+
+// AccountPatch is the body of PATCH /accounts/{id}. Each field is a
+// pointer so a key that's missing (nil) differs from one set to the
+// zero value: {"email": ""} clears the email, {} leaves it alone. In JS
+// you'd check `"email" in body`.
+type AccountPatch struct {
+	Name   *string   `json:"name"`
+	Email  *string   `json:"email"`
+	Status *Status   `json:"status"`
+	Tags   *[]string `json:"tags"`
+}
+
+// 5. Missing versus zero
+// ApplyPatch decodes data strictly as an AccountPatch and returns a
+// with the fields it sets changed. A name can't be set to "". On an
+// error, a comes back unchanged.
+func ApplyPatch(a Account, data []byte) (Account, error) {
+	// TODO: DecodeStrict, then one if per field
+	return a, nil
+}
+
+// Event is something that happened on the site. Each kind of event has
+// its own payload type.
+type Event interface {
+	Kind() string
+}
+
+type Click struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+}
+
+type Purchase struct {
+	SKU   string `json:"sku"`
+	Cents int    `json:"cents"`
+}
+
+func (Click) Kind() string    { return "click" }
+func (Purchase) Kind() string { return "purchase" }
+
+// Envelope is how an event travels: {"kind": "click", "data": {...}}.
+// json.RawMessage holds the data's bytes undecoded until the kind says
+// what to decode them into; in JS you'd switch on msg.kind after
+// JSON.parse and trust the rest.
+type Envelope struct {
+	Kind string          `json:"kind"`
+	Data json.RawMessage `json:"data"`
+}
+
+// 6. Decoding in two steps
+// DecodeEvent reads an Envelope and returns its data as a Click or a
+// Purchase (values, not pointers), depending on the kind. An unknown
+// kind is an error naming it; data that isn't JSON is json.Unmarshal's
+// error, not a complaint about the kind.
+func DecodeEvent(data []byte) (Event, error) {
+	// TODO: unmarshal the Envelope, then switch on env.Kind and
+	// unmarshal env.Data into the right type
+	return nil, nil
+}
+
+// 7. Encoding in two steps
+// EncodeEvent is the reverse: e's JSON, wrapped in an Envelope with
+// e.Kind().
+func EncodeEvent(e Event) ([]byte, error) {
+	// TODO: json.Marshal(e) gives the RawMessage
+	return nil, nil
+}
+
+// Item is one line of an order export.
+type Item struct {
+	SKU string `json:"sku"`
+	Qty int    `json:"qty"`
+}
+
+// 8. Streaming with Token
+// StreamItems reads an export like
+//
+//	{"exported": "2024-03-01", "items": [{"sku": "A1", "qty": 2}, ...]}
+//
+// and calls fn with each item as soon as it's decoded, so a file of a
+// million items never sits in memory at once. It skips keys other than
+// "items", whatever their values are, and returns how many items it
+// passed to fn. An error from fn stops the stream and is returned as
+// is; so does an error in the JSON, and fn sees nothing after it,
+// even what looks like items.
+//
+// json.Decoder.Token returns the next delimiter ('{', '[', ']', '}'),
+// key or value; Decode reads one whole value; More reports whether the
+// current array or object has more elements.
+func StreamItems(r io.Reader, fn func(Item) error) (int, error) {
+	// TODO: dec := json.NewDecoder(r); expect '{'; for dec.More(): read a
+	// key; for "items" expect '[', Decode items while More, expect ']';
+	// otherwise Decode the value into a json.RawMessage to skip it
+	return 0, nil
+}
@@ -0,0 +1,398 @@
+package jsonadvanced
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/imgarylai/learn-go/internal/assert"
+)
+
+func TestStatusJSON(t *testing.T) {
+	for _, tt := range []struct {
+		s    Status
+		want string
+	}{
+		{Active, `"active"`},
+		{Suspended, `"suspended"`},
+		{Closed, `"closed"`},
+	} {
+		data, err := json.Marshal(tt.s)
+		if err != nil {
+			t.Errorf("Marshal(%v): %v", tt.s, err)
+			continue
+		}
+		assert.Equal(t, string(data), tt.want, "Marshal(%v)", tt.s)
+
+		var back Status = -1
+		if err := json.Unmarshal(data, &back); err != nil || back != tt.s {
+			t.Errorf("Unmarshal(%s) = %v, %v", data, back, err)
+		}
+	}
+
+	// The value receiver is what makes this work: the struct is passed
+	// by value, so its fields aren't addressable.
+	data, _ := json.Marshal(struct{ S Status }{Suspended})
+	assert.Equal(t, string(data), `{"S":"suspended"}`, "a Status field")
+
+	for _, s := range []Status{-1, 3} {
+		if data, err := json.Marshal(s); err == nil {
+			t.Errorf("Marshal(%v) = %s, want an error", s, data)
+		}
+	}
+	for _, in := range []string{`"deleted"`, `"Active"`, `""`, `0`, `null`} {
+		s := Suspended
+		err := json.Unmarshal([]byte(in), &s)
+		if in == "null" {
+			if err != nil {
+				t.Errorf("Unmarshal(null): %v", err)
+			}
+			assert.Equal(t, s, Suspended, "after null")
+			continue
+		}
+		if err == nil {
+			t.Errorf("Unmarshal(%s) = %v, want an error", in, s)
+		}
+	}
+	var typeErr *json.UnmarshalTypeError
+	if err := json.Unmarshal([]byte(`0`), new(Status)); !errors.As(err, &typeErr) {
+		t.Errorf("Unmarshal(0) = %v, want json.Unmarshal's *json.UnmarshalTypeError for a number", err)
+	}
+}
+
+func TestUnixTime(t *testing.T) {
+	at := time.Date(2023, 11, 14, 22, 13, 20, 0, time.UTC)
+	for _, tt := range []struct {
+		name string
+		t    time.Time
+		want string
+	}{
+		{"UTC", at, "1700000000"},
+		{"another zone", at.In(time.FixedZone("JST", 9*3600)), "1700000000"},
+		{"fraction dropped", at.Add(999 * time.Millisecond), "1700000000"},
+		{"the epoch", time.Unix(0, 0), "0"},
+		{"before the epoch", time.Unix(-60, 0), "-60"},
+		{"zero", time.Time{}, "null"},
+	} {
+		data, err := json.Marshal(UnixTime{tt.t})
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		assert.Equal(t, string(data), tt.want, tt.name)
+	}
+
+	for _, tt := range []struct {
+		in   string
+		want time.Time
+	}{
+		{"1700000000", at},
+		{"0", time.Unix(0, 0).UTC()},
+		{"null", time.Time{}},
+	} {
+		got := UnixTime{time.Now()}
+		if err := json.Unmarshal([]byte(tt.in), &got); err != nil {
+			t.Errorf("Unmarshal(%s): %v", tt.in, err)
+			continue
+		}
+		assert.Equal(t, got.Time, tt.want, "Unmarshal(%s)", tt.in)
+		assert.Equal(t, got.Location(), time.UTC, "location of %s", tt.in)
+	}
+	for _, in := range []string{`"1700000000"`, `1.5`, `true`, `"2023-11-14T22:13:20Z"`} {
+		var got UnixTime
+		if err := json.Unmarshal([]byte(in), &got); err == nil {
+			t.Errorf("Unmarshal(%s) = %v, want an error", in, got.Time)
+		}
+	}
+}
+
+func TestAccountJSON(t *testing.T) {
+	a := Account{
+		ID:      1,
+		Name:    "Ada",
+		Status:  Active,
+		Created: UnixTime{time.Unix(1700000000, 0)},
+	}
+	data, err := json.Marshal(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// No email (omitempty), no closed (omitzero), and tags null: a nil
+	// slice has no omitempty here, so it's written as null, not [].
+	assert.Equal(t, string(data), `{"id":1,"name":"Ada","status":"active","created":1700000000,"tags":null}`, "an open account")
+
+	a.Email = "ada@example.com"
+	a.Status = Closed
+	a.Closed = time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	a.Tags = []string{}
+	data, _ = json.Marshal(a)
+	assert.Equal(t, string(data), `{"id":1,"name":"Ada","email":"ada@example.com","status":"closed","created":1700000000,"closed":"2024-03-01T12:00:00Z","tags":[]}`, "a closed account")
+
+	var back Account
+	if err := json.Unmarshal(data, &back); err != nil {
+		t.Fatal(err)
+	}
+	if back.Status != Closed || !back.Created.Equal(a.Created.Time) || !back.Closed.Equal(a.Closed) {
+		t.Errorf("round trip: got %+v", back)
+	}
+}
+
+func TestDecodeStrict(t *testing.T) {
+	var v struct {
+		Name string `json:"name"`
+	}
+	for _, tt := range []struct {
+		in string
+		ok bool
+	}{
+		{`{"name": "Ada"}`, true},
+		{`  {"name": "Ada"}  ` + "\n", true},
+		{`{}`, true},
+		{`{"name": "Ada", "emial": "x"}`, false},
+		{`{"name": "Ada"} {"name": "Bo"}`, false},
+		{`{"name": "Ada"} x`, false},
+		{`{"name": "Ada"}]`, false},
+		{`{"name": `, false},
+		{``, false},
+	} {
+		err := DecodeStrict([]byte(tt.in), &v)
+		if (err == nil) != tt.ok {
+			t.Errorf("DecodeStrict(%q) = %v, want ok %v", tt.in, err, tt.ok)
+		}
+	}
+	if err := DecodeStrict([]byte(`{"name": "Cy"}`), &v); err != nil || v.Name != "Cy" {
+		t.Errorf("decoded %+v, %v", v, err)
+	}
+}
+
+func TestApplyPatch(t *testing.T) {
+	a := Account{ID: 1, Name: "Ada", Email: "ada@example.com", Status: Active, Tags: []string{"vip"}}
+	for _, tt := range []struct {
+		name  string
+		patch string
+		want  Account
+	}{
+		{"nothing", `{}`, a},
+		{"name", `{"name": "Ada L."}`, Account{ID: 1, Name: "Ada L.", Email: "ada@example.com", Status: Active, Tags: []string{"vip"}}},
+		{"clear email", `{"email": ""}`, Account{ID: 1, Name: "Ada", Status: Active, Tags: []string{"vip"}}},
+		{"null is missing", `{"email": null}`, a},
+		{"status", `{"status": "suspended"}`, Account{ID: 1, Name: "Ada", Email: "ada@example.com", Status: Suspended, Tags: []string{"vip"}}},
+		{"back to active", `{"status": "active"}`, a},
+		{"empty tags", `{"tags": []}`, Account{ID: 1, Name: "Ada", Email: "ada@example.com", Status: Active, Tags: []string{}}},
+		{"several", `{"name": "Bo", "tags": ["a", "b"]}`, Account{ID: 1, Name: "Bo", Email: "ada@example.com", Status: Active, Tags: []string{"a", "b"}}},
+	} {
+		got, err := ApplyPatch(a, []byte(tt.patch))
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		assert.Equal(t, got, tt.want, tt.name)
+	}
+
+	for _, tt := range []struct{ name, patch string }{
+		{"empty name", `{"name": ""}`},
+		{"unknown key", `{"nmae": "Bo"}`},
+		{"unknown status", `{"status": "banned"}`},
+		{"id can't be patched", `{"id": 2}`},
+		{"not JSON", `name=Bo`},
+		{"bad name with a good email", `{"email": "x@y", "name": ""}`},
+	} {
+		got, err := ApplyPatch(a, []byte(tt.patch))
+		if err == nil {
+			t.Errorf("%s: no error", tt.name)
+		}
+		assert.Equal(t, got, a, tt.name+": a changed")
+	}
+}
+
+func TestDecodeEvent(t *testing.T) {
+	for _, tt := range []struct {
+		in   string
+		want Event
+	}{
+		{`{"kind": "click", "data": {"x": 10, "y": 20}}`, Click{X: 10, Y: 20}},
+		{`{"data": {"sku": "A1", "cents": 1999}, "kind": "purchase"}`, Purchase{SKU: "A1", Cents: 1999}},
+		{`{"kind": "click", "data": {}}`, Click{}},
+	} {
+		got, err := DecodeEvent([]byte(tt.in))
+		if err != nil {
+			t.Errorf("DecodeEvent(%s): %v", tt.in, err)
+			continue
+		}
+		assert.Equal(t, got, tt.want, tt.in)
+	}
+
+	for _, tt := range []struct{ in, want string }{
+		{`{"kind": "scroll", "data": {}}`, `unknown event kind "scroll"`},
+		{`{"data": {}}`, `unknown event kind ""`},
+	} {
+		_, err := DecodeEvent([]byte(tt.in))
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("DecodeEvent(%s) = %v, want an error containing %s", tt.in, err, tt.want)
+		}
+	}
+	for _, in := range []string{
+		`{"kind": "click", "data": {"x": "ten"}}`,
+		`{"kind": "purchase", "data": [1]}`,
+		`{"kind": "click"`,
+	} {
+		if ev, err := DecodeEvent([]byte(in)); err == nil {
+			t.Errorf("DecodeEvent(%s) = %v, want an error", in, ev)
+		}
+	}
+	var syntaxErr *json.SyntaxError
+	if _, err := DecodeEvent([]byte(`{"kind": "click"`)); !errors.As(err, &syntaxErr) {
+		t.Errorf("DecodeEvent of cut-off JSON = %v, want json.Unmarshal's *json.SyntaxError", err)
+	}
+}
+
+func TestEncodeEvent(t *testing.T) {
+	for _, tt := range []struct {
+		e    Event
+		want string
+	}{
+		{Click{X: 1, Y: 2}, `{"kind":"click","data":{"x":1,"y":2}}`},
+		{Purchase{SKU: "B2", Cents: 500}, `{"kind":"purchase","data":{"sku":"B2","cents":500}}`},
+	} {
+		data, err := EncodeEvent(tt.e)
+		if err != nil {
+			t.Errorf("EncodeEvent(%v): %v", tt.e, err)
+			continue
+		}
+		assert.Equal(t, string(data), tt.want, "EncodeEvent(%v)", tt.e)
+
+		back, err := DecodeEvent(data)
+		if err != nil || back != tt.e {
+			t.Errorf("round trip of %v: %v, %v", tt.e, back, err)
+		}
+	}
+
+	if _, err := EncodeEvent(badEvent{Ch: make(chan int)}); err == nil {
+		t.Error("no error for an event json can't encode")
+	}
+}
+
+// badEvent has a field encoding/json can't marshal.
+type badEvent struct{ Ch chan int }
+
+func (badEvent) Kind() string { return "bad" }
+
+func TestStreamItems(t *testing.T) {
+	in := `{
+		"exported": "2024-03-01",
+		"meta": {"pages": [1, 2], "note": "items: not these"},
+		"items": [{"sku": "A1", "qty": 2}, {"sku": "B2", "qty": 1}, {"sku": "C3", "qty": 5}],
+		"count": 3
+	}`
+	var got []Item
+	n, err := StreamItems(strings.NewReader(in), func(it Item) error {
+		got = append(got, it)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, n, 3, "count")
+	assert.Equal(t, got, []Item{{"A1", 2}, {"B2", 1}, {"C3", 5}}, "items")
+
+	for _, tt := range []struct {
+		in   string
+		want int
+	}{
+		{`{"items": []}`, 0},
+		{`{}`, 0},
+		{`{"other": [{"sku": "X", "qty": 1}]}`, 0},
+	} {
+		n, err := StreamItems(strings.NewReader(tt.in), func(Item) error { return nil })
+		if err != nil || n != tt.want {
+			t.Errorf("StreamItems(%s) = %d, %v; want %d, nil", tt.in, n, err, tt.want)
+		}
+	}
+}
+
+func TestStreamItemsErrors(t *testing.T) {
+	ok := func(Item) error { return nil }
+	for _, in := range []string{
+		`[{"sku": "A1", "qty": 2}]`,
+		`{"items": {"sku": "A1"}}`,
+		`{"items": {}}`,
+		`{"items": [{"sku": "A1", "qty": "two"}]}`,
+		`{"items": [{"sku": "A1", "qty": 2}`,
+		`{"items": [], "more": `,
+		`{"items": []`,
+		`{"items": [] ]`,
+		`{"items": [{"sku": "A1", "qty": 2}] ,`,
+		``,
+	} {
+		if n, err := StreamItems(strings.NewReader(in), ok); err == nil {
+			t.Errorf("StreamItems(%s) = %d, nil; want an error", in, n)
+		}
+	}
+	// Whatever follows an error in the JSON doesn't count, even when it
+	// looks like items.
+	for _, in := range []string{
+		`[{"sku": "A1", "qty": 2}]`,
+		`["items", [{"sku": "A1", "qty": 2}]]`,
+		`{1 "items": [{"sku": "A1", "qty": 2}]}`,
+		`{"items": {{"sku": "A1", "qty": 2}}}`,
+	} {
+		calls := 0
+		n, err := StreamItems(strings.NewReader(in), func(Item) error {
+			calls++
+			return nil
+		})
+		if n != 0 || calls != 0 || err == nil {
+			t.Errorf("StreamItems(%s) = %d, %v after %d calls of fn; want 0 and an error, with no calls", in, n, err, calls)
+		}
+	}
+
+	// fn's error stops the stream, unwrapped.
+	stop := errors.New("stop")
+	calls := 0
+	n, err := StreamItems(strings.NewReader(`{"items": [{"sku": "A"}, {"sku": "B"}, {"sku": "C"}]}`), func(it Item) error {
+		calls++
+		if it.SKU == "B" {
+			return stop
+		}
+		return nil
+	})
+	if err != stop {
+		t.Errorf("got %v, want fn's error itself", err)
+	}
+	assert.Equal(t, calls, 2, "fn calls")
+	assert.Equal(t, n, 2, "items passed to fn")
+}
+
+func TestStreamItemsIsStreaming(t *testing.T) {
+	// A reader that fails after the first item: StreamItems must already
+	// have handed that item to fn.
+	first := `{"items": [{"sku": "A1", "qty": 1},`
+	r := &failingReader{data: first, err: errors.New("connection reset")}
+	var got []Item
+	_, err := StreamItems(r, func(it Item) error {
+		got = append(got, it)
+		return nil
+	})
+	if err == nil {
+		t.Error("no error from a reader that failed")
+	}
+	assert.Equal(t, got, []Item{{"A1", 1}}, fmt.Sprintf("items before the failure (%v)", err))
+}
+
+// failingReader returns data, then err.
+type failingReader struct {
+	data string
+	err  error
+}
+
+func (r *failingReader) Read(p []byte) (int, error) {
+	if r.data == "" {
+		return 0, r.err
+	}
+	n := copy(p, r.data)
+	r.data = r.data[n:]
+	return n, nil
+}
@@ -0,0 +1,122 @@
+//go:build !solutions
+
+package ctxwork
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Exercise 22: The context package
+//
+// 06 raced work against time.After. That stops the caller waiting, but
+// the work itself carries on, and so does every goroutine it started.
+// A context.Context is how Go tells work to stop: like an AbortSignal
+// in JS, passed as the first argument to everything that might block.
+// ctx.Done() is a channel that closes when the work should stop, and
+// ctx.Err() then says why, context.Canceled or
+// context.DeadlineExceeded.
+//
+// Contexts form a tree. context.WithCancel, WithTimeout and
+// WithDeadline derive a child that stops when its parent does, or
+// sooner; always call the cancel func they return, or the child lives
+// on until the parent ends.
+//
+// The tests check that no goroutine outlives the call that started it.
+//
+// Run tests with: go test -v
+
+// 1. Waiting that can be interrupted
+// Sleep waits d, or returns ctx.Err() as soon as ctx is done. If ctx is
+// already done it returns at once, without waiting at all.
+func Sleep(ctx context.Context, d time.Duration) error {
+	// TODO: time.NewTimer(d), defer t.Stop(), then select on t.C and
+	// ctx.Done(); time.After would keep its timer until it fires
+	return nil
+}
+
+// 2. Stopping early
+// DoWithContext runs work in its own goroutine and returns its result,
+// or ctx.Err() if ctx is done first. work doesn't know about ctx, so it
+// can't be stopped, only abandoned; make sure its goroutine can still
+// finish, and exit, once nobody is waiting for it.
+// In JS: Promise.race([work(), abortedPromise(signal)])
+func DoWithContext(ctx context.Context, work func() (int, error)) (int, error) {
+	// TODO: a channel with room for the one result, so the send never
+	// blocks after DoWithContext has returned
+	return 0, nil
+}
+
+// 3. Passing cancellation down
+// Workers starts n goroutines that take jobs, call fn(ctx, job), and
+// send the results on the returned channel, in any order. They stop
+// when jobs is closed and drained, or when ctx is done, even in the
+// middle of a send nobody is receiving. The returned channel is closed
+// once every worker has stopped.
+func Workers(ctx context.Context, n int, jobs <-chan int, fn func(context.Context, int) int) <-chan int {
+	// TODO: a sync.WaitGroup and a goroutine that closes out after
+	// wg.Wait(); every receive and send selects on ctx.Done() too
+	out := make(chan int)
+	close(out)
+	return out
+}
+
+// 4. Cancelling the losers
+// FirstResult calls every fn at once and returns the first successful
+// result. As soon as it has one it cancels the ctx it gave the others,
+// so they can stop. If every fn fails it returns their errors joined
+// with errors.Join, in any order. If ctx is done first it returns
+// ctx.Err().
+func FirstResult(ctx context.Context, fns ...func(context.Context) (string, error)) (string, error) {
+	// TODO: ctx, cancel := context.WithCancel(ctx); defer cancel()
+	return "", nil
+}
+
+// ErrNoTime means there wasn't enough time left to start.
+var ErrNoTime = errors.New("not enough time left")
+
+// 5. Reading a deadline
+// RunIfTime calls fn(ctx) if ctx has at least need left before its
+// deadline, or has no deadline at all; otherwise it returns ErrNoTime
+// without calling fn. Better to fail fast than to start work that the
+// deadline will cut off halfway.
+func RunIfTime(ctx context.Context, need time.Duration, fn func(context.Context) error) error {
+	// TODO: deadline, ok := ctx.Deadline(); time.Until(deadline)
+	return nil
+}
+
+// ErrTooSlow is the cause CallWithTimeout gives its own timeout.
+var ErrTooSlow = errors.New("call took too long")
+
+// 6. Why did it stop?
+// CallWithTimeout calls fn with a ctx that times out after d, with
+// ErrTooSlow as its cause. If that ctx is done by the time fn returns,
+// it returns context.Cause(ctx) instead of fn's error: ErrTooSlow for
+// its own timeout, or whatever cause the parent was cancelled with, so
+// the caller learns why and not just that.
+func CallWithTimeout(ctx context.Context, d time.Duration, fn func(context.Context) error) error {
+	// TODO: context.WithTimeoutCause(ctx, d, ErrTooSlow)
+	return nil
+}
+
+// requestIDKey is the key for request IDs. Its type is unexported, so no
+// other package can make an equal key, read the value by accident or
+// overwrite it: keys match on type as well as value. A plain string key
+// like "requestID" could collide with anyone's.
+type requestIDKey struct{}
+
+// 7. Request-scoped values
+// WithRequestID returns a child of ctx carrying id; RequestID gets it
+// back, reporting whether there was one. Values are for data that
+// follows a request through every layer, like a trace ID, not for
+// passing a function its arguments.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	// TODO: context.WithValue(ctx, requestIDKey{}, id)
+	return ctx
+}
+
+func RequestID(ctx context.Context) (string, bool) {
+	// TODO: a type assertion on ctx.Value(requestIDKey{})
+	return "", false
+}
@@ -0,0 +1,384 @@
+package ctxwork
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.uber.org/goleak"
+
+	"github.com/imgarylai/learn-go/internal/assert"
+)
+
+// within fails the test if f takes longer than d.
+func within(t *testing.T, d time.Duration, what string, f func()) {
+	t.Helper()
+	start := time.Now()
+	f()
+	if took := time.Since(start); took > d {
+		t.Errorf("%s took %v, want under %v", what, took, d)
+	}
+}
+
+func TestSleep(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	start := time.Now()
+	if err := Sleep(context.Background(), 20*time.Millisecond); err != nil {
+		t.Errorf("Sleep: %v", err)
+	}
+	if took := time.Since(start); took < 20*time.Millisecond {
+		t.Errorf("Sleep(20ms) returned after %v", took)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	within(t, 500*time.Millisecond, "Sleep(1h) with a 10ms timeout", func() {
+		if err := Sleep(ctx, time.Hour); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("got %v, want context.DeadlineExceeded", err)
+		}
+	})
+
+	done, cancel := context.WithCancel(context.Background())
+	cancel()
+	within(t, 50*time.Millisecond, "Sleep with a cancelled context", func() {
+		// A select picks at random among ready cases, so once isn't
+		// enough to tell.
+		for range 50 {
+			if err := Sleep(done, 0); !errors.Is(err, context.Canceled) {
+				t.Fatalf("Sleep(0) with a cancelled context: got %v, want context.Canceled", err)
+			}
+		}
+	})
+}
+
+func TestDoWithContext(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	n, err := DoWithContext(context.Background(), func() (int, error) { return 42, nil })
+	if n != 42 || err != nil {
+		t.Errorf("got %d, %v; want 42, nil", n, err)
+	}
+	boom := errors.New("boom")
+	if _, err := DoWithContext(context.Background(), func() (int, error) { return 0, boom }); err != boom {
+		t.Errorf("got %v, want work's error", err)
+	}
+
+	release := make(chan struct{})
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	within(t, 500*time.Millisecond, "DoWithContext with stuck work", func() {
+		n, err := DoWithContext(ctx, func() (int, error) {
+			<-release
+			return 1, nil
+		})
+		if n != 0 || !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("got %d, %v; want 0, context.DeadlineExceeded", n, err)
+		}
+	})
+	// The work finishes after all; its goroutine must be able to exit,
+	// which goleak checks.
+	close(release)
+}
+
+func TestWorkers(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	jobs := make(chan int)
+	go func() {
+		for i := 1; i <= 20; i++ {
+			jobs <- i
+		}
+		close(jobs)
+	}()
+	var running, most atomic.Int32
+	out := Workers(context.Background(), 4, jobs, func(_ context.Context, n int) int {
+		now := running.Add(1)
+		for {
+			m := most.Load()
+			if now <= m || most.CompareAndSwap(m, now) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		running.Add(-1)
+		return n * n
+	})
+	var got []int
+	for v := range out {
+		got = append(got, v)
+	}
+	slices.Sort(got)
+	var want []int
+	for i := 1; i <= 20; i++ {
+		want = append(want, i*i)
+	}
+	assert.Equal(t, got, want, "results")
+	if m := most.Load(); m < 2 || m > 4 {
+		t.Errorf("at most %d jobs ran at once, want 2 to 4 of the 4 workers", m)
+	}
+}
+
+func TestWorkersCancel(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	// Jobs never run out and nobody reads the results: only ctx can stop
+	// the workers.
+	jobs := make(chan int)
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		for i := 0; ; i++ {
+			select {
+			case jobs <- i:
+			case <-stop:
+				return
+			}
+		}
+	}()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var sawCancel atomic.Bool
+	out := Workers(ctx, 3, jobs, func(ctx context.Context, n int) int {
+		if Sleep(ctx, time.Hour) != nil {
+			sawCancel.Store(true)
+		}
+		return n
+	})
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+
+	within(t, time.Second, "closing out after cancel", func() {
+		for range out {
+		}
+	})
+	if !sawCancel.Load() {
+		t.Error("fn never saw ctx cancelled: pass the workers' ctx to it")
+	}
+}
+
+func TestWorkersBlockedSend(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	jobs := make(chan int, 3)
+	jobs <- 1
+	jobs <- 2
+	jobs <- 3
+	close(jobs)
+	ctx, cancel := context.WithCancel(context.Background())
+	out := Workers(ctx, 2, jobs, func(_ context.Context, n int) int { return n })
+
+	// Take one result, then walk away.
+	<-out
+	cancel()
+	within(t, time.Second, "closing out after cancel", func() {
+		for range out {
+		}
+	})
+}
+
+func TestFirstResult(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	var cancelled atomic.Int32
+	slow := func(ctx context.Context) (string, error) {
+		if err := Sleep(ctx, time.Hour); err != nil {
+			cancelled.Add(1)
+			return "", err
+		}
+		return "slow", nil
+	}
+	fast := func(context.Context) (string, error) { return "fast", nil }
+	failing := func(context.Context) (string, error) { return "", errors.New("down") }
+
+	within(t, time.Second, "FirstResult", func() {
+		got, err := FirstResult(context.Background(), slow, failing, fast, slow)
+		if got != "fast" || err != nil {
+			t.Errorf("got %q, %v; want fast, nil", got, err)
+		}
+	})
+	// The slow ones can only exit if they were cancelled.
+	for deadline := time.Now().Add(time.Second); cancelled.Load() < 2 && time.Now().Before(deadline); {
+		time.Sleep(time.Millisecond)
+	}
+	if n := cancelled.Load(); n != 2 {
+		t.Errorf("%d of the 2 slow calls saw their ctx cancelled", n)
+	}
+}
+
+func TestFirstResultAllFail(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	errA, errB := errors.New("a down"), errors.New("b down")
+	got, err := FirstResult(context.Background(),
+		func(context.Context) (string, error) { return "", errA },
+		func(context.Context) (string, error) {
+			time.Sleep(5 * time.Millisecond)
+			return "", errB
+		},
+	)
+	if got != "" || !errors.Is(err, errA) || !errors.Is(err, errB) {
+		t.Errorf("got %q, %v; want both errors", got, err)
+	}
+	if err != nil && !strings.Contains(err.Error(), "\n") {
+		t.Errorf("%q isn't both errors joined", err)
+	}
+
+	if _, err := FirstResult(context.Background()); err != nil {
+		t.Errorf("no fns: got %v, want nil", err)
+	}
+}
+
+func TestFirstResultParentCancelled(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	stuck := make(chan struct{})
+	defer close(stuck)
+	within(t, time.Second, "FirstResult after its ctx times out", func() {
+		_, err := FirstResult(ctx, func(context.Context) (string, error) {
+			<-stuck // ignores ctx, so only FirstResult itself can give up
+			return "late", nil
+		})
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("got %v, want context.DeadlineExceeded", err)
+		}
+	})
+}
+
+func TestRunIfTime(t *testing.T) {
+	ran := false
+	fn := func(context.Context) error { ran = true; return nil }
+
+	short, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	long, cancel := context.WithTimeout(context.Background(), time.Hour)
+	defer cancel()
+
+	for _, tt := range []struct {
+		name    string
+		ctx     context.Context
+		need    time.Duration
+		wantRun bool
+	}{
+		{"no deadline", context.Background(), time.Hour, true},
+		{"plenty of time", long, time.Minute, true},
+		{"not enough", short, time.Second, false},
+		{"just enough", long, 59 * time.Minute, true},
+		{"needs nothing", short, 0, true},
+	} {
+		ran = false
+		err := RunIfTime(tt.ctx, tt.need, fn)
+		if tt.wantRun && (err != nil || !ran) {
+			t.Errorf("%s: got %v, ran %v; want fn run", tt.name, err, ran)
+		}
+		if !tt.wantRun && (err != ErrNoTime || ran) {
+			t.Errorf("%s: got %v, ran %v; want ErrNoTime and fn not run", tt.name, err, ran)
+		}
+	}
+
+	// fn gets ctx itself, with its values and deadline.
+	ctx := WithRequestID(long, "abc")
+	RunIfTime(ctx, 0, func(got context.Context) error {
+		if id, _ := RequestID(got); id != "abc" {
+			t.Error("fn didn't get ctx")
+		}
+		return nil
+	})
+	boom := errors.New("boom")
+	if err := RunIfTime(ctx, 0, func(context.Context) error { return boom }); err != boom {
+		t.Errorf("got %v, want fn's error", err)
+	}
+}
+
+func TestCallWithTimeout(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	waitForCtx := func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	boom := errors.New("boom")
+	for _, tt := range []struct {
+		name string
+		fn   func(context.Context) error
+		want error
+	}{
+		{"fast", func(context.Context) error { return nil }, nil},
+		{"fails", func(context.Context) error { return boom }, boom},
+		{"too slow", waitForCtx, ErrTooSlow},
+		{"too slow, error wrapped", func(ctx context.Context) error {
+			return fmt.Errorf("fetch: %w", waitForCtx(ctx))
+		}, ErrTooSlow},
+	} {
+		within(t, time.Second, tt.name, func() {
+			if err := CallWithTimeout(context.Background(), 10*time.Millisecond, tt.fn); err != tt.want {
+				t.Errorf("%s: got %v, want %v", tt.name, err, tt.want)
+			}
+		})
+	}
+
+	// The fn's ctx is a child of the one passed in.
+	ctx := WithRequestID(context.Background(), "r1")
+	CallWithTimeout(ctx, time.Second, func(ctx context.Context) error {
+		if _, ok := ctx.Deadline(); !ok {
+			t.Error("fn's ctx has no deadline")
+		}
+		if id, _ := RequestID(ctx); id != "r1" {
+			t.Error("fn's ctx lost the request ID")
+		}
+		return nil
+	})
+
+	// A parent cancelled with a cause of its own passes that cause on.
+	shutdown := errors.New("server shutting down")
+	parent, cancel := context.WithCancelCause(context.Background())
+	go func() {
+		time.Sleep(5 * time.Millisecond)
+		cancel(shutdown)
+	}()
+	if err := CallWithTimeout(parent, time.Hour, waitForCtx); err != shutdown {
+		t.Errorf("parent cancelled: got %v, want its cause", err)
+	}
+}
+
+func TestRequestID(t *testing.T) {
+	if id, ok := RequestID(context.Background()); ok || id != "" {
+		t.Errorf("no ID: got %q, %v", id, ok)
+	}
+
+	ctx := WithRequestID(context.Background(), "req-1")
+	if id, ok := RequestID(ctx); !ok || id != "req-1" {
+		t.Errorf("got %q, %v; want req-1, true", id, ok)
+	}
+	// An empty ID is still an ID.
+	if id, ok := RequestID(WithRequestID(context.Background(), "")); !ok || id != "" {
+		t.Errorf("empty ID: got %q, %v", id, ok)
+	}
+
+	// Children see it; a closer one wins; the parent is unchanged.
+	child, cancel := context.WithCancel(ctx)
+	defer cancel()
+	if id, _ := RequestID(child); id != "req-1" {
+		t.Errorf("child: got %q", id)
+	}
+	inner := WithRequestID(child, "req-2")
+	if id, _ := RequestID(inner); id != "req-2" {
+		t.Errorf("inner: got %q", id)
+	}
+	if id, _ := RequestID(ctx); id != "req-1" {
+		t.Errorf("parent after WithRequestID on a child: got %q", id)
+	}
+
+	// A string key with the same spelling is a different key.
+	other := context.WithValue(context.Background(), "requestIDKey", "nope")
+	if id, ok := RequestID(other); ok {
+		t.Errorf("a string key was read as the request ID: %q", id)
+	}
+}
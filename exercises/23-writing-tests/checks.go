@@ -0,0 +1,86 @@
+//go:build !solutions
+
+package writingtests
+
+import (
+	"testing"
+)
+
+// Exercise 23: Writing tests
+//
+// Until now the tests were given and you wrote the code. This time the
+// code is given, in code.go, and every func there has at least one bug.
+// Write the tests that catch them.
+//
+// Each Check func below is a test: write it exactly as you'd write
+// TestSlugify in a _test.go file. The harness (writing_tests_test.go)
+// runs each one twice over: against a fixed version of the code, where
+// it must pass, and against the code as shipped plus a few other broken
+// versions, where it must fail. A Check that never fails catches
+// nothing; one that fails on the fixed code is wrong.
+//
+// Read the doc comments in code.go for what each func should do, and
+// test that, not what the code happens to do. The harness also checks
+// a few habits:
+//
+//   - table-driven tests with t.Run, so each case fails under its own
+//     name (in JS, it.each or one it() per case)
+//   - t.Parallel in the subtests that can run side by side
+//   - t.Cleanup to undo whatever a test changed outside itself
+//
+// Run tests with: go test -v
+
+// 1. Table-driven subtests
+// CheckSlugify tests Slugify with a table of {title, want} cases, each
+// run with t.Run(title, ...). The harness wants at least 4 subtests.
+// Think about what the doc comment promises: case, runs of separators,
+// the ends, digits, letters beyond ASCII.
+func CheckSlugify(t *testing.T) {
+	// TODO: for _, tt := range []struct{ title, want string }{...} {
+	//     t.Run(tt.title, func(t *testing.T) { ... t.Errorf(...) })
+	// }
+}
+
+// 2. Parallel subtests
+// CheckPaginate tests Paginate the same way, with at least 4 subtests,
+// and each subtest calls t.Parallel() first: Paginate shares nothing
+// between calls, so they can run side by side. Cover the pages that
+// exist, the short last page, and the pages that don't.
+func CheckPaginate(t *testing.T) {
+	// TODO: t.Run(name, func(t *testing.T) { t.Parallel(); ... })
+	// Since Go 1.22 each iteration has its own tt, so the closures
+	// don't all see the last case
+}
+
+// 3. Testing behavior over several calls
+// CheckCache tests a Cache from NewCache, one subtest per behavior
+// (at least 3), each starting from a fresh cache: eviction of the least
+// recently used key, Get counting as a use, and Put of a key that's
+// already there.
+func CheckCache(t *testing.T) {
+	// TODO: t.Run("evicts the least recently used", ...) and so on
+}
+
+// 4. t.Cleanup
+// CheckGreeting tests Greeting at chosen times of day by setting Now to
+// a func returning a fixed time. Whatever a test changes outside itself
+// it must change back, even when it fails halfway: register the
+// restore with t.Cleanup right after the change, like an afterEach in
+// JS. The harness checks that Now is the real clock again afterwards.
+// Test the hours on either side of each boundary.
+func CheckGreeting(t *testing.T) {
+	// TODO: old := Now; t.Cleanup(func() { Now = old })
+	// time.Date(2024, 1, 1, hour, 0, 0, 0, time.UTC) is a time at hour
+}
+
+// 5. Testing with the file system
+// CheckWriteAtomic tests WriteAtomic in a directory from t.TempDir(),
+// which removes it once the test is over (with t.Cleanup, as it
+// happens). Check that it returns nil, what ends up on disk, the
+// file's mode, and what's left in the directory when WriteAtomic
+// fails. One way to make it fail: give it a path that's an existing
+// directory.
+func CheckWriteAtomic(t *testing.T) {
+	// TODO: dir := t.TempDir(); os.ReadFile, os.Stat(...).Mode().Perm(),
+	// os.ReadDir
+}
@@ -0,0 +1,153 @@
+package writingtests
+
+import (
+	"container/list"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// The code under test. Each of these has a bug, and this exercise is to
+// write the tests that find them: don't fix anything in this file.
+//
+// They're variables, not plain funcs, so the harness can swap in a
+// fixed version of each and check that your tests pass on it, then
+// swap in the buggy ones and check that your tests fail. Call them like
+// any func.
+var (
+	Slugify     = slugify
+	Paginate    = paginate
+	NewCache    = newCache
+	Greeting    = greeting
+	WriteAtomic = writeAtomic
+
+	// Now is the clock Greeting reads. Tests can set it to a fixed time,
+	// as long as they put the real one back.
+	Now = time.Now
+)
+
+// Slugify turns a title into the last part of a URL: lowercase letters
+// and digits, with every run of anything else between them turned into
+// a single "-", and none at either end.
+//
+//	Slugify("Hello, World!") == "hello-world"
+func slugify(title string) string {
+	s := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return '-'
+	}, title)
+	return strings.Trim(s, "-")
+}
+
+// ErrNoPage is Paginate's error for a page that can't exist.
+var ErrNoPage = errors.New("no such page")
+
+// Paginate says which items page shows, counting pages from 1: items
+// [start, end) of total, perPage to a page. The last page may be short.
+// Page 1 always exists, even when total is 0; any page before it or
+// after the last is ErrNoPage, and so is a perPage below 1.
+func paginate(total, perPage, page int) (start, end int, err error) {
+	if perPage < 1 || page < 1 {
+		return 0, 0, ErrNoPage
+	}
+	start = (page - 1) * perPage
+	if start >= total && page > 1 {
+		return 0, 0, ErrNoPage
+	}
+	return start, start + perPage, nil
+}
+
+// Cache is a least-recently-used cache: once it holds capacity keys,
+// a Put of a new key evicts the one that was used longest ago, where
+// both Get and Put count as using a key. Putting a key it already holds
+// replaces the value and evicts nothing.
+type Cache interface {
+	Get(key string) (int, bool)
+	Put(key string, value int)
+	Len() int
+}
+
+type entry struct {
+	key   string
+	value int
+}
+
+type lru struct {
+	capacity int
+	order    *list.List // front is the most recently used
+	items    map[string]*list.Element
+}
+
+func newCache(capacity int) Cache {
+	return &lru{capacity: capacity, order: list.New(), items: map[string]*list.Element{}}
+}
+
+func (c *lru) Get(key string) (int, bool) {
+	el, ok := c.items[key]
+	if !ok {
+		return 0, false
+	}
+	return el.Value.(*entry).value, true
+}
+
+func (c *lru) Put(key string, value int) {
+	if el, ok := c.items[key]; ok {
+		el.Value.(*entry).value = value
+		c.order.MoveToFront(el)
+		return
+	}
+	if c.order.Len() >= c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*entry).key)
+	}
+	c.items[key] = c.order.PushFront(&entry{key, value})
+}
+
+func (c *lru) Len() int { return c.order.Len() }
+
+// Greeting greets name by the time of day Now says: "Good morning"
+// before noon, "Good afternoon" from noon until 6pm, and "Good evening"
+// from then until midnight.
+//
+//	Greeting("Ada") == "Good morning, Ada!" // at 9:30
+func greeting(name string) string {
+	h := Now().Hour()
+	switch {
+	case h <= 12:
+		return "Good morning, " + name + "!"
+	case h < 18:
+		return "Good afternoon, " + name + "!"
+	}
+	return "Good evening, " + name + "!"
+}
+
+// WriteAtomic writes data to path so that anyone reading path sees the
+// old contents or the new, never half of the new: it writes a temporary
+// file in the same directory and renames it over path. The file ends up
+// with mode 0644. When it fails, it leaves no temporary file behind.
+func writeAtomic(path string, data []byte) error {
+	f, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	if err := os.Chmod(f.Name(), 0o644); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
@@ -0,0 +1,330 @@
+package writingtests
+
+import (
+	"container/list"
+	"errors"
+	"maps"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"unicode"
+)
+
+// The tests here test your tests. Each TestCheckX runs the test binary
+// again, once per version of the code, with only TestRunCheck enabled;
+// TestRunCheck puts that version in place and runs CheckX on it. CheckX
+// has to pass on the fixed version and fail on every buggy one.
+
+const (
+	checkEnv   = "LEARNGO_CHECK"
+	versionEnv = "LEARNGO_VERSION"
+)
+
+// fixed is the version without bugs.
+const fixed = "fixed"
+
+type harness struct {
+	check func(*testing.T)
+	// versions puts each version of the code in place, by name: fixed,
+	// the one in code.go and the other bugs.
+	versions map[string]func()
+	// subtests is how many t.Run subtests the check needs at least.
+	subtests int
+	parallel bool // whether they have to call t.Parallel
+}
+
+var harnesses = map[string]harness{
+	"Slugify": {
+		check: CheckSlugify,
+		versions: map[string]func(){
+			fixed:                                func() { Slugify = fixedSlugify(true, true) },
+			"is as shipped in code.go":           func() {},
+			"keeps the separators at either end": func() { Slugify = fixedSlugify(false, true) },
+			"keeps upper case":                   func() { Slugify = fixedSlugify(true, false) },
+		},
+		subtests: 4,
+	},
+	"Paginate": {
+		check: CheckPaginate,
+		versions: map[string]func(){
+			fixed:                         func() { Paginate = fixedPaginate(0) },
+			"is as shipped in code.go":    func() {},
+			"has page 0":                  func() { Paginate = fixedPaginate(bugPageZero) },
+			"has a page past the last":    func() { Paginate = fixedPaginate(bugPastLast) },
+			"has no page 1 with no items": func() { Paginate = fixedPaginate(bugNoFirst) },
+			"takes a perPage of 0":        func() { Paginate = fixedPaginate(bugPerPageZero) },
+			"clamps the end off by one":   func() { Paginate = fixedPaginate(bugEndOffByOne) },
+		},
+		subtests: 4,
+		parallel: true,
+	},
+	"Cache": {
+		check: CheckCache,
+		versions: map[string]func(){
+			fixed:                           func() { NewCache = fixedCache(0) },
+			"is as shipped in code.go":      func() {},
+			"evicts on a Put of a held key": func() { NewCache = fixedCache(bugEvictOnUpdate) },
+			"holds one more than capacity":  func() { NewCache = fixedCache(bugOverCapacity) },
+			"evicts the newest":             func() { NewCache = fixedCache(bugEvictNewest) },
+		},
+		subtests: 3,
+	},
+	"Greeting": {
+		check: CheckGreeting,
+		versions: map[string]func(){
+			fixed:                           func() { Greeting = fixedGreeting(0, 12, 18) },
+			"is as shipped in code.go":      func() {},
+			"says good evening until 1am":   func() { Greeting = fixedGreeting(1, 12, 18) },
+			"says good morning until 11":    func() { Greeting = fixedGreeting(0, 11, 18) },
+			"says good afternoon until 7pm": func() { Greeting = fixedGreeting(0, 12, 19) },
+			"says good evening from 5pm":    func() { Greeting = fixedGreeting(0, 12, 17) },
+		},
+		subtests: 4,
+	},
+	"WriteAtomic": {
+		check: CheckWriteAtomic,
+		versions: map[string]func(){
+			fixed:                            func() { WriteAtomic = fixedWriteAtomic(0o644, false) },
+			"is as shipped in code.go":       func() {},
+			"leaves the mode at 0600":        func() { WriteAtomic = fixedWriteAtomic(0, false) },
+			"reports an error after writing": func() { WriteAtomic = fixedWriteAtomic(0o644, true) },
+		},
+		subtests: 2,
+	},
+}
+
+func TestCheckSlugify(t *testing.T)     { testCheck(t, "Slugify") }
+func TestCheckPaginate(t *testing.T)    { testCheck(t, "Paginate") }
+func TestCheckCache(t *testing.T)       { testCheck(t, "Cache") }
+func TestCheckGreeting(t *testing.T)    { testCheck(t, "Greeting") }
+func TestCheckWriteAtomic(t *testing.T) { testCheck(t, "WriteAtomic") }
+
+func testCheck(t *testing.T, name string) {
+	if os.Getenv(checkEnv) != "" {
+		t.Skip("running a check")
+	}
+	h := harnesses[name]
+
+	out, err := runCheck(t, name, fixed)
+	if err != nil {
+		t.Fatalf("Check%s fails on the fixed %s, so it's testing for something %s doesn't promise:\n%s", name, name, name, out)
+	}
+	prefix := "TestRunCheck/" + name + "/"
+	if n := strings.Count(out, "=== RUN   "+prefix); n < h.subtests {
+		t.Errorf("Check%s ran %d subtests, want at least %d: one t.Run per case", name, n, h.subtests)
+	}
+	if n := strings.Count(out, "=== PAUSE "+prefix); h.parallel && n < h.subtests {
+		t.Errorf("%d of Check%s's subtests called t.Parallel, want at least %d", n, name, h.subtests)
+	}
+
+	for _, version := range slices.Sorted(maps.Keys(h.versions)) {
+		if version == fixed {
+			continue
+		}
+		if out, err := runCheck(t, name, version); err == nil {
+			t.Errorf("Check%s passes on a %s that %s: it missed a bug", name, name, version)
+		} else if strings.Contains(out, "panic: ") {
+			t.Errorf("Check%s panics on a %s that %s; fail with t.Error or t.Fatal instead:\n%s", name, name, version, out)
+		}
+	}
+}
+
+// runCheck runs Check<name> against one version of the code in a new
+// process, so a failing check fails only that process, and returns its
+// output.
+func runCheck(t *testing.T, name, version string) (string, error) {
+	t.Helper()
+	cmd := exec.Command(os.Args[0], "-test.run=^TestRunCheck$", "-test.v", "-test.count=1")
+	cmd.Env = append(os.Environ(), checkEnv+"="+name, versionEnv+"="+version)
+	out, err := cmd.CombinedOutput()
+	var exit *exec.ExitError
+	if err != nil && !errors.As(err, &exit) {
+		t.Fatalf("running the check: %v", err)
+	}
+	return string(out), err
+}
+
+// TestRunCheck is what runCheck runs. On its own it does nothing.
+func TestRunCheck(t *testing.T) {
+	name := os.Getenv(checkEnv)
+	if name == "" {
+		t.Skip("only run by the TestCheck tests")
+	}
+	h := harnesses[name]
+	install := h.versions[os.Getenv(versionEnv)]
+	if install == nil {
+		t.Fatalf("no version %q of %s", os.Getenv(versionEnv), name)
+	}
+	install()
+
+	clock := reflect.ValueOf(Now).Pointer()
+	t.Run(name, h.check)
+	if reflect.ValueOf(Now).Pointer() != clock {
+		t.Errorf("Check%s left Now changed: put it back with t.Cleanup", name)
+	}
+}
+
+// The fixed versions take the bugs they should have as arguments, so
+// each one also makes the buggy versions.
+
+func fixedSlugify(trim, lower bool) func(string) string {
+	return func(title string) string {
+		var b strings.Builder
+		sep := false
+		for _, r := range title {
+			if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+				sep = true
+				continue
+			}
+			if sep && (b.Len() > 0 || !trim) {
+				b.WriteByte('-')
+			}
+			sep = false
+			if lower {
+				r = unicode.ToLower(r)
+			}
+			b.WriteRune(r)
+		}
+		if sep && !trim {
+			b.WriteByte('-')
+		}
+		return b.String()
+	}
+}
+
+const (
+	bugPageZero = 1 << iota
+	bugPastLast
+	bugNoFirst
+	bugPerPageZero
+	bugEndOffByOne
+)
+
+func fixedPaginate(bugs int) func(total, perPage, page int) (int, int, error) {
+	return func(total, perPage, page int) (int, int, error) {
+		if perPage < 1 && bugs&bugPerPageZero == 0 || page < 1 && bugs&bugPageZero == 0 {
+			return 0, 0, ErrNoPage
+		}
+		start := (page - 1) * perPage
+		switch {
+		case bugs&bugPastLast != 0:
+			if start > total {
+				return 0, 0, ErrNoPage
+			}
+		case bugs&bugNoFirst != 0:
+			if start >= total {
+				return 0, 0, ErrNoPage
+			}
+		default:
+			if start >= total && page > 1 {
+				return 0, 0, ErrNoPage
+			}
+		}
+		end := min(start+perPage, total)
+		if bugs&bugEndOffByOne != 0 && end < start+perPage {
+			end--
+		}
+		return max(start, 0), max(end, 0), nil
+	}
+}
+
+const (
+	bugEvictOnUpdate = 1 << iota
+	bugOverCapacity
+	bugEvictNewest
+)
+
+type fixedLRU struct {
+	lru
+	bugs int
+}
+
+func fixedCache(bugs int) func(int) Cache {
+	return func(capacity int) Cache {
+		return &fixedLRU{lru{capacity: capacity, order: list.New(), items: map[string]*list.Element{}}, bugs}
+	}
+}
+
+func (c *fixedLRU) Get(key string) (int, bool) {
+	el, ok := c.items[key]
+	if !ok {
+		return 0, false
+	}
+	c.order.MoveToFront(el)
+	return el.Value.(*entry).value, true
+}
+
+func (c *fixedLRU) Put(key string, value int) {
+	el, ok := c.items[key]
+	full := c.order.Len() >= c.capacity
+	if c.bugs&bugOverCapacity != 0 {
+		full = c.order.Len() > c.capacity
+	}
+	if full && (!ok || c.bugs&bugEvictOnUpdate != 0) {
+		victim := c.order.Back()
+		if c.bugs&bugEvictNewest != 0 {
+			victim = c.order.Front()
+		}
+		c.order.Remove(victim)
+		delete(c.items, victim.Value.(*entry).key)
+		el, ok = c.items[key]
+	}
+	if ok {
+		el.Value.(*entry).value = value
+		c.order.MoveToFront(el)
+		return
+	}
+	c.items[key] = c.order.PushFront(&entry{key, value})
+}
+
+func fixedGreeting(morning, noon, evening int) func(string) string {
+	return func(name string) string {
+		h := Now().Hour()
+		switch {
+		case h < morning:
+			return "Good evening, " + name + "!"
+		case h < noon:
+			return "Good morning, " + name + "!"
+		case h < evening:
+			return "Good afternoon, " + name + "!"
+		}
+		return "Good evening, " + name + "!"
+	}
+}
+
+// fixedWriteAtomic sets the mode to mode, or leaves CreateTemp's 0600
+// for 0. With removeAfter it also removes the temporary file after the
+// rename has moved it, and returns the error that gets.
+func fixedWriteAtomic(mode os.FileMode, removeAfter bool) func(string, []byte) error {
+	return func(path string, data []byte) (err error) {
+		f, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if err != nil {
+				os.Remove(f.Name())
+			}
+		}()
+		if _, err := f.Write(data); err != nil {
+			f.Close()
+			return err
+		}
+		if err := f.Close(); err != nil {
+			return err
+		}
+		if mode != 0 {
+			if err := os.Chmod(f.Name(), mode); err != nil {
+				return err
+			}
+		}
+		if err := os.Rename(f.Name(), path); err != nil || !removeAfter {
+			return err
+		}
+		return os.Remove(f.Name())
+	}
+}
@@ -0,0 +1,173 @@
+//go:build !solutions
+
+package unicodestrings
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Exercise 24: Strings, runes and Unicode
+//
+// 13 showed that a Go string is UTF-8 bytes, and that ranging over it
+// or converting it to []rune gives code points. This one goes further.
+// A code point still isn't always what a reader calls a character: "é"
+// can be one rune (U+00E9) or two ("e" and a combining accent, U+0301),
+// and a family emoji is seven. Upper and lower case aren't one-to-one
+// either. JS has the same problems one level down, in UTF-16: "😀".length
+// is 2, [...s] splits by code point, and Intl.Segmenter finds the
+// characters.
+//
+// Run tests with: go test -v
+
+// 1. Bytes vs runes
+// Lengths returns the length of s in bytes and in runes.
+// In JS: new TextEncoder().encode(s).length and [...s].length
+func Lengths(s string) (bytes, runes int) {
+	// TODO: len(s) and utf8.RuneCountInString(s)
+	return 0, 0
+}
+
+// 2. What range gives you
+// RuneOffsets returns the byte offset where each rune of s starts:
+// RuneOffsets("añb") is [0 1 3]. Ranging over a string gives exactly
+// these offsets, with the rune at each; an invalid byte comes out as
+// utf8.RuneError and counts as a rune of its own.
+func RuneOffsets(s string) []int {
+	// TODO: for i := range s
+	return nil
+}
+
+// 3. Cutting on a rune boundary
+// Truncate shortens s to at most n runes, ending in "…" (one rune, so
+// n-1 of s) when anything was cut. s[:n] could cut a rune in half and
+// leave invalid UTF-8. n is at least 1.
+func Truncate(s string, n int) string {
+	// TODO: find the byte offset of rune n-1 while ranging, and slice there
+	return s
+}
+
+// 4. strings.Builder
+// Capitalize puts the first letter of every word in title case, where
+// words are separated by white space (unicode.IsSpace), and leaves the
+// rest as it is. Title case isn't always upper case: the digraph ǆ is ǅ
+// at the start of a word, not Ǆ. Build the result in a strings.Builder,
+// which, like an array you join at the end in JS, doesn't copy the
+// whole string again for every piece added.
+func Capitalize(s string) string {
+	// TODO: b.Grow(len(s)); for each rune, b.WriteRune(unicode.ToTitle(r))
+	// if the rune before it was a space (or there was none)
+	var b strings.Builder
+	return b.String()
+}
+
+// 5. strings.Fields and strings.Join
+// NormalizeSpace collapses every run of white space in s, Unicode's
+// included (a no-break space, an ideographic space), into one " ", and
+// drops it at either end.
+// In JS: s.trim().split(/\s+/).join(" "), plus the "" case
+func NormalizeSpace(s string) string {
+	// TODO
+	return s
+}
+
+// 6. strings.Split
+// ParseTags splits a comma-separated list of tags, trims the space
+// around each and drops the empty ones: " go, ,unicode," is
+// ["go" "unicode"]. Split, unlike Fields, keeps empty pieces, and
+// strings.Split("", ",") is [""], not [].
+func ParseTags(s string) []string {
+	// TODO
+	return nil
+}
+
+// 7. Case folding
+// strings.ToLower isn't enough to compare text without case: "ΟΔΟΣ"
+// lowers to "οδοσ", but the word is spelled "οδος", with a final ς, and
+// the Kelvin sign K lowers to itself, not to k. strings.EqualFold knows
+// that σ, ς and Σ are one letter in different cases; it walks each
+// rune's orbit, the cycle unicode.SimpleFold steps through
+// (k → K → K → k).
+//
+// FoldKey maps s to a key for case-insensitive lookups: every rune
+// replaced by the smallest rune in its orbit. Two strings have the same
+// key exactly when strings.EqualFold says they're equal.
+func FoldKey(s string) string {
+	// TODO: for each rune, follow unicode.SimpleFold(r) until it comes
+	// back to r, keeping the smallest; strings.Map does the rest
+	return s
+}
+
+// 8. Using a fold key
+// Dedupe returns words without the ones that repeat an earlier word
+// but for case, keeping the first spelling and the order.
+func Dedupe(words []string) []string {
+	// TODO: a map[string]bool of the FoldKeys seen so far
+	return words
+}
+
+// 9. Graphemes
+// A grapheme cluster is what a reader sees as one character. The full
+// rules are in Unicode's UAX #29; Graphemes follows these:
+//
+//   - a rune starts a new cluster, except that these join the one
+//     before: combining marks (unicode.Mn, Me and Mc, which take in the
+//     variation selectors that ask for a glyph in emoji or text style),
+//     emoji skin tones (U+1F3FB to U+1F3FF), a zero width joiner
+//     (U+200D), and whatever follows a zero width joiner
+//   - two regional indicators (U+1F1E6 to U+1F1FF) make one cluster, a
+//     flag, but a third starts the next one
+//   - "\r\n" is one cluster
+//
+// Graphemes returns the clusters of s in order.
+func Graphemes(s string) []string {
+	// TODO: remember where the current cluster started and decide, rune
+	// by rune, whether to cut before it
+	return nil
+}
+
+// 10. Reversing text
+// Reverse reverses s by grapheme cluster. Reversing bytes breaks every
+// multi-byte rune, and reversing runes moves combining accents onto the
+// wrong letter and takes flags apart: "🇯🇵" reversed by rune is "🇵🇯",
+// another flag altogether.
+// In JS: [...new Intl.Segmenter().segment(s)].map(x => x.segment).reverse().join("")
+func Reverse(s string) string {
+	// TODO
+	return s
+}
+
+// InvalidUTF8Error says where in some bytes the UTF-8 went wrong.
+type InvalidUTF8Error struct {
+	Offset int // in bytes
+}
+
+func (e *InvalidUTF8Error) Error() string {
+	return fmt.Sprintf("invalid UTF-8 at byte %d", e.Offset)
+}
+
+// 11. Validating UTF-8
+// CheckUTF8 returns nil when b is valid UTF-8, or an
+// *InvalidUTF8Error with the offset of the first bad byte. Input from
+// files and the network may be anything; utf8.Valid only says whether.
+func CheckUTF8(b []byte) error {
+	// TODO: utf8.DecodeRune returns (utf8.RuneError, 1) for a bad byte;
+	// U+FFFD itself, written out properly, decodes with a size of 3
+	return nil
+}
+
+// 12. Repairing it
+// Sanitize returns b as a valid string, with each byte that isn't part
+// of valid UTF-8 replaced by U+FFFD, "�": what ranging over string(b)
+// sees. (strings.ToValidUTF8 replaces a whole run of bad bytes with
+// one.)
+func Sanitize(b []byte) string {
+	// TODO
+	return string(b)
+}
+
+// Keep imports used
+var _ = unicode.ToTitle
+var _ = utf8.DecodeRune
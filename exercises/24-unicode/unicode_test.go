@@ -0,0 +1,247 @@
+package unicodestrings
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/imgarylai/learn-go/internal/assert"
+)
+
+// Text with more than one way to spell a character is written with
+// escapes, so what's tested is visible: "e\u0301" is an e followed by
+// a combining acute accent, "é" the single precomposed rune.
+const (
+	family = "\U0001F468\u200d\U0001F469\u200d\U0001F467" // 👨‍👩‍👧
+	thumbs = "\U0001F44D\U0001F3FD"                       // 👍🏽
+	japan  = "\U0001F1EF\U0001F1F5"                       // 🇯🇵
+	france = "\U0001F1EB\U0001F1F7"                       // 🇫🇷
+	heart  = "❤\ufe0f"                                    // ❤️
+)
+
+func TestLengths(t *testing.T) {
+	for _, tt := range []struct {
+		s            string
+		bytes, runes int
+	}{
+		{"", 0, 0},
+		{"go", 2, 2},
+		{"é", 2, 1},
+		{"e\u0301", 3, 2},
+		{"日本語", 9, 3},
+		{family, 18, 5},
+		{"\xff", 1, 1},
+	} {
+		bytes, runes := Lengths(tt.s)
+		if bytes != tt.bytes || runes != tt.runes {
+			t.Errorf("Lengths(%q) = %d, %d; want %d, %d", tt.s, bytes, runes, tt.bytes, tt.runes)
+		}
+	}
+}
+
+func TestRuneOffsets(t *testing.T) {
+	assert.Equal(t, RuneOffsets("añb"), []int{0, 1, 3}, "añb")
+	assert.Equal(t, RuneOffsets("日本"), []int{0, 3}, "日本")
+	assert.Equal(t, RuneOffsets("a\xffb"), []int{0, 1, 2}, "an invalid byte")
+	assert.Equal(t, len(RuneOffsets("")), 0, "empty string")
+}
+
+func TestTruncate(t *testing.T) {
+	for _, tt := range []struct {
+		s    string
+		n    int
+		want string
+	}{
+		{"hello", 10, "hello"},
+		{"hello", 5, "hello"},
+		{"hello", 4, "hel…"},
+		{"日本語のテキスト", 4, "日本語…"},
+		{"日本語", 3, "日本語"},
+		{"héllo", 3, "hé…"},
+		{"ab", 1, "…"},
+		{"", 1, ""},
+	} {
+		got := Truncate(tt.s, tt.n)
+		assert.Equal(t, got, tt.want, "Truncate(%q, %d)", tt.s, tt.n)
+		if !utf8.ValidString(got) {
+			t.Errorf("Truncate(%q, %d) = %q isn't valid UTF-8", tt.s, tt.n, got)
+		}
+	}
+}
+
+func TestCapitalize(t *testing.T) {
+	for _, tt := range []struct{ s, want string }{
+		{"hello world", "Hello World"},
+		{"élan vital", "Élan Vital"},
+		{"  two  spaces\tand\ntabs", "  Two  Spaces\tAnd\nTabs"},
+		{"already Capital, mIxEd", "Already Capital, MIxEd"},
+		{"ǆungla", "ǅungla"},
+		{"123 go", "123 Go"},
+		{"", ""},
+	} {
+		assert.Equal(t, Capitalize(tt.s), tt.want, "Capitalize(%q)", tt.s)
+	}
+}
+
+func TestNormalizeSpace(t *testing.T) {
+	for _, tt := range []struct{ s, want string }{
+		{"  hello   world  ", "hello world"},
+		{"a\tb\nc\r\nd", "a b c d"},
+		{"no\u00a0break\u3000ideographic", "no break ideographic"},
+		{"one", "one"},
+		{" \t\n ", ""},
+		{"", ""},
+	} {
+		assert.Equal(t, NormalizeSpace(tt.s), tt.want, "NormalizeSpace(%q)", tt.s)
+	}
+}
+
+func TestParseTags(t *testing.T) {
+	assert.Equal(t, ParseTags(" go, ,unicode,"), []string{"go", "unicode"}, "empty tags")
+	assert.Equal(t, ParseTags("web dev,  api  "), []string{"web dev", "api"}, "spaces inside a tag")
+	assert.Equal(t, ParseTags("solo"), []string{"solo"}, "one tag")
+	for _, s := range []string{"", ",", " , ,"} {
+		if got := ParseTags(s); len(got) != 0 {
+			t.Errorf("ParseTags(%q) = %q, want no tags", s, got)
+		}
+	}
+}
+
+func TestFoldKey(t *testing.T) {
+	same := [][2]string{
+		{"Go", "gO"},
+		{"ΟΔΟΣ", "οδος"},
+		{"σ", "ς"},
+		{"K", "k"}, // the Kelvin sign
+		{"Straße", "STRAßE"},
+		{"ǅ", "ǆ"},
+	}
+	for _, p := range same {
+		if FoldKey(p[0]) != FoldKey(p[1]) {
+			t.Errorf("FoldKey(%q) = %q and FoldKey(%q) = %q; want the same key", p[0], FoldKey(p[0]), p[1], FoldKey(p[1]))
+		}
+	}
+	// EqualFold only folds one rune to one rune, so ß isn't SS.
+	different := [][2]string{
+		{"go", "og"},
+		{"Straße", "STRASSE"},
+		{"e\u0301", "é"},
+		{"a", "á"},
+	}
+	for _, p := range different {
+		if FoldKey(p[0]) == FoldKey(p[1]) {
+			t.Errorf("FoldKey(%q) and FoldKey(%q) are both %q; want different keys", p[0], p[1], FoldKey(p[0]))
+		}
+	}
+	for _, p := range append(same, different...) {
+		if strings.EqualFold(p[0], p[1]) != (FoldKey(p[0]) == FoldKey(p[1])) {
+			t.Errorf("FoldKey disagrees with strings.EqualFold on %q and %q", p[0], p[1])
+		}
+	}
+	assert.Equal(t, len(FoldKey("ΟΔΟΣ")), len("ΟΔΟΣ"), "a key is one rune per rune")
+}
+
+func TestDedupe(t *testing.T) {
+	assert.Equal(t, Dedupe([]string{"Go", "rust", "GO", "Rust", "go", "zig"}), []string{"Go", "rust", "zig"})
+	assert.Equal(t, Dedupe([]string{"οδος", "ΟΔΟΣ", "Οδος"}), []string{"οδος"}, "final sigma")
+	assert.Equal(t, Dedupe([]string{"Kelvin", "kelvin"}), []string{"Kelvin"}, "Kelvin sign")
+	assert.Equal(t, Dedupe([]string{"a", "b", "c"}), []string{"a", "b", "c"}, "nothing repeats")
+	if got := Dedupe(nil); len(got) != 0 {
+		t.Errorf("Dedupe(nil) = %q", got)
+	}
+}
+
+func TestGraphemes(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		s    string
+		want []string
+	}{
+		{"ASCII", "go!", []string{"g", "o", "!"}},
+		{"precomposed", "café", []string{"c", "a", "f", "é"}},
+		{"combining", "cafe\u0301", []string{"c", "a", "f", "e\u0301"}},
+		{"two marks", "a\u0301\u0323b", []string{"a\u0301\u0323", "b"}},
+		{"spacing mark", "क\u093f", []string{"क\u093f"}}, // कि
+		{"enclosing mark", "1\u20e3", []string{"1\u20e3"}},
+		{"skin tone", thumbs + "!", []string{thumbs, "!"}},
+		{"variation selector", heart + heart, []string{heart, heart}},
+		{"ZWJ sequence", family + "a", []string{family, "a"}},
+		{"flags", japan + france, []string{japan, france}},
+		{"odd flag out", japan + "\U0001F1EB", []string{japan, "\U0001F1EB"}},
+		{"flag between", "a" + japan + "b" + france, []string{"a", japan, "b", france}},
+		{"CRLF", "a\r\nb\n\r", []string{"a", "\r\n", "b", "\n", "\r"}},
+		{"leading mark", "\u0301a", []string{"\u0301", "a"}},
+		{"CJK", "日本", []string{"日", "本"}},
+		// The first and last rune of each range, and the ones just outside.
+		{"variation selectors", "a\ufe00b\ufe0fc\ufe10", []string{"a\ufe00", "b\ufe0f", "c", "\ufe10"}},
+		{"skin tones", "\U0001F44B\U0001F3FB\U0001F44B\U0001F3FF\U0001F3FA\U0001F400", []string{"\U0001F44B\U0001F3FB", "\U0001F44B\U0001F3FF", "\U0001F3FA", "\U0001F400"}},
+		{"regional indicators", "\U0001F1E6\U0001F1FF\U0001F1E5\U0001F1E6\U0001F200", []string{"\U0001F1E6\U0001F1FF", "\U0001F1E5", "\U0001F1E6", "\U0001F200"}},
+	} {
+		assert.Equal(t, Graphemes(tt.s), tt.want, tt.name)
+	}
+	if got := Graphemes(""); len(got) != 0 {
+		t.Errorf("Graphemes(\"\") = %q, want none", got)
+	}
+}
+
+func TestReverse(t *testing.T) {
+	for _, tt := range []struct{ name, s, want string }{
+		{"ASCII", "hello", "olleh"},
+		{"multi-byte", "日本語", "語本日"},
+		{"precomposed", "noël", "lëon"},
+		{"combining", "noe\u0308l", "le\u0308on"},
+		{"flags", japan + france, france + japan},
+		{"emoji", "hi " + family + thumbs, thumbs + family + " ih"},
+		{"CRLF", "a\r\nb", "b\r\na"},
+		{"empty", "", ""},
+	} {
+		assert.Equal(t, Reverse(tt.s), tt.want, tt.name)
+	}
+}
+
+func TestCheckUTF8(t *testing.T) {
+	for _, s := range []string{"", "hello", "日本語", family, "�"} {
+		if err := CheckUTF8([]byte(s)); err != nil {
+			t.Errorf("CheckUTF8(%q) = %v, want nil", s, err)
+		}
+	}
+	for _, tt := range []struct {
+		name   string
+		b      string
+		offset int
+	}{
+		{"bad first byte", "\xffabc", 0},
+		{"after ASCII", "ab\x80", 2},
+		{"cut short", "日本\xe8\xaa", 6},
+		{"overlong", "a\xc0\xaf", 1},
+		{"surrogate", "é\xed\xa0\x80", 2},
+		{"first of two", "a\xffb\xfe", 1},
+	} {
+		err := CheckUTF8([]byte(tt.b))
+		var u *InvalidUTF8Error
+		if !errors.As(err, &u) {
+			t.Errorf("%s: CheckUTF8(%q) = %v, want an *InvalidUTF8Error", tt.name, tt.b, err)
+			continue
+		}
+		assert.Equal(t, u.Offset, tt.offset, "%s: offset", tt.name)
+	}
+}
+
+func TestSanitize(t *testing.T) {
+	for _, tt := range []struct{ b, want string }{
+		{"hello", "hello"},
+		{"日本語", "日本語"},
+		{"a\xffb", "a�b"},
+		{"a\xff\xfeb", "a��b"},
+		{"日本\xe8\xaa", "日本��"},
+		{"�", "�"},
+		{"", ""},
+	} {
+		got := Sanitize([]byte(tt.b))
+		assert.Equal(t, got, tt.want, "Sanitize(%q)", tt.b)
+		if !utf8.ValidString(got) {
+			t.Errorf("Sanitize(%q) = %q isn't valid UTF-8", tt.b, got)
+		}
+	}
+}
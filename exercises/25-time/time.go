@@ -0,0 +1,172 @@
+//go:build !solutions
+
+package timehandling
+
+import (
+	"fmt"
+	"time"
+)
+
+// Exercise 25: Dates and times
+//
+// 12 made the clock injectable; this one is about the values it hands
+// out. A time.Time is an instant plus a location to show it in, so the
+// same instant is 09:00 in Tokyo and 01:00 in London, and two times are
+// compared with Equal, not ==, which also compares the location (and
+// the monotonic reading time.Now adds). JS's Date has only the local
+// zone and UTC; Go can use any zone in the IANA database, by name.
+//
+// Calendar arithmetic is where the bugs live. A day isn't always 24
+// hours, because of daylight saving time, and an hour doesn't always
+// start at :00, because some zones are 30 or 45 minutes off UTC. The
+// tests use zones where this shows.
+//
+// Run tests with: go test -v
+
+// 1. Parsing with layouts
+// Go describes a format by writing out one fixed moment in it, Mon Jan
+// 2 15:04:05 MST 2006 (1 2 3 4 5 6 7: month, day, hour, minute, second,
+// year, zone), instead of %Y-%m-%d or "YYYY-MM-DD" as in JS libraries.
+//
+// ParseTimestamp accepts any of these, tried in order:
+//
+//	2024-03-05T14:30:00+09:00   time.RFC3339, with a zone
+//	2024-03-05 14:30:00         date and time, in UTC
+//	2024-03-05                  a date, at midnight UTC
+//
+// Anything else is an error that says "unrecognized time" and quotes s.
+func ParseTimestamp(s string) (time.Time, error) {
+	// TODO: time.Parse(layout, s) for each layout; time.DateTime and
+	// time.DateOnly are the other two
+	return time.Time{}, nil
+}
+
+// 2. Formatting with layouts
+// FormatHuman shows t as "Tue, Mar 5 2024 at 2:30 PM", in t's own
+// location.
+func FormatHuman(t time.Time) string {
+	// TODO: t.Format with a layout built from the reference moment
+	return ""
+}
+
+// 3. Durations
+// A time.Duration is an int64 count of nanoseconds; d.String() gives
+// "26h3m4s". FormatDuration gives "1d 2h 3m 4s" instead: d rounded to
+// the second, then days, hours, minutes and seconds, leaving out the
+// parts that are 0 ("2h 4s"). Zero is "0s", and a negative duration
+// gets a "-" in front.
+func FormatDuration(d time.Duration) string {
+	// TODO: d.Round(time.Second), then divide by 24 * time.Hour,
+	// time.Hour, ... taking the remainder each time
+	return ""
+}
+
+// 4. Time zones
+// InZone returns the instant t as seen in the IANA zone name, such as
+// "Asia/Tokyo". An unknown name is an error wrapping the one from
+// time.LoadLocation, as "load zone <name>: <err>".
+//
+// LoadLocation reads the zone database from the system. The tests
+// import time/tzdata, which embeds a copy in the binary, so they pass
+// on machines without one; a program can do the same.
+func InZone(t time.Time, name string) (time.Time, error) {
+	// TODO: time.LoadLocation, then t.In(loc)
+	return t, nil
+}
+
+// 5. Adding a day
+// SameTimeTomorrow returns the same wall clock time on the next day in
+// t's location: 9:00 stays 9:00, even across a change to or from
+// daylight saving time, when tomorrow is 23 or 25 hours away. t.Add(24
+// * time.Hour) adds exactly 24 hours, which isn't the same thing.
+func SameTimeTomorrow(t time.Time) time.Time {
+	// TODO: t.AddDate(years, months, days)
+	return t
+}
+
+// 6. Counting days
+// DaysBetween counts the calendar days from a to b in a's location: 1
+// from 23:59 one day to 00:01 the next, and 0 between times on the same
+// date, however many hours apart. Negative when b's date comes first.
+// Dividing b.Sub(a) by 24 hours gets it wrong both ways.
+func DaysBetween(a, b time.Time) int {
+	// TODO: take the year, month and day of each (b in a's location),
+	// build both dates at midnight UTC, where every day is 24 hours,
+	// and divide
+	return 0
+}
+
+// 7. The start of a day
+// StartOfDay returns midnight at the start of t's date, in t's
+// location. time.Date builds a time from its parts in any location.
+func StartOfDay(t time.Time) time.Time {
+	// TODO
+	return t
+}
+
+// 8. Truncating to the hour
+// StartOfHour returns the start of t's hour in t's location: 14:37 is
+// 14:00. t.Truncate(time.Hour) looks right and isn't: Truncate counts
+// from the zero time, in UTC, so in Kolkata, at +05:30, it gives 14:30.
+func StartOfHour(t time.Time) time.Time {
+	// TODO: time.Date again, or subtract the minutes, seconds and
+	// nanoseconds
+	return t
+}
+
+// 9. Rounding
+// RoundToQuarter rounds t to the nearest quarter hour, halves rounding
+// up: 14:07:30 is 14:15. Every zone in use is a whole number of
+// quarter hours off UTC, so here t.Round is right wherever t is.
+func RoundToQuarter(t time.Time) time.Time {
+	// TODO
+	return t
+}
+
+// 10. Next business day
+// NextBusinessDay returns the start of the first day after t's date, in
+// t's location, that is a Monday to Friday and not one of holidays. A
+// holiday counts by its date, year, month and day, whatever its time or
+// location.
+func NextBusinessDay(t time.Time, holidays []time.Time) time.Time {
+	// TODO: AddDate one day at a time from StartOfDay(t); t.Weekday()
+	return t
+}
+
+// Office has opening hours on business days in one location.
+type Office struct {
+	Loc         *time.Location
+	Open, Close int // hours of the day, 9 and 17 for 9:00 to 17:00
+	Holidays    []time.Time
+
+	// Now is the office's clock: time.Now unless a test sets another.
+	// A func field is the lightest way to inject one, when an interface
+	// like 12's Clock would have only one method.
+	Now func() time.Time
+}
+
+// NewOffice returns an Office open from 9 to 17 in loc, on the real
+// clock.
+func NewOffice(loc *time.Location) *Office {
+	return &Office{Loc: loc, Open: 9, Close: 17, Now: time.Now}
+}
+
+// 11. Reading an injected clock
+// IsOpen reports whether the office is open now: on a business day in
+// o.Loc, from o.Open o'clock until just before o.Close o'clock.
+func (o *Office) IsOpen() bool {
+	// TODO: o.Now().In(o.Loc)
+	return false
+}
+
+// 12. Putting it together
+// NextOpening returns when the office next opens, in o.Loc: now if it
+// is open, today at o.Open if that's still to come on a business day,
+// or else o.Open on the next business day.
+func (o *Office) NextOpening() time.Time {
+	// TODO
+	return time.Time{}
+}
+
+// Keep imports used
+var _ = fmt.Errorf
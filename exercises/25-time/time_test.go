@@ -0,0 +1,286 @@
+package timehandling
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+	_ "time/tzdata" // the zone database, for machines without one
+
+	"github.com/imgarylai/learn-go/internal/assert"
+)
+
+// In New York, 2024's clocks went forward an hour at 2:00 on March 10
+// and back at 2:00 on November 3.
+var (
+	newYork   = load("America/New_York")
+	tokyo     = load("Asia/Tokyo")
+	kolkata   = load("Asia/Kolkata")   // +05:30
+	kathmandu = load("Asia/Kathmandu") // +05:45
+)
+
+func load(name string) *time.Location {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		panic(err)
+	}
+	return loc
+}
+
+func at(loc *time.Location, y int, m time.Month, d, h, min int) time.Time {
+	return time.Date(y, m, d, h, min, 0, 0, loc)
+}
+
+// same fails unless got and want are the same instant in the same
+// location.
+func same(t *testing.T, got, want time.Time, what string) {
+	t.Helper()
+	if !got.Equal(want) || got.Location().String() != want.Location().String() {
+		t.Errorf("%s: got %v, want %v", what, got, want)
+	}
+}
+
+func TestParseTimestamp(t *testing.T) {
+	for _, tt := range []struct {
+		s      string
+		want   time.Time
+		offset int
+	}{
+		{"2024-03-05T14:30:00+09:00", at(time.UTC, 2024, 3, 5, 5, 30), 9 * 3600},
+		{"2024-03-05T14:30:00Z", at(time.UTC, 2024, 3, 5, 14, 30), 0},
+		{"2024-03-05 14:30:00", at(time.UTC, 2024, 3, 5, 14, 30), 0},
+		{"2024-03-05", at(time.UTC, 2024, 3, 5, 0, 0), 0},
+	} {
+		got, err := ParseTimestamp(tt.s)
+		if err != nil || !got.Equal(tt.want) {
+			t.Errorf("ParseTimestamp(%q) = %v, %v; want %v", tt.s, got, err, tt.want)
+			continue
+		}
+		_, offset := got.Zone()
+		assert.Equal(t, offset, tt.offset, "ParseTimestamp(%q): offset", tt.s)
+	}
+
+	for _, s := range []string{"", "05/03/2024", "2024-13-01", "2024-03-05T14:30:00", "2024-03-05 14:30", "yesterday"} {
+		_, err := ParseTimestamp(s)
+		if err == nil {
+			t.Errorf("ParseTimestamp(%q) gave no error", s)
+			continue
+		}
+		if want := `unrecognized time "` + s + `"`; !strings.Contains(err.Error(), want) {
+			t.Errorf("ParseTimestamp(%q): error %q doesn't say %s", s, err, want)
+		}
+	}
+}
+
+func TestFormatHuman(t *testing.T) {
+	assert.Equal(t, FormatHuman(at(time.UTC, 2024, 3, 5, 14, 30)), "Tue, Mar 5 2024 at 2:30 PM", "afternoon")
+	assert.Equal(t, FormatHuman(at(tokyo, 2024, 12, 25, 0, 5)), "Wed, Dec 25 2024 at 12:05 AM", "just after midnight")
+	assert.Equal(t, FormatHuman(at(time.UTC, 2024, 3, 5, 20, 0).In(tokyo)), "Wed, Mar 6 2024 at 5:00 AM", "in t's location")
+	assert.Equal(t, FormatHuman(at(time.UTC, 2024, 11, 10, 12, 0)), "Sun, Nov 10 2024 at 12:00 PM", "noon")
+}
+
+func TestFormatDuration(t *testing.T) {
+	for _, tt := range []struct {
+		d    time.Duration
+		want string
+	}{
+		{0, "0s"},
+		{400 * time.Millisecond, "0s"},
+		{1500 * time.Millisecond, "2s"},
+		{45 * time.Second, "45s"},
+		{90 * time.Second, "1m 30s"},
+		{time.Hour, "1h"},
+		{2*time.Hour + 4*time.Second, "2h 4s"},
+		{26*time.Hour + 3*time.Minute + 4*time.Second, "1d 2h 3m 4s"},
+		{48 * time.Hour, "2d"},
+		{-90 * time.Second, "-1m 30s"},
+		{-1500 * time.Millisecond, "-2s"},
+	} {
+		assert.Equal(t, FormatDuration(tt.d), tt.want, "FormatDuration(%v)", tt.d)
+	}
+}
+
+func TestInZone(t *testing.T) {
+	newYear := at(time.UTC, 2024, 1, 1, 0, 0)
+	for _, tt := range []struct {
+		zone      string
+		hour, min int
+	}{
+		{"Asia/Tokyo", 9, 0},
+		{"Asia/Kolkata", 5, 30},
+		{"America/New_York", 19, 0},
+		{"UTC", 0, 0},
+	} {
+		got, err := InZone(newYear, tt.zone)
+		if err != nil {
+			t.Errorf("InZone(%s): %v", tt.zone, err)
+			continue
+		}
+		if !got.Equal(newYear) {
+			t.Errorf("InZone(%s) = %v, a different instant from %v", tt.zone, got, newYear)
+		}
+		assert.Equal(t, got.Location().String(), tt.zone, "InZone(%s): location", tt.zone)
+		assert.Equal(t, [2]int{got.Hour(), got.Minute()}, [2]int{tt.hour, tt.min}, "InZone(%s): wall clock", tt.zone)
+	}
+
+	_, err := InZone(newYear, "Mars/Olympus_Mons")
+	if err == nil {
+		t.Fatal("an unknown zone gave no error")
+	}
+	if !strings.HasPrefix(err.Error(), "load zone Mars/Olympus_Mons: ") || errors.Unwrap(err) == nil {
+		t.Errorf("got %q, want LoadLocation's error wrapped as \"load zone Mars/Olympus_Mons: ...\"", err)
+	}
+}
+
+func TestSameTimeTomorrow(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		t    time.Time
+		want time.Time
+		gap  time.Duration
+	}{
+		{"ordinary day", at(newYork, 2024, 3, 5, 9, 0), at(newYork, 2024, 3, 6, 9, 0), 24 * time.Hour},
+		{"clocks go forward", at(newYork, 2024, 3, 9, 9, 0), at(newYork, 2024, 3, 10, 9, 0), 23 * time.Hour},
+		{"clocks go back", at(newYork, 2024, 11, 2, 9, 0), at(newYork, 2024, 11, 3, 9, 0), 25 * time.Hour},
+		{"end of month", at(time.UTC, 2024, 2, 29, 23, 30), at(time.UTC, 2024, 3, 1, 23, 30), 24 * time.Hour},
+	} {
+		got := SameTimeTomorrow(tt.t)
+		same(t, got, tt.want, tt.name)
+		assert.Equal(t, got.Sub(tt.t), tt.gap, "%s: hours later", tt.name)
+	}
+}
+
+func TestDaysBetween(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		a, b time.Time
+		want int
+	}{
+		{"a minute apart, over midnight", at(newYork, 2024, 3, 5, 23, 59), at(newYork, 2024, 3, 6, 0, 1), 1},
+		{"same date", at(newYork, 2024, 3, 5, 0, 1), at(newYork, 2024, 3, 5, 23, 59), 0},
+		{"47 hours, clocks going forward", at(newYork, 2024, 3, 9, 0, 0), at(newYork, 2024, 3, 11, 0, 0), 2},
+		{"25 hours, clocks going back", at(newYork, 2024, 11, 3, 0, 0), at(newYork, 2024, 11, 4, 0, 0), 1},
+		{"b first", at(newYork, 2024, 3, 6, 0, 1), at(newYork, 2024, 3, 5, 23, 59), -1},
+		{"leap year", at(time.UTC, 2024, 2, 28, 12, 0), at(time.UTC, 2024, 3, 1, 12, 0), 2},
+		{"new year", at(time.UTC, 2024, 12, 31, 23, 0), at(time.UTC, 2025, 1, 1, 1, 0), 1},
+		// 21:00 in New York is already the next day in UTC.
+		{"b in another zone", at(newYork, 2024, 1, 1, 20, 0), at(time.UTC, 2024, 1, 2, 2, 0), 0},
+		{"a year", at(time.UTC, 2023, 6, 1, 0, 0), at(time.UTC, 2024, 6, 1, 0, 0), 366},
+	} {
+		assert.Equal(t, DaysBetween(tt.a, tt.b), tt.want, tt.name)
+	}
+}
+
+func TestStartOfDay(t *testing.T) {
+	same(t, StartOfDay(at(newYork, 2024, 3, 10, 15, 45)), at(newYork, 2024, 3, 10, 0, 0), "day the clocks go forward")
+	same(t, StartOfDay(at(kolkata, 2024, 3, 5, 3, 0)), at(kolkata, 2024, 3, 5, 0, 0), "Kolkata")
+	same(t, StartOfDay(time.Date(2024, 3, 5, 23, 59, 59, 999, time.UTC)), at(time.UTC, 2024, 3, 5, 0, 0), "last nanosecond")
+}
+
+func TestStartOfHour(t *testing.T) {
+	same(t, StartOfHour(time.Date(2024, 3, 5, 14, 37, 12, 500, kolkata)), at(kolkata, 2024, 3, 5, 14, 0), "Kolkata")
+	same(t, StartOfHour(at(kathmandu, 2024, 3, 5, 10, 20)), at(kathmandu, 2024, 3, 5, 10, 0), "Kathmandu")
+	same(t, StartOfHour(time.Date(2024, 3, 5, 14, 59, 59, 0, time.UTC)), at(time.UTC, 2024, 3, 5, 14, 0), "UTC")
+	same(t, StartOfHour(at(newYork, 2024, 3, 5, 9, 0)), at(newYork, 2024, 3, 5, 9, 0), "on the hour")
+}
+
+func TestRoundToQuarter(t *testing.T) {
+	sec := func(loc *time.Location, h, m, s int) time.Time { return time.Date(2024, 3, 5, h, m, s, 0, loc) }
+	same(t, RoundToQuarter(sec(time.UTC, 14, 7, 29)), sec(time.UTC, 14, 0, 0), "down")
+	same(t, RoundToQuarter(sec(time.UTC, 14, 7, 30)), sec(time.UTC, 14, 15, 0), "half way")
+	same(t, RoundToQuarter(sec(time.UTC, 14, 52, 30)), sec(time.UTC, 15, 0, 0), "into the next hour")
+	same(t, RoundToQuarter(sec(kathmandu, 10, 8, 0)), sec(kathmandu, 10, 15, 0), "Kathmandu")
+	same(t, RoundToQuarter(sec(kolkata, 10, 22, 29)), sec(kolkata, 10, 15, 0), "Kolkata")
+}
+
+func TestNextBusinessDay(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		t        time.Time
+		holidays []time.Time
+		want     time.Time
+	}{
+		{"Thursday", at(newYork, 2024, 3, 7, 15, 0), nil, at(newYork, 2024, 3, 8, 0, 0)},
+		{"Friday", at(newYork, 2024, 3, 8, 15, 0), nil, at(newYork, 2024, 3, 11, 0, 0)},
+		{"Saturday", at(newYork, 2024, 3, 9, 1, 0), nil, at(newYork, 2024, 3, 11, 0, 0)},
+		{"Sunday, late", at(newYork, 2024, 3, 10, 23, 59), nil, at(newYork, 2024, 3, 11, 0, 0)},
+		{"Monday holiday", at(newYork, 2024, 3, 8, 15, 0), []time.Time{at(time.UTC, 2024, 3, 11, 0, 0)}, at(newYork, 2024, 3, 12, 0, 0)},
+		{"holidays in a row", at(time.UTC, 2024, 12, 23, 10, 0), []time.Time{
+			at(time.UTC, 2024, 12, 25, 0, 0),
+			at(time.UTC, 2024, 12, 24, 0, 0),
+			at(time.UTC, 2025, 1, 1, 0, 0),
+		}, at(time.UTC, 2024, 12, 26, 0, 0)},
+		// At 1:00 on the 24th in Tokyo it's still the 23rd in New York,
+		// but the holiday is the 24th.
+		{"holiday by its own date", at(newYork, 2024, 12, 23, 10, 0), []time.Time{at(tokyo, 2024, 12, 24, 1, 0)}, at(newYork, 2024, 12, 25, 0, 0)},
+		{"in t's location", at(time.UTC, 2024, 3, 9, 2, 0).In(newYork), nil, at(newYork, 2024, 3, 11, 0, 0)},
+	} {
+		same(t, NextBusinessDay(tt.t, tt.holidays), tt.want, tt.name)
+	}
+}
+
+// office is a New York office with the clock stopped at now.
+func office(now time.Time, holidays ...time.Time) *Office {
+	o := NewOffice(newYork)
+	o.Holidays = holidays
+	o.Now = func() time.Time { return now }
+	return o
+}
+
+func TestNewOffice(t *testing.T) {
+	o := NewOffice(tokyo)
+	if o.Loc != tokyo || o.Open != 9 || o.Close != 17 || o.Now == nil {
+		t.Fatalf("NewOffice(tokyo) = %+v", o)
+	}
+	if d := time.Since(o.Now()); d < 0 || d > time.Minute {
+		t.Errorf("Now isn't the real clock: it says %v", o.Now())
+	}
+}
+
+func TestIsOpen(t *testing.T) {
+	holiday := at(time.UTC, 2024, 3, 6, 0, 0)
+	for _, tt := range []struct {
+		name string
+		now  time.Time
+		want bool
+	}{
+		{"Tuesday morning", at(newYork, 2024, 3, 5, 10, 0), true},
+		{"opening time", at(newYork, 2024, 3, 5, 9, 0), true},
+		{"a minute before", at(newYork, 2024, 3, 5, 8, 59), false},
+		{"last second", time.Date(2024, 3, 5, 16, 59, 59, 0, newYork), true},
+		{"closing time", at(newYork, 2024, 3, 5, 17, 0), false},
+		{"Saturday", at(newYork, 2024, 3, 9, 10, 0), false},
+		{"Sunday", at(newYork, 2024, 3, 10, 10, 0), false},
+		{"holiday", at(newYork, 2024, 3, 6, 10, 0), false},
+		{"clock in UTC, 9:00 in New York", at(time.UTC, 2024, 3, 5, 14, 0), true},
+		{"clock in UTC, 17:30 in New York", at(time.UTC, 2024, 3, 5, 22, 30), false},
+		{"clock in UTC, Saturday in New York", at(time.UTC, 2024, 3, 9, 3, 0), false},
+	} {
+		assert.Equal(t, office(tt.now, holiday).IsOpen(), tt.want, tt.name)
+	}
+}
+
+func TestNextOpening(t *testing.T) {
+	holiday := at(time.UTC, 2024, 3, 6, 0, 0)
+	for _, tt := range []struct {
+		name string
+		now  time.Time
+		want time.Time
+	}{
+		{"open", at(newYork, 2024, 3, 5, 10, 30), at(newYork, 2024, 3, 5, 10, 30)},
+		{"early", at(newYork, 2024, 3, 5, 7, 0), at(newYork, 2024, 3, 5, 9, 0)},
+		{"after closing", at(newYork, 2024, 3, 4, 18, 0), at(newYork, 2024, 3, 5, 9, 0)},
+		{"before a holiday", at(newYork, 2024, 3, 5, 18, 0), at(newYork, 2024, 3, 7, 9, 0)},
+		{"early on a holiday", at(newYork, 2024, 3, 6, 7, 0), at(newYork, 2024, 3, 7, 9, 0)},
+		{"Friday evening", at(newYork, 2024, 3, 8, 18, 0), at(newYork, 2024, 3, 11, 9, 0)},
+		{"Saturday early", at(newYork, 2024, 3, 9, 7, 0), at(newYork, 2024, 3, 11, 9, 0)},
+		{"clock in UTC", at(time.UTC, 2024, 3, 5, 12, 0), at(newYork, 2024, 3, 5, 9, 0)},
+	} {
+		same(t, office(tt.now, holiday).NextOpening(), tt.want, tt.name)
+	}
+
+	// The weekend the clocks go forward: Monday 9:00 is 13:00 UTC, not
+	// 14:00 as it was on Friday.
+	got := office(at(newYork, 2024, 3, 8, 18, 0)).NextOpening()
+	same(t, got.UTC(), at(time.UTC, 2024, 3, 11, 13, 0), "after the clocks change")
+}
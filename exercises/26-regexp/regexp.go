@@ -0,0 +1,220 @@
+//go:build !solutions
+
+package regexps
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Exercise 26: Regular expressions
+//
+// The regexp package is RE2: the syntax JS and Perl use, minus the
+// features that can take exponential time, chiefly backreferences (\1)
+// and lookaround ((?=...), (?<=...)). In exchange, a match always runs
+// in time linear in the input, so a pattern can't be made to hang on a
+// crafted string.
+//
+// Compiling is the slow part, so compile a pattern once and reuse it;
+// a *Regexp is safe for concurrent use. Here each pattern is a func
+// returning it compiled, with regexp.MustCompile, and a package
+// variable that calls the func once, when the package is initialized.
+// Fill in the funcs and use the variables. Write patterns in `raw
+// strings`, where \d needs no second backslash.
+//
+// Run tests with: go test -v
+
+// 1. Named capture groups
+// LogPattern matches one line of a log in this format:
+//
+//	2024-03-05T14:30:00Z ERROR [api] connection refused
+//
+// with these named groups, (?P<name>...), that ParseLogLine reads:
+//
+//   - time: an RFC 3339 timestamp: date, "T", time with optional
+//     fractional seconds, then "Z" or an offset like +09:00
+//   - level: DEBUG, INFO, WARN or ERROR, in any case
+//   - component: a name in square brackets, [api], which may be left
+//     out; letters, digits, "_", "." and "-"
+//   - msg: the rest of the line, which isn't empty
+//
+// Fields are separated by any run of spaces or tabs, and space at
+// either end of the line, "\r" included, is ignored. The pattern has
+// to match the whole line: use ^ and $.
+func LogPattern() *regexp.Regexp {
+	// TODO: (?i:...) makes part of a pattern case-insensitive, and .*?
+	// is a lazy .*
+	return regexp.MustCompile(`^$`)
+}
+
+var logLine = LogPattern()
+
+// LogEntry is one parsed log line.
+type LogEntry struct {
+	Time      string
+	Level     string // upper case
+	Component string // "" when the line has none
+	Message   string
+}
+
+// 2. Reading the groups
+// ParseLogLine parses line with logLine and reports whether it
+// matched. FindStringSubmatch returns the whole match and then each
+// group by number; logLine.SubexpIndex("level") is the number of a
+// named one. A group that took no part in the match comes back "".
+// In JS: line.match(re)?.groups
+func ParseLogLine(line string) (LogEntry, bool) {
+	// TODO
+	return LogEntry{}, false
+}
+
+// 3. A whole log
+// ParseLog parses every line of text. Blank lines are skipped; lines
+// that don't parse are left out of entries and their line numbers,
+// counting from 1, are returned in bad.
+func ParseLog(text string) (entries []LogEntry, bad []int) {
+	// TODO: strings.Split(text, "\n")
+	return nil, nil
+}
+
+// 4. Validating with anchors
+// IsHexColor reports whether s is a CSS hex color: "#" and then 3 or 6
+// hex digits, in either case. MatchString finds a match anywhere in s,
+// so without ^ and $ "#fff;drop table" would pass.
+func IsHexColor(s string) bool {
+	// TODO: hexColor.MatchString(s)
+	return false
+}
+
+var hexColor = hexColorPattern()
+
+func hexColorPattern() *regexp.Regexp {
+	// TODO
+	return regexp.MustCompile(`^$`)
+}
+
+// 5. Alternation and repetition
+// IsSemver reports whether s is a semantic version: an optional "v",
+// then MAJOR.MINOR.PATCH, each 0 or a number with no leading zero, and
+// optionally "-" and a pre-release of letters, digits, "." and "-":
+// "1.2.3", "v0.10.0", "2.0.0-rc.1". Not "1.2", "01.2.3" or "1.2.3.4".
+func IsSemver(s string) bool {
+	// TODO
+	return false
+}
+
+var semver = semverPattern()
+
+func semverPattern() *regexp.Regexp {
+	// TODO: (0|[1-9]\d*) is a number without leading zeros
+	return regexp.MustCompile(`^$`)
+}
+
+// 6. Working without lookbehind
+// ExtractHashtags returns the hashtags in text, lowercased, without
+// the "#", each once, in the order they first appear. A hashtag is "#"
+// and one or more letters, digits or "_", at the start of text or
+// after white space; so not the "#" in "C#", "page#top" or "&#39;". In
+// JS you could write (?<=^|\s)#(\w+); RE2 has no lookbehind, so match
+// the space too and take the group.
+func ExtractHashtags(text string) []string {
+	// TODO: hashtag.FindAllStringSubmatch(text, -1), then m[1] of each
+	return nil
+}
+
+var hashtag = hashtagPattern()
+
+func hashtagPattern() *regexp.Regexp {
+	// TODO: \w is ASCII only in RE2; [\p{L}\p{N}_] takes any letter or
+	// digit
+	return regexp.MustCompile(`^$`)
+}
+
+// 7. Replacing with a func
+// Redact hides the emails and card numbers in text. An email is one or
+// more of letters, digits and ".", "_", "%", "+" or "-", then "@", then
+// a domain: dot-separated labels of letters, digits and "-", ending in
+// a label of two or more letters. Letters means any script's, \p{L},
+// not just a-z. An email keeps its first character, which may be more
+// than one byte, and its domain: "ada@example.com" becomes
+// "a***@example.com". A card number is
+// 13 to 16 digits, possibly in groups split by single spaces or dashes,
+// with no digit right before or after; it becomes "****" and its last
+// four digits: "****1111".
+// In JS: text.replace(re, m => ...)
+func Redact(text string) string {
+	// TODO: ReplaceAllStringFunc, once with each pattern
+	return text
+}
+
+var (
+	email = emailPattern()
+	card  = cardPattern()
+)
+
+func emailPattern() *regexp.Regexp {
+	// TODO
+	return regexp.MustCompile(`^$`)
+}
+
+func cardPattern() *regexp.Regexp {
+	// TODO: match a whole run of digits with single spaces or dashes
+	// between them, which can't have a digit on either side, and count
+	// its digits in the func: without lookaround that's simpler than
+	// saying 13 to 16 in the pattern
+	return regexp.MustCompile(`^$`)
+}
+
+// 8. Replacing with group references
+// ReformatDates rewrites every US date in text, month/day/year like
+// 3/5/2024 or 03/05/2024, as ISO 8601: 2024-03-05, with two-digit month
+// and day. A date is 1 or 2 digits, "/", 1 or 2 digits, "/" and 4
+// digits, with no letter or digit right before or after it (\b, a word
+// boundary, says that); leave it alone when the month is above 12 or
+// the day above 31.
+func ReformatDates(text string) string {
+	// TODO: ReplaceAllString can refer to groups as ${1} or ${name}, but
+	// can't pad "3" to "03"; ReplaceAllStringFunc with
+	// usDate.FindStringSubmatch can. (In a template, $1x means the group
+	// named "1x": write ${1}x.)
+	return text
+}
+
+var usDate = usDatePattern()
+
+func usDatePattern() *regexp.Regexp {
+	// TODO
+	return regexp.MustCompile(`^$`)
+}
+
+// 9. Splitting on a pattern
+// SplitList splits a list whose items are separated by commas,
+// semicolons, pipes or line breaks, with any space around them, and
+// drops the empty items: "a, b;;c |d\n" is ["a" "b" "c" "d"].
+// strings.Split only splits on one fixed string.
+func SplitList(s string) []string {
+	// TODO: listSeparator.Split(s, -1)
+	return nil
+}
+
+var listSeparator = listSeparatorPattern()
+
+func listSeparatorPattern() *regexp.Regexp {
+	// TODO
+	return regexp.MustCompile(`^$`)
+}
+
+// 10. Building a pattern from input
+// Highlight wraps every occurrence of term in text in "**", ignoring
+// case and keeping the text's own spelling: Highlight("Go and go",
+// "GO") is "**Go** and **go**". term is plain text, not a pattern:
+// "c++" or "1.5" must match only themselves. An empty term changes
+// nothing. This pattern depends on the input, so it's compiled on
+// every call.
+func Highlight(text, term string) string {
+	// TODO: regexp.QuoteMeta, (?i), and ${0} for the whole match
+	return text
+}
+
+// Keep imports used
+var _ = strings.TrimSpace
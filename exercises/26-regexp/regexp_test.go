@@ -0,0 +1,200 @@
+package regexps
+
+import (
+	"os"
+	"testing"
+
+	"github.com/imgarylai/learn-go/internal/assert"
+)
+
+func readTestdata(t *testing.T, name string) string {
+	t.Helper()
+	b, err := os.ReadFile("testdata/" + name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
+
+func TestLogPattern(t *testing.T) {
+	re := LogPattern()
+	for _, name := range []string{"time", "level", "component", "msg"} {
+		if re.SubexpIndex(name) < 0 {
+			t.Errorf("LogPattern has no group named %q; its groups are %q", name, re.SubexpNames()[1:])
+		}
+	}
+	// Anchored: nothing may come before the timestamp but space.
+	if re.MatchString("x 2024-03-05T14:30:00Z INFO hello") {
+		t.Error("LogPattern matches a line with text before the timestamp: anchor it with ^")
+	}
+}
+
+func TestParseLogLine(t *testing.T) {
+	for _, tt := range []struct {
+		line string
+		want LogEntry
+	}{
+		{"2024-03-05T14:30:00Z INFO [api] server started", LogEntry{"2024-03-05T14:30:00Z", "INFO", "api", "server started"}},
+		{"2024-03-05T14:30:00.123456+09:00 error [db.pool-2] timeout", LogEntry{"2024-03-05T14:30:00.123456+09:00", "ERROR", "db.pool-2", "timeout"}},
+		{"  2024-03-05T14:30:00Z\tWarn\t\tdisk 91% full \r", LogEntry{"2024-03-05T14:30:00Z", "WARN", "", "disk 91% full"}},
+		{"2024-03-05T14:30:00-05:00 DEBUG [a_b] x", LogEntry{"2024-03-05T14:30:00-05:00", "DEBUG", "a_b", "x"}},
+		{"2024-03-05T14:30:00Z INFO [api] [nested] brackets [stay]", LogEntry{"2024-03-05T14:30:00Z", "INFO", "api", "[nested] brackets [stay]"}},
+	} {
+		got, ok := ParseLogLine(tt.line)
+		if !ok {
+			t.Errorf("ParseLogLine(%q) didn't match", tt.line)
+			continue
+		}
+		assert.Equal(t, got, tt.want, "ParseLogLine(%q)", tt.line)
+	}
+
+	for _, line := range []string{
+		"",
+		"2024-03-05T14:30:00Z INFO",
+		"2024-03-05T14:30:00Z INFO   ",
+		"2024-03-05T14:30:00Z NOTICE [api] unknown level",
+		"2024-03-05T14:30:00Z INFORMATION [api] level with more after it",
+		"2024-03-05T14:30:00ZINFO [api] no space",
+		"2024-03-05 14:30:00 INFO [api] no T",
+		"2024-03-05T14:30:00 INFO [api] no zone",
+		"2024-03-05T14:30:00+0900 INFO [api] offset without a colon",
+		"24-03-05T14:30:00Z INFO [api] two-digit year",
+		"2024-03-05T14:30:00Z\nINFO [api] split over lines",
+	} {
+		if e, ok := ParseLogLine(line); ok {
+			t.Errorf("ParseLogLine(%q) matched: %+v", line, e)
+		}
+	}
+}
+
+func TestParseLog(t *testing.T) {
+	entries, bad := ParseLog(readTestdata(t, "app.log"))
+	assert.Equal(t, entries, []LogEntry{
+		{"2024-03-05T14:30:00Z", "INFO", "api", "server listening on :8080"},
+		{"2024-03-05T14:30:01.123Z", "DEBUG", "db.pool", "opened 4 connections"},
+		{"2024-03-05T14:31:12+09:00", "WARN", "cache", "hit rate 41% (below 50%)"},
+		{"2024-03-05T14:32:00Z", "ERROR", "api", "request failed: GET /users/7: connection refused"},
+		{"2024-03-05T14:32:00Z", "ERROR", "", "no component here, just a message"},
+		{"2024-03-05T14:35:00-05:00", "INFO", "worker-2", "job 12 done in 3.2s ✓"},
+		{"2024-03-05T14:38:00.5+05:30", "DEBUG", "metrics_v2", "flushed 1,024 points to https://example.com/ingest?x=[1]"},
+	}, "entries")
+	assert.Equal(t, bad, []int{6, 7, 10, 11, 13, 15}, "bad lines")
+
+	entries, bad = ParseLog("")
+	if len(entries) != 0 || len(bad) != 0 {
+		t.Errorf("ParseLog(\"\") = %v, %v; want nothing", entries, bad)
+	}
+}
+
+func TestIsHexColor(t *testing.T) {
+	for _, s := range []string{"#fff", "#FFF", "#1a2B3c", "#000000", "#abc"} {
+		assert.Equal(t, IsHexColor(s), true, "IsHexColor(%q)", s)
+	}
+	for _, s := range []string{"", "#", "fff", "#ff", "#ffff", "#fffff", "#fffffff", "#ggg", "#fff;drop table", "x#fff", "#fff\n", " #fff", "#ff ff"} {
+		assert.Equal(t, IsHexColor(s), false, "IsHexColor(%q)", s)
+	}
+}
+
+func TestIsSemver(t *testing.T) {
+	for _, s := range []string{"1.2.3", "v0.10.0", "0.0.0", "2.0.0-rc.1", "10.20.30", "1.0.0-alpha-beta.2", "v1.2.3-0"} {
+		assert.Equal(t, IsSemver(s), true, "IsSemver(%q)", s)
+	}
+	for _, s := range []string{"", "1.2", "1.2.3.4", "01.2.3", "1.02.3", "1.2.03", "V1.2.3", "vv1.2.3", "1.2.3-", "1.2.3-rc_1", "1.2.3 ", "version 1.2.3", "1.2.x", "-1.2.3"} {
+		assert.Equal(t, IsSemver(s), false, "IsSemver(%q)", s)
+	}
+}
+
+func TestExtractHashtags(t *testing.T) {
+	for _, tt := range []struct {
+		text string
+		want []string
+	}{
+		{"#Go is fun #golang #go", []string{"go", "golang"}},
+		{"C# and F# aren't tags, nor is page#top or &#39;", nil},
+		{"tabs\t#one\nand lines\n#two", []string{"one", "two"}},
+		{"#café au lait #日本", []string{"café", "日本"}},
+		{"#snake_case #kebab-case #v2", []string{"snake_case", "kebab", "v2"}},
+		{"## #", nil},
+		{"#a#b #c", []string{"a", "c"}},
+		{"", nil},
+	} {
+		got := ExtractHashtags(tt.text)
+		if len(got) != 0 || len(tt.want) != 0 {
+			assert.Equal(t, got, tt.want, "ExtractHashtags(%q)", tt.text)
+		}
+	}
+	assert.Equal(t, ExtractHashtags(readTestdata(t, "tickets.txt")), []string{"4411", "billing", "refund", "4412", "docs", "bug"}, "tickets.txt")
+}
+
+func TestRedact(t *testing.T) {
+	for _, tt := range []struct{ text, want string }{
+		{"mail ada@example.com now", "mail a***@example.com now"},
+		{"<first.last+tag@mail.example.org>", "<f***@mail.example.org>"},
+		{"émile@exemple.fr", "é***@exemple.fr"},
+		{"a@b.co, c@d.museum", "a***@b.co, c***@d.museum"},
+		{"x@y.z and root@localhost", "x@y.z and root@localhost"},
+		{"card 4111111111111111.", "card ****1111."},
+		{"card 4111 1111 1111 1111 ok", "card ****1111 ok"},
+		{"card 4111-1111-1111-1111", "card ****1111"},
+		{"amex 3782 822463 10005", "amex ****0005"},
+		{"13 digits 4222222222222", "13 digits ****2222"},
+		{"12 digits 422222222222", "12 digits 422222222222"},
+		{"17 digits 42222222222222222", "17 digits 42222222222222222"},
+		{"phone 555-123-4567", "phone 555-123-4567"},
+		{"double space 4111  1111 1111 1111", "double space 4111  1111 1111 1111"},
+		{"", ""},
+	} {
+		assert.Equal(t, Redact(tt.text), tt.want, "Redact(%q)", tt.text)
+	}
+}
+
+func TestReformatDates(t *testing.T) {
+	for _, tt := range []struct{ text, want string }{
+		{"due 3/5/2024", "due 2024-03-05"},
+		{"from 03/05/2024 to 12/31/2024.", "from 2024-03-05 to 2024-12-31."},
+		{"13/01/2024 and 1/32/2024 stay", "13/01/2024 and 1/32/2024 stay"},
+		{"exp 09/27, id 123/4/2024, 1/2/20245", "exp 09/27, id 123/4/2024, 1/2/20245"},
+		{"(1/1/2000)", "(2000-01-01)"},
+		{"", ""},
+	} {
+		assert.Equal(t, ReformatDates(tt.text), tt.want, "ReformatDates(%q)", tt.text)
+	}
+}
+
+func TestTickets(t *testing.T) {
+	got := Redact(ReformatDates(readTestdata(t, "tickets.txt")))
+	assert.Equal(t, got, readTestdata(t, "tickets.golden"), "tickets.txt, dates reformatted and redacted")
+}
+
+func TestSplitList(t *testing.T) {
+	for _, tt := range []struct {
+		s    string
+		want []string
+	}{
+		{"a, b;;c |d\n", []string{"a", "b", "c", "d"}},
+		{"  go , rust\r\nzig|  ", []string{"go", "rust", "zig"}},
+		{"new york, los angeles", []string{"new york", "los angeles"}},
+		{"one", []string{"one"}},
+	} {
+		assert.Equal(t, SplitList(tt.s), tt.want, "SplitList(%q)", tt.s)
+	}
+	for _, s := range []string{"", " ", ",;|", " , \n ; "} {
+		if got := SplitList(s); len(got) != 0 {
+			t.Errorf("SplitList(%q) = %q, want no items", s, got)
+		}
+	}
+}
+
+func TestHighlight(t *testing.T) {
+	for _, tt := range []struct{ text, term, want string }{
+		{"Go and go and GO", "go", "**Go** and **go** and **GO**"},
+		{"I like c++ and c", "C++", "I like **c++** and c"},
+		{"v1.5 not 1x5", "1.5", "v**1.5** not 1x5"},
+		{"costs $5 (or $50)", "$5", "costs **$5** (or **$5**0)"},
+		{"[a] and a", "[a]", "**[a]** and a"},
+		{"nothing here", "zzz", "nothing here"},
+		{"unchanged", "", "unchanged"},
+	} {
+		assert.Equal(t, Highlight(tt.text, tt.term), tt.want, "Highlight(%q, %q)", tt.text, tt.term)
+	}
+}
@@ -0,0 +1,210 @@
+//go:build !solutions
+
+package iocompose
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Exercise 27: Composing io.Reader and io.Writer
+//
+// Exercise 5 implemented the two interfaces; exercise 7 used files
+// through them. This one is about the part in between: the contract a
+// Read or Write call makes, and the small adapters in the io package
+// that plug readers and writers together, the way Node streams are
+// piped through Transform streams. Each adapter is itself a Reader or a
+// Writer, so they nest:
+//
+//	io.Copy(io.MultiWriter(file, hash), io.LimitReader(body, 1<<20))
+//
+// The contract, in short: Read may return fewer bytes than asked for,
+// and may return n > 0 together with an error, so handle the n bytes
+// before looking at the error; io.EOF is how a reader says it's done,
+// not a failure. Write returns a non-nil error whenever n < len(p).
+//
+// Run tests with: go test -v
+
+// LineCounter is an io.Writer that counts the lines written to it, like
+// piping into `wc -l`, except that a last line with no "\n" counts too.
+type LineCounter struct {
+	lines int  // "\n"s seen
+	open  bool // whether bytes have come since the last "\n"
+}
+
+// 1. A writer that keeps state between calls
+// Write may be called with any chunks: "a\nb" and then "c\n" is two
+// lines, and so is "a" then "\n" then "bc\n".
+func (c *LineCounter) Write(p []byte) (int, error) {
+	// TODO: count the "\n"s in p, and remember in c.open whether p
+	// ends partway through a line
+	return 0, nil
+}
+
+// Lines is how many lines have been written: every "\n", plus one if
+// the data so far doesn't end with one.
+func (c *LineCounter) Lines() int {
+	// TODO
+	return 0
+}
+
+// 2. The Read contract
+// CopyInChunks copies src to dst by hand, reading into a buffer of size
+// bytes and writing each chunk it reads, and returns how many bytes
+// were written. That's what io.Copy does, so don't use it, or
+// io.CopyBuffer: they hand the job to src's WriteTo method when it has
+// one, and then your buffer is never used.
+//
+// A Read that returns n > 0 and an error still read n bytes: write them
+// first. io.EOF ends the copy without an error; any other error from
+// either side is returned. A Write that writes less than it was given
+// without an error breaks the contract: return io.ErrShortWrite.
+func CopyInChunks(dst io.Writer, src io.Reader, size int) (int64, error) {
+	// TODO: buf := make([]byte, size), then loop on src.Read(buf)
+	return 0, nil
+}
+
+// upperReader is the reader NewUpperReader returns.
+type upperReader struct {
+	r       io.Reader
+	buf     []byte // what Read reads r into
+	partial []byte // the start of a rune cut off by the end of a read
+	out     []byte // converted, waiting for Read to hand it out
+	err     error  // r's error, returned once out is empty
+}
+
+// 3. A reader that transforms its input
+// NewUpperReader returns a reader that reads r and upper-cases it. Unlike
+// exercise 5's UppercaseReader, it handles all of UTF-8, not just ASCII,
+// which brings two problems:
+//
+//   - a read can end in the middle of a rune: "é" is 2 bytes, and
+//     r may return the first one now and the second one next time
+//   - a rune and its upper case can be different lengths: "ɐ" is 2
+//     bytes and "Ɐ" is 3, so the result doesn't always fit in p
+//
+// So keep the bytes of an unfinished rune in partial until the rest
+// arrive, and the converted bytes that didn't fit in out for the next
+// Read. Bytes that aren't valid UTF-8, an unfinished rune at the end of
+// r included, come out as U+FFFD, the way bytes.ToUpper does it: the
+// output is always what bytes.ToUpper makes of all of r at once.
+func NewUpperReader(r io.Reader) io.Reader {
+	return &upperReader{r: r, buf: make([]byte, 512)}
+}
+
+func (u *upperReader) Read(p []byte) (int, error) {
+	// TODO: until there's something in u.out or an error: read, put
+	// any unfinished rune at the end aside (utf8.RuneStart and
+	// utf8.FullRune tell where one starts and whether it's complete),
+	// and bytes.ToUpper the rest. Then copy from u.out into p.
+	return 0, io.EOF
+}
+
+// prefixWriter is the writer NewPrefixWriter returns.
+type prefixWriter struct {
+	w         io.Writer
+	prefix    []byte
+	lineStart bool // whether the next byte starts a line
+}
+
+// 4. A writer that transforms its output
+// NewPrefixWriter returns a writer that writes to w with prefix at the
+// start of every line, the way `sed 's/^/> /'` quotes text. A line
+// starts with the first byte written and after every "\n"; the prefix
+// goes out only once the line has a byte of its own, so "a\n" writes
+// "> a\n" and not "> a\n> ".
+//
+// Write returns how many bytes of p were written, not counting
+// prefixes.
+func NewPrefixWriter(w io.Writer, prefix string) io.Writer {
+	return &prefixWriter{w: w, prefix: []byte(prefix), lineStart: true}
+}
+
+func (pw *prefixWriter) Write(p []byte) (int, error) {
+	// TODO: write p a line at a time (bytes.IndexByte finds the
+	// "\n"), each after the prefix if it starts a line
+	return 0, nil
+}
+
+// 5. io.TeeReader
+// CopyWithSHA256 copies src to dst and returns how many bytes it copied
+// and the SHA-256 of them in hex, reading src once. io.TeeReader(r, w)
+// is a reader that writes to w everything that's read from r through
+// it; a hash.Hash is an io.Writer.
+func CopyWithSHA256(dst io.Writer, src io.Reader) (int64, string, error) {
+	// TODO: h := sha256.New(), io.Copy through a TeeReader, then
+	// hex.EncodeToString(h.Sum(nil))
+	return 0, "", nil
+}
+
+// ErrTooLarge is returned by ReadAtMost.
+var ErrTooLarge = errors.New("input too large")
+
+// 6. io.LimitReader
+// ReadAtMost reads all of r, as long as it's no more than limit bytes.
+// If r has more, it returns an error wrapping ErrTooLarge, with the
+// limit in the message, and no data; either way it reads no more than
+// limit+1 bytes of r, so a huge or endless input can't use up memory.
+// That's what http.MaxBytesReader does for request bodies.
+func ReadAtMost(r io.Reader, limit int64) ([]byte, error) {
+	// TODO: io.ReadAll an io.LimitReader one byte longer than limit,
+	// and if that byte came, the input was too large
+	return nil, nil
+}
+
+// 7. io.MultiReader
+// WithHeader returns a reader that reads header and then body, without
+// copying body into memory.
+func WithHeader(header string, body io.Reader) io.Reader {
+	// TODO: io.MultiReader and strings.NewReader
+	return body
+}
+
+// Stats describes what Archive copied.
+type Stats struct {
+	Bytes  int64
+	Lines  int    // counted like LineCounter does
+	SHA256 string // in hex
+}
+
+// 8. io.MultiWriter
+// Archive copies src to every writer in dsts at once, say a file and
+// its backup, and returns Stats about what it copied, all in a single
+// pass over src. io.MultiWriter(ws...) is a writer that writes
+// everything to each of ws in turn; CopyWithSHA256 did the same job
+// with the hash on the reading side.
+//
+// If a write fails, Archive stops and returns the error.
+func Archive(src io.Reader, dsts ...io.Writer) (Stats, error) {
+	// TODO: io.Copy into an io.MultiWriter of dsts, a LineCounter and
+	// a hash
+	return Stats{}, nil
+}
+
+// 9. bufio.Writer
+// WriteNumbered writes lines to w, numbered like `cat -n`: the number
+// right-aligned in 6 columns, a tab, the line, "\n": []string{"first"}
+// comes out as "     1\tfirst\n".
+//
+// Writing to a file or a network connection is a system call each
+// time, so writing many small pieces is slow. Wrap w in a bufio.Writer,
+// which collects writes into a buffer and passes it on when it's full,
+// and Flush it at the end, or the last of the output is never written.
+// The bufio.Writer keeps the first error w returns and returns it from
+// every call after, so checking the one from Flush is enough.
+func WriteNumbered(w io.Writer, lines []string) error {
+	// TODO: bufio.NewWriter(w), fmt.Fprintf to it, return bw.Flush()
+	return nil
+}
+
+// Keep imports used
+var _ = bufio.NewWriter
+var _ = sha256.New
+var _ = hex.EncodeToString
+var _ = fmt.Errorf
+var _ = strings.NewReader
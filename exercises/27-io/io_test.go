@@ -0,0 +1,458 @@
+package iocompose
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/imgarylai/learn-go/internal/assert"
+)
+
+var (
+	_ io.Writer = (*LineCounter)(nil)
+	_ io.Reader = (*upperReader)(nil)
+	_ io.Writer = (*prefixWriter)(nil)
+)
+
+var boom = errors.New("disk on fire")
+
+// chunkWriter records each Write it gets.
+type chunkWriter struct {
+	chunks []string
+}
+
+func (w *chunkWriter) Write(p []byte) (int, error) {
+	w.chunks = append(w.chunks, string(p))
+	return len(p), nil
+}
+
+func (w *chunkWriter) String() string { return strings.Join(w.chunks, "") }
+
+// fullWriter takes room bytes and then fails with boom.
+type fullWriter struct {
+	room int
+	got  bytes.Buffer
+}
+
+func (w *fullWriter) Write(p []byte) (int, error) {
+	n := min(len(p), w.room)
+	w.room -= n
+	w.got.Write(p[:n])
+	if n < len(p) {
+		return n, boom
+	}
+	return n, nil
+}
+
+// flakyWriter fails its first Write and takes the rest.
+type flakyWriter struct {
+	failed bool
+	got    bytes.Buffer
+}
+
+func (w *flakyWriter) Write(p []byte) (int, error) {
+	if !w.failed {
+		w.failed = true
+		return 0, boom
+	}
+	return w.got.Write(p)
+}
+
+// shortWriter breaks the Write contract: it drops the last byte of
+// every chunk and doesn't say why.
+type shortWriter struct{}
+
+func (shortWriter) Write(p []byte) (int, error) { return max(len(p)-1, 0), nil }
+
+// lastWithErr returns its data and err from the same Read, the way the
+// io.Reader documentation allows.
+type lastWithErr struct {
+	data string
+	err  error
+}
+
+func (r *lastWithErr) Read(p []byte) (int, error) {
+	n := copy(p, r.data)
+	r.data = r.data[n:]
+	if r.data == "" {
+		return n, r.err
+	}
+	return n, nil
+}
+
+// sizedReader returns the bytes of r in reads of the given sizes, one
+// after another and the last one over again.
+type sizedReader struct {
+	r     io.Reader
+	sizes []int
+}
+
+func (s *sizedReader) Read(p []byte) (int, error) {
+	n := min(len(p), s.sizes[0])
+	if len(s.sizes) > 1 {
+		s.sizes = s.sizes[1:]
+	}
+	return s.r.Read(p[:n])
+}
+
+// endless is a reader that never ends.
+type endless struct{ read int }
+
+func (e *endless) Read(p []byte) (int, error) {
+	e.read += len(p)
+	return len(p), nil
+}
+
+func TestLineCounter(t *testing.T) {
+	for _, tt := range []struct {
+		chunks []string
+		want   int
+	}{
+		{nil, 0},
+		{[]string{""}, 0},
+		{[]string{"a"}, 1},
+		{[]string{"a\n"}, 1},
+		{[]string{"a\nb"}, 2},
+		{[]string{"\n\n"}, 2},
+		{[]string{"a\nb", "c\n"}, 2},
+		{[]string{"a", "\n", "bc\n"}, 2},
+		{[]string{"a", ""}, 1},
+		{[]string{"a\n", ""}, 1},
+		{[]string{"a\n", "b"}, 2},
+	} {
+		var c LineCounter
+		for _, chunk := range tt.chunks {
+			n, err := c.Write([]byte(chunk))
+			if n != len(chunk) || err != nil {
+				t.Errorf("Write(%q) = %d, %v; want %d, nil", chunk, n, err, len(chunk))
+			}
+		}
+		assert.Equal(t, c.Lines(), tt.want, "lines in %q", tt.chunks)
+	}
+}
+
+func TestCopyInChunks(t *testing.T) {
+	for _, tt := range []struct {
+		name   string
+		src    io.Reader
+		size   int
+		chunks []string
+		err    error
+	}{
+		{"in chunks of size", strings.NewReader("abcdefghij"), 4, []string{"abcd", "efgh", "ij"}, nil},
+		{"short reads", iotest.OneByteReader(strings.NewReader("abc")), 4, []string{"a", "b", "c"}, nil},
+		{"data with io.EOF", iotest.DataErrReader(strings.NewReader("abcdef")), 4, []string{"abcd", "ef"}, nil},
+		{"data with an error", &lastWithErr{"abcdef", boom}, 4, []string{"abcd", "ef"}, boom},
+		{"an error after data", iotest.TimeoutReader(strings.NewReader("abcdef")), 4, []string{"abcd"}, iotest.ErrTimeout},
+		{"an error at once", iotest.ErrReader(boom), 4, nil, boom},
+		{"nothing", strings.NewReader(""), 4, nil, nil},
+	} {
+		var dst chunkWriter
+		n, err := CopyInChunks(&dst, tt.src, tt.size)
+		if !errors.Is(err, tt.err) {
+			t.Errorf("%s: error %v, want %v", tt.name, err, tt.err)
+		}
+		assert.Equal(t, dst.chunks, tt.chunks, "%s: writes", tt.name)
+		assert.Equal(t, n, int64(len(dst.String())), "%s: bytes written", tt.name)
+	}
+
+	dst := &fullWriter{room: 6}
+	n, err := CopyInChunks(dst, strings.NewReader("abcdefghij"), 4)
+	if !errors.Is(err, boom) || n != 6 {
+		t.Errorf("into a writer with room for 6 bytes: %d, %v; want 6, %v", n, err, boom)
+	}
+
+	n, err = CopyInChunks(shortWriter{}, strings.NewReader("abcdefghij"), 4)
+	if err != io.ErrShortWrite || n != 3 {
+		t.Errorf("into a writer that writes short: %d, %v; want 3, %v", n, err, io.ErrShortWrite)
+	}
+}
+
+func TestUpperReader(t *testing.T) {
+	const text = "héllo wörld! ɐ ǆ straße, 日本語 and a 👍"
+	want := []byte(strings.ToUpper(text))
+	if err := iotest.TestReader(NewUpperReader(strings.NewReader(text)), want); err != nil {
+		t.Error(err)
+	}
+	if len(want) == len(text) {
+		t.Fatal("the test text should change length when upper-cased")
+	}
+
+	for _, tt := range []struct {
+		name string
+		r    io.Reader
+	}{
+		{"one byte at a time", iotest.OneByteReader(strings.NewReader(text))},
+		{"in halves", iotest.HalfReader(strings.NewReader(text))},
+		{"with io.EOF on the data", iotest.DataErrReader(strings.NewReader(text))},
+		{"cut in the middle of runes", &sizedReader{strings.NewReader(text), []int{2, 3, 5, 7}}},
+	} {
+		got, err := io.ReadAll(NewUpperReader(tt.r))
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+		}
+		assert.Equal(t, string(got), string(want), tt.name)
+	}
+
+	// Read into one byte at a time, so the 3 bytes of an "Ɐ" never fit.
+	r := NewUpperReader(iotest.OneByteReader(strings.NewReader("ɐɐ")))
+	var got []byte
+	p := make([]byte, 1)
+	for {
+		n, err := r.Read(p)
+		got = append(got, p[:n]...)
+		if err == io.EOF {
+			break
+		}
+		if err != nil || n != 1 {
+			t.Fatalf("Read into 1 byte = %d, %v after %q", n, err, got)
+		}
+	}
+	assert.Equal(t, string(got), "ⱯⱯ", "read a byte at a time")
+
+	for _, b := range []string{"a\xffb", "日本\xe8\xaa", "\xe8\xaa", "\xe8\xaaé", "a\x80\x80\x80\x80b", "\xf0\x9f\x91"} {
+		want := bytes.ToUpper([]byte(b))
+		got, err := io.ReadAll(NewUpperReader(iotest.OneByteReader(strings.NewReader(b))))
+		if err != nil {
+			t.Errorf("reading %q: %v", b, err)
+		}
+		assert.Equal(t, string(got), string(want), "invalid UTF-8 %q", b)
+	}
+
+	got, err := io.ReadAll(NewUpperReader(&lastWithErr{"é", boom}))
+	if !errors.Is(err, boom) || string(got) != "É" {
+		t.Errorf("from a reader that fails: %q, %v; want %q, %v", got, err, "É", boom)
+	}
+	if _, err := NewUpperReader(iotest.ErrReader(boom)).Read(make([]byte, 8)); !errors.Is(err, boom) {
+		t.Errorf("got error %v, want %v", err, boom)
+	}
+}
+
+func TestPrefixWriter(t *testing.T) {
+	for _, tt := range []struct {
+		chunks []string
+		want   string
+	}{
+		{[]string{"a\nb\n"}, "> a\n> b\n"},
+		{[]string{"a\nb"}, "> a\n> b"},
+		{[]string{"a\n"}, "> a\n"},
+		{[]string{"a", "b\nc", "\n", "", "\n"}, "> ab\n> c\n> \n"},
+		{[]string{"\n\n"}, "> \n> \n"},
+		{[]string{"", ""}, ""},
+	} {
+		var dst bytes.Buffer
+		w := NewPrefixWriter(&dst, "> ")
+		for _, chunk := range tt.chunks {
+			n, err := w.Write([]byte(chunk))
+			if n != len(chunk) || err != nil {
+				t.Errorf("Write(%q) = %d, %v; want %d, nil", chunk, n, err, len(chunk))
+			}
+		}
+		assert.Equal(t, dst.String(), tt.want, "writing %q", tt.chunks)
+	}
+
+	var dst bytes.Buffer
+	w := NewPrefixWriter(&dst, "")
+	w.Write([]byte("a\nb"))
+	assert.Equal(t, dst.String(), "a\nb", "no prefix")
+
+	for _, tt := range []struct {
+		room, n int
+		got     string
+	}{
+		{1, 0, ">"},
+		{3, 1, "> a"},
+		{6, 2, "> a\n> "},
+		{7, 3, "> a\n> b"},
+	} {
+		full := &fullWriter{room: tt.room}
+		n, err := NewPrefixWriter(full, "> ").Write([]byte("a\nbc"))
+		if n != tt.n || !errors.Is(err, boom) {
+			t.Errorf("into a writer with room for %d bytes: %d, %v; want %d, %v", tt.room, n, err, tt.n, boom)
+		}
+		assert.Equal(t, full.got.String(), tt.got, "written into room for %d bytes", tt.room)
+	}
+
+	// Nothing of a line goes out when its prefix couldn't.
+	flaky := &flakyWriter{}
+	if n, err := NewPrefixWriter(flaky, "> ").Write([]byte("a\n")); n != 0 || !errors.Is(err, boom) || flaky.got.Len() != 0 {
+		t.Errorf("when writing the prefix fails: %d, %v and wrote %q; want 0, %v and nothing", n, err, flaky.got.String(), boom)
+	}
+}
+
+func sum(s string) string {
+	h := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(h[:])
+}
+
+func TestCopyWithSHA256(t *testing.T) {
+	for _, s := range []string{"", "hello", strings.Repeat("0123456789", 10000)} {
+		var dst bytes.Buffer
+		n, got, err := CopyWithSHA256(&dst, iotest.HalfReader(strings.NewReader(s)))
+		if err != nil {
+			t.Errorf("copying %d bytes: %v", len(s), err)
+		}
+		assert.Equal(t, n, int64(len(s)), "bytes copied")
+		assert.Equal(t, got, sum(s), "SHA-256 of %d bytes", len(s))
+		if dst.String() != s {
+			t.Errorf("copying %d bytes wrote %d bytes that aren't the same", len(s), dst.Len())
+		}
+	}
+	assert.Equal(t, sum("hello"), "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824", "SHA-256 of hello")
+
+	var dst bytes.Buffer
+	n, got, err := CopyWithSHA256(&dst, &lastWithErr{"hello", boom})
+	if !errors.Is(err, boom) || n != 5 || got != sum("hello") {
+		t.Errorf("from a reader that fails: %d, %s, %v; want 5, %s, %v", n, got, err, sum("hello"), boom)
+	}
+}
+
+func TestReadAtMost(t *testing.T) {
+	for _, tt := range []struct {
+		s     string
+		limit int64
+	}{
+		{"hello", 5},
+		{"hello", 6},
+		{"", 0},
+		{"", 10},
+	} {
+		got, err := ReadAtMost(strings.NewReader(tt.s), tt.limit)
+		if err != nil || string(got) != tt.s {
+			t.Errorf("ReadAtMost(%q, %d) = %q, %v; want %q, nil", tt.s, tt.limit, got, err, tt.s)
+		}
+	}
+
+	for _, tt := range []struct {
+		s     string
+		limit int64
+	}{
+		{"hello", 4},
+		{"x", 0},
+	} {
+		got, err := ReadAtMost(strings.NewReader(tt.s), tt.limit)
+		if !errors.Is(err, ErrTooLarge) || got != nil {
+			t.Errorf("ReadAtMost(%q, %d) = %q, %v; want nil, an error wrapping ErrTooLarge", tt.s, tt.limit, got, err)
+			continue
+		}
+		if want := fmt.Sprint(tt.limit); !strings.Contains(err.Error(), want) {
+			t.Errorf("ReadAtMost(%q, %d): %q doesn't say what the limit is", tt.s, tt.limit, err)
+		}
+	}
+
+	r := strings.NewReader(strings.Repeat("x", 100))
+	ReadAtMost(r, 10)
+	assert.Equal(t, r.Len(), 89, "bytes left unread after reading at most 10 of 100")
+
+	var e endless
+	if _, err := ReadAtMost(&e, 1000); !errors.Is(err, ErrTooLarge) || e.read > 1001 {
+		t.Errorf("from an endless reader: %v after %d bytes; want ErrTooLarge after no more than 1001", err, e.read)
+	}
+
+	if got, err := ReadAtMost(&lastWithErr{"abc", boom}, 10); !errors.Is(err, boom) || got != nil {
+		t.Errorf("from a reader that fails: %q, %v; want nil, %v", got, err, boom)
+	}
+}
+
+func TestWithHeader(t *testing.T) {
+	r := WithHeader("HEADER\n", strings.NewReader("body\n"))
+	if err := iotest.TestReader(r, []byte("HEADER\nbody\n")); err != nil {
+		t.Error(err)
+	}
+
+	got, err := io.ReadAll(WithHeader("", &lastWithErr{"body", boom}))
+	if !errors.Is(err, boom) || string(got) != "body" {
+		t.Errorf("with a body that fails: %q, %v; want %q, %v", got, err, "body", boom)
+	}
+
+	// The header has to come out before the body is read at all.
+	var e endless
+	p := make([]byte, 3)
+	n, _ := io.ReadFull(WithHeader("abc", &e), p)
+	if string(p[:n]) != "abc" || e.read != 0 {
+		t.Errorf("read %q and %d bytes of the body, want %q and none", p[:n], e.read, "abc")
+	}
+}
+
+func TestArchive(t *testing.T) {
+	text := "first line\nsecond\nno newline at the end"
+	var a, b bytes.Buffer
+	stats, err := Archive(iotest.OneByteReader(strings.NewReader(text)), &a, &b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, stats, Stats{Bytes: int64(len(text)), Lines: 3, SHA256: sum(text)})
+	assert.Equal(t, a.String(), text, "first copy")
+	assert.Equal(t, b.String(), text, "second copy")
+
+	stats, err = Archive(strings.NewReader("a\nb\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, stats, Stats{Bytes: 4, Lines: 2, SHA256: sum("a\nb\n")}, "with no writers")
+
+	full := &fullWriter{room: 3}
+	var c bytes.Buffer
+	stats, err = Archive(iotest.OneByteReader(strings.NewReader(text)), full, &c)
+	if !errors.Is(err, boom) {
+		t.Errorf("into a full writer: error %v, want %v", err, boom)
+	}
+	assert.Equal(t, stats.Bytes, int64(3), "bytes copied into a writer with room for 3")
+	assert.Equal(t, c.String(), "fir", "the other copy stops at the failed write")
+}
+
+// countingWriter counts the Writes it gets.
+type countingWriter struct {
+	bytes.Buffer
+	writes int
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.writes++
+	return w.Buffer.Write(p)
+}
+
+func TestWriteNumbered(t *testing.T) {
+	var w countingWriter
+	if err := WriteNumbered(&w, []string{"first", "", "third"}); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, w.String(), "     1\tfirst\n     2\t\n     3\tthird\n")
+	assert.Equal(t, w.writes, 1, "writes to w for a few lines")
+
+	lines := make([]string, 100000)
+	var want strings.Builder
+	for i := range lines {
+		lines[i] = fmt.Sprint("line ", i)
+		fmt.Fprintf(&want, "%6d\tline %d\n", i+1, i)
+	}
+	w = countingWriter{}
+	if err := WriteNumbered(&w, lines); err != nil {
+		t.Fatal(err)
+	}
+	if w.String() != want.String() {
+		t.Errorf("the output of %d lines isn't right", len(lines))
+	}
+	if w.writes > want.Len()/1000 {
+		t.Errorf("%d writes to w for %d lines; buffer them", w.writes, len(lines))
+	}
+
+	w = countingWriter{}
+	if err := WriteNumbered(&w, nil); err != nil || w.Len() != 0 {
+		t.Errorf("no lines: wrote %q, %v", w.String(), err)
+	}
+
+	full := &fullWriter{room: 100}
+	if err := WriteNumbered(full, lines); !errors.Is(err, boom) {
+		t.Errorf("into a full writer: %v, want %v", err, boom)
+	}
+	if err := WriteNumbered(&fullWriter{}, []string{"x"}); !errors.Is(err, boom) {
+		t.Errorf("one line into a full writer: %v, want %v; did you Flush?", err, boom)
+	}
+}
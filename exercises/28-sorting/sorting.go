@@ -0,0 +1,201 @@
+//go:build !solutions
+
+package sorting
+
+import (
+	"cmp"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Exercise 28: Sorting and searching
+//
+// JS has one way to sort, arr.sort(compareFn), and it sorts in place
+// and is stable. Go has two generations of API side by side:
+//
+//   - package sort, from before generics: sort.Slice(s, less), which
+//     takes a less func over indexes, and sort.Sort(data), which sorts
+//     anything implementing sort.Interface (exercise 5's ByAge)
+//   - package slices, generic: slices.SortFunc(s, cmp), where cmp
+//     returns a negative number, zero or a positive number, like a JS
+//     compare func
+//
+// New code mostly uses slices. Neither sort.Slice nor slices.SortFunc
+// is stable; sort.SliceStable and slices.SortStableFunc are. And a
+// sorted slice can be searched in O(log n) with a binary search.
+//
+// Run tests with: go test -v
+
+// Book is what most of this exercise sorts.
+type Book struct {
+	Title  string
+	Author string
+	Year   int
+	Rating float64 // out of 5
+}
+
+// 1. sort.Slice
+// SortByYear sorts books in place, oldest first, and books from the
+// same year by Title.
+// In JS: books.sort((a, b) => a.year - b.year || a.title.localeCompare(b.title))
+func SortByYear(books []Book) {
+	// TODO: sort.Slice(books, func(i, j int) bool { ... }); less gets
+	// indexes into books, not two books
+}
+
+// Scores holds a leaderboard in two slices, the way it might come
+// out of a columnar file: Points[i] is the score of Names[i].
+type Scores struct {
+	Names  []string
+	Points []int
+}
+
+// 2. Implementing sort.Interface
+// sort.Slice can only move the elements of one slice. To sort the two
+// slices of Scores together, implement sort.Interface, so that Swap
+// can swap in both. Sort highest Points first, and equal Points by
+// Name; then sort.Sort(s) works.
+func (s Scores) Len() int {
+	// TODO
+	return 0
+}
+
+func (s Scores) Less(i, j int) bool {
+	// TODO
+	return false
+}
+
+func (s Scores) Swap(i, j int) {
+	// TODO: swap element i and j of both slices
+}
+
+// 3. A multi-key compare func
+// CompareBooks orders books by Author, then newest first, then by
+// Title. It returns a negative number if a comes first, a positive
+// one if b does, and 0 if they tie, the contract of slices.SortFunc's
+// cmp argument.
+//
+// cmp.Compare(x, y) compares two values that way, and cmp.Or returns
+// the first of its arguments that isn't zero, like JS's || chain.
+func CompareBooks(a, b Book) int {
+	// TODO: cmp.Or(cmp.Compare(...), ...); swap the arguments to
+	// reverse an order
+	return 0
+}
+
+// SortBooks sorts books in place with CompareBooks.
+func SortBooks(books []Book) {
+	slices.SortFunc(books, CompareBooks)
+}
+
+// 4. Building compare funcs
+// By returns a compare func that compares values by key(value), like
+// lodash's _.sortBy(arr, key). Desc reverses a compare func, and
+// ThenBy chains them: the first that doesn't tie decides. So
+// CompareBooks could have been
+//
+//	ThenBy(By(author), Desc(By(year)), By(title))
+//
+// where author is func(b Book) string { return b.Author } and so on.
+func By[T any, K cmp.Ordered](key func(T) K) func(a, b T) int {
+	// TODO
+	return func(a, b T) int { return 0 }
+}
+
+// Desc orders the other way round from c.
+func Desc[T any](c func(a, b T) int) func(a, b T) int {
+	// TODO
+	return c
+}
+
+// ThenBy with no compare funcs calls everything a tie.
+func ThenBy[T any](cs ...func(a, b T) int) func(a, b T) int {
+	// TODO
+	return func(a, b T) int { return 0 }
+}
+
+// 5. Stable sorting
+// GroupByAuthor sorts books by Author only, and keeps books by the
+// same author in the order they came in. So sorting by rating first
+// and then grouping lists each author's books best first.
+func GroupByAuthor(books []Book) {
+	// TODO: slices.SortStableFunc, comparing authors only
+}
+
+// 6. Sorting a copy
+// TopRated returns the n best-rated books, best first, and books with
+// the same rating by Title; all of them if there are fewer than n.
+// Sorting is in place, so sort a copy: the caller's slice must keep its
+// order, unlike JS's arr.sort() and like its arr.toSorted().
+func TopRated(books []Book, n int) []Book {
+	// TODO: slices.Clone, sort, then keep at most n
+	return nil
+}
+
+// 7. Binary search
+// FindYear looks for year in books sorted by SortByYear. It returns
+// the index of the first book from that year and true, or, if there's
+// none, the index where a book from that year would go and false: the
+// same results as slices.BinarySearch. Use slices.BinarySearchFunc,
+// whose cmp compares an element with the target.
+func FindYear(books []Book, year int) (int, bool) {
+	// TODO
+	return 0, false
+}
+
+// BetweenYears returns the books from the years from to to, both
+// included, out of books sorted by SortByYear: a subslice of books
+// found with two binary searches.
+func BetweenYears(books []Book, from, to int) []Book {
+	// TODO: FindYear tells where each end goes
+	return nil
+}
+
+// 8. sort.Search: binary search over a question
+// Bisect finds the first bad commit, like `git bisect`. Commits are
+// numbered 0 to n-1, and once one is bad, every later one is too; bad
+// tells whether a commit is. It returns the number of the first bad
+// commit, or n if none is, calling bad only O(log n) times: testing a
+// commit is slow.
+//
+// sort.Search(n, f) doesn't need a slice at all. It finds the smallest
+// i in [0, n) for which f(i) is true, given that f is false and then
+// true.
+func Bisect(n int, bad func(commit int) bool) int {
+	// TODO: one line
+	return 0
+}
+
+// 9. Natural order
+// NaturalCompare compares strings the way a person would sort file
+// names, with runs of digits compared as numbers: "file9" before
+// "file10", where plain < puts "file10" first. JS does this with
+// a.localeCompare(b, undefined, { numeric: true }).
+//
+// Outside digits, compare byte by byte. Two runs of digits with the
+// same value, like "7" and "007", compare by length, shorter first, so
+// that different strings never tie. Runs can be longer than an int64
+// holds: compare them without converting.
+func NaturalCompare(a, b string) int {
+	// TODO: walk both strings; where both have a digit, take the whole
+	// run from each, strings.TrimLeft the zeros, and compare first by
+	// length and then as strings
+	return strings.Compare(a, b)
+}
+
+// 10. A generic sort of your own
+// InsertionSort sorts s in place with cmp, like slices.SortStableFunc.
+// It takes each element in turn and moves it left past the elements
+// bigger than it, like sorting a hand of cards.
+//
+// It's O(n²), but it's stable, needs no extra memory, and on a slice
+// that's already nearly sorted it's close to O(n): on a sorted one it
+// calls cmp only len(s)-1 times. The standard library's sorts switch
+// to it for short stretches of a slice.
+func InsertionSort[T any](s []T, cmp func(a, b T) int) {
+	// TODO
+}
+
+// Keep imports used
+var _ = sort.Slice
@@ -0,0 +1,358 @@
+package sorting
+
+import (
+	"cmp"
+	"math/rand/v2"
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/imgarylai/learn-go/internal/assert"
+)
+
+var _ sort.Interface = Scores{}
+
+// shelf returns a fresh copy of the same books each time, in no
+// particular order.
+func shelf() []Book {
+	return []Book{
+		{"The Dispossessed", "Le Guin", 1974, 4.3},
+		{"Kindred", "Butler", 1979, 4.3},
+		{"A Wizard of Earthsea", "Le Guin", 1968, 4.0},
+		{"Parable of the Sower", "Butler", 1993, 4.5},
+		{"The Left Hand of Darkness", "Le Guin", 1969, 4.3},
+		{"Dune", "Herbert", 1965, 4.3},
+		{"Dawn", "Butler", 1987, 4.1},
+		{"The Lathe of Heaven", "Le Guin", 1971, 4.0},
+		{"Children of Dune", "Herbert", 1976, 4.0},
+		{"Wild Seed", "Butler", 1980, 4.2},
+		{"Dune Messiah", "Herbert", 1969, 3.9},
+	}
+}
+
+func titles(books []Book) []string {
+	var ts []string
+	for _, b := range books {
+		ts = append(ts, b.Title)
+	}
+	return ts
+}
+
+func TestSortByYear(t *testing.T) {
+	books := shelf()
+	SortByYear(books)
+	assert.Equal(t, titles(books), []string{
+		"Dune",                      // 1965
+		"A Wizard of Earthsea",      // 1968
+		"Dune Messiah",              // 1969
+		"The Left Hand of Darkness", // 1969
+		"The Lathe of Heaven",       // 1971
+		"The Dispossessed",          // 1974
+		"Children of Dune",          // 1976
+		"Kindred",                   // 1979
+		"Wild Seed",                 // 1980
+		"Dawn",                      // 1987
+		"Parable of the Sower",      // 1993
+	})
+	SortByYear(nil)
+}
+
+func TestScores(t *testing.T) {
+	s := Scores{
+		Names:  []string{"dana", "ari", "cy", "bo", "eve"},
+		Points: []int{70, 95, 70, 100, 95},
+	}
+	if s.Len() != 5 {
+		t.Fatalf("Len() = %d, want 5", s.Len())
+	}
+	// Less is a strict order, like <: nothing is less than itself.
+	s.Names[2] = "dana"
+	if s.Less(0, 0) || s.Less(0, 2) {
+		t.Error("Less reports dana with 70 points as less than dana with 70 points")
+	}
+	s.Names[2] = "cy"
+	sort.Sort(s)
+	assert.Equal(t, s.Names, []string{"bo", "ari", "eve", "cy", "dana"}, "names")
+	assert.Equal(t, s.Points, []int{100, 95, 95, 70, 70}, "points, still with their names")
+
+	empty := Scores{}
+	sort.Sort(empty)
+	assert.Equal(t, empty.Len(), 0, "no scores")
+}
+
+func TestCompareBooks(t *testing.T) {
+	books := shelf()
+	SortBooks(books)
+	assert.Equal(t, titles(books), []string{
+		"Parable of the Sower", // Butler 1993
+		"Dawn",                 // Butler 1987
+		"Wild Seed",            // Butler 1980
+		"Kindred",              // Butler 1979
+		"Children of Dune",     // Herbert 1976
+		"Dune Messiah",         // Herbert 1969
+		"Dune",                 // Herbert 1965
+		"The Dispossessed",     // Le Guin 1974
+		"The Lathe of Heaven",  // Le Guin 1971
+		"The Left Hand of Darkness",
+		"A Wizard of Earthsea",
+	})
+
+	a := Book{"A", "X", 2000, 1}
+	for _, tt := range []struct {
+		b    Book
+		want int
+	}{
+		{Book{"A", "X", 2000, 5}, 0},
+		{Book{"A", "Y", 1900, 1}, -1},
+		{Book{"A", "W", 2100, 1}, 1},
+		{Book{"A", "X", 1999, 1}, -1},
+		{Book{"A", "X", 2001, 1}, 1},
+		{Book{"B", "X", 2000, 1}, -1},
+		{Book{"0", "X", 2000, 1}, 1},
+	} {
+		got := cmp.Compare(CompareBooks(a, tt.b), 0)
+		assert.Equal(t, got, tt.want, "the sign of CompareBooks(%v, %v)", a, tt.b)
+		assert.Equal(t, cmp.Compare(CompareBooks(tt.b, a), 0), -tt.want, "the sign of CompareBooks(%v, %v)", tt.b, a)
+	}
+}
+
+func TestByThenBy(t *testing.T) {
+	author := func(b Book) string { return b.Author }
+	year := func(b Book) int { return b.Year }
+	title := func(b Book) string { return b.Title }
+
+	want := shelf()
+	SortBooks(want)
+	got := shelf()
+	slices.SortFunc(got, ThenBy(By(author), Desc(By(year)), By(title)))
+	assert.Equal(t, titles(got), titles(want), "ThenBy(By(author), Desc(By(year)), By(title))")
+
+	rating := func(b Book) float64 { return b.Rating }
+	slices.SortFunc(got, ThenBy(Desc(By(rating)), By(title)))
+	assert.Equal(t, titles(got)[:4], []string{"Parable of the Sower", "Dune", "Kindred", "The Dispossessed"}, "by rating, then title")
+
+	words := []string{"pear", "fig", "apple", "kiwi", "date"}
+	slices.SortFunc(words, ThenBy(By(func(s string) int { return len(s) }), By(func(s string) string { return s })))
+	assert.Equal(t, words, []string{"fig", "date", "kiwi", "pear", "apple"}, "by length, then alphabetically")
+
+	byLen := By(func(s string) int { return len(s) })
+	assert.Equal(t, byLen("ab", "abc"), -1, "By: shorter first")
+	assert.Equal(t, Desc(byLen)("ab", "abc"), 1, "Desc(By): longer first")
+	assert.Equal(t, byLen("ab", "cd"), 0, "By: a tie")
+	assert.Equal(t, ThenBy[string]()("a", "b"), 0, "ThenBy with no funcs")
+}
+
+func TestGroupByAuthor(t *testing.T) {
+	books := shelf()
+	// Best first, with ties by Title; then grouping must keep that
+	// order inside each author.
+	slices.SortFunc(books, func(a, b Book) int {
+		return cmp.Or(cmp.Compare(b.Rating, a.Rating), cmp.Compare(a.Title, b.Title))
+	})
+	GroupByAuthor(books)
+	assert.Equal(t, titles(books), []string{
+		"Parable of the Sower", // 4.5
+		"Kindred",              // 4.3
+		"Wild Seed",            // 4.2
+		"Dawn",                 // 4.1
+		"Dune",                 // 4.3
+		"Children of Dune",     // 4.0
+		"Dune Messiah",         // 3.9
+		"The Dispossessed",     // 4.3
+		"The Left Hand of Darkness",
+		"A Wizard of Earthsea", // 4.0
+		"The Lathe of Heaven",
+	})
+
+	// Long enough that an unstable sort shuffles the ties.
+	var many []Book
+	for i := range 200 {
+		many = append(many, Book{Title: string(rune('a' + i%26)), Author: []string{"C", "A", "B"}[i%3], Year: i})
+	}
+	GroupByAuthor(many)
+	for i := 1; i < len(many); i++ {
+		if many[i-1].Author == many[i].Author && many[i-1].Year > many[i].Year {
+			t.Fatalf("books by %s out of their order: %d before %d", many[i].Author, many[i-1].Year, many[i].Year)
+		}
+		if many[i-1].Author > many[i].Author {
+			t.Fatalf("%s before %s", many[i-1].Author, many[i].Author)
+		}
+	}
+}
+
+func TestTopRated(t *testing.T) {
+	books := shelf()
+	got := TopRated(books, 3)
+	assert.Equal(t, titles(got), []string{"Parable of the Sower", "Dune", "Kindred"})
+	assert.Equal(t, books, shelf(), "the books passed in, left in their order")
+
+	all := TopRated(books, 100)
+	if len(all) != len(books) {
+		t.Errorf("TopRated(books, 100) returned %d books, want all %d", len(all), len(books))
+	} else {
+		assert.Equal(t, all[len(all)-1].Title, "Dune Messiah", "the worst rated last")
+	}
+	assert.Equal(t, len(TopRated(books, 0)), 0, "none")
+	assert.Equal(t, len(TopRated(nil, 3)), 0, "from no books")
+}
+
+func byYear() []Book {
+	books := shelf()
+	slices.SortStableFunc(books, func(a, b Book) int {
+		return cmp.Or(cmp.Compare(a.Year, b.Year), cmp.Compare(a.Title, b.Title))
+	})
+	return books
+}
+
+func TestFindYear(t *testing.T) {
+	books := byYear()
+	for _, tt := range []struct {
+		year  int
+		i     int
+		found bool
+	}{
+		{1965, 0, true},
+		{1969, 2, true}, // the first of two
+		{1993, 10, true},
+		{1900, 0, false},
+		{1970, 4, false},
+		{2000, 11, false},
+	} {
+		i, found := FindYear(books, tt.year)
+		if i != tt.i || found != tt.found {
+			t.Errorf("FindYear(%d) = %d, %t; want %d, %t", tt.year, i, found, tt.i, tt.found)
+		}
+	}
+	if i, found := FindYear(nil, 2000); i != 0 || found {
+		t.Errorf("FindYear in no books = %d, %t; want 0, false", i, found)
+	}
+}
+
+func TestBetweenYears(t *testing.T) {
+	books := byYear()
+	for _, tt := range []struct {
+		from, to int
+		want     []string
+	}{
+		{1969, 1974, []string{"Dune Messiah", "The Left Hand of Darkness", "The Lathe of Heaven", "The Dispossessed"}},
+		{1970, 1973, []string{"The Lathe of Heaven"}},
+		{1969, 1969, []string{"Dune Messiah", "The Left Hand of Darkness"}},
+		{1990, 2020, []string{"Parable of the Sower"}},
+		{1900, 1966, []string{"Dune"}},
+		{1900, 2100, titles(books)},
+		{1994, 2100, nil},
+		{1972, 1973, nil},
+		{1974, 1969, nil},
+	} {
+		got := BetweenYears(books, tt.from, tt.to)
+		if len(got) != 0 || len(tt.want) != 0 {
+			assert.Equal(t, titles(got), tt.want, "BetweenYears(%d, %d)", tt.from, tt.to)
+		}
+	}
+	if got := BetweenYears(books, 1969, 1971); len(got) > 0 && &got[0] != &books[2] {
+		t.Error("BetweenYears copied the books; return a subslice of books")
+	}
+}
+
+func TestBisect(t *testing.T) {
+	for _, tt := range []struct{ n, firstBad int }{
+		{1000, 437},
+		{1000, 0},
+		{1000, 999},
+		{1000, 1000},
+		{1, 0},
+		{1, 1},
+		{0, 0},
+	} {
+		calls := 0
+		got := Bisect(tt.n, func(commit int) bool {
+			calls++
+			if commit < 0 || commit >= tt.n {
+				t.Fatalf("Bisect(%d) tested commit %d", tt.n, commit)
+			}
+			return commit >= tt.firstBad
+		})
+		assert.Equal(t, got, tt.firstBad, "Bisect(%d) with %d the first bad commit", tt.n, tt.firstBad)
+		if calls > 11 {
+			t.Errorf("Bisect(%d) tested %d commits, want no more than 11", tt.n, calls)
+		}
+	}
+}
+
+func TestNaturalCompare(t *testing.T) {
+	// In order: each is less than the next.
+	ordered := []string{
+		"",
+		"0",
+		"1",
+		"01",
+		"001",
+		"2",
+		"10",
+		"99999999999999999999999",
+		"100000000000000000000000",
+		"a",
+		"file",
+		"file2",
+		"file2.txt",
+		"file9",
+		"file10",
+		"file10a",
+		"file10b",
+		"file010",
+		"file11",
+		"filea",
+		"v1.2.10",
+		"v1.10.2",
+		"v2",
+	}
+	for i, a := range ordered {
+		for j, b := range ordered {
+			assert.Equal(t, cmp.Compare(NaturalCompare(a, b), 0), cmp.Compare(i, j), "the sign of NaturalCompare(%q, %q)", a, b)
+		}
+	}
+
+	files := []string{"img12.png", "img10.png", "IMG2.png", "img2.png", "img1.png"}
+	slices.SortFunc(files, NaturalCompare)
+	assert.Equal(t, files, []string{"IMG2.png", "img1.png", "img2.png", "img10.png", "img12.png"}, "sorted naturally")
+}
+
+func TestInsertionSort(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	for _, n := range []int{0, 1, 2, 3, 10, 100} {
+		// Pairs of a key with many ties and their first position, so
+		// the result shows whether the sort was stable.
+		s := make([][2]int, n)
+		for i := range s {
+			s[i] = [2]int{r.IntN(5), i}
+		}
+		want := slices.Clone(s)
+		byKey := func(a, b [2]int) int { return cmp.Compare(a[0], b[0]) }
+		slices.SortStableFunc(want, byKey)
+		InsertionSort(s, byKey)
+		assert.Equal(t, s, want, "InsertionSort of %d elements", n)
+	}
+
+	words := strings.Fields("the quick brown fox jumps over the lazy dog")
+	InsertionSort(words, strings.Compare)
+	assert.Equal(t, words, []string{"brown", "dog", "fox", "jumps", "lazy", "over", "quick", "the", "the"}, "words")
+
+	desc := []int{5, 4, 3, 2, 1}
+	InsertionSort(desc, func(a, b int) int { return b - a })
+	assert.Equal(t, desc, []int{5, 4, 3, 2, 1}, "already sorted the other way")
+
+	sorted := make([]int, 1000)
+	for i := range sorted {
+		sorted[i] = i
+	}
+	calls := 0
+	InsertionSort(sorted, func(a, b int) int {
+		calls++
+		return cmp.Compare(a, b)
+	})
+	assert.Equal(t, calls, len(sorted)-1, "compares on a sorted slice")
+	if !slices.IsSorted(sorted) {
+		t.Error("InsertionSort unsorted a sorted slice")
+	}
+}
@@ -0,0 +1,94 @@
+//go:build !solutions
+
+package datastructures
+
+import (
+	"container/heap"
+	"iter"
+)
+
+// Exercise 29, part 5: A min-heap for container/heap
+//
+// Exercise 5's TaskHeap implemented heap.Interface for one type.
+// MinHeap does it once for any type, given how to order it, and wraps
+// the untyped heap.Push and heap.Pop, which take and return any, in
+// typed methods.
+//
+// A binary heap is a tree kept in a slice: the children of items[i] are
+// items[2i+1] and items[2i+2], and each item is no bigger than its
+// children, so the smallest is always items[0]. heap.Push and heap.Pop
+// keep it that way in O(log n), moving items up and down with Less and
+// Swap.
+
+// MinHeap is a priority queue: Next always returns the smallest item
+// by less.
+type MinHeap[T any] struct {
+	items []T
+	less  func(a, b T) bool
+}
+
+// NewMinHeap returns an empty heap ordered by less.
+func NewMinHeap[T any](less func(a, b T) bool) *MinHeap[T] {
+	return &MinHeap[T]{less: less}
+}
+
+// 9. heap.Interface
+// These five are for the heap package to call, not for users of the
+// MinHeap; Push and Pop are at the end of the slice.
+func (h *MinHeap[T]) Len() int {
+	// TODO
+	return 0
+}
+
+func (h *MinHeap[T]) Less(i, j int) bool {
+	// TODO
+	return false
+}
+
+func (h *MinHeap[T]) Swap(i, j int) {
+	// TODO
+}
+
+func (h *MinHeap[T]) Push(x any) {
+	// TODO: x.(T)
+}
+
+func (h *MinHeap[T]) Pop() any {
+	// TODO: remove and return the last item, and clear its slot
+	return nil
+}
+
+// 10. The typed API
+// Add adds v to the heap.
+func (h *MinHeap[T]) Add(v T) {
+	// TODO: heap.Push(h, v)
+}
+
+// Next removes and returns the smallest item, or the zero value and
+// false if the heap is empty.
+func (h *MinHeap[T]) Next() (T, bool) {
+	// TODO: heap.Pop(h).(T)
+	var zero T
+	return zero, false
+}
+
+// Peek returns the smallest item without removing it.
+func (h *MinHeap[T]) Peek() (T, bool) {
+	// TODO
+	var zero T
+	return zero, false
+}
+
+// 11. Using a heap
+// Smallest returns the k smallest values of seq by less, smallest first,
+// in one pass and with no more than k+1 values held at a time: keep the
+// k smallest seen so far in a heap with the biggest on top, by the
+// opposite order, and drop the top whenever there are more than k.
+// sort is O(n log n) and needs them all at once; this is O(n log k).
+func Smallest[T any](seq iter.Seq[T], k int, less func(a, b T) bool) []T {
+	// TODO
+	return nil
+}
+
+// Keep imports used
+var _ = heap.Push
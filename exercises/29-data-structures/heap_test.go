@@ -0,0 +1,126 @@
+package datastructures
+
+import (
+	"cmp"
+	"container/heap"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/imgarylai/learn-go/internal/assert"
+)
+
+var _ heap.Interface = (*MinHeap[int])(nil)
+
+func intLess(a, b int) bool { return a < b }
+
+func TestMinHeap(t *testing.T) {
+	h := NewMinHeap(intLess)
+	if v, ok := h.Next(); ok || v != 0 {
+		t.Errorf("Next on an empty heap = %d, %t; want 0, false", v, ok)
+	}
+	if _, ok := h.Peek(); ok {
+		t.Error("Peek on an empty heap returned true")
+	}
+
+	r := rand.New(rand.NewPCG(5, 6))
+	var all []int
+	for range 100 {
+		v := r.IntN(50)
+		h.Add(v)
+		all = append(all, v)
+	}
+	assert.Equal(t, h.Len(), 100, "Len after 100 Adds")
+	slices.Sort(all)
+	if v, ok := h.Peek(); v != all[0] || !ok {
+		t.Errorf("Peek = %d, %t; want %d, true", v, ok, all[0])
+	}
+
+	var got []int
+	for range 50 {
+		v, _ := h.Next()
+		got = append(got, v)
+	}
+	assert.Equal(t, got, all[:50], "the first 50, smallest first")
+
+	// Adding between Nexts still comes out in order.
+	h.Add(1000)
+	h.Add(-1)
+	got = nil
+	for {
+		v, ok := h.Next()
+		if !ok {
+			break
+		}
+		got = append(got, v)
+	}
+	assert.Equal(t, got, slices.Concat([]int{-1}, all[50:], []int{1000}), "the rest, after adding -1 and 1000")
+	assert.Equal(t, h.Len(), 0, "Len when emptied")
+}
+
+func TestMinHeapPeek(t *testing.T) {
+	h := NewMinHeap(intLess)
+	for _, v := range []int{3, 1, 2} {
+		h.Add(v)
+	}
+	for _, want := range []int{1, 2, 3} {
+		if v, ok := h.Peek(); v != want || !ok {
+			t.Errorf("Peek = %d, %t; want %d, true", v, ok, want)
+		}
+		h.Next()
+	}
+}
+
+func TestMinHeapOfStructs(t *testing.T) {
+	type job struct {
+		name     string
+		priority int
+	}
+	h := NewMinHeap(func(a, b job) bool {
+		return cmp.Or(cmp.Compare(a.priority, b.priority), cmp.Compare(a.name, b.name)) < 0
+	})
+	for _, j := range []job{{"deploy", 2}, {"page", 0}, {"backup", 5}, {"build", 2}} {
+		h.Add(j)
+	}
+	var order []string
+	for h.Len() > 0 {
+		j, _ := h.Next()
+		order = append(order, j.name)
+	}
+	assert.Equal(t, order, []string{"page", "build", "deploy", "backup"})
+}
+
+func TestMinHeapPopClears(t *testing.T) {
+	h := NewMinHeap(func(a, b *int) bool { return *a < *b })
+	for i := range 3 {
+		h.Add(&i)
+	}
+	h.Next()
+	if rest := h.items[:cap(h.items)]; len(h.items) < len(rest) && rest[len(h.items)] != nil {
+		t.Error("Pop left the popped pointer in the slice's backing array")
+	}
+}
+
+func TestSmallest(t *testing.T) {
+	r := rand.New(rand.NewPCG(7, 8))
+	values := make([]int, 1000)
+	for i := range values {
+		values[i] = r.IntN(10000)
+	}
+	sorted := slices.Sorted(slices.Values(values))
+
+	for _, k := range []int{1, 5, 999, 1000, 2000} {
+		got := Smallest(slices.Values(values), k, intLess)
+		assert.Equal(t, got, sorted[:min(k, len(sorted))], "Smallest(%d)", k)
+	}
+	if got := Smallest(slices.Values(values), 0, intLess); len(got) != 0 {
+		t.Errorf("Smallest(0) = %v", got)
+	}
+	if got := Smallest(slices.Values([]int(nil)), 3, intLess); len(got) != 0 {
+		t.Errorf("Smallest of nothing = %v", got)
+	}
+
+	words := []string{"pear", "fig", "apple", "kiwi", "date", "banana"}
+	longest := Smallest(slices.Values(words), 2, func(a, b string) bool { return len(a) > len(b) })
+	assert.Equal(t, longest, []string{"banana", "apple"}, "the 2 longest words, by the opposite order")
+}
@@ -0,0 +1,76 @@
+//go:build !solutions
+
+package datastructures
+
+import "iter"
+
+// Exercise 29, part 3: A singly linked list
+//
+// Each value is in a node that points to the next one, like a chain of
+// { value, next } objects in JS. Adding or removing at the front is
+// O(1) and never moves the other values, but getting to the i-th value
+// means following i pointers. Go's container/list is the doubly linked
+// kind, not generic; this one is simpler and keeps a tail pointer, so
+// adding at the back is O(1) too.
+//
+// Most of the bugs in linked list code are in keeping head, tail and
+// the length right at the edges: the first node, the last node, an
+// empty list.
+
+// List is a singly linked list.
+type List[T any] struct {
+	head, tail *node[T] // both nil when the list is empty
+	n          int
+}
+
+type node[T any] struct {
+	value T
+	next  *node[T]
+}
+
+// 4. Linking nodes
+// PushFront adds v at the front.
+func (l *List[T]) PushFront(v T) {
+	// TODO: a new node whose next is the old head; it's also the tail
+	// if the list was empty
+}
+
+// PushBack adds v at the back.
+func (l *List[T]) PushBack(v T) {
+	// TODO
+}
+
+// PopFront removes and returns the front value, or the zero value and
+// false if the list is empty.
+func (l *List[T]) PopFront() (T, bool) {
+	// TODO: what's the tail once the last node is gone?
+	var zero T
+	return zero, false
+}
+
+func (l *List[T]) Len() int {
+	// TODO
+	return 0
+}
+
+// All yields the values from front to back.
+func (l *List[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		// TODO: for n := l.head; n != nil; n = n.next
+	}
+}
+
+// 5. Rewiring the list
+// Reverse reverses the list in place, by pointing each node's next at
+// the one before it, not by copying values.
+func (l *List[T]) Reverse() {
+	// TODO: walk the list with prev and cur pointers
+}
+
+// RemoveFunc removes every value for which del returns true and
+// returns how many it removed.
+func (l *List[T]) RemoveFunc(del func(T) bool) int {
+	// TODO: keep a pointer to the node before the current one, or to
+	// the *node[T] field that points at it; and fix up the tail
+	return 0
+}
@@ -0,0 +1,104 @@
+package datastructures
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/imgarylai/learn-go/internal/assert"
+)
+
+// checkList checks l's values and that its head, tail and length agree
+// with each other.
+func checkList(t *testing.T, l *List[int], want []int, what string) {
+	t.Helper()
+	var got []int
+	var last *node[int]
+	for n := l.head; n != nil && len(got) <= len(want); n = n.next {
+		got = append(got, n.value)
+		last = n
+	}
+	if len(got) != 0 || len(want) != 0 {
+		assert.Equal(t, got, want, "%s: the values", what)
+	}
+	assert.Equal(t, l.Len(), len(want), "%s: Len", what)
+	if l.tail != last {
+		t.Errorf("%s: tail isn't the last node", what)
+	}
+	if l.tail != nil && l.tail.next != nil {
+		t.Errorf("%s: the tail's next isn't nil", what)
+	}
+}
+
+func TestList(t *testing.T) {
+	var l List[int]
+	checkList(t, &l, nil, "empty")
+	if v, ok := l.PopFront(); ok || v != 0 {
+		t.Errorf("PopFront on an empty list = %d, %t; want 0, false", v, ok)
+	}
+
+	l.PushBack(2)
+	checkList(t, &l, []int{2}, "PushBack(2)")
+	l.PushFront(1)
+	l.PushBack(3)
+	checkList(t, &l, []int{1, 2, 3}, "PushFront(1), PushBack(3)")
+	assert.Equal(t, slices.Collect(l.All()), []int{1, 2, 3}, "All")
+	assert.Equal(t, firstN(l.All(), 2), []int{1, 2}, "All, stopped after 2")
+
+	for _, want := range []int{1, 2, 3} {
+		if v, ok := l.PopFront(); v != want || !ok {
+			t.Errorf("PopFront = %d, %t; want %d, true", v, ok, want)
+		}
+	}
+	checkList(t, &l, nil, "popped empty")
+
+	l.PushFront(5)
+	checkList(t, &l, []int{5}, "PushFront onto an emptied list")
+	l.PopFront()
+	l.PushBack(6)
+	l.PushBack(7)
+	checkList(t, &l, []int{6, 7}, "PushBack onto an emptied list")
+}
+
+func TestListReverse(t *testing.T) {
+	for _, values := range [][]int{nil, {1}, {1, 2}, {1, 2, 3, 4, 5}} {
+		var l List[int]
+		var nodes []*node[int]
+		for _, v := range values {
+			l.PushBack(v)
+			nodes = append(nodes, l.tail)
+		}
+		l.Reverse()
+		want := slices.Clone(values)
+		slices.Reverse(want)
+		checkList(t, &l, want, "Reverse")
+		if len(nodes) > 0 && l.head != nodes[len(nodes)-1] {
+			t.Errorf("Reverse of %v made new nodes; relink the ones there are", values)
+		}
+		l.PushBack(9)
+		checkList(t, &l, append(want, 9), "PushBack after Reverse")
+	}
+}
+
+func TestListRemoveFunc(t *testing.T) {
+	isEven := func(v int) bool { return v%2 == 0 }
+	for _, tt := range []struct {
+		values, want []int
+	}{
+		{[]int{1, 2, 3, 4, 5}, []int{1, 3, 5}},
+		{[]int{2, 4, 1, 3}, []int{1, 3}},
+		{[]int{1, 3, 2, 4}, []int{1, 3}},
+		{[]int{2, 4, 6}, nil},
+		{[]int{1, 3}, []int{1, 3}},
+		{nil, nil},
+	} {
+		var l List[int]
+		for _, v := range tt.values {
+			l.PushBack(v)
+		}
+		n := l.RemoveFunc(isEven)
+		assert.Equal(t, n, len(tt.values)-len(tt.want), "RemoveFunc(isEven) on %v: removed", tt.values)
+		checkList(t, &l, tt.want, "RemoveFunc")
+		l.PushBack(7)
+		checkList(t, &l, append(tt.want, 7), "PushBack after RemoveFunc")
+	}
+}
@@ -0,0 +1,64 @@
+//go:build !solutions
+
+package datastructures
+
+import "iter"
+
+// Exercise 29, part 2: A queue on a ring buffer
+//
+// JS's arr.shift() takes the first element off an array, and moves
+// every other element down one place to do it: O(n). Reslicing in Go,
+// q = q[1:], is O(1), but the slots before the new start can never be
+// used again, so a queue that's pushed to and popped from forever keeps
+// allocating.
+//
+// A ring buffer reuses them. The values are in buf from index head
+// on, wrapping around to the start of buf at the end:
+//
+//	buf:  [d e _ _ a b c]    head = 4, n = 5
+//
+// so the front is buf[head] and the i-th value is
+// buf[(head+i)%len(buf)]. Only when all of buf is in use does Push
+// need a bigger one.
+
+// Queue is first in, first out: a line at a shop.
+type Queue[T any] struct {
+	buf  []T // len(buf) is the capacity
+	head int // the index in buf of the front value
+	n    int // how many values there are
+}
+
+// 3. A ring buffer
+// Push adds v at the back. When buf is full (or nil), it moves the
+// values into a new buf twice as big, at least 4, starting at index 0.
+func (q *Queue[T]) Push(v T) {
+	// TODO: grow if q.n == len(q.buf), then put v at index
+	// (q.head+q.n) % len(q.buf)
+}
+
+// Pop removes and returns the front value, or the zero value and false
+// if the queue is empty. Like Stack.Pop, it clears the slot.
+func (q *Queue[T]) Pop() (T, bool) {
+	// TODO: head moves forward one, wrapping around
+	var zero T
+	return zero, false
+}
+
+// Peek returns the front value without removing it.
+func (q *Queue[T]) Peek() (T, bool) {
+	// TODO
+	var zero T
+	return zero, false
+}
+
+func (q *Queue[T]) Len() int {
+	// TODO
+	return 0
+}
+
+// All yields the values from the front to the back.
+func (q *Queue[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		// TODO
+	}
+}
@@ -0,0 +1,101 @@
+package datastructures
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/imgarylai/learn-go/internal/assert"
+)
+
+func TestQueue(t *testing.T) {
+	var q Queue[int]
+	if v, ok := q.Pop(); ok || v != 0 {
+		t.Errorf("Pop on an empty queue = %d, %t; want 0, false", v, ok)
+	}
+	if _, ok := q.Peek(); ok {
+		t.Error("Peek on an empty queue returned true")
+	}
+
+	for i := 1; i <= 3; i++ {
+		q.Push(i)
+	}
+	assert.Equal(t, q.Len(), 3, "Len after 3 Pushes")
+	if v, ok := q.Peek(); v != 1 || !ok {
+		t.Errorf("Peek = %d, %t; want 1, true", v, ok)
+	}
+	if v, ok := q.Pop(); v != 1 || !ok {
+		t.Errorf("Pop = %d, %t; want 1, true", v, ok)
+	}
+	assert.Equal(t, slices.Collect(q.All()), []int{2, 3}, "All")
+	assert.Equal(t, q.Len(), 2, "Len after All")
+}
+
+// TestQueueWraps pushes and pops in a pattern that wraps around the
+// ring buffer and grows it while it's wrapped, checking the order
+// against a plain slice.
+func TestQueueWraps(t *testing.T) {
+	var q Queue[int]
+	var want []int
+	next := 0
+	for round := range 40 {
+		for range round%5 + 1 {
+			q.Push(next)
+			want = append(want, next)
+			next++
+		}
+		for range round % 4 {
+			v, ok := q.Pop()
+			if !ok || v != want[0] {
+				t.Fatalf("round %d: Pop = %d, %t; want %d, true", round, v, ok, want[0])
+			}
+			want = want[1:]
+		}
+		if got := slices.Collect(q.All()); !slices.Equal(got, want) {
+			t.Fatalf("round %d: All = %v, want %v", round, got, want)
+		}
+		if v, ok := q.Peek(); !ok || v != want[0] {
+			t.Fatalf("round %d: Peek = %d, %t; want %d, true", round, v, ok, want[0])
+		}
+		assert.Equal(t, q.Len(), len(want), "round %d: Len", round)
+	}
+}
+
+func TestQueueReusesItsBuffer(t *testing.T) {
+	var q Queue[int]
+	for i, want := range []int{4, 4, 4, 4, 8, 8, 8, 8, 16} {
+		q.Push(i)
+		assert.Equal(t, len(q.buf), want, "len(buf) with %d values", i+1)
+	}
+	q = Queue[int]{}
+	for i := range 1000 {
+		q.Push(i)
+		q.Push(i)
+		q.Pop()
+		q.Pop()
+	}
+	if len(q.buf) > 4 {
+		t.Errorf("with at most 2 values queued at a time, buf has grown to %d; it should stay at 4", len(q.buf))
+	}
+
+	var p Queue[*int]
+	for i := range 3 {
+		p.Push(&i)
+	}
+	p.Pop()
+	for i, v := range p.buf {
+		if v != nil && (i-p.head+len(p.buf))%len(p.buf) >= p.n {
+			t.Errorf("Pop left a pointer in buf[%d]", i)
+		}
+	}
+}
+
+func TestQueueAllStops(t *testing.T) {
+	var q Queue[string]
+	for _, s := range []string{"a", "b", "c"} {
+		q.Push(s)
+	}
+	assert.Equal(t, firstN(q.All(), 2), []string{"a", "b"}, "All, stopped after 2")
+	if len(slices.Collect((&Queue[int]{}).All())) != 0 {
+		t.Error("All on an empty queue yielded values")
+	}
+}
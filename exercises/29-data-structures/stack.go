@@ -0,0 +1,79 @@
+//go:build !solutions
+
+package datastructures
+
+// Exercise 29: Classic data structures
+//
+// JS gets by with arrays and objects for almost everything, and so can
+// Go with slices and maps. But knowing what's underneath them, and
+// writing the few structures they don't cover, is part of the job. Each
+// file here is one structure, generic over what it holds, the way
+// exercise 18 wrote Set[T]:
+//
+//   - stack.go: a stack on a slice
+//   - queue.go: a queue on a ring buffer
+//   - list.go: a singly linked list
+//   - tree.go: a binary search tree
+//   - heap.go: a min-heap for container/heap
+//
+// The zero value of each but the heap, which needs to be told the
+// order, is empty and ready to use, like bytes.Buffer's and
+// sync.Mutex's: `var s Stack[int]` needs no constructor. Each has
+// an All method returning an iterator, an iter.Seq, so it works with
+// for-range:
+//
+//	for v := range s.All() { ... }
+//
+// An iter.Seq[T] is a func(yield func(T) bool). It calls yield for each
+// value and must stop as soon as yield returns false, which is what
+// `break` in the loop body does. It's the Go version of a JS generator
+// function, function* () { yield v }.
+//
+// Run tests with: go test -v
+
+import "iter"
+
+// Stack is last in, first out: JS's push and pop on an array.
+type Stack[T any] struct {
+	items []T // the top is the end
+}
+
+// 1. A stack on a slice
+// Push adds v on top.
+func (s *Stack[T]) Push(v T) {
+	// TODO
+}
+
+// Pop removes and returns the top value, or the zero value and false
+// if the stack is empty.
+//
+// Clear the slot Pop takes the value out of: the slice's backing array
+// keeps what's in it alive, so a popped pointer would never be garbage
+// collected until something overwrote it.
+func (s *Stack[T]) Pop() (T, bool) {
+	// TODO: var zero T is the zero value of any type
+	var zero T
+	return zero, false
+}
+
+// Peek returns the top value without removing it.
+func (s *Stack[T]) Peek() (T, bool) {
+	// TODO
+	var zero T
+	return zero, false
+}
+
+func (s *Stack[T]) Len() int {
+	// TODO
+	return 0
+}
+
+// 2. An iterator
+// All yields the values from the top down, the order Pop would return
+// them in, without changing the stack.
+func (s *Stack[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		// TODO: loop from the end of s.items, and return as soon as
+		// yield returns false
+	}
+}
@@ -0,0 +1,77 @@
+package datastructures
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/imgarylai/learn-go/internal/assert"
+)
+
+// firstN runs seq, stopping it after n values the way break in a
+// for-range loop does, and returns what it yielded.
+func firstN[T any](seq func(yield func(T) bool), n int) []T {
+	var got []T
+	seq(func(v T) bool {
+		got = append(got, v)
+		return len(got) < n
+	})
+	return got
+}
+
+func TestStack(t *testing.T) {
+	var s Stack[string]
+	if v, ok := s.Pop(); ok || v != "" {
+		t.Errorf("Pop on an empty stack = %q, %t; want \"\", false", v, ok)
+	}
+	if _, ok := s.Peek(); ok {
+		t.Error("Peek on an empty stack returned true")
+	}
+
+	for _, v := range []string{"a", "b", "c"} {
+		s.Push(v)
+	}
+	assert.Equal(t, s.Len(), 3, "Len after 3 Pushes")
+	if v, ok := s.Peek(); v != "c" || !ok {
+		t.Errorf("Peek = %q, %t; want c, true", v, ok)
+	}
+	assert.Equal(t, s.Len(), 3, "Len after Peek")
+
+	for _, want := range []string{"c", "b"} {
+		if v, ok := s.Pop(); v != want || !ok {
+			t.Errorf("Pop = %q, %t; want %q, true", v, ok, want)
+		}
+	}
+	s.Push("d")
+	assert.Equal(t, slices.Collect(s.All()), []string{"d", "a"}, "All after pushing again")
+	assert.Equal(t, s.Len(), 2, "Len after All")
+
+	for range 2 {
+		s.Pop()
+	}
+	if v, ok := s.Pop(); ok {
+		t.Errorf("Pop on an emptied stack = %q, true", v)
+	}
+	assert.Equal(t, s.Len(), 0, "Len of an emptied stack")
+}
+
+func TestStackAllStops(t *testing.T) {
+	var s Stack[int]
+	for i := range 5 {
+		s.Push(i)
+	}
+	assert.Equal(t, firstN(s.All(), 2), []int{4, 3}, "All, stopped after 2")
+	if len(slices.Collect((&Stack[int]{}).All())) != 0 {
+		t.Error("All on an empty stack yielded values")
+	}
+}
+
+func TestStackPopClears(t *testing.T) {
+	var s Stack[*int]
+	for i := range 3 {
+		s.Push(&i)
+	}
+	s.Pop()
+	if rest := s.items[:cap(s.items)]; len(s.items) < len(rest) && rest[len(s.items)] != nil {
+		t.Error("Pop left the popped pointer in the slice's backing array, so it can't be garbage collected")
+	}
+}
@@ -0,0 +1,94 @@
+//go:build !solutions
+
+package datastructures
+
+import (
+	"cmp"
+	"iter"
+)
+
+// Exercise 29, part 4: A binary search tree
+//
+// A map, Go's or JS's, finds a key fast but keeps no order. A binary
+// search tree keeps its keys sorted: everything in a node's left
+// subtree is smaller than its key and everything in its right subtree
+// bigger. So finding a key is a walk down from the root, left or right
+// at each node, and visiting left subtree, node, right subtree lists
+// every key in order.
+//
+// The walk is as long as the tree is high. In a tree of keys added in
+// random order that's O(log n), but add them already sorted and each
+// node hangs off the last one: a linked list, O(n). Balanced trees (a
+// red-black tree, a B-tree) rearrange themselves to prevent that; this
+// one doesn't.
+
+// Tree maps keys to values in key order.
+type Tree[K cmp.Ordered, V any] struct {
+	root *treeNode[K, V]
+	n    int
+}
+
+type treeNode[K cmp.Ordered, V any] struct {
+	key         K
+	value       V
+	left, right *treeNode[K, V]
+}
+
+// 6. Walking down a tree
+// Put sets the value of key, adding it if it's new.
+func (t *Tree[K, V]) Put(key K, value V) {
+	// TODO: walk down from t.root with a pointer to the link to follow,
+	// p := &t.root, so that adding a node is *p = &treeNode{...}
+}
+
+// Get returns the value of key and whether the tree has it.
+func (t *Tree[K, V]) Get(key K) (V, bool) {
+	// TODO
+	var zero V
+	return zero, false
+}
+
+func (t *Tree[K, V]) Len() int {
+	// TODO
+	return 0
+}
+
+// Min returns the smallest key, or false if the tree is empty.
+func (t *Tree[K, V]) Min() (K, bool) {
+	// TODO: keep going left
+	var zero K
+	return zero, false
+}
+
+// Height is how many nodes the longest path from the root down has: 0
+// for an empty tree, 1 for a tree of one key.
+func (t *Tree[K, V]) Height() int {
+	// TODO: recursion is easiest
+	return 0
+}
+
+// 7. Deleting
+// Delete removes key and reports whether the tree had it. A node with
+// no children just goes; one with a single child is replaced by that
+// child. One with two children is the hard case: take the smallest key
+// of its right subtree, which has no left child, out of there, and put
+// it in the node's place.
+func (t *Tree[K, V]) Delete(key K) bool {
+	// TODO
+	return false
+}
+
+// 8. An in-order iterator
+// All yields the keys and their values in key order. An iter.Seq2
+// yields two values at a time, the way range over a map does:
+//
+//	for k, v := range t.All() { ... }
+//
+// A recursive walk has to stop all the way up when yield returns false,
+// not only in the call that got it.
+func (t *Tree[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		// TODO: a helper func(n *treeNode[K, V]) bool that returns
+		// false once yield has
+	}
+}
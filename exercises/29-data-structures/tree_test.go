@@ -0,0 +1,177 @@
+package datastructures
+
+import (
+	"maps"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/imgarylai/learn-go/internal/assert"
+)
+
+// checkTree checks that t holds exactly want, in order, and that every
+// node is in the right place.
+func checkTree(t *testing.T, tree *Tree[int, string], want map[int]string, what string) {
+	t.Helper()
+	var keys []int
+	for k, v := range tree.All() {
+		keys = append(keys, k)
+		if v != want[k] {
+			t.Errorf("%s: All yielded %d: %q, want %q", what, k, v, want[k])
+		}
+	}
+	if len(keys) != 0 || len(want) != 0 {
+		assert.Equal(t, keys, slices.Sorted(maps.Keys(want)), "%s: keys in order", what)
+	}
+	assert.Equal(t, tree.Len(), len(want), "%s: Len", what)
+	var check func(n *treeNode[int, string], lo, hi int)
+	check = func(n *treeNode[int, string], lo, hi int) {
+		if n == nil {
+			return
+		}
+		if n.key <= lo || n.key >= hi {
+			t.Errorf("%s: node %d is under the wrong parent", what, n.key)
+			return
+		}
+		check(n.left, lo, n.key)
+		check(n.right, n.key, hi)
+	}
+	check(tree.root, -1<<31, 1<<31)
+}
+
+func TestTree(t *testing.T) {
+	var tree Tree[int, string]
+	checkTree(t, &tree, nil, "empty")
+	if _, ok := tree.Get(1); ok {
+		t.Error("Get on an empty tree returned true")
+	}
+	if _, ok := tree.Min(); ok {
+		t.Error("Min on an empty tree returned true")
+	}
+	assert.Equal(t, tree.Height(), 0, "Height of an empty tree")
+
+	want := map[int]string{}
+	for _, k := range []int{50, 30, 70, 20, 40, 60, 80, 35} {
+		tree.Put(k, "v"+string(rune('0'+k/10)))
+		want[k] = "v" + string(rune('0'+k/10))
+	}
+	checkTree(t, &tree, want, "after Puts")
+	tree.Put(40, "forty")
+	want[40] = "forty"
+	checkTree(t, &tree, want, "after replacing 40")
+
+	for k, v := range want {
+		if got, ok := tree.Get(k); got != v || !ok {
+			t.Errorf("Get(%d) = %q, %t; want %q, true", k, got, ok, v)
+		}
+	}
+	for _, k := range []int{0, 25, 45, 90} {
+		if v, ok := tree.Get(k); ok {
+			t.Errorf("Get(%d) = %q, true; want false", k, v)
+		}
+	}
+	if k, ok := tree.Min(); k != 20 || !ok {
+		t.Errorf("Min = %d, %t; want 20, true", k, ok)
+	}
+	assert.Equal(t, tree.Height(), 4, "Height: 50, 30, 40, 35")
+}
+
+func TestTreeHeight(t *testing.T) {
+	var tree Tree[int, string]
+	tree.Put(1, "")
+	assert.Equal(t, tree.Height(), 1, "one key")
+	for k := 2; k <= 10; k++ {
+		tree.Put(k, "")
+	}
+	assert.Equal(t, tree.Height(), 10, "keys added in order make a list")
+	tree.Put(0, "")
+	assert.Equal(t, tree.Height(), 10, "a key to the left of the root")
+}
+
+func TestTreeDelete(t *testing.T) {
+	build := func() (*Tree[int, string], map[int]string) {
+		var tree Tree[int, string]
+		want := map[int]string{}
+		//         50
+		//      30      70
+		//    20  40  60  80
+		//       35 45      90
+		for _, k := range []int{50, 30, 70, 20, 40, 60, 80, 35, 45, 90} {
+			tree.Put(k, "")
+			want[k] = ""
+		}
+		return &tree, want
+	}
+	for _, tt := range []struct {
+		name string
+		keys []int
+	}{
+		{"a leaf", []int{20}},
+		{"a node with a right child", []int{80}},
+		{"a node with a left child", []int{45, 40}},
+		{"a node with two children", []int{30}},
+		{"whose successor is its right child", []int{70}},
+		{"the root", []int{50}},
+		{"the root, again and again", []int{50, 60, 70, 80, 90}},
+		{"everything", []int{35, 50, 20, 90, 30, 45, 40, 80, 70, 60}},
+	} {
+		tree, want := build()
+		for _, k := range tt.keys {
+			if !tree.Delete(k) {
+				t.Errorf("deleting %s: Delete(%d) = false", tt.name, k)
+			}
+			delete(want, k)
+		}
+		checkTree(t, tree, want, "deleting "+tt.name)
+		if tree.Delete(tt.keys[0]) {
+			t.Errorf("deleting %s: Delete(%d) a second time = true", tt.name, tt.keys[0])
+		}
+		assert.Equal(t, tree.Len(), len(want), "deleting %s: Len after a Delete of a missing key", tt.name)
+	}
+
+	var empty Tree[int, string]
+	if empty.Delete(1) {
+		t.Error("Delete on an empty tree = true")
+	}
+}
+
+func TestTreeRandom(t *testing.T) {
+	r := rand.New(rand.NewPCG(3, 4))
+	var tree Tree[int, string]
+	want := map[int]string{}
+	for i := range 2000 {
+		k := r.IntN(200)
+		if r.IntN(3) == 0 {
+			_, had := want[k]
+			assert.Equal(t, tree.Delete(k), had, "step %d: Delete(%d)", i, k)
+			delete(want, k)
+		} else {
+			v := string(rune('a' + r.IntN(26)))
+			tree.Put(k, v)
+			want[k] = v
+		}
+	}
+	checkTree(t, &tree, want, "after random Puts and Deletes")
+}
+
+func TestTreeAllStops(t *testing.T) {
+	var tree Tree[string, int]
+	for i, k := range []string{"m", "f", "t", "a", "h", "p", "z"} {
+		tree.Put(k, i)
+	}
+	var keys []string
+	for k := range tree.All() {
+		keys = append(keys, k)
+		if k == "m" {
+			break
+		}
+	}
+	assert.Equal(t, keys, []string{"a", "f", "h", "m"}, "keys up to a break at m")
+
+	calls := 0
+	tree.All()(func(k string, _ int) bool {
+		calls++
+		return k != "f"
+	})
+	assert.Equal(t, calls, 2, "yield calls after it returned false at f")
+}
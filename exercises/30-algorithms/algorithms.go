@@ -0,0 +1,140 @@
+//go:build !solutions
+
+package algorithms
+
+import "errors"
+
+// Exercise 30: Algorithms practice
+//
+// The classic interview problems, done the Go way. Each one says how
+// you'd write it in JS first: the algorithm is the same, and the
+// differences are in the tools. Go has maps and slices but no Set, no
+// deque and no memoize helper; a map[K]bool is a set, and a slice with
+// append and reslicing is a stack or a queue.
+//
+// Run tests with: go test -v
+
+// Graph is a directed graph as an adjacency list: g["a"] lists the
+// nodes a has an edge to. A node that only appears in someone's list
+// has no edges of its own. In JS: a Map<string, string[]>.
+type Graph map[string][]string
+
+// 1. Breadth-first search
+// ShortestPath returns a path from from to to with the fewest edges,
+// both ends included, or nil if there's none. From a node to itself
+// the path is just that node.
+//
+// In JS: a queue array you shift() from, a Set of visited nodes, and a
+// Map from each node to the one you reached it from, to walk the path
+// back at the end. Visit each node's edges in the order they're
+// listed, so that ties are broken the same way every time.
+func ShortestPath(g Graph, from, to string) []string {
+	// TODO: mark a node visited when you queue it, not when you take
+	// it off the queue, or it can be queued twice
+	return nil
+}
+
+// 2. Depth-first search
+// DFS returns the nodes reachable from start in the order a
+// depth-first search visits them: start, then everything reachable
+// from its first edge, then from its second, and so on, each node once.
+//
+// In JS: a recursive function visit(node) and a Set. In Go a func
+// literal can't refer to itself by name as it's declared, so declare
+// it first: var visit func(string); visit = func(n string) { ... }.
+func DFS(g Graph, start string) []string {
+	// TODO
+	return nil
+}
+
+// ErrCycle is returned by TopoSort.
+var ErrCycle = errors.New("graph has a cycle")
+
+// 3. Topological sort
+// TopoSort orders every node of g, including the ones that only appear
+// in lists, so that each comes before all the nodes it has an edge to:
+// an order to build packages in, with edges from each to what depends
+// on it. If g has a cycle there's no such order; return ErrCycle.
+//
+// Depth-first again: a node's place is settled once everything it
+// reaches is, so list nodes as their visit finishes, and reverse the
+// list at the end. Start from the nodes in sorted order (slices.Sorted
+// of maps.Keys) so the result is always the same. A node reached again
+// while its own visit is still going is on a cycle: track three
+// states, not visited, in progress and done.
+func TopoSort(g Graph) ([]string, error) {
+	// TODO
+	return nil, nil
+}
+
+// 4. Two-sum with a map
+// TwoSum returns the indexes i < j of two numbers in nums that add up
+// to target, and true: of all the pairs, the one with the smallest j,
+// and then the smallest i. If there's none it returns 0, 0, false.
+//
+// In JS, the O(n²) way is two nested loops. The O(n) one is a single
+// loop with a Map from each number seen to its index: for each n, ask
+// whether target-n has been seen.
+func TwoSum(nums []int, target int) (int, int, bool) {
+	// TODO: seen := map[int]int{}
+	return 0, 0, false
+}
+
+// 5. Sliding window maximum
+// WindowMax returns the maximum of each window of k numbers in nums:
+// nums[0:k], nums[1:k+1], and so on, len(nums)-k+1 of them; none if k
+// is less than 1 or more than len(nums).
+//
+// Taking the max of each window is O(n·k). The O(n) way keeps a deque
+// of indexes whose numbers are decreasing: before pushing i, pop from
+// the back every index whose number is <= nums[i], since it can never
+// be a maximum again; drop the front once it falls out of the window.
+// The front is then the window's maximum. In JS the deque is an array
+// with push, pop and shift; here a slice, resliced at the front.
+func WindowMax(nums []int, k int) []int {
+	// TODO
+	return nil
+}
+
+// 6. Memoizing a recursive func
+// Memo turns f into a func that computes each result once. f gets, as
+// its first argument, the func to call for recursive calls, which is
+// the memoized one, so that they're cached too:
+//
+//	fib := Memo(func(fib func(int) int, n int) int { ... fib(n-1) ... })
+//
+// In JS you'd wrap the function and reassign its name, or use a Map in
+// a closure. Go doesn't let a func literal call itself by name, so the
+// recursive func comes in as an argument instead.
+func Memo[K comparable, V any](f func(recur func(K) V, k K) V) func(K) V {
+	// TODO: a map[K]V in a closure; declare the returned func first
+	// so it can pass itself to f
+	return func(k K) V {
+		var zero V
+		return zero
+	}
+}
+
+// Fib returns the n-th Fibonacci number, Fib(0) = 0 and Fib(1) = 1, with
+// Memo, in O(n): the plain recursive version makes O(2ⁿ) calls.
+func Fib(n int) int {
+	// TODO
+	return 0
+}
+
+// 7. Dynamic programming
+// EditDistance is the Levenshtein distance between a and b: the fewest
+// one-rune insertions, deletions and substitutions that turn a into b.
+// "kitten" to "sitting" takes 3.
+//
+// Fill in a table where d[i][j] is the distance between the first i
+// runes of a and the first j runes of b: d[i][0] = i, d[0][j] = j, and
+// each other cell is the smallest of the one above + 1 (a deletion),
+// the one to the left + 1 (an insertion) and the one diagonally up
+// and left, + 1 if a[i-1] != b[j-1] (a substitution). It's the same in
+// JS, with Array.from for the table. Each row only needs the one
+// before it, so two rows are enough.
+func EditDistance(a, b string) int {
+	// TODO: work on []rune(a) and []rune(b), not bytes
+	return 0
+}
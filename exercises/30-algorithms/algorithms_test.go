@@ -0,0 +1,263 @@
+package algorithms
+
+import (
+	"errors"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/imgarylai/learn-go/internal/assert"
+)
+
+// graph is the one the graph tests share:
+//
+//	a → b → d → f
+//	↓   ↑   ↓
+//	c → e → g   h → a
+var graph = Graph{
+	"a": {"b", "c"},
+	"b": {"d"},
+	"c": {"e"},
+	"d": {"f", "g"},
+	"e": {"b", "g"},
+	"h": {"a"},
+}
+
+func TestShortestPath(t *testing.T) {
+	for _, tt := range []struct {
+		from, to string
+		want     []string
+	}{
+		{"a", "f", []string{"a", "b", "d", "f"}},
+		{"a", "g", []string{"a", "b", "d", "g"}}, // a c e g is as short; b is listed first
+		{"c", "g", []string{"c", "e", "g"}},
+		{"c", "f", []string{"c", "e", "b", "d", "f"}},
+		{"h", "e", []string{"h", "a", "c", "e"}},
+		{"a", "a", []string{"a"}},
+		{"f", "f", []string{"f"}},
+		{"f", "a", nil},
+		{"a", "h", nil},
+		{"a", "nowhere", nil},
+	} {
+		got := ShortestPath(graph, tt.from, tt.to)
+		if len(got) != 0 || len(tt.want) != 0 {
+			assert.Equal(t, got, tt.want, "ShortestPath(%s, %s)", tt.from, tt.to)
+		}
+	}
+
+	// A cycle, and a node with an edge to itself.
+	loops := Graph{"x": {"x", "y"}, "y": {"z", "x"}, "z": {"y"}}
+	assert.Equal(t, ShortestPath(loops, "x", "z"), []string{"x", "y", "z"}, "around cycles")
+	if got := ShortestPath(loops, "x", "w"); got != nil {
+		t.Errorf("ShortestPath to a node not in a graph with cycles = %v", got)
+	}
+}
+
+func TestShortestPathLong(t *testing.T) {
+	// A ladder: every node has an edge to the next and to the one two
+	// on, so the shortest path takes the long steps.
+	g := Graph{}
+	name := func(i int) string { return string(rune('A' + i)) }
+	for i := range 26 {
+		for _, j := range []int{i + 1, i + 2} {
+			if j < 26 {
+				g[name(i)] = append(g[name(i)], name(j))
+			}
+		}
+	}
+	got := ShortestPath(g, "A", "Z")
+	assert.Equal(t, len(got), 14, "nodes on the path from A to Z")
+	if len(got) > 0 && (got[0] != "A" || got[len(got)-1] != "Z") {
+		t.Errorf("the path from A to Z is %v", got)
+	}
+}
+
+func TestDFS(t *testing.T) {
+	assert.Equal(t, DFS(graph, "a"), []string{"a", "b", "d", "f", "g", "c", "e"}, "DFS from a")
+	assert.Equal(t, DFS(graph, "h"), []string{"h", "a", "b", "d", "f", "g", "c", "e"}, "DFS from h")
+	assert.Equal(t, DFS(graph, "e"), []string{"e", "b", "d", "f", "g"}, "DFS from e")
+	assert.Equal(t, DFS(graph, "f"), []string{"f"}, "DFS from a node with no edges")
+	assert.Equal(t, DFS(Graph{"x": {"x", "y"}, "y": {"x"}}, "x"), []string{"x", "y"}, "DFS around cycles")
+}
+
+func TestTopoSort(t *testing.T) {
+	got, err := TopoSort(graph)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, got, []string{"h", "a", "c", "e", "b", "d", "g", "f"})
+
+	// Whatever the exact order, every edge has to point forward.
+	deps := Graph{
+		"std":    {"json", "http", "log"},
+		"json":   {"api"},
+		"http":   {"api", "server"},
+		"log":    {"server"},
+		"api":    {"server"},
+		"config": {"server", "log"},
+	}
+	got, err = TopoSort(deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, len(got), 7, "nodes in the order")
+	for from, tos := range deps {
+		for _, to := range tos {
+			if slices.Index(got, from) > slices.Index(got, to) {
+				t.Errorf("%s comes after %s in %v", from, to, got)
+			}
+		}
+	}
+
+	for _, g := range []Graph{
+		{"a": {"b"}, "b": {"c"}, "c": {"a"}},
+		{"a": {"a"}},
+		{"a": {"b"}, "b": {"c"}, "c": {"d"}, "d": {"b"}},
+		{"z": {"y"}, "y": {"z"}, "a": {"b"}},
+	} {
+		if got, err := TopoSort(g); !errors.Is(err, ErrCycle) {
+			t.Errorf("TopoSort(%v) = %v, %v; want ErrCycle", g, got, err)
+		}
+	}
+
+	if got, err := TopoSort(Graph{}); len(got) != 0 || err != nil {
+		t.Errorf("TopoSort of an empty graph = %v, %v", got, err)
+	}
+}
+
+func TestTwoSum(t *testing.T) {
+	for _, tt := range []struct {
+		nums   []int
+		target int
+		i, j   int
+		ok     bool
+	}{
+		{[]int{2, 7, 11, 15}, 9, 0, 1, true},
+		{[]int{3, 2, 4}, 6, 1, 2, true},
+		{[]int{3, 3}, 6, 0, 1, true},
+		{[]int{3}, 6, 0, 0, false},
+		{[]int{1, 5, 3, 3, 5}, 8, 1, 2, true},
+		{[]int{5, 5, 5}, 10, 0, 1, true},
+		{[]int{5, 1, 5}, 10, 0, 2, true},
+		{[]int{-3, 4, 1, 90}, -2, 0, 2, true},
+		{[]int{0, 4, 0}, 0, 0, 2, true},
+		{[]int{1, 2, 3}, 7, 0, 0, false},
+		{nil, 0, 0, 0, false},
+	} {
+		i, j, ok := TwoSum(tt.nums, tt.target)
+		if ok != tt.ok || i != tt.i || j != tt.j {
+			t.Errorf("TwoSum(%v, %d) = %d, %d, %t; want %d, %d, %t", tt.nums, tt.target, i, j, ok, tt.i, tt.j, tt.ok)
+		}
+	}
+}
+
+func naiveWindowMax(nums []int, k int) []int {
+	var out []int
+	for i := 0; k >= 1 && i+k <= len(nums); i++ {
+		out = append(out, slices.Max(nums[i:i+k]))
+	}
+	return out
+}
+
+func TestWindowMax(t *testing.T) {
+	for _, tt := range []struct {
+		nums []int
+		k    int
+		want []int
+	}{
+		{[]int{1, 3, -1, -3, 5, 3, 6, 7}, 3, []int{3, 3, 5, 5, 6, 7}},
+		{[]int{9, 8, 7, 6, 5}, 2, []int{9, 8, 7, 6}},
+		{[]int{1, 2, 3, 4, 5}, 2, []int{2, 3, 4, 5}},
+		{[]int{4, 4, 4, 1}, 2, []int{4, 4, 4}},
+		{[]int{5, 1, 1, 1, 1}, 3, []int{5, 1, 1}},
+		{[]int{2, 1}, 1, []int{2, 1}},
+		{[]int{2, 1}, 2, []int{2}},
+	} {
+		assert.Equal(t, WindowMax(tt.nums, tt.k), tt.want, "WindowMax(%v, %d)", tt.nums, tt.k)
+	}
+	for _, k := range []int{0, -1, 3} {
+		if got := WindowMax([]int{1, 2}, k); len(got) != 0 {
+			t.Errorf("WindowMax([1 2], %d) = %v, want none", k, got)
+		}
+	}
+
+	r := rand.New(rand.NewPCG(9, 10))
+	for range 50 {
+		nums := make([]int, r.IntN(40)+1)
+		for i := range nums {
+			nums[i] = r.IntN(10)
+		}
+		k := r.IntN(len(nums)) + 1
+		assert.Equal(t, WindowMax(nums, k), naiveWindowMax(nums, k), "WindowMax(%v, %d)", nums, k)
+	}
+}
+
+func TestMemo(t *testing.T) {
+	calls := map[int]int{}
+	fib := Memo(func(fib func(int) int, n int) int {
+		calls[n]++
+		if n < 2 {
+			return n
+		}
+		return fib(n-1) + fib(n-2)
+	})
+	assert.Equal(t, fib(40), 102334155, "fib(40)")
+	for n, c := range calls {
+		if c > 1 {
+			t.Errorf("computed fib(%d) %d times", n, c)
+		}
+	}
+	assert.Equal(t, len(calls), 41, "results computed for fib(40)")
+	fib(30)
+	assert.Equal(t, calls[30], 1, "computations of fib(30) after asking for it again")
+
+	// A func with a string key, counting the ways to split s into
+	// words from a dictionary.
+	words := map[string]bool{"a": true, "aa": true, "aaa": true}
+	splits := Memo(func(splits func(string) int, s string) int {
+		if s == "" {
+			return 1
+		}
+		total := 0
+		for i := 1; i <= len(s); i++ {
+			if words[s[:i]] {
+				total += splits(s[i:])
+			}
+		}
+		return total
+	})
+	assert.Equal(t, splits("aaaa"), 7, "ways to split aaaa")
+	assert.Equal(t, splits("aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"), 23837527729, "ways to split 40 a's")
+}
+
+func TestFib(t *testing.T) {
+	for n, want := range []int{0, 1, 1, 2, 3, 5, 8, 13, 21, 34, 55} {
+		assert.Equal(t, Fib(n), want, "Fib(%d)", n)
+	}
+	assert.Equal(t, Fib(90), 2880067194370816120, "Fib(90), in O(n)")
+}
+
+func TestEditDistance(t *testing.T) {
+	for _, tt := range []struct {
+		a, b string
+		want int
+	}{
+		{"kitten", "sitting", 3},
+		{"flaw", "lawn", 2},
+		{"", "", 0},
+		{"", "abc", 3},
+		{"abc", "", 3},
+		{"same", "same", 0},
+		{"abc", "acb", 2},
+		{"intention", "execution", 5},
+		{"a", "b", 1},
+		{"ab", "ba", 2},
+		{"abcdef", "azced", 3},
+		{"café", "cafe", 1},
+		{"日本語", "日本", 1},
+		{"héllo", "hello", 1},
+	} {
+		assert.Equal(t, EditDistance(tt.a, tt.b), tt.want, "EditDistance(%q, %q)", tt.a, tt.b)
+		assert.Equal(t, EditDistance(tt.b, tt.a), tt.want, "EditDistance(%q, %q)", tt.b, tt.a)
+	}
+}
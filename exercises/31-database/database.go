@@ -0,0 +1,215 @@
+//go:build !solutions
+
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	// Registers the "sqlite" driver, the same one the leaderboard server
+	// uses. It's SQLite translated to Go, so it needs no C compiler.
+	_ "modernc.org/sqlite"
+)
+
+// Exercise 31: Databases with database/sql
+//
+// In Node you pick a client library per database, pg or better-sqlite3,
+// and each has its own API. Go splits that in two: package database/sql
+// is the one API, and a driver package registers itself with it by
+// being imported, usually with _ since nothing else in it is used.
+// Switching databases means changing the driver and the SQL, not the
+// code around it.
+//
+// A *sql.DB isn't a connection but a pool of them, safe to share
+// between goroutines; open one when the program starts and keep it.
+// Every query takes its arguments as ? placeholders, never spliced into
+// the SQL with fmt.Sprintf: the driver sends them separately, so a name
+// like "Robert'); DROP TABLE people;--" is just a name.
+//
+// The tests run against a database file in a temp directory, with the
+// rows from testdata/people.csv and testdata/products.csv, the files of
+// exercise 7.
+//
+// Run tests with: go test -v
+
+// Person is a row of the people table.
+type Person struct {
+	ID    int64
+	Name  string
+	Age   int
+	Email string
+}
+
+// Product is a row of the products table.
+type Product struct {
+	ID       int64
+	Name     string
+	Price    float64
+	Category string
+}
+
+// ErrNotFound is returned when a row that was asked for isn't there.
+var ErrNotFound = errors.New("not found")
+
+// 1. Opening a database
+// Open opens the SQLite database file at path, creating it if it
+// doesn't exist, and checks that it can be used.
+//
+// sql.Open only checks its arguments and sets the pool up; it doesn't
+// connect, so a bad path isn't an error until the first query. Call
+// db.PingContext to find out now, and close the DB if that fails.
+//
+// Use a file, not ":memory:", outside one-connection setups like the
+// leaderboard's: every connection in the pool gets its own empty
+// in-memory database.
+func Open(ctx context.Context, path string) (*sql.DB, error) {
+	// TODO: sql.Open("sqlite", path), then db.PingContext(ctx)
+	return nil, nil
+}
+
+// 2. Creating tables
+// CreateTables creates the two tables, if they don't exist yet:
+//
+//	people:   id INTEGER PRIMARY KEY, name TEXT NOT NULL,
+//	          age INTEGER NOT NULL, email TEXT NOT NULL UNIQUE
+//	products: id INTEGER PRIMARY KEY, name TEXT NOT NULL,
+//	          price REAL NOT NULL, category TEXT NOT NULL
+//
+// Calling it on a database that has them already does nothing. In
+// SQLite, an INTEGER PRIMARY KEY column is filled in with the next
+// number when an insert leaves it out, like SERIAL in Postgres.
+func CreateTables(ctx context.Context, db *sql.DB) error {
+	// TODO: db.ExecContext with CREATE TABLE IF NOT EXISTS; the sqlite
+	// driver runs several statements separated by ; in one call
+	return nil
+}
+
+// Execer is what InsertPerson needs from a database: the ExecContext
+// method that *sql.DB, *sql.Tx and *sql.Conn all have. database/sql has
+// no interface for it, so the code that needs one declares it, the way
+// exercise 5 did with its own small interfaces.
+type Execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// 3. Inserting a row
+// InsertPerson inserts p into people, leaving p.ID for the database to
+// choose, and returns the ID it chose. A duplicate email is an error.
+// In JS (better-sqlite3):
+// db.prepare('INSERT INTO people (name, age, email) VALUES (?, ?, ?)').run(...).lastInsertRowid
+func InsertPerson(ctx context.Context, db Execer, p Person) (int64, error) {
+	// TODO: db.ExecContext with ? placeholders, then the Result's
+	// LastInsertId
+	return 0, nil
+}
+
+// 4. Querying one row
+// GetPerson returns the person with the given ID, or an error wrapping
+// ErrNotFound, with the ID in the message, if there's no such row.
+//
+// QueryRowContext never returns an error itself; its Scan does, and
+// it's sql.ErrNoRows when the query found nothing. Callers shouldn't
+// have to know about database/sql to check for a missing person, so
+// turn it into ErrNotFound, and pass any other error on.
+func GetPerson(ctx context.Context, db *sql.DB, id int64) (Person, error) {
+	// TODO: db.QueryRowContext(...).Scan(&p.ID, &p.Name, ...), then
+	// errors.Is(err, sql.ErrNoRows)
+	return Person{}, nil
+}
+
+// 5. Querying many rows
+// PeopleOlderThan returns the people older than age, youngest first,
+// and people of the same age by name. None is an empty result, not an
+// error.
+//
+// Rows is a cursor, like a Node stream: call Next until it returns
+// false, Scan each row, and check rows.Err afterwards, since Next also
+// returns false when reading fails. Close it when you're done, or its
+// connection never goes back to the pool; defer rows.Close() right
+// after the error check.
+func PeopleOlderThan(ctx context.Context, db *sql.DB, age int) ([]Person, error) {
+	// TODO: db.QueryContext with ORDER BY, then a rows.Next loop
+	return nil, nil
+}
+
+// 6. Transactions
+// ImportPeople reads people from CSV with a name,age,email header, the
+// format of testdata/people.csv, inserts them, and returns how many it
+// inserted. It's all or nothing: if a row is malformed or can't be
+// inserted, or ctx is canceled, it returns the error and inserts none
+// of them.
+//
+// db.BeginTx starts a transaction, a *sql.Tx, whose methods run on one
+// connection. Commit it at the end; on any error, Rollback. A common
+// way is to defer tx.Rollback() straight away: after a Commit it does
+// nothing. A *sql.Tx is an Execer, so InsertPerson works inside one.
+func ImportPeople(ctx context.Context, db *sql.DB, r io.Reader) (int, error) {
+	// TODO: csv.NewReader(r).ReadAll, BeginTx, InsertPerson(ctx, tx,
+	// ...) for each row after the header, Commit
+	return 0, nil
+}
+
+// 7. Prepared statements
+// InsertProducts inserts products, with their IDs, in one transaction:
+// all of them or, on an error such as an ID that's taken or a canceled
+// ctx, none.
+//
+// Running the same INSERT many times, it pays to prepare it once:
+// tx.PrepareContext parses the SQL and returns a *sql.Stmt, whose
+// ExecContext takes just the arguments. Close it when you're done.
+func InsertProducts(ctx context.Context, db *sql.DB, products []Product) error {
+	// TODO: BeginTx, tx.PrepareContext, stmt.ExecContext for each
+	// product, Commit
+	return nil
+}
+
+// CategoryTotal is a row of CategoryTotals.
+type CategoryTotal struct {
+	Category string
+	Count    int
+	Total    float64 // the sum of the prices
+}
+
+// 8. Scanning into structs
+// CategoryTotals returns, for each category of products, how many there
+// are and what they cost together, ordered by category.
+//
+// database/sql has no ORM: a row is scanned into variables, one per
+// column, in order. Aggregates work the same way as columns.
+func CategoryTotals(ctx context.Context, db *sql.DB) ([]CategoryTotal, error) {
+	// TODO: SELECT category, COUNT(*), SUM(price) ... GROUP BY category
+	return nil, nil
+}
+
+// 9. Results of an update
+// SetPrice changes the price of the product with the given ID. If
+// there's no such product, it returns an error wrapping ErrNotFound:
+// an UPDATE that matches nothing isn't an error to SQL, but the Result
+// says how many rows it changed.
+func SetPrice(ctx context.Context, db *sql.DB, id int64, price float64) error {
+	// TODO: ExecContext, then RowsAffected
+	return nil
+}
+
+// 10. NULL
+// MaxPrice returns the highest price in category, and false if the
+// category has no products.
+//
+// An aggregate over no rows still returns one row, so this isn't
+// sql.ErrNoRows: it's a row whose MAX is NULL. Scanning NULL into a
+// float64 is an error; scan into a sql.NullFloat64, whose Valid says
+// whether there was a value, the way a JS driver would give you null.
+func MaxPrice(ctx context.Context, db *sql.DB, category string) (float64, bool, error) {
+	// TODO
+	return 0, false, nil
+}
+
+// Keep imports used
+var _ = csv.NewReader
+var _ = fmt.Errorf
+var _ = strconv.Atoi
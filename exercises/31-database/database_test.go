@@ -0,0 +1,411 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"errors"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/imgarylai/learn-go/internal/assert"
+)
+
+// openTest opens a new database file in a temp dir, with the tables
+// created.
+func openTest(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := Open(context.Background(), filepath.Join(t.TempDir(), "test.db"))
+	if err != nil || db == nil {
+		t.Fatalf("Open() = %v, %v", db, err)
+	}
+	t.Cleanup(func() { db.Close() })
+	if err := CreateTables(context.Background(), db); err != nil {
+		t.Fatalf("CreateTables() = %v", err)
+	}
+	return db
+}
+
+// withPeople is openTest with testdata/people.csv imported.
+func withPeople(t *testing.T) *sql.DB {
+	t.Helper()
+	db := openTest(t)
+	f, err := os.Open("testdata/people.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if n, err := ImportPeople(context.Background(), db, f); n != 5 || err != nil {
+		t.Fatalf("ImportPeople(testdata/people.csv) = %d, %v; want 5, nil", n, err)
+	}
+	return db
+}
+
+// readProducts reads testdata/products.csv.
+func readProducts(t *testing.T) []Product {
+	t.Helper()
+	f, err := os.Open("testdata/products.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	records, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var products []Product
+	for _, rec := range records[1:] {
+		id, _ := strconv.ParseInt(rec[0], 10, 64)
+		price, _ := strconv.ParseFloat(rec[2], 64)
+		products = append(products, Product{ID: id, Name: rec[1], Price: price, Category: rec[3]})
+	}
+	return products
+}
+
+// withProducts is openTest with testdata/products.csv inserted.
+func withProducts(t *testing.T) *sql.DB {
+	t.Helper()
+	db := openTest(t)
+	if err := InsertProducts(context.Background(), db, readProducts(t)); err != nil {
+		t.Fatalf("InsertProducts(testdata/products.csv) = %v", err)
+	}
+	return db
+}
+
+// count returns the number of rows in table, read with plain SQL.
+func count(t *testing.T, db *sql.DB, table string) int {
+	t.Helper()
+	var n int
+	if err := db.QueryRow("SELECT COUNT(*) FROM " + table).Scan(&n); err != nil {
+		t.Fatal(err)
+	}
+	return n
+}
+
+// cents rounds a sum of prices, which floating point leaves a little
+// off, to whole cents.
+func cents(x float64) float64 { return math.Round(x*100) / 100 }
+
+func TestOpen(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "new.db")
+	db, err := Open(ctx, path)
+	if err != nil || db == nil {
+		t.Fatalf("Open(%q) = %v, %v", path, db, err)
+	}
+	defer db.Close()
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("after Open, the database file should exist: %v", err)
+	}
+
+	// sql.Open alone would succeed here; only connecting finds out.
+	bad := filepath.Join(t.TempDir(), "no", "such", "dir", "x.db")
+	if db, err := Open(ctx, bad); err == nil {
+		db.Close()
+		t.Errorf("Open(%q) = nil error; want one: ping the database", bad)
+	}
+}
+
+func TestCreateTables(t *testing.T) {
+	ctx := context.Background()
+	db := openTest(t)
+	if err := CreateTables(ctx, db); err != nil {
+		t.Errorf("CreateTables() a second time = %v; want nil (IF NOT EXISTS)", err)
+	}
+	for _, q := range []string{
+		`INSERT INTO people (name, age, email) VALUES ('Ann', 40, 'ann@example.com')`,
+		`INSERT INTO products (name, price, category) VALUES ('Pen', 1.5, 'Office')`,
+	} {
+		if _, err := db.Exec(q); err != nil {
+			t.Errorf("%s: %v", q, err)
+		}
+	}
+	for _, q := range []string{
+		`INSERT INTO people (name, age, email) VALUES ('Ann 2', 41, 'ann@example.com')`, // email UNIQUE
+		`INSERT INTO people (name, age) VALUES ('Ben', 20)`,                             // email NOT NULL
+		`INSERT INTO people (age, email) VALUES (20, 'nameless@example.com')`,           // name NOT NULL
+		`INSERT INTO products (name, category) VALUES ('Free', 'Office')`,               // price NOT NULL
+	} {
+		if _, err := db.Exec(q); err == nil {
+			t.Errorf("%s succeeded; want a constraint error", q)
+		}
+	}
+}
+
+func TestInsertPerson(t *testing.T) {
+	ctx := context.Background()
+	db := openTest(t)
+	ann := Person{Name: "Ann", Age: 40, Email: "ann@example.com"}
+	id, err := InsertPerson(ctx, db, ann)
+	assert.Equal(t, id, int64(1), "InsertPerson(%v) = %d, %v", ann, id, err)
+	ben := Person{ID: 7, Name: "Ben", Age: 20, Email: "ben@example.com"}
+	id, err = InsertPerson(ctx, db, ben)
+	assert.Equal(t, id, int64(2), "InsertPerson(%v) = %d, %v; the ID is the database's to choose", ben, id, err)
+
+	var got Person
+	db.QueryRow(`SELECT id, name, age, email FROM people WHERE id = 2`).Scan(&got.ID, &got.Name, &got.Age, &got.Email)
+	assert.Equal(t, got, Person{ID: 2, Name: "Ben", Age: 20, Email: "ben@example.com"}, "row 2")
+
+	if id, err := InsertPerson(ctx, db, Person{Name: "Ann again", Age: 41, Email: "ann@example.com"}); id != 0 || err == nil {
+		t.Errorf("InsertPerson with a duplicate email = %d, %v; want 0 and an error", id, err)
+	}
+
+	// Quotes in a value are data, not SQL.
+	bobby := Person{Name: "Robert'); DROP TABLE people;--", Age: 10, Email: "bobby@example.com"}
+	id, err = InsertPerson(ctx, db, bobby)
+	if err != nil {
+		t.Fatalf("InsertPerson(%v) = %v; use ? placeholders", bobby, err)
+	}
+	var name string
+	db.QueryRow(`SELECT name FROM people WHERE id = ?`, id).Scan(&name)
+	assert.Equal(t, name, bobby.Name, "name stored for %d", id)
+
+	// A transaction is an Execer too; rolled back, its insert is gone.
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := InsertPerson(ctx, tx, Person{Name: "Cy", Age: 30, Email: "cy@example.com"}); err != nil {
+		t.Errorf("InsertPerson in a transaction = %v", err)
+	}
+	tx.Rollback()
+	assert.Equal(t, count(t, db, "people"), 3, "people after a rolled-back insert")
+}
+
+func TestGetPerson(t *testing.T) {
+	ctx := context.Background()
+	db := withPeople(t)
+	got, err := GetPerson(ctx, db, 3)
+	assert.Equal(t, got, Person{ID: 3, Name: "Charlie", Age: 35, Email: "charlie@example.com"}, "GetPerson(3) (err %v)", err)
+
+	_, err = GetPerson(ctx, db, 42)
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetPerson(42) error = %v; want one wrapping ErrNotFound", err)
+	} else if !strings.Contains(err.Error(), "42") {
+		t.Errorf("GetPerson(42) error = %q; want the ID in it", err)
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("GetPerson(42) error = %v; callers shouldn't see sql.ErrNoRows", err)
+	}
+
+	// Other errors are not ErrNotFound.
+	if _, err := db.Exec(`DROP TABLE people`); err != nil {
+		t.Fatal(err)
+	}
+	_, err = GetPerson(ctx, db, 3)
+	if err == nil || errors.Is(err, ErrNotFound) {
+		t.Errorf("GetPerson(3) with no people table = %v; want the database's error", err)
+	}
+}
+
+func TestPeopleOlderThan(t *testing.T) {
+	ctx := context.Background()
+	db := withPeople(t)
+	if _, err := InsertPerson(ctx, db, Person{Name: "Aaron", Age: 30, Email: "aaron@example.com"}); err != nil {
+		t.Fatal(err)
+	}
+	got, err := PeopleOlderThan(ctx, db, 25)
+	assert.Equal(t, got, []Person{
+		{ID: 4, Name: "Diana", Age: 28, Email: "diana@example.com"},
+		{ID: 6, Name: "Aaron", Age: 30, Email: "aaron@example.com"},
+		{ID: 1, Name: "Alice", Age: 30, Email: "alice@example.com"},
+		{ID: 3, Name: "Charlie", Age: 35, Email: "charlie@example.com"},
+	}, "PeopleOlderThan(25) (err %v)", err)
+
+	got, err = PeopleOlderThan(ctx, db, 35)
+	if len(got) != 0 || err != nil {
+		t.Errorf("PeopleOlderThan(35) = %v, %v; want none, nil", got, err)
+	}
+
+	// SQLite keeps a value an INTEGER column can't convert as it is;
+	// it doesn't Scan into an int.
+	if _, err := db.Exec(`INSERT INTO people (name, age, email) VALUES ('Odd', 'old', 'odd@example.com')`); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := PeopleOlderThan(ctx, db, 25); err == nil {
+		t.Errorf("PeopleOlderThan(25) with an age of 'old' = %v, nil; want Scan's error", got)
+	}
+
+	if _, err := db.Exec(`DROP TABLE people`); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := PeopleOlderThan(ctx, db, 25); err == nil {
+		t.Errorf("PeopleOlderThan with no people table = nil error")
+	}
+}
+
+func TestImportPeople(t *testing.T) {
+	ctx := context.Background()
+	db := withPeople(t)
+	got, _ := PeopleOlderThan(ctx, db, 0)
+	assert.Equal(t, got, []Person{
+		{ID: 5, Name: "Eve", Age: 22, Email: "eve@example.com"},
+		{ID: 2, Name: "Bob", Age: 25, Email: "bob@example.com"},
+		{ID: 4, Name: "Diana", Age: 28, Email: "diana@example.com"},
+		{ID: 1, Name: "Alice", Age: 30, Email: "alice@example.com"},
+		{ID: 3, Name: "Charlie", Age: 35, Email: "charlie@example.com"},
+	}, "everyone after importing testdata/people.csv")
+
+	n, err := ImportPeople(ctx, db, strings.NewReader("name,age,email\n"))
+	if n != 0 || err != nil {
+		t.Errorf("ImportPeople(header only) = %d, %v; want 0, nil", n, err)
+	}
+}
+
+func TestImportPeopleAllOrNothing(t *testing.T) {
+	for _, tt := range []struct {
+		name, csv string
+		line      string // in the error
+	}{
+		{"bad age", "name,age,email\nFay,40,fay@example.com\nGus,old,gus@example.com\n", "line 3"},
+		{"missing field", "name,age,email\nFay,40,fay@example.com\nGus,50\n", "line 3"},
+		{"duplicate email", "name,age,email\nFay,40,fay@example.com\nGus,50,alice@example.com\n", "line 3"},
+		{"duplicate in the file", "name,age,email\nFay,40,fay@example.com\nGus,50,fay@example.com\n", "line 3"},
+		{"no email column", "name,age\nFay,40\n", "line 2"},
+		{"bad quoting", "name,age,email\nFay,40,\"fay@example.com\n", "line 2"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			db := withPeople(t)
+			n, err := ImportPeople(context.Background(), db, strings.NewReader(tt.csv))
+			if n != 0 || err == nil {
+				t.Errorf("ImportPeople(%q) = %d, %v; want 0 and an error", tt.csv, n, err)
+			} else if !strings.Contains(err.Error(), tt.line) {
+				t.Errorf("ImportPeople(%q) error = %q; want %q in it", tt.csv, err, tt.line)
+			}
+			assert.Equal(t, count(t, db, "people"), 5, "people after a failed import: roll back the first row too")
+		})
+	}
+
+	t.Run("canceled", func(t *testing.T) {
+		db := withPeople(t)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		n, err := ImportPeople(ctx, db, strings.NewReader("name,age,email\nFay,40,fay@example.com\n"))
+		if n != 0 || !errors.Is(err, context.Canceled) {
+			t.Errorf("ImportPeople with a canceled context = %d, %v; want 0, context.Canceled", n, err)
+		}
+		assert.Equal(t, count(t, db, "people"), 5, "people after a canceled import")
+	})
+}
+
+func TestInsertProducts(t *testing.T) {
+	ctx := context.Background()
+	db := withProducts(t)
+	var got Product
+	db.QueryRow(`SELECT id, name, price, category FROM products WHERE id = 3`).Scan(&got.ID, &got.Name, &got.Price, &got.Category)
+	assert.Equal(t, got, Product{ID: 3, Name: "Coffee Mug", Price: 12.99, Category: "Kitchen"}, "product 3")
+	assert.Equal(t, count(t, db, "products"), 8, "products")
+
+	more := []Product{
+		{ID: 9, Name: "Stapler", Price: 8.5, Category: "Office"},
+		{ID: 4, Name: "Another Notebook", Price: 5, Category: "Office"}, // 4 is taken
+	}
+	if err := InsertProducts(ctx, db, more); err == nil {
+		t.Errorf("InsertProducts with a taken ID = nil error")
+	}
+	assert.Equal(t, count(t, db, "products"), 8, "products after a failed insert: roll back the first one too")
+
+	if err := InsertProducts(ctx, db, nil); err != nil {
+		t.Errorf("InsertProducts(nil) = %v", err)
+	}
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	if err := InsertProducts(canceled, db, more[:1]); !errors.Is(err, context.Canceled) {
+		t.Errorf("InsertProducts with a canceled context = %v; want context.Canceled", err)
+	}
+	assert.Equal(t, count(t, db, "products"), 8, "products after a canceled insert")
+
+	if _, err := db.Exec(`DROP TABLE products`); err != nil {
+		t.Fatal(err)
+	}
+	if err := InsertProducts(ctx, db, more[:1]); err == nil {
+		t.Errorf("InsertProducts with no products table = nil error")
+	}
+}
+
+func TestCategoryTotals(t *testing.T) {
+	db := withProducts(t)
+	got, err := CategoryTotals(context.Background(), db)
+	if err != nil {
+		t.Fatalf("CategoryTotals() error = %v", err)
+	}
+	for i := range got {
+		got[i].Total = cents(got[i].Total)
+	}
+	assert.Equal(t, got, []CategoryTotal{
+		{"Accessories", 1, 49.99},
+		{"Electronics", 3, 1109.97},
+		{"Kitchen", 2, 32.98},
+		{"Office", 2, 39.98},
+	}, "CategoryTotals()")
+
+	got, err = CategoryTotals(context.Background(), openTest(t))
+	if len(got) != 0 || err != nil {
+		t.Errorf("CategoryTotals() with no products = %v, %v; want none, nil", got, err)
+	}
+
+	if _, err := db.Exec(`DROP TABLE products`); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := CategoryTotals(context.Background(), db); err == nil {
+		t.Errorf("CategoryTotals() with no products table = %v, nil; want an error", got)
+	}
+}
+
+func TestSetPrice(t *testing.T) {
+	ctx := context.Background()
+	db := withProducts(t)
+	if err := SetPrice(ctx, db, 6, 24.99); err != nil {
+		t.Errorf("SetPrice(6, 24.99) = %v", err)
+	}
+	var price float64
+	db.QueryRow(`SELECT price FROM products WHERE id = 6`).Scan(&price)
+	assert.Equal(t, price, 24.99, "price of 6 after SetPrice")
+	db.QueryRow(`SELECT price FROM products WHERE id = 2`).Scan(&price)
+	assert.Equal(t, price, 79.99, "price of 2 after SetPrice(6, ...)")
+
+	err := SetPrice(ctx, db, 42, 1)
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("SetPrice(42, 1) = %v; want an error wrapping ErrNotFound", err)
+	} else if !strings.Contains(err.Error(), "42") {
+		t.Errorf("SetPrice(42, 1) = %q; want the ID in it", err)
+	}
+
+	if _, err := db.Exec(`DROP TABLE products`); err != nil {
+		t.Fatal(err)
+	}
+	if err := SetPrice(ctx, db, 6, 1); err == nil || errors.Is(err, ErrNotFound) {
+		t.Errorf("SetPrice with no products table = %v; want the database's error", err)
+	}
+}
+
+func TestMaxPrice(t *testing.T) {
+	ctx := context.Background()
+	db := withProducts(t)
+	for _, tt := range []struct {
+		category string
+		want     float64
+		ok       bool
+	}{
+		{"Electronics", 999.99, true},
+		{"Kitchen", 19.99, true},
+		{"Garden", 0, false},
+	} {
+		got, ok, err := MaxPrice(ctx, db, tt.category)
+		if got != tt.want || ok != tt.ok || err != nil {
+			t.Errorf("MaxPrice(%q) = %v, %v, %v; want %v, %v, nil", tt.category, got, ok, err, tt.want, tt.ok)
+		}
+	}
+
+	if _, err := db.Exec(`DROP TABLE products`); err != nil {
+		t.Fatal(err)
+	}
+	if got, ok, err := MaxPrice(ctx, db, "Kitchen"); got != 0 || ok || err == nil {
+		t.Errorf("MaxPrice with no products table = %v, %v, %v; want 0, false and an error", got, ok, err)
+	}
+}
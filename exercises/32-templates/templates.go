@@ -0,0 +1,252 @@
+//go:build !solutions
+
+package templates
+
+import (
+	"bytes"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// Exercise 32: Text and HTML templates
+//
+// JS builds text with template literals, `Hello, ${name}!`, and HTML
+// with a library: Handlebars, EJS, or JSX. Go has two template packages
+// in the standard library with the same syntax:
+//
+//   - text/template, for any text: reports, emails, config files, code
+//   - html/template, for web pages: the same API, but every value is
+//     escaped for where it lands in the HTML, so data can't inject tags
+//     or scripts
+//
+// A template is parsed once and then executed any number of times with
+// some data, the "dot": {{.Product}} is a field of it, {{.Revenue}}
+// calls its method, and {{range}}, {{if}} and {{with}} work on it.
+// Real code usually parses its templates once, into a package-level
+// var with template.Must; here each func parses its own.
+//
+// The data is exercise 8's sales; testdata/sales.csv is its file.
+//
+// Run tests with: go test -v
+
+// Sale is a sales record, like exercise 8's.
+type Sale struct {
+	Product  string
+	Quantity int
+	Price    float64
+	Region   string
+}
+
+// Revenue is what the sale brought in. Templates can call methods
+// that take no arguments like fields: {{.Revenue}}.
+func (s Sale) Revenue() float64 {
+	return float64(s.Quantity) * s.Price
+}
+
+// 1. Executing a template
+// FormatSale describes s on one line:
+//
+//	Widget: 10 x 25.00 = 250.00 (North)
+//
+// Parse a template with template.New(name).Parse(text) and execute it
+// with s as the data into a strings.Builder. printf in a template works
+// like fmt.Sprintf: {{printf "%.2f" .Price}}.
+func FormatSale(s Sale) (string, error) {
+	// TODO
+	return "", nil
+}
+
+// 2. range
+// ListSales lists sales, one line each, and "No sales." when there are
+// none:
+//
+//   - Widget, North: 250.00
+//   - Gadget, South: 250.00
+//
+// Every line ends with "\n". {{range .}} runs its body once per
+// element, with the element as the dot, and its {{else}} when there
+// are none. Template text is copied as it is, newlines included; a
+// dash inside the braces, {{- or -}}, trims the spaces and newlines
+// next to it, so a template can span lines without adding blank ones.
+func ListSales(sales []Sale) (string, error) {
+	// TODO
+	return "", nil
+}
+
+// 3. Funcs and pipelines
+// Funcs returns the funcs the templates below can call:
+//
+//   - money formats a float64 with two decimals and commas between
+//     thousands: 2740 is "2,740.00", -1234.5 is "-1,234.50", and
+//     -0.001 is "0.00", not "-0.00"
+//   - upper is strings.ToUpper
+//   - pad(width, s) pads s with spaces on the right to width runes, and
+//     padLeft(width, s) on the left; longer strings are left alone
+//
+// Add them to a template with .Funcs before it's parsed. As in a shell,
+// a pipeline passes each value on as the last argument of the next
+// call: {{.Revenue | money | padLeft 11}} is padLeft 11 (money
+// .Revenue).
+func Funcs() template.FuncMap {
+	// TODO: return template.FuncMap{"money": ..., ...}
+	return template.FuncMap{}
+}
+
+// RegionTotal is a row of the sales report.
+type RegionTotal struct {
+	Region  string
+	Sales   int // how many sales
+	Units   int
+	Revenue float64
+}
+
+// ProductTotal is a product and all it brought in.
+type ProductTotal struct {
+	Product string
+	Revenue float64
+}
+
+// Summary is what the sales report shows. The sums are worked out in
+// Go: a template is for layout, and has no arithmetic of its own.
+type Summary struct {
+	Regions []RegionTotal // by region name
+	Total   RegionTotal   // Region is "Total"
+	Top     *ProductTotal // the most revenue, the first by name on a tie; nil with no sales
+}
+
+// Summarize works out the Summary of sales.
+func Summarize(sales []Sale) Summary {
+	sum := Summary{Total: RegionTotal{Region: "Total"}}
+	regions := map[string]*RegionTotal{}
+	products := map[string]float64{}
+	for _, s := range sales {
+		r := regions[s.Region]
+		if r == nil {
+			r = &RegionTotal{Region: s.Region}
+			regions[s.Region] = r
+		}
+		for _, t := range []*RegionTotal{r, &sum.Total} {
+			t.Sales++
+			t.Units += s.Quantity
+			t.Revenue += s.Revenue()
+		}
+		products[s.Product] += s.Revenue()
+	}
+	for _, r := range regions {
+		sum.Regions = append(sum.Regions, *r)
+	}
+	slices.SortFunc(sum.Regions, func(a, b RegionTotal) int {
+		return strings.Compare(a.Region, b.Region)
+	})
+	for p, rev := range products {
+		if sum.Top == nil || rev > sum.Top.Revenue || rev == sum.Top.Revenue && p < sum.Top.Product {
+			sum.Top = &ProductTotal{Product: p, Revenue: rev}
+		}
+	}
+	return sum
+}
+
+// 4. define, template and with
+// SalesReport writes the report on sales to w:
+//
+//	SALES REPORT
+//
+//	Region     Sales  Units     Revenue
+//	East           2     15      450.00
+//	North          3     34    1,150.00
+//	Total          5     49    1,600.00
+//
+//	Top product: Gadget (600.00)
+//
+// The region is padded to 10, Sales to 5, Units to 6 and Revenue,
+// with money, to 11, with a space between them; the heading is padded
+// the same. With no sales there's only the Total row, and no blank line
+// and top product after it. Every line ends with "\n", and the tests
+// compare with the golden files testdata/report-*.golden.
+//
+// Execute the template with Summarize(sales). The region rows and the
+// total are laid out the same: write that once, with
+// {{define "row"}}...{{end}}, and use it with {{template "row" .}}.
+// {{with .Top}} runs its body only if .Top isn't nil (or otherwise
+// empty), with .Top as the dot.
+func SalesReport(w io.Writer, sales []Sale) error {
+	// TODO: template.New("report").Funcs(Funcs()).Parse(...), then
+	// Execute(w, Summarize(sales))
+	return nil
+}
+
+// 5. Template errors
+// Render parses text as a template with Funcs and executes it with
+// data. A template can fail twice: Parse finds syntax errors, and
+// Execute finds what only the data can tell, like a field that isn't
+// there. Return either error, and "" with it.
+//
+// Execute may have written part of the output before it fails, so
+// execute into a bytes.Buffer and only use it if there was no error.
+// And by default, a key missing from a map prints "<no value>";
+// .Option("missingkey=error") makes it an error, which catches typos.
+func Render(text string, data any) (string, error) {
+	// TODO
+	return "", nil
+}
+
+// 6. Trusted HTML
+// Highlight wraps s in <mark>, escaping s: Highlight("a<b") is
+// "<mark>a&lt;b</mark>".
+//
+// html/template escapes every string it prints, but not a
+// template.HTML: that's a promise that the string is safe HTML already.
+// Returning one is how a func adds markup to a page; escape the parts
+// that came from data yourself, with template.HTMLEscapeString, or the
+// promise is a lie and an injection.
+func Highlight(s string) htmltemplate.HTML {
+	// TODO
+	return ""
+}
+
+// 7. html/template
+// SalesPage writes an HTML page listing sales to w. Each sale is a row
+// of a table, linking to its product page and showing its region and
+// revenue (with money); the top product, from Summarize, is in a
+// paragraph with Highlight; and the sales are in a script, for the
+// page's JS:
+//
+//	<!DOCTYPE html>
+//	<html>
+//	<head><title>{{.Title}}</title></head>
+//	<body>
+//	<h1>{{.Title}}</h1>
+//	<table>
+//	<tr><td><a href="/products?name={{.Product}}">{{.Product}}</a></td><td>...</td><td>...</td></tr>
+//	</table>
+//	<p>Top product: ...</p>
+//	<script>const sales = {{.Sales}};</script>
+//	</body>
+//	</html>
+//
+// Leave the paragraph out when there are no sales.
+//
+// The template is the same as with text/template; what differs is that
+// html/template knows where each value lands. The same product name is
+// HTML-escaped in the cell, query-escaped in the link, and written as
+// JSON in the script, so a product called "<script>alert(1)</script>"
+// is shown as text and never runs. In a file that uses both packages,
+// give one of them another name, as this one does with htmltemplate.
+func SalesPage(w io.Writer, title string, sales []Sale) error {
+	// TODO: htmltemplate.New("page").Funcs(...).Parse(...); the funcs
+	// are Funcs() converted, htmltemplate.FuncMap(Funcs()), plus
+	// highlight
+	return nil
+}
+
+// Keep imports used
+var _ = bytes.NewBuffer
+var _ = fmt.Sprintf
+var _ = math.Abs
+var _ = strconv.FormatFloat
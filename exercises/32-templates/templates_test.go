@@ -0,0 +1,242 @@
+package templates
+
+import (
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/imgarylai/learn-go/internal/assert"
+	"github.com/imgarylai/learn-go/internal/testutil"
+)
+
+// readSales reads testdata/sales.csv.
+func readSales(t *testing.T) []Sale {
+	t.Helper()
+	f, err := os.Open("testdata/sales.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	records, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var sales []Sale
+	for _, rec := range records[1:] {
+		qty, _ := strconv.Atoi(rec[1])
+		price, _ := strconv.ParseFloat(rec[2], 64)
+		sales = append(sales, Sale{Product: rec[0], Quantity: qty, Price: price, Region: rec[3]})
+	}
+	return sales
+}
+
+// inRegions returns the sales in the given regions.
+func inRegions(sales []Sale, regions ...string) []Sale {
+	var in []Sale
+	for _, s := range sales {
+		for _, r := range regions {
+			if s.Region == r {
+				in = append(in, s)
+			}
+		}
+	}
+	return in
+}
+
+func TestFormatSale(t *testing.T) {
+	for _, tt := range []struct {
+		sale Sale
+		want string
+	}{
+		{Sale{"Widget", 10, 25, "North"}, "Widget: 10 x 25.00 = 250.00 (North)"},
+		{Sale{"Coffee Mug", 3, 12.99, "East"}, "Coffee Mug: 3 x 12.99 = 38.97 (East)"},
+		{Sale{"Free Sample", 0, 0, ""}, "Free Sample: 0 x 0.00 = 0.00 ()"},
+	} {
+		got, err := FormatSale(tt.sale)
+		assert.Equal(t, got, tt.want, "FormatSale(%v) (err %v)", tt.sale, err)
+	}
+}
+
+func TestListSales(t *testing.T) {
+	sales := readSales(t)[:3]
+	got, err := ListSales(sales)
+	assert.Equal(t, got, "- Widget, North: 250.00\n- Gadget, South: 250.00\n- Widget, South: 200.00\n", "ListSales(first 3) (err %v)", err)
+
+	got, err = ListSales(nil)
+	assert.Equal(t, got, "No sales.\n", "ListSales(nil) (err %v)", err)
+}
+
+func TestFuncs(t *testing.T) {
+	for _, tt := range []struct {
+		text string
+		data any
+		want string
+	}{
+		{`{{money .}}`, 2740.0, "2,740.00"},
+		{`{{money .}}`, -1234.5, "-1,234.50"},
+		{`{{money .}}`, 0.0, "0.00"},
+		{`{{money .}}`, -0.001, "0.00"},
+		{`{{money .}}`, 0.5, "0.50"},
+		{`{{money .}}`, 999.0, "999.00"},
+		{`{{money .}}`, 999.999, "1,000.00"},
+		{`{{money .}}`, 100000.0, "100,000.00"},
+		{`{{money .}}`, 1234567.891, "1,234,567.89"},
+		{`{{money .}}`, -12.3, "-12.30"},
+		{`{{upper .}}`, "Widget", "WIDGET"},
+		{`[{{pad 6 .}}]`, "abc", "[abc   ]"},
+		{`[{{padLeft 6 .}}]`, "abc", "[   abc]"},
+		{`[{{pad 4 .}}]`, "café", "[café]"},
+		{`[{{padLeft 5 .}}]`, "café", "[ café]"},
+		{`[{{pad 2 .}}]`, "abc", "[abc]"},
+		{`[{{padLeft 2 .}}]`, "abc", "[abc]"},
+		{`[{{. | money | padLeft 10}}]`, 1234.5, "[  1,234.50]"},
+	} {
+		tmpl, err := template.New("test").Funcs(Funcs()).Parse(tt.text)
+		if err != nil {
+			t.Errorf("parsing %s: %v", tt.text, err)
+			continue
+		}
+		var b strings.Builder
+		err = tmpl.Execute(&b, tt.data)
+		assert.Equal(t, b.String(), tt.want, "%s with %v (err %v)", tt.text, tt.data, err)
+	}
+}
+
+// The expected reports are golden files in testdata/. If you change the
+// layout on purpose, `go test -run TestSalesReport -update` rewrites
+// them; check the diff before keeping it.
+func TestSalesReport(t *testing.T) {
+	sales := readSales(t)
+	for _, tt := range []struct {
+		name  string
+		sales []Sale
+	}{
+		{"sample", sales},
+		{"east-north", inRegions(sales, "East", "North")}, // the one in the comment
+		{"empty", nil},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var b strings.Builder
+			if err := SalesReport(&b, tt.sales); err != nil {
+				t.Fatalf("SalesReport() = %v", err)
+			}
+			testutil.Golden(t, filepath.Join("testdata", "report-"+tt.name+".golden"), []byte(b.String()))
+		})
+	}
+}
+
+func TestRender(t *testing.T) {
+	sale := Sale{"Widget", 10, 25, "North"}
+	for _, tt := range []struct {
+		text    string
+		data    any
+		want    string
+		wantErr bool
+	}{
+		{`{{.Product | upper}} {{.Revenue | money}}`, sale, "WIDGET 250.00", false},
+		{`Hi, {{.name}}!`, map[string]string{"name": "Ann"}, "Hi, Ann!", false},
+		{`no actions`, nil, "no actions", false},
+		{`Hi, {{.nmae}}!`, map[string]string{"name": "Ann"}, "", true}, // missing key
+		{`{{.Product}} {{.Prodcut}}`, sale, "", true},                  // no such field; "Widget " was written first
+		{`{{.Product`, sale, "", true},                                 // unclosed action
+		{`{{if .Product}}no end`, sale, "", true},                      // unclosed if
+		{`{{nope .Product}}`, sale, "", true},                          // no such func
+	} {
+		got, err := Render(tt.text, tt.data)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("Render(%q, %v) = %q, %v; want %q, error %v", tt.text, tt.data, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestHighlight(t *testing.T) {
+	for _, tt := range []struct {
+		in, want string
+	}{
+		{"Gizmo", "<mark>Gizmo</mark>"},
+		{"a<b", "<mark>a&lt;b</mark>"},
+		{`"Tom & Jerry's"`, "<mark>&#34;Tom &amp; Jerry&#39;s&#34;</mark>"},
+		{"", "<mark></mark>"},
+	} {
+		assert.Equal(t, string(Highlight(tt.in)), tt.want, "Highlight(%q)", tt.in)
+	}
+}
+
+// page renders SalesPage.
+func page(t *testing.T, title string, sales []Sale) string {
+	t.Helper()
+	var b strings.Builder
+	if err := SalesPage(&b, title, sales); err != nil {
+		t.Fatalf("SalesPage() = %v", err)
+	}
+	return b.String()
+}
+
+func TestSalesPage(t *testing.T) {
+	got := page(t, "Q1 sales", readSales(t))
+	for _, want := range []string{
+		"<!DOCTYPE html>\n<html>\n",
+		"<head><title>Q1 sales</title></head>",
+		"<h1>Q1 sales</h1>",
+		`<tr><td><a href="/products?name=Widget">Widget</a></td><td>North</td><td>250.00</td></tr>`,
+		`<tr><td><a href="/products?name=Gizmo">Gizmo</a></td><td>West</td><td>330.00</td></tr>`,
+		"<p>Top product: <mark>Gizmo</mark></p>",
+		`<script>const sales = [{"Product":"Widget","Quantity":10,"Price":25,"Region":"North"},`,
+		"</body>\n</html>\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("SalesPage() doesn't contain %s; it's\n%s", want, got)
+		}
+	}
+	if n := strings.Count(got, "<tr>"); n != 10 {
+		t.Errorf("SalesPage() has %d rows; want 10", n)
+	}
+
+	// A product name with spaces and an ampersand is escaped differently
+	// in each place it goes.
+	got = page(t, "Mugs", []Sale{{"Tom & Jerry Mug", 2, 12.5, "East"}})
+	for _, want := range []string{
+		`<a href="/products?name=Tom%20%26%20Jerry%20Mug">Tom &amp; Jerry Mug</a>`,
+		"<td>25.00</td>",
+		"<mark>Tom &amp; Jerry Mug</mark>",
+		`"Product":"Tom \u0026 Jerry Mug"`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("SalesPage() doesn't contain %s; it's\n%s", want, got)
+		}
+	}
+
+	got = page(t, "Nothing yet", nil)
+	if strings.Contains(got, "<tr>") || strings.Contains(got, "Top product") {
+		t.Errorf("SalesPage() with no sales has a row or a top product:\n%s", got)
+	}
+	if !strings.Contains(got, "null") {
+		t.Errorf("SalesPage() with no sales doesn't have sales as null in the script:\n%s", got)
+	}
+}
+
+func TestSalesPageEscapes(t *testing.T) {
+	attack := "<script>alert(1)</script>"
+	got := page(t, "</title>"+attack, []Sale{{attack, 1, 1, `"><img src=x onerror=alert(2)>`}})
+	for _, bad := range []string{attack, "<img", "&amp;lt;"} {
+		if strings.Contains(got, bad) {
+			t.Errorf("SalesPage() contains %s; use html/template, and escape what Highlight wraps, once:\n%s", bad, got)
+		}
+	}
+	for _, want := range []string{
+		"<title>&lt;/title&gt;&lt;script&gt;alert(1)&lt;/script&gt;</title>",
+		">&lt;script&gt;alert(1)&lt;/script&gt;</a>",                    // as text
+		`href="/products?name=%3cscript%3ealert%281%29%3c%2fscript%3e"`, // as a query
+		"<mark>&lt;script&gt;alert(1)&lt;/script&gt;</mark>",            // by Highlight
+		`"Product":"\u003cscript\u003ealert(1)\u003c/script\u003e"`,     // as JSON in JS
+		"<td>&#34;&gt;&lt;img src=x onerror=alert(2)&gt;</td>",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("SalesPage() doesn't contain %s; it's\n%s", want, got)
+		}
+	}
+}
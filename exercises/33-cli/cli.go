@@ -0,0 +1,196 @@
+//go:build !solutions
+
+package cli
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// Exercise 33: Building a CLI with flag
+//
+// A Node CLI reads process.argv, usually through yargs or commander,
+// writes to process.stdout and ends with process.exit(code). A Go one
+// gets os.Args, os.Stdin, os.Stdout and os.Stderr, parses flags with
+// package flag, and ends with os.Exit(code).
+//
+// This exercise builds csvtool, with two subcommands the way git has
+// them:
+//
+//	csvtool filter -col region -eq North sales.csv
+//	csvtool convert -to md < sales.csv
+//
+// To make it testable, nothing here touches the os package's globals:
+// Run takes the arguments and an Env of streams, and returns the exit
+// code instead of exiting. Only main, in cmd/examples/33-cli, passes
+// the real ones in, the same split cmd/learngo makes with its app
+// struct. The tests call Run with strings.Readers and
+// strings.Builders.
+//
+// Exit codes follow the Unix custom: 0 for success, 1 when the command
+// failed, and 2 when it was called wrong.
+//
+// Run tests with: go test -v
+
+// Env is where a command reads and writes.
+type Env struct {
+	Stdin  io.Reader
+	Stdout io.Writer
+	Stderr io.Writer
+}
+
+// ErrUsage is wrapped by the errors a command returns when it was
+// called wrong: an unknown flag, a missing one, too many files.
+var ErrUsage = errors.New("usage error")
+
+// command is a subcommand of csvtool.
+type command struct {
+	name  string
+	usage string // what follows "csvtool "
+	run   func(env Env, args []string) error
+}
+
+var commands = []command{
+	{"filter", "filter -col name (-eq value | -contains text) [-v] [-i] [file]", Filter},
+	{"convert", "convert [-to json|tsv|md] [file]", Convert},
+}
+
+// readTable reads CSV from r: a header row, then rows with as many
+// fields.
+func readTable(r io.Reader) (header []string, rows [][]string, err error) {
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(records) == 0 {
+		return nil, nil, errors.New("no header row")
+	}
+	return records[0], records[1:], nil
+}
+
+// 1. Reading a file or stdin
+// Input returns what a command should read, given its arguments after
+// the flags: the file named by args[0], or stdin if there's none or it's
+// "-", the way cat and grep work. More than one file is an error
+// wrapping ErrUsage.
+//
+// Either way the caller closes what it gets. Closing stdin isn't its
+// job, though, so wrap stdin in io.NopCloser, whose Close does nothing.
+func Input(stdin io.Reader, args []string) (io.ReadCloser, error) {
+	// TODO: os.Open for a file
+	return nil, nil
+}
+
+// 2. flag.FlagSet
+// Filter implements `csvtool filter`. It reads a table and writes it as
+// CSV with only the rows whose column -col is -eq, or contains
+// -contains, in their order and with the header.
+//
+//	-col name        the column to look at, by its name in the header
+//	-eq value        keep rows where the column is value
+//	-contains text   keep rows where the column contains text
+//	-v               keep the rows that don't match instead, like grep -v
+//	-i               ignore case
+//
+// Exactly one of -eq and -contains must be given, and -col always:
+// otherwise, or for an unknown flag or more than one file, return an
+// error wrapping ErrUsage. A column the header doesn't have is an
+// ordinary error, naming it.
+//
+// The flag package's top-level funcs parse os.Args into globals;
+// flag.NewFlagSet(name, flag.ContinueOnError) makes a parser of your
+// own, whose Parse returns an error instead of exiting. It writes that
+// error and a usage message to its output too; fs.SetOutput(io.Discard)
+// leaves that to Run. Flags must come before other arguments, which
+// fs.Args() returns. And since -eq "" is a real value, whether a flag
+// was given can't be told from its value: fs.Visit calls a func for
+// each flag that was set.
+//
+// On -h, Parse returns flag.ErrHelp: return it as it is.
+func Filter(env Env, args []string) error {
+	// TODO: fs := flag.NewFlagSet("filter", flag.ContinueOnError), then
+	// fs.String("col", "", "..."), fs.Bool("v", false, "..."), ...
+	return nil
+}
+
+// Format is a format Convert can write: "json", "tsv" or "md".
+type Format string
+
+// 3. A flag of your own type
+// Set makes *Format a flag.Value, so it can be a flag with
+// fs.Var(&f, "to", "...") and reject a format it doesn't know when the
+// flags are parsed. The error it returns is wrapped in the one from
+// Parse; say which formats there are.
+func (f *Format) Set(s string) error {
+	// TODO
+	return nil
+}
+
+// String is the other half of flag.Value.
+func (f *Format) String() string {
+	// TODO
+	return ""
+}
+
+// 4. Writing output
+// Convert implements `csvtool convert [-to format] [file]`, which
+// writes the table in another format, json if -to isn't given:
+//
+//   - json: an array with an object per row, keyed by the header, as
+//     json.MarshalIndent with two spaces makes it, and a "\n"; no rows
+//     is "[]\n"
+//   - tsv: the header and the rows, their fields separated by tabs; a
+//     tab or a newline in a field becomes a space
+//   - md: a Markdown table, "| a | b |", then "| --- | --- |", then a
+//     line per row; a "|" in a field is written "\|"
+//
+// Every line ends with "\n". Usage errors are as for Filter.
+func Convert(env Env, args []string) error {
+	// TODO: fs.Var for -to, with the Format set to "json" first
+	return nil
+}
+
+// 5. Subcommands and exit codes
+// Run runs csvtool with args, os.Args[1:] in main, and returns the exit
+// code. args[0] is the command, and the rest its arguments:
+//
+//   - no command: print the usage to env.Stderr, and return 2
+//   - an unknown one: print `csvtool: unknown command "x"` and the usage
+//     to env.Stderr, and return 2
+//   - "help", "-h" or "--help": print the usage to env.Stdout, and
+//     return 0
+//   - the command returns nil: 0
+//   - it returns flag.ErrHelp: print "usage: csvtool " and its usage
+//     line to env.Stdout, and return 0
+//   - it returns an error wrapping ErrUsage: print
+//     "csvtool <name>: <error>" and then its usage line, as above, to
+//     env.Stderr, and return 2
+//   - any other error: print "csvtool <name>: <error>" to env.Stderr,
+//     and return 1
+//
+// The usage lists the commands:
+//
+//	usage: csvtool <command> [flags] [file]
+//
+//	commands:
+//	  filter -col name (-eq value | -contains text) [-v] [-i] [file]
+//	  convert [-to json|tsv|md] [file]
+//
+// Every line ends with "\n". In JS: a switch on process.argv[2].
+func Run(args []string, env Env) int {
+	// TODO: find args[0] in commands
+	return 0
+}
+
+// Keep imports used
+var _ = json.MarshalIndent
+var _ = flag.NewFlagSet
+var _ = fmt.Errorf
+var _ = os.Open
+var _ = strings.Contains
@@ -0,0 +1,284 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"io"
+	"io/fs"
+	"strings"
+	"testing"
+
+	"github.com/imgarylai/learn-go/internal/assert"
+)
+
+// csvtool runs Run with args and stdin, the way a shell would run
+// `csvtool args... < stdin`, and returns what it printed.
+func csvtool(stdin string, args ...string) (code int, stdout, stderr string) {
+	var out, errOut strings.Builder
+	code = Run(args, Env{Stdin: strings.NewReader(stdin), Stdout: &out, Stderr: &errOut})
+	return code, out.String(), errOut.String()
+}
+
+const usageText = `usage: csvtool <command> [flags] [file]
+
+commands:
+  filter -col name (-eq value | -contains text) [-v] [-i] [file]
+  convert [-to json|tsv|md] [file]
+`
+
+const people = `name,city
+Ann,Taipei
+Bob,Tokyo
+"Lee, Jr.",taipei
+Cy,
+`
+
+// closeRecorder is a reader that notices being closed.
+type closeRecorder struct {
+	io.Reader
+	closed bool
+}
+
+func (c *closeRecorder) Close() error {
+	c.closed = true
+	return nil
+}
+
+func TestInput(t *testing.T) {
+	for _, args := range [][]string{nil, {"-"}} {
+		stdin := &closeRecorder{Reader: strings.NewReader("from stdin")}
+		r, err := Input(stdin, args)
+		if r == nil || err != nil {
+			t.Errorf("Input(stdin, %q) = %v, %v; want stdin", args, r, err)
+			continue
+		}
+		data, _ := io.ReadAll(r)
+		assert.Equal(t, string(data), "from stdin", "read from Input(stdin, %q)", args)
+		r.Close()
+		if stdin.closed {
+			t.Errorf("closing Input(stdin, %q) closed stdin; wrap it in io.NopCloser", args)
+		}
+	}
+
+	r, err := Input(strings.NewReader("from stdin"), []string{"testdata/sales.csv"})
+	if r == nil || err != nil {
+		t.Fatalf("Input(stdin, testdata/sales.csv) = %v, %v", r, err)
+	}
+	data, _ := io.ReadAll(r)
+	r.Close()
+	if !strings.HasPrefix(string(data), "product,quantity,price,region\n") {
+		t.Errorf("Input(stdin, testdata/sales.csv) read %q; want the file", data)
+	}
+
+	if _, err := Input(nil, []string{"testdata/nope.csv"}); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Input(stdin, testdata/nope.csv) error = %v; want os.Open's", err)
+	}
+	if _, err := Input(nil, []string{"a.csv", "b.csv"}); !errors.Is(err, ErrUsage) {
+		t.Errorf("Input(stdin, two files) error = %v; want one wrapping ErrUsage", err)
+	}
+}
+
+func TestFilter(t *testing.T) {
+	for _, tt := range []struct {
+		args []string
+		want string
+	}{
+		{[]string{"-col", "city", "-eq", "Taipei"}, "name,city\nAnn,Taipei\n"},
+		{[]string{"-col", "city", "-eq", "Taipei", "-i"}, "name,city\nAnn,Taipei\n\"Lee, Jr.\",taipei\n"},
+		{[]string{"-i", "-col=city", "-eq=TAIPEI"}, "name,city\nAnn,Taipei\n\"Lee, Jr.\",taipei\n"},
+		{[]string{"-col", "city", "-eq", "Taipei", "-v"}, "name,city\nBob,Tokyo\n\"Lee, Jr.\",taipei\nCy,\n"},
+		{[]string{"-col", "city", "-contains", "o"}, "name,city\nBob,Tokyo\n"},
+		{[]string{"-col", "city", "-contains", "T", "-i", "-v"}, "name,city\nCy,\n"},
+		{[]string{"-col", "name", "-contains", ","}, "name,city\n\"Lee, Jr.\",taipei\n"},
+		{[]string{"-col", "city", "-eq", ""}, "name,city\nCy,\n"},                       // -eq "" is set
+		{[]string{"-col", "city", "-contains", ""}, people},                             // everything contains ""
+		{[]string{"-col", "city", "-eq", "Paris"}, "name,city\n"},                       // just the header
+		{[]string{"-col", "city", "-eq", "Tokyo", "-"}, "name,city\nBob,Tokyo\n"},       // - is stdin
+		{[]string{"-col", "city", "-eq", "Tokyo", "--", "-"}, "name,city\nBob,Tokyo\n"}, // -- ends the flags
+	} {
+		args := append([]string{"filter"}, tt.args...)
+		code, stdout, stderr := csvtool(people, args...)
+		if code != 0 || stdout != tt.want {
+			t.Errorf("csvtool %s = %d, stdout %q, stderr %q; want 0, %q", strings.Join(args, " "), code, stdout, stderr, tt.want)
+		}
+	}
+}
+
+func TestFilterFile(t *testing.T) {
+	code, stdout, stderr := csvtool("", "filter", "-col", "region", "-eq", "West", "testdata/sales.csv")
+	want := "product,quantity,price,region\nWidget,6,25.00,West\nGizmo,11,30.00,West\n"
+	if code != 0 || stdout != want {
+		t.Errorf("csvtool filter ... testdata/sales.csv = %d, %q, stderr %q; want 0, %q", code, stdout, stderr, want)
+	}
+}
+
+func TestFilterUsage(t *testing.T) {
+	for _, tt := range []struct {
+		args []string
+		why  string // in stderr
+	}{
+		{[]string{"-eq", "Taipei"}, "-col"},
+		{[]string{"-col", "city"}, "-eq"},
+		{[]string{"-col", "city", "-eq", "x", "-contains", "y"}, "-contains"},
+		{[]string{"-col", "city", "-eq", "x", "-z"}, "-z"},
+		{[]string{"-col"}, "-col"},
+		{[]string{"-col", "city", "-eq", "x", "a.csv", "b.csv"}, "one file"},
+	} {
+		args := append([]string{"filter"}, tt.args...)
+		code, stdout, stderr := csvtool(people, args...)
+		if code != 2 || stdout != "" {
+			t.Errorf("csvtool %s = %d, stdout %q; want 2 and nothing", strings.Join(args, " "), code, stdout)
+		}
+		if !strings.HasPrefix(stderr, "csvtool filter: ") || !strings.Contains(stderr, tt.why) ||
+			!strings.HasSuffix(stderr, "\nusage: csvtool "+commands[0].usage+"\n") {
+			t.Errorf("csvtool %s stderr = %q; want the error, mentioning %s, then the usage", strings.Join(args, " "), stderr, tt.why)
+		}
+	}
+}
+
+func TestFilterErrors(t *testing.T) {
+	for _, tt := range []struct {
+		stdin string
+		args  []string
+		why   string // in stderr
+	}{
+		{people, []string{"-col", "country", "-eq", "Japan"}, `"country"`},
+		{people, []string{"-col", "city", "-eq", "x", "testdata/nope.csv"}, "testdata/nope.csv"},
+		{"name,city\nAnn\n", []string{"-col", "city", "-eq", "x"}, "line 2"}, // csv.Reader's error
+		{"", []string{"-col", "city", "-eq", "x"}, "header"},
+	} {
+		args := append([]string{"filter"}, tt.args...)
+		code, stdout, stderr := csvtool(tt.stdin, args...)
+		if code != 1 || stdout != "" {
+			t.Errorf("csvtool %s < %q = %d, stdout %q; want 1 and nothing", strings.Join(args, " "), tt.stdin, code, stdout)
+		}
+		if !strings.HasPrefix(stderr, "csvtool filter: ") || !strings.Contains(stderr, tt.why) || strings.Contains(stderr, "usage") {
+			t.Errorf("csvtool %s < %q stderr = %q; want an error mentioning %s, and no usage", strings.Join(args, " "), tt.stdin, stderr, tt.why)
+		}
+	}
+}
+
+func TestFormat(t *testing.T) {
+	var f Format
+	var _ flag.Value = &f
+	for _, s := range []string{"json", "tsv", "md"} {
+		if err := f.Set(s); err != nil || f != Format(s) || f.String() != s {
+			t.Errorf("Set(%q) = %v; then f = %q, String() = %q", s, err, f, f.String())
+		}
+	}
+	f = "tsv"
+	for _, s := range []string{"xml", "", "JSON"} {
+		err := f.Set(s)
+		if err == nil {
+			t.Errorf("Set(%q) = nil; want an error", s)
+		} else if !strings.Contains(err.Error(), "json, tsv") {
+			t.Errorf("Set(%q) = %q; want it to list the formats", s, err)
+		}
+		assert.Equal(t, f, Format("tsv"), "after Set(%q) failed", s)
+	}
+}
+
+func TestConvert(t *testing.T) {
+	const table = "name,note\nAnn,a|b\nBob,\"x\ty\"\nCy,\"two\nlines\"\n"
+	for _, tt := range []struct {
+		args  []string
+		stdin string
+		want  string
+	}{
+		{nil, table, `[
+  {
+    "name": "Ann",
+    "note": "a|b"
+  },
+  {
+    "name": "Bob",
+    "note": "x\ty"
+  },
+  {
+    "name": "Cy",
+    "note": "two\nlines"
+  }
+]
+`},
+		{[]string{"-to", "json"}, "a\n", "[]\n"},
+		{[]string{"-to", "tsv"}, table, "name\tnote\nAnn\ta|b\nBob\tx y\nCy\ttwo lines\n"},
+		{[]string{"-to=md"}, "name,note\nAnn,a|b\nBob,\n", "| name | note |\n| --- | --- |\n| Ann | a\\|b |\n| Bob |  |\n"},
+		{[]string{"-to", "md"}, "a,b,c\n", "| a | b | c |\n| --- | --- | --- |\n"},
+		{[]string{"-to", "tsv", "testdata/sales.csv"}, "", "product\tquantity\tprice\tregion\nWidget\t10\t25.00\tNorth\n"},
+	} {
+		args := append([]string{"convert"}, tt.args...)
+		code, stdout, stderr := csvtool(tt.stdin, args...)
+		if tt.stdin == "" { // the sales file: check its first two lines
+			if i := strings.Index(stdout, "North\n"); i >= 0 {
+				stdout = stdout[:i+len("North\n")]
+			}
+		}
+		if code != 0 || stdout != tt.want {
+			t.Errorf("csvtool %s < %q = %d, stderr %q, stdout\n%s\nwant 0, stdout\n%s", strings.Join(args, " "), tt.stdin, code, stderr, stdout, tt.want)
+		}
+	}
+
+	code, stdout, stderr := csvtool(table, "convert", "-to", "xml")
+	if code != 2 || stdout != "" || !strings.Contains(stderr, `"xml"`) || !strings.Contains(stderr, "usage: csvtool convert") {
+		t.Errorf("csvtool convert -to xml = %d, stdout %q, stderr %q; want 2, and the error and usage on stderr", code, stdout, stderr)
+	}
+}
+
+func TestRun(t *testing.T) {
+	for _, tt := range []struct {
+		args           []string
+		code           int
+		stdout, stderr string
+	}{
+		{nil, 2, "", usageText},
+		{[]string{"help"}, 0, usageText, ""},
+		{[]string{"-h"}, 0, usageText, ""},
+		{[]string{"--help"}, 0, usageText, ""},
+		{[]string{"sort", "-col", "name"}, 2, "", "csvtool: unknown command \"sort\"\n" + usageText},
+		{[]string{"filter", "-h"}, 0, "usage: csvtool " + commands[0].usage + "\n", ""},
+		{[]string{"convert", "-help", "-to", "xml"}, 0, "usage: csvtool " + commands[1].usage + "\n", ""},
+	} {
+		code, stdout, stderr := csvtool(people, tt.args...)
+		if code != tt.code || stdout != tt.stdout || stderr != tt.stderr {
+			t.Errorf("csvtool %s = %d, stdout %q, stderr %q; want %d, %q, %q", strings.Join(tt.args, " "), code, stdout, stderr, tt.code, tt.stdout, tt.stderr)
+		}
+	}
+}
+
+func TestConvertErrors(t *testing.T) {
+	for _, tt := range []struct {
+		stdin string
+		args  []string
+		why   string // in stderr
+	}{
+		{"", []string{"testdata/nope.csv"}, "testdata/nope.csv"},
+		{"a,b\n1\n", []string{"-to", "md"}, "line 2"},
+	} {
+		args := append([]string{"convert"}, tt.args...)
+		code, stdout, stderr := csvtool(tt.stdin, args...)
+		if code != 1 || stdout != "" || !strings.HasPrefix(stderr, "csvtool convert: ") || !strings.Contains(stderr, tt.why) {
+			t.Errorf("csvtool %s < %q = %d, stdout %q, stderr %q; want 1, and an error mentioning %s", strings.Join(args, " "), tt.stdin, code, stdout, stderr, tt.why)
+		}
+	}
+}
+
+// brokenPipe is a stdout whose reader has gone away, like
+// `csvtool ... | head -0`.
+type brokenPipe struct{}
+
+func (brokenPipe) Write(p []byte) (int, error) { return 0, errors.New("broken pipe") }
+
+func TestWriteErrors(t *testing.T) {
+	for _, args := range [][]string{
+		{"filter", "-col", "city", "-eq", "Tokyo"},
+		{"convert"},
+		{"convert", "-to", "tsv"},
+		{"convert", "-to", "md"},
+	} {
+		var stderr strings.Builder
+		code := Run(args, Env{Stdin: strings.NewReader(people), Stdout: brokenPipe{}, Stderr: &stderr})
+		if code != 1 || !strings.Contains(stderr.String(), "broken pipe") {
+			t.Errorf("csvtool %s with a broken stdout = %d, stderr %q; want 1 and the write error", strings.Join(args, " "), code, stderr.String())
+		}
+	}
+}
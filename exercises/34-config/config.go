@@ -0,0 +1,210 @@
+//go:build !solutions
+
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	yaml "gopkg.in/yaml.v3"
+)
+
+// Exercise 34: Configuration and environment variables
+//
+// A Node service reads process.env.PORT ?? "8080" wherever it needs it,
+// maybe after dotenv has loaded a file into it. That's hard to test:
+// process.env is one global, and so is the disk. Here a service's
+// settings come together in one place, in the order most tools use:
+//
+//	defaults  <  a config file (JSON or YAML)  <  APP_* environment variables
+//
+// and LoadConfig, which does it, takes the file system and the
+// environment as arguments instead of reaching for them:
+//
+//	cfg, err := LoadConfig(os.DirFS("."), os.Getenv) // in main
+//	cfg, err := LoadConfig(fstest.MapFS{...}, env{...}.get) // in a test
+//
+// fs.FS is io/fs's read-only file system interface; os.DirFS is the
+// real one and fstest.MapFS an in-memory one. func(string) string is
+// os.Getenv's type, so a map lookup can stand in for the environment.
+//
+// YAML comes from gopkg.in/yaml.v3, which this repo's go.mod already
+// requires; the standard library has no YAML package.
+//
+// Run tests with: go test -v
+
+// Config is a service's settings. The tags name its keys in a file,
+// the same in JSON and in YAML.
+type Config struct {
+	Name           string   `json:"name" yaml:"name"`
+	Addr           string   `json:"addr" yaml:"addr"`
+	Debug          bool     `json:"debug" yaml:"debug"`
+	LogLevel       string   `json:"log_level" yaml:"log_level"`
+	Timeout        Duration `json:"timeout" yaml:"timeout"`
+	Database       Database `json:"database" yaml:"database"`
+	AllowedOrigins []string `json:"allowed_origins" yaml:"allowed_origins"`
+}
+
+// Database is the database part of a Config.
+type Database struct {
+	URL      string `json:"url" yaml:"url"`
+	MaxConns int    `json:"max_conns" yaml:"max_conns"`
+}
+
+// Defaults returns the settings before any file or variable is read.
+// Name and Database.URL have no sensible default.
+func Defaults() Config {
+	return Config{
+		Addr:     ":8080",
+		LogLevel: "info",
+		Timeout:  Duration(30 * time.Second),
+		Database: Database{MaxConns: 10},
+	}
+}
+
+// Duration is a time.Duration written the way people write one: "30s",
+// "1m30s". A time.Duration is an int64 of nanoseconds, and JSON and YAML
+// would want 30000000000.
+type Duration time.Duration
+
+func (d Duration) String() string { return time.Duration(d).String() }
+
+// MarshalText writes d as "30s", so printing a Config as JSON or YAML
+// gives what it was read from.
+func (d Duration) MarshalText() ([]byte, error) { return []byte(d.String()), nil }
+
+// FieldError says what is wrong with one field of a Config. Field is
+// its key, as in a file: "database.url".
+type FieldError struct {
+	Field string
+	Msg   string
+}
+
+func (e *FieldError) Error() string { return e.Field + ": " + e.Msg }
+
+// 1. Variables with defaults
+// Getenv returns env(key), or def if that is "". os.Getenv can't tell
+// a variable that isn't set from one set to "", and neither can this:
+// for settings, both mean "not given". (os.LookupEnv can tell, when it
+// matters.) In JS: env[key] || def.
+func Getenv(env func(string) string, key, def string) string {
+	// TODO
+	return ""
+}
+
+// 2. Typed variables
+// EnvInt is Getenv for an int: def if the variable is "", and an error
+// naming the variable if it isn't a number, so APP_PORT=eighty fails
+// with `APP_PORT: strconv.Atoi: parsing "eighty": invalid syntax`
+// rather than quietly using the default.
+//
+// EnvBool does the same with strconv.ParseBool, which takes 1, t, true,
+// 0, f, false and their capitalizations.
+func EnvInt(env func(string) string, key string, def int) (int, error) {
+	// TODO: strconv.Atoi
+	return 0, nil
+}
+
+func EnvBool(env func(string) string, key string, def bool) (bool, error) {
+	// TODO: strconv.ParseBool
+	return false, nil
+}
+
+// 3. encoding.TextUnmarshaler
+// UnmarshalText parses "30s" with time.ParseDuration into d. Both
+// encoding/json, for a JSON string, and yaml.v3, for a scalar, use a
+// field's UnmarshalText when it has one, so this one method lets
+// Timeout be read from either, and from a variable too. A duration
+// that isn't positive is an error here already.
+func (d *Duration) UnmarshalText(text []byte) error {
+	// TODO
+	return nil
+}
+
+// 4. Parsing a file
+// Parse reads a config file's contents, starting from Defaults, so
+// keys the file leaves out keep their defaults. name picks the format
+// by its extension: ".json", or ".yaml" or ".yml"; any other is an
+// error naming it. An empty file is just the defaults.
+//
+// A key that Config doesn't have is an error, not something to ignore:
+// it's usually a typo, like "log_lvl", whose setting would silently
+// never apply. Decoders reject them when asked: json.Decoder's
+// DisallowUnknownFields, and yaml.Decoder's KnownFields(true). Both
+// return io.EOF for an empty input. Start the errors with name.
+func Parse(name string, data []byte) (Config, error) {
+	// TODO: cfg := Defaults(), then decode into &cfg
+	return Config{}, nil
+}
+
+// 5. Overrides from the environment
+// ApplyEnv overrides cfg's settings with those variables that are set:
+//
+//	APP_NAME                 Name
+//	APP_ADDR                 Addr
+//	APP_DEBUG                Debug, as EnvBool reads it
+//	APP_LOG_LEVEL            LogLevel
+//	APP_TIMEOUT              Timeout, as UnmarshalText reads it
+//	APP_DATABASE_URL         Database.URL
+//	APP_DATABASE_MAX_CONNS   Database.MaxConns, as EnvInt reads it
+//	APP_ALLOWED_ORIGINS      AllowedOrigins, separated by commas, each
+//	                         trimmed of spaces, and empty ones dropped
+//
+// A variable that can't be read is an error naming it. Report all of
+// them, not only the first, with errors.Join.
+func ApplyEnv(cfg *Config, env func(string) string) error {
+	// TODO: the current setting is the default, as in
+	// EnvInt(env, "APP_DATABASE_MAX_CONNS", cfg.Database.MaxConns)
+	return nil
+}
+
+// 6. Validating
+// Validate checks what a service can't start without, and returns a
+// *FieldError for each problem, joined with errors.Join, or nil:
+//
+//   - name: "is required"
+//   - database.url: "is required"
+//   - database.max_conns: "must be at least 1"
+//   - timeout: "must be positive"
+//   - addr: must be "host:port" for net.SplitHostPort, and the
+//     message is that func's error's
+//   - log_level: one of debug, info, warn and error, or the message
+//     is `unknown level "x"`
+//
+// in that order. Telling the user every problem at once saves them a
+// round trip per mistake.
+func Validate(cfg Config) error {
+	// TODO
+	return nil
+}
+
+// 7. Putting it together
+// LoadConfig reads the file APP_CONFIG names in fsys, or config.yaml if
+// it's not set, applies the environment to it and validates the
+// result. A missing config.yaml is fine, and leaves the defaults; a
+// missing file that APP_CONFIG named is an error, since someone
+// meant it to be there. On any error it returns a zero Config.
+//
+// fs.ReadFile reads a file from an fs.FS, and a missing one's error is
+// fs.ErrNotExist, for errors.Is.
+func LoadConfig(fsys fs.FS, env func(string) string) (Config, error) {
+	// TODO
+	return Config{}, nil
+}
+
+// Keep imports used
+var _ = json.NewDecoder
+var _ = errors.Join
+var _ = fmt.Errorf
+var _ = net.SplitHostPort
+var _ = path.Ext
+var _ = strconv.Atoi
+var _ = strings.Split
+var _ = yaml.NewDecoder
@@ -0,0 +1,398 @@
+package config
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/imgarylai/learn-go/internal/assert"
+)
+
+// env is an environment for tests; env{...}.get stands in for
+// os.Getenv.
+type env map[string]string
+
+func (e env) get(key string) string { return e[key] }
+
+// shop is what testdata/config.yaml and testdata/config.json hold.
+func shop() Config {
+	return Config{
+		Name:     "shop",
+		Addr:     "localhost:9000",
+		LogLevel: "debug",
+		Timeout:  Duration(90 * time.Second),
+		Database: Database{MaxConns: 20},
+		AllowedOrigins: []string{
+			"https://shop.example.com",
+			"https://admin.example.com",
+		},
+	}
+}
+
+// valid returns the defaults with what Validate requires filled in.
+func valid() Config {
+	cfg := Defaults()
+	cfg.Name = "shop"
+	cfg.Database.URL = "postgres://localhost/shop"
+	return cfg
+}
+
+// problems returns the messages of the *FieldErrors in err, which
+// errors.Join joined.
+func problems(t *testing.T, err error) []string {
+	t.Helper()
+	var errs []error
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		errs = joined.Unwrap()
+	} else if err != nil {
+		errs = []error{err}
+	}
+	var msgs []string
+	for _, err := range errs {
+		var fe *FieldError
+		if !errors.As(err, &fe) {
+			t.Errorf("%v is a %T, not a *FieldError", err, err)
+			continue
+		}
+		msgs = append(msgs, fe.Error())
+	}
+	return msgs
+}
+
+// wantErr fails t unless err is an error whose message contains each
+// of parts.
+func wantErr(t *testing.T, err error, parts ...string) {
+	t.Helper()
+	if err == nil {
+		t.Errorf("got no error; want one mentioning %q", parts)
+		return
+	}
+	for _, p := range parts {
+		if !strings.Contains(err.Error(), p) {
+			t.Errorf("error %q doesn't mention %q", err, p)
+		}
+	}
+}
+
+func TestGetenv(t *testing.T) {
+	e := env{"APP_NAME": "shop", "APP_EMPTY": ""}.get
+	assert.Equal(t, Getenv(e, "APP_NAME", "default"), "shop", "a variable that is set")
+	assert.Equal(t, Getenv(e, "APP_EMPTY", "default"), "default", `a variable set to ""`)
+	assert.Equal(t, Getenv(e, "APP_UNSET", "default"), "default", "a variable that isn't set")
+}
+
+func TestEnvInt(t *testing.T) {
+	e := env{"N": "42", "NEG": "-3", "BAD": "eighty", "BIG": "99999999999999999999"}.get
+	for _, tt := range []struct {
+		key  string
+		want int
+	}{
+		{"N", 42},
+		{"NEG", -3},
+		{"UNSET", 7},
+	} {
+		got, err := EnvInt(e, tt.key, 7)
+		assert.Equal(t, got, tt.want, "EnvInt(%s)", tt.key)
+		if err != nil {
+			t.Errorf("EnvInt(%s): %v", tt.key, err)
+		}
+	}
+	for _, key := range []string{"BAD", "BIG"} {
+		_, err := EnvInt(e, key, 7)
+		wantErr(t, err, key+":", "strconv.Atoi")
+	}
+}
+
+func TestEnvBool(t *testing.T) {
+	e := env{"T": "true", "ONE": "1", "F": "FALSE", "BAD": "yes"}.get
+	for _, tt := range []struct {
+		key  string
+		def  bool
+		want bool
+	}{
+		{"T", false, true},
+		{"ONE", false, true},
+		{"F", true, false},
+		{"UNSET", true, true},
+		{"UNSET", false, false},
+	} {
+		got, err := EnvBool(e, tt.key, tt.def)
+		assert.Equal(t, got, tt.want, "EnvBool(%s, %v)", tt.key, tt.def)
+		if err != nil {
+			t.Errorf("EnvBool(%s, %v): %v", tt.key, tt.def, err)
+		}
+	}
+	_, err := EnvBool(e, "BAD", false)
+	wantErr(t, err, "BAD:", `"yes"`)
+}
+
+func TestDurationUnmarshalText(t *testing.T) {
+	for text, want := range map[string]time.Duration{
+		"30s":   30 * time.Second,
+		"1m30s": 90 * time.Second,
+		"250ms": 250 * time.Millisecond,
+		"1ns":   time.Nanosecond,
+	} {
+		d := Duration(time.Hour)
+		err := d.UnmarshalText([]byte(text))
+		if err != nil {
+			t.Errorf("UnmarshalText(%q): %v", text, err)
+		}
+		assert.Equal(t, time.Duration(d), want, "UnmarshalText(%q)", text)
+	}
+	for _, text := range []string{"", "30", "soon", "0s", "-5s"} {
+		d := Duration(time.Hour)
+		if err := d.UnmarshalText([]byte(text)); err == nil {
+			t.Errorf("UnmarshalText(%q) = nil; want an error", text)
+		}
+		assert.Equal(t, time.Duration(d), time.Hour, "after a failed UnmarshalText(%q)", text)
+	}
+	var d Duration
+	wantErr(t, d.UnmarshalText([]byte("soon")), "invalid duration")
+}
+
+func TestParse(t *testing.T) {
+	for _, name := range []string{"config.yaml", "config.json"} {
+		data, err := os.ReadFile("testdata/" + name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := Parse(name, data)
+		if err != nil {
+			t.Errorf("Parse(%s): %v", name, err)
+		}
+		assert.Equal(t, got, shop(), "Parse(%s)", name)
+	}
+
+	// What a file leaves out keeps its default, even inside database.
+	partial := Defaults()
+	partial.Name = "shop"
+	partial.Database.URL = "sqlite://shop.db"
+	for name, data := range map[string]string{
+		"a.json": `{"name": "shop", "database": {"url": "sqlite://shop.db"}}`,
+		"a.yaml": "name: shop\ndatabase:\n  url: sqlite://shop.db\n",
+		"a.yml":  "name: shop\ndatabase: {url: 'sqlite://shop.db'}\n",
+	} {
+		got, err := Parse(name, []byte(data))
+		if err != nil {
+			t.Errorf("Parse(%s): %v", name, err)
+		}
+		assert.Equal(t, got, partial, "Parse(%s)", name)
+	}
+
+	for _, name := range []string{"empty.json", "empty.yaml"} {
+		got, err := Parse(name, nil)
+		if err != nil {
+			t.Errorf("Parse(%s) of nothing: %v", name, err)
+		}
+		assert.Equal(t, got, Defaults(), "Parse(%s) of nothing", name)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, tt := range []struct {
+		name, data string
+		mentions   []string
+	}{
+		{"a.json", `{"name": "shop", "log_lvl": "debug"}`, []string{"a.json", "log_lvl"}},
+		{"a.yaml", "name: shop\nlog_lvl: debug\n", []string{"a.yaml", "log_lvl"}},
+		{"a.yaml", "database:\n  urll: x\n", []string{"urll"}},
+		{"a.json", `{"timeout": "soon"}`, []string{"a.json", "soon"}},
+		{"a.yaml", "timeout: 30\n", []string{"a.yaml", "30"}},
+		{"a.json", `{"name": `, []string{"a.json"}},
+		{"a.yaml", "name: [shop\n", []string{"a.yaml"}},
+		{"config.toml", `name = "shop"`, []string{"config.toml", ".toml"}},
+		{"config", `{}`, []string{"config"}},
+	} {
+		got, err := Parse(tt.name, []byte(tt.data))
+		wantErr(t, err, tt.mentions...)
+		assert.Equal(t, got, Config{}, "Parse(%s, %q) with an error", tt.name, tt.data)
+	}
+}
+
+func TestApplyEnv(t *testing.T) {
+	cfg := shop()
+	err := ApplyEnv(&cfg, env{
+		"APP_NAME":               "shop-staging",
+		"APP_ADDR":               ":9090",
+		"APP_DEBUG":              "true",
+		"APP_LOG_LEVEL":          "warn",
+		"APP_TIMEOUT":            "5s",
+		"APP_DATABASE_URL":       "postgres://db/shop",
+		"APP_DATABASE_MAX_CONNS": "5",
+		"APP_ALLOWED_ORIGINS":    " https://a.example.com,,https://b.example.com , ",
+		"APP_OTHER":              "ignored",
+	}.get)
+	if err != nil {
+		t.Errorf("ApplyEnv: %v", err)
+	}
+	assert.Equal(t, cfg, Config{
+		Name:           "shop-staging",
+		Addr:           ":9090",
+		Debug:          true,
+		LogLevel:       "warn",
+		Timeout:        Duration(5 * time.Second),
+		Database:       Database{URL: "postgres://db/shop", MaxConns: 5},
+		AllowedOrigins: []string{"https://a.example.com", "https://b.example.com"},
+	})
+
+	// Variables that aren't set change nothing.
+	cfg = shop()
+	cfg.Debug = true
+	want := cfg
+	err = ApplyEnv(&cfg, env{}.get)
+	if err != nil {
+		t.Errorf("ApplyEnv with no variables: %v", err)
+	}
+	assert.Equal(t, cfg, want, "ApplyEnv with no variables")
+}
+
+func TestApplyEnvErrors(t *testing.T) {
+	cfg := shop()
+	err := ApplyEnv(&cfg, env{
+		"APP_DEBUG":              "yes",
+		"APP_TIMEOUT":            "soon",
+		"APP_DATABASE_MAX_CONNS": "many",
+		"APP_NAME":               "shop-staging",
+	}.get)
+	wantErr(t, err, "APP_DEBUG", "APP_TIMEOUT", "APP_DATABASE_MAX_CONNS")
+	assert.Equal(t, cfg.Name, "shop-staging", "Name, which was fine")
+	assert.Equal(t, cfg.Debug, false, "Debug, after a bad APP_DEBUG")
+	assert.Equal(t, cfg.Timeout, Duration(90*time.Second), "Timeout, after a bad APP_TIMEOUT")
+	assert.Equal(t, cfg.Database.MaxConns, 20, "Database.MaxConns, after a bad APP_DATABASE_MAX_CONNS")
+}
+
+func TestValidate(t *testing.T) {
+	if err := Validate(valid()); err != nil {
+		t.Errorf("Validate of a valid config: %v", err)
+	}
+	for _, level := range []string{"debug", "info", "warn", "error"} {
+		cfg := valid()
+		cfg.LogLevel = level
+		if err := Validate(cfg); err != nil {
+			t.Errorf("Validate with log_level %s: %v", level, err)
+		}
+	}
+	cfg := valid()
+	cfg.Database.MaxConns = 1
+	cfg.Timeout = Duration(time.Nanosecond)
+	if err := Validate(cfg); err != nil {
+		t.Errorf("Validate with the smallest max_conns and timeout: %v", err)
+	}
+	for _, addr := range []string{":80", "localhost:8080", "[::1]:443"} {
+		cfg := valid()
+		cfg.Addr = addr
+		if err := Validate(cfg); err != nil {
+			t.Errorf("Validate with addr %q: %v", addr, err)
+		}
+	}
+
+	assert.Equal(t, problems(t, Validate(Config{})), []string{
+		"name: is required",
+		"database.url: is required",
+		"database.max_conns: must be at least 1",
+		"timeout: must be positive",
+		"addr: missing port in address",
+		`log_level: unknown level ""`,
+	}, "Validate(Config{})")
+
+	cfg = valid()
+	cfg.Addr = "localhost"
+	cfg.LogLevel = "verbose"
+	cfg.Database.MaxConns = -1
+	assert.Equal(t, problems(t, Validate(cfg)), []string{
+		"database.max_conns: must be at least 1",
+		"addr: address localhost: missing port in address",
+		`log_level: unknown level "verbose"`,
+	})
+}
+
+func TestLoadConfig(t *testing.T) {
+	fsys := fstest.MapFS{
+		"config.yaml":      {Data: []byte("name: shop\nlog_level: warn\n")},
+		"prod/config.json": {Data: []byte(`{"name": "shop-prod", "addr": ":443"}`)},
+	}
+	dbURL := "postgres://db/shop"
+
+	want := valid()
+	want.Database.URL = dbURL
+	want.LogLevel = "warn"
+	got, err := LoadConfig(fsys, env{"APP_DATABASE_URL": dbURL}.get)
+	if err != nil {
+		t.Errorf("LoadConfig with config.yaml: %v", err)
+	}
+	assert.Equal(t, got, want, "LoadConfig with config.yaml")
+
+	// The environment wins over the file.
+	want.LogLevel = "error"
+	got, err = LoadConfig(fsys, env{"APP_DATABASE_URL": dbURL, "APP_LOG_LEVEL": "error"}.get)
+	if err != nil {
+		t.Errorf("LoadConfig with APP_LOG_LEVEL: %v", err)
+	}
+	assert.Equal(t, got, want, "LoadConfig with APP_LOG_LEVEL")
+
+	want = valid()
+	want.Name = "shop-prod"
+	want.Addr = ":443"
+	want.Database.URL = dbURL
+	got, err = LoadConfig(fsys, env{"APP_CONFIG": "prod/config.json", "APP_DATABASE_URL": dbURL}.get)
+	if err != nil {
+		t.Errorf("LoadConfig with APP_CONFIG: %v", err)
+	}
+	assert.Equal(t, got, want, "LoadConfig with APP_CONFIG")
+
+	// No config.yaml is fine: everything can come from the environment.
+	want = valid()
+	want.Database.URL = dbURL
+	got, err = LoadConfig(fstest.MapFS{}, env{"APP_NAME": "shop", "APP_DATABASE_URL": dbURL}.get)
+	if err != nil {
+		t.Errorf("LoadConfig without config.yaml: %v", err)
+	}
+	assert.Equal(t, got, want, "LoadConfig without config.yaml")
+}
+
+func TestLoadConfigErrors(t *testing.T) {
+	fsys := fstest.MapFS{
+		"config.yaml": {Data: []byte("name: shop\n")},
+		"broken.yaml": {Data: []byte("name: shop\ncolour: blue\n")},
+	}
+	dbURL := "postgres://db/shop"
+
+	got, err := LoadConfig(fsys, env{"APP_CONFIG": "missing.yaml", "APP_DATABASE_URL": dbURL}.get)
+	if !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("LoadConfig with a missing APP_CONFIG file: error %v; want one that is fs.ErrNotExist", err)
+	}
+	assert.Equal(t, got, Config{}, "LoadConfig with a missing APP_CONFIG file")
+
+	got, err = LoadConfig(fsys, env{"APP_CONFIG": "broken.yaml", "APP_DATABASE_URL": dbURL}.get)
+	wantErr(t, err, "broken.yaml", "colour")
+	assert.Equal(t, got, Config{}, "LoadConfig with a broken file")
+
+	got, err = LoadConfig(fsys, env{"APP_DATABASE_URL": dbURL, "APP_DEBUG": "sure"}.get)
+	wantErr(t, err, "APP_DEBUG")
+	assert.Equal(t, got, Config{}, "LoadConfig with a bad APP_DEBUG")
+
+	// No APP_DATABASE_URL: the file is fine, but the result isn't.
+	got, err = LoadConfig(fsys, env{}.get)
+	assert.Equal(t, problems(t, err), []string{"database.url: is required"}, "LoadConfig without a database URL")
+	assert.Equal(t, got, Config{}, "LoadConfig without a database URL")
+}
+
+func TestLoadConfigTestdata(t *testing.T) {
+	want := shop()
+	want.Database.URL = "postgres://db/shop"
+	for _, name := range []string{"config.yaml", "config.json"} {
+		got, err := LoadConfig(os.DirFS("testdata"), env{
+			"APP_CONFIG":       name,
+			"APP_DATABASE_URL": "postgres://db/shop",
+		}.get)
+		if err != nil {
+			t.Errorf("LoadConfig(testdata/%s): %v", name, err)
+		}
+		assert.Equal(t, got, want, "LoadConfig(testdata/%s)", name)
+	}
+}
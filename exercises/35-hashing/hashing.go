@@ -0,0 +1,180 @@
+//go:build !solutions
+
+package hashing
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Exercise 35: Hashing and message digests
+//
+// Node has crypto.createHash("sha256"), crypto.createHmac,
+// crypto.timingSafeEqual, and bcrypt from npm. Go has crypto/sha256,
+// crypto/hmac and crypto/subtle in the standard library, and bcrypt in
+// golang.org/x/crypto, which this repo's go.mod already requires.
+//
+// A hash in Go is a hash.Hash: an io.Writer you write data into, with
+// Sum(nil) to get the digest. Because it's a Writer, io.Copy can feed
+// it a file of any size a buffer at a time, like piping a stream into
+// createHash in Node.
+//
+// The three tools do different jobs:
+//
+//   - SHA-256 fingerprints data: anyone can compute it, so it proves a
+//     file is the one you expected, not who sent it.
+//   - HMAC-SHA256 mixes in a secret key: only someone with the key can
+//     make the signature, so it proves a message came from them.
+//   - bcrypt is for passwords: slow on purpose, and salted, so a leaked
+//     table of hashes takes years to guess from instead of seconds.
+//
+// The tests check against published test vectors: FIPS 180-2 for
+// SHA-256 and RFC 4231 for HMAC.
+//
+// Run tests with: go test -v
+
+// 1. Streaming a hash
+// SumReader returns the SHA-256 of everything r gives, as 64 lowercase
+// hex digits. Don't read it all into memory first: copy it into
+// sha256.New() with io.Copy, which goes 32 KB at a time, so a 10 GB
+// file hashes in as little memory as a 10-byte one. If r fails, return
+// its error.
+//
+// hex.EncodeToString turns the digest's bytes into hex, like
+// digest("hex") in Node.
+func SumReader(r io.Reader) (string, error) {
+	// TODO: h := sha256.New(), io.Copy(h, r), then h.Sum(nil)
+	return "", nil
+}
+
+// 2. Hashing a file
+// SumFile returns the SHA-256 of the file at path, like `sha256sum
+// path`, streaming it the same way. Close the file when done.
+func SumFile(path string) (string, error) {
+	// TODO: os.Open and SumReader
+	return "", nil
+}
+
+// 3. HMAC
+// Sign returns the HMAC-SHA256 of msg under key, in hex: hmac.New
+// with sha256.New and the key makes a hash.Hash like any other.
+// Webhooks are signed this way: GitHub and Stripe send one in a header,
+// and you recompute it to check the body came from them.
+func Sign(key, msg []byte) string {
+	// TODO: hmac.New(sha256.New, key)
+	return ""
+}
+
+// 4. Constant-time comparison
+// Equal reports whether a and b are the same bytes, taking as long for
+// any two of a length however early they differ.
+//
+// bytes.Equal, like === on strings in JS, stops at the first byte that
+// differs. Comparing a signature an attacker sent with the right one,
+// that's a leak: a guess whose first byte is right takes a little
+// longer to reject, and timing enough guesses recovers the signature a
+// byte at a time. So look at every byte, OR-ing together the XOR of
+// each pair, and only then decide. The lengths may differ early: a
+// signature's length is no secret.
+//
+// crypto/subtle.ConstantTimeCompare and hmac.Equal do this for you, and
+// you'd use them in real code; write it once here to see how.
+func Equal(a, b []byte) bool {
+	// TODO
+	return false
+}
+
+// 5. Verifying a signature
+// Verify reports whether sig is Sign(key, msg). Decode sig from hex
+// and compare the raw bytes with Equal; a sig that isn't hex is simply
+// wrong. Never compare with ==.
+func Verify(key, msg []byte, sig string) bool {
+	// TODO: hex.DecodeString, then Equal with a fresh MAC
+	return false
+}
+
+// ErrBadToken is returned for a token that wasn't made by SignToken
+// with the same key, or was changed since.
+var ErrBadToken = errors.New("bad token")
+
+// 6. Signed tokens
+// SignToken makes a token carrying payload that only holders of key
+// could have made, the idea behind signed cookies and JWTs:
+//
+//	base64url(payload) + "." + Sign(key, base64url(payload))
+//
+// with base64.RawURLEncoding, which needs no escaping in a URL or a
+// cookie. The payload isn't secret, only tamper-proof: anyone can
+// decode it.
+//
+// OpenToken checks a token and returns its payload. A token that
+// doesn't have that shape, or whose signature Verify rejects, returns
+// ErrBadToken.
+func SignToken(key []byte, payload string) string {
+	// TODO
+	return ""
+}
+
+func OpenToken(key []byte, token string) (string, error) {
+	// TODO: strings.Cut at the "."
+	return "", nil
+}
+
+// 7. Hashing passwords
+// HashPassword hashes a password with bcrypt at the given cost, from
+// bcrypt.MinCost, 4, up; bcrypt.DefaultCost is 10. Each step up
+// doubles the time it takes, to hash and to guess. The result, like
+// "$2a$10$N9qo8uLOickgx2ZMRZoMye...", holds the cost and a random salt
+// with the hash, so the same password hashes differently every time
+// and needs nothing stored beside it.
+//
+// bcrypt only looks at the first 72 bytes of a password, and
+// GenerateFromPassword returns an error for a longer one rather than
+// ignore the rest; return it.
+//
+// Never hash a password with SHA-256: it's built to be fast, and a GPU
+// tries billions of guesses a second.
+func HashPassword(password string, cost int) (string, error) {
+	// TODO: bcrypt.GenerateFromPassword
+	return "", nil
+}
+
+// 8. Checking a password
+// CheckPassword reports whether password is the one hash was made
+// from. A wrong password isn't an error, just false; a hash bcrypt
+// can't read is. bcrypt.CompareHashAndPassword re-hashes with the
+// salt and cost in hash, and compares in constant time; a wrong
+// password is bcrypt.ErrMismatchedHashAndPassword.
+func CheckPassword(hash, password string) (bool, error) {
+	// TODO
+	return false, nil
+}
+
+// 9. Raising the cost
+// NeedsRehash reports whether hash was made with a lower cost than
+// cost, or can't be read. Computers get faster, so a service raises its
+// cost every few years; the hashes it has can't be upgraded without
+// the passwords, so it re-hashes each one when its user next logs in
+// and CheckPassword has the password in hand. bcrypt.Cost reads a
+// hash's cost.
+func NeedsRehash(hash string, cost int) bool {
+	// TODO
+	return false
+}
+
+// Keep imports used
+var _ = hmac.New
+var _ = sha256.New
+var _ = base64.RawURLEncoding
+var _ = hex.EncodeToString
+var _ = os.Open
+var _ = strings.Cut
+var _ = bcrypt.GenerateFromPassword
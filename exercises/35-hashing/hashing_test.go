@@ -0,0 +1,287 @@
+package hashing
+
+import (
+	"encoding/hex"
+	"errors"
+	"io"
+	"io/fs"
+	"runtime"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/imgarylai/learn-go/internal/assert"
+	"github.com/imgarylai/learn-go/internal/testutil"
+)
+
+// repeat is an endless stream of one byte, so tests can hash a lot of
+// data without having it in memory.
+type repeat byte
+
+func (r repeat) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = byte(r)
+	}
+	return len(p), nil
+}
+
+// failing gives some data, then an error.
+type failing struct{ sent bool }
+
+var errDisk = errors.New("disk on fire")
+
+func (f *failing) Read(p []byte) (int, error) {
+	if f.sent {
+		return 0, errDisk
+	}
+	f.sent = true
+	return copy(p, "some data"), nil
+}
+
+// FIPS 180-2's examples.
+var sha256Vectors = []struct {
+	name string
+	r    func() io.Reader
+	want string
+}{
+	{"empty", func() io.Reader { return strings.NewReader("") }, "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"},
+	{"abc", func() io.Reader { return strings.NewReader("abc") }, "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"},
+	{"two blocks", func() io.Reader {
+		return strings.NewReader("abcdbcdecdefdefgefghfghighijhijkijkljklmklmnlmnomnopnopq")
+	}, "248d6a61d20638b8e5c026930c3e6039a33ce45964ff2167f6ecedd419db06c1"},
+	{"a million a's", func() io.Reader { return io.LimitReader(repeat('a'), 1_000_000) }, "cdc76e5c9914fb9281a1c7e284d73e67f1809a48a497200e046d39ccc7112cd0"},
+}
+
+func TestSumReader(t *testing.T) {
+	for _, tt := range sha256Vectors {
+		got, err := SumReader(tt.r())
+		if err != nil {
+			t.Errorf("SumReader(%s): %v", tt.name, err)
+		}
+		assert.Equal(t, got, tt.want, "SumReader(%s)", tt.name)
+	}
+
+	got, err := SumReader(&failing{})
+	if !errors.Is(err, errDisk) {
+		t.Errorf("SumReader of a failing reader: error %v; want %v", err, errDisk)
+	}
+	assert.Equal(t, got, "", "SumReader of a failing reader")
+}
+
+// TestSumReaderStreams hashes 64 MB and checks it didn't take anything
+// like 64 MB of memory to do it.
+func TestSumReaderStreams(t *testing.T) {
+	const size = 64 << 20
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	got, err := SumReader(io.LimitReader(repeat(0), size))
+	runtime.ReadMemStats(&after)
+	if err != nil || len(got) != 64 {
+		t.Fatalf("SumReader of 64 MB = %q, %v; want 64 hex digits", got, err)
+	}
+	if alloc := after.TotalAlloc - before.TotalAlloc; alloc > 1<<20 {
+		t.Errorf("SumReader of 64 MB allocated %d MB; stream it with io.Copy instead of reading it all", alloc>>20)
+	}
+}
+
+func TestSumFile(t *testing.T) {
+	got, err := SumFile("testdata/proverbs.txt")
+	if err != nil {
+		t.Errorf("SumFile: %v", err)
+	}
+	// What `sha256sum testdata/proverbs.txt` says.
+	assert.Equal(t, got, "f81a24b4fe30434963c95cf59c615499c04adfdcb21cf106eb033692ddbfe169")
+
+	got, err = SumFile("testdata/missing.txt")
+	if !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("SumFile of a missing file: error %v; want one that is fs.ErrNotExist", err)
+	}
+	assert.Equal(t, got, "", "SumFile of a missing file")
+}
+
+// RFC 4231's HMAC-SHA256 test cases 1 to 4, 6 and 7. Case 5 truncates
+// the output.
+var hmacVectors = []struct {
+	name      string
+	key, data string // hex
+	want      string
+}{
+	{"case 1", strings.Repeat("0b", 20), hex.EncodeToString([]byte("Hi There")),
+		"b0344c61d8db38535ca8afceaf0bf12b881dc200c9833da726e9376c2e32cff7"},
+	{"case 2", hex.EncodeToString([]byte("Jefe")), hex.EncodeToString([]byte("what do ya want for nothing?")),
+		"5bdcc146bf60754e6a042426089575c75a003f089d2739839dec58b964ec3843"},
+	{"case 3", strings.Repeat("aa", 20), strings.Repeat("dd", 50),
+		"773ea91e36800e46854db8ebd09181a72959098b3ef8c122d9635514ced565fe"},
+	{"case 4", "0102030405060708090a0b0c0d0e0f10111213141516171819", strings.Repeat("cd", 50),
+		"82558a389a443c0ea4cc819899f2083a85f0faa3e578f8077a2e3ff46729665b"},
+	{"case 6", strings.Repeat("aa", 131), hex.EncodeToString([]byte("Test Using Larger Than Block-Size Key - Hash Key First")),
+		"60e431591ee0b67f0d8a26aacbf5b77f8e0bc6213728c5140546040f0ee37f54"},
+	{"case 7", strings.Repeat("aa", 131), hex.EncodeToString([]byte("This is a test using a larger than block-size key and a larger than block-size data. The key needs to be hashed before being used by the HMAC algorithm.")),
+		"9b09ffa71b942fcb27635fbcd5b0e944bfdc63644f0713938a7f51535c3a35e2"},
+}
+
+func TestSign(t *testing.T) {
+	for _, tt := range hmacVectors {
+		got := Sign(testutil.Unhex(t, tt.key), testutil.Unhex(t, tt.data))
+		assert.Equal(t, got, tt.want, "Sign for RFC 4231 %s", tt.name)
+	}
+}
+
+func TestEqual(t *testing.T) {
+	for _, tt := range []struct {
+		a, b string
+		want bool
+	}{
+		{"", "", true},
+		{"secret", "secret", true},
+		{"secret", "secreT", false},
+		{"secret", "Secret", false},
+		{"secret", "secret!", false},
+		{"secret!", "secret", false},
+		{"secret", "", false},
+		{"\x01", "\x03", false},
+		{"\x80\x00", "\x00\x80", false},
+	} {
+		assert.Equal(t, Equal([]byte(tt.a), []byte(tt.b)), tt.want, "Equal(%q, %q)", tt.a, tt.b)
+	}
+}
+
+func TestVerify(t *testing.T) {
+	for _, tt := range hmacVectors {
+		key, data := testutil.Unhex(t, tt.key), testutil.Unhex(t, tt.data)
+		assert.Equal(t, Verify(key, data, tt.want), true, "Verify for RFC 4231 %s", tt.name)
+	}
+
+	key, msg := []byte("Jefe"), []byte("what do ya want for nothing?")
+	sig := hmacVectors[1].want
+	for _, bad := range []string{
+		"",
+		sig[:62],
+		sig + "00",
+		"0" + sig[1:],
+		sig[:63] + "4",
+		strings.ToUpper(sig[:2]) + "zz" + sig[4:],
+		"not hex at all",
+	} {
+		assert.Equal(t, Verify(key, msg, bad), false, "Verify with signature %q", bad)
+	}
+	assert.Equal(t, Verify([]byte("jefe"), msg, sig), false, "Verify with the wrong key")
+	assert.Equal(t, Verify(key, []byte("what do ya want for nothing!"), sig), false, "Verify of a changed message")
+	assert.Equal(t, Verify(key, msg, strings.ToUpper(sig)), true, "Verify with the signature in upper case")
+}
+
+func TestSignToken(t *testing.T) {
+	key := []byte("cookie secret")
+	for _, payload := range []string{`{"user":42}`, "", "a.b.c", "~?&/+=é"} {
+		token := SignToken(key, payload)
+		body, sig, _ := strings.Cut(token, ".")
+		if strings.ContainsAny(body, "+/=") {
+			t.Errorf("SignToken(%q) = %q; encode the payload with base64.RawURLEncoding", payload, token)
+		}
+		assert.Equal(t, sig, Sign(key, []byte(body)), "the signature in SignToken(%q)", payload)
+
+		got, err := OpenToken(key, token)
+		if err != nil {
+			t.Errorf("OpenToken(SignToken(%q)): %v", payload, err)
+		}
+		assert.Equal(t, got, payload, "OpenToken(SignToken(%q))", payload)
+	}
+	assert.Equal(t, SignToken(key, `{"user":42}`),
+		"eyJ1c2VyIjo0Mn0.09b33e4f41dc5368fe98e5a9cebb29e12185f3dbdc1a28ea03cfc39622d343d5")
+}
+
+func TestOpenTokenRejects(t *testing.T) {
+	key := []byte("cookie secret")
+	token := SignToken(key, `{"user":42}`)
+	body, sig, _ := strings.Cut(token, ".")
+	if len(sig) != 64 {
+		t.Fatalf("SignToken(key, %q) = %q; want a payload, a dot and 64 hex digits", `{"user":42}`, token)
+	}
+	forged := "eyJ1c2VyIjoxfQ" // {"user":1}
+	for name, bad := range map[string]string{
+		"empty":                  "",
+		"no signature":           body,
+		"only a signature":       "." + sig,
+		"another payload":        forged + "." + sig,
+		"a changed signature":    body + "." + sig[:63] + "0",
+		"a signed bad payload":   "!!!." + Sign(key, []byte("!!!")),
+		"the signature repeated": token + "." + sig,
+	} {
+		got, err := OpenToken(key, bad)
+		if !errors.Is(err, ErrBadToken) {
+			t.Errorf("OpenToken of %s: error %v; want ErrBadToken", name, err)
+		}
+		assert.Equal(t, got, "", "OpenToken of %s", name)
+	}
+	if _, err := OpenToken([]byte("another secret"), token); !errors.Is(err, ErrBadToken) {
+		t.Errorf("OpenToken with the wrong key: error %v; want ErrBadToken", err)
+	}
+}
+
+func TestHashPassword(t *testing.T) {
+	hash, err := HashPassword("hunter2", bcrypt.MinCost)
+	if err != nil {
+		t.Fatalf("HashPassword: %v", err)
+	}
+	if !strings.HasPrefix(hash, "$2a$04$") || len(hash) != 60 {
+		t.Errorf("HashPassword = %q; want 60 characters from bcrypt at cost 4", hash)
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(hash), []byte("hunter2")); err != nil {
+		t.Errorf("bcrypt doesn't accept hunter2 for HashPassword(hunter2): %v", err)
+	}
+	again, _ := HashPassword("hunter2", bcrypt.MinCost)
+	if again == hash {
+		t.Errorf("HashPassword gave %q twice; each hash should have its own salt", hash)
+	}
+	hash, _ = HashPassword("hunter2", bcrypt.MinCost+1)
+	assert.Equal(t, strings.HasPrefix(hash, "$2a$05$"), true, "HashPassword at cost 5 = %q", hash)
+
+	hash, err = HashPassword(strings.Repeat("x", 73), bcrypt.MinCost)
+	if !errors.Is(err, bcrypt.ErrPasswordTooLong) {
+		t.Errorf("HashPassword of 73 bytes: error %v; want bcrypt.ErrPasswordTooLong", err)
+	}
+	assert.Equal(t, hash, "", "HashPassword of 73 bytes")
+}
+
+func TestCheckPassword(t *testing.T) {
+	// From golang.org/x/crypto/bcrypt's own tests.
+	const allmine = "$2a$10$XajjQvNhvvRt5GSeFk1xFeyqRrsxkhBkUiQeg0dt.wU1qD4aFDcga"
+	for _, tt := range []struct {
+		hash, password string
+		want           bool
+	}{
+		{allmine, "allmine", true},
+		{allmine, "allmine ", false},
+		{allmine, "Allmine", false},
+		{allmine, "", false},
+	} {
+		got, err := CheckPassword(tt.hash, tt.password)
+		if err != nil {
+			t.Errorf("CheckPassword(%q, %q): %v", tt.hash, tt.password, err)
+		}
+		assert.Equal(t, got, tt.want, "CheckPassword(%q, %q)", tt.hash, tt.password)
+	}
+
+	hash, _ := bcrypt.GenerateFromPassword([]byte("correct horse"), bcrypt.MinCost)
+	ok, err := CheckPassword(string(hash), "correct horse")
+	if !ok || err != nil {
+		t.Errorf("CheckPassword of a fresh hash = %v, %v; want true, nil", ok, err)
+	}
+
+	for _, bad := range []string{"", "allmine", "$2a$10$short", strings.Replace(allmine, "$2a$", "$9z$", 1)} {
+		ok, err := CheckPassword(bad, "allmine")
+		if err == nil || ok {
+			t.Errorf("CheckPassword(%q, ...) = %v, %v; want false and an error", bad, ok, err)
+		}
+	}
+}
+
+func TestNeedsRehash(t *testing.T) {
+	hash, _ := bcrypt.GenerateFromPassword([]byte("pw"), 5)
+	for cost, want := range map[int]bool{4: false, 5: false, 6: true, 10: true} {
+		assert.Equal(t, NeedsRehash(string(hash), cost), want, "NeedsRehash(cost 5 hash, %d)", cost)
+	}
+	assert.Equal(t, NeedsRehash("not a hash", 4), true, "NeedsRehash of a hash bcrypt can't read")
+	assert.Equal(t, NeedsRehash("", bcrypt.MinCost), true, `NeedsRehash("")`)
+}
@@ -0,0 +1,141 @@
+//go:build !solutions
+
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+// Exercise 36: Symmetric encryption
+//
+// In Node you'd call crypto.createCipheriv("aes-256-gcm", key, iv),
+// then update, final and getAuthTag, and keep the IV and the tag next
+// to the ciphertext yourself. Go's crypto/cipher has an AEAD interface
+// ("authenticated encryption with associated data") that does it in
+// two calls, Seal and Open, and AES-GCM is the one to use.
+//
+// Authenticated means Open doesn't only decrypt: it checks a 16-byte
+// tag that Seal computed, and fails if a single bit of the ciphertext,
+// or the key, is different. Plain AES-CBC or AES-CTR would hand back
+// garbage, or worse, something an attacker chose, without a word.
+//
+// GCM needs a nonce (a "number used once"), 12 bytes, for every Seal:
+// using one twice with the same key leaks the XOR of the two
+// plaintexts and lets anyone forge tags. Random nonces from crypto/rand
+// are safe for billions of messages per key, and since the nonce isn't
+// secret, it's stored in front of the ciphertext:
+//
+//	nonce (12 bytes) | ciphertext (as long as the plaintext) | tag (16 bytes)
+//
+// A key must be random bytes, not a password. DeriveKey turns a
+// passphrase into one with scrypt, from golang.org/x/crypto, which this
+// repo's go.mod already requires.
+//
+// The tests use the AES-GCM spec's and RFC 7914's test vectors, and
+// flip every byte of a ciphertext to check each is caught.
+//
+// Run tests with: go test -v
+
+// KeySize is the length of a key, in bytes: 32 makes it AES-256.
+const KeySize = 32
+
+// ErrDecrypt is returned for a ciphertext that can't be decrypted: too
+// short to hold a nonce and a tag, changed since it was made, or made
+// with another key or other associated data. Which of those it was is
+// deliberately not said.
+var ErrDecrypt = errors.New("decryption failed")
+
+// 1. Random keys
+// NewKey returns a new random key of KeySize bytes from crypto/rand,
+// the operating system's secure random source. Never math/rand: its
+// numbers can be predicted from a few outputs.
+func NewKey() []byte {
+	// TODO: rand.Read
+	return nil
+}
+
+// 2. Encrypting
+// Encrypt encrypts plaintext with AES-GCM under key, which must be 16,
+// 24 or 32 bytes, and returns nonce|ciphertext|tag with a new random
+// nonce. aes.NewCipher(key) makes the block cipher, cipher.NewGCM(block)
+// wraps it in GCM, and its NonceSize() says how long a nonce is.
+//
+// aad, the associated data, is authenticated but not encrypted or
+// stored: Decrypt must be given the same. Pass the ID of the record the
+// ciphertext belongs to, and it can't be copied into another one. It
+// may be nil.
+//
+// gcm.Seal(dst, nonce, plaintext, aad) appends to dst, so
+// Seal(nonce, nonce, ...) gives the nonce and what follows in one
+// slice.
+func Encrypt(key, plaintext, aad []byte) ([]byte, error) {
+	// TODO
+	return nil, nil
+}
+
+// 3. Decrypting
+// Decrypt reverses Encrypt: it splits off the nonce and opens the rest
+// with gcm.Open. A key of the wrong length is aes.NewCipher's error;
+// anything else wrong is ErrDecrypt.
+func Decrypt(key, ciphertext, aad []byte) ([]byte, error) {
+	// TODO: check the length before slicing
+	return nil, nil
+}
+
+// KDFParams are scrypt's cost parameters: N, a power of two, is the
+// CPU and memory cost, R the block size, and P how many in parallel. It
+// takes 128*N*R bytes of memory.
+type KDFParams struct {
+	N, R, P int
+}
+
+// DefaultParams are scrypt's recommended costs for interactive logins:
+// 32 MB of memory and about 50 ms.
+var DefaultParams = KDFParams{N: 1 << 15, R: 8, P: 1}
+
+// 4. Keys from passphrases
+// DeriveKey makes a key of KeySize bytes from a passphrase and a salt
+// with scrypt.Key. Like bcrypt in exercise 35, scrypt is slow on
+// purpose, and uses a lot of memory too, so guessing passphrases costs
+// an attacker as much as it can. The salt makes the same passphrase
+// give a different key each time it's used with a new one.
+func DeriveKey(passphrase string, salt []byte, p KDFParams) ([]byte, error) {
+	// TODO: scrypt.Key
+	return nil, nil
+}
+
+// SaltSize is the length of the salt EncryptWithPassphrase uses.
+const SaltSize = 16
+
+// 5. Encrypting with a passphrase
+// EncryptWithPassphrase encrypts plaintext with a key DeriveKey makes
+// from passphrase, a new random salt of SaltSize bytes, and
+// DefaultParams, and returns
+//
+//	salt | Encrypt(key, plaintext, nil)
+//
+// so the salt is there to derive the key again. Like the nonce, it
+// isn't secret.
+//
+// DecryptWithPassphrase reverses it. As with Decrypt, anything wrong
+// with the data or the passphrase is ErrDecrypt.
+func EncryptWithPassphrase(passphrase string, plaintext []byte) ([]byte, error) {
+	// TODO
+	return nil, nil
+}
+
+func DecryptWithPassphrase(passphrase string, data []byte) ([]byte, error) {
+	// TODO
+	return nil, nil
+}
+
+// Keep imports used
+var _ = aes.NewCipher
+var _ = cipher.NewGCM
+var _ = rand.Read
+var _ = scrypt.Key
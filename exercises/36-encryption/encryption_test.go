@@ -0,0 +1,234 @@
+package encryption
+
+import (
+	"bytes"
+	"crypto/aes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/imgarylai/learn-go/internal/assert"
+	"github.com/imgarylai/learn-go/internal/testutil"
+)
+
+// The GCM spec's test cases 3 and 4: AES-128, a 12-byte nonce, and in
+// case 4 associated data. Decrypt takes them as nonce|ciphertext|tag.
+var gcmVectors = []struct {
+	name                         string
+	key, nonce, aad, pt, ct, tag string
+}{
+	{
+		name:  "case 3",
+		key:   "feffe9928665731c6d6a8f9467308308",
+		nonce: "cafebabefacedbaddecaf888",
+		pt:    "d9313225f88406e5a55909c5aff5269a86a7a9531534f7da2e4c303d8a318a721c3c0c95956809532fcf0e2449a6b525b16aedf5aa0de657ba637b391aafd255",
+		ct:    "42831ec2217774244b7221b784d0d49ce3aa212f2c02a4e035c17e2329aca12e21d514b25466931c7d8f6a5aac84aa051ba30b396a0aac973d58e091473f5985",
+		tag:   "4d5c2af327cd64a62cf35abd2ba6fab4",
+	},
+	{
+		name:  "case 4",
+		key:   "feffe9928665731c6d6a8f9467308308",
+		nonce: "cafebabefacedbaddecaf888",
+		aad:   "feedfacedeadbeeffeedfacedeadbeefabaddad2",
+		pt:    "d9313225f88406e5a55909c5aff5269a86a7a9531534f7da2e4c303d8a318a721c3c0c95956809532fcf0e2449a6b525b16aedf5aa0de657ba637b39",
+		ct:    "42831ec2217774244b7221b784d0d49ce3aa212f2c02a4e035c17e2329aca12e21d514b25466931c7d8f6a5aac84aa051ba30b396a0aac973d58e091",
+		tag:   "5bc94fbc3221a5db94fae95ae7121a47",
+	},
+}
+
+func TestNewKey(t *testing.T) {
+	a, b := NewKey(), NewKey()
+	assert.Equal(t, len(a), KeySize, "len(NewKey())")
+	if len(a) > 0 && bytes.Equal(a, b) {
+		t.Errorf("NewKey() gave %x twice; use crypto/rand", a)
+	}
+	if len(a) > 0 && bytes.Equal(a, make([]byte, KeySize)) {
+		t.Errorf("NewKey() = all zeros; fill it with rand.Read")
+	}
+}
+
+func TestDecryptVectors(t *testing.T) {
+	for _, tt := range gcmVectors {
+		sealed := testutil.Unhex(t, tt.nonce+tt.ct+tt.tag)
+		got, err := Decrypt(testutil.Unhex(t, tt.key), sealed, testutil.Unhex(t, tt.aad))
+		if err != nil {
+			t.Errorf("Decrypt of GCM %s: %v", tt.name, err)
+		}
+		assert.Equal(t, hex.EncodeToString(got), tt.pt, "Decrypt of GCM %s", tt.name)
+	}
+}
+
+func TestEncrypt(t *testing.T) {
+	key := NewKey()
+	for _, msg := range []string{"attack at dawn", "", "a longer message that takes more than one sixteen-byte block"} {
+		sealed, err := Encrypt(key, []byte(msg), nil)
+		if err != nil {
+			t.Errorf("Encrypt(%q): %v", msg, err)
+			continue
+		}
+		assert.Equal(t, len(sealed), 12+len(msg)+16, "len(Encrypt(%q)): nonce, ciphertext and tag", msg)
+		if len(msg) > 0 && bytes.Contains(sealed, []byte(msg)) {
+			t.Errorf("Encrypt(%q) = %x, which has the plaintext in it", msg, sealed)
+		}
+		got, err := Decrypt(key, sealed, nil)
+		if err != nil {
+			t.Errorf("Decrypt(Encrypt(%q)): %v", msg, err)
+		}
+		assert.Equal(t, string(got), msg, "Decrypt(Encrypt(%q))", msg)
+	}
+
+	// A new nonce each time: the same message never looks the same.
+	a, _ := Encrypt(key, []byte("yes"), nil)
+	b, _ := Encrypt(key, []byte("yes"), nil)
+	if len(a) >= 12 && bytes.Equal(a[:12], b[:12]) {
+		t.Errorf("Encrypt used nonce %x twice; make a new random one each time", a[:12])
+	}
+
+	// The vectors' keys, plaintexts and associated data round-trip too.
+	for _, tt := range gcmVectors {
+		key := testutil.Unhex(t, tt.key)
+		sealed, err := Encrypt(key, testutil.Unhex(t, tt.pt), testutil.Unhex(t, tt.aad))
+		if err != nil || len(sealed) < 12 {
+			t.Errorf("Encrypt of GCM %s = %x, %v", tt.name, sealed, err)
+			continue
+		}
+		got, err := Decrypt(key, sealed, testutil.Unhex(t, tt.aad))
+		if err != nil {
+			t.Errorf("Decrypt(Encrypt) of GCM %s: %v", tt.name, err)
+		}
+		assert.Equal(t, hex.EncodeToString(got), tt.pt, "Decrypt(Encrypt) of GCM %s", tt.name)
+	}
+}
+
+func TestKeySizes(t *testing.T) {
+	for _, n := range []int{16, 24, 32} {
+		key := bytes.Repeat([]byte{7}, n)
+		sealed, err := Encrypt(key, []byte("hi"), nil)
+		if err != nil {
+			t.Errorf("Encrypt with a %d-byte key: %v", n, err)
+		}
+		got, err := Decrypt(key, sealed, nil)
+		if err != nil || string(got) != "hi" {
+			t.Errorf("Decrypt(Encrypt) with a %d-byte key = %q, %v", n, got, err)
+		}
+	}
+	for _, n := range []int{0, 15, 33} {
+		key := make([]byte, n)
+		var sizeErr aes.KeySizeError
+		if _, err := Encrypt(key, []byte("hi"), nil); !errors.As(err, &sizeErr) {
+			t.Errorf("Encrypt with a %d-byte key: error %v; want an aes.KeySizeError", n, err)
+		}
+		if _, err := Decrypt(key, make([]byte, 40), nil); !errors.As(err, &sizeErr) {
+			t.Errorf("Decrypt with a %d-byte key: error %v; want an aes.KeySizeError", n, err)
+		}
+	}
+}
+
+// wantErrDecrypt fails t unless Decrypt returned ErrDecrypt and no
+// plaintext.
+func wantErrDecrypt(t *testing.T, got []byte, err error, what string) {
+	t.Helper()
+	if !errors.Is(err, ErrDecrypt) {
+		t.Errorf("%s: error %v; want ErrDecrypt", what, err)
+	}
+	if got != nil {
+		t.Errorf("%s returned %q along with its error", what, got)
+	}
+}
+
+func TestTamperDetection(t *testing.T) {
+	key := NewKey()
+	aad := []byte("user 42")
+	sealed, err := Encrypt(key, []byte("pay Bob $10"), aad)
+	if err != nil || len(sealed) != 12+11+16 {
+		t.Fatalf("Encrypt = %x, %v; want 39 bytes", sealed, err)
+	}
+
+	// Every byte counts: the nonce, the ciphertext and the tag.
+	for i := range sealed {
+		for _, bit := range []byte{0x01, 0x80} {
+			tampered := bytes.Clone(sealed)
+			tampered[i] ^= bit
+			got, err := Decrypt(key, tampered, aad)
+			wantErrDecrypt(t, got, err, fmt.Sprintf("Decrypt with bit %#x of byte %d flipped", bit, i))
+		}
+	}
+
+	got, err := Decrypt(NewKey(), sealed, aad)
+	wantErrDecrypt(t, got, err, "Decrypt with another key")
+	got, err = Decrypt(key, sealed, []byte("user 43"))
+	wantErrDecrypt(t, got, err, "Decrypt with other associated data")
+	got, err = Decrypt(key, sealed, nil)
+	wantErrDecrypt(t, got, err, "Decrypt without the associated data")
+	got, err = Decrypt(key, sealed[:len(sealed)-1], aad)
+	wantErrDecrypt(t, got, err, "Decrypt of a truncated ciphertext")
+	got, err = Decrypt(key, append(bytes.Clone(sealed), 0), aad)
+	wantErrDecrypt(t, got, err, "Decrypt with a byte appended")
+	for _, n := range []int{0, 11, 12, 27} {
+		got, err = Decrypt(key, make([]byte, n), aad)
+		wantErrDecrypt(t, got, err, fmt.Sprintf("Decrypt of %d bytes", n))
+	}
+}
+
+func TestDeriveKey(t *testing.T) {
+	// RFC 7914's scrypt test vectors 2 and 3, cut to KeySize bytes.
+	for _, tt := range []struct {
+		passphrase, salt string
+		p                KDFParams
+		want             string
+	}{
+		{"password", "NaCl", KDFParams{N: 1024, R: 8, P: 16}, "fdbabe1c9d3472007856e7190d01e9fe7c6ad7cbc8237830e77376634b373162"},
+		{"pleaseletmein", "SodiumChloride", KDFParams{N: 16384, R: 8, P: 1}, "7023bdcb3afd7348461c06cd81fd38ebfda8fbba904f8e3ea9b543f6545da1f2"},
+	} {
+		got, err := DeriveKey(tt.passphrase, []byte(tt.salt), tt.p)
+		if err != nil {
+			t.Errorf("DeriveKey(%q, %q): %v", tt.passphrase, tt.salt, err)
+		}
+		assert.Equal(t, hex.EncodeToString(got), tt.want, "DeriveKey(%q, %q, %+v)", tt.passphrase, tt.salt, tt.p)
+	}
+
+	if _, err := DeriveKey("password", []byte("NaCl"), KDFParams{N: 1000, R: 8, P: 1}); err == nil {
+		t.Error("DeriveKey with N = 1000, not a power of two: got no error")
+	}
+}
+
+func TestEncryptWithPassphrase(t *testing.T) {
+	const pass = "correct horse battery staple"
+	data, err := EncryptWithPassphrase(pass, []byte("the diary"))
+	if err != nil {
+		t.Fatalf("EncryptWithPassphrase: %v", err)
+	}
+	if len(data) != SaltSize+12+9+16 {
+		t.Fatalf("EncryptWithPassphrase = %d bytes; want %d: salt, nonce, ciphertext and tag", len(data), SaltSize+12+9+16)
+	}
+
+	// Its parts are what the doc says.
+	key, _ := DeriveKey(pass, data[:SaltSize], DefaultParams)
+	got, err := Decrypt(key, data[SaltSize:], nil)
+	if err != nil || string(got) != "the diary" {
+		t.Errorf("Decrypt with DeriveKey(pass, salt) = %q, %v; want the diary", got, err)
+	}
+
+	got, err = DecryptWithPassphrase(pass, data)
+	if err != nil {
+		t.Errorf("DecryptWithPassphrase: %v", err)
+	}
+	assert.Equal(t, string(got), "the diary", "DecryptWithPassphrase(EncryptWithPassphrase)")
+
+	again, _ := EncryptWithPassphrase(pass, []byte("the diary"))
+	if len(again) >= SaltSize && bytes.Equal(again[:SaltSize], data[:SaltSize]) {
+		t.Errorf("EncryptWithPassphrase used salt %x twice", data[:SaltSize])
+	}
+
+	got, err = DecryptWithPassphrase("Correct horse battery staple", data)
+	wantErrDecrypt(t, got, err, "DecryptWithPassphrase with the wrong passphrase")
+	tampered := bytes.Clone(data)
+	tampered[0] ^= 1 // the salt: the key comes out different
+	got, err = DecryptWithPassphrase(pass, tampered)
+	wantErrDecrypt(t, got, err, "DecryptWithPassphrase with the salt changed")
+	for _, n := range []int{0, SaltSize - 1, SaltSize, SaltSize + 27} {
+		got, err = DecryptWithPassphrase(pass, data[:n])
+		wantErrDecrypt(t, got, err, fmt.Sprintf("DecryptWithPassphrase of %d bytes", n))
+	}
+}
@@ -0,0 +1,188 @@
+//go:build !solutions
+
+package archives
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Exercise 37: Compression and archives
+//
+// Node needs zlib for gzip and npm packages like archiver, adm-zip or
+// tar for archives. Go's standard library has all of it:
+// compress/gzip, archive/zip and archive/tar. They're built on
+// io.Reader and io.Writer, so they stack like pipes:
+//
+//	file <- gzip.Writer <- tar.Writer <- your files    (a .tar.gz)
+//
+// A .zip compresses each file on its own and has an index at the end,
+// so it needs to seek (an io.ReaderAt). A .tar.gz is one tar stream,
+// gzipped as a whole, read front to back.
+//
+// Extracting has a classic hole, "zip slip": an archive's entry names
+// are just strings, and one called ../../.bashrc, or /etc/cron.d/x,
+// would be written outside the directory you meant, if you join it on
+// blindly. Every extractor must check each name first; SafePath does.
+//
+// Run tests with: go test -v
+
+// ErrUnsafePath is returned for an archive entry whose name would land
+// outside the directory being extracted to.
+var ErrUnsafePath = errors.New("unsafe path in archive")
+
+// createFile writes what r gives to a new file at path, making the
+// directories above it first. Files get mode 0o644 whatever the
+// archive says, so an archive can't make them executable, or setuid.
+func createFile(path string, r io.Reader) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// 1. gzip
+// Gzip compresses src into dst, like `gzip` does a file, and stores
+// name, the original file name, in the gzip header (gzip.Writer's
+// Name field), as gzip does.
+//
+// Close the gzip.Writer when done and return its error: it flushes
+// the last compressed block and writes the checksum, and without it
+// the output is cut short. In Node that's what stream end does.
+func Gzip(dst io.Writer, src io.Reader, name string) error {
+	// TODO: zw := gzip.NewWriter(dst)
+	return nil
+}
+
+// 2. gunzip
+// Gunzip decompresses src into dst and returns the name from its
+// header. gzip.NewReader fails on data that isn't gzip; reading fails
+// on data cut short or whose checksum doesn't match.
+func Gunzip(dst io.Writer, src io.Reader) (name string, err error) {
+	// TODO
+	return "", nil
+}
+
+// 3. Zip slip
+// SafePath returns where the entry called name in an archive goes
+// when extracting to dir: dir joined with name. If name could land
+// outside dir, because it is absolute or climbs out with "..", or it's
+// empty, return an error wrapping ErrUnsafePath that quotes it.
+//
+// Archives use "/" whatever the OS; filepath.FromSlash converts.
+// filepath.IsLocal (Go 1.20) reports whether a path stays inside the
+// directory it's relative to, and knows Windows' rules too. Checking
+// strings.HasPrefix(joined, dir) instead is a classic bug: /tmp/out2
+// starts with /tmp/out.
+func SafePath(dir, name string) (string, error) {
+	// TODO
+	return "", nil
+}
+
+// 4. Writing a zip
+// WriteZip writes every file and directory in fsys to w as a zip, with
+// their paths in fsys as names: "src/main.go". Use fs.WalkDir to visit
+// them, skipping ".", the root. zw.CreateHeader(&zip.FileHeader{...})
+// starts an entry and returns a writer for its contents:
+//
+//   - a directory has its name with "/" at the end, and no contents,
+//     so that empty directories survive
+//   - a file has Method zip.Deflate (the default, zip.Store, doesn't
+//     compress) and Modified set to its modification time
+//   - anything else (a symlink, say) is an error naming it
+//
+// Then Close the zip.Writer, which writes the index; zip.Writer.AddFS
+// does all this in real code, but write it once.
+func WriteZip(w io.Writer, fsys fs.FS) error {
+	// TODO: zw := zip.NewWriter(w), then fs.WalkDir(fsys, ".", ...)
+	return nil
+}
+
+// 5. Extracting a zip
+// ExtractZip extracts the zip in r, of size bytes, into dir, making it
+// if need be. zip.NewReader reads the index, and its File slice lists
+// the entries: f.Mode().IsDir() for a directory, which os.MkdirAll
+// makes with 0o755, and f.Mode().IsRegular() for a file, which f.Open
+// reads and createFile writes. Anything else is an error naming it.
+//
+// Check each name with SafePath before doing anything with it, and
+// stop at the first unsafe one.
+func ExtractZip(r io.ReaderAt, size int64, dir string) error {
+	// TODO
+	return nil
+}
+
+// 6. Writing a .tar.gz
+// WriteTarGz writes fsys to w as a gzipped tar, like
+// `tar -czf - -C dir .` without the "./": a tar.Writer on top of a
+// gzip.Writer. Each entry is a tw.WriteHeader(&tar.Header{...}), then,
+// for a file, its Size bytes written to tw:
+//
+//   - a directory: Typeflag tar.TypeDir, its name with "/" at the end,
+//     and Mode 0o755
+//   - a file: Typeflag tar.TypeReg, Mode 0o644, Size, and ModTime
+//   - anything else: an error naming it
+//
+// Close the tar.Writer, then the gzip.Writer: the tar footer has to be
+// compressed too.
+func WriteTarGz(w io.Writer, fsys fs.FS) error {
+	// TODO
+	return nil
+}
+
+// 7. Extracting a .tar.gz
+// ExtractTarGz extracts a gzipped tar from r into dir, as ExtractZip
+// does a zip. A tar.Reader is read entry by entry: tr.Next() returns
+// the next header, or io.EOF after the last, and then tr itself reads
+// that entry's contents.
+//
+// A tar can hold symlinks and hard links, and one called link pointing
+// at /etc followed by a file called link/passwd slips out just as well
+// as ../ does; treat them, like anything but a directory or a regular
+// file, as an error.
+func ExtractTarGz(r io.Reader, dir string) error {
+	// TODO
+	return nil
+}
+
+// 8. Picking by extension
+// CompressDir writes everything in dir to a new archive file, a zip if
+// its name ends in ".zip", a .tar.gz if it ends in ".tar.gz" or
+// ".tgz"; any other name is an error, before anything is created.
+// os.DirFS(dir) is dir as an fs.FS. If writing fails, remove the
+// half-written archive: it's no use to anyone.
+//
+// ExtractArchive extracts an archive file into dir, choosing the same
+// way. A zip needs its size: f.Stat() has it.
+func CompressDir(dir, archive string) error {
+	// TODO
+	return nil
+}
+
+func ExtractArchive(archive, dir string) error {
+	// TODO
+	return nil
+}
+
+// Keep imports used
+var _ = tar.NewWriter
+var _ = zip.NewWriter
+var _ = gzip.NewWriter
+var _ = fmt.Errorf
+var _ = strings.HasSuffix
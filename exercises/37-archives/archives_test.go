@@ -0,0 +1,582 @@
+package archives
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"io/fs"
+	"math/rand/v2"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"testing/iotest"
+	"time"
+
+	"github.com/imgarylai/learn-go/internal/assert"
+)
+
+// project is a small directory tree, with an empty directory in it.
+var project = fstest.MapFS{
+	"readme.md":           {Data: []byte("# demo\n"), ModTime: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)},
+	"src/main.go":         {Data: []byte("package main\n\nfunc main() {}\n")},
+	"src/util/strings.go": {Data: []byte(strings.Repeat("package util\n", 100))},
+	"docs/empty":          {Mode: fs.ModeDir | 0o755},
+}
+
+// projectTree is what extracting project should give, as tree sees it.
+var projectTree = map[string]string{
+	"docs/":               "",
+	"docs/empty/":         "",
+	"readme.md":           "# demo\n",
+	"src/":                "",
+	"src/main.go":         "package main\n\nfunc main() {}\n",
+	"src/util/":           "",
+	"src/util/strings.go": strings.Repeat("package util\n", 100),
+}
+
+// tree returns what's under dir: each file's slash-separated path and
+// contents, and each directory's path with a "/".
+func tree(t *testing.T, dir string) map[string]string {
+	t.Helper()
+	got := map[string]string{}
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || path == dir {
+			return err
+		}
+		rel, _ := filepath.Rel(dir, path)
+		rel = filepath.ToSlash(rel)
+		if d.IsDir() {
+			got[rel+"/"] = ""
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		got[rel] = string(data)
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return got
+}
+
+// checkDirModes fails t unless dir, and every directory under it, has
+// the permissions os.MkdirAll(path, 0o755) gives, after the umask.
+func checkDirModes(t *testing.T, dir string) {
+	t.Helper()
+	ref := filepath.Join(t.TempDir(), "ref")
+	if err := os.MkdirAll(ref, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	want, _ := os.Stat(ref)
+	filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || !d.IsDir() {
+			return err
+		}
+		if info, err := d.Info(); err == nil && info.Mode().Perm() != want.Mode().Perm() {
+			t.Errorf("%s has mode %v; want %v, from 0o755", path, info.Mode().Perm(), want.Mode().Perm())
+		}
+		return nil
+	})
+}
+
+func TestGzip(t *testing.T) {
+	text := strings.Repeat("all work and no play makes Jack a dull boy\n", 1000)
+	var buf bytes.Buffer
+	if err := Gzip(&buf, strings.NewReader(text), "jack.txt"); err != nil {
+		t.Fatalf("Gzip: %v", err)
+	}
+	if buf.Len() == 0 || buf.Len() > len(text)/20 {
+		t.Errorf("Gzip of %d repetitive bytes gave %d bytes; want far fewer", len(text), buf.Len())
+	}
+
+	zr, err := gzip.NewReader(&buf)
+	if err != nil {
+		t.Fatalf("gzip.NewReader of Gzip's output: %v", err)
+	}
+	assert.Equal(t, zr.Name, "jack.txt", "the name in the gzip header")
+	data, err := io.ReadAll(zr)
+	if err != nil {
+		t.Errorf("reading Gzip's output: %v; did you Close the gzip.Writer?", err)
+	}
+	assert.Equal(t, string(data), text, "Gzip's output, decompressed")
+}
+
+// brokenPipe is a writer that stops taking data.
+type brokenPipe struct{}
+
+func (brokenPipe) Write([]byte) (int, error) { return 0, errors.New("broken pipe") }
+
+func TestGzipErrors(t *testing.T) {
+	if err := Gzip(brokenPipe{}, strings.NewReader("data"), "x"); err == nil {
+		t.Error("Gzip to a broken pipe: got no error")
+	}
+	readErr := errors.New("read failed")
+	src := io.MultiReader(strings.NewReader("data"), iotest.ErrReader(readErr))
+	if err := Gzip(io.Discard, src, "x"); !errors.Is(err, readErr) {
+		t.Errorf("Gzip of a failing reader: error %v; want %v", err, readErr)
+	}
+}
+
+func TestGunzip(t *testing.T) {
+	// testdata/hello.txt.gz was made by `gzip -k hello.txt`.
+	data, err := os.ReadFile("testdata/hello.txt.gz")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out strings.Builder
+	name, err := Gunzip(&out, bytes.NewReader(data))
+	if err != nil {
+		t.Errorf("Gunzip: %v", err)
+	}
+	assert.Equal(t, name, "hello.txt", "the name Gunzip returned")
+	assert.Equal(t, out.String(), "Hello, gzip!\n", "what Gunzip wrote")
+
+	// Gzip and Gunzip round-trip.
+	var buf bytes.Buffer
+	Gzip(&buf, strings.NewReader("round trip"), "rt.txt")
+	out.Reset()
+	name, err = Gunzip(&out, &buf)
+	if err != nil || name != "rt.txt" || out.String() != "round trip" {
+		t.Errorf("Gunzip(Gzip(round trip)) = %q, %q, %v", out.String(), name, err)
+	}
+}
+
+func TestGunzipErrors(t *testing.T) {
+	data, _ := os.ReadFile("testdata/hello.txt.gz")
+	for _, tt := range []struct {
+		name string
+		data []byte
+		want error
+	}{
+		{"not gzip", []byte("Hello, gzip!\n"), gzip.ErrHeader},
+		{"cut short", data[:len(data)-4], io.ErrUnexpectedEOF},
+		{"a bad checksum", append(bytes.Clone(data[:len(data)-8]), 0, 0, 0, 0, 0x0d, 0, 0, 0), gzip.ErrChecksum},
+	} {
+		name, err := Gunzip(io.Discard, bytes.NewReader(tt.data))
+		if !errors.Is(err, tt.want) {
+			t.Errorf("Gunzip of %s: error %v; want %v", tt.name, err, tt.want)
+		}
+		assert.Equal(t, name, "", "the name from Gunzip of %s", tt.name)
+	}
+}
+
+func TestSafePath(t *testing.T) {
+	dir := filepath.Join("tmp", "out")
+	for name, want := range map[string]string{
+		"a.txt":         filepath.Join(dir, "a.txt"),
+		"src/main.go":   filepath.Join(dir, "src", "main.go"),
+		"src/../a.txt":  filepath.Join(dir, "a.txt"),
+		"./b/c":         filepath.Join(dir, "b", "c"),
+		"dir/":          filepath.Join(dir, "dir"),
+		"..foo/bar.txt": filepath.Join(dir, "..foo", "bar.txt"),
+	} {
+		got, err := SafePath(dir, name)
+		if err != nil {
+			t.Errorf("SafePath(%q, %q): %v", dir, name, err)
+		}
+		assert.Equal(t, got, want, "SafePath(%q, %q)", dir, name)
+	}
+	for _, name := range []string{"", "..", "../evil.txt", "a/../../evil.txt", "/etc/passwd", "src/../../out2/x"} {
+		got, err := SafePath(dir, name)
+		if !errors.Is(err, ErrUnsafePath) {
+			t.Errorf("SafePath(%q, %q): error %v; want ErrUnsafePath", dir, name, err)
+		} else if !strings.Contains(err.Error(), `"`+name+`"`) {
+			t.Errorf("SafePath(%q, %q): error %q doesn't quote the name", dir, name, err)
+		}
+		assert.Equal(t, got, "", "SafePath(%q, %q)", dir, name)
+	}
+}
+
+func TestWriteZip(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteZip(&buf, project); err != nil {
+		t.Fatalf("WriteZip: %v", err)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("zip.NewReader of WriteZip's output: %v", err)
+	}
+	var names []string
+	for _, f := range zr.File {
+		names = append(names, f.Name)
+		if strings.HasSuffix(f.Name, "/") {
+			continue
+		}
+		assert.Equal(t, f.Method, zip.Deflate, "the Method of %s", f.Name)
+		rc, err := f.Open()
+		if err != nil {
+			t.Errorf("opening %s: %v", f.Name, err)
+			continue
+		}
+		data, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			t.Errorf("reading %s: %v", f.Name, err)
+		}
+		assert.Equal(t, string(data), string(project[f.Name].Data), "the contents of %s", f.Name)
+	}
+	assert.Equal(t, names, []string{"docs/", "docs/empty/", "readme.md", "src/", "src/main.go", "src/util/", "src/util/strings.go"}, "the entries WriteZip wrote")
+	if len(zr.File) > 2 {
+		assert.Equal(t, zr.File[2].Modified.Equal(project["readme.md"].ModTime), true, "readme.md's Modified = %v", zr.File[2].Modified)
+	}
+}
+
+func TestZipRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	WriteZip(&buf, project)
+	dir := filepath.Join(t.TempDir(), "out")
+	if err := ExtractZip(bytes.NewReader(buf.Bytes()), int64(buf.Len()), dir); err != nil {
+		t.Fatalf("ExtractZip: %v", err)
+	}
+	assert.Equal(t, tree(t, dir), projectTree, "what ExtractZip(WriteZip) made")
+	checkDirModes(t, dir)
+
+	// An empty archive makes an empty directory.
+	buf.Reset()
+	WriteZip(&buf, fstest.MapFS{})
+	dir = filepath.Join(t.TempDir(), "empty")
+	if err := ExtractZip(bytes.NewReader(buf.Bytes()), int64(buf.Len()), dir); err != nil {
+		t.Errorf("ExtractZip of an empty zip: %v", err)
+	}
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		t.Errorf("ExtractZip of an empty zip didn't make the directory: %v", err)
+	}
+
+	if err := ExtractZip(strings.NewReader("not a zip"), 9, t.TempDir()); !errors.Is(err, zip.ErrFormat) {
+		t.Errorf("ExtractZip of something else: error %v; want zip.ErrFormat", err)
+	}
+}
+
+func TestWriteTarGz(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteTarGz(&buf, project); err != nil {
+		t.Fatalf("WriteTarGz: %v", err)
+	}
+	zr, err := gzip.NewReader(&buf)
+	if err != nil {
+		t.Fatalf("gzip.NewReader of WriteTarGz's output: %v", err)
+	}
+	tr := tar.NewReader(zr)
+	var names []string
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("reading WriteTarGz's output: %v; close the tar.Writer and then the gzip.Writer", err)
+		}
+		names = append(names, hdr.Name)
+		if strings.HasSuffix(hdr.Name, "/") {
+			assert.Equal(t, hdr.Typeflag, byte(tar.TypeDir), "the Typeflag of %s", hdr.Name)
+			assert.Equal(t, hdr.Mode, int64(0o755), "the Mode of %s", hdr.Name)
+			continue
+		}
+		assert.Equal(t, hdr.Typeflag, byte(tar.TypeReg), "the Typeflag of %s", hdr.Name)
+		assert.Equal(t, hdr.Mode, int64(0o644), "the Mode of %s", hdr.Name)
+		data, _ := io.ReadAll(tr)
+		assert.Equal(t, string(data), string(project[hdr.Name].Data), "the contents of %s", hdr.Name)
+		if hdr.Name == "readme.md" {
+			assert.Equal(t, hdr.ModTime.Equal(project[hdr.Name].ModTime), true, "%s's ModTime = %v", hdr.Name, hdr.ModTime)
+		}
+	}
+	assert.Equal(t, names, []string{"docs/", "docs/empty/", "readme.md", "src/", "src/main.go", "src/util/", "src/util/strings.go"}, "the entries WriteTarGz wrote")
+}
+
+func TestTarGzRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	WriteTarGz(&buf, project)
+	dir := filepath.Join(t.TempDir(), "out")
+	if err := ExtractTarGz(&buf, dir); err != nil {
+		t.Fatalf("ExtractTarGz: %v", err)
+	}
+	assert.Equal(t, tree(t, dir), projectTree, "what ExtractTarGz(WriteTarGz) made")
+	checkDirModes(t, dir)
+
+	buf.Reset()
+	WriteTarGz(&buf, fstest.MapFS{})
+	dir = filepath.Join(t.TempDir(), "empty")
+	if err := ExtractTarGz(&buf, dir); err != nil {
+		t.Errorf("ExtractTarGz of an empty archive: %v", err)
+	}
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		t.Errorf("ExtractTarGz of an empty archive didn't make the directory: %v", err)
+	}
+
+	if err := ExtractTarGz(strings.NewReader("this is plain text, not gzip"), t.TempDir()); !errors.Is(err, gzip.ErrHeader) {
+		t.Errorf("ExtractTarGz of something else: error %v; want gzip.ErrHeader", err)
+	}
+}
+
+var errBroken = errors.New("broken")
+
+// brokenFS is an fs.FS whose file called bad can't be opened or, if
+// info is set, can't even be stat'd.
+type brokenFS struct {
+	fstest.MapFS
+	bad  string
+	info bool
+}
+
+func (f brokenFS) Open(name string) (fs.File, error) {
+	if name == f.bad {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: errBroken}
+	}
+	return f.MapFS.Open(name)
+}
+
+func (f brokenFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	entries, err := f.MapFS.ReadDir(name)
+	for i, e := range entries {
+		if f.info && path.Join(name, e.Name()) == f.bad {
+			entries[i] = brokenEntry{e}
+		}
+	}
+	return entries, err
+}
+
+type brokenEntry struct{ fs.DirEntry }
+
+func (brokenEntry) Info() (fs.FileInfo, error) { return nil, errBroken }
+
+func TestWriteErrors(t *testing.T) {
+	for _, info := range []bool{false, true} {
+		fsys := brokenFS{project, "src/main.go", info}
+		if err := WriteZip(io.Discard, fsys); !errors.Is(err, errBroken) {
+			t.Errorf("WriteZip of an fs.FS whose src/main.go is broken: error %v; want errBroken", err)
+		}
+		if err := WriteTarGz(io.Discard, fsys); !errors.Is(err, errBroken) {
+			t.Errorf("WriteTarGz of an fs.FS whose src/main.go is broken: error %v; want errBroken", err)
+		}
+	}
+
+	// Random bytes don't compress, so they are more than zip.Writer
+	// buffers, and it finds the pipe broken by the next entry.
+	noise := make([]byte, 10000)
+	for i := range noise {
+		noise[i] = byte(rand.N(256))
+	}
+	fsys := fstest.MapFS{"a.bin": {Data: noise}, "b.txt": {Data: []byte("b")}}
+	if err := WriteZip(brokenPipe{}, fsys); err == nil {
+		t.Error("WriteZip to a broken pipe: got no error")
+	}
+	if err := WriteTarGz(brokenPipe{}, fsys); err == nil {
+		t.Error("WriteTarGz to a broken pipe: got no error")
+	}
+}
+
+func TestExtractErrors(t *testing.T) {
+	// A file where the directory should be.
+	file := filepath.Join(t.TempDir(), "file")
+	os.WriteFile(file, nil, 0o644)
+	var buf bytes.Buffer
+	WriteZip(&buf, fstest.MapFS{})
+	if err := ExtractZip(bytes.NewReader(buf.Bytes()), int64(buf.Len()), file); err == nil {
+		t.Error("ExtractZip into a file: got no error")
+	}
+	buf.Reset()
+	WriteTarGz(&buf, fstest.MapFS{})
+	if err := ExtractTarGz(&buf, file); err == nil {
+		t.Error("ExtractTarGz into a file: got no error")
+	}
+
+	// A zip whose index is fine but whose entry isn't.
+	r := evilZip(t, entry{name: "a.txt", body: "a"})
+	data := make([]byte, r.Len())
+	r.Read(data)
+	data[0] = 'X' // "PK\x03\x04" starts each entry
+	if err := ExtractZip(bytes.NewReader(data), int64(len(data)), t.TempDir()); !errors.Is(err, zip.ErrFormat) {
+		t.Errorf("ExtractZip of a broken entry: error %v; want zip.ErrFormat", err)
+	}
+
+	// A tar cut off partway through its first header.
+	var tarBuf bytes.Buffer
+	tw := tar.NewWriter(&tarBuf)
+	tw.WriteHeader(&tar.Header{Typeflag: tar.TypeReg, Name: "a.txt", Mode: 0o644})
+	tw.Close()
+	buf.Reset()
+	Gzip(&buf, bytes.NewReader(tarBuf.Bytes()[:100]), "")
+	if err := ExtractTarGz(&buf, t.TempDir()); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("ExtractTarGz of a cut-off tar: error %v; want io.ErrUnexpectedEOF", err)
+	}
+}
+
+// entry is an entry for a hand-made archive.
+type entry struct {
+	name, body string
+	link       string // for a symlink
+}
+
+func evilZip(t *testing.T, entries ...entry) *bytes.Reader {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, e := range entries {
+		hdr := &zip.FileHeader{Name: e.name}
+		body := e.body
+		if e.link != "" {
+			hdr.SetMode(fs.ModeSymlink | 0o777)
+			body = e.link
+		}
+		w, err := zw.CreateHeader(hdr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		io.WriteString(w, body)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return bytes.NewReader(buf.Bytes())
+}
+
+func evilTarGz(t *testing.T, entries ...entry) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(zw)
+	for _, e := range entries {
+		hdr := &tar.Header{Typeflag: tar.TypeReg, Name: e.name, Mode: 0o644, Size: int64(len(e.body))}
+		if e.link != "" {
+			hdr = &tar.Header{Typeflag: tar.TypeSymlink, Name: e.name, Linkname: e.link, Mode: 0o777}
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatal(err)
+		}
+		io.WriteString(tw, e.body)
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	zw.Close()
+	return &buf
+}
+
+// zipSlips are entries that try to write outside the directory.
+var zipSlips = []entry{
+	{name: "../evil.txt", body: "pwned"},
+	{name: "a/../../evil.txt", body: "pwned"},
+	{name: "/tmp/evil.txt", body: "pwned"},
+}
+
+func TestExtractZipSlip(t *testing.T) {
+	for _, slip := range zipSlips {
+		root := t.TempDir()
+		dir := filepath.Join(root, "out")
+		r := evilZip(t, entry{name: "fine.txt", body: "ok"}, slip)
+		err := ExtractZip(r, r.Size(), dir)
+		if !errors.Is(err, ErrUnsafePath) {
+			t.Errorf("ExtractZip of an entry called %q: error %v; want ErrUnsafePath", slip.name, err)
+		}
+		if _, err := os.Stat(filepath.Join(root, "evil.txt")); err == nil {
+			t.Errorf("ExtractZip of an entry called %q wrote outside its directory", slip.name)
+		}
+	}
+
+	dir := t.TempDir()
+	r := evilZip(t, entry{name: "link", link: "/etc"}, entry{name: "link/passwd", body: "pwned"})
+	if err := ExtractZip(r, r.Size(), dir); err == nil {
+		t.Error("ExtractZip of a symlink: got no error")
+	}
+	if _, err := os.Lstat(filepath.Join(dir, "link")); err == nil {
+		t.Error("ExtractZip of a symlink made something called link")
+	}
+}
+
+func TestExtractTarGzSlip(t *testing.T) {
+	for _, slip := range zipSlips {
+		root := t.TempDir()
+		dir := filepath.Join(root, "out")
+		err := ExtractTarGz(evilTarGz(t, entry{name: "fine.txt", body: "ok"}, slip), dir)
+		if !errors.Is(err, ErrUnsafePath) {
+			t.Errorf("ExtractTarGz of an entry called %q: error %v; want ErrUnsafePath", slip.name, err)
+		}
+		if _, err := os.Stat(filepath.Join(root, "evil.txt")); err == nil {
+			t.Errorf("ExtractTarGz of an entry called %q wrote outside its directory", slip.name)
+		}
+	}
+
+	dir := t.TempDir()
+	err := ExtractTarGz(evilTarGz(t, entry{name: "link", link: "/etc"}, entry{name: "link/passwd", body: "pwned"}), dir)
+	if err == nil {
+		t.Error("ExtractTarGz of a symlink: got no error")
+	}
+	if _, err := os.Lstat(filepath.Join(dir, "link")); err == nil {
+		t.Error("ExtractTarGz of a symlink made something called link")
+	}
+}
+
+func TestCompressDir(t *testing.T) {
+	src := filepath.Join(t.TempDir(), "project")
+	if err := os.CopyFS(src, project); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"project.zip", "project.tar.gz", "project.tgz"} {
+		archive := filepath.Join(t.TempDir(), name)
+		if err := CompressDir(src, archive); err != nil {
+			t.Errorf("CompressDir(%s): %v", name, err)
+			continue
+		}
+		dir := filepath.Join(t.TempDir(), "out")
+		if err := ExtractArchive(archive, dir); err != nil {
+			t.Errorf("ExtractArchive(%s): %v", name, err)
+			continue
+		}
+		assert.Equal(t, tree(t, dir), projectTree, "ExtractArchive(CompressDir(%s))", name)
+	}
+
+	// The formats are the real ones.
+	archive := filepath.Join(t.TempDir(), "project.zip")
+	CompressDir(src, archive)
+	if _, err := zip.OpenReader(archive); err != nil {
+		t.Errorf("CompressDir(project.zip) didn't write a zip: %v", err)
+	}
+	archive = filepath.Join(t.TempDir(), "project.tgz")
+	CompressDir(src, archive)
+	if f, err := os.Open(archive); err == nil {
+		if _, err := gzip.NewReader(f); err != nil {
+			t.Errorf("CompressDir(project.tgz) didn't write gzip: %v", err)
+		}
+		f.Close()
+	}
+}
+
+func TestCompressDirErrors(t *testing.T) {
+	src := t.TempDir()
+	out := t.TempDir()
+	for _, name := range []string{"project.rar", "project.tar", "project.gz", "zip"} {
+		if err := CompressDir(src, filepath.Join(out, name)); err == nil || !strings.Contains(err.Error(), name) {
+			t.Errorf("CompressDir(%s): error %v; want one naming it", name, err)
+		}
+		if _, err := os.Stat(filepath.Join(out, name)); err == nil {
+			t.Errorf("CompressDir(%s) created it", name)
+		}
+		if err := ExtractArchive(filepath.Join(out, name), t.TempDir()); err == nil || !strings.Contains(err.Error(), name) {
+			t.Errorf("ExtractArchive(%s): error %v; want one naming it", name, err)
+		}
+	}
+
+	// A directory that doesn't exist: no half-written archive is left.
+	archive := filepath.Join(out, "missing.zip")
+	if err := CompressDir(filepath.Join(src, "missing"), archive); err == nil {
+		t.Error("CompressDir of a missing directory: got no error")
+	}
+	if _, err := os.Stat(archive); err == nil {
+		t.Error("CompressDir of a missing directory left a half-written archive")
+	}
+	if err := CompressDir(src, filepath.Join(out, "no", "such", "dir.zip")); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("CompressDir into a missing directory: error %v; want fs.ErrNotExist", err)
+	}
+
+	for _, name := range []string{"missing.zip", "missing.tar.gz"} {
+		if err := ExtractArchive(filepath.Join(out, name), t.TempDir()); !errors.Is(err, fs.ErrNotExist) {
+			t.Errorf("ExtractArchive of a missing %s: error %v; want fs.ErrNotExist", name, err)
+		}
+	}
+}
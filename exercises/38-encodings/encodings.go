@@ -0,0 +1,250 @@
+//go:build !solutions
+
+package encodings
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/gob"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+)
+
+// Exercise 38: Binary and text encodings
+//
+// JSON isn't the only way bytes travel. In Node you'd reach for
+// Buffer.toString('base64'), a DataView for packed binary records and
+// an npm package for XML; Go has them all under encoding/:
+//
+//   - encoding/base64 and encoding/hex turn bytes into text and back
+//   - encoding/binary reads and writes fixed-size numbers and structs,
+//     in the byte order a file format or protocol says
+//   - encoding/gob is Go's own self-describing format, for Go programs
+//     talking to Go programs
+//   - encoding/xml works like encoding/json, with struct tags
+//
+// Run tests with: go test -v
+
+// 1. base64, every flavor
+// ToBase64URL encodes data in the URL-safe alphabet ("-" and "_" for
+// "+" and "/") without "=" padding, as JWTs do.
+//
+// FromBase64 decodes any of the four flavors you meet in the wild:
+// standard or URL-safe alphabet, padded or not. One way is to make it
+// one flavor first: trim the "=" padding, swap "-" and "_" back, and
+// decode with base64.RawStdEncoding. Bad input is an error, a
+// base64.CorruptInputError.
+func ToBase64URL(data []byte) string {
+	// TODO
+	return ""
+}
+
+func FromBase64(s string) ([]byte, error) {
+	// TODO
+	return nil, nil
+}
+
+// 2. hex with separators
+// ToHex writes data as lowercase hex, with sep between bytes:
+// ToHex([]byte{0xde, 0xad}, ":") is "de:ad", the way MAC addresses and
+// certificate fingerprints are shown. With sep "" it's
+// hex.EncodeToString.
+//
+// FromHex reads it back, in either case, ignoring ":", "-" and " "
+// between the digits. An odd number of digits is hex.ErrLength; a
+// character that isn't a digit is a hex.InvalidByteError.
+func ToHex(data []byte, sep string) string {
+	// TODO
+	return ""
+}
+
+func FromHex(s string) ([]byte, error) {
+	// TODO: strings.NewReplacer, then hex.DecodeString
+	return nil, nil
+}
+
+// Reading is one sample from a sensor, stored as a fixed-size record:
+// every field a fixed size, in this order, big-endian, no padding.
+// That's RecordSize bytes, the way a C struct or a network protocol
+// lays out data, and what a DataView reads in JS.
+type Reading struct {
+	Sensor uint16  // 2 bytes
+	Flags  uint8   // 1 byte
+	Time   int64   // 8 bytes, Unix nanoseconds
+	Value  float32 // 4 bytes, IEEE 754
+}
+
+// RecordSize is how many bytes a Reading takes.
+const RecordSize = 15
+
+// 3. A record by hand
+// AppendReading appends r's RecordSize bytes to b and returns the
+// result, the way binary.BigEndian.AppendUint16 and friends do; each
+// of those appends one field. math.Float32bits gives a float32's bits
+// as a uint32, and int64(x) and uint64(x) convert without changing
+// bits.
+//
+// ParseReading reads one back from exactly RecordSize bytes, with
+// binary.BigEndian.Uint16 and friends, and math.Float32frombits. Any
+// other length is an error.
+func AppendReading(b []byte, r Reading) []byte {
+	// TODO
+	return b
+}
+
+func ParseReading(b []byte) (Reading, error) {
+	// TODO
+	return Reading{}, nil
+}
+
+// A log file of Readings starts with a header: Magic, the format
+// Version, and how many Readings follow. Files often start with magic
+// bytes like this; a PNG starts "\x89PNG", a zip "PK".
+type header struct {
+	Magic   [4]byte
+	Version uint8
+	Count   uint32
+}
+
+var Magic = [4]byte{'R', 'D', 'N', 'G'}
+
+const Version = 1
+
+// MaxReadings is the most Readings ReadLog will take from one file.
+const MaxReadings = 1 << 20
+
+var (
+	ErrBadMagic = errors.New("not a readings log")
+	ErrVersion  = errors.New("unsupported version")
+	ErrTooLarge = errors.New("too many readings")
+)
+
+// 4. binary.Write and binary.Read
+// WriteLog writes a header, then rs. binary.Write(w, binary.BigEndian,
+// v) writes any fixed-size value: a number, an array, a struct of
+// those (header), or a slice of them (rs), field by field, so a
+// Reading comes out the same as AppendReading's.
+//
+// ReadLog reads a log back with binary.Read:
+//
+//   - a header whose Magic is wrong is ErrBadMagic
+//   - a Version other than 1 is an error wrapping ErrVersion that says
+//     which version it was
+//   - a Count over MaxReadings is ErrTooLarge; check before allocating,
+//     or 9 bytes of garbage could ask for 64 GB
+//   - an empty r is io.EOF: there was no log. binary.Read also returns
+//     io.EOF when it reads nothing at all, but once the header is read a
+//     missing Reading means the file was cut short, so return
+//     io.ErrUnexpectedEOF for it, as binary.Read does for a partial one
+func WriteLog(w io.Writer, rs []Reading) error {
+	// TODO
+	return nil
+}
+
+func ReadLog(r io.Reader) ([]Reading, error) {
+	// TODO
+	return nil, nil
+}
+
+// Shape is anything with an area. Circle and Rect are Shapes.
+type Shape interface {
+	Area() float64
+}
+
+type Circle struct{ R float64 }
+
+func (c Circle) Area() float64 { return math.Pi * c.R * c.R }
+
+type Rect struct{ W, H float64 }
+
+func (r Rect) Area() float64 { return r.W * r.H }
+
+// 5. gob and interfaces
+// EncodeShapes gob-encodes shapes, and DecodeShapes decodes them back
+// into the same concrete types. gob.NewEncoder(w).Encode(shapes) and
+// gob.NewDecoder(r).Decode(&shapes) do the work, but gob writes the
+// name of each value's concrete type, and only reads back types it has
+// been told about with gob.Register(Circle{}). Register both types
+// before encoding and before decoding; registering one again is fine.
+//
+// An unregistered type is an error, not a panic. Unlike JSON, gob only
+// makes sense between Go programs, but it's compact and keeps types.
+func EncodeShapes(shapes []Shape) ([]byte, error) {
+	// TODO
+	return nil, nil
+}
+
+func DecodeShapes(data []byte) ([]Shape, error) {
+	// TODO
+	return nil, nil
+}
+
+// Feed is an RSS feed. XML tags work like JSON ones, with more to say:
+// ",attr" for an attribute, "a>b" for an element b inside a, and
+// XMLName for the element's own name.
+type Feed struct {
+	XMLName xml.Name `xml:"rss"`
+	Version string   `xml:"version,attr"`
+	Title   string   `xml:"channel>title"`
+	Link    string   `xml:"channel>link"`
+	Items   []Item   `xml:"channel>item"`
+}
+
+// Item is one entry in a Feed. An element can repeat, like category
+// here, and ",chardata" is an element's text when it has attributes
+// too.
+type Item struct {
+	Title      string   `xml:"title"`
+	Link       string   `xml:"link"`
+	GUID       GUID     `xml:"guid"`
+	Categories []string `xml:"category"`
+	Summary    string   `xml:"description,omitempty"`
+}
+
+// GUID is an item's ID: <guid isPermaLink="false">42</guid>.
+type GUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	ID          string `xml:",chardata"`
+}
+
+// 6. XML documents
+// ParseFeed decodes an RSS document; xml.NewDecoder(r).Decode does,
+// and fails on a document whose root isn't <rss>, thanks to XMLName.
+//
+// WriteFeed writes f as a document: xml.Header (the <?xml ...?> line),
+// then f indented by two spaces with an xml.Encoder, then a newline.
+// testdata/feed.xml is what it should write for the feed in the tests.
+func ParseFeed(r io.Reader) (*Feed, error) {
+	// TODO
+	return nil, nil
+}
+
+func WriteFeed(w io.Writer, f *Feed) error {
+	// TODO: enc := xml.NewEncoder(w); enc.Indent("", "  ")
+	return nil
+}
+
+// 7. Streaming XML
+// ItemTitles returns the title of each <item> in an RSS document, read
+// a token at a time with an xml.Decoder, so a huge feed is never all
+// in memory: loop on d.Token() until io.EOF, and at each
+// xml.StartElement named "item", d.DecodeElement(&item, &start) reads
+// just that element into an Item. Malformed XML is an error, an
+// *xml.SyntaxError.
+func ItemTitles(r io.Reader) ([]string, error) {
+	// TODO
+	return nil, nil
+}
+
+// Keep imports used
+var _ = base64.RawStdEncoding
+var _ = binary.BigEndian
+var _ = gob.NewEncoder
+var _ = hex.EncodeToString
+var _ = fmt.Errorf
+var _ = strings.NewReplacer
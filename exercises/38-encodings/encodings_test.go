@@ -0,0 +1,409 @@
+package encodings
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"io"
+	"math"
+	"os"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/imgarylai/learn-go/internal/assert"
+)
+
+func TestToBase64URL(t *testing.T) {
+	for data, want := range map[string]string{
+		"":                            "",
+		"f":                           "Zg",
+		"fo":                          "Zm8",
+		"foo":                         "Zm9v",
+		"\xfb\xff\xbf":                "-_-_",
+		`{"alg":"HS256","typ":"JWT"}`: "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9",
+	} {
+		assert.Equal(t, ToBase64URL([]byte(data)), want, "ToBase64URL(%q)", data)
+	}
+}
+
+func TestFromBase64(t *testing.T) {
+	// RFC 4648's vectors, in every flavor.
+	for _, tt := range []struct{ in, want string }{
+		{"", ""},
+		{"Zg==", "f"},
+		{"Zg", "f"},
+		{"Zm8=", "fo"},
+		{"Zm8", "fo"},
+		{"Zm9vYmFy", "foobar"},
+		{"Zm9vYmE=", "fooba"},
+		{"+/+/", "\xfb\xff\xbf"},
+		{"-_-_", "\xfb\xff\xbf"},
+		{"-_8", "\xfb\xff"},
+		{"-_8=", "\xfb\xff"},
+	} {
+		got, err := FromBase64(tt.in)
+		if err != nil {
+			t.Errorf("FromBase64(%q): %v", tt.in, err)
+		}
+		assert.Equal(t, string(got), tt.want, "FromBase64(%q)", tt.in)
+	}
+	for _, in := range []string{"Z", "Zm9v!", "Zm 9v", "Z===g"} {
+		var corrupt base64.CorruptInputError
+		if _, err := FromBase64(in); !errors.As(err, &corrupt) {
+			t.Errorf("FromBase64(%q): error %v; want a base64.CorruptInputError", in, err)
+		}
+	}
+}
+
+func TestToHex(t *testing.T) {
+	for _, tt := range []struct {
+		data []byte
+		sep  string
+		want string
+	}{
+		{[]byte{0xde, 0xad, 0xbe, 0xef}, "", "deadbeef"},
+		{[]byte{0xde, 0xad, 0xbe, 0xef}, ":", "de:ad:be:ef"},
+		{[]byte{0x00, 0x1a, 0x2b, 0x3c, 0x4d, 0x5e}, "-", "00-1a-2b-3c-4d-5e"},
+		{[]byte{0x0f}, ":", "0f"},
+		{nil, ":", ""},
+	} {
+		assert.Equal(t, ToHex(tt.data, tt.sep), tt.want, "ToHex(%x, %q)", tt.data, tt.sep)
+	}
+}
+
+func TestFromHex(t *testing.T) {
+	for in, want := range map[string]string{
+		"deadbeef":          "deadbeef",
+		"DEADBEEF":          "deadbeef",
+		"de:ad:BE:ef":       "deadbeef",
+		"00-1A-2B-3C-4D-5E": "001a2b3c4d5e",
+		"de ad be ef":       "deadbeef",
+		"":                  "",
+	} {
+		got, err := FromHex(in)
+		if err != nil {
+			t.Errorf("FromHex(%q): %v", in, err)
+		}
+		assert.Equal(t, hex.EncodeToString(got), want, "FromHex(%q)", in)
+	}
+	if _, err := FromHex("de:ad:be:e"); !errors.Is(err, hex.ErrLength) {
+		t.Errorf("FromHex of 7 digits: error %v; want hex.ErrLength", err)
+	}
+	var invalid hex.InvalidByteError
+	if _, err := FromHex("de:ad:be:eg"); !errors.As(err, &invalid) || invalid != 'g' {
+		t.Errorf("FromHex with a g in it: error %v; want hex.InvalidByteError('g')", err)
+	}
+	if _, err := FromHex("de.ad"); !errors.As(err, &invalid) {
+		t.Errorf("FromHex(\"de.ad\"): error %v; want a hex.InvalidByteError", err)
+	}
+}
+
+// sample and sampleBytes are the same Reading, as a value and as bytes.
+var sample = Reading{Sensor: 0x0102, Flags: 0x80, Time: 1_700_000_000_123_456_789, Value: -1.5}
+
+var sampleBytes = []byte{
+	0x01, 0x02, // Sensor
+	0x80,                                           // Flags
+	0x17, 0x97, 0x9c, 0xfe, 0x3d, 0x85, 0xcd, 0x15, // Time
+	0xbf, 0xc0, 0x00, 0x00, // Value: -1.5
+}
+
+var readings = []Reading{
+	sample,
+	{Sensor: 7, Time: -1, Value: float32(math.Inf(1))},
+	{Sensor: 0xffff, Flags: 0xff, Time: math.MinInt64, Value: 0.1},
+	{},
+}
+
+func TestAppendReading(t *testing.T) {
+	assert.Equal(t, hex.EncodeToString(AppendReading(nil, sample)), hex.EncodeToString(sampleBytes), "AppendReading(nil, sample)")
+
+	// It appends, and leaves what was there alone.
+	got := AppendReading([]byte("hdr"), sample)
+	assert.Equal(t, string(got), "hdr"+string(sampleBytes), "AppendReading([]byte(\"hdr\"), sample)")
+
+	for _, r := range readings {
+		b := AppendReading(nil, r)
+		assert.Equal(t, len(b), RecordSize, "len(AppendReading(nil, %+v))", r)
+		back, err := ParseReading(b)
+		if err != nil {
+			t.Errorf("ParseReading(AppendReading(%+v)): %v", r, err)
+		}
+		assert.Equal(t, back, r, "ParseReading(AppendReading(%+v))", r)
+	}
+}
+
+func TestParseReading(t *testing.T) {
+	got, err := ParseReading(sampleBytes)
+	if err != nil {
+		t.Errorf("ParseReading: %v", err)
+	}
+	assert.Equal(t, got, sample, "ParseReading(sampleBytes)")
+
+	for _, n := range []int{0, RecordSize - 1, RecordSize + 1} {
+		b := append(bytes.Clone(sampleBytes), 0)[:n]
+		if _, err := ParseReading(b); err == nil {
+			t.Errorf("ParseReading of %d bytes: got no error", n)
+		}
+	}
+}
+
+// logHeader is the header WriteLog writes for n Readings.
+func logHeader(n byte) []byte {
+	return []byte{'R', 'D', 'N', 'G', 1, 0, 0, 0, n}
+}
+
+func TestWriteLog(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteLog(&buf, readings); err != nil {
+		t.Fatalf("WriteLog: %v", err)
+	}
+	want := logHeader(byte(len(readings)))
+	for _, r := range readings {
+		want = AppendReading(want, r)
+	}
+	assert.Equal(t, hex.EncodeToString(buf.Bytes()), hex.EncodeToString(want), "WriteLog(readings)")
+
+	buf.Reset()
+	WriteLog(&buf, nil)
+	assert.Equal(t, buf.Bytes(), logHeader(0), "WriteLog(nil)")
+
+	// A failed write fails WriteLog, at the header or after it.
+	for _, n := range []int{1, 2} {
+		if err := WriteLog(&flakyWriter{n: n}, readings); err == nil {
+			t.Errorf("WriteLog to a writer that fails write %d: got no error", n)
+		}
+	}
+}
+
+// flakyWriter fails its nth write, and only that one.
+type flakyWriter struct{ n int }
+
+func (w *flakyWriter) Write(p []byte) (int, error) {
+	w.n--
+	if w.n == 0 {
+		return 0, errors.New("connection reset")
+	}
+	return len(p), nil
+}
+
+func TestReadLog(t *testing.T) {
+	var buf bytes.Buffer
+	WriteLog(&buf, readings)
+	data := buf.Bytes()
+	if len(data) != 9+len(readings)*RecordSize {
+		t.Fatalf("WriteLog(readings) wrote %d bytes; want %d", len(data), 9+len(readings)*RecordSize)
+	}
+
+	got, err := ReadLog(bytes.NewReader(data))
+	if err != nil {
+		t.Errorf("ReadLog: %v", err)
+	}
+	assert.Equal(t, got, readings, "ReadLog(WriteLog(readings))")
+
+	// binary.Read reads in pieces too.
+	got, err = ReadLog(iotest.OneByteReader(bytes.NewReader(data)))
+	if err != nil || len(got) != len(readings) {
+		t.Errorf("ReadLog a byte at a time = %d readings, %v", len(got), err)
+	}
+
+	got, err = ReadLog(bytes.NewReader(logHeader(0)))
+	if err != nil || len(got) != 0 {
+		t.Errorf("ReadLog of an empty log = %v, %v; want no readings", got, err)
+	}
+
+	for _, tt := range []struct {
+		name string
+		data []byte
+		want error
+	}{
+		{"nothing", nil, io.EOF},
+		{"half a header", data[:5], io.ErrUnexpectedEOF},
+		{"a header and no readings", data[:9], io.ErrUnexpectedEOF},
+		{"a reading cut short", data[:9+RecordSize+3], io.ErrUnexpectedEOF},
+		{"the last reading missing", data[:len(data)-RecordSize], io.ErrUnexpectedEOF},
+		{"a PNG", []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\x0dIHDR"), ErrBadMagic},
+		{"version 2", append([]byte("RDNG\x02"), data[5:]...), ErrVersion},
+		{"version 0", append([]byte("RDNG\x00"), data[5:]...), ErrVersion},
+		{"4 billion readings", []byte("RDNG\x01\xff\xff\xff\xff"), ErrTooLarge},
+		{"MaxReadings + 1", []byte("RDNG\x01\x00\x10\x00\x01"), ErrTooLarge},
+	} {
+		got, err := ReadLog(bytes.NewReader(tt.data))
+		if !errors.Is(err, tt.want) {
+			t.Errorf("ReadLog of %s: error %v; want %v", tt.name, err, tt.want)
+		}
+		if got != nil {
+			t.Errorf("ReadLog of %s = %v along with its error", tt.name, got)
+		}
+	}
+
+	_, err = ReadLog(bytes.NewReader(append([]byte("RDNG\x02"), data[5:]...)))
+	if err == nil || !strings.Contains(err.Error(), "2") {
+		t.Errorf("ReadLog of version 2: error %v; want one that says 2", err)
+	}
+
+	// MaxReadings itself is fine, as far as the header goes.
+	if _, err := ReadLog(bytes.NewReader([]byte("RDNG\x01\x00\x10\x00\x00"))); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("ReadLog of a header for MaxReadings and nothing else: error %v; want io.ErrUnexpectedEOF", err)
+	}
+}
+
+func TestShapes(t *testing.T) {
+	shapes := []Shape{Circle{R: 1}, Rect{W: 2, H: 3}, Circle{R: 0.5}, Rect{}}
+	data, err := EncodeShapes(shapes)
+	if err != nil {
+		t.Fatalf("EncodeShapes: %v", err)
+	}
+	// gob names the types it writes.
+	for _, name := range []string{"Circle", "Rect"} {
+		if !bytes.Contains(data, []byte(name)) {
+			t.Errorf("EncodeShapes' output doesn't name %s: %q", name, data)
+		}
+	}
+
+	got, err := DecodeShapes(data)
+	if err != nil {
+		t.Errorf("DecodeShapes: %v", err)
+	}
+	assert.Equal(t, got, shapes, "DecodeShapes(EncodeShapes(shapes))")
+	if len(got) == len(shapes) {
+		assert.Equal(t, got[1].Area(), 6.0, "the Area of the decoded Rect")
+	}
+
+	data, err = EncodeShapes(nil)
+	if err != nil {
+		t.Errorf("EncodeShapes(nil): %v", err)
+	}
+	got, err = DecodeShapes(data)
+	if err != nil || len(got) != 0 {
+		t.Errorf("DecodeShapes(EncodeShapes(nil)) = %v, %v", got, err)
+	}
+}
+
+// square is a Shape that nobody registered with gob.
+type square struct{ Side float64 }
+
+func (s square) Area() float64 { return s.Side * s.Side }
+
+func TestShapesErrors(t *testing.T) {
+	if data, err := EncodeShapes([]Shape{Circle{R: 1}, square{Side: 2}}); err == nil {
+		t.Errorf("EncodeShapes of an unregistered type = %q; want an error", data)
+	}
+	for _, data := range [][]byte{nil, []byte("not gob"), {0x03, 0xff, 0x80}} {
+		got, err := DecodeShapes(data)
+		if err == nil {
+			t.Errorf("DecodeShapes(%q) = %v; want an error", data, got)
+		}
+		if got != nil {
+			t.Errorf("DecodeShapes(%q) = %v along with its error", data, got)
+		}
+	}
+}
+
+// feed is what testdata/feed.xml holds.
+var feed = &Feed{
+	XMLName: xml.Name{Local: "rss"},
+	Version: "2.0",
+	Title:   "Go, from JS",
+	Link:    "https://example.com/",
+	Items: []Item{
+		{
+			Title:      "Encodings <& friends>",
+			Link:       "https://example.com/encodings",
+			GUID:       GUID{IsPermaLink: false, ID: "38"},
+			Categories: []string{"go", "encoding"},
+			Summary:    "base64, hex, binary, gob & XML",
+		},
+		{
+			Title: "Archives",
+			Link:  "https://example.com/archives",
+			GUID:  GUID{IsPermaLink: true, ID: "https://example.com/archives"},
+		},
+	},
+}
+
+func TestParseFeed(t *testing.T) {
+	f, err := os.Open("testdata/feed.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	got, err := ParseFeed(f)
+	if err != nil {
+		t.Fatalf("ParseFeed: %v", err)
+	}
+	assert.Equal(t, got, feed, "ParseFeed(testdata/feed.xml)")
+
+	for _, doc := range []string{
+		`<feed xmlns="http://www.w3.org/2005/Atom"><title>Atom</title></feed>`,
+		`<rss version="2.0"><channel><title>Cut short`,
+		``,
+	} {
+		if got, err := ParseFeed(strings.NewReader(doc)); err == nil {
+			t.Errorf("ParseFeed(%q) = %+v; want an error", doc, got)
+		}
+	}
+}
+
+func TestWriteFeed(t *testing.T) {
+	want, err := os.ReadFile("testdata/feed.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := WriteFeed(&buf, feed); err != nil {
+		t.Errorf("WriteFeed: %v", err)
+	}
+	assert.Equal(t, buf.String(), string(want), "WriteFeed(feed)")
+
+	for _, n := range []int{1, 2, 3} {
+		if err := WriteFeed(&flakyWriter{n: n}, feed); err == nil {
+			t.Errorf("WriteFeed to a writer that fails write %d: got no error", n)
+		}
+	}
+}
+
+func TestItemTitles(t *testing.T) {
+	f, err := os.Open("testdata/feed.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	got, err := ItemTitles(f)
+	if err != nil {
+		t.Errorf("ItemTitles: %v", err)
+	}
+	assert.Equal(t, got, []string{"Encodings <& friends>", "Archives"}, "ItemTitles(testdata/feed.xml)")
+
+	// Items anywhere count, and nothing else does.
+	doc := `<root><title>not this</title><a><item><title>one</title></item></a><item><title>two</title><item>ignored</item></item></root>`
+	got, err = ItemTitles(strings.NewReader(doc))
+	if err != nil {
+		t.Errorf("ItemTitles(%q): %v", doc, err)
+	}
+	assert.Equal(t, got, []string{"one", "two"}, "ItemTitles(%q)", doc)
+
+	for _, doc := range []string{
+		`<rss><channel><item><title>one</title></item><item><title>two`,
+		`<rss><channel><item><title>one</title></item></channel></rsss>`,
+		`<rss><item><title>a & b</title></item></rss>`,
+	} {
+		var syntax *xml.SyntaxError
+		got, err := ItemTitles(strings.NewReader(doc))
+		if !errors.As(err, &syntax) {
+			t.Errorf("ItemTitles(%q): error %v; want an *xml.SyntaxError", doc, err)
+		}
+		if got != nil {
+			t.Errorf("ItemTitles(%q) = %q along with its error", doc, got)
+		}
+	}
+
+	// Well-formed, but not an Item.
+	doc = `<rss><item><title>one</title><guid isPermaLink="maybe">1</guid></item><item><title>two</title></item></rss>`
+	if got, err := ItemTitles(strings.NewReader(doc)); err == nil {
+		t.Errorf("ItemTitles(%q) = %q; want an error for isPermaLink=\"maybe\"", doc, got)
+	}
+}
@@ -0,0 +1,175 @@
+//go:build !solutions
+
+package networking
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Exercise 39: TCP and UDP
+//
+// Under HTTP there's TCP: a reliable, ordered stream of bytes between
+// two programs, and nothing more, no messages, no requests. In Node
+// that's net.createServer and net.connect; in Go it's net.Listen and
+// net.Dial, and a connection is a net.Conn, an io.Reader and io.Writer
+// like a file. The protocol, where one message ends and the next
+// begins, is up to you: here it's lines.
+//
+// UDP (Node's dgram) is the other way: single datagrams, each arriving
+// whole or not at all, in any order, with no connection.
+//
+// Each goroutine blocks on its own connection, so a server is an
+// accept loop that starts a goroutine per connection; no callbacks or
+// event loop. The tests listen on "127.0.0.1:0", port 0 meaning any
+// free one, so they run anywhere.
+//
+// Run tests with: go test -v
+
+// 1. An echo server
+// Echo is a connection handler: it writes back everything it reads
+// from conn until the client is done sending, then closes conn.
+// io.Copy works on connections as on files.
+func Echo(conn net.Conn) {
+	// TODO
+}
+
+// 2. An echo client
+// EchoRoundTrip connects to the echo server at addr with
+// net.DialTimeout, sends msg, and returns everything the server sends
+// back. All of it must happen within timeout: conn.SetDeadline sets a
+// time after which reads and writes fail with os.ErrDeadlineExceeded,
+// so a server that never answers can't hang the client.
+//
+// The server copies until it sees the end of the stream, but closing
+// conn would close it both ways. A *net.TCPConn's CloseWrite closes
+// just the sending half (conn.(*net.TCPConn) gets at it): the server
+// sees EOF, and the client can still read the reply with io.ReadAll.
+func EchoRoundTrip(addr, msg string, timeout time.Duration) (string, error) {
+	// TODO
+	return "", nil
+}
+
+// 3. A line protocol
+// Respond is the heart of a small text protocol, one command per line,
+// like SMTP or Redis's inline commands. It returns the reply to line,
+// without the newline, and whether the client asked to quit. Commands
+// are case-insensitive, and spaces around the line don't matter:
+//
+//	PING          PONG
+//	ECHO text     text (everything after the first space)
+//	ADD 2 40      42; anything but two integers is an error
+//	QUIT          BYE, and quit is true
+//
+// Errors are replies too, starting "ERR ": ERR unknown command "FOO"
+// (%q quotes it), ERR ADD takes two integers, and for an empty line,
+// ERR empty command.
+func Respond(line string) (reply string, quit bool) {
+	// TODO: strings.Cut(line, " ") splits off the command
+	return "", false
+}
+
+// 4. Serving lines
+// ServeLines is a connection handler speaking Respond's protocol: it
+// reads conn a line at a time with a bufio.Scanner, writes each reply
+// followed by "\n", and closes conn when the client quits or goes
+// away. bufio.Scanner drops the "\r" of a "\r\n" line ending, so telnet
+// and nc work too.
+func ServeLines(conn net.Conn) {
+	// TODO
+}
+
+// ErrServerClosed is what Serve returns once Shutdown has been called.
+var ErrServerClosed = errors.New("networking: server closed")
+
+// Server serves connections on a listener, each with Handler in its own
+// goroutine, and can shut down gracefully: stop accepting, and let the
+// connections it has finish. It's http.Server's shutdown, for TCP.
+type Server struct {
+	Handler func(net.Conn)
+
+	mu       sync.Mutex
+	listener net.Listener          // the listener Serve is using
+	conns    map[net.Conn]struct{} // the connections being handled
+	closed   bool                  // Shutdown has been called
+	wg       sync.WaitGroup        // one for each running Handler
+}
+
+// 5. An accept loop
+// Serve accepts connections on ln until it's closed, running Handler on
+// each in a new goroutine and closing the connection once Handler
+// returns. Keep track of the connections, and of the running
+// Handlers in s.wg, for Shutdown.
+//
+// When Accept fails because Shutdown closed ln, return ErrServerClosed;
+// any other Accept error is returned as it is. If Shutdown has already
+// been called, close ln and return ErrServerClosed straight away, and
+// likewise for a connection accepted just as Shutdown runs: close it.
+// Check s.closed and call s.wg.Add under s.mu, and Shutdown can't miss
+// a connection.
+func (s *Server) Serve(ln net.Listener) error {
+	// TODO
+	return nil
+}
+
+// 6. Graceful shutdown
+// Shutdown stops the server: it closes the listener, so no more
+// connections come in and Serve returns, then waits for every running
+// Handler to return. If ctx is done first, it closes the connections
+// that are left, which makes their Handlers' reads fail, and returns
+// ctx.Err(). Calling it before Serve, or twice, is fine.
+//
+// Waiting on a WaitGroup with a timeout takes a goroutine: one that
+// calls s.wg.Wait() and then closes a channel that a select can wait
+// on along with ctx.Done().
+func (s *Server) Shutdown(ctx context.Context) error {
+	// TODO
+	return nil
+}
+
+// MaxDatagram is the most a UDP datagram can carry.
+const MaxDatagram = 65507
+
+// 7. A UDP server
+// ServeUDP answers each datagram that arrives on pc with the same bytes
+// upper-cased, sent back to whoever sent it: pc.ReadFrom says who, and
+// pc.WriteTo replies. There's no connection, so one loop serves every
+// client. Ignore WriteTo's errors; a client that went away mustn't stop
+// the server. When pc is closed, ReadFrom fails with net.ErrClosed
+// (check with errors.Is) and ServeUDP returns nil; it returns any other
+// error.
+//
+// Read into a buffer of MaxDatagram bytes: a datagram longer than the
+// buffer is cut short, and the rest is gone.
+func ServeUDP(pc net.PacketConn) error {
+	// TODO
+	return nil
+}
+
+// 8. A UDP client
+// QueryUDP sends msg to addr as one datagram and returns the datagram
+// that comes back. net.Dial("udp", addr) doesn't connect anything; it
+// fixes the address Write sends to and Read accepts from. Nothing tells
+// you a datagram was lost, so the deadline is the only way to stop
+// waiting: after timeout, return the os.ErrDeadlineExceeded error.
+func QueryUDP(addr string, msg []byte, timeout time.Duration) ([]byte, error) {
+	// TODO
+	return nil, nil
+}
+
+// Keep imports used
+var _ = bufio.NewScanner
+var _ = bytes.ToUpper
+var _ = fmt.Sprintf
+var _ = io.Copy
+var _ = strconv.Atoi
+var _ = strings.Cut
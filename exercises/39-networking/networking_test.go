@@ -0,0 +1,463 @@
+package networking
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/imgarylai/learn-go/internal/assert"
+)
+
+// wait is how long a test waits for anything that should be quick.
+const wait = 2 * time.Second
+
+// listen starts a TCP listener on a free port, closed when the test
+// ends.
+func listen(t *testing.T) net.Listener {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+	return ln
+}
+
+// serve runs handler on each connection to a new listener, and returns
+// its address.
+func serve(t *testing.T, handler func(net.Conn)) string {
+	t.Helper()
+	ln := listen(t)
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go handler(conn)
+		}
+	}()
+	return ln.Addr().String()
+}
+
+// dial connects to addr, with a deadline so that no test hangs.
+func dial(t *testing.T, addr string) net.Conn {
+	t.Helper()
+	conn, err := net.DialTimeout("tcp", addr, wait)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn.SetDeadline(time.Now().Add(wait))
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func TestEcho(t *testing.T) {
+	addr := serve(t, Echo)
+	conn := dial(t, addr)
+	io.WriteString(conn, "hello\n")
+	buf := make([]byte, 6)
+	if _, err := io.ReadFull(conn, buf); err != nil {
+		t.Fatalf("reading the echo: %v", err)
+	}
+	assert.Equal(t, string(buf), "hello\n", "what Echo sent back")
+
+	// Lots, in both directions at once.
+	data := bytes.Repeat([]byte("0123456789abcdef"), 1<<16)
+	go func() {
+		conn.Write(data)
+		conn.(*net.TCPConn).CloseWrite()
+	}()
+	got, err := io.ReadAll(conn)
+	if err != nil {
+		t.Errorf("reading 1 MB of echo: %v", err)
+	}
+	if !bytes.Equal(got, data) {
+		t.Errorf("Echo sent back %d bytes of 1 MB, or changed them", len(got))
+	}
+	// io.ReadAll returned, so Echo closed the connection.
+}
+
+func TestEchoRoundTrip(t *testing.T) {
+	addr := serve(t, Echo)
+	for _, msg := range []string{"hello", "", "two\nlines\n", strings.Repeat("x", 1<<20)} {
+		got, err := EchoRoundTrip(addr, msg, wait)
+		if err != nil {
+			t.Errorf("EchoRoundTrip(%.20q): %v", msg, err)
+		}
+		if got != msg {
+			t.Errorf("EchoRoundTrip(%.20q) = %.20q (%d bytes)", msg, got, len(got))
+		}
+	}
+}
+
+func TestEchoRoundTripErrors(t *testing.T) {
+	// A server that reads everything and never answers.
+	silent := serve(t, func(conn net.Conn) {
+		io.Copy(io.Discard, conn)
+		time.Sleep(wait)
+		conn.Close()
+	})
+	start := time.Now()
+	_, err := EchoRoundTrip(silent, "hello?", 100*time.Millisecond)
+	if !errors.Is(err, os.ErrDeadlineExceeded) {
+		t.Errorf("EchoRoundTrip to a silent server: error %v; want os.ErrDeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > wait/2 {
+		t.Errorf("EchoRoundTrip with a 100ms timeout took %v", elapsed)
+	}
+
+	// A port nobody listens on.
+	ln := listen(t)
+	addr := ln.Addr().String()
+	ln.Close()
+	if _, err := EchoRoundTrip(addr, "hello", wait); err == nil {
+		t.Errorf("EchoRoundTrip to a closed port: got no error")
+	}
+
+	// A server that closes the connection as soon as it opens.
+	rude := serve(t, func(conn net.Conn) {
+		conn.(*net.TCPConn).SetLinger(0) // close with a reset
+		conn.Close()
+	})
+	if got, err := EchoRoundTrip(rude, strings.Repeat("x", 1<<20), wait); err == nil {
+		t.Errorf("EchoRoundTrip to a server that hangs up = %.20q; want an error", got)
+	}
+}
+
+func TestRespond(t *testing.T) {
+	for _, tt := range []struct {
+		line, reply string
+		quit        bool
+	}{
+		{"PING", "PONG", false},
+		{"ping", "PONG", false},
+		{"  PING  ", "PONG", false},
+		{"PING\r", "PONG", false},
+		{"ECHO hello world", "hello world", false},
+		{"echo  two  spaces", " two  spaces", false},
+		{"ECHO", "", false},
+		{"ADD 2 40", "42", false},
+		{"add -5 3", "-2", false},
+		{"ADD  1   2", "3", false},
+		{"ADD 1", "ERR ADD takes two integers", false},
+		{"ADD 1 2 3", "ERR ADD takes two integers", false},
+		{"ADD one 2", "ERR ADD takes two integers", false},
+		{"ADD 1 two", "ERR ADD takes two integers", false},
+		{"ADD", "ERR ADD takes two integers", false},
+		{"QUIT", "BYE", true},
+		{"quit now", "BYE", true},
+		{"FOO bar", `ERR unknown command "FOO"`, false},
+		{"Pong", `ERR unknown command "Pong"`, false},
+		{"", "ERR empty command", false},
+		{"   ", "ERR empty command", false},
+	} {
+		reply, quit := Respond(tt.line)
+		assert.Equal(t, reply, tt.reply, "Respond(%q)'s reply", tt.line)
+		assert.Equal(t, quit, tt.quit, "Respond(%q)'s quit", tt.line)
+	}
+}
+
+func TestServeLines(t *testing.T) {
+	addr := serve(t, ServeLines)
+	conn := dial(t, addr)
+	r := bufio.NewReader(conn)
+	for _, tt := range [][2]string{
+		{"PING\n", "PONG\n"},
+		{"ECHO hi there\r\n", "hi there\n"},
+		{"ADD 20 22\n", "42\n"},
+		{"NOPE\n", "ERR unknown command \"NOPE\"\n"},
+	} {
+		io.WriteString(conn, tt[0])
+		got, err := r.ReadString('\n')
+		if err != nil {
+			t.Fatalf("reading the reply to %q: %v", tt[0], err)
+		}
+		assert.Equal(t, got, tt[1], "the reply to %q", tt[0])
+	}
+
+	// Several commands in one write get a reply each.
+	io.WriteString(conn, "PING\nADD 1 1\nECHO x\n")
+	for _, want := range []string{"PONG\n", "2\n", "x\n"} {
+		got, err := r.ReadString('\n')
+		if err != nil {
+			t.Fatalf("reading a reply to three commands: %v", err)
+		}
+		assert.Equal(t, got, want, "a reply to three commands in one write")
+	}
+
+	// QUIT, and the server hangs up.
+	io.WriteString(conn, "QUIT\nPING\n")
+	got, _ := r.ReadString('\n')
+	assert.Equal(t, got, "BYE\n", "the reply to QUIT")
+	if rest, err := io.ReadAll(r); err != nil || len(rest) != 0 {
+		t.Errorf("after QUIT, read %q, %v; want the connection closed", rest, err)
+	}
+
+	// The client going away ends the handler.
+	done := make(chan struct{})
+	a, b := net.Pipe()
+	go func() {
+		ServeLines(b)
+		close(done)
+	}()
+	a.Close()
+	select {
+	case <-done:
+	case <-time.After(wait):
+		t.Error("ServeLines didn't return when the client went away")
+	}
+}
+
+// startServer runs s.Serve on a new listener, and returns its address
+// and where Serve's error will arrive.
+func startServer(t *testing.T, s *Server) (string, <-chan error) {
+	t.Helper()
+	ln := listen(t)
+	errc := make(chan error, 1)
+	go func() { errc <- s.Serve(ln) }()
+	return ln.Addr().String(), errc
+}
+
+// roundTrip sends cmd on conn and returns the reply line.
+func roundTrip(conn net.Conn, r *bufio.Reader, cmd string) (string, error) {
+	if _, err := io.WriteString(conn, cmd+"\n"); err != nil {
+		return "", err
+	}
+	return r.ReadString('\n')
+}
+
+func TestServer(t *testing.T) {
+	s := &Server{Handler: ServeLines}
+	addr, errc := startServer(t, s)
+
+	// Two clients at once.
+	c1, c2 := dial(t, addr), dial(t, addr)
+	r1, r2 := bufio.NewReader(c1), bufio.NewReader(c2)
+	for _, c := range []struct {
+		conn net.Conn
+		r    *bufio.Reader
+	}{{c1, r1}, {c2, r2}, {c1, r1}} {
+		got, err := roundTrip(c.conn, c.r, "PING")
+		if err != nil || got != "PONG\n" {
+			t.Fatalf("PING through the Server = %q, %v", got, err)
+		}
+	}
+
+	// Shutdown waits for both clients.
+	shutdown := make(chan error, 1)
+	go func() { shutdown <- s.Shutdown(context.Background()) }()
+	select {
+	case err := <-errc:
+		if !errors.Is(err, ErrServerClosed) {
+			t.Errorf("Serve returned %v after Shutdown; want ErrServerClosed", err)
+		}
+	case <-time.After(wait):
+		t.Fatal("Serve didn't return after Shutdown")
+	}
+	if conn, err := net.DialTimeout("tcp", addr, wait); err == nil {
+		conn.Close()
+		t.Error("after Shutdown, a new connection went through")
+	}
+
+	// The clients it has can carry on.
+	got, err := roundTrip(c1, r1, "ECHO still here")
+	if err != nil || got != "still here\n" {
+		t.Errorf("a command during Shutdown = %q, %v", got, err)
+	}
+	roundTrip(c1, r1, "QUIT")
+	select {
+	case err := <-shutdown:
+		t.Fatalf("Shutdown returned %v with a client still connected", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+	roundTrip(c2, r2, "QUIT")
+	select {
+	case err := <-shutdown:
+		if err != nil {
+			t.Errorf("Shutdown: %v", err)
+		}
+	case <-time.After(wait):
+		t.Error("Shutdown didn't return once the clients quit")
+	}
+}
+
+func TestServerClosesConns(t *testing.T) {
+	// A Handler that returns without closing: the Server closes.
+	s := &Server{Handler: func(conn net.Conn) { io.WriteString(conn, "hi\n") }}
+	addr, _ := startServer(t, s)
+	got, err := io.ReadAll(dial(t, addr))
+	if err != nil || string(got) != "hi\n" {
+		t.Errorf("reading from a Handler that returns = %q, %v; want hi and the connection closed", got, err)
+	}
+	s.Shutdown(context.Background())
+}
+
+func TestShutdownTimeout(t *testing.T) {
+	s := &Server{Handler: ServeLines}
+	addr, _ := startServer(t, s)
+	conn := dial(t, addr)
+	r := bufio.NewReader(conn)
+	if got, err := roundTrip(conn, r, "PING"); err != nil || got != "PONG\n" {
+		t.Fatalf("PING through the Server = %q, %v", got, err)
+	}
+
+	// An idle client doesn't hold Shutdown up past its deadline.
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err := s.Shutdown(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Shutdown with an idle client: error %v; want context.DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed < 80*time.Millisecond || elapsed > wait/2 {
+		t.Errorf("Shutdown with a 100ms deadline took %v", elapsed)
+	}
+	// and its connection was closed.
+	if rest, err := io.ReadAll(r); err != nil || len(rest) != 0 {
+		t.Errorf("reading after Shutdown gave up = %q, %v; want the connection closed", rest, err)
+	}
+}
+
+func TestShutdownEarly(t *testing.T) {
+	// Before Serve, with nothing to wait for.
+	s := &Server{Handler: Echo}
+	if err := s.Shutdown(context.Background()); err != nil {
+		t.Errorf("Shutdown before Serve: %v", err)
+	}
+	ln := listen(t)
+	if err := s.Serve(ln); !errors.Is(err, ErrServerClosed) {
+		t.Errorf("Serve after Shutdown: error %v; want ErrServerClosed", err)
+	}
+	if conn, err := net.DialTimeout("tcp", ln.Addr().String(), wait); err == nil {
+		conn.Close()
+		t.Error("Serve after Shutdown left its listener open")
+	}
+	if err := s.Shutdown(context.Background()); err != nil {
+		t.Errorf("a second Shutdown: %v", err)
+	}
+}
+
+// failingListener is a listener whose Accept fails.
+type failingListener struct{ net.Listener }
+
+var errAccept = errors.New("too many open files")
+
+func (failingListener) Accept() (net.Conn, error) { return nil, errAccept }
+
+func TestServeAcceptError(t *testing.T) {
+	s := &Server{Handler: Echo}
+	errc := make(chan error, 1)
+	go func() { errc <- s.Serve(failingListener{listen(t)}) }()
+	select {
+	case err := <-errc:
+		if !errors.Is(err, errAccept) {
+			t.Errorf("Serve with a failing Accept: error %v; want %v", err, errAccept)
+		}
+	case <-time.After(wait):
+		t.Error("Serve with a failing Accept didn't return")
+	}
+}
+
+// listenUDP opens a UDP socket on a free port, closed when the test
+// ends.
+func listenUDP(t *testing.T) net.PacketConn {
+	t.Helper()
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { pc.Close() })
+	return pc
+}
+
+func TestUDP(t *testing.T) {
+	pc := listenUDP(t)
+	errc := make(chan error, 1)
+	go func() { errc <- ServeUDP(pc) }()
+	addr := pc.LocalAddr().String()
+
+	for _, msg := range []string{"hello", "Mixed Case 123", strings.Repeat("big ", 10000)} {
+		got, err := QueryUDP(addr, []byte(msg), wait)
+		if err != nil {
+			t.Errorf("QueryUDP(%.20q): %v", msg, err)
+		}
+		if want := strings.ToUpper(msg); string(got) != want {
+			t.Errorf("QueryUDP(%.20q) = %.20q (%d bytes); want %.20q (%d bytes)", msg, got, len(got), want, len(want))
+		}
+	}
+
+	// Each datagram is a message of its own, from whoever sent it.
+	client := listenUDP(t)
+	client.SetDeadline(time.Now().Add(wait))
+	for _, msg := range []string{"one", "two"} {
+		client.WriteTo([]byte(msg), pc.LocalAddr())
+	}
+	buf := make([]byte, 100)
+	for _, want := range []string{"ONE", "TWO"} {
+		n, from, err := client.ReadFrom(buf)
+		if err != nil {
+			t.Fatalf("reading a reply: %v", err)
+		}
+		assert.Equal(t, string(buf[:n]), want, "a reply to two datagrams")
+		assert.Equal(t, from.String(), addr, "where the reply came from")
+	}
+
+	pc.Close()
+	select {
+	case err := <-errc:
+		if err != nil {
+			t.Errorf("ServeUDP after its conn was closed: %v; want nil", err)
+		}
+	case <-time.After(wait):
+		t.Error("ServeUDP didn't return when its conn was closed")
+	}
+}
+
+// failingPacketConn is a PacketConn whose ReadFrom fails.
+type failingPacketConn struct{ net.PacketConn }
+
+var errRead = errors.New("network is down")
+
+func (failingPacketConn) ReadFrom([]byte) (int, net.Addr, error) { return 0, nil, errRead }
+
+func TestServeUDPError(t *testing.T) {
+	errc := make(chan error, 1)
+	go func() { errc <- ServeUDP(failingPacketConn{listenUDP(t)}) }()
+	select {
+	case err := <-errc:
+		if !errors.Is(err, errRead) {
+			t.Errorf("ServeUDP with a failing ReadFrom: error %v; want %v", err, errRead)
+		}
+	case <-time.After(wait):
+		t.Error("ServeUDP with a failing ReadFrom didn't return")
+	}
+}
+
+func TestQueryUDPTimeout(t *testing.T) {
+	// A socket that never answers: the datagram might as well be lost.
+	silent := listenUDP(t)
+	start := time.Now()
+	got, err := QueryUDP(silent.LocalAddr().String(), []byte("anyone?"), 100*time.Millisecond)
+	if !errors.Is(err, os.ErrDeadlineExceeded) {
+		t.Errorf("QueryUDP to a silent socket = %q, %v; want os.ErrDeadlineExceeded", got, err)
+	}
+	if elapsed := time.Since(start); elapsed > wait/2 {
+		t.Errorf("QueryUDP with a 100ms timeout took %v", elapsed)
+	}
+
+	if _, err := QueryUDP("127.0.0.1:99999", []byte("x"), wait); err == nil {
+		t.Error("QueryUDP to port 99999: got no error")
+	}
+	// That fails at once, rather than waiting for a reply.
+	if _, err := QueryUDP(silent.LocalAddr().String(), make([]byte, MaxDatagram+1), wait); err == nil || errors.Is(err, os.ErrDeadlineExceeded) {
+		t.Errorf("QueryUDP of a datagram too big to send: error %v; want the one from Write", err)
+	}
+}
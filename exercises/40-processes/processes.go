@@ -0,0 +1,164 @@
+//go:build !solutions
+
+package processes
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// Exercise 40: Processes and signals
+//
+// Node has child_process.spawn and execFile, and process.on('SIGTERM').
+// Go has os/exec and os/signal. An exec.Cmd describes a command: its
+// program and arguments, and where its stdin, stdout and stderr go.
+// Run starts it and waits; Start and Wait do the two halves, so you can
+// do something in between. No shell is involved, so an argument with
+// spaces or a * in it is passed as it is, and nothing can be injected.
+//
+// The other side is being the process that's told to stop: Ctrl-C
+// sends SIGINT, and a supervisor like Docker, systemd or Kubernetes
+// sends SIGTERM, then SIGKILL if you take too long. A well-behaved
+// worker finishes what it's doing and exits cleanly.
+//
+// The tests run Unix commands (sh, sort, tr), so they need macOS,
+// Linux or WSL.
+//
+// Run tests with: go test -v
+
+// CommandError is a command that ran and failed: it exited with a
+// status other than 0, or was killed.
+type CommandError struct {
+	Command  string // the command line: "ls -l /missing"
+	ExitCode int    // -1 if it was killed by a signal
+	Stderr   string // what it wrote to stderr, without surrounding space
+	Err      error  // the *exec.ExitError
+}
+
+func (e *CommandError) Error() string {
+	msg := fmt.Sprintf("%s: %v", e.Command, e.Err)
+	if e.Stderr != "" {
+		msg += ": " + e.Stderr
+	}
+	return msg
+}
+
+func (e *CommandError) Unwrap() error { return e.Err }
+
+// 1. Running a command
+// Run runs the program name with args and returns what it wrote to
+// stdout. exec.CommandContext makes the Cmd; point its Stdout and
+// Stderr at two bytes.Buffers to capture them separately.
+//
+// If the command fails, return a *CommandError: its Command is name
+// and args joined with spaces, its ExitCode is the *exec.ExitError's
+// ExitCode(), and its Stderr is what the command wrote to stderr, which
+// is usually why. A program that can't be started at all (it's not
+// found, say) isn't a CommandError; return exec's error as it is.
+//
+// When ctx ends, CommandContext kills the command, and Run gets a
+// "signal: killed" error. Return ctx.Err() instead, which says why.
+func Run(ctx context.Context, name string, args ...string) (string, error) {
+	// TODO
+	return "", nil
+}
+
+// 2. Exit codes
+// ExitCode returns the exit code in err: 0 for nil, the code of an
+// *exec.ExitError anywhere in err's chain (a CommandError has one),
+// and -1 for anything else. Shell scripts check $? like this.
+func ExitCode(err error) int {
+	// TODO: errors.As
+	return 0
+}
+
+// 3. Pipelines
+// Pipeline runs cmds as the shell would run `cmd1 | cmd2 | ...`: the
+// first reads input, each one's stdout is the next one's stdin, and it
+// returns the last one's stdout. Each command in cmds is a name
+// followed by its args.
+//
+// next.Stdin, _ = cmd.StdoutPipe() connects two commands. Start them
+// all, then Wait for them all: they run at the same time, each
+// reading as the one before writes. If any of them fails, return the
+// first one's error, as a *CommandError, as bash's `set -o pipefail`
+// does. No commands at all, or an empty one, is an error.
+func Pipeline(ctx context.Context, input string, cmds ...[]string) (string, error) {
+	// TODO
+	return "", nil
+}
+
+// 4. Streaming output
+// RunLines runs the command and calls fn with each line it writes to
+// stdout, as it writes it, rather than once it's done: the way you'd
+// follow a long build or `tail -f`. cmd.StdoutPipe() gives a reader
+// for a bufio.Scanner; read it all before cmd.Wait, which closes it.
+// A command that fails is a *CommandError, as in Run.
+func RunLines(ctx context.Context, fn func(line string), name string, args ...string) error {
+	// TODO
+	return nil
+}
+
+// 5. A worker that drains
+// RunWorker is a long-running job. Every interval it does a unit of
+// work, writing "tick N" to w, N counting from 1. When ctx ends it
+// stops taking new work, writes "draining", finishes up, and writes
+// "stopped after N ticks", then returns nil. Each line ends in "\n".
+// A time.Ticker and a select on ticker.C and ctx.Done() are all it
+// takes.
+func RunWorker(ctx context.Context, interval time.Duration, w io.Writer) error {
+	// TODO
+	return nil
+}
+
+// 6. Catching signals
+// RunUntilSignal calls work with a context that's canceled when the
+// process gets one of sigs, and returns work's error. Until then the
+// signals are caught, so they don't kill the process, and work gets to
+// stop cleanly.
+//
+// signal.Notify(c, sigs...) sends them to a channel c instead (make it
+// buffered: the signal package doesn't wait for you); a goroutine
+// waiting on c can cancel the context. After the first signal, stop
+// catching them with signal.Stop(c), before canceling, so that by the
+// time work is cleaning up, a second Ctrl-C kills a worker whose
+// cleanup hangs, as users expect. Make sure the goroutine ends when
+// work returns without a signal too. (signal.NotifyContext does the
+// first half of this, but its context is canceled before you can stop
+// it.)
+func RunUntilSignal(work func(ctx context.Context) error, sigs ...os.Signal) error {
+	// TODO
+	return nil
+}
+
+// 7. Stopping a child gracefully
+// GracefulCommand is exec.CommandContext, except that when ctx ends it
+// sends the command SIGTERM rather than killing it outright, and only
+// kills it if it's still running grace later: what Kubernetes does to
+// a pod. A Cmd's Cancel field is the function called when ctx ends,
+// and WaitDelay is how long Wait then waits before killing it.
+//
+// If the command then exits cleanly, Wait returns ctx.Err(); if it had
+// to be killed, an *exec.ExitError.
+func GracefulCommand(ctx context.Context, grace time.Duration, name string, args ...string) *exec.Cmd {
+	// TODO
+	return exec.CommandContext(ctx, name, args...)
+}
+
+// Keep imports used
+var _ = bufio.NewScanner
+var _ = bytes.Buffer{}
+var _ = errors.As
+var _ = signal.Notify
+var _ = strings.Join
+var _ = syscall.SIGTERM
@@ -0,0 +1,416 @@
+package processes
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/imgarylai/learn-go/internal/assert"
+)
+
+// wait is how long a test waits for anything that should be quick.
+const wait = 5 * time.Second
+
+func TestRun(t *testing.T) {
+	ctx := context.Background()
+	out, err := Run(ctx, "echo", "hello")
+	if err != nil {
+		t.Errorf("Run(echo hello): %v", err)
+	}
+	assert.Equal(t, out, "hello\n", "Run(echo hello)")
+
+	// No shell: spaces and * arrive as they are.
+	out, err = Run(ctx, "printf", "%s|", "a b", "*", "$HOME")
+	if err != nil {
+		t.Errorf("Run(printf): %v", err)
+	}
+	assert.Equal(t, out, "a b|*|$HOME|", "Run(printf %%s| \"a b\" * $HOME)")
+
+	// stderr doesn't end up in the output.
+	out, err = Run(ctx, "sh", "-c", "echo out; echo err >&2")
+	if err != nil {
+		t.Errorf("Run(sh -c ...): %v", err)
+	}
+	assert.Equal(t, out, "out\n", "the stdout of a command that writes to both")
+}
+
+func TestRunErrors(t *testing.T) {
+	ctx := context.Background()
+	script := "echo partial; echo '  it broke  ' >&2; exit 3"
+	_, err := Run(ctx, "sh", "-c", script)
+	var cmdErr *CommandError
+	if !errors.As(err, &cmdErr) {
+		t.Fatalf("Run of a command that exits 3: error %v; want a *CommandError", err)
+	}
+	assert.Equal(t, cmdErr.Command, "sh -c "+script, "the CommandError's Command")
+	assert.Equal(t, cmdErr.ExitCode, 3, "the CommandError's ExitCode")
+	assert.Equal(t, cmdErr.Stderr, "it broke", "the CommandError's Stderr")
+	var exit *exec.ExitError
+	if !errors.As(err, &exit) {
+		t.Errorf("the CommandError's Err is %v; want the *exec.ExitError", cmdErr.Err)
+	}
+
+	// A program that isn't there never ran.
+	_, err = Run(ctx, "no-such-command-learn-go")
+	if !errors.Is(err, exec.ErrNotFound) {
+		t.Errorf("Run of a missing program: error %v; want exec.ErrNotFound", err)
+	}
+	if errors.As(err, &cmdErr) {
+		t.Errorf("Run of a missing program returned a CommandError: %v", err)
+	}
+
+	// Killed by a signal.
+	_, err = Run(ctx, "sh", "-c", "kill -9 $$")
+	if !errors.As(err, &cmdErr) || cmdErr.ExitCode != -1 {
+		t.Errorf("Run of a command killed by SIGKILL: error %v; want a CommandError with ExitCode -1", err)
+	}
+}
+
+func TestRunTimeout(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := Run(ctx, "sleep", "10")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Run(sleep 10) with a 100ms timeout: error %v; want context.DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > wait/2 {
+		t.Errorf("Run(sleep 10) with a 100ms timeout took %v", elapsed)
+	}
+
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	if _, err := Run(ctx, "echo", "hi"); !errors.Is(err, context.Canceled) {
+		t.Errorf("Run with a canceled context: error %v; want context.Canceled", err)
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	ctx := context.Background()
+	_, exit3 := Run(ctx, "sh", "-c", "exit 3")
+	_, exit1 := Run(ctx, "false")
+	_, missing := Run(ctx, "no-such-command-learn-go")
+	_, killed := Run(ctx, "sh", "-c", "kill -9 $$")
+	for _, tt := range []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"nil", nil, 0},
+		{"exit 3", exit3, 3},
+		{"false", exit1, 1},
+		{"exit 3, wrapped", fmt.Errorf("deploying: %w", exit3), 3},
+		{"a plain *exec.ExitError", exec.Command("sh", "-c", "exit 7").Run(), 7},
+		{"a missing program", missing, -1},
+		{"SIGKILL", killed, -1},
+		{"some other error", errors.New("disk full"), -1},
+	} {
+		assert.Equal(t, ExitCode(tt.err), tt.want, "ExitCode of %s (%v)", tt.name, tt.err)
+	}
+}
+
+func TestPipeline(t *testing.T) {
+	ctx := context.Background()
+	out, err := Pipeline(ctx, "banana\napple\ncherry\napple\n", []string{"sort"}, []string{"uniq"}, []string{"tr", "a-z", "A-Z"})
+	if err != nil {
+		t.Errorf("Pipeline(sort | uniq | tr): %v", err)
+	}
+	assert.Equal(t, out, "APPLE\nBANANA\nCHERRY\n", "Pipeline(sort | uniq | tr a-z A-Z)")
+
+	out, err = Pipeline(ctx, "just one\n", []string{"cat"})
+	if err != nil || out != "just one\n" {
+		t.Errorf("Pipeline(cat) = %q, %v", out, err)
+	}
+
+	// More than a pipe holds: they have to run at the same time.
+	big := strings.Repeat("all work and no play\n", 50000)
+	ctx, cancel := context.WithTimeout(context.Background(), wait)
+	defer cancel()
+	out, err = Pipeline(ctx, big, []string{"cat"}, []string{"cat"}, []string{"cat"})
+	if err != nil {
+		t.Errorf("Pipeline of 1 MB through cat | cat | cat: %v", err)
+	}
+	if out != big {
+		t.Errorf("Pipeline of 1 MB through cat | cat | cat gave %d bytes", len(out))
+	}
+}
+
+func TestPipelineErrors(t *testing.T) {
+	ctx := context.Background()
+	for _, tt := range []struct {
+		name   string
+		cmds   [][]string
+		code   int
+		stderr string
+	}{
+		{"the first failing", [][]string{{"sh", "-c", "echo first failed >&2; exit 4"}, {"cat"}}, 4, "first failed"},
+		{"the middle failing", [][]string{{"cat"}, {"sh", "-c", "cat >/dev/null; echo nope >&2; exit 5"}, {"cat"}}, 5, "nope"},
+		{"the last failing", [][]string{{"cat"}, {"sh", "-c", "cat >/dev/null; exit 6"}}, 6, ""},
+		{"two failing", [][]string{{"sh", "-c", "echo one >&2; exit 2"}, {"sh", "-c", "cat >/dev/null; echo two >&2; exit 3"}}, 2, "one"},
+	} {
+		out, err := Pipeline(ctx, "input\n", tt.cmds...)
+		var cmdErr *CommandError
+		if !errors.As(err, &cmdErr) {
+			t.Errorf("Pipeline with %s = %q, %v; want a *CommandError", tt.name, out, err)
+			continue
+		}
+		assert.Equal(t, cmdErr.ExitCode, tt.code, "the ExitCode of Pipeline with %s", tt.name)
+		assert.Equal(t, cmdErr.Stderr, tt.stderr, "the Stderr of Pipeline with %s", tt.name)
+	}
+
+	if _, err := Pipeline(ctx, "x"); err == nil {
+		t.Error("Pipeline of no commands: got no error")
+	}
+	if _, err := Pipeline(ctx, "x", []string{"cat"}, []string{}); err == nil {
+		t.Error("Pipeline with an empty command: got no error")
+	}
+	if _, err := Pipeline(ctx, "x", []string{"cat"}, []string{"no-such-command-learn-go"}); !errors.Is(err, exec.ErrNotFound) {
+		t.Errorf("Pipeline with a missing program: error %v; want exec.ErrNotFound", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cancel()
+	if _, err := Pipeline(ctx, "", []string{"sleep", "10"}, []string{"cat"}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Pipeline(sleep 10 | cat) with a 100ms timeout: error %v; want context.DeadlineExceeded", err)
+	}
+}
+
+func TestRunLines(t *testing.T) {
+	ctx := context.Background()
+	var lines []string
+	var first time.Time
+	err := RunLines(ctx, func(line string) {
+		if first.IsZero() {
+			first = time.Now()
+		}
+		lines = append(lines, line)
+	}, "sh", "-c", "for i in 1 2 3; do echo line $i; sleep 0.1; done")
+	if err != nil {
+		t.Errorf("RunLines: %v", err)
+	}
+	assert.Equal(t, lines, []string{"line 1", "line 2", "line 3"}, "the lines RunLines saw")
+	if since := time.Since(first); since < 150*time.Millisecond {
+		t.Errorf("RunLines saw the first line only %v before the command ended; want it as it was written", since)
+	}
+
+	lines = nil
+	RunLines(ctx, func(line string) { lines = append(lines, line) }, "printf", "a\nb")
+	assert.Equal(t, lines, []string{"a", "b"}, "the lines of a\\nb")
+}
+
+func TestRunLinesErrors(t *testing.T) {
+	ctx := context.Background()
+	var lines []string
+	err := RunLines(ctx, func(line string) { lines = append(lines, line) }, "sh", "-c", "echo a; echo oops >&2; exit 1")
+	var cmdErr *CommandError
+	if !errors.As(err, &cmdErr) || cmdErr.ExitCode != 1 || cmdErr.Stderr != "oops" {
+		t.Errorf("RunLines of a command that fails: error %#v; want a CommandError with ExitCode 1 and Stderr oops", err)
+	}
+	assert.Equal(t, lines, []string{"a"}, "the lines of a command that fails")
+
+	if err := RunLines(ctx, func(string) {}, "no-such-command-learn-go"); !errors.Is(err, exec.ErrNotFound) {
+		t.Errorf("RunLines of a missing program: error %v; want exec.ErrNotFound", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cancel()
+	if err := RunLines(ctx, func(string) {}, "sleep", "10"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("RunLines(sleep 10) with a 100ms timeout: error %v; want context.DeadlineExceeded", err)
+	}
+}
+
+func TestRunWorker(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 55*time.Millisecond)
+	defer cancel()
+	var out strings.Builder
+	if err := RunWorker(ctx, 10*time.Millisecond, &out); err != nil {
+		t.Errorf("RunWorker: %v", err)
+	}
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	n := len(lines) - 2
+	if n < 3 {
+		t.Fatalf("RunWorker for 55ms at 10ms wrote %q; want at least 3 ticks, then draining and stopped", out.String())
+	}
+	for i, line := range lines[:n] {
+		assert.Equal(t, line, fmt.Sprintf("tick %d", i+1), "line %d of RunWorker's output", i+1)
+	}
+	assert.Equal(t, lines[n:], []string{"draining", fmt.Sprintf("stopped after %d ticks", n)}, "the end of RunWorker's output")
+
+	out.Reset()
+	RunWorker(ctx, time.Millisecond, &out)
+	assert.Equal(t, out.String(), "draining\nstopped after 0 ticks\n", "RunWorker with a context that's already done")
+}
+
+// workerEnv tells the test binary to be a worker, for the signal
+// tests, rather than run the tests.
+const workerEnv = "LEARN_GO_WORKER"
+
+// TestWorkerProcess is what startWorker runs. On its own it does
+// nothing.
+func TestWorkerProcess(t *testing.T) {
+	var err error
+	switch os.Getenv(workerEnv) {
+	case "":
+		t.Skip("only run by the signal tests")
+	case "worker":
+		err = RunUntilSignal(func(ctx context.Context) error {
+			return RunWorker(ctx, 10*time.Millisecond, os.Stdout)
+		}, syscall.SIGINT, syscall.SIGTERM)
+	case "hang":
+		// A worker whose cleanup never ends.
+		err = RunUntilSignal(func(ctx context.Context) error {
+			fmt.Println("tick 1")
+			<-ctx.Done()
+			fmt.Println("draining")
+			time.Sleep(time.Minute)
+			return nil
+		}, syscall.SIGTERM)
+	}
+	fmt.Printf("exit: %v\n", err)
+	os.Exit(0)
+}
+
+// worker is a running TestWorkerProcess.
+type worker struct {
+	cmd   *exec.Cmd
+	lines chan string   // its stdout, a line at a time, closed at EOF
+	done  chan struct{} // closed when it has exited
+	err   error         // cmd.Wait's error, once done is closed
+}
+
+// startWorker runs TestWorkerProcess in mode in a new process, with
+// cmd, which may be nil. The process is killed when the test ends.
+func startWorker(t *testing.T, cmd *exec.Cmd, mode string) *worker {
+	t.Helper()
+	if cmd == nil {
+		cmd = exec.Command(os.Args[0], "-test.run=^TestWorkerProcess$")
+	}
+	cmd.Env = append(os.Environ(), workerEnv+"="+mode)
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cmd.Start(); err != nil {
+		t.Fatal(err)
+	}
+	w := &worker{cmd: cmd, lines: make(chan string, 100), done: make(chan struct{})}
+	go func() {
+		sc := bufio.NewScanner(stdout)
+		for sc.Scan() {
+			w.lines <- sc.Text()
+		}
+		close(w.lines)
+		w.err = cmd.Wait()
+		close(w.done)
+	}()
+	t.Cleanup(func() {
+		cmd.Process.Kill()
+		<-w.done
+	})
+	return w
+}
+
+// waitFor reads w's output until the line want, and fails t if it
+// doesn't come.
+func (w *worker) waitFor(t *testing.T, want string) {
+	t.Helper()
+	timeout := time.After(wait)
+	for {
+		select {
+		case line, ok := <-w.lines:
+			if !ok {
+				t.Fatalf("the worker ended without writing %q", want)
+			}
+			if line == want {
+				return
+			}
+		case <-timeout:
+			t.Fatalf("the worker didn't write %q within %v", want, wait)
+		}
+	}
+}
+
+// wait waits for w to exit, and fails t if it doesn't.
+func (w *worker) wait(t *testing.T) error {
+	t.Helper()
+	select {
+	case <-w.done:
+		return w.err
+	case <-time.After(wait):
+		t.Fatalf("the worker didn't exit within %v", wait)
+		return nil
+	}
+}
+
+func TestRunUntilSignal(t *testing.T) {
+	for _, sig := range []syscall.Signal{syscall.SIGTERM, syscall.SIGINT} {
+		w := startWorker(t, nil, "worker")
+		w.waitFor(t, "tick 2")
+		w.cmd.Process.Signal(sig)
+		w.waitFor(t, "draining")
+		var rest []string
+		for line := range w.lines {
+			rest = append(rest, line)
+		}
+		if len(rest) != 2 || !strings.HasPrefix(rest[0], "stopped after ") {
+			t.Errorf("after %v, draining, the worker wrote %q; want stopped after N ticks, then its exit", sig, rest)
+		} else {
+			assert.Equal(t, rest[1], "exit: <nil>", "what RunUntilSignal returned after %v", sig)
+		}
+		if err := w.wait(t); err != nil {
+			t.Errorf("the worker sent %v didn't exit cleanly: %v", sig, err)
+		}
+	}
+}
+
+func TestSecondSignal(t *testing.T) {
+	w := startWorker(t, nil, "hang")
+	w.waitFor(t, "tick 1")
+	w.cmd.Process.Signal(syscall.SIGTERM)
+	w.waitFor(t, "draining")
+
+	// The cleanup hangs; a second signal should kill it.
+	w.cmd.Process.Signal(syscall.SIGTERM)
+	w.wait(t)
+	status, _ := w.cmd.ProcessState.Sys().(syscall.WaitStatus)
+	if !status.Signaled() || status.Signal() != syscall.SIGTERM {
+		t.Errorf("after a second SIGTERM the worker ended with %v; want it killed by SIGTERM", w.cmd.ProcessState)
+	}
+}
+
+func TestGracefulCommand(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cmd := GracefulCommand(ctx, wait, os.Args[0], "-test.run=^TestWorkerProcess$")
+	w := startWorker(t, cmd, "worker")
+	w.waitFor(t, "tick 1")
+	cancel()
+	w.waitFor(t, "draining")
+	if err := w.wait(t); !errors.Is(err, context.Canceled) {
+		t.Errorf("Wait of a GracefulCommand that drained: error %v; want context.Canceled", err)
+	}
+
+	// One that doesn't stop is killed after the grace period.
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	cmd = GracefulCommand(ctx, 200*time.Millisecond, os.Args[0], "-test.run=^TestWorkerProcess$")
+	w = startWorker(t, cmd, "hang")
+	w.waitFor(t, "tick 1")
+	cancel()
+	w.waitFor(t, "draining")
+	start := time.Now()
+	err := w.wait(t)
+	var exit *exec.ExitError
+	if !errors.As(err, &exit) || exit.ExitCode() != -1 {
+		t.Errorf("Wait of a GracefulCommand that hung: error %v; want it killed", err)
+	}
+	if elapsed := time.Since(start); elapsed > wait/2 {
+		t.Errorf("a GracefulCommand with 200ms of grace took %v to kill", elapsed)
+	}
+}
@@ -0,0 +1,159 @@
+//go:build !solutions
+
+package iofs
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Exercise 41: io/fs and filesystem abstraction
+//
+// The functions in exercise 7 take a file name and call os.Open, so
+// their tests have to write real files to disk first. Go 1.16 added
+// io/fs: fs.FS is a read-only filesystem, an interface with one
+// method, Open(name). Take an fs.FS instead of a file name and the
+// caller decides where the files come from:
+//
+//   - os.DirFS("/some/dir") is a directory on disk
+//   - fstest.MapFS{"a.txt": {Data: []byte("hi")}} is a map in memory,
+//     for tests: no temp dirs, nothing to clean up
+//   - embed.FS is files compiled into the binary with //go:embed
+//   - zip.Reader is a zip archive
+//
+// In Node you'd reach for memfs or mock-fs, which patch the fs module
+// underneath your code; here the code asks for what it needs.
+//
+// Names in an fs.FS are always slash-separated and relative, with no
+// "." or ".." in them: "src/main.go", never "/src/main.go" or
+// "./src/main.go" (fs.ValidPath checks). The root is ".". Use the path
+// package on them, not path/filepath, which uses \ on Windows.
+//
+// Run tests with: go test -v
+
+// Person is exercise 7's Person, for the CSV and JSON files.
+type Person struct {
+	Name  string `json:"name"`
+	Age   int    `json:"age"`
+	Email string `json:"email"`
+}
+
+// 1. ReadLines
+// ReadLines returns the lines of the file called name in fsys, as
+// exercise 7's does: fsys.Open(name) instead of os.Open, then the same
+// bufio.Scanner. An fs.File has Read, Stat and Close, and that's all a
+// Scanner needs.
+func ReadLines(fsys fs.FS, name string) ([]string, error) {
+	// TODO: f, err := fsys.Open(name)
+	return nil, nil
+}
+
+// 2. CountLines
+// CountLines counts the lines of the file called name in fsys without
+// reading it all into memory.
+func CountLines(fsys fs.FS, name string) (int, error) {
+	// TODO
+	return 0, nil
+}
+
+// 3. ReadCSV
+// ReadCSV reads people from the CSV file called name in fsys: a header
+// row, name,age,email, then one person a row. A row whose age isn't a
+// number is an error that says where: "people.csv line 3: ...",
+// counting the header as line 1.
+//
+// Unlike exercise 7, there's no need to check len(row): a csv.Reader
+// expects every row to have as many fields as the first, and ReadAll
+// fails on one that doesn't.
+func ReadCSV(fsys fs.FS, name string) ([]Person, error) {
+	// TODO
+	return nil, nil
+}
+
+// 4. ReadJSON
+// ReadJSON reads a JSON array of people from the file called name in
+// fsys. fs.ReadFile is os.ReadFile for an fs.FS.
+//
+// There's no WriteJSON: an fs.FS is read-only. Code that writes takes
+// an io.Writer instead, which a test can make a bytes.Buffer.
+func ReadJSON(fsys fs.FS, name string) ([]Person, error) {
+	// TODO
+	return nil, nil
+}
+
+// 5. Walking a tree
+// ListFiles returns the paths of the files in dir in fsys and in every
+// directory below it, in lexical order, like `find dir -type f`, but
+// skipping hidden ones: a file or directory whose name starts with "."
+// is left out, and so is everything in it. dir itself is never
+// skipped, though: ListFiles(fsys, ".git") lists .git.
+//
+// fs.WalkDir(fsys, dir, fn) calls fn for each file and directory, in
+// lexical order, and for a directory, fn returning fs.SkipDir skips
+// what's in it. If dir doesn't exist, fn is called with the error;
+// return it.
+func ListFiles(fsys fs.FS, dir string) ([]string, error) {
+	// TODO: fs.WalkDir(fsys, dir, func(p string, d fs.DirEntry, err error) error { ... })
+	return nil, nil
+}
+
+// 6. Glob matching
+// FindFiles returns the files ListFiles(fsys, ".") lists whose base
+// name matches the glob pattern, like `find . -name '*.go'`:
+// path.Match("*.go", "main.go") reports whether a name matches, and
+// path.Base gives the last element of a path.
+//
+// A malformed pattern, like "[", is an error wrapping
+// path.ErrBadPattern. path.Match only notices when it gets to the bad
+// part, so check the pattern first, even if there are no files.
+//
+// fs.Glob(fsys, "src/*.go") matches whole paths, a directory level at a
+// time: * never crosses a "/", and there's no ** like in a
+// .gitignore. That's why FindFiles walks.
+func FindFiles(fsys fs.FS, pattern string) ([]string, error) {
+	// TODO
+	return nil, nil
+}
+
+// 7. Directory sizes
+// DirSizes returns the total size in bytes of the files in each
+// directory of fsys and all the directories below it, like `du -b`,
+// keyed by path; "." is the whole tree. Every directory has an entry,
+// an empty one too. Hidden files count: du doesn't skip them.
+//
+// d.Info() gives a DirEntry's fs.FileInfo, with its Size. Add each
+// file's size to its directory, and that directory's, up to ".":
+// path.Dir("src/util/x.go") is "src/util", and path.Dir("src") is ".".
+func DirSizes(fsys fs.FS) (map[string]int64, error) {
+	// TODO
+	return nil, nil
+}
+
+// 8. Back to disk
+// ReadFileLines is exercise 7's ReadLines(filename) written with this
+// one: filename's directory as an fs.FS with os.DirFS, and its base
+// name in it. filepath.Dir and filepath.Base split a path on disk.
+// A missing file is still an error that errors.Is fs.ErrNotExist.
+func ReadFileLines(filename string) ([]string, error) {
+	// TODO
+	return nil, nil
+}
+
+// Keep imports used
+var _ = bufio.NewScanner
+var _ = csv.NewReader
+var _ = json.Unmarshal
+var _ = fmt.Errorf
+var _ = os.DirFS
+var _ = path.Match
+var _ = filepath.Dir
+var _ = strconv.Atoi
+var _ = strings.HasPrefix
@@ -0,0 +1,267 @@
+package iofs
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/imgarylai/learn-go/internal/assert"
+)
+
+// project is a small source tree, in memory: no temp dirs to make.
+var project = fstest.MapFS{
+	"README.md":        {Data: []byte("# demo\n")},
+	".env":             {Data: []byte("TOKEN=secret\n")},
+	".git/config":      {Data: []byte("[core]\n")},
+	".git/HEAD":        {Data: []byte("ref: refs/heads/main\n")},
+	"src/main.go":      {Data: []byte("package main\n\nfunc main() {}\n")},
+	"src/.cache/x.go":  {Data: []byte("package cache\n")},
+	"src/util/util.go": {Data: []byte("package util\n")},
+	"src/util/doc.txt": {Data: []byte("utilities")},
+	"empty":            {Mode: fs.ModeDir},
+	"docs/guide.md":    {Data: []byte("## Guide\nRead me.\n")},
+}
+
+func TestReadLines(t *testing.T) {
+	fsys := fstest.MapFS{
+		"notes.txt":  {Data: []byte("first\nsecond\n\nfourth")},
+		"crlf.txt":   {Data: []byte("one\r\ntwo\r\n")},
+		"empty.txt":  {Data: nil},
+		"dir/a.txt":  {Data: []byte("in a directory\n")},
+		"long.txt":   {Data: []byte(strings.Repeat("x", 100_000) + "\n")},
+		"dir/b/c.md": {Data: []byte("deep\n")},
+	}
+	for _, tt := range []struct {
+		name string
+		want []string
+	}{
+		{"notes.txt", []string{"first", "second", "", "fourth"}},
+		{"crlf.txt", []string{"one", "two"}},
+		{"empty.txt", nil},
+		{"dir/a.txt", []string{"in a directory"}},
+		{"dir/b/c.md", []string{"deep"}},
+	} {
+		got, err := ReadLines(fsys, tt.name)
+		if err != nil {
+			t.Errorf("ReadLines(%q): %v", tt.name, err)
+		}
+		assert.Equal(t, strings.Join(got, "|"), strings.Join(tt.want, "|"), "ReadLines(%q), joined with |", tt.name)
+		assert.Equal(t, len(got), len(tt.want), "len(ReadLines(%q))", tt.name)
+	}
+
+	if _, err := ReadLines(fsys, "missing.txt"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("ReadLines of a missing file: error %v; want fs.ErrNotExist", err)
+	}
+	if _, err := ReadLines(fsys, "/notes.txt"); err == nil {
+		t.Error(`ReadLines(fsys, "/notes.txt"): got no error; fs.FS names never start with /`)
+	}
+	// A line longer than bufio.Scanner's 64KB buffer is an error, as in
+	// exercise 7, not a silently short file.
+	if got, err := ReadLines(fsys, "long.txt"); err == nil {
+		t.Errorf("ReadLines of a 100KB line = %d lines; want bufio.ErrTooLong", len(got))
+	}
+}
+
+func TestCountLines(t *testing.T) {
+	fsys := fstest.MapFS{
+		"three.txt": {Data: []byte("a\nb\nc\n")},
+		"no-nl.txt": {Data: []byte("a\nb")},
+		"blank.txt": {Data: []byte("\n\n")},
+		"empty.txt": {},
+	}
+	for name, want := range map[string]int{"three.txt": 3, "no-nl.txt": 2, "blank.txt": 2, "empty.txt": 0} {
+		got, err := CountLines(fsys, name)
+		if err != nil {
+			t.Errorf("CountLines(%q): %v", name, err)
+		}
+		assert.Equal(t, got, want, "CountLines(%q)", name)
+	}
+	if _, err := CountLines(fsys, "missing.txt"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("CountLines of a missing file: error %v; want fs.ErrNotExist", err)
+	}
+}
+
+func TestReadCSV(t *testing.T) {
+	fsys := fstest.MapFS{
+		"people.csv": {Data: []byte("name,age,email\nAlice,30,alice@example.com\n\"Lee, Bo\",25,bo@example.com\n")},
+		"header.csv": {Data: []byte("name,age,email\n")},
+		"short.csv":  {Data: []byte("name,age,email\nAlice,30\n")},
+		"age.csv":    {Data: []byte("name,age,email\nAlice,thirty,alice@example.com\n")},
+	}
+	got, err := ReadCSV(fsys, "people.csv")
+	if err != nil {
+		t.Errorf("ReadCSV(people.csv): %v", err)
+	}
+	want := []Person{{"Alice", 30, "alice@example.com"}, {"Lee, Bo", 25, "bo@example.com"}}
+	assert.Equal(t, len(got), len(want), "len(ReadCSV(people.csv))")
+	for i := range min(len(got), len(want)) {
+		assert.Equal(t, got[i], want[i], "ReadCSV(people.csv)[%d]", i)
+	}
+
+	got, err = ReadCSV(fsys, "header.csv")
+	if err != nil || len(got) != 0 {
+		t.Errorf("ReadCSV of just a header = %v, %v; want no people and no error", got, err)
+	}
+	if got, err := ReadCSV(fsys, "short.csv"); err == nil {
+		t.Errorf("ReadCSV of a row with two fields = %v; want an error", got)
+	}
+	got, err = ReadCSV(fsys, "age.csv")
+	if err == nil {
+		t.Errorf("ReadCSV of an age of thirty = %v; want an error", got)
+	} else if !strings.HasPrefix(err.Error(), "age.csv line 2:") {
+		t.Errorf("ReadCSV of an age of thirty: error %q; want it to start %q", err, "age.csv line 2:")
+	}
+	if _, err := ReadCSV(fsys, "missing.csv"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("ReadCSV of a missing file: error %v; want fs.ErrNotExist", err)
+	}
+}
+
+func TestReadJSON(t *testing.T) {
+	fsys := fstest.MapFS{
+		"people.json": {Data: []byte(`[{"name":"Alice","age":30,"email":"alice@example.com"},{"name":"Bob","age":25}]`)},
+		"bad.json":    {Data: []byte(`[{"name":"Alice",`)},
+	}
+	got, err := ReadJSON(fsys, "people.json")
+	if err != nil {
+		t.Errorf("ReadJSON(people.json): %v", err)
+	}
+	want := []Person{{"Alice", 30, "alice@example.com"}, {"Bob", 25, ""}}
+	assert.Equal(t, len(got), len(want), "len(ReadJSON(people.json))")
+	for i := range min(len(got), len(want)) {
+		assert.Equal(t, got[i], want[i], "ReadJSON(people.json)[%d]", i)
+	}
+	if got, err := ReadJSON(fsys, "bad.json"); err == nil {
+		t.Errorf("ReadJSON of cut-off JSON = %v; want an error", got)
+	}
+	if _, err := ReadJSON(fsys, "missing.json"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("ReadJSON of a missing file: error %v; want fs.ErrNotExist", err)
+	}
+}
+
+func TestListFiles(t *testing.T) {
+	for _, tt := range []struct {
+		dir  string
+		want []string
+	}{
+		{".", []string{"README.md", "docs/guide.md", "src/main.go", "src/util/doc.txt", "src/util/util.go"}},
+		{"src", []string{"src/main.go", "src/util/doc.txt", "src/util/util.go"}},
+		{"src/util", []string{"src/util/doc.txt", "src/util/util.go"}},
+		{"empty", nil},
+		{".git", []string{".git/HEAD", ".git/config"}},
+		{"src/.cache", []string{"src/.cache/x.go"}},
+	} {
+		got, err := ListFiles(project, tt.dir)
+		if err != nil {
+			t.Errorf("ListFiles(%q): %v", tt.dir, err)
+		}
+		assert.Equal(t, strings.Join(got, " "), strings.Join(tt.want, " "), "ListFiles(%q)", tt.dir)
+	}
+
+	if _, err := ListFiles(project, "nope"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("ListFiles of a missing directory: error %v; want fs.ErrNotExist", err)
+	}
+
+	// The same function works on a real directory.
+	got, err := ListFiles(os.DirFS("testdata"), ".")
+	if err != nil {
+		t.Errorf("ListFiles(os.DirFS(testdata)): %v", err)
+	}
+	assert.Equal(t, strings.Join(got, " "), "notes.txt", "ListFiles(os.DirFS(testdata))")
+}
+
+func TestFindFiles(t *testing.T) {
+	for _, tt := range []struct {
+		pattern string
+		want    []string
+	}{
+		{"*.go", []string{"src/main.go", "src/util/util.go"}},
+		{"*.md", []string{"README.md", "docs/guide.md"}},
+		{"[a-m]*", []string{"docs/guide.md", "src/main.go", "src/util/doc.txt"}},
+		{"util.go", []string{"src/util/util.go"}},
+		{"src", nil},
+		{"*.rs", nil},
+	} {
+		got, err := FindFiles(project, tt.pattern)
+		if err != nil {
+			t.Errorf("FindFiles(%q): %v", tt.pattern, err)
+		}
+		assert.Equal(t, strings.Join(got, " "), strings.Join(tt.want, " "), "FindFiles(%q)", tt.pattern)
+	}
+
+	if _, err := FindFiles(os.DirFS("testdata/missing"), "*"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("FindFiles in a missing directory: error %v; want fs.ErrNotExist", err)
+	}
+
+	for _, fsys := range []fstest.MapFS{project, {}} {
+		if got, err := FindFiles(fsys, "*.go["); !errors.Is(err, path.ErrBadPattern) {
+			t.Errorf("FindFiles(%q) on %d files = %v, %v; want path.ErrBadPattern", "*.go[", len(fsys), got, err)
+		}
+	}
+}
+
+func TestDirSizes(t *testing.T) {
+	got, err := DirSizes(project)
+	if err != nil {
+		t.Fatalf("DirSizes: %v", err)
+	}
+	want := map[string]int64{
+		".":          7 + 13 + 7 + 21 + 29 + 14 + 13 + 9 + 18,
+		".git":       7 + 21,
+		"src":        29 + 14 + 13 + 9,
+		"src/.cache": 14,
+		"src/util":   13 + 9,
+		"docs":       18,
+		"empty":      0,
+	}
+	for dir, size := range want {
+		n, ok := got[dir]
+		if !ok {
+			t.Errorf("DirSizes has no entry for %q", dir)
+			continue
+		}
+		assert.Equal(t, n, size, "DirSizes()[%q]", dir)
+	}
+	for dir := range got {
+		if _, ok := want[dir]; !ok {
+			t.Errorf("DirSizes has an entry for %q, which isn't a directory", dir)
+		}
+	}
+
+	if got, err := DirSizes(os.DirFS("testdata/missing")); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("DirSizes of a missing directory = %v, %v; want fs.ErrNotExist", got, err)
+	}
+
+	// The root's size is every file's.
+	var total int64
+	for _, f := range project {
+		total += int64(len(f.Data))
+	}
+	assert.Equal(t, got["."], total, `DirSizes()["."]`)
+}
+
+func TestReadFileLines(t *testing.T) {
+	name := filepath.Join("testdata", "notes.txt")
+	got, err := ReadFileLines(name)
+	if err != nil {
+		t.Errorf("ReadFileLines(%q): %v", name, err)
+	}
+	data, _ := os.ReadFile(name)
+	want := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	assert.Equal(t, strings.Join(got, "|"), strings.Join(want, "|"), "ReadFileLines(%q), joined with |", name)
+
+	// An absolute path works too: os.DirFS takes any directory.
+	abs, _ := filepath.Abs(name)
+	got, err = ReadFileLines(abs)
+	if err != nil || len(got) != len(want) {
+		t.Errorf("ReadFileLines(%q) = %d lines, %v; want %d", abs, len(got), err, len(want))
+	}
+
+	if _, err := ReadFileLines(filepath.Join("testdata", "missing.txt")); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("ReadFileLines of a missing file: error %v; want fs.ErrNotExist", err)
+	}
+}
@@ -0,0 +1,276 @@
+//go:build !solutions
+
+package primitives
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// Exercise 42: Advanced sync primitives
+//
+// Exercise 6 used channels, a WaitGroup and a Mutex. The sync and
+// sync/atomic packages have more, each for one job:
+//
+//   - sync.Once runs something exactly once, however many goroutines
+//     ask at the same moment
+//   - sync.RWMutex lets any number of readers in together, but a
+//     writer alone
+//   - sync.Map is a map that's safe to share, tuned for a few cases
+//   - sync/atomic updates a single number or pointer without a lock
+//
+// and golang.org/x/sync/errgroup is a WaitGroup that also collects the
+// first error and cancels the rest. Node runs your JS on one thread, so
+// it never needs any of this; in Go, every map or counter two
+// goroutines touch needs one of them. `go test -race` finds the places
+// that are missing one.
+//
+// Run tests with: go test -v -race
+
+// 1. Lazy initialization
+// Lazy holds a value that's expensive to make, like a database handle
+// or a parsed template, and makes it the first time it's wanted rather
+// than at startup. NewLazy is given.
+type Lazy[T any] struct {
+	once sync.Once
+	load func() (T, error)
+	val  T
+	err  error
+}
+
+// NewLazy returns a Lazy that calls load the first time Get is called.
+func NewLazy[T any](load func() (T, error)) *Lazy[T] {
+	return &Lazy[T]{load: load}
+}
+
+// Get returns the value, calling load to make it the first time. load
+// is called once only, even if many goroutines call Get at once: the
+// others wait for it, then all get what it returned. An error is kept
+// too, and every later Get returns it again, as a failed require() is
+// in Node.
+//
+// l.once.Do(f) runs f the first time and never again; a call that
+// arrives while f is running waits for it. (sync.OnceValues(load) does
+// all of Lazy in one call, since Go 1.21; write it once yourself.)
+func (l *Lazy[T]) Get() (T, error) {
+	// TODO: l.once.Do(func() { ... })
+	var zero T
+	return zero, nil
+}
+
+// 2. A read-mostly cache
+// Cache maps keys to values, for many goroutines at once. Lookups far
+// outnumber updates, so it uses an RWMutex: RLock for reading lets any
+// number of Gets in together, and Lock for writing waits for them all
+// to leave and keeps everyone else out.
+type Cache struct {
+	mu    sync.RWMutex
+	items map[string]string
+}
+
+// NewCache returns an empty Cache.
+func NewCache() *Cache {
+	return &Cache{items: make(map[string]string)}
+}
+
+// Get returns the value for key, and whether there was one.
+func (c *Cache) Get(key string) (string, bool) {
+	// TODO: c.mu.RLock(); defer c.mu.RUnlock()
+	return "", false
+}
+
+// Set stores value for key.
+func (c *Cache) Set(key, value string) {
+	// TODO: c.mu.Lock(); defer c.mu.Unlock()
+}
+
+// Len returns how many keys are cached.
+func (c *Cache) Len() int {
+	// TODO
+	return 0
+}
+
+// GetOrLoad returns the value for key, calling load(key) and caching
+// what it returns if there isn't one. An error isn't cached: it's
+// returned, and the next call tries again.
+//
+// Don't hold the lock while load runs: it's slow (that's why there's a
+// cache), and every other Get would wait for it. So look under RLock,
+// load with no lock held, then Lock and look again before storing:
+// another goroutine may have loaded the same key meanwhile, and then
+// its value wins and is what you return, so every caller sees the same
+// one.
+func (c *Cache) GetOrLoad(key string, load func(string) (string, error)) (string, error) {
+	// TODO
+	return "", nil
+}
+
+// 3. sync.Map or a mutex?
+// Store is a map from string to int that's safe for concurrent use.
+// MutexMap and SyncMap are two ways to make one; bench_test.go races
+// them, with `learngo bench 42`.
+//
+// sync.Map has no lock to forget, but it isn't a faster map. Its docs
+// say it's for two cases: a key written once and then only read, like a
+// cache that only grows, or goroutines that each work on their own set
+// of keys. Anything else, and a map with a mutex is as fast or faster,
+// and it has types: a sync.Map's keys and values are any.
+type Store interface {
+	Load(key string) (int, bool)
+	Store(key string, value int)
+}
+
+// MutexMap is a map guarded by an RWMutex.
+type MutexMap struct {
+	mu sync.RWMutex
+	m  map[string]int
+}
+
+// NewMutexMap returns an empty MutexMap.
+func NewMutexMap() *MutexMap {
+	return &MutexMap{m: make(map[string]int)}
+}
+
+func (m *MutexMap) Load(key string) (int, bool) {
+	// TODO
+	return 0, false
+}
+
+func (m *MutexMap) Store(key string, value int) {
+	// TODO
+}
+
+// SyncMap is a sync.Map. Its zero value is ready to use; v, ok :=
+// m.m.Load(key) returns an any, so assert v.(int).
+type SyncMap struct {
+	m sync.Map
+}
+
+func (m *SyncMap) Load(key string) (int, bool) {
+	// TODO
+	return 0, false
+}
+
+func (m *SyncMap) Store(key string, value int) {
+	// TODO
+}
+
+// 4. Atomic counters
+// Stats counts a server's requests as they happen, from every handler
+// goroutine at once, with atomic.Int64s instead of a mutex: Add, Load
+// and CompareAndSwap are each a single CPU instruction that no other
+// goroutine can see half done.
+type Stats struct {
+	requests atomic.Int64
+	errors   atomic.Int64
+	inFlight atomic.Int64
+	peak     atomic.Int64
+}
+
+// Snapshot is what a Stats had counted at one moment.
+type Snapshot struct {
+	Requests int64 // finished, with or without an error
+	Errors   int64 // finished with an error
+	InFlight int64 // begun and not yet finished
+	Peak     int64 // the most that were ever in flight at once
+}
+
+// Begin records a request starting. If that makes more in flight than
+// ever before, it raises peak.
+//
+// Raising a maximum needs a compare-and-swap loop: load peak, and if n
+// is bigger, CompareAndSwap(old, n). That fails if another goroutine
+// changed peak in between, and then you load it and try again. A Load
+// and a Store would let a smaller peak overwrite a bigger one.
+func (s *Stats) Begin() {
+	// TODO: n := s.inFlight.Add(1)
+}
+
+// End records a request finishing, with err if it failed.
+func (s *Stats) End(err error) {
+	// TODO
+}
+
+// Snapshot returns the counts. Each is read atomically, but not all
+// four at the same instant: fine for a dashboard.
+func (s *Stats) Snapshot() Snapshot {
+	// TODO
+	return Snapshot{}
+}
+
+// 5. Hot-swapping config
+// Config is settings that can be reloaded while the server runs, say
+// on SIGHUP. Handlers read it on every request, so reading mustn't
+// lock.
+type Config struct {
+	Greeting string
+	Limit    int
+	Features []string
+}
+
+// ErrNilConfig is returned for a nil *Config.
+var ErrNilConfig = errors.New("config is nil")
+
+// ConfigStore holds the current *Config in an atomic.Value. A reader
+// gets the pointer and keeps using that Config, even if a new one is
+// stored a moment later: never change a Config once it's stored, store
+// a new one. (atomic.Pointer[Config] is the same with types.)
+type ConfigStore struct {
+	v atomic.Value
+}
+
+// NewConfigStore returns a ConfigStore holding initial.
+func NewConfigStore(initial *Config) (*ConfigStore, error) {
+	// TODO
+	return nil, nil
+}
+
+// Load returns the current Config: s.v.Load().(*Config).
+func (s *ConfigStore) Load() *Config {
+	// TODO
+	return nil
+}
+
+// Store replaces the current Config with c, or returns ErrNilConfig.
+func (s *ConfigStore) Store(c *Config) error {
+	// TODO
+	return nil
+}
+
+// Update replaces the current Config with what change returns when
+// given a copy of it, and returns the new one. Two Updates at once
+// mustn't lose either change: Load, call change, and store the result
+// only if the Config is still the one you loaded, with
+// s.v.CompareAndSwap(old, new). If it isn't, someone else got in
+// first; start again from theirs.
+//
+// The copy change gets shares Features with the stored Config, so
+// change must make a new slice rather than append to or edit that one.
+func (s *ConfigStore) Update(change func(Config) Config) *Config {
+	// TODO
+	return nil
+}
+
+// 6. errgroup
+// FetchAll calls fetch for every key, at most limit at a time (any
+// number if limit is 0 or less), and returns the results in the same order as keys. If any fails, FetchAll
+// returns the first error, and nil results, and the ctx the other calls
+// got is canceled so they can stop early: like Promise.all, but the
+// losers are told.
+//
+// g, ctx := errgroup.WithContext(ctx) gives a group and a ctx that's
+// canceled when a g.Go func returns an error; g.SetLimit(limit) makes
+// g.Go wait while limit funcs are running; g.Wait() waits for all of
+// them and returns the first error. Each goroutine writes only its own
+// results[i], so they don't need a lock.
+func FetchAll(ctx context.Context, keys []string, limit int, fetch func(ctx context.Context, key string) (string, error)) ([]string, error) {
+	// TODO
+	return nil, nil
+}
+
+// Keep imports used
+var _ = errgroup.WithContext
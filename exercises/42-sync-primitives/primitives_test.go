@@ -0,0 +1,463 @@
+package primitives
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.uber.org/goleak"
+
+	"github.com/imgarylai/learn-go/internal/assert"
+)
+
+// waitFor fails t if ch isn't closed, or sent on, within a second.
+func waitFor[T any](t *testing.T, ch <-chan T, what string) {
+	t.Helper()
+	select {
+	case <-ch:
+	case <-time.After(time.Second):
+		t.Fatalf("timed out waiting for %s", what)
+	}
+}
+
+func TestLazy(t *testing.T) {
+	var calls atomic.Int32
+	l := NewLazy(func() (int, error) {
+		calls.Add(1)
+		time.Sleep(20 * time.Millisecond) // long enough for every Get to arrive
+		return 42, nil
+	})
+	assert.Equal(t, calls.Load(), int32(0), "calls to load before any Get")
+
+	var wg sync.WaitGroup
+	got := make([]int, 50)
+	for i := range got {
+		wg.Go(func() {
+			v, err := l.Get()
+			if err != nil {
+				t.Errorf("Get: %v", err)
+			}
+			got[i] = v
+		})
+	}
+	wg.Wait()
+	assert.Equal(t, calls.Load(), int32(1), "calls to load after 50 Gets at once")
+	for i, v := range got {
+		if v != 42 {
+			t.Fatalf("Get number %d = %d; want 42", i, v)
+		}
+	}
+	v, _ := l.Get()
+	assert.Equal(t, v, 42, "Get after the first")
+	assert.Equal(t, calls.Load(), int32(1), "calls to load after another Get")
+}
+
+func TestLazyError(t *testing.T) {
+	var calls atomic.Int32
+	l := NewLazy(func() (string, error) {
+		n := calls.Add(1)
+		return "partial", fmt.Errorf("connect attempt %d failed", n)
+	})
+	for range 3 {
+		v, err := l.Get()
+		if err == nil || err.Error() != "connect attempt 1 failed" {
+			t.Errorf("Get() = %q, %v; want the first attempt's error every time", v, err)
+		}
+	}
+	assert.Equal(t, calls.Load(), int32(1), "calls to load that failed, after 3 Gets")
+}
+
+func TestCache(t *testing.T) {
+	c := NewCache()
+	if v, ok := c.Get("lang"); ok {
+		t.Errorf("Get(lang) on an empty Cache = %q, true", v)
+	}
+	c.Set("lang", "go")
+	c.Set("editor", "vim")
+	c.Set("lang", "Go")
+	c.Set("empty", "")
+	for key, want := range map[string]string{"lang": "Go", "editor": "vim", "empty": ""} {
+		v, ok := c.Get(key)
+		if !ok {
+			t.Errorf("Get(%q): not found", key)
+		}
+		assert.Equal(t, v, want, "Get(%q)", key)
+	}
+	assert.Equal(t, c.Len(), 3, "Len()")
+
+	// Many goroutines at once, each with its own keys.
+	var wg sync.WaitGroup
+	for g := range 8 {
+		wg.Go(func() {
+			for i := range 100 {
+				key := fmt.Sprintf("g%d-%d", g, i)
+				c.Set(key, key)
+				if v, ok := c.Get(key); !ok || v != key {
+					t.Errorf("Get(%q) right after Set = %q, %v", key, v, ok)
+					return
+				}
+			}
+		})
+	}
+	wg.Wait()
+	assert.Equal(t, c.Len(), 3+8*100, "Len() after 8 goroutines set 100 keys each")
+}
+
+func TestCacheGetOrLoad(t *testing.T) {
+	c := NewCache()
+	var calls int
+	load := func(key string) (string, error) {
+		calls++
+		if key == "bad" {
+			return "", fmt.Errorf("no such user %q", key)
+		}
+		return strings.ToUpper(key), nil
+	}
+	for range 2 {
+		v, err := c.GetOrLoad("ann", load)
+		if err != nil {
+			t.Errorf("GetOrLoad(ann): %v", err)
+		}
+		assert.Equal(t, v, "ANN", "GetOrLoad(ann)")
+	}
+	assert.Equal(t, calls, 1, "calls to load after GetOrLoad(ann) twice")
+
+	c.Set("bob", "Robert")
+	v, _ := c.GetOrLoad("bob", load)
+	assert.Equal(t, v, "Robert", "GetOrLoad of a key that was Set")
+	assert.Equal(t, calls, 1, "calls to load for a key that was Set")
+
+	for range 2 {
+		if v, err := c.GetOrLoad("bad", load); err == nil {
+			t.Errorf("GetOrLoad(bad) = %q; want load's error", v)
+		}
+	}
+	assert.Equal(t, calls, 3, "calls to load after it failed twice: errors aren't cached")
+	if v, ok := c.Get("bad"); ok {
+		t.Errorf("Get(bad) after load failed = %q; want nothing cached", v)
+	}
+}
+
+func TestCacheGetOrLoadUnlocked(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	c := NewCache()
+	c.Set("other", "x")
+	started, release := make(chan struct{}), make(chan struct{})
+	defer func() {
+		select {
+		case <-release:
+		default:
+			close(release)
+		}
+	}()
+	type result struct {
+		v   string
+		err error
+	}
+	slow := make(chan result, 1)
+	go func() {
+		v, err := c.GetOrLoad("user", func(string) (string, error) {
+			close(started)
+			<-release
+			return "from the slow load", nil
+		})
+		slow <- result{v, err}
+	}()
+	waitFor(t, started, "GetOrLoad to call load")
+
+	// The slow load holds no lock: the cache carries on without it.
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		c.Get("other")
+		c.Set("new", "y")
+		if v, err := c.GetOrLoad("user", func(string) (string, error) { return "from the fast load", nil }); err != nil || v != "from the fast load" {
+			t.Errorf("a second GetOrLoad(user) = %q, %v; want the fast load's value", v, err)
+		}
+	}()
+	waitFor(t, done, "Get, Set and GetOrLoad while a load runs; is the lock held during load?")
+
+	// The fast load stored its value first, so the slow one's loses.
+	close(release)
+	r := <-slow
+	if r.err != nil || r.v != "from the fast load" {
+		t.Errorf("the slow GetOrLoad(user) = %q, %v; want the value stored while it loaded", r.v, r.err)
+	}
+	v, _ := c.Get("user")
+	assert.Equal(t, v, "from the fast load", "Get(user) after both loads")
+}
+
+func TestStores(t *testing.T) {
+	for name, newStore := range map[string]func() Store{
+		"MutexMap": func() Store { return NewMutexMap() },
+		"SyncMap":  func() Store { return new(SyncMap) },
+	} {
+		s := newStore()
+		if v, ok := s.Load("a"); ok || v != 0 {
+			t.Errorf("%s: Load(a) when empty = %d, %v; want 0, false", name, v, ok)
+		}
+		s.Store("a", 1)
+		s.Store("zero", 0)
+		s.Store("a", 2)
+		if v, ok := s.Load("a"); !ok || v != 2 {
+			t.Errorf("%s: Load(a) = %d, %v; want 2, true", name, v, ok)
+		}
+		if v, ok := s.Load("zero"); !ok || v != 0 {
+			t.Errorf("%s: Load(zero) = %d, %v; want 0, true", name, v, ok)
+		}
+
+		var wg sync.WaitGroup
+		for g := range 8 {
+			wg.Go(func() {
+				for i := range 200 {
+					s.Store(fmt.Sprintf("%d/%d", g, i), g*1000+i)
+					s.Load("a")
+				}
+			})
+		}
+		wg.Wait()
+		for g := range 8 {
+			for i := range 200 {
+				if v, ok := s.Load(fmt.Sprintf("%d/%d", g, i)); !ok || v != g*1000+i {
+					t.Fatalf("%s: Load(%d/%d) after concurrent Stores = %d, %v; want %d", name, g, i, v, ok, g*1000+i)
+				}
+			}
+		}
+	}
+}
+
+func TestStats(t *testing.T) {
+	var s Stats
+	s.Begin()
+	s.Begin()
+	s.Begin()
+	s.End(nil)
+	s.End(errors.New("timeout"))
+	s.Begin()
+	assert.Equal(t, s.Snapshot(), Snapshot{Requests: 2, Errors: 1, InFlight: 2, Peak: 3}, "Snapshot after 4 Begins, End(nil) and End(err)")
+
+	// 100 requests in flight at once, from 100 goroutines.
+	var all Stats
+	var begun, wg sync.WaitGroup
+	begun.Add(100)
+	finish := make(chan struct{})
+	for i := range 100 {
+		wg.Go(func() {
+			all.Begin()
+			begun.Done()
+			<-finish
+			var err error
+			if i%10 == 0 {
+				err = errors.New("failed")
+			}
+			all.End(err)
+		})
+	}
+	begun.Wait()
+	assert.Equal(t, all.Snapshot().InFlight, int64(100), "InFlight with 100 requests begun")
+	close(finish)
+	wg.Wait()
+	assert.Equal(t, all.Snapshot(), Snapshot{Requests: 100, Errors: 10, InFlight: 0, Peak: 100}, "Snapshot after 100 concurrent requests, 10 failing")
+}
+
+func TestStatsPeak(t *testing.T) {
+	// Many short requests at once, racing to raise the peak.
+	var s Stats
+	var wg sync.WaitGroup
+	for range 16 {
+		wg.Go(func() {
+			for range 1000 {
+				s.Begin()
+				s.End(nil)
+			}
+		})
+	}
+	wg.Wait()
+	snap := s.Snapshot()
+	if snap.Peak < 1 || snap.Peak > 16 {
+		t.Errorf("Peak = %d with 16 goroutines; want 1 to 16", snap.Peak)
+	}
+	assert.Equal(t, snap.Requests, int64(16*1000), "Requests")
+	assert.Equal(t, snap.InFlight, int64(0), "InFlight when all have ended")
+}
+
+func TestConfigStore(t *testing.T) {
+	if _, err := NewConfigStore(nil); !errors.Is(err, ErrNilConfig) {
+		t.Errorf("NewConfigStore(nil): error %v; want ErrNilConfig", err)
+	}
+	first := &Config{Greeting: "hello", Limit: 10}
+	s, err := NewConfigStore(first)
+	if err != nil || s == nil {
+		t.Fatalf("NewConfigStore = %v, %v", s, err)
+	}
+	if got := s.Load(); got != first {
+		t.Fatalf("Load() = %+v; want the *Config NewConfigStore was given", got)
+	}
+
+	second := &Config{Greeting: "hi", Limit: 20}
+	if err := s.Store(second); err != nil {
+		t.Errorf("Store: %v", err)
+	}
+	assert.Equal(t, s.Load(), second, "Load() after Store")
+	if err := s.Store(nil); !errors.Is(err, ErrNilConfig) {
+		t.Errorf("Store(nil): error %v; want ErrNilConfig", err)
+	}
+	assert.Equal(t, s.Load(), second, "Load() after Store(nil)")
+	assert.Equal(t, *first, Config{Greeting: "hello", Limit: 10}, "the first Config, after it was replaced")
+}
+
+func TestConfigStoreUpdate(t *testing.T) {
+	initial := &Config{Greeting: "hello", Limit: 0, Features: []string{"base"}}
+	s, err := NewConfigStore(initial)
+	if err != nil || s == nil {
+		t.Fatalf("NewConfigStore = %v, %v", s, err)
+	}
+
+	got := s.Update(func(c Config) Config {
+		c.Greeting = "hi"
+		c.Features = append(slices.Clone(c.Features), "beta")
+		return c
+	})
+	if got == nil {
+		t.Fatal("Update returned nil")
+	}
+	assert.Equal(t, s.Load(), got, "Load() after Update: the Config Update returned")
+	assert.Equal(t, got.Greeting, "hi", "Greeting after Update")
+	assert.Equal(t, strings.Join(got.Features, ","), "base,beta", "Features after Update")
+	assert.Equal(t, initial.Greeting, "hello", "the initial Config's Greeting after Update: it must not change")
+
+	// 50 goroutines raise Limit by one each, at once. A Load then Store
+	// loses some of them; CompareAndSwap loses none.
+	var wg sync.WaitGroup
+	var start sync.WaitGroup
+	start.Add(1)
+	for range 50 {
+		wg.Go(func() {
+			start.Wait()
+			for range 20 {
+				s.Update(func(c Config) Config {
+					c.Limit++
+					runtime.Gosched() // let another Update in, mid-change
+					return c
+				})
+			}
+		})
+	}
+	start.Done()
+	wg.Wait()
+	assert.Equal(t, s.Load().Limit, 50*20, "Limit after 1000 concurrent Updates adding 1")
+	assert.Equal(t, initial.Limit, 0, "the initial Config's Limit")
+}
+
+func TestFetchAll(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	keys := []string{"a", "b", "c", "d", "e", "f", "g", "h"}
+	var running, most atomic.Int32
+	fetch := func(ctx context.Context, key string) (string, error) {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for {
+			m := most.Load()
+			if n <= m || most.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		time.Sleep(time.Duration(len(keys)-int(key[0]-'a')) * 2 * time.Millisecond) // later keys finish first
+		return strings.Repeat(key, 2), nil
+	}
+	got, err := FetchAll(context.Background(), keys, 3, fetch)
+	if err != nil {
+		t.Errorf("FetchAll: %v", err)
+	}
+	assert.Equal(t, strings.Join(got, " "), "aa bb cc dd ee ff gg hh", "FetchAll(a..h), in order")
+	if m := most.Load(); m > 3 || m < 2 {
+		t.Errorf("FetchAll with limit 3 ran %d fetches at once; want 2 or 3", m)
+	}
+
+	most.Store(0)
+	got, err = FetchAll(context.Background(), keys[:4], 1, fetch)
+	if err != nil || len(got) != 4 {
+		t.Errorf("FetchAll(a..d) with limit 1 = %q, %v", got, err)
+	}
+	assert.Equal(t, most.Load(), int32(1), "fetches at once with limit 1")
+
+	got, err = FetchAll(context.Background(), nil, 3, fetch)
+	if err != nil || len(got) != 0 {
+		t.Errorf("FetchAll with no keys = %q, %v; want none and no error", got, err)
+	}
+}
+
+func TestFetchAllError(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	errNotFound := errors.New("not found")
+	var canceled atomic.Int32
+	fetch := func(ctx context.Context, key string) (string, error) {
+		if key == "missing" {
+			time.Sleep(10 * time.Millisecond)
+			return "", errNotFound
+		}
+		select {
+		case <-ctx.Done():
+			canceled.Add(1)
+			return "", ctx.Err()
+		case <-time.After(5 * time.Second):
+			return key, nil
+		}
+	}
+	start := time.Now()
+	got, err := FetchAll(context.Background(), []string{"a", "b", "missing", "c"}, 0, fetch)
+	if !errors.Is(err, errNotFound) {
+		t.Errorf("FetchAll with one key missing: error %v; want fetch's error, not context.Canceled", err)
+	}
+	if got != nil {
+		t.Errorf("FetchAll = %q with its error; want nil", got)
+	}
+	if took := time.Since(start); took > time.Second {
+		t.Errorf("FetchAll took %v after a fetch failed; cancel the others' ctx", took)
+	}
+	assert.Equal(t, canceled.Load(), int32(3), "fetches that saw their ctx canceled")
+
+	// The caller's ctx reaches fetch too.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := FetchAll(ctx, []string{"a"}, 1, fetch); !errors.Is(err, context.Canceled) {
+		t.Errorf("FetchAll with a canceled ctx: error %v; want context.Canceled", err)
+	}
+}
+
+func TestFetchAllNoLimit(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	// Every fetch waits until all 20 have started: that only happens if
+	// a limit of 0 means no limit.
+	var started atomic.Int32
+	all := make(chan struct{})
+	keys := make([]string, 20)
+	for i := range keys {
+		keys[i] = fmt.Sprint(i)
+	}
+	got, err := FetchAll(context.Background(), keys, 0, func(ctx context.Context, key string) (string, error) {
+		if started.Add(1) == 20 {
+			close(all)
+		}
+		select {
+		case <-all:
+			return "#" + key, nil
+		case <-time.After(time.Second):
+			return "", errors.New("the other fetches never started")
+		}
+	})
+	if err != nil {
+		t.Errorf("FetchAll of 20 keys with limit 0: %v", err)
+	}
+	assert.Equal(t, len(got), 20, "len(FetchAll) of 20 keys")
+	if len(got) == 20 {
+		assert.Equal(t, got[19], "#19", "FetchAll(...)[19]")
+	}
+}
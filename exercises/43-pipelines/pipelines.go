@@ -0,0 +1,160 @@
+//go:build !solutions
+
+package pipelines
+
+import (
+	"context"
+	"sync"
+)
+
+// Exercise 43: Channel pipeline patterns
+//
+// A pipeline is stages joined by channels: each stage is goroutines
+// that receive values from upstream, do something with them, and send
+// the results downstream, like Node streams piped together, or an RxJS
+// chain. Exercise 6 built a worker pool and a fan-out; this exercise
+// builds the standard pieces, generic so they work for any type, and
+// makes every one of them stop cleanly.
+//
+// That's the hard part. A goroutine blocked on a send that nobody will
+// ever receive, or a receive from a channel nobody will close, is
+// stuck for good: a goroutine leak, like a listener never removed in
+// Node, except each holds its stack and everything it points to. So
+// every stage here follows the same rules:
+//
+//   - a stage closes the channels it returns once it's done sending,
+//     so whoever ranges over them finishes
+//   - every send and receive selects on ctx.Done() too, so canceling
+//     ctx stops the whole pipeline, even mid-send, and then every stage
+//     closes its output
+//
+// The tests check the second with goleak, which fails a test if any
+// goroutine it started is still running at the end.
+//
+// Run tests with: go test -v
+
+// 1. Generator
+// Generate sends values, in order, on the channel it returns, then
+// closes it. It's the first stage: it turns a slice into a stream.
+func Generate[T any](ctx context.Context, values ...T) <-chan T {
+	// TODO: out := make(chan T); go func() { defer close(out); ... }()
+	out := make(chan T)
+	close(out)
+	return out
+}
+
+// 2. A stage
+// Map sends fn(v) for every v received from in, in the same order, and
+// closes its output once in is closed, or ctx is done.
+func Map[T, U any](ctx context.Context, in <-chan T, fn func(T) U) <-chan U {
+	// TODO
+	out := make(chan U)
+	close(out)
+	return out
+}
+
+// 3. Stopping early
+// Take passes on the first n values from in, then closes its output,
+// having received no more than n: a value received and dropped would
+// be lost to anyone else reading in. So receive from in directly:
+// OrDone's goroutine would already have received the next value
+// before Take knew it was done. The stages before Take carry on until
+// their ctx is canceled, so a caller that takes a few and is done must
+// cancel it.
+func Take[T any](ctx context.Context, in <-chan T, n int) <-chan T {
+	// TODO
+	out := make(chan T)
+	close(out)
+	return out
+}
+
+// 4. Or-done
+// OrDone passes on every value from in until in is closed or ctx is
+// done. It makes a channel that doesn't know about ctx safe to range
+// over:
+//
+//	for v := range OrDone(ctx, in) { ... }
+//
+// stops when ctx is canceled, where `for v := range in` would wait for
+// in to be closed. The other stages can use it for their receives.
+func OrDone[T any](ctx context.Context, in <-chan T) <-chan T {
+	// TODO: a select on ctx.Done() and <-in, then another on
+	// ctx.Done() and out <- v
+	out := make(chan T)
+	close(out)
+	return out
+}
+
+// 5. Fan-in
+// Merge sends every value from every channel in cs on the one it
+// returns, in whatever order they arrive, and closes it once all of cs
+// are closed, or ctx is done. One goroutine per input channel, and a
+// sync.WaitGroup to know when to close: closing out while another
+// goroutine might still send on it panics.
+func Merge[T any](ctx context.Context, cs ...<-chan T) <-chan T {
+	// TODO
+	out := make(chan T)
+	close(out)
+	return out
+}
+
+// 6. Fan-out
+// FanOut starts n workers, at least one, that each take values from
+// in, the same channel, so each value goes to one of them, and send
+// fn(v) on their own output channel; it returns the n channels. Slow
+// work gets n times the hands, and Merge brings the results back
+// together, though no longer in order.
+func FanOut[T, U any](ctx context.Context, in <-chan T, n int, fn func(T) U) []<-chan U {
+	// TODO
+	return nil
+}
+
+// 7. Tee
+// Tee sends every value from in on both channels it returns, like the
+// Unix command tee, or a Node stream piped to two places. Each value
+// goes to both before Tee takes the next, so the slower reader sets the
+// pace, and both must keep reading, or cancel ctx.
+//
+// To send to both in either order, set each channel variable to nil
+// once it has the value: a send on a nil channel blocks forever, so
+// select never picks that case again.
+func Tee[T any](ctx context.Context, in <-chan T) (<-chan T, <-chan T) {
+	// TODO
+	out1, out2 := make(chan T), make(chan T)
+	close(out1)
+	close(out2)
+	return out1, out2
+}
+
+// 8. Bridge
+// Bridge takes a channel of channels and sends everything from the
+// first of them, until it's closed, then everything from the second,
+// and so on, as one stream: flatMap for channels. It closes its output
+// once chans is closed and the last channel drained, or ctx is done.
+func Bridge[T any](ctx context.Context, chans <-chan (<-chan T)) <-chan T {
+	// TODO
+	out := make(chan T)
+	close(out)
+	return out
+}
+
+// 9. A bounded, ordered stage
+// OrderedMap is Map with up to n calls of fn, at least one, running at
+// once, and results still sent in the order their values came in: like
+// Promise.all over a p-limit'd list, but streaming. fn gets ctx so a
+// slow call can give up when it's canceled.
+//
+// One way: for each value, make a channel with room for its one result,
+// start a goroutine that sends fn's result on it, and queue the channel
+// on a channel of channels with room for n-1. A second goroutine takes
+// them off the queue in order, waits for each result and sends it on.
+// The queue filling up is what stops more than n starting.
+func OrderedMap[T, U any](ctx context.Context, in <-chan T, n int, fn func(context.Context, T) U) <-chan U {
+	// TODO
+	out := make(chan U)
+	close(out)
+	return out
+}
+
+// Keep imports used
+var _ = sync.WaitGroup{}
@@ -0,0 +1,398 @@
+package pipelines
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.uber.org/goleak"
+
+	"github.com/imgarylai/learn-go/internal/assert"
+)
+
+// Every test starts with defer goleak.VerifyNone(t): once the test's
+// own defers have run, canceling ctx among them, no goroutine may be
+// left. goleak retries for a moment, so stages have time to see ctx.
+
+// collect receives from ch until it's closed, failing t if that takes
+// more than a second.
+func collect[T any](t *testing.T, ch <-chan T) []T {
+	t.Helper()
+	var got []T
+	timeout := time.After(time.Second)
+	for {
+		select {
+		case v, ok := <-ch:
+			if !ok {
+				return got
+			}
+			got = append(got, v)
+		case <-timeout:
+			t.Fatalf("channel still open after a second, with %v received", got)
+		}
+	}
+}
+
+// receive returns the next value from ch, failing t if there isn't one
+// within a second.
+func receive[T any](t *testing.T, ch <-chan T) T {
+	t.Helper()
+	select {
+	case v, ok := <-ch:
+		if !ok {
+			t.Fatal("channel closed; want another value")
+		}
+		return v
+	case <-time.After(time.Second):
+		t.Fatal("no value within a second")
+	}
+	panic("unreachable")
+}
+
+// counter sends 0, 1, 2 and on until ctx is done, and never closes: a
+// source that only canceling ctx stops.
+func counter(ctx context.Context) <-chan int {
+	out := make(chan int)
+	go func() {
+		for i := 0; ; i++ {
+			select {
+			case out <- i:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// tracker records how many calls are running at once, and the most
+// there ever were.
+type tracker struct {
+	running, most atomic.Int32
+}
+
+func (tr *tracker) enter() {
+	n := tr.running.Add(1)
+	for {
+		m := tr.most.Load()
+		if n <= m || tr.most.CompareAndSwap(m, n) {
+			return
+		}
+	}
+}
+
+func (tr *tracker) leave() { tr.running.Add(-1) }
+
+func ints(n int) []int {
+	s := make([]int, n)
+	for i := range s {
+		s[i] = i
+	}
+	return s
+}
+
+func TestGenerate(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	assert.Equal(t, fmt.Sprint(collect(t, Generate(ctx, 1, 2, 3))), "[1 2 3]", "Generate(1, 2, 3)")
+	assert.Equal(t, fmt.Sprint(collect(t, Generate(ctx, "a", "b"))), "[a b]", "Generate(a, b)")
+	assert.Equal(t, len(collect(t, Generate[int](ctx))), 0, "values from Generate()")
+
+	// Canceled partway: the channel closes, and nothing is left running.
+	g := Generate(ctx, ints(1000)...)
+	receive(t, g)
+	cancel()
+	if got := collect(t, g); len(got) > 100 {
+		t.Errorf("Generate sent %d more values after ctx was canceled", len(got))
+	}
+}
+
+func TestMap(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	got := collect(t, Map(ctx, Generate(ctx, 1, 2, 3, 4), func(n int) string { return strings.Repeat("*", n) }))
+	assert.Equal(t, strings.Join(got, " "), "* ** *** ****", "Map(1..4, stars)")
+
+	// Stages chain.
+	double := func(n int) int { return n * 2 }
+	chained := collect(t, Map(ctx, Map(ctx, Generate(ctx, 1, 2, 3), double), double))
+	assert.Equal(t, fmt.Sprint(chained), "[4 8 12]", "Map(Map(1..3, double), double)")
+
+	// An endless source: canceling stops Map, and Map's output closes.
+	m := Map(ctx, counter(ctx), double)
+	assert.Equal(t, receive(t, m), 0, "first value of Map(counter, double)")
+	assert.Equal(t, receive(t, m), 2, "second value of Map(counter, double)")
+	cancel()
+	collect(t, m)
+}
+
+func TestTake(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	in := make(chan int, 10)
+	for i := range 10 {
+		in <- i
+	}
+	close(in)
+	assert.Equal(t, fmt.Sprint(collect(t, Take(ctx, in, 3))), "[0 1 2]", "Take(0..9, 3)")
+	assert.Equal(t, len(in), 7, "values left in the channel after Take 3 of 10: it mustn't receive a 4th")
+	assert.Equal(t, fmt.Sprint(collect(t, Take(ctx, in, 100))), "[3 4 5 6 7 8 9]", "Take(100) of the 7 left")
+	assert.Equal(t, len(collect(t, Take(ctx, Generate(ctx, 1, 2), 0))), 0, "values from Take(0)")
+
+	// Take from an endless source, then cancel the source.
+	assert.Equal(t, fmt.Sprint(collect(t, Take(ctx, counter(ctx), 5))), "[0 1 2 3 4]", "Take(counter, 5)")
+
+	// Canceled while waiting on a source that has nothing.
+	ctx2, cancel2 := context.WithCancel(context.Background())
+	never := make(chan int)
+	tk := Take(ctx2, never, 3)
+	cancel2()
+	collect(t, tk)
+}
+
+func TestOrDone(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	assert.Equal(t, fmt.Sprint(collect(t, OrDone(ctx, Generate(ctx, 1, 2, 3)))), "[1 2 3]", "OrDone(1, 2, 3)")
+
+	// in is never closed: only ctx ends the range.
+	in := make(chan int)
+	o := OrDone(ctx, in)
+	select {
+	case in <- 42:
+	case <-time.After(time.Second):
+		t.Fatal("OrDone didn't receive from in")
+	}
+	assert.Equal(t, receive(t, o), 42, "value passed on by OrDone")
+	cancel()
+	var n int
+	for range o {
+		n++
+	}
+	assert.Equal(t, n, 0, "values from OrDone after ctx was canceled")
+}
+
+func TestMerge(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	got := collect(t, Merge(ctx, Generate(ctx, 1, 2, 3), Generate(ctx, 4, 5), Generate[int](ctx)))
+	slices.Sort(got)
+	assert.Equal(t, fmt.Sprint(got), "[1 2 3 4 5]", "Merge(1..3, 4..5, nothing), sorted")
+	assert.Equal(t, len(collect(t, Merge[int](ctx))), 0, "values from Merge()")
+
+	// Values from each input stay in order.
+	letters := collect(t, Merge(ctx, Generate(ctx, "a1", "a2", "a3"), Generate(ctx, "b1", "b2", "b3")))
+	var as, bs []string
+	for _, s := range letters {
+		if s[0] == 'a' {
+			as = append(as, s)
+		} else {
+			bs = append(bs, s)
+		}
+	}
+	assert.Equal(t, strings.Join(as, " ")+" | "+strings.Join(bs, " "), "a1 a2 a3 | b1 b2 b3", "Merge's values from each input, in order")
+
+	// Endless sources: both feed in, and canceling closes the output.
+	m := Merge(ctx, Map(ctx, counter(ctx), func(n int) int { return -n - 1 }), counter(ctx))
+	var neg, pos int
+	for range 200 {
+		if receive(t, m) < 0 {
+			neg++
+		} else {
+			pos++
+		}
+	}
+	if neg == 0 || pos == 0 {
+		t.Errorf("Merge of two endless sources gave %d from one and %d from the other; want both", neg, pos)
+	}
+	cancel()
+	collect(t, m)
+}
+
+func TestFanOut(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var tr tracker
+	square := func(n int) int {
+		tr.enter()
+		defer tr.leave()
+		time.Sleep(2 * time.Millisecond)
+		return n * n
+	}
+	outs := FanOut(ctx, Generate(ctx, ints(40)...), 4, square)
+	if len(outs) != 4 {
+		t.Fatalf("FanOut(n=4) returned %d channels; want 4", len(outs))
+	}
+	got := collect(t, Merge(ctx, outs...))
+	slices.Sort(got)
+	want := make([]int, 40)
+	for i := range want {
+		want[i] = i * i
+	}
+	assert.Equal(t, fmt.Sprint(got), fmt.Sprint(want), "Merge(FanOut(0..39, square)...), sorted")
+	assert.Equal(t, tr.most.Load(), int32(4), "calls to fn at once with 4 workers")
+
+	if outs := FanOut(ctx, Generate(ctx, 1, 2), 0, square); len(outs) != 1 {
+		t.Errorf("FanOut(n=0) returned %d channels; want 1", len(outs))
+	} else {
+		assert.Equal(t, fmt.Sprint(collect(t, outs[0])), "[1 4]", "FanOut(n=0)'s one channel")
+	}
+
+	// Canceled with every worker mid-send.
+	outs = FanOut(ctx, counter(ctx), 3, func(n int) int { return n })
+	if len(outs) == 3 {
+		receive(t, outs[0])
+	}
+	cancel()
+	for _, o := range outs {
+		collect(t, o)
+	}
+}
+
+func TestTee(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	a, b := Tee(ctx, Generate(ctx, 1, 2, 3, 4, 5))
+	done := make(chan []int)
+	go func() {
+		var got []int
+		for v := range b {
+			got = append(got, v)
+		}
+		done <- got
+	}()
+	assert.Equal(t, fmt.Sprint(collect(t, a)), "[1 2 3 4 5]", "Tee's first channel")
+	select {
+	case got := <-done:
+		assert.Equal(t, fmt.Sprint(got), "[1 2 3 4 5]", "Tee's second channel")
+	case <-time.After(time.Second):
+		t.Fatal("Tee's second channel still open after a second")
+	}
+
+	// Only one side reads: Tee waits for the other, and canceling frees it.
+	a, b = Tee(ctx, counter(ctx))
+	assert.Equal(t, receive(t, a), 0, "first value on Tee's first channel")
+	assert.Equal(t, receive(t, b), 0, "first value on Tee's second channel")
+	assert.Equal(t, receive(t, b), 1, "second value on Tee's second channel, read first")
+	cancel()
+	collect(t, a)
+	collect(t, b)
+}
+
+func TestBridge(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	chans := Generate(ctx, Generate(ctx, 1, 2), Generate[int](ctx), Generate(ctx, 3, 4, 5))
+	assert.Equal(t, fmt.Sprint(collect(t, Bridge(ctx, chans))), "[1 2 3 4 5]", "Bridge of (1, 2), (), (3, 4, 5)")
+	assert.Equal(t, len(collect(t, Bridge(ctx, Generate[<-chan int](ctx)))), 0, "values from Bridge of no channels")
+
+	// Each stream is drained before the next: Take 3 from each of
+	// endless streams and they come out one stream at a time.
+	streams := make(chan (<-chan string))
+	go func() {
+		defer close(streams)
+		for _, name := range []string{"a", "b", "c"} {
+			s := Take(ctx, Map(ctx, counter(ctx), func(n int) string { return fmt.Sprint(name, n) }), 3)
+			select {
+			case streams <- s:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	got := collect(t, Bridge(ctx, streams))
+	assert.Equal(t, strings.Join(got, " "), "a0 a1 a2 b0 b1 b2 c0 c1 c2", "Bridge of three Take(3)s")
+
+	// Canceled in the middle of an endless stream.
+	forever := Bridge(ctx, Generate(ctx, counter(ctx)))
+	receive(t, forever)
+	cancel()
+	collect(t, forever)
+}
+
+func TestOrderedMap(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var tr tracker
+	slow := func(ctx context.Context, n int) string {
+		tr.enter()
+		defer tr.leave()
+		time.Sleep(time.Duration(20-n) * time.Millisecond) // later values finish first
+		return fmt.Sprint("#", n)
+	}
+	got := collect(t, OrderedMap(ctx, Generate(ctx, ints(20)...), 4, slow))
+	want := make([]string, 20)
+	for i := range want {
+		want[i] = fmt.Sprint("#", i)
+	}
+	assert.Equal(t, strings.Join(got, " "), strings.Join(want, " "), "OrderedMap(0..19, n=4)")
+	if m := tr.most.Load(); m > 4 || m < 2 {
+		t.Errorf("OrderedMap with n=4 ran fn %d at once; want 2 to 4", m)
+	}
+
+	tr.most.Store(0)
+	got = collect(t, OrderedMap(ctx, Generate(ctx, 3, 2, 1), 1, slow))
+	assert.Equal(t, strings.Join(got, " "), "#3 #2 #1", "OrderedMap(3, 2, 1, n=1)")
+	assert.Equal(t, tr.most.Load(), int32(1), "calls to fn at once with n=1")
+
+	tr.most.Store(0)
+	got = collect(t, OrderedMap(ctx, Generate(ctx, 5, 6), 0, slow))
+	assert.Equal(t, strings.Join(got, " "), "#5 #6", "OrderedMap(5, 6, n=0)")
+	assert.Equal(t, tr.most.Load(), int32(1), "calls to fn at once with n=0")
+}
+
+func TestOrderedMapCancel(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// fn blocks until ctx is canceled, as a slow request would.
+	var tr tracker
+	var calls atomic.Int32
+	wait := func(ctx context.Context, n int) int {
+		tr.enter()
+		defer tr.leave()
+		calls.Add(1)
+		select {
+		case <-ctx.Done():
+		case <-time.After(5 * time.Second):
+		}
+		return n
+	}
+	out := OrderedMap(ctx, counter(ctx), 3, wait)
+	deadline := time.Now().Add(time.Second)
+	for calls.Load() < 3 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(20 * time.Millisecond) // time for a 4th call, if one could start
+	assert.Equal(t, calls.Load(), int32(3), "calls to fn started with n=3 and none finished")
+
+	start := time.Now()
+	cancel()
+	collect(t, out)
+	if took := time.Since(start); took > time.Second {
+		t.Errorf("OrderedMap took %v to close after cancel", took)
+	}
+}
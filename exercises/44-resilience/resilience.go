@@ -0,0 +1,270 @@
+//go:build !solutions
+
+package resilience
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// Exercise 44: Rate limiting and retries
+//
+// Calling another service means being polite when it's healthy and
+// careful when it isn't. Three patterns, which in Node you'd take from
+// bottleneck, p-retry and opossum:
+//
+//   - a rate limiter keeps you under the calls per second you're
+//     allowed, letting short bursts through
+//   - retrying with exponential backoff rides out a blip, waiting
+//     longer each time, and jitter stops every client retrying at the
+//     same instant
+//   - a circuit breaker stops calling a service that keeps failing, so
+//     it can recover, and so you fail fast instead of waiting on it
+//
+// All three are about time, so all three take a Clock, as in exercise
+// 12. The tests pass a fake one whose Sleep returns at once, having
+// moved its time on: a minute of backoff takes no time at all, and
+// comes out the same on every run.
+//
+// Run tests with: go test -v
+
+// Clock is the time these patterns see.
+type Clock interface {
+	Now() time.Time
+	// Sleep waits d, or returns ctx.Err() if ctx is done first.
+	Sleep(ctx context.Context, d time.Duration) error
+}
+
+// RealClock is the production Clock.
+type RealClock struct{}
+
+func (RealClock) Now() time.Time { return time.Now() }
+
+func (RealClock) Sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// 1. A token bucket
+// TokenBucket holds up to burst tokens, starts full, and refills at
+// perSecond tokens a second. Each call takes one token; with none left,
+// it must wait or be refused. So a caller can make burst calls at once,
+// then perSecond a second on average.
+//
+// Nothing needs to run in the background to refill it: store when the
+// tokens were last counted, and when asked, add perSecond times the
+// seconds since then, up to burst. Tokens are float64s: at 2 a second,
+// 100ms adds 0.2 of one.
+type TokenBucket struct {
+	mu        sync.Mutex
+	clock     Clock
+	perSecond float64
+	burst     float64
+	tokens    float64
+	last      time.Time
+}
+
+// NewTokenBucket returns a full bucket.
+func NewTokenBucket(c Clock, perSecond float64, burst int) *TokenBucket {
+	// TODO
+	return &TokenBucket{clock: c}
+}
+
+// Allow takes a token if there is one and reports whether it did.
+func (b *TokenBucket) Allow() bool {
+	// TODO: refill, then if b.tokens >= 1, take one
+	return false
+}
+
+// Wait takes a token, waiting for one if need be, or returns ctx.Err()
+// if ctx is done first; then the token goes back. Take it before
+// waiting, so tokens can go below zero: a second waiter then waits for
+// the token after the first one's, and they don't both wake up for the
+// same one. Don't hold the lock while sleeping.
+//
+// The wait for a bucket at -0.5 tokens is 1.5 tokens' worth:
+// time.Duration(1.5 / b.perSecond * float64(time.Second)).
+func (b *TokenBucket) Wait(ctx context.Context) error {
+	// TODO
+	return nil
+}
+
+// 2. golang.org/x/time/rate
+// rate.Limiter is the token bucket in the extended standard library.
+// Its Allow and Wait read time.Now() themselves, but AllowN and
+// ReserveN take the time as an argument, so a Limiter driven through
+// them works with any Clock.
+type Limiter struct {
+	clock Clock
+	lim   *rate.Limiter
+}
+
+// NewLimiter returns a Limiter allowing perSecond events a second, in
+// bursts of up to burst: rate.NewLimiter(rate.Limit(perSecond), burst).
+func NewLimiter(c Clock, perSecond float64, burst int) *Limiter {
+	// TODO
+	return &Limiter{clock: c}
+}
+
+// Allow is TokenBucket.Allow: lim.AllowN(now, 1).
+func (l *Limiter) Allow() bool {
+	// TODO
+	return false
+}
+
+// Wait is TokenBucket.Wait. r := lim.ReserveN(now, 1) takes a token
+// now, maybe one that won't exist until later: r.DelayFrom(now) says
+// how long to wait for it, if at all, and if the wait is cut short,
+// r.CancelAt(now) gives it back. If !r.OK(), the limiter can never
+// allow it: return an error.
+func (l *Limiter) Wait(ctx context.Context) error {
+	// TODO
+	return nil
+}
+
+// 3. Exponential backoff with jitter
+// Backoff says how long to wait before each retry: Initial before the
+// first, then Multiplier times as long each time, never more than Max.
+// With Initial 100ms, Multiplier 2 and Max 1s: 100ms, 200ms, 400ms,
+// 800ms, 1s, 1s, ...
+//
+// Then jitter: take off up to Jitter of it at random, so with Jitter
+// 0.5 a 400ms wait is anything from 200ms to 400ms. A thousand clients
+// that failed at the same moment then don't all retry at the same
+// moment too. Jitter 1 is "full jitter", anything from 0 up.
+type Backoff struct {
+	Initial    time.Duration
+	Multiplier float64
+	Max        time.Duration
+	Jitter     float64 // 0 to 1
+}
+
+// Delay returns the wait before retry number retry, counting from 0,
+// given rnd, a random number from 0 up to 1: the delay, less Jitter
+// times rnd times the delay. Compute in float64 and math.Pow; the
+// doubling overflows a Duration after about 60 retries, so cap it
+// before converting back.
+func (b Backoff) Delay(retry int, rnd float64) time.Duration {
+	// TODO
+	return 0
+}
+
+// permanentError marks an error that retrying won't fix.
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent wraps err so that Retrier.Do returns it at once, without
+// retrying: a 404, or a request the server says is invalid, won't
+// succeed the second time either. Permanent(nil) is nil.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err}
+}
+
+// 4. Retrying
+// Retrier calls a function until it succeeds, up to Attempts times in
+// all, waiting Backoff.Delay(i, Rand()) before retry i.
+type Retrier struct {
+	Attempts int
+	Backoff  Backoff
+	Clock    Clock
+	Rand     func() float64 // rand.Float64, or a fake in tests; nil for no jitter
+}
+
+// Do calls fn. If it fails, Do waits, and calls it again, until it
+// succeeds, or has been called Attempts times, and then returns its
+// last error, wrapped as "after 3 attempts: %w". fn is always called
+// at least once.
+//
+// It stops early, returning the error, if fn returns one wrapped by
+// Permanent, found with errors.As; and it returns ctx.Err() if ctx is
+// done, before an attempt or during a wait.
+func (r Retrier) Do(ctx context.Context, fn func(context.Context) error) error {
+	// TODO
+	return nil
+}
+
+// 5. A circuit breaker
+// State is where a Breaker's circuit is.
+type State int
+
+const (
+	Closed   State = iota // calls go through; failures are counted
+	Open                  // calls fail at once with ErrOpen
+	HalfOpen              // one trial call goes through, to see if it's better
+)
+
+func (s State) String() string {
+	switch s {
+	case Closed:
+		return "closed"
+	case Open:
+		return "open"
+	case HalfOpen:
+		return "half-open"
+	}
+	return fmt.Sprintf("State(%d)", int(s))
+}
+
+// ErrOpen is returned, without calling anything, while a Breaker is
+// open.
+var ErrOpen = errors.New("circuit breaker is open")
+
+// Breaker stops calling something that keeps failing. Closed, it
+// passes calls through and counts failures in a row; threshold of them
+// opens it. Open, it fails every call with ErrOpen for cooldown. Then
+// it's half-open: it lets one call through, a trial, while the rest
+// still get ErrOpen. If the trial succeeds, it closes and the count
+// starts again; if it fails, it opens for another cooldown.
+type Breaker struct {
+	mu        sync.Mutex
+	clock     Clock
+	threshold int
+	cooldown  time.Duration
+	state     State
+	failures  int       // in a row, while closed
+	openedAt  time.Time // when it last opened
+	trial     bool      // whether a trial call is running, while half-open
+}
+
+// NewBreaker returns a closed Breaker.
+func NewBreaker(c Clock, threshold int, cooldown time.Duration) *Breaker {
+	return &Breaker{clock: c, threshold: threshold, cooldown: cooldown}
+}
+
+// State returns the breaker's state: half-open once an open breaker's
+// cooldown is over, even before another call arrives.
+func (b *Breaker) State() State {
+	// TODO
+	return Closed
+}
+
+// Do calls fn if the breaker lets it, and returns its error, or
+// ErrOpen if it doesn't. Hold the lock to decide, and again to record
+// the result, but not while fn runs. A call let through while closed
+// that finishes while the breaker is open counts for nothing.
+func (b *Breaker) Do(fn func() error) error {
+	// TODO
+	return nil
+}
+
+// Keep imports used
+var _ = math.Pow
@@ -0,0 +1,402 @@
+package resilience
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/imgarylai/learn-go/internal/assert"
+)
+
+// fakeClock's Sleep returns at once, having moved the time on, unless
+// it's frozen, and records what it was asked to sleep.
+type fakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	frozen  bool   // Sleep doesn't move the time
+	onSleep func() // called at the start of each Sleep, if set
+	slept   []time.Duration
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Sleep(ctx context.Context, d time.Duration) error {
+	if c.onSleep != nil {
+		c.onSleep()
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.slept = append(c.slept, d)
+	if !c.frozen {
+		c.now = c.now.Add(d)
+	}
+	return nil
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func (c *fakeClock) Slept() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return fmt.Sprint(c.slept)
+}
+
+// limiter is what TokenBucket and Limiter both do, so one set of
+// tests covers both.
+type limiter interface {
+	Allow() bool
+	Wait(ctx context.Context) error
+}
+
+var limiters = map[string]func(c Clock, perSecond float64, burst int) limiter{
+	"TokenBucket": func(c Clock, perSecond float64, burst int) limiter { return NewTokenBucket(c, perSecond, burst) },
+	"Limiter":     func(c Clock, perSecond float64, burst int) limiter { return NewLimiter(c, perSecond, burst) },
+}
+
+func TestAllow(t *testing.T) {
+	for name, newLimiter := range limiters {
+		c := newFakeClock()
+		l := newLimiter(c, 2, 3)
+		var got []bool
+		allow := func(n int) string {
+			got = got[:0]
+			for range n {
+				got = append(got, l.Allow())
+			}
+			return fmt.Sprint(got)
+		}
+
+		assert.Equal(t, allow(4), "[true true true false]", "%s: 4 Allows with burst 3", name)
+		c.Advance(499 * time.Millisecond)
+		assert.Equal(t, allow(1), "[false]", "%s: Allow 499ms later, at 2 a second", name)
+		c.Advance(time.Millisecond)
+		assert.Equal(t, allow(2), "[true false]", "%s: 2 Allows 500ms later", name)
+		c.Advance(250 * time.Millisecond)
+		assert.Equal(t, allow(1), "[false]", "%s: Allow with half a token", name)
+		c.Advance(250 * time.Millisecond)
+		assert.Equal(t, allow(1), "[true]", "%s: Allow with a whole token", name)
+		c.Advance(time.Hour)
+		assert.Equal(t, allow(4), "[true true true false]", "%s: 4 Allows an hour later: the bucket holds 3 at most", name)
+	}
+}
+
+func TestWait(t *testing.T) {
+	for name, newLimiter := range limiters {
+		c := newFakeClock()
+		l := newLimiter(c, 2, 1)
+		for range 3 {
+			if err := l.Wait(context.Background()); err != nil {
+				t.Errorf("%s: Wait: %v", name, err)
+			}
+		}
+		assert.Equal(t, c.Slept(), "[500ms 500ms]", "%s: sleeps for 3 Waits with burst 1, at 2 a second", name)
+		assert.Equal(t, l.Allow(), false, "%s: Allow right after a Wait", name)
+
+		// Two waiters at once: the clock doesn't move while they wait,
+		// and the second waits for the token after the first's.
+		c = newFakeClock()
+		c.frozen = true
+		l = newLimiter(c, 2, 1)
+		l.Allow()
+		l.Wait(context.Background())
+		l.Wait(context.Background())
+		assert.Equal(t, c.Slept(), "[500ms 1s]", "%s: sleeps for 2 Waits on an empty bucket, without time passing", name)
+
+		// However short the wait, it still waits.
+		c = newFakeClock()
+		l = newLimiter(c, 1e9, 1)
+		l.Allow()
+		l.Wait(context.Background())
+		assert.Equal(t, c.Slept(), "[1ns]", "%s: sleeps for a Wait on an empty bucket, at 1e9 a second", name)
+	}
+}
+
+func TestWaitCanceled(t *testing.T) {
+	for name, newLimiter := range limiters {
+		c := newFakeClock()
+		l := newLimiter(c, 1, 1)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		if err := l.Wait(ctx); !errors.Is(err, context.Canceled) {
+			t.Errorf("%s: Wait with a canceled ctx: error %v; want context.Canceled", name, err)
+		}
+		assert.Equal(t, l.Allow(), true, "%s: Allow after a Wait that was canceled before it began", name)
+
+		// Canceled while waiting: the token it took goes back.
+		ctx, cancel = context.WithCancel(context.Background())
+		c.frozen = true
+		c.onSleep = cancel
+		if err := l.Wait(ctx); !errors.Is(err, context.Canceled) {
+			t.Errorf("%s: Wait canceled while sleeping: error %v; want context.Canceled", name, err)
+		}
+		c.onSleep = nil
+		c.Advance(time.Second)
+		assert.Equal(t, l.Allow(), true, "%s: Allow a second after a canceled Wait: it gave its token back", name)
+		assert.Equal(t, l.Allow(), false, "%s: a second Allow", name)
+	}
+}
+
+func TestLimiterNeverAllows(t *testing.T) {
+	l := NewLimiter(newFakeClock(), 10, 0)
+	if err := l.Wait(context.Background()); err == nil {
+		t.Error("Wait on a Limiter with burst 0: got no error; it can never allow an event")
+	}
+	assert.Equal(t, l.Allow(), false, "Allow with burst 0")
+}
+
+func TestBackoffDelay(t *testing.T) {
+	b := Backoff{Initial: 100 * time.Millisecond, Multiplier: 2, Max: time.Second}
+	var got []time.Duration
+	for i := range 7 {
+		got = append(got, b.Delay(i, 0.9))
+	}
+	assert.Equal(t, fmt.Sprint(got), "[100ms 200ms 400ms 800ms 1s 1s 1s]", "Delay(0..6) with no jitter")
+	assert.Equal(t, b.Delay(100, 0), time.Second, "Delay(100): doubling 100 times overflows a Duration")
+	assert.Equal(t, b.Delay(5000, 0), time.Second, "Delay(5000): math.Pow gives +Inf")
+
+	slower := Backoff{Initial: 100 * time.Millisecond, Multiplier: 1.5, Max: time.Minute}
+	assert.Equal(t, slower.Delay(2, 0), 225*time.Millisecond, "Delay(2) with Multiplier 1.5")
+
+	b.Jitter = 0.5
+	for _, tt := range []struct {
+		retry int
+		rnd   float64
+		want  time.Duration
+	}{
+		{2, 0, 400 * time.Millisecond},
+		{2, 0.5, 300 * time.Millisecond},
+		{2, 1, 200 * time.Millisecond},
+		{6, 0.25, 875 * time.Millisecond},
+	} {
+		assert.Equal(t, b.Delay(tt.retry, tt.rnd), tt.want, "Delay(%d, %v) with Jitter 0.5", tt.retry, tt.rnd)
+	}
+	b.Jitter = 1
+	assert.Equal(t, b.Delay(0, 0.25), 75*time.Millisecond, "Delay(0, 0.25) with full jitter")
+}
+
+// flaky returns a func that fails with err its first n calls, and
+// counts them all.
+func flaky(n int, err error, calls *int) func(context.Context) error {
+	return func(context.Context) error {
+		*calls++
+		if *calls <= n {
+			return err
+		}
+		return nil
+	}
+}
+
+var backoff = Backoff{Initial: 100 * time.Millisecond, Multiplier: 2, Max: time.Second, Jitter: 0.5}
+
+func TestRetrier(t *testing.T) {
+	errBusy := errors.New("503 service unavailable")
+
+	c := newFakeClock()
+	var calls int
+	err := Retrier{Attempts: 5, Backoff: backoff, Clock: c}.Do(context.Background(), flaky(2, errBusy, &calls))
+	if err != nil {
+		t.Errorf("Do of a func failing twice, with 5 attempts: %v", err)
+	}
+	assert.Equal(t, calls, 3, "calls of a func failing twice")
+	assert.Equal(t, c.Slept(), "[100ms 200ms]", "sleeps with no Rand: no jitter")
+
+	c = newFakeClock()
+	calls = 0
+	half := func() float64 { return 0.5 }
+	err = Retrier{Attempts: 4, Backoff: backoff, Clock: c, Rand: half}.Do(context.Background(), flaky(10, errBusy, &calls))
+	if !errors.Is(err, errBusy) {
+		t.Errorf("Do when every attempt fails: error %v; want it to wrap the last one", err)
+	} else {
+		assert.Equal(t, err.Error(), "after 4 attempts: 503 service unavailable", "Do's error when every attempt fails")
+	}
+	assert.Equal(t, calls, 4, "calls with 4 attempts, all failing")
+	assert.Equal(t, c.Slept(), "[75ms 150ms 300ms]", "sleeps between 4 attempts, with Jitter 0.5 and Rand 0.5")
+
+	c = newFakeClock()
+	calls = 0
+	if err := (Retrier{Attempts: 0, Backoff: backoff, Clock: c}).Do(context.Background(), flaky(10, errBusy, &calls)); err == nil {
+		t.Error("Do with Attempts 0 and a failing func: got no error")
+	}
+	assert.Equal(t, calls, 1, "calls with Attempts 0: always at least one")
+	assert.Equal(t, c.Slept(), "[]", "sleeps with Attempts 0")
+}
+
+func TestRetrierPermanent(t *testing.T) {
+	if Permanent(nil) != nil {
+		t.Error("Permanent(nil) != nil")
+	}
+	errNotFound := errors.New("404 not found")
+	c := newFakeClock()
+	var calls int
+	err := Retrier{Attempts: 5, Backoff: backoff, Clock: c}.Do(context.Background(), func(context.Context) error {
+		calls++
+		if calls == 2 {
+			return fmt.Errorf("get user: %w", Permanent(errNotFound))
+		}
+		return errors.New("timeout")
+	})
+	if !errors.Is(err, errNotFound) {
+		t.Errorf("Do with a permanent error: error %v; want it", err)
+	} else {
+		assert.Equal(t, err.Error(), "get user: 404 not found", "Do's permanent error, as it was returned")
+	}
+	assert.Equal(t, calls, 2, "calls when the second returns a permanent error")
+	assert.Equal(t, c.Slept(), "[100ms]", "sleeps before a permanent error")
+}
+
+func TestRetrierCanceled(t *testing.T) {
+	errBusy := errors.New("busy")
+	c := newFakeClock()
+	var calls int
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := (Retrier{Attempts: 3, Backoff: backoff, Clock: c}).Do(ctx, flaky(10, errBusy, &calls)); !errors.Is(err, context.Canceled) {
+		t.Errorf("Do with a canceled ctx: error %v; want context.Canceled", err)
+	}
+	assert.Equal(t, calls, 0, "calls with a ctx canceled before Do")
+
+	// Canceled during the first wait.
+	calls = 0
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	c.onSleep = cancel
+	if err := (Retrier{Attempts: 3, Backoff: backoff, Clock: c}).Do(ctx, flaky(10, errBusy, &calls)); !errors.Is(err, context.Canceled) {
+		t.Errorf("Do canceled while waiting: error %v; want context.Canceled", err)
+	}
+	assert.Equal(t, calls, 1, "calls when canceled during the first wait")
+
+	// fn gets Do's ctx.
+	type key struct{}
+	ctx = context.WithValue(context.Background(), key{}, "req-7")
+	var seen any
+	(Retrier{Attempts: 1, Clock: c}).Do(ctx, func(ctx context.Context) error {
+		seen = ctx.Value(key{})
+		return nil
+	})
+	assert.Equal(t, seen, any("req-7"), "ctx.Value seen by fn")
+}
+
+func TestBreaker(t *testing.T) {
+	c := newFakeClock()
+	b := NewBreaker(c, 3, 10*time.Second)
+	errDown := errors.New("connection refused")
+	var calls int
+	fail := func() error { calls++; return errDown }
+	ok := func() error { calls++; return nil }
+
+	assert.Equal(t, b.State(), Closed, "a new Breaker's State")
+	b.Do(fail)
+	b.Do(fail)
+	if err := b.Do(ok); err != nil {
+		t.Errorf("Do(ok) while closed: %v", err)
+	}
+	b.Do(fail)
+	b.Do(fail)
+	assert.Equal(t, b.State(), Closed, "State after fail, fail, ok, fail, fail: a success starts the count again")
+	if err := b.Do(fail); !errors.Is(err, errDown) {
+		t.Errorf("Do(fail): error %v; want fn's", err)
+	}
+	assert.Equal(t, b.State(), Open, "State after 3 failures in a row")
+
+	calls = 0
+	if err := b.Do(ok); !errors.Is(err, ErrOpen) {
+		t.Errorf("Do while open: error %v; want ErrOpen", err)
+	}
+	assert.Equal(t, calls, 0, "calls while open")
+	c.Advance(10*time.Second - time.Millisecond)
+	assert.Equal(t, b.State(), Open, "State just before the cooldown is over")
+	b.Do(ok)
+	assert.Equal(t, calls, 0, "calls just before the cooldown is over")
+	c.Advance(time.Millisecond)
+	assert.Equal(t, b.State(), HalfOpen, "State once the cooldown is over")
+
+	// A failed trial opens it again, for another cooldown.
+	if err := b.Do(fail); !errors.Is(err, errDown) {
+		t.Errorf("the trial call: error %v; want fn's", err)
+	}
+	assert.Equal(t, calls, 1, "calls once half-open")
+	assert.Equal(t, b.State(), Open, "State after a failed trial")
+	c.Advance(5 * time.Second)
+	assert.Equal(t, b.State(), Open, "State halfway through the second cooldown")
+	c.Advance(5 * time.Second)
+	assert.Equal(t, b.State(), HalfOpen, "State after the second cooldown")
+
+	// Only one trial at a time.
+	calls = 0
+	err := b.Do(func() error {
+		calls++
+		assert.Equal(t, b.State(), HalfOpen, "State during the trial")
+		if err := b.Do(ok); !errors.Is(err, ErrOpen) {
+			t.Errorf("Do during the trial call: error %v; want ErrOpen", err)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Errorf("a successful trial: %v", err)
+	}
+	assert.Equal(t, calls, 1, "calls during a trial")
+	assert.Equal(t, b.State(), Closed, "State after a successful trial")
+
+	// Closed again, with the count from zero.
+	b.Do(fail)
+	b.Do(fail)
+	assert.Equal(t, b.State(), Closed, "State after 2 failures, once closed again")
+	b.Do(fail)
+	assert.Equal(t, b.State(), Open, "State after 3")
+}
+
+func TestBreakerStaleResult(t *testing.T) {
+	c := newFakeClock()
+	b := NewBreaker(c, 2, time.Minute)
+	errDown := errors.New("down")
+	// A slow call starts while closed; meanwhile other calls fail and
+	// open the breaker; then the slow call succeeds.
+	b.Do(func() error {
+		b.Do(func() error { return errDown })
+		b.Do(func() error { return errDown })
+		return nil
+	})
+	assert.Equal(t, b.State(), Open, "State after a slow success that finished once the breaker had opened")
+
+}
+
+func TestBreakerConcurrent(t *testing.T) {
+	c := newFakeClock()
+	b := NewBreaker(c, 5, time.Second)
+	var wg sync.WaitGroup
+	for i := range 50 {
+		wg.Go(func() {
+			for range 100 {
+				b.Do(func() error {
+					if i%2 == 0 {
+						return errors.New("even")
+					}
+					return nil
+				})
+				b.State()
+			}
+		})
+	}
+	wg.Wait()
+	if s := b.State(); s != Closed && s != Open {
+		t.Errorf("State after concurrent calls = %v; want closed or open, with the clock stopped", s)
+	}
+}
@@ -0,0 +1,218 @@
+//go:build !solutions
+
+package caching
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+// Exercise 45: Caching
+//
+// A cache answers the second request for something without doing the
+// work again. Node apps reach for lru-cache, node-cache and the
+// in-flight-promise trick; this exercise builds all of them, safe for
+// many goroutines at once:
+//
+//   - an LRU cache, which holds a fixed number of entries and evicts
+//     the one used least recently to make room
+//   - a TTL cache, whose entries expire, and a sweeper that clears the
+//     expired ones out in the background
+//   - a single-flight Group: if ten goroutines ask for the same missing
+//     key at once, one loads it and the other nine wait for that
+//     result, instead of all ten hitting the database
+//   - Memoize, which puts a cache and a Group in front of a function
+//
+// Exercise 23 had an LRU cache to test; exercises 2 and 30 memoized
+// pure functions on one goroutine. Here the functions can fail, and
+// goroutines share everything.
+//
+// Run tests with: go test -v -race
+
+// Cache is what Memoize needs from a cache. LRU and TTLCache are both
+// Caches.
+type Cache[K comparable, V any] interface {
+	Get(key K) (V, bool)
+	Put(key K, value V)
+}
+
+// 1. An LRU cache
+// LRU keeps the entries in a container/list, most recently used at the
+// front, and a map from each key to its element, so a Get can find an
+// entry and move it to the front in O(1), and a Put can find the back
+// one to evict. Both change the list, so both need the whole lock.
+type LRU[K comparable, V any] struct {
+	mu       sync.Mutex
+	capacity int
+	order    *list.List // of *entry[K, V]; the front is the most recently used
+	items    map[K]*list.Element
+}
+
+type entry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+// NewLRU returns an empty LRU holding up to capacity entries. It panics
+// if capacity < 1.
+func NewLRU[K comparable, V any](capacity int) *LRU[K, V] {
+	if capacity < 1 {
+		panic("caching: LRU capacity must be at least 1")
+	}
+	return &LRU[K, V]{capacity: capacity, order: list.New(), items: map[K]*list.Element{}}
+}
+
+// Get returns key's value, and makes it the most recently used.
+func (c *LRU[K, V]) Get(key K) (V, bool) {
+	// TODO
+	var zero V
+	return zero, false
+}
+
+// Put sets key's value and makes it the most recently used. If that
+// takes the cache over capacity, it evicts the least recently used.
+func (c *LRU[K, V]) Put(key K, value V) {
+	// TODO
+}
+
+// Remove deletes key, and reports whether it was there.
+func (c *LRU[K, V]) Remove(key K) bool {
+	// TODO
+	return false
+}
+
+// Len returns how many entries the cache holds.
+func (c *LRU[K, V]) Len() int {
+	// TODO
+	return 0
+}
+
+// Keys returns the keys, most recently used first. It doesn't count as
+// using them.
+func (c *LRU[K, V]) Keys() []K {
+	// TODO
+	return nil
+}
+
+// 2. A TTL cache
+// TTLCache forgets each entry ttl after it was Put. It reads the time
+// from now, which is time.Now in production and a fake clock's Now in
+// tests.
+//
+// An expired entry is never returned, but it still takes up memory
+// until something deletes it: Get deletes the ones it finds, and Sweep
+// goes through them all.
+type TTLCache[K comparable, V any] struct {
+	mu    sync.Mutex
+	ttl   time.Duration
+	now   func() time.Time
+	items map[K]ttlEntry[V]
+}
+
+type ttlEntry[V any] struct {
+	value   V
+	expires time.Time
+}
+
+// NewTTLCache returns an empty TTLCache.
+func NewTTLCache[K comparable, V any](ttl time.Duration, now func() time.Time) *TTLCache[K, V] {
+	return &TTLCache[K, V]{ttl: ttl, now: now, items: map[K]ttlEntry[V]{}}
+}
+
+// Get returns key's value, unless it has expired: an entry Put at t
+// expires at t+ttl, and from then on Get deletes it and returns false.
+func (c *TTLCache[K, V]) Get(key K) (V, bool) {
+	// TODO
+	var zero V
+	return zero, false
+}
+
+// Put sets key's value, to expire ttl from now.
+func (c *TTLCache[K, V]) Put(key K, value V) {
+	// TODO
+}
+
+// Len returns how many entries the cache holds, counting expired ones
+// nothing has deleted yet.
+func (c *TTLCache[K, V]) Len() int {
+	// TODO
+	return 0
+}
+
+// Sweep deletes every expired entry, and returns how many it deleted.
+// Deleting from a map while ranging over it is allowed in Go.
+func (c *TTLCache[K, V]) Sweep() int {
+	// TODO
+	return 0
+}
+
+// 3. A background sweeper
+// RunSweeper calls Sweep on every tick, until ctx is done or ticks is
+// closed. Taking the ticks as a channel, rather than making a Ticker,
+// lets tests send them one at a time; StartSweeper, given, is the
+// version with a real time.Ticker.
+func (c *TTLCache[K, V]) RunSweeper(ctx context.Context, ticks <-chan time.Time) {
+	// TODO
+}
+
+// StartSweeper runs RunSweeper in a goroutine, ticking every every.
+// Call stop to end it: it returns once the goroutine has.
+func (c *TTLCache[K, V]) StartSweeper(every time.Duration) (stop func()) {
+	ctx, cancel := context.WithCancel(context.Background())
+	ticker := time.NewTicker(every)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		c.RunSweeper(ctx, ticker.C)
+	}()
+	return func() {
+		cancel()
+		ticker.Stop()
+		<-done
+	}
+}
+
+// 4. Single flight
+// Group dedupes concurrent calls by key, like
+// golang.org/x/sync/singleflight, or the JS trick of caching the
+// Promise rather than its result. It remembers nothing once a call is
+// over: it only stops the same work running twice at the same time.
+type Group[K comparable, V any] struct {
+	mu    sync.Mutex
+	calls map[K]*call[V] // the calls running now; make it on first use
+}
+
+// call is one run of fn, which every caller of Do for its key waits
+// on. Set val and err, then close done: once done is closed, anyone
+// can read them without a lock.
+type call[V any] struct {
+	done   chan struct{}
+	val    V
+	err    error
+	shared bool // whether another caller joined in; set under Group.mu
+}
+
+// Do calls fn and returns its results, unless a call for key is
+// already running; then it waits for that call and returns its results
+// instead. shared reports whether the results went to more than one
+// caller, the one whose fn ran included.
+//
+// Take the call out of the map once fn returns, so the next Do for key
+// calls fn again.
+func (g *Group[K, V]) Do(key K, fn func() (V, error)) (v V, err error, shared bool) {
+	// TODO
+	return v, err, false
+}
+
+// 5. Memoizing
+// Memoize returns a version of fn that gets each key's value from
+// cache, and only calls fn on a miss, through a Group so that
+// concurrent misses for one key make a single call. A value fn
+// returns goes in the cache; an error doesn't, so the next call tries
+// again.
+func Memoize[K comparable, V any](cache Cache[K, V], fn func(K) (V, error)) func(K) (V, error) {
+	// TODO
+	return fn
+}
@@ -0,0 +1,438 @@
+package caching
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/imgarylai/learn-go/internal/assert"
+	"go.uber.org/goleak"
+)
+
+// waitFor fails the test if ch isn't closed within a second.
+func waitFor(t *testing.T, ch <-chan struct{}, what string) bool {
+	t.Helper()
+	select {
+	case <-ch:
+		return true
+	case <-time.After(time.Second):
+		t.Errorf("timed out waiting for %s", what)
+		return false
+	}
+}
+
+func TestLRU(t *testing.T) {
+	c := NewLRU[string, int](3)
+	c.Put("a", 1)
+	c.Put("b", 2)
+	c.Put("c", 3)
+	assert.Equal(t, fmt.Sprint(c.Keys()), "[c b a]", "Keys after Put a, b, c")
+	v, ok := c.Get("a")
+	assert.Equal(t, v, 1, "Get(a)")
+	assert.Equal(t, ok, true, "Get(a) ok")
+	assert.Equal(t, fmt.Sprint(c.Keys()), "[a c b]", "Keys after Get(a)")
+
+	c.Put("d", 4)
+	assert.Equal(t, fmt.Sprint(c.Keys()), "[d a c]", "Keys after Put(d) on a full cache: b was least recently used")
+	if _, ok := c.Get("b"); ok {
+		t.Error("Get(b) after it was evicted: ok = true")
+	}
+	c.Put("c", 30)
+	assert.Equal(t, c.Len(), 3, "Len after updating c")
+	assert.Equal(t, fmt.Sprint(c.Keys()), "[c d a]", "Keys after updating c")
+	v, _ = c.Get("c")
+	assert.Equal(t, v, 30, "Get(c) after updating it")
+
+	assert.Equal(t, c.Remove("d"), true, "Remove(d)")
+	assert.Equal(t, c.Remove("d"), false, "Remove(d) again")
+	assert.Equal(t, c.Len(), 2, "Len after Remove")
+	c.Put("e", 5)
+	c.Put("f", 6)
+	assert.Equal(t, fmt.Sprint(c.Keys()), "[f e c]", "Keys after Remove(d), Put e, f")
+
+	one := NewLRU[int, string](1)
+	one.Put(1, "one")
+	one.Put(2, "two")
+	assert.Equal(t, fmt.Sprint(one.Keys()), "[2]", "Keys of an LRU with capacity 1")
+}
+
+func TestNewLRUPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("NewLRU(0) didn't panic")
+		}
+	}()
+	NewLRU[string, int](0)
+}
+
+func TestLRUConcurrent(t *testing.T) {
+	c := NewLRU[int, int](50)
+	var wg sync.WaitGroup
+	for g := range 20 {
+		wg.Go(func() {
+			for i := range 1000 {
+				k := (g*7 + i) % 100
+				if i%3 == 0 {
+					c.Put(k, i)
+				} else {
+					c.Get(k)
+				}
+				if i%100 == 0 {
+					c.Remove(k)
+					c.Keys()
+				}
+			}
+		})
+	}
+	wg.Wait()
+	keys := c.Keys()
+	assert.Equal(t, len(keys), c.Len(), "len(Keys()) after concurrent use, against Len")
+	if len(keys) > 50 {
+		t.Errorf("Len after concurrent use = %d; want at most the capacity, 50", len(keys))
+	}
+	seen := map[int]bool{}
+	for _, k := range keys {
+		if seen[k] {
+			t.Errorf("Keys has %d twice", k)
+		}
+		seen[k] = true
+		if _, ok := c.Get(k); !ok {
+			t.Errorf("Get(%d) of a key in Keys: ok = false", k)
+		}
+	}
+}
+
+// clock is a fake time for TTLCache: pass its Now.
+type clock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func newClock() *clock {
+	return &clock{now: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)}
+}
+
+func (c *clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *clock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func TestTTLCache(t *testing.T) {
+	clk := newClock()
+	c := NewTTLCache[string, int](time.Minute, clk.Now)
+	c.Put("a", 1)
+	clk.Advance(30 * time.Second)
+	c.Put("b", 2)
+	clk.Advance(29 * time.Second)
+	if v, ok := c.Get("a"); !ok || v != 1 {
+		t.Errorf("Get(a) 59s after Put = %d, %v; want 1, true", v, ok)
+	}
+	clk.Advance(time.Second)
+	if _, ok := c.Get("a"); ok {
+		t.Error("Get(a) a minute after Put: ok = true; it has expired")
+	}
+	assert.Equal(t, c.Len(), 1, "Len after Get found a expired: it deletes it")
+	if v, ok := c.Get("b"); !ok || v != 2 {
+		t.Errorf("Get(b) 30s after Put = %d, %v; want 2, true", v, ok)
+	}
+
+	c.Put("b", 20)
+	clk.Advance(45 * time.Second)
+	if v, ok := c.Get("b"); !ok || v != 20 {
+		t.Errorf("Get(b) 45s after Put again = %d, %v; want 20, true: Put starts the ttl again", v, ok)
+	}
+	if _, ok := c.Get("missing"); ok {
+		t.Error("Get(missing): ok = true")
+	}
+}
+
+func TestSweep(t *testing.T) {
+	clk := newClock()
+	c := NewTTLCache[int, string](10*time.Second, clk.Now)
+	for i := range 5 {
+		c.Put(i, fmt.Sprint(i))
+		clk.Advance(time.Second)
+	}
+	// Put at 0s, 1s, ... 4s; now 5s.
+	assert.Equal(t, c.Sweep(), 0, "Sweep before anything has expired")
+	clk.Advance(7 * time.Second)
+	assert.Equal(t, c.Sweep(), 3, "Sweep at 12s, of entries Put at 0s to 4s, ttl 10s")
+	assert.Equal(t, c.Len(), 2, "Len after Sweep")
+	assert.Equal(t, c.Sweep(), 0, "Sweep again")
+	if v, ok := c.Get(4); !ok || v != "4" {
+		t.Errorf("Get(4) after Sweep = %q, %v; want \"4\", true", v, ok)
+	}
+}
+
+func TestRunSweeper(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	clk := newClock()
+	c := NewTTLCache[string, int](time.Minute, clk.Now)
+	c.Put("a", 1)
+	clk.Advance(time.Minute)
+
+	ticks := make(chan time.Time)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		c.RunSweeper(context.Background(), ticks)
+	}()
+	tick := func() bool {
+		select {
+		case ticks <- time.Time{}:
+			return true
+		case <-done:
+			t.Error("RunSweeper returned before ticks was closed")
+		case <-time.After(time.Second):
+			t.Error("timed out sending RunSweeper a tick")
+		}
+		return false
+	}
+	// The second tick can't be received until the first one's Sweep is
+	// done.
+	if tick() && tick() {
+		assert.Equal(t, c.Len(), 0, "Len after a tick, with one expired entry")
+	}
+	close(ticks)
+	waitFor(t, done, "RunSweeper to return after ticks was closed")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done = make(chan struct{})
+	go func() {
+		defer close(done)
+		c.RunSweeper(ctx, make(chan time.Time))
+	}()
+	cancel()
+	waitFor(t, done, "RunSweeper to return after ctx was canceled")
+}
+
+func TestStartSweeper(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	c := NewTTLCache[string, int](time.Millisecond, time.Now)
+	c.Put("a", 1)
+	stop := c.StartSweeper(time.Millisecond)
+	defer stop()
+	for deadline := time.Now().Add(time.Second); c.Len() > 0; time.Sleep(time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Error("the sweeper hadn't deleted an expired entry after a second")
+			break
+		}
+	}
+}
+
+// blocking returns a fn for Group.Do that signals started, then waits
+// for release before returning v and err, and counts its calls.
+func blocking[V any](v V, err error, calls *atomic.Int32, started, release chan struct{}) func() (V, error) {
+	return func() (V, error) {
+		calls.Add(1)
+		close(started)
+		<-release
+		return v, err
+	}
+}
+
+// join starts n goroutines calling do, and returns a channel that's
+// closed once they have all returned. It sleeps a little first, so
+// they're all waiting inside do.
+func join(n int, do func()) <-chan struct{} {
+	var wg sync.WaitGroup
+	for range n {
+		wg.Go(do)
+	}
+	time.Sleep(20 * time.Millisecond)
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	return done
+}
+
+func TestGroup(t *testing.T) {
+	var g Group[string, int]
+	var calls, otherCalls atomic.Int32
+	started, release := make(chan struct{}), make(chan struct{})
+
+	type result struct {
+		v      int
+		err    error
+		shared bool
+	}
+	results := make(chan result, 10)
+	first := make(chan struct{})
+	go func() {
+		defer close(first)
+		v, err, shared := g.Do("user:1", blocking(42, nil, &calls, started, release))
+		results <- result{v, err, shared}
+	}()
+	if !waitFor(t, started, "the first Do to call fn") {
+		close(release)
+		return
+	}
+	done := join(9, func() {
+		v, err, shared := g.Do("user:1", func() (int, error) {
+			otherCalls.Add(1)
+			return -1, nil
+		})
+		results <- result{v, err, shared}
+	})
+
+	// Another key doesn't wait for user:1.
+	v, err, shared := g.Do("user:2", func() (int, error) { return 7, nil })
+	assert.Equal(t, v, 7, "Do(user:2) while user:1 runs")
+	assert.Equal(t, err, nil, "Do(user:2) error")
+	assert.Equal(t, shared, false, "Do(user:2) shared")
+
+	close(release)
+	waitFor(t, first, "the first call to return")
+	if !waitFor(t, done, "the 9 joining calls to return") {
+		return
+	}
+	close(results)
+	n := 0
+	for r := range results {
+		n++
+		if r.v != 42 || r.err != nil || !r.shared {
+			t.Errorf("a Do(user:1) = %d, %v, %v; want 42, <nil>, true", r.v, r.err, r.shared)
+		}
+	}
+	assert.Equal(t, n, 10, "Do(user:1) calls that returned")
+	assert.Equal(t, calls.Load(), int32(1), "calls of the first fn")
+	assert.Equal(t, otherCalls.Load(), int32(0), "calls of the fns that joined in")
+
+	// Once it's over, the next call runs fn again.
+	v, _, shared = g.Do("user:1", func() (int, error) { return 43, nil })
+	assert.Equal(t, v, 43, "Do(user:1) after the first call was over")
+	assert.Equal(t, shared, false, "Do(user:1) shared, after the first call was over")
+}
+
+func TestGroupError(t *testing.T) {
+	var g Group[string, int]
+	var calls atomic.Int32
+	started, release := make(chan struct{}), make(chan struct{})
+	errDB := errors.New("database is down")
+
+	errs := make(chan error, 5)
+	first := make(chan struct{})
+	go func() {
+		defer close(first)
+		_, err, _ := g.Do("k", blocking(0, errDB, &calls, started, release))
+		errs <- err
+	}()
+	if !waitFor(t, started, "the first Do to call fn") {
+		close(release)
+		return
+	}
+	done := join(4, func() {
+		_, err, _ := g.Do("k", func() (int, error) { return 0, nil })
+		errs <- err
+	})
+	close(release)
+	waitFor(t, first, "the first call to return")
+	if !waitFor(t, done, "the joining calls to return") {
+		return
+	}
+	close(errs)
+	for err := range errs {
+		if !errors.Is(err, errDB) {
+			t.Errorf("a Do returned error %v; want the shared call's", err)
+		}
+	}
+	assert.Equal(t, calls.Load(), int32(1), "calls of fn")
+}
+
+func TestMemoize(t *testing.T) {
+	var calls atomic.Int32
+	errNegative := errors.New("negative")
+	square := func(n int) (int, error) {
+		calls.Add(1)
+		if n < 0 {
+			return 0, errNegative
+		}
+		return n * n, nil
+	}
+	cache := NewLRU[int, int](2)
+	f := Memoize[int, int](cache, square)
+
+	for range 3 {
+		v, err := f(4)
+		if err != nil {
+			t.Errorf("f(4): %v", err)
+		}
+		assert.Equal(t, v, 16, "f(4)")
+	}
+	assert.Equal(t, calls.Load(), int32(1), "calls after f(4) three times")
+
+	for range 2 {
+		if _, err := f(-1); !errors.Is(err, errNegative) {
+			t.Errorf("f(-1): error %v; want fn's", err)
+		}
+	}
+	assert.Equal(t, calls.Load(), int32(3), "calls after f(-1) twice: errors aren't cached")
+	assert.Equal(t, fmt.Sprint(cache.Keys()), "[4]", "cache keys")
+
+	f(5)
+	f(6)
+	f(4)
+	assert.Equal(t, calls.Load(), int32(6), "calls after f(5), f(6), f(4), with room for 2")
+
+	clk := newClock()
+	g := Memoize[int, int](NewTTLCache[int, int](time.Minute, clk.Now), square)
+	calls.Store(0)
+	g(3)
+	clk.Advance(59 * time.Second)
+	g(3)
+	assert.Equal(t, calls.Load(), int32(1), "calls of a TTL-cached f(3) twice in a minute")
+	clk.Advance(time.Second)
+	g(3)
+	assert.Equal(t, calls.Load(), int32(2), "calls once the cached f(3) has expired")
+}
+
+func TestMemoizeConcurrent(t *testing.T) {
+	var calls atomic.Int32
+	started, release := make(chan struct{}), make(chan struct{})
+	var once sync.Once
+	f := Memoize[string, string](NewLRU[string, string](10), func(k string) (string, error) {
+		calls.Add(1)
+		once.Do(func() { close(started) })
+		<-release
+		return "profile of " + k, nil
+	})
+
+	got := make(chan string, 20)
+	first := make(chan struct{})
+	go func() {
+		defer close(first)
+		v, _ := f("ada")
+		got <- v
+	}()
+	if !waitFor(t, started, "the first call to call fn") {
+		close(release)
+		return
+	}
+	done := join(19, func() {
+		v, _ := f("ada")
+		got <- v
+	})
+	close(release)
+	waitFor(t, first, "the first call to return")
+	if !waitFor(t, done, "the concurrent calls to return") {
+		return
+	}
+	close(got)
+	for v := range got {
+		assert.Equal(t, v, "profile of ada", "a concurrent f(ada)")
+	}
+	assert.Equal(t, calls.Load(), int32(1), "calls of fn for 20 concurrent f(ada)")
+}
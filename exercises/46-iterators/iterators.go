@@ -0,0 +1,123 @@
+//go:build !solutions
+
+package iterators
+
+import (
+	"bufio"
+	"io"
+	"iter"
+)
+
+// Exercise 46: Iterators
+//
+// Since Go 1.23, for-range works over functions. An iter.Seq[V] is a
+// func(yield func(V) bool): it calls yield with each value in turn,
+// and the loop body runs once for each. When the body breaks or
+// returns, yield returns false, and the iterator must stop there and
+// return; calling yield again after that panics. It's a JS generator
+// function, function* () { yield v }, without the generator object.
+// An iter.Seq2[K, V] yields pairs, the way ranging over a map or a
+// slice gives two values.
+//
+// Exercise 29 wrote All methods; this one writes the functions that
+// work on any sequence. Like JS generators, and unlike array.filter
+// and array.map, they're lazy: nothing runs until something ranges
+// over the result, and only as far as the loop goes, so they can work
+// on a file bigger than memory, or a sequence that never ends.
+//
+// The slices and maps packages have some of these: slices.Values,
+// slices.Collect, slices.All, maps.Keys. Write them by hand here, and
+// use the library ones afterwards.
+//
+// Run tests with: go test -v
+
+// 1. Lines of a reader
+// Lines yields r's lines, without their line endings, reading only as
+// far as the loop asks for. Use a bufio.Scanner. A read error ends the
+// sequence, as if the reader had ended; LinesErr is the version that
+// says so.
+func Lines(r io.Reader) iter.Seq[string] {
+	// TODO
+	return func(yield func(string) bool) {}
+}
+
+// 2. Yielding an error
+// An iter.Seq can't return an error, but an iter.Seq2 can yield one:
+// LinesErr yields each line with a nil error, then, if the scanner
+// stopped on a read error, yields "" and the error. The caller checks
+// it in the loop:
+//
+//	for line, err := range LinesErr(r) {
+//		if err != nil { return err }
+//		...
+//	}
+func LinesErr(r io.Reader) iter.Seq2[string, error] {
+	// TODO
+	return func(yield func(string, error) bool) {}
+}
+
+// 3. Filter
+// Filter yields the values of seq for which keep returns true. It's
+// lazy: it calls keep on each value as the loop reaches it, not before.
+// Check what yield returns, and stop when it's false.
+func Filter[V any](seq iter.Seq[V], keep func(V) bool) iter.Seq[V] {
+	// TODO
+	return func(yield func(V) bool) {}
+}
+
+// 4. Map
+// Map yields f of each value of seq, calling f only as the loop
+// reaches each one.
+func Map[V, W any](seq iter.Seq[V], f func(V) W) iter.Seq[W] {
+	// TODO
+	return func(yield func(W) bool) {}
+}
+
+// 5. Take
+// Take yields the first n values of seq, or all of them if there are
+// fewer, and stops asking seq for more once it has n: so it can take
+// from a sequence that never ends, and asks for nothing if n <= 0.
+func Take[V any](seq iter.Seq[V], n int) iter.Seq[V] {
+	// TODO
+	return func(yield func(V) bool) {}
+}
+
+// 6. From a slice and back
+// Values yields the elements of s in order, like slices.Values.
+func Values[V any](s []V) iter.Seq[V] {
+	// TODO
+	return func(yield func(V) bool) {}
+}
+
+// Collect ranges over seq and returns its values in a new slice, like
+// slices.Collect: nil for an empty sequence.
+func Collect[V any](seq iter.Seq[V]) []V {
+	// TODO
+	return nil
+}
+
+// 7. Pairs
+// Enumerate yields each value of seq with its index, counting from 0,
+// as ranging over a slice does.
+func Enumerate[V any](seq iter.Seq[V]) iter.Seq2[int, V] {
+	// TODO
+	return func(yield func(int, V) bool) {}
+}
+
+// 8. Pulling
+// for-range pushes values at the loop body, one sequence at a time.
+// To walk two sequences side by side, pull from them instead:
+// next, stop := iter.Pull(seq) returns a next func that gives you the
+// sequence's values one at a time (v, ok), like a JS iterator's
+// next(). Always defer stop(): it ends a sequence you didn't read to
+// the end, which otherwise is left waiting.
+//
+// Zip yields pairs, one value from each sequence, until either runs
+// out.
+func Zip[A, B any](a iter.Seq[A], b iter.Seq[B]) iter.Seq2[A, B] {
+	// TODO
+	return func(yield func(A, B) bool) {}
+}
+
+// Keep imports used
+var _ = bufio.NewScanner
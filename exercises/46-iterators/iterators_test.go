@@ -0,0 +1,276 @@
+package iterators
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"strconv"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/imgarylai/learn-go/internal/assert"
+	"go.uber.org/goleak"
+)
+
+// takeN calls seq directly, without for-range, with a yield that
+// returns false once it has n values, and fails the test if seq calls
+// it again after that.
+func takeN[V any](t *testing.T, seq iter.Seq[V], n int) []V {
+	t.Helper()
+	var got []V
+	stopped := false
+	seq(func(v V) bool {
+		if stopped {
+			t.Errorf("yield called again after it returned false, with %v", v)
+			return false
+		}
+		got = append(got, v)
+		stopped = len(got) == n
+		return !stopped
+	})
+	return got
+}
+
+// naturals counts 0, 1, 2, ... forever, and records how many values it
+// yielded and whether it has returned.
+type naturals struct {
+	yielded int
+	done    bool
+}
+
+func (s *naturals) All() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		defer func() { s.done = true }()
+		for i := 0; ; i++ {
+			s.yielded++
+			if !yield(i) {
+				return
+			}
+		}
+	}
+}
+
+// countingReader counts the bytes read through it.
+type countingReader struct {
+	r io.Reader
+	n int
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += n
+	return n, err
+}
+
+// yes is an endless reader of "y\n", like the Unix command.
+type yes struct{}
+
+func (yes) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = "y\n"[i%2]
+	}
+	return len(p) &^ 1, nil
+}
+
+func TestLines(t *testing.T) {
+	for _, tt := range []struct {
+		in   string
+		want string
+	}{
+		{"one\ntwo\nthree\n", "[one two three]"},
+		{"one\r\ntwo", "[one two]"},
+		{"\n\n", "[ ]"},
+		{"", "[]"},
+	} {
+		got := fmt.Sprint(Collect(Lines(strings.NewReader(tt.in))))
+		assert.Equal(t, got, tt.want, "Lines(%q)", tt.in)
+	}
+
+	// One byte per Read, so it's clear how far Lines read.
+	r := &countingReader{r: iotest.OneByteReader(strings.NewReader("one\ntwo\nthree\n"))}
+	for line := range Lines(r) {
+		assert.Equal(t, line, "one", "the first line")
+		break
+	}
+	assert.Equal(t, r.n, len("one\n"), "bytes read for a loop that breaks after the first line")
+
+	got := takeN(t, Lines(strings.NewReader("a\nb\nc\n")), 2)
+	assert.Equal(t, fmt.Sprint(got), "[a b]", "Lines, stopped after 2")
+	got = Collect(Take(Lines(yes{}), 3))
+	assert.Equal(t, fmt.Sprint(got), "[y y y]", "3 lines of an endless reader")
+}
+
+func TestLinesErr(t *testing.T) {
+	errDisk := errors.New("disk on fire")
+	reader := func() io.Reader {
+		return io.MultiReader(strings.NewReader("a\nb\n"), iotest.ErrReader(errDisk))
+	}
+	assert.Equal(t, fmt.Sprint(Collect(Lines(reader()))), "[a b]", "Lines of a reader that fails after 2 lines")
+
+	var lines []string
+	var errs []error
+	for line, err := range LinesErr(reader()) {
+		lines = append(lines, line)
+		errs = append(errs, err)
+	}
+	assert.Equal(t, fmt.Sprintf("%q", lines), `["a" "b" ""]`, "LinesErr's lines, for a reader that fails after 2")
+	if len(errs) == 3 {
+		if errs[0] != nil || errs[1] != nil || !errors.Is(errs[2], errDisk) {
+			t.Errorf("LinesErr's errors = %v; want <nil>, <nil>, then the reader's", errs)
+		}
+	}
+
+	n := 0
+	for _, err := range LinesErr(strings.NewReader("a\nb\n")) {
+		if err != nil {
+			t.Errorf("LinesErr of a strings.Reader: error %v", err)
+		}
+		n++
+	}
+	assert.Equal(t, n, 2, "pairs LinesErr yields for 2 lines and no error")
+
+	// Breaking on the first line, the error is never yielded.
+	calls := 0
+	LinesErr(reader())(func(string, error) bool {
+		calls++
+		return false
+	})
+	assert.Equal(t, calls, 1, "yields after the first returned false")
+}
+
+func TestFilterMap(t *testing.T) {
+	even := func(n int) bool { return n%2 == 0 }
+	square := func(n int) int { return n * n }
+	ten := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+
+	got := Collect(Map(Filter(Values(ten), even), square))
+	assert.Equal(t, fmt.Sprint(got), "[4 16 36 64 100]", "the squares of the even numbers up to 10")
+	strs := Collect(Map(Values([]int{7, 42}), strconv.Itoa))
+	assert.Equal(t, fmt.Sprintf("%q", strs), `["7" "42"]`, "Map with strconv.Itoa")
+	assert.Equal(t, len(Collect(Filter(Values(ten), func(int) bool { return false }))), 0, "values when keep is always false")
+
+	var kept, mapped int
+	seq := Map(Filter(Values(ten), func(n int) bool { kept++; return even(n) }), func(n int) int { mapped++; return n })
+	assert.Equal(t, kept, 0, "keep calls before ranging: Filter is lazy")
+	assert.Equal(t, mapped, 0, "f calls before ranging: Map is lazy")
+	got = takeN(t, seq, 2)
+	assert.Equal(t, fmt.Sprint(got), "[2 4]", "the first 2 even numbers")
+	assert.Equal(t, kept, 4, "keep calls to find the first 2 even numbers")
+	assert.Equal(t, mapped, 2, "f calls for the first 2 even numbers")
+
+	var s naturals
+	got = takeN(t, Filter(s.All(), func(n int) bool { return n%3 == 0 }), 3)
+	assert.Equal(t, fmt.Sprint(got), "[0 3 6]", "multiples of 3, from an endless sequence")
+	assert.Equal(t, s.done, true, "the endless sequence returned, once the loop stopped")
+	s = naturals{}
+	got = takeN(t, Map(s.All(), square), 3)
+	assert.Equal(t, fmt.Sprint(got), "[0 1 4]", "squares, from an endless sequence")
+	assert.Equal(t, s.done, true, "the endless sequence returned, once the loop stopped")
+}
+
+func TestTake(t *testing.T) {
+	var s naturals
+	assert.Equal(t, fmt.Sprint(Collect(Take(s.All(), 5))), "[0 1 2 3 4]", "Take(naturals, 5)")
+	assert.Equal(t, s.yielded, 5, "values the sequence yielded for Take 5")
+	assert.Equal(t, s.done, true, "the sequence returned, after Take had 5")
+
+	for _, n := range []int{0, -1} {
+		s = naturals{}
+		assert.Equal(t, len(Collect(Take(s.All(), n))), 0, "values of Take(naturals, %d)", n)
+		assert.Equal(t, s.yielded, 0, "values the sequence yielded for Take %d", n)
+	}
+	assert.Equal(t, fmt.Sprint(Collect(Take(Values([]int{1, 2}), 5))), "[1 2]", "Take 5 of 2 values")
+	assert.Equal(t, fmt.Sprint(Collect(Take(Values([]int{1, 2}), 1))), "[1]", "Take 1 of 2 values")
+
+	s = naturals{}
+	got := takeN(t, Take(s.All(), 10), 3)
+	assert.Equal(t, fmt.Sprint(got), "[0 1 2]", "Take 10, stopped after 3")
+	assert.Equal(t, s.yielded, 3, "values yielded when the loop stopped first")
+}
+
+func TestValuesCollect(t *testing.T) {
+	if got := Collect(Values[string](nil)); got != nil {
+		t.Errorf("Collect(Values(nil)) = %#v; want nil", got)
+	}
+	s := []string{"a", "b", "c"}
+	got := Collect(Values(s))
+	assert.Equal(t, fmt.Sprint(got), "[a b c]", "Collect(Values(s))")
+	if len(got) > 0 {
+		got[0] = "changed"
+		assert.Equal(t, s[0], "a", "s[0] after changing the collected copy")
+	}
+	assert.Equal(t, fmt.Sprint(takeN(t, Values(s), 2)), "[a b]", "Values, stopped after 2")
+
+	n := 0
+	for v := range Values([]int{10, 20, 30}) {
+		n += v
+		if v == 20 {
+			break
+		}
+	}
+	assert.Equal(t, n, 30, "sum of Values up to a break at 20")
+}
+
+func TestEnumerate(t *testing.T) {
+	var got []string
+	for i, line := range Enumerate(Lines(strings.NewReader("a\nb\nc"))) {
+		got = append(got, fmt.Sprint(i, ":", line))
+	}
+	assert.Equal(t, fmt.Sprint(got), "[0:a 1:b 2:c]", "Enumerate(Lines)")
+
+	var s naturals
+	got = nil
+	for i, v := range Enumerate(Map(s.All(), func(n int) string { return strconv.Itoa(n * 10) })) {
+		if i == 2 {
+			break
+		}
+		got = append(got, fmt.Sprint(i, ":", v))
+	}
+	assert.Equal(t, fmt.Sprint(got), "[0:0 1:10]", "Enumerate of an endless sequence, up to a break")
+	assert.Equal(t, s.done, true, "the endless sequence returned, after the break")
+
+	calls := 0
+	Enumerate(Values([]int{1, 2, 3}))(func(int, int) bool {
+		calls++
+		return false
+	})
+	assert.Equal(t, calls, 1, "yields after the first returned false")
+}
+
+func TestZip(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	var got []string
+	for n, s := range Zip(Values([]int{1, 2, 3}), Values([]string{"a", "b"})) {
+		got = append(got, fmt.Sprint(n, s))
+	}
+	assert.Equal(t, fmt.Sprint(got), "[1a 2b]", "Zip of 3 ints and 2 strings")
+
+	var a naturals
+	got = nil
+	for n, s := range Zip(a.All(), Values([]string{"x", "y", "z"})) {
+		got = append(got, fmt.Sprint(n, s))
+	}
+	assert.Equal(t, fmt.Sprint(got), "[0x 1y 2z]", "Zip of an endless sequence and 3 strings")
+	assert.Equal(t, a.done, true, "the endless sequence returned, once the other ran out")
+
+	var b, c naturals
+	got = nil
+	for x, y := range Zip(b.All(), Map(c.All(), func(n int) int { return n * n })) {
+		if x == 3 {
+			break
+		}
+		got = append(got, fmt.Sprint(x, ":", y))
+	}
+	assert.Equal(t, fmt.Sprint(got), "[0:0 1:1 2:4]", "Zip of two endless sequences, up to a break")
+	assert.Equal(t, b.done, true, "the first endless sequence returned, after the break")
+	assert.Equal(t, c.done, true, "the second endless sequence returned, after the break")
+
+	calls := 0
+	Zip(Values([]int{1, 2}), Values([]int{3, 4}))(func(int, int) bool {
+		calls++
+		return false
+	})
+	assert.Equal(t, calls, 1, "yields after the first returned false")
+}
@@ -0,0 +1,173 @@
+//go:build !solutions
+
+package slicesmaps
+
+import (
+	"cmp"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// Exercise 47: The slices, maps and cmp packages
+//
+// Exercise 4 said Go has no map, filter or find, and had you write
+// the loops. That was true until generics: since Go 1.21 the standard
+// library has package slices, with most of what JS arrays have as
+// methods, package maps, and package cmp, for comparing. Each section
+// here redoes something from exercise 4, or something close, with
+// them, and its doc comment shows the loop it replaces.
+//
+// Most of these take a slice and return one. Watch which ones change
+// the slice you pass in, as slices.SortFunc and slices.Delete do, like
+// JS's arr.sort() and arr.splice(), and which give you a new one.
+//
+// Run tests with: go test -v
+
+// Person is exercise 4's.
+type Person struct {
+	Name string
+	Age  int
+}
+
+// 1. Contains and IndexFunc
+// Missing returns the names in want that aren't in have, in want's
+// order, or nil if there are none. The loop slices.Contains replaces:
+//
+//	for _, h := range have { if h == w { found = true; break } }
+//
+// In JS: want.filter(w => !have.includes(w))
+func Missing(have, want []string) []string {
+	// TODO
+	return nil
+}
+
+// FindByName is exercise 4's: a pointer to the first person named
+// name, or nil. slices.IndexFunc(people, f) returns the index of the
+// first element for which f is true, or -1; point into people at it,
+// not at a copy.
+//
+// In JS: people.find(p => p.name === name)
+func FindByName(people []Person, name string) *Person {
+	// TODO
+	return nil
+}
+
+// 2. SortFunc, cmp.Compare and cmp.Or
+// SortPeople sorts people in place, oldest first, and people of the
+// same age by name. cmp.Compare(a, b) is -1, 0 or +1, for any ordered
+// type; cmp.Or returns the first of its arguments that isn't zero, so
+// cmp.Or(byAge, byName) only compares names when the ages are equal:
+//
+//	slices.SortFunc(people, func(a, b Person) int { return cmp.Or(...) })
+//
+// Exercise 28 wrote compare funcs by hand, with if-else chains.
+func SortPeople(people []Person) {
+	// TODO
+}
+
+// 3. DeleteFunc
+// KeepGreaterThan is exercise 4's FilterGreaterThan: the numbers
+// greater than threshold, in order. slices.DeleteFunc(s, f) removes
+// the elements for which f is true, in place, and returns the shorter
+// slice, so the caller's nums would be overwritten: call it on a
+// slices.Clone. Return an empty slice, not nil, if none are left,
+// which DeleteFunc does anyway unless nums was nil.
+//
+// In JS: nums.filter(n => n > threshold)
+func KeepGreaterThan(nums []int, threshold int) []int {
+	// TODO
+	return nil
+}
+
+// 4. BinarySearch and Insert
+// InsertSorted adds v to sorted, a sorted slice, where it belongs, and
+// returns the result. slices.BinarySearch(s, v) returns where v is or
+// would go; slices.Insert(s, i, v) shifts s[i:] up one and puts v at
+// i, growing the array if it has to, like append. Like append, the
+// result may share sorted's array.
+//
+// In JS: arr.splice(i, 0, v), after finding i with a loop.
+func InsertSorted(sorted []int, v int) []int {
+	// TODO
+	return sorted
+}
+
+// 5. Index and Delete
+// RemoveFirst removes the first name equal to name and returns the
+// shorter slice, or names itself if there's none. slices.Delete(s, i,
+// j) removes s[i:j], shifting the rest down, in place, and zeroes the
+// elements past the new end, so nothing still holds what they held.
+//
+// In JS: arr.splice(arr.indexOf(name), 1), if it's there.
+func RemoveFirst(names []string, name string) []string {
+	// TODO
+	return names
+}
+
+// 6. maps.Values, Sorted and Compact
+// DistinctScores returns each score in scores once, highest first.
+// maps.Values(m) is an iterator over m's values, as in exercise 46;
+// slices.Sorted collects an iterator into a sorted slice; and
+// slices.Compact replaces each run of equal elements with one, so on
+// a sorted slice it removes all the duplicates. slices.Reverse does
+// what it says, in place.
+//
+// Exercise 4's Deduplicate used a map, to keep the order; Compact on
+// an unsorted slice only removes duplicates that are next to each
+// other.
+func DistinctScores(scores map[string]int) []int {
+	// TODO
+	return nil
+}
+
+// 7. maps.Keys and MaxFunc
+// TopScorer is exercise 4's GetTopScorer, with ties settled: there, a
+// tie went to whichever name the map's random order came to first.
+// Here it goes to the name first in alphabetical order. Collect the
+// keys with slices.Collect(maps.Keys(scores)), and pick with
+// slices.MaxFunc, comparing so that a name counts as bigger the higher
+// its score, or if the scores tie, the earlier it is in the alphabet.
+// MaxFunc panics on an empty slice; return "".
+func TopScorer(scores map[string]int) string {
+	// TODO
+	return ""
+}
+
+// Ranking returns the names in scores, highest score first, and ties
+// in alphabetical order: slices.SortedFunc(maps.Keys(scores), ...).
+func Ranking(scores map[string]int) []string {
+	// TODO
+	return nil
+}
+
+// 8. maps.Clone
+// WithBonus returns a copy of scores with bonus added to every score,
+// leaving scores as it was. A map is a reference, like a JS object, so
+// assigning it copies nothing; maps.Clone makes a shallow copy. It
+// returns nil for nil, and so should WithBonus.
+//
+// In JS: Object.fromEntries(Object.entries(scores).map(...))
+func WithBonus(scores map[string]int, bonus int) map[string]int {
+	// TODO
+	return scores
+}
+
+// 9. cmp.Or for defaults
+// DisplayName returns the first of nickname, name and email that isn't
+// "", or "anonymous" if they're all "": cmp.Or works on any comparable
+// type, and its zero is the empty string. It's JS's
+// nickname || name || email || "anonymous", with only "" counting as
+// false.
+func DisplayName(nickname, name, email string) string {
+	// TODO
+	return ""
+}
+
+// Keep imports used
+var (
+	_ = cmp.Compare[int]
+	_ = maps.Clone[map[string]int]
+	_ = slices.Contains[[]string]
+	_ = strings.Compare
+)
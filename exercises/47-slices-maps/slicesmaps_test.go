@@ -0,0 +1,165 @@
+package slicesmaps
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/imgarylai/learn-go/internal/assert"
+)
+
+func TestMissing(t *testing.T) {
+	for _, tt := range []struct {
+		have, want []string
+		missing    string
+	}{
+		{[]string{"eggs", "milk"}, []string{"milk", "bread", "eggs", "jam"}, "[bread jam]"},
+		{[]string{"eggs", "milk"}, []string{"milk"}, "[]"},
+		{nil, []string{"milk", "eggs"}, "[milk eggs]"},
+		{[]string{"eggs"}, nil, "[]"},
+	} {
+		got := Missing(tt.have, tt.want)
+		assert.Equal(t, fmt.Sprint(got), tt.missing, "Missing(%q, %q)", tt.have, tt.want)
+	}
+	if got := Missing([]string{"a"}, []string{"a"}); got != nil {
+		t.Errorf("Missing with nothing missing = %#v; want nil", got)
+	}
+}
+
+func TestFindByName(t *testing.T) {
+	people := []Person{{"Ada", 36}, {"Alan", 41}, {"Ada", 20}}
+	p := FindByName(people, "Ada")
+	if p == nil {
+		t.Fatal("FindByName(Ada) = nil")
+	}
+	assert.Equal(t, *p, Person{"Ada", 36}, "FindByName(Ada): the first Ada")
+	p.Age++
+	assert.Equal(t, people[0].Age, 37, "people[0].Age after changing it through FindByName's pointer")
+	if p := FindByName(people, "Grace"); p != nil {
+		t.Errorf("FindByName(Grace) = %v; want nil", *p)
+	}
+	if p := FindByName(nil, "Ada"); p != nil {
+		t.Errorf("FindByName(nil, Ada) = %v; want nil", *p)
+	}
+}
+
+func TestSortPeople(t *testing.T) {
+	people := []Person{{"Linus", 28}, {"Ada", 36}, {"Grace", 85}, {"Alan", 41}, {"Barbara", 36}, {"Aaron", 28}}
+	SortPeople(people)
+	assert.Equal(t, fmt.Sprint(people), "[{Grace 85} {Alan 41} {Ada 36} {Barbara 36} {Aaron 28} {Linus 28}]", "people after SortPeople")
+	SortPeople(nil)
+}
+
+func TestKeepGreaterThan(t *testing.T) {
+	nums := []int{5, 1, 8, 3, 9, 4}
+	got := KeepGreaterThan(nums, 4)
+	assert.Equal(t, fmt.Sprint(got), "[5 8 9]", "KeepGreaterThan(%v, 4)", nums)
+	assert.Equal(t, fmt.Sprint(nums), "[5 1 8 3 9 4]", "nums after KeepGreaterThan: it mustn't change them")
+	for _, in := range [][]int{{1, 2}, nil} {
+		got := KeepGreaterThan(in, 10)
+		if got == nil || len(got) != 0 {
+			t.Errorf("KeepGreaterThan(%v, 10) = %#v; want []int{}", in, got)
+		}
+	}
+}
+
+func TestInsertSorted(t *testing.T) {
+	for _, tt := range []struct {
+		v    int
+		want string
+	}{
+		{0, "[0 1 3 5]"},
+		{4, "[1 3 4 5]"},
+		{6, "[1 3 5 6]"},
+		{3, "[1 3 3 5]"},
+	} {
+		got := InsertSorted([]int{1, 3, 5}, tt.v)
+		assert.Equal(t, fmt.Sprint(got), tt.want, "InsertSorted([1 3 5], %d)", tt.v)
+	}
+	assert.Equal(t, fmt.Sprint(InsertSorted(nil, 7)), "[7]", "InsertSorted(nil, 7)")
+
+	r := rand.New(rand.NewPCG(47, 1))
+	var s, all []int
+	for range 200 {
+		v := r.IntN(50)
+		s = InsertSorted(s, v)
+		all = append(all, v)
+	}
+	slices.Sort(all)
+	assert.Equal(t, slices.Equal(s, all), true, "200 random InsertSorteds, against sorting them all")
+}
+
+func TestRemoveFirst(t *testing.T) {
+	got := RemoveFirst([]string{"a", "b", "a", "c"}, "a")
+	assert.Equal(t, fmt.Sprint(got), "[b a c]", "RemoveFirst([a b a c], a)")
+	got = RemoveFirst([]string{"a", "b"}, "z")
+	assert.Equal(t, fmt.Sprint(got), "[a b]", "RemoveFirst([a b], z)")
+	assert.Equal(t, len(RemoveFirst(nil, "a")), 0, "len(RemoveFirst(nil, a))")
+
+	names := []string{"x", "y", "z"}
+	got = RemoveFirst(names, "y")
+	assert.Equal(t, fmt.Sprint(got), "[x z]", "RemoveFirst([x y z], y)")
+	assert.Equal(t, fmt.Sprintf("%q", names), `["x" "z" ""]`, "the array under names, after RemoveFirst: shifted in place, the end zeroed")
+}
+
+func TestDistinctScores(t *testing.T) {
+	scores := map[string]int{"ada": 3, "alan": 5, "grace": 3, "linus": 1, "barbara": 5}
+	assert.Equal(t, fmt.Sprint(DistinctScores(scores)), "[5 3 1]", "DistinctScores(%v)", scores)
+	assert.Equal(t, len(DistinctScores(nil)), 0, "len(DistinctScores(nil))")
+}
+
+func TestTopScorer(t *testing.T) {
+	for _, tt := range []struct {
+		scores map[string]int
+		want   string
+	}{
+		{map[string]int{"ada": 3, "alan": 5, "grace": 4}, "alan"},
+		{map[string]int{"linus": 9, "grace": 9, "barbara": 2, "ken": 9}, "grace"},
+		{map[string]int{"solo": -1}, "solo"},
+		{nil, ""},
+	} {
+		// The map's order changes from run to run: try a few.
+		for range 5 {
+			assert.Equal(t, TopScorer(tt.scores), tt.want, "TopScorer(%v)", tt.scores)
+		}
+	}
+}
+
+func TestRanking(t *testing.T) {
+	scores := map[string]int{"ada": 3, "alan": 5, "grace": 3, "linus": 1, "barbara": 5}
+	for range 5 {
+		assert.Equal(t, fmt.Sprint(Ranking(scores)), "[alan barbara ada grace linus]", "Ranking(%v)", scores)
+	}
+	assert.Equal(t, len(Ranking(nil)), 0, "len(Ranking(nil))")
+}
+
+func TestWithBonus(t *testing.T) {
+	scores := map[string]int{"ada": 3, "alan": 5}
+	got := WithBonus(scores, 10)
+	assert.Equal(t, fmt.Sprint(got), "map[ada:13 alan:15]", "WithBonus(%v, 10)", scores)
+	assert.Equal(t, fmt.Sprint(scores), "map[ada:3 alan:5]", "scores after WithBonus: it mustn't change them")
+	if got != nil {
+		got["grace"] = 1
+		assert.Equal(t, len(scores), 2, "len(scores) after adding to WithBonus's map")
+	}
+	if got := WithBonus(nil, 1); got != nil {
+		t.Errorf("WithBonus(nil, 1) = %#v; want nil", got)
+	}
+}
+
+func TestDisplayName(t *testing.T) {
+	for _, tt := range []struct {
+		nickname, name, email string
+		want                  string
+	}{
+		{"ace", "Ada", "ada@example.com", "ace"},
+		{"", "Ada", "ada@example.com", "Ada"},
+		{"", "", "ada@example.com", "ada@example.com"},
+		{"", "", "", "anonymous"},
+		{" ", "Ada", "", " "},
+	} {
+		got := DisplayName(tt.nickname, tt.name, tt.email)
+		assert.Equal(t, got, tt.want, "DisplayName(%q, %q, %q)", tt.nickname, tt.name, tt.email)
+	}
+}
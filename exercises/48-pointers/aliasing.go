@@ -0,0 +1,55 @@
+//go:build !solutions
+
+package pointers
+
+// Exercise 48, part 2: Find the aliasing bugs
+//
+// Each func here compiles, looks right, and passes a quick test by
+// hand, but has a bug where two things share memory that shouldn't,
+// or a copy is changed where the original should have been. The tests
+// show what each should do. Find each bug and fix it, changing as
+// little as you can; each is a line or two.
+
+// 6. A buffer reused
+// Windows returns every run of size numbers in a row from nums, in
+// order: Windows([1 2 3 4], 2) is [[1 2] [2 3] [3 4]]. Changing one
+// window afterwards mustn't change another, or nums.
+func Windows(nums []int, size int) [][]int {
+	var windows [][]int
+	buf := make([]int, size)
+	for i := 0; i+size <= len(nums); i++ {
+		copy(buf, nums[i:i+size])
+		windows = append(windows, buf)
+	}
+	return windows
+}
+
+// 7. A shared prefix
+// WithSuffixes returns base with each suffix appended, one slice per
+// suffix: WithSuffixes([a b], x, y) is [[a b x] [a b y]]. base may
+// have spare capacity, as one built with append usually does.
+func WithSuffixes(base []string, suffixes ...string) [][]string {
+	var out [][]string
+	for _, s := range suffixes {
+		out = append(out, append(base, s))
+	}
+	return out
+}
+
+// User is someone with a name and an email.
+type User struct {
+	Name  string
+	Email string
+}
+
+// 8. A pointer to a copy
+// IndexByName returns a map from each user's name to that user, to
+// look users up by name and update them: a change through the map
+// should be a change to the user in users.
+func IndexByName(users []User) map[string]*User {
+	index := make(map[string]*User, len(users))
+	for _, u := range users {
+		index[u.Name] = &u
+	}
+	return index
+}
@@ -0,0 +1,55 @@
+package pointers
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/imgarylai/learn-go/internal/assert"
+)
+
+func TestWindows(t *testing.T) {
+	nums := []int{1, 2, 3, 4, 5}
+	got := Windows(nums, 2)
+	assert.Equal(t, fmt.Sprint(got), "[[1 2] [2 3] [3 4] [4 5]]", "Windows(%v, 2)", nums)
+	if len(got) == 4 {
+		got[0][0] = 100
+		assert.Equal(t, fmt.Sprint(got[1:]), "[[2 3] [3 4] [4 5]]", "the other windows, after changing the first")
+		assert.Equal(t, nums[0], 1, "nums[0] after changing the first window")
+	}
+	assert.Equal(t, fmt.Sprint(Windows(nums, 5)), "[[1 2 3 4 5]]", "Windows(%v, 5)", nums)
+	assert.Equal(t, len(Windows(nums, 6)), 0, "len(Windows(%v, 6))", nums)
+}
+
+func TestWithSuffixes(t *testing.T) {
+	got := WithSuffixes([]string{"a", "b"}, "x", "y")
+	assert.Equal(t, fmt.Sprint(got), "[[a b x] [a b y]]", "WithSuffixes([a b], x, y)")
+
+	// Built with append, so it has spare capacity.
+	var base []string
+	for _, s := range []string{"usr", "local", "bin"} {
+		base = append(base, s)
+	}
+	got = WithSuffixes(base, "go", "gofmt", "git")
+	assert.Equal(t, fmt.Sprint(got), "[[usr local bin go] [usr local bin gofmt] [usr local bin git]]", "WithSuffixes of a base with spare capacity")
+	assert.Equal(t, fmt.Sprint(base), "[usr local bin]", "base after WithSuffixes")
+	assert.Equal(t, fmt.Sprint(base[:cap(base)][len(base):]), fmt.Sprint(make([]string, cap(base)-len(base))), "base's spare capacity after WithSuffixes: nothing should write there")
+	assert.Equal(t, len(WithSuffixes(base)), 0, "len(WithSuffixes(base)) with no suffixes")
+}
+
+func TestIndexByName(t *testing.T) {
+	users := []User{{"ada", "ada@example.com"}, {"alan", "alan@example.com"}}
+	index := IndexByName(users)
+	assert.Equal(t, len(index), 2, "len(IndexByName(users))")
+	ada, alan := index["ada"], index["alan"]
+	if ada == nil || alan == nil {
+		t.Fatalf("IndexByName(users) = %v; want pointers for ada and alan", index)
+	}
+	assert.Equal(t, *ada, users[0], "*index[ada]")
+	assert.Equal(t, *alan, users[1], "*index[alan]")
+
+	ada.Email = "countess@example.com"
+	assert.Equal(t, users[0].Email, "countess@example.com", "users[0].Email after changing it through the index")
+	if ada == alan {
+		t.Error("index[ada] == index[alan]")
+	}
+}
@@ -0,0 +1,159 @@
+//go:build !solutions
+
+package pointers
+
+// Exercise 48: Pointers and value semantics
+//
+// In JS, numbers and strings are copied and objects are shared: pass
+// an object to a function and it can change your object. Go decides
+// by type instead, and the rule is simpler: everything is copied.
+// Assigning a struct, passing it, ranging over a slice of them, all
+// copy it. A pointer, *T, is how you share one instead: &v is v's
+// address, and *p is the value at it.
+//
+// Copying a struct is shallow, though, as exercise 11 showed: a slice
+// or map inside it is a small header pointing at data that both copies
+// share. Most surprises in Go come from one of the two, a copy you
+// didn't expect or sharing you didn't expect. Part 2, aliasing.go, is
+// three bugs of that kind to fix.
+//
+// Run tests with: go test -v
+
+// 1. Swap
+// Swap exchanges the values a and b point at. A func given two ints
+// gets copies, and can't change the caller's; given their addresses,
+// it can. JS can't write this for two numbers at all; it swaps array
+// elements instead. Go's a, b = b, a does both sides at once, no temp
+// needed.
+func Swap[T any](a, b *T) {
+	// TODO
+}
+
+// 2. Pointers as optional values
+// Ptr returns a pointer to a copy of v. &v of a parameter or local
+// variable is fine in Go: the compiler keeps v alive, on the heap,
+// for as long as the pointer is. It's how you fill a *string field
+// from a literal, since &"literal" isn't allowed.
+func Ptr[T any](v T) *T {
+	// TODO
+	return nil
+}
+
+// Deref returns *p, or def if p is nil: JS's p ?? def. Reading *p
+// when p is nil panics, with the Go version of "cannot read properties
+// of undefined".
+func Deref[T any](p *T, def T) T {
+	// TODO
+	return def
+}
+
+// 3. Methods on nil
+// A method with a pointer receiver can be called on a nil pointer: the
+// call is fine, and the method only panics if it reads through the
+// pointer. So a nil *Node can be the empty list, and methods can check
+// for it, without every caller having to.
+type Node struct {
+	Value int
+	Next  *Node
+}
+
+// List builds a list of vals, in order; nil if there are none.
+func List(vals ...int) *Node {
+	var head *Node
+	for i := len(vals) - 1; i >= 0; i-- {
+		head = &Node{vals[i], head}
+	}
+	return head
+}
+
+// Len returns how many nodes there are from n on: 0 for a nil n.
+func (n *Node) Len() int {
+	// TODO
+	return 0
+}
+
+// Sum returns the total of the values from n on: 0 for a nil n.
+func (n *Node) Sum() int {
+	// TODO
+	return 0
+}
+
+// 4. Pointer receivers and method sets
+// Counter counts. Value has a value receiver: it gets a copy of the
+// Counter, which is all it needs. Inc and Reset change the Counter, so
+// they need a pointer receiver; with a value receiver they'd change
+// the copy and the caller would never see it.
+//
+// Calling c.Inc() on a variable c is fine: Go takes &c for you. But
+// only *Counter has Inc and Reset in its method set, so only a
+// *Counter is a Resetter; passing a Counter where a Resetter is wanted
+// doesn't compile.
+type Counter struct {
+	n int
+}
+
+// Resetter is anything that can be reset.
+type Resetter interface {
+	Reset()
+}
+
+// The compiler checks this line: it's the usual way to say, and
+// check, that a type implements an interface.
+var _ Resetter = (*Counter)(nil)
+
+// Value returns the count.
+func (c Counter) Value() int { return c.n }
+
+// Inc adds one to the count.
+func (c *Counter) Inc() {
+	// TODO
+}
+
+// Reset sets the count back to zero.
+func (c *Counter) Reset() {
+	// TODO
+}
+
+// IncrementAll increments every counter in counters. for _, c := range
+// counters gives you a copy of each; counters[i] is the one in the
+// slice.
+func IncrementAll(counters []Counter) {
+	// TODO
+}
+
+// ResetAll resets each of rs.
+func ResetAll(rs ...Resetter) {
+	// TODO
+}
+
+// 5. Copying a struct with a slice and a map inside
+// Team has value semantics on the outside: WithMember and WithScore
+// have value receivers and return a changed copy, leaving the team
+// they were called on as it was, like an immutable update in JS,
+// {...team, members: [...team.members, name]}.
+//
+// t2 := t copies Members and Scores, but only their headers: t2's
+// slice has the same backing array, and the map is the same map. So
+// append to the copy's Members can write into the original's array,
+// if it has spare capacity, and writing to the copy's Scores writes to
+// the original's. Make new ones: slices.Clone and maps.Clone, as in
+// exercise 47.
+type Team struct {
+	Name    string
+	Members []string
+	Scores  map[string]int
+}
+
+// WithMember returns a copy of t with name added to the end of its
+// Members.
+func (t Team) WithMember(name string) Team {
+	// TODO
+	return t
+}
+
+// WithScore returns a copy of t with Scores[name] set to score. Its
+// Scores is never nil, even if t's is.
+func (t Team) WithScore(name string, score int) Team {
+	// TODO
+	return t
+}
@@ -0,0 +1,136 @@
+package pointers
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/imgarylai/learn-go/internal/assert"
+)
+
+func TestSwap(t *testing.T) {
+	a, b := 1, 2
+	Swap(&a, &b)
+	assert.Equal(t, fmt.Sprint(a, b), "2 1", "a, b after Swap(&a, &b)")
+	Swap(&a, &a)
+	assert.Equal(t, a, 2, "a after Swap(&a, &a)")
+
+	s, u := "left", "right"
+	Swap(&s, &u)
+	assert.Equal(t, s+" "+u, "right left", "strings after Swap")
+
+	nums := []int{10, 20, 30}
+	Swap(&nums[0], &nums[2])
+	assert.Equal(t, fmt.Sprint(nums), "[30 20 10]", "nums after Swap(&nums[0], &nums[2])")
+}
+
+func TestPtrDeref(t *testing.T) {
+	v := 7
+	p := Ptr(v)
+	if p == nil {
+		t.Fatal("Ptr(7) = nil")
+	}
+	assert.Equal(t, *p, 7, "*Ptr(7)")
+	*p = 8
+	assert.Equal(t, v, 7, "v after changing *Ptr(v): it points at a copy")
+	if Ptr(1) == Ptr(1) {
+		t.Error("Ptr(1) == Ptr(1): two calls returned the same pointer")
+	}
+
+	assert.Equal(t, Deref(p, 0), 8, "Deref of a pointer to 8")
+	assert.Equal(t, Deref(nil, 42), 42, "Deref(nil, 42)")
+	assert.Equal(t, Deref(Ptr(""), "default"), "", "Deref of a pointer to \"\": only nil means missing")
+	var name *string
+	assert.Equal(t, Deref(name, "anonymous"), "anonymous", "Deref of a nil *string")
+}
+
+func TestNilReceiver(t *testing.T) {
+	var empty *Node
+	assert.Equal(t, empty.Len(), 0, "Len of a nil *Node")
+	assert.Equal(t, empty.Sum(), 0, "Sum of a nil *Node")
+
+	l := List(3, 4, 5)
+	assert.Equal(t, l.Len(), 3, "List(3, 4, 5).Len()")
+	assert.Equal(t, l.Sum(), 12, "List(3, 4, 5).Sum()")
+	assert.Equal(t, l.Next.Len(), 2, "Len from the second node")
+	assert.Equal(t, l.Next.Next.Sum(), 5, "Sum from the last node")
+	assert.Equal(t, List(-1).Sum(), -1, "List(-1).Sum()")
+}
+
+func TestCounter(t *testing.T) {
+	var c Counter
+	c.Inc()
+	c.Inc()
+	assert.Equal(t, c.Value(), 2, "Value after Inc twice on a variable")
+	p := &c
+	p.Inc()
+	assert.Equal(t, c.Value(), 3, "c.Value() after Inc through a pointer to it")
+
+	d := c
+	d.Inc()
+	assert.Equal(t, c.Value(), 3, "c.Value() after Inc on a copy of it")
+	assert.Equal(t, d.Value(), 4, "the copy's Value")
+	c.Reset()
+	assert.Equal(t, c.Value(), 0, "Value after Reset")
+}
+
+func TestIncrementAll(t *testing.T) {
+	counters := make([]Counter, 3)
+	counters[1].Inc()
+	IncrementAll(counters)
+	IncrementAll(counters)
+	got := []int{counters[0].Value(), counters[1].Value(), counters[2].Value()}
+	assert.Equal(t, fmt.Sprint(got), "[2 3 2]", "values after IncrementAll twice")
+	IncrementAll(nil)
+}
+
+func TestResetAll(t *testing.T) {
+	var a, b Counter
+	a.Inc()
+	b.Inc()
+	b.Inc()
+	ResetAll(&a, &b)
+	assert.Equal(t, fmt.Sprint(a.Value(), b.Value()), "0 0", "values after ResetAll(&a, &b)")
+
+	var n resetCount
+	ResetAll(&n, &n, &a)
+	assert.Equal(t, int(n), 2, "Resets of a value passed twice")
+	ResetAll()
+}
+
+// resetCount is another Resetter: it counts Resets.
+type resetCount int
+
+func (r *resetCount) Reset() { *r++ }
+
+func TestWithMember(t *testing.T) {
+	members := make([]string, 2, 10) // room to append without moving
+	members[0], members[1] = "ada", "alan"
+	base := Team{Name: "core", Members: members}
+
+	t1 := base.WithMember("grace")
+	t2 := base.WithMember("linus")
+	assert.Equal(t, fmt.Sprint(t1.Members), "[ada alan grace]", "base.WithMember(grace).Members")
+	assert.Equal(t, fmt.Sprint(t2.Members), "[ada alan linus]", "base.WithMember(linus).Members, after making both")
+	assert.Equal(t, fmt.Sprint(base.Members), "[ada alan]", "base.Members after WithMember")
+	assert.Equal(t, t1.Name, "core", "the copy's Name")
+
+	t1.Members[0] = "changed"
+	assert.Equal(t, base.Members[0], "ada", "base.Members[0] after changing the copy's")
+	assert.Equal(t, fmt.Sprint(Team{}.WithMember("solo").Members), "[solo]", "WithMember on a Team with no Members")
+}
+
+func TestWithScore(t *testing.T) {
+	base := Team{Name: "core", Scores: map[string]int{"ada": 3}}
+	t1 := base.WithScore("alan", 5)
+	t2 := base.WithScore("ada", 9)
+	assert.Equal(t, fmt.Sprint(t1.Scores), "map[ada:3 alan:5]", "base.WithScore(alan, 5).Scores")
+	assert.Equal(t, fmt.Sprint(t2.Scores), "map[ada:9]", "base.WithScore(ada, 9).Scores, after making both")
+	assert.Equal(t, fmt.Sprint(base.Scores), "map[ada:3]", "base.Scores after WithScore")
+
+	empty := Team{Name: "new"}
+	got := empty.WithScore("ada", 1)
+	assert.Equal(t, fmt.Sprint(got.Scores), "map[ada:1]", "WithScore on a Team with nil Scores")
+	if empty.Scores != nil {
+		t.Errorf("Scores of the Team WithScore was called on = %v; want it still nil", empty.Scores)
+	}
+}
@@ -0,0 +1,72 @@
+package optimization
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Benchmarks: `learngo bench 49` runs these, and `learngo bench --check 49`
+// compares them with testdata/bench-baseline.json, recorded from the
+// reference solution. WriteLines and CountByte work on a real file
+// here, where every Write and Read is a system call.
+
+func BenchmarkRenderTable(b *testing.B) {
+	rows := testRows(1000)
+	b.ReportAllocs()
+	for b.Loop() {
+		RenderTable(rows)
+	}
+}
+
+func BenchmarkDistinctWords(b *testing.B) {
+	lines := testLines(1000)
+	b.ReportAllocs()
+	for b.Loop() {
+		DistinctWords(lines)
+	}
+}
+
+// benchFile opens a new file in a temporary directory, which is
+// removed after the benchmark.
+func benchFile(b *testing.B) *os.File {
+	b.Helper()
+	f, err := os.Create(filepath.Join(b.TempDir(), "bench.txt"))
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.Cleanup(func() { f.Close() })
+	return f
+}
+
+func BenchmarkWriteLines(b *testing.B) {
+	lines := testLines(1000)
+	f := benchFile(b)
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, err := f.Seek(0, io.SeekStart); err != nil {
+			b.Fatal(err)
+		}
+		if err := WriteLines(f, lines); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkCountByte(b *testing.B) {
+	f := benchFile(b)
+	if _, err := f.WriteString(strings.Repeat("one line\nand another\n\n", 2000)); err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, err := f.Seek(0, io.SeekStart); err != nil {
+			b.Fatal(err)
+		}
+		if _, err := CountByte(f, '\n'); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
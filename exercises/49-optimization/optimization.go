@@ -0,0 +1,125 @@
+//go:build !solutions
+
+package optimization
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Exercise 49: Benchmarking and optimization
+//
+// Every func in this file already works: the tests that check what
+// they return pass from the start. They're slow, though, each in one
+// of the ways Go code most often is, and the exercise is to make them
+// fast without changing what they return:
+//
+//   - building a string with += copies everything built so far, every
+//     time, as it would in JS without an engine's rope tricks
+//   - making a map, or any buffer, in a loop allocates every time round
+//   - an io.Reader or io.Writer that's a file or a socket makes a
+//     system call for every Read or Write, however small
+//
+// Measure first. The benchmarks in bench_test.go call b.ReportAllocs,
+// so they show the allocations along with the time:
+//
+//	go test -bench . -benchmem
+//	learngo bench 49           # and again after a change, to compare
+//	learngo bench --check 49   # against the reference solution's numbers
+//
+// The tests hold each func to a budget of allocations, or of Reads and
+// Writes, that the slow versions are far over; bench --check holds
+// them to the reference solution's ns/op and allocs/op too, scaled to
+// your machine's speed.
+//
+// Run tests with: go test -v
+
+// 1. Building a string
+// Row is one line of a report.
+type Row struct {
+	Name  string
+	Count int
+}
+
+// RenderTable returns one "name: count" line for each row.
+//
+// Budget: 1 allocation, for the string itself. Write into a
+// strings.Builder, after Grow(n) with n at least the final length:
+// each row takes len(Name), plus 3 for ": " and "\n", plus at most 20
+// for the number. strconv.Itoa allocates a new string for any number
+// over 99; strconv.AppendInt(buf[:0], n, 10) writes the digits into a
+// byte array of your own, which can live on the stack.
+func RenderTable(rows []Row) string {
+	s := ""
+	for _, r := range rows {
+		s += r.Name + ": " + strconv.Itoa(r.Count) + "\n"
+	}
+	return s
+}
+
+// 2. Allocating in a loop
+// DistinctWords returns, for each line, how many different words it
+// has, splitting on white space like strings.Fields.
+//
+// Budget: 16 allocations for 1,000 lines. The result's length is known
+// up front, so make it once. Make one map before the loop, and
+// clear(m) it for each line: clear keeps the memory the map has grown
+// into. And strings.FieldsSeq yields the words one at a time, without
+// a []string to hold them.
+func DistinctWords(lines []string) []int {
+	var counts []int
+	for _, line := range lines {
+		seen := map[string]bool{}
+		for _, w := range strings.Fields(line) {
+			seen[w] = true
+		}
+		counts = append(counts, len(seen))
+	}
+	return counts
+}
+
+// 3. Unbuffered writes
+// WriteLines writes each line to w, followed by "\n", and returns the
+// first error.
+//
+// Budget: one Write to w per 4,096 bytes, rounded up, and 2
+// allocations. A bufio.Writer collects what you write in a 4KB buffer
+// and passes it on to w only when it's full, and when you call Flush,
+// which you must, and whose error you must return. It also remembers
+// the first error a Write returned, so you can check only the Flush.
+func WriteLines(w io.Writer, lines []string) error {
+	for _, line := range lines {
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// 4. Unbuffered reads
+// CountByte returns how many times c occurs in what r has to read,
+// and the first error other than io.EOF.
+//
+// Budget: one Read of r per 1,024 bytes, plus 2, and 2 allocations.
+// Read into a buffer of 32KB, like io.Copy's, and count each chunk
+// with bytes.Count, which is much faster than a loop of your own.
+// Count the n bytes a Read returns before looking at its error: a
+// Read can return data and io.EOF together.
+func CountByte(r io.Reader, c byte) (int, error) {
+	count := 0
+	buf := make([]byte, 1)
+	for {
+		n, err := r.Read(buf)
+		if n == 1 && buf[0] == c {
+			count++
+		}
+		if err == io.EOF {
+			return count, nil
+		}
+		if err != nil {
+			return count, err
+		}
+	}
+}
@@ -0,0 +1,238 @@
+package optimization
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/imgarylai/learn-go/internal/assert"
+)
+
+// The Test*Budget tests hold each func to the budget in its doc
+// comment; the others check only what it returns, and pass before any
+// optimizing.
+
+func testRows(n int) []Row {
+	rows := make([]Row, n)
+	for i := range rows {
+		rows[i] = Row{Name: fmt.Sprintf("item-%d", i), Count: i * i * 37}
+	}
+	return rows
+}
+
+func TestRenderTable(t *testing.T) {
+	rows := []Row{{"apples", 3}, {"pears", 0}, {"debt", -120}, {"", 7}}
+	assert.Equal(t, RenderTable(rows), "apples: 3\npears: 0\ndebt: -120\n: 7\n", "RenderTable(%v)", rows)
+	assert.Equal(t, RenderTable(nil), "", "RenderTable(nil)")
+
+	extremes := []Row{{"min", math.MinInt64}, {"max", math.MaxInt64}}
+	assert.Equal(t, RenderTable(extremes), "min: -9223372036854775808\nmax: 9223372036854775807\n", "RenderTable(%v)", extremes)
+
+	var want strings.Builder
+	rows = testRows(1000)
+	for _, r := range rows {
+		fmt.Fprintf(&want, "%s: %d\n", r.Name, r.Count)
+	}
+	assert.Equal(t, RenderTable(rows), want.String(), "RenderTable of 1,000 rows")
+}
+
+func TestRenderTableBudget(t *testing.T) {
+	rows := testRows(1000)
+	if allocs := testing.AllocsPerRun(20, func() { RenderTable(rows) }); allocs > 1 {
+		t.Errorf("RenderTable of 1,000 rows made %.0f allocations; want 1", allocs)
+	}
+	// The longest numbers there are: a Grow that allows less than 20
+	// bytes for each has to grow again.
+	rows = make([]Row, 1000)
+	for i := range rows {
+		rows[i] = Row{Name: "x", Count: math.MinInt64}
+	}
+	if allocs := testing.AllocsPerRun(20, func() { RenderTable(rows) }); allocs > 1 {
+		t.Errorf("RenderTable of 1,000 rows of math.MinInt64 made %.0f allocations; want 1", allocs)
+	}
+}
+
+func testLines(n int) []string {
+	words := strings.Fields("the quick brown fox jumps over the lazy dog and the cat naps in the sun")
+	lines := make([]string, n)
+	for i := range lines {
+		var sb strings.Builder
+		for j := range i % 16 {
+			if j > 0 {
+				sb.WriteString(" ")
+			}
+			sb.WriteString(words[(i+j*j)%len(words)])
+		}
+		lines[i] = sb.String()
+	}
+	return lines
+}
+
+func TestDistinctWords(t *testing.T) {
+	lines := []string{"the cat and the hat", "", "  spaces\tand\ttabs  and\nnewlines ", "a a a a", "Go go GO"}
+	assert.Equal(t, DistinctWords(lines), []int{4, 0, 4, 1, 3}, "DistinctWords(%q)", lines)
+	assert.Equal(t, len(DistinctWords(nil)), 0, "len(DistinctWords(nil))")
+
+	lines = testLines(1000)
+	got := DistinctWords(lines)
+	if !assert.Equal(t, len(got), len(lines), "len(DistinctWords) of 1,000 lines") {
+		return
+	}
+	for i, line := range lines {
+		seen := map[string]bool{}
+		for _, w := range strings.Fields(line) {
+			seen[w] = true
+		}
+		if got[i] != len(seen) {
+			t.Errorf("DistinctWords: line %d, %q, has %d different words; got %d", i, line, len(seen), got[i])
+			break
+		}
+	}
+}
+
+func TestDistinctWordsBudget(t *testing.T) {
+	lines := testLines(1000)
+	if allocs := testing.AllocsPerRun(20, func() { DistinctWords(lines) }); allocs > 16 {
+		t.Errorf("DistinctWords of 1,000 lines made %.0f allocations; want at most 16", allocs)
+	}
+}
+
+// countingWriter counts the Writes it gets, and keeps what they wrote.
+type countingWriter struct {
+	strings.Builder
+	writes int
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.writes++
+	return w.Builder.Write(p)
+}
+
+// failingWriter fails every Write after the first ok ones.
+type failingWriter struct {
+	ok  int
+	err error
+}
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	if w.ok == 0 {
+		return 0, w.err
+	}
+	w.ok--
+	return len(p), nil
+}
+
+func TestWriteLines(t *testing.T) {
+	var w countingWriter
+	if err := WriteLines(&w, []string{"one", "", "three"}); err != nil {
+		t.Fatalf("WriteLines: %v", err)
+	}
+	assert.Equal(t, w.String(), "one\n\nthree\n", "what WriteLines([one  three]) wrote")
+
+	w.Reset()
+	if err := WriteLines(&w, nil); err != nil {
+		t.Fatalf("WriteLines(nil): %v", err)
+	}
+	assert.Equal(t, w.String(), "", "what WriteLines(nil) wrote")
+
+	w.Reset()
+	lines := testLines(1000)
+	if err := WriteLines(&w, lines); err != nil {
+		t.Fatalf("WriteLines: %v", err)
+	}
+	assert.Equal(t, w.String(), strings.Join(lines, "\n")+"\n", "what WriteLines of 1,000 lines wrote")
+
+	errFull := errors.New("disk full")
+	for _, ok := range []int{0, 1} {
+		err := WriteLines(&failingWriter{ok: ok, err: errFull}, lines)
+		if !errors.Is(err, errFull) {
+			t.Errorf("WriteLines to a writer that fails after %d Writes = %v; want %v", ok, err, errFull)
+		}
+	}
+	if err := WriteLines(&failingWriter{err: errFull}, []string{"short"}); !errors.Is(err, errFull) {
+		t.Errorf("WriteLines of one short line to a failing writer = %v; want %v: did you Flush?", err, errFull)
+	}
+}
+
+func TestWriteLinesBudget(t *testing.T) {
+	lines := testLines(1000)
+	var w countingWriter
+	WriteLines(&w, lines)
+	if want := (w.Len() + 4095) / 4096; w.writes > want {
+		t.Errorf("WriteLines of %d bytes made %d Writes; want at most %d", w.Len(), w.writes, want)
+	}
+
+	if allocs := testing.AllocsPerRun(20, func() {
+		WriteLines(io.Discard, lines)
+	}); allocs > 2 {
+		t.Errorf("WriteLines of 1,000 lines made %.0f allocations; want at most 2", allocs)
+	}
+}
+
+// countingReader counts the Reads it passes on to r.
+type countingReader struct {
+	r     io.Reader
+	reads int
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	r.reads++
+	return r.r.Read(p)
+}
+
+func TestCountByte(t *testing.T) {
+	text := strings.Repeat("one line\nand another\n\n", 5000)
+	want := strings.Count(text, "\n")
+	readers := map[string]func() io.Reader{
+		"strings.Reader": func() io.Reader { return strings.NewReader(text) },
+		"a Reader that returns data with io.EOF": func() io.Reader {
+			return iotest.DataErrReader(strings.NewReader(text))
+		},
+		"a Reader that returns a byte at a time": func() io.Reader {
+			return iotest.OneByteReader(strings.NewReader(text))
+		},
+		"a Reader that returns half of what it's asked for": func() io.Reader {
+			return iotest.HalfReader(strings.NewReader(text))
+		},
+	}
+	for name, r := range readers {
+		got, err := CountByte(r(), '\n')
+		if err != nil {
+			t.Errorf("CountByte of %s: %v", name, err)
+		}
+		assert.Equal(t, got, want, "CountByte of %s", name)
+	}
+
+	got, err := CountByte(strings.NewReader(""), 'x')
+	assert.Equal(t, fmt.Sprint(got, err), "0 <nil>", "CountByte of an empty reader")
+	got, err = CountByte(strings.NewReader("xXxx"), 'x')
+	assert.Equal(t, fmt.Sprint(got, err), "3 <nil>", "CountByte(xXxx, 'x')")
+
+	errCut := errors.New("connection reset")
+	got, err = CountByte(io.MultiReader(strings.NewReader("a,b,c"), iotest.ErrReader(errCut)), ',')
+	if !errors.Is(err, errCut) {
+		t.Errorf("CountByte of a reader that fails = _, %v; want %v", err, errCut)
+	}
+	assert.Equal(t, got, 2, "the count from CountByte of a reader that fails after a,b,c")
+}
+
+func TestCountByteBudget(t *testing.T) {
+	text := strings.Repeat("one line\nand another\n\n", 5000)
+	r := &countingReader{r: strings.NewReader(text)}
+	CountByte(r, '\n')
+	if want := len(text)/1024 + 2; r.reads > want {
+		t.Errorf("CountByte of %d bytes made %d Reads; want at most %d", len(text), r.reads, want)
+	}
+
+	sr := strings.NewReader(text)
+	if allocs := testing.AllocsPerRun(20, func() {
+		sr.Reset(text)
+		CountByte(sr, '\n')
+	}); allocs > 2 {
+		t.Errorf("CountByte of %d bytes made %.0f allocations; want at most 2", len(text), allocs)
+	}
+}
@@ -0,0 +1,125 @@
+// Package achievements awards badges for milestones: your first
+// exercise with every test green, a concurrency exercise that passes
+// under the race detector, a benchmark that stops allocating...
+//
+// Think of the achievements in a game, or the streaks in Duolingo. A
+// badge is earned once and kept, even if you break the code again
+// later; the progress file remembers when (progress.File.Badges).
+package achievements
+
+import (
+	"slices"
+	"time"
+
+	"github.com/imgarylai/learn-go/internal/bench"
+	"github.com/imgarylai/learn-go/internal/progress"
+	"github.com/imgarylai/learn-go/internal/registry"
+	"github.com/imgarylai/learn-go/internal/runner"
+)
+
+// Event is something that just happened to one exercise. The progress
+// file passed to Award already includes it.
+type Event struct {
+	Exercise string
+	Tests    *runner.Result // a test run, nil if this isn't one
+	Race     bool           // the test run used the race detector
+	Bench    []bench.Result // a benchmark run of your code
+}
+
+// Badge is one achievement.
+type Badge struct {
+	ID          string // key in progress.File.Badges
+	Title       string
+	Description string // how to earn it
+
+	earned func(f *progress.File, ev Event) bool
+}
+
+// All lists every badge, roughly in the order you'd earn them.
+var All = []Badge{
+	{
+		ID:          "first-green",
+		Title:       "First green module",
+		Description: "every test of one exercise passes",
+		earned: func(f *progress.File, _ Event) bool {
+			for id := range f.Exercises {
+				if green(f, id) {
+					return true
+				}
+			}
+			return false
+		},
+	},
+	{
+		ID:          "perfect-quiz",
+		Title:       "Perfect quiz",
+		Description: "every answer right in a `learngo quiz`",
+		earned: func(f *progress.File, _ Event) bool {
+			for _, e := range f.Exercises {
+				if e.Quiz != nil && e.Quiz.Total > 0 && e.Quiz.Correct == e.Quiz.Total {
+					return true
+				}
+			}
+			return false
+		},
+	},
+	{
+		ID:          "race-free",
+		Title:       "Race-free concurrency",
+		Description: "a concurrency exercise passes under the race detector (`learngo run 06`)",
+		earned: func(f *progress.File, ev Event) bool {
+			e, ok := registry.Lookup(ev.Exercise)
+			return ok && e.Race && ev.Race && ev.Tests != nil && ev.Tests.OK() &&
+				len(ev.Tests.Races) == 0 && green(f, e.ID)
+		},
+	},
+	{
+		ID:          "zero-alloc-mapints",
+		Title:       "Zero-allocation MapInts",
+		Description: "BenchmarkMapInts allocates nothing but the slice it returns (`learngo bench 02`)",
+		earned: func(f *progress.File, ev Event) bool {
+			e := f.Exercises[ev.Exercise]
+			if ev.Exercise != "02-functions" || e == nil || !slices.Contains(e.Passed, "TestMapInts") {
+				return false // returning nil allocates nothing too
+			}
+			i := slices.IndexFunc(ev.Bench, func(r bench.Result) bool { return r.Name == "BenchmarkMapInts" })
+			return i >= 0 && ev.Bench[i].AllocsPerOp <= 1
+		},
+	},
+	{
+		ID:          "all-green",
+		Title:       "All green",
+		Description: "every test of every built-in exercise passes",
+		earned: func(f *progress.File, _ Event) bool {
+			for _, e := range registry.All() {
+				if e.Pack == "" && !green(f, e.ID) {
+					return false
+				}
+			}
+			return true
+		},
+	},
+}
+
+// green reports whether every test of id passed in its last run.
+func green(f *progress.File, id string) bool {
+	share, ok := f.Completion(id)
+	return ok && share == 1
+}
+
+// Award records in f every badge that ev earns for the first time, at
+// now, and returns them.
+func Award(f *progress.File, ev Event, now time.Time) []Badge {
+	var won []Badge
+	for _, b := range All {
+		if _, ok := f.Badges[b.ID]; ok || !b.earned(f, ev) {
+			continue
+		}
+		if f.Badges == nil {
+			f.Badges = map[string]time.Time{}
+		}
+		f.Badges[b.ID] = now
+		won = append(won, b)
+	}
+	return won
+}
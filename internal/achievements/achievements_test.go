@@ -0,0 +1,121 @@
+package achievements
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/imgarylai/learn-go/internal/bench"
+	"github.com/imgarylai/learn-go/internal/progress"
+	"github.com/imgarylai/learn-go/internal/registry"
+	"github.com/imgarylai/learn-go/internal/runner"
+)
+
+var t0 = time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)
+
+func ids(badges []Badge) []string {
+	var out []string
+	for _, b := range badges {
+		out = append(out, b.ID)
+	}
+	return out
+}
+
+func passing(names ...string) *runner.Result {
+	res := &runner.Result{}
+	for _, n := range names {
+		res.Tests = append(res.Tests, runner.Test{Name: n, Status: runner.Pass})
+	}
+	return res
+}
+
+func TestAwardOnce(t *testing.T) {
+	var f progress.File
+	f.Get("04-collections").RecordTests([]string{"TestSum"}, 2)
+	if got := Award(&f, Event{Exercise: "04-collections"}, t0); len(got) != 0 {
+		t.Errorf("half done: got %v", ids(got))
+	}
+
+	f.Get("04-collections").RecordTests([]string{"TestMax", "TestSum"}, 2)
+	if got := ids(Award(&f, Event{Exercise: "04-collections"}, t0)); !slices.Equal(got, []string{"first-green"}) {
+		t.Errorf("all green: got %v, want [first-green]", got)
+	}
+	if got := Award(&f, Event{Exercise: "04-collections"}, t0.Add(time.Hour)); len(got) != 0 {
+		t.Errorf("awarded again: %v", ids(got))
+	}
+	// Kept, with the first date, even after breaking the code again.
+	f.Get("04-collections").RecordTests(nil, 2)
+	Award(&f, Event{Exercise: "04-collections"}, t0.Add(time.Hour))
+	if at, ok := f.Badges["first-green"]; !ok || !at.Equal(t0) {
+		t.Errorf("badges: got %v", f.Badges)
+	}
+}
+
+func TestRaceFree(t *testing.T) {
+	tests := []struct {
+		name string
+		ev   Event
+		want bool
+	}{
+		{"with -race", Event{Exercise: "06-concurrency", Tests: passing("TestCounter"), Race: true}, true},
+		{"without -race", Event{Exercise: "06-concurrency", Tests: passing("TestCounter")}, false},
+		{"not a concurrency exercise", Event{Exercise: "04-collections", Tests: passing("TestCounter"), Race: true}, false},
+		{"a race was found", Event{Exercise: "06-concurrency", Tests: &runner.Result{
+			Tests: []runner.Test{{Name: "TestCounter", Status: runner.Pass}},
+			Races: []runner.Race{{Test: "TestCounter"}},
+		}, Race: true}, false},
+	}
+	for _, tt := range tests {
+		var f progress.File
+		f.Get(tt.ev.Exercise).RecordTests([]string{"TestCounter"}, 1)
+		f.Badges = map[string]time.Time{"first-green": t0}
+		got := ids(Award(&f, tt.ev, t0))
+		if slices.Contains(got, "race-free") != tt.want {
+			t.Errorf("%s: got %v", tt.name, got)
+		}
+	}
+}
+
+func TestZeroAllocMapInts(t *testing.T) {
+	run := func(passed []string, allocs int64) []string {
+		var f progress.File
+		f.Get("02-functions").RecordTests(passed, 20)
+		ev := Event{Exercise: "02-functions", Bench: []bench.Result{
+			{Name: "BenchmarkMapInts", N: 1000, AllocsPerOp: allocs},
+			{Name: "BenchmarkSum", N: 1000},
+		}}
+		return ids(Award(&f, ev, t0))
+	}
+	if got := run([]string{"TestMapInts"}, 1); !slices.Equal(got, []string{"zero-alloc-mapints"}) {
+		t.Errorf("one allocation: got %v", got)
+	}
+	if got := run([]string{"TestMapInts"}, 15); len(got) != 0 {
+		t.Errorf("growing with append: got %v", got)
+	}
+	if got := run(nil, 0); len(got) != 0 {
+		t.Errorf("the stub returning nil: got %v", got)
+	}
+}
+
+func TestAllGreen(t *testing.T) {
+	var f progress.File
+	for _, e := range registry.All() {
+		f.Get(e.ID).RecordTests([]string{"TestA"}, 1)
+	}
+	f.Get("01-basics").Quiz = &progress.QuizScore{Correct: 5, Total: 5}
+
+	got := ids(Award(&f, Event{}, t0))
+	if want := []string{"first-green", "perfect-quiz", "all-green"}; !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestBadgeIDsAreUnique(t *testing.T) {
+	seen := map[string]bool{}
+	for _, b := range All {
+		if seen[b.ID] || b.Title == "" || b.Description == "" || b.earned == nil {
+			t.Errorf("badge %q is a duplicate or incomplete", b.ID)
+		}
+		seen[b.ID] = true
+	}
+}
@@ -0,0 +1,126 @@
+// Package assert compares what an exercise returned with what a test
+// expected, and explains the difference the way a tutor would.
+//
+// A bare `reflect.DeepEqual` check prints "got [], want []" and leaves
+// you wondering how two empty slices can differ. Equal prints a diff of
+// the two values, names the usual suspects (a nil slice that should be
+// empty, the same elements in map order, a float off by rounding...),
+// and points at the exercise's TODO the test is about. Think of the
+// messages of Jest's expect(...).toEqual, with a hint attached.
+package assert
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/imgarylai/learn-go/internal/textdiff"
+)
+
+// Equal reports whether got and want are deeply equal, as
+// reflect.DeepEqual decides. If they aren't, it fails t with an
+// explanation; msgAndArgs, a format string and its arguments, say what
+// was being checked ("Chunk(%v, 2)"). Like t.Errorf, the test goes on:
+//
+//	if !assert.Equal(t, got, want) {
+//		t.FailNow()
+//	}
+//
+// stops it instead, like t.Fatalf.
+func Equal[T any](t testing.TB, got, want T, msgAndArgs ...any) bool {
+	t.Helper()
+	if reflect.DeepEqual(got, want) {
+		return true
+	}
+	var sb strings.Builder
+	if what := context(msgAndArgs); what != "" {
+		sb.WriteString(what + ": ")
+	}
+	sb.WriteString(Explain(got, want, colorful()))
+	if _, seen := linked.LoadOrStore(t, true); !seen {
+		_, file, _, _ := runtime.Caller(1)
+		if p, ok := promptFor(file, t.Name()); ok {
+			sb.WriteString("\n" + p)
+		}
+	}
+	t.Errorf("%s", sb.String())
+	return false
+}
+
+// linked holds the tests whose failures already pointed at the
+// exercise: once per test is enough.
+var linked sync.Map
+
+func context(msgAndArgs []any) string {
+	if len(msgAndArgs) == 0 {
+		return ""
+	}
+	format, ok := msgAndArgs[0].(string)
+	if !ok {
+		return fmt.Sprint(msgAndArgs...)
+	}
+	return fmt.Sprintf(format, msgAndArgs[1:]...)
+}
+
+// Explain describes how got differs from want: both values, a diff when
+// they don't fit on a line, and what the difference usually means. With
+// color, the diff uses ANSI colors, red for want and green for got.
+func Explain(got, want any, color bool) string {
+	g, w := Format(got), Format(want)
+	var sb strings.Builder
+	if strings.Contains(g+w, "\n") {
+		d := textdiff.Unified("want", "got", w+"\n", g+"\n")
+		sb.WriteString("values differ (-want +got):\n")
+		for _, line := range strings.Split(strings.TrimSuffix(d, "\n"), "\n")[2:] {
+			sb.WriteString("    " + paint(line, color) + "\n")
+		}
+	} else {
+		fmt.Fprintf(&sb, "values differ:\n    got:  %s\n    want: %s\n", tint(g, green, color), tint(w, red, color))
+	}
+	for _, why := range mistakes(reflect.ValueOf(got), reflect.ValueOf(want)) {
+		sb.WriteString("    hint: " + why + "\n")
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+const (
+	red   = "\x1b[31m"
+	green = "\x1b[32m"
+	cyan  = "\x1b[36m"
+	reset = "\x1b[0m"
+)
+
+// paint colors one diff line by its first byte.
+func paint(line string, color bool) string {
+	switch {
+	case strings.HasPrefix(line, "-"):
+		return tint(line, red, color)
+	case strings.HasPrefix(line, "+"):
+		return tint(line, green, color)
+	case strings.HasPrefix(line, "@"):
+		return tint(line, cyan, color)
+	}
+	return line
+}
+
+func tint(s, code string, color bool) string {
+	if !color {
+		return s
+	}
+	return code + s + reset
+}
+
+// colorful reports whether failure messages go to a terminal that shows
+// colors. NO_COLOR (https://no-color.org) turns them off, and learngo
+// sets it when it runs the tests, so its reports stay plain text.
+func colorful() bool {
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	term := os.Getenv("TERM")
+	return term != "" && term != "dumb"
+}
@@ -0,0 +1,164 @@
+package assert
+
+import (
+	"fmt"
+	"math"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/imgarylai/learn-go/internal/testutil"
+)
+
+// fakeT records failures instead of failing the real test.
+type fakeT struct {
+	testing.TB
+	name   string
+	failed bool
+	msg    string
+}
+
+func (f *fakeT) Helper()      {}
+func (f *fakeT) Name() string { return f.name }
+func (f *fakeT) Errorf(format string, args ...any) {
+	f.failed, f.msg = true, fmt.Sprintf(format, args...)
+}
+
+func TestEqual(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+	f := &fakeT{name: "TestDouble"}
+	if !Equal(f, []int{1, 2}, []int{1, 2}) || f.failed {
+		t.Fatalf("equal slices failed: %s", f.msg)
+	}
+	if Equal(f, []int{1}, []int{2}, "Double(%v)", []int{1}) || !f.failed {
+		t.Fatal("different slices passed")
+	}
+	if want := "Double([1]): values differ:\n    got:  [1]\n    want: [2]"; f.msg != want {
+		t.Errorf("got\n%s\nwant\n%s", f.msg, want)
+	}
+}
+
+func TestPrompt(t *testing.T) {
+	dir := testutil.TempDir(t, map[string]string{
+		"double.go": `package x
+
+// Exercise 4: Slices
+
+// 3. Double each element (like JS map)
+// In JS: nums.map(n => n * 2)
+// Allocate the result once.
+func Double(nums []int) []int { return nil }
+
+// DoubleAll has a longer name.
+func DoubleAll() {}
+
+// Stack is a type to write.
+type Stack struct{}
+`,
+		"double_solution.go": "package x\n\n// Not the prompt.\nfunc Double(nums []int) []int { return nums }\n",
+		"double_test.go":     "package x\n",
+	})
+	test := filepath.Join(dir, "double_test.go")
+	tests := []struct{ name, want string }{
+		{"TestDouble/empty", "    exercise: double.go:8\n      3. Double each element (like JS map)\n      In JS: nums.map(n => n * 2)"},
+		{"TestDoubleAll", "    exercise: double.go:11\n      DoubleAll has a longer name."},
+		{"TestStackPush", "    exercise: double.go:14\n      Stack is a type to write."},
+		{"TestSomethingElse", ""},
+	}
+	for _, tt := range tests {
+		if got, _ := promptFor(test, tt.name); got != tt.want {
+			t.Errorf("%s:\ngot  %q\nwant %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestExplain(t *testing.T) {
+	type point struct{ X, Y float64 }
+	a, b := 0.1, 0.2
+	tests := []struct {
+		name      string
+		got, want any
+		hint      string // "" for none
+	}{
+		{"nil slice", []int(nil), []int{}, "got a nil slice, want an empty one"},
+		{"empty slice", []string{}, []string(nil), "got an empty slice, want nil"},
+		{"nil map", map[string]int(nil), map[string]int{}, "got a nil map"},
+		{"order", []string{"b", "a"}, []string{"a", "b"}, "Go visits a map in random order"},
+		{"too many", []int{1, 2, 3}, []int{1, 2}, "got 1 element(s) too many"},
+		{"too few", []int{1}, []int{1, 2}, "got 1 element(s) too few"},
+		{"zero value", point{}, point{1, 2}, "got the zero value of assert.point"},
+		{"rounding", a + b, 0.3, "a rounding error"},
+		{"rounding in a field", point{a + b, 1}, point{0.3, 1}, "at X, the floats differ"},
+		{"NaN", math.NaN(), math.NaN(), "NaN never equals anything"},
+		{"newline", "ok\n", "ok", "a stray \"\\n\""},
+		{"case", "Hello", "hello", "only upper and lower case differ"},
+		{"types", any(int64(1)), any(1), "got a int64, want a int"},
+		{"missing key", map[string]int{"a": 1}, map[string]int{"a": 1, "b": 2}, `missing keys "b"`},
+		{"element", [][]string{{"a"}, {"b "}}, [][]string{{"a"}, {"b"}}, "at [1][0], only spaces"},
+		{"just wrong", 3, 4, ""},
+	}
+	for _, tt := range tests {
+		msg := Explain(tt.got, tt.want, false)
+		hinted := strings.Contains(msg, "hint: ")
+		if tt.hint == "" && hinted || tt.hint != "" && (!hinted || !strings.Contains(msg, tt.hint)) {
+			t.Errorf("%s: want hint %q in\n%s", tt.name, tt.hint, msg)
+		}
+	}
+}
+
+func TestExplainDiff(t *testing.T) {
+	long := func(last string) []string {
+		return []string{"alpha", "bravo", "charlie", "delta", "echo", "foxtrot", "golf", last}
+	}
+	msg := Explain(long("hotel"), long("india"), true)
+	for _, want := range []string{"values differ (-want +got):", "\x1b[31m-\t\"india\",\x1b[0m", "\x1b[32m+\t\"hotel\",\x1b[0m"} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("diff doesn't contain %q:\n%s", want, msg)
+		}
+	}
+	if plain := Explain(long("hotel"), long("india"), false); strings.Contains(plain, "\x1b[") {
+		t.Errorf("colors without color:\n%s", plain)
+	}
+}
+
+func TestFormat(t *testing.T) {
+	type row struct {
+		Name string
+		Tags map[string]int
+	}
+	tests := []struct {
+		v    any
+		want string
+	}{
+		{[]int(nil), "nil"},
+		{[]string{"a"}, `["a"]`},
+		{map[string]int{"b": 2, "a": 1}, `{"a": 1, "b": 2}`},
+		{&row{Name: "x"}, `&row{Name: "x", Tags: nil}`},
+		{[]row{{"a long enough name", map[string]int{"k": 1}}, {"to wrap the line", nil}}, `[
+	row{Name: "a long enough name", Tags: {"k": 1}},
+	row{Name: "to wrap the line", Tags: nil},
+]`},
+	}
+	for _, tt := range tests {
+		if got := Format(tt.v); got != tt.want {
+			t.Errorf("Format(%#v):\ngot  %s\nwant %s", tt.v, got, tt.want)
+		}
+	}
+}
+
+func TestColorful(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	t.Setenv("TERM", "xterm-256color")
+	if !colorful() {
+		t.Error("no colors in a terminal")
+	}
+	t.Setenv("TERM", "dumb")
+	if colorful() {
+		t.Error("colors with TERM=dumb")
+	}
+	t.Setenv("TERM", "xterm-256color")
+	t.Setenv("NO_COLOR", "1")
+	if colorful() {
+		t.Error("colors with NO_COLOR")
+	}
+}
@@ -0,0 +1,123 @@
+package assert
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// wrap is how long a value may print on one line before Format splits
+// it, one element per line, so a diff can point at the element.
+const wrap = 60
+
+// Format prints v like %#v without the type noise: strings are quoted,
+// nil slices and maps say nil, and values too long for a line are
+// spread one element or field per line, map keys in sorted order.
+func Format(v any) string {
+	return format(reflect.ValueOf(v), "")
+}
+
+func format(v reflect.Value, indent string) string {
+	if one := oneLine(v); len(one) <= wrap || !composite(v) {
+		return one
+	}
+	inner := indent + "\t"
+	var sb strings.Builder
+	switch v.Kind() {
+	case reflect.Pointer:
+		return "&" + format(v.Elem(), indent)
+	case reflect.Interface:
+		return format(v.Elem(), indent)
+	case reflect.Slice, reflect.Array:
+		sb.WriteString("[\n")
+		for i := range v.Len() {
+			sb.WriteString(inner + format(v.Index(i), inner) + ",\n")
+		}
+		sb.WriteString(indent + "]")
+	case reflect.Map:
+		sb.WriteString("{\n")
+		for _, k := range sortedKeys(v) {
+			sb.WriteString(inner + oneLine(k) + ": " + format(v.MapIndex(k), inner) + ",\n")
+		}
+		sb.WriteString(indent + "}")
+	case reflect.Struct:
+		sb.WriteString(v.Type().Name() + "{\n")
+		for i := range v.NumField() {
+			sb.WriteString(inner + v.Type().Field(i).Name + ": " + format(v.Field(i), inner) + ",\n")
+		}
+		sb.WriteString(indent + "}")
+	}
+	return sb.String()
+}
+
+// composite reports whether v can be split over several lines.
+func composite(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		return !v.IsNil() && composite(v.Elem())
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return v.Len() > 0
+	case reflect.Struct:
+		return v.NumField() > 0
+	}
+	return false
+}
+
+func oneLine(v reflect.Value) string {
+	if !v.IsValid() {
+		return "nil"
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return fmt.Sprintf("%q", v.String())
+	case reflect.Pointer:
+		if v.IsNil() {
+			return "nil"
+		}
+		if composite(v.Elem()) {
+			return "&" + oneLine(v.Elem())
+		}
+	case reflect.Interface:
+		if v.IsNil() {
+			return "nil"
+		}
+		return oneLine(v.Elem())
+	case reflect.Slice, reflect.Map:
+		if v.IsNil() {
+			return "nil"
+		}
+	}
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		parts := make([]string, v.Len())
+		for i := range parts {
+			parts[i] = oneLine(v.Index(i))
+		}
+		return "[" + strings.Join(parts, " ") + "]"
+	case reflect.Map:
+		var parts []string
+		for _, k := range sortedKeys(v) {
+			parts = append(parts, oneLine(k)+": "+oneLine(v.MapIndex(k)))
+		}
+		return "{" + strings.Join(parts, ", ") + "}"
+	case reflect.Struct:
+		parts := make([]string, v.NumField())
+		for i := range parts {
+			parts[i] = v.Type().Field(i).Name + ": " + oneLine(v.Field(i))
+		}
+		return v.Type().Name() + "{" + strings.Join(parts, ", ") + "}"
+	}
+	if v.CanInterface() {
+		return fmt.Sprintf("%v", v.Interface())
+	}
+	return fmt.Sprintf("%v", v) // unexported field: fmt still reads it
+}
+
+func sortedKeys(m reflect.Value) []reflect.Value {
+	keys := m.MapKeys()
+	slices.SortFunc(keys, func(a, b reflect.Value) int {
+		return strings.Compare(oneLine(a), oneLine(b))
+	})
+	return keys
+}
@@ -0,0 +1,174 @@
+package assert
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// maxHints caps the explanations, so a wrong answer in every element
+// of a long slice doesn't bury the diff.
+const maxHints = 3
+
+// mistakes walks got and want side by side and names the differences a
+// newcomer to Go tends to trip over.
+func mistakes(got, want reflect.Value) []string {
+	var h hints
+	if got.IsValid() && want.IsValid() && got.Type() == want.Type() && got.IsZero() && !want.IsZero() && !emptyish(want) {
+		h.add("", fmt.Sprintf("got the zero value of %s: is the function still the TODO stub, or does a path return before the result is ready?", got.Type()))
+		return h
+	}
+	h.walk(got, want, "")
+	return h
+}
+
+type hints []string
+
+func (h *hints) add(path, why string) {
+	if path != "" {
+		why = "at " + path + ", " + why
+	}
+	if len(*h) < maxHints && !slices.Contains(*h, why) {
+		*h = append(*h, why)
+	}
+}
+
+func (h *hints) walk(got, want reflect.Value, path string) {
+	if !got.IsValid() || !want.IsValid() {
+		return // one side is a nil interface; the values say it all
+	}
+	if got.Type() != want.Type() {
+		h.add(path, fmt.Sprintf("got a %s, want a %s: they can print alike, but values of different types are never equal", got.Type(), want.Type()))
+		return
+	}
+	switch got.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if !got.IsNil() && !want.IsNil() {
+			h.walk(got.Elem(), want.Elem(), path)
+		}
+	case reflect.Slice:
+		if got.Len() == 0 && want.Len() == 0 {
+			h.nilOrEmpty(got, want, path, "slice", "[]T{} or make([]T, 0)", "var s []T")
+			return
+		}
+		h.sequence(got, want, path)
+	case reflect.Array:
+		h.sequence(got, want, path)
+	case reflect.Map:
+		if got.Len() == 0 && want.Len() == 0 {
+			h.nilOrEmpty(got, want, path, "map", "map[K]V{} or make(map[K]V)", "var m map[K]V")
+			return
+		}
+		h.keys(got, want, path)
+	case reflect.Struct:
+		for i := range got.NumField() {
+			h.walk(got.Field(i), want.Field(i), join(path, "."+got.Type().Field(i).Name))
+		}
+	case reflect.Float32, reflect.Float64:
+		g, w := got.Float(), want.Float()
+		switch {
+		case math.IsNaN(g) && math.IsNaN(w):
+			h.add(path, "both are NaN, and NaN never equals anything, itself included: check math.IsNaN instead")
+		case g != w && math.Abs(g-w) <= 1e-9*math.Max(math.Abs(g), math.Abs(w)):
+			h.add(path, fmt.Sprintf("the floats differ by %.3g, a rounding error: sums and divisions round, so compare with a tolerance (testutil.Near) or change the order of the operations", g-w))
+		}
+	case reflect.String:
+		g, w := got.String(), want.String()
+		switch {
+		case g == w:
+		case strings.TrimSpace(g) == strings.TrimSpace(w):
+			h.add(path, "only spaces or newlines at the ends differ: a stray \"\\n\" or strings.TrimSpace forgotten?")
+		case strings.EqualFold(g, w):
+			h.add(path, "only upper and lower case differ")
+		case strings.Join(strings.Fields(g), " ") == strings.Join(strings.Fields(w), " "):
+			h.add(path, "only the spacing between words differs")
+		}
+	}
+}
+
+// nilOrEmpty explains the difference that doesn't print: both []
+// (or map[]), but one is nil. reflect.DeepEqual tells them apart.
+func (h *hints) nilOrEmpty(got, want reflect.Value, path, kind, empty, nilDecl string) {
+	switch {
+	case got.IsNil() && !want.IsNil():
+		h.add(path, fmt.Sprintf("got a nil %s, want an empty one. Both print as nothing, but a nil %s is what `%s` gives you: start from %s instead", kind, kind, nilDecl, empty))
+	case !got.IsNil() && want.IsNil():
+		h.add(path, fmt.Sprintf("got an empty %s, want nil: return nil when there's nothing to return", kind))
+	}
+}
+
+// sequence compares slices or arrays element by element, after ruling
+// out the mistakes that change the whole sequence.
+func (h *hints) sequence(got, want reflect.Value, path string) {
+	g, w := elements(got), elements(want)
+	switch {
+	case len(g) > len(w) && slices.Equal(g[:len(w)], w):
+		h.add(path, fmt.Sprintf("got %d element(s) too many at the end: a loop that runs once too often, or appending to a slice that wasn't empty?", len(g)-len(w)))
+	case len(g) < len(w) && slices.Equal(w[:len(g)], g):
+		h.add(path, fmt.Sprintf("got %d element(s) too few at the end: a loop that stops one short (< or <=)?", len(w)-len(g)))
+	case len(g) != len(w):
+		h.add(path, fmt.Sprintf("got %d elements, want %d", len(g), len(w)))
+	case sameElements(g, w):
+		h.add(path, "the same elements in a different order. If they came from ranging over a map, remember Go visits a map in random order: sort them (slices.Sort) or the keys first (slices.Sorted(maps.Keys(m)))")
+	default:
+		for i := range got.Len() {
+			h.walk(got.Index(i), want.Index(i), join(path, fmt.Sprintf("[%d]", i)))
+		}
+	}
+}
+
+// keys compares maps key by key.
+func (h *hints) keys(got, want reflect.Value, path string) {
+	var missing, extra []string
+	for _, k := range sortedKeys(want) {
+		if !got.MapIndex(k).IsValid() {
+			missing = append(missing, oneLine(k))
+		}
+	}
+	for _, k := range sortedKeys(got) {
+		if !want.MapIndex(k).IsValid() {
+			extra = append(extra, oneLine(k))
+		}
+	}
+	if len(missing) > 0 {
+		h.add(path, "missing keys "+strings.Join(missing, ", "))
+	}
+	if len(extra) > 0 {
+		h.add(path, "unexpected keys "+strings.Join(extra, ", "))
+	}
+	for _, k := range sortedKeys(want) {
+		if g := got.MapIndex(k); g.IsValid() {
+			h.walk(g, want.MapIndex(k), join(path, "["+oneLine(k)+"]"))
+		}
+	}
+}
+
+func elements(v reflect.Value) []string {
+	out := make([]string, v.Len())
+	for i := range out {
+		out[i] = oneLine(v.Index(i))
+	}
+	return out
+}
+
+func sameElements(a, b []string) bool {
+	a, b = slices.Clone(a), slices.Clone(b)
+	slices.Sort(a)
+	slices.Sort(b)
+	return slices.Equal(a, b)
+}
+
+// emptyish reports whether v is an empty slice or map: the nil vs empty
+// explanation fits it better than the zero value one.
+func emptyish(v reflect.Value) bool {
+	return (v.Kind() == reflect.Slice || v.Kind() == reflect.Map) && v.Len() == 0
+}
+
+func join(path, step string) string {
+	if path == "" {
+		return strings.TrimPrefix(step, ".")
+	}
+	return path + step
+}
@@ -0,0 +1,114 @@
+package assert
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// decl is a function or type an exercise asks you to write, with the
+// comment that explains the task.
+type decl struct {
+	name string
+	file string
+	line int
+	doc  []string
+}
+
+var (
+	declsMu sync.Mutex
+	declsIn = map[string][]decl{} // by directory
+)
+
+// promptFor finds the exercise the failing test is about: the function
+// or type in testFile's package whose name the test is named after
+// (TestChunk and TestChunk/empty check Chunk), and returns where it is
+// with the first lines of its doc comment.
+func promptFor(testFile, testName string) (string, bool) {
+	if testFile == "" {
+		return "", false
+	}
+	name := strings.TrimPrefix(strings.Split(testName, "/")[0], "Test")
+	name = strings.TrimPrefix(name, "Fuzz")
+	var best *decl
+	for _, d := range decls(filepath.Dir(testFile)) {
+		if strings.HasPrefix(name, d.name) && (best == nil || len(d.name) > len(best.name)) {
+			best = &d
+		}
+	}
+	if best == nil {
+		return "", false
+	}
+	var sb strings.Builder
+	sb.WriteString("    exercise: " + best.file + ":" + strconv.Itoa(best.line))
+	for _, line := range best.doc {
+		sb.WriteString("\n      " + line)
+	}
+	return sb.String(), true
+}
+
+// decls lists the top-level functions and types of the exercise in
+// dir, read from the stubs: the files that are neither tests nor
+// generated solutions.
+func decls(dir string) []decl {
+	declsMu.Lock()
+	defer declsMu.Unlock()
+	if ds, ok := declsIn[dir]; ok {
+		return ds
+	}
+	var ds []decl
+	entries, _ := os.ReadDir(dir)
+	fset := token.NewFileSet()
+	for _, e := range entries {
+		n := e.Name()
+		if !strings.HasSuffix(n, ".go") || strings.HasSuffix(n, "_test.go") || strings.HasSuffix(n, "_solution.go") {
+			continue
+		}
+		f, err := parser.ParseFile(fset, filepath.Join(dir, n), nil, parser.ParseComments)
+		if err != nil {
+			continue
+		}
+		for _, d := range f.Decls {
+			switch d := d.(type) {
+			case *ast.FuncDecl:
+				if d.Recv == nil {
+					ds = append(ds, newDecl(fset, n, d.Name, d.Doc))
+				}
+			case *ast.GenDecl:
+				for _, s := range d.Specs {
+					if ts, ok := s.(*ast.TypeSpec); ok {
+						doc := ts.Doc
+						if doc == nil {
+							doc = d.Doc
+						}
+						ds = append(ds, newDecl(fset, n, ts.Name, doc))
+					}
+				}
+			}
+		}
+	}
+	declsIn[dir] = ds
+	return ds
+}
+
+// docLines is how much of a doc comment the prompt shows: the stubs
+// open with the task ("3. Double each element") and a JS equivalent.
+const docLines = 2
+
+func newDecl(fset *token.FileSet, file string, name *ast.Ident, doc *ast.CommentGroup) decl {
+	d := decl{name: name.Name, file: file, line: fset.Position(name.Pos()).Line}
+	if doc != nil {
+		for _, line := range strings.Split(strings.TrimSpace(doc.Text()), "\n") {
+			if len(d.doc) == docLines || line == "" {
+				break
+			}
+			d.doc = append(d.doc, line)
+		}
+	}
+	return d
+}
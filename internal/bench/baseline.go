@@ -0,0 +1,138 @@
+package bench
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+)
+
+// BaselineFile is where an exercise keeps its committed baseline,
+// relative to the exercise directory.
+const BaselineFile = "testdata/bench-baseline.json"
+
+// CalibrationDir is the package holding BenchmarkCalibrate, relative to
+// the repository root.
+const CalibrationDir = "internal/bench/calibrate"
+
+// BaselineTolerance is how much slower than the scaled baseline a
+// solution may be: up to twice the reference time. Calibration only
+// corrects for raw CPU speed, not caches, memory bandwidth or GC timing,
+// so this is far looser than Tolerance. It still catches the solutions
+// that matter, like a quadratic loop; allocations are the precise check.
+const BaselineTolerance = 1.0
+
+// Baseline is the reference solution's benchmark results for one
+// exercise, together with the calibration timing of the machine that
+// recorded them.
+type Baseline struct {
+	CalibrationNsPerOp float64  `json:"calibration_ns_per_op"`
+	Results            []Result `json:"results"`
+}
+
+// LoadBaseline reads the baseline of the exercise in dir. A missing
+// file is reported as an fs.ErrNotExist error: not every exercise is
+// about performance.
+func LoadBaseline(dir string) (Baseline, error) {
+	var b Baseline
+	data, err := os.ReadFile(filepath.Join(dir, BaselineFile))
+	if err != nil {
+		return b, err
+	}
+	if err := json.Unmarshal(data, &b); err != nil {
+		return b, fmt.Errorf("reading %s baseline: %w", dir, err)
+	}
+	return b, nil
+}
+
+// Save writes b to the exercise in dir.
+func (b Baseline) Save(dir string) error {
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(dir, BaselineFile)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// Verdict is how one benchmark did against the baseline.
+type Verdict struct {
+	Name      string
+	Current   Result
+	MaxNs     float64 // baseline ns/op scaled to this machine, plus tolerance
+	MaxAllocs int64
+	Missing   bool // in the baseline but not in this run
+}
+
+// Regressed reports whether the benchmark is slower or allocates more
+// than the baseline allows. A missing benchmark counts as a regression.
+func (v Verdict) Regressed() bool {
+	return v.Missing || v.Current.NsPerOp > v.MaxNs || v.Current.AllocsPerOp > v.MaxAllocs
+}
+
+// Check compares cur with the baseline. calibrationNs is this machine's
+// BenchmarkCalibrate time: if it's twice the baseline's, every expected
+// ns/op is doubled too. Allocation counts don't depend on the machine
+// and get no slack. Benchmarks that aren't in the baseline are ignored.
+func (b Baseline) Check(cur []Result, calibrationNs float64) []Verdict {
+	scale := 1.0
+	if b.CalibrationNsPerOp > 0 && calibrationNs > 0 {
+		scale = calibrationNs / b.CalibrationNsPerOp
+	}
+	byName := make(map[string]Result, len(cur))
+	for _, r := range cur {
+		byName[r.Name] = r
+	}
+
+	verdicts := make([]Verdict, 0, len(b.Results))
+	for _, base := range b.Results {
+		r, ok := byName[base.Name]
+		verdicts = append(verdicts, Verdict{
+			Name:      base.Name,
+			Current:   r,
+			MaxNs:     base.NsPerOp * scale * (1 + BaselineTolerance),
+			MaxAllocs: base.AllocsPerOp,
+			Missing:   !ok,
+		})
+	}
+	return verdicts
+}
+
+// Median combines several runs of the same benchmarks into one, taking
+// the median ns/op and allocs/op of each, so a single noisy run can't
+// skew a baseline. Benchmarks keep the order of the first run.
+func Median(runs [][]Result) []Result {
+	if len(runs) == 0 {
+		return nil
+	}
+	byName := map[string][]Result{}
+	for _, run := range runs {
+		for _, r := range run {
+			byName[r.Name] = append(byName[r.Name], r)
+		}
+	}
+
+	out := make([]Result, 0, len(runs[0]))
+	for _, first := range runs[0] {
+		rs := byName[first.Name]
+		m := first
+		m.NsPerOp = median(rs, func(r Result) float64 { return r.NsPerOp })
+		m.BytesPerOp = int64(median(rs, func(r Result) float64 { return float64(r.BytesPerOp) }))
+		m.AllocsPerOp = int64(median(rs, func(r Result) float64 { return float64(r.AllocsPerOp) }))
+		out = append(out, m)
+	}
+	return out
+}
+
+func median(rs []Result, field func(Result) float64) float64 {
+	vals := make([]float64, len(rs))
+	for i, r := range rs {
+		vals[i] = field(r)
+	}
+	slices.Sort(vals)
+	return vals[len(vals)/2]
+}
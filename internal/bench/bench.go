@@ -0,0 +1,95 @@
+// Package bench runs an exercise's benchmarks and compares them with
+// earlier runs.
+//
+// JS has no built-in equivalent; the closest is benchmark.js or
+// `vitest bench`. In Go, benchmarks are ordinary functions named
+// BenchmarkXxx(b *testing.B) that live next to your tests.
+package bench
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Result is one line of `go test -bench` output.
+type Result struct {
+	Name        string  `json:"name"` // without the -GOMAXPROCS suffix
+	N           int     `json:"n"`
+	NsPerOp     float64 `json:"ns_per_op"`
+	BytesPerOp  int64   `json:"bytes_per_op"`
+	AllocsPerOp int64   `json:"allocs_per_op"`
+}
+
+// Run runs the benchmarks matching pattern in dir (relative to root, or absolute)
+// with -benchmem, skipping the regular tests. extraArgs go to `go test`,
+// e.g. "-tags=solutions".
+func Run(ctx context.Context, root, dir, pattern string, extraArgs ...string) ([]Result, error) {
+	if pattern == "" {
+		pattern = "."
+	}
+	wd, pkg := root, "./"+filepath.ToSlash(dir)
+	if filepath.IsAbs(dir) {
+		wd, pkg = dir, "." // an exercise pack, possibly its own module
+	}
+	args := append([]string{"test", "-run", "^$", "-bench", pattern, "-benchmem"}, extraArgs...)
+	cmd := exec.CommandContext(ctx, "go", append(args, pkg)...)
+	cmd.Dir = wd
+	out, err := cmd.CombinedOutput()
+
+	results, parseErr := Parse(strings.NewReader(string(out)))
+	if parseErr != nil {
+		return nil, parseErr
+	}
+	var exit *exec.ExitError
+	if errors.As(err, &exit) {
+		// Failing benchmarks or a build error: show go's own output.
+		return results, fmt.Errorf("go test failed:\n%s", strings.TrimSpace(string(out)))
+	}
+	return results, err
+}
+
+// benchLine matches e.g.
+//
+//	BenchmarkSum-8   1000000   1234 ns/op   16 B/op   1 allocs/op
+var benchLine = regexp.MustCompile(`^(Benchmark\S+?)(?:-\d+)?\s+(\d+)\s+([\d.]+) ns/op(.*)$`)
+
+// Parse extracts benchmark results from `go test -bench` text output.
+// Other lines (PASS, ok, goos:...) are ignored.
+func Parse(r io.Reader) ([]Result, error) {
+	var results []Result
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		m := benchLine.FindStringSubmatch(strings.TrimSpace(sc.Text()))
+		if m == nil {
+			continue
+		}
+		res := Result{Name: m[1]}
+		res.N, _ = strconv.Atoi(m[2])
+		res.NsPerOp, _ = strconv.ParseFloat(m[3], 64)
+
+		// The -benchmem columns come as "<value> <unit>" pairs.
+		fields := strings.Fields(m[4])
+		for i := 0; i+1 < len(fields); i += 2 {
+			v, err := strconv.ParseInt(fields[i], 10, 64)
+			if err != nil {
+				continue
+			}
+			switch fields[i+1] {
+			case "B/op":
+				res.BytesPerOp = v
+			case "allocs/op":
+				res.AllocsPerOp = v
+			}
+		}
+		results = append(results, res)
+	}
+	return results, sc.Err()
+}
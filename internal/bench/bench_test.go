@@ -0,0 +1,183 @@
+package bench
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const sampleOutput = `goos: linux
+goarch: amd64
+pkg: github.com/imgarylai/learn-go/exercises/04-collections
+BenchmarkSum-8            	21196880	        51.06 ns/op	      88 B/op	       1 allocs/op
+BenchmarkDouble/small-8   	1000000000	         0.7226 ns/op	       0 B/op	       0 allocs/op
+BenchmarkNoMem            	    1000	      1200 ns/op
+PASS
+ok  	github.com/imgarylai/learn-go/exercises/04-collections	1.949s
+`
+
+func TestParse(t *testing.T) {
+	results, err := Parse(strings.NewReader(sampleOutput))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("got %d results, want 3: %+v", len(results), results)
+	}
+
+	want := Result{Name: "BenchmarkSum", N: 21196880, NsPerOp: 51.06, BytesPerOp: 88, AllocsPerOp: 1}
+	if results[0] != want {
+		t.Errorf("got %+v, want %+v", results[0], want)
+	}
+	if results[1].Name != "BenchmarkDouble/small" {
+		t.Errorf("sub-benchmark name: got %q", results[1].Name)
+	}
+	if results[2].NsPerOp != 1200 || results[2].AllocsPerOp != 0 {
+		t.Errorf("without -benchmem: got %+v", results[2])
+	}
+}
+
+func TestCompare(t *testing.T) {
+	prev := []Result{
+		{Name: "BenchmarkSum", NsPerOp: 100, AllocsPerOp: 1},
+		{Name: "BenchmarkMax", NsPerOp: 100, AllocsPerOp: 0},
+	}
+	cur := []Result{
+		{Name: "BenchmarkSum", NsPerOp: 105, AllocsPerOp: 1}, // within tolerance
+		{Name: "BenchmarkMax", NsPerOp: 90, AllocsPerOp: 2},  // faster but allocates
+		{Name: "BenchmarkNew", NsPerOp: 10},
+	}
+
+	deltas := Compare(prev, cur)
+	if len(deltas) != 3 {
+		t.Fatalf("got %d deltas, want 3", len(deltas))
+	}
+	if deltas[0].Regressed() {
+		t.Errorf("5%% slower should be within tolerance (change %.2f)", deltas[0].NsChange())
+	}
+	if !deltas[1].Regressed() || deltas[1].AllocsChange() != 2 {
+		t.Errorf("extra allocations should regress: %+v", deltas[1])
+	}
+	if deltas[2].HasPrev || deltas[2].Regressed() {
+		t.Errorf("new benchmark can't regress: %+v", deltas[2])
+	}
+
+	slow := Compare(prev[:1], []Result{{Name: "BenchmarkSum", NsPerOp: 150, AllocsPerOp: 1}})
+	if !slow[0].Regressed() {
+		t.Error("50% slower should regress")
+	}
+}
+
+func TestHistory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bench-history.json")
+
+	h, err := LoadHistory(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := h.Last("04-collections"); ok {
+		t.Error("empty history should have no runs")
+	}
+
+	h.Add("04-collections", Record{Time: time.Unix(1, 0), Results: []Result{{Name: "BenchmarkSum", NsPerOp: 1}}})
+	h.Add("04-collections", Record{Time: time.Unix(2, 0), Results: []Result{{Name: "BenchmarkSum", NsPerOp: 2}}})
+	if err := h.Save(path); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := LoadHistory(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	last, ok := loaded.Last("04-collections")
+	if !ok || last.Results[0].NsPerOp != 2 {
+		t.Errorf("Last: got %+v, %v", last, ok)
+	}
+}
+
+func TestBaselineCheck(t *testing.T) {
+	b := Baseline{
+		CalibrationNsPerOp: 1000,
+		Results: []Result{
+			{Name: "BenchmarkFast", NsPerOp: 100, AllocsPerOp: 1},
+			{Name: "BenchmarkAllocs", NsPerOp: 100, AllocsPerOp: 1},
+			{Name: "BenchmarkGone", NsPerOp: 100},
+		},
+	}
+	cur := []Result{
+		// This machine is twice as slow, so 350ns is within 100*2*2.
+		{Name: "BenchmarkFast", NsPerOp: 350, AllocsPerOp: 1},
+		{Name: "BenchmarkAllocs", NsPerOp: 50, AllocsPerOp: 14},
+		{Name: "BenchmarkExtra", NsPerOp: 1e9},
+	}
+
+	verdicts := b.Check(cur, 2000)
+	if len(verdicts) != 3 {
+		t.Fatalf("got %d verdicts, want 3", len(verdicts))
+	}
+	if v := verdicts[0]; v.Regressed() || v.MaxNs != 400 {
+		t.Errorf("BenchmarkFast: %+v", v)
+	}
+	if !verdicts[1].Regressed() {
+		t.Error("BenchmarkAllocs allocates more than the baseline")
+	}
+	if v := verdicts[2]; !v.Missing || !v.Regressed() {
+		t.Errorf("BenchmarkGone: %+v", v)
+	}
+}
+
+func TestBaselineRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := LoadBaseline(dir); err == nil {
+		t.Error("a missing baseline should be an error")
+	}
+
+	b := Baseline{CalibrationNsPerOp: 42, Results: []Result{{Name: "BenchmarkSum", NsPerOp: 1.5}}}
+	if err := b.Save(dir); err != nil {
+		t.Fatal(err)
+	}
+	back, err := LoadBaseline(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if back.CalibrationNsPerOp != 42 || len(back.Results) != 1 || back.Results[0] != b.Results[0] {
+		t.Errorf("got %+v", back)
+	}
+}
+
+func TestMedian(t *testing.T) {
+	runs := [][]Result{
+		{{Name: "BenchmarkA", NsPerOp: 10, AllocsPerOp: 1}, {Name: "BenchmarkB", NsPerOp: 5}},
+		{{Name: "BenchmarkB", NsPerOp: 500}, {Name: "BenchmarkA", NsPerOp: 30, AllocsPerOp: 1}},
+		{{Name: "BenchmarkA", NsPerOp: 20, AllocsPerOp: 2}, {Name: "BenchmarkB", NsPerOp: 6}},
+	}
+	got := Median(runs)
+	want := []Result{{Name: "BenchmarkA", NsPerOp: 20, AllocsPerOp: 1}, {Name: "BenchmarkB", NsPerOp: 6}}
+	if len(got) != 2 || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+const sampleTop = `File: bench.test
+Type: alloc_space
+Showing nodes accounting for 1.14GB, 99.76% of 1.14GB total
+Dropped 14 nodes (cum <= 0.01GB)
+      flat  flat%   sum%        cum   cum%
+    1.14GB 99.76% 99.76%     1.14GB 99.76%  example.com/fx.MapInts (inline)
+         0     0% 99.76%     1.14GB 99.76%  testing.(*B).run1.func1
+`
+
+func TestParseTop(t *testing.T) {
+	spots, err := ParseTop(strings.NewReader(sampleTop))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Hotspot{
+		{Func: "example.com/fx.MapInts", Flat: "1.14GB", FlatPct: 99.76, Cum: "1.14GB", CumPct: 99.76},
+		{Func: "testing.(*B).run1.func1", Flat: "0", FlatPct: 0, Cum: "1.14GB", CumPct: 99.76},
+	}
+	if len(spots) != len(want) || spots[0] != want[0] || spots[1] != want[1] {
+		t.Errorf("got %+v\nwant %+v", spots, want)
+	}
+}
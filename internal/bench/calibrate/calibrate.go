@@ -0,0 +1,25 @@
+// Package calibrate holds the benchmark `learngo bench --check` uses to
+// measure how fast this machine is.
+//
+// Baselines are recorded on one computer and checked on another, so raw
+// ns/op can't be compared directly. Instead both sides run
+// BenchmarkCalibrate and expected timings are scaled by the ratio, the
+// way a running app might adjust a pace for altitude.
+package calibrate
+
+import "slices"
+
+// Work is a fixed mix of the things the exercises do: allocating and
+// filling slices, map updates and sorting.
+func Work() int {
+	nums := make([]int, 2000)
+	for i := range nums {
+		nums[i] = (i * 7919) % 2003
+	}
+	counts := make(map[int]int)
+	for _, n := range nums {
+		counts[n%97]++
+	}
+	slices.Sort(nums)
+	return nums[len(nums)/2] + len(counts)
+}
@@ -0,0 +1,17 @@
+package calibrate
+
+import "testing"
+
+var sink int
+
+func BenchmarkCalibrate(b *testing.B) {
+	for b.Loop() {
+		sink = Work()
+	}
+}
+
+func TestWork(t *testing.T) {
+	if a, b := Work(), Work(); a != b {
+		t.Errorf("Work isn't deterministic: %d vs %d", a, b)
+	}
+}
@@ -0,0 +1,50 @@
+package bench
+
+// Tolerance is how much slower (as a fraction) a benchmark may get before
+// we call it a regression. Timing is noisy, so 10% is deliberately loose.
+// Allocation counts are deterministic and get no tolerance at all.
+const Tolerance = 0.10
+
+// Delta compares one benchmark with its previous run.
+type Delta struct {
+	Name     string
+	Current  Result
+	Previous Result
+	HasPrev  bool // false for a benchmark we haven't seen before
+}
+
+// NsChange is the relative change in ns/op, e.g. 0.25 for 25% slower.
+func (d Delta) NsChange() float64 {
+	if !d.HasPrev || d.Previous.NsPerOp == 0 {
+		return 0
+	}
+	return (d.Current.NsPerOp - d.Previous.NsPerOp) / d.Previous.NsPerOp
+}
+
+// AllocsChange is the difference in allocs/op.
+func (d Delta) AllocsChange() int64 {
+	if !d.HasPrev {
+		return 0
+	}
+	return d.Current.AllocsPerOp - d.Previous.AllocsPerOp
+}
+
+// Regressed reports whether the benchmark got meaningfully worse.
+func (d Delta) Regressed() bool {
+	return d.NsChange() > Tolerance || d.AllocsChange() > 0
+}
+
+// Compare pairs each current result with the same benchmark in prev.
+func Compare(prev, cur []Result) []Delta {
+	byName := make(map[string]Result, len(prev))
+	for _, r := range prev {
+		byName[r.Name] = r
+	}
+
+	deltas := make([]Delta, 0, len(cur))
+	for _, r := range cur {
+		p, ok := byName[r.Name]
+		deltas = append(deltas, Delta{Name: r.Name, Current: r, Previous: p, HasPrev: ok})
+	}
+	return deltas
+}
@@ -0,0 +1,86 @@
+package bench
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Record is one `learngo bench` invocation for one exercise.
+type Record struct {
+	Time    time.Time `json:"time"`
+	Results []Result  `json:"results"`
+}
+
+// History keeps past runs per exercise ID, oldest first.
+type History struct {
+	Exercises map[string][]Record `json:"exercises"`
+}
+
+// DefaultHistoryPath returns where benchmark history is kept, next to
+// the progress file. LEARNGO_BENCH_HISTORY overrides it.
+func DefaultHistoryPath() (string, error) {
+	if env := os.Getenv("LEARNGO_BENCH_HISTORY"); env != "" {
+		return env, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".learn-go", "bench-history.json"), nil
+}
+
+// LoadHistory reads the history file; a missing file is an empty history.
+func LoadHistory(path string) (*History, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return &History{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var h History
+	if err := json.Unmarshal(data, &h); err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	return &h, nil
+}
+
+// Save writes the history to path, creating the directory if needed.
+func (h *History) Save(path string) error {
+	data, err := json.MarshalIndent(h, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// Last returns the most recent run for id, if any.
+func (h *History) Last(id string) (Record, bool) {
+	runs := h.Exercises[id]
+	if len(runs) == 0 {
+		return Record{}, false
+	}
+	return runs[len(runs)-1], true
+}
+
+// Add appends a run for id.
+func (h *History) Add(id string, run Record) {
+	if h.Exercises == nil {
+		h.Exercises = map[string][]Record{}
+	}
+	h.Exercises[id] = append(h.Exercises[id], run)
+}
@@ -0,0 +1,105 @@
+package bench
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Hotspot is one row of `go tool pprof -top`: a function and how much
+// of the profile it accounts for. Flat counts only the function's own
+// code; Cum (cumulative) adds everything it calls.
+type Hotspot struct {
+	Func    string
+	Flat    string // with its unit: "70ms", "1.14GB"
+	FlatPct float64
+	Cum     string
+	CumPct  float64
+}
+
+// DefaultProfileDir returns where `learngo profile` writes its files.
+// LEARNGO_PROFILES overrides the default.
+func DefaultProfileDir() (string, error) {
+	if env := os.Getenv("LEARNGO_PROFILES"); env != "" {
+		return env, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".learn-go", "profiles"), nil
+}
+
+// ProfileArgs are the `go test` flags that write a CPU profile, a
+// memory profile and the test binary (which pprof reads function names
+// from) into dir. Pass them to Run.
+func ProfileArgs(dir string) []string {
+	return []string{
+		"-cpuprofile", filepath.Join(dir, "cpu.pprof"),
+		"-memprofile", filepath.Join(dir, "mem.pprof"),
+		"-o", filepath.Join(dir, "bench.test"),
+	}
+}
+
+// Top runs `go tool pprof -top` on one of the profiles in dir ("cpu" or
+// "mem") and returns the n functions with the most flat time or memory.
+// Memory is counted as bytes allocated over the whole run, which is
+// what a benchmark cares about, rather than bytes still in use at the
+// end.
+func Top(ctx context.Context, dir, kind string, n int) ([]Hotspot, error) {
+	args := []string{"tool", "pprof", "-top", "-nodecount=" + strconv.Itoa(n)}
+	if kind == "mem" {
+		args = append(args, "-sample_index=alloc_space")
+	}
+	// Go profiles carry their function names, so the binary is optional.
+	if bin := filepath.Join(dir, "bench.test"); fileExists(bin) {
+		args = append(args, bin)
+	}
+	args = append(args, filepath.Join(dir, kind+".pprof"))
+	cmd := exec.CommandContext(ctx, "go", args...)
+	out, err := cmd.Output()
+	var exit *exec.ExitError
+	if errors.As(err, &exit) {
+		return nil, fmt.Errorf("go tool pprof failed:\n%s", strings.TrimSpace(string(exit.Stderr)))
+	}
+	if err != nil {
+		return nil, err
+	}
+	return ParseTop(strings.NewReader(string(out)))
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// topLine matches e.g.
+//
+//	70ms 30.43% 30.43%      230ms   100%  example.com/fx.MapInts (inline)
+var topLine = regexp.MustCompile(`^(\S+)\s+([\d.]+)%\s+[\d.]+%\s+(\S+)\s+([\d.]+)%\s+(.+?)(?: \(inline\))?$`)
+
+// ParseTop extracts the rows of `go tool pprof -top` output. The header
+// lines (File:, Type:, Showing nodes...) are ignored.
+func ParseTop(r io.Reader) ([]Hotspot, error) {
+	var spots []Hotspot
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		m := topLine.FindStringSubmatch(strings.TrimSpace(sc.Text()))
+		if m == nil {
+			continue
+		}
+		h := Hotspot{Func: m[5], Flat: m[1], Cum: m[3]}
+		h.FlatPct, _ = strconv.ParseFloat(m[2], 64)
+		h.CumPct, _ = strconv.ParseFloat(m[4], 64)
+		spots = append(spots, h)
+	}
+	return spots, sc.Err()
+}
@@ -0,0 +1,39 @@
+// Package certificate renders the completion certificate you get for
+// finishing every exercise.
+//
+// The certificate is an SVG built with text/template, which is Go's
+// answer to template literals or Handlebars. The template file is
+// compiled into the binary with go:embed, so there's nothing to ship
+// alongside it.
+package certificate
+
+import (
+	_ "embed"
+	"io"
+	"text/template"
+	"time"
+)
+
+//go:embed certificate.svg.tmpl
+var svgSource string
+
+var svgTemplate = template.Must(template.New("certificate").Parse(svgSource))
+
+// Data is what goes on the certificate.
+type Data struct {
+	Name      string
+	Course    string
+	Date      time.Time
+	Exercises int // number of exercises completed
+	Tests     int // number of passing tests
+	Topics    int // number of distinct topics covered
+}
+
+// WriteSVG renders the certificate as SVG. Text fields are escaped, so a
+// name like "Ada <Lovelace>" can't break the markup.
+func WriteSVG(w io.Writer, d Data) error {
+	if d.Course == "" {
+		d.Course = "Learn Go"
+	}
+	return svgTemplate.Execute(w, d)
+}
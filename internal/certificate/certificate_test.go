@@ -0,0 +1,42 @@
+package certificate
+
+import (
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWriteSVG(t *testing.T) {
+	var b strings.Builder
+	err := WriteSVG(&b, Data{
+		Name:      "Ada <Lovelace> & co",
+		Date:      time.Date(2025, time.March, 14, 0, 0, 0, 0, time.UTC),
+		Exercises: 8,
+		Tests:     87,
+		Topics:    25,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	svg := b.String()
+
+	for _, want := range []string{"Ada &lt;Lovelace&gt; &amp; co", "March 14, 2025", "8 exercises", "87 passing tests", "Learn Go"} {
+		if !strings.Contains(svg, want) {
+			t.Errorf("missing %q in:\n%s", want, svg)
+		}
+	}
+
+	// The output must be well-formed XML, or browsers refuse to show it.
+	dec := xml.NewDecoder(strings.NewReader(svg))
+	for {
+		_, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("invalid XML: %v", err)
+		}
+	}
+}
@@ -0,0 +1,190 @@
+// Package constraint is a go/analysis pass that enforces the per-exercise
+// rules declared in registry.Exercise.Constraints, like "04-collections
+// must not import slices".
+//
+// go/analysis is the framework behind `go vet` and gopls: an Analyzer
+// gets one type-checked package at a time and reports diagnostics. It's
+// roughly what an ESLint rule is in JS land.
+package constraint
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/checker"
+	"golang.org/x/tools/go/packages"
+	"golang.org/x/tools/go/types/typeutil"
+
+	"github.com/imgarylai/learn-go/internal/registry"
+)
+
+// Analyzer checks the package against the constraints of the exercise it
+// belongs to, found from the last element of its import path. Packages
+// that aren't exercises are left alone, and so are _test.go files.
+var Analyzer = &analysis.Analyzer{
+	Name: "constraint",
+	Doc:  "check that exercise solutions use the technique the exercise teaches",
+	Run:  run,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	e, ok := registry.Lookup(path.Base(pass.Pkg.Path()))
+	if !ok || path.Base(pass.Pkg.Path()) != e.ID || len(e.Constraints) == 0 {
+		return nil, nil
+	}
+
+	for _, file := range pass.Files {
+		if strings.HasSuffix(pass.Fset.File(file.Pos()).Name(), "_test.go") {
+			continue
+		}
+		for _, c := range e.Constraints {
+			if c.NoImport != "" {
+				checkImport(pass, file, c)
+			}
+			if c.NoCall != "" {
+				checkCalls(pass, file, c)
+			}
+		}
+	}
+	return nil, nil
+}
+
+func checkImport(pass *analysis.Pass, file *ast.File, c registry.Constraint) {
+	for _, imp := range file.Imports {
+		if p, _ := strconv.Unquote(imp.Path.Value); p == c.NoImport {
+			pass.Reportf(imp.Pos(), "don't import %s: %s", p, c.Why)
+		}
+	}
+}
+
+func checkCalls(pass *analysis.Pass, file *ast.File, c registry.Constraint) {
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Body == nil || (c.In != "" && fn.Name.Name != c.In) {
+			continue
+		}
+		ast.Inspect(fn.Body, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			if callee, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func); ok && callee.FullName() == c.NoCall {
+				pass.Reportf(call.Pos(), "%s calls %s: %s", fn.Name.Name, c.NoCall, c.Why)
+			}
+			return true
+		})
+	}
+}
+
+// Describe is a one-line summary of c, for listing the rules.
+func Describe(c registry.Constraint) string {
+	switch {
+	case c.NoImport != "":
+		return fmt.Sprintf("no import of %s", c.NoImport)
+	case c.In != "":
+		return fmt.Sprintf("%s must not call %s", c.In, c.NoCall)
+	default:
+		return fmt.Sprintf("no calls to %s", c.NoCall)
+	}
+}
+
+// Violation is one broken rule.
+type Violation struct {
+	Pos     token.Position
+	Message string
+}
+
+func (v Violation) String() string { return fmt.Sprintf("%s: %s", v.Pos, v.Message) }
+
+// Result is what Check found in one package.
+type Result struct {
+	Dir        string
+	Violations []Violation
+	Err        error // the package didn't load or type-check
+}
+
+// Check loads the packages in dirs (relative to root, like
+// "exercises/04-collections") and runs Analyzer on each. A package that
+// doesn't compile gets an Err instead of stopping the whole check.
+// Positions in violations are relative to root.
+func Check(root string, dirs ...string) ([]Result, error) {
+	cfg := &packages.Config{Mode: packages.LoadAllSyntax, Dir: root}
+	patterns := make([]string, len(dirs))
+	for i, d := range dirs {
+		patterns[i] = "./" + filepath.ToSlash(d)
+	}
+	pkgs, err := packages.Load(cfg, patterns...)
+	if err != nil {
+		return nil, err
+	}
+
+	absRoot, err := filepath.Abs(root)
+	if err != nil {
+		return nil, err
+	}
+	byDir := map[string]*Result{}
+	results := make([]Result, len(dirs))
+	for i, d := range dirs {
+		results[i].Dir = d
+		byDir[filepath.Join(absRoot, d)] = &results[i]
+	}
+
+	var ok []*packages.Package
+	for _, p := range pkgs {
+		res := resultFor(byDir, p)
+		if res == nil {
+			continue
+		}
+		if len(p.Errors) > 0 {
+			res.Err = fmt.Errorf("doesn't compile: %v", p.Errors[0])
+			continue
+		}
+		ok = append(ok, p)
+	}
+	if len(ok) == 0 {
+		return results, nil
+	}
+
+	graph, err := checker.Analyze([]*analysis.Analyzer{Analyzer}, ok, nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, act := range graph.Roots {
+		res := resultFor(byDir, act.Package)
+		if res == nil {
+			continue
+		}
+		if act.Err != nil {
+			res.Err = act.Err
+			continue
+		}
+		for _, d := range act.Diagnostics {
+			pos := act.Package.Fset.Position(d.Pos)
+			if rel, err := filepath.Rel(absRoot, pos.Filename); err == nil {
+				pos.Filename = rel
+			}
+			res.Violations = append(res.Violations, Violation{Pos: pos, Message: d.Message})
+		}
+	}
+	return results, nil
+}
+
+// resultFor finds the Result for the directory p lives in.
+func resultFor(byDir map[string]*Result, p *packages.Package) *Result {
+	if len(p.GoFiles) > 0 {
+		return byDir[filepath.Dir(p.GoFiles[0])]
+	}
+	for dir, res := range byDir {
+		if path.Base(p.PkgPath) == filepath.Base(dir) {
+			return res
+		}
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package constraint
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+// The testdata packages are named after real exercises, so they pick up
+// the constraints declared in the registry. Expected diagnostics are
+// marked with `// want "regexp"` comments.
+func TestAnalyzer(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), Analyzer,
+		"04-collections", "06-concurrency", "07-file-processing", "12-clock")
+}
+
+func TestCheck(t *testing.T) {
+	root := t.TempDir()
+	write := func(name, src string) {
+		t.Helper()
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(src), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("go.mod", "module example.com/course\n\ngo 1.24\n")
+	write("exercises/04-collections/c.go", "package collections\n\nimport \"slices\"\n\nvar Max = slices.Max[[]int]\n")
+	write("exercises/05-interfaces/i.go", "package interfaces\n\nvar x int = \"broken\"\n")
+
+	results, err := Check(root, filepath.Join("exercises", "04-collections"), filepath.Join("exercises", "05-interfaces"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("got %d results, want 2", len(results))
+	}
+
+	vs := results[0].Violations
+	if len(vs) != 1 {
+		t.Fatalf("04: got %v, want one violation", vs)
+	}
+	if want := filepath.Join("exercises", "04-collections", "c.go") + ":3:8"; !strings.HasPrefix(vs[0].String(), want) {
+		t.Errorf("04: got %q, want it to start with %q", vs[0], want)
+	}
+	if results[1].Err == nil {
+		t.Error("05 doesn't compile and should report an error")
+	}
+}
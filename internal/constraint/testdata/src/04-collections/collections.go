@@ -0,0 +1,14 @@
+package collections
+
+import (
+	"slices" // want `don't import slices: write the loops yourself`
+	"strings"
+)
+
+func Max(nums []int) int {
+	return slices.Max(nums)
+}
+
+func Shout(s string) string {
+	return strings.ToUpper(s)
+}
@@ -0,0 +1,6 @@
+package collections
+
+// Tests may use whatever they like.
+import "slices"
+
+var _ = slices.Max[[]int]
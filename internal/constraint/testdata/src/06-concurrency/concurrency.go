@@ -0,0 +1,25 @@
+package concurrency
+
+import (
+	"sync"
+	"time"
+)
+
+func Wait(work func()) {
+	go work()
+	time.Sleep(100 * time.Millisecond) // want `Wait calls time.Sleep: sleeping isn't synchronization`
+}
+
+func WaitProperly(work func()) {
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		work()
+	}()
+	wg.Wait()
+}
+
+func Timeout() <-chan time.Time {
+	return time.After(time.Second) // other time functions are fine
+}
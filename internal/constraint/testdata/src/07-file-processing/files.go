@@ -0,0 +1,19 @@
+package fileprocessing
+
+import (
+	"bytes"
+	"os"
+)
+
+func CountLines(filename string) (int, error) {
+	data, err := os.ReadFile(filename) // want `CountLines calls os.ReadFile: stream the file`
+	if err != nil {
+		return 0, err
+	}
+	return bytes.Count(data, []byte("\n")), nil
+}
+
+// ReadConfig isn't CountLines, so it may read the whole file.
+func ReadConfig(filename string) ([]byte, error) {
+	return os.ReadFile(filename)
+}
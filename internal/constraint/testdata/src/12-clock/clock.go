@@ -0,0 +1,28 @@
+package clock
+
+import "time"
+
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+type Token struct{ ExpiresAt time.Time }
+
+func IsExpired(tok Token, c Clock) bool {
+	return !time.Now().Before(tok.ExpiresAt) // want `IsExpired calls time.Now: use the Clock`
+}
+
+func WaitOrTimeout(c Clock, done <-chan struct{}, timeout time.Duration) bool {
+	select {
+	case <-done:
+		return true
+	case <-time.After(timeout): // want `WaitOrTimeout calls time.After: use the Clock`
+		return false
+	}
+}
+
+type RealClock struct{}
+
+// The real clock is where the time package belongs.
+func (RealClock) Now() time.Time { return time.Now() }
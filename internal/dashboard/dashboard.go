@@ -0,0 +1,255 @@
+// Package dashboard is the local web page behind `learngo serve`: your
+// progress, the output of the last test run and how often you needed a
+// hint, one row per exercise, and the badges you've earned. A button runs an exercise's tests with the
+// same engine as the CLI, so it's `learngo run` with a UI, the way
+// `vitest --ui` sits on top of vitest.
+package dashboard
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/imgarylai/learn-go/internal/achievements"
+	"github.com/imgarylai/learn-go/internal/progress"
+	"github.com/imgarylai/learn-go/internal/registry"
+	"github.com/imgarylai/learn-go/internal/runner"
+)
+
+//go:embed dashboard.html.tmpl
+var pageSource string
+
+var pages = template.Must(template.New("dashboard").Funcs(template.FuncMap{
+	"percent":  func(share float64) int { return int(share*100 + 0.5) },
+	"duration": formatDuration,
+}).Parse(pageSource))
+
+// Options wires the dashboard to the rest of learngo.
+type Options struct {
+	Exercises []registry.Exercise
+
+	// Progress reads the progress file. It's called for every page, so
+	// changes made with the CLI show up on reload.
+	Progress func() (*progress.File, error)
+
+	// Run tests an exercise and records the result in the progress
+	// file, like `learngo run` does.
+	Run func(ctx context.Context, e registry.Exercise) (runner.Result, error)
+
+	// Now is the clock; nil means time.Now.
+	Now func() time.Time
+}
+
+// Handler serves the dashboard:
+//
+//	GET  /                    every exercise
+//	GET  /exercises/{id}      one exercise, with the last test output
+//	POST /exercises/{id}/run  run its tests, then back to its page
+//	GET  /api/exercises       the overview as JSON
+type Handler struct {
+	opts Options
+	mux  *http.ServeMux
+
+	mu   sync.Mutex
+	last map[string]Run // by exercise ID; only kept while the server runs
+}
+
+// Run is the last test run of an exercise from the dashboard.
+type Run struct {
+	Result runner.Result
+	At     time.Time
+}
+
+// Summary is the one-line outcome, as `learngo run` prints it.
+func (r Run) Summary() string {
+	if r.Result.BuildFailed {
+		return "build failed"
+	}
+	pass, fail, _ := r.Result.Counts()
+	return fmt.Sprintf("%d passed, %d failed", pass, fail)
+}
+
+// NewHandler returns a Handler for opts.
+func NewHandler(opts Options) *Handler {
+	h := &Handler{opts: opts, mux: http.NewServeMux(), last: map[string]Run{}}
+	h.mux.HandleFunc("GET /{$}", h.index)
+	h.mux.HandleFunc("GET /exercises/{id}", h.exercise)
+	h.mux.HandleFunc("POST /exercises/{id}/run", h.run)
+	h.mux.HandleFunc("GET /api/exercises", h.api)
+	return h
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+// Row is what the dashboard shows for one exercise.
+type Row struct {
+	ID         string              `json:"id"`
+	Title      string              `json:"title"`
+	Difficulty string              `json:"difficulty"`
+	Status     progress.Status     `json:"status,omitempty"`
+	Completion float64             `json:"completion"` // share of tests passing in the last recorded run
+	Tested     bool                `json:"tested"`     // whether Completion is known
+	TimeSpent  time.Duration       `json:"time_spent_ns"`
+	Hints      int                 `json:"hints"`
+	Hinted     bool                `json:"-"`              // the exercise has hints to ask for
+	Quiz       *progress.QuizScore `json:"quiz,omitempty"` // last `learngo quiz`
+
+	Last *Run `json:"-"`
+}
+
+func (h *Handler) rows() ([]Row, error) {
+	prog, err := h.opts.Progress()
+	if err != nil {
+		return nil, err
+	}
+	return h.rowsFor(prog), nil
+}
+
+func (h *Handler) rowsFor(prog *progress.File) []Row {
+	now := h.now()
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	rows := make([]Row, 0, len(h.opts.Exercises))
+	for _, e := range h.opts.Exercises {
+		row := Row{
+			ID:         e.ID,
+			Title:      e.Title,
+			Difficulty: string(e.Difficulty),
+			Status:     prog.Status(e.ID),
+			Hinted:     len(e.Hints) > 0,
+		}
+		row.Completion, row.Tested = prog.Completion(e.ID)
+		if pe, ok := prog.Exercises[e.ID]; ok {
+			row.TimeSpent = pe.TimeSpent(now)
+			row.Hints = pe.Hints
+			row.Quiz = pe.Quiz
+		}
+		if last, ok := h.last[e.ID]; ok {
+			row.Last = &last
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// Badge is one achievement on the overview; Earned is zero until you
+// earn it.
+type Badge struct {
+	Title       string
+	Description string
+	Earned      time.Time
+}
+
+func badges(prog *progress.File) (all []Badge, earned int) {
+	for _, b := range achievements.All {
+		at, ok := prog.Badges[b.ID]
+		if ok {
+			earned++
+		}
+		all = append(all, Badge{Title: b.Title, Description: b.Description, Earned: at})
+	}
+	return all, earned
+}
+
+func (h *Handler) index(w http.ResponseWriter, r *http.Request) {
+	prog, err := h.opts.Progress()
+	if err != nil {
+		serverError(w, err)
+		return
+	}
+	all, earned := badges(prog)
+	render(w, "index", struct {
+		Rows   []Row
+		Badges []Badge
+		Earned int
+	}{h.rowsFor(prog), all, earned})
+}
+
+func (h *Handler) exercise(w http.ResponseWriter, r *http.Request) {
+	rows, err := h.rows()
+	if err != nil {
+		serverError(w, err)
+		return
+	}
+	for _, row := range rows {
+		if row.ID == r.PathValue("id") {
+			render(w, "exercise", row)
+			return
+		}
+	}
+	http.NotFound(w, r)
+}
+
+func (h *Handler) run(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	var ex *registry.Exercise
+	for i := range h.opts.Exercises {
+		if h.opts.Exercises[i].ID == id {
+			ex = &h.opts.Exercises[i]
+		}
+	}
+	if ex == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	res, err := h.opts.Run(r.Context(), *ex)
+	if err != nil {
+		serverError(w, err)
+		return
+	}
+	h.mu.Lock()
+	h.last[id] = Run{Result: res, At: h.now()}
+	h.mu.Unlock()
+	// Post/Redirect/Get, so reloading the page doesn't rerun the tests.
+	http.Redirect(w, r, "/exercises/"+id, http.StatusSeeOther)
+}
+
+func (h *Handler) api(w http.ResponseWriter, r *http.Request) {
+	rows, err := h.rows()
+	if err != nil {
+		serverError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(rows); err != nil {
+		log.Printf("writing response: %v", err)
+	}
+}
+
+func (h *Handler) now() time.Time {
+	if h.opts.Now != nil {
+		return h.opts.Now()
+	}
+	return time.Now()
+}
+
+// formatDuration prints a duration the way `learngo stats` does: "1h05m", "12m".
+func formatDuration(d time.Duration) string {
+	d = d.Round(time.Minute)
+	if d >= time.Hour {
+		return fmt.Sprintf("%dh%02dm", d/time.Hour, d%time.Hour/time.Minute)
+	}
+	return fmt.Sprintf("%dm", d/time.Minute)
+}
+
+func render(w http.ResponseWriter, name string, data any) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := pages.ExecuteTemplate(w, name, data); err != nil {
+		log.Printf("rendering %s: %v", name, err)
+	}
+}
+
+func serverError(w http.ResponseWriter, err error) {
+	log.Print(err)
+	http.Error(w, "internal error", http.StatusInternalServerError)
+}
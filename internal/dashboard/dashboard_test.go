@@ -0,0 +1,145 @@
+package dashboard
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/imgarylai/learn-go/internal/progress"
+	"github.com/imgarylai/learn-go/internal/registry"
+	"github.com/imgarylai/learn-go/internal/runner"
+)
+
+func newServer(t *testing.T, prog *progress.File, run func(registry.Exercise) runner.Result) *httptest.Server {
+	t.Helper()
+	exercises := registry.All()[:2]
+	h := NewHandler(Options{
+		Exercises: exercises,
+		Progress:  func() (*progress.File, error) { return prog, nil },
+		Run: func(_ context.Context, e registry.Exercise) (runner.Result, error) {
+			return run(e), nil
+		},
+		Now: func() time.Time { return time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC) },
+	})
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func get(t *testing.T, url string) (int, string) {
+	t.Helper()
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp.StatusCode, string(body)
+}
+
+func TestOverview(t *testing.T) {
+	prog := &progress.File{}
+	e := prog.Get("01-basics")
+	e.Status = progress.Started
+	e.Hints = 3
+	e.RecordTests([]string{"TestA"}, 4)
+	prog.Badges = map[string]time.Time{"perfect-quiz": time.Date(2025, 2, 14, 0, 0, 0, 0, time.UTC)}
+
+	srv := newServer(t, prog, nil)
+	code, body := get(t, srv.URL+"/")
+	if code != http.StatusOK {
+		t.Fatalf("GET /: %d", code)
+	}
+	for _, want := range []string{
+		"01-basics", "02-functions", "started", "25%", "not started",
+		"Badges <span class=\"dim\">1 of", "<strong>Perfect quiz</strong> <span class=\"dim\">earned 2025-02-14",
+		"First green module: every test of one exercise passes",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("page is missing %q", want)
+		}
+	}
+
+	_, body = get(t, srv.URL+"/api/exercises")
+	var rows []Row
+	if err := json.Unmarshal([]byte(body), &rows); err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 2 || rows[0].Hints != 3 || !rows[0].Tested || rows[0].Completion != 0.25 || rows[1].Tested {
+		t.Errorf("api: %+v", rows)
+	}
+}
+
+func TestRunShowsLastOutput(t *testing.T) {
+	prog := &progress.File{}
+	var ran []string
+	srv := newServer(t, prog, func(e registry.Exercise) runner.Result {
+		ran = append(ran, e.ID)
+		return runner.Result{Tests: []runner.Test{
+			{Name: "TestA", Status: runner.Pass},
+			{Name: "TestB", Status: runner.Fail, Output: []string{"    basics_test.go:12: got 1, want 2"}},
+		}}
+	})
+
+	_, body := get(t, srv.URL+"/exercises/02-functions")
+	if !strings.Contains(body, "No run since the server started") {
+		t.Errorf("before a run:\n%s", body)
+	}
+
+	resp, err := http.Post(srv.URL+"/exercises/02-functions/run", "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body2, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	// The client follows the redirect back to the exercise page.
+	if resp.StatusCode != http.StatusOK || resp.Request.URL.Path != "/exercises/02-functions" {
+		t.Fatalf("POST run: %d, ended at %s", resp.StatusCode, resp.Request.URL.Path)
+	}
+	if len(ran) != 1 || ran[0] != "02-functions" {
+		t.Errorf("ran %v", ran)
+	}
+	for _, want := range []string{"FAIL: 1 passed, 1 failed", "TestB", "got 1, want 2"} {
+		if !strings.Contains(string(body2), want) {
+			t.Errorf("exercise page is missing %q:\n%s", want, body2)
+		}
+	}
+	if _, body := get(t, srv.URL+"/"); !strings.Contains(body, "1 passed, 1 failed") {
+		t.Error("the overview should show the last run")
+	}
+}
+
+func TestUnknownExercise(t *testing.T) {
+	srv := newServer(t, &progress.File{}, nil)
+	if code, _ := get(t, srv.URL+"/exercises/99-nope"); code != http.StatusNotFound {
+		t.Errorf("GET: %d", code)
+	}
+	resp, err := http.Post(srv.URL+"/exercises/99-nope/run", "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("POST: %d", resp.StatusCode)
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	for d, want := range map[time.Duration]string{
+		40 * time.Second:             "1m",
+		12 * time.Minute:             "12m",
+		65*time.Minute + time.Second: "1h05m",
+	} {
+		if got := formatDuration(d); got != want {
+			t.Errorf("formatDuration(%v) = %q, want %q", d, got, want)
+		}
+	}
+}
@@ -0,0 +1,200 @@
+// Package fixtures generates the CSV and JSON datasets the exercises read
+// (people, products, employees, sales) from a seeded random source, for
+// `cmd/gen-testdata`.
+//
+// The same seed and row count always give the same bytes, the way a
+// faker.js script with `faker.seed(42)` does, so a generated dataset can
+// be committed and regenerated later without a noisy diff.
+package fixtures
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"math"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+)
+
+// Kind is how a column is typed in JSON. CSV is all text either way.
+type Kind int
+
+const (
+	String Kind = iota
+	Int
+	Float
+)
+
+// Column is one field of a dataset.
+type Column struct {
+	Name string
+	Kind Kind
+}
+
+// Dataset describes one fixture file: its columns, in the order the
+// exercises' readers expect, and how to make a row.
+type Dataset struct {
+	Name    string // also the file name without its extension
+	Columns []Column
+	row     func(r *rand.Rand, i int) []string
+}
+
+// Datasets lists every dataset, matching the structs in the exercises:
+// Person and Product in 07-file-processing, Employee and Sale in
+// 08-data-processing (whose sales columns 14-capstone shares).
+var Datasets = []Dataset{
+	{
+		Name:    "people",
+		Columns: []Column{{"name", String}, {"age", Int}, {"email", String}},
+		row: func(r *rand.Rand, i int) []string {
+			name := pick(r, firstNames)
+			email := fmt.Sprintf("%s%d@example.com", strings.ToLower(name), i+1)
+			return []string{name, strconv.Itoa(18 + r.IntN(53)), email}
+		},
+	},
+	{
+		Name:    "products",
+		Columns: []Column{{"id", Int}, {"name", String}, {"price", Float}, {"category", String}},
+		row: func(r *rand.Rand, i int) []string {
+			p := pick(r, catalog)
+			return []string{strconv.Itoa(i + 1), p.name, price(r, p), p.category}
+		},
+	},
+	{
+		Name:    "employees",
+		Columns: []Column{{"id", Int}, {"name", String}, {"department", String}, {"salary", Int}, {"years", Int}},
+		row: func(r *rand.Rand, i int) []string {
+			salary := 40_000 + 1_000*r.IntN(121)
+			return []string{strconv.Itoa(i + 1), pick(r, firstNames), pick(r, departments), strconv.Itoa(salary), strconv.Itoa(r.IntN(31))}
+		},
+	},
+	{
+		Name:    "sales",
+		Columns: []Column{{"product", String}, {"quantity", Int}, {"price", Float}, {"region", String}},
+		row: func(r *rand.Rand, i int) []string {
+			p := pick(r, catalog)
+			return []string{p.name, strconv.Itoa(1 + r.IntN(50)), price(r, p), pick(r, regions)}
+		},
+	},
+}
+
+// Lookup finds a dataset by name.
+func Lookup(name string) (Dataset, bool) {
+	for _, d := range Datasets {
+		if d.Name == name {
+			return d, true
+		}
+	}
+	return Dataset{}, false
+}
+
+// Names lists the dataset names, for usage messages.
+func Names() []string {
+	names := make([]string, len(Datasets))
+	for i, d := range Datasets {
+		names[i] = d.Name
+	}
+	return names
+}
+
+// Rows returns n rows generated from seed. Each dataset mixes its name
+// into the seed, so people and employees don't share a sequence of names.
+func (d Dataset) Rows(seed uint64, n int) [][]string {
+	h := fnv.New64a()
+	h.Write([]byte(d.Name))
+	r := rand.New(rand.NewPCG(seed, h.Sum64()))
+	rows := make([][]string, n)
+	for i := range rows {
+		rows[i] = d.row(r, i)
+	}
+	return rows
+}
+
+// WriteCSV writes a header line and then rows.
+func (d Dataset) WriteCSV(w io.Writer, rows [][]string) error {
+	cw := csv.NewWriter(w)
+	header := make([]string, len(d.Columns))
+	for i, c := range d.Columns {
+		header[i] = c.Name
+	}
+	cw.Write(header)
+	cw.WriteAll(rows) // flushes, and reports any error from either call
+	return cw.Error()
+}
+
+// WriteJSON writes rows as an indented array of objects, keys in column
+// order, with numbers as JSON numbers: what encoding/json makes of the
+// exercises' structs.
+func (d Dataset) WriteJSON(w io.Writer, rows [][]string) error {
+	var buf bytes.Buffer
+	buf.WriteString("[")
+	for i, row := range rows {
+		if i > 0 {
+			buf.WriteString(",")
+		}
+		buf.WriteString("\n  {")
+		for j, c := range d.Columns {
+			if j > 0 {
+				buf.WriteString(", ")
+			}
+			key, _ := json.Marshal(c.Name)
+			buf.Write(key)
+			buf.WriteString(": ")
+			if c.Kind == String {
+				v, _ := json.Marshal(row[j])
+				buf.Write(v)
+			} else {
+				buf.WriteString(row[j]) // already a valid JSON number
+			}
+		}
+		buf.WriteString("}")
+	}
+	if len(rows) > 0 {
+		buf.WriteString("\n")
+	}
+	buf.WriteString("]\n")
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+type product struct {
+	name, category string
+	minPrice       float64 // prices vary up to 20% above this
+}
+
+var catalog = []product{
+	{"Laptop", "Electronics", 899.99},
+	{"Headphones", "Electronics", 59.99},
+	{"Monitor", "Electronics", 179.99},
+	{"Keyboard", "Electronics", 39.99},
+	{"Coffee Mug", "Kitchen", 9.99},
+	{"Water Bottle", "Kitchen", 14.99},
+	{"Frying Pan", "Kitchen", 29.99},
+	{"Notebook", "Office", 3.99},
+	{"Desk Lamp", "Office", 29.99},
+	{"Stapler", "Office", 7.49},
+}
+
+var (
+	firstNames = []string{
+		"Alice", "Bob", "Charlie", "Diana", "Eve", "Frank", "Grace", "Henry",
+		"Ivy", "Jack", "Kenji", "Lina", "Mateo", "Nora", "Omar", "Priya",
+	}
+	departments = []string{"Engineering", "Marketing", "Sales", "Support", "Finance"}
+	regions     = []string{"North", "South", "East", "West"}
+)
+
+func pick[T any](r *rand.Rand, from []T) T {
+	return from[r.IntN(len(from))]
+}
+
+// price is p's price plus up to 20%, in whole cents.
+func price(r *rand.Rand, p product) string {
+	base := int(math.Round(p.minPrice * 100))
+	cents := base + r.IntN(base/5+1)
+	return fmt.Sprintf("%d.%02d", cents/100, cents%100)
+}
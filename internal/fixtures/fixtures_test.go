@@ -0,0 +1,89 @@
+package fixtures
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestRowsAreDeterministic(t *testing.T) {
+	for _, d := range Datasets {
+		a, b := d.Rows(42, 50), d.Rows(42, 50)
+		if !reflect.DeepEqual(a, b) {
+			t.Errorf("%s: the same seed gave different rows", d.Name)
+		}
+		if reflect.DeepEqual(a, d.Rows(43, 50)) {
+			t.Errorf("%s: different seeds gave the same rows", d.Name)
+		}
+	}
+}
+
+func TestCSVRoundTrip(t *testing.T) {
+	for _, d := range Datasets {
+		var buf bytes.Buffer
+		if err := d.WriteCSV(&buf, d.Rows(1, 20)); err != nil {
+			t.Fatal(err)
+		}
+		records, err := csv.NewReader(&buf).ReadAll()
+		if err != nil {
+			t.Fatalf("%s: %v", d.Name, err)
+		}
+		if len(records) != 21 {
+			t.Fatalf("%s: got %d records, want a header and 20 rows", d.Name, len(records))
+		}
+		for i, c := range d.Columns {
+			if records[0][i] != c.Name {
+				t.Errorf("%s: header %v", d.Name, records[0])
+			}
+			for _, rec := range records[1:] {
+				if !valid(c.Kind, rec[i]) {
+					t.Errorf("%s: %s = %q isn't a valid %v", d.Name, c.Name, rec[i], c.Kind)
+				}
+			}
+		}
+	}
+}
+
+func valid(k Kind, s string) bool {
+	var err error
+	switch k {
+	case Int:
+		_, err = strconv.Atoi(s)
+	case Float:
+		_, err = strconv.ParseFloat(s, 64)
+	}
+	return err == nil && s != ""
+}
+
+func TestJSONMatchesTheExerciseStructs(t *testing.T) {
+	// The same shape as 07-file-processing's Product.
+	type product struct {
+		ID       int     `json:"id"`
+		Name     string  `json:"name"`
+		Price    float64 `json:"price"`
+		Category string  `json:"category"`
+	}
+	d, _ := Lookup("products")
+	rows := d.Rows(7, 5)
+	var buf bytes.Buffer
+	if err := d.WriteJSON(&buf, rows); err != nil {
+		t.Fatal(err)
+	}
+	var got []product
+	dec := json.NewDecoder(&buf)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&got); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 5 || got[0].ID != 1 || got[0].Name != rows[0][1] || got[4].ID != 5 {
+		t.Errorf("got %+v", got)
+	}
+
+	buf.Reset()
+	if err := d.WriteJSON(&buf, nil); err != nil || buf.String() != "[]\n" {
+		t.Errorf("no rows: %q, %v", buf.String(), err)
+	}
+}
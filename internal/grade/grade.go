@@ -0,0 +1,138 @@
+// Package grade turns test results into a score with partial credit.
+//
+// Each top-level test is worth a number of points: 1 unless the exercise
+// declares otherwise in registry.Exercise.Weights. A passing test earns
+// its points, anything else earns nothing, and the rubric lists every
+// test so students can see where the points went. It's the Go version of
+// the rubric spreadsheet a TA would keep next to the jest output.
+package grade
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/imgarylai/learn-go/internal/registry"
+	"github.com/imgarylai/learn-go/internal/runner"
+)
+
+// Item is one row of the rubric.
+type Item struct {
+	Test   string        `json:"test"`
+	Status runner.Status `json:"status"`
+	Points float64       `json:"points"`
+	Earned float64       `json:"earned"`
+	// Hidden marks a test the student never saw (see package hidden).
+	Hidden bool `json:"hidden,omitempty"`
+}
+
+// Rubric is the breakdown for one exercise.
+type Rubric struct {
+	Items []Item
+	Score float64
+	Max   float64
+}
+
+// Percent is Score as a percentage of Max, or 0 when there's nothing to earn.
+func (r Rubric) Percent() float64 {
+	if r.Max == 0 {
+		return 0
+	}
+	return 100 * r.Score / r.Max
+}
+
+// Grade scores res against e's weights. tests is the full list of
+// top-level tests the exercise should have (see TestNames); a test that
+// never ran, because the build failed or the binary crashed, earns
+// nothing. When tests is nil the tests in res are used instead.
+func Grade(e registry.Exercise, res runner.Result, tests []string) Rubric {
+	ran := map[string]runner.Status{}
+	for _, t := range res.Tests {
+		if !strings.Contains(t.Name, "/") {
+			ran[t.Name] = t.Status
+		}
+	}
+	if tests == nil {
+		for _, t := range res.Tests {
+			if !strings.Contains(t.Name, "/") {
+				tests = append(tests, t.Name)
+			}
+		}
+	}
+
+	var r Rubric
+	for _, name := range tests {
+		st, ok := ran[name]
+		if !ok || res.BuildFailed {
+			st = runner.Fail
+		}
+		it := Item{Test: name, Status: st, Points: e.Points(name)}
+		if st == runner.Pass {
+			it.Earned = it.Points
+		}
+		r.Items = append(r.Items, it)
+		r.Score += it.Earned
+		r.Max += it.Points
+	}
+	return r
+}
+
+// TestNames lists the top-level Test functions in the _test.go files of
+// dir and its subpackages, in file order. It reads the source rather
+// than running `go test -list`, so it works even when the exercise
+// doesn't compile.
+func TestNames(dir string) ([]string, error) {
+	var paths []string
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() && d.Name() == "testdata" {
+			return filepath.SkipDir
+		}
+		if !d.IsDir() && strings.HasSuffix(p, "_test.go") {
+			paths = append(paths, p)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	fset := token.NewFileSet()
+	for _, p := range paths {
+		src, err := os.ReadFile(p)
+		if err != nil {
+			return nil, err
+		}
+		f, err := parser.ParseFile(fset, p, src, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err
+		}
+		for _, d := range f.Decls {
+			fn, ok := d.(*ast.FuncDecl)
+			if ok && fn.Recv == nil && isTest(fn.Name.Name) {
+				names = append(names, fn.Name.Name)
+			}
+		}
+	}
+	return names, nil
+}
+
+// isTest mirrors go test's rule: "Test" followed by nothing or a
+// character that isn't a lowercase letter (so Testify isn't a test).
+func isTest(name string) bool {
+	rest, ok := strings.CutPrefix(name, "Test")
+	if !ok {
+		return false
+	}
+	r, _ := utf8.DecodeRuneInString(rest)
+	return rest == "" || !unicode.IsLower(r)
+}
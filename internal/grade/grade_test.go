@@ -0,0 +1,143 @@
+package grade
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/imgarylai/learn-go/internal/registry"
+	"github.com/imgarylai/learn-go/internal/runner"
+)
+
+func TestGrade(t *testing.T) {
+	e := registry.Exercise{ID: "04-collections", Weights: map[string]float64{"TestHard": 3}}
+	res := runner.Result{Tests: []runner.Test{
+		{Name: "TestEasy", Status: runner.Pass},
+		{Name: "TestHard", Status: runner.Fail},
+		{Name: "TestHard/case", Status: runner.Fail},
+		{Name: "TestSkipped", Status: runner.Skip},
+	}}
+
+	r := Grade(e, res, []string{"TestEasy", "TestHard", "TestSkipped", "TestNeverRan"})
+	if r.Score != 1 || r.Max != 6 {
+		t.Errorf("score: got %v/%v, want 1/6", r.Score, r.Max)
+	}
+	want := []Item{
+		{Test: "TestEasy", Status: runner.Pass, Points: 1, Earned: 1},
+		{Test: "TestHard", Status: runner.Fail, Points: 3},
+		{Test: "TestSkipped", Status: runner.Skip, Points: 1},
+		{Test: "TestNeverRan", Status: runner.Fail, Points: 1},
+	}
+	if !reflect.DeepEqual(r.Items, want) {
+		t.Errorf("items:\ngot  %+v\nwant %+v", r.Items, want)
+	}
+
+	if got := Grade(e, res, nil); got.Max != 5 {
+		t.Errorf("without a test list: max %v, want 5 (the tests that ran)", got.Max)
+	}
+}
+
+func TestGradeBuildFailure(t *testing.T) {
+	r := Grade(registry.Exercise{}, runner.Result{BuildFailed: true}, []string{"TestA", "TestB"})
+	if r.Score != 0 || r.Max != 2 || r.Percent() != 0 {
+		t.Errorf("got %+v", r)
+	}
+}
+
+func TestTestNames(t *testing.T) {
+	dir := t.TempDir()
+	src := "package x\n\nimport \"testing\"\n\n" +
+		"func TestA(t *testing.T) {}\nfunc Testify() {}\nfunc helper() {}\nfunc Test(t *testing.T) {}\nfunc TestÄ(t *testing.T) {}\n"
+	if err := os.WriteFile(filepath.Join(dir, "x_test.go"), []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	got, err := TestNames(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"TestA", "Test", "TestÄ"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+// Every weight in the registry must name a real test, or a typo would
+// silently leave a test at the default of 1 point.
+func TestRegistryWeightsNameRealTests(t *testing.T) {
+	for _, e := range registry.All() {
+		names, err := TestNames(filepath.Join("..", "..", e.Dir()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		known := map[string]bool{}
+		for _, n := range names {
+			known[n] = true
+		}
+		for name, w := range e.Weights {
+			if !known[name] {
+				t.Errorf("%s: weight for unknown test %s", e.ID, name)
+			}
+			if w <= 0 {
+				t.Errorf("%s: %s has weight %v, want > 0", e.ID, name, w)
+			}
+		}
+	}
+}
+
+func TestWeights(t *testing.T) {
+	w, err := ReadWeights(strings.NewReader(`{"06": {"TestWorkerPool": 5, "TestChannelBasics": 0}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	w, err = w.Resolve("../..", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e, _ := registry.Lookup("06-concurrency")
+	e = w.Apply(e)
+
+	for test, want := range map[string]float64{
+		"TestWorkerPool":    5, // overridden
+		"TestChannelBasics": 0, // dropped
+		"TestFanOutFanIn":   3, // the registry's weight
+	} {
+		if got := e.Points(test); got != want {
+			t.Errorf("%s: got %v points, want %v", test, got, want)
+		}
+	}
+	if orig, _ := registry.Lookup("06"); orig.Points("TestWorkerPool") != 3 {
+		t.Error("Apply changed the registry's weights")
+	}
+}
+
+func TestWeightsHidden(t *testing.T) {
+	w, err := ReadWeights(strings.NewReader(`{"06": {"TestPoolNoWorkers": 4}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Resolve("../..", nil); err == nil {
+		t.Error("a test that isn't in the checkout was accepted")
+	}
+	if _, err := w.Resolve("../..", map[string][]string{"06-concurrency": {"TestPoolNoWorkers"}}); err != nil {
+		t.Errorf("a hidden test was rejected: %v", err)
+	}
+}
+
+func TestWeightsErrors(t *testing.T) {
+	for _, tt := range []struct{ json, want string }{
+		{`{"99": {"TestX": 1}}`, "unknown exercise"},
+		{`{"06": {"TestTypo": 1}}`, "no test TestTypo"},
+		{`{"06": {"TestWorkerPool": -1}}`, "negative weight"},
+		{`{"06": {}, "06-concurrency": {}}`, "listed twice"},
+		{`{"06": 3}`, "weights:"},
+	} {
+		w, err := ReadWeights(strings.NewReader(tt.json))
+		if err == nil {
+			_, err = w.Resolve("../..", nil)
+		}
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: got %v, want an error containing %q", tt.json, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package grade
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+
+	"github.com/imgarylai/learn-go/internal/registry"
+)
+
+// Weights lets an instructor regrade without touching the registry:
+// points per top-level test, keyed by exercise and then test name. Tests
+// it doesn't mention keep the registry's weight.
+//
+//	{
+//	  "04-collections": {"TestSum": 3, "TestGenericMap": 0},
+//	  "06": {"TestWorkerPool": 5}
+//	}
+//
+// A weight of 0 leaves a test out of the grade.
+type Weights map[string]map[string]float64
+
+// ReadWeights parses a weights file. Exercise IDs can be short ("06"),
+// as on the command line; see Resolve.
+func ReadWeights(r io.Reader) (Weights, error) {
+	var w Weights
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&w); err != nil {
+		return nil, fmt.Errorf("weights: %w", err)
+	}
+	return w, nil
+}
+
+// Resolve checks w against the exercises in the checkout at root and
+// returns it keyed by full exercise ID. A typo would otherwise silently
+// leave the default weight in place, so unknown exercises and tests,
+// and negative weights, are errors. hidden lists, by full exercise ID,
+// tests that aren't in the checkout but can be weighted too: an
+// instructor's hidden tests.
+func (w Weights) Resolve(root string, hidden map[string][]string) (Weights, error) {
+	out := Weights{}
+	for _, id := range slices.Sorted(maps.Keys(w)) {
+		e, ok := registry.Lookup(id)
+		if !ok {
+			return nil, fmt.Errorf("weights: unknown exercise %q", id)
+		}
+		if _, dup := out[e.ID]; dup {
+			return nil, fmt.Errorf("weights: %s is listed twice", e.ID)
+		}
+		tests, err := TestNames(e.DirIn(root))
+		if err != nil {
+			return nil, err
+		}
+		tests = append(tests, hidden[e.ID]...)
+		for name, pts := range w[id] {
+			if !slices.Contains(tests, name) {
+				return nil, fmt.Errorf("weights: %s has no test %s", e.ID, name)
+			}
+			if pts < 0 {
+				return nil, fmt.Errorf("weights: %s %s: negative weight %v", e.ID, name, pts)
+			}
+		}
+		out[e.ID] = w[id]
+	}
+	return out, nil
+}
+
+// Apply returns e with w's weights for it layered over its own.
+func (w Weights) Apply(e registry.Exercise) registry.Exercise {
+	if len(w[e.ID]) == 0 {
+		return e
+	}
+	merged := maps.Clone(e.Weights)
+	if merged == nil {
+		merged = map[string]float64{}
+	}
+	maps.Copy(merged, w[e.ID])
+	e.Weights = merged
+	return e
+}
@@ -0,0 +1,257 @@
+// Package hidden holds the tests an instructor grades with but doesn't
+// ship: edge cases the students never see, so a solution can't be
+// hard-coded to the visible tests. Think of the private test suite an
+// online judge runs after the sample cases pass.
+//
+// A set of hidden tests is a directory with one folder per exercise:
+//
+//	hidden/
+//	  04-collections/
+//	    sum_test.go       // package collections, func TestSumOverflow...
+//	  06-concurrency/
+//	    pool_test.go
+//
+// It can be handed around as is, or sealed into a single encrypted file
+// (Seal) that's safe to publish next to the exercises: grading then
+// needs the key, which stays with the instructor.
+//
+// The hidden tests never touch the student's checkout. `go test
+// -overlay` makes them appear inside the exercise package for one run.
+package hidden
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// KeyEnv is the environment variable holding the key of a sealed set,
+// as hex.
+const KeyEnv = "LEARNGO_HIDDEN_KEY"
+
+// Prefix starts the name every hidden test file gets inside the
+// exercise package, so it can't clash with a visible one.
+const Prefix = "hidden_"
+
+// File is one hidden test file.
+type File struct {
+	Name string `json:"name"`
+	Data []byte `json:"data"`
+}
+
+// Set is the hidden tests of some exercises, keyed by exercise ID.
+type Set map[string][]File
+
+// LoadDir reads a directory of hidden tests: one subdirectory per
+// exercise, holding _test.go files.
+func LoadDir(dir string) (Set, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	s := Set{}
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		files, err := os.ReadDir(filepath.Join(dir, e.Name()))
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range files {
+			if f.IsDir() || !strings.HasSuffix(f.Name(), "_test.go") {
+				continue
+			}
+			data, err := os.ReadFile(filepath.Join(dir, e.Name(), f.Name()))
+			if err != nil {
+				return nil, err
+			}
+			s[e.Name()] = append(s[e.Name()], File{Name: f.Name(), Data: data})
+		}
+	}
+	if len(s) == 0 {
+		return nil, fmt.Errorf("%s has no hidden tests (want <exercise>/*_test.go)", dir)
+	}
+	return s, nil
+}
+
+// Load reads hidden tests from path: a directory (LoadDir) or a sealed
+// file, opened with the key in $LEARNGO_HIDDEN_KEY.
+func Load(path string) (Set, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if info.IsDir() {
+		return LoadDir(path)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	env := os.Getenv(KeyEnv)
+	if env == "" {
+		return nil, fmt.Errorf("%s is sealed; set %s to its key", path, KeyEnv)
+	}
+	key, err := ParseKey(env)
+	if err != nil {
+		return nil, err
+	}
+	return Open(data, key)
+}
+
+// Tests lists the top-level Test functions of the hidden tests of an
+// exercise, in file order.
+func (s Set) Tests(id string) ([]string, error) {
+	var names []string
+	fset := token.NewFileSet()
+	for _, f := range s[id] {
+		parsed, err := parser.ParseFile(fset, f.Name, f.Data, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err
+		}
+		for _, d := range parsed.Decls {
+			if fn, ok := d.(*ast.FuncDecl); ok && fn.Recv == nil && isTest(fn.Name.Name) {
+				names = append(names, fn.Name.Name)
+			}
+		}
+	}
+	return names, nil
+}
+
+// IDs lists the exercises with hidden tests, sorted.
+func (s Set) IDs() []string {
+	return slices.Sorted(maps.Keys(s))
+}
+
+// isTest is go test's rule, as in grade.TestNames.
+func isTest(name string) bool {
+	rest, ok := strings.CutPrefix(name, "Test")
+	if !ok {
+		return false
+	}
+	r, _ := utf8.DecodeRuneInString(rest)
+	return rest == "" || !unicode.IsLower(r)
+}
+
+// Overlay writes the hidden tests of an exercise to a temporary
+// directory, with the `go test -overlay` file that places them in the
+// exercise directory dir, and returns the -overlay flag. cleanup
+// removes the files. It fails if a hidden file would hide a real one.
+func (s Set) Overlay(id, dir string) (flag string, cleanup func(), err error) {
+	dir, err = filepath.Abs(dir)
+	if err != nil {
+		return "", nil, err
+	}
+	tmp, err := os.MkdirTemp("", "learngo-hidden-")
+	if err != nil {
+		return "", nil, err
+	}
+	cleanup = func() { os.RemoveAll(tmp) }
+	replace := map[string]string{}
+	for _, f := range s[id] {
+		target := filepath.Join(dir, Prefix+f.Name)
+		if _, err := os.Stat(target); err == nil {
+			cleanup()
+			return "", nil, fmt.Errorf("%s already exists, so the hidden test can't take its name", target)
+		}
+		src := filepath.Join(tmp, f.Name)
+		if err := os.WriteFile(src, f.Data, 0o600); err != nil {
+			cleanup()
+			return "", nil, err
+		}
+		replace[target] = src
+	}
+	overlay, err := json.Marshal(map[string]any{"Replace": replace})
+	if err == nil {
+		err = os.WriteFile(filepath.Join(tmp, "overlay.json"), overlay, 0o600)
+	}
+	if err != nil {
+		cleanup()
+		return "", nil, err
+	}
+	return "-overlay=" + filepath.Join(tmp, "overlay.json"), cleanup, nil
+}
+
+// magic starts every sealed file, so Open can tell one from garbage.
+var magic = []byte("learngo-hidden-v1\n")
+
+// NewKey returns a random key for Seal.
+func NewKey() []byte {
+	key := make([]byte, 32)
+	rand.Read(key)
+	return key
+}
+
+// ParseKey decodes a key printed as hex, as $LEARNGO_HIDDEN_KEY holds it.
+func ParseKey(s string) ([]byte, error) {
+	key, err := hex.DecodeString(strings.TrimSpace(s))
+	if err != nil || len(key) != 32 {
+		return nil, errors.New("the key should be 64 hex digits, as `learngo hidden seal` printed it")
+	}
+	return key, nil
+}
+
+// Seal encrypts s with key (AES-256-GCM), so the file can be shared
+// without giving the tests away.
+func Seal(s Set, key []byte) ([]byte, error) {
+	plain, err := json.Marshal(s)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	rand.Read(nonce)
+	out := append(slices.Clone(magic), nonce...)
+	return gcm.Seal(out, nonce, plain, magic), nil
+}
+
+// Open decrypts a file made by Seal.
+func Open(data, key []byte) (Set, error) {
+	rest, ok := bytes.CutPrefix(data, magic)
+	if !ok {
+		return nil, errors.New("not a sealed set of hidden tests")
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) < gcm.NonceSize() {
+		return nil, errors.New("sealed hidden tests are truncated")
+	}
+	plain, err := gcm.Open(nil, rest[:gcm.NonceSize()], rest[gcm.NonceSize():], magic)
+	if err != nil {
+		return nil, errors.New("can't open the hidden tests: wrong key, or the file was changed")
+	}
+	var s Set
+	if err := json.Unmarshal(plain, &s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
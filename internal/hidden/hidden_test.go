@@ -0,0 +1,156 @@
+package hidden
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+const edge = `package collections
+
+import "testing"
+
+func TestSumOverflow(t *testing.T) {}
+
+func TestSumNil(t *testing.T) {}
+
+func helperTest(t *testing.T) {}
+`
+
+func writeSet(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "04-collections"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for name, data := range map[string]string{
+		"04-collections/edge_test.go": edge,
+		"04-collections/notes.md":     "not a test",
+		"README.md":                   "ignored",
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestLoadDir(t *testing.T) {
+	s, err := LoadDir(writeSet(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ids := s.IDs(); !slices.Equal(ids, []string{"04-collections"}) || len(s["04-collections"]) != 1 {
+		t.Fatalf("got %v", s)
+	}
+	tests, err := s.Tests("04-collections")
+	if err != nil || !slices.Equal(tests, []string{"TestSumOverflow", "TestSumNil"}) {
+		t.Errorf("got %v, %v", tests, err)
+	}
+
+	if _, err := LoadDir(t.TempDir()); err == nil {
+		t.Error("an empty directory loaded")
+	}
+}
+
+func TestSeal(t *testing.T) {
+	s, err := LoadDir(writeSet(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	key := NewKey()
+	sealed, err := Seal(s, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(sealed), "TestSumOverflow") {
+		t.Error("the sealed file shows the tests")
+	}
+	opened, err := Open(sealed, key)
+	if err != nil || string(opened["04-collections"][0].Data) != edge {
+		t.Errorf("got %v, %v", opened, err)
+	}
+
+	if _, err := Open(sealed, NewKey()); err == nil || !strings.Contains(err.Error(), "wrong key") {
+		t.Errorf("wrong key: %v", err)
+	}
+	sealed[len(sealed)-1] ^= 1
+	if _, err := Open(sealed, key); err == nil {
+		t.Error("a tampered file opened")
+	}
+	if _, err := Open([]byte("hello"), key); err == nil {
+		t.Error("garbage opened")
+	}
+}
+
+func TestLoadSealed(t *testing.T) {
+	s, err := LoadDir(writeSet(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	key := NewKey()
+	sealed, err := Seal(s, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "hidden.sealed")
+	if err := os.WriteFile(path, sealed, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv(KeyEnv, "")
+	if _, err := Load(path); err == nil || !strings.Contains(err.Error(), KeyEnv) {
+		t.Errorf("no key: %v", err)
+	}
+	t.Setenv(KeyEnv, "abc")
+	if _, err := Load(path); err == nil || !strings.Contains(err.Error(), "64 hex digits") {
+		t.Errorf("bad key: %v", err)
+	}
+	t.Setenv(KeyEnv, strings.ToUpper(hex.EncodeToString(key)))
+	if got, err := Load(path); err != nil || len(got["04-collections"]) != 1 {
+		t.Errorf("got %v, %v", got, err)
+	}
+}
+
+func TestOverlay(t *testing.T) {
+	s := Set{"04-collections": {{Name: "edge_test.go", Data: []byte(edge)}}}
+	dir := t.TempDir()
+	flag, cleanup, err := s.Overlay("04-collections", dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path, ok := strings.CutPrefix(flag, "-overlay=")
+	if !ok {
+		t.Fatalf("flag %q", flag)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var overlay struct{ Replace map[string]string }
+	if err := json.Unmarshal(data, &overlay); err != nil {
+		t.Fatal(err)
+	}
+	src := overlay.Replace[filepath.Join(dir, "hidden_edge_test.go")]
+	if got, err := os.ReadFile(src); err != nil || string(got) != edge {
+		t.Errorf("overlay %v: %q, %v", overlay.Replace, got, err)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Error("the overlay wrote into the exercise directory")
+	}
+	cleanup()
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("cleanup left %s", path)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "hidden_edge_test.go"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := s.Overlay("04-collections", dir); err == nil {
+		t.Error("the hidden test replaced a file that exists")
+	}
+}
@@ -0,0 +1,87 @@
+package hints
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/imgarylai/learn-go/internal/i18n"
+	"github.com/imgarylai/learn-go/internal/runner"
+)
+
+// symptoms are the runtime errors Failures recognizes in a failing
+// test's output, each with the catalog key of its hint.
+var symptoms = []struct {
+	output string
+	key    string
+}{
+	{"index out of range", "hint.failure.index"},
+	{"slice bounds out of range", "hint.failure.index"},
+	{"assignment to entry in nil map", "hint.failure.nilmap"},
+	{"nil pointer dereference", "hint.failure.nilpointer"},
+	{"all goroutines are asleep - deadlock!", "hint.failure.deadlock"},
+	{"close of closed channel", "hint.failure.closedchan"},
+	{"send on closed channel", "hint.failure.closedchan"},
+}
+
+// Failures reads the latest test run: a build failure, a data race, a
+// test stopped at the time or memory limit (runner.Limits), or a failing
+// test whose output shows a panic it recognizes, like an index out of
+// range. Each kind of problem is mentioned once, for the first
+// test it turned up in. When nothing is recognized it points at the
+// first failing test. Without a test run it has nothing to say.
+type Failures struct{}
+
+// Hints implements Provider.
+func (Failures) Hints(_ context.Context, req Request) ([]Hint, error) {
+	res := req.Result
+	if res == nil {
+		return nil, nil
+	}
+	if res.BuildFailed {
+		return []Hint{{Text: i18n.T(req.Lang, "hint.failure.build", firstError(res.BuildOutput))}}, nil
+	}
+
+	var hints []Hint
+	seen := map[string]bool{}
+	add := func(test, key string) {
+		if !seen[key] {
+			seen[key] = true
+			hints = append(hints, Hint{Test: test, Text: i18n.T(req.Lang, key)})
+		}
+	}
+	for _, race := range res.Races {
+		add(race.Test, "hint.failure.race")
+	}
+	for _, t := range res.Tests {
+		if t.Status != runner.Fail {
+			continue
+		}
+		switch t.Aborted {
+		case runner.TimedOut:
+			add(t.Name, "hint.failure.timeout")
+		case runner.OutOfMemory:
+			add(t.Name, "hint.failure.memory")
+		}
+		for _, s := range symptoms {
+			if slices.ContainsFunc(t.Output, func(line string) bool { return strings.Contains(line, s.output) }) {
+				add(t.Name, s.key)
+			}
+		}
+	}
+	if failed := res.Failed(); len(hints) == 0 && len(failed) > 0 {
+		hints = append(hints, Hint{Text: i18n.T(req.Lang, "hint.failure.first", failed[0], req.Exercise.ID)})
+	}
+	return hints, nil
+}
+
+// firstError picks the first compiler error out of go build's output,
+// skipping the "# package" header.
+func firstError(output []string) string {
+	for _, line := range output {
+		if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "#") {
+			return line
+		}
+	}
+	return "see `go build`"
+}
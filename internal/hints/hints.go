@@ -0,0 +1,54 @@
+// Package hints decides what to tell a learner who's stuck. Each source
+// of hints is a Provider: Static serves the hints written for the
+// exercise, and Failures reads the output of the tests that fail for
+// mistakes it recognizes, like a panic on a nil map.
+//
+// Providers are to `learngo hint` what plugins are to ESLint: anything
+// with a Hints method will do, so a richer source (an external command
+// that gets the failing output on stdin, a language model, a course's
+// own FAQ) can be added to the Chain in cmd/learngo without touching the
+// others.
+package hints
+
+import (
+	"context"
+
+	"github.com/imgarylai/learn-go/internal/registry"
+	"github.com/imgarylai/learn-go/internal/runner"
+)
+
+// Request is what a provider knows about the learner's situation.
+type Request struct {
+	Exercise registry.Exercise
+	Lang     string         // an i18n language tag, e.g. "ja"
+	Result   *runner.Result // the latest test run, nil if there's none
+}
+
+// Hint is one nudge.
+type Hint struct {
+	Text string
+	Test string // the failing test it's about, "" for a general hint
+}
+
+// Provider is a source of hints. It returns them gentlest first, and
+// none, rather than an error, when it has nothing to say.
+type Provider interface {
+	Hints(ctx context.Context, req Request) ([]Hint, error)
+}
+
+// Chain asks each provider in turn and returns all their hints, in
+// order. The first error stops it.
+type Chain []Provider
+
+// Hints implements Provider.
+func (c Chain) Hints(ctx context.Context, req Request) ([]Hint, error) {
+	var all []Hint
+	for _, p := range c {
+		hints, err := p.Hints(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, hints...)
+	}
+	return all, nil
+}
@@ -0,0 +1,105 @@
+package hints
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/imgarylai/learn-go/internal/registry"
+	"github.com/imgarylai/learn-go/internal/runner"
+)
+
+func texts(hints []Hint) []string {
+	var out []string
+	for _, h := range hints {
+		out = append(out, h.Test+": "+h.Text)
+	}
+	return out
+}
+
+func TestStatic(t *testing.T) {
+	e, _ := registry.Lookup("04-collections")
+	en, _ := Static{}.Hints(context.Background(), Request{Exercise: e, Lang: "en"})
+	ja, _ := Static{}.Hints(context.Background(), Request{Exercise: e, Lang: "ja"})
+	if len(en) == 0 || len(en) != len(ja) || en[0].Text == ja[0].Text {
+		t.Errorf("en %v, ja %v", en, ja)
+	}
+
+	pack := registry.Exercise{ID: "acme-01", Pack: "acme", Hints: []string{"Store cents as int64."}}
+	got, _ := Static{}.Hints(context.Background(), Request{Exercise: pack, Lang: "ja"})
+	if len(got) != 1 || got[0].Text != "Store cents as int64." {
+		t.Errorf("pack: got %v", got)
+	}
+}
+
+func TestFailures(t *testing.T) {
+	ex := registry.Exercise{ID: "04-collections"}
+	tests := []struct {
+		name string
+		res  *runner.Result
+		want []string // substrings, one per hint
+	}{
+		{"no test run", nil, nil},
+		{"all green", &runner.Result{Tests: []runner.Test{{Name: "TestSum", Status: runner.Pass}}}, nil},
+		{"build failure", &runner.Result{BuildFailed: true, BuildOutput: []string{
+			"# github.com/imgarylai/learn-go/exercises/04-collections",
+			"./collections.go:12:2: undefined: total",
+		}}, []string{": Your code doesn't compile yet. Start with the first error, the rest often follow from it: ./collections.go:12:2: undefined: total"}},
+		{"a panic it knows", &runner.Result{Tests: []runner.Test{
+			{Name: "TestSum", Status: runner.Pass},
+			{Name: "TestMax", Status: runner.Fail, Output: []string{
+				"panic: runtime error: index out of range [0] with length 0 [recovered]",
+			}},
+			{Name: "TestMin", Status: runner.Fail, Output: []string{"panic: runtime error: index out of range [0] with length 0"}},
+			{Name: "TestGroup", Status: runner.Fail, Output: []string{"panic: assignment to entry in nil map"}},
+		}}, []string{"TestMax: An index went past the end", "TestGroup: Writing to a nil map panics"}},
+		{"stopped", &runner.Result{Tests: []runner.Test{
+			{Name: "TestLoop", Status: runner.Fail, Aborted: runner.TimedOut},
+			{Name: "TestLoop/sub", Status: runner.Fail, Aborted: runner.TimedOut},
+		}}, []string{"TestLoop: A test never finished"}},
+		{"a race", &runner.Result{
+			Tests: []runner.Test{{Name: "TestCounter", Status: runner.Fail}},
+			Races: []runner.Race{{Test: "TestCounter"}, {Test: "TestCounter"}},
+		}, []string{"TestCounter: Two goroutines touched the same variable"}},
+		{"nothing recognized", &runner.Result{Tests: []runner.Test{
+			{Name: "TestMax", Status: runner.Fail, Output: []string{"got 0, want 9"}},
+			{Name: "TestMax/empty", Status: runner.Fail},
+		}}, []string{": TestMax fails; `learngo run -v 04-collections` shows"}},
+	}
+	for _, tt := range tests {
+		hints, err := Failures{}.Hints(context.Background(), Request{Exercise: ex, Lang: "en", Result: tt.res})
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		got := texts(hints)
+		if len(got) != len(tt.want) {
+			t.Errorf("%s: got %q, want %d hint(s)", tt.name, got, len(tt.want))
+			continue
+		}
+		for i, w := range tt.want {
+			if !strings.HasPrefix(got[i], w) {
+				t.Errorf("%s: hint %d is %q, want it to start with %q", tt.name, i, got[i], w)
+			}
+		}
+	}
+}
+
+type fixed []Hint
+
+func (f fixed) Hints(context.Context, Request) ([]Hint, error) { return f, nil }
+
+type broken struct{}
+
+func (broken) Hints(context.Context, Request) ([]Hint, error) { return nil, errors.New("offline") }
+
+func TestChain(t *testing.T) {
+	c := Chain{fixed{{Text: "a"}}, fixed{}, fixed{{Text: "b"}, {Text: "c"}}}
+	got, err := c.Hints(context.Background(), Request{})
+	if err != nil || len(got) != 3 || got[0].Text != "a" || got[2].Text != "c" {
+		t.Errorf("got %v, %v", got, err)
+	}
+	if _, err := append(c, broken{}).Hints(context.Background(), Request{}); err == nil {
+		t.Error("an error from one provider should stop the chain")
+	}
+}
@@ -0,0 +1,25 @@
+package hints
+
+import (
+	"context"
+
+	"github.com/imgarylai/learn-go/internal/i18n"
+)
+
+// Static serves the hints written for the exercise: from the i18n
+// catalog in the requested language for a built-in exercise, as the
+// pack wrote them for one from a pack. It doesn't look at the tests.
+type Static struct{}
+
+// Hints implements Provider.
+func (Static) Hints(_ context.Context, req Request) ([]Hint, error) {
+	texts := req.Exercise.Hints
+	if req.Exercise.Pack == "" {
+		texts = i18n.Hints(req.Lang, req.Exercise.ID)
+	}
+	hints := make([]Hint, 0, len(texts))
+	for _, t := range texts {
+		hints = append(hints, Hint{Text: t})
+	}
+	return hints, nil
+}
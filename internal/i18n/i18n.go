@@ -0,0 +1,153 @@
+// Package i18n holds the learner-facing text of the exercises (each
+// exercise's one-line prompt and its hints) in message catalogs, one
+// per language: English, Traditional Chinese (zh-TW) and Japanese.
+//
+// It plays the part of i18next or react-intl in a JS app: messages are
+// looked up by key ("04-collections.hint.1") in the learner's language,
+// falling back to English. The catalogs are JSON files embedded into the
+// binary with go:embed, so there is nothing to ship next to learngo, and
+// a unit test checks that every catalog has exactly the keys English has.
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Default is the language every other catalog is checked against and
+// falls back to.
+const Default = "en"
+
+//go:embed catalogs/*.json
+var files embed.FS
+
+// catalogs maps a language tag ("zh-TW") to its messages.
+var catalogs = load()
+
+func load() map[string]map[string]string {
+	entries, err := files.ReadDir("catalogs")
+	if err != nil {
+		panic(err)
+	}
+	all := make(map[string]map[string]string, len(entries))
+	for _, e := range entries {
+		data, err := files.ReadFile("catalogs/" + e.Name())
+		if err != nil {
+			panic(err)
+		}
+		var msgs map[string]string
+		if err := json.Unmarshal(data, &msgs); err != nil {
+			// Embedded at build time, so this is a bug in the repo, not
+			// something a learner can cause or fix.
+			panic(fmt.Sprintf("i18n: %s: %v", e.Name(), err))
+		}
+		all[strings.TrimSuffix(e.Name(), path.Ext(e.Name()))] = msgs
+	}
+	return all
+}
+
+// Languages lists the language tags that have a catalog, sorted.
+func Languages() []string {
+	return slices.Sorted(maps.Keys(catalogs))
+}
+
+// Keys lists the keys of lang's catalog, sorted, or nil if there is no
+// such catalog.
+func Keys(lang string) []string {
+	msgs, ok := catalogs[lang]
+	if !ok {
+		return nil
+	}
+	return slices.Sorted(maps.Keys(msgs))
+}
+
+// T returns the message for key in lang, formatted with args like
+// fmt.Sprintf. A key missing from lang falls back to English, and one
+// missing from English too comes back as the key itself, the way
+// i18next shows an untranslated key rather than nothing.
+func T(lang, key string, args ...any) string {
+	msg, ok := catalogs[lang][key]
+	if !ok {
+		if msg, ok = catalogs[Default][key]; !ok {
+			return key
+		}
+	}
+	if len(args) == 0 {
+		return msg
+	}
+	return fmt.Sprintf(msg, args...)
+}
+
+// Prompt returns the one-line summary of what exercise id asks you to
+// do, or "" if it has none.
+func Prompt(lang, id string) string {
+	if key := id + ".prompt"; has(key) {
+		return T(lang, key)
+	}
+	return ""
+}
+
+// Hints returns exercise id's hints in lang, gentlest first: the
+// messages "<id>.hint.1", "<id>.hint.2", ... up to the first one
+// missing from English.
+func Hints(lang, id string) []string {
+	var hints []string
+	for n := 1; ; n++ {
+		key := id + ".hint." + strconv.Itoa(n)
+		if !has(key) {
+			return hints
+		}
+		hints = append(hints, T(lang, key))
+	}
+}
+
+func has(key string) bool {
+	_, ok := catalogs[Default][key]
+	return ok
+}
+
+// Match returns the catalog for a language tag as users and locales
+// write it: "ja", "ja_JP.UTF-8", "zh-tw" and "zh_Hant_TW" all find one.
+// Plain "zh" means Traditional Chinese, the only Chinese catalog.
+func Match(tag string) (string, bool) {
+	tag, _, _ = strings.Cut(tag, ".") // drop the ".UTF-8" encoding
+	tag, _, _ = strings.Cut(tag, "@") // and any "@euro" modifier
+	parts := strings.FieldsFunc(strings.ToLower(tag), func(r rune) bool { return r == '-' || r == '_' })
+	if len(parts) == 0 {
+		return "", false
+	}
+	if parts[0] == "zh" {
+		if slices.Contains(parts[1:], "cn") || slices.Contains(parts[1:], "hans") {
+			return "", false
+		}
+		return "zh-TW", true
+	}
+	if _, ok := catalogs[parts[0]]; ok {
+		return parts[0], true
+	}
+	return "", false
+}
+
+// Detect picks the language from the environment: LEARNGO_LANG if set,
+// then the usual POSIX locale variables, then English. "C" and "POSIX"
+// locales, and languages without a catalog, mean English.
+func Detect() string {
+	for _, name := range []string{"LEARNGO_LANG", "LC_ALL", "LC_MESSAGES", "LANG"} {
+		v := os.Getenv(name)
+		if v == "" {
+			continue
+		}
+		if lang, ok := Match(v); ok {
+			return lang
+		}
+		return Default
+	}
+	return Default
+}
@@ -0,0 +1,145 @@
+package i18n_test
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/imgarylai/learn-go/internal/i18n"
+	"github.com/imgarylai/learn-go/internal/registry"
+)
+
+func TestCatalogsHaveTheSameKeys(t *testing.T) {
+	want := i18n.Keys(i18n.Default)
+	if len(want) == 0 {
+		t.Fatal("the English catalog is empty")
+	}
+	for _, lang := range i18n.Languages() {
+		got := i18n.Keys(lang)
+		for _, k := range want {
+			if _, ok := slices.BinarySearch(got, k); !ok {
+				t.Errorf("%s: missing %q", lang, k)
+			}
+		}
+		for _, k := range got {
+			if _, ok := slices.BinarySearch(want, k); !ok {
+				t.Errorf("%s: %q is not in the English catalog", lang, k)
+			}
+		}
+	}
+}
+
+var verb = regexp.MustCompile(`%[^%]`)
+
+// A translation that drops a %s would print "%!(EXTRA string=...)".
+func TestTranslationsKeepTheirVerbs(t *testing.T) {
+	for _, lang := range i18n.Languages() {
+		for _, k := range i18n.Keys(i18n.Default) {
+			en, tr := i18n.T(i18n.Default, k), i18n.T(lang, k)
+			if !slices.Equal(verb.FindAllString(en, -1), verb.FindAllString(tr, -1)) {
+				t.Errorf("%s %s: %q has different verbs from %q", lang, k, tr, en)
+			}
+			if strings.TrimSpace(tr) == "" {
+				t.Errorf("%s %s: empty message", lang, k)
+			}
+		}
+	}
+}
+
+func TestEveryExerciseHasAPrompt(t *testing.T) {
+	ids := map[string]bool{}
+	for _, e := range registry.All() {
+		ids[e.ID] = true
+		for _, lang := range i18n.Languages() {
+			if i18n.Prompt(lang, e.ID) == "" {
+				t.Errorf("%s: no prompt for %s", lang, e.ID)
+			}
+		}
+		if got := i18n.Hints(i18n.Default, e.ID); !slices.Equal(got, e.Hints) {
+			t.Errorf("%s: registry hints %q, catalog %q", e.ID, e.Hints, got)
+		}
+	}
+	// Every other key belongs to an exercise or to a command.
+	for _, k := range i18n.Keys(i18n.Default) {
+		prefix, _, _ := strings.Cut(k, ".")
+		if !ids[prefix] && prefix != "hint" {
+			t.Errorf("%q is for no known exercise or command", k)
+		}
+	}
+}
+
+// Hints stops at the first missing number, so a gap would hide the rest.
+func TestHintsAreNumberedWithoutGaps(t *testing.T) {
+	count := map[string]int{}
+	for _, k := range i18n.Keys(i18n.Default) {
+		if id, _, ok := strings.Cut(k, ".hint."); ok {
+			count[id]++
+		}
+	}
+	for id, n := range count {
+		if got := len(i18n.Hints(i18n.Default, id)); got != n {
+			t.Errorf("%s: %d hint keys but Hints finds %d; check the numbering", id, n, got)
+		}
+	}
+}
+
+func TestT(t *testing.T) {
+	if got := i18n.T("ja", "hint.title", "04-collections"); got != "04-collections のヒント:" {
+		t.Errorf("ja: got %q", got)
+	}
+	if got := i18n.T("fr", "hint.title", "04-collections"); got != "Hints for 04-collections:" {
+		t.Errorf("an unknown language should fall back to English, got %q", got)
+	}
+	if got := i18n.T("en", "no.such.key"); got != "no.such.key" {
+		t.Errorf("a missing key should come back as itself, got %q", got)
+	}
+}
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		tag  string
+		want string
+		ok   bool
+	}{
+		{"en", "en", true},
+		{"en_US.UTF-8", "en", true},
+		{"ja_JP.UTF-8", "ja", true},
+		{"JA", "ja", true},
+		{"zh-TW", "zh-TW", true},
+		{"zh_tw", "zh-TW", true},
+		{"zh-Hant-TW", "zh-TW", true},
+		{"zh", "zh-TW", true},
+		{"zh_CN.UTF-8", "", false},
+		{"zh-Hans", "", false},
+		{"fr_FR", "", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := i18n.Match(tt.tag)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("Match(%q) = %q, %v; want %q, %v", tt.tag, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestDetect(t *testing.T) {
+	for _, name := range []string{"LEARNGO_LANG", "LC_ALL", "LC_MESSAGES", "LANG"} {
+		t.Setenv(name, "")
+	}
+	if got := i18n.Detect(); got != "en" {
+		t.Errorf("no locale: got %q", got)
+	}
+	t.Setenv("LANG", "ja_JP.UTF-8")
+	if got := i18n.Detect(); got != "ja" {
+		t.Errorf("LANG=ja_JP.UTF-8: got %q", got)
+	}
+	t.Setenv("LC_ALL", "C")
+	if got := i18n.Detect(); got != "en" {
+		t.Errorf("LC_ALL=C should win over LANG, got %q", got)
+	}
+	t.Setenv("LEARNGO_LANG", "zh-TW")
+	if got := i18n.Detect(); got != "zh-TW" {
+		t.Errorf("LEARNGO_LANG should win over the locale, got %q", got)
+	}
+}
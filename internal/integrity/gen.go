@@ -0,0 +1,34 @@
+//go:build ignore
+
+// gen records the checksums of every exercise's tests in manifest.json.
+// Run it with `go generate ./internal/integrity` after changing a test or
+// a testdata file, from a clean checkout (it hashes whatever is on disk).
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/imgarylai/learn-go/internal/integrity"
+	"github.com/imgarylai/learn-go/internal/registry"
+)
+
+func main() {
+	m := integrity.Manifest{}
+	for _, e := range registry.All() {
+		sums, err := integrity.Hash(filepath.Join("..", "..", e.Dir()))
+		if err != nil {
+			log.Fatal(err)
+		}
+		m[e.ID] = sums
+	}
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile("manifest.json", append(data, '\n'), 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,124 @@
+// Package integrity notices when an exercise's tests have been edited.
+//
+// Changing a test is the quickest way to make it pass, so the checksum
+// of every test file and testdata file is committed in manifest.json,
+// much like the integrity hashes in a package-lock.json. `learngo
+// verify` and `learngo done` compare the files on disk with it before
+// counting an exercise as finished.
+//
+// After changing an exercise's tests on purpose, run
+// `go generate ./internal/integrity` to update the manifest.
+package integrity
+
+import (
+	"bytes"
+	"crypto/sha256"
+	_ "embed"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"maps"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+//go:generate go run gen.go
+
+//go:embed manifest.json
+var manifestJSON []byte
+
+// Manifest maps exercise IDs to their checksums, keyed by
+// slash-separated path within the exercise.
+type Manifest map[string]map[string]string
+
+// ErrNoManifest means the exercise has no recorded checksums, which is
+// the case for exercises from a pack.
+var ErrNoManifest = errors.New("no checksums recorded for this exercise")
+
+// Load returns the manifest built into learngo.
+func Load() (Manifest, error) {
+	var m Manifest
+	if err := json.Unmarshal(manifestJSON, &m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Hash returns the checksum of every test file (*_test.go) and every
+// file under a testdata directory in the exercise at dir, subpackages
+// included. Line endings are normalized first, so a Windows checkout
+// with CRLF files still matches.
+func Hash(dir string) (map[string]string, error) {
+	sums := map[string]string{}
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if !tracked(rel) {
+			return nil
+		}
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		sum := sha256.Sum256(bytes.ReplaceAll(data, []byte("\r\n"), []byte("\n")))
+		sums[rel] = hex.EncodeToString(sum[:])
+		return nil
+	})
+	return sums, err
+}
+
+// tracked reports whether the file at rel belongs to the tests.
+func tracked(rel string) bool {
+	if strings.HasPrefix(path.Base(rel), ".") {
+		return false // editor swap files and the like
+	}
+	return strings.HasSuffix(rel, "_test.go") || slices.Contains(strings.Split(rel, "/"), "testdata")
+}
+
+// Change is one way the tests on disk differ from the manifest.
+type Change struct {
+	File string // slash-separated, relative to the exercise
+	Kind string // "modified", "missing" or "added"
+}
+
+// Check compares the tests of exercise id, in dir, with the manifest
+// and returns the differences sorted by file. No changes means the
+// tests are the ones that shipped.
+func (m Manifest) Check(id, dir string) ([]Change, error) {
+	want, ok := m[id]
+	if !ok {
+		return nil, ErrNoManifest
+	}
+	got, err := Hash(dir)
+	if err != nil {
+		return nil, err
+	}
+	var changes []Change
+	for _, name := range slices.Sorted(maps.Keys(want)) {
+		switch sum, ok := got[name]; {
+		case !ok:
+			changes = append(changes, Change{name, "missing"})
+		case sum != want[name]:
+			changes = append(changes, Change{name, "modified"})
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(got)) {
+		if _, ok := want[name]; !ok && strings.HasSuffix(name, "_test.go") {
+			// A new test file can hold a TestMain that skips the rest.
+			// New testdata can't change what the tests read.
+			changes = append(changes, Change{name, "added"})
+		}
+	}
+	slices.SortFunc(changes, func(a, b Change) int { return strings.Compare(a.File, b.File) })
+	return changes, nil
+}
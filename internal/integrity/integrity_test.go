@@ -0,0 +1,77 @@
+package integrity
+
+import (
+	"errors"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/imgarylai/learn-go/internal/registry"
+	"github.com/imgarylai/learn-go/internal/testutil"
+)
+
+func TestManifestIsCurrent(t *testing.T) {
+	m, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range registry.All() {
+		changes, err := m.Check(e.ID, filepath.Join("..", "..", e.Dir()))
+		if err != nil {
+			t.Errorf("%s: %v", e.ID, err)
+			continue
+		}
+		for _, c := range changes {
+			t.Errorf("%s/%s is %s; run `go generate ./internal/integrity` if that's intended", e.ID, c.File, c.Kind)
+		}
+	}
+}
+
+func TestCheck(t *testing.T) {
+	dir := testutil.TempDir(t, map[string]string{
+		"sum.go":           "package sum",
+		"sum_test.go":      "package sum\n\nfunc TestSum(t *testing.T) {}\n",
+		"more_test.go":     "package sum",
+		"testdata/in.csv":  "a,b\n",
+		"api/api_test.go":  "package api",
+		".sum_test.go.swp": "editor junk",
+	})
+	sums, err := Hash(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"api/api_test.go", "more_test.go", "sum_test.go", "testdata/in.csv"}
+	if got := slices.Sorted(maps.Keys(sums)); !slices.Equal(got, want) {
+		t.Fatalf("hashed %v, want %v", got, want)
+	}
+	m := Manifest{"01-sum": sums}
+
+	if changes, err := m.Check("01-sum", dir); err != nil || len(changes) != 0 {
+		t.Fatalf("untouched: %v, %v", changes, err)
+	}
+
+	// CRLF line endings alone aren't a change.
+	testutil.WriteFile(t, dir, "sum_test.go", "package sum\r\n\r\nfunc TestSum(t *testing.T) {}\r\n")
+	if changes, _ := m.Check("01-sum", dir); len(changes) != 0 {
+		t.Errorf("CRLF: %v", changes)
+	}
+
+	testutil.WriteFile(t, dir, "sum_test.go", "package sum\n\nfunc TestSum(t *testing.T) { t.Skip() }\n")
+	os.Remove(filepath.Join(dir, "more_test.go"))
+	testutil.WriteFile(t, dir, "main_test.go", "package sum")
+	testutil.WriteFile(t, dir, "testdata/extra.csv", "x\n")
+	changes, err := m.Check("01-sum", dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantChanges := []Change{{"main_test.go", "added"}, {"more_test.go", "missing"}, {"sum_test.go", "modified"}}
+	if !slices.Equal(changes, wantChanges) {
+		t.Errorf("got %v, want %v", changes, wantChanges)
+	}
+
+	if _, err := m.Check("acme-01-ledger", dir); !errors.Is(err, ErrNoManifest) {
+		t.Errorf("unknown exercise: %v", err)
+	}
+}
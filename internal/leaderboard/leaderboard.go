@@ -0,0 +1,80 @@
+// Package leaderboard is the classroom scoreboard behind `learngo submit`
+// and cmd/learngo-server.
+//
+// Students only ever send a pseudonymous handle and per-exercise test
+// counts: no names, paths, code or test output. The server keeps every
+// submission in SQLite and ranks each handle by its latest one.
+package leaderboard
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/imgarylai/learn-go/internal/registry"
+)
+
+// MaxHandleLen bounds handles so one student can't push the table off screen.
+const MaxHandleLen = 32
+
+// Submission is what `learngo submit` posts.
+type Submission struct {
+	Handle    string  `json:"handle"`
+	Exercises []Score `json:"exercises"`
+}
+
+// Score is the test tally for one exercise.
+type Score struct {
+	ID     string `json:"id"`
+	Passed int    `json:"passed"`
+	Total  int    `json:"total"`
+}
+
+// Done reports whether every test of the exercise passes.
+func (s Score) Done() bool { return s.Total > 0 && s.Passed == s.Total }
+
+// Validate rejects submissions the leaderboard can't make sense of.
+func (s Submission) Validate() error {
+	if s.Handle == "" {
+		return errors.New("handle is empty")
+	}
+	if len(s.Handle) > MaxHandleLen {
+		return fmt.Errorf("handle is longer than %d bytes", MaxHandleLen)
+	}
+	seen := map[string]bool{}
+	for _, sc := range s.Exercises {
+		if _, ok := registry.Lookup(sc.ID); !ok {
+			return fmt.Errorf("unknown exercise %q", sc.ID)
+		}
+		if seen[sc.ID] {
+			return fmt.Errorf("exercise %q listed twice", sc.ID)
+		}
+		seen[sc.ID] = true
+		if sc.Passed < 0 || sc.Total < 0 || sc.Passed > sc.Total {
+			return fmt.Errorf("%s: bad score %d/%d", sc.ID, sc.Passed, sc.Total)
+		}
+	}
+	return nil
+}
+
+// Totals adds up the scores across exercises.
+func (s Submission) Totals() (passed, total, done int) {
+	for _, sc := range s.Exercises {
+		passed += sc.Passed
+		total += sc.Total
+		if sc.Done() {
+			done++
+		}
+	}
+	return passed, total, done
+}
+
+// Entry is one row of the leaderboard.
+type Entry struct {
+	Rank      int       `json:"rank"`
+	Handle    string    `json:"handle"`
+	Passed    int       `json:"passed"`
+	Total     int       `json:"total"`
+	Done      int       `json:"done"`
+	Submitted time.Time `json:"submitted"`
+}
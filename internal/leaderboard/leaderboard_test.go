@@ -0,0 +1,147 @@
+package leaderboard
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func openStore(t *testing.T) *Store {
+	t.Helper()
+	s, err := Open(":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { s.Close() })
+	return s
+}
+
+func sub(handle string, scores ...Score) Submission {
+	return Submission{Handle: handle, Exercises: scores}
+}
+
+func TestValidate(t *testing.T) {
+	tests := map[string]Submission{
+		"empty handle":   sub(""),
+		"long handle":    sub(strings.Repeat("x", MaxHandleLen+1)),
+		"unknown":        sub("a", Score{ID: "99-nope", Passed: 1, Total: 1}),
+		"duplicate":      sub("a", Score{ID: "01-basics", Total: 1}, Score{ID: "01-basics", Total: 1}),
+		"passed > total": sub("a", Score{ID: "01-basics", Passed: 3, Total: 2}),
+		"negative":       sub("a", Score{ID: "01-basics", Passed: -1, Total: 2}),
+	}
+	for name, s := range tests {
+		if err := s.Validate(); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+	if err := sub("a", Score{ID: "01-basics", Passed: 2, Total: 2}).Validate(); err != nil {
+		t.Errorf("valid submission: %v", err)
+	}
+}
+
+func TestLeaderboardUsesLatestSubmission(t *testing.T) {
+	s := openStore(t)
+	ctx := context.Background()
+	t0 := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	adds := []struct {
+		sub Submission
+		at  time.Time
+	}{
+		{sub("alice", Score{ID: "01-basics", Passed: 9, Total: 10}), t0},
+		{sub("bob", Score{ID: "01-basics", Passed: 5, Total: 10}), t0},
+		{sub("carol", Score{ID: "01-basics", Passed: 9, Total: 10}), t0.Add(time.Minute)},
+		{sub("bob", Score{ID: "01-basics", Passed: 10, Total: 10}), t0.Add(2 * time.Minute)},
+	}
+	for _, a := range adds {
+		if err := s.Add(ctx, a.sub, a.at); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	entries, err := s.Leaderboard(ctx, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, e := range entries {
+		got = append(got, e.Handle)
+	}
+	// bob's resubmission puts him first; alice beats carol by getting there first.
+	if want := "bob alice carol"; strings.Join(got, " ") != want {
+		t.Errorf("order: got %v, want %s", got, want)
+	}
+	if e := entries[0]; e.Rank != 1 || e.Passed != 10 || e.Done != 1 || !e.Submitted.Equal(t0.Add(2*time.Minute)) {
+		t.Errorf("bob's entry: %+v", e)
+	}
+
+	top, err := s.Leaderboard(ctx, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(top) != 2 {
+		t.Errorf("limit 2: got %d entries", len(top))
+	}
+}
+
+func TestHandler(t *testing.T) {
+	srv := httptest.NewServer(NewHandler(openStore(t)))
+	defer srv.Close()
+
+	post := func(body string) *http.Response {
+		t.Helper()
+		resp, err := http.Post(srv.URL+"/api/submissions", "application/json", strings.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { resp.Body.Close() })
+		return resp
+	}
+
+	resp := post(`{"handle":"gopher-1","exercises":[{"id":"01-basics","passed":3,"total":4}]}`)
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("status: got %s", resp.Status)
+	}
+	var receipt Receipt
+	if err := json.NewDecoder(resp.Body).Decode(&receipt); err != nil {
+		t.Fatal(err)
+	}
+	if receipt != (Receipt{Rank: 1, Players: 1}) {
+		t.Errorf("receipt: %+v", receipt)
+	}
+
+	for _, bad := range []string{`not json`, `{"handle":""}`, `{"handle":"x","name":"Real Name"}`} {
+		if resp := post(bad); resp.StatusCode != http.StatusBadRequest {
+			t.Errorf("%s: got %s, want 400", bad, resp.Status)
+		}
+	}
+
+	page, err := http.Get(srv.URL + "/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer page.Body.Close()
+	html, _ := io.ReadAll(page.Body)
+	for _, want := range []string{"gopher-1", "3/4"} {
+		if !strings.Contains(string(html), want) {
+			t.Errorf("page is missing %q:\n%s", want, html)
+		}
+	}
+}
+
+func TestPageEscapesHandles(t *testing.T) {
+	s := openStore(t)
+	if err := s.Add(context.Background(), sub("<script>x</script>"), time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	rec := httptest.NewRecorder()
+	NewHandler(s).ServeHTTP(rec, httptest.NewRequest("GET", "/", nil))
+	if strings.Contains(rec.Body.String(), "<script>x") {
+		t.Errorf("handle was not escaped:\n%s", rec.Body)
+	}
+}
@@ -0,0 +1,121 @@
+package leaderboard
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"log"
+	"net/http"
+	"time"
+)
+
+//go:embed leaderboard.html.tmpl
+var pageSource string
+
+var page = template.Must(template.New("leaderboard").Parse(pageSource))
+
+// maxBody caps a submission's size; a real one is well under 1 KB.
+const maxBody = 64 << 10
+
+// Receipt is the server's reply to a submission.
+type Receipt struct {
+	Rank    int `json:"rank"`
+	Players int `json:"players"`
+}
+
+// Handler serves the leaderboard:
+//
+//	GET  /                  the leaderboard page
+//	GET  /api/leaderboard   the same as JSON
+//	POST /api/submissions   add a Submission, replies with a Receipt
+type Handler struct {
+	store *Store
+	mux   *http.ServeMux
+
+	// Now is the clock; nil means time.Now.
+	Now func() time.Time
+}
+
+// NewHandler returns a Handler backed by store.
+func NewHandler(store *Store) *Handler {
+	h := &Handler{store: store, mux: http.NewServeMux()}
+	h.mux.HandleFunc("GET /{$}", h.page)
+	h.mux.HandleFunc("GET /api/leaderboard", h.list)
+	h.mux.HandleFunc("POST /api/submissions", h.submit)
+	return h
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+func (h *Handler) page(w http.ResponseWriter, r *http.Request) {
+	entries, err := h.store.Leaderboard(r.Context(), 0)
+	if err != nil {
+		serverError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := page.Execute(w, entries); err != nil {
+		log.Printf("rendering leaderboard: %v", err)
+	}
+}
+
+func (h *Handler) list(w http.ResponseWriter, r *http.Request) {
+	entries, err := h.store.Leaderboard(r.Context(), 0)
+	if err != nil {
+		serverError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, entries)
+}
+
+func (h *Handler) submit(w http.ResponseWriter, r *http.Request) {
+	var sub Submission
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBody))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&sub); err != nil {
+		http.Error(w, fmt.Sprintf("bad submission: %v", err), http.StatusBadRequest)
+		return
+	}
+	if err := sub.Validate(); err != nil {
+		http.Error(w, fmt.Sprintf("bad submission: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	now := time.Now
+	if h.Now != nil {
+		now = h.Now
+	}
+	if err := h.store.Add(r.Context(), sub, now()); err != nil {
+		serverError(w, err)
+		return
+	}
+
+	entries, err := h.store.Leaderboard(r.Context(), 0)
+	if err != nil {
+		serverError(w, err)
+		return
+	}
+	receipt := Receipt{Players: len(entries)}
+	for _, e := range entries {
+		if e.Handle == sub.Handle {
+			receipt.Rank = e.Rank
+		}
+	}
+	writeJSON(w, http.StatusCreated, receipt)
+}
+
+func writeJSON(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("writing response: %v", err)
+	}
+}
+
+func serverError(w http.ResponseWriter, err error) {
+	log.Print(err)
+	http.Error(w, "internal error", http.StatusInternalServerError)
+}
@@ -0,0 +1,95 @@
+package leaderboard
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	// Registers the "sqlite" driver with database/sql, the way a JS app
+	// would pick a driver package for knex. It's SQLite translated to Go,
+	// so building the server needs no C compiler.
+	_ "modernc.org/sqlite"
+)
+
+const schema = `
+CREATE TABLE IF NOT EXISTS submissions (
+	id        INTEGER PRIMARY KEY,
+	handle    TEXT    NOT NULL,
+	submitted INTEGER NOT NULL, -- unix seconds
+	passed    INTEGER NOT NULL,
+	total     INTEGER NOT NULL,
+	done      INTEGER NOT NULL,
+	exercises TEXT    NOT NULL  -- the []Score as JSON
+);
+CREATE INDEX IF NOT EXISTS submissions_handle ON submissions (handle, id);
+`
+
+// Store keeps submissions in a SQLite database.
+type Store struct {
+	db *sql.DB
+}
+
+// Open opens (or creates) the database at path. ":memory:" works for tests.
+func Open(path string) (*Store, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, err
+	}
+	// SQLite allows one writer at a time; a single connection keeps
+	// concurrent submissions from tripping over "database is locked".
+	db.SetMaxOpenConns(1)
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &Store{db: db}, nil
+}
+
+// Close closes the database.
+func (s *Store) Close() error { return s.db.Close() }
+
+// Add records sub as submitted at now. Call sub.Validate first.
+func (s *Store) Add(ctx context.Context, sub Submission, now time.Time) error {
+	exercises, err := json.Marshal(sub.Exercises)
+	if err != nil {
+		return err
+	}
+	passed, total, done := sub.Totals()
+	_, err = s.db.ExecContext(ctx,
+		`INSERT INTO submissions (handle, submitted, passed, total, done, exercises) VALUES (?, ?, ?, ?, ?, ?)`,
+		sub.Handle, now.Unix(), passed, total, done, string(exercises))
+	return err
+}
+
+// Leaderboard ranks every handle by its latest submission: most passing
+// tests first, then most finished exercises, then whoever got there first.
+// limit <= 0 means no limit.
+func (s *Store) Leaderboard(ctx context.Context, limit int) ([]Entry, error) {
+	if limit <= 0 {
+		limit = -1 // SQLite for "no limit"
+	}
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT handle, passed, total, done, submitted
+		FROM submissions s
+		WHERE id = (SELECT MAX(id) FROM submissions WHERE handle = s.handle)
+		ORDER BY passed DESC, done DESC, submitted ASC, handle ASC
+		LIMIT ?`, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []Entry
+	for rows.Next() {
+		var e Entry
+		var submitted int64
+		if err := rows.Scan(&e.Handle, &e.Passed, &e.Total, &e.Done, &submitted); err != nil {
+			return nil, err
+		}
+		e.Rank = len(entries) + 1
+		e.Submitted = time.Unix(submitted, 0).UTC()
+		entries = append(entries, e)
+	}
+	return entries, rows.Err()
+}
@@ -0,0 +1,242 @@
+// Package migrate brings your copy of an exercise up to a new version
+// of its stubs without losing your work.
+//
+// It's a three-way merge, like the one `git merge` does, but by
+// top-level declaration instead of by line: each function, type, var
+// or const block is compared in the stub you started from (base), your
+// file (mine) and the new stub (theirs). A function you never touched
+// becomes the new stub; one only you changed stays yours; one you both
+// changed is a conflict, and yours is kept. Merging whole declarations
+// keeps your solution to one function from ever mixing with upstream
+// edits to another.
+package migrate
+
+import (
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/imgarylai/learn-go/internal/textdiff"
+)
+
+// Status says what Merge did with one declaration.
+type Status string
+
+const (
+	Updated  Status = "updated"  // you hadn't touched it: it's the new stub now
+	Added    Status = "added"    // new in this version
+	Removed  Status = "removed"  // gone in this version, and you hadn't touched it
+	Conflict Status = "conflict" // changed by both you and upstream: yours is kept
+)
+
+// Change is one declaration Merge changed, or couldn't.
+type Change struct {
+	Decl   string // "func Chunk", "method (*Stack).Push", "type Stack", "import"
+	Status Status
+	// Upstream is, for a conflict, the diff from the old stub to the new
+	// one for this declaration: the change to make by hand.
+	Upstream string
+}
+
+func (c Change) String() string {
+	switch c.Status {
+	case Conflict:
+		return c.Decl + ": conflict, you both changed it; yours is kept"
+	case Updated:
+		return c.Decl + ": updated to the new stub"
+	}
+	return c.Decl + ": " + string(c.Status)
+}
+
+// Merge merges the changes from base to theirs into mine, declaration
+// by declaration, and returns the result, gofmt'ed, with what it did.
+// base may be nil for a file that's new upstream.
+func Merge(base, mine, theirs []byte) ([]byte, []Change, error) {
+	b, err := split(base)
+	if err != nil {
+		return nil, nil, fmt.Errorf("old stub: %w", err)
+	}
+	m, err := split(mine)
+	if err != nil {
+		return nil, nil, fmt.Errorf("your file: %w", err)
+	}
+	t, err := split(theirs)
+	if err != nil {
+		return nil, nil, fmt.Errorf("new stub: %w", err)
+	}
+
+	var changes []Change
+	var edits []edit
+	conflict := func(key, baseText, theirText string) {
+		changes = append(changes, Change{Decl: key, Status: Conflict,
+			Upstream: textdiff.Unified("old stub", "new stub", lines(baseText), lines(theirText))})
+	}
+	for _, d := range m.decls {
+		bd, inBase := b.byKey[d.key]
+		td, inTheirs := t.byKey[d.key]
+		switch {
+		case !inTheirs && !inBase:
+			// Yours alone: a helper you added.
+		case !inTheirs:
+			if d.text != bd.text {
+				conflict(d.key, bd.text, "")
+				continue
+			}
+			edits = append(edits, edit{d.start, d.end, ""})
+			changes = append(changes, Change{Decl: d.key, Status: Removed})
+		case d.text == td.text || inBase && td.text == bd.text:
+			// Already the same, or upstream didn't change it.
+		case inBase && d.text == bd.text:
+			edits = append(edits, edit{d.start, d.end, td.text})
+			changes = append(changes, Change{Decl: d.key, Status: Updated})
+		default:
+			conflict(d.key, bd.text, td.text)
+		}
+	}
+
+	// New declarations go after the one they follow in the new stub.
+	anchor := m.pkgEnd
+	for _, d := range t.decls {
+		if md, ok := m.byKey[d.key]; ok {
+			anchor = md.end
+			continue
+		}
+		bd, inBase := b.byKey[d.key]
+		switch {
+		case !inBase:
+			edits = append(edits, edit{anchor, anchor, "\n\n" + d.text})
+			changes = append(changes, Change{Decl: d.key, Status: Added})
+		case bd.text != d.text:
+			// You deleted it, upstream changed it.
+			conflict(d.key, bd.text, d.text)
+		}
+	}
+
+	out := apply(mine, edits)
+	if formatted, err := format.Source(out); err == nil {
+		out = formatted
+	}
+	return out, changes, nil
+}
+
+type decl struct {
+	key        string
+	text       string
+	start, end int // byte offsets, doc comment included
+}
+
+type file struct {
+	decls  []decl
+	byKey  map[string]decl
+	pkgEnd int // offset of the end of the package clause
+}
+
+func split(src []byte) (file, error) {
+	f := file{byKey: map[string]decl{}}
+	if src == nil {
+		return f, nil
+	}
+	fset := token.NewFileSet()
+	parsed, err := parser.ParseFile(fset, "", src, parser.ParseComments|parser.SkipObjectResolution)
+	if err != nil {
+		return f, err
+	}
+	f.pkgEnd = fset.Position(parsed.Name.End()).Offset
+	for _, d := range parsed.Decls {
+		var doc *ast.CommentGroup
+		switch d := d.(type) {
+		case *ast.FuncDecl:
+			doc = d.Doc
+		case *ast.GenDecl:
+			doc = d.Doc
+		}
+		start := d.Pos()
+		if doc != nil {
+			start = doc.Pos()
+		}
+		dd := decl{
+			key:   key(d),
+			start: fset.Position(start).Offset,
+			end:   fset.Position(d.End()).Offset,
+		}
+		dd.text = string(src[dd.start:dd.end])
+		for n := 2; f.byKey[dd.key].key != ""; n++ {
+			dd.key = fmt.Sprintf("%s #%d", key(d), n)
+		}
+		f.decls = append(f.decls, dd)
+		f.byKey[dd.key] = dd
+	}
+	return f, nil
+}
+
+// key names a declaration the same way in every version of a file.
+func key(d ast.Decl) string {
+	switch d := d.(type) {
+	case *ast.FuncDecl:
+		if d.Recv == nil || len(d.Recv.List) == 0 {
+			return "func " + d.Name.Name
+		}
+		return "method (" + recv(d.Recv.List[0].Type) + ")." + d.Name.Name
+	case *ast.GenDecl:
+		if d.Tok == token.IMPORT {
+			return "import"
+		}
+		var names []string
+		for _, s := range d.Specs {
+			switch s := s.(type) {
+			case *ast.TypeSpec:
+				names = append(names, s.Name.Name)
+			case *ast.ValueSpec:
+				for _, n := range s.Names {
+					names = append(names, n.Name)
+				}
+			}
+		}
+		sort.Strings(names)
+		return d.Tok.String() + " " + strings.Join(names, ", ")
+	}
+	return "?"
+}
+
+func recv(x ast.Expr) string {
+	switch x := x.(type) {
+	case *ast.StarExpr:
+		return "*" + recv(x.X)
+	case *ast.IndexExpr:
+		return recv(x.X)
+	case *ast.IndexListExpr:
+		return recv(x.X)
+	case *ast.Ident:
+		return x.Name
+	}
+	return "?"
+}
+
+type edit struct {
+	start, end int
+	text       string
+}
+
+// apply makes non-overlapping edits to src. Insertions at the same
+// offset end up in the order they were listed.
+func apply(src []byte, edits []edit) []byte {
+	slices.Reverse(edits)
+	slices.SortStableFunc(edits, func(a, b edit) int { return b.start - a.start })
+	out := slices.Clone(src)
+	for _, e := range edits {
+		out = slices.Concat(out[:e.start], []byte(e.text), out[e.end:])
+	}
+	return out
+}
+
+func lines(s string) string {
+	if s == "" {
+		return ""
+	}
+	return s + "\n"
+}
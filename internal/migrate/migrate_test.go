@@ -0,0 +1,189 @@
+package migrate
+
+import (
+	"strings"
+	"testing"
+)
+
+const base = `package collections
+
+import "strings"
+
+// 1. Sum all elements
+func Sum(nums []int) int {
+	// TODO
+	return 0
+}
+
+// 2. Join words
+func Join(words []string) string {
+	// TODO
+	return strings.Join(nil, "")
+}
+
+// 3. Max
+func Max(nums []int) int {
+	// TODO
+	return 0
+}
+
+// Old is dropped in v2.
+func Old() {}
+`
+
+const theirs = `package collections
+
+import "strings"
+
+// 1. Sum all elements (now with a hint)
+// In JS: nums.reduce((a, b) => a + b, 0)
+func Sum(nums []int) int {
+	// TODO
+	return 0
+}
+
+// 2. Join words with a separator
+func Join(words []string, sep string) string {
+	// TODO
+	return strings.Join(nil, "")
+}
+
+// 2b. Min
+func Min(nums []int) int {
+	// TODO
+	return 0
+}
+
+// 3. Max
+func Max(nums []int) int {
+	// TODO
+	return 0
+}
+`
+
+const mine = `package collections
+
+import "strings"
+
+// 1. Sum all elements
+func Sum(nums []int) int {
+	// TODO
+	return 0
+}
+
+// 2. Join words
+func Join(words []string) string {
+	return strings.Join(words, " ")
+}
+
+// 3. Max
+func Max(nums []int) int {
+	best := nums[0]
+	for _, n := range nums {
+		best = max(best, n)
+	}
+	return best
+}
+
+// Old is dropped in v2.
+func Old() {}
+
+func helper() {}
+`
+
+func TestMerge(t *testing.T) {
+	out, changes, err := Merge([]byte(base), []byte(mine), []byte(theirs))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, c := range changes {
+		got = append(got, c.String())
+	}
+	want := []string{
+		"func Sum: updated to the new stub",
+		"func Join: conflict, you both changed it; yours is kept",
+		"func Old: removed",
+		"func Min: added",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("changes:\ngot  %q\nwant %q", got, want)
+	}
+	if up := changes[1].Upstream; !strings.Contains(up, "\n+func Join(words []string, sep string) string {\n") {
+		t.Errorf("conflict doesn't show the upstream change:\n%s", up)
+	}
+
+	src := string(out)
+	for _, keep := range []string{
+		"// In JS: nums.reduce",           // Sum: the new stub
+		`return strings.Join(words, " ")`, // Join: yours
+		"best = max(best, n)",             // Max: yours, unchanged upstream
+		"func helper() {}",                // your own helper
+	} {
+		if !strings.Contains(src, keep) {
+			t.Errorf("merged file lacks %q:\n%s", keep, src)
+		}
+	}
+	if strings.Contains(src, "func Old") {
+		t.Errorf("Old is still there:\n%s", src)
+	}
+	if i, j := strings.Index(src, "func Join"), strings.Index(src, "func Min"); j < i || j > strings.Index(src, "func Max") {
+		t.Errorf("Min isn't between Join and Max:\n%s", src)
+	}
+}
+
+func TestMergeNothingToDo(t *testing.T) {
+	for name, mine := range map[string]string{"untouched": base, "already merged": theirs} {
+		out, changes, err := Merge([]byte(base), []byte(mine), []byte(base))
+		if err != nil || len(changes) != 0 || string(out) != mine {
+			t.Errorf("%s: got %v, %v:\n%s", name, changes, err, out)
+		}
+	}
+}
+
+func TestMergeNewFile(t *testing.T) {
+	out, changes, err := Merge(nil, []byte("package x\n"), []byte("package x\n\n// A is new.\nfunc A() {}\n\nfunc B() {}\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 2 || changes[0].Decl != "func A" || changes[1].Status != Added {
+		t.Errorf("changes: %v", changes)
+	}
+	if want := "package x\n\n// A is new.\nfunc A() {}\n\nfunc B() {}\n"; string(out) != want {
+		t.Errorf("got\n%s\nwant\n%s", out, want)
+	}
+}
+
+func TestMergeKeys(t *testing.T) {
+	src := `package x
+
+type Stack[T any] struct{}
+
+func (s *Stack[T]) Push(v T) {}
+
+var a, b = 1, 2
+
+const (
+	Z = iota
+	Y
+)
+`
+	f, err := split([]byte(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, d := range f.decls {
+		got = append(got, d.key)
+	}
+	want := "type Stack|method (*Stack).Push|var a, b|const Y, Z"
+	if strings.Join(got, "|") != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestMergeBadSyntax(t *testing.T) {
+	if _, _, err := Merge([]byte(base), []byte("package x\nfunc {"), []byte(theirs)); err == nil || !strings.HasPrefix(err.Error(), "your file:") {
+		t.Errorf("got %v", err)
+	}
+}
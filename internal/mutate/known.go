@@ -0,0 +1,50 @@
+package mutate
+
+import (
+	"bufio"
+	_ "embed"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// KnownFile is where the known survivors live, relative to the
+// repository root.
+const KnownFile = "internal/mutate/survivors.txt"
+
+// survivors is the list of mutants known to survive each exercise's
+// tests, one per line: the exercise ID, then the mutant's ID. Blank
+// lines and lines starting with # are ignored.
+//
+//go:embed survivors.txt
+var survivors string
+
+// Known returns the mutants known to survive, by exercise ID. A run that
+// finds only these passes, so `learngo mutate` can gate CI: a new
+// exercise, or a change to an old one, can't add weak tests unnoticed.
+// Like a snapshot file in Jest, the list is reviewed and committed.
+func Known() map[string][]string {
+	known, err := ParseKnown(strings.NewReader(survivors))
+	if err != nil {
+		panic(err) // TestKnown catches this before it ships
+	}
+	return known
+}
+
+// ParseKnown reads a list in the format of survivors.txt.
+func ParseKnown(r io.Reader) (map[string][]string, error) {
+	known := map[string][]string{}
+	sc := bufio.NewScanner(r)
+	for n := 1; sc.Scan(); n++ {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		exercise, id, ok := strings.Cut(line, " ")
+		if id = strings.TrimSpace(id); !ok || id == "" {
+			return nil, fmt.Errorf("line %d: want \"<exercise> <mutant>\", got %q", n, line)
+		}
+		known[exercise] = append(known[exercise], id)
+	}
+	return known, sc.Err()
+}
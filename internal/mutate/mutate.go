@@ -0,0 +1,190 @@
+// Package mutate checks that an exercise's tests actually pin down its
+// solution. It makes small, plausible bugs ("mutants") in the reference
+// solution, like turning < into <=, and runs the tests against each one.
+// A mutant the tests still pass on has survived, which means a student
+// could make that same mistake and never find out. It's what Stryker
+// does for JS test suites.
+//
+// Mutants are edits to the source text, so the rest of the file,
+// comments included, stays byte for byte the same.
+package mutate
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Kind says which mutation produced a mutant.
+type Kind string
+
+const (
+	Comparison Kind = "comparison"  // flip a comparison operator
+	Constant   Kind = "constant"    // off by one in an integer literal
+	ErrorCheck Kind = "error-check" // skip an `if err != nil` block
+)
+
+// Mutant is the solution with one small change.
+type Mutant struct {
+	Kind Kind
+	Pos  token.Position // where the change is
+	Func string         // the function it's in, "Type.Method" for a method
+	Desc string         // e.g. "< -> <="
+	Src  []byte         // the whole mutated file
+
+	// ID names the mutant by what it changes rather than where, so it
+	// stays the same when the lines around it move: "Max: comparison:
+	// > -> >=", with " #2" and up for the same change again in the same
+	// function. Accepted survivors are listed by ID (see Accepted).
+	ID string
+}
+
+func (m Mutant) String() string {
+	return fmt.Sprintf("%s: %s", m.Pos, m.ID)
+}
+
+// flips is the replacement for each comparison operator. Boundaries
+// move by one and equality is negated, the usual off-by-one suspects.
+var flips = map[token.Token]token.Token{
+	token.LSS: token.LEQ,
+	token.LEQ: token.LSS,
+	token.GTR: token.GEQ,
+	token.GEQ: token.GTR,
+	token.EQL: token.NEQ,
+	token.NEQ: token.EQL,
+}
+
+// Generate returns every mutant of the Go file src, in source order.
+func Generate(filename string, src []byte) ([]Mutant, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, filename, src, parser.SkipObjectResolution)
+	if err != nil {
+		return nil, err
+	}
+	tf := fset.File(f.Pos())
+
+	var mutants []Mutant
+	var fn string
+	// edit replaces src[start:end] with repl.
+	edit := func(kind Kind, start, end token.Pos, repl, desc string) {
+		lo, hi := tf.Offset(start), tf.Offset(end)
+		out := slices.Concat(src[:lo], []byte(repl), src[hi:])
+		mutants = append(mutants, Mutant{Kind: kind, Pos: fset.Position(start), Func: fn, Desc: desc, Src: out})
+	}
+
+	for _, decl := range f.Decls {
+		// Only function bodies: mutating a type or a constant the
+		// tests also use tends to change both sides at once.
+		decl, ok := decl.(*ast.FuncDecl)
+		if !ok || decl.Body == nil {
+			continue
+		}
+		fn = funcName(decl)
+		ast.Inspect(decl.Body, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.BinaryExpr:
+				if to, ok := flips[n.Op]; ok {
+					end := n.OpPos + token.Pos(len(n.Op.String()))
+					edit(Comparison, n.OpPos, end, to.String(), n.Op.String()+" -> "+to.String())
+				}
+			case *ast.BasicLit:
+				if n.Kind != token.INT {
+					break
+				}
+				repl, ok := plusOne(n.Value)
+				if !ok {
+					break // doesn't fit in an int64; leave it alone
+				}
+				edit(Constant, n.Pos(), n.End(), repl, n.Value+" -> "+repl)
+			case *ast.IfStmt:
+				if isErrCheck(n.Cond) {
+					edit(ErrorCheck, n.Cond.Pos(), n.Cond.End(), "false", "skip `if "+exprString(fset, n.Cond)+"`")
+				}
+			}
+			return true
+		})
+	}
+	slices.SortStableFunc(mutants, func(a, b Mutant) int { return a.Pos.Offset - b.Pos.Offset })
+	seen := map[string]int{}
+	for i, m := range mutants {
+		id := fmt.Sprintf("%s: %s: %s", m.Func, m.Kind, m.Desc)
+		if seen[id]++; seen[id] > 1 {
+			id += fmt.Sprintf(" #%d", seen[id])
+		}
+		mutants[i].ID = id
+	}
+	return mutants, nil
+}
+
+// funcName is "F" for a function and "T.M" for a method, pointer
+// receiver or not.
+func funcName(fn *ast.FuncDecl) string {
+	if fn.Recv == nil || len(fn.Recv.List) == 0 {
+		return fn.Name.Name
+	}
+	t := fn.Recv.List[0].Type
+	if star, ok := t.(*ast.StarExpr); ok {
+		t = star.X
+	}
+	switch x := t.(type) {
+	case *ast.IndexExpr: // generic: Stack[T]
+		t = x.X
+	case *ast.IndexListExpr:
+		t = x.X
+	}
+	if id, ok := t.(*ast.Ident); ok {
+		return id.Name + "." + fn.Name.Name
+	}
+	return fn.Name.Name
+}
+
+// plusOne adds one to the integer literal lit and writes the result in
+// the same base, so a file mode stays readable: 0644 -> 0645, not 421.
+func plusOne(lit string) (string, bool) {
+	v, err := strconv.ParseInt(lit, 0, 64)
+	if err != nil {
+		return "", false
+	}
+	v++
+	lower := strings.ToLower(lit)
+	switch {
+	case strings.HasPrefix(lower, "0x"):
+		return lit[:2] + strconv.FormatInt(v, 16), true
+	case strings.HasPrefix(lower, "0o"):
+		return lit[:2] + strconv.FormatInt(v, 8), true
+	case strings.HasPrefix(lower, "0b"):
+		return lit[:2] + strconv.FormatInt(v, 2), true
+	case len(lit) > 1 && lit[0] == '0':
+		return "0" + strconv.FormatInt(v, 8), true
+	}
+	return strconv.FormatInt(v, 10), true
+}
+
+// isErrCheck reports whether cond looks like `err != nil`. Without type
+// information we go by the name: err, or anything ending in Err or err.
+func isErrCheck(cond ast.Expr) bool {
+	b, ok := cond.(*ast.BinaryExpr)
+	if !ok || b.Op != token.NEQ {
+		return false
+	}
+	x, ok := b.X.(*ast.Ident)
+	if !ok {
+		return false
+	}
+	if y, ok := b.Y.(*ast.Ident); !ok || y.Name != "nil" {
+		return false
+	}
+	return strings.HasSuffix(x.Name, "err") || strings.HasSuffix(x.Name, "Err")
+}
+
+func exprString(fset *token.FileSet, e ast.Expr) string {
+	var buf bytes.Buffer
+	format.Node(&buf, fset, e)
+	return buf.String()
+}
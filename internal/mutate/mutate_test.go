@@ -0,0 +1,163 @@
+package mutate
+
+import (
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+
+	"github.com/imgarylai/learn-go/internal/registry"
+)
+
+const solution = `package sum
+
+import "errors"
+
+// Max returns the largest of nums.
+func Max(nums []int) (int, error) {
+	if len(nums) == 0 {
+		return 0, errors.New("empty")
+	}
+	best := nums[0]
+	for _, n := range nums[1:] {
+		if n > best {
+			best = n
+		}
+	}
+	return best, nil
+}
+
+func Parse(s string) (int, error) {
+	n, err := atoi(s)
+	if err != nil {
+		return 0, err
+	}
+	return n, nil
+}
+`
+
+func TestGenerate(t *testing.T) {
+	mutants, err := Generate("solution.go", []byte(solution))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for _, m := range mutants {
+		got = append(got, string(m.Kind)+" "+m.Desc)
+		if _, err := parser.ParseFile(token.NewFileSet(), "", m.Src, 0); err != nil {
+			t.Errorf("%s: mutant doesn't parse: %v", m, err)
+		}
+	}
+	want := []string{
+		"comparison == -> !=",
+		"constant 0 -> 1",
+		"constant 0 -> 1",
+		"constant 0 -> 1",
+		"constant 1 -> 2",
+		"comparison > -> >=",
+		"error-check skip `if err != nil`",
+		"comparison != -> ==",
+		"constant 0 -> 1",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("mutants:\ngot  %q\nwant %q", got, want)
+	}
+
+	// Only the mutated token changes.
+	m := mutants[5]
+	if !strings.Contains(string(m.Src), "if n >= best {") || m.Pos.Line != 12 {
+		t.Errorf("bad > mutant at %s:\n%s", m.Pos, m.Src)
+	}
+	if !strings.Contains(string(m.Src), "// Max returns the largest of nums.") {
+		t.Error("comments should survive mutation")
+	}
+	if strings.Contains(string(mutants[6].Src), "if err != nil") {
+		t.Errorf("error check still there:\n%s", mutants[6].Src)
+	}
+}
+
+func TestGenerateIDs(t *testing.T) {
+	src := `package x
+
+type Stack[T any] struct{ items []T }
+
+func (s *Stack[T]) Empty() bool { return len(s.items) == 0 }
+
+func Clamp(n int) int {
+	if n < 0 {
+		return 0
+	}
+	if n < 0o10 {
+		return 0x0f
+	}
+	return n
+}
+`
+	mutants, err := Generate("x.go", []byte(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, m := range mutants {
+		got = append(got, m.ID)
+	}
+	want := []string{
+		"Stack.Empty: comparison: == -> !=",
+		"Stack.Empty: constant: 0 -> 1",
+		"Clamp: comparison: < -> <=",
+		"Clamp: constant: 0 -> 1",
+		"Clamp: constant: 0 -> 1 #2",
+		"Clamp: comparison: < -> <= #2",
+		"Clamp: constant: 0o10 -> 0o11",
+		"Clamp: constant: 0x0f -> 0x10",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("IDs:\ngot  %q\nwant %q", got, want)
+	}
+}
+
+func TestPlusOne(t *testing.T) {
+	for lit, want := range map[string]string{
+		"9": "10", "0": "1", "0644": "0645", "0o777": "0o1000", "0XfF": "0X100", "0b1": "0b10", "1_000": "1001",
+	} {
+		if got, ok := plusOne(lit); !ok || got != want {
+			t.Errorf("plusOne(%s) = %s, %v; want %s", lit, got, ok, want)
+		}
+	}
+	if _, ok := plusOne("99999999999999999999"); ok {
+		t.Error("a literal that overflows int64 was mutated")
+	}
+}
+
+func TestKnown(t *testing.T) {
+	known := Known() // panics if survivors.txt is malformed
+	for exercise, ids := range known {
+		// A pasted line of mutate's output parses as an exercise
+		// named "23-writing-tests:", which nothing would ever match.
+		if e, ok := registry.Lookup(exercise); !ok || e.ID != exercise {
+			t.Errorf("%q isn't an exercise ID", exercise)
+		}
+		seen := map[string]bool{}
+		for _, id := range ids {
+			if seen[id] {
+				t.Errorf("%s: %s is listed twice", exercise, id)
+			}
+			seen[id] = true
+		}
+	}
+
+	got, err := ParseKnown(strings.NewReader("# why\n\n04-collections Sum: comparison: < -> <=\n"))
+	if err != nil || len(got["04-collections"]) != 1 || got["04-collections"][0] != "Sum: comparison: < -> <=" {
+		t.Errorf("got %v, %v", got, err)
+	}
+	if _, err := ParseKnown(strings.NewReader("04-collections\n")); err == nil {
+		t.Error("a line without a mutant was accepted")
+	}
+}
+
+func TestGenerateSyntaxError(t *testing.T) {
+	if _, err := Generate("bad.go", []byte("package x\nfunc {")); err == nil {
+		t.Error("expected a parse error")
+	}
+}
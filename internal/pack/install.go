@@ -0,0 +1,112 @@
+package pack
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// A pack can be published as a Go module: put learngo-pack.json at the
+// module root, next to go.mod, and tag a release. `learngo install-pack
+// github.com/acme/go-exercises` then fetches it like `go get` would,
+// through GOPROXY and the module cache, and copies it into the pack
+// directory, the way `npm install -g` unpacks a package.
+
+// Download fetches module, "path" or "path@version" (latest by
+// default), with `go mod download` and returns the directory it was
+// unpacked in, inside the read-only module cache, and the exact
+// version.
+func Download(ctx context.Context, module string) (dir, version string, err error) {
+	if !strings.Contains(module, "@") {
+		module += "@latest"
+	}
+	cmd := exec.CommandContext(ctx, "go", "mod", "download", "-json", module)
+	cmd.Dir = os.TempDir() // outside any module, so go.mod doesn't get in the way
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, runErr := cmd.Output()
+	var info struct{ Dir, Version, Error string }
+	if err := json.Unmarshal(out, &info); err != nil {
+		if runErr != nil {
+			return "", "", fmt.Errorf("go mod download %s: %v\n%s", module, runErr, strings.TrimSpace(stderr.String()))
+		}
+		return "", "", fmt.Errorf("go mod download %s: %w", module, err)
+	}
+	if info.Error != "" {
+		return "", "", fmt.Errorf("go mod download %s: %s", module, info.Error)
+	}
+	return info.Dir, info.Version, nil
+}
+
+// Install copies the pack in src into root, as root/<name>, and loads
+// the copy. It refuses to overwrite an installed pack of the same name
+// unless replace is set. The copy is made next to the destination and
+// renamed into place, so a failure never leaves half a pack behind.
+func Install(src, root string, replace bool) (*Pack, error) {
+	p, err := Load(src)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("%s has no %s: it isn't an exercise pack", src, ManifestFile)
+	}
+	if err != nil {
+		return nil, err
+	}
+	dest := filepath.Join(root, p.Name)
+	if _, err := os.Stat(dest); err == nil && !replace {
+		return nil, fmt.Errorf("pack %s is already installed in %s", p.Name, dest)
+	}
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, err
+	}
+	// Discover skips dot directories, so the half-copied pack is never loaded.
+	tmp, err := os.MkdirTemp(root, "."+p.Name+"-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmp)
+	if err := copyTree(p.Dir, tmp); err != nil {
+		return nil, err
+	}
+	if err := os.RemoveAll(dest); err != nil {
+		return nil, err
+	}
+	if err := os.Rename(tmp, dest); err != nil {
+		return nil, err
+	}
+	return Load(dest)
+}
+
+// copyTree copies the files under src to dst, leaving out version
+// control. Files in the module cache are read-only; the copies aren't,
+// so the exercises can be edited.
+func copyTree(src, dst string) error {
+	return filepath.WalkDir(src, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, p)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		switch {
+		case d.IsDir() && d.Name() == ".git":
+			return filepath.SkipDir
+		case d.IsDir():
+			return os.MkdirAll(target, 0o755)
+		case !d.Type().IsRegular():
+			return nil
+		}
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(target, data, 0o644)
+	})
+}
@@ -0,0 +1,207 @@
+// Package pack loads external exercise packs: folders of extra exercises
+// that someone else maintains, like a company's internal-domain drills.
+// learngo picks them up without anyone forking this repository.
+//
+// A pack is a directory with a learngo-pack.json manifest next to one
+// folder per exercise. It's the same idea as an npm package's
+// package.json: the manifest says what's inside, and the folders hold
+// the code.
+//
+//	acme/
+//	  learngo-pack.json
+//	  go.mod
+//	  acme-01-ledger/
+//	    ledger.go
+//	    ledger_test.go
+//
+// Exercises run with `go test` from their own folder, so a pack needs
+// its own go.mod (or has to live inside a module).
+package pack
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/imgarylai/learn-go/internal/registry"
+)
+
+// ManifestFile is the name of the manifest inside a pack directory.
+const ManifestFile = "learngo-pack.json"
+
+// Manifest is the contents of learngo-pack.json.
+type Manifest struct {
+	// Name identifies the pack. Every exercise ID must start with
+	// Name + "-", so packs can't collide with each other or with the
+	// built-in exercises.
+	Name      string     `json:"name"`
+	Title     string     `json:"title,omitempty"`
+	Exercises []Exercise `json:"exercises"`
+}
+
+// Exercise is one entry in the manifest. Prerequisites may name
+// built-in exercises as well as other exercises from the same pack.
+type Exercise struct {
+	ID            string             `json:"id"`
+	Dir           string             `json:"dir,omitempty"` // relative to the pack; defaults to ID
+	Title         string             `json:"title"`
+	Topics        []string           `json:"topics,omitempty"`
+	Difficulty    string             `json:"difficulty"`
+	Prerequisites []string           `json:"prerequisites,omitempty"`
+	Hints         []string           `json:"hints,omitempty"`
+	Weights       map[string]float64 `json:"weights,omitempty"`
+	Race          bool               `json:"race,omitempty"` // run with -race by default
+}
+
+// Pack is a loaded, validated pack.
+type Pack struct {
+	Manifest
+	Dir string // absolute path of the pack directory
+}
+
+var validName = regexp.MustCompile(`^[a-z][a-z0-9]*$`)
+
+// Load reads and validates the pack in dir.
+func Load(dir string) (*Pack, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	path := filepath.Join(dir, ManifestFile)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	p := &Pack{Manifest: m, Dir: dir}
+	if err := p.validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return p, nil
+}
+
+func (p *Pack) validate() error {
+	if !validName.MatchString(p.Name) {
+		return fmt.Errorf("pack name %q must be lowercase letters and digits", p.Name)
+	}
+	if len(p.Exercises) == 0 {
+		return errors.New("pack has no exercises")
+	}
+	for _, e := range p.Exercises {
+		if !strings.HasPrefix(e.ID, p.Name+"-") {
+			return fmt.Errorf("exercise ID %q must start with %q", e.ID, p.Name+"-")
+		}
+		if _, err := registry.ParseDifficulty(e.Difficulty); err != nil {
+			return fmt.Errorf("exercise %s: %w", e.ID, err)
+		}
+		dir := e.dir()
+		if !filepath.IsLocal(dir) {
+			return fmt.Errorf("exercise %s: dir %q must be inside the pack", e.ID, dir)
+		}
+		if info, err := os.Stat(filepath.Join(p.Dir, dir)); err != nil || !info.IsDir() {
+			return fmt.Errorf("exercise %s: missing directory %s", e.ID, dir)
+		}
+	}
+	return nil
+}
+
+func (e Exercise) dir() string {
+	if e.Dir != "" {
+		return filepath.FromSlash(e.Dir)
+	}
+	return e.ID
+}
+
+// RegistryExercises converts the manifest entries into registry exercises,
+// ready for registry.Register.
+func (p *Pack) RegistryExercises() []registry.Exercise {
+	var out []registry.Exercise
+	for _, e := range p.Exercises {
+		d, _ := registry.ParseDifficulty(e.Difficulty) // checked by Load
+		out = append(out, registry.Exercise{
+			ID:            e.ID,
+			Title:         e.Title,
+			Topics:        e.Topics,
+			Difficulty:    d,
+			Prerequisites: e.Prerequisites,
+			Hints:         e.Hints,
+			Weights:       e.Weights,
+			Race:          e.Race,
+			Pack:          p.Name,
+			Path:          filepath.Join(p.Dir, e.dir()),
+		})
+	}
+	return out
+}
+
+// DefaultPaths returns where to look for packs. LEARNGO_PACKS is a list
+// of directories separated like $PATH; otherwise ~/.learn-go/packs.
+func DefaultPaths() ([]string, error) {
+	if env := os.Getenv("LEARNGO_PACKS"); env != "" {
+		return filepath.SplitList(env), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, err
+	}
+	return []string{filepath.Join(home, ".learn-go", "packs")}, nil
+}
+
+// Discover loads every pack found in paths. Each path is either a pack
+// itself or a directory whose subdirectories are packs. Missing paths
+// and hidden subdirectories are skipped. A broken pack doesn't stop the
+// others from loading; its error is joined into the returned error.
+func Discover(paths []string) ([]*Pack, error) {
+	var packs []*Pack
+	var errs []error
+	load := func(dir string) {
+		p, err := Load(dir)
+		if err != nil {
+			errs = append(errs, err)
+			return
+		}
+		packs = append(packs, p)
+	}
+
+	for _, path := range paths {
+		if path == "" {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(path, ManifestFile)); err == nil {
+			load(path)
+			continue
+		}
+		entries, err := os.ReadDir(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		for _, e := range entries {
+			dir := filepath.Join(path, e.Name())
+			if strings.HasPrefix(e.Name(), ".") {
+				continue // hidden, like a pack Install is still copying
+			}
+			if e.IsDir() && isFile(filepath.Join(dir, ManifestFile)) {
+				load(dir)
+			}
+		}
+	}
+	return packs, errors.Join(errs...)
+}
+
+func isFile(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
+}
@@ -0,0 +1,143 @@
+package pack
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const manifest = `{
+  "name": "acme",
+  "title": "ACME internal drills",
+  "exercises": [
+    {
+      "id": "acme-01-ledger",
+      "title": "Ledger",
+      "topics": ["money"],
+      "difficulty": "intermediate",
+      "prerequisites": ["04-collections"],
+      "hints": ["Store cents as int64."]
+    }
+  ]
+}`
+
+// writePack creates a pack called name under parent with one exercise dir.
+func writePack(t *testing.T, parent, name, manifest string) string {
+	t.Helper()
+	dir := filepath.Join(parent, name)
+	if err := os.MkdirAll(filepath.Join(dir, "acme-01-ledger"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, ManifestFile), []byte(manifest), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestLoad(t *testing.T) {
+	dir := writePack(t, t.TempDir(), "acme", manifest)
+
+	p, err := Load(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exs := p.RegistryExercises()
+	if len(exs) != 1 {
+		t.Fatalf("got %d exercises, want 1", len(exs))
+	}
+	e := exs[0]
+	if e.Pack != "acme" || e.Path != filepath.Join(dir, "acme-01-ledger") {
+		t.Errorf("pack %q, path %q", e.Pack, e.Path)
+	}
+	if e.Difficulty != "intermediate" || len(e.Hints) != 1 {
+		t.Errorf("metadata not copied: %+v", e)
+	}
+}
+
+func TestLoadRejectsBadManifests(t *testing.T) {
+	tests := []struct {
+		name, from, to, want string
+	}{
+		{"bad name", `"name": "acme"`, `"name": "ACME"`, "lowercase"},
+		{"foreign id", `"id": "acme-01-ledger"`, `"id": "04-collections"`, `must start with "acme-"`},
+		{"difficulty", `"intermediate"`, `"hard"`, "unknown difficulty"},
+		{"escaping dir", `"title": "Ledger"`, `"title": "Ledger", "dir": "../elsewhere"`, "inside the pack"},
+		{"missing dir", `"title": "Ledger"`, `"title": "Ledger", "dir": "nope"`, "missing directory"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := strings.Replace(manifest, tt.from, tt.to, 1)
+			_, err := Load(writePack(t, t.TempDir(), "acme", m))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("got %v, want an error containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiscover(t *testing.T) {
+	parent := t.TempDir()
+	writePack(t, parent, "acme", manifest)
+	writePack(t, parent, "broken", "{")
+	single := writePack(t, t.TempDir(), "other", strings.ReplaceAll(manifest, "acme", "other"))
+	// The exercise folder keeps its acme name; point the manifest at it.
+	if err := os.Rename(filepath.Join(single, "acme-01-ledger"), filepath.Join(single, "other-01-ledger")); err != nil {
+		t.Fatal(err)
+	}
+
+	packs, err := Discover([]string{parent, single, filepath.Join(parent, "missing")})
+	if err == nil || !strings.Contains(err.Error(), "broken") {
+		t.Errorf("want an error about the broken pack, got %v", err)
+	}
+	var names []string
+	for _, p := range packs {
+		names = append(names, p.Name)
+	}
+	if got := strings.Join(names, ","); got != "acme,other" {
+		t.Errorf("loaded %s, want acme,other", got)
+	}
+}
+
+func TestInstall(t *testing.T) {
+	src := writePack(t, t.TempDir(), "go-acme-exercises", manifest)
+	if err := os.WriteFile(filepath.Join(src, "acme-01-ledger", "ledger.go"), []byte("package ledger\n"), 0o444); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(src, ".git"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	root := filepath.Join(t.TempDir(), "packs")
+
+	p, err := Install(src, root, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Name != "acme" || p.Dir != filepath.Join(root, "acme") {
+		t.Errorf("installed %s in %s", p.Name, p.Dir)
+	}
+	info, err := os.Stat(filepath.Join(root, "acme", "acme-01-ledger", "ledger.go"))
+	if err != nil || info.Mode().Perm()&0o200 == 0 {
+		t.Errorf("ledger.go should be copied and writable: %v, %v", info, err)
+	}
+	if _, err := os.Stat(filepath.Join(root, "acme", ".git")); err == nil {
+		t.Error(".git was copied")
+	}
+
+	if _, err := Install(src, root, false); err == nil || !strings.Contains(err.Error(), "already installed") {
+		t.Errorf("second install: got %v", err)
+	}
+	if _, err := Install(src, root, true); err != nil {
+		t.Errorf("replace: %v", err)
+	}
+	packs, err := Discover([]string{root})
+	if err != nil || len(packs) != 1 {
+		t.Errorf("after installing: %d packs, %v", len(packs), err)
+	}
+}
+
+func TestInstallNotAPack(t *testing.T) {
+	if _, err := Install(t.TempDir(), t.TempDir(), false); err == nil || !strings.Contains(err.Error(), "isn't an exercise pack") {
+		t.Errorf("got %v", err)
+	}
+}
@@ -0,0 +1,176 @@
+package progress
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"time"
+)
+
+// ExportFormat and ExportVersion identify an exported progress file, so
+// `learngo progress import` can tell one from any other JSON file and
+// later versions of learngo can still read old exports.
+const (
+	ExportFormat  = "learngo-progress"
+	ExportVersion = 1
+)
+
+// Export is the portable form of a progress file: what `learngo progress
+// export` writes for moving to another machine or handing to an
+// instructor. It's the progress file plus a small header, the way a
+// package-lock.json carries a lockfileVersion.
+type Export struct {
+	Format    string               `json:"format"`
+	Version   int                  `json:"version"`
+	Learner   string               `json:"learner,omitempty"`
+	Exported  time.Time            `json:"exported"`
+	Exercises map[string]*Exercise `json:"exercises"`
+	Badges    map[string]time.Time `json:"badges,omitempty"`
+}
+
+// Export wraps f for writing, stamped with who and when.
+func (f *File) Export(learner string, now time.Time) Export {
+	exercises := f.Exercises
+	if exercises == nil {
+		exercises = map[string]*Exercise{}
+	}
+	return Export{
+		Format:    ExportFormat,
+		Version:   ExportVersion,
+		Learner:   learner,
+		Exported:  now.UTC(),
+		Exercises: exercises,
+		Badges:    f.Badges,
+	}
+}
+
+// WriteTo writes x as indented JSON.
+func (x Export) WriteTo(w io.Writer) (int64, error) {
+	data, err := json.MarshalIndent(x, "", "  ")
+	if err != nil {
+		return 0, err
+	}
+	n, err := w.Write(append(data, '\n'))
+	return int64(n), err
+}
+
+// ErrNotExport means the input is JSON but not an exported progress file.
+var ErrNotExport = errors.New("not a learngo progress export")
+
+// ReadExport reads and checks an export written by Export.WriteTo.
+func ReadExport(r io.Reader) (*Export, error) {
+	var x Export
+	if err := json.NewDecoder(r).Decode(&x); err != nil {
+		return nil, err
+	}
+	if x.Format != ExportFormat {
+		return nil, ErrNotExport
+	}
+	if x.Version < 1 || x.Version > ExportVersion {
+		return nil, fmt.Errorf("export version %d is not supported (want %d); update learngo", x.Version, ExportVersion)
+	}
+	for id, e := range x.Exercises {
+		if e == nil {
+			return nil, fmt.Errorf("%s: no data", id)
+		}
+		if _, ok := statusRank[e.Status]; !ok {
+			return nil, fmt.Errorf("%s: unknown status %q", id, e.Status)
+		}
+		for _, s := range e.Sessions {
+			if !s.End.IsZero() && s.End.Before(s.Start) {
+				return nil, fmt.Errorf("%s: session ends before it starts", id)
+			}
+		}
+		if len(e.Passed) > e.Tests {
+			return nil, fmt.Errorf("%s: %d tests passed out of %d", id, len(e.Passed), e.Tests)
+		}
+	}
+	return &x, nil
+}
+
+var statusRank = map[Status]int{NotStarted: 0, Started: 1, Done: 2}
+
+// Merge folds x into f, keeping the furthest-along of each: the later
+// status, every session from both sides, the better test run, the most
+// runs and hints, the first red and green runs, the latest quiz and
+// every badge, dated when it was first earned. Merging the same export
+// twice changes nothing the second time, so importing is always safe to
+// repeat.
+func (f *File) Merge(x *Export) {
+	for id, in := range x.Exercises {
+		e := f.Get(id)
+		if statusRank[in.Status] > statusRank[e.Status] {
+			e.Status = in.Status
+		}
+		e.Sessions = mergeSessions(e.Sessions, in.Sessions)
+		if better(in, e) {
+			e.RecordTests(in.Passed, in.Tests)
+		}
+		e.Hints = max(e.Hints, in.Hints)
+		e.Runs = max(e.Runs, in.Runs)
+		e.FirstRed = earliest(e.FirstRed, in.FirstRed)
+		e.FirstGreen = earliest(e.FirstGreen, in.FirstGreen)
+		if in.Quiz != nil && (e.Quiz == nil || in.Quiz.Taken.After(e.Quiz.Taken)) {
+			q := *in.Quiz
+			e.Quiz = &q
+		}
+	}
+	for id, at := range x.Badges {
+		if old, ok := f.Badges[id]; !ok || at.Before(old) {
+			if f.Badges == nil {
+				f.Badges = map[string]time.Time{}
+			}
+			f.Badges[id] = at
+		}
+	}
+}
+
+// earliest is the earlier of a and b, ignoring zero times.
+func earliest(a, b time.Time) time.Time {
+	if a.IsZero() || (!b.IsZero() && b.Before(a)) {
+		return b
+	}
+	return a
+}
+
+// better reports whether a's last test run got further than b's.
+func better(a, b *Exercise) bool {
+	as, aok := a.Completion()
+	bs, bok := b.Completion()
+	switch {
+	case !aok:
+		return false
+	case !bok:
+		return true
+	case as != bs:
+		return as > bs
+	default:
+		return len(a.Passed) > len(b.Passed)
+	}
+}
+
+// mergeSessions is the union of a and b in start order. Sessions that
+// start at the same moment are the same session, perhaps closed on one
+// side and still open on the other; the closed one wins.
+func mergeSessions(a, b []Session) []Session {
+	byStart := map[time.Time]Session{}
+	for _, s := range slices.Concat(a, b) {
+		s.Start, s.End = s.Start.UTC(), s.End.UTC()
+		// A zero End is before every real one.
+		if old, ok := byStart[s.Start]; !ok || s.End.After(old.End) {
+			byStart[s.Start] = s
+		}
+	}
+	if len(byStart) == 0 {
+		return nil
+	}
+	return slices.SortedFunc(maps.Values(byStart), func(x, y Session) int { return x.Start.Compare(y.Start) })
+}
+
+// IDs lists the exercises in x, sorted.
+func (x *Export) IDs() []string {
+	return slices.Sorted(maps.Keys(x.Exercises))
+}
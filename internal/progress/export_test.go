@@ -0,0 +1,106 @@
+package progress
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExportRoundTrip(t *testing.T) {
+	t0 := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	var f File
+	e := f.Get("04-collections")
+	e.Status = Done
+	e.Sessions = []Session{{Start: t0, End: t0.Add(time.Hour)}}
+	e.RecordTests([]string{"TestA", "TestB"}, 3)
+	e.Hints = 2
+	e.Quiz = &QuizScore{Correct: 2, Total: 3, Taken: t0}
+
+	var buf bytes.Buffer
+	if _, err := f.Export("Ada", t0).WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	x, err := ReadExport(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if x.Learner != "Ada" || !x.Exported.Equal(t0) {
+		t.Errorf("header: %+v", x)
+	}
+	if !reflect.DeepEqual(x.Exercises, f.Exercises) {
+		t.Errorf("got %+v, want %+v", x.Exercises["04-collections"], e)
+	}
+}
+
+func TestReadExportRejects(t *testing.T) {
+	tests := map[string]string{
+		"progress file":  `{"exercises": {}}`,
+		"future version": `{"format": "learngo-progress", "version": 99}`,
+		"bad status":     `{"format": "learngo-progress", "version": 1, "exercises": {"01-basics": {"status": "finished"}}}`,
+		"backwards":      `{"format": "learngo-progress", "version": 1, "exercises": {"01-basics": {"sessions": [{"start": "2025-01-02T00:00:00Z", "end": "2025-01-01T00:00:00Z"}]}}}`,
+		"too many":       `{"format": "learngo-progress", "version": 1, "exercises": {"01-basics": {"passed": ["TestA", "TestB"], "tests": 1}}}`,
+		"not json":       `progress`,
+	}
+	for name, in := range tests {
+		if _, err := ReadExport(strings.NewReader(in)); err == nil {
+			t.Errorf("%s: no error", name)
+		}
+	}
+	_, err := ReadExport(strings.NewReader(`{"exercises": {}}`))
+	if !errors.Is(err, ErrNotExport) {
+		t.Errorf("a plain progress file: got %v, want ErrNotExport", err)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	t0 := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	var home File
+	h := home.Get("04-collections")
+	h.Status = Started
+	h.Sessions = []Session{{Start: t0, End: t0.Add(time.Hour)}, {Start: t0.Add(5 * time.Hour)}}
+	h.RecordTests([]string{"TestA"}, 3)
+	h.Hints = 3
+	h.Runs, h.FirstRed = 7, t0.Add(30*time.Minute)
+	h.Quiz = &QuizScore{Correct: 1, Total: 3, Taken: t0}
+	home.Badges = map[string]time.Time{"first-green": t0.Add(2 * time.Hour)}
+
+	var work File
+	w := work.Get("04-collections")
+	w.Status = Done
+	w.Sessions = []Session{{Start: t0.Add(5 * time.Hour), End: t0.Add(6 * time.Hour)}}
+	w.RecordTests([]string{"TestA", "TestB", "TestC"}, 3)
+	w.Hints = 1
+	w.Runs, w.FirstRed, w.FirstGreen = 2, t0.Add(5*time.Hour), t0.Add(6*time.Hour)
+	w.Quiz = &QuizScore{Correct: 3, Total: 3, Taken: t0.Add(time.Hour)}
+	work.Get("05-interfaces").Status = Started
+	work.Badges = map[string]time.Time{"first-green": t0.Add(time.Hour), "perfect-quiz": t0.Add(time.Hour)}
+
+	x := work.Export("", t0)
+	home.Merge(&x)
+	home.Merge(&x) // a second import changes nothing
+
+	want := &Exercise{
+		Status:     Done,
+		Sessions:   []Session{{Start: t0, End: t0.Add(time.Hour)}, {Start: t0.Add(5 * time.Hour), End: t0.Add(6 * time.Hour)}},
+		Passed:     []string{"TestA", "TestB", "TestC"},
+		Tests:      3,
+		Hints:      3,
+		Runs:       7,
+		FirstRed:   t0.Add(30 * time.Minute),
+		FirstGreen: t0.Add(6 * time.Hour),
+		Quiz:       &QuizScore{Correct: 3, Total: 3, Taken: t0.Add(time.Hour)},
+	}
+	if got := home.Exercises["04-collections"]; !reflect.DeepEqual(got, want) {
+		t.Errorf("got  %+v\nwant %+v", got, want)
+	}
+	if got := home.Status("05-interfaces"); got != Started {
+		t.Errorf("05-interfaces: got %q, want started", got)
+	}
+	wantBadges := map[string]time.Time{"first-green": t0.Add(time.Hour), "perfect-quiz": t0.Add(time.Hour)}
+	if !reflect.DeepEqual(home.Badges, wantBadges) {
+		t.Errorf("badges: got %v, want %v", home.Badges, wantBadges)
+	}
+}
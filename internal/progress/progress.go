@@ -0,0 +1,231 @@
+// Package progress remembers where you are in the exercises between runs.
+//
+// State lives in a small JSON file (by default ~/.learn-go/progress.json),
+// a bit like the .eslintcache or jest cache files you may have seen in JS
+// projects: safe to delete, and you only lose your bookmarks.
+package progress
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+)
+
+// Status is how far along an exercise is.
+type Status string
+
+const (
+	NotStarted Status = ""
+	Started    Status = "started"
+	Done       Status = "done"
+)
+
+// Exercise is the saved state for one exercise.
+type Exercise struct {
+	Status   Status    `json:"status,omitempty"`
+	Sessions []Session `json:"sessions,omitempty"`
+
+	// Passed and Tests come from the last test run: the top-level
+	// tests that passed, and how many there were in all. Each test
+	// checks one function, so this is how many of them you've finished.
+	Passed []string `json:"passed,omitempty"`
+	Tests  int      `json:"tests,omitempty"`
+
+	// Runs counts the test runs that built. FirstRed is when one first
+	// had a failing test, and FirstGreen when every test first passed
+	// after that; see NoteRun.
+	Runs       int       `json:"runs,omitempty"`
+	FirstRed   time.Time `json:"first_red,omitzero"`
+	FirstGreen time.Time `json:"first_green,omitzero"`
+
+	// Hints counts how many times you've asked for the hints.
+	Hints int `json:"hints,omitempty"`
+
+	// Quiz is your last score on the exercise's quiz, nil if you
+	// haven't taken it.
+	Quiz *QuizScore `json:"quiz,omitempty"`
+
+	// Version is the exercise version (registry.Exercise.Version) your
+	// files follow: the stubs you started from, or the version `learngo
+	// migrate` last brought them up to. Zero means the first version.
+	Version int `json:"version,omitempty"`
+}
+
+// QuizScore is the result of one `learngo quiz`.
+type QuizScore struct {
+	Correct int       `json:"correct"`
+	Total   int       `json:"total"`
+	Taken   time.Time `json:"taken"`
+}
+
+// Session is one stretch of work on an exercise, from `learngo start`
+// to `learngo pause` or `learngo done`. End is zero while it is still open.
+type Session struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end,omitzero"`
+}
+
+// Open reports whether the last session hasn't been closed yet.
+func (e *Exercise) Open() bool {
+	return len(e.Sessions) > 0 && e.Sessions[len(e.Sessions)-1].End.IsZero()
+}
+
+// StartSession opens a new session at now, unless one is already open.
+func (e *Exercise) StartSession(now time.Time) {
+	if !e.Open() {
+		e.Sessions = append(e.Sessions, Session{Start: now})
+	}
+}
+
+// EndSession closes the open session at now, if there is one.
+func (e *Exercise) EndSession(now time.Time) {
+	if e.Open() {
+		e.Sessions[len(e.Sessions)-1].End = now
+	}
+}
+
+// TimeSpent adds up all sessions. An open session counts up to now.
+func (e *Exercise) TimeSpent(now time.Time) time.Duration {
+	var total time.Duration
+	for _, s := range e.Sessions {
+		end := s.End
+		if end.IsZero() {
+			end = now
+		}
+		total += end.Sub(s.Start)
+	}
+	return total
+}
+
+// RecordTests saves the outcome of a test run: the names of the
+// top-level tests that passed, out of total. It replaces the previous
+// run, so a test you break again stops counting.
+func (e *Exercise) RecordTests(passed []string, total int) {
+	e.Passed = slices.Sorted(slices.Values(passed))
+	e.Tests = total
+}
+
+// NoteRun counts the test run just saved by RecordTests, made at now.
+// The clock to green starts at the first run with a failing test, not
+// at `learngo start`, so it measures solving rather than reading.
+func (e *Exercise) NoteRun(now time.Time) {
+	e.Runs++
+	share, ok := e.Completion()
+	switch {
+	case !ok:
+	case share < 1 && e.FirstRed.IsZero():
+		e.FirstRed = now
+	case share == 1 && e.FirstGreen.IsZero() && !e.FirstRed.IsZero():
+		e.FirstGreen = now
+	}
+}
+
+// TimeToGreen is how long it took from the first failing run to the
+// first fully green one. ok is false until both have happened.
+func (e *Exercise) TimeToGreen() (d time.Duration, ok bool) {
+	if e.FirstRed.IsZero() || e.FirstGreen.IsZero() {
+		return 0, false
+	}
+	return e.FirstGreen.Sub(e.FirstRed), true
+}
+
+// Completion is the share of tests that passed in the last run, from 0
+// to 1. ok is false if the tests have never been run.
+func (e *Exercise) Completion() (share float64, ok bool) {
+	if e.Tests == 0 {
+		return 0, false
+	}
+	return float64(len(e.Passed)) / float64(e.Tests), true
+}
+
+// File is the whole progress file. The zero value is an empty, usable file.
+type File struct {
+	Exercises map[string]*Exercise `json:"exercises"`
+	// Badges maps each badge you've earned (see package achievements)
+	// to when you earned it. Badges are never taken away.
+	Badges map[string]time.Time `json:"badges,omitempty"`
+}
+
+// DefaultPath returns where the progress file is kept.
+// LEARNGO_PROGRESS overrides the default, which is handy in tests and CI.
+func DefaultPath() (string, error) {
+	if env := os.Getenv("LEARNGO_PROGRESS"); env != "" {
+		return env, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".learn-go", "progress.json"), nil
+}
+
+// Load reads the progress file at path. A missing file is not an error:
+// it just means nothing has been started yet.
+func Load(path string) (*File, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return &File{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var f File
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	return &f, nil
+}
+
+// Save writes f to path, creating the parent directory if needed.
+// It writes to a temporary file first and renames it into place, so a
+// crash halfway through never leaves a truncated progress file behind.
+func (f *File) Save(path string) error {
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// Get returns the entry for id, creating it if necessary.
+func (f *File) Get(id string) *Exercise {
+	if f.Exercises == nil {
+		f.Exercises = map[string]*Exercise{}
+	}
+	e, ok := f.Exercises[id]
+	if !ok {
+		e = &Exercise{}
+		f.Exercises[id] = e
+	}
+	return e
+}
+
+// Status returns the status of id without creating an entry.
+func (f *File) Status(id string) Status {
+	if e, ok := f.Exercises[id]; ok {
+		return e.Status
+	}
+	return NotStarted
+}
+
+// Completion is Exercise.Completion for id, without creating an entry.
+func (f *File) Completion(id string) (share float64, ok bool) {
+	if e, found := f.Exercises[id]; found {
+		return e.Completion()
+	}
+	return 0, false
+}
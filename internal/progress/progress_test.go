@@ -0,0 +1,130 @@
+package progress
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestLoadMissingFile(t *testing.T) {
+	f, err := Load(filepath.Join(t.TempDir(), "nope.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := f.Status("04-collections"); got != NotStarted {
+		t.Errorf("got %q, want not started", got)
+	}
+}
+
+func TestSaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "progress.json")
+
+	var f File
+	f.Get("04-collections").Status = Started
+	f.Get("01-basics").Status = Done
+	if err := f.Save(path); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := loaded.Status("04-collections"); got != Started {
+		t.Errorf("04-collections: got %q, want started", got)
+	}
+	if got := loaded.Status("01-basics"); got != Done {
+		t.Errorf("01-basics: got %q, want done", got)
+	}
+}
+
+func TestStatusDoesNotCreateEntries(t *testing.T) {
+	var f File
+	f.Status("06-concurrency")
+	if len(f.Exercises) != 0 {
+		t.Errorf("Status created an entry: %v", f.Exercises)
+	}
+}
+
+func TestSessions(t *testing.T) {
+	t0 := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+	var e Exercise
+
+	e.StartSession(t0)
+	e.StartSession(t0.Add(time.Minute)) // already open: ignored
+	if !e.Open() || len(e.Sessions) != 1 {
+		t.Fatalf("sessions after start: %+v", e.Sessions)
+	}
+	if got := e.TimeSpent(t0.Add(10 * time.Minute)); got != 10*time.Minute {
+		t.Errorf("open session: got %v, want 10m", got)
+	}
+
+	e.EndSession(t0.Add(30 * time.Minute))
+	e.EndSession(t0.Add(time.Hour)) // nothing open: ignored
+	e.StartSession(t0.Add(2 * time.Hour))
+	e.EndSession(t0.Add(2*time.Hour + 15*time.Minute))
+
+	if e.Open() {
+		t.Error("no session should be open")
+	}
+	if got := e.TimeSpent(t0.Add(24 * time.Hour)); got != 45*time.Minute {
+		t.Errorf("closed sessions: got %v, want 45m", got)
+	}
+}
+
+func TestRecordTests(t *testing.T) {
+	var f File
+	if _, ok := f.Completion("04-collections"); ok {
+		t.Error("Completion reported a share before any run")
+	}
+
+	e := f.Get("04-collections")
+	e.RecordTests([]string{"TestSum", "TestFilter"}, 5)
+	if got, _ := f.Completion("04-collections"); got != 0.4 {
+		t.Errorf("after first run: got %v, want 0.4", got)
+	}
+	if !slices.Equal(e.Passed, []string{"TestFilter", "TestSum"}) {
+		t.Errorf("Passed should be sorted: %v", e.Passed)
+	}
+
+	// A later run replaces the earlier one, regressions included.
+	e.RecordTests([]string{"TestFilter"}, 5)
+	if got, _ := e.Completion(); got != 0.2 {
+		t.Errorf("after regression: got %v, want 0.2", got)
+	}
+}
+
+func TestNoteRun(t *testing.T) {
+	t0 := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+	var e Exercise
+	run := func(passed int, at time.Time) {
+		e.RecordTests(make([]string, passed), 4)
+		e.NoteRun(at)
+	}
+
+	run(0, t0)
+	run(2, t0.Add(10*time.Minute))
+	if _, ok := e.TimeToGreen(); ok {
+		t.Error("TimeToGreen before any green run")
+	}
+	run(4, t0.Add(25*time.Minute))
+	run(3, t0.Add(30*time.Minute)) // breaking it again changes nothing
+	run(4, t0.Add(50*time.Minute))
+
+	if d, ok := e.TimeToGreen(); !ok || d != 25*time.Minute {
+		t.Errorf("TimeToGreen: got %v, %v; want 25m", d, ok)
+	}
+	if e.Runs != 5 {
+		t.Errorf("Runs: got %d, want 5", e.Runs)
+	}
+
+	// Green on the first run: there was nothing to solve, or it was
+	// solved somewhere the clock couldn't see.
+	var first Exercise
+	first.RecordTests([]string{"TestA"}, 1)
+	first.NoteRun(t0)
+	if _, ok := first.TimeToGreen(); ok || !first.FirstGreen.IsZero() {
+		t.Errorf("green first time: got %+v", first)
+	}
+}
@@ -0,0 +1,139 @@
+package proptest
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"strings"
+)
+
+// Int generates ints in [lo, hi], shrinking towards 0, or towards
+// whichever end is closer to 0 when 0 is out of range.
+func Int(lo, hi int) Gen[int] {
+	if lo > hi {
+		panic(fmt.Sprintf("proptest.Int(%d, %d): empty range", lo, hi))
+	}
+	target := min(max(0, lo), hi)
+	return Gen[int]{
+		Generate: func(r *rand.Rand, _ int) int {
+			return lo + r.IntN(hi-lo+1)
+		},
+		Shrink: func(v int) []int {
+			// target first, then halfway there, a quarter of the way...
+			// down to a single step.
+			var out []int
+			for d := v - target; d != 0; d /= 2 {
+				out = append(out, v-d)
+			}
+			return out
+		},
+	}
+}
+
+// SliceOf generates slices of up to maxLen elements from g. Slices
+// shrink by dropping elements, then by shrinking one element at a time.
+func SliceOf[T any](g Gen[T], maxLen int) Gen[[]T] {
+	return Gen[[]T]{
+		Generate: func(r *rand.Rand, size int) []T {
+			s := make([]T, r.IntN(min(size, maxLen)+1))
+			for i := range s {
+				s[i] = g.Generate(r, size)
+			}
+			return s
+		},
+		Shrink: func(s []T) [][]T {
+			var out [][]T
+			// Drop everything, then halves, quarters... then single
+			// elements.
+			for n := len(s); n > 0; n /= 2 {
+				for i := 0; i+n <= len(s); i += n {
+					out = append(out, append(s[:i:i], s[i+n:]...))
+				}
+			}
+			if g.Shrink == nil {
+				return out
+			}
+			for i, v := range s {
+				for _, c := range g.Shrink(v) {
+					t := append([]T(nil), s...)
+					t[i] = c
+					out = append(out, t)
+				}
+			}
+			return out
+		},
+	}
+}
+
+// StringOf generates strings of up to maxLen bytes from alphabet, which
+// must be ASCII. Strings shrink like slices, with each byte shrinking
+// towards the first one in alphabet: "ba" becomes "a", then "".
+func StringOf(alphabet string, maxLen int) Gen[string] {
+	indexes := SliceOf(Int(0, len(alphabet)-1), maxLen)
+	toString := func(is []int) string {
+		var b strings.Builder
+		for _, i := range is {
+			b.WriteByte(alphabet[i])
+		}
+		return b.String()
+	}
+	return Gen[string]{
+		Generate: func(r *rand.Rand, size int) string {
+			return toString(indexes.Generate(r, size))
+		},
+		Shrink: func(s string) []string {
+			is := make([]int, len(s))
+			for i := range s {
+				is[i] = strings.IndexByte(alphabet, s[i])
+			}
+			var out []string
+			for _, c := range indexes.Shrink(is) {
+				out = append(out, toString(c))
+			}
+			return out
+		},
+	}
+}
+
+// OneOf picks one of choices. It shrinks towards the first.
+func OneOf[T any](choices ...T) Gen[T] {
+	index := Int(0, len(choices)-1)
+	return Gen[T]{
+		Generate: func(r *rand.Rand, size int) T {
+			return choices[index.Generate(r, size)]
+		},
+	}
+}
+
+// Pair holds two generated values; see Zip.
+type Pair[A, B any] struct {
+	A A
+	B B
+}
+
+// GoString prints p as its two values, which is how Check reports it.
+func (p Pair[A, B]) GoString() string {
+	return fmt.Sprintf("%#v, %#v", p.A, p.B)
+}
+
+// Zip generates pairs, shrinking one side at a time.
+func Zip[A, B any](ga Gen[A], gb Gen[B]) Gen[Pair[A, B]] {
+	return Gen[Pair[A, B]]{
+		Generate: func(r *rand.Rand, size int) Pair[A, B] {
+			return Pair[A, B]{ga.Generate(r, size), gb.Generate(r, size)}
+		},
+		Shrink: func(p Pair[A, B]) []Pair[A, B] {
+			var out []Pair[A, B]
+			if ga.Shrink != nil {
+				for _, a := range ga.Shrink(p.A) {
+					out = append(out, Pair[A, B]{a, p.B})
+				}
+			}
+			if gb.Shrink != nil {
+				for _, b := range gb.Shrink(p.B) {
+					out = append(out, Pair[A, B]{p.A, b})
+				}
+			}
+			return out
+		},
+	}
+}
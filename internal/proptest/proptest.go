@@ -0,0 +1,116 @@
+// Package proptest is a small property-based testing library for the
+// exercise tests, in the spirit of fast-check in JS.
+//
+// A property is a func that should return true for every input:
+// "reversing a slice twice gives it back", "Sum(a+b) == Sum(a)+Sum(b)".
+// Check feeds it generated inputs, small ones first, and when one fails
+// it shrinks that input to the smallest one that still fails, so the
+// report reads "[]int{0, 1}" rather than twenty random numbers:
+//
+//	proptest.Check(t, proptest.SliceOf(proptest.Int(-100, 100), 20), func(s []int) bool {
+//		return slices.Equal(Reversed(Reversed(s)), s)
+//	})
+//
+// Inputs come from testutil.Rand, so LEARNGO_SEED replays a failure.
+package proptest
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/imgarylai/learn-go/internal/testutil"
+)
+
+// Runs is how many inputs Check and Find try.
+const Runs = 100
+
+// maxShrinks bounds shrinking, in case a Shrink func keeps offering
+// candidates that fail without getting any smaller.
+const maxShrinks = 1000
+
+// Gen generates and shrinks values of type T.
+type Gen[T any] struct {
+	// Generate returns a random value. size grows from 0 to Runs-1 over
+	// a run, so the first inputs are small (empty slices, short
+	// strings) and later ones bigger.
+	Generate func(r *rand.Rand, size int) T
+	// Shrink returns values a little simpler than v, most promising
+	// first. nil means v can't be shrunk.
+	Shrink func(v T) []T
+}
+
+// Failure describes a counterexample: an input the property fails for.
+type Failure[T any] struct {
+	Input   T   // the smallest failing input found
+	Shrinks int // how many times it was shrunk from the first one
+	Panic   any // what the property panicked with, if it did
+}
+
+// String reports f the way Check does.
+func (f Failure[T]) String() string {
+	msg := fmt.Sprintf("property fails for %#v", f.Input)
+	if f.Panic != nil {
+		msg = fmt.Sprintf("property panics (%v) for %#v", f.Panic, f.Input)
+	}
+	if f.Shrinks > 0 {
+		msg += fmt.Sprintf(" (shrunk %d times)", f.Shrinks)
+	}
+	return msg
+}
+
+// Check fails t if prop returns false, or panics, for any of Runs
+// generated inputs, reporting the smallest such input it can find.
+func Check[T any](t testing.TB, g Gen[T], prop func(T) bool) {
+	t.Helper()
+	if f, ok := Find(testutil.Rand(t), g, prop); ok {
+		t.Fatal(f)
+	}
+}
+
+// Check2 is Check for a property of two inputs.
+func Check2[A, B any](t testing.TB, ga Gen[A], gb Gen[B], prop func(A, B) bool) {
+	t.Helper()
+	Check(t, Zip(ga, gb), func(p Pair[A, B]) bool { return prop(p.A, p.B) })
+}
+
+// Find looks for an input prop fails for without failing a test, which
+// is what you want when testing the properties themselves.
+func Find[T any](r *rand.Rand, g Gen[T], prop func(T) bool) (Failure[T], bool) {
+	for size := range Runs {
+		v := g.Generate(r, size)
+		if ok, p := holds(prop, v); !ok {
+			return shrink(g, prop, Failure[T]{Input: v, Panic: p}), true
+		}
+	}
+	return Failure[T]{}, false
+}
+
+// shrink greedily replaces f's input with the first simpler candidate
+// that still fails, until none does.
+func shrink[T any](g Gen[T], prop func(T) bool, f Failure[T]) Failure[T] {
+	if g.Shrink == nil {
+		return f
+	}
+outer:
+	for f.Shrinks < maxShrinks {
+		for _, c := range g.Shrink(f.Input) {
+			if ok, p := holds(prop, c); !ok {
+				f = Failure[T]{Input: c, Shrinks: f.Shrinks + 1, Panic: p}
+				continue outer
+			}
+		}
+		break
+	}
+	return f
+}
+
+// holds runs prop, counting a panic as a failure.
+func holds[T any](prop func(T) bool, v T) (ok bool, panicked any) {
+	defer func() {
+		if p := recover(); p != nil {
+			ok, panicked = false, p
+		}
+	}()
+	return prop(v), nil
+}
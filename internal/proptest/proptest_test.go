@@ -0,0 +1,134 @@
+package proptest
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func newRand() *rand.Rand { return rand.New(rand.NewPCG(1, 2)) }
+
+func TestFindShrinksToTheSmallestInput(t *testing.T) {
+	// "No element is 10 or more" fails; the smallest counterexample is
+	// a single 10.
+	g := SliceOf(Int(-100, 100), 20)
+	f, ok := Find(newRand(), g, func(s []int) bool {
+		return !slices.ContainsFunc(s, func(n int) bool { return n >= 10 })
+	})
+	if !ok {
+		t.Fatal("no counterexample found")
+	}
+	if !slices.Equal(f.Input, []int{10}) {
+		t.Errorf("got %v, want [10]", f.Input)
+	}
+	if f.Shrinks == 0 {
+		t.Error("expected some shrinking")
+	}
+}
+
+func TestFindShrinksBothSidesOfAPair(t *testing.T) {
+	// A Sum that drops the last element: the smallest failing split is
+	// ([1], []) or ([], [1]).
+	sum := func(s []int) int {
+		total := 0
+		for i := 0; i < len(s)-1; i++ {
+			total += s[i]
+		}
+		return total
+	}
+	ints := SliceOf(Int(-100, 100), 20)
+	f, ok := Find(newRand(), Zip(ints, ints), func(p Pair[[]int, []int]) bool {
+		return sum(slices.Concat(p.A, p.B)) == sum(p.A)+sum(p.B)
+	})
+	if !ok {
+		t.Fatal("no counterexample found")
+	}
+	if len(f.Input.A)+len(f.Input.B) > 2 {
+		t.Errorf("got %#v, want at most two elements in all", f.Input)
+	}
+	if got := f.String(); !strings.HasPrefix(got, "property fails for []int{") {
+		t.Errorf("String: got %q", got)
+	}
+}
+
+func TestFindCountsAPanicAsAFailure(t *testing.T) {
+	f, ok := Find(newRand(), SliceOf(Int(0, 9), 10), func(s []int) bool {
+		return s[0] >= 0 // panics on the empty slice
+	})
+	if !ok || f.Panic == nil || len(f.Input) != 0 {
+		t.Fatalf("got %+v, %v; want a panic for the empty slice", f, ok)
+	}
+	if got := f.String(); !strings.Contains(got, "panics (runtime error: index out of range") {
+		t.Errorf("String: got %q", got)
+	}
+}
+
+func TestFindPassesAProperty(t *testing.T) {
+	if f, ok := Find(newRand(), StringOf("ab", 30), func(s string) bool {
+		return len(strings.ReplaceAll(s, "a", "")) <= len(s)
+	}); ok {
+		t.Errorf("a true property failed: %v", f)
+	}
+}
+
+func TestIntShrinksWithinItsRange(t *testing.T) {
+	tests := []struct {
+		lo, hi, v int
+		want      []int
+	}{
+		{-10, 10, 5, []int{0, 3, 4}},
+		{-10, 10, -5, []int{0, -3, -4}},
+		{3, 10, 7, []int{3, 5, 6}},
+		{-10, -3, -7, []int{-3, -5, -6}},
+		{-10, 10, 0, nil},
+	}
+	for _, tt := range tests {
+		if got := Int(tt.lo, tt.hi).Shrink(tt.v); !slices.Equal(got, tt.want) {
+			t.Errorf("Int(%d, %d).Shrink(%d): got %v, want %v", tt.lo, tt.hi, tt.v, got, tt.want)
+		}
+	}
+}
+
+func TestGeneratorsGrowWithSize(t *testing.T) {
+	r := newRand()
+	g := StringOf("xyz", 50)
+	if s := g.Generate(r, 0); s != "" {
+		t.Errorf("size 0: got %q, want an empty string", s)
+	}
+	longest := 0
+	for size := range Runs {
+		s := g.Generate(r, size)
+		if strings.Trim(s, "xyz") != "" {
+			t.Fatalf("%q has bytes outside the alphabet", s)
+		}
+		longest = max(longest, len(s))
+	}
+	if longest > 50 || longest < 20 {
+		t.Errorf("longest string was %d bytes, want up to 50", longest)
+	}
+	if got := g.Shrink("zy"); !slices.Contains(got, "") || !slices.Contains(got, "xy") {
+		t.Errorf(`Shrink("zy"): got %q`, got)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	Check2(t, Int(-5, 5), OneOf("a", "b"), func(n int, s string) bool { return n*n >= 0 && s != "" })
+
+	ft := &fakeT{TB: t}
+	Check(ft, Int(0, 100), func(n int) bool { return n < 50 })
+	if !strings.HasPrefix(ft.msg, "property fails for 50") {
+		t.Errorf("got %q", ft.msg)
+	}
+}
+
+// fakeT records the failure instead of failing the real test.
+type fakeT struct {
+	testing.TB
+	msg string
+}
+
+func (f *fakeT) Helper()           {}
+func (f *fakeT) Cleanup(func())    {}
+func (f *fakeT) Fatal(args ...any) { f.msg = fmt.Sprint(args...) }
@@ -0,0 +1,987 @@
+package quiz
+
+// banks are the questions for each exercise, keyed by exercise ID. Keep
+// them to a handful each, on what the exercise is about, and explain
+// the answer: the explanation is where the learning happens.
+var banks = map[string][]Question{
+	"01-basics": {
+		{
+			Prompt:  "What does `var count int` hold before you assign to it?",
+			Choices: []string{"undefined", "nil", "0", "It doesn't compile without a value"},
+			Answer:  2,
+			Explain: "Every Go type has a zero value: 0 for numbers, \"\" for strings, false for bools.",
+		},
+		{
+			Prompt:  "What does `strconv.Atoi(\"12a\")` return?",
+			Choices: []string{"12 and a nil error", "0 and a non-nil error", "NaN", "It panics"},
+			Answer:  1,
+			Explain: "Unlike parseInt, Go doesn't stop at the first bad character: the whole string must parse, and failure is an error value.",
+		},
+		{
+			Prompt:  "Which line declares a constant?",
+			Choices: []string{"let Max = 10", "const Max = 10", "var Max = 10", "Max := 10"},
+			Answer:  1,
+			Explain: "Constants are evaluated at compile time, so they can only hold numbers, strings, bools and runes.",
+		},
+	},
+	"02-functions": {
+		{
+			Prompt:  "How does a Go function usually report failure?",
+			Choices: []string{"It throws an exception", "It returns an error as its last result", "It returns -1", "It calls os.Exit"},
+			Answer:  1,
+			Explain: "Errors are values: `v, err := f()` then `if err != nil`. panic is for bugs, not expected failures.",
+		},
+		{
+			Prompt:  "When does a deferred call run?",
+			Choices: []string{"Immediately", "At the end of the enclosing block", "When the surrounding function returns", "When the program exits"},
+			Answer:  2,
+			Explain: "Defers run when the function returns, even on panic, in last-in first-out order, much like a finally block.",
+		},
+		{
+			Prompt:  "A closure returned by a function keeps using a local variable of that function after it returns. Is that allowed?",
+			Choices: []string{"Yes, the variable lives on as long as the closure does", "No, it's a dangling pointer", "Only if the variable is a pointer", "Only for constants"},
+			Answer:  0,
+			Explain: "The compiler moves captured variables to the heap, exactly as closures over `let` work in JS.",
+		},
+	},
+	"03-structs": {
+		{
+			Prompt:  "Which method can change the struct it's called on?",
+			Choices: []string{"func (c Counter) Inc()", "func (c *Counter) Inc()", "Both", "Neither; structs are immutable"},
+			Answer:  1,
+			Explain: "A value receiver gets a copy. Use a pointer receiver when the method modifies the struct.",
+		},
+		{
+			Prompt:  "encoding/json ignores a struct field called `name`. Why?",
+			Choices: []string{"It needs a json tag", "Lowercase fields are unexported and invisible to other packages", "Strings need `omitempty`", "json only encodes pointers"},
+			Answer:  1,
+			Explain: "Capitalization is Go's public/private. Export the field and add `json:\"name\"` to keep the lowercase key.",
+		},
+		{
+			Prompt:  "If type Admin embeds User, what is true?",
+			Choices: []string{"Admin is a subclass of User", "Admin's methods override User's", "User's fields and methods are promoted to Admin", "An Admin can be passed where a User is expected"},
+			Answer:  2,
+			Explain: "Embedding is composition: admin.Name reaches admin.User.Name, but an Admin is still not a User.",
+		},
+	},
+	"04-collections": {
+		{
+			Prompt:  "What is the zero value of a map?",
+			Choices: []string{"An empty map you can write to", "nil: reading works, writing panics", "undefined", "A compile error"},
+			Answer:  1,
+			Explain: "Reading a nil map returns zero values, but assigning to one panics. Create it with make or a literal first.",
+		},
+		{
+			Prompt:  "What does `counts[\"missing\"]` return for a map[string]int without that key?",
+			Choices: []string{"An error", "nil", "0", "It panics"},
+			Answer:  2,
+			Explain: "Missing keys read as the zero value. Use `v, ok := counts[k]` when you need to tell \"absent\" from 0.",
+		},
+		{
+			Prompt:  "In what order does `for k := range m` visit a map's keys?",
+			Choices: []string{"Insertion order", "Sorted order", "Unspecified, and it changes between runs", "Reverse insertion order"},
+			Answer:  2,
+			Explain: "Go randomizes map iteration on purpose. Sort the keys (slices.Sorted(maps.Keys(m))) when order matters.",
+		},
+	},
+	"05-interfaces": {
+		{
+			Prompt:  "How does a type declare that it implements io.Writer?",
+			Choices: []string{"`implements io.Writer`", "By embedding io.Writer", "It doesn't: having a Write method with the right signature is enough", "With a //go:implements comment"},
+			Answer:  2,
+			Explain: "Interfaces are satisfied implicitly, like structural typing in TypeScript.",
+		},
+		{
+			Prompt:  "What does `s, ok := v.(fmt.Stringer)` do when v isn't a Stringer?",
+			Choices: []string{"It panics", "ok is false and s is the zero value", "It returns an error", "It doesn't compile"},
+			Answer:  1,
+			Explain: "The two-result form of a type assertion never panics; the one-result form `v.(fmt.Stringer)` does.",
+		},
+		{
+			Prompt:  "A function returns a nil *MyError as an error. What does `err != nil` give?",
+			Choices: []string{"false", "true", "It panics", "It doesn't compile"},
+			Answer:  1,
+			Explain: "An interface holding a nil pointer has a type, so it isn't nil. Return a literal nil for \"no error\".",
+		},
+	},
+	"06-concurrency": {
+		{
+			Prompt:  "What happens when you send on an unbuffered channel nobody receives from?",
+			Choices: []string{"The value is dropped", "The sender blocks until a receiver arrives", "It returns an error", "It panics"},
+			Answer:  1,
+			Explain: "Unbuffered channels hand values over directly; if no goroutine ever receives, you get a deadlock.",
+		},
+		{
+			Prompt:  "What is sync.WaitGroup for?",
+			Choices: []string{"Locking shared data", "Waiting for a group of goroutines to finish", "Limiting how many goroutines run", "Passing values between goroutines"},
+			Answer:  1,
+			Explain: "It's the Promise.all of goroutines: Add (or wg.Go) before starting each, Done when it ends, Wait for all.",
+		},
+		{
+			Prompt:  "What does `select` do when several of its cases are ready?",
+			Choices: []string{"Runs the first one listed", "Runs all of them", "Picks one at random", "Blocks forever"},
+			Answer:  2,
+			Explain: "The random choice keeps one busy channel from starving the others. Add a default case to not block at all.",
+		},
+	},
+	"07-file-processing": {
+		{
+			Prompt:  "Why `defer f.Close()` right after a successful os.Open?",
+			Choices: []string{"Go closes files only when told to, and defer does it on every return path", "It makes reads faster", "os.Open requires it", "To flush the write buffer first"},
+			Answer:  0,
+			Explain: "There's no garbage-collected file handle to rely on; defer guarantees the close even on an early error return.",
+		},
+		{
+			Prompt:  "What does bufio.Scanner give you by default?",
+			Choices: []string{"One byte at a time", "One line at a time, without the newline", "The whole file", "One word at a time"},
+			Answer:  1,
+			Explain: "Check scanner.Err() after the loop: Scan returns false on errors too, not just at the end.",
+		},
+		{
+			Prompt:  "How does encoding/csv hand you a row?",
+			Choices: []string{"As a map of header to value", "As a []string of fields", "As a struct", "As a single string"},
+			Answer:  1,
+			Explain: "Fields come back as strings; converting and validating them (strconv) is up to you.",
+		},
+	},
+	"08-data-processing": {
+		{
+			Prompt:  "Go has no built-in Array.prototype.map. What's the idiomatic replacement?",
+			Choices: []string{"A for loop that appends to a new slice", "reflect.Map", "A goroutine per element", "sort.Slice"},
+			Answer:  0,
+			Explain: "A plain loop, ideally into make([]T, 0, len(in)), is clear and fast. Generics make a reusable Map easy if you want one.",
+		},
+		{
+			Prompt:  "Filtering in place with `out := in[:0]` and append: what happens to in?",
+			Choices: []string{"Nothing, out is a copy", "Its start is overwritten, since out shares its backing array", "It is freed", "It panics"},
+			Answer:  1,
+			Explain: "It saves an allocation but reuses in's memory, so only do it when you no longer need the original.",
+		},
+		{
+			Prompt:  "How do you sum a []float64 in Go?",
+			Choices: []string{"xs.reduce(+)", "math.Sum(xs)", "A for range loop with an accumulator", "strings.Join"},
+			Answer:  2,
+			Explain: "Reduce is just a loop; keep the accumulator's zero value (0) in mind for empty input.",
+		},
+	},
+	"09-matrices": {
+		{
+			Prompt:  "After `grid := make([][]int, 3)`, what is grid[0]?",
+			Choices: []string{"A slice of 3 zeros", "nil, an empty row", "It panics", "A compile error"},
+			Answer:  1,
+			Explain: "make only allocates the outer slice. Each row needs its own make([]int, cols).",
+		},
+		{
+			Prompt:  "In m[i][j] for a [][]int, what are i and j?",
+			Choices: []string{"Column then row", "Row then column", "It depends on how it was allocated", "Flat index then offset"},
+			Answer:  1,
+			Explain: "m[i] is a row slice and [j] indexes into it, so loops go rows outside, columns inside.",
+		},
+		{
+			Prompt:  "What does transposing a 2x3 matrix produce?",
+			Choices: []string{"A 2x3 matrix", "A 3x2 matrix", "A 3x3 matrix", "An error: only square matrices can be transposed"},
+			Answer:  1,
+			Explain: "Rows become columns, so allocate len(m[0]) rows of len(m) each.",
+		},
+	},
+	"10-slice-internals": {
+		{
+			Prompt:  "`b := a[1:3]` then `b[0] = 9`. What happens to a?",
+			Choices: []string{"Nothing, b is a copy", "a[1] is now 9", "a[0] is now 9", "It panics"},
+			Answer:  1,
+			Explain: "Slicing shares the backing array. Use copy or slices.Clone for an independent slice.",
+		},
+		{
+			Prompt:  "Why write `s = append(s, x)` rather than just `append(s, x)`?",
+			Choices: []string{"Style only", "append may allocate a new array and returns the slice that uses it", "append only works on assignment", "To avoid a data race"},
+			Answer:  1,
+			Explain: "When len reaches cap, append copies to a bigger array; only the returned slice sees it.",
+		},
+		{
+			Prompt:  "What does the third index in s[1:3:4] set?",
+			Choices: []string{"The step", "The capacity of the result", "The length of the result", "The maximum value"},
+			Answer:  1,
+			Explain: "Capping capacity makes a later append reallocate instead of overwriting s[3].",
+		},
+	},
+	"11-deep-copy": {
+		{
+			Prompt:  "Assigning one struct variable to another copies...",
+			Choices: []string{"Everything, deeply", "The fields, but slices, maps and pointers inside still share data", "Only a reference", "Nothing until one is written (copy-on-write)"},
+			Answer:  1,
+			Explain: "Struct assignment is shallow. A deep copy has to clone each slice, map and pointed-to value.",
+		},
+		{
+			Prompt:  "Can you compare two structs with ==?",
+			Choices: []string{"Always", "Only if all their fields are comparable (no slices, maps or funcs)", "Never", "Only pointers to structs"},
+			Answer:  1,
+			Explain: "Structs with slice or map fields don't compile with ==; compare field by field or use reflect.DeepEqual in tests.",
+		},
+		{
+			Prompt:  "What does maps.Clone(m) copy?",
+			Choices: []string{"The keys and values, shallowly", "Everything, deeply", "Only the keys", "Nothing; it returns m"},
+			Answer:  0,
+			Explain: "Values that are slices or pointers are still shared with the original.",
+		},
+	},
+	"12-clock": {
+		{
+			Prompt:  "Why take a Clock interface instead of calling time.Now() directly?",
+			Choices: []string{"time.Now is slow", "So tests can pass a fake clock and control time", "time.Now isn't safe for goroutines", "Interfaces are required for methods"},
+			Answer:  1,
+			Explain: "It's dependency injection, like jest.useFakeTimers but explicit: tests stop depending on the wall clock.",
+		},
+		{
+			Prompt:  "Which compares two time.Time values correctly?",
+			Choices: []string{"a == b", "a.Equal(b)", "a.String() == b.String()", "a.Sub(b) == nil"},
+			Answer:  1,
+			Explain: "== also compares the location and monotonic reading, so two equal instants can differ under ==.",
+		},
+		{
+			Prompt:  "What is `2 * time.Second`?",
+			Choices: []string{"A time.Duration of two seconds", "The int 2000", "A compile error", "A time.Time"},
+			Answer:  0,
+			Explain: "Durations are int64 nanoseconds with a type, so units can't get mixed up.",
+		},
+	},
+	"13-string-algorithms": {
+		{
+			Prompt:  "What is len(\"héllo\")?",
+			Choices: []string{"5", "6", "It depends on the platform", "10"},
+			Answer:  1,
+			Explain: "len counts bytes of UTF-8, and é takes two. utf8.RuneCountInString gives 5.",
+		},
+		{
+			Prompt:  "What does `for i, r := range s` iterate over?",
+			Choices: []string{"Bytes", "Runes, with i as the byte offset", "Characters, with i as the character index", "Lines"},
+			Answer:  1,
+			Explain: "Ranging over a string decodes UTF-8, so i can jump by more than 1.",
+		},
+		{
+			Prompt:  "What's the efficient way to build a long string in a loop?",
+			Choices: []string{"s += piece", "strings.Builder", "fmt.Sprint on each step", "Converting to []rune each time"},
+			Answer:  1,
+			Explain: "Strings are immutable, so += copies every time. A Builder grows a buffer instead.",
+		},
+	},
+	"14-capstone": {
+		{
+			Prompt:  "Where do packages in a multi-package module import each other from?",
+			Choices: []string{"Relative paths like ../report", "The module path plus the directory, e.g. example.com/app/report", "node_modules", "GOPATH/src only"},
+			Answer:  1,
+			Explain: "Imports are always full paths rooted at the module name in go.mod.",
+		},
+		{
+			Prompt:  "What does http.Server.Shutdown do?",
+			Choices: []string{"Kills open connections at once", "Stops accepting new connections and waits for requests in flight", "Restarts the server", "Only closes idle keep-alive connections and returns"},
+			Answer:  1,
+			Explain: "Pass it a context with a timeout so a stuck request can't hang your shutdown forever.",
+		},
+		{
+			Prompt:  "How do you test an http.Handler without opening a port?",
+			Choices: []string{"You can't", "httptest.NewRecorder and call ServeHTTP", "Mock net.Listen", "Run the binary in a subprocess"},
+			Answer:  1,
+			Explain: "Handlers are plain interfaces, so a recorder and a built request are all a test needs.",
+		},
+	},
+	"15-property-testing": {
+		{
+			Prompt:  "A property test fails on a 20-element slice. What does shrinking do?",
+			Choices: []string{"Reruns the test with a new seed", "Looks for a smaller input that still fails, to report that instead", "Compresses the failing input", "Skips the slowest inputs"},
+			Answer:  1,
+			Explain: "Removing elements and shrinking numbers while the property still fails leaves something like []int{0, 1}, which is far easier to debug.",
+		},
+		{
+			Prompt:  "Returning the input unchanged passes \"reverse(reverse(s)) == s\". What does that tell you?",
+			Choices: []string{"The property is wrong", "One property rarely pins a function down; add another that only a real reverse satisfies", "Reverse should be tested with examples only", "The generator needs bigger inputs"},
+			Answer:  1,
+			Explain: "Round trips catch a lot but not everything. Pair them with a property about what the function is for.",
+		},
+		{
+			Prompt:  "What's a test oracle?",
+			Choices: []string{"A fixed list of expected outputs", "A simpler implementation that's obviously right, to compare the real one with", "A generator of random inputs", "The seed that reproduces a failure"},
+			Answer:  1,
+			Explain: "A linear scan can check a binary search: slower, but hard to get wrong.",
+		},
+	},
+	"16-static-analysis": {
+		{
+			Prompt:  "Why does an analyzer ask pass.TypesInfo which function a call refers to, instead of matching the text \"fmt.Println\"?",
+			Choices: []string{"Text matching is slower", "fmt can be imported under another name, and other types can have a Println method", "The syntax tree has no names in it", "go vet forbids reading source text"},
+			Answer:  1,
+			Explain: "The type checker resolves every identifier to the object it names, the way TypeScript's checker resolves a symbol.",
+		},
+		{
+			Prompt:  "What does analysistest.Run check?",
+			Choices: []string{"That the analyzer reports exactly the lines marked with a // want comment, whose regexp its message must match", "That the testdata packages compile", "That the analyzer runs in under a second", "That the analyzer reports at least one problem"},
+			Answer:  0,
+			Explain: "A missing report and an unexpected one both fail the test, like ESLint's RuleTester with its valid and invalid cases.",
+		},
+		{
+			Prompt:  "Two variables in different scopes are both called f. How does an analyzer tell them apart?",
+			Choices: []string{"By their names", "By their line numbers", "By the types.Object each identifier refers to (pass.TypesInfo.ObjectOf)", "It can't"},
+			Answer:  2,
+			Explain: "Every declaration gets its own object; every use of the name points back at the one it means.",
+		},
+	},
+	"17-errors": {
+		{
+			Prompt:  "err := fmt.Errorf(\"load: %w\", ErrNotFound). What does err == ErrNotFound give?",
+			Choices: []string{"true", "false: err is a new error wrapping ErrNotFound; errors.Is(err, ErrNotFound) is true", "It doesn't compile", "It panics"},
+			Answer:  1,
+			Explain: "Wrapping makes a new value. errors.Is follows the chain of Unwrap calls, like checking err.cause all the way down in JS.",
+		},
+		{
+			Prompt:  "What's the difference between %w and %v in fmt.Errorf?",
+			Choices: []string{"None", "%w keeps the original error in the chain for errors.Is and errors.As; %v only copies its message", "%v is faster", "%w can only wrap sentinel errors"},
+			Answer:  1,
+			Explain: "Use %w when callers may need the cause, %v when it's an implementation detail you don't want them to depend on.",
+		},
+		{
+			Prompt:  "How do you get the *ValidationError out of a wrapped error?",
+			Choices: []string{"err.(*ValidationError)", "var v *ValidationError; errors.As(err, &v)", "errors.Is(err, ValidationError{})", "errors.Unwrap(err).(ValidationError)"},
+			Answer:  1,
+			Explain: "A type assertion only looks at the outermost error; errors.As searches the chain, and the tree errors.Join builds, for the first match.",
+		},
+	},
+	"18-generics": {
+		{
+			Prompt:  "func Sum[T any](xs []T) T { var t T; for _, x := range xs { t += x }; return t }. What happens?",
+			Choices: []string{"It works for numbers and strings", "It doesn't compile: any promises no operators, so + needs a constraint like ~int | ~float64", "It panics for non-numbers", "It compiles but always returns the zero value"},
+			Answer:  1,
+			Explain: "A type parameter can only do what every type in its constraint can. TypeScript checks T extends number the same way.",
+		},
+		{
+			Prompt:  "type Celsius float64. Which constraint lets Celsius in?",
+			Choices: []string{"float64", "~float64", "interface{ float64 }", "Celsius can never satisfy a constraint"},
+			Answer:  1,
+			Explain: "~float64 means every type whose underlying type is float64. Plain float64 in a type set means float64 itself only.",
+		},
+		{
+			Prompt:  "Why is MapResult a function rather than a method, result.Map(f)?",
+			Choices: []string{"Methods can't take func arguments", "Go methods can't have type parameters of their own, and Map needs a new one for the result type", "Methods on generic types must be exported", "For speed"},
+			Answer:  1,
+			Explain: "A method gets only its receiver's type parameters. Anything that introduces a new one, or needs a stricter constraint, has to be a plain function.",
+		},
+	},
+	"19-http-server": {
+		{
+			Prompt:  "A handler calls w.WriteHeader(201), then w.Header().Set(\"Location\", \"/todos/1\"). What does the client get?",
+			Choices: []string{"201 with the Location header", "201 without it: headers go out with the status, so later changes are ignored", "A 500 error", "It panics"},
+			Answer:  1,
+			Explain: "Set headers first, then the status, then the body. Unlike Express's res object, nothing is buffered until you return.",
+		},
+		{
+			Prompt:  "The mux has only mux.HandleFunc(\"GET /todos/{id}\", h). What does DELETE /todos/1 get?",
+			Choices: []string{"h runs anyway", "404 Not Found", "405 Method Not Allowed, from the mux", "It depends on h"},
+			Answer:  2,
+			Explain: "Since Go 1.22 the mux knows which methods a path has and answers the others with 405, and an Allow header.",
+		},
+		{
+			Prompt:  "How do you test a handler without starting a server?",
+			Choices: []string{"You can't", "Call h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(...)) and check the recorder", "Mock the net package", "Use http.Get on localhost"},
+			Answer:  1,
+			Explain: "A handler is just a method taking a ResponseWriter; the recorder is one that keeps the status, headers and body for the test to check.",
+		},
+	},
+	"20-http-client": {
+		{
+			Prompt:  "resp, err := http.Get(url) and the server answers 500. What's err?",
+			Choices: []string{"An error holding the status", "nil: the request worked, so check resp.StatusCode", "io.EOF", "It depends on the body"},
+			Answer:  1,
+			Explain: "Like fetch, http.Client only fails when there's no response at all. A status is something you check, the way you'd check res.ok.",
+		},
+		{
+			Prompt:  "Which responses are worth retrying?",
+			Choices: []string{"All of them", "Only 404", "5xx and requests that failed to go through; a 4xx means the request itself is wrong and will fail again", "None: retries hide bugs"},
+			Answer:  2,
+			Explain: "A 503 may be gone in a second; a 400 will be the same however often you send it. Backing off exponentially keeps retries from piling onto a struggling server.",
+		},
+		{
+			Prompt:  "Why must a RoundTripper clone the request before setting a header?",
+			Choices: []string{"Headers are read-only", "The caller still owns the request and may reuse or inspect it; RoundTrip must not change it", "Cloning is faster", "It doesn't have to"},
+			Answer:  1,
+			Explain: "The http.RoundTripper docs say so: RoundTrip should not modify the request. req.Clone gives a copy with its own headers.",
+		},
+	},
+	"21-json": {
+		{
+			Prompt:  "A struct has Closed time.Time `json:\"closed,omitempty\"` and Closed is the zero time. What does json.Marshal write?",
+			Choices: []string{"Nothing for closed", "\"closed\":\"0001-01-01T00:00:00Z\": omitempty never omits a struct; omitzero would", "\"closed\":null", "An error"},
+			Answer:  1,
+			Explain: "omitempty knows false, 0, \"\", nil and empty slices and maps. omitzero, since Go 1.24, omits any zero value, or whatever an IsZero method says is zero.",
+		},
+		{
+			Prompt:  "How can a PATCH handler tell {\"email\": \"\"} from {}?",
+			Choices: []string{"It can't", "Decode into a *string field: nil means the key was missing, a pointer to \"\" means it was set empty", "Use omitempty", "Check len(body)"},
+			Answer:  1,
+			Explain: "A plain string field is \"\" either way. A pointer adds a third state, like checking \"email\" in body in JS.",
+		},
+		{
+			Prompt:  "What does json.RawMessage do?",
+			Choices: []string{"Skips the field", "Holds a value's raw bytes undecoded, to decode later once you know its type", "Compresses the JSON", "Decodes into map[string]any"},
+			Answer:  1,
+			Explain: "It's a []byte that implements Marshaler and Unmarshaler by copying bytes as they are, so decoding can be done in two steps.",
+		},
+	},
+	"22-context": {
+		{
+			Prompt:  "ctx, cancel := context.WithTimeout(parent, time.Second). Why call cancel even if the work finishes in time?",
+			Choices: []string{"It doesn't matter", "It releases the timer and unlinks ctx from parent; until then they live as long as parent does", "It makes the work return faster", "It's needed to read ctx.Err()"},
+			Answer:  1,
+			Explain: "defer cancel() right after creating the context. go vet's lostcancel check warns when you forget.",
+		},
+		{
+			Prompt:  "A function returns early on <-ctx.Done() while its goroutine still tries to send on an unbuffered channel. What happens to that goroutine?",
+			Choices: []string{"It's garbage collected", "It blocks forever: a goroutine leak", "The send panics", "The runtime cancels it"},
+			Answer:  1,
+			Explain: "Nothing stops a goroutine from outside. Give the channel a buffer, or make the send select on ctx.Done() as well.",
+		},
+		{
+			Prompt:  "Why use an unexported struct type as a context value key?",
+			Choices: []string{"It's faster than a string", "Keys match by type and value, so no other package can build an equal key and clash with yours", "Strings aren't allowed", "To save memory"},
+			Answer:  1,
+			Explain: "context.WithValue(ctx, \"user\", u) from two packages would collide. type userKey struct{} can only be named inside your package.",
+		},
+	},
+	"23-writing-tests": {
+		{
+			Prompt:  "for _, tt := range tests { t.Run(tt.name, func(t *testing.T) { t.Parallel(); check(tt) }) }. Since Go 1.22, which case does each subtest check?",
+			Choices: []string{"All of them check the last case", "Its own: each iteration has its own tt", "None, t.Parallel skips them", "A random one"},
+			Answer:  1,
+			Explain: "Before Go 1.22 the loop had one tt for all iterations and paused parallel subtests saw only the last value, hence the old tt := tt line.",
+		},
+		{
+			Prompt:  "A test swaps a package variable and a later line calls t.Fatal. When does a restore registered with t.Cleanup run?",
+			Choices: []string{"Never, t.Fatal stops the test", "After the test and its subtests finish, failed or not", "Before t.Fatal", "Only when the test passes"},
+			Answer:  1,
+			Explain: "t.Fatal ends the test's goroutine, but cleanups still run, last registered first, like afterEach in JS.",
+		},
+		{
+			Prompt:  "Why is t.Errorf usually better than t.Fatalf in a table-driven test?",
+			Choices: []string{"It's faster", "The test keeps going and reports every wrong case, not just the first", "t.Fatalf doesn't work in subtests", "t.Errorf prints in color"},
+			Answer:  1,
+			Explain: "Save t.Fatal for when carrying on makes no sense, such as a nil result the next line would dereference.",
+		},
+	},
+	"24-unicode": {
+		{
+			Prompt:  "What are len(\"héllo\") and utf8.RuneCountInString(\"héllo\")?",
+			Choices: []string{"5 and 5", "6 and 5: é takes two bytes in UTF-8", "5 and 6", "10 and 5"},
+			Answer:  1,
+			Explain: "len counts bytes. JS's \"héllo\".length is 5, counting UTF-16 code units, which only differs from runes beyond U+FFFF, emoji for instance.",
+		},
+		{
+			Prompt:  "Why can reversing a string's []rune produce wrong text?",
+			Choices: []string{"It can't", "A character can be several runes, like e plus a combining accent or a flag's two regional indicators, and reversing splits them", "Runes are bytes", "[]rune conversion drops emoji"},
+			Answer:  1,
+			Explain: "Reverse by grapheme cluster instead, which is what a reader calls a character.",
+		},
+		{
+			Prompt:  "strings.ToLower(a) == strings.ToLower(b) vs strings.EqualFold(a, b): which handles \"ΟΔΟΣ\" and \"οδος\"?",
+			Choices: []string{"Both", "Only EqualFold: Σ lowers to σ, but the word ends in ς, and only folding treats σ and ς as the same letter", "Only ToLower", "Neither"},
+			Answer:  1,
+			Explain: "Case folding compares each rune's whole case orbit (unicode.SimpleFold) rather than one mapping of it.",
+		},
+	},
+	"25-time": {
+		{
+			Prompt:  "Which layout formats a time as 2024-03-05?",
+			Choices: []string{"\"YYYY-MM-DD\"", "\"2006-01-02\"", "\"%Y-%m-%d\"", "\"yyyy-mm-dd\""},
+			Answer:  1,
+			Explain: "Go writes layouts as the reference moment Mon Jan 2 15:04:05 MST 2006. time.DateOnly is this same layout.",
+		},
+		{
+			Prompt:  "In New York the night the clocks go forward, how far apart are t and t.AddDate(0, 0, 1)?",
+			Choices: []string{"24 hours", "23 hours: AddDate keeps the wall clock time, and that day is an hour short", "25 hours", "It depends on the year"},
+			Answer:  1,
+			Explain: "t.Add(24 * time.Hour) would keep the gap at 24 hours and move the wall clock an hour instead.",
+		},
+		{
+			Prompt:  "Why compare times with t1.Equal(t2) rather than t1 == t2?",
+			Choices: []string{"== doesn't compile for structs", "== also compares the location and the monotonic clock reading, so the same instant can compare unequal", "Equal is faster", "They're the same"},
+			Answer:  1,
+			Explain: "t.In(tokyo) == t is false even though both are the same instant. Equal compares only the instant.",
+		},
+	},
+	"26-regexp": {
+		{
+			Prompt:  "regexp.MustCompile(`\\d{3}`).MatchString(\"phone: 5551234\") is true. Why?",
+			Choices: []string{"It's a bug", "MatchString looks for a match anywhere in the string; anchor with ^ and $ to match all of it", "\\d matches any character", "MustCompile ignores quantifiers"},
+			Answer:  1,
+			Explain: "Same as JS's re.test(s). To validate a whole string, write ^\\d{3}$.",
+		},
+		{
+			Prompt:  "Which of these does Go's regexp package not support?",
+			Choices: []string{"Named groups (?P<name>...)", "Lookbehind (?<=...) and backreferences \\1", "Non-greedy .*?", "Case-insensitive (?i)"},
+			Answer:  1,
+			Explain: "RE2 leaves out features that need backtracking, so every match runs in time linear in the input.",
+		},
+		{
+			Prompt:  "Where should a fixed pattern be compiled?",
+			Choices: []string{"Inside the function, on every call", "Once, in a package variable with regexp.MustCompile", "In init() with regexp.Compile, ignoring the error", "It doesn't need compiling"},
+			Answer:  1,
+			Explain: "Compiling is the slow part, and a *Regexp is safe for concurrent use. MustCompile panics at start-up on a bad pattern, where it's found at once.",
+		},
+	},
+	"27-io": {
+		{
+			Prompt:  "A Read call returns n = 5 and err = io.EOF. What should the caller do?",
+			Choices: []string{"Discard the 5 bytes: the read failed", "Use the 5 bytes, then stop: the reader is done", "Call Read again to get the bytes without the error", "Panic: a Read can't return both"},
+			Answer:  1,
+			Explain: "The io.Reader contract lets a reader return its last bytes and io.EOF together. Handle p[:n] before looking at err.",
+		},
+		{
+			Prompt:  "What does io.TeeReader(r, w) return?",
+			Choices: []string{"A reader that writes everything read from r through it to w", "A writer that copies to both r and w", "A reader that reads r and w in turn", "A copy of r that w can read later"},
+			Answer:  0,
+			Explain: "Like tee(1): read through it, and w gets a copy, say a hash.Hash, so one pass over the input both copies and checksums it.",
+		},
+		{
+			Prompt:  "A function writes through a bufio.Writer and returns nil, but the last part of the output is missing. What was left out?",
+			Choices: []string{"Closing the underlying writer", "A call to Flush, whose error should be returned", "Setting the buffer size", "A final newline"},
+			Answer:  1,
+			Explain: "bufio.Writer holds data until its buffer fills. Flush writes the rest and returns the first error the underlying writer gave.",
+		},
+	},
+	"28-sorting": {
+		{
+			Prompt:  "What's the difference between sort.Slice(s, less) and slices.SortFunc(s, cmp)?",
+			Choices: []string{"sort.Slice is stable", "less gets two indexes and returns a bool; cmp gets two elements and returns a negative, zero or positive int", "slices.SortFunc returns a sorted copy", "There is none: one is an alias of the other"},
+			Answer:  1,
+			Explain: "cmp works like a JS compare function. Both sort in place, and neither is stable.",
+		},
+		{
+			Prompt:  "A slice is already sorted by rating. Which call groups it by author and keeps each author's books in rating order?",
+			Choices: []string{"slices.SortFunc by author", "slices.SortStableFunc by author", "sort.Sort by author", "slices.Sort"},
+			Answer:  1,
+			Explain: "Only a stable sort promises to keep elements that compare equal in the order they were in.",
+		},
+		{
+			Prompt:  "sort.Search(n, f) returns what?",
+			Choices: []string{"The index of an element equal to f", "The smallest i in [0, n) with f(i) true, or n if there's none", "Whether f is true for some i", "A sorted slice of n elements"},
+			Answer:  1,
+			Explain: "It's a binary search over a question, not over a slice, so f must be false and then true. It works for git-bisect-style searches too.",
+		},
+	},
+	"29-data-structures": {
+		{
+			Prompt:  "Why does a Stack's Pop set the slot it took the value from to the zero value before reslicing?",
+			Choices: []string{"Reslicing panics otherwise", "The backing array still holds the value, so a popped pointer would keep what it points to from being garbage collected", "So Len is right", "It doesn't need to; it's only style"},
+			Answer:  1,
+			Explain: "s = s[:len(s)-1] doesn't touch the array. Until something overwrites that slot, it keeps the value alive.",
+		},
+		{
+			Prompt:  "A func returns iter.Seq[T] and keeps calling yield after yield returned false. What happens in for v := range seq { break }?",
+			Choices: []string{"The loop body runs again", "The extra values are dropped silently", "The program panics: the range func continued iteration after the loop exited", "Nothing; break is ignored"},
+			Answer:  2,
+			Explain: "yield returning false means the loop is done, from a break, return or panic. An iterator must stop there, all the way up a recursive walk.",
+		},
+		{
+			Prompt:  "Keys are added to an unbalanced binary search tree in sorted order. How long does a lookup take?",
+			Choices: []string{"O(1)", "O(log n)", "O(n): each node is the right child of the one before, so the tree is a linked list", "It depends on the values"},
+			Answer:  2,
+			Explain: "A BST is only O(log n) when it's balanced. Balanced trees, like red-black trees, rebalance as they go.",
+		},
+	},
+	"30-algorithms": {
+		{
+			Prompt:  "What does Go use for a set of strings, where JS has new Set()?",
+			Choices: []string{"[]string", "map[string]bool or map[string]struct{}", "container/list", "A Set type in package sets"},
+			Answer:  1,
+			Explain: "A map's keys are the set. map[string]struct{} uses no memory for values; map[string]bool reads better with if seen[x].",
+		},
+		{
+			Prompt:  "Why doesn't `visit := func(n string) { ... visit(next) ... }` compile?",
+			Choices: []string{"Closures can't be recursive in Go", "visit isn't in scope until the statement ends; declare it first with var visit func(string), then assign", "Func literals can't take arguments", "It needs the go keyword"},
+			Answer:  1,
+			Explain: "With := the name exists only after the declaration, so the literal can't refer to it. Declaring it first makes the closure capture the variable.",
+		},
+		{
+			Prompt:  "In a breadth-first search, when should a node be marked visited?",
+			Choices: []string{"When it's taken off the queue", "When it's put on the queue", "When the search ends", "Never; the queue prevents repeats"},
+			Answer:  1,
+			Explain: "Marking on dequeue lets a node be queued more than once, by each neighbour that reaches it before it's processed.",
+		},
+	},
+	"31-database": {
+		{
+			Prompt:  "db, err := sql.Open(\"sqlite\", path) returned a nil error. What does that tell you?",
+			Choices: []string{"The database file exists", "A connection was made", "Only that the driver is registered and the arguments look fine; db.Ping connects", "That the tables exist"},
+			Answer:  2,
+			Explain: "sql.Open sets up a connection pool and connects lazily, on the first query. Ping, or PingContext, checks that a connection can be made now.",
+		},
+		{
+			Prompt:  "Why pass a user's name as a ? argument instead of building the SQL with fmt.Sprintf?",
+			Choices: []string{"It's faster to type", "The driver sends the value apart from the SQL, so quotes in it can't change the query", "fmt.Sprintf can't format strings", "database/sql rejects queries longer than a line"},
+			Answer:  1,
+			Explain: "Placeholders are the defence against SQL injection, like parameterized queries in pg or better-sqlite3.",
+		},
+		{
+			Prompt:  "SELECT MAX(price) FROM products WHERE category = ? matches no rows. What does QueryRow(...).Scan(&f) with f a float64 do?",
+			Choices: []string{"Returns sql.ErrNoRows", "Sets f to 0 with no error", "Returns an error: the one row it gets has a NULL, which a float64 can't hold; scan into sql.NullFloat64", "Panics"},
+			Answer:  2,
+			Explain: "An aggregate always returns a row. Its value is NULL when nothing matched, and the sql.Null types say whether there was a value.",
+		},
+	},
+	"32-templates": {
+		{
+			Prompt:  "Why use html/template rather than text/template for a web page?",
+			Choices: []string{"It's faster", "It escapes each value for where it lands: HTML text, attributes, URLs or JS, so data can't inject markup", "text/template can't produce HTML", "It supports {{range}}"},
+			Answer:  1,
+			Explain: "Both have the same syntax and API. Only html/template knows HTML, and it escapes contextually, the way JSX escapes what you put in braces.",
+		},
+		{
+			Prompt:  "In {{.Revenue | money | padLeft 11}}, what does padLeft get?",
+			Choices: []string{"11 and the result of money .Revenue, as its last argument", "The result of money, then 11", "Only 11", "The dot"},
+			Answer:  0,
+			Explain: "A pipeline passes each result on as the last argument of the next command, like a shell pipe feeding stdin.",
+		},
+		{
+			Prompt:  "A func in an html/template returns template.HTML(\"<b>\" + name + \"</b>\"). What's wrong?",
+			Choices: []string{"Nothing; template.HTML is escaped anyway", "template.HTML isn't escaped, so a name with markup in it is injected; escape name with template.HTMLEscapeString", "Funcs can't return template.HTML", "<b> is deprecated"},
+			Answer:  1,
+			Explain: "template.HTML tells html/template the string is already safe. Whoever builds one has to escape the data in it.",
+		},
+	},
+	"33-cli": {
+		{
+			Prompt:  "`csvtool filter data.csv -col city` is parsed with a FlagSet. What happens to -col?",
+			Choices: []string{"It's parsed as a flag anyway", "Parsing stops at data.csv, the first non-flag argument, so -col and city end up in fs.Args()", "Parse returns an error", "The flags are sorted first"},
+			Answer:  1,
+			Explain: "Go's flag package wants flags before other arguments, unlike many Node argument parsers. -- also ends the flags.",
+		},
+		{
+			Prompt:  "Why flag.NewFlagSet(name, flag.ContinueOnError) rather than the flag package's top-level funcs?",
+			Choices: []string{"It's faster", "Each subcommand gets its own flags, and Parse returns an error instead of calling os.Exit, so it can be tested", "The top-level funcs don't support bools", "ContinueOnError ignores unknown flags"},
+			Answer:  1,
+			Explain: "flag.Parse parses os.Args into a global set with ExitOnError. A FlagSet of your own takes any args slice and leaves the exit to you.",
+		},
+		{
+			Prompt:  "By Unix custom, what should a command exit with when it was called with an unknown flag?",
+			Choices: []string{"0", "1", "2", "-1"},
+			Answer:  2,
+			Explain: "0 is success, 1 is failure, and 2 is a usage error, as grep, diff and Go's own flag package use it.",
+		},
+	},
+	"34-config": {
+		{
+			Prompt:  "os.Getenv(\"PORT\") returns \"\". What do you know?",
+			Choices: []string{"PORT isn't set", "PORT is set to \"\"", "Either; os.LookupEnv can tell them apart", "The environment couldn't be read"},
+			Answer:  2,
+			Explain: "Getenv returns \"\" both ways, like process.env.PORT ?? \"\" in JS. LookupEnv also returns whether the variable is set.",
+		},
+		{
+			Prompt:  "A Config has a field `Timeout Duration` where *Duration has UnmarshalText. The JSON is {\"timeout\": \"30s\"}. What does encoding/json do?",
+			Choices: []string{"Fails, since Duration is an int64", "Calls UnmarshalText with the text 30s", "Sets it to 30", "Ignores the field"},
+			Answer:  1,
+			Explain: "encoding/json uses a TextUnmarshaler for a JSON string, and yaml.v3 does for a scalar, so one method serves both formats.",
+		},
+		{
+			Prompt:  "Why does LoadConfig take an fs.FS and a func(string) string rather than calling os.ReadFile and os.Getenv?",
+			Choices: []string{"It's faster", "Tests can pass an fstest.MapFS and a map lookup, with no files to write and no process-wide environment to change", "os.Getenv is deprecated", "fs.FS can write files too"},
+			Answer:  1,
+			Explain: "main passes os.DirFS(\".\") and os.Getenv. Like injecting a Clock, injecting the file system and the environment makes the code deterministic to test.",
+		},
+	},
+	"35-hashing": {
+		{
+			Prompt:  "Why hash a 10 GB file with io.Copy(h, f) rather than os.ReadFile and sha256.Sum256?",
+			Choices: []string{"io.Copy gives a different, safer digest", "io.Copy feeds the hash a buffer at a time, so memory use stays small however big the file is", "sha256.Sum256 only takes strings", "os.ReadFile can't read large files"},
+			Answer:  1,
+			Explain: "A hash.Hash is an io.Writer. Streaming into it gives the same digest as hashing it all at once, without holding the file in memory.",
+		},
+		{
+			Prompt:  "Why check an HMAC with hmac.Equal rather than bytes.Equal or ==?",
+			Choices: []string{"bytes.Equal can't compare signatures", "== stops at the first differing byte, so how long a rejection takes leaks how much of a guess was right", "hmac.Equal is faster", "hmac.Equal also checks the key"},
+			Answer:  1,
+			Explain: "Like crypto.timingSafeEqual in Node, hmac.Equal and subtle.ConstantTimeCompare look at every byte whatever they find.",
+		},
+		{
+			Prompt:  "Why store passwords with bcrypt rather than SHA-256?",
+			Choices: []string{"SHA-256 has been broken", "bcrypt is slow on purpose and salts each hash, so guessing from leaked hashes is far more expensive", "bcrypt hashes are shorter", "SHA-256 can't hash Unicode"},
+			Answer:  1,
+			Explain: "SHA-256 is built to be fast, and a GPU can try billions of guesses a second. bcrypt's cost makes each guess take milliseconds.",
+		},
+	},
+	"36-encryption": {
+		{
+			Prompt:  "What goes wrong if AES-GCM encrypts two messages with the same key and the same nonce?",
+			Choices: []string{"Nothing; the nonce is public anyway", "The XOR of the two plaintexts leaks, and tags can be forged", "The second Seal returns an error", "Decrypt gets slower"},
+			Answer:  1,
+			Explain: "A nonce must never repeat under a key. A fresh random 12-byte nonce per message makes a repeat vanishingly unlikely.",
+		},
+		{
+			Prompt:  "One byte of an AES-GCM ciphertext is flipped in storage. What does gcm.Open do?",
+			Choices: []string{"Returns the plaintext with one byte wrong", "Returns garbage", "Returns an error: the tag no longer matches", "Panics"},
+			Answer:  2,
+			Explain: "GCM is authenticated encryption. Open checks the tag before returning anything, which unauthenticated modes like CBC or CTR don't.",
+		},
+		{
+			Prompt:  "Why not use sha256.Sum256([]byte(passphrase)) as an AES key?",
+			Choices: []string{"It's the wrong length", "It's fast and unsalted, so guesses are cheap and the same passphrase always gives the same key; scrypt is slow, memory-hard and salted", "SHA-256 output isn't random enough for AES", "AES keys must be printable"},
+			Answer:  1,
+			Explain: "A key derivation function like scrypt or argon2 makes every guess expensive. The salt is stored with the ciphertext.",
+		},
+	},
+	"37-archives": {
+		{
+			Prompt:  "Gzip output written with gzip.NewWriter can't be decompressed: reading it ends in unexpected EOF. What was most likely forgotten?",
+			Choices: []string{"Flush on the underlying file", "Close on the gzip.Writer, which writes the last block and the checksum", "Setting the Name", "Choosing a compression level"},
+			Answer:  1,
+			Explain: "Compressors buffer. Close finishes the stream; its error is worth returning too.",
+		},
+		{
+			Prompt:  "An archive has an entry called ../../home/me/.bashrc. What should extracting it into out/ do?",
+			Choices: []string{"Write it to out/home/me/.bashrc", "Refuse it: joined onto out/ it lands outside, the \"zip slip\" attack", "Write it where the name says", "Skip the ../ parts silently"},
+			Answer:  1,
+			Explain: "filepath.IsLocal reports whether a name stays inside the directory. Check every entry before writing it.",
+		},
+		{
+			Prompt:  "Why does zip.NewReader need an io.ReaderAt and a size, when tar.NewReader takes any io.Reader?",
+			Choices: []string{"Zip files are always small", "A zip's index of entries is at the end of the file, so reading it means seeking; a tar is one stream read front to back", "tar doesn't compress", "io.ReaderAt is faster"},
+			Answer:  1,
+			Explain: "That's also why a .tar.gz can be read from a pipe, and a zip can't.",
+		},
+	},
+	"38-encodings": {
+		{
+			Prompt:  "A JWT segment like eyJhbGciOiJIUzI1NiJ9 fails with base64.StdEncoding.DecodeString. Why?",
+			Choices: []string{"JWTs are encrypted", "JWTs use the URL-safe alphabet without = padding: base64.RawURLEncoding", "It needs hex first", "Go can't decode JWTs"},
+			Answer:  1,
+			Explain: "There are four base64 flavors: standard or URL alphabet, each padded or raw. Use the one the format says.",
+		},
+		{
+			Prompt:  "binary.Write(w, binary.BigEndian, v) works for which v?",
+			Choices: []string{"Anything, like json.Marshal", "Fixed-size values: numbers, bools, arrays and structs of them, and slices of those", "Only []byte", "Only structs with binary tags"},
+			Answer:  1,
+			Explain: "Strings, maps and int (whose size depends on the platform) have no fixed size, so binary.Write rejects them.",
+		},
+		{
+			Prompt:  "Decoding a gob of []Shape fails with \"name not registered for interface\". What's missing?",
+			Choices: []string{"gob.Register for each concrete type, in the program that decodes", "Exported struct tags", "A gob.Decoder per value", "Implementing GobDecoder"},
+			Answer:  0,
+			Explain: "gob writes an interface value's type by name; the decoder needs that name mapped to a Go type.",
+		},
+	},
+	"39-networking": {
+		{
+			Prompt:  "A client writes \"hello\" and then \"world\" to a TCP connection. What can the server's Read calls return?",
+			Choices: []string{"Exactly \"hello\", then \"world\"", "Any split of the bytes: \"helloworld\" at once, or \"hel\" then \"loworld\"", "The two messages in either order", "Only whole messages, or an error"},
+			Answer:  1,
+			Explain: "TCP is a stream of bytes, in order, with no message boundaries; a protocol such as one message per line puts them back.",
+		},
+		{
+			Prompt:  "Why does a TCP client call CloseWrite instead of Close after sending its request?",
+			Choices: []string{"Close doesn't flush", "It tells the server no more is coming (EOF) while the client can still read the reply", "CloseWrite is faster", "Close is only for servers"},
+			Answer:  1,
+			Explain: "A half-close: the sending side is shut and the receiving side stays open.",
+		},
+		{
+			Prompt:  "A UDP client sends a query and the reply never comes. How does the client find out?",
+			Choices: []string{"Write returns an error", "Read returns io.EOF", "It doesn't, unless a read deadline fails the Read", "The server retries"},
+			Answer:  2,
+			Explain: "UDP doesn't report lost datagrams. SetReadDeadline is how a client stops waiting; it can then retry.",
+		},
+	},
+	"40-processes": {
+		{
+			Prompt:  "exec.Command(\"ls\", \"-l\", \"my files/*.txt\") runs ls on what?",
+			Choices: []string{"Every .txt file in \"my files\"", "One argument, the literal string my files/*.txt: no shell expands the *", "Two arguments, my and files/*.txt", "It's a syntax error"},
+			Answer:  1,
+			Explain: "os/exec starts the program directly. Use sh -c to get a shell, and then quoting and injection are your problem.",
+		},
+		{
+			Prompt:  "A command run with cmd.Run() exits with status 2. What does Run return?",
+			Choices: []string{"nil; the status is in cmd.Stdout", "An *exec.ExitError, whose ExitCode() is 2", "exec.ErrNotFound", "It panics"},
+			Answer:  1,
+			Explain: "Any status but 0 is an error. errors.As gets the *exec.ExitError and its code, and its Stderr field is only filled in by Output.",
+		},
+		{
+			Prompt:  "A worker catches SIGTERM with signal.Notify to drain its jobs. Why call signal.Stop once the first signal arrives?",
+			Choices: []string{"To free memory", "So a second signal gets the default action again and kills a worker whose cleanup hangs", "signal.Notify requires it", "To forward the signal to child processes"},
+			Answer:  1,
+			Explain: "Users press Ctrl-C twice to mean \"now\". While signals are caught they never kill the process.",
+		},
+	},
+	"41-iofs": {
+		{
+			Prompt:  "Why does ReadLines(fsys fs.FS, name string) make tests easier than ReadLines(filename string)?",
+			Choices: []string{"It's faster", "A test can pass an fstest.MapFS, files in a map, instead of writing real files to a temp dir", "fs.FS files can't be missing", "It can write files too"},
+			Answer:  1,
+			Explain: "fs.FS is an interface. os.DirFS, embed.FS, zip.Reader and fstest.MapFS all satisfy it, so the caller picks where files come from.",
+		},
+		{
+			Prompt:  "Which of these is a valid name to Open in an fs.FS?",
+			Choices: []string{"/src/main.go", "./src/main.go", "src/main.go", "src\\main.go"},
+			Answer:  2,
+			Explain: "fs.FS names are slash-separated and relative, with no . or .. elements; fs.ValidPath checks. The root is \".\".",
+		},
+		{
+			Prompt:  "In the func passed to fs.WalkDir, what does returning fs.SkipDir for a directory do?",
+			Choices: []string{"Stops the whole walk with an error", "Skips that directory's contents and carries on with the rest", "Deletes the directory", "Visits the directory again later"},
+			Answer:  1,
+			Explain: "It's how a walk leaves out .git or node_modules. Returned for a file, it skips the rest of that file's directory.",
+		},
+	},
+	"42-sync-primitives": {
+		{
+			Prompt:  "50 goroutines call once.Do(load) at the same moment, and load takes a second. What happens?",
+			Choices: []string{"load runs 50 times", "load runs once; the other 49 calls return at once, before it has finished", "load runs once, and the other 49 calls wait for it to finish", "It panics"},
+			Answer:  2,
+			Explain: "Do doesn't return until f has, so everyone who gets past it sees what f set up.",
+		},
+		{
+			Prompt:  "When is sync.Map a better choice than a map guarded by a sync.RWMutex?",
+			Choices: []string{"Always: it's the concurrent map", "When keys are written once and then read many times, or each goroutine uses its own keys", "When every goroutine updates the same few keys", "When you need to know the map's length"},
+			Answer:  1,
+			Explain: "Those are the two cases its docs name. Otherwise a map and a mutex is as fast and keeps its types.",
+		},
+		{
+			Prompt:  "Why does raising a maximum with sync/atomic need a CompareAndSwap loop rather than Load then Store?",
+			Choices: []string{"Store is slower", "Between the Load and the Store another goroutine may store a bigger value, which the Store then overwrites", "Load isn't atomic", "atomic.Int64 has no Store"},
+			Answer:  1,
+			Explain: "Each call is atomic, but two calls in a row aren't. CompareAndSwap only stores if the value is still the one you loaded.",
+		},
+	},
+	"43-pipelines": {
+		{
+			Prompt:  "A consumer reads 3 values from a generator's channel and returns. What happens to the generator's goroutine?",
+			Choices: []string{"The garbage collector stops it", "It blocks forever on its next send, unless it also selects on a ctx.Done() that gets canceled", "It panics", "It exits when the channel goes out of scope"},
+			Answer:  1,
+			Explain: "A goroutine blocked on a channel is never collected. Selecting on ctx.Done() alongside every send is what lets it stop.",
+		},
+		{
+			Prompt:  "Merge starts a goroutine per input channel. Why does a separate goroutine close the output after wg.Wait()?",
+			Choices: []string{"Closing is slow", "Sending on a closed channel panics, so out can only be closed once every sender has finished", "Channels must be closed by the goroutine that made them", "So the output is closed in order"},
+			Answer:  1,
+			Explain: "Any of the input goroutines could still send until they have all returned, and wg.Wait() is how you know.",
+		},
+		{
+			Prompt:  "In Tee, why set a channel variable to nil once it has been sent the value?",
+			Choices: []string{"To free it", "A send on a nil channel blocks forever, so select stops choosing that case, and the value goes to each output exactly once", "To close it", "nil channels are faster"},
+			Answer:  1,
+			Explain: "Setting a case's channel to nil switches that case off, a common way to build selects whose cases change.",
+		},
+	},
+	"44-resilience": {
+		{
+			Prompt:  "A token bucket has burst 5 and refills at 1 token a second. It has been idle for a minute. How many calls can go through at once?",
+			Choices: []string{"60", "5, since the bucket never holds more than burst tokens", "1", "0, it must refill first"},
+			Answer:  1,
+			Explain: "Tokens pile up only to burst, so after any idle time the bucket allows a burst of burst calls, then 1 a second.",
+		},
+		{
+			Prompt:  "Why add jitter to exponential backoff?",
+			Choices: []string{"To make retries faster", "So clients that failed at the same moment don't all retry at the same moment and overload the service again", "To make tests deterministic", "Because time.Sleep isn't precise"},
+			Answer:  1,
+			Explain: "Without jitter, a thousand clients that failed together retry together, in waves that keep the service down.",
+		},
+		{
+			Prompt:  "A circuit breaker's cooldown is over and it is half-open. What happens to calls?",
+			Choices: []string{"They all go through", "One trial call goes through while the rest still fail fast; its result closes the breaker or opens it again", "They all fail until the next cooldown", "They are queued until the service recovers"},
+			Answer:  1,
+			Explain: "Letting just one call test the service keeps a still-failing service from being flooded the moment the cooldown ends.",
+		},
+	},
+	"45-caching": {
+		{
+			Prompt:  "Why does an LRU cache keep both a map and a linked list?",
+			Choices: []string{"To store each value twice in case one is lost", "The map finds an entry in O(1), and the list keeps the order of use so moving an entry to the front and finding the oldest are O(1) too", "Go maps can't be iterated", "The list is only for printing"},
+			Answer:  1,
+			Explain: "Neither alone is enough: a map has no order, and finding a key in a list takes O(n).",
+		},
+		{
+			Prompt:  "Ten goroutines miss the cache for the same key at once. With a single-flight Group, how many times does the loader run?",
+			Choices: []string{"Ten", "Once; the other nine wait for that call and share its result", "Zero; the Group caches it", "Once per CPU"},
+			Answer:  1,
+			Explain: "The Group only dedupes calls running at the same time. It remembers nothing afterwards, which is the cache's job.",
+		},
+		{
+			Prompt:  "Why shouldn't Memoize cache an error?",
+			Choices: []string{"Errors can't be stored in a map", "A failure is often temporary, and caching it would keep returning it until it was evicted", "It would make the cache slower", "errors.Is wouldn't work on it"},
+			Answer:  1,
+			Explain: "A database that was down a second ago may be up now, so the next call should try again.",
+		},
+	},
+	"46-iterators": {
+		{
+			Prompt:  "A for-range loop over an iter.Seq breaks. What does the iterator see?",
+			Choices: []string{"A panic it must recover", "Its next call to yield returns false, and it must return without calling yield again", "Nothing; it keeps running in the background", "A closed channel"},
+			Answer:  1,
+			Explain: "The loop body is the yield func. Calling yield again after it returned false panics.",
+		},
+		{
+			Prompt:  "Filter(seq, keep) returns an iter.Seq. When is keep first called?",
+			Choices: []string{"Immediately, on every value", "When something ranges over the result, one value at a time as the loop reaches it", "When Collect is imported", "Never; Filter only records keep"},
+			Answer:  1,
+			Explain: "Like a JS generator, the sequence does no work until it's iterated, and only as far as the loop goes.",
+		},
+		{
+			Prompt:  "Why must you call the stop func that iter.Pull returns?",
+			Choices: []string{"It frees the slice", "It ends a sequence you didn't read to the end, which would otherwise be left waiting on its next yield", "It resets the iterator to the start", "It's only needed for iter.Seq2"},
+			Answer:  1,
+			Explain: "Pull runs the sequence alongside your code. defer stop() makes sure it's finished off when you return early.",
+		},
+	},
+	"47-slices-maps": {
+		{
+			Prompt:  "What does cmp.Or(cmp.Compare(a.Age, b.Age), strings.Compare(a.Name, b.Name)) return when the ages differ?",
+			Choices: []string{"The name comparison", "The age comparison, since cmp.Or returns its first argument that isn't zero", "Always 0", "The sum of both"},
+			Answer:  1,
+			Explain: "cmp.Or returns the first non-zero value, so later keys only break ties in earlier ones.",
+		},
+		{
+			Prompt:  "Why call slices.DeleteFunc on slices.Clone(nums) rather than on nums?",
+			Choices: []string{"DeleteFunc doesn't accept nil", "DeleteFunc works in place, so it would overwrite the caller's slice", "Clone makes it faster", "DeleteFunc only works on copies"},
+			Answer:  1,
+			Explain: "Like JS's splice, DeleteFunc and Delete shift elements within the same array. Clone first to leave the original alone.",
+		},
+		{
+			Prompt:  "On the slice [3, 1, 3, 2, 2], what does slices.Compact return?",
+			Choices: []string{"[1, 2, 3]", "[3, 1, 3, 2], since it only collapses runs of equal elements next to each other", "[3, 1, 2]", "[3, 1, 3, 2, 2]"},
+			Answer:  1,
+			Explain: "Compact replaces each run of equal elements with one. Sort first to remove every duplicate.",
+		},
+	},
+	"48-pointers": {
+		{
+			Prompt:  "Counter's Reset method has a pointer receiver. Which of these is a Resetter?",
+			Choices: []string{"Counter only", "*Counter only, since a Counter's method set doesn't include pointer-receiver methods", "Both Counter and *Counter", "Neither, without an explicit implements clause"},
+			Answer:  1,
+			Explain: "c.Reset() on a variable works because Go takes &c for you, but an interface value holding a Counter has no address to take.",
+		},
+		{
+			Prompt:  "t2 := t copies a struct whose Tags field is a slice. What does t2.Tags[0] = \"x\" do to t?",
+			Choices: []string{"Nothing; t2 is a copy", "It changes t.Tags[0] too, since both slice headers point at the same backing array", "It panics", "It appends to t.Tags"},
+			Answer:  1,
+			Explain: "Copying a struct is shallow: slices and maps inside it are shared until you clone them.",
+		},
+		{
+			Prompt:  "In for _, u := range users { index[u.Name] = &u }, what does each pointer point at?",
+			Choices: []string{"The element in users", "A copy of the element, so changes through it never reach users", "The same variable for every user, in every Go version", "nil"},
+			Answer:  1,
+			Explain: "Since Go 1.22 each iteration has its own u, but it's still a copy. &users[i] points into the slice.",
+		},
+	},
+	"49-optimization": {
+		{
+			Prompt:  "Why is s += piece in a loop slow for building a long string?",
+			Choices: []string{"Strings are UTF-8", "Strings are immutable, so every += allocates a new string and copies everything built so far", "+= takes a lock", "It isn't; the compiler turns it into a strings.Builder"},
+			Answer:  1,
+			Explain: "n appends copy O(n^2) bytes in all. A strings.Builder, grown once with Grow, copies each byte once.",
+		},
+		{
+			Prompt:  "What does b.ReportAllocs do in a benchmark?",
+			Choices: []string{"It fails the benchmark if it allocates", "It adds B/op and allocs/op to the benchmark's results, like running it with -benchmem", "It turns off the garbage collector", "It records a heap profile"},
+			Answer:  1,
+			Explain: "The numbers are averages per b.Loop iteration. To enforce a limit in a test, use testing.AllocsPerRun, or compare with a recorded baseline.",
+		},
+		{
+			Prompt:  "You wrap an *os.File in a bufio.Writer and write 100 short lines. What must you do before returning?",
+			Choices: []string{"Nothing; it writes through as you go", "Call Flush, and return its error: until then the last lines are only in the buffer", "Close the bufio.Writer", "Call Sync on the file"},
+			Answer:  1,
+			Explain: "bufio.Writer passes the buffer on to the file only when it's full or flushed, and keeps the first Write error to return from Flush.",
+		},
+	},
+}
@@ -0,0 +1,116 @@
+// Package quiz holds short multiple-choice quizzes, one per exercise,
+// on the ideas the exercise practices: "what is the zero value of a
+// map?" rather than "write this function". `learngo quiz` asks them.
+//
+// The tests check you can write the code; the quiz checks you know why
+// it works, which is where JS habits tend to hide (a nil map is not {}).
+// Like the registry, the questions are plain Go values so the compiler
+// and a unit test catch a typo in an answer index.
+package quiz
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Question is one multiple-choice question.
+type Question struct {
+	Prompt  string
+	Choices []string
+	Answer  int    // index into Choices
+	Explain string // shown after the answer, right or wrong
+}
+
+// For returns the questions for an exercise, or nil if it has none.
+func For(id string) []Question {
+	return banks[id]
+}
+
+// Validate checks every bank: at least one question, two or more
+// choices each, and an answer that is one of them.
+func Validate() error {
+	for id, qs := range banks {
+		if len(qs) == 0 {
+			return fmt.Errorf("%s: empty question bank", id)
+		}
+		for i, q := range qs {
+			switch {
+			case q.Prompt == "":
+				return fmt.Errorf("%s question %d: no prompt", id, i+1)
+			case len(q.Choices) < 2:
+				return fmt.Errorf("%s question %d: needs at least two choices", id, i+1)
+			case len(q.Choices) > len(letters):
+				return fmt.Errorf("%s question %d: more than %d choices", id, i+1, len(letters))
+			case q.Answer < 0 || q.Answer >= len(q.Choices):
+				return fmt.Errorf("%s question %d: answer %d is not one of the choices", id, i+1, q.Answer)
+			}
+		}
+	}
+	return nil
+}
+
+const letters = "abcdef"
+
+// ErrAborted means the input ended before the last question was answered.
+var ErrAborted = errors.New("quiz aborted")
+
+// Take asks qs one at a time, reading answers from r and writing to w,
+// and returns how many were answered correctly. An answer is a letter
+// ("b") or a number ("2"); anything else asks again. If r ends first,
+// Take returns ErrAborted.
+func Take(r io.Reader, w io.Writer, qs []Question) (correct int, err error) {
+	in := bufio.NewScanner(r)
+	for i, q := range qs {
+		fmt.Fprintf(w, "\n%d/%d. %s\n", i+1, len(qs), q.Prompt)
+		for j, c := range q.Choices {
+			fmt.Fprintf(w, "  %c) %s\n", letters[j], c)
+		}
+
+		choice := -1
+		for choice < 0 {
+			fmt.Fprint(w, "> ")
+			if !in.Scan() {
+				if err := in.Err(); err != nil {
+					return correct, err
+				}
+				fmt.Fprintln(w)
+				return correct, ErrAborted
+			}
+			var ok bool
+			if choice, ok = parseChoice(in.Text(), len(q.Choices)); !ok {
+				fmt.Fprintf(w, "Answer with a letter from a to %c.\n", letters[len(q.Choices)-1])
+				choice = -1
+			}
+		}
+
+		if choice == q.Answer {
+			correct++
+			fmt.Fprint(w, "Correct.")
+		} else {
+			fmt.Fprintf(w, "Not quite: the answer is %c.", letters[q.Answer])
+		}
+		if q.Explain != "" {
+			fmt.Fprintf(w, " %s", q.Explain)
+		}
+		fmt.Fprintln(w)
+	}
+	return correct, nil
+}
+
+// parseChoice reads "b", "B", "b)" or "2" as the second of n choices.
+func parseChoice(s string, n int) (int, bool) {
+	s = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(s)), ")")
+	if len(s) == 1 {
+		if i := strings.IndexByte(letters[:n], s[0]); i >= 0 {
+			return i, true
+		}
+	}
+	if i, err := strconv.Atoi(s); err == nil && i >= 1 && i <= n {
+		return i - 1, true
+	}
+	return 0, false
+}
@@ -0,0 +1,57 @@
+package quiz
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/imgarylai/learn-go/internal/registry"
+)
+
+func TestBanksAreValid(t *testing.T) {
+	if err := Validate(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestEveryExerciseHasAQuiz(t *testing.T) {
+	for _, e := range registry.All() {
+		if len(For(e.ID)) == 0 {
+			t.Errorf("%s has no quiz", e.ID)
+		}
+	}
+	for id := range banks {
+		if _, ok := registry.Lookup(id); !ok {
+			t.Errorf("quiz for unknown exercise %s", id)
+		}
+	}
+}
+
+var sample = []Question{
+	{Prompt: "Zero value of a map?", Choices: []string{"{}", "nil"}, Answer: 1, Explain: "Writes panic."},
+	{Prompt: "len(\"é\")?", Choices: []string{"1", "2", "3"}, Answer: 1},
+}
+
+func TestTake(t *testing.T) {
+	var out strings.Builder
+	// "x" and "4" aren't choices and are asked again; "B)" and "1" are.
+	correct, err := Take(strings.NewReader("x\nB)\n4\n1\n"), &out, sample)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if correct != 1 {
+		t.Errorf("correct = %d, want 1", correct)
+	}
+	for _, want := range []string{"1/2. Zero value of a map?", "  b) nil", "Correct. Writes panic.", "Answer with a letter from a to c.", "Not quite: the answer is b."} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output is missing %q:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestTakeAborted(t *testing.T) {
+	correct, err := Take(strings.NewReader("b\n"), &strings.Builder{}, sample)
+	if !errors.Is(err, ErrAborted) || correct != 1 {
+		t.Errorf("got %d, %v; want 1, ErrAborted", correct, err)
+	}
+}
@@ -0,0 +1,133 @@
+package registry
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Validate checks the prerequisite graph: every prerequisite must be a
+// known exercise and there must be no cycles (A needs B needs A), or
+// some exercises could never be unlocked. Together those guarantee every
+// exercise is reachable: follow its prerequisites back and you always
+// end at one that has none.
+func Validate() error {
+	return validate(exercises)
+}
+
+func validate(exs []Exercise) error {
+	byID := make(map[string]Exercise, len(exs))
+	for _, e := range exs {
+		byID[e.ID] = e
+	}
+	for _, e := range exs {
+		for _, p := range e.Prerequisites {
+			if _, ok := byID[p]; !ok {
+				return fmt.Errorf("%s: unknown prerequisite %q", e.ID, p)
+			}
+		}
+	}
+
+	// Classic depth-first search with three colors: white (unvisited),
+	// grey (on the current path) and black (finished). Reaching a grey
+	// node again means we walked in a circle.
+	const (
+		white = iota
+		grey
+		black
+	)
+	color := make(map[string]int, len(exs))
+	var path []string
+
+	var visit func(id string) error
+	visit = func(id string) error {
+		switch color[id] {
+		case grey:
+			return fmt.Errorf("prerequisite cycle: %s -> %s", strings.Join(path, " -> "), id)
+		case black:
+			return nil
+		}
+		color[id] = grey
+		path = append(path, id)
+		for _, p := range byID[id].Prerequisites {
+			if err := visit(p); err != nil {
+				return err
+			}
+		}
+		path = path[:len(path)-1]
+		color[id] = black
+		return nil
+	}
+
+	for _, e := range exs {
+		if err := visit(e.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Unlocked returns the exercises that aren't done yet but whose
+// prerequisites all are, in curriculum order. done reports whether an
+// exercise ID is completed.
+func Unlocked(done func(id string) bool) []Exercise {
+	var out []Exercise
+	for _, e := range exercises {
+		if done(e.ID) {
+			continue
+		}
+		ready := true
+		for _, p := range e.Prerequisites {
+			if !done(p) {
+				ready = false
+				break
+			}
+		}
+		if ready {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// Requires returns every exercise that must be done before id can be
+// unlocked, prerequisites of prerequisites included, in curriculum
+// order. It's empty for an exercise with no prerequisites or an
+// unknown ID.
+func Requires(id string) []Exercise {
+	byID := make(map[string]Exercise, len(exercises))
+	for _, e := range exercises {
+		byID[e.ID] = e
+	}
+	need := map[string]bool{}
+	var walk func(id string)
+	walk = func(id string) {
+		for _, p := range byID[id].Prerequisites {
+			if !need[p] {
+				need[p] = true
+				walk(p)
+			}
+		}
+	}
+	walk(id)
+
+	var out []Exercise
+	for _, e := range exercises {
+		if need[e.ID] {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// Unlocks returns the exercises that list id as a direct prerequisite,
+// in curriculum order: finishing id brings each of them a step closer.
+func Unlocks(id string) []Exercise {
+	var out []Exercise
+	for _, e := range exercises {
+		if slices.Contains(e.Prerequisites, id) {
+			out = append(out, e)
+		}
+	}
+	return out
+}
@@ -0,0 +1,128 @@
+package registry
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRegistryGraphIsValid(t *testing.T) {
+	if err := Validate(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestValidateDetectsCycles(t *testing.T) {
+	tests := []struct {
+		name string
+		exs  []Exercise
+		want string // substring of the error, "" for no error
+	}{
+		{
+			name: "chain",
+			exs: []Exercise{
+				{ID: "a"},
+				{ID: "b", Prerequisites: []string{"a"}},
+				{ID: "c", Prerequisites: []string{"a", "b"}},
+			},
+		},
+		{
+			name: "self loop",
+			exs:  []Exercise{{ID: "a", Prerequisites: []string{"a"}}},
+			want: "a -> a",
+		},
+		{
+			name: "three-step cycle",
+			exs: []Exercise{
+				{ID: "a", Prerequisites: []string{"c"}},
+				{ID: "b", Prerequisites: []string{"a"}},
+				{ID: "c", Prerequisites: []string{"b"}},
+			},
+			want: "cycle",
+		},
+		{
+			name: "unknown prerequisite",
+			exs:  []Exercise{{ID: "a", Prerequisites: []string{"zzz"}}},
+			want: `unknown prerequisite "zzz"`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validate(tc.exs)
+			switch {
+			case tc.want == "" && err != nil:
+				t.Errorf("unexpected error: %v", err)
+			case tc.want != "" && (err == nil || !strings.Contains(err.Error(), tc.want)):
+				t.Errorf("got %v, want error containing %q", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestUnlocked(t *testing.T) {
+	ids := func(exs []Exercise) []string {
+		var out []string
+		for _, e := range exs {
+			out = append(out, e.ID)
+		}
+		return out
+	}
+
+	nothingDone := Unlocked(func(string) bool { return false })
+	if got := ids(nothingDone); len(got) != 1 || got[0] != "01-basics" {
+		t.Errorf("fresh start: got %v, want [01-basics]", got)
+	}
+
+	done := map[string]bool{"01-basics": true, "02-functions": true}
+	got := ids(Unlocked(func(id string) bool { return done[id] }))
+	want := "03-structs,04-collections"
+	if strings.Join(got, ",") != want {
+		t.Errorf("after 01+02: got %v, want %s", got, want)
+	}
+
+	all := func(string) bool { return true }
+	if got := Unlocked(all); len(got) != 0 {
+		t.Errorf("everything done: got %v, want none", ids(got))
+	}
+}
+
+func TestRequires(t *testing.T) {
+	var ids []string
+	for _, e := range Requires("06-concurrency") {
+		ids = append(ids, e.ID)
+	}
+	// 06 needs 02 and 04; 04 needs 02, which needs 01.
+	if got, want := strings.Join(ids, " "), "01-basics 02-functions 04-collections"; got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+	if got := Requires("01-basics"); len(got) != 0 {
+		t.Errorf("01-basics has no prerequisites, got %v", got)
+	}
+}
+
+func TestEveryExerciseIsReachable(t *testing.T) {
+	// Start from the exercises without prerequisites and follow Unlocks.
+	seen := map[string]bool{}
+	var queue []string
+	for _, e := range All() {
+		if len(e.Prerequisites) == 0 {
+			queue = append(queue, e.ID)
+		}
+	}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		for _, e := range Unlocks(id) {
+			queue = append(queue, e.ID)
+		}
+	}
+	for _, e := range All() {
+		if !seen[e.ID] {
+			t.Errorf("%s can't be reached from an exercise without prerequisites", e.ID)
+		}
+	}
+}
@@ -0,0 +1,805 @@
+// Package registry describes every exercise in the repository: where it
+// lives, what it teaches, and how hard it is.
+//
+// In JS/TS you might keep this in a JSON file next to package.json.
+// In Go we keep it as plain structs so the compiler checks it for us.
+package registry
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/imgarylai/learn-go/internal/i18n"
+)
+
+// Difficulty is a coarse level used for filtering exercises.
+type Difficulty string
+
+const (
+	Beginner     Difficulty = "beginner"
+	Intermediate Difficulty = "intermediate"
+	Advanced     Difficulty = "advanced"
+)
+
+// Difficulties lists the known levels from easiest to hardest.
+var Difficulties = []Difficulty{Beginner, Intermediate, Advanced}
+
+// ParseDifficulty converts user input like "Intermediate" into a Difficulty.
+func ParseDifficulty(s string) (Difficulty, error) {
+	d := Difficulty(strings.ToLower(strings.TrimSpace(s)))
+	if !slices.Contains(Difficulties, d) {
+		return "", fmt.Errorf("unknown difficulty %q (want beginner, intermediate or advanced)", s)
+	}
+	return d, nil
+}
+
+// Exercise is the metadata for one exercises/NN-name folder.
+type Exercise struct {
+	ID            string // folder name, e.g. "04-collections"
+	Title         string
+	Topics        []string
+	Difficulty    Difficulty
+	Prerequisites []string // IDs of exercises to finish first
+	Constraints   []Constraint
+	// Weights gives some tests more points than the default of 1 for
+	// partial-credit grading, keyed by top-level test name.
+	Weights map[string]float64
+	// Race makes `learngo run` use the race detector by default, for
+	// exercises whose bugs are data races.
+	Race bool
+	// Hints are nudges shown by `learngo hint`, gentlest first. The
+	// built-in exercises take them from the English catalog in package
+	// i18n, which also holds their translations.
+	Hints []string
+	// Version counts changes to the stubs or tests that a copy you've
+	// started working on has to follow, like the major version of a
+	// package. Bump it when such a change ships; `learngo migrate`
+	// carries it over into your copy. Zero means the first version.
+	Version int
+
+	// Pack and Path are set for exercises from an external exercise
+	// pack (see package pack): the pack's name and the exercise's
+	// absolute directory. Both are empty for the built-in exercises.
+	Pack string
+	Path string
+}
+
+// Points is how much the test called name is worth.
+func (e Exercise) Points(name string) float64 {
+	if w, ok := e.Weights[name]; ok {
+		return w
+	}
+	return 1
+}
+
+// CurrentVersion is the version of the exercise in this checkout,
+// counting from 1.
+func (e Exercise) CurrentVersion() int {
+	return max(e.Version, 1)
+}
+
+// Constraint is a rule a solution must follow so it practices the
+// technique the exercise is about. `learngo check` enforces them.
+// Set exactly one of NoImport and NoCall.
+type Constraint struct {
+	// NoImport forbids importing a package, e.g. "slices".
+	NoImport string
+	// NoCall forbids calling a function, written as types.Func.FullName
+	// prints it: "os.ReadFile", or "(*bytes.Buffer).String" for a method.
+	NoCall string
+	// In limits NoCall to one function or method; empty means anywhere.
+	In string
+	// Why is shown to the student alongside the violation.
+	Why string
+}
+
+// Dir returns the exercise directory relative to the repository root,
+// or the absolute directory of an exercise from a pack.
+func (e Exercise) Dir() string {
+	if e.Path != "" {
+		return e.Path
+	}
+	return filepath.Join("exercises", e.ID)
+}
+
+// DirIn returns the exercise directory for a checkout at root.
+func (e Exercise) DirIn(root string) string {
+	if filepath.IsAbs(e.Dir()) {
+		return e.Dir()
+	}
+	return filepath.Join(root, e.Dir())
+}
+
+// HasTopic reports whether the exercise is tagged with topic (case-insensitive).
+func (e Exercise) HasTopic(topic string) bool {
+	for _, t := range e.Topics {
+		if strings.EqualFold(t, topic) {
+			return true
+		}
+	}
+	return false
+}
+
+// exercises is the source of truth, kept in curriculum order.
+var exercises = []Exercise{
+	{
+		ID:         "01-basics",
+		Title:      "Basics",
+		Topics:     []string{"variables", "types", "constants", "zero-values", "strconv"},
+		Difficulty: Beginner,
+	},
+	{
+		ID:            "02-functions",
+		Title:         "Functions",
+		Topics:        []string{"functions", "errors", "defer", "cleanup", "closures", "recursion"},
+		Difficulty:    Beginner,
+		Prerequisites: []string{"01-basics"},
+	},
+	{
+		ID:            "03-structs",
+		Title:         "Structs",
+		Topics:        []string{"structs", "methods", "embedding", "tags", "json"},
+		Difficulty:    Beginner,
+		Prerequisites: []string{"02-functions"},
+	},
+	{
+		ID:            "04-collections",
+		Title:         "Collections",
+		Topics:        []string{"slices", "maps", "iteration", "generics"},
+		Difficulty:    Beginner,
+		Prerequisites: []string{"02-functions"},
+		Constraints: []Constraint{
+			{NoImport: "slices", Why: "write the loops yourself; that's what this module practices"},
+			{NoImport: "maps", Why: "write the loops yourself; that's what this module practices"},
+		},
+		Weights: map[string]float64{
+			"TestGetTopScorer":     2,
+			"TestCountOccurrences": 2,
+		},
+		Hints: i18n.Hints(i18n.Default, "04-collections"),
+	},
+	{
+		ID:            "05-interfaces",
+		Title:         "Interfaces",
+		Topics:        []string{"interfaces", "type-assertions", "errors", "io"},
+		Difficulty:    Intermediate,
+		Prerequisites: []string{"03-structs"},
+	},
+	{
+		ID:            "06-concurrency",
+		Title:         "Concurrency",
+		Topics:        []string{"concurrency", "goroutines", "channels", "sync"},
+		Difficulty:    Intermediate,
+		Prerequisites: []string{"02-functions", "04-collections"},
+		Constraints: []Constraint{
+			{NoCall: "time.Sleep", Why: "sleeping isn't synchronization; use channels, sync.WaitGroup or sync.Mutex"},
+		},
+		Weights: map[string]float64{
+			"TestWorkerPool":                       3,
+			"TestFanOutFanIn":                      3,
+			"TestConcurrentIncrementRaceDetection": 2,
+		},
+		Race:  true,
+		Hints: i18n.Hints(i18n.Default, "06-concurrency"),
+	},
+	{
+		ID:            "07-file-processing",
+		Title:         "File Processing",
+		Topics:        []string{"io", "files", "csv", "json"},
+		Difficulty:    Intermediate,
+		Prerequisites: []string{"03-structs", "04-collections"},
+		Constraints: []Constraint{
+			{NoCall: "os.ReadFile", In: "CountLines", Why: "stream the file with bufio.Scanner instead of loading it all into memory"},
+		},
+		Weights: map[string]float64{
+			"TestConvertCSVToJSON": 2,
+			"TestProcessLargeFile": 2,
+		},
+		Hints: i18n.Hints(i18n.Default, "07-file-processing"),
+	},
+	{
+		ID:            "08-data-processing",
+		Title:         "Data Processing",
+		Topics:        []string{"generics", "slices", "maps", "csv", "dataframe"},
+		Difficulty:    Intermediate,
+		Prerequisites: []string{"04-collections", "07-file-processing"},
+		Weights: map[string]float64{
+			"TestGenericGroupBy":            2,
+			"TestSalesToDataFrame":          2,
+			"TestAverageSalaryByDepartment": 2,
+		},
+	},
+	{
+		ID:            "09-matrices",
+		Title:         "Matrices",
+		Topics:        []string{"slices", "2d-slices", "csv", "errors"},
+		Difficulty:    Intermediate,
+		Prerequisites: []string{"04-collections", "07-file-processing"},
+		Weights: map[string]float64{
+			"TestMultiply":  2,
+			"TestTranspose": 2,
+		},
+		Hints: i18n.Hints(i18n.Default, "09-matrices"),
+	},
+	{
+		ID:            "10-slice-internals",
+		Title:         "Slice Internals",
+		Topics:        []string{"slices", "memory", "aliasing"},
+		Difficulty:    Intermediate,
+		Prerequisites: []string{"04-collections"},
+		Weights: map[string]float64{
+			"TestAppendTo": 2,
+			"TestRemoveAt": 2,
+		},
+		Hints: i18n.Hints(i18n.Default, "10-slice-internals"),
+	},
+	{
+		ID:            "11-deep-copy",
+		Title:         "Deep Copy and Equality",
+		Topics:        []string{"structs", "pointers", "maps", "reflection"},
+		Difficulty:    Intermediate,
+		Prerequisites: []string{"03-structs", "04-collections"},
+		Weights: map[string]float64{
+			"TestEqual": 2,
+		},
+		Hints: i18n.Hints(i18n.Default, "11-deep-copy"),
+	},
+	{
+		ID:            "12-clock",
+		Title:         "Injectable Clock",
+		Topics:        []string{"interfaces", "time", "testing"},
+		Difficulty:    Intermediate,
+		Prerequisites: []string{"05-interfaces", "06-concurrency"},
+		Constraints: []Constraint{
+			{NoCall: "time.Now", In: "IsExpired", Why: "use the Clock you were given, or tests can't control the time"},
+			{NoCall: "time.Now", In: "Allow", Why: "use the Clock you were given, or tests can't control the time"},
+			{NoCall: "time.After", In: "WaitOrTimeout", Why: "use the Clock you were given, or tests can't control the time"},
+		},
+		Weights: map[string]float64{
+			"TestFakeClockAfter": 2,
+			"TestRateWindow":     2,
+		},
+		Hints: i18n.Hints(i18n.Default, "12-clock"),
+	},
+	{
+		ID:            "13-string-algorithms",
+		Title:         "String Algorithms",
+		Topics:        []string{"strings", "runes", "unicode", "maps"},
+		Difficulty:    Beginner,
+		Prerequisites: []string{"01-basics", "04-collections"},
+		Weights: map[string]float64{
+			"TestLongestCommonPrefix": 2,
+			"TestCaesar":              2,
+		},
+		Hints: i18n.Hints(i18n.Default, "13-string-algorithms"),
+	},
+	{
+		ID:            "14-capstone",
+		Title:         "Capstone: Sales Report Service",
+		Topics:        []string{"packages", "csv", "concurrency", "http", "json", "context"},
+		Difficulty:    Advanced,
+		Prerequisites: []string{"02-functions", "06-concurrency", "07-file-processing"},
+		Weights: map[string]float64{
+			"TestEndToEnd":                 3,
+			"TestLoadSales":                2,
+			"TestServeShutsDownGracefully": 2,
+		},
+		Hints: i18n.Hints(i18n.Default, "14-capstone"),
+	},
+	{
+		ID:            "15-property-testing",
+		Title:         "Property-Based Testing",
+		Topics:        []string{"testing", "properties", "slices"},
+		Difficulty:    Intermediate,
+		Prerequisites: []string{"04-collections"},
+		Weights: map[string]float64{
+			"TestDedupeProperties": 2,
+			"TestSearchProperty":   2,
+		},
+		Hints: i18n.Hints(i18n.Default, "15-property-testing"),
+	},
+	{
+		ID:            "16-static-analysis",
+		Title:         "Write Your Own Analyzer",
+		Topics:        []string{"analysis", "ast", "types", "tooling", "testing"},
+		Difficulty:    Advanced,
+		Prerequisites: []string{"05-interfaces", "07-file-processing"},
+		Weights: map[string]float64{
+			"TestNoPrint":   2,
+			"TestFileClose": 3,
+		},
+		Hints: i18n.Hints(i18n.Default, "16-static-analysis"),
+	},
+	{
+		ID:            "17-errors",
+		Title:         "Errors in Depth",
+		Topics:        []string{"errors", "wrapping", "interfaces"},
+		Difficulty:    Intermediate,
+		Prerequisites: []string{"02-functions", "05-interfaces"},
+		Weights: map[string]float64{
+			"TestInvalidFields": 2,
+			"TestRetry":         2,
+		},
+		Hints: i18n.Hints(i18n.Default, "17-errors"),
+	},
+	{
+		ID:            "18-generics",
+		Title:         "Generics in Depth",
+		Topics:        []string{"generics", "constraints", "type-sets"},
+		Difficulty:    Intermediate,
+		Prerequisites: []string{"04-collections", "05-interfaces"},
+		Weights: map[string]float64{
+			"TestUnionIntersect": 2,
+			"TestMapResult":      2,
+			"TestParseEnum":      2,
+		},
+		Hints: i18n.Hints(i18n.Default, "18-generics"),
+	},
+	{
+		ID:            "19-http-server",
+		Title:         "HTTP Server Basics",
+		Topics:        []string{"net/http", "json", "httptest"},
+		Difficulty:    Intermediate,
+		Prerequisites: []string{"03-structs", "06-concurrency"},
+		Weights: map[string]float64{
+			"TestRoutes":     2,
+			"TestListTodos":  2,
+			"TestCreateTodo": 2,
+		},
+		Hints: i18n.Hints(i18n.Default, "19-http-server"),
+	},
+	{
+		ID:            "20-http-client",
+		Title:         "HTTP Client: Retries and Timeouts",
+		Topics:        []string{"net/http", "context", "retries"},
+		Difficulty:    Advanced,
+		Prerequisites: []string{"17-errors", "19-http-server"},
+		Weights: map[string]float64{
+			"TestDoRetries":            2,
+			"TestDoRewindsBody":        2,
+			"TestDoStopsWhenCancelled": 2,
+			"TestPostJSONTimeout":      2,
+		},
+		Hints: i18n.Hints(i18n.Default, "20-http-client"),
+	},
+	{
+		ID:            "21-json",
+		Title:         "JSON in Depth",
+		Topics:        []string{"json", "interfaces", "streaming"},
+		Difficulty:    Intermediate,
+		Prerequisites: []string{"03-structs", "05-interfaces"},
+		Weights: map[string]float64{
+			"TestApplyPatch":  2,
+			"TestDecodeEvent": 2,
+			"TestStreamItems": 2,
+		},
+		Hints: i18n.Hints(i18n.Default, "21-json"),
+	},
+	{
+		ID:            "22-context",
+		Title:         "The context Package",
+		Topics:        []string{"context", "cancellation", "goroutines"},
+		Difficulty:    Advanced,
+		Prerequisites: []string{"06-concurrency", "17-errors"},
+		Weights: map[string]float64{
+			"TestDoWithContext":   2,
+			"TestWorkersCancel":   2,
+			"TestFirstResult":     2,
+			"TestCallWithTimeout": 2,
+		},
+		Hints: i18n.Hints(i18n.Default, "22-context"),
+	},
+	{
+		ID:            "23-writing-tests",
+		Title:         "Writing Tests",
+		Topics:        []string{"testing", "table-driven tests", "subtests"},
+		Difficulty:    Intermediate,
+		Prerequisites: []string{"02-functions", "15-property-testing"},
+		Weights: map[string]float64{
+			"TestCheckCache":       2,
+			"TestCheckWriteAtomic": 2,
+		},
+		Hints: i18n.Hints(i18n.Default, "23-writing-tests"),
+	},
+	{
+		ID:            "24-unicode",
+		Title:         "Strings, Runes and Unicode",
+		Topics:        []string{"strings", "unicode", "utf-8"},
+		Difficulty:    Intermediate,
+		Prerequisites: []string{"13-string-algorithms"},
+		Weights: map[string]float64{
+			"TestFoldKey":   2,
+			"TestGraphemes": 2,
+			"TestCheckUTF8": 2,
+		},
+		Hints: i18n.Hints(i18n.Default, "24-unicode"),
+	},
+	{
+		ID:            "25-time",
+		Title:         "Dates and Times",
+		Topics:        []string{"time", "time zones", "testing"},
+		Difficulty:    Intermediate,
+		Prerequisites: []string{"12-clock"},
+		Weights: map[string]float64{
+			"TestDaysBetween":     2,
+			"TestNextBusinessDay": 2,
+			"TestNextOpening":     2,
+		},
+		Hints: i18n.Hints(i18n.Default, "25-time"),
+	},
+	{
+		ID:            "26-regexp",
+		Title:         "Regular Expressions",
+		Topics:        []string{"regexp", "strings", "parsing"},
+		Difficulty:    Intermediate,
+		Prerequisites: []string{"13-string-algorithms"},
+		Weights: map[string]float64{
+			"TestParseLog": 2,
+			"TestRedact":   2,
+		},
+		Hints: i18n.Hints(i18n.Default, "26-regexp"),
+	},
+	{
+		ID:            "27-io",
+		Title:         "Composing Readers and Writers",
+		Topics:        []string{"io", "bufio", "streams"},
+		Difficulty:    Intermediate,
+		Prerequisites: []string{"05-interfaces", "07-file-processing"},
+		Weights: map[string]float64{
+			"TestCopyInChunks": 2,
+			"TestUpperReader":  2,
+		},
+		Hints: i18n.Hints(i18n.Default, "27-io"),
+	},
+	{
+		ID:            "28-sorting",
+		Title:         "Sorting and Searching",
+		Topics:        []string{"sort", "slices", "generics"},
+		Difficulty:    Intermediate,
+		Prerequisites: []string{"05-interfaces", "18-generics"},
+		Weights: map[string]float64{
+			"TestNaturalCompare": 2,
+			"TestInsertionSort":  2,
+		},
+		Hints: i18n.Hints(i18n.Default, "28-sorting"),
+	},
+	{
+		ID:            "29-data-structures",
+		Title:         "Classic Data Structures",
+		Topics:        []string{"generics", "iterators", "pointers", "container/heap"},
+		Difficulty:    Intermediate,
+		Prerequisites: []string{"18-generics", "28-sorting"},
+		Weights: map[string]float64{
+			"TestTreeDelete": 2,
+			"TestQueueWraps": 2,
+			"TestTreeRandom": 2,
+		},
+		Hints: i18n.Hints(i18n.Default, "29-data-structures"),
+	},
+	{
+		ID:            "30-algorithms",
+		Title:         "Algorithms Practice",
+		Topics:        []string{"graphs", "maps", "dynamic programming", "closures"},
+		Difficulty:    Intermediate,
+		Prerequisites: []string{"04-collections", "18-generics"},
+		Weights: map[string]float64{
+			"TestTopoSort":  2,
+			"TestWindowMax": 2,
+		},
+		Hints: i18n.Hints(i18n.Default, "30-algorithms"),
+	},
+	{
+		ID:            "31-database",
+		Title:         "Databases with database/sql",
+		Topics:        []string{"database/sql", "sqlite", "transactions", "errors"},
+		Difficulty:    Intermediate,
+		Prerequisites: []string{"07-file-processing", "17-errors", "22-context"},
+		Weights: map[string]float64{
+			"TestImportPeopleAllOrNothing": 2,
+			"TestInsertProducts":           2,
+		},
+		Hints: i18n.Hints(i18n.Default, "31-database"),
+	},
+	{
+		ID:            "32-templates",
+		Title:         "Text and HTML Templates",
+		Topics:        []string{"text/template", "html/template", "escaping", "golden files"},
+		Difficulty:    Intermediate,
+		Prerequisites: []string{"08-data-processing", "19-http-server"},
+		Weights: map[string]float64{
+			"TestSalesReport":      2,
+			"TestSalesPageEscapes": 2,
+		},
+		Hints: i18n.Hints(i18n.Default, "32-templates"),
+	},
+	{
+		ID:            "33-cli",
+		Title:         "Building a CLI with flag",
+		Topics:        []string{"flag", "os", "io", "exit codes"},
+		Difficulty:    Intermediate,
+		Prerequisites: []string{"07-file-processing", "17-errors"},
+		Weights: map[string]float64{
+			"TestFilter": 2,
+			"TestRun":    2,
+		},
+		Hints: i18n.Hints(i18n.Default, "33-cli"),
+	},
+	{
+		ID:            "34-config",
+		Title:         "Configuration and environment variables",
+		Topics:        []string{"os.Getenv", "io/fs", "encoding", "yaml", "validation"},
+		Difficulty:    Intermediate,
+		Prerequisites: []string{"17-errors", "21-json"},
+		Weights: map[string]float64{
+			"TestParse":      2,
+			"TestLoadConfig": 2,
+		},
+		Hints: i18n.Hints(i18n.Default, "34-config"),
+	},
+	{
+		ID:            "35-hashing",
+		Title:         "Hashing and message digests",
+		Topics:        []string{"crypto/sha256", "crypto/hmac", "constant time", "bcrypt"},
+		Difficulty:    Intermediate,
+		Prerequisites: []string{"05-interfaces", "07-file-processing"},
+		Weights: map[string]float64{
+			"TestVerify":           2,
+			"TestOpenTokenRejects": 2,
+		},
+		Hints: i18n.Hints(i18n.Default, "35-hashing"),
+	},
+	{
+		ID:            "36-encryption",
+		Title:         "Symmetric encryption",
+		Topics:        []string{"crypto/aes", "crypto/cipher", "AES-GCM", "scrypt"},
+		Difficulty:    Advanced,
+		Prerequisites: []string{"35-hashing"},
+		Weights: map[string]float64{
+			"TestTamperDetection": 2,
+		},
+		Hints: i18n.Hints(i18n.Default, "36-encryption"),
+	},
+	{
+		ID:            "37-archives",
+		Title:         "Compression and archives",
+		Topics:        []string{"compress/gzip", "archive/zip", "archive/tar", "io/fs", "zip slip"},
+		Difficulty:    Intermediate,
+		Prerequisites: []string{"05-interfaces", "07-file-processing"},
+		Weights: map[string]float64{
+			"TestExtractZipSlip":   2,
+			"TestExtractTarGzSlip": 2,
+		},
+		Hints: i18n.Hints(i18n.Default, "37-archives"),
+	},
+	{
+		ID:            "38-encodings",
+		Title:         "Binary and text encodings",
+		Topics:        []string{"encoding/base64", "encoding/hex", "encoding/binary", "encoding/gob", "encoding/xml"},
+		Difficulty:    Intermediate,
+		Prerequisites: []string{"21-json", "27-io"},
+		Weights: map[string]float64{
+			"TestReadLog":    2,
+			"TestItemTitles": 2,
+		},
+		Hints: i18n.Hints(i18n.Default, "38-encodings"),
+	},
+	{
+		ID:            "39-networking",
+		Title:         "TCP and UDP",
+		Topics:        []string{"net", "tcp", "udp", "bufio", "graceful shutdown"},
+		Difficulty:    Advanced,
+		Prerequisites: []string{"06-concurrency", "22-context", "27-io"},
+		Weights: map[string]float64{
+			"TestServer":          2,
+			"TestShutdownTimeout": 2,
+		},
+		Hints: i18n.Hints(i18n.Default, "39-networking"),
+	},
+	{
+		ID:            "40-processes",
+		Title:         "Processes and signals",
+		Topics:        []string{"os/exec", "os/signal", "pipelines", "exit codes", "graceful shutdown"},
+		Difficulty:    Advanced,
+		Prerequisites: []string{"17-errors", "22-context"},
+		Weights: map[string]float64{
+			"TestPipeline":       2,
+			"TestRunUntilSignal": 2,
+		},
+		Hints: i18n.Hints(i18n.Default, "40-processes"),
+	},
+	{
+		ID:            "41-iofs",
+		Title:         "io/fs and filesystem abstraction",
+		Topics:        []string{"io/fs", "fs.WalkDir", "glob", "fstest.MapFS", "os.DirFS"},
+		Difficulty:    Intermediate,
+		Prerequisites: []string{"05-interfaces", "07-file-processing"},
+		Weights: map[string]float64{
+			"TestListFiles": 2,
+			"TestDirSizes":  2,
+		},
+		Hints: i18n.Hints(i18n.Default, "41-iofs"),
+	},
+	{
+		ID:            "42-sync-primitives",
+		Title:         "Advanced sync primitives",
+		Topics:        []string{"sync.Once", "sync.RWMutex", "sync.Map", "sync/atomic", "errgroup"},
+		Difficulty:    Advanced,
+		Prerequisites: []string{"06-concurrency", "18-generics", "22-context"},
+		Weights: map[string]float64{
+			"TestCacheGetOrLoadUnlocked": 2,
+			"TestConfigStoreUpdate":      2,
+			"TestFetchAllError":          2,
+		},
+		Race:  true,
+		Hints: i18n.Hints(i18n.Default, "42-sync-primitives"),
+	},
+	{
+		ID:            "43-pipelines",
+		Title:         "Channel pipeline patterns",
+		Topics:        []string{"pipelines", "generics", "fan-out/fan-in", "or-done", "tee", "bridge", "goroutine leaks"},
+		Difficulty:    Advanced,
+		Prerequisites: []string{"18-generics", "22-context"},
+		Weights: map[string]float64{
+			"TestTee":              2,
+			"TestBridge":           2,
+			"TestOrderedMap":       2,
+			"TestOrderedMapCancel": 2,
+		},
+		Race:  true,
+		Hints: i18n.Hints(i18n.Default, "43-pipelines"),
+	},
+	{
+		ID:            "44-resilience",
+		Title:         "Rate limiting and retries",
+		Topics:        []string{"token bucket", "x/time/rate", "exponential backoff", "jitter", "circuit breaker"},
+		Difficulty:    Advanced,
+		Prerequisites: []string{"12-clock", "17-errors", "22-context"},
+		Weights: map[string]float64{
+			"TestWait":    2,
+			"TestRetrier": 2,
+			"TestBreaker": 3,
+		},
+		Hints: i18n.Hints(i18n.Default, "44-resilience"),
+	},
+	{
+		ID:            "45-caching",
+		Title:         "Caching",
+		Topics:        []string{"LRU", "container/list", "TTL", "background sweeper", "single flight", "memoization"},
+		Difficulty:    Advanced,
+		Prerequisites: []string{"12-clock", "23-writing-tests", "42-sync-primitives"},
+		Weights: map[string]float64{
+			"TestLRU":               2,
+			"TestGroup":             3,
+			"TestMemoizeConcurrent": 2,
+		},
+		Race:  true,
+		Hints: i18n.Hints(i18n.Default, "45-caching"),
+	},
+	{
+		ID:            "46-iterators",
+		Title:         "Iterators",
+		Topics:        []string{"range over func", "iter.Seq", "iter.Seq2", "lazy sequences", "iter.Pull"},
+		Difficulty:    Intermediate,
+		Prerequisites: []string{"18-generics", "27-io", "29-data-structures"},
+		Weights: map[string]float64{
+			"TestLines":     2,
+			"TestFilterMap": 2,
+			"TestZip":       2,
+		},
+		Hints: i18n.Hints(i18n.Default, "46-iterators"),
+	},
+	{
+		ID:            "47-slices-maps",
+		Title:         "The slices, maps and cmp packages",
+		Topics:        []string{"slices", "maps", "cmp.Compare", "cmp.Or", "in place or a copy"},
+		Difficulty:    Intermediate,
+		Prerequisites: []string{"04-collections", "28-sorting", "46-iterators"},
+		Weights: map[string]float64{
+			"TestSortPeople": 2,
+			"TestTopScorer":  2,
+			"TestRanking":    2,
+		},
+		Hints: i18n.Hints(i18n.Default, "47-slices-maps"),
+	},
+	{
+		ID:            "48-pointers",
+		Title:         "Pointers and value semantics",
+		Topics:        []string{"pointers", "nil receivers", "method sets", "shallow copies", "aliasing"},
+		Difficulty:    Intermediate,
+		Prerequisites: []string{"03-structs", "10-slice-internals", "11-deep-copy"},
+		Weights: map[string]float64{
+			"TestWithMember":   2,
+			"TestWindows":      2,
+			"TestWithSuffixes": 2,
+			"TestIndexByName":  2,
+		},
+		Hints: i18n.Hints(i18n.Default, "48-pointers"),
+	},
+	{
+		ID:            "49-optimization",
+		Title:         "Benchmarking and optimization",
+		Topics:        []string{"benchmarks", "allocations", "strings.Builder", "buffered I/O", "performance budgets"},
+		Difficulty:    Advanced,
+		Prerequisites: []string{"07-file-processing", "10-slice-internals", "23-writing-tests", "27-io"},
+		Weights: map[string]float64{
+			"TestRenderTableBudget":   2,
+			"TestDistinctWordsBudget": 2,
+			"TestWriteLinesBudget":    2,
+			"TestCountByteBudget":     2,
+		},
+		Hints: i18n.Hints(i18n.Default, "49-optimization"),
+	},
+}
+
+// Register adds exercises from a pack after the built-in ones. IDs must
+// be new and the prerequisite graph must stay valid; on error nothing is
+// added. The returned func removes them again.
+func Register(exs ...Exercise) (unregister func(), err error) {
+	all := slices.Clone(exercises)
+	for _, e := range exs {
+		if slices.ContainsFunc(all, func(x Exercise) bool { return x.ID == e.ID }) {
+			return nil, fmt.Errorf("exercise %s is already registered", e.ID)
+		}
+		all = append(all, e)
+	}
+	if err := validate(all); err != nil {
+		return nil, err
+	}
+	exercises = all
+	return func() {
+		exercises = slices.DeleteFunc(exercises, func(x Exercise) bool {
+			return slices.ContainsFunc(exs, func(y Exercise) bool { return x.ID == y.ID })
+		})
+	}, nil
+}
+
+// All returns every exercise in curriculum order.
+// The slice is a copy, so callers can't modify the registry by accident.
+func All() []Exercise {
+	return slices.Clone(exercises)
+}
+
+// Lookup finds an exercise by ID. Shorthands like "4", "04" or
+// "collections" also work, so `learngo run 4` does what you'd expect.
+func Lookup(id string) (Exercise, bool) {
+	for _, e := range exercises {
+		num, name, _ := strings.Cut(e.ID, "-")
+		switch {
+		case id == e.ID, id == name:
+			return e, true
+		case id != "" && strings.TrimLeft(id, "0") == strings.TrimLeft(num, "0"):
+			return e, true
+		}
+	}
+	return Exercise{}, false
+}
+
+// Filter selects exercises. Zero-value fields match everything.
+type Filter struct {
+	Topic      string
+	Difficulty Difficulty
+}
+
+// Match reports whether e passes the filter.
+func (f Filter) Match(e Exercise) bool {
+	if f.Topic != "" && !e.HasTopic(f.Topic) {
+		return false
+	}
+	if f.Difficulty != "" && e.Difficulty != f.Difficulty {
+		return false
+	}
+	return true
+}
+
+// Select returns the exercises matching f, in curriculum order.
+func Select(f Filter) []Exercise {
+	var out []Exercise
+	for _, e := range exercises {
+		if f.Match(e) {
+			out = append(out, e)
+		}
+	}
+	return out
+}
@@ -0,0 +1,155 @@
+package registry
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestEveryExerciseHasADirectory(t *testing.T) {
+	for _, e := range All() {
+		dir := filepath.Join("..", "..", e.Dir())
+		if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+			t.Errorf("%s: directory %s not found", e.ID, dir)
+		}
+	}
+}
+
+func TestEveryDirectoryIsRegistered(t *testing.T) {
+	entries, err := os.ReadDir(filepath.Join("..", "..", "exercises"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		if _, ok := Lookup(entry.Name()); !ok {
+			t.Errorf("exercises/%s is missing from the registry", entry.Name())
+		}
+	}
+}
+
+func TestMetadataIsComplete(t *testing.T) {
+	seen := map[string]bool{}
+	for _, e := range All() {
+		if seen[e.ID] {
+			t.Errorf("duplicate ID %s", e.ID)
+		}
+		seen[e.ID] = true
+
+		if e.Title == "" || len(e.Topics) == 0 {
+			t.Errorf("%s: title and topics are required", e.ID)
+		}
+		if _, err := ParseDifficulty(string(e.Difficulty)); err != nil {
+			t.Errorf("%s: %v", e.ID, err)
+		}
+		for _, p := range e.Prerequisites {
+			if _, ok := Lookup(p); !ok {
+				t.Errorf("%s: unknown prerequisite %q", e.ID, p)
+			}
+		}
+		for _, c := range e.Constraints {
+			if (c.NoImport == "") == (c.NoCall == "") || c.Why == "" {
+				t.Errorf("%s: constraint %+v needs exactly one of NoImport/NoCall, and a Why", e.ID, c)
+			}
+			if c.In != "" && c.NoCall == "" {
+				t.Errorf("%s: constraint %+v: In only applies to NoCall", e.ID, c)
+			}
+		}
+	}
+}
+
+func TestLookup(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"04-collections", "04-collections"},
+		{"04", "04-collections"},
+		{"4", "04-collections"},
+		{"collections", "04-collections"},
+		{"nope", ""},
+		{"", ""},
+	}
+
+	for _, tc := range tests {
+		e, ok := Lookup(tc.input)
+		if ok != (tc.want != "") || e.ID != tc.want {
+			t.Errorf("Lookup(%q): got %q (ok=%v), want %q", tc.input, e.ID, ok, tc.want)
+		}
+	}
+}
+
+func TestParseDifficulty(t *testing.T) {
+	if d, err := ParseDifficulty(" Intermediate "); err != nil || d != Intermediate {
+		t.Errorf("got %q, %v; want intermediate", d, err)
+	}
+	if _, err := ParseDifficulty("expert"); err == nil {
+		t.Error("expected error for unknown difficulty")
+	}
+}
+
+func TestSelect(t *testing.T) {
+	got := Select(Filter{Topic: "Channels", Difficulty: Intermediate})
+	if len(got) != 1 || got[0].ID != "06-concurrency" {
+		t.Errorf("got %v, want only 06-concurrency", got)
+	}
+
+	if got := Select(Filter{}); len(got) != len(All()) {
+		t.Errorf("empty filter: got %d exercises, want %d", len(got), len(All()))
+	}
+
+	if got := Select(Filter{Topic: "channels", Difficulty: Beginner}); len(got) != 0 {
+		t.Errorf("got %v, want none", got)
+	}
+}
+
+func TestAllReturnsCopy(t *testing.T) {
+	all := All()
+	all[0].ID = "changed"
+	if All()[0].ID == "changed" {
+		t.Error("All() must not expose the internal slice")
+	}
+}
+
+func TestRegister(t *testing.T) {
+	before := len(All())
+	extra := Exercise{ID: "acme-logging", Title: "Logging", Prerequisites: []string{"02-functions"}, Pack: "acme", Path: "/packs/acme/logging"}
+	unregister, err := Register(extra)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e, ok := Lookup("acme-logging")
+	if !ok || e.Dir() != "/packs/acme/logging" || e.DirIn("/repo") != "/packs/acme/logging" {
+		t.Errorf("registered exercise: %+v, %v", e, ok)
+	}
+	if _, err := Register(extra); err == nil {
+		t.Error("registering the same ID twice should fail")
+	}
+
+	unregister()
+	if _, ok := Lookup("acme-logging"); ok {
+		t.Error("unregister should remove the exercise")
+	}
+
+	bad := []Exercise{
+		{ID: "acme-a", Prerequisites: []string{"acme-missing"}},
+		{ID: "01-basics"},
+	}
+	for _, e := range bad {
+		if _, err := Register(e); err == nil {
+			t.Errorf("Register(%s) should fail", e.ID)
+		}
+	}
+	if len(All()) != before {
+		t.Error("a failed Register must not change the registry")
+	}
+}
+
+func TestDirIn(t *testing.T) {
+	e, _ := Lookup("04")
+	if got, want := e.DirIn("/repo"), filepath.Join("/repo", "exercises", "04-collections"); got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,117 @@
+package report
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// The JUnit XML format has no official schema; these structs follow the
+// widely used Ant/Jenkins flavour. Struct tags drive encoding/xml the
+// same way `json:"..."` tags drive encoding/json.
+
+type junitSuites struct {
+	XMLName  xml.Name     `xml:"testsuites"`
+	Tests    int          `xml:"tests,attr"`
+	Failures int          `xml:"failures,attr"`
+	Errors   int          `xml:"errors,attr"`
+	Skipped  int          `xml:"skipped,attr"`
+	Suites   []junitSuite `xml:"testsuite"`
+}
+
+type junitSuite struct {
+	Name      string      `xml:"name,attr"`
+	Tests     int         `xml:"tests,attr"`
+	Failures  int         `xml:"failures,attr"`
+	Errors    int         `xml:"errors,attr"`
+	Skipped   int         `xml:"skipped,attr"`
+	Time      string      `xml:"time,attr"`
+	Timestamp string      `xml:"timestamp,attr,omitempty"`
+	Cases     []junitCase `xml:"testcase"`
+}
+
+type junitCase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitProblem `xml:"failure,omitempty"`
+	Error     *junitProblem `xml:"error,omitempty"`
+	Skipped   *junitSkipped `xml:"skipped,omitempty"`
+}
+
+type junitProblem struct {
+	Message string `xml:"message,attr"`
+	Body    string `xml:",chardata"`
+}
+
+type junitSkipped struct {
+	Message string `xml:"message,attr,omitempty"`
+}
+
+// WriteJUnit writes r as JUnit XML: one <testsuite> per exercise and one
+// <testcase> per test and subtest. An exercise that doesn't compile gets
+// a single errored "build" test case carrying the compiler output.
+func WriteJUnit(w io.Writer, r Report) error {
+	suites := junitSuites{}
+	for _, ex := range r.Exercises {
+		s := junitSuite{
+			Name: ex.Package,
+			Time: seconds(ex.Elapsed),
+		}
+		if s.Name == "" {
+			s.Name = ex.ID
+		}
+		if !r.Generated.IsZero() {
+			s.Timestamp = r.Generated.UTC().Format("2006-01-02T15:04:05")
+		}
+
+		if ex.BuildFailed {
+			s.Errors = 1
+			s.Cases = append(s.Cases, junitCase{
+				Name:      "build",
+				Classname: s.Name,
+				Time:      seconds(0),
+				Error:     &junitProblem{Message: "build failed", Body: strings.Join(ex.BuildOutput, "\n")},
+			})
+		}
+		for _, t := range ex.Tests {
+			c := junitCase{Name: t.Name, Classname: s.Name, Time: seconds(t.Elapsed)}
+			switch {
+			case t.Aborted != "":
+				// It didn't fail an assertion; it never finished.
+				c.Error = &junitProblem{Message: t.Aborted, Body: strings.Join(t.Output, "\n")}
+				s.Errors++
+			case t.Status == "fail":
+				c.Failure = &junitProblem{Message: "Failed", Body: strings.Join(t.Output, "\n")}
+				s.Failures++
+			case t.Status == "skip":
+				c.Skipped = &junitSkipped{Message: strings.TrimSpace(strings.Join(t.Output, " "))}
+				s.Skipped++
+			}
+			s.Cases = append(s.Cases, c)
+		}
+		s.Tests = len(s.Cases)
+
+		suites.Tests += s.Tests
+		suites.Failures += s.Failures
+		suites.Errors += s.Errors
+		suites.Skipped += s.Skipped
+		suites.Suites = append(suites.Suites, s)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(suites); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func seconds(s float64) string {
+	return fmt.Sprintf("%.3f", s)
+}
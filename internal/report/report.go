@@ -0,0 +1,187 @@
+// Package report turns test results into formats other tools understand:
+// JSON for dashboards and scripts, JUnit XML for CI servers and LMS
+// autograders (GitHub Classroom, GitLab, Jenkins all read it), and TAP
+// for anything in the node-tap world.
+package report
+
+import (
+	"encoding/json"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/imgarylai/learn-go/internal/grade"
+	"github.com/imgarylai/learn-go/internal/registry"
+	"github.com/imgarylai/learn-go/internal/runner"
+)
+
+// Report is the result of testing a set of exercises.
+type Report struct {
+	Generated time.Time  `json:"generated"`
+	Summary   Summary    `json:"summary"`
+	Exercises []Exercise `json:"exercises"`
+}
+
+// Summary counts top-level tests across all exercises.
+type Summary struct {
+	Exercises int `json:"exercises"`
+	Passed    int `json:"passed"`
+	Failed    int `json:"failed"`
+	Skipped   int `json:"skipped"`
+
+	Score    float64 `json:"score"`
+	MaxScore float64 `json:"max_score"`
+}
+
+// Exercise is the result for one exercise package.
+type Exercise struct {
+	ID          string   `json:"id"`
+	Title       string   `json:"title"`
+	Package     string   `json:"package"`
+	BuildFailed bool     `json:"build_failed,omitempty"`
+	BuildOutput []string `json:"build_output,omitempty"`
+	Elapsed     float64  `json:"elapsed_seconds"`
+	Passed      int      `json:"passed"`
+	Failed      int      `json:"failed"`
+	Skipped     int      `json:"skipped"`
+	Tests       []Test   `json:"tests"`
+	// Aborted is "timed out" or "out of memory" when the tests were
+	// stopped at a limit (see runner.Limits).
+	Aborted string `json:"aborted,omitempty"`
+
+	// Only with `learngo report --cover`.
+	Coverage *Coverage `json:"coverage,omitempty"`
+
+	// Partial credit: see package grade.
+	Score    float64      `json:"score"`
+	MaxScore float64      `json:"max_score"`
+	Rubric   []grade.Item `json:"rubric"`
+}
+
+// Test is one test or subtest ("TestSum/empty").
+type Test struct {
+	Name    string   `json:"name"`
+	Status  string   `json:"status"` // pass, fail or skip
+	Elapsed float64  `json:"elapsed_seconds"`
+	Output  []string `json:"output,omitempty"`
+	Aborted string   `json:"aborted,omitempty"` // it never finished, see Exercise.Aborted
+	// Hidden marks an instructor's hidden test. Its output is left out,
+	// since the failure messages would give the test away.
+	Hidden bool `json:"hidden,omitempty"`
+}
+
+// Coverage is how much of an exercise's code its tests ran.
+type Coverage struct {
+	Total     float64        `json:"total_percent"`
+	Functions []FuncCoverage `json:"functions"`
+}
+
+// FuncCoverage is the share of one function's statements that ran.
+type FuncCoverage struct {
+	Func    string  `json:"func"`
+	File    string  `json:"file"`
+	Line    int     `json:"line"`
+	Percent float64 `json:"percent"`
+}
+
+// New builds a Report. results[i] belongs to exercises[i].
+//
+// root is the repository root. The rubric lists every test found in the
+// exercise's source there, so a build failure still shows the points it
+// cost; with root == "" only the tests that ran are graded.
+//
+// hidden names, by exercise ID, the top-level tests that came from the
+// instructor's hidden set (see package hidden). They're graded with the
+// rest, into the same score, and also count when they never ran.
+func New(root string, exercises []registry.Exercise, results []runner.Result, hidden map[string][]string, generated time.Time) Report {
+	r := Report{Generated: generated, Exercises: make([]Exercise, 0, len(exercises))}
+	for i, e := range exercises {
+		res := results[i]
+		pass, fail, skip := res.Counts()
+		ex := Exercise{
+			ID:          e.ID,
+			Title:       e.Title,
+			Package:     res.Package,
+			BuildFailed: res.BuildFailed,
+			BuildOutput: res.BuildOutput,
+			Elapsed:     res.Elapsed.Seconds(),
+			Passed:      pass,
+			Failed:      fail,
+			Skipped:     skip,
+			Tests:       make([]Test, 0, len(res.Tests)),
+			Aborted:     string(res.Aborted),
+		}
+		isHidden := func(name string) bool {
+			top, _, _ := strings.Cut(name, "/")
+			return slices.Contains(hidden[e.ID], top)
+		}
+		for _, t := range res.Tests {
+			test := Test{
+				Name:    t.Name,
+				Status:  string(t.Status),
+				Elapsed: t.Elapsed.Seconds(),
+				Output:  t.Output,
+				Aborted: string(t.Aborted),
+				Hidden:  isHidden(t.Name),
+			}
+			if test.Hidden {
+				test.Output = nil
+			}
+			ex.Tests = append(ex.Tests, test)
+		}
+
+		if c := res.Coverage; c != nil {
+			ex.Coverage = &Coverage{Total: c.Total, Functions: make([]FuncCoverage, 0, len(c.Funcs))}
+			for _, f := range c.Funcs {
+				ex.Coverage.Functions = append(ex.Coverage.Functions, FuncCoverage(f))
+			}
+		}
+
+		var tests []string
+		if root != "" {
+			// A missing or unreadable directory just falls back to the tests that ran.
+			tests, _ = grade.TestNames(e.DirIn(root))
+		}
+		if len(hidden[e.ID]) > 0 {
+			if tests == nil {
+				for _, t := range res.Tests {
+					if !strings.Contains(t.Name, "/") && !isHidden(t.Name) {
+						tests = append(tests, t.Name)
+					}
+				}
+			}
+			tests = append(tests, hidden[e.ID]...)
+		}
+		rubric := grade.Grade(e, res, tests)
+		for i := range rubric.Items {
+			rubric.Items[i].Hidden = isHidden(rubric.Items[i].Test)
+		}
+		ex.Score, ex.MaxScore, ex.Rubric = rubric.Score, rubric.Max, rubric.Items
+
+		r.Exercises = append(r.Exercises, ex)
+		r.Summary.Exercises++
+		r.Summary.Passed += pass
+		r.Summary.Failed += fail
+		r.Summary.Skipped += skip
+		r.Summary.Score += ex.Score
+		r.Summary.MaxScore += ex.MaxScore
+	}
+	return r
+}
+
+// Formats maps each output format, as `learngo report --format` takes
+// it, to its writer.
+var Formats = map[string]func(io.Writer, Report) error{
+	"json":   WriteJSON,
+	"junit":  WriteJUnit,
+	"tap":    WriteTAP,
+	"rubric": WriteRubric,
+}
+
+// WriteJSON writes r as indented JSON.
+func WriteJSON(w io.Writer, r Report) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
@@ -0,0 +1,245 @@
+package report
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/imgarylai/learn-go/internal/registry"
+	"github.com/imgarylai/learn-go/internal/runner"
+)
+
+func sampleReport() Report {
+	exercises := []registry.Exercise{
+		{ID: "04-collections", Title: "Collections", Weights: map[string]float64{"TestSum": 2}},
+		{ID: "05-interfaces", Title: "Interfaces"},
+	}
+	results := []runner.Result{
+		{
+			Package: "github.com/imgarylai/learn-go/exercises/04-collections",
+			Elapsed: 1500 * time.Millisecond,
+			Tests: []runner.Test{
+				{Name: "TestSum", Status: runner.Pass},
+				{Name: "TestMax", Status: runner.Fail, Output: []string{"got 0, want 9 <&>"}},
+				{Name: "TestMax/empty", Status: runner.Fail},
+				{Name: "TestLater", Status: runner.Skip, Output: []string{"not yet"}},
+			},
+			Coverage: &runner.Coverage{Total: 75, Funcs: []runner.FuncCoverage{
+				{Func: "Sum", File: "github.com/imgarylai/learn-go/exercises/04-collections/collections.go", Line: 5, Percent: 100},
+				{Func: "Max", File: "github.com/imgarylai/learn-go/exercises/04-collections/collections.go", Line: 14, Percent: 50},
+			}},
+		},
+		{BuildFailed: true, BuildOutput: []string{"./interfaces.go:3:1: syntax error"}},
+	}
+	return New("", exercises, results, nil, time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC))
+}
+
+func TestNew(t *testing.T) {
+	r := sampleReport()
+	want := Summary{Exercises: 2, Passed: 1, Failed: 1, Skipped: 1, Score: 2, MaxScore: 4}
+	if r.Summary != want {
+		t.Errorf("summary: got %+v, want %+v", r.Summary, want)
+	}
+	if len(r.Exercises[0].Tests) != 4 {
+		t.Errorf("tests: got %d, want 4 (subtests included)", len(r.Exercises[0].Tests))
+	}
+	if !r.Exercises[1].BuildFailed {
+		t.Error("05-interfaces should be marked as a build failure")
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	var b strings.Builder
+	if err := WriteJSON(&b, sampleReport()); err != nil {
+		t.Fatal(err)
+	}
+
+	var back Report
+	if err := json.Unmarshal([]byte(b.String()), &back); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, b.String())
+	}
+	if c := back.Exercises[0].Coverage; c == nil || c.Total != 75 || c.Functions[1].Func != "Max" {
+		t.Errorf("coverage: got %+v", c)
+	}
+	if back.Exercises[1].Coverage != nil {
+		t.Error("an exercise without coverage shouldn't have a coverage field")
+	}
+	if back.Exercises[0].Tests[1].Status != "fail" || back.Exercises[0].Elapsed != 1.5 {
+		t.Errorf("round trip lost data: %+v", back.Exercises[0])
+	}
+}
+
+func TestWriteJUnit(t *testing.T) {
+	var b strings.Builder
+	if err := WriteJUnit(&b, sampleReport()); err != nil {
+		t.Fatal(err)
+	}
+	out := b.String()
+
+	var suites junitSuites
+	if err := xml.Unmarshal([]byte(out), &suites); err != nil {
+		t.Fatalf("invalid XML: %v\n%s", err, out)
+	}
+	if suites.Tests != 5 || suites.Failures != 2 || suites.Errors != 1 || suites.Skipped != 1 {
+		t.Errorf("totals: got tests=%d failures=%d errors=%d skipped=%d",
+			suites.Tests, suites.Failures, suites.Errors, suites.Skipped)
+	}
+
+	first := suites.Suites[0]
+	if first.Name != "github.com/imgarylai/learn-go/exercises/04-collections" || first.Time != "1.500" {
+		t.Errorf("suite attributes: %+v", first)
+	}
+	if f := first.Cases[1].Failure; f == nil || f.Body != "got 0, want 9 <&>" {
+		t.Errorf("failure body: %+v", f)
+	}
+	if suites.Suites[1].Name != "05-interfaces" || suites.Suites[1].Cases[0].Error == nil {
+		t.Errorf("build failure suite: %+v", suites.Suites[1])
+	}
+}
+
+func TestRubricListsTestsThatNeverRan(t *testing.T) {
+	e, _ := registry.Lookup("05-interfaces")
+	r := New("../..", []registry.Exercise{e}, []runner.Result{{BuildFailed: true}}, nil, time.Time{})
+
+	ex := r.Exercises[0]
+	if ex.Score != 0 || ex.MaxScore == 0 || len(ex.Rubric) == 0 {
+		t.Errorf("a build failure should score 0 out of every test in the source: %+v", ex)
+	}
+}
+
+func TestWriteRubric(t *testing.T) {
+	var b strings.Builder
+	if err := WriteRubric(&b, sampleReport()); err != nil {
+		t.Fatal(err)
+	}
+	out := b.String()
+	for _, want := range []string{"04-collections", "2/4", "50.0%", "TestSum", "2/2", "TestMax", "0/1", "build failed", "total",
+		"coverage", "75.0%", "Max (collections.go:14)"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "Sum (") {
+		t.Errorf("fully covered functions shouldn't be listed:\n%s", out)
+	}
+}
+
+func TestWriteTAP(t *testing.T) {
+	var b strings.Builder
+	if err := WriteTAP(&b, sampleReport()); err != nil {
+		t.Fatal(err)
+	}
+	out := b.String()
+	for _, want := range []string{
+		"TAP version 14\n1..2\n",
+		"# Subtest: 04-collections\n    1..3\n",
+		"    ok 1 - TestSum (2/2 points)\n",
+		"    not ok 2 - TestMax (0/1 points)\n      ---\n      message: \"Failed\"\n      output: |\n        got 0, want 9 <&>\n      ...\n",
+		"    ok 3 - TestLater (0/1 points) # SKIP not yet\n",
+		"not ok 1 - 04-collections (2/4 points)\n",
+		"not ok 2 - 05-interfaces (0/0 points)\n  ---\n  message: \"build failed\"\n  output: |\n    ./interfaces.go:3:1: syntax error\n  ...\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in:\n%s", want, out)
+		}
+	}
+}
+
+// go test indents test output, and a YAML block whose first line is
+// indented more than the rest would end early.
+func TestTAPOutputIndentation(t *testing.T) {
+	var b strings.Builder
+	yamlBlock(&b, "  ", "Failed", []string{"    max_test.go:9: got 0", "        diff:", "    done  "})
+	want := "  ---\n  message: \"Failed\"\n  output: |\n    max_test.go:9: got 0\n        diff:\n    done\n  ...\n"
+	if b.String() != want {
+		t.Errorf("got\n%s\nwant\n%s", b.String(), want)
+	}
+
+	b.Reset()
+	yamlBlock(&b, "", "Failed", []string{"      first", "    second"})
+	if want := "output: |2\n    first\n  second\n"; !strings.Contains(b.String(), want) {
+		t.Errorf("got\n%s\nwant %q", b.String(), want)
+	}
+}
+
+func TestFormats(t *testing.T) {
+	for name, write := range Formats {
+		var b strings.Builder
+		if err := write(&b, sampleReport()); err != nil || b.Len() == 0 {
+			t.Errorf("%s: %d bytes, %v", name, b.Len(), err)
+		}
+	}
+}
+
+func TestAbortedTests(t *testing.T) {
+	res := runner.Result{
+		Aborted: runner.TimedOut,
+		Tests: []runner.Test{
+			{Name: "TestSum", Status: runner.Pass},
+			{Name: "TestLoop", Status: runner.Fail, Aborted: runner.TimedOut, Output: []string{"panic: test timed out after 2m0s"}},
+		},
+	}
+	r := New("", []registry.Exercise{{ID: "06-concurrency"}}, []runner.Result{res}, nil, time.Time{})
+	if ex := r.Exercises[0]; ex.Aborted != "timed out" || ex.Tests[1].Aborted != "timed out" || ex.Tests[0].Aborted != "" {
+		t.Errorf("got %+v", ex)
+	}
+
+	var junit strings.Builder
+	if err := WriteJUnit(&junit, r); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(junit.String(), `errors="1"`) || !strings.Contains(junit.String(), `<error message="timed out">`) {
+		t.Errorf("a test stopped at the time limit should be an error, not a failure:\n%s", junit.String())
+	}
+	var tap strings.Builder
+	if err := WriteTAP(&tap, r); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(tap.String(), "not ok 2 - TestLoop (0/1 points)\n      ---\n      message: \"timed out\"") {
+		t.Errorf("TAP:\n%s", tap.String())
+	}
+	var rubric strings.Builder
+	if err := WriteRubric(&rubric, r); err != nil {
+		t.Fatal(err)
+	}
+	if !regexp.MustCompile(`TestLoop\s+timed out\s+0/1`).MatchString(rubric.String()) {
+		t.Errorf("rubric:\n%s", rubric.String())
+	}
+}
+
+func TestHiddenTests(t *testing.T) {
+	res := runner.Result{Tests: []runner.Test{
+		{Name: "TestSum", Status: runner.Pass},
+		{Name: "TestSumOverflow", Status: runner.Fail, Output: []string{"sum_test.go:9: Sum([MaxInt 1]) = MinInt"}},
+		{Name: "TestSumOverflow/two", Status: runner.Fail, Output: []string{"want 0"}},
+	}}
+	hidden := map[string][]string{"04-collections": {"TestSumOverflow", "TestSumNil"}}
+	r := New("", []registry.Exercise{{ID: "04-collections"}}, []runner.Result{res}, hidden, time.Time{})
+	ex := r.Exercises[0]
+
+	// TestSumNil didn't run, but still counts against the score.
+	if ex.Score != 1 || ex.MaxScore != 3 {
+		t.Errorf("score %v/%v, want 1/3", ex.Score, ex.MaxScore)
+	}
+	for _, it := range ex.Rubric {
+		if it.Hidden != (it.Test != "TestSum") {
+			t.Errorf("%s: hidden = %v", it.Test, it.Hidden)
+		}
+	}
+	for _, test := range ex.Tests[1:] {
+		if !test.Hidden || test.Output != nil {
+			t.Errorf("%s: hidden %v, output %q", test.Name, test.Hidden, test.Output)
+		}
+	}
+
+	var rubric strings.Builder
+	if err := WriteRubric(&rubric, r); err != nil {
+		t.Fatal(err)
+	}
+	if !regexp.MustCompile(`TestSumNil \(hidden\)\s+fail\s+0/1`).MatchString(rubric.String()) {
+		t.Errorf("rubric:\n%s", rubric.String())
+	}
+}
@@ -0,0 +1,57 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"text/tabwriter"
+)
+
+// WriteRubric writes r as a plain-text rubric: one block per exercise
+// with the points each test earned, then the overall score. It's meant
+// for handing back to students alongside their grade. With coverage, it
+// also lists the functions the tests didn't fully run.
+func WriteRubric(w io.Writer, r Report) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	for _, ex := range r.Exercises {
+		fmt.Fprintf(tw, "%s\t\t%s/%s\t%s\n", ex.ID, points(ex.Score), points(ex.MaxScore), percent(ex.Score, ex.MaxScore))
+		if ex.BuildFailed {
+			fmt.Fprintf(tw, "  (build failed: no tests ran)\t\t\t\n")
+		}
+		for _, it := range ex.Rubric {
+			status := string(it.Status)
+			if a := aborted(ex, it.Test); a != "" {
+				status = a
+			}
+			name := it.Test
+			if it.Hidden {
+				name += " (hidden)"
+			}
+			fmt.Fprintf(tw, "  %s\t%s\t%s/%s\t\n", name, status, points(it.Earned), points(it.Points))
+		}
+		if c := ex.Coverage; c != nil {
+			fmt.Fprintf(tw, "  coverage\t%.1f%%\t\t\n", c.Total)
+			for _, f := range c.Functions {
+				if f.Percent < 100 {
+					fmt.Fprintf(tw, "    %s (%s:%d)\t%.1f%%\t\t\n", f.Func, path.Base(f.File), f.Line, f.Percent)
+				}
+			}
+		}
+	}
+	fmt.Fprintf(tw, "total\t\t%s/%s\t%s\n", points(r.Summary.Score), points(r.Summary.MaxScore),
+		percent(r.Summary.Score, r.Summary.MaxScore))
+	return tw.Flush()
+}
+
+// points prints 2 as "2" and 1.5 as "1.5".
+func points(p float64) string {
+	return strconv.FormatFloat(p, 'f', -1, 64)
+}
+
+func percent(score, max float64) string {
+	if max == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.1f%%", 100*score/max)
+}
@@ -0,0 +1,118 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/imgarylai/learn-go/internal/runner"
+)
+
+// WriteTAP writes r as TAP version 14, the Test Anything Protocol that
+// node-tap and tape print: one subtest per exercise, holding one test
+// point per rubric item with the points it earned, so a TAP consumer
+// sees the grade and not just pass/fail. A failing point carries a YAML
+// block with its output; an exercise that doesn't compile carries the
+// compiler's.
+func WriteTAP(w io.Writer, r Report) error {
+	b := &strings.Builder{}
+	fmt.Fprintf(b, "TAP version 14\n1..%d\n", len(r.Exercises))
+	for i, ex := range r.Exercises {
+		fmt.Fprintf(b, "# Subtest: %s\n", ex.ID)
+		fmt.Fprintf(b, "    1..%d\n", len(ex.Rubric))
+		passed := !ex.BuildFailed
+		for j, it := range ex.Rubric {
+			name := fmt.Sprintf("%s (%s/%s points)", it.Test, points(it.Earned), points(it.Points))
+			switch it.Status {
+			case runner.Skip:
+				reason := strings.TrimSpace(strings.Join(output(ex, it.Test), " "))
+				fmt.Fprintf(b, "    ok %d - %s # SKIP %s\n", j+1, name, reason)
+			case runner.Fail:
+				passed = false
+				fmt.Fprintf(b, "    not ok %d - %s\n", j+1, name)
+				message := "Failed"
+				if a := aborted(ex, it.Test); a != "" {
+					message = a
+				}
+				if out := output(ex, it.Test); len(out) > 0 || message != "Failed" {
+					yamlBlock(b, "      ", message, out)
+				}
+			default:
+				fmt.Fprintf(b, "    ok %d - %s\n", j+1, name)
+			}
+		}
+		result := "ok"
+		if !passed {
+			result = "not ok"
+		}
+		fmt.Fprintf(b, "%s %d - %s (%s/%s points)\n", result, i+1, ex.ID, points(ex.Score), points(ex.MaxScore))
+		if ex.BuildFailed {
+			yamlBlock(b, "  ", "build failed", ex.BuildOutput)
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// output is what test printed, subtests included.
+func output(ex Exercise, test string) []string {
+	var out []string
+	for _, t := range ex.Tests {
+		if t.Name == test || strings.HasPrefix(t.Name, test+"/") {
+			out = append(out, t.Output...)
+		}
+	}
+	return out
+}
+
+// aborted is why test was stopped, "" if it wasn't.
+func aborted(ex Exercise, test string) string {
+	for _, t := range ex.Tests {
+		if t.Name == test {
+			return t.Aborted
+		}
+	}
+	return ""
+}
+
+// yamlBlock writes TAP's YAML diagnostics, with lines as a literal
+// block so nothing in them needs escaping. go test indents what a test
+// prints; the common indentation is dropped.
+func yamlBlock(b *strings.Builder, indent, message string, lines []string) {
+	fmt.Fprintf(b, "%s---\n%smessage: %q\n", indent, indent, message)
+	if len(lines) > 0 {
+		lines = dedent(lines)
+		header := "|"
+		if strings.HasPrefix(lines[0], " ") || strings.HasPrefix(lines[0], "\t") {
+			header = "|2" // YAML would take the first line's indentation for the block's
+		}
+		fmt.Fprintf(b, "%soutput: %s\n", indent, header)
+		for _, l := range lines {
+			fmt.Fprintf(b, "%s  %s\n", indent, l)
+		}
+	}
+	fmt.Fprintf(b, "%s...\n", indent)
+}
+
+// dedent trims trailing space from lines and removes the leading
+// whitespace they all share.
+func dedent(lines []string) []string {
+	out := make([]string, len(lines))
+	common := -1
+	for i, l := range lines {
+		out[i] = strings.TrimRight(l, " \t")
+		if out[i] == "" {
+			continue
+		}
+		n := len(out[i]) - len(strings.TrimLeft(out[i], " \t"))
+		if common < 0 || n < common {
+			common = n
+		}
+	}
+	for i, l := range out {
+		if l != "" {
+			out[i] = l[common:]
+		}
+	}
+	return out
+}
@@ -0,0 +1,103 @@
+package runner
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// Coverage says how much of each function the tests ran, from `go test
+// -coverprofile`, like the per-file table of `jest --coverage`. A test
+// can pass without running all of the code it's meant to check: a
+// branch it never reaches is a branch nobody knows works.
+type Coverage struct {
+	Funcs []FuncCoverage
+	Total float64 // percent of all statements
+}
+
+// FuncCoverage is the share of one function's statements that ran.
+type FuncCoverage struct {
+	Func    string
+	File    string // import path and file name, as go tool cover prints it
+	Line    int
+	Percent float64
+}
+
+// Uncovered lists the functions that didn't run in full, in file order.
+func (c *Coverage) Uncovered() []FuncCoverage {
+	var out []FuncCoverage
+	for _, f := range c.Funcs {
+		if f.Percent < 100 {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+// Where is "file.go:12", without the import path.
+func (f FuncCoverage) Where() string {
+	return path.Base(f.File) + ":" + strconv.Itoa(f.Line)
+}
+
+// CoverArgs are the `go test` flags that write a coverage profile to
+// profile. Pass them to Run, then the profile to ReadCoverage.
+func CoverArgs(profile string) []string {
+	return []string{"-coverprofile=" + profile}
+}
+
+// ReadCoverage summarizes a coverage profile per function with `go
+// tool cover -func`, which needs the source, so it runs where Run ran
+// the tests for dir.
+func ReadCoverage(ctx context.Context, root, dir, profile string) (*Coverage, error) {
+	wd, _ := packageDir(root, dir)
+	cmd := exec.CommandContext(ctx, "go", "tool", "cover", "-func="+profile)
+	cmd.Dir = wd
+	out, err := cmd.Output()
+	var exit *exec.ExitError
+	if errors.As(err, &exit) {
+		return nil, fmt.Errorf("go tool cover failed:\n%s", strings.TrimSpace(string(exit.Stderr)))
+	}
+	if err != nil {
+		return nil, err
+	}
+	return ParseCoverage(strings.NewReader(string(out)))
+}
+
+// ParseCoverage reads `go tool cover -func` output:
+//
+//	example.com/fx/sum.go:12:	Sum		85.7%
+//	total:				(statements)	85.7%
+func ParseCoverage(r io.Reader) (*Coverage, error) {
+	c := &Coverage{}
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		fields := strings.Fields(sc.Text())
+		if len(fields) != 3 || !strings.HasSuffix(fields[2], "%") {
+			continue
+		}
+		pct, err := strconv.ParseFloat(strings.TrimSuffix(fields[2], "%"), 64)
+		if err != nil {
+			return nil, fmt.Errorf("bad coverage line %q", sc.Text())
+		}
+		if fields[0] == "total:" {
+			c.Total = pct
+			continue
+		}
+		file, line, ok := strings.Cut(strings.TrimSuffix(fields[0], ":"), ":")
+		if !ok {
+			return nil, fmt.Errorf("bad coverage line %q", sc.Text())
+		}
+		n, err := strconv.Atoi(line)
+		if err != nil {
+			return nil, fmt.Errorf("bad coverage line %q", sc.Text())
+		}
+		c.Funcs = append(c.Funcs, FuncCoverage{Func: fields[1], File: file, Line: n, Percent: pct})
+	}
+	return c, sc.Err()
+}
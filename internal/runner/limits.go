@@ -0,0 +1,117 @@
+package runner
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Limits stop a test binary that runs away, so an infinite loop or an
+// ever-growing slice fails its own exercise instead of hanging the
+// whole run: the test equivalent of jest's --testTimeout.
+type Limits struct {
+	// Timeout is how long each package's tests may take, go test's
+	// -timeout. 0 means go test's default, 10 minutes.
+	Timeout time.Duration
+	// Memory is how many bytes the test binary may allocate; 0 means no
+	// limit. It's enforced with `ulimit -d`, so only on Unix.
+	Memory int64
+}
+
+// DefaultLimits are generous for the exercises' tests, which take
+// seconds and megabytes when they work.
+var DefaultLimits = Limits{Timeout: 2 * time.Minute, Memory: 2 << 30}
+
+// LimitsFromEnv returns DefaultLimits, overridden by LEARNGO_TIMEOUT (a
+// duration, "30s") and LEARNGO_MEMORY (bytes with an optional KiB, MiB
+// or GiB suffix, as in GOMEMLIMIT: "512MiB"). 0 turns a limit off.
+func LimitsFromEnv() (Limits, error) {
+	l := DefaultLimits
+	if env := os.Getenv("LEARNGO_TIMEOUT"); env != "" {
+		d, err := time.ParseDuration(env)
+		if err != nil {
+			return l, fmt.Errorf("LEARNGO_TIMEOUT: %w", err)
+		}
+		l.Timeout = d
+	}
+	if env := os.Getenv("LEARNGO_MEMORY"); env != "" {
+		n, err := parseBytes(env)
+		if err != nil {
+			return l, fmt.Errorf("LEARNGO_MEMORY: %w", err)
+		}
+		l.Memory = n
+	}
+	return l, nil
+}
+
+func parseBytes(s string) (int64, error) {
+	units := []struct {
+		suffix string
+		size   int64
+	}{{"KiB", 1 << 10}, {"MiB", 1 << 20}, {"GiB", 1 << 30}, {"TiB", 1 << 40}, {"B", 1}}
+	num, size := s, int64(1)
+	for _, u := range units {
+		if n, ok := strings.CutSuffix(s, u.suffix); ok {
+			num, size = n, u.size
+			break
+		}
+	}
+	n, err := strconv.ParseInt(strings.TrimSpace(num), 10, 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("%q isn't a size like 512MiB", s)
+	}
+	return n * size, nil
+}
+
+// Args are the `go test` flags that apply l. Pass them to Run before
+// any of your own, which take precedence.
+func (l Limits) Args() []string {
+	var args []string
+	if l.Timeout > 0 {
+		args = append(args, "-timeout="+l.Timeout.String())
+	}
+	if l.Memory > 0 && runtime.GOOS != "windows" {
+		// go test -exec runs the test binary through sh, which lowers
+		// its data segment limit first; go adds the binary and its
+		// flags as "$@". A limit on the address space (ulimit -v) would
+		// be simpler, but the Go runtime reserves far more of that than
+		// it uses and wouldn't even start.
+		kib := max(l.Memory>>10, 1)
+		args = append(args, fmt.Sprintf(`-exec=sh -c 'ulimit -d %d && exec "$@"' learngo-limit`, kib))
+	}
+	return args
+}
+
+// Abort says why a test binary was stopped before its tests finished.
+type Abort string
+
+const (
+	TimedOut    Abort = "timed out"
+	OutOfMemory Abort = "out of memory"
+)
+
+// aborts maps what the test binary prints when it's stopped to why.
+var aborts = []struct {
+	prefix string
+	abort  Abort
+}{
+	{"panic: test timed out after", TimedOut},
+	{"fatal error: runtime: out of memory", OutOfMemory},
+}
+
+// abortIn returns why output shows the binary being stopped, and where
+// that message starts. What follows is a dump of every goroutine's
+// stack, which helps nobody learning Go.
+func abortIn(output []string) (Abort, int) {
+	for i, line := range output {
+		for _, a := range aborts {
+			if strings.HasPrefix(strings.TrimSpace(line), a.prefix) {
+				return a.abort, i
+			}
+		}
+	}
+	return "", -1
+}
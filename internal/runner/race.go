@@ -0,0 +1,115 @@
+package runner
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Race is one report from the race detector (`go test -race`): two
+// goroutines touched the same memory, at least one of them writing,
+// with nothing ordering the two accesses.
+type Race struct {
+	Test       string   // the test that was running; empty if none was
+	Accesses   []Access // the conflicting accesses, latest first
+	Goroutines []Goroutine
+}
+
+// Access is one side of a race: what a goroutine did and where.
+type Access struct {
+	Op        string // "Read", "Write", "Previous write"...
+	Goroutine int
+	Stack     []Frame // innermost call first
+}
+
+// Goroutine says where one of the goroutines in a race was started.
+type Goroutine struct {
+	ID      int
+	State   string // "running" or "finished"
+	Created []Frame
+}
+
+// Frame is one line of a stack trace.
+type Frame struct {
+	Func string // e.g. "example.com/fx.(*Counter).Inc"
+	File string
+	Line int
+}
+
+// Std reports whether f is in the standard library (runtime, testing,
+// sync...) rather than in the code under test. Standard import paths
+// have no dot in their first element; module paths do.
+func (f Frame) Std() bool {
+	pkg := f.Func
+	if i := strings.LastIndex(pkg, "/"); i >= 0 {
+		pkg = pkg[:i]
+	} else if i := strings.Index(pkg, "."); i >= 0 {
+		pkg = pkg[:i]
+	}
+	first, _, _ := strings.Cut(pkg, "/")
+	return !strings.Contains(first, ".")
+}
+
+// Name is the function without its package path: "(*Counter).Inc".
+func (f Frame) Name() string {
+	name := f.Func[strings.LastIndex(f.Func, "/")+1:]
+	if _, rest, ok := strings.Cut(name, "."); ok {
+		return rest
+	}
+	return name
+}
+
+const (
+	raceStart = "WARNING: DATA RACE"
+	raceEnd   = "=================="
+)
+
+var (
+	// "Read at 0x00c0000182b8 by goroutine 9:", optionally followed by
+	// the mutexes held: "by goroutine 7 (mutexes: write M1):".
+	accessLine    = regexp.MustCompile(`^(\w[\w ]*) at 0x[0-9a-f]+ by (?:main goroutine|goroutine (\d+))(?: \(.*\))?:$`)
+	goroutineLine = regexp.MustCompile(`^Goroutine (\d+) \((\w+)\) created at:$`)
+	// "      /home/gopher/fx/counter.go:7 +0x7e"
+	fileLine = regexp.MustCompile(`^\s+(.+):(\d+)(?: \+0x[0-9a-f]+)?$`)
+)
+
+// parseRaces finds the race reports in the lines a test printed.
+func parseRaces(test string, lines []string) []Race {
+	var races []Race
+	var cur *Race
+	var stack *[]Frame
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == raceStart:
+			races = append(races, Race{Test: test})
+			cur, stack = &races[len(races)-1], nil
+		case cur == nil:
+		case trimmed == raceEnd:
+			cur, stack = nil, nil
+		case accessLine.MatchString(trimmed):
+			m := accessLine.FindStringSubmatch(trimmed)
+			id := 1 // the main goroutine
+			if m[2] != "" {
+				id, _ = strconv.Atoi(m[2])
+			}
+			cur.Accesses = append(cur.Accesses, Access{Op: m[1], Goroutine: id})
+			stack = &cur.Accesses[len(cur.Accesses)-1].Stack
+		case goroutineLine.MatchString(trimmed):
+			m := goroutineLine.FindStringSubmatch(trimmed)
+			id, _ := strconv.Atoi(m[1])
+			cur.Goroutines = append(cur.Goroutines, Goroutine{ID: id, State: m[2]})
+			stack = &cur.Goroutines[len(cur.Goroutines)-1].Created
+		case stack == nil || trimmed == "":
+		case fileLine.MatchString(line) && len(*stack) > 0 && (*stack)[len(*stack)-1].File == "":
+			m := fileLine.FindStringSubmatch(line)
+			f := &(*stack)[len(*stack)-1]
+			f.File = m[1]
+			f.Line, _ = strconv.Atoi(m[2])
+		default:
+			// A function line: "  example.com/fx.(*Counter).Inc()".
+			*stack = append(*stack, Frame{Func: strings.TrimSuffix(trimmed, "()")})
+		}
+	}
+	return races
+}
@@ -0,0 +1,302 @@
+// Package runner runs `go test` for an exercise and turns its output
+// into structured results.
+//
+// We ask `go test` for -json output (one event per line, see
+// `go doc test2json`) instead of scraping the human-readable text.
+// Think of it like jest's --json reporter.
+package runner
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Status is the outcome of one test.
+type Status string
+
+const (
+	Pass Status = "pass"
+	Fail Status = "fail"
+	Skip Status = "skip"
+)
+
+// Test is the result of a single test function (or subtest).
+type Test struct {
+	Name    string
+	Status  Status
+	Elapsed time.Duration
+	Output  []string // lines printed while the test ran
+	// Aborted is set on the tests that were running when the test
+	// binary was stopped: they didn't fail, they never finished.
+	Aborted Abort
+}
+
+// Result is everything we learned from one `go test` run.
+type Result struct {
+	Package     string
+	Tests       []Test
+	BuildFailed bool
+	BuildOutput []string // compiler errors when BuildFailed is set
+	Elapsed     time.Duration
+	// Aborted is why a test binary was stopped (see Limits), "" if
+	// every one ran to the end.
+	Aborted Abort
+	// Races are the data races found when the tests ran with -race.
+	Races []Race
+	// Coverage is nil unless the caller ran with CoverArgs and filled
+	// it in with ReadCoverage.
+	Coverage *Coverage
+}
+
+// Counts tallies the top-level tests. Subtests are left out so a table
+// test with 10 cases still counts as one test, matching the exercise
+// numbering in the stub files.
+func (r Result) Counts() (pass, fail, skip int) {
+	for _, t := range r.Tests {
+		if strings.Contains(t.Name, "/") {
+			continue
+		}
+		switch t.Status {
+		case Pass:
+			pass++
+		case Fail:
+			fail++
+		case Skip:
+			skip++
+		}
+	}
+	return pass, fail, skip
+}
+
+// Failed returns the names of the failing top-level tests, in run order.
+func (r Result) Failed() []string {
+	var names []string
+	for _, t := range r.Tests {
+		if t.Status == Fail && !strings.Contains(t.Name, "/") {
+			names = append(names, t.Name)
+		}
+	}
+	return names
+}
+
+// Passed returns the names of the passing top-level tests, in run order.
+func (r Result) Passed() []string {
+	var names []string
+	for _, t := range r.Tests {
+		if t.Status == Pass && !strings.Contains(t.Name, "/") {
+			names = append(names, t.Name)
+		}
+	}
+	return names
+}
+
+// OK reports whether the package built and no test failed.
+func (r Result) OK() bool {
+	_, fail, _ := r.Counts()
+	return !r.BuildFailed && fail == 0
+}
+
+// Run executes `go test -json` for the packages in dir and below
+// (relative to root, or absolute) and parses the result. Most exercises
+// are a single package; a larger one can split into subpackages.
+// extraArgs are passed to `go test` before the package path, e.g.
+// "-run", "TestSum".
+//
+// A failing test is not an error: it shows up in the Result. Run only
+// returns an error when go itself could not be started or its output
+// made no sense.
+func Run(ctx context.Context, root, dir string, extraArgs ...string) (Result, error) {
+	return Stream(ctx, root, dir, nil, extraArgs...)
+}
+
+// Stream is Run, but calls onOutput with each line go test prints while
+// the tests are still running, like watching `go test -v` scroll by.
+// onOutput may be nil.
+func Stream(ctx context.Context, root, dir string, onOutput func(line string), extraArgs ...string) (Result, error) {
+	wd, pkg := packageDir(root, dir)
+	args := append([]string{"test", "-json"}, extraArgs...)
+	args = append(args, pkg)
+
+	cmd := exec.CommandContext(ctx, "go", args...)
+	cmd.Dir = wd
+	cmd.Env = append(os.Environ(), "NO_COLOR=1") // plain failure messages (internal/assert)
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return Result{}, err
+	}
+	if err := cmd.Start(); err != nil {
+		return Result{}, err
+	}
+
+	res, parseErr := parse(stdout, onOutput)
+	waitErr := cmd.Wait()
+
+	// `go test` exits 1 when tests fail; that's expected and already in res.
+	var exit *exec.ExitError
+	if waitErr != nil && !errors.As(waitErr, &exit) {
+		return res, waitErr
+	}
+	if parseErr != nil {
+		return res, parseErr
+	}
+	if res.Package == "" {
+		// Nothing on stdout at all: go refused to run (bad path, broken go.mod...).
+		return res, errors.New(strings.TrimSpace(stderr.String()))
+	}
+	return res, nil
+}
+
+// packageDir returns where to run go and which packages to name. An
+// absolute dir (an exercise from a pack) may live in a module of its
+// own, so go runs inside it instead of at root.
+func packageDir(root, dir string) (wd, pkgs string) {
+	if filepath.IsAbs(dir) {
+		return dir, "./..."
+	}
+	return root, "./" + filepath.ToSlash(dir) + "/..."
+}
+
+// event mirrors the JSON that `go test -json` prints.
+type event struct {
+	Action      string
+	Package     string
+	ImportPath  string
+	Test        string
+	Output      string
+	Elapsed     float64
+	FailedBuild string
+}
+
+// Parse reads a `go test -json` stream. When it covers several packages
+// their tests are merged, and Package is the shortest import path: the
+// exercise's own package rather than one of its subpackages.
+func Parse(r io.Reader) (Result, error) {
+	return parse(r, nil)
+}
+
+func parse(r io.Reader, onOutput func(string)) (Result, error) {
+	var res Result
+	index := map[string]int{} // package + test name -> position in res.Tests
+	var pkgOutput []string    // printed outside any test
+
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for sc.Scan() {
+		line := sc.Bytes()
+		if len(line) == 0 || line[0] != '{' {
+			continue // stray non-JSON output, e.g. from a panicking TestMain
+		}
+		var ev event
+		if err := json.Unmarshal(line, &ev); err != nil {
+			return res, err
+		}
+
+		if onOutput != nil && (ev.Action == "output" || ev.Action == "build-output") {
+			onOutput(strings.TrimRight(ev.Output, "\n"))
+		}
+		if ev.Action == "build-output" {
+			res.BuildOutput = append(res.BuildOutput, strings.TrimRight(ev.Output, "\n"))
+			continue
+		}
+		if ev.Package != "" && (res.Package == "" || len(ev.Package) < len(res.Package)) {
+			res.Package = ev.Package
+		}
+
+		if ev.Test == "" {
+			if ev.Action == "output" {
+				pkgOutput = append(pkgOutput, strings.TrimRight(ev.Output, "\n"))
+			}
+			if ev.Action == "fail" && ev.FailedBuild != "" {
+				res.BuildFailed = true
+			}
+			// Packages are tested in parallel, so the slowest one is
+			// how long the whole run took.
+			if ev.Action == "pass" || ev.Action == "fail" || ev.Action == "skip" {
+				res.Elapsed = max(res.Elapsed, seconds(ev.Elapsed))
+			}
+			continue
+		}
+
+		key := ev.Package + " " + ev.Test
+		i, ok := index[key]
+		if !ok {
+			i = len(res.Tests)
+			index[key] = i
+			res.Tests = append(res.Tests, Test{Name: ev.Test})
+		}
+		t := &res.Tests[i]
+		switch ev.Action {
+		case "output":
+			if out := strings.TrimRight(ev.Output, "\n"); !isFrame(out) {
+				t.Output = append(t.Output, out)
+			}
+		case "pass", "fail", "skip":
+			t.Status = Status(ev.Action)
+			t.Elapsed = seconds(ev.Elapsed)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return res, err
+	}
+
+	// A test that never reported back (e.g. the binary crashed) counts as failed.
+	for i := range res.Tests {
+		if res.Tests[i].Status == "" {
+			res.Tests[i].Status = Fail
+		}
+		res.Races = append(res.Races, parseRaces(res.Tests[i].Name, res.Tests[i].Output)...)
+		if abort, at := abortIn(res.Tests[i].Output); abort != "" {
+			markAborted(res.Tests, res.Tests[i].Name, abort)
+			res.Tests[i].Output = stackless(res.Tests[i].Output, at)
+			res.Aborted = abort
+		}
+	}
+	// A goroutine that outlives its test can race after every test has
+	// finished.
+	res.Races = append(res.Races, parseRaces("", pkgOutput)...)
+	return res, nil
+}
+
+// markAborted sets abort on the test called name and on the tests it's
+// a subtest of, which were waiting for it.
+func markAborted(tests []Test, name string, abort Abort) {
+	for i := range tests {
+		if n := tests[i].Name; n == name || strings.HasPrefix(name, n+"/") {
+			tests[i].Aborted = abort
+		}
+	}
+}
+
+// stackless cuts output after the message at line at, and the list of
+// running tests after a timeout, dropping the goroutine dump that
+// follows.
+func stackless(output []string, at int) []string {
+	end := at + 1
+	for end < len(output) && strings.TrimSpace(output[end]) != "" {
+		end++
+	}
+	return output[:end]
+}
+
+// isFrame reports whether line is one of go test's own "=== RUN" or
+// "--- PASS" markers rather than something the test printed.
+func isFrame(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	prefixes := []string{"=== RUN", "=== PAUSE", "=== CONT", "=== NAME", "--- PASS", "--- FAIL", "--- SKIP"}
+	return slices.ContainsFunc(prefixes, func(p string) bool { return strings.HasPrefix(trimmed, p) })
+}
+
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
@@ -0,0 +1,285 @@
+package runner
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func parseFixture(t *testing.T, name string) Result {
+	t.Helper()
+	f, err := os.Open(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	res, err := Parse(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return res
+}
+
+func TestParse(t *testing.T) {
+	res := parseFixture(t, "go-test.json")
+
+	if res.Package != "example.com/fx" {
+		t.Errorf("package: got %q", res.Package)
+	}
+	if res.BuildFailed {
+		t.Error("BuildFailed should be false")
+	}
+
+	pass, fail, skip := res.Counts()
+	if pass != 1 || fail != 2 || skip != 1 {
+		t.Errorf("counts: got %d/%d/%d, want 1/2/1", pass, fail, skip)
+	}
+
+	if got, want := res.Failed(), []string{"TestFail", "TestTable"}; !slices.Equal(got, want) {
+		t.Errorf("Failed(): got %v, want %v", got, want)
+	}
+	if got, want := res.Passed(), []string{"TestPass"}; !slices.Equal(got, want) {
+		t.Errorf("Passed(): got %v, want %v", got, want)
+	}
+	if res.OK() {
+		t.Error("OK() should be false with failing tests")
+	}
+}
+
+func TestParseKeepsTestOutput(t *testing.T) {
+	res := parseFixture(t, "go-test.json")
+
+	for _, test := range res.Tests {
+		if test.Name != "TestFail" {
+			continue
+		}
+		joined := strings.Join(test.Output, "\n")
+		if !strings.Contains(joined, "got 1, want 2") {
+			t.Errorf("output: got %q", joined)
+		}
+		if strings.Contains(joined, "=== RUN") {
+			t.Errorf("framing lines should be dropped: %q", joined)
+		}
+		return
+	}
+	t.Error("TestFail not found")
+}
+
+func TestParseBuildFailure(t *testing.T) {
+	res := parseFixture(t, "build-failed.json")
+
+	if !res.BuildFailed {
+		t.Fatal("BuildFailed should be true")
+	}
+	if !strings.Contains(strings.Join(res.BuildOutput, "\n"), "cannot use") {
+		t.Errorf("build output: got %q", res.BuildOutput)
+	}
+	if res.OK() {
+		t.Error("OK() should be false when the build fails")
+	}
+}
+
+func TestParseStreamsOutput(t *testing.T) {
+	f, err := os.Open(filepath.Join("testdata", "go-test.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	var lines []string
+	if _, err := parse(f, func(line string) { lines = append(lines, line) }); err != nil {
+		t.Fatal(err)
+	}
+	joined := strings.Join(lines, "\n")
+	for _, want := range []string{"=== RUN   TestPass", "got 1, want 2", "--- FAIL: TestTable"} {
+		if !strings.Contains(joined, want) {
+			t.Errorf("streamed output is missing %q:\n%s", want, joined)
+		}
+	}
+}
+
+func TestParseSeveralPackages(t *testing.T) {
+	res := parseFixture(t, "multi-package.json")
+
+	if res.Package != "example.com/fx" {
+		t.Errorf("package: got %q, want the top-level one", res.Package)
+	}
+	// Same test name in two packages: two results, not one.
+	pass, fail, _ := res.Counts()
+	if pass != 1 || fail != 1 {
+		t.Errorf("counts: got %d passed, %d failed, want 1 and 1", pass, fail)
+	}
+	if res.Elapsed.Seconds() != 0.4 {
+		t.Errorf("elapsed: got %v, want the slowest package's 400ms", res.Elapsed)
+	}
+}
+
+func TestPackageDir(t *testing.T) {
+	wd, pkgs := packageDir("/repo", filepath.Join("exercises", "14-capstone"))
+	if wd != "/repo" || pkgs != "./exercises/14-capstone/..." {
+		t.Errorf("relative: got %q, %q", wd, pkgs)
+	}
+	wd, pkgs = packageDir("/repo", "/packs/acme/acme-01")
+	if wd != "/packs/acme/acme-01" || pkgs != "./..." {
+		t.Errorf("absolute: got %q, %q", wd, pkgs)
+	}
+}
+
+func TestParseRaces(t *testing.T) {
+	res := parseFixture(t, "race.json")
+
+	if got, want := res.Failed(), []string{"TestRace"}; !slices.Equal(got, want) {
+		t.Errorf("Failed(): got %v, want %v", got, want)
+	}
+	if len(res.Races) != 1 {
+		t.Fatalf("got %d races, want 1", len(res.Races))
+	}
+	race := res.Races[0]
+	if race.Test != "TestRace" {
+		t.Errorf("Test: got %q", race.Test)
+	}
+
+	if len(race.Accesses) != 2 {
+		t.Fatalf("got %d accesses, want 2: %+v", len(race.Accesses), race.Accesses)
+	}
+	read, write := race.Accesses[0], race.Accesses[1]
+	if read.Op != "Read" || read.Goroutine != 9 || write.Op != "Previous write" || write.Goroutine != 10 {
+		t.Errorf("accesses: got %s by %d and %s by %d", read.Op, read.Goroutine, write.Op, write.Goroutine)
+	}
+	want := Frame{Func: "example.com/fx.(*Counter).Inc", File: "/home/gopher/fx/counter.go", Line: 7}
+	if len(read.Stack) != 2 || read.Stack[0] != want {
+		t.Errorf("read stack: got %+v, want %+v first", read.Stack, want)
+	}
+
+	if len(race.Goroutines) != 2 {
+		t.Fatalf("got %d goroutines, want 2", len(race.Goroutines))
+	}
+	g := race.Goroutines[1]
+	if g.ID != 10 || g.State != "finished" || len(g.Created) != 4 {
+		t.Errorf("goroutine: got %+v", g)
+	}
+	if g.Created[0].Line != 13 || g.Created[0].Std() || !g.Created[2].Std() {
+		t.Errorf("created at: got %+v", g.Created)
+	}
+	if got := g.Created[1].Name(); got != "TestRace" {
+		t.Errorf("Name: got %q", got)
+	}
+
+	// A run without a race report has no races.
+	if r := parseFixture(t, "go-test.json"); len(r.Races) != 0 {
+		t.Errorf("found races in a run without any: %+v", r.Races)
+	}
+}
+
+func TestParseCoverage(t *testing.T) {
+	f, err := os.Open(filepath.Join("testdata", "cover-func.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	c, err := ParseCoverage(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c.Total != 70.6 || len(c.Funcs) != 3 {
+		t.Fatalf("got %+v", c)
+	}
+	want := FuncCoverage{Func: "Max", File: "example.com/fx/sum.go", Line: 13, Percent: 62.5}
+	if c.Funcs[1] != want {
+		t.Errorf("got %+v, want %+v", c.Funcs[1], want)
+	}
+	var names []string
+	for _, fc := range c.Uncovered() {
+		names = append(names, fc.Func+" "+fc.Where())
+	}
+	if want := []string{"Max sum.go:13", "Serve api.go:9"}; !slices.Equal(names, want) {
+		t.Errorf("Uncovered: got %v, want %v", names, want)
+	}
+
+	if _, err := ParseCoverage(strings.NewReader("sum.go:x:\tSum\t1.0%\n")); err == nil {
+		t.Error("no error for a bad line number")
+	}
+}
+
+func TestParseAborted(t *testing.T) {
+	tests := []struct {
+		fixture string
+		want    Abort
+		aborted []string // tests marked, parents included
+		first   string   // first line of the aborted test's output
+	}{
+		{"timeout.json", TimedOut, []string{"TestLoop", "TestLoop/sub"}, "panic: test timed out after 1s"},
+		{"oom.json", OutOfMemory, []string{"TestHog"}, "fatal error: runtime: out of memory"},
+	}
+	for _, tt := range tests {
+		res := parseFixture(t, tt.fixture)
+		if res.Aborted != tt.want {
+			t.Errorf("%s: Aborted %q, want %q", tt.fixture, res.Aborted, tt.want)
+		}
+		var aborted []string
+		for _, test := range res.Tests {
+			if test.Aborted != "" {
+				aborted = append(aborted, test.Name)
+				if test.Status != Fail {
+					t.Errorf("%s: %s has status %q", tt.fixture, test.Name, test.Status)
+				}
+			}
+		}
+		if !slices.Equal(aborted, tt.aborted) {
+			t.Errorf("%s: aborted tests %v, want %v", tt.fixture, aborted, tt.aborted)
+		}
+		last := res.Tests[len(res.Tests)-1]
+		if len(last.Output) == 0 || last.Output[0] != tt.first {
+			t.Errorf("%s: output starts %q", tt.fixture, last.Output)
+		}
+		if slices.ContainsFunc(last.Output, func(l string) bool { return strings.HasPrefix(l, "goroutine ") }) {
+			t.Errorf("%s: the goroutine dump should be dropped:\n%s", tt.fixture, strings.Join(last.Output, "\n"))
+		}
+	}
+
+	if res := parseFixture(t, "go-test.json"); res.Aborted != "" {
+		t.Errorf("a normal run: Aborted %q", res.Aborted)
+	}
+}
+
+func TestLimitsArgs(t *testing.T) {
+	args := Limits{Timeout: 30 * time.Second, Memory: 512 << 20}.Args()
+	if len(args) == 0 || args[0] != "-timeout=30s" {
+		t.Errorf("got %q", args)
+	}
+	if runtime.GOOS != "windows" && (len(args) != 2 || !strings.Contains(args[1], "ulimit -d 524288 ")) {
+		t.Errorf("memory: got %q", args)
+	}
+	if args := (Limits{}).Args(); len(args) != 0 {
+		t.Errorf("no limits: got %q", args)
+	}
+}
+
+func TestLimitsFromEnv(t *testing.T) {
+	t.Setenv("LEARNGO_TIMEOUT", "")
+	t.Setenv("LEARNGO_MEMORY", "")
+	if l, err := LimitsFromEnv(); err != nil || l != DefaultLimits {
+		t.Errorf("defaults: got %+v, %v", l, err)
+	}
+
+	t.Setenv("LEARNGO_TIMEOUT", "45s")
+	t.Setenv("LEARNGO_MEMORY", "256MiB")
+	if l, err := LimitsFromEnv(); err != nil || l != (Limits{45 * time.Second, 256 << 20}) {
+		t.Errorf("got %+v, %v", l, err)
+	}
+	t.Setenv("LEARNGO_TIMEOUT", "0")
+	t.Setenv("LEARNGO_MEMORY", "0")
+	if l, err := LimitsFromEnv(); err != nil || l != (Limits{}) {
+		t.Errorf("turned off: got %+v, %v", l, err)
+	}
+	t.Setenv("LEARNGO_MEMORY", "lots")
+	if _, err := LimitsFromEnv(); err == nil {
+		t.Error("expected an error for LEARNGO_MEMORY=lots")
+	}
+}
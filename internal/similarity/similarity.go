@@ -0,0 +1,201 @@
+// Package similarity flags suspiciously similar solutions, for
+// instructors grading coursework built on these exercises.
+//
+// Each file is parsed with go/parser and flattened into a stream of
+// tokens that describe its shape: node kinds, operators and literal
+// kinds, with every local identifier replaced by "ID". Renaming
+// variables, reformatting or rewriting comments therefore changes
+// nothing. The stream is cut into overlapping k-grams (runs of K
+// tokens) and two submissions are compared by the Jaccard index of their
+// k-gram sets, the same idea MOSS is built on.
+package similarity
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"hash/fnv"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/imgarylai/learn-go/internal/solutions"
+)
+
+// K is the k-gram length. Shorter grams match by accident more often;
+// longer ones miss copies with a few edits.
+const K = 5
+
+// Fingerprint is the set of k-grams of one submission.
+type Fingerprint struct {
+	Name  string
+	grams map[uint64]bool
+}
+
+// Size is the number of distinct k-grams.
+func (f Fingerprint) Size() int { return len(f.grams) }
+
+// Tokens flattens a parsed file into its normalized token stream.
+func Tokens(file *ast.File) []string {
+	imports := map[string]bool{}
+	for _, imp := range file.Imports {
+		path := strings.Trim(imp.Path.Value, `"`)
+		name := path[strings.LastIndex(path, "/")+1:]
+		if imp.Name != nil {
+			name = imp.Name.Name
+		}
+		imports[name] = true
+	}
+
+	var toks []string
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case nil:
+			return false
+		case *ast.File:
+			return true // skip the package clause, walk the declarations
+		case *ast.ImportSpec, *ast.CommentGroup, *ast.Comment:
+			return false
+		case *ast.SelectorExpr:
+			// fmt.Println is part of the shape; p.name is not.
+			if x, ok := n.X.(*ast.Ident); ok && imports[x.Name] {
+				toks = append(toks, x.Name+"."+n.Sel.Name)
+				return false
+			}
+			toks = append(toks, "Selector")
+			return true
+		case *ast.Ident:
+			toks = append(toks, normalize(n.Name))
+		case *ast.BasicLit:
+			toks = append(toks, n.Kind.String())
+		case *ast.BinaryExpr:
+			toks = append(toks, n.Op.String())
+		case *ast.UnaryExpr:
+			toks = append(toks, "unary"+n.Op.String())
+		case *ast.AssignStmt:
+			toks = append(toks, n.Tok.String())
+		case *ast.IncDecStmt:
+			toks = append(toks, n.Tok.String())
+		case *ast.BranchStmt:
+			toks = append(toks, n.Tok.String())
+		default:
+			toks = append(toks, reflect.TypeOf(n).Elem().Name())
+		}
+		return true
+	})
+	return toks
+}
+
+// normalize keeps builtins like len, append, int and nil, which say a
+// lot about the solution, and hides every other name.
+func normalize(name string) string {
+	if name == "_" || types.Universe.Lookup(name) != nil {
+		return name
+	}
+	return "ID"
+}
+
+// New fingerprints a token stream.
+func New(name string, toks []string) Fingerprint {
+	f := Fingerprint{Name: name, grams: map[uint64]bool{}}
+	f.add(toks)
+	return f
+}
+
+func (f Fingerprint) add(toks []string) {
+	for i := 0; i+K <= len(toks); i++ {
+		h := fnv.New64a()
+		for _, t := range toks[i : i+K] {
+			h.Write([]byte(t))
+			h.Write([]byte{0})
+		}
+		f.grams[h.Sum64()] = true
+	}
+}
+
+// LoadDir fingerprints every non-test .go file under dir. Test files are
+// skipped because students share them, and so are generated solutions.
+func LoadDir(dir string) (Fingerprint, error) {
+	f := Fingerprint{Name: dir, grams: map[uint64]bool{}}
+	fset := token.NewFileSet()
+	files := 0
+	err := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || filepath.Ext(path) != ".go" || strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+		if solutions.IsGenerated(path) {
+			return nil // the reference solution, if a student left it in
+		}
+		file, err := parser.ParseFile(fset, path, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return err
+		}
+		f.add(Tokens(file))
+		files++
+		return nil
+	})
+	if err != nil {
+		return f, err
+	}
+	if files == 0 {
+		return f, fmt.Errorf("%s: no Go files", dir)
+	}
+	return f, nil
+}
+
+// Subtract drops the k-grams that also appear in base, typically the
+// starter code everyone was handed, so shared boilerplate doesn't count
+// as copying.
+func (f Fingerprint) Subtract(base Fingerprint) {
+	for g := range base.grams {
+		delete(f.grams, g)
+	}
+}
+
+// Score is the Jaccard index of a and b: shared k-grams over all
+// distinct k-grams, from 0 (nothing in common) to 1 (same shape).
+func Score(a, b Fingerprint) float64 {
+	if len(a.grams) == 0 && len(b.grams) == 0 {
+		return 0
+	}
+	shared := 0
+	for g := range a.grams {
+		if b.grams[g] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(a.grams)+len(b.grams)-shared)
+}
+
+// Pair is the score of two submissions.
+type Pair struct {
+	A, B  string
+	Score float64
+}
+
+// Compare scores every pair of fingerprints, most similar first.
+func Compare(fps []Fingerprint) []Pair {
+	var pairs []Pair
+	for i := range fps {
+		for j := i + 1; j < len(fps); j++ {
+			pairs = append(pairs, Pair{A: fps[i].Name, B: fps[j].Name, Score: Score(fps[i], fps[j])})
+		}
+	}
+	slices.SortStableFunc(pairs, func(x, y Pair) int {
+		switch {
+		case x.Score > y.Score:
+			return -1
+		case x.Score < y.Score:
+			return 1
+		}
+		return 0
+	})
+	return pairs
+}
@@ -0,0 +1,122 @@
+package similarity
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const original = `package sum
+
+import "fmt"
+
+// Sum adds up nums.
+func Sum(nums []int) int {
+	total := 0
+	for _, n := range nums {
+		total += n
+	}
+	fmt.Println("summed", len(nums))
+	return total
+}
+`
+
+// Same code: names changed, comments rewritten, reformatted.
+const renamed = `package sum
+import "fmt"
+func Add(values []int) int {
+	// accumulate
+	acc := 0
+	for _, v := range values { acc += v }
+	fmt.Println("added up", len(values))
+	return acc
+}
+`
+
+const different = `package sum
+
+import "strings"
+
+func Join(words []string) string {
+	var b strings.Builder
+	for i, w := range words {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString(w)
+	}
+	return b.String()
+}
+`
+
+func fingerprint(t *testing.T, name, src string) Fingerprint {
+	t.Helper()
+	file, err := parser.ParseFile(token.NewFileSet(), name+".go", src, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return New(name, Tokens(file))
+}
+
+func TestRenamingDoesNotHide(t *testing.T) {
+	a, b := fingerprint(t, "a", original), fingerprint(t, "b", renamed)
+	if got := Score(a, b); got != 1 {
+		t.Errorf("renamed copy: got %.2f, want 1", got)
+	}
+}
+
+func TestDifferentSolutionsScoreLow(t *testing.T) {
+	a, c := fingerprint(t, "a", original), fingerprint(t, "c", different)
+	if got := Score(a, c); got > 0.3 {
+		t.Errorf("different code: got %.2f, want <= 0.3", got)
+	}
+}
+
+func TestCompareOrder(t *testing.T) {
+	pairs := Compare([]Fingerprint{
+		fingerprint(t, "a", original),
+		fingerprint(t, "b", renamed),
+		fingerprint(t, "c", different),
+	})
+	if len(pairs) != 3 {
+		t.Fatalf("got %d pairs, want 3", len(pairs))
+	}
+	if p := pairs[0]; p.A != "a" || p.B != "b" {
+		t.Errorf("most similar pair: got %s/%s, want a/b", p.A, p.B)
+	}
+}
+
+func TestSubtractBase(t *testing.T) {
+	a, b := fingerprint(t, "a", original), fingerprint(t, "b", renamed)
+	base := fingerprint(t, "base", original)
+	a.Subtract(base)
+	b.Subtract(base)
+	if a.Size() != 0 || Score(a, b) != 0 {
+		t.Errorf("after subtracting the starter code: size %d, score %.2f", a.Size(), Score(a, b))
+	}
+}
+
+func TestLoadDirSkipsTests(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, src string) {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(src), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("sum.go", original)
+	write("sum_test.go", different)
+
+	f, err := LoadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := Score(f, fingerprint(t, "a", original)); got != 1 {
+		t.Errorf("test files should be ignored: got %.2f", got)
+	}
+
+	if _, err := LoadDir(t.TempDir()); err == nil {
+		t.Error("an empty directory should be an error")
+	}
+}
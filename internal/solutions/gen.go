@@ -0,0 +1,79 @@
+//go:build ignore
+
+// gen writes the *_solution.go files next to the stubs of every
+// exercise, from its solution.go.txt files. Run it with
+// `go generate ./internal/solutions` after changing a solution or a stub,
+// from a clean checkout (it reads whatever stubs are on disk).
+package main
+
+import (
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/imgarylai/learn-go/internal/registry"
+	"github.com/imgarylai/learn-go/internal/solutions"
+)
+
+func main() {
+	for _, e := range registry.All() {
+		root := filepath.Join("..", "..", e.Dir())
+		err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() && d.Name() == "testdata" {
+				return filepath.SkipDir
+			}
+			if d.Name() != "solution.go.txt" {
+				return nil
+			}
+			return generate(filepath.Dir(p))
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+}
+
+// generate replaces the solution files of the package in dir.
+func generate(dir string) error {
+	solution, err := os.ReadFile(filepath.Join(dir, "solution.go.txt"))
+	if err != nil {
+		return err
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	stubs := map[string][]byte{}
+	for _, ent := range entries {
+		name := ent.Name()
+		if ent.IsDir() || filepath.Ext(name) != ".go" || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		if solutions.IsGenerated(name) {
+			if err := os.Remove(filepath.Join(dir, name)); err != nil {
+				return err
+			}
+			continue
+		}
+		if stubs[name], err = os.ReadFile(filepath.Join(dir, name)); err != nil {
+			return err
+		}
+	}
+
+	files, err := solutions.Fill(stubs, solution)
+	if err != nil {
+		return fmt.Errorf("%s: %w", dir, err)
+	}
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), data, 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,320 @@
+// Package solutions turns the reference solutions (solution.go.txt) into
+// Go code the compiler sees.
+//
+// Each stub file that has funcs to fill in starts with
+// `//go:build !solutions`, and next to it is a generated twin, foo.go ->
+// foo_solution.go, built only with `-tags solutions`: the same file with
+// the solution's funcs in place of the TODOs. So
+//
+//	go test -tags solutions ./exercises/...
+//
+// runs every test against the reference solution, the way a JS project
+// might point its test suite at a `dist/` build instead of `src/`.
+// Without the tag nothing changes for students.
+//
+// solution.go.txt stays the place to read and edit a solution; run
+// `go generate ./internal/solutions` after changing one.
+//
+// Some funcs have more than one reference implementation, the naive one
+// and the idiomatic one; see VariantsFile.
+package solutions
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"maps"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+//go:generate go run gen.go
+
+// Tag is the build tag that swaps the solutions in.
+const Tag = "solutions"
+
+const (
+	suffix    = "_solution.go"
+	stubLine  = "//go:build !" + Tag
+	solLine   = "//go:build " + Tag
+	genHeader = "// Code generated by `go generate ./internal/solutions` from solution.go.txt. DO NOT EDIT.\n\n"
+)
+
+// FileName is the name of the generated twin of a stub file:
+// "api/api.go" -> "api/api_solution.go".
+func FileName(stub string) string {
+	return strings.TrimSuffix(stub, ".go") + suffix
+}
+
+// IsGenerated reports whether name is a generated solution file rather
+// than something students edit.
+func IsGenerated(name string) bool {
+	return strings.HasSuffix(path.Base(name), suffix)
+}
+
+// Fill writes out the solution for one package. files are its stub
+// files by name and solution is its solution.go.txt. For each stub that
+// declares funcs the solution defines, Fill returns a copy, keyed by
+// FileName, with the solution's funcs in their place. The stub's doc
+// comments stay; helpers only the solution has go after the func they
+// follow there.
+//
+// A stub it fills in must start with `//go:build !solutions`, or both
+// versions would be compiled together.
+func Fill(files map[string][]byte, solution []byte) (map[string][]byte, error) {
+	fset := token.NewFileSet()
+	sol, err := parser.ParseFile(fset, "solution.go", solution, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+	solFile := fset.File(sol.Pos())
+	text := func(from, to token.Pos) string {
+		return string(solution[solFile.Offset(from):solFile.Offset(to)])
+	}
+
+	stubs := map[string]*ast.File{}
+	declared := map[string]*ast.FuncDecl{} // by funcKey
+	for _, name := range slices.Sorted(maps.Keys(files)) {
+		f, err := parser.ParseFile(fset, name, files[name], parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		stubs[name] = f
+		for _, d := range f.Decls {
+			if fn, ok := d.(*ast.FuncDecl); ok && fn.Body != nil {
+				declared[funcKey(fn)] = fn
+			}
+		}
+	}
+
+	// The text that replaces each stub func: the solution's func, minus
+	// its doc comment, plus any helpers that follow it.
+	repl := map[*ast.FuncDecl]string{}
+	var last *ast.FuncDecl
+	var orphans []string // helpers before the first stub func
+	for _, d := range sol.Decls {
+		fn, ok := d.(*ast.FuncDecl)
+		if !ok {
+			continue
+		}
+		if stubFn, ok := declared[funcKey(fn)]; ok {
+			last = stubFn
+			repl[last] = strings.Join(append([]string{text(fn.Pos(), fn.End())}, orphans...), "\n\n")
+			orphans = nil
+			continue
+		}
+		helper := text(declStart(fn), fn.End())
+		if last == nil {
+			orphans = append(orphans, helper)
+		} else {
+			repl[last] += "\n\n" + helper
+		}
+	}
+	if last == nil {
+		return nil, fmt.Errorf("the solution defines none of the stub funcs")
+	}
+	if len(orphans) > 0 {
+		repl[last] += "\n\n" + strings.Join(orphans, "\n\n")
+	}
+
+	imports := sol.Imports
+	out := map[string][]byte{}
+	for name, f := range stubs {
+		src, err := fill(fset, name, files[name], f, repl, imports)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		if src != nil {
+			out[FileName(name)] = src
+		}
+	}
+	return out, nil
+}
+
+// fill applies repl to one stub file. It returns nil if no func of f
+// is replaced.
+func fill(fset *token.FileSet, name string, src []byte, f *ast.File, repl map[*ast.FuncDecl]string, imports []*ast.ImportSpec) ([]byte, error) {
+	tf := fset.File(f.Pos())
+	type edit struct {
+		start, end token.Pos
+		text       string
+	}
+	var edits []edit
+	for _, d := range f.Decls {
+		switch d := d.(type) {
+		case *ast.FuncDecl:
+			if r, ok := repl[d]; ok {
+				edits = append(edits, edit{d.Pos(), d.End(), r})
+			}
+		case *ast.GenDecl:
+			if isKeepImport(d) {
+				edits = append(edits, edit{declStart(d), d.End(), ""})
+			}
+		}
+	}
+	if !slices.ContainsFunc(edits, func(e edit) bool { return e.text != "" }) {
+		return nil, nil
+	}
+
+	constraint := slices.IndexFunc(f.Comments, func(c *ast.CommentGroup) bool {
+		return c.Pos() < f.Package && slices.ContainsFunc(c.List, func(c *ast.Comment) bool { return c.Text == stubLine })
+	})
+	if constraint < 0 {
+		return nil, fmt.Errorf("the solution fills it in, so it needs a %q line", stubLine)
+	}
+	for _, c := range f.Comments[constraint].List {
+		if c.Text == stubLine {
+			edits = append(edits, edit{c.Pos(), c.End(), solLine})
+		}
+	}
+
+	slices.SortFunc(edits, func(a, b edit) int { return int(b.start - a.start) })
+	out := slices.Clone(src)
+	for _, e := range edits {
+		lo, hi := tf.Offset(e.start), tf.Offset(e.end)
+		out = slices.Concat(out[:lo], []byte(e.text), out[hi:])
+	}
+	out = append([]byte(genHeader), out...)
+
+	// The solution may need imports the stub doesn't have, and the stub
+	// may import things only its TODO hints used.
+	fset2 := token.NewFileSet()
+	g, err := parser.ParseFile(fset2, name, out, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+	for _, imp := range imports {
+		p, _ := strconv.Unquote(imp.Path.Value)
+		if imp.Name != nil {
+			astutil.AddNamedImport(fset2, g, imp.Name.Name, p)
+		} else {
+			astutil.AddImport(fset2, g, p)
+		}
+	}
+	deleteUnusedImports(fset2, g)
+	var buf bytes.Buffer
+	if err := format.Node(&buf, fset2, g); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Strip prepares a stub file to be compiled next to the solution: it
+// removes the funcs and methods that solution also declares, the
+// `var _ = pkg.Name` lines stubs use to keep imports alive, and then any
+// import nothing uses any more. `learngo mutate` uses it to test
+// changed copies of solution.go.txt.
+func Strip(filename string, stub, solution []byte) ([]byte, error) {
+	fset := token.NewFileSet()
+	sol, err := parser.ParseFile(fset, "solution.go", solution, parser.SkipObjectResolution)
+	if err != nil {
+		return nil, err
+	}
+	defined := map[string]bool{}
+	for _, d := range sol.Decls {
+		if fn, ok := d.(*ast.FuncDecl); ok {
+			defined[funcKey(fn)] = true
+		}
+	}
+
+	f, err := parser.ParseFile(fset, filename, stub, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+	var removed []ast.Decl
+	f.Decls = slices.DeleteFunc(f.Decls, func(d ast.Decl) bool {
+		var drop bool
+		switch d := d.(type) {
+		case *ast.FuncDecl:
+			drop = defined[funcKey(d)]
+		case *ast.GenDecl:
+			drop = isKeepImport(d)
+		}
+		if drop {
+			removed = append(removed, d)
+		}
+		return drop
+	})
+	// Their comments, doc and TODOs alike, would otherwise be left
+	// floating around the file.
+	f.Comments = slices.DeleteFunc(f.Comments, func(c *ast.CommentGroup) bool {
+		return slices.ContainsFunc(removed, func(d ast.Decl) bool {
+			return declStart(d) <= c.Pos() && c.End() <= d.End()
+		})
+	})
+	deleteUnusedImports(fset, f)
+	var buf bytes.Buffer
+	if err := format.Node(&buf, fset, f); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func deleteUnusedImports(fset *token.FileSet, f *ast.File) {
+	for _, imp := range slices.Clone(f.Imports) {
+		path, _ := strconv.Unquote(imp.Path.Value)
+		name := ""
+		if imp.Name != nil {
+			name = imp.Name.Name
+		}
+		if name != "_" && !astutil.UsesImport(f, path) {
+			astutil.DeleteNamedImport(fset, f, name, path)
+		}
+	}
+}
+
+// declStart is where d begins, including its doc comment.
+func declStart(d ast.Decl) token.Pos {
+	switch d := d.(type) {
+	case *ast.FuncDecl:
+		if d.Doc != nil {
+			return d.Doc.Pos()
+		}
+	case *ast.GenDecl:
+		if d.Doc != nil {
+			return d.Doc.Pos()
+		}
+	}
+	return d.Pos()
+}
+
+// isKeepImport matches `var _ = strings.Map`.
+func isKeepImport(d *ast.GenDecl) bool {
+	if d.Tok != token.VAR || len(d.Specs) != 1 {
+		return false
+	}
+	vs := d.Specs[0].(*ast.ValueSpec)
+	if len(vs.Names) != 1 || vs.Names[0].Name != "_" || len(vs.Values) != 1 {
+		return false
+	}
+	_, ok := vs.Values[0].(*ast.SelectorExpr)
+	return ok
+}
+
+// funcKey is "Name" for a func and "Type.Name" for a method.
+func funcKey(fn *ast.FuncDecl) string {
+	if fn.Recv == nil || len(fn.Recv.List) == 0 {
+		return fn.Name.Name
+	}
+	t := fn.Recv.List[0].Type
+	if s, ok := t.(*ast.StarExpr); ok {
+		t = s.X
+	}
+	switch g := t.(type) { // generic receivers: Stack[T], Pair[K, V]
+	case *ast.IndexExpr:
+		t = g.X
+	case *ast.IndexListExpr:
+		t = g.X
+	}
+	if id, ok := t.(*ast.Ident); ok {
+		return id.Name + "." + fn.Name.Name
+	}
+	return fn.Name.Name
+}
@@ -0,0 +1,167 @@
+package solutions
+
+import (
+	"maps"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/imgarylai/learn-go/internal/registry"
+	"github.com/imgarylai/learn-go/internal/stubs"
+)
+
+const stub = `package sum
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrEmpty is returned for empty input.
+var ErrEmpty = errors.New("empty")
+
+type Stack[T any] struct{ items []T }
+
+// Max returns the largest of nums.
+func Max(nums []int) (int, error) {
+	// TODO: loop over nums
+	return 0, nil
+}
+
+// Push adds v on top.
+func (s *Stack[T]) Push(v T) {
+	// TODO: append
+}
+
+// Len is given.
+func (s *Stack[T]) Len() int { return len(s.items) }
+
+// Keep imports used
+var _ = strings.Map
+`
+
+func TestStrip(t *testing.T) {
+	sol := `package sum
+
+func Max(nums []int) (int, error) { return 0, nil }
+
+func (s *Stack[T]) Push(v T) { s.items = append(s.items, v) }
+`
+	out, err := Strip("stub.go", []byte(stub), []byte(sol))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := string(out)
+	for _, gone := range []string{"func Max", "TODO", "Push", `"strings"`, "strings.Map", "Keep imports used"} {
+		if strings.Contains(got, gone) {
+			t.Errorf("%q should be stripped:\n%s", gone, got)
+		}
+	}
+	for _, kept := range []string{`"errors"`, "var ErrEmpty", "// ErrEmpty is returned", "type Stack[T any]", "func (s *Stack[T]) Len()"} {
+		if !strings.Contains(got, kept) {
+			t.Errorf("%q should be kept:\n%s", kept, got)
+		}
+	}
+}
+
+func TestFill(t *testing.T) {
+	sol := `package sum
+
+import "slices"
+
+// 1. Max
+func Max(nums []int) (int, error) {
+	if len(nums) == 0 {
+		return 0, ErrEmpty
+	}
+	return slices.Max(nums), nil
+}
+
+// grow is a helper only the solution has.
+func grow[T any](s *Stack[T]) {}
+
+func (s *Stack[T]) Push(v T) { grow(s); s.items = append(s.items, v) }
+`
+	files := map[string][]byte{
+		"stub.go":  []byte("//go:build !solutions\n\n" + stub),
+		"other.go": []byte("package sum\n\nfunc Given() {}\n"),
+	}
+	out, err := Fill(files, []byte(sol))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(out) != 1 {
+		t.Fatalf("got files %v, want only stub_solution.go", slices.Collect(maps.Keys(out)))
+	}
+	got := string(out["stub_solution.go"])
+	for _, want := range []string{
+		"DO NOT EDIT", "//go:build solutions\n", `"errors"`, `"slices"`,
+		"// Max returns the largest of nums.\nfunc Max", "return slices.Max(nums), nil",
+		"// grow is a helper only the solution has.", "func (s *Stack[T]) Len()",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("missing %q:\n%s", want, got)
+		}
+	}
+	for _, gone := range []string{"TODO", "!solutions", `"strings"`, "Keep imports used", "// 1. Max"} {
+		if strings.Contains(got, gone) {
+			t.Errorf("%q should be gone:\n%s", gone, got)
+		}
+	}
+}
+
+func TestFillNeedsBuildConstraint(t *testing.T) {
+	sol := "package sum\n\nfunc Max(nums []int) (int, error) { return 0, nil }\n"
+	_, err := Fill(map[string][]byte{"stub.go": []byte(stub)}, []byte(sol))
+	if err == nil || !strings.Contains(err.Error(), "//go:build !solutions") {
+		t.Errorf("got %v, want an error about the missing build constraint", err)
+	}
+}
+
+// The generated files must match what Fill makes from the original
+// stubs. If this fails, run `go generate ./internal/solutions`.
+func TestGeneratedFilesUpToDate(t *testing.T) {
+	for _, e := range registry.All() {
+		files, err := stubs.Files(e.ID)
+		if err != nil {
+			t.Fatalf("%s: %v", e.ID, err)
+		}
+		byPkg := map[string]map[string][]byte{}
+		for _, f := range files {
+			pkg := path.Dir(f.Name)
+			if byPkg[pkg] == nil {
+				byPkg[pkg] = map[string][]byte{}
+			}
+			byPkg[pkg][path.Base(f.Name)] = f.Data
+		}
+
+		for pkg, stubFiles := range byPkg {
+			dir := filepath.Join("..", "..", e.Dir(), filepath.FromSlash(pkg))
+			solution, err := os.ReadFile(filepath.Join(dir, "solution.go.txt"))
+			if os.IsNotExist(err) {
+				continue // given code only, like a main package
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			want, err := Fill(stubFiles, solution)
+			if err != nil {
+				t.Errorf("%s/%s: %v", e.ID, pkg, err)
+				continue
+			}
+			for name, data := range want {
+				got, err := os.ReadFile(filepath.Join(dir, name))
+				if err != nil || string(got) != string(data) {
+					t.Errorf("%s/%s/%s is out of date", e.ID, pkg, name)
+				}
+			}
+			onDisk, _ := filepath.Glob(filepath.Join(dir, "*"+suffix))
+			if len(onDisk) != len(want) {
+				t.Errorf("%s/%s: %d solution files on disk, want %d", e.ID, pkg, len(onDisk), len(want))
+			}
+		}
+	}
+}
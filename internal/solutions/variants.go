@@ -0,0 +1,200 @@
+package solutions
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"slices"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+// VariantsFile sits next to solution.go.txt and holds other ways to
+// write some of its funcs: Caesar with += as well as strings.Map, say.
+// Each one is labeled with a directive in its doc comment:
+//
+//	// Caesar, building the result with +=: every step copies the
+//	// string so far, so the work grows with the square of its length.
+//	//
+//	//learngo:variant concat
+//	func Caesar(s string, shift int) string { ... }
+//
+// The same directive on the func in solution.go.txt names the reference
+// implementation; without one it is called "reference". Declarations
+// after a variant that aren't variants themselves (a heap type, a
+// helper func) go along with it, so their names mustn't clash with the
+// solution's. `learngo variants` benchmarks them against each other.
+const VariantsFile = "variants.go.txt"
+
+const (
+	directive        = "//learngo:variant "
+	defaultReference = "reference"
+)
+
+// Implementation is one labeled way of writing a solution func.
+type Implementation struct {
+	Func      string // "Caesar", or "Type.Method" for a method
+	Label     string
+	Doc       string // the doc comment, without the directive
+	Reference bool   // the one in solution.go.txt
+	Src       []byte // the whole solution.go.txt with this implementation in it
+}
+
+// Implementations lists, for every func variants gives alternatives
+// to, the reference implementation followed by the variants in file
+// order. solution and variants are the contents of solution.go.txt and
+// VariantsFile.
+func Implementations(solution, variants []byte) ([]Implementation, error) {
+	fset := token.NewFileSet()
+	sol, err := parser.ParseFile(fset, "solution.go", solution, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+	vf, err := parser.ParseFile(fset, VariantsFile, variants, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+	solFile, varFile := fset.File(sol.Pos()), fset.File(vf.Pos())
+
+	reference := map[string]*ast.FuncDecl{}
+	for _, d := range sol.Decls {
+		if fn, ok := d.(*ast.FuncDecl); ok {
+			reference[funcKey(fn)] = fn
+		}
+	}
+
+	// Split the variants file into one chunk per variant: its func plus
+	// whatever follows until the next variant.
+	type chunk struct {
+		fn         *ast.FuncDecl
+		label      string
+		start, end token.Pos
+	}
+	var chunks []chunk
+	for _, d := range vf.Decls {
+		if gd, ok := d.(*ast.GenDecl); ok && gd.Tok == token.IMPORT {
+			continue // merged in below
+		}
+		fn, ok := d.(*ast.FuncDecl)
+		label := ""
+		if ok {
+			label = variantLabel(fn.Doc)
+		}
+		if label == "" {
+			if len(chunks) == 0 {
+				return nil, fmt.Errorf("%s: %s comes before the first variant", VariantsFile, declName(d))
+			}
+			chunks[len(chunks)-1].end = d.End()
+			continue
+		}
+		if _, ok := reference[funcKey(fn)]; !ok {
+			return nil, fmt.Errorf("%s: %s (%s) is not a func of the solution", VariantsFile, funcKey(fn), label)
+		}
+		chunks = append(chunks, chunk{fn, label, declStart(fn), fn.End()})
+	}
+	if len(chunks) == 0 {
+		return nil, fmt.Errorf("%s: no funcs labeled %q", VariantsFile, strings.TrimSpace(directive))
+	}
+
+	var out []Implementation
+	seen := map[string]bool{} // "Func/label"
+	for _, c := range chunks {
+		key := funcKey(c.fn)
+		ref := reference[key]
+		if !slices.ContainsFunc(out, func(im Implementation) bool { return im.Func == key }) {
+			label := variantLabel(ref.Doc)
+			if label == "" {
+				label = defaultReference
+			}
+			seen[key+"/"+label] = true
+			out = append(out, Implementation{Func: key, Label: label, Doc: docText(ref.Doc), Reference: true, Src: solution})
+		}
+		if seen[key+"/"+c.label] {
+			return nil, fmt.Errorf("%s: two implementations of %s are labeled %q", VariantsFile, key, c.label)
+		}
+		seen[key+"/"+c.label] = true
+
+		// Splice the chunk in where the reference func (and its doc)
+		// was, then bring in the variants file's imports and drop the
+		// ones nothing uses any more.
+		var src bytes.Buffer
+		src.Write(solution[:solFile.Offset(declStart(ref))])
+		src.Write(variants[varFile.Offset(c.start):varFile.Offset(c.end)])
+		src.Write(solution[solFile.Offset(ref.End()):])
+		merged, err := withImports(src.Bytes(), vf.Imports)
+		if err != nil {
+			return nil, fmt.Errorf("%s (%s): %w", key, c.label, err)
+		}
+		out = append(out, Implementation{Func: key, Label: c.label, Doc: docText(c.fn.Doc), Src: merged})
+	}
+
+	// Group by func, in the order each first appears.
+	order := map[string]int{}
+	for i, im := range out {
+		if _, ok := order[im.Func]; !ok {
+			order[im.Func] = i
+		}
+	}
+	slices.SortStableFunc(out, func(a, b Implementation) int { return order[a.Func] - order[b.Func] })
+	return out, nil
+}
+
+// withImports adds imports to src and removes the unused ones.
+func withImports(src []byte, imports []*ast.ImportSpec) ([]byte, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "solution.go", src, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+	for _, imp := range imports {
+		path, _ := strconv.Unquote(imp.Path.Value)
+		name := ""
+		if imp.Name != nil {
+			name = imp.Name.Name
+		}
+		astutil.AddNamedImport(fset, f, name, path)
+	}
+	deleteUnusedImports(fset, f)
+	var buf bytes.Buffer
+	if err := format.Node(&buf, fset, f); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// variantLabel is the label in doc's `//learngo:variant` line, if any.
+func variantLabel(doc *ast.CommentGroup) string {
+	if doc == nil {
+		return ""
+	}
+	for _, c := range doc.List {
+		if label, ok := strings.CutPrefix(c.Text, directive); ok {
+			return strings.TrimSpace(label)
+		}
+	}
+	return ""
+}
+
+// docText is doc's text; Text already leaves out directives.
+func docText(doc *ast.CommentGroup) string {
+	if doc == nil {
+		return ""
+	}
+	return strings.TrimSpace(doc.Text())
+}
+
+// declName names d in an error message.
+func declName(d ast.Decl) string {
+	switch d := d.(type) {
+	case *ast.FuncDecl:
+		return funcKey(d)
+	case *ast.GenDecl:
+		return d.Tok.String() + " declaration"
+	}
+	return "a declaration"
+}
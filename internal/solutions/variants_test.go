@@ -0,0 +1,149 @@
+package solutions
+
+import (
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const variantsSolution = `package words
+
+import "strings"
+
+// 1. Join, with strings.Join.
+//
+//learngo:variant join
+func Join(words []string) string {
+	return strings.Join(words, " ")
+}
+
+// 2. Count
+func Count(s string) int { return len(strings.Fields(s)) }
+`
+
+const variantsFile = `package words
+
+import "bytes"
+
+// Join with +=, copying as it goes.
+//
+//learngo:variant concat
+func Join(words []string) string {
+	out := ""
+	for i, w := range words {
+		out += sep(i) + w
+	}
+	return out
+}
+
+func sep(i int) string {
+	if i == 0 {
+		return ""
+	}
+	return " "
+}
+
+// Join with a bytes.Buffer.
+//
+//learngo:variant buffer
+func Join(words []string) string {
+	var b bytes.Buffer
+	for i, w := range words {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(w)
+	}
+	return b.String()
+}
+`
+
+func TestImplementations(t *testing.T) {
+	impls, err := Implementations([]byte(variantsSolution), []byte(variantsFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var labels []string
+	for _, im := range impls {
+		labels = append(labels, im.Func+"/"+im.Label)
+	}
+	if got := strings.Join(labels, " "); got != "Join/join Join/concat Join/buffer" {
+		t.Fatalf("got %s", got)
+	}
+	if !impls[0].Reference || impls[1].Reference || impls[0].Doc != "1. Join, with strings.Join." {
+		t.Errorf("reference: %+v", impls[0])
+	}
+
+	concat := string(impls[1].Src)
+	for _, want := range []string{"out += sep(i) + w", "func sep(i int) string", "func Count(s string) int", `"strings"`} {
+		if !strings.Contains(concat, want) {
+			t.Errorf("concat variant is missing %q:\n%s", want, concat)
+		}
+	}
+	if strings.Contains(concat, "strings.Join") || strings.Contains(concat, "bytes.Buffer") {
+		t.Errorf("concat variant has the other implementations:\n%s", concat)
+	}
+
+	buffer := string(impls[2].Src)
+	if !strings.Contains(buffer, `"bytes"`) || strings.Contains(buffer, "func sep") {
+		t.Errorf("buffer variant:\n%s", buffer)
+	}
+}
+
+func TestImplementationsErrors(t *testing.T) {
+	tests := map[string]string{
+		"no variants":    "package words\n\nfunc helper() {}\n",
+		"unknown func":   "package words\n\n//learngo:variant fast\nfunc Split(s string) []string { return nil }\n",
+		"label reused":   "package words\n\n//learngo:variant join\nfunc Join(words []string) string { return \"\" }\n",
+		"label repeated": "package words\n\n//learngo:variant a\nfunc Count(s string) int { return 0 }\n\n//learngo:variant a\nfunc Count(s string) int { return 1 }\n",
+	}
+	for name, variants := range tests {
+		if _, err := Implementations([]byte(variantsSolution), []byte(variants)); err == nil {
+			t.Errorf("%s: no error", name)
+		}
+	}
+}
+
+// The variants files in the repo are only compiled by `learngo
+// variants`, so check here that every implementation is at least valid Go.
+func TestEveryVariantsFile(t *testing.T) {
+	found := 0
+	err := filepath.WalkDir(filepath.Join("..", "..", "exercises"), func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.Name() != VariantsFile {
+			return err
+		}
+		found++
+		variants, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		solution, err := os.ReadFile(filepath.Join(filepath.Dir(p), "solution.go.txt"))
+		if err != nil {
+			return err
+		}
+		impls, err := Implementations(solution, variants)
+		if err != nil {
+			t.Errorf("%s: %v", p, err)
+			return nil
+		}
+		for _, im := range impls {
+			if _, err := parser.ParseFile(token.NewFileSet(), "solution.go", im.Src, 0); err != nil {
+				t.Errorf("%s: %s (%s): %v", p, im.Func, im.Label, err)
+			}
+			if im.Doc == "" {
+				t.Errorf("%s: %s (%s) needs a doc comment saying how it differs", p, im.Func, im.Label)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found == 0 {
+		t.Error("no variants files found")
+	}
+}
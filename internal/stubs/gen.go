@@ -0,0 +1,92 @@
+//go:build ignore
+
+// gen copies each exercise's stub files into files/ so they can be
+// embedded. Run it with `go generate ./internal/stubs` after changing a
+// stub, from a clean checkout (it copies whatever is on disk).
+//
+// When an exercise's Version went up since the last run, the copies of
+// the previous version move to history/<id>/v<N> first.
+package main
+
+import (
+	"encoding/json"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/imgarylai/learn-go/internal/registry"
+	"github.com/imgarylai/learn-go/internal/solutions"
+)
+
+func main() {
+	versions := map[string]int{}
+	if data, err := os.ReadFile(filepath.Join("files", "versions.json")); err == nil {
+		if err := json.Unmarshal(data, &versions); err != nil {
+			log.Fatal(err)
+		}
+	}
+	for _, e := range registry.All() {
+		old, ok := versions[e.ID]
+		if !ok || old >= e.CurrentVersion() {
+			continue
+		}
+		archive := filepath.Join("history", e.ID, "v"+strconv.Itoa(old))
+		if err := os.MkdirAll(filepath.Dir(archive), 0o755); err != nil {
+			log.Fatal(err)
+		}
+		if err := os.Rename(filepath.Join("files", e.ID), archive); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	if err := os.RemoveAll("files"); err != nil {
+		log.Fatal(err)
+	}
+	for _, e := range registry.All() {
+		versions[e.ID] = e.CurrentVersion()
+		src := filepath.Join("..", "..", e.Dir())
+		dst := filepath.Join("files", e.ID)
+		// Walk rather than glob: larger exercises have subpackages.
+		err := filepath.WalkDir(src, func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() && d.Name() == "testdata" {
+				return filepath.SkipDir
+			}
+			if d.IsDir() || filepath.Ext(p) != ".go" || strings.HasSuffix(p, "_test.go") {
+				return nil
+			}
+			if solutions.IsGenerated(p) {
+				return nil // reference solutions, not stubs
+			}
+			rel, err := filepath.Rel(src, p)
+			if err != nil {
+				return err
+			}
+			data, err := os.ReadFile(p)
+			if err != nil {
+				return err
+			}
+			// .txt keeps the go tool from compiling the copies, like solution.go.txt.
+			out := filepath.Join(dst, rel+".txt")
+			if err := os.MkdirAll(filepath.Dir(out), 0o755); err != nil {
+				return err
+			}
+			return os.WriteFile(out, data, 0o644)
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+	data, err := json.MarshalIndent(versions, "", "  ")
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join("files", "versions.json"), append(data, '\n'), 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
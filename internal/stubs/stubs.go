@@ -0,0 +1,96 @@
+// Package stubs holds pristine copies of every exercise's starter code,
+// embedded into the binary, so `learngo reset` can put an exercise back
+// the way it was and `learngo diff` can show what you changed. Think of
+// it as a built-in `git checkout -- file` that works even if you never
+// committed.
+//
+// Stubs change now and then. The copies of earlier versions stay in
+// history/<id>/v<N>, so `learngo migrate` can tell your changes to an
+// old stub from the changes made upstream since.
+package stubs
+
+import (
+	"embed"
+	"encoding/json"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+//go:generate go run gen.go
+
+//go:embed files history
+var files embed.FS
+
+// File is one stub file.
+type File struct {
+	Name string // relative to the exercise, with slashes: "collections.go", "api/api.go"
+	Data []byte
+}
+
+// Files returns the stub files of the exercise with the given ID,
+// including those of its subpackages, sorted by name. It returns
+// fs.ErrNotExist for an unknown ID.
+func Files(id string) ([]File, error) {
+	return walk(path.Join("files", id))
+}
+
+// FilesAt returns the stub files of an exercise as they were in the
+// given version (registry.Exercise.Version), which may be the current
+// one. It returns fs.ErrNotExist for a version that was never stored.
+func FilesAt(id string, version int) ([]File, error) {
+	if version == Version(id) {
+		return Files(id)
+	}
+	return walk(path.Join("history", id, "v"+strconv.Itoa(version)))
+}
+
+// Version returns the version of the current stubs of an exercise, as
+// recorded when they were copied, or 0 for an unknown ID.
+func Version(id string) int {
+	data, err := fs.ReadFile(files, "files/versions.json")
+	if err != nil {
+		return 0
+	}
+	var versions map[string]int
+	if json.Unmarshal(data, &versions) != nil {
+		return 0
+	}
+	return versions[id]
+}
+
+func walk(dir string) ([]File, error) {
+	if _, err := fs.Stat(files, dir); err != nil {
+		return nil, err
+	}
+	var out []File
+	err := fs.WalkDir(files, dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		data, err := fs.ReadFile(files, p)
+		if err != nil {
+			return err
+		}
+		name := strings.TrimSuffix(strings.TrimPrefix(p, dir+"/"), ".txt")
+		out = append(out, File{Name: name, Data: data})
+		return nil
+	})
+	return out, err
+}
+
+// DefaultBackupDir returns where `learngo reset` saves your work before
+// restoring a stub. LEARNGO_BACKUPS overrides the default.
+func DefaultBackupDir() (string, error) {
+	if env := os.Getenv("LEARNGO_BACKUPS"); env != "" {
+		return env, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".learn-go", "backups"), nil
+}
@@ -0,0 +1,110 @@
+package stubs
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/imgarylai/learn-go/internal/registry"
+)
+
+// The copies must stay in step with the exercises. We can't compare the
+// bytes, since the checkout may hold a student's solution, but the files
+// and their top-level declarations have to match. If this fails after
+// editing a stub, run `go generate ./internal/stubs`.
+func TestStubsMatchExercises(t *testing.T) {
+	for _, e := range registry.All() {
+		files, err := Files(e.ID)
+		if err != nil {
+			t.Errorf("%s: %v", e.ID, err)
+			continue
+		}
+		for _, f := range files {
+			onDisk, err := os.ReadFile(filepath.Join("..", "..", e.Dir(), filepath.FromSlash(f.Name)))
+			if err != nil {
+				t.Errorf("%s: %v", e.ID, err)
+				continue
+			}
+			if got, want := decls(t, f.Data), decls(t, onDisk); !slices.Equal(got, want) {
+				t.Errorf("%s/%s: stub declares %v, exercise declares %v", e.ID, f.Name, got, want)
+			}
+		}
+	}
+}
+
+// After bumping an exercise's Version, `go generate ./internal/stubs`
+// moves the old stubs to history/ and records the new version.
+func TestVersionsMatchRegistry(t *testing.T) {
+	for _, e := range registry.All() {
+		if got := Version(e.ID); got != e.CurrentVersion() {
+			t.Errorf("%s: stubs are version %d, registry says %d", e.ID, got, e.CurrentVersion())
+		}
+		current, err := FilesAt(e.ID, e.CurrentVersion())
+		if files, _ := Files(e.ID); err != nil || len(current) != len(files) {
+			t.Errorf("%s: FilesAt the current version: %d files, %v", e.ID, len(current), err)
+		}
+		for v := 1; v < e.CurrentVersion(); v++ {
+			if _, err := FilesAt(e.ID, v); err != nil {
+				t.Errorf("%s: version %d: %v", e.ID, v, err)
+			}
+		}
+	}
+	if _, err := FilesAt("04-collections", 99); err == nil {
+		t.Error("version 99 of 04-collections: expected an error")
+	}
+}
+
+func TestUnknownExercise(t *testing.T) {
+	if _, err := Files("99-nope"); err == nil {
+		t.Error("expected an error")
+	}
+}
+
+// decls lists the top-level names in src, sorted.
+func decls(t *testing.T, src []byte) []string {
+	t.Helper()
+	f, err := parser.ParseFile(token.NewFileSet(), "", src, parser.SkipObjectResolution)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, d := range f.Decls {
+		switch d := d.(type) {
+		case *ast.FuncDecl:
+			name := d.Name.Name
+			if d.Recv != nil {
+				name = recvName(d.Recv.List[0].Type) + "." + name
+			}
+			names = append(names, name)
+		case *ast.GenDecl:
+			for _, s := range d.Specs {
+				switch s := s.(type) {
+				case *ast.TypeSpec:
+					names = append(names, s.Name.Name)
+				case *ast.ValueSpec:
+					for _, n := range s.Names {
+						names = append(names, n.Name)
+					}
+				}
+			}
+		}
+	}
+	slices.Sort(names)
+	return names
+}
+
+func recvName(x ast.Expr) string {
+	switch x := x.(type) {
+	case *ast.StarExpr:
+		return recvName(x.X)
+	case *ast.IndexExpr:
+		return recvName(x.X)
+	case *ast.Ident:
+		return x.Name
+	}
+	return "?"
+}
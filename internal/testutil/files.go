@@ -0,0 +1,75 @@
+package testutil
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/imgarylai/learn-go/internal/textdiff"
+)
+
+var update = flag.Bool("update", false, "rewrite golden files with the current output")
+
+// WriteFile writes content to name inside dir, creating subdirectories
+// as needed, and returns the file's path. It fails the test on error.
+func WriteFile(t testing.TB, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// TempDir returns a fresh directory holding files, keyed by slash-separated
+// path. It's removed when the test ends, like t.TempDir.
+func TempDir(t testing.TB, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		WriteFile(t, dir, name, content)
+	}
+	return dir
+}
+
+// Golden compares got with the golden file at path, usually under
+// testdata/. Run the tests with -update to write got there instead,
+// then review the diff before committing it, the way you would a Jest
+// snapshot after `jest -u`:
+//
+//	go test -run TestFormatSalesReport -update
+//
+// A mismatch is reported as a unified diff from the golden file to got,
+// so a one-character change in a long report is easy to spot. Windows
+// line endings in the golden file (a git checkout with autocrlf) don't
+// count as a difference.
+func Golden(t testing.TB, path string, got []byte) {
+	t.Helper()
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading golden file: %v (run with -update to create it)", err)
+	}
+	want = bytes.ReplaceAll(want, []byte("\r\n"), []byte("\n"))
+	if bytes.Equal(got, want) {
+		return
+	}
+	d := textdiff.Unified(path, "got", string(want), string(got))
+	if d == "" {
+		d = "(the only difference is the newline at the end)\n"
+	}
+	t.Errorf("output doesn't match %s (run with -update to accept it):\n%s", path, d)
+}
@@ -0,0 +1,69 @@
+package testutil
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestTempDir(t *testing.T) {
+	dir := TempDir(t, map[string]string{"a.txt": "one", "sub/b.txt": "two"})
+	for name, want := range map[string]string{"a.txt": "one", "sub/b.txt": "two"} {
+		got, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil || string(got) != want {
+			t.Errorf("%s: got %q, %v; want %q", name, got, err, want)
+		}
+	}
+}
+
+func TestGolden(t *testing.T) {
+	path := WriteFile(t, t.TempDir(), "out.golden", "one\ntwo\nthree\n")
+	Golden(t, path, []byte("one\ntwo\nthree\n"))
+
+	ft := &fakeT{TB: t}
+	Golden(ft, path, []byte("one\n2\nthree\n"))
+	if !ft.failed {
+		t.Fatal("a mismatch should fail the test")
+	}
+	if !strings.Contains(ft.msg, "-two\n+2\n") {
+		t.Errorf("the failure should show a diff, got:\n%s", ft.msg)
+	}
+
+	ft = &fakeT{TB: t}
+	Golden(ft, path, []byte("one\ntwo\nthree"))
+	if !strings.Contains(ft.msg, "newline at the end") {
+		t.Errorf("a missing final newline should be called out, got:\n%s", ft.msg)
+	}
+}
+
+func TestGoldenIgnoresCRLF(t *testing.T) {
+	path := WriteFile(t, t.TempDir(), "out.golden", "one\r\ntwo\r\n")
+	Golden(t, path, []byte("one\ntwo\n"))
+}
+
+func TestGoldenUpdate(t *testing.T) {
+	*update = true
+	defer func() { *update = false }()
+	path := filepath.Join(t.TempDir(), "sub", "new.golden")
+	Golden(t, path, []byte("fresh\n"))
+	if got, err := os.ReadFile(path); err != nil || string(got) != "fresh\n" {
+		t.Errorf("-update wrote %q, %v", got, err)
+	}
+}
+
+// fakeT records failures instead of failing the real test.
+type fakeT struct {
+	testing.TB
+	failed bool
+	msg    string
+}
+
+func (f *fakeT) Helper() {}
+func (f *fakeT) Errorf(format string, args ...any) {
+	f.failed, f.msg = true, fmt.Sprintf(format, args...)
+}
+func (f *fakeT) Fatalf(format string, args ...any) {
+	f.failed, f.msg = true, fmt.Sprintf(format, args...)
+}
@@ -0,0 +1,79 @@
+// Package testutil holds small helpers shared by the exercise tests.
+package testutil
+
+import (
+	"math/rand/v2"
+	"os"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// SeedEnv names the environment variable that pins the random seed.
+const SeedEnv = "LEARNGO_SEED"
+
+// Rand returns a random source for property tests.
+//
+// The seed comes from LEARNGO_SEED when set, otherwise from the clock,
+// so every run explores new inputs. If the test fails, the seed is
+// logged so you can replay exactly the same inputs:
+//
+//	LEARNGO_SEED=1712345678 go test -run TestSumProperties
+//
+// It's the same idea as fast-check's `seed` option in JS. Every call in
+// the same test starts from the same seed, so the one logged replays
+// them all.
+func Rand(t testing.TB) *rand.Rand {
+	t.Helper()
+	seedsMu.Lock()
+	seed, ok := seeds[t]
+	seedsMu.Unlock()
+	if !ok {
+		seed = newSeed(t)
+		seedsMu.Lock()
+		seeds[t] = seed
+		seedsMu.Unlock()
+		t.Cleanup(func() {
+			seedsMu.Lock()
+			delete(seeds, t)
+			seedsMu.Unlock()
+			if t.Failed() {
+				t.Logf("random seed: %d (rerun with %s=%d)", seed, SeedEnv, seed)
+			}
+		})
+	}
+	return rand.New(rand.NewPCG(seed, seed))
+}
+
+var (
+	seedsMu sync.Mutex
+	seeds   = map[testing.TB]uint64{}
+)
+
+func newSeed(t testing.TB) uint64 {
+	t.Helper()
+	env := os.Getenv(SeedEnv)
+	if env == "" {
+		return uint64(time.Now().UnixNano())
+	}
+	seed, err := strconv.ParseUint(env, 10, 64)
+	if err != nil {
+		t.Fatalf("%s=%q is not a valid seed: %v", SeedEnv, env, err)
+	}
+	return seed
+}
+
+// Ints returns n random ints in [lo, hi].
+func Ints(r *rand.Rand, n, lo, hi int) []int {
+	out := make([]int, n)
+	for i := range out {
+		out[i] = lo + r.IntN(hi-lo+1)
+	}
+	return out
+}
+
+// Pick returns a random element of choices.
+func Pick[T any](r *rand.Rand, choices []T) T {
+	return choices[r.IntN(len(choices))]
+}
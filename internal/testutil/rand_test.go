@@ -0,0 +1,32 @@
+package testutil
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestRandIsReproducible(t *testing.T) {
+	t.Setenv(SeedEnv, "42")
+	a := Ints(Rand(t), 20, -5, 5)
+	b := Ints(Rand(t), 20, -5, 5)
+	if !slices.Equal(a, b) {
+		t.Errorf("same seed gave different values:\n%v\n%v", a, b)
+	}
+}
+
+func TestIntsRange(t *testing.T) {
+	for _, n := range Ints(Rand(t), 1000, -3, 3) {
+		if n < -3 || n > 3 {
+			t.Fatalf("%d out of range [-3, 3]", n)
+		}
+	}
+}
+
+func TestRandReusesTheSeedWithinATest(t *testing.T) {
+	t.Setenv(SeedEnv, "")
+	a := Ints(Rand(t), 20, -1000, 1000)
+	b := Ints(Rand(t), 20, -1000, 1000)
+	if !slices.Equal(a, b) {
+		t.Errorf("two calls in one test gave different values:\n%v\n%v", a, b)
+	}
+}
@@ -0,0 +1,26 @@
+package testutil
+
+import (
+	"encoding/hex"
+	"math"
+	"testing"
+)
+
+// Near reports whether a and b differ by at most tolerance. Floats
+// rarely come out exactly equal after arithmetic (0.1+0.2 != 0.3 in Go
+// too), so compare them with this rather than ==.
+func Near(a, b, tolerance float64) bool {
+	return math.Abs(a-b) <= tolerance
+}
+
+// Unhex decodes the hex string s, the form test vectors in specs and
+// RFCs come in, like Buffer.from(s, "hex") in Node. It fails the test
+// if s isn't valid hex.
+func Unhex(t testing.TB, s string) []byte {
+	t.Helper()
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatalf("Unhex(%q): %v", s, err)
+	}
+	return b
+}
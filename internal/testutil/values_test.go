@@ -0,0 +1,26 @@
+package testutil
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestNear(t *testing.T) {
+	if !Near(0.1+0.2, 0.3, 1e-9) {
+		t.Error("0.1+0.2 should be near 0.3")
+	}
+	if Near(1, 1.1, 0.01) {
+		t.Error("1 and 1.1 aren't within 0.01")
+	}
+}
+
+func TestUnhex(t *testing.T) {
+	if got := Unhex(t, "00ff10"); !bytes.Equal(got, []byte{0, 0xff, 0x10}) {
+		t.Errorf("Unhex(00ff10) = %x", got)
+	}
+	var ft fakeT
+	Unhex(&ft, "zz")
+	if !ft.failed {
+		t.Error("Unhex of invalid hex didn't fail the test")
+	}
+}
@@ -0,0 +1,155 @@
+// Package textdiff produces line-based unified diffs, the format `git
+// diff` and `diff -u` print.
+//
+// It uses the textbook longest-common-subsequence table, which is
+// quadratic but plenty fast for exercise files of a few hundred lines.
+package textdiff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Context is how many unchanged lines surround each change.
+const Context = 3
+
+type op struct {
+	kind byte // ' ', '-' or '+'
+	line string
+}
+
+// Unified returns the diff from a to b with the given file names in the
+// header, or "" when they're equal.
+func Unified(nameA, nameB, a, b string) string {
+	ops := diff(splitLines(a), splitLines(b))
+	hunks := group(ops)
+	if len(hunks) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "--- %s\n+++ %s\n", nameA, nameB)
+	for _, h := range hunks {
+		fmt.Fprintf(&sb, "@@ -%s +%s @@\n", span(h.startA, h.lenA), span(h.startB, h.lenB))
+		for _, o := range h.ops {
+			sb.WriteByte(o.kind)
+			sb.WriteString(o.line)
+			sb.WriteByte('\n')
+		}
+	}
+	return sb.String()
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
+
+// diff walks the LCS table of a and b and returns the edit script.
+func diff(a, b []string) []op {
+	// lcs[i][j] is the LCS length of a[i:] and b[j:].
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var ops []op
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			ops = append(ops, op{' ', a[i]})
+			i++
+			j++
+		case j < len(b) && (i == len(a) || lcs[i][j+1] > lcs[i+1][j]):
+			ops = append(ops, op{'+', b[j]})
+			j++
+		default:
+			ops = append(ops, op{'-', a[i]})
+			i++
+		}
+	}
+	return ops
+}
+
+type hunk struct {
+	startA, lenA int // 1-based start line in a
+	startB, lenB int
+	ops          []op
+}
+
+// span formats a hunk range the way diff -u does: an empty range is
+// written as the line before it.
+func span(start, n int) string {
+	switch n {
+	case 0:
+		return fmt.Sprintf("%d,0", start-1)
+	case 1:
+		return fmt.Sprint(start)
+	}
+	return fmt.Sprintf("%d,%d", start, n)
+}
+
+// group cuts the edit script into hunks, keeping Context unchanged
+// lines around each change and merging changes that are close together.
+func group(ops []op) []hunk {
+	var hunks []hunk
+	lineA, lineB := 1, 1 // line numbers of ops[i]
+	for i := 0; i < len(ops); {
+		if ops[i].kind == ' ' {
+			i, lineA, lineB = i+1, lineA+1, lineB+1
+			continue
+		}
+
+		// Back up to include the leading context. The previous hunk ended
+		// more than 2*Context unchanged lines ago, so they can't overlap.
+		start := max(i-Context, 0)
+		back := i - start
+		h := hunk{startA: lineA - back, startB: lineB - back}
+
+		// Extend until Context*2 unchanged lines in a row (or the end).
+		end, same := i, 0
+		for end < len(ops) && same <= 2*Context {
+			if ops[end].kind == ' ' {
+				same++
+			} else {
+				same = 0
+			}
+			end++
+		}
+		end -= max(same-Context, 0)
+
+		h.ops = ops[start:end]
+		for _, o := range h.ops {
+			if o.kind != '+' {
+				h.lenA++
+			}
+			if o.kind != '-' {
+				h.lenB++
+			}
+		}
+		hunks = append(hunks, h)
+
+		for _, o := range ops[i:end] {
+			if o.kind != '+' {
+				lineA++
+			}
+			if o.kind != '-' {
+				lineB++
+			}
+		}
+		i = end
+	}
+	return hunks
+}
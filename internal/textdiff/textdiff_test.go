@@ -0,0 +1,99 @@
+package textdiff_test
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/imgarylai/learn-go/internal/testutil"
+	"github.com/imgarylai/learn-go/internal/textdiff"
+)
+
+func TestEqual(t *testing.T) {
+	if got := textdiff.Unified("a", "b", "x\ny\n", "x\ny\n"); got != "" {
+		t.Errorf("got %q, want no diff", got)
+	}
+}
+
+func TestUnified(t *testing.T) {
+	a := "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n13\n14\n15\n"
+	b := "1\n2\nthree\n4\n5\n6\n7\n8\n9\n10\n11\n12\n13\n15\n16\n"
+	want := `--- a
++++ b
+@@ -1,6 +1,6 @@
+ 1
+ 2
+-3
++three
+ 4
+ 5
+ 6
+@@ -11,5 +11,5 @@
+ 11
+ 12
+ 13
+-14
+ 15
++16
+`
+	if got := textdiff.Unified("a", "b", a, b); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestEmptySides(t *testing.T) {
+	want := "--- a\n+++ b\n@@ -0,0 +1,2 @@\n+x\n+y\n"
+	if got := textdiff.Unified("a", "b", "", "x\ny\n"); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+// TestMatchesPatch checks random edits by applying our diff with patch(1),
+// when it's installed, and comparing the result.
+func TestMatchesPatch(t *testing.T) {
+	patch, err := exec.LookPath("patch")
+	if err != nil {
+		t.Skip("patch not installed")
+	}
+	r := testutil.Rand(t)
+	words := []string{"a", "b", "c", "d"}
+	for range 50 {
+		var a, b []string
+		for range r.IntN(30) {
+			a = append(a, testutil.Pick(r, words))
+		}
+		for _, line := range a {
+			switch r.IntN(6) {
+			case 0: // drop
+			case 1:
+				b = append(b, testutil.Pick(r, words), line)
+			default:
+				b = append(b, line)
+			}
+		}
+		join := func(lines []string) string {
+			if len(lines) == 0 {
+				return ""
+			}
+			return strings.Join(lines, "\n") + "\n"
+		}
+		d := textdiff.Unified("f", "f", join(a), join(b))
+
+		dir := t.TempDir()
+		f := filepath.Join(dir, "f")
+		if err := os.WriteFile(f, []byte(join(a)), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		cmd := exec.Command(patch, "-s", f)
+		cmd.Stdin = strings.NewReader(d)
+		if out, err := cmd.CombinedOutput(); d != "" && err != nil {
+			t.Fatalf("patch failed: %v\n%s\ndiff:\n%s", err, out, d)
+		}
+		got, _ := os.ReadFile(f)
+		if string(got) != join(b) {
+			t.Fatalf("patched file differs\na: %q\nb: %q\ndiff:\n%s", a, b, d)
+		}
+	}
+}
@@ -0,0 +1,435 @@
+// Package tui is the interactive terminal UI behind `learngo tui`.
+//
+// It's built on bubbletea, which follows the Elm architecture: a Model
+// holds all state, Update turns messages (key presses, test output)
+// into a new Model, and View renders the Model as a string. If you've
+// used Redux, Update is the reducer and messages are actions.
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/progress"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	learnprogress "github.com/imgarylai/learn-go/internal/progress"
+	"github.com/imgarylai/learn-go/internal/registry"
+	"github.com/imgarylai/learn-go/internal/runner"
+)
+
+// RunFunc runs the tests in dir, calling onOutput for every line printed.
+// args go to `go test`, e.g. "-run", "^TestSum$".
+type RunFunc func(ctx context.Context, dir string, onOutput func(line string), args ...string) (runner.Result, error)
+
+// ListFunc lists the top-level tests of an exercise, one per function
+// to write.
+type ListFunc func(e registry.Exercise) ([]string, error)
+
+// Messages sent from the test goroutine back into Update.
+type (
+	outputMsg struct{ line string }
+	doneMsg   struct {
+		id   string
+		test string // "" for the whole exercise
+		res  runner.Result
+		err  error
+	}
+)
+
+var (
+	titleStyle    = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#00ADD8"))
+	paneStyle     = lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).BorderForeground(lipgloss.Color("240")).Padding(0, 1)
+	selectedStyle = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#00ADD8"))
+	passStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("42"))
+	failStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("203"))
+	dimStyle      = lipgloss.NewStyle().Foreground(lipgloss.Color("243"))
+)
+
+// listWidth is the width of the exercise list, borders included.
+const listWidth = 34
+
+// Model is the whole UI state.
+type Model struct {
+	exercises []registry.Exercise
+	status    map[string]learnprogress.Status
+	results   map[string]runner.Result
+	run       RunFunc
+	list      ListFunc
+
+	// Drilling down into an exercise lists its tests, so you can run
+	// one function's test at a time. Those runs are kept apart from the
+	// exercise's results until it's run as a whole again.
+	names      map[string][]string
+	single     map[string]map[string]runner.Result // exercise ID -> test -> result
+	drilled    bool
+	testCursor int
+
+	cursor      int
+	running     string       // ID being tested, "" when idle
+	runningTest string       // the one test being run, if not all of them
+	events      chan tea.Msg // output from the running test, closed when done
+	lines       []string
+	err         error
+
+	output        viewport.Model
+	bar           progress.Model
+	width, height int
+}
+
+// New returns a Model listing exercises. status holds the saved progress
+// of each exercise, run executes tests and list finds the tests to show
+// when you drill down into an exercise.
+func New(exercises []registry.Exercise, status map[string]learnprogress.Status, run RunFunc, list ListFunc) Model {
+	return Model{
+		exercises: exercises,
+		status:    status,
+		results:   map[string]runner.Result{},
+		run:       run,
+		list:      list,
+		names:     map[string][]string{},
+		single:    map[string]map[string]runner.Result{},
+		output:    viewport.New(40, 10),
+		bar:       progress.New(progress.WithDefaultGradient()),
+	}
+}
+
+// Init implements tea.Model. Nothing happens until a key is pressed.
+func (m Model) Init() tea.Cmd { return nil }
+
+// Update implements tea.Model.
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		m.resize()
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "ctrl+c":
+			return m, tea.Quit
+		case "up", "k":
+			if m.drilled && m.testCursor > 0 {
+				m.testCursor--
+			} else if !m.drilled && m.cursor > 0 {
+				m.cursor--
+			}
+			m.showResult()
+		case "down", "j":
+			if m.drilled && m.testCursor < len(m.names[m.selected().ID])-1 {
+				m.testCursor++
+			} else if !m.drilled && m.cursor < len(m.exercises)-1 {
+				m.cursor++
+			}
+			m.showResult()
+		case "right", "l", "tab":
+			m.drillDown()
+			m.showResult()
+		case "left", "h", "esc":
+			m.drilled = false
+			m.showResult()
+		case "enter", "r":
+			return m.startRun()
+		case "pgup", "pgdown":
+			var cmd tea.Cmd
+			m.output, cmd = m.output.Update(msg)
+			return m, cmd
+		}
+		return m, nil
+
+	case outputMsg:
+		m.appendLine(msg.line)
+		return m, wait(m.events)
+
+	case doneMsg:
+		m.running, m.runningTest = "", ""
+		m.err = msg.err
+		switch {
+		case msg.err != nil:
+		case msg.test == "":
+			m.results[msg.id] = msg.res
+			delete(m.single, msg.id)
+		default:
+			if m.single[msg.id] == nil {
+				m.single[msg.id] = map[string]runner.Result{}
+			}
+			m.single[msg.id][msg.test] = msg.res
+		}
+		return m, nil
+	}
+	return m, nil
+}
+
+func (m Model) selected() registry.Exercise { return m.exercises[m.cursor] }
+
+// drillDown switches the list to the tests of the selected exercise,
+// listing them the first time.
+func (m *Model) drillDown() {
+	if m.drilled || len(m.exercises) == 0 || m.list == nil {
+		return
+	}
+	e := m.selected()
+	if _, ok := m.names[e.ID]; !ok {
+		names, err := m.list(e)
+		if err != nil {
+			m.err = err
+			return
+		}
+		m.names[e.ID] = names
+	}
+	m.drilled = true
+	m.testCursor = 0
+}
+
+// startRun kicks off the tests for the selected exercise, or just the
+// selected test when drilled down, in a goroutine. Output flows back
+// through m.events one line at a time.
+func (m Model) startRun() (tea.Model, tea.Cmd) {
+	if m.running != "" || len(m.exercises) == 0 {
+		return m, nil // one run at a time
+	}
+	e := m.selected()
+	var test string
+	var args []string
+	if m.drilled {
+		names := m.names[e.ID]
+		if len(names) == 0 {
+			return m, nil
+		}
+		test = names[m.testCursor]
+		args = []string{"-run", "^" + test + "$"}
+	}
+	m.running, m.runningTest = e.ID, test
+	m.err = nil
+	m.lines = nil
+	m.output.SetContent("")
+
+	events := make(chan tea.Msg, 64)
+	m.events = events
+	go func() {
+		defer close(events)
+		res, err := m.run(context.Background(), e.Dir(), func(line string) {
+			events <- outputMsg{line}
+		}, args...)
+		events <- doneMsg{id: e.ID, test: test, res: res, err: err}
+	}()
+	return m, wait(events)
+}
+
+// wait is a tea.Cmd that delivers the next message from ch.
+func wait(ch <-chan tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		msg, ok := <-ch
+		if !ok {
+			return nil
+		}
+		return msg
+	}
+}
+
+func (m *Model) appendLine(line string) {
+	m.lines = append(m.lines, line)
+	m.output.SetContent(strings.Join(m.lines, "\n"))
+	m.output.GotoBottom()
+}
+
+// showResult swaps the output pane to the failures of the newly selected
+// exercise, or the output of the selected test, unless a run is
+// streaming into it.
+func (m *Model) showResult() {
+	if m.running != "" || len(m.exercises) == 0 {
+		return
+	}
+	m.lines = nil
+	id := m.selected().ID
+	switch res, ok := m.results[id]; {
+	case m.drilled && len(m.names[id]) > 0:
+		name := m.names[id][m.testCursor]
+		if t, out, ok := m.testResult(id, name); ok {
+			m.lines = append([]string{strings.ToUpper(string(t.Status)) + " " + name}, out...)
+		} else {
+			m.lines = []string{name + " not run yet"}
+		}
+	case ok:
+		m.lines = summarize(res)
+	}
+	m.output.SetContent(strings.Join(m.lines, "\n"))
+}
+
+// testResult finds the latest result of one test: from running it on its
+// own, or else from the last run of the whole exercise. out is what it
+// and its subtests printed.
+func (m Model) testResult(id, name string) (t runner.Test, out []string, ok bool) {
+	res, found := m.single[id][name]
+	if !found {
+		if res, found = m.results[id]; !found {
+			return t, nil, false
+		}
+	}
+	if res.BuildFailed {
+		return runner.Test{Name: name, Status: runner.Fail}, append([]string{"build failed:"}, res.BuildOutput...), true
+	}
+	for _, rt := range res.Tests {
+		switch {
+		case rt.Name == name:
+			t, ok = rt, true
+			out = append(out, rt.Output...)
+		case strings.HasPrefix(rt.Name, name+"/"):
+			out = append(out, rt.Output...)
+		}
+	}
+	return t, out, ok
+}
+
+// summarize lists the failing tests of res with their output.
+func summarize(res runner.Result) []string {
+	if res.BuildFailed {
+		return append([]string{"build failed:"}, res.BuildOutput...)
+	}
+	var lines []string
+	for _, t := range res.Tests {
+		if t.Status != runner.Fail || strings.Contains(t.Name, "/") {
+			continue
+		}
+		lines = append(lines, "FAIL "+t.Name)
+		lines = append(lines, t.Output...)
+	}
+	if len(lines) == 0 {
+		lines = []string{"all tests pass"}
+	}
+	return lines
+}
+
+func (m *Model) resize() {
+	// Header (2 lines), pane borders (2), footer (3).
+	h := max(m.height-7, 3)
+	m.output.Width = max(m.width-listWidth-4, 10)
+	m.output.Height = h
+	m.bar.Width = max(m.width-30, 10)
+}
+
+// View implements tea.Model.
+func (m Model) View() string {
+	var b strings.Builder
+
+	done := 0
+	for _, e := range m.exercises {
+		if m.isDone(e.ID) {
+			done++
+		}
+	}
+	fmt.Fprintf(&b, "%s  %s %d/%d done\n\n",
+		titleStyle.Render("learngo"), m.bar.ViewAs(ratio(done, len(m.exercises))), done, len(m.exercises))
+
+	list := paneStyle.Width(listWidth - 2).Height(m.output.Height).Render(m.listView())
+	out := paneStyle.Height(m.output.Height).Render(m.output.View())
+	b.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, list, out))
+	b.WriteString("\n")
+
+	if len(m.exercises) > 0 {
+		e := m.selected()
+		switch res, ok := m.results[e.ID]; {
+		case m.running == e.ID && m.runningTest != "":
+			fmt.Fprintf(&b, "%s running %s...\n", e.ID, m.runningTest)
+		case m.running == e.ID:
+			fmt.Fprintf(&b, "%s running tests...\n", e.ID)
+		case ok && res.BuildFailed:
+			fmt.Fprintf(&b, "%s %s\n", e.ID, failStyle.Render("build failed"))
+		case ok:
+			pass, fail, _ := res.Counts()
+			fmt.Fprintf(&b, "%s %s %d/%d tests passing\n", e.ID, m.bar.ViewAs(ratio(pass, pass+fail)), pass, pass+fail)
+		default:
+			fmt.Fprintf(&b, "%s not run yet\n", e.ID)
+		}
+	}
+	if m.err != nil {
+		b.WriteString(failStyle.Render("error: "+m.err.Error()) + "\n")
+	}
+	if m.drilled {
+		b.WriteString(dimStyle.Render("↑/↓ select • enter run this test • ← back • pgup/pgdn scroll • q quit"))
+	} else {
+		b.WriteString(dimStyle.Render("↑/↓ select • enter run tests • → tests • pgup/pgdn scroll • q quit"))
+	}
+	return b.String()
+}
+
+func (m Model) listView() string {
+	if m.drilled {
+		return m.testListView()
+	}
+	var lines []string
+	for i, e := range m.exercises {
+		mark := "  "
+		switch res, ok := m.results[e.ID]; {
+		case m.running == e.ID:
+			mark = "… "
+		case ok && res.OK():
+			mark = passStyle.Render("✓ ")
+		case ok:
+			mark = failStyle.Render("✗ ")
+		case m.status[e.ID] == learnprogress.Done:
+			mark = passStyle.Render("✓ ")
+		}
+
+		line := mark + e.ID
+		if res, ok := m.results[e.ID]; ok && !res.BuildFailed {
+			pass, fail, _ := res.Counts()
+			line += dimStyle.Render(fmt.Sprintf(" %d/%d", pass, pass+fail))
+		}
+		if i == m.cursor {
+			line = selectedStyle.Render("> ") + line
+		} else {
+			line = "  " + line
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// testListView lists the tests of the selected exercise.
+func (m Model) testListView() string {
+	e := m.selected()
+	lines := []string{titleStyle.Render(e.ID)}
+	for i, name := range m.names[e.ID] {
+		mark := "  "
+		t, _, ok := m.testResult(e.ID, name)
+		switch {
+		case m.running == e.ID && (m.runningTest == "" || m.runningTest == name):
+			mark = "… "
+		case ok && t.Status == runner.Pass:
+			mark = passStyle.Render("✓ ")
+		case ok && t.Status == runner.Fail:
+			mark = failStyle.Render("✗ ")
+		}
+		line := mark + name
+		if i == m.testCursor {
+			line = selectedStyle.Render("> ") + line
+		} else {
+			line = "  " + line
+		}
+		lines = append(lines, line)
+	}
+	if len(m.names[e.ID]) == 0 {
+		lines = append(lines, dimStyle.Render("no tests found"))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// isDone counts an exercise as done if it's marked done or passed this session.
+func (m Model) isDone(id string) bool {
+	if res, ok := m.results[id]; ok {
+		return res.OK()
+	}
+	return m.status[id] == learnprogress.Done
+}
+
+func ratio(n, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(n) / float64(total)
+}
@@ -0,0 +1,145 @@
+package tui
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	learnprogress "github.com/imgarylai/learn-go/internal/progress"
+	"github.com/imgarylai/learn-go/internal/registry"
+	"github.com/imgarylai/learn-go/internal/runner"
+)
+
+func key(s string) tea.KeyMsg {
+	switch s {
+	case "enter":
+		return tea.KeyMsg{Type: tea.KeyEnter}
+	case "down":
+		return tea.KeyMsg{Type: tea.KeyDown}
+	case "right":
+		return tea.KeyMsg{Type: tea.KeyRight}
+	case "left":
+		return tea.KeyMsg{Type: tea.KeyLeft}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+}
+
+// drain runs cmd and feeds the resulting messages back into m until the
+// chain of commands ends, like the bubbletea event loop would.
+func drain(t *testing.T, m tea.Model, cmd tea.Cmd) tea.Model {
+	t.Helper()
+	for cmd != nil {
+		msg := cmd()
+		if msg == nil {
+			break
+		}
+		m, cmd = m.Update(msg)
+	}
+	return m
+}
+
+func fakeRun(ctx context.Context, dir string, onOutput func(string), args ...string) (runner.Result, error) {
+	onOutput("=== RUN   TestSum")
+	onOutput("    got 0, want 15")
+	onOutput("--- FAIL: TestSum")
+	return runner.Result{Tests: []runner.Test{
+		{Name: "TestSum", Status: runner.Fail, Output: []string{"    got 0, want 15"}},
+		{Name: "TestMax", Status: runner.Pass},
+	}}, nil
+}
+
+func fakeList(registry.Exercise) ([]string, error) {
+	return []string{"TestSum", "TestMax"}, nil
+}
+
+func TestRunSelectedExercise(t *testing.T) {
+	exs := registry.All()[:2]
+	var m tea.Model = New(exs, nil, fakeRun, fakeList)
+	m, _ = m.Update(tea.WindowSizeMsg{Width: 100, Height: 30})
+
+	m, _ = m.Update(key("down"))
+	m, cmd := m.Update(key("enter"))
+	if got := m.(Model).running; got != exs[1].ID {
+		t.Fatalf("running: got %q, want %q", got, exs[1].ID)
+	}
+
+	m = drain(t, m, cmd)
+	model := m.(Model)
+	if model.running != "" {
+		t.Error("run should have finished")
+	}
+	if _, ok := model.results[exs[1].ID]; !ok {
+		t.Fatal("result not stored")
+	}
+
+	view := model.View()
+	for _, want := range []string{"got 0, want 15", "1/2 tests passing", "✗"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view is missing %q:\n%s", want, view)
+		}
+	}
+}
+
+func TestViewShowsSavedProgress(t *testing.T) {
+	exs := registry.All()[:3]
+	status := map[string]learnprogress.Status{exs[0].ID: learnprogress.Done}
+	m := New(exs, status, fakeRun, fakeList)
+
+	if view := m.View(); !strings.Contains(view, "1/3 done") {
+		t.Errorf("view:\n%s", view)
+	}
+}
+
+func TestQuit(t *testing.T) {
+	_, cmd := New(registry.All(), nil, fakeRun, fakeList).Update(key("q"))
+	if cmd == nil {
+		t.Fatal("q should return a command")
+	}
+	if _, ok := cmd().(tea.QuitMsg); !ok {
+		t.Error("q should quit")
+	}
+}
+
+func TestDrillDownRunsOneTest(t *testing.T) {
+	exs := registry.All()[:2]
+	var gotArgs []string
+	run := func(ctx context.Context, dir string, onOutput func(string), args ...string) (runner.Result, error) {
+		gotArgs = args
+		onOutput("    got 1, want 9")
+		return runner.Result{Tests: []runner.Test{{Name: "TestMax", Status: runner.Fail, Output: []string{"    got 1, want 9"}}}}, nil
+	}
+	var m tea.Model = New(exs, nil, run, fakeList)
+	m, _ = m.Update(tea.WindowSizeMsg{Width: 100, Height: 30})
+
+	m, _ = m.Update(key("right"))
+	if view := m.View(); !strings.Contains(view, "TestSum") || !strings.Contains(view, "TestMax") {
+		t.Fatalf("tests not listed:\n%s", view)
+	}
+	m, _ = m.Update(key("down"))
+	m, cmd := m.Update(key("enter"))
+	if got := m.(Model).runningTest; got != "TestMax" {
+		t.Fatalf("runningTest: got %q, want TestMax", got)
+	}
+	m = drain(t, m, cmd)
+
+	if strings.Join(gotArgs, " ") != "-run ^TestMax$" {
+		t.Errorf("args: got %q", gotArgs)
+	}
+	model := m.(Model)
+	if _, ok := model.results[exs[0].ID]; ok {
+		t.Error("a single test run shouldn't count as a run of the exercise")
+	}
+	view := model.View()
+	for _, want := range []string{"✗ TestMax", "got 1, want 9"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view is missing %q:\n%s", want, view)
+		}
+	}
+
+	m, _ = m.Update(key("left"))
+	if view := m.View(); strings.Contains(view, "TestMax") {
+		t.Errorf("left should go back to the exercises:\n%s", view)
+	}
+}